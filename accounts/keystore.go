@@ -0,0 +1,212 @@
+package accounts
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// StandardScryptN and StandardScryptP are the scrypt parameters of the keys
+	// stored by default, costing about 256MB of memory and 1s of CPU to decrypt a key
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+
+	// LightScryptN and LightScryptP are the scrypt parameters suited to the test setups
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+
+	scryptR     = 8
+	scryptDKLen = 32
+
+	keystoreVersion = 3
+)
+
+var (
+	ErrDecrypt = errors.New("could not decrypt key with given passphrase")
+)
+
+// encryptedKey is the Web3 Secret Storage (version 3) encoding of a key
+type encryptedKey struct {
+	Address string     `json:"address"`
+	Crypto  cryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+type cryptoJSON struct {
+	Cipher       string           `json:"cipher"`
+	CipherText   string           `json:"ciphertext"`
+	CipherParams cipherParamsJSON `json:"cipherparams"`
+	KDF          string           `json:"kdf"`
+	KDFParams    scryptParamsJSON `json:"kdfparams"`
+	MAC          string           `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+type scryptParamsJSON struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// encryptKey encodes the key in the Web3 Secret Storage format, encrypted with the passphrase
+func encryptKey(key *ecdsa.PrivateKey, passphrase string, scryptN, scryptP int) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes, err := crypto.MarshalECDSAPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	cipherText, err := aesCTRXOR(derivedKey[:16], keyBytes, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&encryptedKey{
+		Address: hex.EncodeToString(crypto.PubKeyToAddress(&key.PublicKey).Bytes()),
+		Crypto: cryptoJSON{
+			Cipher:     "aes-128-ctr",
+			CipherText: hex.EncodeToString(cipherText),
+			CipherParams: cipherParamsJSON{
+				IV: hex.EncodeToString(iv),
+			},
+			KDF: "scrypt",
+			KDFParams: scryptParamsJSON{
+				N:     scryptN,
+				R:     scryptR,
+				P:     scryptP,
+				DKLen: scryptDKLen,
+				Salt:  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText)),
+		},
+		ID:      uuid.NewString(),
+		Version: keystoreVersion,
+	})
+}
+
+// decryptKey decrypts the key encoded in the Web3 Secret Storage format with the passphrase
+func decryptKey(data []byte, passphrase string) (*ecdsa.PrivateKey, error) {
+	encrypted := new(encryptedKey)
+	if err := json.Unmarshal(data, encrypted); err != nil {
+		return nil, err
+	}
+
+	if encrypted.Version != keystoreVersion {
+		return nil, fmt.Errorf("unsupported keystore version %d", encrypted.Version)
+	}
+
+	if encrypted.Crypto.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("unsupported cipher %s", encrypted.Crypto.Cipher)
+	}
+
+	if encrypted.Crypto.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported key derivation function %s", encrypted.Crypto.KDF)
+	}
+
+	params := encrypted.Crypto.KDFParams
+
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	iv, err := hex.DecodeString(encrypted.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := hex.DecodeString(encrypted.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := hex.DecodeString(encrypted.Crypto.MAC)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(derivedKey) < 32 || !bytes.Equal(crypto.Keccak256(derivedKey[16:32], cipherText), mac) {
+		return nil, ErrDecrypt
+	}
+
+	keyBytes, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := crypto.ParseECDSAPrivateKey(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// the address of the file must match the key, a mismatch means a corrupted keystore
+	if addr := hex.EncodeToString(crypto.PubKeyToAddress(&key.PublicKey).Bytes()); addr != encrypted.Address {
+		return nil, fmt.Errorf("key address %s doesn't match keystore address %s", addr, encrypted.Address)
+	}
+
+	return key, nil
+}
+
+// keyAddress reads the address of the encoded key without decrypting it
+func keyAddress(data []byte) (types.Address, error) {
+	encrypted := new(encryptedKey)
+	if err := json.Unmarshal(data, encrypted); err != nil {
+		return types.ZeroAddress, err
+	}
+
+	addr := types.Address{}
+	if err := addr.UnmarshalText([]byte("0x" + encrypted.Address)); err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return addr, nil
+}
+
+func aesCTRXOR(key, in, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+
+	return out, nil
+}
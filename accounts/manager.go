@@ -0,0 +1,333 @@
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+var (
+	ErrAccountNotFound = errors.New("account not found in the keystore")
+	ErrAccountLocked   = errors.New("account is locked")
+	ErrAccountExists   = errors.New("account already exists in the keystore")
+	ErrInvalidKey      = errors.New("invalid private key, should be 32B")
+)
+
+// unlockedKey is a decrypted key, held until it's locked again
+type unlockedKey struct {
+	key *ecdsa.PrivateKey
+
+	// timer locks the key after the unlock timeout, nil if the key is unlocked indefinitely
+	timer *time.Timer
+}
+
+// Manager manages the accounts whose keys are stored encrypted in the node keystore.
+// The transactions and messages of an account are signed only after it has been unlocked
+// with its passphrase, or with the passphrase given along
+type Manager struct {
+	logger hclog.Logger
+	dir    string
+	signer crypto.TxSigner
+
+	// scryptN and scryptP are the scrypt parameters of the keys created by the manager
+	scryptN int
+	scryptP int
+
+	lock     sync.Mutex
+	files    map[types.Address]string
+	unlocked map[types.Address]*unlockedKey
+}
+
+// NewManager creates a manager of the keystore at the given directory,
+// the transactions are signed by the signer
+func NewManager(logger hclog.Logger, dir string, signer crypto.TxSigner) (*Manager, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create keystore directory: %w", err)
+	}
+
+	m := &Manager{
+		logger:   logger.Named("accounts"),
+		dir:      dir,
+		signer:   signer,
+		scryptN:  StandardScryptN,
+		scryptP:  StandardScryptP,
+		files:    make(map[types.Address]string),
+		unlocked: make(map[types.Address]*unlockedKey),
+	}
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// load indexes the key files of the keystore by address
+func (m *Manager) load() error {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read keystore directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(m.dir, entry.Name())
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		addr, err := keyAddress(data)
+		if err != nil {
+			m.logger.Warn("skipping invalid key file", "file", entry.Name(), "err", err)
+
+			continue
+		}
+
+		m.files[addr] = path
+	}
+
+	m.logger.Info("keystore loaded", "accounts", len(m.files))
+
+	return nil
+}
+
+// Accounts returns the addresses of the accounts in the keystore
+func (m *Manager) Accounts() []types.Address {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	addrs := make([]types.Address, 0, len(m.files))
+	for addr := range m.files {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].String() < addrs[j].String()
+	})
+
+	return addrs
+}
+
+// NewAccount generates a new key and stores it encrypted with the passphrase
+func (m *Manager) NewAccount(passphrase string) (types.Address, error) {
+	key, err := crypto.GenerateECDSAKey()
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return m.storeKey(key, passphrase)
+}
+
+// ImportRawKey stores the given private key encrypted with the passphrase
+func (m *Manager) ImportRawKey(rawKey []byte, passphrase string) (types.Address, error) {
+	if len(rawKey) != 32 {
+		return types.ZeroAddress, ErrInvalidKey
+	}
+
+	key, err := crypto.ParseECDSAPrivateKey(rawKey)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return m.storeKey(key, passphrase)
+}
+
+// storeKey writes the key file of a new account
+func (m *Manager) storeKey(key *ecdsa.PrivateKey, passphrase string) (types.Address, error) {
+	addr := crypto.PubKeyToAddress(&key.PublicKey)
+
+	data, err := encryptKey(key, passphrase, m.scryptN, m.scryptP)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.files[addr]; ok {
+		return types.ZeroAddress, ErrAccountExists
+	}
+
+	path := filepath.Join(m.dir, keyFileName(addr, time.Now()))
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return types.ZeroAddress, fmt.Errorf("failed to write key file: %w", err)
+	}
+
+	m.files[addr] = path
+
+	return addr, nil
+}
+
+// Unlock decrypts the key of the account and holds it for the timeout,
+// until the account is locked if the timeout is 0
+func (m *Manager) Unlock(addr types.Address, passphrase string, timeout time.Duration) error {
+	key, err := m.decrypt(addr, passphrase)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// unlocking again resets the timeout
+	if prev, ok := m.unlocked[addr]; ok && prev.timer != nil {
+		prev.timer.Stop()
+	}
+
+	unlocked := &unlockedKey{key: key}
+	if timeout > 0 {
+		unlocked.timer = time.AfterFunc(timeout, func() {
+			m.expire(addr, unlocked)
+		})
+	}
+
+	m.unlocked[addr] = unlocked
+
+	return nil
+}
+
+// expire locks the account once its unlock timeout is over,
+// unless it has been unlocked again in the meantime
+func (m *Manager) expire(addr types.Address, unlocked *unlockedKey) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.unlocked[addr] == unlocked {
+		delete(m.unlocked, addr)
+	}
+}
+
+// Lock drops the decrypted key of the account
+func (m *Manager) Lock(addr types.Address) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.files[addr]; !ok {
+		return ErrAccountNotFound
+	}
+
+	if unlocked, ok := m.unlocked[addr]; ok {
+		if unlocked.timer != nil {
+			unlocked.timer.Stop()
+		}
+
+		delete(m.unlocked, addr)
+	}
+
+	return nil
+}
+
+// SignTx signs the transaction with the key of its sender, which must be unlocked
+func (m *Manager) SignTx(tx *types.Transaction) (*types.Transaction, error) {
+	key, err := m.unlockedKey(tx.From)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.signer.SignTx(tx, key)
+}
+
+// SignTxWithPassphrase signs the transaction with the key of its sender
+// decrypted with the passphrase, without unlocking the account
+func (m *Manager) SignTxWithPassphrase(tx *types.Transaction, passphrase string) (*types.Transaction, error) {
+	key, err := m.decrypt(tx.From, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.signer.SignTx(tx, key)
+}
+
+// SignHash signs the hash with the key of the account, which must be unlocked.
+// The signature is in the [R || S || V] format, V being 0 or 1
+func (m *Manager) SignHash(addr types.Address, hash []byte) ([]byte, error) {
+	key, err := m.unlockedKey(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Sign(key, hash)
+}
+
+// SignHashWithPassphrase signs the hash with the key of the account
+// decrypted with the passphrase, without unlocking the account
+func (m *Manager) SignHashWithPassphrase(addr types.Address, passphrase string, hash []byte) ([]byte, error) {
+	key, err := m.decrypt(addr, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Sign(key, hash)
+}
+
+// Close locks all the accounts
+func (m *Manager) Close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, unlocked := range m.unlocked {
+		if unlocked.timer != nil {
+			unlocked.timer.Stop()
+		}
+	}
+
+	m.unlocked = make(map[types.Address]*unlockedKey)
+}
+
+// unlockedKey returns the decrypted key of the unlocked account
+func (m *Manager) unlockedKey(addr types.Address) (*ecdsa.PrivateKey, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.files[addr]; !ok {
+		return nil, ErrAccountNotFound
+	}
+
+	unlocked, ok := m.unlocked[addr]
+	if !ok {
+		return nil, ErrAccountLocked
+	}
+
+	return unlocked.key, nil
+}
+
+// decrypt reads the key file of the account and decrypts it
+func (m *Manager) decrypt(addr types.Address, passphrase string) (*ecdsa.PrivateKey, error) {
+	m.lock.Lock()
+	path, ok := m.files[addr]
+	m.lock.Unlock()
+
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return decryptKey(data, passphrase)
+}
+
+// keyFileName returns the name of the key file, in the format of the other Ethereum clients
+func keyFileName(addr types.Address, now time.Time) string {
+	return fmt.Sprintf(
+		"UTC--%s--%x",
+		now.UTC().Format("2006-01-02T15-04-05.000000000Z"),
+		addr.Bytes(),
+	)
+}
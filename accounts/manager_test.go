@@ -0,0 +1,181 @@
+package accounts
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestManager(t *testing.T, dir string) *Manager {
+	t.Helper()
+
+	m, err := NewManager(hclog.NewNullLogger(), dir, crypto.NewEIP155Signer(100))
+	require.NoError(t, err)
+
+	m.scryptN, m.scryptP = LightScryptN, LightScryptP
+
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+func TestKeystore_EncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	data, err := encryptKey(key, "secret", LightScryptN, LightScryptP)
+	require.NoError(t, err)
+
+	addr, err := keyAddress(data)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&key.PublicKey), addr)
+
+	decrypted, err := decryptKey(data, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, key.D, decrypted.D)
+
+	_, err = decryptKey(data, "wrong")
+	assert.ErrorIs(t, err, ErrDecrypt)
+}
+
+func TestManager_Accounts(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m := newTestManager(t, dir)
+
+	created, err := m.NewAccount("secret")
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	rawKey, err := crypto.MarshalECDSAPrivateKey(key)
+	require.NoError(t, err)
+
+	imported, err := m.ImportRawKey(rawKey, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&key.PublicKey), imported)
+
+	_, err = m.ImportRawKey(rawKey, "other")
+	assert.ErrorIs(t, err, ErrAccountExists)
+
+	_, err = m.ImportRawKey(rawKey[1:], "secret")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	assert.ElementsMatch(t, []types.Address{created, imported}, m.Accounts())
+
+	// the accounts are loaded from the keystore on restart
+	assert.ElementsMatch(t, []types.Address{created, imported}, newTestManager(t, dir).Accounts())
+}
+
+func TestManager_SignTx(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t, t.TempDir())
+
+	addr, err := m.NewAccount("secret")
+	require.NoError(t, err)
+
+	tx := &types.Transaction{
+		From:     addr,
+		To:       &addr,
+		Value:    big.NewInt(1),
+		Gas:      21000,
+		GasPrice: big.NewInt(1),
+	}
+
+	_, err = m.SignTx(tx)
+	assert.ErrorIs(t, err, ErrAccountLocked)
+
+	_, err = m.SignTx(&types.Transaction{From: types.StringToAddress("1")})
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+
+	assert.ErrorIs(t, m.Unlock(addr, "wrong", 0), ErrDecrypt)
+	require.NoError(t, m.Unlock(addr, "secret", 0))
+
+	signed, err := m.SignTx(tx)
+	require.NoError(t, err)
+
+	sender, err := crypto.NewEIP155Signer(100).Sender(signed)
+	require.NoError(t, err)
+	assert.Equal(t, addr, sender)
+
+	require.NoError(t, m.Lock(addr))
+
+	_, err = m.SignTx(tx)
+	assert.ErrorIs(t, err, ErrAccountLocked)
+
+	// signing with the passphrase doesn't unlock the account
+	_, err = m.SignTxWithPassphrase(tx, "secret")
+	require.NoError(t, err)
+
+	_, err = m.SignTx(tx)
+	assert.ErrorIs(t, err, ErrAccountLocked)
+}
+
+func TestManager_UnlockTimeout(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t, t.TempDir())
+
+	addr, err := m.NewAccount("secret")
+	require.NoError(t, err)
+
+	tx := &types.Transaction{From: addr, To: &addr, Value: big.NewInt(0), GasPrice: big.NewInt(1)}
+
+	require.NoError(t, m.Unlock(addr, "secret", 50*time.Millisecond))
+
+	_, err = m.SignTx(tx)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		_, err := m.SignTx(tx)
+
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// unlocking indefinitely overrides a pending timeout
+	require.NoError(t, m.Unlock(addr, "secret", 50*time.Millisecond))
+	require.NoError(t, m.Unlock(addr, "secret", 0))
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = m.SignTx(tx)
+	assert.NoError(t, err)
+}
+
+func TestManager_SignHash(t *testing.T) {
+	t.Parallel()
+
+	m := newTestManager(t, t.TempDir())
+
+	addr, err := m.NewAccount("secret")
+	require.NoError(t, err)
+
+	hash := crypto.TextHash([]byte("hello"))
+
+	_, err = m.SignHash(addr, hash)
+	assert.ErrorIs(t, err, ErrAccountLocked)
+
+	sig, err := m.SignHashWithPassphrase(addr, "secret", hash)
+	require.NoError(t, err)
+
+	pub, err := crypto.RecoverPubkey(sig, hash)
+	require.NoError(t, err)
+	assert.Equal(t, addr, crypto.PubKeyToAddress(pub))
+
+	require.NoError(t, m.Unlock(addr, "secret", 0))
+
+	unlockedSig, err := m.SignHash(addr, hash)
+	require.NoError(t, err)
+	assert.Equal(t, sig, unlockedSig)
+}
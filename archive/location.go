@@ -0,0 +1,180 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+const (
+	s3Scheme = "s3://"
+
+	// defaultS3Region is used for the S3 compatible endpoints when no region is configured
+	defaultS3Region = "us-east-1"
+)
+
+var errSnapshotAborted = errors.New("snapshot aborted")
+
+// SnapshotDestination is where a snapshot is written to
+type SnapshotDestination interface {
+	io.Writer
+
+	// Commit completes the snapshot once fully written
+	Commit() error
+
+	// Abort discards the partially written snapshot
+	Abort()
+}
+
+// OpenSnapshotDestination creates the snapshot at the location, which is either
+// a local path or a s3://bucket/key url. The endpoint is set for the S3 compatible stores
+func OpenSnapshotDestination(location, s3Endpoint string) (SnapshotDestination, error) {
+	if !strings.HasPrefix(location, s3Scheme) {
+		// always create new file, throw error if the file exists
+		fs, err := os.OpenFile(location, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return nil, err
+		}
+
+		return &fileDestination{fs}, nil
+	}
+
+	bucket, key, err := parseS3Location(location)
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := newS3Session(s3Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+	dest := &s3Destination{writer: writer, done: make(chan error, 1)}
+
+	go func() {
+		_, err := s3manager.NewUploader(sess).Upload(&s3manager.UploadInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Body:   reader,
+		})
+
+		// unblock the writer if the upload failed
+		_ = reader.CloseWithError(err)
+
+		dest.done <- err
+	}()
+
+	return dest, nil
+}
+
+// OpenSnapshotSource opens the snapshot at the location, which is either
+// a local path or a s3://bucket/key url
+func OpenSnapshotSource(location, s3Endpoint string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(location, s3Scheme) {
+		return os.Open(location)
+	}
+
+	bucket, key, err := parseS3Location(location)
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := newS3Session(s3Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := s3.New(sess).GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
+func parseS3Location(location string) (string, string, error) {
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(location, s3Scheme), "/")
+	if !ok || bucket == "" || key == "" {
+		return "", "", fmt.Errorf("invalid S3 location %q, expected s3://bucket/key", location)
+	}
+
+	return bucket, key, nil
+}
+
+// newS3Session creates the session from the environment and the shared config
+func newS3Session(endpoint string) (*session.Session, error) {
+	config := aws.Config{}
+
+	if endpoint != "" {
+		config.Endpoint = aws.String(endpoint)
+		// the S3 compatible stores don't resolve the virtual hosted buckets
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize S3 client: %w", err)
+	}
+
+	if aws.StringValue(sess.Config.Region) == "" {
+		sess.Config.Region = aws.String(defaultS3Region)
+	}
+
+	return sess, nil
+}
+
+type fileDestination struct {
+	*os.File
+}
+
+func (f *fileDestination) Commit() error {
+	if err := f.Sync(); err != nil {
+		f.Abort()
+
+		return err
+	}
+
+	return f.Close()
+}
+
+func (f *fileDestination) Abort() {
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+}
+
+// s3Destination streams the snapshot to a multipart upload
+type s3Destination struct {
+	writer *io.PipeWriter
+	done   chan error
+}
+
+func (s *s3Destination) Write(b []byte) (int, error) {
+	return s.writer.Write(b)
+}
+
+func (s *s3Destination) Commit() error {
+	_ = s.writer.Close()
+
+	return <-s.done
+}
+
+// Abort fails the upload, the uploader aborting the multipart upload
+func (s *s3Destination) Abort() {
+	_ = s.writer.CloseWithError(errSnapshotAborted)
+
+	<-s.done
+}
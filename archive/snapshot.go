@@ -0,0 +1,509 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// The snapshot is a gzip stream of the records below, all the lengths being uvarints:
+//
+//	header:   "EDGESNAP" | version
+//	database: 'd' | len | name
+//	entry:    'e' | len | key | len | value
+//	trailer:  't' | entries | sha256 of all the preceding bytes
+const (
+	snapshotMagic   = "EDGESNAP"
+	snapshotVersion = byte(1)
+
+	recordDatabase = byte('d')
+	recordEntry    = byte('e')
+	recordTrailer  = byte('t')
+
+	// restoreBatchSize is the size of the entries written to the database at once
+	restoreBatchSize = 4 * 1024 * 1024
+)
+
+// snapshotDatabases are the databases of the datadir in the snapshot,
+// the secrets are never part of it
+var snapshotDatabases = map[string]bool{
+	"blockchain": true,
+	"trie":       true,
+}
+
+var (
+	ErrSnapshotInvalid   = errors.New("invalid snapshot")
+	ErrSnapshotTruncated = errors.New("snapshot is truncated")
+	ErrSnapshotChecksum  = errors.New("snapshot checksum mismatch")
+	ErrDataDirNotEmpty   = errors.New("the databases already exist in the data directory")
+)
+
+// SnapshotSummary describes a written or restored snapshot
+type SnapshotSummary struct {
+	Entries  uint64
+	Checksum string
+}
+
+// snapshotEventSource is the stream of the snapshot entries sent by the node
+type snapshotEventSource interface {
+	Recv() (*proto.SnapshotEvent, error)
+}
+
+// CreateSnapshot fetches a consistent snapshot of the node databases via gRPC
+// and writes it compressed and checksummed to the writer
+func CreateSnapshot(conn *grpc.ClientConn, logger hclog.Logger, writer io.Writer) (*SnapshotSummary, error) {
+	signalCh := common.GetTerminationSignalCh()
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	go func() {
+		<-signalCh
+		logger.Info("Caught termination signal, shutting down...")
+		cancelFn()
+	}()
+
+	stream, err := proto.NewSystemClient(conn).Snapshot(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return writeSnapshot(stream, logger, writer)
+}
+
+func writeSnapshot(stream snapshotEventSource, logger hclog.Logger, writer io.Writer) (*SnapshotSummary, error) {
+	gz := gzip.NewWriter(writer)
+	w := newSnapshotWriter(gz)
+
+	if err := w.writeHeader(); err != nil {
+		return nil, err
+	}
+
+	database := ""
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(event.Keys) != len(event.Values) {
+			return nil, fmt.Errorf("%w: %d keys and %d values", ErrSnapshotInvalid, len(event.Keys), len(event.Values))
+		}
+
+		if event.Database != database {
+			if database != "" {
+				logger.Info("Wrote database to snapshot", "database", database, "entries", w.entries)
+			}
+
+			database = event.Database
+
+			if err := w.writeDatabase(database); err != nil {
+				return nil, err
+			}
+		}
+
+		for i := range event.Keys {
+			if err := w.writeEntry(event.Keys[i], event.Values[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if database == "" {
+		return nil, errors.New("the node sent no snapshot entries")
+	}
+
+	logger.Info("Wrote database to snapshot", "database", database, "entries", w.entries)
+
+	summary, err := w.writeTrailer()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// RestoreSnapshot restores the snapshot read from the reader into the data directory.
+// The databases are written aside and only moved in place once the checksum is verified
+func RestoreSnapshot(reader io.Reader, logger hclog.Logger, dataDir string) (*SnapshotSummary, error) {
+	for name := range snapshotDatabases {
+		if _, err := os.Stat(filepath.Join(dataDir, name)); err == nil {
+			return nil, fmt.Errorf("%w: %s", ErrDataDirNotEmpty, filepath.Join(dataDir, name))
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp(dataDir, "restore")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			logger.Error("an error occurred while removing the temporary directory", "err", err)
+		}
+	}()
+
+	w := &restoreWriter{
+		logger: logger,
+		dir:    tmpDir,
+		dbs:    map[string]*leveldb.DB{},
+	}
+
+	summary, err := readSnapshot(reader, w.put)
+	if err == nil {
+		err = w.flush()
+	}
+
+	if closeErr := w.close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range w.dbs {
+		if err := os.Rename(filepath.Join(tmpDir, name), filepath.Join(dataDir, name)); err != nil {
+			return nil, err
+		}
+	}
+
+	return summary, nil
+}
+
+// restoreWriter writes the restored entries in batches to the databases
+type restoreWriter struct {
+	logger hclog.Logger
+	dir    string
+
+	dbs      map[string]*leveldb.DB
+	database string
+	batch    leveldb.Batch
+}
+
+func (w *restoreWriter) put(database string, key, value []byte) error {
+	if database != w.database {
+		if err := w.flush(); err != nil {
+			return err
+		}
+
+		if _, ok := w.dbs[database]; !ok {
+			db, err := leveldb.OpenFile(filepath.Join(w.dir, database), nil)
+			if err != nil {
+				return err
+			}
+
+			w.dbs[database] = db
+		}
+
+		w.database = database
+	}
+
+	w.batch.Put(key, value)
+
+	if len(w.batch.Dump()) >= restoreBatchSize {
+		return w.flush()
+	}
+
+	return nil
+}
+
+func (w *restoreWriter) flush() error {
+	if w.batch.Len() == 0 {
+		return nil
+	}
+
+	if err := w.dbs[w.database].Write(&w.batch, nil); err != nil {
+		return err
+	}
+
+	w.batch.Reset()
+
+	return nil
+}
+
+func (w *restoreWriter) close() error {
+	var closeErr error
+
+	for name, db := range w.dbs {
+		if err := db.Close(); err != nil {
+			w.logger.Error("an error occurred while closing the database", "database", name, "err", err)
+
+			closeErr = err
+		}
+	}
+
+	return closeErr
+}
+
+// snapshotWriter writes the records of the snapshot while hashing them
+type snapshotWriter struct {
+	w       *bufio.Writer
+	hash    hash.Hash
+	entries uint64
+	buf     [binary.MaxVarintLen64]byte
+}
+
+func newSnapshotWriter(writer io.Writer) *snapshotWriter {
+	return &snapshotWriter{
+		w:    bufio.NewWriter(writer),
+		hash: sha256.New(),
+	}
+}
+
+func (s *snapshotWriter) write(b []byte) error {
+	s.hash.Write(b)
+
+	_, err := s.w.Write(b)
+
+	return err
+}
+
+func (s *snapshotWriter) writeUvarint(n uint64) error {
+	return s.write(s.buf[:binary.PutUvarint(s.buf[:], n)])
+}
+
+func (s *snapshotWriter) writeBytes(b []byte) error {
+	if err := s.writeUvarint(uint64(len(b))); err != nil {
+		return err
+	}
+
+	return s.write(b)
+}
+
+func (s *snapshotWriter) writeHeader() error {
+	return s.write(append([]byte(snapshotMagic), snapshotVersion))
+}
+
+func (s *snapshotWriter) writeDatabase(name string) error {
+	if !snapshotDatabases[name] {
+		return fmt.Errorf("%w: unknown database %q", ErrSnapshotInvalid, name)
+	}
+
+	if err := s.write([]byte{recordDatabase}); err != nil {
+		return err
+	}
+
+	return s.writeBytes([]byte(name))
+}
+
+func (s *snapshotWriter) writeEntry(key, value []byte) error {
+	if err := s.write([]byte{recordEntry}); err != nil {
+		return err
+	}
+
+	if err := s.writeBytes(key); err != nil {
+		return err
+	}
+
+	if err := s.writeBytes(value); err != nil {
+		return err
+	}
+
+	s.entries++
+
+	return nil
+}
+
+func (s *snapshotWriter) writeTrailer() (*SnapshotSummary, error) {
+	if err := s.write([]byte{recordTrailer}); err != nil {
+		return nil, err
+	}
+
+	if err := s.writeUvarint(s.entries); err != nil {
+		return nil, err
+	}
+
+	checksum := s.hash.Sum(nil)
+
+	if _, err := s.w.Write(checksum); err != nil {
+		return nil, err
+	}
+
+	if err := s.w.Flush(); err != nil {
+		return nil, err
+	}
+
+	return &SnapshotSummary{Entries: s.entries, Checksum: hex.EncodeToHex(checksum)}, nil
+}
+
+// snapshotReader reads the records of the snapshot while hashing them
+type snapshotReader struct {
+	r    *bufio.Reader
+	hash hash.Hash
+}
+
+func (s *snapshotReader) ReadByte() (byte, error) {
+	b, err := s.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	s.hash.Write([]byte{b})
+
+	return b, nil
+}
+
+func (s *snapshotReader) readFull(b []byte) error {
+	if _, err := io.ReadFull(s.r, b); err != nil {
+		return err
+	}
+
+	s.hash.Write(b)
+
+	return nil
+}
+
+func (s *snapshotReader) readBytes() ([]byte, error) {
+	size, err := binary.ReadUvarint(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't trust the length for the allocation, the data may be corrupted
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, s.r, int64(size)); err != nil {
+		return nil, err
+	}
+
+	s.hash.Write(buf.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+// readSnapshot decompresses the snapshot and passes its entries to the handler,
+// it fails if the snapshot is truncated or doesn't match its checksum
+func readSnapshot(reader io.Reader, handler func(database string, key, value []byte) error) (*SnapshotSummary, error) {
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSnapshotInvalid, err)
+	}
+
+	s := &snapshotReader{r: bufio.NewReader(gz), hash: sha256.New()}
+
+	summary, err := readSnapshotRecords(s, handler)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, ErrSnapshotTruncated
+	}
+
+	return summary, err
+}
+
+func readSnapshotRecords(
+	s *snapshotReader,
+	handler func(database string, key, value []byte) error,
+) (*SnapshotSummary, error) {
+	header := make([]byte, len(snapshotMagic)+1)
+	if err := s.readFull(header); err != nil {
+		return nil, err
+	}
+
+	if string(header[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, fmt.Errorf("%w: not a snapshot", ErrSnapshotInvalid)
+	}
+
+	if header[len(snapshotMagic)] != snapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrSnapshotInvalid, header[len(snapshotMagic)])
+	}
+
+	var (
+		database string
+		entries  uint64
+	)
+
+	for {
+		record, err := s.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch record {
+		case recordDatabase:
+			name, err := s.readBytes()
+			if err != nil {
+				return nil, err
+			}
+
+			if !snapshotDatabases[string(name)] {
+				return nil, fmt.Errorf("%w: unknown database %q", ErrSnapshotInvalid, name)
+			}
+
+			database = string(name)
+
+		case recordEntry:
+			if database == "" {
+				return nil, fmt.Errorf("%w: entry outside of a database", ErrSnapshotInvalid)
+			}
+
+			key, err := s.readBytes()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := s.readBytes()
+			if err != nil {
+				return nil, err
+			}
+
+			if err := handler(database, key, value); err != nil {
+				return nil, err
+			}
+
+			entries++
+
+		case recordTrailer:
+			count, err := binary.ReadUvarint(s)
+			if err != nil {
+				return nil, err
+			}
+
+			expected := s.hash.Sum(nil)
+
+			checksum := make([]byte, sha256.Size)
+			if _, err := io.ReadFull(s.r, checksum); err != nil {
+				return nil, err
+			}
+
+			if count != entries || !bytes.Equal(checksum, expected) {
+				return nil, ErrSnapshotChecksum
+			}
+
+			return &SnapshotSummary{Entries: entries, Checksum: hex.EncodeToHex(checksum)}, nil
+
+		default:
+			return nil, fmt.Errorf("%w: unknown record %#x", ErrSnapshotInvalid, record)
+		}
+	}
+}
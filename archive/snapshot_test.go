@@ -0,0 +1,143 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type mockSnapshotClient struct {
+	events []*proto.SnapshotEvent
+}
+
+func (m *mockSnapshotClient) Recv() (*proto.SnapshotEvent, error) {
+	if len(m.events) == 0 {
+		return nil, io.EOF
+	}
+
+	event := m.events[0]
+	m.events = m.events[1:]
+
+	return event, nil
+}
+
+func testSnapshotEvents() []*proto.SnapshotEvent {
+	return []*proto.SnapshotEvent{
+		{Database: "blockchain", Keys: [][]byte{[]byte("h1"), []byte("h2")}, Values: [][]byte{{0x1}, {0x2}}},
+		{Database: "blockchain", Keys: [][]byte{[]byte("o")}, Values: [][]byte{{0x3}}},
+		{Database: "trie", Keys: [][]byte{[]byte("code1")}, Values: [][]byte{{}}},
+	}
+}
+
+func writeTestSnapshot(t *testing.T) ([]byte, *SnapshotSummary) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	summary, err := writeSnapshot(&mockSnapshotClient{testSnapshotEvents()}, hclog.NewNullLogger(), &buf)
+	require.NoError(t, err)
+
+	return buf.Bytes(), summary
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	data, written := writeTestSnapshot(t)
+	assert.Equal(t, uint64(4), written.Entries)
+
+	read := map[string]map[string][]byte{}
+
+	summary, err := readSnapshot(bytes.NewReader(data), func(database string, key, value []byte) error {
+		if read[database] == nil {
+			read[database] = map[string][]byte{}
+		}
+
+		read[database][string(key)] = value
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, written, summary)
+	assert.Equal(t, map[string]map[string][]byte{
+		"blockchain": {"h1": {0x1}, "h2": {0x2}, "o": {0x3}},
+		"trie":       {"code1": {}},
+	}, read)
+}
+
+func TestSnapshot_Corrupted(t *testing.T) {
+	t.Parallel()
+
+	data, _ := writeTestSnapshot(t)
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	raw, err := io.ReadAll(gz)
+	require.NoError(t, err)
+
+	compress := func(raw []byte) []byte {
+		var buf bytes.Buffer
+
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write(raw)
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+
+		return buf.Bytes()
+	}
+
+	noop := func(string, []byte, []byte) error { return nil }
+
+	// the value of the first entry is changed
+	tampered := bytes.Replace(raw, []byte("h1\x01\x01"), []byte("h1\x01\x09"), 1)
+	require.NotEqual(t, raw, tampered)
+
+	_, err = readSnapshot(bytes.NewReader(compress(tampered)), noop)
+	assert.ErrorIs(t, err, ErrSnapshotChecksum)
+
+	// the trailer is lost
+	_, err = readSnapshot(bytes.NewReader(compress(raw[:len(raw)-40])), noop)
+	assert.ErrorIs(t, err, ErrSnapshotTruncated)
+
+	_, err = readSnapshot(bytes.NewReader([]byte("not a snapshot")), noop)
+	assert.ErrorIs(t, err, ErrSnapshotInvalid)
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	t.Parallel()
+
+	data, written := writeTestSnapshot(t)
+	dataDir := t.TempDir()
+
+	summary, err := RestoreSnapshot(bytes.NewReader(data), hclog.NewNullLogger(), dataDir)
+	require.NoError(t, err)
+	assert.Equal(t, written, summary)
+
+	// only the databases are left in the data directory
+	entries, err := os.ReadDir(dataDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	db, err := leveldb.OpenFile(filepath.Join(dataDir, "blockchain"), nil)
+	require.NoError(t, err)
+
+	value, err := db.Get([]byte("h2"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x2}, value)
+	require.NoError(t, db.Close())
+
+	// the databases are never overwritten
+	_, err = RestoreSnapshot(bytes.NewReader(data), hclog.NewNullLogger(), dataDir)
+	assert.ErrorIs(t, err, ErrDataDirNotEmpty)
+}
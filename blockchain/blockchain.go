@@ -22,8 +22,9 @@ import (
 )
 
 const (
-	BlockGasTargetDivisor uint64 = 1024 // The bound divisor of the gas limit, used in update calculations
-	defaultCacheSize      int    = 100  // The default size for Blockchain LRU cache structures
+	// The default bound divisor of the gas limit, used in update calculations
+	BlockGasTargetDivisor uint64 = chain.DefaultGasLimitBoundDivisor
+	defaultCacheSize      int    = 100 // The default size for Blockchain LRU cache structures
 )
 
 var (
@@ -38,6 +39,13 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+	ErrStateDiffsDisabled   = errors.New("state diffs are not persisted")
+	ErrRewindAhead          = errors.New("can't rewind to a block after the head")
+	ErrInvalidBlobGas       = errors.New("invalid block blob gas")
+	ErrInvalidBlobGasUsed   = errors.New("invalid block blob gas used")
+	ErrClosed               = errors.New("blockchain is closed")
+	ErrBlockVetoed          = errors.New("block vetoed")
+	ErrGasLimitOutOfBounds  = errors.New("block gas limit out of bounds")
 )
 
 // Blockchain is a blockchain reference
@@ -66,6 +74,22 @@ type Blockchain struct {
 	// any new fields from being added
 	receiptsCache *lru.Cache // LRU cache for the block receipts
 
+	// The state diffs are kept between the verification and the insertion
+	// of a block the same way as the receipts, when they are persisted
+	persistStateDiffs bool
+	stateDiffsCache   *lru.Cache // LRU cache for the block state diffs
+
+	// the sidecars of the blob transactions are kept for blobRetention blocks,
+	// those not carried by the written blocks are looked up in sidecarSource
+	blobRetention uint64
+	sidecarSource BlobSidecarSource
+
+	// gates refuse the blocks the node must not produce nor import
+	gates []BlockGate
+
+	// hooks vet the blocks sealed by the node and the blocks written
+	hooks []BlockHook
+
 	currentHeader     atomic.Value // The current header
 	currentDifficulty atomic.Value // The current difficulty of the chain (total difficulty)
 
@@ -74,6 +98,7 @@ type Blockchain struct {
 	gpAverage *gasPriceAverage // A reference to the average gas price
 
 	writeLock sync.Mutex
+	closed    bool // set under the write lock once closed, the blocks are no longer written
 }
 
 // gasPriceAverage keeps track of the average gas price (rolling average)
@@ -95,15 +120,40 @@ type Executor interface {
 	ProcessBlock(parentRoot types.Hash, block *types.Block, blockCreator types.Address) (*state.Transition, error)
 }
 
+// BlobSidecarSource looks up the sidecars of the blob transactions received apart from the blocks
+type BlobSidecarSource interface {
+	// BlobSidecar returns the sidecar of the blob transaction, nil if unknown
+	BlobSidecar(hash types.Hash) *types.BlobSidecar
+}
+
+// BlockGate refuses the blocks the node must not produce nor import, such as
+// the blocks of an unsupported upgrade or those past a coordinated halt
+type BlockGate interface {
+	// CheckBlock returns an error if the block of the given number is refused
+	CheckBlock(number uint64) error
+}
+
+// BlockHook vets the blocks sealed by the node before they are proposed, and the blocks
+// before they are written, such as the hooks of the plugins
+type BlockHook interface {
+	// SealBlock returns an error if the block sealed by the node is refused
+	SealBlock(block *types.Block) error
+
+	// ImportBlock returns an error if the block from the given source is refused
+	ImportBlock(block *types.Block, source string) error
+}
+
 type TxSigner interface {
 	// Sender returns the sender of the transaction
 	Sender(tx *types.Transaction) (types.Address, error)
 }
 
 type BlockResult struct {
-	Root     types.Hash
-	Receipts []*types.Receipt
-	TotalGas uint64
+	Root        types.Hash
+	Receipts    []*types.Receipt
+	TotalGas    uint64
+	BlobGasUsed uint64
+	StateDiff   *types.StateDiff // set when the state diffs are persisted
 }
 
 // updateGasPriceAvg updates the rolling average value of the gas price
@@ -255,6 +305,11 @@ func (b *Blockchain) initCaches(size int) error {
 		return fmt.Errorf("unable to create receipts cache, %w", err)
 	}
 
+	b.stateDiffsCache, err = lru.New(size)
+	if err != nil {
+		return fmt.Errorf("unable to create state diffs cache, %w", err)
+	}
+
 	return nil
 }
 
@@ -383,24 +438,31 @@ func (b *Blockchain) CalculateGasLimit(number uint64) (uint64, error) {
 
 // calculateGasLimit calculates gas limit in reference to the block gas target
 func (b *Blockchain) calculateGasLimit(parentGasLimit uint64) uint64 {
-	// The gas limit cannot move more than 1/1024 * parentGasLimit
+	// The gas limit cannot move more than 1/divisor * parentGasLimit
 	// in either direction per block
-	blockGasTarget := b.Config().BlockGasTarget
+	var (
+		bounds         = b.Config().GasLimitBounds
+		blockGasTarget = b.Config().BlockGasTarget
+	)
 
 	// Check if the gas limit target has been set
 	if blockGasTarget == 0 {
 		// The gas limit target has not been set,
 		// so it should use the parent gas limit
-		return parentGasLimit
+		blockGasTarget = parentGasLimit
 	}
 
+	// The gas limit can't move beyond the bounds set in the genesis,
+	// whatever the target of the proposer
+	blockGasTarget = bounds.Clamp(blockGasTarget)
+
 	// Check if the gas limit is already at the target
 	if parentGasLimit == blockGasTarget {
 		// The gas limit is already at the target, no need to move it
 		return blockGasTarget
 	}
 
-	delta := parentGasLimit * 1 / BlockGasTargetDivisor
+	delta := parentGasLimit * 1 / bounds.GetDivisor()
 	if parentGasLimit < blockGasTarget {
 		// The gas limit is lower than the gas target, so it should
 		// increase towards the target
@@ -533,6 +595,65 @@ func (b *Blockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error
 	return b.db.ReadReceipts(hash)
 }
 
+// EnableStateDiffs persists the state diffs of the blocks written from now on
+func (b *Blockchain) EnableStateDiffs() {
+	b.persistStateDiffs = true
+}
+
+// GetStateDiffByHash returns the state diff of the block by its hash
+func (b *Blockchain) GetStateDiffByHash(hash types.Hash) (*types.StateDiff, error) {
+	if !b.persistStateDiffs {
+		return nil, ErrStateDiffsDisabled
+	}
+
+	return b.db.ReadStateDiff(hash)
+}
+
+// SetBlobRetention keeps the sidecars of the blob transactions for the given number of blocks,
+// looking up those not carried by the blocks in the given source
+func (b *Blockchain) SetBlobRetention(retention uint64, source BlobSidecarSource) {
+	b.blobRetention = retention
+	b.sidecarSource = source
+}
+
+// AddBlockGate adds a gate refusing blocks, the gates are added before the blockchain is in use
+func (b *Blockchain) AddBlockGate(gate BlockGate) {
+	b.gates = append(b.gates, gate)
+}
+
+// CheckBlockGates returns an error if a gate refuses the block of the given number
+func (b *Blockchain) CheckBlockGates(number uint64) error {
+	for _, gate := range b.gates {
+		if err := gate.CheckBlock(number); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddBlockHook adds a hook vetting blocks, the hooks are added before the blockchain is in use
+func (b *Blockchain) AddBlockHook(hook BlockHook) {
+	b.hooks = append(b.hooks, hook)
+}
+
+// CheckSealHooks returns an error if a hook refuses the block sealed by the node
+func (b *Blockchain) CheckSealHooks(block *types.Block) error {
+	for _, hook := range b.hooks {
+		if err := hook.SealBlock(block); err != nil {
+			return fmt.Errorf("%w: %v", ErrBlockVetoed, err)
+		}
+	}
+
+	return nil
+}
+
+// GetBlobSidecarsByHash returns the sidecars of the blob transactions of the block by its hash,
+// in their order in the block. They are pruned once out of the retention window
+func (b *Blockchain) GetBlobSidecarsByHash(hash types.Hash) ([]*types.BlobSidecar, error) {
+	return b.db.ReadBlobSidecars(hash)
+}
+
 // GetBodyByHash returns the body by their hash
 func (b *Blockchain) GetBodyByHash(hash types.Hash) (*types.Body, bool) {
 	return b.readBody(hash)
@@ -706,6 +827,11 @@ func (b *Blockchain) verifyBlock(block *types.Block) error {
 		return ErrNoBlock
 	}
 
+	// Make sure the block isn't refused by a gate, such as an unsupported upgrade
+	if err := b.CheckBlockGates(block.Number()); err != nil {
+		return err
+	}
+
 	// Make sure the block is in line with the parent block
 	if err := b.verifyBlockParent(block); err != nil {
 		return err
@@ -767,6 +893,47 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 		return fmt.Errorf("invalid gas limit, %w", gasLimitErr)
 	}
 
+	// Make sure the blob gas follows the parent one
+	if err := b.verifyBlobGas(childBlock.Header, parent); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// verifyBlobGas checks the blob gas fields of the header, which are set
+// only once the blob transactions are enabled
+func (b *Blockchain) verifyBlobGas(header *types.Header, parentHeader *types.Header) error {
+	if forks := b.config.Params.Forks; forks == nil || !forks.IsBlobTx(header.Number) {
+		if header.BlobGasUsed != nil || header.ExcessBlobGas != nil {
+			return ErrInvalidBlobGas
+		}
+
+		return nil
+	}
+
+	if header.BlobGasUsed == nil || header.ExcessBlobGas == nil {
+		return ErrInvalidBlobGas
+	}
+
+	if *header.BlobGasUsed > types.MaxBlobGasPerBlock {
+		return fmt.Errorf(
+			"%w: blob gas used exceeds the limit, limit = %d, used = %d",
+			ErrInvalidBlobGas,
+			types.MaxBlobGasPerBlock,
+			*header.BlobGasUsed,
+		)
+	}
+
+	if expected := types.CalcExcessBlobGas(parentHeader); *header.ExcessBlobGas != expected {
+		return fmt.Errorf(
+			"%w: invalid excess blob gas, have %d, want %d",
+			ErrInvalidBlobGas,
+			*header.ExcessBlobGas,
+			expected,
+		)
+	}
+
 	return nil
 }
 
@@ -829,6 +996,11 @@ func (br *BlockResult) verifyBlockResult(referenceBlock *types.Block) error {
 		return ErrInvalidGasUsed
 	}
 
+	// Make sure the blob gas used is valid, once the blob transactions are enabled
+	if blobGasUsed := referenceBlock.Header.BlobGasUsed; blobGasUsed != nil && br.BlobGasUsed != *blobGasUsed {
+		return ErrInvalidBlobGasUsed
+	}
+
 	// Make sure the receipts root matches up
 	receiptsRoot := buildroot.CalculateReceiptsRoot(br.Receipts)
 	if receiptsRoot != referenceBlock.Header.ReceiptsRoot {
@@ -867,10 +1039,22 @@ func (b *Blockchain) executeBlockTransactions(block *types.Block) (*BlockResult,
 	// Append the receipts to the receipts cache
 	b.receiptsCache.Add(header.Hash, txn.Receipts())
 
+	var stateDiff *types.StateDiff
+
+	if b.persistStateDiffs {
+		if stateDiff, err = txn.StateDiff(); err != nil {
+			return nil, err
+		}
+
+		b.stateDiffsCache.Add(header.Hash, stateDiff)
+	}
+
 	return &BlockResult{
-		Root:     root,
-		Receipts: txn.Receipts(),
-		TotalGas: txn.TotalGas(),
+		Root:        root,
+		Receipts:    txn.Receipts(),
+		TotalGas:    txn.TotalGas(),
+		BlobGasUsed: txn.BlobGasUsed(),
+		StateDiff:   stateDiff,
 	}, nil
 }
 
@@ -880,12 +1064,26 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 	b.writeLock.Lock()
 	defer b.writeLock.Unlock()
 
+	if b.closed {
+		return ErrClosed
+	}
+
 	if block.Number() <= b.Header().Number {
 		b.logger.Info("block already inserted", "block", block.Number(), "source", source)
 
 		return nil
 	}
 
+	if err := b.CheckBlockGates(block.Number()); err != nil {
+		return err
+	}
+
+	for _, hook := range b.hooks {
+		if err := hook.ImportBlock(block, source); err != nil {
+			return fmt.Errorf("%w: %v", ErrBlockVetoed, err)
+		}
+	}
+
 	header := block.Header
 
 	if err := b.writeBody(block); err != nil {
@@ -911,6 +1109,14 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 		return err
 	}
 
+	if err := b.writeStateDiff(block); err != nil {
+		return err
+	}
+
+	if err := b.writeBlobSidecars(block); err != nil {
+		return err
+	}
+
 	// update snapshot
 	if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
 		return err
@@ -961,6 +1167,78 @@ func (b *Blockchain) extractBlockReceipts(block *types.Block) ([]*types.Receipt,
 	return extractedReceipts, nil
 }
 
+// writeStateDiff writes the state diff of the block, if they are persisted
+func (b *Blockchain) writeStateDiff(block *types.Block) error {
+	if !b.persistStateDiffs {
+		return nil
+	}
+
+	stateDiff, ok := b.stateDiffsCache.Get(block.Hash())
+	if !ok {
+		// No state diff found in the cache, execute the transactions
+		// from the block again
+		blockResult, err := b.executeBlockTransactions(block)
+		if err != nil {
+			return err
+		}
+
+		stateDiff = blockResult.StateDiff
+	}
+
+	extractedStateDiff, ok := stateDiff.(*types.StateDiff)
+	if !ok {
+		return errors.New("invalid type assertion for state diff")
+	}
+
+	return b.db.WriteStateDiff(block.Hash(), extractedStateDiff)
+}
+
+// writeBlobSidecars writes the sidecars of the blob transactions of the block,
+// and prunes those of the block leaving the retention window
+func (b *Blockchain) writeBlobSidecars(block *types.Block) error {
+	if b.blobRetention == 0 {
+		return nil
+	}
+
+	sidecars := make([]*types.BlobSidecar, 0)
+
+	for _, tx := range block.Transactions {
+		if tx.Type != types.BlobTx {
+			continue
+		}
+
+		sidecar := tx.Sidecar
+		if sidecar == nil && b.sidecarSource != nil {
+			sidecar = b.sidecarSource.BlobSidecar(tx.Hash)
+		}
+
+		if sidecar == nil {
+			b.logger.Debug("blob sidecar not received", "hash", tx.Hash, "block", block.Number())
+
+			sidecar = &types.BlobSidecar{}
+		}
+
+		sidecars = append(sidecars, sidecar)
+	}
+
+	if len(sidecars) > 0 {
+		if err := b.db.WriteBlobSidecars(block.Hash(), sidecars); err != nil {
+			return err
+		}
+	}
+
+	if block.Number() < b.blobRetention {
+		return nil
+	}
+
+	pruned, ok := b.GetHeaderByNumber(block.Number() - b.blobRetention)
+	if !ok {
+		return nil
+	}
+
+	return b.db.DeleteBlobSidecars(pruned.Hash)
+}
+
 // updateGasPriceAvgWithBlock extracts the gas price information from the
 // block, and updates the average gas price for the chain accordingly
 func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
@@ -1003,11 +1281,87 @@ func (b *Blockchain) writeBody(block *types.Block) error {
 	return nil
 }
 
+// Rewind sets the head of the chain back to the block with the given number,
+// whose state becomes the latest one. The blocks after it are no longer canonical
+// and their transactions are no longer found, a reorg event is dispatched for them
+func (b *Blockchain) Rewind(number uint64) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	current := b.Header()
+	if number > current.Number {
+		return ErrRewindAhead
+	}
+
+	if number == current.Number {
+		return nil
+	}
+
+	header, ok := b.GetHeaderByNumber(number)
+	if !ok {
+		return fmt.Errorf("block %d not found", number)
+	}
+
+	td, ok := b.readTotalDifficulty(header.Hash)
+	if !ok {
+		return fmt.Errorf("difficulty of block %d not found", number)
+	}
+
+	evnt := &Event{Source: "rewind"}
+
+	for n := current.Number; n > number; n-- {
+		old, ok := b.GetHeaderByNumber(n)
+		if !ok {
+			return fmt.Errorf("block %d not found", n)
+		}
+
+		// no block has the zero hash, the lookups and the number point to nothing
+		if body, ok := b.readBody(old.Hash); ok {
+			for _, txn := range body.Transactions {
+				if err := b.db.WriteTxLookup(txn.Hash, types.ZeroHash); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := b.db.WriteCanonicalHash(n, types.ZeroHash); err != nil {
+			return err
+		}
+
+		evnt.AddOldHeader(old)
+	}
+
+	if err := b.db.WriteHeadHash(header.Hash); err != nil {
+		return err
+	}
+
+	if err := b.db.WriteHeadNumber(header.Number); err != nil {
+		return err
+	}
+
+	b.setCurrentHeader(header, td)
+
+	evnt.Type = EventReorg
+	evnt.AddNewHeader(header)
+	evnt.SetDifficulty(td)
+
+	b.dispatchEvent(evnt)
+
+	b.logger.Info("chain rewound", "number", number, "hash", header.Hash, "removed", current.Number-number)
+
+	return nil
+}
+
 // ReadTxLookup returns the block hash using the transaction hash
 func (b *Blockchain) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	v, ok := b.db.ReadTxLookup(hash)
 
-	return v, ok
+	// the transactions of the rewound blocks point to the zero hash
+	return v, ok && v != types.ZeroHash
 }
 
 // recoverFromFieldsInBlock recovers 'from' fields in the transactions of the given block
@@ -1074,7 +1428,9 @@ func (b *Blockchain) verifyGasLimit(header *types.Header, parentHeader *types.He
 		diff *= -1
 	}
 
-	limit := parentHeader.GasLimit / BlockGasTargetDivisor
+	bounds := b.Config().GasLimitBounds
+
+	limit := parentHeader.GasLimit / bounds.GetDivisor()
 	if uint64(diff) > limit {
 		return fmt.Errorf(
 			"invalid gas limit, limit = %d, want %d +- %d",
@@ -1084,6 +1440,16 @@ func (b *Blockchain) verifyGasLimit(header *types.Header, parentHeader *types.He
 		)
 	}
 
+	if !bounds.Contains(header.GasLimit) {
+		return fmt.Errorf(
+			"%w, limit = %d, min = %d, max = %d",
+			ErrGasLimitOutOfBounds,
+			header.GasLimit,
+			bounds.Min,
+			bounds.Max,
+		)
+	}
+
 	return nil
 }
 
@@ -1350,6 +1716,23 @@ func (b *Blockchain) GetBlockByNumber(blockNumber uint64, full bool) (*types.Blo
 }
 
 // Close closes the DB connection
+// Close waits for the block write in flight, if any, and closes the storage.
+// The blocks are no longer written afterwards
 func (b *Blockchain) Close() error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	b.closed = true
+
 	return b.db.Close()
 }
+
+// KVSnapshot takes a consistent snapshot of the blockchain database
+func (b *Blockchain) KVSnapshot() (storage.KVSnapshot, error) {
+	snapshotter, ok := b.db.(storage.KVSnapshotter)
+	if !ok {
+		return nil, storage.ErrKVSnapshotNotSupported
+	}
+
+	return snapshotter.KVSnapshot()
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/hashicorp/go-hclog"
@@ -902,6 +903,7 @@ func TestCalculateGasLimit(t *testing.T) {
 	tests := []struct {
 		name             string
 		blockGasTarget   uint64
+		gasLimitBounds   *chain.GasLimitBounds
 		parentGasLimit   uint64
 		expectedGasLimit uint64
 	}{
@@ -935,6 +937,26 @@ func TestCalculateGasLimit(t *testing.T) {
 			parentGasLimit:   25000000,
 			expectedGasLimit: 25000000 - 25000000/1024 + 100,
 		},
+		{
+			name:             "should not increase next gas limit beyond the maximum",
+			blockGasTarget:   25000000,
+			gasLimitBounds:   &chain.GasLimitBounds{Max: 20000000 + 100},
+			parentGasLimit:   20000000,
+			expectedGasLimit: 20000000 + 100,
+		},
+		{
+			name:             "should increase next gas limit towards the minimum without target",
+			gasLimitBounds:   &chain.GasLimitBounds{Min: 21000000},
+			parentGasLimit:   20000000,
+			expectedGasLimit: 20000000/1024 + 20000000,
+		},
+		{
+			name:             "should move next gas limit by the bound divisor",
+			blockGasTarget:   25000000,
+			gasLimitBounds:   &chain.GasLimitBounds{Divisor: 512},
+			parentGasLimit:   20000000,
+			expectedGasLimit: 20000000/512 + 20000000,
+		},
 	}
 
 	for _, tt := range tests {
@@ -957,6 +979,7 @@ func TestCalculateGasLimit(t *testing.T) {
 
 			b.config.Params = &chain.Params{
 				BlockGasTarget: tt.blockGasTarget,
+				GasLimitBounds: tt.gasLimitBounds,
 			}
 
 			nextGas, err := b.CalculateGasLimit(1)
@@ -1177,6 +1200,32 @@ func TestBlockchain_VerifyBlockParent(t *testing.T) {
 
 		assert.Error(t, blockchain.verifyBlockParent(block))
 	})
+
+	t.Run("Block gas limit out of bounds", func(t *testing.T) {
+		t.Parallel()
+
+		blockchain, err := NewMockBlockchain(nil)
+		if err != nil {
+			t.Fatalf("unable to instantiate new blockchain, %v", err)
+		}
+
+		blockchain.config.Params = &chain.Params{
+			GasLimitBounds: &chain.GasLimitBounds{Min: 4096, Max: 5000},
+		}
+
+		parentHeader := emptyHeader.Copy()
+		parentHeader.GasLimit = 5000
+
+		header := &types.Header{
+			Number:   1,
+			GasLimit: parentHeader.GasLimit + 1, // The gas limit is within the allowed rate
+		}
+
+		assert.ErrorIs(t, blockchain.verifyGasLimit(header, parentHeader), ErrGasLimitOutOfBounds)
+
+		header.GasLimit = parentHeader.GasLimit - 1
+		assert.NoError(t, blockchain.verifyGasLimit(header, parentHeader))
+	})
 }
 
 // TestBlockchain_VerifyBlockBody makes sure that the block body is verified correctly
@@ -1330,3 +1379,147 @@ func TestBlockchain_VerifyBlockBody(t *testing.T) {
 		assert.ErrorIs(t, blockchain.verifyBlockBody(block), errUnableToExecute)
 	})
 }
+
+func TestBlockchain_Rewind(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+	b := NewTestBlockchain(t, headers)
+
+	sub := b.SubscribeEvents()
+	defer sub.Close()
+
+	assert.ErrorIs(t, b.Rewind(10), ErrRewindAhead)
+	assert.NoError(t, b.Rewind(5))
+
+	assert.Equal(t, headers[5].Hash, b.Header().Hash)
+
+	_, ok := b.GetHeaderByNumber(6)
+	assert.False(t, ok)
+
+	_, ok = b.GetBlockByNumber(9, false)
+	assert.False(t, ok)
+
+	evnt := sub.GetEvent()
+	assert.Equal(t, EventReorg, evnt.Type)
+	assert.Len(t, evnt.OldChain, 4)
+	assert.Len(t, evnt.NewChain, 1)
+	assert.Equal(t, headers[5].Hash, evnt.NewChain[0].Hash)
+
+	// the chain grows again from the block it has been rewound to
+	newHeaders := AppendNewTestheadersWithSeed(headers[:6], 2, 1)
+	assert.NoError(t, b.WriteHeaders(newHeaders[6:]))
+
+	header, ok := b.GetHeaderByNumber(7)
+	assert.True(t, ok)
+	assert.Equal(t, newHeaders[7].Hash, header.Hash)
+	assert.Equal(t, newHeaders[7].Hash, b.Header().Hash)
+}
+
+func TestBlockchain_Close(t *testing.T) {
+	t.Parallel()
+
+	b := NewTestBlockchain(t, NewTestHeaders(5))
+
+	// the blockchain is closed once the write in flight is done
+	b.writeLock.Lock()
+
+	closedCh := make(chan error, 1)
+
+	go func() {
+		closedCh <- b.Close()
+	}()
+
+	select {
+	case <-closedCh:
+		t.Fatal("the blockchain is closed during a write")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	b.writeLock.Unlock()
+
+	assert.NoError(t, <-closedCh)
+
+	// the blocks are no longer written
+	block := &types.Block{Header: &types.Header{Number: 5}}
+
+	assert.ErrorIs(t, b.WriteBlock(block, "test"), ErrClosed)
+	assert.ErrorIs(t, b.Rewind(1), ErrClosed)
+}
+
+func TestBlockchain_VerifyBlobGas(t *testing.T) {
+	t.Parallel()
+
+	b := &Blockchain{
+		config: &chain.Chain{
+			Params: &chain.Params{
+				Forks: &chain.Forks{BlobTx: chain.NewFork(2)},
+			},
+		},
+	}
+
+	uint64Ptr := func(n uint64) *uint64 {
+		return &n
+	}
+
+	parent := &types.Header{
+		Number:        1,
+		BlobGasUsed:   uint64Ptr(types.MaxBlobGasPerBlock),
+		ExcessBlobGas: uint64Ptr(types.TargetBlobGasPerBlock),
+	}
+
+	// the blob gas fields aren't set before the fork
+	assert.NoError(t, b.verifyBlobGas(&types.Header{Number: 1}, parent))
+	assert.ErrorIs(t, b.verifyBlobGas(parent, parent), ErrInvalidBlobGas)
+
+	// and must be set after it
+	assert.ErrorIs(t, b.verifyBlobGas(&types.Header{Number: 2}, parent), ErrInvalidBlobGas)
+
+	assert.NoError(t, b.verifyBlobGas(&types.Header{
+		Number:        2,
+		BlobGasUsed:   uint64Ptr(0),
+		ExcessBlobGas: uint64Ptr(2 * types.TargetBlobGasPerBlock),
+	}, parent))
+
+	assert.ErrorIs(t, b.verifyBlobGas(&types.Header{
+		Number:        2,
+		BlobGasUsed:   uint64Ptr(0),
+		ExcessBlobGas: uint64Ptr(types.TargetBlobGasPerBlock),
+	}, parent), ErrInvalidBlobGas)
+
+	assert.ErrorIs(t, b.verifyBlobGas(&types.Header{
+		Number:        2,
+		BlobGasUsed:   uint64Ptr(types.MaxBlobGasPerBlock + types.BlobGasPerBlob),
+		ExcessBlobGas: uint64Ptr(2 * types.TargetBlobGasPerBlock),
+	}, parent), ErrInvalidBlobGas)
+}
+
+type mockBlockGate struct {
+	haltAt uint64
+}
+
+func (m *mockBlockGate) CheckBlock(number uint64) error {
+	if number >= m.haltAt {
+		return errors.New("unsupported upgrade")
+	}
+
+	return nil
+}
+
+func TestBlockchain_CheckBlockGates(t *testing.T) {
+	t.Parallel()
+
+	b := NewTestBlockchain(t, nil)
+
+	// the blocks are accepted without gates
+	assert.NoError(t, b.CheckBlockGates(100))
+
+	b.AddBlockGate(&mockBlockGate{haltAt: 200})
+	b.AddBlockGate(&mockBlockGate{haltAt: 100})
+
+	assert.NoError(t, b.CheckBlockGates(99))
+	assert.Error(t, b.CheckBlockGates(100))
+
+	// the refused blocks are refused before their verification
+	assert.Error(t, b.verifyBlock(&types.Block{Header: &types.Header{Number: 100}}))
+}
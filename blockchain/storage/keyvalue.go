@@ -39,6 +39,12 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// STATE_DIFF is the prefix for the state diffs of the blocks
+	STATE_DIFF = []byte("t")
+
+	// BLOB_SIDECARS is the prefix for the sidecars of the blob transactions of the blocks
+	BLOB_SIDECARS = []byte("x")
 )
 
 // Sub-prefixes
@@ -55,6 +61,7 @@ type KV interface {
 	Close() error
 	Set(p []byte, v []byte) error
 	Get(p []byte) ([]byte, bool, error)
+	Delete(p []byte) error
 }
 
 // KeyValueStorage is a generic storage for kv databases
@@ -238,6 +245,43 @@ func (s *KeyValueStorage) ReadReceipts(hash types.Hash) ([]*types.Receipt, error
 	return *receipts, err
 }
 
+// STATE DIFFS //
+
+// WriteStateDiff writes the state diff of the block
+func (s *KeyValueStorage) WriteStateDiff(hash types.Hash, diff *types.StateDiff) error {
+	return s.writeRLP(STATE_DIFF, hash.Bytes(), diff)
+}
+
+// ReadStateDiff reads the state diff of the block
+func (s *KeyValueStorage) ReadStateDiff(hash types.Hash) (*types.StateDiff, error) {
+	diff := &types.StateDiff{}
+	err := s.readRLP(STATE_DIFF, hash.Bytes(), diff)
+
+	return diff, err
+}
+
+// BLOB SIDECARS //
+
+// WriteBlobSidecars writes the sidecars of the blob transactions of the block
+func (s *KeyValueStorage) WriteBlobSidecars(hash types.Hash, sidecars []*types.BlobSidecar) error {
+	ss := types.BlobSidecars(sidecars)
+
+	return s.writeRLP(BLOB_SIDECARS, hash.Bytes(), &ss)
+}
+
+// ReadBlobSidecars reads the sidecars of the blob transactions of the block
+func (s *KeyValueStorage) ReadBlobSidecars(hash types.Hash) ([]*types.BlobSidecar, error) {
+	sidecars := &types.BlobSidecars{}
+	err := s.readRLP(BLOB_SIDECARS, hash.Bytes(), sidecars)
+
+	return *sidecars, err
+}
+
+// DeleteBlobSidecars deletes the sidecars of the blob transactions of the block
+func (s *KeyValueStorage) DeleteBlobSidecars(hash types.Hash) error {
+	return s.remove(BLOB_SIDECARS, hash.Bytes())
+}
+
 // TX LOOKUP //
 
 // WriteTxLookup maps the transaction hash to the block hash
@@ -335,6 +379,12 @@ func (s *KeyValueStorage) set(p []byte, k []byte, v []byte) error {
 	return s.db.Set(p, v)
 }
 
+func (s *KeyValueStorage) remove(p []byte, k []byte) error {
+	p = append(p, k...)
+
+	return s.db.Delete(p)
+}
+
 func (s *KeyValueStorage) get(p []byte, k []byte) ([]byte, bool) {
 	p = append(p, k...)
 	data, ok, err := s.db.Get(p)
@@ -0,0 +1,30 @@
+package storage
+
+import "errors"
+
+var ErrKVSnapshotNotSupported = errors.New("the kv storage doesn't support snapshots")
+
+// KVSnapshot is a consistent read-only view of a kv storage at the time it was taken
+type KVSnapshot interface {
+	// Iterate calls the handler for every entry in the key order, the key and the value
+	// are only valid during the call
+	Iterate(handler func(key, value []byte) error) error
+
+	// Release releases the resources held by the snapshot
+	Release()
+}
+
+// KVSnapshotter is implemented by the kv storages able to take consistent snapshots
+type KVSnapshotter interface {
+	KVSnapshot() (KVSnapshot, error)
+}
+
+// KVSnapshot takes a snapshot of the underlying kv storage
+func (s *KeyValueStorage) KVSnapshot() (KVSnapshot, error) {
+	snapshotter, ok := s.db.(KVSnapshotter)
+	if !ok {
+		return nil, ErrKVSnapshotNotSupported
+	}
+
+	return snapshotter.KVSnapshot()
+}
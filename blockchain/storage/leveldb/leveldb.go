@@ -59,7 +59,51 @@ func (l *levelDBKV) Get(p []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+// Delete removes the key-value pair from leveldb storage
+func (l *levelDBKV) Delete(p []byte) error {
+	return l.db.Delete(p, nil)
+}
+
 // Close closes the leveldb storage instance
 func (l *levelDBKV) Close() error {
 	return l.db.Close()
 }
+
+// KVSnapshot takes a snapshot of the leveldb storage
+func (l *levelDBKV) KVSnapshot() (storage.KVSnapshot, error) {
+	return NewKVSnapshot(l.db)
+}
+
+// NewKVSnapshot takes a snapshot of the leveldb database
+func NewKVSnapshot(db *leveldb.DB) (storage.KVSnapshot, error) {
+	snapshot, err := db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return &levelDBSnapshot{snapshot}, nil
+}
+
+// levelDBSnapshot is the leveldb implementation of the kv snapshot
+type levelDBSnapshot struct {
+	snapshot *leveldb.Snapshot
+}
+
+// Iterate walks the entries of the snapshot in the key order
+func (l *levelDBSnapshot) Iterate(handler func(key, value []byte) error) error {
+	iter := l.snapshot.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := handler(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+// Release releases the snapshot
+func (l *levelDBSnapshot) Release() {
+	l.snapshot.Release()
+}
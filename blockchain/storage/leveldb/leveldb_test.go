@@ -37,3 +37,49 @@ func newStorage(t *testing.T) (storage.Storage, func()) {
 func TestStorage(t *testing.T) {
 	storage.TestStorage(t, newStorage)
 }
+
+func TestStorage_KVSnapshot(t *testing.T) {
+	s, closeFn := newStorage(t)
+	defer closeFn()
+
+	kv, ok := s.(*storage.KeyValueStorage)
+	if !ok {
+		t.Fatal("unexpected storage type")
+	}
+
+	if err := s.WriteHeadNumber(1); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := kv.KVSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer snapshot.Release()
+
+	// the writes after the snapshot are not part of it
+	if err := s.WriteHeadNumber(2); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := map[string][]byte{}
+
+	if err := snapshot.Iterate(func(key, value []byte) error {
+		entries[string(key)] = append([]byte{}, value...)
+
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+
+	for _, value := range entries {
+		if value[len(value)-1] != 1 {
+			t.Fatalf("expected the head number 1, got %x", value)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package memory
 
 import (
+	"sort"
+
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/hashicorp/go-hclog"
@@ -33,6 +35,50 @@ func (m *memoryKV) Get(p []byte) ([]byte, bool, error) {
 	return v, true, nil
 }
 
+func (m *memoryKV) Delete(p []byte) error {
+	delete(m.db, hex.EncodeToHex(p))
+
+	return nil
+}
+
 func (m *memoryKV) Close() error {
 	return nil
 }
+
+// KVSnapshot copies the entries of the storage
+func (m *memoryKV) KVSnapshot() (storage.KVSnapshot, error) {
+	snapshot := &memorySnapshot{
+		keys:   make([]string, 0, len(m.db)),
+		values: make(map[string][]byte, len(m.db)),
+	}
+
+	for k, v := range m.db {
+		key := string(hex.MustDecodeHex(k))
+
+		snapshot.keys = append(snapshot.keys, key)
+		snapshot.values[key] = v
+	}
+
+	sort.Strings(snapshot.keys)
+
+	return snapshot, nil
+}
+
+// memorySnapshot is a copy of the in memory storage
+type memorySnapshot struct {
+	keys   []string
+	values map[string][]byte
+}
+
+func (m *memorySnapshot) Iterate(handler func(key, value []byte) error) error {
+	for _, key := range m.keys {
+		if err := handler([]byte(key), m.values[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *memorySnapshot) Release() {
+}
@@ -34,6 +34,13 @@ type Storage interface {
 	WriteReceipts(hash types.Hash, receipts []*types.Receipt) error
 	ReadReceipts(hash types.Hash) ([]*types.Receipt, error)
 
+	WriteStateDiff(hash types.Hash, diff *types.StateDiff) error
+	ReadStateDiff(hash types.Hash) (*types.StateDiff, error)
+
+	WriteBlobSidecars(hash types.Hash, sidecars []*types.BlobSidecar) error
+	ReadBlobSidecars(hash types.Hash) ([]*types.BlobSidecar, error)
+	DeleteBlobSidecars(hash types.Hash) error
+
 	WriteTxLookup(hash types.Hash, blockHash types.Hash) error
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
 
@@ -48,6 +48,12 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("", func(t *testing.T) {
+		testStateDiff(t, m)
+	})
+	t.Run("", func(t *testing.T) {
+		testBlobSidecars(t, m)
+	})
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -393,6 +399,95 @@ func testReceipts(t *testing.T, m PlaceholderStorage) {
 	assert.True(t, reflect.DeepEqual(receipts, found))
 }
 
+func testStateDiff(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	diff := &types.StateDiff{
+		Accounts: []*types.AccountDiff{
+			{
+				Address:      addr1,
+				PrevNonce:    1,
+				Nonce:        2,
+				PrevBalance:  big.NewInt(100),
+				Balance:      big.NewInt(90),
+				PrevCodeHash: hash1,
+				CodeHash:     hash1,
+				Storage: []*types.StorageDiff{
+					{
+						Key:       hash1,
+						PrevValue: types.Hash{},
+						Value:     hash2,
+					},
+				},
+			},
+			{
+				Address:      addr2,
+				Created:      true,
+				PrevBalance:  big.NewInt(0),
+				Balance:      big.NewInt(10),
+				PrevCodeHash: hash2,
+				CodeHash:     hash2,
+			},
+		},
+	}
+
+	if err := s.WriteStateDiff(hash1, diff); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := s.ReadStateDiff(hash1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, diff, found)
+
+	_, err = s.ReadStateDiff(hash2)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func testBlobSidecars(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	sidecars := []*types.BlobSidecar{
+		{
+			Blobs:       [][]byte{{0x1}},
+			Commitments: [][]byte{{0x2}},
+			Proofs:      [][]byte{{0x3}},
+		},
+		{
+			Blobs:       [][]byte{},
+			Commitments: [][]byte{},
+			Proofs:      [][]byte{},
+		},
+	}
+
+	if err := s.WriteBlobSidecars(hash1, sidecars); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := s.ReadBlobSidecars(hash1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, sidecars, found)
+
+	// the sidecars are pruned after a while
+	if err := s.DeleteBlobSidecars(hash1); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.ReadBlobSidecars(hash1)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
 func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 	t.Helper()
 
@@ -467,6 +562,11 @@ type writeSnapshotDelegate func(types.Hash, []byte) error
 type readSnapshotDelegate func(types.Hash) ([]byte, bool)
 type writeReceiptsDelegate func(types.Hash, []*types.Receipt) error
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
+type writeStateDiffDelegate func(types.Hash, *types.StateDiff) error
+type readStateDiffDelegate func(types.Hash) (*types.StateDiff, error)
+type writeBlobSidecarsDelegate func(types.Hash, []*types.BlobSidecar) error
+type readBlobSidecarsDelegate func(types.Hash) ([]*types.BlobSidecar, error)
+type deleteBlobSidecarsDelegate func(types.Hash) error
 type writeTxLookupDelegate func(types.Hash, types.Hash) error
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
 type closeDelegate func() error
@@ -489,6 +589,11 @@ type MockStorage struct {
 	readBodyFn             readBodyDelegate
 	writeReceiptsFn        writeReceiptsDelegate
 	readReceiptsFn         readReceiptsDelegate
+	writeStateDiffFn       writeStateDiffDelegate
+	readStateDiffFn        readStateDiffDelegate
+	writeBlobSidecarsFn    writeBlobSidecarsDelegate
+	readBlobSidecarsFn     readBlobSidecarsDelegate
+	deleteBlobSidecarsFn   deleteBlobSidecarsDelegate
 	writeTxLookupFn        writeTxLookupDelegate
 	readTxLookupFn         readTxLookupDelegate
 	closeFn                closeDelegate
@@ -702,6 +807,66 @@ func (m *MockStorage) HookReadReceipts(fn readReceiptsDelegate) {
 	m.readReceiptsFn = fn
 }
 
+func (m *MockStorage) WriteStateDiff(hash types.Hash, diff *types.StateDiff) error {
+	if m.writeStateDiffFn != nil {
+		return m.writeStateDiffFn(hash, diff)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteStateDiff(fn writeStateDiffDelegate) {
+	m.writeStateDiffFn = fn
+}
+
+func (m *MockStorage) ReadStateDiff(hash types.Hash) (*types.StateDiff, error) {
+	if m.readStateDiffFn != nil {
+		return m.readStateDiffFn(hash)
+	}
+
+	return &types.StateDiff{}, nil
+}
+
+func (m *MockStorage) HookReadStateDiff(fn readStateDiffDelegate) {
+	m.readStateDiffFn = fn
+}
+
+func (m *MockStorage) WriteBlobSidecars(hash types.Hash, sidecars []*types.BlobSidecar) error {
+	if m.writeBlobSidecarsFn != nil {
+		return m.writeBlobSidecarsFn(hash, sidecars)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteBlobSidecars(fn writeBlobSidecarsDelegate) {
+	m.writeBlobSidecarsFn = fn
+}
+
+func (m *MockStorage) ReadBlobSidecars(hash types.Hash) ([]*types.BlobSidecar, error) {
+	if m.readBlobSidecarsFn != nil {
+		return m.readBlobSidecarsFn(hash)
+	}
+
+	return []*types.BlobSidecar{}, nil
+}
+
+func (m *MockStorage) HookReadBlobSidecars(fn readBlobSidecarsDelegate) {
+	m.readBlobSidecarsFn = fn
+}
+
+func (m *MockStorage) DeleteBlobSidecars(hash types.Hash) error {
+	if m.deleteBlobSidecarsFn != nil {
+		return m.deleteBlobSidecarsFn(hash)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookDeleteBlobSidecars(fn deleteBlobSidecarsDelegate) {
+	m.deleteBlobSidecarsFn = fn
+}
+
 func (m *MockStorage) WriteTxLookup(hash types.Hash, blockHash types.Hash) error {
 	if m.writeTxLookupFn != nil {
 		return m.writeTxLookupFn(hash, blockHash)
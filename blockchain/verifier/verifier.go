@@ -0,0 +1,461 @@
+package verifier
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Kind is the kind of the chain data a corruption is found in
+type Kind string
+
+const (
+	KindCanonical Kind = "canonical"
+	KindHeader    Kind = "header"
+	KindBody      Kind = "body"
+	KindTxLookup  Kind = "txlookup"
+	KindReceipts  Kind = "receipts"
+	KindState     Kind = "state"
+)
+
+var (
+	errNoAnchor      = errors.New("no intact local hash to check the re-fetched block against")
+	errNotRefetched  = errors.New("the receipts are regenerated by re-executing the block, not re-fetched")
+	errStateNotFixed = errors.New("the state is regenerated by re-executing the blocks, not re-fetched")
+)
+
+// Corruption is an inconsistency found in the chain data
+type Corruption struct {
+	Number uint64 `json:"number"`
+	Kind   Kind   `json:"kind"`
+	Key    string `json:"key"`
+	Reason string `json:"reason"`
+	Healed bool   `json:"healed"`
+
+	// HealError is the reason the corruption couldn't be healed, if healing was requested
+	HealError string `json:"healError,omitempty"`
+}
+
+// Report is the result of the verification of a block range
+type Report struct {
+	From        uint64        `json:"from"`
+	To          uint64        `json:"to"`
+	StateNodes  int           `json:"stateNodes"`
+	Corruptions []*Corruption `json:"corruptions"`
+}
+
+// BlockFetcher fetches the blocks of the canonical chain from a healthy peer
+type BlockFetcher interface {
+	GetBlockByNumber(number uint64) (*types.Block, error)
+}
+
+// HeaderHashFunc calculates the hash of the header as done by the consensus of the chain
+type HeaderHashFunc func(header *types.Header) (types.Hash, error)
+
+// Verifier walks the stored chain data and checks it against the hashes and roots of the headers
+type Verifier struct {
+	logger       hclog.Logger
+	storage      storage.Storage
+	stateStorage itrie.Storage
+	hashHeader   HeaderHashFunc
+
+	// fetcher re-fetches the corrupted blocks, no healing is done if nil
+	fetcher BlockFetcher
+}
+
+// NewVerifier creates the verifier of the chain data
+func NewVerifier(
+	logger hclog.Logger,
+	storage storage.Storage,
+	stateStorage itrie.Storage,
+	hashHeader HeaderHashFunc,
+	fetcher BlockFetcher,
+) *Verifier {
+	return &Verifier{
+		logger:       logger.Named("verifier"),
+		storage:      storage,
+		stateStorage: stateStorage,
+		hashHeader:   hashHeader,
+		fetcher:      fetcher,
+	}
+}
+
+// Verify checks the headers, bodies, transaction lookups, receipts and state roots
+// of the blocks in the range. The full state trie of the last block is walked if fullState is set
+func (v *Verifier) Verify(from, to uint64, fullState bool) *Report {
+	report := &Report{
+		From:        from,
+		To:          to,
+		Corruptions: []*Corruption{},
+	}
+
+	for number := from; number <= to; number++ {
+		report.Corruptions = append(report.Corruptions, v.verifyBlock(number)...)
+
+		if number%1000 == 0 {
+			v.logger.Info("verified blocks", "number", number, "corruptions", len(report.Corruptions))
+		}
+
+		// avoid the overflow on the last block
+		if number == to {
+			break
+		}
+	}
+
+	if fullState {
+		report.StateNodes, report.Corruptions = v.verifyFullState(to, report.Corruptions)
+	}
+
+	return report
+}
+
+// verifyBlock verifies the chain data of the block and heals the corruptions if possible
+func (v *Verifier) verifyBlock(number uint64) []*Corruption {
+	corruptions, parentCorruption := v.verifyHeader(number)
+
+	// the body and receipts are stored by the header hash, they're checked once it's healed
+	if len(corruptions) > 0 && !v.heal(number, corruptions) {
+		return corruptions
+	}
+
+	if parentCorruption != nil {
+		// the intact header is the anchor of its parent
+		v.heal(number-1, []*Corruption{parentCorruption})
+		corruptions = append(corruptions, parentCorruption)
+	}
+
+	hash, _ := v.storage.ReadCanonicalHash(number)
+
+	header, err := v.storage.ReadHeader(hash)
+	if err != nil {
+		return corruptions
+	}
+
+	if number == 0 {
+		// the genesis has no body and receipts
+		return append(corruptions, v.verifyStateRoot(header)...)
+	}
+
+	bodyCorruptions := v.verifyBody(header, hash)
+	if len(bodyCorruptions) > 0 {
+		v.heal(number, bodyCorruptions)
+		corruptions = append(corruptions, bodyCorruptions...)
+	}
+
+	corruptions = append(corruptions, v.verifyReceipts(header, hash)...)
+
+	return append(corruptions, v.verifyStateRoot(header)...)
+}
+
+// verifyHeader checks the canonical hash of the number matches the hash of the stored header.
+// The canonical hash of the parent is checked against the parent hash of the intact header
+func (v *Verifier) verifyHeader(number uint64) ([]*Corruption, *Corruption) {
+	hash, ok := v.storage.ReadCanonicalHash(number)
+	if !ok {
+		return []*Corruption{newCorruption(number, KindCanonical, canonicalKey(number), "canonical hash missing")}, nil
+	}
+
+	header, err := v.storage.ReadHeader(hash)
+	if err != nil {
+		return []*Corruption{
+			newCorruption(number, KindHeader, dbKey(storage.HEADER, hash), "header unreadable: %v", err),
+		}, nil
+	}
+
+	computed, err := v.hashHeader(header)
+	if err != nil {
+		return []*Corruption{
+			newCorruption(number, KindHeader, dbKey(storage.HEADER, hash), "header hash failed: %v", err),
+		}, nil
+	}
+
+	corruptions := []*Corruption{}
+
+	if computed != hash {
+		corruptions = append(corruptions,
+			newCorruption(number, KindHeader, dbKey(storage.HEADER, hash), "header hashes to %s", computed))
+	}
+
+	if header.Number != number {
+		corruptions = append(corruptions,
+			newCorruption(number, KindHeader, dbKey(storage.HEADER, hash), "header has number %d", header.Number))
+	}
+
+	if len(corruptions) > 0 || number == 0 {
+		return corruptions, nil
+	}
+
+	if parentHash, ok := v.storage.ReadCanonicalHash(number - 1); ok && parentHash != header.ParentHash {
+		return corruptions, newCorruption(number-1, KindCanonical, canonicalKey(number-1),
+			"canonical hash %s differs from the parent hash %s of the next block", parentHash, header.ParentHash)
+	}
+
+	return corruptions, nil
+}
+
+// verifyBody checks the body matches the roots of the header and the transaction lookups
+func (v *Verifier) verifyBody(header *types.Header, hash types.Hash) []*Corruption {
+	body, err := v.storage.ReadBody(hash)
+	if err != nil {
+		return []*Corruption{newCorruption(header.Number, KindBody, dbKey(storage.BODY, hash), "body unreadable: %v", err)}
+	}
+
+	corruptions := []*Corruption{}
+
+	if root := buildroot.CalculateTransactionsRoot(body.Transactions); root != header.TxRoot {
+		corruptions = append(corruptions, newCorruption(header.Number, KindBody, dbKey(storage.BODY, hash),
+			"transactions root %s differs from the header %s", root, header.TxRoot))
+	}
+
+	if root := buildroot.CalculateUncleRoot(body.Uncles); root != header.Sha3Uncles {
+		corruptions = append(corruptions, newCorruption(header.Number, KindBody, dbKey(storage.BODY, hash),
+			"uncles root %s differs from the header %s", root, header.Sha3Uncles))
+	}
+
+	if len(corruptions) > 0 {
+		// the transaction hashes can't be trusted
+		return corruptions
+	}
+
+	for _, tx := range body.Transactions {
+		blockHash, ok := v.storage.ReadTxLookup(tx.Hash)
+		if ok && blockHash == hash {
+			continue
+		}
+
+		corruption := newCorruption(header.Number, KindTxLookup, dbKey(storage.TX_LOOKUP_PREFIX, tx.Hash),
+			"transaction lookup of %s missing or pointing to %s", tx.Hash, blockHash)
+
+		// the lookup is rebuilt from the intact body
+		if v.healing() {
+			if err := v.storage.WriteTxLookup(tx.Hash, hash); err != nil {
+				corruption.HealError = err.Error()
+			} else {
+				corruption.Healed = true
+			}
+		}
+
+		corruptions = append(corruptions, corruption)
+	}
+
+	return corruptions
+}
+
+// verifyReceipts checks the receipts match the receipts root of the header
+func (v *Verifier) verifyReceipts(header *types.Header, hash types.Hash) []*Corruption {
+	receipts, err := v.storage.ReadReceipts(hash)
+	if err != nil {
+		return v.unhealable(
+			newCorruption(header.Number, KindReceipts, dbKey(storage.RECEIPTS, hash), "receipts unreadable: %v", err),
+			errNotRefetched,
+		)
+	}
+
+	if root := buildroot.CalculateReceiptsRoot(receipts); root != header.ReceiptsRoot {
+		return v.unhealable(
+			newCorruption(header.Number, KindReceipts, dbKey(storage.RECEIPTS, hash),
+				"receipts root %s differs from the header %s", root, header.ReceiptsRoot),
+			errNotRefetched,
+		)
+	}
+
+	return nil
+}
+
+// verifyStateRoot checks the root node of the state of the block is stored
+func (v *Verifier) verifyStateRoot(header *types.Header) []*Corruption {
+	if header.StateRoot == types.EmptyRootHash {
+		return nil
+	}
+
+	if _, ok := v.stateStorage.Get(header.StateRoot.Bytes()); !ok {
+		return v.unhealable(
+			newCorruption(header.Number, KindState, header.StateRoot.String(), "%v", itrie.ErrTrieNodeMissing),
+			errStateNotFixed,
+		)
+	}
+
+	return nil
+}
+
+// verifyFullState walks the whole state trie of the block
+func (v *Verifier) verifyFullState(number uint64, corruptions []*Corruption) (int, []*Corruption) {
+	hash, ok := v.storage.ReadCanonicalHash(number)
+	if !ok {
+		return 0, corruptions
+	}
+
+	header, err := v.storage.ReadHeader(hash)
+	if err != nil {
+		return 0, corruptions
+	}
+
+	v.logger.Info("verifying the state", "number", number, "root", header.StateRoot)
+
+	nodes := itrie.NewState(v.stateStorage).VerifyState(header.StateRoot, func(key []byte, err error) {
+		corruptions = append(corruptions, v.unhealable(
+			newCorruption(number, KindState, fmt.Sprintf("0x%x", key), "%v", err),
+			errStateNotFixed,
+		)...)
+	})
+
+	return nodes, corruptions
+}
+
+// heal re-fetches the block from the peer and rewrites its header, body and transaction lookups.
+// It returns true if the corruptions were healed
+func (v *Verifier) heal(number uint64, corruptions []*Corruption) bool {
+	if !v.healing() {
+		return false
+	}
+
+	err := v.refetchBlock(number)
+
+	for _, corruption := range corruptions {
+		if err != nil {
+			corruption.HealError = err.Error()
+		} else {
+			corruption.Healed = true
+		}
+	}
+
+	if err != nil {
+		v.logger.Warn("unable to heal the block", "number", number, "err", err)
+
+		return false
+	}
+
+	v.logger.Info("healed the block", "number", number)
+
+	return true
+}
+
+// refetchBlock fetches the block from the peer, checks it against the local chain
+// and writes it
+func (v *Verifier) refetchBlock(number uint64) error {
+	block, err := v.fetcher.GetBlockByNumber(number)
+	if err != nil {
+		return err
+	}
+
+	header := block.Header
+
+	if header.Hash, err = v.hashHeader(header); err != nil {
+		return err
+	}
+
+	if header.Number != number {
+		return fmt.Errorf("re-fetched block has number %d", header.Number)
+	}
+
+	if buildroot.CalculateTransactionsRoot(block.Transactions) != header.TxRoot ||
+		buildroot.CalculateUncleRoot(block.Uncles) != header.Sha3Uncles {
+		return errors.New("re-fetched block body doesn't match its header")
+	}
+
+	expected, err := v.anchorHash(number)
+	if err != nil {
+		return err
+	}
+
+	if expected != header.Hash {
+		return fmt.Errorf("re-fetched block hash %s differs from the local chain %s", header.Hash, expected)
+	}
+
+	if err := v.storage.WriteHeader(header); err != nil {
+		return err
+	}
+
+	if err := v.storage.WriteCanonicalHash(number, header.Hash); err != nil {
+		return err
+	}
+
+	// the senders are recovered by the blockchain when the body is read
+	if err := v.storage.WriteBody(header.Hash, block.Body()); err != nil {
+		return err
+	}
+
+	for _, tx := range block.Transactions {
+		if err := v.storage.WriteTxLookup(tx.Hash, header.Hash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// anchorHash returns the hash the block of the number is expected to have by the local chain,
+// the parent hash of the next header if it's intact, the canonical hash if its own header is intact otherwise
+func (v *Verifier) anchorHash(number uint64) (types.Hash, error) {
+	if child, ok := v.intactHeader(number + 1); ok {
+		return child.ParentHash, nil
+	}
+
+	if header, ok := v.intactHeader(number); ok {
+		return header.Hash, nil
+	}
+
+	return types.ZeroHash, errNoAnchor
+}
+
+// intactHeader returns the canonical header of the number if it matches its hash
+func (v *Verifier) intactHeader(number uint64) (*types.Header, bool) {
+	hash, ok := v.storage.ReadCanonicalHash(number)
+	if !ok {
+		return nil, false
+	}
+
+	header, err := v.storage.ReadHeader(hash)
+	if err != nil {
+		return nil, false
+	}
+
+	if computed, err := v.hashHeader(header); err != nil || computed != hash {
+		return nil, false
+	}
+
+	header.Hash = hash
+
+	return header, true
+}
+
+// healing returns true if the corruptions are healed
+func (v *Verifier) healing() bool {
+	return v.fetcher != nil
+}
+
+// unhealable marks the corruption as not healable by re-fetching if healing is requested
+func (v *Verifier) unhealable(corruption *Corruption, reason error) []*Corruption {
+	if v.healing() {
+		corruption.HealError = reason.Error()
+	}
+
+	return []*Corruption{corruption}
+}
+
+func newCorruption(number uint64, kind Kind, key string, format string, args ...interface{}) *Corruption {
+	return &Corruption{
+		Number: number,
+		Kind:   kind,
+		Key:    key,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// dbKey returns the hex encoded key of the entry in the blockchain storage
+func dbKey(prefix []byte, hash types.Hash) string {
+	return fmt.Sprintf("0x%x%x", prefix, hash.Bytes())
+}
+
+func canonicalKey(number uint64) string {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, number)
+
+	return fmt.Sprintf("0x%x%x", storage.CANONICAL, key)
+}
@@ -0,0 +1,209 @@
+package verifier
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFetcher struct {
+	blocks map[uint64]*types.Block
+}
+
+func (m *mockFetcher) GetBlockByNumber(number uint64) (*types.Block, error) {
+	block, ok := m.blocks[number]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+
+	// the re-fetched block is decoded from the peer response
+	fetched := &types.Block{}
+	if err := fetched.UnmarshalRLP(block.MarshalRLP()); err != nil {
+		return nil, err
+	}
+
+	return fetched, nil
+}
+
+func hashHeader(header *types.Header) (types.Hash, error) {
+	return types.HeaderHash(header), nil
+}
+
+func newTestBlock(t *testing.T, parent *types.Header, seed int64) *types.Block {
+	t.Helper()
+
+	to := types.StringToAddress("1")
+	txs := []*types.Transaction{
+		{Nonce: uint64(seed), GasPrice: big.NewInt(1), Gas: 21000, To: &to, Value: big.NewInt(seed)},
+	}
+
+	for _, tx := range txs {
+		tx.ComputeHash()
+	}
+
+	header := &types.Header{
+		Sha3Uncles:   types.EmptyUncleHash,
+		StateRoot:    types.EmptyRootHash,
+		TxRoot:       buildroot.CalculateTransactionsRoot(txs),
+		ReceiptsRoot: buildroot.CalculateReceiptsRoot(newTestReceipts(txs)),
+		GasLimit:     uint64(seed),
+	}
+
+	if parent != nil {
+		header.ParentHash = parent.Hash
+		header.Number = parent.Number + 1
+	}
+
+	header.ComputeHash()
+
+	return &types.Block{Header: header, Transactions: txs}
+}
+
+func newTestReceipts(txs []*types.Transaction) []*types.Receipt {
+	receipts := make([]*types.Receipt, len(txs))
+
+	for i, tx := range txs {
+		receipts[i] = &types.Receipt{GasUsed: 21000, CumulativeGasUsed: uint64(i+1) * 21000, TxHash: tx.Hash}
+		receipts[i].SetStatus(types.ReceiptSuccess)
+	}
+
+	return receipts
+}
+
+// newTestChain writes a chain of the blocks to the storage
+func newTestChain(t *testing.T, length int) (storage.Storage, []*types.Block) {
+	t.Helper()
+
+	db, err := memory.NewMemoryStorage(hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	blocks := []*types.Block{{Header: (&types.Header{StateRoot: types.EmptyRootHash}).ComputeHash()}}
+
+	for i := 1; i < length; i++ {
+		blocks = append(blocks, newTestBlock(t, blocks[i-1].Header, int64(i)))
+	}
+
+	for _, block := range blocks {
+		writeTestBlock(t, db, block)
+	}
+
+	return db, blocks
+}
+
+func writeTestBlock(t *testing.T, db storage.Storage, block *types.Block) {
+	t.Helper()
+
+	require.NoError(t, db.WriteHeader(block.Header))
+	require.NoError(t, db.WriteCanonicalHash(block.Number(), block.Hash()))
+
+	if block.Number() == 0 {
+		return
+	}
+
+	require.NoError(t, db.WriteBody(block.Hash(), block.Body()))
+	require.NoError(t, db.WriteReceipts(block.Hash(), newTestReceipts(block.Transactions)))
+
+	for _, tx := range block.Transactions {
+		require.NoError(t, db.WriteTxLookup(tx.Hash, block.Hash()))
+	}
+}
+
+func kinds(corruptions []*Corruption) []Kind {
+	res := make([]Kind, len(corruptions))
+	for i, corruption := range corruptions {
+		res[i] = corruption.Kind
+	}
+
+	return res
+}
+
+func TestVerifier_Intact(t *testing.T) {
+	t.Parallel()
+
+	db, _ := newTestChain(t, 5)
+
+	report := NewVerifier(hclog.NewNullLogger(), db, itrie.NewMemoryStorage(), hashHeader, nil).Verify(0, 4, true)
+	assert.Empty(t, report.Corruptions)
+}
+
+func TestVerifier_Corruptions(t *testing.T) {
+	t.Parallel()
+
+	db, blocks := newTestChain(t, 5)
+
+	// the body of another block
+	require.NoError(t, db.WriteBody(blocks[2].Hash(), blocks[3].Body()))
+
+	// the lookup of a transaction is lost
+	require.NoError(t, db.WriteTxLookup(blocks[1].Transactions[0].Hash, types.ZeroHash))
+
+	// the receipts don't match
+	require.NoError(t, db.WriteReceipts(blocks[4].Hash(), []*types.Receipt{}))
+
+	report := NewVerifier(hclog.NewNullLogger(), db, itrie.NewMemoryStorage(), hashHeader, nil).Verify(1, 4, false)
+
+	assert.Equal(t, []Kind{KindTxLookup, KindBody, KindReceipts}, kinds(report.Corruptions))
+	assert.Equal(t, dbKey(storage.BODY, blocks[2].Hash()), report.Corruptions[1].Key)
+
+	for _, corruption := range report.Corruptions {
+		assert.False(t, corruption.Healed)
+		assert.Empty(t, corruption.HealError)
+	}
+}
+
+func TestVerifier_Heal(t *testing.T) {
+	t.Parallel()
+
+	db, blocks := newTestChain(t, 5)
+
+	fetcher := &mockFetcher{blocks: map[uint64]*types.Block{}}
+	for _, block := range blocks {
+		fetcher.blocks[block.Number()] = block
+	}
+
+	// the body of another block
+	require.NoError(t, db.WriteBody(blocks[2].Hash(), blocks[3].Body()))
+
+	// the canonical hash points to a block of another chain
+	fork := newTestBlock(t, blocks[0].Header, 100)
+	writeTestBlock(t, db, fork)
+
+	verifier := NewVerifier(hclog.NewNullLogger(), db, itrie.NewMemoryStorage(), hashHeader, fetcher)
+
+	report := verifier.Verify(1, 3, false)
+	require.Equal(t, []Kind{KindCanonical, KindBody}, kinds(report.Corruptions))
+
+	for _, corruption := range report.Corruptions {
+		assert.True(t, corruption.Healed)
+	}
+
+	hash, ok := db.ReadCanonicalHash(1)
+	require.True(t, ok)
+	assert.Equal(t, blocks[1].Hash(), hash)
+
+	body, err := db.ReadBody(blocks[2].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, blocks[2].Transactions[0].Hash, body.Transactions[0].Hash)
+
+	// the healed chain is intact
+	assert.Empty(t, verifier.Verify(0, 4, false).Corruptions)
+
+	// the state root of the last block is missing, the state isn't re-fetched
+	blocks[4].Header.StateRoot = types.StringToHash("1")
+	blocks[4].Header.ComputeHash()
+	writeTestBlock(t, db, blocks[4])
+
+	report = verifier.Verify(4, 4, false)
+	require.Equal(t, []Kind{KindState}, kinds(report.Corruptions))
+	assert.False(t, report.Corruptions[0].Healed)
+	assert.Equal(t, errStateNotFixed.Error(), report.Corruptions[0].HealError)
+}
@@ -1,8 +1,10 @@
 package chain
 
 import (
+	"encoding/json"
 	"math/big"
 
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -13,6 +15,184 @@ type Params struct {
 	Engine         map[string]interface{} `json:"engine"`
 	Whitelists     *Whitelists            `json:"whitelists,omitempty"`
 	BlockGasTarget uint64                 `json:"blockGasTarget"`
+
+	// GasLimitBounds bound the block gas limit the proposers move toward their target
+	GasLimitBounds *GasLimitBounds `json:"gasLimitBounds,omitempty"`
+
+	// PrivateNetworkKey is the hex encoded pre-shared key of a private network,
+	// only the nodes holding it can join the P2P network
+	PrivateNetworkKey string `json:"privateNetworkKey,omitempty"`
+
+	// WasmContracts are the addresses of the contracts run by the experimental WASM runtime,
+	// their code is set in the genesis allocation
+	WasmContracts []types.Address `json:"wasmContracts,omitempty"`
+
+	// TxOrdering is the policy ordering the transactions of the proposed blocks:
+	// price (default), fifo or fair
+	TxOrdering string `json:"txOrdering,omitempty"`
+
+	// EncryptedMempool enables the experimental encrypted mempool
+	EncryptedMempool *EncryptedMempool `json:"encryptedMempool,omitempty"`
+
+	// MaxCodeSize is the maximum size of a deployed contract code (EIP-170), DefaultMaxCodeSize if 0
+	MaxCodeSize uint64 `json:"maxCodeSize,omitempty"`
+
+	// MaxInitCodeSize is the maximum size of a contract creation code (EIP-3860), unlimited if 0
+	MaxInitCodeSize uint64 `json:"maxInitCodeSize,omitempty"`
+
+	// GasOverrides override the gas costs of opcodes and precompiled contracts,
+	// the genesis mix hash commits to them
+	GasOverrides *GasOverrides `json:"gasOverrides,omitempty"`
+
+	// FreeGas enables the free-gas mode of the consortium chains
+	FreeGas *FreeGas `json:"freeGas,omitempty"`
+
+	// Freeze enables the emergency freeze of the accounts voted by the governors
+	Freeze *Freeze `json:"freeze,omitempty"`
+
+	// Upgrades are the scheduled upgrades requiring a minimum version of the binaries
+	Upgrades []*Upgrade `json:"upgrades,omitempty"`
+}
+
+// Upgrade is a scheduled upgrade: the nodes whose binary is older than its version
+// stop producing and importing blocks at its height
+type Upgrade struct {
+	Name    string `json:"name"`
+	Block   uint64 `json:"block"`
+	Version string `json:"version"`
+}
+
+// FreeGas is the configuration of the free-gas mode: the transactions with a zero gas price are
+// accepted by every node, the gas they use is accounted per account and day in the gas quota contract
+type FreeGas struct {
+	// DailyGasQuota is the gas an account can use per day with zero gas price transactions,
+	// unlimited if 0
+	DailyGasQuota uint64 `json:"dailyGasQuota"`
+}
+
+// Freeze is the configuration of the emergency freeze of the enterprise chains: the governors vote
+// in the freeze contract to freeze or unfreeze an account, the transactions from and to
+// the frozen accounts are rejected
+type Freeze struct {
+	// Governors are the accounts voting the freezes
+	Governors []types.Address `json:"governors"`
+
+	// Threshold is the number of the governor votes freezing or unfreezing an account
+	Threshold uint64 `json:"threshold"`
+}
+
+// IsGovernor returns true if the account votes the freezes
+func (f *Freeze) IsGovernor(account types.Address) bool {
+	for _, governor := range f.Governors {
+		if governor == account {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GasLimitBounds are the protocol bounds of the block gas limit. Each proposer moves the gas limit
+// toward its block gas target by at most 1/Divisor of the parent gas limit, within Min and Max
+type GasLimitBounds struct {
+	// Min is the minimum block gas limit
+	Min uint64 `json:"min"`
+
+	// Max is the maximum block gas limit, unbounded if 0
+	Max uint64 `json:"max,omitempty"`
+
+	// Divisor bounds the change of the gas limit per block, DefaultGasLimitBoundDivisor if 0
+	Divisor uint64 `json:"divisor,omitempty"`
+}
+
+// DefaultGasLimitBoundDivisor is the bound divisor of the change of the gas limit per block
+const DefaultGasLimitBoundDivisor = 1024
+
+// GetDivisor returns the bound divisor of the change of the gas limit per block
+func (b *GasLimitBounds) GetDivisor() uint64 {
+	if b == nil || b.Divisor == 0 {
+		return DefaultGasLimitBoundDivisor
+	}
+
+	return b.Divisor
+}
+
+// Clamp returns the gas limit within the bounds
+func (b *GasLimitBounds) Clamp(gasLimit uint64) uint64 {
+	if b == nil {
+		return gasLimit
+	}
+
+	if gasLimit < b.Min {
+		return b.Min
+	}
+
+	if b.Max != 0 && gasLimit > b.Max {
+		return b.Max
+	}
+
+	return gasLimit
+}
+
+// Contains returns true if the gas limit is within the bounds
+func (b *GasLimitBounds) Contains(gasLimit uint64) bool {
+	return b.Clamp(gasLimit) == gasLimit
+}
+
+// DefaultMaxCodeSize is the EIP-170 limit of the size of a deployed contract code
+const DefaultMaxCodeSize = 24576
+
+// GetMaxCodeSize returns the maximum size of a deployed contract code
+func (p *Params) GetMaxCodeSize() uint64 {
+	if p.MaxCodeSize == 0 {
+		return DefaultMaxCodeSize
+	}
+
+	return p.MaxCodeSize
+}
+
+// EncryptedMempool is the configuration of the experimental encrypted mempool: the transactions
+// are encrypted to the aggregate key of the validators and decrypted by a threshold of them
+// once included, to be executed in the next block
+type EncryptedMempool struct {
+	// PublicKey is the hex encoded aggregate key the transactions are encrypted to
+	PublicKey string `json:"publicKey"`
+
+	// Threshold is the number of decryption shares decrypting a transaction
+	Threshold uint64 `json:"threshold"`
+
+	// PublicShares are the hex encoded public keys of the key shares, by share index,
+	// verifying the decryption shares
+	PublicShares []string `json:"publicShares"`
+}
+
+// GasOverrides are the gas costs of opcodes and precompiled contracts overridden chain-wide
+// from a fork block
+type GasOverrides struct {
+	// Block is the block the overrides are active from
+	Block uint64 `json:"block"`
+
+	// Opcodes are the constant gas costs of the opcodes, by mnemonic.
+	// The dynamic costs (memory expansion, copied words...) are left unchanged
+	Opcodes map[string]uint64 `json:"opcodes,omitempty"`
+
+	// Precompiles are the gas costs of the precompiled contracts, by address,
+	// replacing the cost computed from their input
+	Precompiles map[types.Address]uint64 `json:"precompiles,omitempty"`
+}
+
+// Active returns true if the overrides are active at the given block
+func (g *GasOverrides) Active(block uint64) bool {
+	return g != nil && block >= g.Block
+}
+
+// Hash returns the hash of the overrides the genesis mix hash is set to,
+// so that the nodes running different overrides don't share the genesis hash
+func (g *GasOverrides) Hash() types.Hash {
+	// the keys of the maps are sorted by the encoding
+	raw, _ := json.Marshal(g)
+
+	return types.BytesToHash(keccak.Keccak256(nil, raw))
 }
 
 func (p *Params) GetEngine() string {
@@ -39,6 +219,28 @@ type Forks struct {
 	EIP150         *Fork `json:"EIP150,omitempty"`
 	EIP158         *Fork `json:"EIP158,omitempty"`
 	EIP155         *Fork `json:"EIP155,omitempty"`
+
+	// SponsoredTx enables the transactions whose gas is paid by a sponsor
+	SponsoredTx *Fork `json:"sponsoredTx,omitempty"`
+
+	// BlobTx enables the blob-carrying transactions and the blob gas fields of the headers,
+	// it isn't enabled by default
+	BlobTx *Fork `json:"blobTx,omitempty"`
+
+	// EIP3529 reduces the gas refunds: no SELFDESTRUCT refund, a smaller refund
+	// for the cleared storage and a refund capped to a fifth of the gas used
+	EIP3529 *Fork `json:"EIP3529,omitempty"`
+
+	// EIP6780 only deletes the accounts calling SELFDESTRUCT in the transaction creating them,
+	// the other accounts only send their balance to the beneficiary
+	EIP6780 *Fork `json:"EIP6780,omitempty"`
+
+	// TxExpiry enables the transactions valid until a block, rejected after it
+	TxExpiry *Fork `json:"txExpiry,omitempty"`
+
+	// BLSProofOfPossession requires the BLS keys registered in the staking contract
+	// to be proven by a proof of possession, the keys registered before are only trusted at the deployments
+	BLSProofOfPossession *Fork `json:"blsProofOfPossession,omitempty"`
 }
 
 func (f *Forks) active(ff *Fork, block uint64) bool {
@@ -77,16 +279,46 @@ func (f *Forks) IsEIP155(block uint64) bool {
 	return f.active(f.EIP155, block)
 }
 
+func (f *Forks) IsSponsoredTx(block uint64) bool {
+	return f.active(f.SponsoredTx, block)
+}
+
+func (f *Forks) IsBlobTx(block uint64) bool {
+	return f.active(f.BlobTx, block)
+}
+
+func (f *Forks) IsEIP3529(block uint64) bool {
+	return f.active(f.EIP3529, block)
+}
+
+func (f *Forks) IsEIP6780(block uint64) bool {
+	return f.active(f.EIP6780, block)
+}
+
+func (f *Forks) IsTxExpiry(block uint64) bool {
+	return f.active(f.TxExpiry, block)
+}
+
+func (f *Forks) IsBLSProofOfPossession(block uint64) bool {
+	return f.active(f.BLSProofOfPossession, block)
+}
+
 func (f *Forks) At(block uint64) ForksInTime {
 	return ForksInTime{
-		Homestead:      f.active(f.Homestead, block),
-		Byzantium:      f.active(f.Byzantium, block),
-		Constantinople: f.active(f.Constantinople, block),
-		Petersburg:     f.active(f.Petersburg, block),
-		Istanbul:       f.active(f.Istanbul, block),
-		EIP150:         f.active(f.EIP150, block),
-		EIP158:         f.active(f.EIP158, block),
-		EIP155:         f.active(f.EIP155, block),
+		Homestead:            f.active(f.Homestead, block),
+		Byzantium:            f.active(f.Byzantium, block),
+		Constantinople:       f.active(f.Constantinople, block),
+		Petersburg:           f.active(f.Petersburg, block),
+		Istanbul:             f.active(f.Istanbul, block),
+		EIP150:               f.active(f.EIP150, block),
+		EIP158:               f.active(f.EIP158, block),
+		EIP155:               f.active(f.EIP155, block),
+		SponsoredTx:          f.active(f.SponsoredTx, block),
+		BlobTx:               f.active(f.BlobTx, block),
+		EIP3529:              f.active(f.EIP3529, block),
+		EIP6780:              f.active(f.EIP6780, block),
+		TxExpiry:             f.active(f.TxExpiry, block),
+		BLSProofOfPossession: f.active(f.BLSProofOfPossession, block),
 	}
 }
 
@@ -114,16 +346,25 @@ type ForksInTime struct {
 	Istanbul,
 	EIP150,
 	EIP158,
-	EIP155 bool
+	EIP155,
+	SponsoredTx,
+	BlobTx,
+	EIP3529,
+	EIP6780,
+	TxExpiry,
+	BLSProofOfPossession bool
 }
 
 var AllForksEnabled = &Forks{
-	Homestead:      NewFork(0),
-	EIP150:         NewFork(0),
-	EIP155:         NewFork(0),
-	EIP158:         NewFork(0),
-	Byzantium:      NewFork(0),
-	Constantinople: NewFork(0),
-	Petersburg:     NewFork(0),
-	Istanbul:       NewFork(0),
+	Homestead:            NewFork(0),
+	EIP150:               NewFork(0),
+	EIP155:               NewFork(0),
+	EIP158:               NewFork(0),
+	Byzantium:            NewFork(0),
+	Constantinople:       NewFork(0),
+	Petersburg:           NewFork(0),
+	Istanbul:             NewFork(0),
+	SponsoredTx:          NewFork(0),
+	TxExpiry:             NewFork(0),
+	BLSProofOfPossession: NewFork(0),
 }
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"reflect"
 	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 func TestParamsForks(t *testing.T) {
@@ -54,3 +56,37 @@ func TestParamsForksInTime(t *testing.T) {
 	expect("constantinople", ff.Constantinople, false)
 	expect("eip150", ff.EIP150, false)
 }
+
+func TestGasOverrides(t *testing.T) {
+	overrides := &GasOverrides{
+		Block:       10,
+		Opcodes:     map[string]uint64{"SHA3": 60, "ADD": 5},
+		Precompiles: map[types.Address]uint64{types.StringToAddress("1"): 6000},
+	}
+
+	if overrides.Active(9) || !overrides.Active(10) {
+		t.Fatal("overrides should be active from their block")
+	}
+
+	var none *GasOverrides
+	if none.Active(10) {
+		t.Fatal("missing overrides should never be active")
+	}
+
+	// the hash doesn't depend on the order of the maps
+	same := &GasOverrides{
+		Block:       10,
+		Opcodes:     map[string]uint64{"ADD": 5, "SHA3": 60},
+		Precompiles: map[types.Address]uint64{types.StringToAddress("1"): 6000},
+	}
+
+	if overrides.Hash() != same.Hash() {
+		t.Fatal("equal overrides should have the same hash")
+	}
+
+	same.Opcodes["ADD"] = 6
+
+	if overrides.Hash() == same.Hash() {
+		t.Fatal("different overrides should have different hashes")
+	}
+}
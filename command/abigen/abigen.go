@@ -0,0 +1,63 @@
+package abigen
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	abigenCmd := &cobra.Command{
+		Use:   "abigen",
+		Short: "Generates the typed Go bindings of a contract from its ABI",
+		Run:   runCommand,
+	}
+
+	setFlags(abigenCmd)
+	helper.SetRequiredFlags(abigenCmd, params.getRequiredFlags())
+
+	return abigenCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.abiPath,
+		abiPathFlag,
+		"",
+		"the path to the JSON ABI of the contract, or to its artifacts JSON",
+	)
+
+	cmd.Flags().StringVar(
+		&params.pkg,
+		packageFlag,
+		"",
+		"the package of the generated bindings",
+	)
+
+	cmd.Flags().StringVar(
+		&params.contract,
+		contractFlag,
+		"",
+		"the name of the contract, used as the name of the binding type",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputPath,
+		outputPathFlag,
+		"",
+		"the path to the generated Go file",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.generate(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,98 @@
+package abigen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/abi"
+)
+
+const (
+	abiPathFlag    = "abi"
+	packageFlag    = "package"
+	contractFlag   = "contract"
+	outputPathFlag = "output"
+)
+
+var (
+	errABINotFound = errors.New("abi field not found in the specified JSON")
+)
+
+var (
+	params = &abigenParams{}
+)
+
+type abigenParams struct {
+	abiPath    string
+	pkg        string
+	contract   string
+	outputPath string
+
+	source []byte
+}
+
+func (p *abigenParams) getRequiredFlags() []string {
+	return []string{
+		abiPathFlag,
+		packageFlag,
+		contractFlag,
+		outputPathFlag,
+	}
+}
+
+// loadABI reads the JSON ABI, or the contract artifacts JSON holding it in its abi field
+func (p *abigenParams) loadABI() ([]byte, error) {
+	raw, err := os.ReadFile(p.abiPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the ABI from %s: %w", p.abiPath, err)
+	}
+
+	// the ABI is a list, the artifacts an object
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		return raw, nil
+	}
+
+	var artifacts map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &artifacts); err != nil {
+		return nil, err
+	}
+
+	contractABI, ok := artifacts["abi"]
+	if !ok {
+		return nil, errABINotFound
+	}
+
+	return contractABI, nil
+}
+
+func (p *abigenParams) generate() error {
+	contractABI, err := p.loadABI()
+	if err != nil {
+		return err
+	}
+
+	source, err := abi.Generate(&abi.GenerateConfig{
+		Package:  p.pkg,
+		Contract: p.contract,
+		ABI:      contractABI,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate the bindings: %w", err)
+	}
+
+	p.source = source
+
+	return os.WriteFile(p.outputPath, source, 0600)
+}
+
+func (p *abigenParams) getResult() command.CommandResult {
+	return &AbigenResult{
+		Contract: p.contract,
+		Output:   p.outputPath,
+		Size:     len(p.source),
+	}
+}
@@ -0,0 +1,28 @@
+package abigen
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type AbigenResult struct {
+	Contract string `json:"contract"`
+	Output   string `json:"output"`
+	Size     int    `json:"size"`
+}
+
+func (r *AbigenResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[ABI BINDINGS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Contract|%s", r.Contract),
+		fmt.Sprintf("Output|%s", r.Output),
+		fmt.Sprintf("Size|%d bytes", r.Size),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,61 @@
+package attach
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	attachCmd := &cobra.Command{
+		Use:     "attach [endpoint]",
+		Aliases: []string{"console"},
+		Short: "Starts an interactive console bound to the JSON-RPC of a node, over its IPC socket, " +
+			"HTTP or WS endpoint. The console evaluates JavaScript expressions, with the web3 style " +
+			"namespaces (eth, net, web3, txpool, personal, debug...), accounts(), rpc() and loadScript(). " +
+			"The endpoint defaults to the JSON-RPC address",
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	helper.RegisterJSONRPCFlag(attachCmd)
+
+	setFlags(attachCmd)
+
+	return attachCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.exec,
+		execFlag,
+		"",
+		"the statements executed instead of starting the interactive console",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.preload,
+		preloadFlag,
+		[]string{},
+		"the scripts evaluated before the console starts",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		params.endpoint = args[0]
+	} else {
+		params.endpoint = helper.GetJSONRPCAddress(cmd)
+	}
+
+	return params.validateEndpoint()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	if err := params.runConsole(); err != nil {
+		outputter := command.InitializeOutputter(cmd)
+		outputter.SetError(err)
+		outputter.WriteOutput()
+	}
+}
@@ -0,0 +1,603 @@
+package console
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dop251/goja"
+)
+
+// Caller sends the JSON-RPC requests of the console to the node
+type Caller interface {
+	Call(method string, out interface{}, params ...interface{}) error
+}
+
+// latest is the block tag defaulted for the omitted block params
+const latest = "latest"
+
+var (
+	errInvalidArgs = errors.New("invalid arguments")
+	errUnknownUnit = errors.New("unknown unit")
+)
+
+// rpcMethod is a method of the JSON-RPC bound to the console
+type rpcMethod struct {
+	name string
+	// block is the number of the params after which the block param is appended
+	// if omitted, 0 for the methods without block param
+	block int
+	// quantity is set for the methods returning a hex quantity, which is decoded to a big number
+	quantity bool
+}
+
+// nativeFunc is a function of the console implemented in Go, its error is thrown
+type nativeFunc func(args []goja.Value) (goja.Value, error)
+
+// namespace is a global object of the console bound to a namespace of the JSON-RPC,
+// whose unknown members call the method of the same name
+type namespace struct {
+	name    string
+	console *Console
+	// getters are the properties whose value is returned by a method
+	getters map[string]rpcMethod
+	methods map[string]rpcMethod
+	funcs   map[string]nativeFunc
+}
+
+func (n *namespace) Get(key string) goja.Value {
+	if m, ok := n.getters[key]; ok {
+		value, err := n.console.callMethod(m, nil)
+		if err != nil {
+			n.console.throw(err)
+		}
+
+		return value
+	}
+
+	if fn, ok := n.funcs[key]; ok {
+		return n.console.newFunction(n.name+"."+key, fn)
+	}
+
+	m, ok := n.methods[key]
+	if !ok {
+		m = rpcMethod{name: n.name + "_" + key}
+	}
+
+	return n.console.newFunction(n.name+"."+key, func(args []goja.Value) (goja.Value, error) {
+		return n.console.callMethod(m, args)
+	})
+}
+
+// Set rejects the assignments, the members are bound to the JSON-RPC
+func (n *namespace) Set(string, goja.Value) bool {
+	return false
+}
+
+// Has returns true for any member, the unknown ones call the method of the same name
+func (n *namespace) Has(string) bool {
+	return true
+}
+
+func (n *namespace) Delete(string) bool {
+	return false
+}
+
+// Keys returns no member, so the encoding of the namespace doesn't call the node
+func (n *namespace) Keys() []string {
+	return nil
+}
+
+// members returns the names of the known members of the namespace
+func (n *namespace) members() []string {
+	names := make([]string, 0, len(n.getters)+len(n.methods)+len(n.funcs))
+
+	for name := range n.getters {
+		names = append(names, name)
+	}
+
+	for name := range n.methods {
+		names = append(names, name)
+	}
+
+	for name := range n.funcs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// units are the exponents of the denominations of the native currency
+var units = map[string]int64{
+	"wei":    0,
+	"kwei":   3,
+	"mwei":   6,
+	"gwei":   9,
+	"szabo":  12,
+	"finney": 15,
+	"ether":  18,
+}
+
+// registerAPI registers the globals of the console: the JSON-RPC namespaces
+// with the web3 helpers, and the account and script helpers
+func (c *Console) registerAPI() {
+	ns := func(name string) *namespace {
+		n := &namespace{
+			name:    name,
+			console: c,
+			getters: map[string]rpcMethod{},
+			methods: map[string]rpcMethod{},
+			funcs:   map[string]nativeFunc{},
+		}
+
+		_ = c.vm.Set(name, c.vm.NewDynamicObject(n))
+
+		return n
+	}
+
+	for _, name := range []string{"debug", "trace", "index", "nonce", "intent", "evm"} {
+		ns(name)
+	}
+
+	eth := ns("eth")
+	eth.getters["blockNumber"] = rpcMethod{name: "eth_blockNumber", quantity: true}
+	eth.getters["gasPrice"] = rpcMethod{name: "eth_gasPrice", quantity: true}
+	eth.getters["chainId"] = rpcMethod{name: "eth_chainId", quantity: true}
+	eth.getters["syncing"] = rpcMethod{name: "eth_syncing"}
+	eth.methods["getBalance"] = rpcMethod{name: "eth_getBalance", block: 1, quantity: true}
+	eth.methods["getTransactionCount"] = rpcMethod{name: "eth_getTransactionCount", block: 1, quantity: true}
+	eth.methods["getCode"] = rpcMethod{name: "eth_getCode", block: 1}
+	eth.methods["getStorageAt"] = rpcMethod{name: "eth_getStorageAt", block: 2}
+	eth.methods["getTransaction"] = rpcMethod{name: "eth_getTransactionByHash"}
+	eth.methods["getTransactionReceipt"] = rpcMethod{name: "eth_getTransactionReceipt"}
+	eth.methods["call"] = rpcMethod{name: "eth_call", block: 1}
+	eth.methods["estimateGas"] = rpcMethod{name: "eth_estimateGas", quantity: true}
+	eth.methods["sendRawTransaction"] = rpcMethod{name: "eth_sendRawTransaction"}
+	eth.methods["getLogs"] = rpcMethod{name: "eth_getLogs"}
+	eth.funcs["getBlock"] = c.getBlock
+
+	net := ns("net")
+	net.getters["version"] = rpcMethod{name: "net_version"}
+	net.getters["listening"] = rpcMethod{name: "net_listening"}
+	net.getters["peerCount"] = rpcMethod{name: "net_peerCount", quantity: true}
+
+	web3 := ns("web3")
+	web3.getters["clientVersion"] = rpcMethod{name: "web3_clientVersion"}
+	web3.methods["sha3"] = rpcMethod{name: "web3_sha3"}
+	web3.funcs["fromWei"] = c.fromWei
+	web3.funcs["toWei"] = c.toWei
+	web3.funcs["toHex"] = c.toHex
+	web3.funcs["toDecimal"] = c.toDecimal
+	web3.funcs["isAddress"] = c.isAddress
+
+	txpool := ns("txpool")
+	txpool.getters["status"] = rpcMethod{name: "txpool_status"}
+	txpool.getters["content"] = rpcMethod{name: "txpool_content"}
+	txpool.getters["inspect"] = rpcMethod{name: "txpool_inspect"}
+	txpool.getters["quarantine"] = rpcMethod{name: "txpool_quarantine"}
+
+	personal := ns("personal")
+	personal.getters["listAccounts"] = rpcMethod{name: "personal_listAccounts"}
+
+	_ = c.vm.Set("accounts", c.newFunction("accounts", c.accounts))
+	_ = c.vm.Set("rpc", c.newFunction("rpc", c.rpc))
+	_ = c.vm.Set("loadScript", c.newFunction("loadScript", c.loadScript))
+}
+
+// throw throws the error in the runtime, it's returned by Eval
+func (c *Console) throw(err error) {
+	panic(c.vm.NewGoError(err))
+}
+
+// newFunction returns the JavaScript function of the name calling fn
+func (c *Console) newFunction(name string, fn nativeFunc) goja.Value {
+	f := c.vm.ToValue(func(call goja.FunctionCall) goja.Value {
+		value, err := fn(call.Arguments)
+		if err != nil {
+			c.throw(err)
+		}
+
+		return value
+	}).(*goja.Object)
+
+	_ = f.DefineDataProperty("name", c.vm.ToValue(name), goja.FLAG_FALSE, goja.FLAG_TRUE, goja.FLAG_FALSE)
+
+	return f
+}
+
+// bigValue returns the number as a big number object, converted with toString(radix) and toNumber().
+// Its JSON encoding is its decimal string
+func (c *Console) bigValue(value *big.Int) goja.Value {
+	value = new(big.Int).Set(value)
+
+	toNumber := func() float64 {
+		number, _ := new(big.Float).SetInt(value).Float64()
+
+		return number
+	}
+
+	obj := c.vm.NewObject()
+
+	_ = obj.SetSymbol(c.bigSymbol, c.vm.ToValue(value))
+	_ = obj.DefineDataProperty("toString", c.vm.ToValue(func(call goja.FunctionCall) goja.Value {
+		radix := int64(10)
+		if arg := call.Argument(0); !goja.IsUndefined(arg) {
+			radix = arg.ToInteger()
+		}
+
+		if radix < 2 || radix > 36 {
+			c.throw(fmt.Errorf("%w: invalid radix %d", errInvalidArgs, radix))
+		}
+
+		return c.vm.ToValue(value.Text(int(radix)))
+	}), goja.FLAG_FALSE, goja.FLAG_FALSE, goja.FLAG_FALSE)
+	_ = obj.DefineDataProperty("toNumber", c.vm.ToValue(toNumber), goja.FLAG_FALSE, goja.FLAG_FALSE, goja.FLAG_FALSE)
+	_ = obj.DefineDataProperty("valueOf", c.vm.ToValue(toNumber), goja.FLAG_FALSE, goja.FLAG_FALSE, goja.FLAG_FALSE)
+	_ = obj.DefineDataProperty("toJSON", c.vm.ToValue(value.String), goja.FLAG_FALSE, goja.FLAG_FALSE, goja.FLAG_FALSE)
+
+	return obj
+}
+
+// toBig returns the value of the big number object
+func (c *Console) toBig(value goja.Value) (*big.Int, bool) {
+	obj, ok := value.(*goja.Object)
+	if !ok {
+		return nil, false
+	}
+
+	hidden := obj.GetSymbol(c.bigSymbol)
+	if hidden == nil {
+		return nil, false
+	}
+
+	n, ok := hidden.Export().(*big.Int)
+
+	return n, ok
+}
+
+// callMethod calls the method with the arguments, the numbers are sent as hex quantities
+func (c *Console) callMethod(m rpcMethod, args []goja.Value) (goja.Value, error) {
+	params := make([]interface{}, 0, len(args)+1)
+	for _, arg := range args {
+		params = append(params, c.toParam(arg))
+	}
+
+	if m.block > 0 && len(params) == m.block {
+		params = append(params, latest)
+	}
+
+	var raw json.RawMessage
+	if err := c.caller.Call(m.name, &raw, params...); err != nil {
+		return nil, err
+	}
+
+	if len(raw) == 0 {
+		return goja.Null(), nil
+	}
+
+	if m.quantity {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			n, err := parseQuantity(s)
+			if err != nil {
+				return nil, err
+			}
+
+			return c.bigValue(n), nil
+		}
+	}
+
+	parse, _ := goja.AssertFunction(c.vm.Get("JSON").ToObject(c.vm).Get("parse"))
+
+	return parse(goja.Undefined(), c.vm.ToValue(string(raw)))
+}
+
+// toParam returns the value sent as a param of the JSON-RPC, whose integers are hex quantities
+func (c *Console) toParam(value goja.Value) interface{} {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return nil
+	}
+
+	if n, ok := c.toBig(value); ok {
+		return "0x" + n.Text(16)
+	}
+
+	obj, ok := value.(*goja.Object)
+	if !ok {
+		switch exported := value.Export().(type) {
+		case int64:
+			return "0x" + big.NewInt(exported).Text(16)
+		case float64:
+			if n, acc := big.NewFloat(exported).Int(nil); acc == big.Exact {
+				return "0x" + n.Text(16)
+			}
+		}
+
+		return value.Export()
+	}
+
+	if _, ok := goja.AssertFunction(obj); ok {
+		return nil
+	}
+
+	if obj.ClassName() == "Array" {
+		arr := make([]interface{}, obj.Get("length").ToInteger())
+		for i := range arr {
+			arr[i] = c.toParam(obj.Get(fmt.Sprint(i)))
+		}
+
+		return arr
+	}
+
+	params := make(map[string]interface{})
+	for _, key := range obj.Keys() {
+		params[key] = c.toParam(obj.Get(key))
+	}
+
+	return params
+}
+
+// getBlock returns the block of the number, the tag or the hash, with the full transactions if requested
+func (c *Console) getBlock(args []goja.Value) (goja.Value, error) {
+	block := interface{}(latest)
+	if len(args) > 0 {
+		block = c.toParam(args[0])
+	}
+
+	fullTx := len(args) > 1 && args[1].ToBoolean()
+
+	if s, ok := block.(string); ok && strings.HasPrefix(s, "0x") && len(s) == 66 {
+		return c.callMethod(rpcMethod{name: "eth_getBlockByHash"}, []goja.Value{args[0], c.vm.ToValue(fullTx)})
+	}
+
+	return c.callMethod(
+		rpcMethod{name: "eth_getBlockByNumber"},
+		[]goja.Value{c.vm.ToValue(block), c.vm.ToValue(fullTx)},
+	)
+}
+
+// accounts returns the accounts managed by the node, with their balance in ether and their nonce
+func (c *Console) accounts([]goja.Value) (goja.Value, error) {
+	list, err := c.callMethod(rpcMethod{name: "personal_listAccounts"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, _ := list.Export().([]interface{})
+	res := make([]interface{}, 0, len(addrs))
+
+	for _, addr := range addrs {
+		addrValue := c.vm.ToValue(addr)
+
+		balance, err := c.callMethod(rpcMethod{name: "eth_getBalance", block: 1, quantity: true}, []goja.Value{addrValue})
+		if err != nil {
+			return nil, err
+		}
+
+		nonce, err := c.callMethod(
+			rpcMethod{name: "eth_getTransactionCount", block: 1, quantity: true},
+			[]goja.Value{addrValue},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		ether, err := c.fromWei([]goja.Value{balance})
+		if err != nil {
+			return nil, err
+		}
+
+		account := c.vm.NewObject()
+		_ = account.Set("address", addrValue)
+		_ = account.Set("balance", ether)
+		_ = account.Set("nonce", nonce)
+
+		res = append(res, account)
+	}
+
+	return c.vm.NewArray(res...), nil
+}
+
+// rpc calls any method of the JSON-RPC with the params
+func (c *Console) rpc(args []goja.Value) (goja.Value, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: the method is required", errInvalidArgs)
+	}
+
+	method, ok := args[0].Export().(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: the method must be a string", errInvalidArgs)
+	}
+
+	return c.callMethod(rpcMethod{name: method}, args[1:])
+}
+
+// loadScript evaluates the statements of the file
+func (c *Console) loadScript(args []goja.Value) (goja.Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w: the path of the script is required", errInvalidArgs)
+	}
+
+	path := args[0].String()
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.vm.RunScript(path, string(src)); err != nil {
+		return nil, unwrapException(err)
+	}
+
+	return c.vm.ToValue(true), nil
+}
+
+// parseQuantity parses the hex quantity
+func parseQuantity(s string) (*big.Int, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return nil, fmt.Errorf("invalid quantity %s", s)
+	}
+
+	n, ok := new(big.Int).SetString(s[2:], 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid quantity %s", s)
+	}
+
+	return n, nil
+}
+
+// toRat returns the number, the big number, or the decimal or hex string, as a rational
+func (c *Console) toRat(value goja.Value) (*big.Rat, error) {
+	if n, ok := c.toBig(value); ok {
+		return new(big.Rat).SetInt(n), nil
+	}
+
+	switch exported := value.Export().(type) {
+	case int64:
+		return new(big.Rat).SetInt64(exported), nil
+	case float64:
+		if r := new(big.Rat).SetFloat64(exported); r != nil {
+			return r, nil
+		}
+	case string:
+		if strings.HasPrefix(exported, "0x") {
+			n, err := parseQuantity(exported)
+			if err != nil {
+				return nil, err
+			}
+
+			return new(big.Rat).SetInt(n), nil
+		}
+
+		if r, ok := new(big.Rat).SetString(exported); ok {
+			return r, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s is not a number", errInvalidArgs, c.Format(value))
+}
+
+// unitArgs returns the amount and the multiplier of the unit, ether if omitted
+func (c *Console) unitArgs(args []goja.Value) (*big.Rat, *big.Rat, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return nil, nil, fmt.Errorf("%w: expected the amount and the unit", errInvalidArgs)
+	}
+
+	amount, err := c.toRat(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unit := "ether"
+	if len(args) == 2 {
+		unit = strings.ToLower(args[1].String())
+	}
+
+	exp, ok := units[unit]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w %s", errUnknownUnit, unit)
+	}
+
+	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil)
+
+	return amount, new(big.Rat).SetInt(multiplier), nil
+}
+
+// fromWei converts the amount of wei to the unit, as a decimal string
+func (c *Console) fromWei(args []goja.Value) (goja.Value, error) {
+	amount, multiplier, err := c.unitArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	value := new(big.Rat).Quo(amount, multiplier)
+
+	// the denominations have at most 18 decimals
+	s := value.FloatString(18)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+
+	return c.vm.ToValue(s), nil
+}
+
+// toWei converts the amount of the unit to wei, as a big number
+func (c *Console) toWei(args []goja.Value) (goja.Value, error) {
+	amount, multiplier, err := c.unitArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	value := new(big.Rat).Mul(amount, multiplier)
+	if !value.IsInt() {
+		return nil, fmt.Errorf("%w: %s is not a whole amount of wei", errInvalidArgs, value.FloatString(18))
+	}
+
+	return c.bigValue(value.Num()), nil
+}
+
+// integerArg returns the single argument as an integer
+func (c *Console) integerArg(args []goja.Value) (*big.Int, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w: expected a value", errInvalidArgs)
+	}
+
+	n, err := c.toRat(args[0])
+	if err != nil || !n.IsInt() {
+		return nil, fmt.Errorf("%w: %s is not an integer", errInvalidArgs, c.Format(args[0]))
+	}
+
+	return n.Num(), nil
+}
+
+// toHex returns the number, or the bytes of the string, as hex
+func (c *Console) toHex(args []goja.Value) (goja.Value, error) {
+	if len(args) == 1 {
+		if s, ok := args[0].Export().(string); ok {
+			return c.vm.ToValue(fmt.Sprintf("0x%x", s)), nil
+		}
+	}
+
+	n, err := c.integerArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.vm.ToValue("0x" + n.Text(16)), nil
+}
+
+// toDecimal returns the hex quantity as a big number
+func (c *Console) toDecimal(args []goja.Value) (goja.Value, error) {
+	n, err := c.integerArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.bigValue(n), nil
+}
+
+// isAddress checks whether the value is a hex address
+func (c *Console) isAddress(args []goja.Value) (goja.Value, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w: expected a value", errInvalidArgs)
+	}
+
+	s, ok := args[0].Export().(string)
+	if !ok || !strings.HasPrefix(s, "0x") || len(s) != 42 {
+		return c.vm.ToValue(false), nil
+	}
+
+	for _, ch := range s[2:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", ch) {
+			return c.vm.ToValue(false), nil
+		}
+	}
+
+	return c.vm.ToValue(true), nil
+}
@@ -0,0 +1,199 @@
+package console
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja/parser"
+)
+
+const (
+	prompt         = "> "
+	continuePrompt = "... "
+
+	// maxCallStackSize is the maximum depth of the calls of the scripts
+	maxCallStackSize = 1024
+)
+
+var (
+	// errIncomplete is returned for the input ending inside a statement,
+	// which is completed by the next lines of the console
+	errIncomplete = errors.New("unexpected end of input")
+	// errStackOverflow is returned once the calls exceed the maximum call stack size
+	errStackOverflow = errors.New("maximum call stack size exceeded")
+)
+
+// Console evaluates JavaScript in a goja runtime, whose globals are bound
+// to the JSON-RPC of a node in the style of web3
+type Console struct {
+	caller Caller
+	vm     *goja.Runtime
+
+	// bigSymbol is the hidden property holding the value of the big numbers
+	bigSymbol *goja.Symbol
+}
+
+// New creates the console sending its requests with the caller
+func New(caller Caller) *Console {
+	c := &Console{
+		caller:    caller,
+		vm:        goja.New(),
+		bigSymbol: goja.NewSymbol("bigNumber"),
+	}
+
+	c.vm.SetMaxCallStackSize(maxCallStackSize)
+	c.registerAPI()
+
+	return c
+}
+
+// Eval evaluates the statements of the source, and returns the value of the last one
+func (c *Console) Eval(src string) (goja.Value, error) {
+	program, err := parser.ParseFile(nil, "console", src, 0)
+	if err != nil {
+		var list parser.ErrorList
+		if errors.As(err, &list) {
+			for _, parseErr := range list {
+				if parseErr.Message == "Unexpected end of input" {
+					return nil, errIncomplete
+				}
+			}
+		}
+
+		return nil, err
+	}
+
+	compiled, err := goja.CompileAST(program, false)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := c.vm.RunProgram(compiled)
+	if err != nil {
+		return nil, unwrapException(err)
+	}
+
+	return value, nil
+}
+
+// unwrapException returns the error thrown by the functions of the console,
+// or the message of the JavaScript error
+func unwrapException(err error) error {
+	var overflow *goja.StackOverflowError
+	if errors.As(err, &overflow) {
+		return errStackOverflow
+	}
+
+	var exception *goja.Exception
+	if !errors.As(err, &exception) {
+		return err
+	}
+
+	if obj, ok := exception.Value().(*goja.Object); ok {
+		if cause := obj.Get("value"); cause != nil {
+			if err, ok := cause.Export().(error); ok {
+				return err
+			}
+		}
+	}
+
+	return errors.New(exception.Value().String())
+}
+
+// Run reads the statements of the input until its end or the exit command, and prints their values.
+// The statements spanning several lines are completed with the next lines
+func (c *Console) Run(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	pending := ""
+
+	for {
+		if pending == "" {
+			fmt.Fprint(out, prompt)
+		} else {
+			fmt.Fprint(out, continuePrompt)
+		}
+
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+
+			return scanner.Err()
+		}
+
+		line := scanner.Text()
+
+		if pending == "" {
+			switch strings.TrimSpace(line) {
+			case "":
+				continue
+			case "exit", "quit":
+				return nil
+			}
+		}
+
+		src := pending + line + "\n"
+
+		value, err := c.Eval(src)
+		if errors.Is(err, errIncomplete) {
+			pending = src
+
+			continue
+		}
+
+		pending = ""
+
+		if err != nil {
+			fmt.Fprintf(out, "Error: %v\n", err)
+
+			continue
+		}
+
+		fmt.Fprintln(out, c.Format(value))
+	}
+}
+
+// Format returns the representation of the value printed by the console,
+// the objects are printed as their JSON encoding
+func (c *Console) Format(value goja.Value) string {
+	if value == nil || goja.IsUndefined(value) {
+		return "undefined"
+	}
+
+	if goja.IsNull(value) {
+		return "null"
+	}
+
+	if n, ok := c.toBig(value); ok {
+		return n.String()
+	}
+
+	obj, ok := value.(*goja.Object)
+	if !ok {
+		if s, ok := value.Export().(string); ok {
+			return strconv.Quote(s)
+		}
+
+		return value.String()
+	}
+
+	if ns, ok := obj.Export().(*namespace); ok {
+		return fmt.Sprintf("{%s}", strings.Join(ns.members(), ", "))
+	}
+
+	if _, ok := goja.AssertFunction(obj); ok {
+		return fmt.Sprintf("function %s()", obj.Get("name").String())
+	}
+
+	stringify, _ := goja.AssertFunction(c.vm.Get("JSON").ToObject(c.vm).Get("stringify"))
+
+	encoded, err := stringify(goja.Undefined(), obj, goja.Null(), c.vm.ToValue("  "))
+	if err != nil {
+		return unwrapException(err).Error()
+	}
+
+	return encoded.String()
+}
@@ -0,0 +1,269 @@
+package console
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	addr1 = "0x0000000000000000000000000000000000000001"
+	addr2 = "0x0000000000000000000000000000000000000002"
+)
+
+type mockCall struct {
+	method string
+	params []interface{}
+}
+
+// mockCaller returns the results of the methods, and records the calls
+type mockCaller struct {
+	results map[string]interface{}
+	calls   []mockCall
+}
+
+func (m *mockCaller) Call(method string, out interface{}, params ...interface{}) error {
+	m.calls = append(m.calls, mockCall{method: method, params: params})
+
+	result, ok := m.results[method]
+	if !ok {
+		return errors.New("method not found")
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+func newTestConsole() (*Console, *mockCaller) {
+	caller := &mockCaller{
+		results: map[string]interface{}{
+			"eth_blockNumber":         "0x10",
+			"eth_getBalance":          "0xde0b6b3a7640000",
+			"eth_getTransactionCount": "0x2",
+			"eth_getBlockByNumber":    map[string]interface{}{"number": "0x5", "transactions": []string{}},
+			"personal_listAccounts":   []string{addr1, addr2},
+			"txpool_status":           map[string]interface{}{"pending": 3, "queued": 1},
+			"debug_getStateDiff":      "diff",
+		},
+	}
+
+	return New(caller), caller
+}
+
+func TestConsole_Eval(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		src      string
+		expected string
+	}{
+		{`1 + 2 * 3`, "7"},
+		{`(1 + 2) * 3`, "9"},
+		{`7 / 2`, "3.5"},
+		{`0x10 - 1`, "15"},
+		{`"a" + 1`, `"a1"`},
+		{`1 < 2 && "yes"`, `"yes"`},
+		{`null || [1, "b"]`, "[\n  1,\n  \"b\"\n]"},
+		{`var x = {a: {b: 2}}; x.a.b`, "2"},
+		{`var y = [1, 2, 3]
+		y[2] + y.length`, "6"},
+		{`!undefined`, "true"},
+		{`({a: 1}).b`, "undefined"},
+		{`"abc" === 'abc'`, "true"},
+		{`function fib(n) { return n < 2 ? n : fib(n - 1) + fib(n - 2); }
+		fib(10)`, "55"},
+		{`[1, 2, 3].map(function(x) { return x * 2; }).join(",")`, `"2,4,6"`},
+		{`JSON.stringify({a: [1, {b: null}]})`, `"{\"a\":[1,{\"b\":null}]}"`},
+		{`web3.toHex`, "function web3.toHex()"},
+	}
+
+	for _, c := range cases {
+		console, _ := newTestConsole()
+
+		value, err := console.Eval(c.src)
+		require.NoError(t, err, c.src)
+		assert.Equal(t, c.expected, console.Format(value), c.src)
+	}
+}
+
+func TestConsole_EvalErrors(t *testing.T) {
+	t.Parallel()
+
+	console, _ := newTestConsole()
+
+	_, err := console.Eval(`foo`)
+	assert.EqualError(t, err, "ReferenceError: foo is not defined")
+
+	_, err = console.Eval(`null.a`)
+	assert.ErrorContains(t, err, "TypeError: Cannot read property 'a'")
+
+	_, err = console.Eval(`var n = 1; n(2)`)
+	assert.ErrorContains(t, err, "TypeError")
+
+	_, err = console.Eval(`eth.getBalance(`)
+	assert.ErrorIs(t, err, errIncomplete)
+
+	_, err = console.Eval(`1 +* 2`)
+	assert.ErrorContains(t, err, "Unexpected token")
+
+	// the unbounded recursions fail
+	_, err = console.Eval(`function f() { return f(); } f()`)
+	assert.ErrorIs(t, err, errStackOverflow)
+
+	// the errors of the functions of the console are returned
+	_, err = console.Eval(`web3.toWei(1, "foo")`)
+	assert.ErrorIs(t, err, errUnknownUnit)
+
+	// the errors of the functions can be caught
+	value, err := console.Eval(`try { web3.toWei(1, "foo") } catch (e) { "caught" }`)
+	require.NoError(t, err)
+	assert.Equal(t, `"caught"`, console.Format(value))
+}
+
+func TestConsole_RPC(t *testing.T) {
+	t.Parallel()
+
+	console, caller := newTestConsole()
+
+	// the quantities are decoded to big numbers
+	value, err := console.Eval(`eth.blockNumber`)
+	require.NoError(t, err)
+	assert.Equal(t, "16", console.Format(value))
+
+	value, err = console.Eval(`eth.blockNumber + 1`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(17), value.Export())
+
+	// the block param defaults to the latest block
+	value, err = console.Eval(`web3.fromWei(eth.getBalance("` + addr1 + `"))`)
+	require.NoError(t, err)
+	assert.Equal(t, "1", value.Export())
+	assert.Equal(t, []interface{}{addr1, latest}, caller.calls[len(caller.calls)-1].params)
+
+	value, err = console.Eval(`eth.getBalance("` + addr1 + `").toString(16)`)
+	require.NoError(t, err)
+	assert.Equal(t, "de0b6b3a7640000", value.Export())
+
+	// the numbers are sent as hex quantities
+	value, err = console.Eval(`eth.getBlock(5).number`)
+	require.NoError(t, err)
+	assert.Equal(t, "0x5", value.Export())
+	assert.Equal(t, []interface{}{"0x5", false}, caller.calls[len(caller.calls)-1].params)
+
+	value, err = console.Eval(`txpool.status.pending`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), value.Export())
+
+	// the unknown members call the method of the namespace
+	value, err = console.Eval(`debug.getStateDiff({value: 16, big: eth.blockNumber})`)
+	require.NoError(t, err)
+	assert.Equal(t, "diff", value.Export())
+	assert.Equal(t,
+		[]interface{}{map[string]interface{}{"value": "0x10", "big": "0x10"}},
+		caller.calls[len(caller.calls)-1].params,
+	)
+
+	value, err = console.Eval(`rpc("eth_blockNumber")`)
+	require.NoError(t, err)
+	assert.Equal(t, "0x10", value.Export())
+
+	value, err = console.Eval(`net`)
+	require.NoError(t, err)
+	assert.Equal(t, "{listening, peerCount, version}", console.Format(value))
+
+	_, err = console.Eval(`eth.unknown()`)
+	assert.ErrorContains(t, err, "method not found")
+}
+
+func TestConsole_Accounts(t *testing.T) {
+	t.Parallel()
+
+	console, _ := newTestConsole()
+
+	value, err := console.Eval(`accounts()`)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `[
+		{"address": "`+addr1+`", "balance": "1", "nonce": "2"},
+		{"address": "`+addr2+`", "balance": "1", "nonce": "2"}
+	]`, console.Format(value))
+
+	value, err = console.Eval(`accounts()[1].nonce.toNumber()`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), value.Export())
+}
+
+func TestConsole_LoadScript(t *testing.T) {
+	t.Parallel()
+
+	console, _ := newTestConsole()
+
+	path := filepath.Join(t.TempDir(), "script.js")
+	require.NoError(t, os.WriteFile(path, []byte("function double(x) { return x * 2; }\n"), 0600))
+
+	value, err := console.Eval(`loadScript("` + path + `") && double(21)`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), value.Export())
+
+	_, err = console.Eval(`loadScript("` + path + `.missing")`)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestConsole_Units(t *testing.T) {
+	t.Parallel()
+
+	console, _ := newTestConsole()
+
+	cases := []struct {
+		src      string
+		expected string
+	}{
+		{`web3.toWei(1.5, "gwei")`, "1500000000"},
+		{`web3.toWei("2")`, "2000000000000000000"},
+		{`web3.fromWei(1500000000, "gwei")`, `"1.5"`},
+		{`web3.fromWei("0x3b9aca00", "GWEI")`, `"1"`},
+		{`web3.toHex(255)`, `"0xff"`},
+		{`web3.toDecimal("0xff")`, "255"},
+		{`web3.isAddress("` + addr1 + `")`, "true"},
+		{`web3.isAddress("0x01")`, "false"},
+	}
+
+	for _, c := range cases {
+		value, err := console.Eval(c.src)
+		require.NoError(t, err, c.src)
+		assert.Equal(t, c.expected, console.Format(value), c.src)
+	}
+
+	_, err := console.Eval(`web3.toWei(1, "foo")`)
+	assert.ErrorIs(t, err, errUnknownUnit)
+
+	_, err = console.Eval(`web3.toWei(0.5, "wei")`)
+	assert.ErrorIs(t, err, errInvalidArgs)
+}
+
+func TestConsole_Run(t *testing.T) {
+	t.Parallel()
+
+	console, _ := newTestConsole()
+
+	in := strings.NewReader("var a = [\n1,\n2]\na.length\nfoo\nexit\n1\n")
+	out := &bytes.Buffer{}
+
+	require.NoError(t, console.Run(in, out))
+
+	assert.Equal(t,
+		"> ... ... undefined\n> 2\n> Error: ReferenceError: foo is not defined\n> ",
+		out.String(),
+	)
+}
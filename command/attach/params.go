@@ -0,0 +1,94 @@
+package attach
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/command/attach/console"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	execFlag    = "exec"
+	preloadFlag = "preload"
+)
+
+var (
+	params = &attachParams{}
+)
+
+var (
+	errNotSocket = errors.New("the endpoint is neither a URL nor an IPC socket")
+)
+
+type attachParams struct {
+	// endpoint is the URL of the HTTP or WS JSON-RPC, or the path of the IPC socket
+	endpoint string
+	exec     string
+	preload  []string
+}
+
+// validateEndpoint checks that the endpoint is a URL, or the path of an existing socket,
+// the host and port without scheme is the HTTP endpoint
+func (p *attachParams) validateEndpoint() error {
+	for _, scheme := range []string{"http://", "https://", "ws://", "wss://"} {
+		if strings.HasPrefix(p.endpoint, scheme) {
+			_, err := helper.ParseJSONRPCAddress(p.endpoint)
+
+			return err
+		}
+	}
+
+	info, err := os.Stat(p.endpoint)
+	if err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return fmt.Errorf("%w: %s", errNotSocket, p.endpoint)
+		}
+
+		return nil
+	}
+
+	if _, _, err := net.SplitHostPort(p.endpoint); err != nil {
+		return fmt.Errorf("%w: %s", errNotSocket, p.endpoint)
+	}
+
+	p.endpoint = "http://" + p.endpoint
+
+	return nil
+}
+
+// runConsole attaches the console to the endpoint, and evaluates the preloaded scripts
+// and the statement to execute, or reads the statements from the standard input
+func (p *attachParams) runConsole() error {
+	client, err := jsonrpc.NewClient(p.endpoint)
+	if err != nil {
+		return fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	defer client.Close()
+
+	c := console.New(client)
+
+	for _, script := range p.preload {
+		if _, err := c.Eval(fmt.Sprintf("loadScript(%q)", script)); err != nil {
+			return fmt.Errorf("unable to load script %s, %w", script, err)
+		}
+	}
+
+	if p.exec != "" {
+		value, err := c.Eval(p.exec)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stdout, c.Format(value))
+
+		return nil
+	}
+
+	return c.Run(os.Stdin, os.Stdout)
+}
@@ -9,8 +9,11 @@ import (
 
 func GetCommand() *cobra.Command {
 	backupCmd := &cobra.Command{
-		Use:     "backup",
-		Short:   "Create blockchain backup file by fetching blockchain data from the running node",
+		Use:   "backup",
+		Short: "Create blockchain backup file by fetching blockchain data from the running node",
+		Long: "Create blockchain backup file by fetching blockchain data from the running node. " +
+			"With --snapshot, a consistent snapshot of the node databases is streamed compressed " +
+			"and checksummed to a file or a s3://bucket/key location, to be loaded with the restore command",
 		PreRunE: runPreRun,
 		Run:     runCommand,
 	}
@@ -28,7 +31,7 @@ func setFlags(cmd *cobra.Command) {
 		&params.out,
 		outFlag,
 		"",
-		"the export path for the backup",
+		"the export path for the backup, or a s3://bucket/key location for the snapshot",
 	)
 
 	cmd.Flags().StringVar(
@@ -44,6 +47,20 @@ func setFlags(cmd *cobra.Command) {
 		"",
 		"the end height of the chain in backup",
 	)
+
+	cmd.Flags().BoolVar(
+		&params.snapshot,
+		snapshotFlag,
+		false,
+		"back up a consistent snapshot of the node databases instead of a block range",
+	)
+
+	cmd.Flags().StringVar(
+		&params.s3Endpoint,
+		s3EndpointFlag,
+		"",
+		"the endpoint of the S3 compatible store, the AWS S3 one if empty",
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -54,7 +71,12 @@ func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	if err := params.createBackup(helper.GetGRPCAddress(cmd)); err != nil {
+	createBackup := params.createBackup
+	if params.snapshot {
+		createBackup = params.createSnapshot
+	}
+
+	if err := createBackup(helper.GetGRPCAddress(cmd)); err != nil {
 		outputter.SetError(err)
 
 		return
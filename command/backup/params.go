@@ -14,6 +14,9 @@ const (
 	outFlag  = "out"
 	fromFlag = "from"
 	toFlag   = "to"
+
+	snapshotFlag   = "snapshot"
+	s3EndpointFlag = "s3-endpoint"
 )
 
 var (
@@ -21,8 +24,9 @@ var (
 )
 
 var (
-	errDecodeRange  = errors.New("unable to decode range value")
-	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+	errDecodeRange   = errors.New("unable to decode range value")
+	errInvalidRange  = errors.New(`invalid "to" value; must be >= "from"`)
+	errSnapshotRange = errors.New(`the snapshot holds the whole chain; "from" and "to" can't be set`)
 )
 
 type backupParams struct {
@@ -34,13 +38,26 @@ type backupParams struct {
 	from uint64
 	to   *uint64
 
+	snapshot   bool
+	s3Endpoint string
+
 	resFrom uint64
 	resTo   uint64
+
+	summary *archive.SnapshotSummary
 }
 
 func (p *backupParams) validateFlags() error {
 	var parseErr error
 
+	if p.snapshot {
+		if p.fromRaw != "0" || p.toRaw != "" {
+			return errSnapshotRange
+		}
+
+		return nil
+	}
+
 	if p.from, parseErr = types.ParseUint64orHex(&p.fromRaw); parseErr != nil {
 		return errDecodeRange
 	}
@@ -97,7 +114,51 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 	return nil
 }
 
+func (p *backupParams) createSnapshot(grpcAddress string) error {
+	connection, err := helper.GetGRPCConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return err
+	}
+
+	dest, err := archive.OpenSnapshotDestination(p.out, p.s3Endpoint)
+	if err != nil {
+		return err
+	}
+
+	summary, err := archive.CreateSnapshot(
+		connection,
+		hclog.New(&hclog.LoggerOptions{
+			Name:  "backup",
+			Level: hclog.LevelFromString("INFO"),
+		}),
+		dest,
+	)
+	if err != nil {
+		dest.Abort()
+
+		return err
+	}
+
+	if err := dest.Commit(); err != nil {
+		return err
+	}
+
+	p.summary = summary
+
+	return nil
+}
+
 func (p *backupParams) getResult() command.CommandResult {
+	if p.summary != nil {
+		return &SnapshotResult{
+			Out:      p.out,
+			Entries:  p.summary.Entries,
+			Checksum: p.summary.Checksum,
+		}
+	}
+
 	return &BackupResult{
 		From: p.resFrom,
 		To:   p.resTo,
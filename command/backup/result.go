@@ -26,3 +26,23 @@ func (r *BackupResult) GetOutput() string {
 
 	return buffer.String()
 }
+
+type SnapshotResult struct {
+	Out      string `json:"out"`
+	Entries  uint64 `json:"entries"`
+	Checksum string `json:"checksum"`
+}
+
+func (r *SnapshotResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BACKUP]\n")
+	buffer.WriteString("Exported snapshot successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Out),
+		fmt.Sprintf("Entries|%d", r.Entries),
+		fmt.Sprintf("Checksum|%s", r.Checksum),
+	}))
+
+	return buffer.String()
+}
@@ -0,0 +1,24 @@
+package chain
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/chain/verify"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	chainCmd := &cobra.Command{
+		Use:   "chain",
+		Short: "Top level command for maintaining the local chain data. Only accepts subcommands.",
+	}
+
+	registerSubcommands(chainCmd)
+
+	return chainCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// chain verify
+		verify.GetCommand(),
+	)
+}
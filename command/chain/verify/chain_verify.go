@@ -0,0 +1,85 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	chainVerifyCmd := &cobra.Command{
+		Use: "verify",
+		Short: "Verifies the headers, bodies, receipts and state roots of the local chain data. " +
+			"The node must be stopped",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(chainVerifyCmd)
+	helper.SetRequiredFlags(chainVerifyCmd, params.getRequiredFlags())
+
+	return chainVerifyCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file of the chain",
+	)
+
+	cmd.Flags().StringVar(
+		&params.fromRaw,
+		fromFlag,
+		"0",
+		"the beginning height of the range to verify",
+	)
+
+	cmd.Flags().StringVar(
+		&params.toRaw,
+		toFlag,
+		"",
+		"the end height of the range to verify, the head of the chain by default",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.fullState,
+		stateFlag,
+		false,
+		"walk the full state trie of the last block of the range",
+	)
+
+	cmd.Flags().StringVar(
+		&params.healFrom,
+		healFromFlag,
+		"",
+		"the GRPC address of a healthy node to re-fetch the corrupted blocks from",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.verifyChain(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
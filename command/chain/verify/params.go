@@ -0,0 +1,198 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/blockchain/verifier"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag  = "data-dir"
+	chainFlag    = "chain"
+	fromFlag     = "from"
+	toFlag       = "to"
+	stateFlag    = "state"
+	healFromFlag = "heal-from"
+)
+
+var (
+	params = &verifyParams{}
+)
+
+var (
+	errDecodeRange  = errors.New("unable to decode range value")
+	errInvalidRange = errors.New(`invalid "to" value; must be >= "from"`)
+	errEmptyChain   = errors.New("the chain data is empty")
+)
+
+type verifyParams struct {
+	dataDir     string
+	genesisPath string
+	fullState   bool
+	healFrom    string
+
+	fromRaw string
+	toRaw   string
+
+	from uint64
+	to   *uint64
+
+	genesisConfig *chain.Chain
+	report        *verifier.Report
+}
+
+func (p *verifyParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *verifyParams) initRawParams() error {
+	var parseErr error
+
+	if p.from, parseErr = types.ParseUint64orHex(&p.fromRaw); parseErr != nil {
+		return errDecodeRange
+	}
+
+	if p.toRaw != "" {
+		var parsedTo uint64
+
+		if parsedTo, parseErr = types.ParseUint64orHex(&p.toRaw); parseErr != nil {
+			return errDecodeRange
+		}
+
+		if p.from > parsedTo {
+			return errInvalidRange
+		}
+
+		p.to = &parsedTo
+	}
+
+	cc, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to load chain config from %s: %w",
+			p.genesisPath,
+			err,
+		)
+	}
+
+	p.genesisConfig = cc
+
+	return nil
+}
+
+func (p *verifyParams) verifyChain() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "chain-verify",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	hashHeader, err := p.getHeaderHashFunc()
+	if err != nil {
+		return err
+	}
+
+	// the storages are locked by a running node
+	db, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the blockchain storage, is the node stopped? %w", err)
+	}
+
+	defer db.Close()
+
+	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(p.dataDir, "trie"), logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the state storage, is the node stopped? %w", err)
+	}
+
+	defer stateStorage.Close()
+
+	to, ok := db.ReadHeadNumber()
+	if !ok {
+		return errEmptyChain
+	}
+
+	if p.to != nil {
+		to = *p.to
+	}
+
+	if p.from > to {
+		return errInvalidRange
+	}
+
+	var fetcher verifier.BlockFetcher
+
+	if p.healFrom != "" {
+		client, err := helper.GetSystemClientConnection(p.healFrom)
+		if err != nil {
+			return err
+		}
+
+		fetcher = &grpcBlockFetcher{client: client}
+	}
+
+	p.report = verifier.NewVerifier(logger, db, stateStorage, hashHeader, fetcher).Verify(p.from, to, p.fullState)
+
+	return nil
+}
+
+// getHeaderHashFunc returns the header hash calculation of the consensus of the chain
+func (p *verifyParams) getHeaderHashFunc() (verifier.HeaderHashFunc, error) {
+	if p.genesisConfig.Params.GetEngine() != string(server.IBFTConsensus) {
+		return func(header *types.Header) (types.Hash, error) {
+			return types.HeaderHash(header), nil
+		}, nil
+	}
+
+	ibftConfig, ok := p.genesisConfig.Params.Engine[string(server.IBFTConsensus)].(map[string]interface{})
+	if !ok {
+		return nil, fork.ErrUndefinedIBFTConfig
+	}
+
+	hasher, err := fork.NewHeaderHasher(ibftConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher.CalculateHeaderHash, nil
+}
+
+func (p *verifyParams) getResult() command.CommandResult {
+	return &VerifyResult{
+		Report:  p.report,
+		Healing: p.healFrom != "",
+	}
+}
+
+// grpcBlockFetcher fetches the blocks from a node through its system service
+type grpcBlockFetcher struct {
+	client proto.SystemClient
+}
+
+func (f *grpcBlockFetcher) GetBlockByNumber(number uint64) (*types.Block, error) {
+	resp, err := f.client.BlockByNumber(context.Background(), &proto.BlockByNumberRequest{Number: number})
+	if err != nil {
+		return nil, err
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(resp.Data); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
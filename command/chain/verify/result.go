@@ -0,0 +1,57 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/verifier"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type VerifyResult struct {
+	*verifier.Report
+
+	// Healing is set if healing was requested
+	Healing bool `json:"healing"`
+}
+
+func (r *VerifyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CHAIN VERIFY]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+		fmt.Sprintf("State nodes|%d", r.StateNodes),
+		fmt.Sprintf("Corruptions|%d", len(r.Corruptions)),
+	}))
+	buffer.WriteString("\n")
+
+	if len(r.Corruptions) == 0 {
+		buffer.WriteString("\nNo corruption found\n")
+
+		return buffer.String()
+	}
+
+	buffer.WriteString("\n[CORRUPTIONS]\n")
+
+	rows := make([]string, 0, len(r.Corruptions)+1)
+	rows = append(rows, "Block|Kind|Key|Reason|Healed")
+
+	for _, c := range r.Corruptions {
+		healed := "-"
+		if r.Healing {
+			healed = "yes"
+			if !c.Healed {
+				healed = fmt.Sprintf("no, %s", c.HealError)
+			}
+		}
+
+		rows = append(rows, fmt.Sprintf("%d|%s|%s|%s|%s", c.Number, c.Kind, c.Key, c.Reason, healed))
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
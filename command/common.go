@@ -25,6 +25,9 @@ const (
 	IBFTValidatorPrefixFlag = "ibft-validators-prefix-path"
 )
 
+// LogLevels are the values of the log level flag
+var LogLevels = []string{"trace", "debug", "info", "warn", "error"}
+
 var (
 	errInvalidValidatorRange = errors.New("minimum number of validators can not be greater than the " +
 		"maximum number of validators")
@@ -0,0 +1,61 @@
+package completion
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/spf13/cobra"
+)
+
+const (
+	bash = "bash"
+	zsh  = "zsh"
+	fish = "fish"
+)
+
+func GetCommand() *cobra.Command {
+	completionCmd := &cobra.Command{
+		Use:   fmt.Sprintf("completion [%s|%s|%s]", bash, zsh, fish),
+		Short: "Generates the completion script of the shell, completing the commands, their aliases and flags",
+		Long: `Generates the completion script of the shell, completing the commands, their aliases and flags,
+and the values of the flags which are enumerable.
+
+Bash:
+  $ source <(polygon-edge completion bash)
+
+Zsh:
+  $ polygon-edge completion zsh > "${fpath[1]}/_polygon-edge"
+
+Fish:
+  $ polygon-edge completion fish > ~/.config/fish/completions/polygon-edge.fish
+`,
+		ValidArgs: []string{bash, zsh, fish},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		Run:       runCommand,
+	}
+
+	return completionCmd
+}
+
+func runCommand(cmd *cobra.Command, args []string) {
+	var (
+		root = cmd.Root()
+		out  = cmd.OutOrStdout()
+		err  error
+	)
+
+	switch args[0] {
+	case bash:
+		err = root.GenBashCompletionV2(out, true)
+	case zsh:
+		err = root.GenZshCompletion(out)
+	case fish:
+		err = root.GenFishCompletion(out, true)
+	}
+
+	if err != nil {
+		outputter := command.InitializeOutputter(cmd)
+		outputter.SetError(err)
+		outputter.WriteOutput()
+	}
+}
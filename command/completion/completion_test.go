@@ -0,0 +1,86 @@
+package completion
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRootCommand(out io.Writer) *cobra.Command {
+	root := &cobra.Command{
+		Use:           "polygon-edge",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		CompletionOptions: cobra.CompletionOptions{
+			DisableDefaultCmd: true,
+		},
+	}
+
+	root.AddCommand(GetCommand())
+	root.SetOut(out)
+	root.SetErr(io.Discard)
+
+	return root
+}
+
+func TestCompletionScripts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		shell  string
+		header string
+	}{
+		{bash, "# bash completion V2 for polygon-edge"},
+		{zsh, "#compdef polygon-edge"},
+		{fish, "# fish completion for polygon-edge"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.shell, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+
+			root := newTestRootCommand(&out)
+			root.SetArgs([]string{"completion", test.shell})
+
+			assert.NoError(t, root.Execute())
+			assert.Contains(t, out.String(), test.header)
+		})
+	}
+}
+
+func TestCompletionInvalidShell(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"completion"},
+		{"completion", "powershell"},
+		{"completion", bash, zsh},
+	} {
+		var out bytes.Buffer
+
+		root := newTestRootCommand(&out)
+		root.SetArgs(args)
+
+		assert.Error(t, root.Execute(), args)
+		assert.Empty(t, out.String(), args)
+	}
+}
+
+func TestCompletionShells(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	root := newTestRootCommand(&out)
+	root.SetArgs([]string{cobra.ShellCompRequestCmd, "completion", ""})
+
+	assert.NoError(t, root.Execute())
+	assert.Equal(t, "bash\nzsh\nfish\n:4\n", out.String())
+}
@@ -0,0 +1,30 @@
+package debug
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/debug/profile"
+	"github.com/0xPolygon/polygon-edge/command/debug/replay"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	debugCmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Top level command for inspecting the execution of the blocks. Only accepts subcommands.",
+	}
+
+	helper.RegisterJSONRPCFlag(debugCmd)
+
+	registerSubcommands(debugCmd)
+
+	return debugCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// debug profile
+		profile.GetCommand(),
+		// debug replay
+		replay.GetCommand(),
+	)
+}
@@ -0,0 +1,77 @@
+package profile
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	profileCmd := &cobra.Command{
+		Use:     "profile",
+		Short:   "Profiles the gas and the time spent per opcode and per contract over a block range",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(profileCmd)
+
+	return profileCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.from,
+		fromFlag,
+		"earliest",
+		"the first block of the range, a number or earliest/latest",
+	)
+
+	cmd.Flags().StringVar(
+		&params.to,
+		toFlag,
+		"latest",
+		"the last block of the range, a number or earliest/latest",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.timeout,
+		timeoutFlag,
+		0,
+		"the maximum time of the profiling, if omitted the node default is used",
+	)
+
+	cmd.Flags().IntVar(
+		&params.top,
+		topFlag,
+		defaultTop,
+		"the number of opcodes and contracts listed, 0 lists all of them",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	params.jsonrpcAddress = helper.GetJSONRPCAddress(cmd)
+
+	if _, err := helper.ParseJSONRPCAddress(params.jsonrpcAddress); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.profileBlocks(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,133 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/profiler"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	fromFlag    = "from"
+	toFlag      = "to"
+	timeoutFlag = "timeout"
+	topFlag     = "top"
+)
+
+const (
+	defaultTop = 10
+)
+
+var (
+	params = &profileParams{}
+)
+
+var (
+	errInvalidTop     = errors.New("the number of listed entries can't be negative")
+	errInvalidTimeout = errors.New("the timeout can't be negative")
+)
+
+// profileConfig is the configuration of debug_profileBlocks
+type profileConfig struct {
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// blocksProfile is the debug_profileBlocks result
+type blocksProfile struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	profiler.Profile
+}
+
+type profileParams struct {
+	from           string
+	to             string
+	timeout        time.Duration
+	top            int
+	jsonrpcAddress string
+
+	profile *blocksProfile
+}
+
+func (p *profileParams) validateFlags() error {
+	if p.top < 0 {
+		return errInvalidTop
+	}
+
+	if p.timeout < 0 {
+		return errInvalidTimeout
+	}
+
+	return nil
+}
+
+func (p *profileParams) profileBlocks() error {
+	client, err := jsonrpc.NewClient(p.jsonrpcAddress)
+	if err != nil {
+		return fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	defer client.Close()
+
+	config := &profileConfig{}
+
+	if p.timeout > 0 {
+		timeout := p.timeout.String()
+		config.Timeout = &timeout
+	}
+
+	p.profile = &blocksProfile{}
+
+	if err := client.Call("debug_profileBlocks", p.profile, p.from, p.to, config); err != nil {
+		return fmt.Errorf("unable to profile blocks, %w", err)
+	}
+
+	return nil
+}
+
+func (p *profileParams) getResult() command.CommandResult {
+	result := &ProfileResult{
+		Transactions: p.profile.Transactions,
+		Gas:          p.profile.Gas,
+		Time:         time.Duration(p.profile.TimeNs).String(),
+		Opcodes:      make([]OpcodeResult, 0, len(p.profile.Opcodes)),
+		Contracts:    make([]ContractResult, 0, len(p.profile.Contracts)),
+	}
+
+	// the node returns the bounds as hex quantities
+	result.From, _ = types.ParseUint64orHex(&p.profile.From)
+	result.To, _ = types.ParseUint64orHex(&p.profile.To)
+
+	for i, opcode := range p.profile.Opcodes {
+		if p.top > 0 && i == p.top {
+			break
+		}
+
+		result.Opcodes = append(result.Opcodes, OpcodeResult{
+			Opcode: opcode.Opcode,
+			Count:  opcode.Count,
+			Gas:    opcode.Gas,
+			Time:   time.Duration(opcode.TimeNs).String(),
+		})
+	}
+
+	for i, contract := range p.profile.Contracts {
+		if p.top > 0 && i == p.top {
+			break
+		}
+
+		result.Contracts = append(result.Contracts, ContractResult{
+			Address: contract.Address.String(),
+			Calls:   contract.Calls,
+			Opcodes: contract.Opcodes,
+			Gas:     contract.Gas,
+			Time:    time.Duration(contract.TimeNs).String(),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type OpcodeResult struct {
+	Opcode string `json:"opcode"`
+	Count  uint64 `json:"count"`
+	Gas    uint64 `json:"gas"`
+	Time   string `json:"time"`
+}
+
+type ContractResult struct {
+	Address string `json:"address"`
+	Calls   uint64 `json:"calls"`
+	Opcodes uint64 `json:"opcodes"`
+	Gas     uint64 `json:"gas"`
+	Time    string `json:"time"`
+}
+
+type ProfileResult struct {
+	From         uint64           `json:"from"`
+	To           uint64           `json:"to"`
+	Transactions uint64           `json:"transactions"`
+	Gas          uint64           `json:"gas"`
+	Time         string           `json:"time"`
+	Opcodes      []OpcodeResult   `json:"opcodes"`
+	Contracts    []ContractResult `json:"contracts"`
+}
+
+func (r *ProfileResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[PROFILE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Blocks|%d - %d", r.From, r.To),
+		fmt.Sprintf("Transactions|%d", r.Transactions),
+		fmt.Sprintf("Gas|%d", r.Gas),
+		fmt.Sprintf("Time|%s", r.Time),
+	}))
+
+	if len(r.Opcodes) > 0 {
+		rows := make([]string, len(r.Opcodes)+1)
+		rows[0] = "Opcode|Count|Gas|Time"
+
+		for i, opcode := range r.Opcodes {
+			rows[i+1] = fmt.Sprintf("%s|%d|%d|%s", opcode.Opcode, opcode.Count, opcode.Gas, opcode.Time)
+		}
+
+		buffer.WriteString("\n\n[OPCODES]\n")
+		buffer.WriteString(helper.FormatList(rows))
+	}
+
+	if len(r.Contracts) > 0 {
+		rows := make([]string, len(r.Contracts)+1)
+		rows[0] = "Address|Calls|Opcodes|Gas|Time"
+
+		for i, contract := range r.Contracts {
+			rows[i+1] = fmt.Sprintf(
+				"%s|%d|%d|%d|%s",
+				contract.Address,
+				contract.Calls,
+				contract.Opcodes,
+				contract.Gas,
+				contract.Time,
+			)
+		}
+
+		buffer.WriteString("\n\n[CONTRACTS]\n")
+		buffer.WriteString(helper.FormatList(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
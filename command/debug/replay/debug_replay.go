@@ -0,0 +1,87 @@
+package replay
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	replayCmd := &cobra.Command{
+		Use: "replay",
+		Short: "Re-executes a block against its parent state with tracing, and compares the state root, " +
+			"the receipts and the state diff with the ones stored by the node",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(replayCmd)
+
+	helper.SetRequiredFlags(replayCmd, params.getRequiredFlags())
+
+	return replayCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.block,
+		blockFlag,
+		"",
+		"the block replayed, a number or latest",
+	)
+
+	cmd.Flags().StringVar(
+		&params.txHash,
+		txFlag,
+		"",
+		"the hash of the transaction traced, all the transactions of the block are traced if omitted",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.timeout,
+		timeoutFlag,
+		0,
+		"the maximum time of the tracing, if omitted the node default is used",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.enableMemory,
+		enableMemoryFlag,
+		false,
+		"include the memory in the traces",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.disableStorage,
+		disableStorageFlag,
+		false,
+		"exclude the storage from the traces",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	params.jsonrpcAddress = helper.GetJSONRPCAddress(cmd)
+
+	if _, err := helper.ParseJSONRPCAddress(params.jsonrpcAddress); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.replayBlock(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
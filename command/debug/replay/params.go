@@ -0,0 +1,166 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	blockFlag          = "block"
+	txFlag             = "tx"
+	timeoutFlag        = "timeout"
+	enableMemoryFlag   = "enable-memory"
+	disableStorageFlag = "disable-storage"
+)
+
+var (
+	params = &replayParams{}
+)
+
+var (
+	errInvalidTxHash  = errors.New("invalid transaction hash")
+	errInvalidTimeout = errors.New("the timeout can't be negative")
+)
+
+// replayConfig is the configuration of debug_replayBlock
+type replayConfig struct {
+	TxHash         *types.Hash `json:"txHash,omitempty"`
+	EnableMemory   bool        `json:"enableMemory"`
+	DisableStorage bool        `json:"disableStorage"`
+	Timeout        *string     `json:"timeout,omitempty"`
+}
+
+type replayedHash struct {
+	Stored   types.Hash `json:"stored"`
+	Replayed types.Hash `json:"replayed"`
+}
+
+// replayedQuantity is a pair of hex quantities returned by the node
+type replayedQuantity struct {
+	Stored   string `json:"stored"`
+	Replayed string `json:"replayed"`
+}
+
+// blockReplay is the debug_replayBlock result
+type blockReplay struct {
+	BlockNumber  string           `json:"blockNumber"`
+	BlockHash    types.Hash       `json:"blockHash"`
+	Valid        bool             `json:"valid"`
+	StateRoot    replayedHash     `json:"stateRoot"`
+	ReceiptsRoot replayedHash     `json:"receiptsRoot"`
+	GasUsed      replayedQuantity `json:"gasUsed"`
+	Txs          []struct {
+		TxHash            types.Hash       `json:"txHash"`
+		Status            replayedQuantity `json:"status"`
+		CumulativeGasUsed replayedQuantity `json:"cumulativeGasUsed"`
+		Trace             json.RawMessage  `json:"trace"`
+	} `json:"txs"`
+	StateDiff       json.RawMessage  `json:"stateDiff"`
+	StateMismatches []*StateMismatch `json:"stateMismatches"`
+}
+
+type replayParams struct {
+	block          string
+	txHash         string
+	timeout        time.Duration
+	enableMemory   bool
+	disableStorage bool
+	jsonrpcAddress string
+
+	replay *blockReplay
+}
+
+func (p *replayParams) getRequiredFlags() []string {
+	return []string{
+		blockFlag,
+	}
+}
+
+func (p *replayParams) validateFlags() error {
+	if p.txHash != "" {
+		if raw, err := hex.DecodeHex(p.txHash); err != nil || len(raw) != types.HashLength {
+			return fmt.Errorf("%w: %s", errInvalidTxHash, p.txHash)
+		}
+	}
+
+	if p.timeout < 0 {
+		return errInvalidTimeout
+	}
+
+	return nil
+}
+
+func (p *replayParams) replayBlock() error {
+	client, err := jsonrpc.NewClient(p.jsonrpcAddress)
+	if err != nil {
+		return fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	defer client.Close()
+
+	config := &replayConfig{
+		EnableMemory:   p.enableMemory,
+		DisableStorage: p.disableStorage,
+	}
+
+	if p.txHash != "" {
+		txHash := types.StringToHash(p.txHash)
+		config.TxHash = &txHash
+	}
+
+	if p.timeout > 0 {
+		timeout := p.timeout.String()
+		config.Timeout = &timeout
+	}
+
+	p.replay = &blockReplay{}
+
+	if err := client.Call("debug_replayBlock", p.replay, p.block, config); err != nil {
+		return fmt.Errorf("unable to replay block, %w", err)
+	}
+
+	return nil
+}
+
+func (p *replayParams) getResult() command.CommandResult {
+	result := &ReplayResult{
+		BlockHash:       p.replay.BlockHash.String(),
+		Valid:           p.replay.Valid,
+		StateRoot:       HashPair{p.replay.StateRoot.Stored.String(), p.replay.StateRoot.Replayed.String()},
+		ReceiptsRoot:    HashPair{p.replay.ReceiptsRoot.Stored.String(), p.replay.ReceiptsRoot.Replayed.String()},
+		GasUsed:         toUint64Pair(p.replay.GasUsed),
+		Txs:             make([]TxResult, 0, len(p.replay.Txs)),
+		StateDiff:       p.replay.StateDiff,
+		StateMismatches: p.replay.StateMismatches,
+	}
+
+	// the node returns the numbers as hex quantities
+	result.Block, _ = types.ParseUint64orHex(&p.replay.BlockNumber)
+
+	for _, tx := range p.replay.Txs {
+		result.Txs = append(result.Txs, TxResult{
+			Hash:              tx.TxHash.String(),
+			Status:            toUint64Pair(tx.Status),
+			CumulativeGasUsed: toUint64Pair(tx.CumulativeGasUsed),
+			Trace:             tx.Trace,
+		})
+	}
+
+	return result
+}
+
+func toUint64Pair(q replayedQuantity) Uint64Pair {
+	var pair Uint64Pair
+
+	pair.Stored, _ = types.ParseUint64orHex(&q.Stored)
+	pair.Replayed, _ = types.ParseUint64orHex(&q.Replayed)
+
+	return pair
+}
@@ -0,0 +1,118 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type HashPair struct {
+	Stored   string `json:"stored"`
+	Replayed string `json:"replayed"`
+}
+
+type Uint64Pair struct {
+	Stored   uint64 `json:"stored"`
+	Replayed uint64 `json:"replayed"`
+}
+
+type TxResult struct {
+	Hash              string          `json:"hash"`
+	Status            Uint64Pair      `json:"status"`
+	CumulativeGasUsed Uint64Pair      `json:"cumulativeGasUsed"`
+	Trace             json.RawMessage `json:"trace,omitempty"`
+}
+
+type StateMismatch struct {
+	Address  string  `json:"address"`
+	Field    string  `json:"field"`
+	Key      *string `json:"key,omitempty"`
+	Stored   string  `json:"stored"`
+	Replayed string  `json:"replayed"`
+}
+
+type ReplayResult struct {
+	Block        uint64     `json:"block"`
+	BlockHash    string     `json:"blockHash"`
+	Valid        bool       `json:"valid"`
+	StateRoot    HashPair   `json:"stateRoot"`
+	ReceiptsRoot HashPair   `json:"receiptsRoot"`
+	GasUsed      Uint64Pair `json:"gasUsed"`
+	Txs          []TxResult `json:"txs"`
+	// StateDiff is the state diff of the replay, as returned by the node
+	StateDiff json.RawMessage `json:"stateDiff"`
+	// StateMismatches are nil if the node doesn't persist the state diffs
+	StateMismatches []*StateMismatch `json:"stateMismatches"`
+}
+
+func (r *ReplayResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BLOCK REPLAY]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("Hash|%s", r.BlockHash),
+		fmt.Sprintf("Valid|%t", r.Valid),
+		fmt.Sprintf("State root (stored)|%s", r.StateRoot.Stored),
+		fmt.Sprintf("State root (replayed)|%s", r.StateRoot.Replayed),
+		fmt.Sprintf("Receipts root (stored)|%s", r.ReceiptsRoot.Stored),
+		fmt.Sprintf("Receipts root (replayed)|%s", r.ReceiptsRoot.Replayed),
+		fmt.Sprintf("Gas used (stored)|%d", r.GasUsed.Stored),
+		fmt.Sprintf("Gas used (replayed)|%d", r.GasUsed.Replayed),
+	}))
+
+	if len(r.Txs) > 0 {
+		rows := make([]string, len(r.Txs)+1)
+		rows[0] = "Hash|Status (stored)|Status (replayed)|Cumulative Gas (stored)|Cumulative Gas (replayed)"
+
+		for i, tx := range r.Txs {
+			rows[i+1] = fmt.Sprintf(
+				"%s|%d|%d|%d|%d",
+				tx.Hash,
+				tx.Status.Stored,
+				tx.Status.Replayed,
+				tx.CumulativeGasUsed.Stored,
+				tx.CumulativeGasUsed.Replayed,
+			)
+		}
+
+		buffer.WriteString("\n\n[TRANSACTIONS]\n")
+		buffer.WriteString(helper.FormatList(rows))
+	}
+
+	buffer.WriteString("\n\n[STATE DIFF]\n")
+
+	switch {
+	case r.StateMismatches == nil:
+		buffer.WriteString("The node doesn't persist the state diffs, only the roots are compared")
+	case len(r.StateMismatches) == 0:
+		buffer.WriteString("The replayed state diff matches the stored one")
+	default:
+		rows := make([]string, len(r.StateMismatches)+1)
+		rows[0] = "Address|Field|Key|Stored|Replayed"
+
+		for i, mismatch := range r.StateMismatches {
+			key := "-"
+			if mismatch.Key != nil {
+				key = *mismatch.Key
+			}
+
+			rows[i+1] = fmt.Sprintf(
+				"%s|%s|%s|%s|%s",
+				mismatch.Address,
+				mismatch.Field,
+				key,
+				mismatch.Stored,
+				mismatch.Replayed,
+			)
+		}
+
+		buffer.WriteString(helper.FormatList(rows))
+	}
+
+	buffer.WriteString("\n\nThe traces and the replayed state diff are printed with the --json output\n")
+
+	return buffer.String()
+}
@@ -14,6 +14,8 @@ const (
 
 const (
 	JSONOutputFlag  = "json"
+	TableOutputFlag = "table"
+	QuietOutputFlag = "quiet"
 	GRPCAddressFlag = "grpc-address"
 	JSONRPCFlag     = "jsonrpc"
 )
@@ -0,0 +1,31 @@
+package devnet
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/devnet/revert"
+	"github.com/0xPolygon/polygon-edge/command/devnet/snapshot"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	devnetCmd := &cobra.Command{
+		Use: "devnet",
+		Short: "Top level command for controlling the chain of a node running the dev consensus. " +
+			"Only accepts subcommands.",
+	}
+
+	helper.RegisterJSONRPCFlag(devnetCmd)
+
+	registerSubcommands(devnetCmd)
+
+	return devnetCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// devnet snapshot
+		snapshot.GetCommand(),
+		// devnet revert
+		revert.GetCommand(),
+	)
+}
@@ -0,0 +1,54 @@
+package revert
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	revertCmd := &cobra.Command{
+		Use: "revert",
+		Short: "Reverts the chain to a snapshot and clears the transaction pool. " +
+			"The snapshot and the ones taken after it are removed",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(revertCmd)
+	helper.SetRequiredFlags(revertCmd, params.getRequiredFlags())
+
+	return revertCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.id,
+		idFlag,
+		0,
+		"the id of the snapshot",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.jsonrpcAddress = helper.GetJSONRPCAddress(cmd)
+
+	if _, err := helper.ParseJSONRPCAddress(params.jsonrpcAddress); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.revert(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
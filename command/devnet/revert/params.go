@@ -0,0 +1,59 @@
+package revert
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	idFlag = "id"
+)
+
+var (
+	params = &revertParams{}
+)
+
+var (
+	errUnknownSnapshot = errors.New("the snapshot doesn't exist")
+)
+
+type revertParams struct {
+	id             uint64
+	jsonrpcAddress string
+}
+
+func (p *revertParams) getRequiredFlags() []string {
+	return []string{
+		idFlag,
+	}
+}
+
+func (p *revertParams) revert() error {
+	client, err := jsonrpc.NewClient(p.jsonrpcAddress)
+	if err != nil {
+		return fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	defer client.Close()
+
+	var reverted bool
+	if err := client.Call("evm_revert", &reverted, hex.EncodeUint64(p.id)); err != nil {
+		return fmt.Errorf("unable to revert the chain, %w", err)
+	}
+
+	if !reverted {
+		return errUnknownSnapshot
+	}
+
+	return nil
+}
+
+func (p *revertParams) getResult() command.CommandResult {
+	return &RevertResult{
+		ID: p.id,
+	}
+}
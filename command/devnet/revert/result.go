@@ -0,0 +1,24 @@
+package revert
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RevertResult struct {
+	ID uint64 `json:"id"`
+}
+
+func (r *RevertResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CHAIN REVERTED]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Snapshot|%d", r.ID),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,60 @@
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+func GetCommand() *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:     "snapshot",
+		Short:   "Saves the current chain, which can then be reverted to with the returned id",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	return snapshotCmd
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	_, err := helper.ParseJSONRPCAddress(helper.GetJSONRPCAddress(cmd))
+
+	return err
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	id, err := takeSnapshot(helper.GetJSONRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&SnapshotResult{
+		ID: id,
+	})
+}
+
+func takeSnapshot(jsonrpcAddress string) (uint64, error) {
+	client, err := jsonrpc.NewClient(jsonrpcAddress)
+	if err != nil {
+		return 0, fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	defer client.Close()
+
+	var id string
+	if err := client.Call("evm_snapshot", &id); err != nil {
+		return 0, fmt.Errorf("unable to take the snapshot, %w", err)
+	}
+
+	return types.ParseUint64orHex(&id)
+}
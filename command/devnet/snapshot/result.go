@@ -0,0 +1,24 @@
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type SnapshotResult struct {
+	ID uint64 `json:"id"`
+}
+
+func (r *SnapshotResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SNAPSHOT TAKEN]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("ID|%d", r.ID),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -3,11 +3,16 @@ package genesis
 import (
 	"fmt"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/command/genesis/predeploy"
+	"github.com/0xPolygon/polygon-edge/command/genesis/snapshot"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/spf13/cobra"
 )
@@ -30,6 +35,8 @@ func GetCommand() *cobra.Command {
 	genesisCmd.AddCommand(
 		// genesis predeploy
 		predeploy.GetCommand(),
+		// genesis staking-snapshot
+		snapshot.GetCommand(),
 	)
 
 	return genesisCmd
@@ -74,6 +81,21 @@ func setFlags(cmd *cobra.Command) {
 		"the maximum amount of gas used by all transactions in a block",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.minBlockGasLimit,
+		minBlockGasLimitFlag,
+		0,
+		"the minimum block gas limit the validators can move the gas limit to with their block gas target",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.maxBlockGasLimit,
+		maxBlockGasLimitFlag,
+		0,
+		"the maximum block gas limit the validators can move the gas limit to with their block gas target, "+
+			"unbounded if 0",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.bootnodes,
 		command.BootnodeFlag,
@@ -88,6 +110,8 @@ func setFlags(cmd *cobra.Command) {
 		"the consensus protocol to be used",
 	)
 
+	helper.RegisterFlagValues(cmd, command.ConsensusFlag, server.SupportedConsensuses()...)
+
 	cmd.Flags().Uint64Var(
 		&params.epochSize,
 		epochSizeFlag,
@@ -95,6 +119,112 @@ func setFlags(cmd *cobra.Command) {
 		"the epoch size for the chain",
 	)
 
+	// Private network
+	{
+		cmd.Flags().StringVar(
+			&params.privateNetworkKey,
+			pskFlag,
+			"",
+			"the hex encoded 32 byte pre-shared key of a private network, "+
+				"only the nodes holding it can join the P2P network",
+		)
+
+		cmd.Flags().BoolVar(
+			&params.generatePSK,
+			generatePSKFlag,
+			false,
+			"the flag indicating that a random pre-shared key of a private network should be generated",
+		)
+
+		cmd.MarkFlagsMutuallyExclusive(pskFlag, generatePSKFlag)
+	}
+
+	cmd.Flags().StringArrayVar(
+		&params.wasmContracts,
+		wasmContractFlag,
+		[]string{},
+		"the contracts run by the experimental WASM runtime (format: <address>:<path to the .wasm module>)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.txOrdering,
+		txOrderingFlag,
+		txpool.PriceOrdering,
+		fmt.Sprintf(
+			"the ordering of the block transactions, mandated to every proposer (%s, %s or %s)",
+			txpool.PriceOrdering,
+			txpool.FIFOOrdering,
+			txpool.FairOrdering,
+		),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.encryptedMempoolThreshold,
+		encryptedMempoolThresholdFlag,
+		0,
+		"the number of validators decrypting the transactions of the experimental encrypted mempool, "+
+			"whose key shares are written to the validator folders of the prefix path (disabled if 0)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.maxCodeSize,
+		maxCodeSizeFlag,
+		chain.DefaultMaxCodeSize,
+		"the maximum size in bytes of a deployed contract code (EIP-170)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.maxInitCodeSize,
+		maxInitCodeSizeFlag,
+		0,
+		"the maximum size in bytes of a contract creation code (EIP-3860), unlimited if 0",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.freeGas,
+		freeGasFlag,
+		false,
+		"the free-gas mode of the consortium chains, accepting the transactions with a zero gas price on every node",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.dailyGasQuota,
+		dailyGasQuotaFlag,
+		0,
+		"the gas an account can use per day with zero gas price transactions in free-gas mode, unlimited if 0",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.freezeGovernorsRaw,
+		freezeGovernorFlag,
+		[]string{},
+		"the governor voting the emergency freezes of the accounts in the freeze contract, "+
+			"whose transactions are then rejected. This flag can be used multiple times",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.freezeThreshold,
+		freezeThresholdFlag,
+		0,
+		"the number of the governor votes freezing or unfreezing an account, a majority of the governors if 0",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.upgrades,
+		upgradeFlag,
+		[]string{},
+		"the scheduled upgrades, the nodes older than their version halt at their block "+
+			"(format: <name>:<block>:<version>). This flag can be used multiple times",
+	)
+
+	cmd.Flags().StringVar(
+		&params.gasOverridesPath,
+		gasOverridesFlag,
+		"",
+		"the path to the JSON file of the gas costs of opcodes and precompiled contracts "+
+			"overridden from a block: {\"block\": 0, \"opcodes\": {\"SHA3\": 60}, \"precompiles\": {\"0x...01\": 6000}}",
+	)
+
 	// IBFT Validators
 	{
 		cmd.Flags().StringVar(
@@ -104,6 +234,13 @@ func setFlags(cmd *cobra.Command) {
 			"the type of validators in IBFT",
 		)
 
+		helper.RegisterFlagValues(
+			cmd,
+			command.IBFTValidatorTypeFlag,
+			string(validators.ECDSAValidatorType),
+			string(validators.BLSValidatorType),
+		)
+
 		cmd.Flags().StringVar(
 			&params.validatorPrefixPath,
 			command.IBFTValidatorPrefixFlag,
@@ -147,6 +284,47 @@ func setFlags(cmd *cobra.Command) {
 			common.MaxSafeJSInt,
 			"the maximum number of validators in the validator set for PoS",
 		)
+
+		cmd.Flags().Uint64Var(
+			&params.unbondingPeriod,
+			unbondingPeriod,
+			stakingHelper.DefaultUnbondingPeriod,
+			"the number of epochs unstaked funds stay locked before they can be withdrawn for PoS",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.maxExits,
+			maxValidatorExits,
+			stakingHelper.DefaultMaxValidatorExits,
+			"the maximum number of validators leaving the validator set per epoch for PoS, "+
+				"the validators beyond it stay in the set until the following epochs. Defaults to no limit",
+		)
+
+		cmd.Flags().StringVar(
+			&params.minSelfStakeRaw,
+			minSelfStake,
+			"",
+			"the minimum stake of a validator for PoS, in wei. The validators staking less leave "+
+				"the validator set at the next epoch, and the genesis validators are staked with it. "+
+				"Defaults to no minimum",
+		)
+
+		cmd.Flags().StringVar(
+			&params.maxValidatorStakeRaw,
+			maxValidatorStake,
+			"",
+			"the maximum stake of a validator for PoS, in wei, to limit the concentration of the stake. "+
+				"The validators staking more leave the validator set at the next epoch. Defaults to no maximum",
+		)
+
+		cmd.Flags().StringArrayVar(
+			&params.validatorOwnersRaw,
+			validatorOwner,
+			[]string{},
+			"the owner controlling the stake of a genesis validator apart from its validator key, "+
+				"<validator address>:<owner address>, for PoS. The owners of the validators whose "+
+				"data directories match the prefix path are read from their secrets",
+		)
 	}
 }
 
@@ -3,6 +3,7 @@ package genesis
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command"
@@ -10,9 +11,13 @@ import (
 	"github.com/0xPolygon/polygon-edge/consensus/ibft"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/contracts/freeze"
+	"github.com/0xPolygon/polygon-edge/contracts/gasquota"
 	"github.com/0xPolygon/polygon-edge/contracts/staking"
 	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 )
@@ -27,6 +32,27 @@ const (
 	posFlag           = "pos"
 	minValidatorCount = "min-validator-count"
 	maxValidatorCount = "max-validator-count"
+	unbondingPeriod   = "unbonding-period"
+	validatorOwner    = "validator-owner"
+	maxValidatorExits = "max-validator-exits"
+	minSelfStake      = "min-self-stake"
+	maxValidatorStake = "max-validator-stake"
+	pskFlag           = "psk"
+	generatePSKFlag   = "generate-psk"
+	wasmContractFlag  = "wasm-contract"
+	txOrderingFlag    = "tx-ordering"
+
+	encryptedMempoolThresholdFlag = "encrypted-mempool-threshold"
+	maxCodeSizeFlag               = "max-code-size"
+	maxInitCodeSizeFlag           = "max-init-code-size"
+	minBlockGasLimitFlag          = "min-block-gas-limit"
+	maxBlockGasLimitFlag          = "max-block-gas-limit"
+	gasOverridesFlag              = "gas-overrides"
+	freeGasFlag                   = "free-gas"
+	dailyGasQuotaFlag             = "daily-gas-quota"
+	freezeGovernorFlag            = "freeze-governor"
+	freezeThresholdFlag           = "freeze-threshold"
+	upgradeFlag                   = "upgrade"
 )
 
 // Legacy flags that need to be preserved for running clients
@@ -42,6 +68,18 @@ var (
 	errValidatorsNotSpecified = errors.New("validator information not specified")
 	errUnsupportedConsensus   = errors.New("specified consensusRaw not supported")
 	errInvalidEpochSize       = errors.New("epoch size must be greater than 1")
+	errUnknownTxOrdering      = errors.New("unknown transaction ordering")
+	errEncryptedMempoolPrefix = errors.New("the encrypted mempool requires the validators prefix path")
+
+	errDailyGasQuotaWithoutFreeGas = errors.New("the daily gas quota requires the free-gas mode")
+	errInvalidGasLimitBounds       = errors.New("the block gas limit must be within its bounds")
+	errValidatorOwnerWithoutPos    = errors.New("the validator owners require the PoS mode")
+	errUnknownOwnedValidator       = errors.New("the owned validator isn't a genesis validator")
+	errStakeLimitsWithoutPos       = errors.New("the stake limits require the PoS mode")
+	errInvalidStakeLimits          = errors.New("the minimum self-stake exceeds the maximum validator stake")
+
+	errFreezeThresholdWithoutGovernors = errors.New("the freeze threshold requires the freeze governors")
+	errInvalidFreezeThreshold          = errors.New("the freeze threshold exceeds the number of the governors")
 )
 
 type genesisParams struct {
@@ -62,6 +100,42 @@ type genesisParams struct {
 
 	minNumValidators uint64
 	maxNumValidators uint64
+	unbondingPeriod  uint64
+	maxExits         uint64
+
+	validatorOwnersRaw []string
+	validatorOwners    map[types.Address]types.Address
+
+	minSelfStakeRaw      string
+	maxValidatorStakeRaw string
+	minSelfStake         *big.Int
+	maxValidatorStake    *big.Int
+
+	privateNetworkKey string
+	generatePSK       bool
+
+	wasmContracts []string
+
+	txOrdering string
+
+	encryptedMempoolThreshold uint64
+
+	maxCodeSize     uint64
+	maxInitCodeSize uint64
+
+	minBlockGasLimit uint64
+	maxBlockGasLimit uint64
+
+	gasOverridesPath string
+
+	freeGas       bool
+	dailyGasQuota uint64
+
+	freezeGovernorsRaw []string
+	freezeGovernors    []types.Address
+	freezeThreshold    uint64
+
+	upgrades []string
 
 	rawIBFTValidatorType string
 	ibftValidatorType    validators.ValidatorType
@@ -105,6 +179,30 @@ func (p *genesisParams) validateFlags() error {
 		return err
 	}
 
+	if p.privateNetworkKey != "" {
+		if _, err := network.ParsePSK(p.privateNetworkKey); err != nil {
+			return err
+		}
+	}
+
+	switch p.txOrdering {
+	case txpool.PriceOrdering, txpool.FIFOOrdering, txpool.FairOrdering:
+	default:
+		return fmt.Errorf("%w: %s", errUnknownTxOrdering, p.txOrdering)
+	}
+
+	if p.encryptedMempoolThreshold != 0 && !p.areValidatorsSetByPrefix() {
+		return errEncryptedMempoolPrefix
+	}
+
+	if p.dailyGasQuota != 0 && !p.freeGas {
+		return errDailyGasQuotaWithoutFreeGas
+	}
+
+	if !p.getGasLimitBounds().Contains(p.blockGasLimit) {
+		return errInvalidGasLimitBounds
+	}
+
 	return nil
 }
 
@@ -137,12 +235,134 @@ func (p *genesisParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initValidatorOwners(); err != nil {
+		return err
+	}
+
+	if err := p.initStakeLimits(); err != nil {
+		return err
+	}
+
+	if err := p.initFreezeGovernors(); err != nil {
+		return err
+	}
+
+	if err := p.initPrivateNetworkKey(); err != nil {
+		return err
+	}
+
 	p.initIBFTExtraData()
 	p.initConsensusEngineConfig()
 
 	return nil
 }
 
+// initValidatorOwners sets the owners of the genesis validators, controlling their stake
+// in the staking contract, from the cli and the data directories matching the prefix
+func (p *genesisParams) initValidatorOwners() error {
+	if !p.isPos {
+		if len(p.validatorOwnersRaw) != 0 {
+			return errValidatorOwnerWithoutPos
+		}
+
+		return nil
+	}
+
+	var err error
+
+	if p.validatorOwners, err = getValidatorOwners(p.validatorPrefixPath, p.validatorOwnersRaw); err != nil {
+		return err
+	}
+
+	for operator := range p.validatorOwners {
+		if !p.ibftValidators.Includes(operator) {
+			return fmt.Errorf("%w: %s", errUnknownOwnedValidator, operator)
+		}
+	}
+
+	return nil
+}
+
+// initStakeLimits sets the minimum self-stake and the maximum stake of the validators
+// in the staking contract from the cli
+func (p *genesisParams) initStakeLimits() error {
+	if !p.isPos {
+		if p.minSelfStakeRaw != "" || p.maxValidatorStakeRaw != "" {
+			return errStakeLimitsWithoutPos
+		}
+
+		return nil
+	}
+
+	var err error
+
+	if p.minSelfStakeRaw != "" {
+		if p.minSelfStake, err = types.ParseUint256orHex(&p.minSelfStakeRaw); err != nil {
+			return fmt.Errorf("invalid minimum self-stake, %w", err)
+		}
+	}
+
+	if p.maxValidatorStakeRaw != "" {
+		if p.maxValidatorStake, err = types.ParseUint256orHex(&p.maxValidatorStakeRaw); err != nil {
+			return fmt.Errorf("invalid maximum validator stake, %w", err)
+		}
+	}
+
+	if p.minSelfStake != nil && p.maxValidatorStake != nil &&
+		p.maxValidatorStake.Sign() != 0 && p.minSelfStake.Cmp(p.maxValidatorStake) > 0 {
+		return errInvalidStakeLimits
+	}
+
+	return nil
+}
+
+// initFreezeGovernors sets the governors voting the emergency freezes from the cli,
+// a majority of them freezes an account if the threshold isn't set
+func (p *genesisParams) initFreezeGovernors() error {
+	if len(p.freezeGovernorsRaw) == 0 {
+		if p.freezeThreshold != 0 {
+			return errFreezeThresholdWithoutGovernors
+		}
+
+		return nil
+	}
+
+	p.freezeGovernors = make([]types.Address, 0, len(p.freezeGovernorsRaw))
+
+	for _, raw := range p.freezeGovernorsRaw {
+		var governor types.Address
+
+		if err := governor.UnmarshalText([]byte(raw)); err != nil {
+			return fmt.Errorf("invalid freeze governor %s: %w", raw, err)
+		}
+
+		p.freezeGovernors = append(p.freezeGovernors, governor)
+	}
+
+	if p.freezeThreshold == 0 {
+		p.freezeThreshold = uint64(len(p.freezeGovernors))/2 + 1
+	}
+
+	if p.freezeThreshold > uint64(len(p.freezeGovernors)) {
+		return errInvalidFreezeThreshold
+	}
+
+	return nil
+}
+
+// initPrivateNetworkKey generates the pre-shared key of a private network if requested
+func (p *genesisParams) initPrivateNetworkKey() error {
+	if !p.generatePSK {
+		return nil
+	}
+
+	var err error
+
+	p.privateNetworkKey, err = network.GeneratePSK()
+
+	return err
+}
+
 // setValidatorSetFromCli sets validator set from cli command
 func (p *genesisParams) setValidatorSetFromCli() error {
 	if len(p.ibftValidatorsRaw) == 0 {
@@ -298,6 +518,12 @@ func (p *genesisParams) initGenesisConfig() error {
 			ChainID: int(p.chainID),
 			Forks:   chain.AllForksEnabled,
 			Engine:  p.consensusEngineConfig,
+
+			PrivateNetworkKey: p.privateNetworkKey,
+			TxOrdering:        p.txOrdering,
+			MaxCodeSize:       p.maxCodeSize,
+			MaxInitCodeSize:   p.maxInitCodeSize,
+			GasLimitBounds:    p.getGasLimitBounds(),
 		},
 		Bootnodes: p.bootnodes,
 	}
@@ -312,15 +538,64 @@ func (p *genesisParams) initGenesisConfig() error {
 		chainConfig.Genesis.Alloc[staking.AddrStakingContract] = stakingAccount
 	}
 
+	// Predeploy the gas quota contract in free-gas mode
+	if p.freeGas {
+		chainConfig.Params.FreeGas = &chain.FreeGas{
+			DailyGasQuota: p.dailyGasQuota,
+		}
+		chainConfig.Genesis.Alloc[gasquota.AddrGasQuotaContract] = gasquota.PredeployGasQuotaSC()
+	}
+
+	// Predeploy the freeze contract voted by the governors
+	if len(p.freezeGovernors) != 0 {
+		chainConfig.Params.Freeze = &chain.Freeze{
+			Governors: p.freezeGovernors,
+			Threshold: p.freezeThreshold,
+		}
+		chainConfig.Genesis.Alloc[freeze.AddrFreezeContract] = freeze.PredeployFreezeSC()
+	}
+
 	if err := fillPremineMap(chainConfig.Genesis.Alloc, p.premine); err != nil {
 		return err
 	}
 
+	if err := fillWasmContracts(chainConfig, p.wasmContracts); err != nil {
+		return err
+	}
+
+	if err := fillUpgrades(chainConfig, p.upgrades); err != nil {
+		return err
+	}
+
+	if err := fillGasOverrides(chainConfig, p.gasOverridesPath); err != nil {
+		return err
+	}
+
+	if err := fillEncryptedMempool(
+		chainConfig,
+		p.validatorPrefixPath,
+		p.encryptedMempoolThreshold,
+	); err != nil {
+		return err
+	}
+
 	p.genesisConfig = chainConfig
 
 	return nil
 }
 
+// getGasLimitBounds returns the bounds of the block gas limit, nil if not bounded
+func (p *genesisParams) getGasLimitBounds() *chain.GasLimitBounds {
+	if p.minBlockGasLimit == 0 && p.maxBlockGasLimit == 0 {
+		return nil
+	}
+
+	return &chain.GasLimitBounds{
+		Min: p.minBlockGasLimit,
+		Max: p.maxBlockGasLimit,
+	}
+}
+
 func (p *genesisParams) shouldPredeployStakingSC() bool {
 	// If the consensus selected is IBFT / Dev and the mechanism is Proof of Stake,
 	// deploy the Staking SC
@@ -333,6 +608,12 @@ func (p *genesisParams) predeployStakingSC() (*chain.GenesisAccount, error) {
 		stakingHelper.PredeployParams{
 			MinValidatorCount: p.minNumValidators,
 			MaxValidatorCount: p.maxNumValidators,
+			UnbondingPeriod:   p.unbondingPeriod,
+			EpochSize:         p.epochSize,
+			MaxValidatorExits: p.maxExits,
+			MinSelfStake:      p.minSelfStake,
+			MaxValidatorStake: p.maxValidatorStake,
+			Owners:            p.validatorOwners,
 		})
 	if predeployErr != nil {
 		return nil, predeployErr
@@ -50,6 +50,20 @@ func setFlags(cmd *cobra.Command) {
 		[]string{},
 		"the constructor arguments, if any",
 	)
+
+	cmd.Flags().StringVar(
+		&params.storageLayoutPath,
+		storageLayoutFlag,
+		"",
+		"the path to the storage layout JSON of the contract, as given by solc --storage-layout",
+	)
+
+	cmd.Flags().StringVar(
+		&params.storageValuesPath,
+		storageValuesFlag,
+		"",
+		"the path to the JSON of the values of the state variables to set in the storage, keyed by their names",
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
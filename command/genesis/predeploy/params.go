@@ -11,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/contracts/staking"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/predeployment"
+	"github.com/0xPolygon/polygon-edge/helper/slots"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -19,6 +20,8 @@ const (
 	predeployAddressFlag = "predeploy-address"
 	artifactsPathFlag    = "artifacts-path"
 	constructorArgsPath  = "constructor-args"
+	storageLayoutFlag    = "storage-layout"
+	storageValuesFlag    = "storage-values"
 )
 
 var (
@@ -28,6 +31,8 @@ var (
 	errInvalidAddress           = fmt.Errorf(
 		"the provided predeploy address must be >= %s", predeployAddressMin.String(),
 	)
+
+	errStorageValuesWithoutLayout = errors.New("the storage values require the storage layout of the contract")
 )
 
 var (
@@ -49,6 +54,9 @@ type predeployParams struct {
 	artifactsPath   string
 	constructorArgs []string
 
+	storageLayoutPath string
+	storageValuesPath string
+
 	genesisConfig *chain.Chain
 }
 
@@ -68,6 +76,10 @@ func (p *predeployParams) initRawParams() error {
 		return err
 	}
 
+	if p.storageValuesPath != "" && p.storageLayoutPath == "" {
+		return errStorageValuesWithoutLayout
+	}
+
 	if err := p.initChain(); err != nil {
 		return err
 	}
@@ -142,11 +154,47 @@ func (p *predeployParams) updateGenesisConfig() error {
 		return err
 	}
 
+	if p.storageValuesPath != "" {
+		if err := p.setStorageValues(predeployAccount); err != nil {
+			return err
+		}
+	}
+
 	p.genesisConfig.Genesis.Alloc[p.address] = predeployAccount
 
 	return nil
 }
 
+// setStorageValues sets the values of the state variables in the storage of the account,
+// over the storage left by the constructor
+func (p *predeployParams) setStorageValues(account *chain.GenesisAccount) error {
+	rawLayout, err := os.ReadFile(p.storageLayoutPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the storage layout: %w", err)
+	}
+
+	layout, err := slots.ParseLayout(rawLayout)
+	if err != nil {
+		return fmt.Errorf("failed to parse the storage layout: %w", err)
+	}
+
+	rawValues, err := os.ReadFile(p.storageValuesPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the storage values: %w", err)
+	}
+
+	values, err := slots.ParseValues(rawValues)
+	if err != nil {
+		return fmt.Errorf("failed to parse the storage values: %w", err)
+	}
+
+	if account.Storage == nil {
+		account.Storage = make(map[types.Hash]types.Hash)
+	}
+
+	return layout.SetValues(account.Storage, values)
+}
+
 func (p *predeployParams) overrideGenesisConfig() error {
 	// Remove the current genesis configuration from disk
 	if err := os.Remove(p.genesisPath); err != nil {
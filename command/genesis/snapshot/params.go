@@ -0,0 +1,272 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	blockFlag    = "block"
+	outputFlag   = "output"
+	chainFlag    = "chain"
+	accountFlag  = "account"
+	logRangeFlag = "log-range"
+
+	defaultOutputPath = "staking-snapshot.json"
+
+	// defaultLogRange is the default block range limit of the eth_getLogs queries of the nodes
+	defaultLogRange = 1000
+)
+
+var (
+	params = &snapshotParams{}
+)
+
+var (
+	errDecodeBlock       = errors.New("unable to decode block value")
+	errInvalidAccount    = errors.New("invalid account address")
+	errInvalidLogRange   = errors.New("the log range must be positive")
+	errNoStakingContract = errors.New("the staking contract isn't deployed at the block")
+	errNoStakingAccount  = errors.New("the genesis has no staking contract account")
+)
+
+// stakerEvents are the events of the staking contract whose first indexed topic is a staker
+var stakerEvents = []string{"Staked", "WithdrawalRequested"}
+
+type snapshotParams struct {
+	blockRaw    string
+	outputPath  string
+	genesisPath string
+	accountsRaw []string
+	logRange    uint64
+
+	jsonrpcAddress string
+
+	block    *uint64
+	accounts []types.Address
+
+	result *SnapshotResult
+}
+
+// genesisPatch is the part of a genesis the snapshot is written as
+type genesisPatch struct {
+	Alloc map[types.Address]*chain.GenesisAccount `json:"alloc"`
+}
+
+func (p *snapshotParams) initRawParams() error {
+	if p.logRange == 0 {
+		return errInvalidLogRange
+	}
+
+	if p.blockRaw != "" {
+		block, err := types.ParseUint64orHex(&p.blockRaw)
+		if err != nil {
+			return errDecodeBlock
+		}
+
+		p.block = &block
+	}
+
+	p.accounts = make([]types.Address, len(p.accountsRaw))
+
+	for idx, raw := range p.accountsRaw {
+		if err := p.accounts[idx].UnmarshalText([]byte(raw)); err != nil {
+			return fmt.Errorf("%w: %s", errInvalidAccount, raw)
+		}
+	}
+
+	return nil
+}
+
+// snapshot reads the staking contract account at the block and writes it as a genesis alloc patch,
+// applied to the genesis file if given
+func (p *snapshotParams) snapshot() error {
+	client, err := jsonrpc.NewClient(p.jsonrpcAddress)
+	if err != nil {
+		return fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	defer client.Close()
+
+	var block uint64
+
+	if p.block != nil {
+		block = *p.block
+	} else if block, err = client.Eth().BlockNumber(); err != nil {
+		return fmt.Errorf("unable to query the latest block, %w", err)
+	}
+
+	account, snapshot, err := p.readStakingAccount(client, block)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(&genesisPatch{
+		Alloc: map[types.Address]*chain.GenesisAccount{
+			staking.AddrStakingContract: account,
+		},
+	}, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to generate the snapshot: %w", err)
+	}
+
+	if err := os.WriteFile(p.outputPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write the snapshot: %w", err)
+	}
+
+	if p.genesisPath != "" {
+		if err := p.patchGenesis(account); err != nil {
+			return err
+		}
+	}
+
+	p.result = &SnapshotResult{
+		Block:      block,
+		Validators: len(snapshot.Validators),
+		Accounts:   len(snapshot.Accounts),
+		Slots:      len(snapshot.Storage),
+		Output:     p.outputPath,
+		Genesis:    p.genesisPath,
+	}
+
+	return nil
+}
+
+// readStakingAccount reads the code, balance, nonce and storage of the staking contract at the block
+func (p *snapshotParams) readStakingAccount(
+	client *jsonrpc.Client,
+	block uint64,
+) (*chain.GenesisAccount, *stakingHelper.Snapshot, error) {
+	var (
+		eth      = client.Eth()
+		address  = ethgo.Address(staking.AddrStakingContract)
+		blockNum = ethgo.BlockNumber(block)
+	)
+
+	rawCode, err := eth.GetCode(address, blockNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to query the staking contract code, %w", err)
+	}
+
+	code, err := hex.DecodeHex(rawCode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(code) == 0 {
+		return nil, nil, fmt.Errorf("%w: %d", errNoStakingContract, block)
+	}
+
+	balance, err := eth.GetBalance(address, blockNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to query the staking contract balance, %w", err)
+	}
+
+	nonce, err := eth.GetNonce(address, blockNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to query the staking contract nonce, %w", err)
+	}
+
+	stakers, err := p.queryStakers(eth, block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	snapshot, err := stakingHelper.SnapshotStakingSC(func(slot types.Hash) (types.Hash, error) {
+		value, err := eth.GetStorageAt(address, ethgo.Hash(slot), blockNum)
+
+		return types.Hash(value), err
+	}, append(stakers, p.accounts...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &chain.GenesisAccount{
+		Code:    code,
+		Storage: snapshot.Storage,
+		Balance: balance,
+		Nonce:   nonce,
+	}, snapshot, nil
+}
+
+// queryStakers returns the accounts which staked or requested a withdrawal up to the block,
+// the stakes and withdrawals of the accounts which aren't validators can't be enumerated otherwise
+func (p *snapshotParams) queryStakers(eth *jsonrpc.Eth, block uint64) ([]types.Address, error) {
+	topics := make([]*ethgo.Hash, len(stakerEvents))
+
+	for idx, name := range stakerEvents {
+		id := abis.StakingABI.Events[name].ID()
+		topics[idx] = &id
+	}
+
+	var (
+		seen    = make(map[types.Address]struct{})
+		stakers = []types.Address{}
+	)
+
+	for from := uint64(0); from <= block; from += p.logRange {
+		to := from + p.logRange - 1
+		if to > block {
+			to = block
+		}
+
+		filter := &ethgo.LogFilter{
+			Address: []ethgo.Address{ethgo.Address(staking.AddrStakingContract)},
+			Topics:  [][]*ethgo.Hash{topics},
+		}
+
+		filter.SetFromUint64(from)
+		filter.SetToUint64(to)
+
+		logs, err := eth.GetLogs(filter)
+		if err != nil {
+			return nil, fmt.Errorf("unable to query the staking logs of blocks %d-%d, %w", from, to, err)
+		}
+
+		for _, log := range logs {
+			if len(log.Topics) < 2 {
+				continue
+			}
+
+			staker := types.BytesToAddress(log.Topics[1].Bytes())
+			if _, ok := seen[staker]; !ok {
+				seen[staker] = struct{}{}
+				stakers = append(stakers, staker)
+			}
+		}
+	}
+
+	return stakers, nil
+}
+
+// patchGenesis replaces the staking contract account of the genesis file with the snapshot
+func (p *snapshotParams) patchGenesis(account *chain.GenesisAccount) error {
+	genesisConfig, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to load chain config from %s: %w", p.genesisPath, err)
+	}
+
+	if genesisConfig.Genesis.Alloc[staking.AddrStakingContract] == nil {
+		return errNoStakingAccount
+	}
+
+	genesisConfig.Genesis.Alloc[staking.AddrStakingContract] = account
+
+	return helper.WriteGenesisConfigToDisk(genesisConfig, p.genesisPath)
+}
+
+func (p *snapshotParams) getResult() *SnapshotResult {
+	return p.result
+}
@@ -0,0 +1,39 @@
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type SnapshotResult struct {
+	Block      uint64 `json:"block"`
+	Validators int    `json:"validators"`
+	Accounts   int    `json:"accounts"`
+	Slots      int    `json:"slots"`
+	Output     string `json:"output"`
+	Genesis    string `json:"genesis,omitempty"`
+}
+
+func (r *SnapshotResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	rows := []string{
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("Validators|%d", r.Validators),
+		fmt.Sprintf("Accounts|%d", r.Accounts),
+		fmt.Sprintf("Storage slots|%d", r.Slots),
+		fmt.Sprintf("Output|%s", r.Output),
+	}
+
+	if r.Genesis != "" {
+		rows = append(rows, fmt.Sprintf("Patched genesis|%s", r.Genesis))
+	}
+
+	buffer.WriteString("\n[STAKING SNAPSHOT]\n")
+	buffer.WriteString(helper.FormatKV(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
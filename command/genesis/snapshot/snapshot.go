@@ -0,0 +1,85 @@
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use: "staking-snapshot",
+		Short: "Reads the state of the staking contract at a block and writes it as a genesis alloc patch, " +
+			"so that a new genesis carries over the validators, stakes and withdrawals exactly",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	helper.RegisterJSONRPCFlag(snapshotCmd)
+
+	setFlags(snapshotCmd)
+
+	return snapshotCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.blockRaw,
+		blockFlag,
+		"",
+		"the height of the block whose staking state is read, the latest block by default",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputPath,
+		outputFlag,
+		defaultOutputPath,
+		"the path the genesis alloc patch is written to",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		"",
+		"the genesis file the staking contract account is replaced in, if set",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.accountsRaw,
+		accountFlag,
+		[]string{},
+		"an account whose stake is carried over in addition to the stakers found in the logs",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.logRange,
+		logRangeFlag,
+		defaultLogRange,
+		"the number of blocks of the eth_getLogs queries the stakers are found with",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.jsonrpcAddress = helper.GetJSONRPCAddress(cmd)
+
+	if _, err := helper.ParseJSONRPCAddress(params.jsonrpcAddress); err != nil {
+		return fmt.Errorf("invalid JSON-RPC address, %w", err)
+	}
+
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.snapshot(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
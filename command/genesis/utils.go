@@ -1,13 +1,25 @@
 package genesis
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/crypto/threshold"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	secretsHelper "github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/secrets/local"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
+	"github.com/0xPolygon/polygon-edge/state/runtime/wasm"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/versioning"
+	"github.com/hashicorp/go-hclog"
 )
 
 const (
@@ -81,3 +93,222 @@ func fillPremineMap(
 
 	return nil
 }
+
+// fillUpgrades schedules the upgrades <name>:<block>:<version> in the chain params
+func fillUpgrades(chainConfig *chain.Chain, upgrades []string) error {
+	for _, raw := range upgrades {
+		parts := strings.Split(raw, ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return fmt.Errorf("invalid upgrade %s, expected <name>:<block>:<version>", raw)
+		}
+
+		block, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid block of the upgrade %s: %w", parts[0], err)
+		}
+
+		if err := versioning.Validate(parts[2]); err != nil {
+			return fmt.Errorf("invalid version of the upgrade %s: %w", parts[0], err)
+		}
+
+		chainConfig.Params.Upgrades = append(chainConfig.Params.Upgrades, &chain.Upgrade{
+			Name:    parts[0],
+			Block:   block,
+			Version: parts[2],
+		})
+	}
+
+	return nil
+}
+
+// fillWasmContracts sets the code of the WASM contracts in the genesis allocation
+// and registers their addresses, the premined balances are kept
+func fillWasmContracts(chainConfig *chain.Chain, contracts []string) error {
+	for _, contract := range contracts {
+		indx := strings.Index(contract, ":")
+		if indx == -1 {
+			return fmt.Errorf("invalid WASM contract %s, expected <address>:<path>", contract)
+		}
+
+		addr, path := types.StringToAddress(contract[:indx]), contract[indx+1:]
+
+		code, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read WASM module %s: %w", path, err)
+		}
+
+		if err := wasm.Validate(code); err != nil {
+			return fmt.Errorf("invalid WASM module %s: %w", path, err)
+		}
+
+		// the system contracts predeployed in the genesis keep running on the EVM
+		account, ok := chainConfig.Genesis.Alloc[addr]
+		if ok && len(account.Code) > 0 {
+			return fmt.Errorf("invalid WASM contract %s, the address holds a predeployed contract", contract)
+		}
+
+		if !ok {
+			account = &chain.GenesisAccount{}
+			chainConfig.Genesis.Alloc[addr] = account
+		}
+
+		account.Code = code
+		chainConfig.Params.WasmContracts = append(chainConfig.Params.WasmContracts, addr)
+	}
+
+	return nil
+}
+
+// fillGasOverrides sets the gas overrides read from the given JSON file in the chain params,
+// and commits to them in the genesis mix hash
+func fillGasOverrides(chainConfig *chain.Chain, path string) error {
+	if path == "" {
+		return nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read gas overrides %s: %w", path, err)
+	}
+
+	overrides := &chain.GasOverrides{}
+	if err := json.Unmarshal(raw, overrides); err != nil {
+		return fmt.Errorf("failed to parse gas overrides %s: %w", path, err)
+	}
+
+	if _, err := evm.NewGasTable(overrides.Opcodes); err != nil {
+		return fmt.Errorf("invalid gas overrides: %w", err)
+	}
+
+	if err := precompiled.NewPrecompiled().SetGasCosts(overrides.Precompiles); err != nil {
+		return fmt.Errorf("invalid gas overrides: %w", err)
+	}
+
+	chainConfig.Params.GasOverrides = overrides
+	chainConfig.Genesis.Mixhash = overrides.Hash()
+
+	return nil
+}
+
+// fillEncryptedMempool deals the key shares of the encrypted mempool to the validators
+// whose data directories match the prefix, and sets the public keys in the chain params
+func fillEncryptedMempool(chainConfig *chain.Chain, prefix string, decryptionThreshold uint64) error {
+	if decryptionThreshold == 0 {
+		return nil
+	}
+
+	files, err := os.ReadDir(".")
+	if err != nil {
+		return err
+	}
+
+	dirs := make([]string, 0)
+
+	for _, file := range files {
+		if file.IsDir() && strings.HasPrefix(file.Name(), prefix) {
+			dirs = append(dirs, file.Name())
+		}
+	}
+
+	publicKey, shares, err := threshold.GenerateShares(int(decryptionThreshold), len(dirs))
+	if err != nil {
+		return err
+	}
+
+	params := &chain.EncryptedMempool{
+		PublicKey:    hex.EncodeToHex(publicKey),
+		Threshold:    decryptionThreshold,
+		PublicShares: make([]string, len(shares)),
+	}
+
+	for i, dir := range dirs {
+		manager, err := local.SecretsManagerFactory(
+			nil,
+			&secrets.SecretsManagerParams{
+				Logger: hclog.NewNullLogger(),
+				Extra: map[string]interface{}{
+					secrets.Path: dir,
+				},
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		encoded := []byte(hex.EncodeToHex(shares[i].Marshal()))
+		if err := manager.SetSecret(secrets.EncryptionKeyShare, encoded); err != nil {
+			return fmt.Errorf("failed to write the encryption key share of %s: %w", dir, err)
+		}
+
+		params.PublicShares[i] = hex.EncodeToHex(shares[i].Public())
+	}
+
+	chainConfig.Params.EncryptedMempool = params
+
+	return nil
+}
+
+// getValidatorOwners returns the owners of the validators, given as <validator>:<owner>
+// or read from the owner keys initialized in the data directories matching the prefix
+func getValidatorOwners(prefix string, owners []string) (map[types.Address]types.Address, error) {
+	res := make(map[types.Address]types.Address)
+
+	if prefix != "" {
+		files, err := os.ReadDir(".")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			if !file.IsDir() || !strings.HasPrefix(file.Name(), prefix) {
+				continue
+			}
+
+			manager, err := secretsHelper.SetupLocalSecretsManager(file.Name())
+			if err != nil {
+				return nil, err
+			}
+
+			owner, err := secretsHelper.LoadValidatorOwnerAddress(manager)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the owner key of %s: %w", file.Name(), err)
+			}
+
+			if owner == types.ZeroAddress {
+				continue
+			}
+
+			operator, err := secretsHelper.LoadValidatorAddress(manager)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the validator key of %s: %w", file.Name(), err)
+			}
+
+			res[operator] = owner
+		}
+	}
+
+	for _, raw := range owners {
+		parts := strings.Split(raw, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid validator owner %s, expected <validator>:<owner>", raw)
+		}
+
+		var operator, owner types.Address
+
+		if err := operator.UnmarshalText([]byte(parts[0])); err != nil {
+			return nil, fmt.Errorf("invalid validator address %s: %w", parts[0], err)
+		}
+
+		if err := owner.UnmarshalText([]byte(parts[1])); err != nil {
+			return nil, fmt.Errorf("invalid owner address %s: %w", parts[1], err)
+		}
+
+		if owner == types.ZeroAddress || owner == operator {
+			return nil, fmt.Errorf("invalid owner of the validator %s: %s", operator, owner)
+		}
+
+		res[operator] = owner
+	}
+
+	return res, nil
+}
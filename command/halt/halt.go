@@ -0,0 +1,36 @@
+package halt
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/halt/manifest"
+	"github.com/0xPolygon/polygon-edge/command/halt/resume"
+	"github.com/0xPolygon/polygon-edge/command/halt/signal"
+	"github.com/0xPolygon/polygon-edge/command/halt/status"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	haltCmd := &cobra.Command{
+		Use:   "halt",
+		Short: "Top level command for the coordinated halt and restart of the chain. Only accepts subcommands.",
+	}
+
+	helper.RegisterGRPCAddressFlag(haltCmd)
+
+	registerSubcommands(haltCmd)
+
+	return haltCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// halt signal
+		signal.GetCommand(),
+		// halt status
+		status.GetCommand(),
+		// halt manifest
+		manifest.GetCommand(),
+		// halt resume
+		resume.GetCommand(),
+	)
+}
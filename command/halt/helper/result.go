@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+type HaltResult struct {
+	Height        uint64        `json:"height"`
+	Halted        bool          `json:"halted"`
+	BlockNumber   uint64        `json:"block_number"`
+	Signers       []string      `json:"signers"`
+	ResumedHeight uint64        `json:"resumed_height"`
+	Signals       []*HaltSignal `json:"signals"`
+}
+
+type HaltSignal struct {
+	Height     uint64 `json:"height"`
+	Validators uint64 `json:"validators"`
+	Total      uint64 `json:"total"`
+}
+
+func NewHaltResult(status *proto.HaltStatus) *HaltResult {
+	res := &HaltResult{
+		Height:        status.Height,
+		Halted:        status.Halted,
+		BlockNumber:   status.BlockNumber,
+		Signers:       status.Signers,
+		ResumedHeight: status.ResumedHeight,
+		Signals:       make([]*HaltSignal, len(status.Signals)),
+	}
+
+	for i, s := range status.Signals {
+		res.Signals[i] = &HaltSignal{
+			Height:     s.Height,
+			Validators: s.Validators,
+			Total:      s.Total,
+		}
+	}
+
+	return res
+}
+
+func (r *HaltResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[HALT STATUS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Halt Height|%d", r.Height),
+		fmt.Sprintf("Halted|%t", r.Halted),
+		fmt.Sprintf("Current Block Number (base 10)|%d", r.BlockNumber),
+		fmt.Sprintf("Last Resumed Height|%d", r.ResumedHeight),
+	}))
+	buffer.WriteString("\n")
+
+	if len(r.Signers) > 0 {
+		buffer.WriteString("\n[HALT SIGNERS]\n")
+		buffer.WriteString(helper.FormatList(r.Signers))
+		buffer.WriteString("\n")
+	}
+
+	if len(r.Signals) > 0 {
+		buffer.WriteString("\n[HALT SIGNALS]\n")
+
+		signals := make([]string, len(r.Signals))
+		for i, s := range r.Signals {
+			signals[i] = fmt.Sprintf("Height %d|%d/%d validators", s.Height, s.Validators, s.Total)
+		}
+
+		buffer.WriteString(helper.FormatKV(signals))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,93 @@
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	manifestCmd := &cobra.Command{
+		Use: "manifest",
+		Short: "Signs the manifest restarting the chain halted at a height with the validator key. " +
+			"An existing manifest of the same halt is co-signed",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(manifestCmd)
+	helper.SetRequiredFlags(manifestCmd, params.getRequiredFlags())
+
+	return manifestCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.height,
+		heightFlag,
+		0,
+		"the halt height the chain is resumed from",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file the nodes restart with",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.override,
+		overrideFlag,
+		false,
+		"commit the manifest to the chain config of the genesis file, "+
+			"only the nodes restarted with the patched genesis file can resume",
+	)
+
+	cmd.Flags().StringVar(
+		&params.manifestPath,
+		manifestFlag,
+		defaultManifestPath,
+		"the path to the manifest, co-signed if it exists",
+	)
+
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the Polygon Edge data if the local FS is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the SecretsManager config file, "+
+			"if omitted, the local FS secrets manager is used",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.sign(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,177 @@
+package manifest
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/halt"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	secretsHelper "github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	heightFlag   = "height"
+	chainFlag    = "chain"
+	overrideFlag = "override"
+	manifestFlag = "manifest"
+	dataDirFlag  = "data-dir"
+	configFlag   = "config"
+
+	defaultManifestPath = "manifest.json"
+)
+
+var (
+	params = &manifestParams{}
+)
+
+var (
+	errInvalidConfig   = errors.New("invalid secrets configuration")
+	errInvalidParams   = errors.New("no config file or data directory passed in")
+	errUnsupportedType = errors.New("unsupported secrets manager")
+	errInvalidHeight   = errors.New("the halt height must be positive")
+)
+
+type manifestParams struct {
+	height       uint64
+	genesisPath  string
+	override     bool
+	manifestPath string
+
+	dataDir    string
+	configPath string
+
+	validatorKey *ecdsa.PrivateKey
+	chainConfig  *chain.Chain
+
+	manifest *halt.Manifest
+}
+
+func (p *manifestParams) getRequiredFlags() []string {
+	return []string{
+		heightFlag,
+	}
+}
+
+func (p *manifestParams) validateFlags() error {
+	if p.dataDir == "" && p.configPath == "" {
+		return errInvalidParams
+	}
+
+	if p.height == 0 {
+		return errInvalidHeight
+	}
+
+	return nil
+}
+
+func (p *manifestParams) initRawParams() error {
+	cc, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to load chain config from %s: %w",
+			p.genesisPath,
+			err,
+		)
+	}
+
+	p.chainConfig = cc
+
+	return p.initSecrets()
+}
+
+func (p *manifestParams) initSecrets() error {
+	secretsManager, err := p.initSecretsManager()
+	if err != nil {
+		return err
+	}
+
+	if p.validatorKey, err = crypto.ReadConsensusKey(secretsManager); err != nil {
+		return fmt.Errorf("unable to read validator key, %w", err)
+	}
+
+	return nil
+}
+
+func (p *manifestParams) initSecretsManager() (secrets.SecretsManager, error) {
+	if p.configPath == "" {
+		return secretsHelper.SetupLocalSecretsManager(p.dataDir)
+	}
+
+	secretsConfig, readErr := secrets.ReadConfig(p.configPath)
+	if readErr != nil {
+		return nil, errInvalidConfig
+	}
+
+	if !secrets.SupportedServiceManager(secretsConfig.Type) {
+		return nil, errUnsupportedType
+	}
+
+	return secretsHelper.InitCloudSecretsManager(secretsConfig)
+}
+
+// sign signs the restart manifest of the halt, co-signing the existing manifest of the same halt
+func (p *manifestParams) sign() error {
+	m := &halt.Manifest{
+		ChainID:    uint64(p.chainConfig.Params.ChainID),
+		HaltHeight: p.height,
+		Signatures: []*halt.Signature{},
+	}
+
+	// the nodes only resume with the patched chain config the manifest commits to
+	if p.override {
+		hash, err := halt.ChainConfigHash(p.chainConfig)
+		if err != nil {
+			return err
+		}
+
+		m.ChainConfigHash = hash
+	}
+
+	if _, err := os.Stat(p.manifestPath); err == nil {
+		existing, err := halt.ReadManifest(p.manifestPath)
+		if err != nil {
+			return err
+		}
+
+		if _, err := existing.Verify(); err != nil {
+			return err
+		}
+
+		if err := m.CoSign(existing); err != nil {
+			return fmt.Errorf("unable to co-sign %s, %w", p.manifestPath, err)
+		}
+	}
+
+	if err := m.Sign(p.validatorKey); err != nil {
+		return err
+	}
+
+	if err := m.WriteFile(p.manifestPath); err != nil {
+		return fmt.Errorf("unable to write manifest, %w", err)
+	}
+
+	p.manifest = m
+
+	return nil
+}
+
+func (p *manifestParams) getResult() *ManifestResult {
+	chainConfigHash := ""
+	if p.manifest.ChainConfigHash != types.ZeroHash {
+		chainConfigHash = p.manifest.ChainConfigHash.String()
+	}
+
+	return &ManifestResult{
+		Manifest:        p.manifestPath,
+		ChainID:         p.manifest.ChainID,
+		HaltHeight:      p.manifest.HaltHeight,
+		ChainConfigHash: chainConfigHash,
+		Signer:          crypto.PubKeyToAddress(&p.validatorKey.PublicKey).String(),
+		Signatures:      len(p.manifest.Signatures),
+	}
+}
@@ -0,0 +1,39 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ManifestResult struct {
+	Manifest        string `json:"manifest"`
+	ChainID         uint64 `json:"chain_id"`
+	HaltHeight      uint64 `json:"halt_height"`
+	ChainConfigHash string `json:"chain_config_hash,omitempty"`
+	Signer          string `json:"signer"`
+	Signatures      int    `json:"signatures"`
+}
+
+func (r *ManifestResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	chainConfigHash := r.ChainConfigHash
+	if chainConfigHash == "" {
+		chainConfigHash = "unchanged"
+	}
+
+	buffer.WriteString("\n[RESTART MANIFEST]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Manifest|%s", r.Manifest),
+		fmt.Sprintf("Chain ID|%d", r.ChainID),
+		fmt.Sprintf("Halt Height|%d", r.HaltHeight),
+		fmt.Sprintf("Chain Config Hash|%s", chainConfigHash),
+		fmt.Sprintf("Signer|%s", r.Signer),
+		fmt.Sprintf("Signatures|%d", r.Signatures),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
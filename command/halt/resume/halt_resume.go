@@ -0,0 +1,47 @@
+package resume
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	resumeCmd := &cobra.Command{
+		Use: "resume",
+		Short: "Resumes the halted chain with a restart manifest signed by a supermajority " +
+			"of the validators at the halt height",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(resumeCmd)
+
+	return resumeCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.manifestPath,
+		manifestFlag,
+		defaultManifestPath,
+		"the path to the restart manifest",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.resume(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,56 @@
+package resume
+
+import (
+	"context"
+	"os"
+
+	haltHelper "github.com/0xPolygon/polygon-edge/command/halt/helper"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+const (
+	manifestFlag = "manifest"
+
+	defaultManifestPath = "manifest.json"
+)
+
+var (
+	params = &resumeParams{}
+)
+
+type resumeParams struct {
+	manifestPath string
+
+	manifest []byte
+
+	haltStatus *proto.HaltStatus
+}
+
+func (p *resumeParams) initRawParams() error {
+	var err error
+
+	p.manifest, err = os.ReadFile(p.manifestPath)
+
+	return err
+}
+
+func (p *resumeParams) resume(grpcAddress string) error {
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.haltStatus, err = client.Resume(
+		context.Background(),
+		&proto.ResumeRequest{
+			Manifest: p.manifest,
+		},
+	)
+
+	return err
+}
+
+func (p *resumeParams) getResult() *haltHelper.HaltResult {
+	return haltHelper.NewHaltResult(p.haltStatus)
+}
@@ -0,0 +1,48 @@
+package signal
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	signalCmd := &cobra.Command{
+		Use: "signal",
+		Short: "Signs the intent of the validator of the node to halt the chain after a block. " +
+			"The chain halts once a supermajority of the validators signals the same height",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(signalCmd)
+	helper.SetRequiredFlags(signalCmd, params.getRequiredFlags())
+
+	return signalCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.height,
+		heightFlag,
+		0,
+		"the number of the last block before the halt",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.signal(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,62 @@
+package signal
+
+import (
+	"context"
+	"errors"
+
+	haltHelper "github.com/0xPolygon/polygon-edge/command/halt/helper"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+const (
+	heightFlag = "height"
+)
+
+var (
+	params = &signalParams{}
+)
+
+var (
+	errInvalidHeight = errors.New("the halt height must be positive")
+)
+
+type signalParams struct {
+	height uint64
+
+	haltStatus *proto.HaltStatus
+}
+
+func (p *signalParams) getRequiredFlags() []string {
+	return []string{
+		heightFlag,
+	}
+}
+
+func (p *signalParams) validateFlags() error {
+	if p.height == 0 {
+		return errInvalidHeight
+	}
+
+	return nil
+}
+
+func (p *signalParams) signal(grpcAddress string) error {
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.haltStatus, err = client.HaltSignal(
+		context.Background(),
+		&proto.HaltRequest{
+			Height: p.height,
+		},
+	)
+
+	return err
+}
+
+func (p *signalParams) getResult() *haltHelper.HaltResult {
+	return haltHelper.NewHaltResult(p.haltStatus)
+}
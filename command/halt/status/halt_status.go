@@ -0,0 +1,48 @@
+package status
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	haltHelper "github.com/0xPolygon/polygon-edge/command/halt/helper"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Returns the halt height of the chain and the pending halt signals of the validators",
+		Args:  cobra.NoArgs,
+		Run:   runCommand,
+	}
+
+	return statusCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	haltStatus, err := getHaltStatus(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(haltHelper.NewHaltResult(haltStatus))
+}
+
+func getHaltStatus(grpcAddress string) (*proto.HaltStatus, error) {
+	client, err := helper.GetSystemClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetHaltStatus(context.Background(), &empty.Empty{})
+}
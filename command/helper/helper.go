@@ -12,12 +12,17 @@ import (
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command"
 	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/0xPolygon/polygon-edge/helper/abi"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/server"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/ryanuber/columnize"
 	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -34,14 +39,17 @@ type IPBinding string
 
 const (
 	LocalHostBinding     IPBinding = "127.0.0.1"
+	LocalHostBinding6    IPBinding = "::1"
 	AllInterfacesBinding IPBinding = "0.0.0.0"
 )
 
 // HandleSignals is a helper method for handling signals sent to the console
-// Like stop, error, etc.
+// Like stop, error, etc. The close callback has the grace period to return,
+// and a second signal stops the client at once
 func HandleSignals(
 	closeFn func(),
 	outputter command.OutputFormatter,
+	gracePeriod time.Duration,
 ) error {
 	signalCh := common.GetTerminationSignalCh()
 	sig := <-signalCh
@@ -70,7 +78,7 @@ func HandleSignals(
 	select {
 	case <-signalCh:
 		return errors.New("shutdown by signal channel")
-	case <-time.After(5 * time.Second):
+	case <-time.After(gracePeriod):
 		return errors.New("shutdown by timeout")
 	case <-gracefulCh:
 		return nil
@@ -176,6 +184,34 @@ func RegisterJSONOutputFlag(cmd *cobra.Command) {
 	)
 }
 
+// RegisterTableOutputFlag registers the --table output setting for all child commands
+func RegisterTableOutputFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool(
+		command.TableOutputFlag,
+		false,
+		"get the outputs of the query commands as tables without decoration (default false)",
+	)
+}
+
+// RegisterQuietOutputFlag registers the --quiet output setting for all child commands
+func RegisterQuietOutputFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool(
+		command.QuietOutputFlag,
+		false,
+		"only output the essential values of the query commands, one per line, and the errors (default false)",
+	)
+}
+
+// RegisterFlagValues registers the completion of the flag with its enumerable values
+func RegisterFlagValues(cmd *cobra.Command, flag string, values ...string) {
+	_ = cmd.RegisterFlagCompletionFunc(
+		flag,
+		func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return values, cobra.ShellCompDirectiveNoFileComp
+		},
+	)
+}
+
 // RegisterGRPCAddressFlag registers the base GRPC address flag for all child commands
 func RegisterGRPCAddressFlag(cmd *cobra.Command) {
 	cmd.PersistentFlags().String(
@@ -251,3 +287,32 @@ func SetRequiredFlags(cmd *cobra.Command, requiredFlags []string) {
 		_ = cmd.MarkFlagRequired(requiredFlag)
 	}
 }
+
+// jsonRPCCaller is the abi.Caller sending the calls to the JSON-RPC endpoint of a node
+type jsonRPCCaller struct {
+	client *jsonrpc.Client
+	from   types.Address
+}
+
+// NewJSONRPCCaller returns the caller sending the calls from the address to the JSON-RPC
+// endpoint of a node, at the latest block
+func NewJSONRPCCaller(client *jsonrpc.Client, from types.Address) abi.Caller {
+	return &jsonRPCCaller{
+		client: client,
+		from:   from,
+	}
+}
+
+// Call implements the abi.Caller interface
+func (c *jsonRPCCaller) Call(to types.Address, input []byte) ([]byte, error) {
+	response, err := c.client.Eth().Call(&ethgo.CallMsg{
+		From: ethgo.Address(c.from),
+		To:   (*ethgo.Address)(&to),
+		Data: input,
+	}, ethgo.Latest)
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeHex(response)
+}
@@ -0,0 +1,34 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterFlagValues(t *testing.T) {
+	t.Parallel()
+
+	var (
+		out  bytes.Buffer
+		root = &cobra.Command{Use: "polygon-edge"}
+		cmd  = &cobra.Command{Use: "genesis", Run: func(*cobra.Command, []string) {}}
+	)
+
+	cmd.Flags().String("consensus", "", "the consensus protocol to be used")
+	RegisterFlagValues(cmd, "consensus", "dev", "ibft")
+
+	root.AddCommand(cmd)
+	root.SetOut(&out)
+	root.SetErr(io.Discard)
+
+	// the values are completed without the files of the directory
+	root.SetArgs([]string{cobra.ShellCompRequestCmd, "genesis", "--consensus", ""})
+
+	assert.NoError(t, root.Execute())
+	assert.Equal(t, fmt.Sprintf("dev\nibft\n:%d\n", cobra.ShellCompDirectiveNoFileComp), out.String())
+}
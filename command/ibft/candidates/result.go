@@ -58,3 +58,21 @@ func formatCandidates(candidates []IBFTCandidate) string {
 
 	return helper.FormatKV(generatedCandidates)
 }
+
+func (r *IBFTCandidatesResult) GetTable() ([]string, [][]string) {
+	rows := make([][]string, len(r.Candidates))
+	for i, c := range r.Candidates {
+		rows[i] = []string{c.Address, ibftHelper.VoteToString(c.Vote)}
+	}
+
+	return []string{"ADDRESS", "VOTE"}, rows
+}
+
+func (r *IBFTCandidatesResult) GetQuietOutput() []string {
+	addrs := make([]string, len(r.Candidates))
+	for i, c := range r.Candidates {
+		addrs[i] = c.Address
+	}
+
+	return addrs
+}
@@ -0,0 +1,71 @@
+package failover
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	fenceFlag = "fence"
+)
+
+var (
+	fence bool
+)
+
+func GetCommand() *cobra.Command {
+	failoverCmd := &cobra.Command{
+		Use: "failover",
+		Short: "Returns the failover status of the node in an active/standby validator pair. " +
+			"With --fence, the node stops signing until restarted and its partner takes over",
+		Run: runCommand,
+	}
+
+	failoverCmd.Flags().BoolVar(
+		&fence,
+		fenceFlag,
+		false,
+		"stop the signing of the node, for a planned switch to its partner",
+	)
+
+	return failoverCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	statusResponse, err := getFailoverStatus(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&IBFTFailoverResult{
+		Role:             statusResponse.Role,
+		Signing:          statusResponse.Signing,
+		Fenced:           statusResponse.Fenced,
+		LastSignedHeight: statusResponse.LastSignedHeight,
+	})
+}
+
+func getFailoverStatus(grpcAddress string) (*ibftOp.FailoverStatusResp, error) {
+	client, err := helper.GetIBFTOperatorClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if fence {
+		return client.Fence(context.Background(), &empty.Empty{})
+	}
+
+	return client.FailoverStatus(context.Background(), &empty.Empty{})
+}
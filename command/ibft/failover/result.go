@@ -0,0 +1,30 @@
+package failover
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type IBFTFailoverResult struct {
+	Role             string `json:"role"`
+	Signing          bool   `json:"signing"`
+	Fenced           bool   `json:"fenced"`
+	LastSignedHeight uint64 `json:"last_signed_height"`
+}
+
+func (r *IBFTFailoverResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[FAILOVER STATUS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Role|%s", r.Role),
+		fmt.Sprintf("Signing|%t", r.Signing),
+		fmt.Sprintf("Fenced|%t", r.Fenced),
+		fmt.Sprintf("Last signed height|%d", r.LastSignedHeight),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -3,11 +3,14 @@ package ibft
 import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/ibft/candidates"
+	"github.com/0xPolygon/polygon-edge/command/ibft/failover"
 	"github.com/0xPolygon/polygon-edge/command/ibft/propose"
 	"github.com/0xPolygon/polygon-edge/command/ibft/quorum"
+	"github.com/0xPolygon/polygon-edge/command/ibft/randomness"
 	"github.com/0xPolygon/polygon-edge/command/ibft/snapshot"
 	"github.com/0xPolygon/polygon-edge/command/ibft/status"
 	_switch "github.com/0xPolygon/polygon-edge/command/ibft/switch"
+	"github.com/0xPolygon/polygon-edge/command/ibft/votingpower"
 	"github.com/spf13/cobra"
 )
 
@@ -38,5 +41,11 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		_switch.GetCommand(),
 		// ibft quorum
 		quorum.GetCommand(),
+		// ibft randomness
+		randomness.GetCommand(),
+		// ibft failover
+		failover.GetCommand(),
+		// ibft voting-power
+		votingpower.GetCommand(),
 	)
 }
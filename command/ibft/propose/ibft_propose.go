@@ -50,6 +50,8 @@ func setFlags(cmd *cobra.Command) {
 		),
 	)
 
+	helper.RegisterFlagValues(cmd, voteFlag, authVote, dropVote)
+
 	cmd.MarkFlagsRequiredTogether(addressFlag, voteFlag)
 }
 
@@ -0,0 +1,69 @@
+package randomness
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	ibftRandomnessCmd := &cobra.Command{
+		Use:     "randomness",
+		Short:   "Specify the block number after which validators include randomness proofs in the headers",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(ibftRandomnessCmd)
+	helper.SetRequiredFlags(ibftRandomnessCmd, params.getRequiredFlags())
+
+	return ibftRandomnessCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file to update",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.from,
+		fromFlag,
+		0,
+		"the height to start the randomness beacon",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.proposerElection,
+		proposerElectionFlag,
+		false,
+		"the flag indicating that the proposer is elected by the randomness beacon",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.updateGenesisConfig(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.overrideGenesisConfig(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
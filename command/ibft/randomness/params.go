@@ -0,0 +1,110 @@
+package randomness
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+const (
+	fromFlag             = "from"
+	chainFlag            = "chain"
+	proposerElectionFlag = "proposer-election"
+)
+
+var (
+	params = &randomnessParams{}
+)
+
+type randomnessParams struct {
+	genesisConfig    *chain.Chain
+	from             uint64
+	proposerElection bool
+	genesisPath      string
+}
+
+func (p *randomnessParams) initChain() error {
+	cc, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to load chain config from %s: %w",
+			p.genesisPath,
+			err,
+		)
+	}
+
+	p.genesisConfig = cc
+
+	return nil
+}
+
+func (p *randomnessParams) initRawParams() error {
+	return p.initChain()
+}
+
+func (p *randomnessParams) getRequiredFlags() []string {
+	return []string{
+		fromFlag,
+	}
+}
+
+func (p *randomnessParams) updateGenesisConfig() error {
+	return appendIBFTRandomness(
+		p.genesisConfig,
+		p.from,
+		p.proposerElection,
+	)
+}
+
+func (p *randomnessParams) overrideGenesisConfig() error {
+	// Remove the current genesis configuration from disk
+	if err := os.Remove(p.genesisPath); err != nil {
+		return err
+	}
+
+	// Save the new genesis configuration
+	if err := helper.WriteGenesisConfigToDisk(
+		p.genesisConfig,
+		p.genesisPath,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *randomnessParams) getResult() command.CommandResult {
+	return &IBFTRandomnessResult{
+		Chain:            p.genesisPath,
+		From:             common.JSONNumber{Value: p.from},
+		ProposerElection: p.proposerElection,
+	}
+}
+
+func appendIBFTRandomness(
+	cc *chain.Chain,
+	from uint64,
+	proposerElection bool,
+) error {
+	ibftConfig, ok := cc.Params.Engine["ibft"].(map[string]interface{})
+	if !ok {
+		return errors.New(`"ibft" setting doesn't exist in "engine" of genesis.json'`)
+	}
+
+	if err := ibft.CheckRandomnessValidators(ibftConfig, from); err != nil {
+		return err
+	}
+
+	ibftConfig[ibft.KeyRandomnessBlockNum] = from
+	ibftConfig[ibft.KeyRandomProposerElection] = proposerElection
+
+	cc.Params.Engine["ibft"] = ibftConfig
+
+	return nil
+}
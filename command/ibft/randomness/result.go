@@ -0,0 +1,32 @@
+package randomness
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+type IBFTRandomnessResult struct {
+	Chain            string            `json:"chain"`
+	From             common.JSONNumber `json:"from"`
+	ProposerElection bool              `json:"proposer_election"`
+}
+
+func (r *IBFTRandomnessResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[NEW IBFT RANDOMNESS BEACON START]\n")
+
+	outputs := []string{
+		fmt.Sprintf("Chain|%s", r.Chain),
+		fmt.Sprintf("From|%d", r.From.Value),
+		fmt.Sprintf("Proposer Election|%t", r.ProposerElection),
+	}
+
+	buffer.WriteString(helper.FormatKV(outputs))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
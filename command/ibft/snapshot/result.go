@@ -121,3 +121,23 @@ func (r *IBFTSnapshotResult) writeValidatorData(buffer *bytes.Buffer) {
 	buffer.WriteString(helper.FormatList(validators))
 	buffer.WriteString("\n")
 }
+
+// GetTable returns the validators of the snapshot
+func (r *IBFTSnapshotResult) GetTable() ([]string, [][]string) {
+	rows := make([][]string, len(r.Validators))
+	for i, v := range r.Validators {
+		rows[i] = []string{v.Addr().String(), v.String()}
+	}
+
+	return []string{"ADDRESS", "VALIDATOR"}, rows
+}
+
+// GetQuietOutput returns the addresses of the validators of the snapshot
+func (r *IBFTSnapshotResult) GetQuietOutput() []string {
+	addrs := make([]string, len(r.Validators))
+	for i, v := range r.Validators {
+		addrs[i] = v.Addr().String()
+	}
+
+	return addrs
+}
@@ -22,3 +22,11 @@ func (r *IBFTStatusResult) GetOutput() string {
 
 	return buffer.String()
 }
+
+func (r *IBFTStatusResult) GetTable() ([]string, [][]string) {
+	return []string{"VALIDATOR KEY"}, [][]string{{r.ValidatorKey}}
+}
+
+func (r *IBFTStatusResult) GetQuietOutput() []string {
+	return []string{r.ValidatorKey}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
 	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/spf13/cobra"
 )
@@ -38,6 +39,8 @@ func setFlags(cmd *cobra.Command) {
 		"the new IBFT type [PoA, PoS]",
 	)
 
+	helper.RegisterFlagValues(cmd, typeFlag, string(fork.PoA), string(fork.PoS))
+
 	{
 		// switch block height
 		cmd.Flags().StringVar(
@@ -63,6 +66,13 @@ func setFlags(cmd *cobra.Command) {
 		"the type of validators in IBFT",
 	)
 
+	helper.RegisterFlagValues(
+		cmd,
+		command.IBFTValidatorTypeFlag,
+		string(validators.ECDSAValidatorType),
+		string(validators.BLSValidatorType),
+	)
+
 	{
 		// PoA Configuration
 		cmd.Flags().StringVar(
@@ -0,0 +1,69 @@
+package votingpower
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	ibftVotingPowerCmd := &cobra.Command{
+		Use:     "voting-power",
+		Short:   "Specify the block number after which the quorum is weighted by the stake of the PoS validators",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(ibftVotingPowerCmd)
+	helper.SetRequiredFlags(ibftVotingPowerCmd, params.getRequiredFlags())
+
+	return ibftVotingPowerCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file to update",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.from,
+		fromFlag,
+		0,
+		"the height to start weighting the quorum by the stake",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.capPercent,
+		capFlag,
+		0,
+		"the maximum voting power of a validator in percentage of the total stake. Defaults to no cap",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.updateGenesisConfig(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.overrideGenesisConfig(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,116 @@
+package votingpower
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+const (
+	fromFlag  = "from"
+	chainFlag = "chain"
+	capFlag   = "cap"
+
+	maxCap = 100
+)
+
+var (
+	params = &votingPowerParams{}
+)
+
+var (
+	errInvalidCap = errors.New("the cap must be a percentage of the total stake")
+)
+
+type votingPowerParams struct {
+	genesisConfig *chain.Chain
+	from          uint64
+	capPercent    uint64
+	genesisPath   string
+}
+
+func (p *votingPowerParams) initChain() error {
+	cc, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to load chain config from %s: %w",
+			p.genesisPath,
+			err,
+		)
+	}
+
+	p.genesisConfig = cc
+
+	return nil
+}
+
+func (p *votingPowerParams) initRawParams() error {
+	if p.capPercent > maxCap {
+		return errInvalidCap
+	}
+
+	return p.initChain()
+}
+
+func (p *votingPowerParams) getRequiredFlags() []string {
+	return []string{
+		fromFlag,
+	}
+}
+
+func (p *votingPowerParams) updateGenesisConfig() error {
+	return appendIBFTVotingPower(
+		p.genesisConfig,
+		p.from,
+		p.capPercent,
+	)
+}
+
+func (p *votingPowerParams) overrideGenesisConfig() error {
+	// Remove the current genesis configuration from disk
+	if err := os.Remove(p.genesisPath); err != nil {
+		return err
+	}
+
+	// Save the new genesis configuration
+	if err := helper.WriteGenesisConfigToDisk(
+		p.genesisConfig,
+		p.genesisPath,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *votingPowerParams) getResult() command.CommandResult {
+	return &IBFTVotingPowerResult{
+		Chain: p.genesisPath,
+		From:  common.JSONNumber{Value: p.from},
+		Cap:   common.JSONNumber{Value: p.capPercent},
+	}
+}
+
+func appendIBFTVotingPower(
+	cc *chain.Chain,
+	from uint64,
+	capPercent uint64,
+) error {
+	ibftConfig, ok := cc.Params.Engine["ibft"].(map[string]interface{})
+	if !ok {
+		return errors.New(`"ibft" setting doesn't exist in "engine" of genesis.json'`)
+	}
+
+	ibftConfig[ibft.KeyVotingPowerBlockNum] = from
+	ibftConfig[ibft.KeyVotingPowerCap] = capPercent
+
+	cc.Params.Engine["ibft"] = ibftConfig
+
+	return nil
+}
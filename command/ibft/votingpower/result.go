@@ -0,0 +1,32 @@
+package votingpower
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+type IBFTVotingPowerResult struct {
+	Chain string            `json:"chain"`
+	From  common.JSONNumber `json:"from"`
+	Cap   common.JSONNumber `json:"cap"`
+}
+
+func (r *IBFTVotingPowerResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[NEW IBFT STAKE-WEIGHTED QUORUM START]\n")
+
+	outputs := []string{
+		fmt.Sprintf("Chain|%s", r.Chain),
+		fmt.Sprintf("From|%d", r.From.Value),
+		fmt.Sprintf("Voting Power Cap|%d%%", r.Cap.Value),
+	}
+
+	buffer.WriteString(helper.FormatKV(outputs))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
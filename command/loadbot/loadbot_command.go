@@ -54,6 +54,8 @@ func setFlags(cmd *cobra.Command) {
 		"the mode of operation [transfer, deploy, erc20, erc721].",
 	)
 
+	helper.RegisterFlagValues(cmd, modeFlag, string(transfer), string(deploy), string(erc20), string(erc721))
+
 	cmd.Flags().StringVar(
 		&params.senderRaw,
 		senderFlag,
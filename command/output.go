@@ -27,14 +27,43 @@ type CommandResult interface {
 	GetOutput() string
 }
 
+// TableResult is the result of a query command which can be written as a table
+type TableResult interface {
+	// GetTable returns the header and the rows of the table
+	GetTable() ([]string, [][]string)
+}
+
+// QuietResult is the result of a query command whose essential values are written in the quiet mode
+type QuietResult interface {
+	// GetQuietOutput returns the essential values of the result, one per line
+	GetQuietOutput() []string
+}
+
 func shouldOutputJSON(baseCmd *cobra.Command) bool {
 	return baseCmd.Flag(JSONOutputFlag).Changed
 }
 
+// isFlagSet checks whether the output flag is set, the commands not registering it never set it
+func isFlagSet(baseCmd *cobra.Command, name string) bool {
+	flag := baseCmd.Flag(name)
+
+	return flag != nil && flag.Changed
+}
+
+// InitializeOutputter returns the formatter of the output format set by the flags,
+// the JSON format having precedence over the table format and the quiet mode
 func InitializeOutputter(cmd *cobra.Command) OutputFormatter {
 	if shouldOutputJSON(cmd) {
 		return newJSONOutput()
 	}
 
+	if isFlagSet(cmd, TableOutputFlag) {
+		return newTableOutput()
+	}
+
+	if isFlagSet(cmd, QuietOutputFlag) {
+		return newQuietOutput()
+	}
+
 	return newCLIOutput()
 }
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testResult struct{}
+
+func (r *testResult) GetOutput() string {
+	return "output"
+}
+
+func (r *testResult) GetTable() ([]string, [][]string) {
+	return []string{"NAME", "VALUE"}, [][]string{{"first", "1"}, {"second", ""}}
+}
+
+func (r *testResult) GetQuietOutput() []string {
+	return []string{"1", "2"}
+}
+
+// plainResult is a result without table or essential values
+type plainResult struct{}
+
+func (r *plainResult) GetOutput() string {
+	return "plain"
+}
+
+func newTestOutputCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool(JSONOutputFlag, false, "")
+	cmd.Flags().Bool(TableOutputFlag, false, "")
+	cmd.Flags().Bool(QuietOutputFlag, false, "")
+
+	require.NoError(t, cmd.ParseFlags(args))
+
+	return cmd
+}
+
+func TestInitializeOutputter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected OutputFormatter
+	}{
+		{
+			name:     "should return the CLI output by default",
+			expected: newCLIOutput(),
+		},
+		{
+			name:     "should return the table output",
+			args:     []string{"--table"},
+			expected: newTableOutput(),
+		},
+		{
+			name:     "should return the quiet output",
+			args:     []string{"--quiet"},
+			expected: newQuietOutput(),
+		},
+		{
+			name:     "should prefer the JSON output",
+			args:     []string{"--quiet", "--table", "--json"},
+			expected: newJSONOutput(),
+		},
+		{
+			name:     "should prefer the table output to the quiet output",
+			args:     []string{"--quiet", "--table"},
+			expected: newTableOutput(),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, InitializeOutputter(newTestOutputCommand(t, test.args...)))
+		})
+	}
+}
+
+func TestInitializeOutputter_UnregisteredFlags(t *testing.T) {
+	t.Parallel()
+
+	// the commands registering the JSON flag only get the CLI output
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool(JSONOutputFlag, false, "")
+
+	assert.Equal(t, newCLIOutput(), InitializeOutputter(cmd))
+}
+
+func TestTableOutput(t *testing.T) {
+	t.Parallel()
+
+	output := newTableOutput()
+	output.SetCommandResult(&testResult{})
+
+	assert.Equal(t, "NAME    VALUE\nfirst   1\nsecond  -", output.getCommandOutput())
+
+	// the results which aren't tables are written as in the CLI output
+	output.SetCommandResult(&plainResult{})
+
+	assert.Equal(t, "plain", output.getCommandOutput())
+}
+
+func TestQuietOutput(t *testing.T) {
+	t.Parallel()
+
+	output := newQuietOutput()
+	output.SetCommandResult(&testResult{})
+
+	assert.Equal(t, "1\n2", output.getCommandOutput())
+
+	// the results without essential values aren't written
+	output.SetCommandResult(&plainResult{})
+
+	assert.Equal(t, "", output.getCommandOutput())
+}
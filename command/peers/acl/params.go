@@ -0,0 +1,82 @@
+package acl
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	params = &aclParams{}
+)
+
+var (
+	errListsWithoutSet = errors.New("the access control lists are only replaced with --set")
+)
+
+const (
+	setFlag        = "set"
+	allowCIDRsFlag = "allow-cidrs"
+	denyCIDRsFlag  = "deny-cidrs"
+	allowPeersFlag = "allow-peers"
+	denyPeersFlag  = "deny-peers"
+)
+
+type aclParams struct {
+	set        bool
+	allowCIDRs []string
+	denyCIDRs  []string
+	allowPeers []string
+	denyPeers  []string
+
+	acl *proto.PeersACL
+}
+
+func (p *aclParams) validateFlags() error {
+	hasLists := len(p.allowCIDRs) > 0 || len(p.denyCIDRs) > 0 ||
+		len(p.allowPeers) > 0 || len(p.denyPeers) > 0
+
+	if hasLists && !p.set {
+		return errListsWithoutSet
+	}
+
+	return nil
+}
+
+func (p *aclParams) initACL(grpcAddress string) error {
+	systemClient, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	if p.set {
+		p.acl, err = systemClient.PeersACLSet(
+			context.Background(),
+			&proto.PeersACL{
+				AllowCidrs: p.allowCIDRs,
+				DenyCidrs:  p.denyCIDRs,
+				AllowPeers: p.allowPeers,
+				DenyPeers:  p.denyPeers,
+			},
+		)
+
+		return err
+	}
+
+	p.acl, err = systemClient.PeersACLGet(context.Background(), &empty.Empty{})
+
+	return err
+}
+
+func (p *aclParams) getResult() command.CommandResult {
+	return &PeersACLResult{
+		AllowCIDRs: p.acl.AllowCidrs,
+		DenyCIDRs:  p.acl.DenyCidrs,
+		AllowPeers: p.acl.AllowPeers,
+		DenyPeers:  p.acl.DenyPeers,
+	}
+}
@@ -0,0 +1,75 @@
+package acl
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	peersACLCmd := &cobra.Command{
+		Use: "acl",
+		Short: "Returns the access control list of the peer connections. " +
+			"With --set, the list is replaced and the connected peers it denies are disconnected",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(peersACLCmd)
+
+	return peersACLCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&params.set,
+		setFlag,
+		false,
+		"replace the access control list with the given ranges and peers, an empty list allows every peer",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.allowCIDRs,
+		allowCIDRsFlag,
+		[]string{},
+		"the CIDR ranges the peers are allowed to connect from",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.denyCIDRs,
+		denyCIDRsFlag,
+		[]string{},
+		"the CIDR ranges the peers are never connected from",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.allowPeers,
+		allowPeersFlag,
+		[]string{},
+		"the libp2p IDs of the peers allowed to connect",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.denyPeers,
+		denyPeersFlag,
+		[]string{},
+		"the libp2p IDs of the peers never connected",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initACL(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
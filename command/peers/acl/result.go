@@ -0,0 +1,46 @@
+package acl
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type PeersACLResult struct {
+	AllowCIDRs []string `json:"allow_cidrs"`
+	DenyCIDRs  []string `json:"deny_cidrs"`
+	AllowPeers []string `json:"allow_peers"`
+	DenyPeers  []string `json:"deny_peers"`
+}
+
+func (r *PeersACLResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[PEERS ACL]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Allowed ranges|%d", len(r.AllowCIDRs)),
+		fmt.Sprintf("Denied ranges|%d", len(r.DenyCIDRs)),
+		fmt.Sprintf("Allowed peers|%d", len(r.AllowPeers)),
+		fmt.Sprintf("Denied peers|%d", len(r.DenyPeers)),
+	}))
+
+	for _, list := range []struct {
+		title   string
+		entries []string
+	}{
+		{"ALLOWED RANGES", r.AllowCIDRs},
+		{"DENIED RANGES", r.DenyCIDRs},
+		{"ALLOWED PEERS", r.AllowPeers},
+		{"DENIED PEERS", r.DenyPeers},
+	} {
+		if len(list.entries) > 0 {
+			buffer.WriteString(fmt.Sprintf("\n\n[%s]\n", list.title))
+			buffer.WriteString(helper.FormatList(list.entries))
+		}
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
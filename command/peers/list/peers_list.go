@@ -12,9 +12,10 @@ import (
 
 func GetCommand() *cobra.Command {
 	peersListCmd := &cobra.Command{
-		Use:   "list",
-		Short: "Returns the list of connected peers, including the current node",
-		Run:   runCommand,
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Returns the list of connected peers, including the current node",
+		Run:     runCommand,
 	}
 
 	return peersListCmd
@@ -44,3 +44,16 @@ func (r *PeersListResult) GetOutput() string {
 
 	return buffer.String()
 }
+
+func (r *PeersListResult) GetTable() ([]string, [][]string) {
+	rows := make([][]string, len(r.Peers))
+	for i, p := range r.Peers {
+		rows[i] = []string{p}
+	}
+
+	return []string{"ID"}, rows
+}
+
+func (r *PeersListResult) GetQuietOutput() []string {
+	return r.Peers
+}
@@ -2,6 +2,7 @@ package peers
 
 import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/peers/acl"
 	"github.com/0xPolygon/polygon-edge/command/peers/add"
 	"github.com/0xPolygon/polygon-edge/command/peers/list"
 	"github.com/0xPolygon/polygon-edge/command/peers/status"
@@ -29,5 +30,7 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		list.GetCommand(),
 		// peers add
 		add.GetCommand(),
+		// peers acl
+		acl.GetCommand(),
 	)
 }
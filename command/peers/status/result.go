@@ -3,6 +3,7 @@ package status
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
 )
@@ -26,3 +27,12 @@ func (r *PeersStatusResult) GetOutput() string {
 
 	return buffer.String()
 }
+
+func (r *PeersStatusResult) GetTable() ([]string, [][]string) {
+	return []string{"ID", "PROTOCOLS", "ADDRESSES"},
+		[][]string{{r.ID, strings.Join(r.Protocols, ","), strings.Join(r.Addresses, ",")}}
+}
+
+func (r *PeersStatusResult) GetQuietOutput() []string {
+	return []string{r.ID}
+}
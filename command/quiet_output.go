@@ -0,0 +1,42 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// QuietOutput writes the essential values of the results only, one per line, and the errors,
+// the results without essential values are not written
+type QuietOutput struct {
+	commonOutputFormatter
+}
+
+func newQuietOutput() *QuietOutput {
+	return &QuietOutput{}
+}
+
+func (qo *QuietOutput) WriteOutput() {
+	if qo.errorOutput != nil {
+		_, _ = fmt.Fprintln(os.Stderr, qo.getErrorOutput())
+
+		return
+	}
+
+	if output := qo.getCommandOutput(); output != "" {
+		_, _ = fmt.Fprintln(os.Stdout, output)
+	}
+}
+
+func (qo *QuietOutput) getErrorOutput() string {
+	return qo.errorOutput.Error()
+}
+
+func (qo *QuietOutput) getCommandOutput() string {
+	quiet, ok := qo.commandOutput.(QuietResult)
+	if !ok {
+		return ""
+	}
+
+	return strings.Join(quiet.GetQuietOutput(), "\n")
+}
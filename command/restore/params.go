@@ -0,0 +1,83 @@
+package restore
+
+import (
+	"path/filepath"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	inFlag         = "in"
+	dataDirFlag    = "data-dir"
+	s3EndpointFlag = "s3-endpoint"
+)
+
+var (
+	params = &restoreParams{}
+)
+
+type restoreParams struct {
+	in         string
+	dataDir    string
+	s3Endpoint string
+
+	summary  *archive.SnapshotSummary
+	head     uint64
+	headHash types.Hash
+}
+
+func (p *restoreParams) getRequiredFlags() []string {
+	return []string{
+		inFlag,
+		dataDirFlag,
+	}
+}
+
+func (p *restoreParams) restoreSnapshot() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "restore",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	source, err := archive.OpenSnapshotSource(p.in, p.s3Endpoint)
+	if err != nil {
+		return err
+	}
+
+	defer source.Close()
+
+	if p.summary, err = archive.RestoreSnapshot(source, logger, p.dataDir); err != nil {
+		return err
+	}
+
+	return p.readHead(logger)
+}
+
+// readHead reads the head of the restored chain
+func (p *restoreParams) readHead(logger hclog.Logger) error {
+	db, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	p.head, _ = db.ReadHeadNumber()
+	p.headHash, _ = db.ReadHeadHash()
+
+	return nil
+}
+
+func (p *restoreParams) getResult() command.CommandResult {
+	return &RestoreResult{
+		DataDir:  p.dataDir,
+		Entries:  p.summary.Entries,
+		Checksum: p.summary.Checksum,
+		Head:     p.head,
+		HeadHash: p.headHash.String(),
+	}
+}
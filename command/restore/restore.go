@@ -0,0 +1,56 @@
+package restore
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore a snapshot created by the backup --snapshot command into a fresh data directory",
+		Run:   runCommand,
+	}
+
+	setFlags(restoreCmd)
+	helper.SetRequiredFlags(restoreCmd, params.getRequiredFlags())
+
+	return restoreCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.in,
+		inFlag,
+		"",
+		"the path of the snapshot, or its s3://bucket/key location",
+	)
+
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory to restore into, it must not hold the databases yet",
+	)
+
+	cmd.Flags().StringVar(
+		&params.s3Endpoint,
+		s3EndpointFlag,
+		"",
+		"the endpoint of the S3 compatible store, the AWS S3 one if empty",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.restoreSnapshot(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
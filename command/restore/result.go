@@ -0,0 +1,32 @@
+package restore
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RestoreResult struct {
+	DataDir  string `json:"dataDir"`
+	Entries  uint64 `json:"entries"`
+	Checksum string `json:"checksum"`
+	Head     uint64 `json:"head"`
+	HeadHash string `json:"headHash"`
+}
+
+func (r *RestoreResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[RESTORE]\n")
+	buffer.WriteString("Restored snapshot successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Data directory|%s", r.DataDir),
+		fmt.Sprintf("Entries|%d", r.Entries),
+		fmt.Sprintf("Checksum|%s", r.Checksum),
+		fmt.Sprintf("Head|%d", r.Head),
+		fmt.Sprintf("Head hash|%s", r.HeadHash),
+	}))
+
+	return buffer.String()
+}
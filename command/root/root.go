@@ -4,18 +4,30 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/0xPolygon/polygon-edge/command/abigen"
+	"github.com/0xPolygon/polygon-edge/command/attach"
 	"github.com/0xPolygon/polygon-edge/command/backup"
+	"github.com/0xPolygon/polygon-edge/command/chain"
+	"github.com/0xPolygon/polygon-edge/command/completion"
+	"github.com/0xPolygon/polygon-edge/command/debug"
+	"github.com/0xPolygon/polygon-edge/command/devnet"
 	"github.com/0xPolygon/polygon-edge/command/genesis"
+	"github.com/0xPolygon/polygon-edge/command/halt"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/ibft"
 	"github.com/0xPolygon/polygon-edge/command/license"
 	"github.com/0xPolygon/polygon-edge/command/loadbot"
 	"github.com/0xPolygon/polygon-edge/command/monitor"
 	"github.com/0xPolygon/polygon-edge/command/peers"
+	"github.com/0xPolygon/polygon-edge/command/restore"
 	"github.com/0xPolygon/polygon-edge/command/secrets"
 	"github.com/0xPolygon/polygon-edge/command/server"
+	"github.com/0xPolygon/polygon-edge/command/simulate"
+	"github.com/0xPolygon/polygon-edge/command/state"
 	"github.com/0xPolygon/polygon-edge/command/status"
 	"github.com/0xPolygon/polygon-edge/command/txpool"
+	"github.com/0xPolygon/polygon-edge/command/upgrade"
+	"github.com/0xPolygon/polygon-edge/command/validator"
 	"github.com/0xPolygon/polygon-edge/command/version"
 	"github.com/0xPolygon/polygon-edge/command/whitelist"
 	"github.com/spf13/cobra"
@@ -29,10 +41,16 @@ func NewRootCommand() *RootCommand {
 	rootCommand := &RootCommand{
 		baseCmd: &cobra.Command{
 			Short: "Polygon Edge is a framework for building Ethereum-compatible Blockchain networks",
+			// the completion command generates the scripts of the supported shells only
+			CompletionOptions: cobra.CompletionOptions{
+				DisableDefaultCmd: true,
+			},
 		},
 	}
 
 	helper.RegisterJSONOutputFlag(rootCommand.baseCmd)
+	helper.RegisterTableOutputFlag(rootCommand.baseCmd)
+	helper.RegisterQuietOutputFlag(rootCommand.baseCmd)
 
 	rootCommand.registerSubCommands()
 
@@ -50,10 +68,22 @@ func (rc *RootCommand) registerSubCommands() {
 		loadbot.GetCommand(),
 		ibft.GetCommand(),
 		backup.GetCommand(),
+		restore.GetCommand(),
+		chain.GetCommand(),
+		state.GetCommand(),
 		genesis.GetCommand(),
 		server.GetCommand(),
 		whitelist.GetCommand(),
 		license.GetCommand(),
+		validator.GetCommand(),
+		debug.GetCommand(),
+		abigen.GetCommand(),
+		simulate.GetCommand(),
+		devnet.GetCommand(),
+		upgrade.GetCommand(),
+		halt.GetCommand(),
+		attach.GetCommand(),
+		completion.GetCommand(),
 	)
 }
 
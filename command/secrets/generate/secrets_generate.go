@@ -12,9 +12,10 @@ import (
 
 func GetCommand() *cobra.Command {
 	secretsGenerateCmd := &cobra.Command{
-		Use:   "generate",
-		Short: "Initializes the secrets manager configuration in the provided directory.",
-		Run:   runCommand,
+		Use:     "generate",
+		Aliases: []string{"gen"},
+		Short:   "Initializes the secrets manager configuration in the provided directory.",
+		Run:     runCommand,
 	}
 
 	setFlags(secretsGenerateCmd)
@@ -57,6 +58,14 @@ func setFlags(cmd *cobra.Command) {
 		),
 	)
 
+	helper.RegisterFlagValues(
+		cmd,
+		typeFlag,
+		string(secrets.HashicorpVault),
+		string(secrets.AWSSSM),
+		string(secrets.GCPSSM),
+	)
+
 	cmd.Flags().StringVar(
 		&params.name,
 		nameFlag,
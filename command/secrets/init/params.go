@@ -14,6 +14,7 @@ const (
 	ecdsaFlag   = "ecdsa"
 	blsFlag     = "bls"
 	networkFlag = "network"
+	ownerFlag   = "owner"
 	numFlag     = "num"
 )
 
@@ -29,6 +30,7 @@ type initParams struct {
 	generatesECDSA   bool
 	generatesBLS     bool
 	generatesNetwork bool
+	generatesOwner   bool
 
 	secretsManager secrets.SecretsManager
 	secretsConfig  *secrets.SecretsManagerConfig
@@ -114,6 +116,12 @@ func (ip *initParams) initValidatorKey() error {
 		}
 	}
 
+	if ip.generatesOwner {
+		if _, err = helper.InitValidatorOwnerKey(ip.secretsManager); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -142,6 +150,10 @@ func (ip *initParams) getResult() (command.CommandResult, error) {
 		return nil, err
 	}
 
+	if res.OwnerAddress, err = helper.LoadValidatorOwnerAddress(ip.secretsManager); err != nil {
+		return nil, err
+	}
+
 	if res.NodeID, err = helper.LoadNodeID(ip.secretsManager); err != nil {
 		return nil, err
 	}
@@ -23,15 +23,16 @@ func (r Results) GetOutput() string {
 }
 
 type SecretsInitResult struct {
-	Address   types.Address `json:"address"`
-	BLSPubkey string        `json:"bls_pubkey"`
-	NodeID    string        `json:"node_id"`
+	Address      types.Address `json:"address"`
+	BLSPubkey    string        `json:"bls_pubkey"`
+	OwnerAddress types.Address `json:"owner_address"`
+	NodeID       string        `json:"node_id"`
 }
 
 func (r *SecretsInitResult) GetOutput() string {
 	var buffer bytes.Buffer
 
-	vals := make([]string, 0, 3)
+	vals := make([]string, 0, 4)
 
 	vals = append(
 		vals,
@@ -45,6 +46,13 @@ func (r *SecretsInitResult) GetOutput() string {
 		)
 	}
 
+	if r.OwnerAddress != types.ZeroAddress {
+		vals = append(
+			vals,
+			fmt.Sprintf("Owner address|%s", r.OwnerAddress.String()),
+		)
+	}
+
 	vals = append(vals, fmt.Sprintf("Node ID|%s", r.NodeID))
 
 	buffer.WriteString("\n[SECRETS INIT]\n")
@@ -84,6 +84,14 @@ func setFlags(cmd *cobra.Command) {
 		true,
 		"the flag indicating whether new BLS key is created",
 	)
+
+	cmd.Flags().BoolVar(
+		&basicParams.generatesOwner,
+		ownerFlag,
+		false,
+		"the flag indicating whether new owner key is created, "+
+			"controlling the stake of the validator apart from its validator key",
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -135,6 +143,7 @@ func newParamsList(params initParams, num int) []initParams {
 			generatesECDSA:   params.generatesECDSA,
 			generatesBLS:     params.generatesBLS,
 			generatesNetwork: params.generatesNetwork,
+			generatesOwner:   params.generatesOwner,
 		}
 	}
 
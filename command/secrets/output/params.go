@@ -19,6 +19,7 @@ const (
 	validatorFlag = "validator"
 	blsFlag       = "bls"
 	nodeIDFlag    = "node-id"
+	ownerFlag     = "owner"
 )
 
 var (
@@ -38,12 +39,14 @@ type outputParams struct {
 	outputNodeID    bool
 	outputValidator bool
 	outputBLS       bool
+	outputOwner     bool
 
 	secretsManager secrets.SecretsManager
 	secretsConfig  *secrets.SecretsManagerConfig
 
 	validatorAddress string
 	blsPubkey        string
+	ownerAddress     string
 
 	nodeID string
 }
@@ -61,7 +64,7 @@ func (op *outputParams) initSecrets() error {
 		return err
 	}
 
-	outputAll := !(op.outputBLS || op.outputValidator || op.outputNodeID)
+	outputAll := !(op.outputBLS || op.outputValidator || op.outputNodeID || op.outputOwner)
 
 	if op.outputValidator || outputAll {
 		if err := op.initValidatorAddress(); err != nil || op.outputValidator {
@@ -75,6 +78,12 @@ func (op *outputParams) initSecrets() error {
 		}
 	}
 
+	if op.outputOwner || outputAll {
+		if err := op.initOwnerAddress(); err != nil || op.outputOwner {
+			return err
+		}
+	}
+
 	return op.initNodeID()
 }
 
@@ -159,6 +168,21 @@ func (op *outputParams) initValidatorAddress() error {
 	return nil
 }
 
+func (op *outputParams) initOwnerAddress() error {
+	ownerAddress, err := helper.LoadValidatorOwnerAddress(op.secretsManager)
+	if err != nil {
+		return err
+	}
+
+	if ownerAddress == types.ZeroAddress {
+		op.ownerAddress = ""
+	} else {
+		op.ownerAddress = ownerAddress.String()
+	}
+
+	return nil
+}
+
 func (op *outputParams) initBLSPublicKey() error {
 	blsPubkey, err := helper.LoadBLSPublicKey(op.secretsManager)
 	if err != nil {
@@ -200,9 +224,16 @@ func (op *outputParams) getResult() command.CommandResult {
 		}
 	}
 
+	if op.outputOwner {
+		return &SecretsOutputOwnerResult{
+			OwnerAddress: op.ownerAddress,
+		}
+	}
+
 	return &SecretsOutputAllResult{
-		BLSPubkey: op.blsPubkey,
-		NodeID:    op.nodeID,
-		Address:   op.validatorAddress,
+		BLSPubkey:    op.blsPubkey,
+		NodeID:       op.nodeID,
+		Address:      op.validatorAddress,
+		OwnerAddress: op.ownerAddress,
 	}
 }
@@ -9,9 +9,10 @@ import (
 
 // SecretsOutputAllResults for default output case
 type SecretsOutputAllResult struct {
-	Address   string `json:"address"`
-	BLSPubkey string `json:"bls"`
-	NodeID    string `json:"node_id"`
+	Address      string `json:"address"`
+	BLSPubkey    string `json:"bls"`
+	OwnerAddress string `json:"owner_address"`
+	NodeID       string `json:"node_id"`
 }
 
 // SecretsOutputNodeIDResults for `--node` output case
@@ -29,6 +30,11 @@ type SecretsOutputValidatorResult struct {
 	Address string `json:"address"`
 }
 
+// SecretsOutputOwnerResult for `--owner` output case
+type SecretsOutputOwnerResult struct {
+	OwnerAddress string `json:"owner_address"`
+}
+
 func (r *SecretsOutputNodeIDResult) GetOutput() string {
 	return r.NodeID
 }
@@ -41,10 +47,14 @@ func (r *SecretsOutputBLSResult) GetOutput() string {
 	return r.BLSPubkey
 }
 
+func (r *SecretsOutputOwnerResult) GetOutput() string {
+	return r.OwnerAddress
+}
+
 func (r *SecretsOutputAllResult) GetOutput() string {
 	var buffer bytes.Buffer
 
-	vals := make([]string, 0, 3)
+	vals := make([]string, 0, 4)
 
 	vals = append(
 		vals,
@@ -56,6 +66,13 @@ func (r *SecretsOutputAllResult) GetOutput() string {
 		fmt.Sprintf("BLS Public key|%s", r.BLSPubkey),
 	)
 
+	if r.OwnerAddress != "" {
+		vals = append(
+			vals,
+			fmt.Sprintf("Owner address|%s", r.OwnerAddress),
+		)
+	}
+
 	vals = append(
 		vals,
 		fmt.Sprintf("Node ID|%s", r.NodeID),
@@ -58,8 +58,16 @@ func setFlags(cmd *cobra.Command) {
 			"from the provided secrets manager",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.outputOwner,
+		ownerFlag,
+		false,
+		"output only the owner key address "+
+			"from the provided secrets manager",
+	)
+
 	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
-	cmd.MarkFlagsMutuallyExclusive(nodeIDFlag, validatorFlag, blsFlag)
+	cmd.MarkFlagsMutuallyExclusive(nodeIDFlag, validatorFlag, blsFlag, ownerFlag)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -15,45 +18,98 @@ import (
 type Config struct {
 	GenesisPath              string     `json:"chain_config" yaml:"chain_config"`
 	SecretsConfigPath        string     `json:"secrets_config" yaml:"secrets_config"`
+	NotifierConfigPath       string     `json:"notifier_config" yaml:"notifier_config"`
 	DataDir                  string     `json:"data_dir" yaml:"data_dir"`
 	BlockGasTarget           string     `json:"block_gas_target" yaml:"block_gas_target"`
 	GRPCAddr                 string     `json:"grpc_addr" yaml:"grpc_addr"`
 	JSONRPCAddr              string     `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
+	RESTAddr                 string     `json:"rest_addr" yaml:"rest_addr"`
 	Telemetry                *Telemetry `json:"telemetry" yaml:"telemetry"`
 	Network                  *Network   `json:"network" yaml:"network"`
 	ShouldSeal               bool       `json:"seal" yaml:"seal"`
+	ExtraDataVersion         bool       `json:"extra_data_version" yaml:"extra_data_version"`
 	TxPool                   *TxPool    `json:"tx_pool" yaml:"tx_pool"`
 	LogLevel                 string     `json:"log_level" yaml:"log_level"`
 	RestoreFile              string     `json:"restore_file" yaml:"restore_file"`
 	BlockTime                uint64     `json:"block_time_s" yaml:"block_time_s"`
+	TxExecutionBudget        uint64     `json:"tx_execution_budget_ms" yaml:"tx_execution_budget_ms"`
+	StateRegenBudget         uint64     `json:"state_regen_budget" yaml:"state_regen_budget"`
+	StorageSweepBudget       uint64     `json:"storage_sweep_budget" yaml:"storage_sweep_budget"`
+	StorageSweepDelay        uint64     `json:"storage_sweep_delay" yaml:"storage_sweep_delay"`
 	Headers                  *Headers   `json:"headers" yaml:"headers"`
 	LogFilePath              string     `json:"log_to" yaml:"log_to"`
 	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
 	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONRPCNonceManager      bool       `json:"json_rpc_nonce_manager" yaml:"json_rpc_nonce_manager"`
+	JSONRPCFilterTimeout     uint64     `json:"json_rpc_filter_timeout_s" yaml:"json_rpc_filter_timeout_s"`
+	JSONRPCMaxClientFilters  uint64     `json:"json_rpc_max_client_filters" yaml:"json_rpc_max_client_filters"`
+	JSONRPCPersistFilters    bool       `json:"json_rpc_persist_filters" yaml:"json_rpc_persist_filters"`
+	JSONRPCExplorerCompat    bool       `json:"json_rpc_explorer_compat" yaml:"json_rpc_explorer_compat"`
+	JSONRPCReadConcurrency   uint64     `json:"json_rpc_read_concurrency" yaml:"json_rpc_read_concurrency"`
+	JSONRPCCallConcurrency   uint64     `json:"json_rpc_call_concurrency" yaml:"json_rpc_call_concurrency"`
+	JSONRPCTraceConcurrency  uint64     `json:"json_rpc_trace_concurrency" yaml:"json_rpc_trace_concurrency"`
+	JSONRPCCallCacheSize     uint64     `json:"json_rpc_call_cache_size" yaml:"json_rpc_call_cache_size"`
+	JSONRPCIPCPath           string     `json:"json_rpc_ipc_path" yaml:"json_rpc_ipc_path"`
 	JSONLogFormat            bool       `json:"json_log_format" yaml:"json_log_format"`
+	LightServer              bool       `json:"light_server" yaml:"light_server"`
+	Indexer                  bool       `json:"indexer" yaml:"indexer"`
+	IndexerTraceBlocks       uint64     `json:"indexer_trace_blocks" yaml:"indexer_trace_blocks"`
+	Firehose                 string     `json:"firehose" yaml:"firehose"`
+	FirehoseTraces           bool       `json:"firehose_traces" yaml:"firehose_traces"`
+	ReferenceRPC             string     `json:"reference_rpc" yaml:"reference_rpc"`
+	ReferenceRPCHalt         bool       `json:"reference_rpc_halt" yaml:"reference_rpc_halt"`
+	StateDiffs               bool       `json:"state_diffs" yaml:"state_diffs"`
+	WarmCache                bool       `json:"warm_cache" yaml:"warm_cache"`
+	WarmCacheEntries         uint64     `json:"warm_cache_entries" yaml:"warm_cache_entries"`
+	TrieCacheSize            uint64     `json:"trie_cache_size_mb" yaml:"trie_cache_size_mb"`
+	BlobRetention            uint64     `json:"blob_retention" yaml:"blob_retention"`
+	AccountManager           bool       `json:"account_manager" yaml:"account_manager"`
+	ValidatorIntents         bool       `json:"validator_intents" yaml:"validator_intents"`
+	FailoverRole             string     `json:"failover_role" yaml:"failover_role"`
+	FailoverPartner          string     `json:"failover_partner" yaml:"failover_partner"`
+	Dev                      bool       `json:"dev" yaml:"dev"`
+	DevInterval              uint64     `json:"dev_interval" yaml:"dev_interval"`
+	ForkURL                  string     `json:"fork_url" yaml:"fork_url"`
+	ForkBlock                uint64     `json:"fork_block" yaml:"fork_block"`
+	ShutdownGracePeriod      uint64     `json:"shutdown_grace_period_s" yaml:"shutdown_grace_period_s"`
+	MaxProcs                 uint64     `json:"max_procs" yaml:"max_procs"`
+	SelfProfileThreshold     uint64     `json:"self_profile_threshold" yaml:"self_profile_threshold"`
+	Plugins                  []string   `json:"plugins" yaml:"plugins"`
 }
 
 // Telemetry holds the config details for metric services.
 type Telemetry struct {
 	PrometheusAddr string `json:"prometheus_addr" yaml:"prometheus_addr"`
+	PprofAddr      string `json:"pprof_addr" yaml:"pprof_addr"`
 }
 
 // Network defines the network configuration params
 type Network struct {
-	NoDiscover       bool   `json:"no_discover" yaml:"no_discover"`
-	Libp2pAddr       string `json:"libp2p_addr" yaml:"libp2p_addr"`
-	NatAddr          string `json:"nat_addr" yaml:"nat_addr"`
-	DNSAddr          string `json:"dns_addr" yaml:"dns_addr"`
-	MaxPeers         int64  `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
-	MaxOutboundPeers int64  `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
-	MaxInboundPeers  int64  `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+	NoDiscover       bool     `json:"no_discover" yaml:"no_discover"`
+	Libp2pAddr       string   `json:"libp2p_addr" yaml:"libp2p_addr"`
+	Libp2pAddr6      string   `json:"libp2p6_addr,omitempty" yaml:"libp2p6_addr,omitempty"`
+	NatAddr          string   `json:"nat_addr" yaml:"nat_addr"`
+	NatAddr6         string   `json:"nat6_addr,omitempty" yaml:"nat6_addr,omitempty"`
+	DNSAddr          string   `json:"dns_addr" yaml:"dns_addr"`
+	MaxPeers         int64    `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
+	MaxOutboundPeers int64    `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
+	MaxInboundPeers  int64    `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+	Sentries         []string `json:"sentries,omitempty" yaml:"sentries,omitempty"`
+	PrivatePeers     []string `json:"private_peers,omitempty" yaml:"private_peers,omitempty"`
+	Compressions     []string `json:"stream_compression,omitempty" yaml:"stream_compression,omitempty"`
+	AllowCIDRs       []string `json:"allow_cidrs,omitempty" yaml:"allow_cidrs,omitempty"`
+	DenyCIDRs        []string `json:"deny_cidrs,omitempty" yaml:"deny_cidrs,omitempty"`
+	AllowPeers       []string `json:"allow_peers,omitempty" yaml:"allow_peers,omitempty"`
+	DenyPeers        []string `json:"deny_peers,omitempty" yaml:"deny_peers,omitempty"`
+	PSKFile          string   `json:"psk_file,omitempty" yaml:"psk_file,omitempty"`
 }
 
 // TxPool defines the TxPool configuration params
 type TxPool struct {
-	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
-	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
-	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PriceLimit         uint64   `json:"price_limit" yaml:"price_limit"`
+	MaxSlots           uint64   `json:"max_slots" yaml:"max_slots"`
+	MaxAccountEnqueued uint64   `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PrivateTxPeers     []string `json:"private_tx_peers" yaml:"private_tx_peers"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -75,6 +131,45 @@ const (
 	// DefaultJSONRPCBlockRangeLimit maximum block range allowed for json_rpc
 	// requests with fromBlock/toBlock values (e.g. eth_getLogs)
 	DefaultJSONRPCBlockRangeLimit uint64 = 1000
+
+	// DefaultJSONRPCFilterTimeout time in seconds after which
+	// the filters not polled with eth_getFilterChanges are removed
+	DefaultJSONRPCFilterTimeout uint64 = 60
+
+	// DefaultJSONRPCMaxClientFilters maximum number of polling filters
+	// installed from a client address
+	DefaultJSONRPCMaxClientFilters uint64 = 100
+
+	// DefaultIndexerTraceBlocks number of the most recent blocks
+	// whose call trees are kept by the indexer
+	DefaultIndexerTraceBlocks uint64 = 128
+
+	// DefaultWarmCacheEntries number of the most accessed accounts
+	// and contract codes preloaded at startup
+	DefaultWarmCacheEntries uint64 = 1024
+
+	// DefaultTrieCacheSize size in MB of the cache of the trie nodes read from the storage
+	DefaultTrieCacheSize uint64 = 128
+
+	// DefaultBlobRetention number of the most recent blocks
+	// whose blob sidecars are kept
+	DefaultBlobRetention uint64 = 131072
+
+	// DefaultTxExecutionBudget maximum execution time in milliseconds
+	// of a transaction while building a block
+	DefaultTxExecutionBudget uint64 = 500
+
+	// DefaultStateRegenBudget maximum number of blocks replayed
+	// to regenerate a missing state for the historical calls
+	DefaultStateRegenBudget uint64 = 128
+
+	// DefaultStorageSweepDelay number of blocks an account must stay deleted
+	// before its storage is swept
+	DefaultStorageSweepDelay uint64 = 128
+
+	// DefaultShutdownGracePeriod time in seconds the node has to drain and close on a termination signal,
+	// below the default termination grace period of the Kubernetes pods
+	DefaultShutdownGracePeriod uint64 = 25
 )
 
 // DefaultConfig returns the default server configuration
@@ -105,12 +200,24 @@ func DefaultConfig() *Config {
 		LogLevel:    "INFO",
 		RestoreFile: "",
 		BlockTime:   DefaultBlockTime,
+
+		TxExecutionBudget: DefaultTxExecutionBudget,
+		StateRegenBudget:  DefaultStateRegenBudget,
+		StorageSweepDelay: DefaultStorageSweepDelay,
 		Headers: &Headers{
 			AccessControlAllowOrigins: []string{"*"},
 		},
 		LogFilePath:              "",
 		JSONRPCBatchRequestLimit: DefaultJSONRPCBatchRequestLimit,
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
+		JSONRPCFilterTimeout:     DefaultJSONRPCFilterTimeout,
+		JSONRPCMaxClientFilters:  DefaultJSONRPCMaxClientFilters,
+		IndexerTraceBlocks:       DefaultIndexerTraceBlocks,
+		ReferenceRPCHalt:         true,
+		WarmCacheEntries:         DefaultWarmCacheEntries,
+		TrieCacheSize:            DefaultTrieCacheSize,
+		BlobRetention:            DefaultBlobRetention,
+		ShutdownGracePeriod:      DefaultShutdownGracePeriod,
 	}
 }
 
@@ -119,6 +226,16 @@ func DefaultConfig() *Config {
 //
 // Supported file types: .json, .hcl, .yaml, .yml
 func ReadConfigFile(path string) (*Config, error) {
+	return readConfigFile(path, false)
+}
+
+// ReadConfigFileStrict reads the config file as ReadConfigFile,
+// and rejects the unknown keys of the JSON and YAML files
+func ReadConfigFileStrict(path string) (*Config, error) {
+	return readConfigFile(path, true)
+}
+
+func readConfigFile(path string, strict bool) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -131,8 +248,32 @@ func ReadConfigFile(path string) (*Config, error) {
 		unmarshalFunc = hcl.Unmarshal
 	case strings.HasSuffix(path, ".json"):
 		unmarshalFunc = json.Unmarshal
+
+		if strict {
+			unmarshalFunc = func(data []byte, v interface{}) error {
+				decoder := json.NewDecoder(bytes.NewReader(data))
+				decoder.DisallowUnknownFields()
+
+				return decoder.Decode(v)
+			}
+		}
 	case strings.HasSuffix(path, ".yaml"), strings.HasSuffix(path, ".yml"):
 		unmarshalFunc = yaml.Unmarshal
+
+		if strict {
+			unmarshalFunc = func(data []byte, v interface{}) error {
+				decoder := yaml.NewDecoder(bytes.NewReader(data))
+				decoder.KnownFields(true)
+
+				err := decoder.Decode(v)
+				if errors.Is(err, io.EOF) {
+					// empty file
+					return nil
+				}
+
+				return err
+			}
+		}
 	default:
 		return nil, fmt.Errorf("suffix of %s is neither hcl, json, yaml nor yml", path)
 	}
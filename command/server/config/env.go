@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding the config
+const EnvPrefix = "EDGE_"
+
+// ApplyEnv overrides the config with the environment variables named after its keys,
+// such as EDGE_DATA_DIR for data_dir and EDGE_NETWORK_MAX_PEERS for max_peers of network.
+// The lists are comma separated
+func (c *Config) ApplyEnv(lookup func(string) (string, bool)) error {
+	return applyEnv(reflect.ValueOf(c).Elem(), EnvPrefix, lookup)
+}
+
+func applyEnv(v reflect.Value, prefix string, lookup func(string) (string, bool)) error {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+
+		name := prefix + strings.ToUpper(key)
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+			if field.IsNil() {
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+
+			if err := applyEnv(field.Elem(), name+"_", lookup); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		raw, ok := lookup(name)
+		if !ok {
+			continue
+		}
+
+		if err := setFromEnv(field, raw); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func setFromEnv(field reflect.Value, raw string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(value)
+	case reflect.Uint64:
+		value, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(value)
+	case reflect.Int64:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(value)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", field.Type())
+		}
+
+		values := []string{}
+
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+
+		field.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
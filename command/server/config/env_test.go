@@ -0,0 +1,140 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lookupFrom(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+
+		return value, ok
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		env    map[string]string
+		expect func(c *Config)
+	}{
+		{
+			name:   "no variables",
+			env:    map[string]string{},
+			expect: func(c *Config) {},
+		},
+		{
+			name: "top level keys",
+			env: map[string]string{
+				"EDGE_DATA_DIR":       "/data",
+				"EDGE_SEAL":           "false",
+				"EDGE_BLOCK_TIME_S":   "5",
+				"EDGE_DEV":            "1",
+				"EDGE_PLUGINS":        "a.so, ,b.so",
+				"EDGE_UNKNOWN_KEY":    "ignored",
+				"data_dir":            "ignored",
+				"EDGE_NETWORK":        "ignored",
+				"EDGE_LOG_LEVEL":      "DEBUG",
+				"EDGE_FORK_URL":       "http://localhost:8545",
+				"EDGE_FORK_BLOCK":     "100",
+				"EDGE_DEV_INTERVAL":   "0",
+				"EDGE_RESTORE_FILE":   "",
+				"EDGE_CHAIN_CONFIG":   "genesis.json",
+				"EDGE_BLOB_RETENTION": "10",
+			},
+			expect: func(c *Config) {
+				c.DataDir = "/data"
+				c.ShouldSeal = false
+				c.BlockTime = 5
+				c.Dev = true
+				c.Plugins = []string{"a.so", "b.so"}
+				c.LogLevel = "DEBUG"
+				c.ForkURL = "http://localhost:8545"
+				c.ForkBlock = 100
+				c.DevInterval = 0
+				c.RestoreFile = ""
+				c.GenesisPath = "genesis.json"
+				c.BlobRetention = 10
+			},
+		},
+		{
+			name: "nested keys",
+			env: map[string]string{
+				"EDGE_NETWORK_MAX_PEERS":                    "-1",
+				"EDGE_NETWORK_NO_DISCOVER":                  "true",
+				"EDGE_TX_POOL_MAX_SLOTS":                    "10",
+				"EDGE_TELEMETRY_PROMETHEUS_ADDR":            ":5001",
+				"EDGE_HEADERS_ACCESS_CONTROL_ALLOW_ORIGINS": "",
+			},
+			expect: func(c *Config) {
+				c.Network.MaxPeers = -1
+				c.Network.NoDiscover = true
+				c.TxPool.MaxSlots = 10
+				c.Telemetry.PrometheusAddr = ":5001"
+				c.Headers.AccessControlAllowOrigins = []string{}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := DefaultConfig()
+			assert.NoError(t, config.ApplyEnv(lookupFrom(test.env)))
+
+			expected := DefaultConfig()
+			test.expect(expected)
+
+			assert.Equal(t, expected, config)
+		})
+	}
+}
+
+func TestApplyEnv_NilSection(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{}
+
+	assert.NoError(t, config.ApplyEnv(lookupFrom(map[string]string{"EDGE_NETWORK_MAX_PEERS": "5"})))
+	assert.Equal(t, int64(5), config.Network.MaxPeers)
+
+	// the sections without variables are created empty
+	assert.Equal(t, &TxPool{}, config.TxPool)
+}
+
+func TestApplyEnv_Malformed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"EDGE_SEAL", "yes"},
+		{"EDGE_SEAL", ""},
+		{"EDGE_BLOCK_TIME_S", "-1"},
+		{"EDGE_BLOCK_TIME_S", "2s"},
+		{"EDGE_BLOCK_TIME_S", "18446744073709551616"},
+		{"EDGE_NETWORK_MAX_PEERS", "many"},
+		{"EDGE_NETWORK_MAX_PEERS", "1.5"},
+		{"EDGE_TX_POOL_PRICE_LIMIT", "0x10"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name+"="+test.value, func(t *testing.T) {
+			t.Parallel()
+
+			err := DefaultConfig().ApplyEnv(lookupFrom(map[string]string{test.name: test.value}))
+
+			assert.ErrorContains(t, err, "invalid "+test.name)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configTypeFlag = "type"
+)
+
+var (
+	errConfigFileMissing = fmt.Errorf("the config file is not set, use --%s or %sCONFIG", configFlag, config.EnvPrefix)
+	errInvalidConfigType = errors.New("invalid file type, only yaml and json are supported")
+)
+
+var (
+	validateConfigPath string
+	defaultsConfigType string
+)
+
+func getConfigCommand() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Top level command for validating and printing the server config file",
+	}
+
+	configCmd.AddCommand(
+		// server config validate
+		getConfigValidateCommand(),
+		// server config print-defaults
+		getConfigDefaultsCommand(),
+	)
+
+	return configCmd
+}
+
+func getConfigValidateCommand() *cobra.Command {
+	validateCmd := &cobra.Command{
+		Use: "validate",
+		Short: "Validates the server config file, with the EDGE_* environment variables applied. " +
+			"The unknown keys of the json and yaml files are rejected",
+		Run: runConfigValidateCommand,
+	}
+
+	validateCmd.Flags().StringVar(
+		&validateConfigPath,
+		configFlag,
+		"",
+		fmt.Sprintf("the path to the config file, defaults to %sCONFIG", config.EnvPrefix),
+	)
+
+	return validateCmd
+}
+
+func getConfigDefaultsCommand() *cobra.Command {
+	defaultsCmd := &cobra.Command{
+		Use:   "print-defaults",
+		Short: "Prints the server config file with the default parameters",
+		Run:   runConfigDefaultsCommand,
+	}
+
+	defaultsCmd.Flags().StringVar(
+		&defaultsConfigType,
+		configTypeFlag,
+		"yaml",
+		"file type of the printed config (yaml or json)",
+	)
+
+	helper.RegisterFlagValues(defaultsCmd, configTypeFlag, "yaml", "json")
+
+	return defaultsCmd
+}
+
+func runConfigValidateCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := validateConfig(cmd); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&configValidateResult{
+		ConfigPath: validateConfigPath,
+	})
+}
+
+// validateConfig parses the config file as the server does, with the flag addresses as defaults
+func validateConfig(cmd *cobra.Command) error {
+	if validateConfigPath == "" {
+		validateConfigPath = os.Getenv(config.EnvPrefix + "CONFIG")
+	}
+
+	if validateConfigPath == "" {
+		return errConfigFileMissing
+	}
+
+	p := &serverParams{
+		rawConfig:  config.DefaultConfig(),
+		configPath: validateConfigPath,
+	}
+
+	p.setRawGRPCAddress(helper.GetGRPCAddress(cmd))
+	p.setRawJSONRPCAddress(helper.GetJSONRPCAddress(cmd))
+
+	if err := p.initConfigFromFile(true); err != nil {
+		return err
+	}
+
+	if err := p.initConfigFromEnv(); err != nil {
+		return err
+	}
+
+	return p.initRawParams()
+}
+
+func runConfigDefaultsCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	data, err := marshalDefaultConfig(defaultsConfigType)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(&configDefaultsResult{
+		Config: string(data),
+	})
+}
+
+// marshalDefaultConfig encodes the default config, with the peer limits unset as in an exported config
+func marshalDefaultConfig(fileType string) ([]byte, error) {
+	defaultConfig := config.DefaultConfig()
+
+	defaultConfig.Network.MaxPeers = unsetPeersValue
+	defaultConfig.Network.MaxInboundPeers = unsetPeersValue
+	defaultConfig.Network.MaxOutboundPeers = unsetPeersValue
+
+	var (
+		data []byte
+		err  error
+	)
+
+	switch fileType {
+	case "yaml", "yml":
+		data, err = yaml.Marshal(defaultConfig)
+	case "json":
+		data, err = json.MarshalIndent(defaultConfig, "", "    ")
+	default:
+		return nil, errInvalidConfigType
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal config struct, %w", err)
+	}
+
+	return data, nil
+}
+
+type configValidateResult struct {
+	ConfigPath string `json:"config_path"`
+}
+
+func (r *configValidateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CONFIG VALID]\n")
+	buffer.WriteString(fmt.Sprintf("%s is a valid server config\n", r.ConfigPath))
+
+	return buffer.String()
+}
+
+type configDefaultsResult struct {
+	Config string `json:"config"`
+}
+
+func (r *configDefaultsResult) GetOutput() string {
+	return r.Config
+}
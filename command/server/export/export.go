@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -31,6 +32,8 @@ func setFlags(cmd *cobra.Command) {
 		"yaml",
 		"file type of exported config file (yaml or json)",
 	)
+
+	helper.RegisterFlagValues(cmd, fileTypeFlag, "yaml", "json")
 }
 
 func runGenerateConfigCommand(cmd *cobra.Command, _ []string) {
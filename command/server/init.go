@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"os"
 
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 
@@ -12,27 +13,95 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus"
 	"github.com/0xPolygon/polygon-edge/network"
+	networkGrpc "github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/notifier"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 var (
 	errInvalidBlockTime       = errors.New("invalid block time specified")
 	errDataDirectoryUndefined = errors.New("data directory not defined")
+	errInvalidFailoverRole    = errors.New(`invalid failover role, expected "active" or "standby"`)
+	errFailoverPartnerMissing = errors.New("the failover partner address is not set")
+	errSentryWithPrivatePeers = errors.New("a validator behind sentries can't have private peers")
+	errInvalidCompression     = errors.New("unsupported stream compression")
+	errForkWithoutDevMode     = errors.New("the chain can only be forked in dev mode")
+	errInvalidGracePeriod     = errors.New("invalid shutdown grace period specified")
 )
 
-func (p *serverParams) initConfigFromFile() error {
-	var parseErr error
+// initConfigFromFile overwrites the raw config, to which the flags are bound, with the config file
+func (p *serverParams) initConfigFromFile(strict bool) error {
+	read := config.ReadConfigFile
+	if strict {
+		read = config.ReadConfigFileStrict
+	}
 
-	if p.rawConfig, parseErr = config.ReadConfigFile(p.configPath); parseErr != nil {
-		return parseErr
+	fileConfig, err := read(p.configPath)
+	if err != nil {
+		return err
+	}
+
+	// the addresses omitted by the file keep the values of their flags
+	if fileConfig.GRPCAddr == "" {
+		fileConfig.GRPCAddr = p.rawConfig.GRPCAddr
 	}
 
+	if fileConfig.JSONRPCAddr == "" {
+		fileConfig.JSONRPCAddr = p.rawConfig.JSONRPCAddr
+	}
+
+	p.setRawConfig(fileConfig)
+
 	return nil
 }
 
+// initConfigFromEnv overrides the raw config with the EDGE_* environment variables
+func (p *serverParams) initConfigFromEnv() error {
+	return p.rawConfig.ApplyEnv(os.LookupEnv)
+}
+
+// setRawConfig overwrites the raw config, keeping the sections to which the flags are bound
+func (p *serverParams) setRawConfig(c *config.Config) {
+	var (
+		telemetryConfig = p.rawConfig.Telemetry
+		networkConfig   = p.rawConfig.Network
+		txPoolConfig    = p.rawConfig.TxPool
+		headersConfig   = p.rawConfig.Headers
+	)
+
+	*telemetryConfig = config.Telemetry{}
+	if c.Telemetry != nil {
+		*telemetryConfig = *c.Telemetry
+	}
+
+	*networkConfig = config.Network{}
+	if c.Network != nil {
+		*networkConfig = *c.Network
+	}
+
+	*txPoolConfig = config.TxPool{}
+	if c.TxPool != nil {
+		*txPoolConfig = *c.TxPool
+	}
+
+	*headersConfig = config.Headers{}
+	if c.Headers != nil {
+		*headersConfig = *c.Headers
+	}
+
+	*p.rawConfig = *c
+
+	p.rawConfig.Telemetry = telemetryConfig
+	p.rawConfig.Network = networkConfig
+	p.rawConfig.TxPool = txPoolConfig
+	p.rawConfig.Headers = headersConfig
+}
+
 func (p *serverParams) initRawParams() error {
 	if err := p.initBlockGasTarget(); err != nil {
 		return err
@@ -42,6 +111,10 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initNotifierConfig(); err != nil {
+		return err
+	}
+
 	if err := p.initGenesisConfig(); err != nil {
 		return err
 	}
@@ -54,16 +127,144 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
-	if p.isDevMode {
+	if err := p.initShutdownGracePeriod(); err != nil {
+		return err
+	}
+
+	if p.rawConfig.Dev {
 		p.initDevMode()
 	}
 
+	if err := p.initFork(); err != nil {
+		return err
+	}
+
+	if err := p.initPrivateTxPeers(); err != nil {
+		return err
+	}
+
+	if err := p.initFailover(); err != nil {
+		return err
+	}
+
+	if err := p.initSentries(); err != nil {
+		return err
+	}
+
+	if err := p.initCompressions(); err != nil {
+		return err
+	}
+
+	if err := p.initACL(); err != nil {
+		return err
+	}
+
+	if err := p.initPSK(); err != nil {
+		return err
+	}
+
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
 	return p.initAddresses()
 }
 
+func (p *serverParams) initFailover() error {
+	switch consensus.FailoverRole(p.rawConfig.FailoverRole) {
+	case "":
+		return nil
+	case consensus.FailoverActive, consensus.FailoverStandby:
+	default:
+		return errInvalidFailoverRole
+	}
+
+	if p.rawConfig.FailoverPartner == "" {
+		return errFailoverPartnerMissing
+	}
+
+	return nil
+}
+
+func (p *serverParams) initACL() error {
+	var err error
+
+	p.acl, err = network.ParseACL(
+		p.rawConfig.Network.AllowCIDRs,
+		p.rawConfig.Network.DenyCIDRs,
+		p.rawConfig.Network.AllowPeers,
+		p.rawConfig.Network.DenyPeers,
+	)
+
+	return err
+}
+
+// initPSK sets the pre-shared key of a private network,
+// the key file of the node takes precedence over the key of the genesis
+func (p *serverParams) initPSK() error {
+	var err error
+
+	if p.rawConfig.Network.PSKFile != "" {
+		p.psk, err = network.ReadPSKFile(p.rawConfig.Network.PSKFile)
+
+		return err
+	}
+
+	if key := p.genesisConfig.Params.PrivateNetworkKey; key != "" {
+		p.psk, err = network.ParsePSK(key)
+	}
+
+	return err
+}
+
+func (p *serverParams) initCompressions() error {
+	for _, compression := range p.rawConfig.Network.Compressions {
+		if !networkGrpc.IsSupportedCompression(compression) {
+			return fmt.Errorf("%w: %q", errInvalidCompression, compression)
+		}
+	}
+
+	return nil
+}
+
+func (p *serverParams) initSentries() error {
+	if len(p.rawConfig.Network.Sentries) > 0 && len(p.rawConfig.Network.PrivatePeers) > 0 {
+		return errSentryWithPrivatePeers
+	}
+
+	for _, rawAddr := range p.rawConfig.Network.Sentries {
+		sentry, err := common.StringToAddrInfo(rawAddr)
+		if err != nil {
+			return fmt.Errorf("invalid sentry %q: %w", rawAddr, err)
+		}
+
+		p.sentries = append(p.sentries, sentry)
+	}
+
+	for _, rawID := range p.rawConfig.Network.PrivatePeers {
+		peerID, err := peer.Decode(rawID)
+		if err != nil {
+			return fmt.Errorf("invalid private peer %q: %w", rawID, err)
+		}
+
+		p.privatePeers = append(p.privatePeers, peerID)
+	}
+
+	return nil
+}
+
+func (p *serverParams) initPrivateTxPeers() error {
+	for _, rawID := range p.rawConfig.TxPool.PrivateTxPeers {
+		peerID, err := peer.Decode(rawID)
+		if err != nil {
+			return fmt.Errorf("invalid private tx peer %q: %w", rawID, err)
+		}
+
+		p.privateTxPeers = append(p.privateTxPeers, peerID)
+	}
+
+	return nil
+}
+
 func (p *serverParams) initBlockTime() error {
 	if p.rawConfig.BlockTime < 1 {
 		return errInvalidBlockTime
@@ -72,6 +273,14 @@ func (p *serverParams) initBlockTime() error {
 	return nil
 }
 
+func (p *serverParams) initShutdownGracePeriod() error {
+	if p.rawConfig.ShutdownGracePeriod < 1 {
+		return errInvalidGracePeriod
+	}
+
+	return nil
+}
+
 func (p *serverParams) initDataDirLocation() error {
 	if p.rawConfig.DataDir == "" {
 		return errDataDirectoryUndefined
@@ -114,6 +323,22 @@ func (p *serverParams) initSecretsConfig() error {
 	return nil
 }
 
+func (p *serverParams) initNotifierConfig() error {
+	if !p.isNotifierConfigPathSet() {
+		return nil
+	}
+
+	var parseErr error
+
+	if p.notifierConfig, parseErr = notifier.ReadConfig(
+		p.rawConfig.NotifierConfigPath,
+	); parseErr != nil {
+		return fmt.Errorf("unable to read notifier config file, %w", parseErr)
+	}
+
+	return nil
+}
+
 func (p *serverParams) initGenesisConfig() error {
 	var parseErr error
 
@@ -141,6 +366,18 @@ func (p *serverParams) initDevMode() {
 	p.initDevConsensusConfig()
 }
 
+func (p *serverParams) initFork() error {
+	if p.rawConfig.ForkURL == "" {
+		return nil
+	}
+
+	if !p.rawConfig.Dev {
+		return errForkWithoutDevMode
+	}
+
+	return nil
+}
+
 func (p *serverParams) initDevConsensusConfig() {
 	if !p.isDevConsensus() {
 		return
@@ -148,7 +385,7 @@ func (p *serverParams) initDevConsensusConfig() {
 
 	p.genesisConfig.Params.Engine = map[string]interface{}{
 		string(server.DevConsensus): map[string]interface{}{
-			"interval": p.devInterval,
+			"interval": p.rawConfig.DevInterval,
 		},
 	}
 }
@@ -212,14 +449,26 @@ func (p *serverParams) initAddresses() error {
 		return err
 	}
 
+	if err := p.initPprofAddress(); err != nil {
+		return err
+	}
+
 	if err := p.initLibp2pAddress(); err != nil {
 		return err
 	}
 
+	if err := p.initLibp2p6Address(); err != nil {
+		return err
+	}
+
 	if err := p.initNATAddress(); err != nil {
 		return err
 	}
 
+	if err := p.initNAT6Address(); err != nil {
+		return err
+	}
+
 	if err := p.initDNSAddress(); err != nil {
 		return err
 	}
@@ -228,6 +477,10 @@ func (p *serverParams) initAddresses() error {
 		return err
 	}
 
+	if err := p.initRESTAddress(); err != nil {
+		return err
+	}
+
 	return p.initGRPCAddress()
 }
 
@@ -248,6 +501,23 @@ func (p *serverParams) initPrometheusAddress() error {
 	return nil
 }
 
+func (p *serverParams) initPprofAddress() error {
+	if !p.isPprofAddressSet() {
+		return nil
+	}
+
+	var parseErr error
+
+	if p.pprofAddress, parseErr = helper.ResolveAddr(
+		p.rawConfig.Telemetry.PprofAddr,
+		helper.AllInterfacesBinding,
+	); parseErr != nil {
+		return parseErr
+	}
+
+	return nil
+}
+
 func (p *serverParams) initLibp2pAddress() error {
 	var parseErr error
 
@@ -261,6 +531,49 @@ func (p *serverParams) initLibp2pAddress() error {
 	return nil
 }
 
+func (p *serverParams) initLibp2p6Address() error {
+	if !p.isLibp2p6AddressSet() {
+		return nil
+	}
+
+	var parseErr error
+
+	if p.libp2p6Address, parseErr = helper.ResolveAddr(
+		p.rawConfig.Network.Libp2pAddr6,
+		helper.LocalHostBinding6,
+	); parseErr != nil {
+		return parseErr
+	}
+
+	if common.IPVersion(p.libp2p6Address.IP) != common.IPv6 {
+		return errInvalidLibp2p6
+	}
+
+	if common.IPVersion(p.libp2pAddress.IP) != common.IPv4 {
+		return errDualStackLibp2p
+	}
+
+	return nil
+}
+
+func (p *serverParams) initNAT6Address() error {
+	if !p.isNAT6AddressSet() {
+		return nil
+	}
+
+	if !p.isLibp2p6AddressSet() {
+		return errNAT6WithoutLibp2p6
+	}
+
+	if p.nat6Address = net.ParseIP(
+		p.rawConfig.Network.NatAddr6,
+	); p.nat6Address == nil || common.IPVersion(p.nat6Address) != common.IPv6 {
+		return errInvalidNAT6Address
+	}
+
+	return nil
+}
+
 func (p *serverParams) initNATAddress() error {
 	if !p.isNATAddressSet() {
 		return nil
@@ -304,6 +617,23 @@ func (p *serverParams) initJSONRPCAddress() error {
 	return nil
 }
 
+func (p *serverParams) initRESTAddress() error {
+	if !p.isRESTAddressSet() {
+		return nil
+	}
+
+	var parseErr error
+
+	if p.restAddress, parseErr = helper.ResolveAddr(
+		p.rawConfig.RESTAddr,
+		helper.AllInterfacesBinding,
+	); parseErr != nil {
+		return parseErr
+	}
+
+	return nil
+}
+
 func (p *serverParams) initGRPCAddress() error {
 	var parseErr error
 
@@ -3,13 +3,24 @@ package server
 import (
 	"errors"
 	"net"
+	"path/filepath"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/crosscheck"
+	"github.com/0xPolygon/polygon-edge/firehose"
+	"github.com/0xPolygon/polygon-edge/helper/resources"
+	"github.com/0xPolygon/polygon-edge/indexer"
 	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/notifier"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/pnet"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -18,26 +29,77 @@ const (
 	genesisPathFlag              = "chain"
 	dataDirFlag                  = "data-dir"
 	libp2pAddressFlag            = "libp2p"
+	libp2p6AddressFlag           = "libp2p6"
 	prometheusAddressFlag        = "prometheus"
+	restAddressFlag              = "rest"
 	natFlag                      = "nat"
+	nat6Flag                     = "nat6"
 	dnsFlag                      = "dns"
 	sealFlag                     = "seal"
+	extraDataVersionFlag         = "extra-data-version"
 	maxPeersFlag                 = "max-peers"
 	maxInboundPeersFlag          = "max-inbound-peers"
 	maxOutboundPeersFlag         = "max-outbound-peers"
 	priceLimitFlag               = "price-limit"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
+	jsonRPCNonceManagerFlag      = "json-rpc-nonce-manager"
+	jsonRPCFilterTimeoutFlag     = "json-rpc-filter-timeout"
+	jsonRPCMaxClientFiltersFlag  = "json-rpc-max-client-filters"
+	jsonRPCPersistFiltersFlag    = "json-rpc-persist-filters"
+	jsonRPCExplorerCompatFlag    = "json-rpc-explorer-compat"
+	jsonRPCReadConcurrencyFlag   = "json-rpc-read-concurrency"
+	jsonRPCCallConcurrencyFlag   = "json-rpc-call-concurrency"
+	jsonRPCTraceConcurrencyFlag  = "json-rpc-trace-concurrency"
+	jsonRPCCallCacheSizeFlag     = "json-rpc-call-cache-size"
+	jsonRPCIPCPathFlag           = "json-rpc-ipc-path"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
+	notifierConfigFlag           = "notifier-config"
 	restoreFlag                  = "restore"
 	blockTimeFlag                = "block-time"
+	txExecutionBudgetFlag        = "tx-execution-budget"
+	stateRegenBudgetFlag         = "state-regen-budget"
+	storageSweepBudgetFlag       = "storage-sweep-budget"
+	storageSweepDelayFlag        = "storage-sweep-delay"
 	devIntervalFlag              = "dev-interval"
 	devFlag                      = "dev"
+	forkURLFlag                  = "fork-url"
+	forkBlockFlag                = "fork-block"
 	corsOriginFlag               = "access-control-allow-origins"
 	logFileLocationFlag          = "log-to"
+	lightServerFlag              = "light-server"
+	indexerFlag                  = "indexer"
+	indexerTraceBlocksFlag       = "indexer-trace-blocks"
+	firehoseFlag                 = "firehose"
+	firehoseTracesFlag           = "firehose-traces"
+	referenceRPCFlag             = "reference-rpc"
+	referenceRPCHaltFlag         = "reference-rpc-halt"
+	stateDiffsFlag               = "state-diffs"
+	warmCacheFlag                = "warm-cache"
+	warmCacheEntriesFlag         = "warm-cache-entries"
+	trieCacheSizeFlag            = "trie-cache-size"
+	blobRetentionFlag            = "blob-retention"
+	privateTxPeersFlag           = "private-tx-peers"
+	accountManagerFlag           = "account-manager"
+	validatorIntentsFlag         = "validator-intents"
+	failoverRoleFlag             = "failover-role"
+	sentriesFlag                 = "sentries"
+	privatePeersFlag             = "private-peers"
+	streamCompressionFlag        = "stream-compression"
+	allowCIDRsFlag               = "allow-cidrs"
+	denyCIDRsFlag                = "deny-cidrs"
+	allowPeersFlag               = "allow-peers"
+	denyPeersFlag                = "deny-peers"
+	pskFileFlag                  = "psk-file"
+	failoverPartnerFlag          = "failover-partner"
+	shutdownGracePeriodFlag      = "shutdown-grace-period"
+	pluginsFlag                  = "plugins"
+	pprofAddressFlag             = "pprof"
+	maxProcsFlag                 = "max-procs"
+	selfProfileThresholdFlag     = "self-profile-threshold"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -56,12 +118,17 @@ var (
 			Telemetry: &config.Telemetry{},
 			Network:   &config.Network{},
 			TxPool:    &config.TxPool{},
+			Headers:   &config.Headers{},
 		},
 	}
 )
 
 var (
-	errInvalidNATAddress = errors.New("could not parse NAT IP address")
+	errInvalidNATAddress  = errors.New("could not parse NAT IP address")
+	errInvalidNAT6Address = errors.New("could not parse IPv6 NAT address")
+	errNAT6WithoutLibp2p6 = errors.New("the IPv6 NAT address requires the IPv6 libp2p address")
+	errInvalidLibp2p6     = errors.New("the IPv6 libp2p address is not an IPv6 address")
+	errDualStackLibp2p    = errors.New("the libp2p address of a dual-stack node must be an IPv4 address")
 )
 
 type serverParams struct {
@@ -69,23 +136,33 @@ type serverParams struct {
 	configPath string
 
 	libp2pAddress     *net.TCPAddr
+	libp2p6Address    *net.TCPAddr
 	prometheusAddress *net.TCPAddr
+	pprofAddress      *net.TCPAddr
 	natAddress        net.IP
+	nat6Address       net.IP
 	dnsAddress        multiaddr.Multiaddr
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
+	restAddress       *net.TCPAddr
 
 	blockGasTarget uint64
-	devInterval    uint64
-	isDevMode      bool
-
-	corsAllowedOrigins []string
 
 	ibftBaseTimeoutLegacy uint64
 
 	genesisConfig *chain.Chain
 	secretsConfig *secrets.SecretsManagerConfig
 
+	notifierConfig *notifier.Config
+
+	privateTxPeers []peer.ID
+
+	sentries     []*peer.AddrInfo
+	privatePeers []peer.ID
+
+	acl *network.ACL
+	psk pnet.PSK
+
 	logFileLocation string
 }
 
@@ -102,14 +179,34 @@ func (p *serverParams) isSecretsConfigPathSet() bool {
 	return p.rawConfig.SecretsConfigPath != ""
 }
 
+func (p *serverParams) isNotifierConfigPathSet() bool {
+	return p.rawConfig.NotifierConfigPath != ""
+}
+
 func (p *serverParams) isPrometheusAddressSet() bool {
 	return p.rawConfig.Telemetry.PrometheusAddr != ""
 }
 
+func (p *serverParams) isPprofAddressSet() bool {
+	return p.rawConfig.Telemetry.PprofAddr != ""
+}
+
+func (p *serverParams) isRESTAddressSet() bool {
+	return p.rawConfig.RESTAddr != ""
+}
+
 func (p *serverParams) isNATAddressSet() bool {
 	return p.rawConfig.Network.NatAddr != ""
 }
 
+func (p *serverParams) isLibp2p6AddressSet() bool {
+	return p.rawConfig.Network.Libp2pAddr6 != ""
+}
+
+func (p *serverParams) isNAT6AddressSet() bool {
+	return p.rawConfig.Network.NatAddr6 != ""
+}
+
 func (p *serverParams) isDNSAddressSet() bool {
 	return p.rawConfig.Network.DNSAddr != ""
 }
@@ -130,6 +227,82 @@ func (p *serverParams) getRestoreFilePath() *string {
 	return nil
 }
 
+func (p *serverParams) getIndexerConfig() *indexer.Config {
+	if !p.rawConfig.Indexer {
+		return nil
+	}
+
+	return &indexer.Config{
+		TraceBlocks: p.rawConfig.IndexerTraceBlocks,
+	}
+}
+
+func (p *serverParams) getFirehoseConfig() *firehose.Config {
+	if p.rawConfig.Firehose == "" {
+		return nil
+	}
+
+	return &firehose.Config{
+		Sink:   p.rawConfig.Firehose,
+		Traces: p.rawConfig.FirehoseTraces,
+	}
+}
+
+func (p *serverParams) getCrossCheckConfig() *crosscheck.Config {
+	if p.rawConfig.ReferenceRPC == "" {
+		return nil
+	}
+
+	return &crosscheck.Config{
+		URL:  p.rawConfig.ReferenceRPC,
+		Halt: p.rawConfig.ReferenceRPCHalt,
+	}
+}
+
+func (p *serverParams) getStorageSweepConfig() *itrie.SweepConfig {
+	if p.rawConfig.StorageSweepBudget == 0 {
+		return nil
+	}
+
+	return &itrie.SweepConfig{
+		Budget: p.rawConfig.StorageSweepBudget,
+		Delay:  p.rawConfig.StorageSweepDelay,
+	}
+}
+
+func (p *serverParams) getSelfProfileConfig() *resources.ProfilerConfig {
+	if p.rawConfig.SelfProfileThreshold == 0 {
+		return nil
+	}
+
+	return &resources.ProfilerConfig{
+		Dir:          filepath.Join(p.rawConfig.DataDir, "profiles"),
+		CPUThreshold: p.rawConfig.SelfProfileThreshold,
+	}
+}
+
+func (p *serverParams) getFailoverConfig() *consensus.FailoverConfig {
+	if p.rawConfig.FailoverRole == "" {
+		return nil
+	}
+
+	return &consensus.FailoverConfig{
+		Role:        consensus.FailoverRole(p.rawConfig.FailoverRole),
+		PartnerAddr: p.rawConfig.FailoverPartner,
+	}
+}
+
+func (p *serverParams) getForkConfig() *server.ForkConfig {
+	if p.rawConfig.ForkURL == "" {
+		return nil
+	}
+
+	return &server.ForkConfig{
+		URL:   p.rawConfig.ForkURL,
+		Block: p.rawConfig.ForkBlock,
+	}
+}
+
 func (p *serverParams) setRawGRPCAddress(grpcAddress string) {
 	p.rawConfig.GRPCAddr = grpcAddress
 }
@@ -147,36 +320,78 @@ func (p *serverParams) generateConfig() *server.Config {
 		Chain: p.genesisConfig,
 		JSONRPC: &server.JSONRPC{
 			JSONRPCAddr:              p.jsonRPCAddress,
-			AccessControlAllowOrigin: p.corsAllowedOrigins,
+			AccessControlAllowOrigin: p.rawConfig.Headers.AccessControlAllowOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			NonceManager:             p.rawConfig.JSONRPCNonceManager,
+			FilterTimeout:            time.Duration(p.rawConfig.JSONRPCFilterTimeout) * time.Second,
+			MaxFiltersPerClient:      p.rawConfig.JSONRPCMaxClientFilters,
+			PersistFilters:           p.rawConfig.JSONRPCPersistFilters,
+			ExplorerCompat:           p.rawConfig.JSONRPCExplorerCompat,
+			ReadConcurrency:          p.rawConfig.JSONRPCReadConcurrency,
+			CallConcurrency:          p.rawConfig.JSONRPCCallConcurrency,
+			TraceConcurrency:         p.rawConfig.JSONRPCTraceConcurrency,
+			CallCacheSize:            p.rawConfig.JSONRPCCallCacheSize,
+			IPCPath:                  p.rawConfig.JSONRPCIPCPath,
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
+		RESTAddr:   p.restAddress,
 		Telemetry: &server.Telemetry{
 			PrometheusAddr: p.prometheusAddress,
+			PprofAddr:      p.pprofAddress,
 		},
 		Network: &network.Config{
 			NoDiscover:       p.rawConfig.Network.NoDiscover,
 			Addr:             p.libp2pAddress,
+			Addr6:            p.libp2p6Address,
 			NatAddr:          p.natAddress,
+			NatAddr6:         p.nat6Address,
 			DNS:              p.dnsAddress,
 			DataDir:          p.rawConfig.DataDir,
 			MaxPeers:         p.rawConfig.Network.MaxPeers,
 			MaxInboundPeers:  p.rawConfig.Network.MaxInboundPeers,
 			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
 			Chain:            p.genesisConfig,
+			Sentries:         p.sentries,
+			PrivatePeers:     p.privatePeers,
+			Compressions:     p.rawConfig.Network.Compressions,
+			ACL:              p.acl,
+			PSK:              p.psk,
 		},
-		DataDir:            p.rawConfig.DataDir,
-		Seal:               p.rawConfig.ShouldSeal,
-		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
-		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
-		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
-		SecretsManager:     p.secretsConfig,
-		RestoreFile:        p.getRestoreFilePath(),
-		BlockTime:          p.rawConfig.BlockTime,
-		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
-		JSONLogFormat:      p.rawConfig.JSONLogFormat,
-		LogFilePath:        p.logFileLocation,
+		DataDir:             p.rawConfig.DataDir,
+		Seal:                p.rawConfig.ShouldSeal,
+		LightServer:         p.rawConfig.LightServer,
+		Indexer:             p.getIndexerConfig(),
+		Firehose:            p.getFirehoseConfig(),
+		CrossCheck:          p.getCrossCheckConfig(),
+		StateDiffs:          p.rawConfig.StateDiffs,
+		WarmCache:           p.rawConfig.WarmCache,
+		WarmCacheEntries:    int(p.rawConfig.WarmCacheEntries),
+		TrieCacheSize:       int(p.rawConfig.TrieCacheSize) * 1024 * 1024,
+		BlobRetention:       p.rawConfig.BlobRetention,
+		AccountManager:      p.rawConfig.AccountManager,
+		ValidatorIntents:    p.rawConfig.ValidatorIntents,
+		Plugins:             p.rawConfig.Plugins,
+		Fork:                p.getForkConfig(),
+		Failover:            p.getFailoverConfig(),
+		PriceLimit:          p.rawConfig.TxPool.PriceLimit,
+		MaxSlots:            p.rawConfig.TxPool.MaxSlots,
+		MaxAccountEnqueued:  p.rawConfig.TxPool.MaxAccountEnqueued,
+		PrivateTxPeers:      p.privateTxPeers,
+		SecretsManager:      p.secretsConfig,
+		Notifier:            p.notifierConfig,
+		RestoreFile:         p.getRestoreFilePath(),
+		BlockTime:           p.rawConfig.BlockTime,
+		TxExecutionBudget:   time.Duration(p.rawConfig.TxExecutionBudget) * time.Millisecond,
+		ExtraDataVersion:    p.rawConfig.ExtraDataVersion,
+		StateRegenBudget:    p.rawConfig.StateRegenBudget,
+		StorageSweep:        p.getStorageSweepConfig(),
+		ShutdownGracePeriod: time.Duration(p.rawConfig.ShutdownGracePeriod) * time.Second,
+		MaxProcs:            int(p.rawConfig.MaxProcs),
+		SelfProfile:         p.getSelfProfileConfig(),
+		LogLevel:            hclog.LevelFromString(p.rawConfig.LogLevel),
+		JSONLogFormat:       p.rawConfig.JSONLogFormat,
+		LogFilePath:         p.logFileLocation,
 	}
 }
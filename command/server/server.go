@@ -2,13 +2,18 @@ package server
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
 	"github.com/0xPolygon/polygon-edge/command/server/export"
+	networkGrpc "github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/plugins"
 	"github.com/0xPolygon/polygon-edge/server"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func GetCommand() *cobra.Command {
@@ -33,6 +38,8 @@ func registerSubcommands(baseCmd *cobra.Command) {
 	baseCmd.AddCommand(
 		// server export
 		export.GetCommand(),
+		// server config
+		getConfigCommand(),
 	)
 }
 
@@ -46,6 +53,8 @@ func setFlags(cmd *cobra.Command) {
 		"the log level for console output",
 	)
 
+	helper.RegisterFlagValues(cmd, command.LogLevelFlag, command.LogLevels...)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.GenesisPath,
 		genesisPathFlag,
@@ -57,7 +66,7 @@ func setFlags(cmd *cobra.Command) {
 		&params.configPath,
 		configFlag,
 		"",
-		"the path to the CLI config. Supports .json and .hcl",
+		"the path to the CLI config, overridden by the EDGE_* environment variables. Supports .json, .hcl, .yaml and .yml",
 	)
 
 	cmd.Flags().StringVar(
@@ -74,6 +83,14 @@ func setFlags(cmd *cobra.Command) {
 		"the address and port for the libp2p service",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.Network.Libp2pAddr6,
+		libp2p6AddressFlag,
+		defaultConfig.Network.Libp2pAddr6,
+		"the IPv6 address and port for the libp2p service of a dual-stack node, "+
+			"listened on along with the IPv4 libp2p address",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.Telemetry.PrometheusAddr,
 		prometheusAddressFlag,
@@ -82,6 +99,22 @@ func setFlags(cmd *cobra.Command) {
 			"If only port is defined (:port) it will bind to 0.0.0.0:port",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.Telemetry.PprofAddr,
+		pprofAddressFlag,
+		"",
+		"the address and port for the pprof profiling endpoint (address:port). "+
+			"If only port is defined (:port) it will bind to 0.0.0.0:port. The endpoint is disabled if omitted",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RESTAddr,
+		restAddressFlag,
+		"",
+		"the address and port for the REST gateway (address:port). "+
+			"If only port is defined (:port) it will bind to 0.0.0.0:port. The gateway is disabled if omitted",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.Network.NatAddr,
 		natFlag,
@@ -89,6 +122,13 @@ func setFlags(cmd *cobra.Command) {
 		"the external IP address without port, as can be seen by peers",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.Network.NatAddr6,
+		nat6Flag,
+		"",
+		"the external IPv6 address without port of a dual-stack node, as can be seen by peers",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.Network.DNSAddr,
 		dnsFlag,
@@ -100,7 +140,8 @@ func setFlags(cmd *cobra.Command) {
 		&params.rawConfig.BlockGasTarget,
 		blockGasTargetFlag,
 		defaultConfig.BlockGasTarget,
-		"the target block gas limit for the chain. If omitted, the value of the parent block is used",
+		"the target block gas limit for the chain, the proposed blocks move the gas limit toward it within "+
+			"the bounds of the genesis. If omitted, the value of the parent block is used",
 	)
 
 	cmd.Flags().StringVar(
@@ -111,6 +152,14 @@ func setFlags(cmd *cobra.Command) {
 			"If omitted, the local FS secrets manager is used",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.NotifierConfigPath,
+		notifierConfigFlag,
+		"",
+		"the path to the notifier config file with the webhooks notified of the new blocks, "+
+			"reorgs, logs and validator set changes. If omitted, no notifications are sent",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.RestoreFile,
 		restoreFlag,
@@ -125,6 +174,153 @@ func setFlags(cmd *cobra.Command) {
 		"the flag indicating that the client should seal blocks",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.ExtraDataVersion,
+		extraDataVersionFlag,
+		defaultConfig.ExtraDataVersion,
+		"the flag indicating that the client should embed its version and commit "+
+			"in the extra data of the blocks it seals",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.LightServer,
+		lightServerFlag,
+		defaultConfig.LightServer,
+		"the flag indicating that the client should serve headers and state proofs to light clients",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.Indexer,
+		indexerFlag,
+		defaultConfig.Indexer,
+		"the flag indicating that the client should index the transactions, internal transfers "+
+			"and token transfers by address, served by the index_ JSON-RPC namespace",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.IndexerTraceBlocks,
+		indexerTraceBlocksFlag,
+		defaultConfig.IndexerTraceBlocks,
+		"the number of the most recent blocks whose call trees are kept by the indexer",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.Firehose,
+		firehoseFlag,
+		defaultConfig.Firehose,
+		"the directory, the Kafka topic as kafka://<broker>[,<broker>...]/<topic>, or the url of a registered sink, "+
+			"the blocks with their transactions, receipts, call trees and state diffs are streamed to "+
+			"as protobuf records, disabled if empty. "+
+			"The state diffs are streamed if persisted",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.FirehoseTraces,
+		firehoseTracesFlag,
+		defaultConfig.FirehoseTraces,
+		"the flag indicating that the firehose should stream the call trees of the transactions",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.ReferenceRPC,
+		referenceRPCFlag,
+		defaultConfig.ReferenceRPC,
+		"the JSON-RPC endpoint of a reference client the state roots and receipts "+
+			"of the imported blocks are compared with, disabled if empty",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.ReferenceRPCHalt,
+		referenceRPCHaltFlag,
+		defaultConfig.ReferenceRPCHalt,
+		"the flag indicating that the client should refuse the blocks after a divergence "+
+			"from the reference client, until restarted",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StateDiffs,
+		stateDiffsFlag,
+		defaultConfig.StateDiffs,
+		"the flag indicating that the client should persist the accounts and storage slots "+
+			"changed by each block, served by debug_getStateDiff",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.WarmCache,
+		warmCacheFlag,
+		defaultConfig.WarmCache,
+		"the flag indicating that the client should track the most accessed accounts and contract codes, "+
+			"persist them at shutdown and preload them into the caches at startup",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.WarmCacheEntries,
+		warmCacheEntriesFlag,
+		defaultConfig.WarmCacheEntries,
+		"the number of the most accessed accounts and contract codes kept for the warm cache",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TrieCacheSize,
+		trieCacheSizeFlag,
+		defaultConfig.TrieCacheSize,
+		"the size in MB of the cache of the trie nodes read from the storage, "+
+			"besides the nodes of the most recent blocks. 0 disables the cache",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.BlobRetention,
+		blobRetentionFlag,
+		defaultConfig.BlobRetention,
+		"the number of the most recent blocks whose blob sidecars are kept, 0 to not keep them",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.AccountManager,
+		accountManagerFlag,
+		defaultConfig.AccountManager,
+		"the flag indicating that the client should manage accounts in its encrypted keystore, "+
+			"served by the personal_ JSON-RPC namespace and eth_sendTransaction. "+
+			"Only enable it on trusted deployments",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.ValidatorIntents,
+		validatorIntentsFlag,
+		defaultConfig.ValidatorIntents,
+		"the flag indicating that the client should exchange the off-chain intents of the validators, "+
+			"served by the intent_ JSON-RPC namespace. The validators sign their intents with the validator key",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Plugins,
+		pluginsFlag,
+		defaultConfig.Plugins,
+		fmt.Sprintf(
+			"the compiled-in plugins enabled, whose hooks vet the transactions admitted to the txpool "+
+				"and the blocks sealed and imported, in this order. The registered plugins are %v",
+			plugins.Registered(),
+		),
+	)
+
+	helper.RegisterFlagValues(cmd, pluginsFlag, plugins.Registered()...)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.FailoverRole,
+		failoverRoleFlag,
+		defaultConfig.FailoverRole,
+		"the role of the validator in an active/standby pair sharing the validator key, "+
+			"active or standby. Only one node of the pair signs, the other one taking over "+
+			"once the signing node is fenced",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.FailoverPartner,
+		failoverPartnerFlag,
+		defaultConfig.FailoverPartner,
+		"the gRPC address of the other node of the active/standby pair",
+	)
+
 	cmd.Flags().BoolVar(
 		&params.rawConfig.Network.NoDiscover,
 		command.NoDiscoverFlag,
@@ -132,6 +328,72 @@ func setFlags(cmd *cobra.Command) {
 		"prevent the client from discovering other peers",
 	)
 
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.Sentries,
+		sentriesFlag,
+		defaultConfig.Network.Sentries,
+		"the multiaddrs of the sentries of the validator, the only peers it connects to. "+
+			"The discovery is turned off, the libp2p address should be a private one",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.PrivatePeers,
+		privatePeersFlag,
+		defaultConfig.Network.PrivatePeers,
+		"the libp2p IDs of the validators behind this sentry, "+
+			"always accepted and never shared through discovery",
+	)
+	cmd.MarkFlagsMutuallyExclusive(sentriesFlag, privatePeersFlag)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.AllowCIDRs,
+		allowCIDRsFlag,
+		defaultConfig.Network.AllowCIDRs,
+		"the CIDR ranges the peers are allowed to connect from. "+
+			"When an allow list is set, only the peers matching one are connected",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.DenyCIDRs,
+		denyCIDRsFlag,
+		defaultConfig.Network.DenyCIDRs,
+		"the CIDR ranges the peers are never connected from",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.AllowPeers,
+		allowPeersFlag,
+		defaultConfig.Network.AllowPeers,
+		"the libp2p IDs of the peers allowed to connect. "+
+			"When an allow list is set, only the peers matching one are connected",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.DenyPeers,
+		denyPeersFlag,
+		defaultConfig.Network.DenyPeers,
+		"the libp2p IDs of the peers never connected",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.Network.PSKFile,
+		pskFileFlag,
+		defaultConfig.Network.PSKFile,
+		"the file of the pre-shared key of a private network, hex encoded or in the /key/swarm/psk/1.0.0/ format. "+
+			"Overrides the key of the genesis, only the peers holding the key can connect",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.Network.Compressions,
+		streamCompressionFlag,
+		defaultConfig.Network.Compressions,
+		fmt.Sprintf(
+			"the compressions of the block sync messages by preference (%s), "+
+				"used with the peers supporting one of them",
+			strings.Join(networkGrpc.SupportedCompressions, ", "),
+		),
+	)
+
 	cmd.Flags().Int64Var(
 		&params.rawConfig.Network.MaxPeers,
 		maxPeersFlag,
@@ -185,6 +447,13 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.TxPool.PrivateTxPeers,
+		privateTxPeersFlag,
+		defaultConfig.TxPool.PrivateTxPeers,
+		"the libp2p IDs of the validators holding the private transactions submitted to this node",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.BlockTime,
 		blockTimeFlag,
@@ -192,8 +461,63 @@ func setFlags(cmd *cobra.Command) {
 		"minimum block time in seconds (at least 1s)",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxExecutionBudget,
+		txExecutionBudgetFlag,
+		defaultConfig.TxExecutionBudget,
+		"the maximum execution time in milliseconds of a transaction while building a block, "+
+			"the transactions exceeding it are quarantined in the pool. 0 for unlimited",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StateRegenBudget,
+		stateRegenBudgetFlag,
+		defaultConfig.StateRegenBudget,
+		"the maximum number of blocks replayed to regenerate a missing state "+
+			"for the calls and the traces at old blocks. 0 disables the regeneration",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StorageSweepBudget,
+		storageSweepBudgetFlag,
+		defaultConfig.StorageSweepBudget,
+		"the maximum number of storage trie nodes of the deleted accounts deleted per block. "+
+			"The historical states of the swept storage can't be queried anymore. 0 disables the sweeping",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StorageSweepDelay,
+		storageSweepDelayFlag,
+		defaultConfig.StorageSweepDelay,
+		"the number of blocks an account must stay deleted before its storage is swept",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.ShutdownGracePeriod,
+		shutdownGracePeriodFlag,
+		defaultConfig.ShutdownGracePeriod,
+		"the time in seconds the node has to stop accepting the RPC requests, finish the block import in flight, "+
+			"disconnect from its peers and close its storages on SIGTERM, before being stopped",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.MaxProcs,
+		maxProcsFlag,
+		defaultConfig.MaxProcs,
+		"the maximum number of CPUs executing the node simultaneously. If 0, the GOMAXPROCS env var "+
+			"is used if set, otherwise the CPU quota of the cgroup of the node, rounded up",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.SelfProfileThreshold,
+		selfProfileThresholdFlag,
+		defaultConfig.SelfProfileThreshold,
+		"the CPU usage in percent above which the node writes CPU, heap and goroutine profiles "+
+			"to the profiles directory of its data dir, once sustained for a minute. 0 disables the self-profiling",
+	)
+
 	cmd.Flags().StringArrayVar(
-		&params.corsAllowedOrigins,
+		&params.rawConfig.Headers.AccessControlAllowOrigins,
 		corsOriginFlag,
 		defaultConfig.Headers.AccessControlAllowOrigins,
 		"the CORS header indicating whether any JSON-RPC response can be shared with the specified origin",
@@ -214,6 +538,81 @@ func setFlags(cmd *cobra.Command) {
 			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCNonceManager,
+		jsonRPCNonceManagerFlag,
+		defaultConfig.JSONRPCNonceManager,
+		"enable the nonce json-rpc namespace assigning the nonces of concurrent submitters",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCFilterTimeout,
+		jsonRPCFilterTimeoutFlag,
+		defaultConfig.JSONRPCFilterTimeout,
+		"the time in seconds after which the filters not polled with eth_getFilterChanges are removed",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCMaxClientFilters,
+		jsonRPCMaxClientFiltersFlag,
+		defaultConfig.JSONRPCMaxClientFilters,
+		"max number of polling filters installed from a client address, value of 0 disables it",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCPersistFilters,
+		jsonRPCPersistFiltersFlag,
+		defaultConfig.JSONRPCPersistFilters,
+		"persist the polling filters in the data directory, so that they survive the restarts of the node",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCExplorerCompat,
+		jsonRPCExplorerCompatFlag,
+		defaultConfig.JSONRPCExplorerCompat,
+		"serve the non-standard endpoints and response shapes expected by the block explorers "+
+			"(eth_getBlockReceipts, pending block, total difficulty)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCReadConcurrency,
+		jsonRPCReadConcurrencyFlag,
+		defaultConfig.JSONRPCReadConcurrency,
+		"max number of the cheap json-rpc reads executed concurrently, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCCallConcurrency,
+		jsonRPCCallConcurrencyFlag,
+		defaultConfig.JSONRPCCallConcurrency,
+		"max number of the eth_call, eth_callMany and eth_estimateGas requests executed concurrently, "+
+			"value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCTraceConcurrency,
+		jsonRPCTraceConcurrencyFlag,
+		defaultConfig.JSONRPCTraceConcurrency,
+		"max number of the debug and trace requests re-executing blocks executed concurrently, "+
+			"value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCCallCacheSize,
+		jsonRPCCallCacheSizeFlag,
+		defaultConfig.JSONRPCCallCacheSize,
+		"the number of the eth_call results cached for the identical calls on the same block, "+
+			"the cheapest calls are evicted first. Value of 0 disables the cache",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCIPCPath,
+		jsonRPCIPCPathFlag,
+		defaultConfig.JSONRPCIPCPath,
+		"the unix socket of the IPC json-rpc endpoint, only accessible to the user running the node, "+
+			"relative to the data directory (the endpoint is disabled if empty)",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.LogFilePath,
 		logFileLocationFlag,
@@ -242,7 +641,7 @@ func setLegacyFlags(cmd *cobra.Command) {
 
 func setDevFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(
-		&params.isDevMode,
+		&params.rawConfig.Dev,
 		devFlag,
 		false,
 		"should the client start in dev mode (default false)",
@@ -251,39 +650,141 @@ func setDevFlags(cmd *cobra.Command) {
 	_ = cmd.Flags().MarkHidden(devFlag)
 
 	cmd.Flags().Uint64Var(
-		&params.devInterval,
+		&params.rawConfig.DevInterval,
 		devIntervalFlag,
 		0,
 		"the client's dev notification interval in seconds (default 1)",
 	)
 
 	_ = cmd.Flags().MarkHidden(devIntervalFlag)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.ForkURL,
+		forkURLFlag,
+		"",
+		"the JSON-RPC endpoint of the chain whose state the dev chain runs on top of",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.ForkBlock,
+		forkBlockFlag,
+		0,
+		"the block of the forked chain whose state is read (default the latest block)",
+	)
 }
 
 func runPreRun(cmd *cobra.Command, _ []string) error {
+	if err := initConfig(cmd, false); err != nil {
+		return err
+	}
+
+	if err := params.initRawParams(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// initConfig initializes the raw config. The config file overrides the defaults,
+// the EDGE_* environment variables override the config file,
+// and the flags set on the command line override both
+func initConfig(cmd *cobra.Command, strict bool) error {
+	explicitFlags := getExplicitFlags(cmd)
+
 	// Set the grpc and json ip:port bindings
-	// The config file will have precedence over --flag
 	params.setRawGRPCAddress(helper.GetGRPCAddress(cmd))
 	params.setRawJSONRPCAddress(helper.GetJSONRPCAddress(cmd))
 	params.setJSONLogFormat(helper.GetJSONLogFormat(cmd))
 
-	// Check if the config file has been specified
-	// Config file settings will override JSON-RPC and GRPC address values
 	if isConfigFileSpecified(cmd) {
-		if err := params.initConfigFromFile(); err != nil {
+		if err := params.initConfigFromFile(strict); err != nil {
 			return err
 		}
 	}
 
-	if err := params.initRawParams(); err != nil {
+	if err := params.initConfigFromEnv(); err != nil {
+		return err
+	}
+
+	if err := restoreExplicitFlags(explicitFlags); err != nil {
 		return err
 	}
 
+	// the address flags are not bound to the raw config
+	if cmd.Flags().Changed(command.GRPCAddressFlag) || cmd.Flags().Changed(command.GRPCAddressFlagLEGACY) {
+		params.setRawGRPCAddress(helper.GetGRPCAddress(cmd))
+	}
+
+	if cmd.Flags().Changed(command.JSONRPCFlag) {
+		params.setRawJSONRPCAddress(helper.GetJSONRPCAddress(cmd))
+	}
+
+	if helper.GetJSONLogFormat(cmd) {
+		params.setJSONLogFormat(true)
+	}
+
 	return nil
 }
 
+// isConfigFileSpecified checks whether the config file is set by the flag, or else by EDGE_CONFIG
 func isConfigFileSpecified(cmd *cobra.Command) bool {
-	return cmd.Flags().Changed(configFlag)
+	if cmd.Flags().Changed(configFlag) {
+		return true
+	}
+
+	path, ok := os.LookupEnv(config.EnvPrefix + "CONFIG")
+	if ok && path != "" {
+		params.configPath = path
+	}
+
+	return ok && path != ""
+}
+
+// explicitFlag is a flag set on the command line, with its value
+type explicitFlag struct {
+	flag  *pflag.Flag
+	value string
+	slice []string
+}
+
+// getExplicitFlags returns the flags set on the command line
+func getExplicitFlags(cmd *cobra.Command) []explicitFlag {
+	flags := []explicitFlag{}
+
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		explicit := explicitFlag{
+			flag:  f,
+			value: f.Value.String(),
+		}
+
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			explicit.slice = slice.GetSlice()
+		}
+
+		flags = append(flags, explicit)
+	})
+
+	return flags
+}
+
+// restoreExplicitFlags sets the values of the flags set on the command line again,
+// after the config file and the environment overwrote the raw config to which they are bound
+func restoreExplicitFlags(flags []explicitFlag) error {
+	for _, explicit := range flags {
+		var err error
+
+		if slice, ok := explicit.flag.Value.(pflag.SliceValue); ok {
+			err = slice.Replace(explicit.slice)
+		} else {
+			err = explicit.flag.Value.Set(explicit.value)
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid flag %s: %w", explicit.flag.Name, err)
+		}
+	}
+
+	return nil
 }
 
 func runCommand(cmd *cobra.Command, _ []string) {
@@ -306,5 +807,5 @@ func runServerLoop(
 		return err
 	}
 
-	return helper.HandleSignals(serverInstance.Close, outputter)
+	return helper.HandleSignals(serverInstance.Close, outputter, config.ShutdownGracePeriod)
 }
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfigFile = `data_dir: file
+network:
+  max_peers: 10
+headers:
+  access_control_allow_origins: [file]
+`
+
+// the tests of the config share the server params to which the flags are bound, they don't run in parallel
+func TestInitConfigPrecedence(t *testing.T) {
+	var (
+		defaultConfig = config.DefaultConfig()
+		configPath    = filepath.Join(t.TempDir(), "config.yaml")
+		env           = map[string]string{
+			"EDGE_DATA_DIR":                             "env",
+			"EDGE_NETWORK_MAX_PEERS":                    "20",
+			"EDGE_HEADERS_ACCESS_CONTROL_ALLOW_ORIGINS": "a,b",
+		}
+		flags = []string{
+			"--" + dataDirFlag, "flag",
+			"--" + maxPeersFlag, "30",
+			"--" + corsOriginFlag, "flag",
+		}
+	)
+
+	require.NoError(t, os.WriteFile(configPath, []byte(testConfigFile), 0600))
+
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		dataDir  string
+		maxPeers int64
+		origins  []string
+	}{
+		{
+			name:     "defaults",
+			dataDir:  defaultConfig.DataDir,
+			maxPeers: unsetPeersValue,
+			origins:  defaultConfig.Headers.AccessControlAllowOrigins,
+		},
+		{
+			name:     "file over defaults",
+			args:     []string{"--" + configFlag, configPath},
+			dataDir:  "file",
+			maxPeers: 10,
+			origins:  []string{"file"},
+		},
+		{
+			name:     "file set by the environment",
+			env:      map[string]string{"EDGE_CONFIG": configPath},
+			dataDir:  "file",
+			maxPeers: 10,
+			origins:  []string{"file"},
+		},
+		{
+			name:     "environment over defaults",
+			env:      env,
+			dataDir:  "env",
+			maxPeers: 20,
+			origins:  []string{"a", "b"},
+		},
+		{
+			name:     "environment over file",
+			args:     []string{"--" + configFlag, configPath},
+			env:      env,
+			dataDir:  "env",
+			maxPeers: 20,
+			origins:  []string{"a", "b"},
+		},
+		{
+			name:     "flags over defaults",
+			args:     flags,
+			dataDir:  "flag",
+			maxPeers: 30,
+			origins:  []string{"flag"},
+		},
+		{
+			name:     "flags over environment and file",
+			args:     append([]string{"--" + configFlag, configPath}, flags...),
+			env:      env,
+			dataDir:  "flag",
+			maxPeers: 30,
+			origins:  []string{"flag"},
+		},
+		{
+			name:     "flags over partial environment",
+			args:     []string{"--" + dataDirFlag, "flag"},
+			env:      env,
+			dataDir:  "flag",
+			maxPeers: 20,
+			origins:  []string{"a", "b"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+
+			cmd := GetCommand()
+			require.NoError(t, cmd.ParseFlags(test.args))
+
+			require.NoError(t, initConfig(cmd, false))
+
+			assert.Equal(t, test.dataDir, params.rawConfig.DataDir)
+			assert.Equal(t, test.maxPeers, params.rawConfig.Network.MaxPeers)
+			assert.Equal(t, test.origins, params.rawConfig.Headers.AccessControlAllowOrigins)
+		})
+	}
+}
+
+func TestInitConfigMalformed(t *testing.T) {
+	var (
+		configPath  = filepath.Join(t.TempDir(), "config.yaml")
+		unknownPath = filepath.Join(t.TempDir(), "unknown.yaml")
+	)
+
+	require.NoError(t, os.WriteFile(configPath, []byte(testConfigFile), 0600))
+	require.NoError(t, os.WriteFile(unknownPath, []byte("unknown_key: 1\n"), 0600))
+
+	tests := []struct {
+		name   string
+		args   []string
+		env    map[string]string
+		strict bool
+		err    string
+	}{
+		{
+			name: "malformed number",
+			env:  map[string]string{"EDGE_NETWORK_MAX_PEERS": "many"},
+			err:  "invalid EDGE_NETWORK_MAX_PEERS",
+		},
+		{
+			name: "malformed bool",
+			env:  map[string]string{"EDGE_SEAL": "yes"},
+			err:  "invalid EDGE_SEAL",
+		},
+		{
+			name: "malformed environment under a valid flag",
+			args: []string{"--" + maxPeersFlag, "30"},
+			env:  map[string]string{"EDGE_NETWORK_MAX_PEERS": "-"},
+			err:  "invalid EDGE_NETWORK_MAX_PEERS",
+		},
+		{
+			name: "malformed environment over a file",
+			args: []string{"--" + configFlag, configPath},
+			env:  map[string]string{"EDGE_BLOCK_TIME_S": "2s"},
+			err:  "invalid EDGE_BLOCK_TIME_S",
+		},
+		{
+			name: "missing file set by the environment",
+			env:  map[string]string{"EDGE_CONFIG": filepath.Join(t.TempDir(), "missing.yaml")},
+			err:  "missing.yaml",
+		},
+		{
+			name:   "unknown key of a strict file",
+			args:   []string{"--" + configFlag, unknownPath},
+			strict: true,
+			err:    "unknown_key",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+
+			cmd := GetCommand()
+			require.NoError(t, cmd.ParseFlags(test.args))
+
+			assert.ErrorContains(t, initConfig(cmd, test.strict), test.err)
+		})
+	}
+
+	// the unknown keys are ignored out of the strict mode
+	cmd := GetCommand()
+	require.NoError(t, cmd.ParseFlags([]string{"--" + configFlag, unknownPath}))
+	assert.NoError(t, initConfig(cmd, false))
+}
+
+func TestInitShutdownGracePeriod(t *testing.T) {
+	p := &serverParams{rawConfig: config.DefaultConfig()}
+	assert.NoError(t, p.initShutdownGracePeriod())
+
+	p.rawConfig.ShutdownGracePeriod = 0
+	assert.ErrorIs(t, p.initShutdownGracePeriod(), errInvalidGracePeriod)
+}
@@ -0,0 +1,154 @@
+package simulate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/simulate/simulator"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	workloadFlag           = "workload"
+	blockGasLimitFlag      = "block-gas-limit"
+	blockTimeFlag          = "block-time"
+	baseFeeFlag            = "base-fee"
+	baseFeeElasticityFlag  = "base-fee-elasticity"
+	baseFeeDenominatorFlag = "base-fee-denominator"
+)
+
+var (
+	params = &simulateParams{}
+)
+
+var (
+	errNoBlockGasLimits = errors.New("no block gas limit specified")
+	errNoBlockTimes     = errors.New("no block time specified")
+	errInvalidBaseFee   = errors.New("invalid base fee parameters")
+)
+
+type simulateParams struct {
+	workloadPath         string
+	blockGasLimitsRaw    []string
+	blockTimes           []time.Duration
+	baseFeesRaw          []string
+	elasticityMultiplier uint64
+	changeDenominator    uint64
+
+	blockGasLimits []uint64
+	baseFees       []*big.Int
+
+	workload *simulator.Workload
+	runs     []*RunResult
+}
+
+func (p *simulateParams) getRequiredFlags() []string {
+	return []string{
+		workloadFlag,
+	}
+}
+
+func (p *simulateParams) initRawParams() error {
+	if len(p.blockGasLimitsRaw) == 0 {
+		return errNoBlockGasLimits
+	}
+
+	if len(p.blockTimes) == 0 {
+		return errNoBlockTimes
+	}
+
+	p.blockGasLimits = make([]uint64, 0, len(p.blockGasLimitsRaw))
+
+	for _, raw := range p.blockGasLimitsRaw {
+		raw := raw
+
+		gasLimit, err := types.ParseUint64orHex(&raw)
+		if err != nil {
+			return fmt.Errorf("invalid block gas limit %s, %w", raw, err)
+		}
+
+		p.blockGasLimits = append(p.blockGasLimits, gasLimit)
+	}
+
+	p.baseFees = make([]*big.Int, 0, len(p.baseFeesRaw))
+
+	for _, raw := range p.baseFeesRaw {
+		raw := raw
+
+		baseFee, err := types.ParseUint256orHex(&raw)
+		if err != nil {
+			return fmt.Errorf("invalid base fee %s, %w", raw, err)
+		}
+
+		p.baseFees = append(p.baseFees, baseFee)
+	}
+
+	if len(p.baseFees) > 0 && (p.elasticityMultiplier == 0 || p.changeDenominator == 0) {
+		return errInvalidBaseFee
+	}
+
+	return nil
+}
+
+// configs returns the combinations of the simulated parameters
+func (p *simulateParams) configs() []*simulator.Config {
+	baseFees := []*simulator.BaseFeeConfig{nil}
+
+	if len(p.baseFees) > 0 {
+		baseFees = make([]*simulator.BaseFeeConfig, len(p.baseFees))
+
+		for i, baseFee := range p.baseFees {
+			baseFees[i] = &simulator.BaseFeeConfig{
+				Initial:              baseFee,
+				ElasticityMultiplier: p.elasticityMultiplier,
+				ChangeDenominator:    p.changeDenominator,
+			}
+		}
+	}
+
+	configs := make([]*simulator.Config, 0, len(p.blockGasLimits)*len(p.blockTimes)*len(baseFees))
+
+	for _, gasLimit := range p.blockGasLimits {
+		for _, blockTime := range p.blockTimes {
+			for _, baseFee := range baseFees {
+				configs = append(configs, &simulator.Config{
+					BlockGasLimit: gasLimit,
+					BlockTime:     blockTime,
+					BaseFee:       baseFee,
+				})
+			}
+		}
+	}
+
+	return configs
+}
+
+func (p *simulateParams) simulate() error {
+	workload, err := simulator.LoadWorkload(p.workloadPath)
+	if err != nil {
+		return fmt.Errorf("unable to load the workload, %w", err)
+	}
+
+	p.workload = workload
+
+	for _, config := range p.configs() {
+		result, err := simulator.Simulate(workload, config)
+		if err != nil {
+			return fmt.Errorf("unable to simulate the workload, %w", err)
+		}
+
+		p.runs = append(p.runs, newRunResult(config, result))
+	}
+
+	return nil
+}
+
+func (p *simulateParams) getResult() command.CommandResult {
+	return &SimulateResult{
+		Transactions: len(p.workload.Transactions),
+		Runs:         p.runs,
+	}
+}
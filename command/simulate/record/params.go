@@ -0,0 +1,122 @@
+package record
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/simulate/simulator"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	fromFlag   = "from"
+	toFlag     = "to"
+	outputFlag = "output"
+)
+
+const (
+	defaultOutputPath = "workload.json"
+)
+
+var (
+	params = &recordParams{}
+)
+
+var (
+	errInvalidRange = errors.New("the first block is after the last one")
+	errNoBlock      = errors.New("block not found")
+)
+
+type recordParams struct {
+	from           uint64
+	to             uint64
+	outputPath     string
+	jsonrpcAddress string
+
+	workload *simulator.Workload
+}
+
+// recordWorkload fetches the transactions of the block range. The submission time of a transaction
+// isn't known, its time in the workload is the one of its block since the first block of the range
+func (p *recordParams) recordWorkload() error {
+	client, err := jsonrpc.NewClient(p.jsonrpcAddress)
+	if err != nil {
+		return fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	defer client.Close()
+
+	if p.to == 0 {
+		if p.to, err = client.Eth().BlockNumber(); err != nil {
+			return fmt.Errorf("unable to get the latest block number, %w", err)
+		}
+	}
+
+	if p.from > p.to {
+		return errInvalidRange
+	}
+
+	p.workload = &simulator.Workload{
+		Transactions: []*simulator.Tx{},
+	}
+
+	var start uint64
+
+	for number := p.from; number <= p.to; number++ {
+		block, err := client.Eth().GetBlockByNumber(ethgo.BlockNumber(number), true)
+		if err != nil {
+			return fmt.Errorf("unable to get block %d, %w", number, err)
+		}
+
+		if block == nil {
+			return fmt.Errorf("%w: %d", errNoBlock, number)
+		}
+
+		if number == p.from {
+			start = block.Timestamp
+		}
+
+		for _, txn := range block.Transactions {
+			receipt, err := client.Eth().GetTransactionReceipt(txn.Hash)
+			if err != nil {
+				return fmt.Errorf("unable to get the receipt of %s, %w", txn.Hash, err)
+			}
+
+			tx := &simulator.Tx{
+				Time:     float64(block.Timestamp - start),
+				From:     types.Address(txn.From),
+				Gas:      txn.Gas,
+				GasPrice: new(big.Int).SetUint64(txn.GasPrice),
+			}
+
+			if receipt != nil {
+				tx.GasUsed = receipt.GasUsed
+			}
+
+			p.workload.Transactions = append(p.workload.Transactions, tx)
+		}
+	}
+
+	if len(p.workload.Transactions) == 0 {
+		return simulator.ErrEmptyWorkload
+	}
+
+	if err := p.workload.Save(p.outputPath); err != nil {
+		return fmt.Errorf("unable to write the workload, %w", err)
+	}
+
+	return nil
+}
+
+func (p *recordParams) getResult() command.CommandResult {
+	return &RecordResult{
+		From:         p.from,
+		To:           p.to,
+		Transactions: len(p.workload.Transactions),
+		Output:       p.outputPath,
+	}
+}
@@ -0,0 +1,29 @@
+package record
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RecordResult struct {
+	From         uint64 `json:"from"`
+	To           uint64 `json:"to"`
+	Transactions int    `json:"transactions"`
+	Output       string `json:"output"`
+}
+
+func (r *RecordResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[WORKLOAD RECORDED]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Blocks|%d - %d", r.From, r.To),
+		fmt.Sprintf("Transactions|%d", r.Transactions),
+		fmt.Sprintf("Output|%s", r.Output),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
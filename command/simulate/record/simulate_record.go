@@ -0,0 +1,68 @@
+package record
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	recordCmd := &cobra.Command{
+		Use:     "record",
+		Short:   "Records the transactions of a block range of a running chain as a simulation workload",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	helper.RegisterJSONRPCFlag(recordCmd)
+
+	setFlags(recordCmd)
+
+	return recordCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.from,
+		fromFlag,
+		1,
+		"the first block of the range",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.to,
+		toFlag,
+		0,
+		"the last block of the range, the latest block if omitted",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputPath,
+		outputFlag,
+		defaultOutputPath,
+		"the JSON file the workload is written to",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.jsonrpcAddress = helper.GetJSONRPCAddress(cmd)
+
+	if _, err := helper.ParseJSONRPCAddress(params.jsonrpcAddress); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.recordWorkload(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
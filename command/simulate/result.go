@@ -0,0 +1,116 @@
+package simulate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/simulate/simulator"
+)
+
+type RunResult struct {
+	BlockGasLimit uint64 `json:"blockGasLimit"`
+	BlockTime     string `json:"blockTime"`
+	BaseFee       string `json:"baseFee,omitempty"`
+
+	Blocks   uint64 `json:"blocks"`
+	Included uint64 `json:"included"`
+	Dropped  uint64 `json:"dropped"`
+	Pending  uint64 `json:"pending"`
+
+	Duration     string  `json:"duration"`
+	TPS          float64 `json:"tps"`
+	GasPerSecond float64 `json:"gasPerSecond"`
+	Utilization  float64 `json:"utilization"`
+
+	LatencyAvg string `json:"latencyAvg"`
+	LatencyP50 string `json:"latencyP50"`
+	LatencyP95 string `json:"latencyP95"`
+	LatencyMax string `json:"latencyMax"`
+
+	Fees         string `json:"fees"`
+	BurnedFees   string `json:"burnedFees"`
+	MinBaseFee   string `json:"minBaseFee,omitempty"`
+	MaxBaseFee   string `json:"maxBaseFee,omitempty"`
+	FinalBaseFee string `json:"finalBaseFee,omitempty"`
+}
+
+func newRunResult(config *simulator.Config, result *simulator.Result) *RunResult {
+	run := &RunResult{
+		BlockGasLimit: config.BlockGasLimit,
+		BlockTime:     config.BlockTime.String(),
+		Blocks:        result.Blocks,
+		Included:      result.Included,
+		Dropped:       result.Dropped,
+		Pending:       result.Pending,
+		Duration:      result.Duration.String(),
+		TPS:           result.TPS,
+		GasPerSecond:  result.GasPerSecond,
+		Utilization:   result.Utilization,
+		LatencyAvg:    result.LatencyAvg.String(),
+		LatencyP50:    result.LatencyP50.String(),
+		LatencyP95:    result.LatencyP95.String(),
+		LatencyMax:    result.LatencyMax.String(),
+		Fees:          result.Fees.String(),
+		BurnedFees:    result.BurnedFees.String(),
+	}
+
+	if config.BaseFee != nil {
+		run.BaseFee = config.BaseFee.Initial.String()
+		run.MinBaseFee = result.MinBaseFee.String()
+		run.MaxBaseFee = result.MaxBaseFee.String()
+		run.FinalBaseFee = result.FinalBaseFee.String()
+	}
+
+	return run
+}
+
+type SimulateResult struct {
+	Transactions int          `json:"transactions"`
+	Runs         []*RunResult `json:"runs"`
+}
+
+func (r *SimulateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[WORKLOAD]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Transactions|%d", r.Transactions),
+		fmt.Sprintf("Simulations|%d", len(r.Runs)),
+	}))
+
+	rows := make([]string, len(r.Runs)+1)
+	rows[0] = "Gas Limit|Block Time|Base Fee|Blocks|Included|Dropped|Pending|TPS|Gas/s|Utilization|" +
+		"Latency p50|Latency p95|Fees|Burned"
+
+	for i, run := range r.Runs {
+		baseFee := run.BaseFee
+		if baseFee == "" {
+			baseFee = "-"
+		}
+
+		rows[i+1] = fmt.Sprintf(
+			"%d|%s|%s|%d|%d|%d|%d|%.2f|%.0f|%.1f%%|%s|%s|%s|%s",
+			run.BlockGasLimit,
+			run.BlockTime,
+			baseFee,
+			run.Blocks,
+			run.Included,
+			run.Dropped,
+			run.Pending,
+			run.TPS,
+			run.GasPerSecond,
+			run.Utilization*100,
+			run.LatencyP50,
+			run.LatencyP95,
+			run.Fees,
+			run.BurnedFees,
+		)
+	}
+
+	buffer.WriteString("\n\n[SIMULATIONS]\n")
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
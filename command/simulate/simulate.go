@@ -0,0 +1,98 @@
+package simulate
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/command/simulate/record"
+	"github.com/0xPolygon/polygon-edge/command/simulate/simulator"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	simulateCmd := &cobra.Command{
+		Use: "simulate",
+		Short: "Replays a recorded transaction workload offline against block gas limits, block times " +
+			"and base fees, and reports the throughput and the fees of each combination",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(simulateCmd)
+	helper.SetRequiredFlags(simulateCmd, params.getRequiredFlags())
+
+	registerSubcommands(simulateCmd)
+
+	return simulateCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// simulate record
+		record.GetCommand(),
+	)
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.workloadPath,
+		workloadFlag,
+		"",
+		"the JSON file of the workload, as written by the record subcommand",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.blockGasLimitsRaw,
+		blockGasLimitFlag,
+		[]string{strconv.FormatUint(command.DefaultGenesisGasLimit, 10)},
+		"the block gas limits simulated",
+	)
+
+	cmd.Flags().DurationSliceVar(
+		&params.blockTimes,
+		blockTimeFlag,
+		[]time.Duration{time.Duration(config.DefaultBlockTime) * time.Second},
+		"the block times simulated",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.baseFeesRaw,
+		baseFeeFlag,
+		nil,
+		"the initial base fees simulated, the blocks have no base fee if omitted",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.elasticityMultiplier,
+		baseFeeElasticityFlag,
+		simulator.DefaultElasticityMultiplier,
+		"the ratio of the block gas limit to the gas target of the base fee",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.changeDenominator,
+		baseFeeDenominatorFlag,
+		simulator.DefaultChangeDenominator,
+		"the denominator bounding the change of the base fee between two blocks",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.simulate(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,331 @@
+// Package simulator replays a transaction workload against the block production parameters,
+// in order to compare their throughput and fees before choosing the ones of a genesis
+package simulator
+
+import (
+	"container/heap"
+	"errors"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// maxIdleBlocks is the number of blocks without inclusion after which the simulation stops,
+	// once all the transactions are submitted. The pending ones are priced out by the base fee
+	maxIdleBlocks = 1000
+
+	DefaultElasticityMultiplier = 2
+	DefaultChangeDenominator    = 8
+)
+
+var (
+	ErrInvalidGasLimit  = errors.New("the block gas limit must be positive")
+	ErrInvalidBlockTime = errors.New("the block time must be positive")
+	ErrInvalidBaseFee   = errors.New("invalid base fee parameters")
+)
+
+// BaseFeeConfig is the configuration of an EIP-1559 base fee. The transactions priced
+// below the base fee wait for it to go down, and the base fee of their gas is burned
+type BaseFeeConfig struct {
+	// Initial is the base fee of the first block
+	Initial *big.Int
+
+	// ElasticityMultiplier is the ratio of the block gas limit to the gas target
+	ElasticityMultiplier uint64
+
+	// ChangeDenominator bounds the change of the base fee between two blocks
+	ChangeDenominator uint64
+}
+
+// Config are the block production parameters
+type Config struct {
+	BlockGasLimit uint64
+	BlockTime     time.Duration
+
+	// BaseFee is the configuration of the base fee, none if nil
+	BaseFee *BaseFeeConfig
+}
+
+func (c *Config) validate() error {
+	if c.BlockGasLimit == 0 {
+		return ErrInvalidGasLimit
+	}
+
+	if c.BlockTime <= 0 {
+		return ErrInvalidBlockTime
+	}
+
+	if c.BaseFee != nil {
+		if c.BaseFee.Initial == nil || c.BaseFee.Initial.Sign() < 0 ||
+			c.BaseFee.ElasticityMultiplier == 0 || c.BaseFee.ChangeDenominator == 0 {
+			return ErrInvalidBaseFee
+		}
+	}
+
+	return nil
+}
+
+// Result are the throughput and the fees of a simulation
+type Result struct {
+	Blocks   uint64
+	Included uint64
+	// Dropped are the transactions exceeding the block gas limit
+	Dropped uint64
+	// Pending are the transactions not included when the simulation ends
+	Pending uint64
+
+	Duration     time.Duration
+	TPS          float64
+	GasPerSecond float64
+	// Utilization is the average ratio of the gas used to the gas limit of the blocks
+	Utilization float64
+
+	LatencyAvg time.Duration
+	LatencyP50 time.Duration
+	LatencyP95 time.Duration
+	LatencyMax time.Duration
+
+	// Fees are the fees paid by the included transactions, BurnedFees is the part of them burned
+	Fees       *big.Int
+	BurnedFees *big.Int
+
+	// the base fees of the blocks, nil without base fee
+	MinBaseFee   *big.Int
+	MaxBaseFee   *big.Int
+	FinalBaseFee *big.Int
+}
+
+// pendingTx is a transaction waiting in the pool
+type pendingTx struct {
+	*Tx
+
+	// seq is the submission order, the ties of the gas prices are broken by it
+	seq int
+}
+
+// priceHeap are the first pending transactions of the senders, by descending gas price
+type priceHeap []*pendingTx
+
+func (h priceHeap) Len() int { return len(h) }
+
+func (h priceHeap) Less(i, j int) bool {
+	if c := h[i].GasPrice.Cmp(h[j].GasPrice); c != 0 {
+		return c > 0
+	}
+
+	return h[i].seq < h[j].seq
+}
+
+func (h priceHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *priceHeap) Push(x interface{}) {
+	tx, _ := x.(*pendingTx)
+	*h = append(*h, tx)
+}
+
+func (h *priceHeap) Pop() interface{} {
+	old := *h
+	tx := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return tx
+}
+
+// Simulate produces the blocks of the workload with the parameters. The blocks are filled as
+// the validators do: the pending transactions are taken by descending gas price, in the submission
+// order for each sender, until the next one doesn't fit in the gas left in the block
+func Simulate(workload *Workload, config *Config) (*Result, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		txs       = workload.sortedTxs()
+		queues    = map[types.Address][]*pendingTx{}
+		latencies = make([]float64, 0, len(txs))
+		blockTime = config.BlockTime.Seconds()
+
+		baseFee *big.Int
+		result  = &Result{
+			Fees:       big.NewInt(0),
+			BurnedFees: big.NewInt(0),
+		}
+
+		next, pending, idleBlocks int
+		utilization               float64
+	)
+
+	if config.BaseFee != nil {
+		baseFee = new(big.Int).Set(config.BaseFee.Initial)
+		result.MinBaseFee = new(big.Int).Set(baseFee)
+		result.MaxBaseFee = new(big.Int).Set(baseFee)
+	}
+
+	// includable returns true if the transaction pays the base fee
+	includable := func(tx *pendingTx) bool {
+		return baseFee == nil || tx.GasPrice.Cmp(baseFee) >= 0
+	}
+
+	for next < len(txs) || pending > 0 {
+		result.Blocks++
+		now := float64(result.Blocks) * blockTime
+
+		// submit the transactions sent before the block
+		for ; next < len(txs) && txs[next].Time <= now; next++ {
+			tx := txs[next]
+
+			if tx.Gas > config.BlockGasLimit {
+				result.Dropped++
+
+				continue
+			}
+
+			queues[tx.From] = append(queues[tx.From], &pendingTx{Tx: tx, seq: next})
+			pending++
+		}
+
+		heads := &priceHeap{}
+
+		for _, queue := range queues {
+			if includable(queue[0]) {
+				heads.Push(queue[0])
+			}
+		}
+
+		heap.Init(heads)
+
+		var (
+			gasLeft  = config.BlockGasLimit
+			gasUsed  uint64
+			included int
+		)
+
+		for heads.Len() > 0 {
+			tx, _ := heap.Pop(heads).(*pendingTx)
+
+			if tx.Gas > gasLeft {
+				break
+			}
+
+			gasLeft -= tx.gasUsed()
+			gasUsed += tx.gasUsed()
+			included++
+
+			latencies = append(latencies, now-tx.Time)
+
+			gas := new(big.Int).SetUint64(tx.gasUsed())
+			result.Fees.Add(result.Fees, new(big.Int).Mul(gas, tx.GasPrice))
+
+			if baseFee != nil {
+				result.BurnedFees.Add(result.BurnedFees, new(big.Int).Mul(gas, baseFee))
+			}
+
+			if queue := queues[tx.From][1:]; len(queue) > 0 {
+				queues[tx.From] = queue
+
+				if includable(queue[0]) {
+					heap.Push(heads, queue[0])
+				}
+			} else {
+				delete(queues, tx.From)
+			}
+		}
+
+		pending -= included
+		result.Included += uint64(included)
+		utilization += float64(gasUsed) / float64(config.BlockGasLimit)
+
+		if baseFee != nil {
+			baseFee = nextBaseFee(baseFee, gasUsed, config)
+
+			if baseFee.Cmp(result.MinBaseFee) < 0 {
+				result.MinBaseFee.Set(baseFee)
+			}
+
+			if baseFee.Cmp(result.MaxBaseFee) > 0 {
+				result.MaxBaseFee.Set(baseFee)
+			}
+		}
+
+		if next == len(txs) && included == 0 {
+			if idleBlocks++; idleBlocks >= maxIdleBlocks {
+				break
+			}
+		} else {
+			idleBlocks = 0
+		}
+	}
+
+	result.Pending = uint64(pending)
+	result.Duration = time.Duration(result.Blocks) * config.BlockTime
+	result.Utilization = utilization / float64(result.Blocks)
+	result.FinalBaseFee = baseFee
+
+	if seconds := result.Duration.Seconds(); seconds > 0 {
+		result.TPS = float64(result.Included) / seconds
+		result.GasPerSecond = utilization * float64(config.BlockGasLimit) / seconds
+	}
+
+	setLatencies(result, latencies)
+
+	return result, nil
+}
+
+// nextBaseFee returns the base fee of the next block, following EIP-1559
+func nextBaseFee(baseFee *big.Int, gasUsed uint64, config *Config) *big.Int {
+	target := config.BlockGasLimit / config.BaseFee.ElasticityMultiplier
+	if target == 0 || gasUsed == target {
+		return baseFee
+	}
+
+	var (
+		denominator = new(big.Int).SetUint64(target * config.BaseFee.ChangeDenominator)
+		delta       = new(big.Int)
+	)
+
+	if gasUsed > target {
+		delta.Mul(baseFee, new(big.Int).SetUint64(gasUsed-target))
+		delta.Div(delta, denominator)
+
+		// the base fee increases by at least 1 when the block is above the target
+		if delta.Sign() == 0 {
+			delta.SetUint64(1)
+		}
+
+		return delta.Add(baseFee, delta)
+	}
+
+	delta.Mul(baseFee, new(big.Int).SetUint64(target-gasUsed))
+	delta.Div(delta, denominator)
+
+	return delta.Sub(baseFee, delta)
+}
+
+func setLatencies(result *Result, latencies []float64) {
+	if len(latencies) == 0 {
+		return
+	}
+
+	sort.Float64s(latencies)
+
+	var sum float64
+	for _, latency := range latencies {
+		sum += latency
+	}
+
+	seconds := func(s float64) time.Duration {
+		return time.Duration(s * float64(time.Second))
+	}
+
+	percentile := func(p int) time.Duration {
+		return seconds(latencies[(len(latencies)-1)*p/100])
+	}
+
+	result.LatencyAvg = seconds(sum / float64(len(latencies)))
+	result.LatencyP50 = percentile(50)
+	result.LatencyP95 = percentile(95)
+	result.LatencyMax = seconds(latencies[len(latencies)-1])
+}
@@ -0,0 +1,153 @@
+package simulator
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+)
+
+func newTx(submitted float64, from types.Address, gas uint64, gasPrice int64) *Tx {
+	return &Tx{
+		Time:     submitted,
+		From:     from,
+		Gas:      gas,
+		GasPrice: big.NewInt(gasPrice),
+	}
+}
+
+func TestParseWorkload(t *testing.T) {
+	t.Parallel()
+
+	workload, err := ParseWorkload([]byte(`{"transactions": [
+		{"time": 0.5, "from": "0x0000000000000000000000000000000000000001", "gas": 21000, "gasPrice": 1}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, workload.Transactions, 1)
+
+	assert.Equal(t, addr1, workload.Transactions[0].From)
+	assert.Equal(t, uint64(21000), workload.Transactions[0].gasUsed())
+
+	_, err = ParseWorkload([]byte(`{"transactions": []}`))
+	assert.ErrorIs(t, err, ErrEmptyWorkload)
+
+	_, err = ParseWorkload([]byte(`{"transactions": [{"time": 0, "gas": 0, "gasPrice": 1}]}`))
+	assert.ErrorIs(t, err, ErrInvalidTx)
+
+	_, err = ParseWorkload([]byte(`{"transactions": [{"time": 0, "gas": 10, "gasUsed": 20, "gasPrice": 1}]}`))
+	assert.ErrorIs(t, err, ErrInvalidTx)
+}
+
+func TestSimulate_Packing(t *testing.T) {
+	t.Parallel()
+
+	workload := &Workload{
+		Transactions: []*Tx{
+			newTx(0, addr1, 40, 1),
+			newTx(0, addr1, 40, 5),
+			newTx(0, addr2, 40, 3),
+			newTx(0, addr2, 200, 3),
+		},
+	}
+
+	result, err := Simulate(workload, &Config{
+		BlockGasLimit: 100,
+		BlockTime:     2 * time.Second,
+	})
+	require.NoError(t, err)
+
+	// the transaction above the gas limit is dropped, and the second transaction of addr1
+	// waits for the first, cheaper one. It doesn't fit in the first block anymore
+	assert.Equal(t, uint64(2), result.Blocks)
+	assert.Equal(t, uint64(3), result.Included)
+	assert.Equal(t, uint64(1), result.Dropped)
+	assert.Equal(t, uint64(0), result.Pending)
+	assert.Equal(t, 4*time.Second, result.Duration)
+	assert.InDelta(t, 0.75, result.TPS, 1e-9)
+	assert.InDelta(t, 0.6, result.Utilization, 1e-9)
+	assert.Equal(t, big.NewInt(40*1+40*3+40*5), result.Fees)
+	assert.Nil(t, result.FinalBaseFee)
+
+	assert.Equal(t, 2*time.Second, result.LatencyP50)
+	assert.Equal(t, 4*time.Second, result.LatencyMax)
+}
+
+func TestSimulate_BaseFee(t *testing.T) {
+	t.Parallel()
+
+	workload := &Workload{
+		Transactions: []*Tx{
+			newTx(0, addr1, 100, 100),
+			newTx(0, addr2, 50, 90),
+		},
+	}
+
+	result, err := Simulate(workload, &Config{
+		BlockGasLimit: 100,
+		BlockTime:     time.Second,
+		BaseFee: &BaseFeeConfig{
+			Initial:              big.NewInt(100),
+			ElasticityMultiplier: DefaultElasticityMultiplier,
+			ChangeDenominator:    DefaultChangeDenominator,
+		},
+	})
+	require.NoError(t, err)
+
+	// the full block raises the base fee to 112, the empty blocks lower it until it goes under 90
+	assert.Equal(t, uint64(2), result.Included)
+	assert.Equal(t, uint64(0), result.Pending)
+	assert.Equal(t, big.NewInt(112), result.MaxBaseFee)
+	assert.True(t, result.MinBaseFee.Cmp(big.NewInt(90)) <= 0)
+	assert.Equal(t, 0, result.FinalBaseFee.Cmp(result.MinBaseFee))
+	assert.Equal(t, big.NewInt(100*100+50*90), result.Fees)
+	assert.True(t, result.BurnedFees.Cmp(result.Fees) <= 0)
+}
+
+func TestSimulate_PricedOut(t *testing.T) {
+	t.Parallel()
+
+	workload := &Workload{
+		Transactions: []*Tx{
+			newTx(0, addr1, 21000, 0),
+		},
+	}
+
+	result, err := Simulate(workload, &Config{
+		BlockGasLimit: 100000,
+		BlockTime:     time.Second,
+		BaseFee: &BaseFeeConfig{
+			Initial:              big.NewInt(1),
+			ElasticityMultiplier: DefaultElasticityMultiplier,
+			ChangeDenominator:    DefaultChangeDenominator,
+		},
+	})
+	require.NoError(t, err)
+
+	// the base fee can't go under 1, the transaction never pays it
+	assert.Equal(t, uint64(maxIdleBlocks), result.Blocks)
+	assert.Equal(t, uint64(1), result.Pending)
+	assert.Equal(t, uint64(0), result.Included)
+}
+
+func TestSimulate_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	workload := &Workload{Transactions: []*Tx{newTx(0, addr1, 1, 1)}}
+
+	_, err := Simulate(workload, &Config{BlockTime: time.Second})
+	assert.ErrorIs(t, err, ErrInvalidGasLimit)
+
+	_, err = Simulate(workload, &Config{BlockGasLimit: 1})
+	assert.ErrorIs(t, err, ErrInvalidBlockTime)
+
+	_, err = Simulate(workload, &Config{BlockGasLimit: 1, BlockTime: time.Second, BaseFee: &BaseFeeConfig{}})
+	assert.ErrorIs(t, err, ErrInvalidBaseFee)
+}
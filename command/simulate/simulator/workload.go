@@ -0,0 +1,113 @@
+package simulator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrEmptyWorkload = errors.New("the workload has no transactions")
+	ErrInvalidTx     = errors.New("invalid workload transaction")
+)
+
+// Tx is a transaction of the workload
+type Tx struct {
+	// Time is the submission time of the transaction, in seconds since the start of the workload
+	Time float64 `json:"time"`
+
+	// From is the sender, the transactions of a sender are included in their submission order
+	From types.Address `json:"from"`
+
+	// Gas is the gas limit of the transaction, checked against the space left in the block
+	Gas uint64 `json:"gas"`
+
+	// GasUsed is the gas the transaction consumes, the gas limit if omitted
+	GasUsed uint64 `json:"gasUsed,omitempty"`
+
+	GasPrice *big.Int `json:"gasPrice"`
+}
+
+// Workload is a recorded sequence of transactions
+type Workload struct {
+	Transactions []*Tx `json:"transactions"`
+}
+
+// LoadWorkload reads the workload from the JSON file
+func LoadWorkload(path string) (*Workload, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseWorkload(data)
+}
+
+// ParseWorkload decodes the JSON workload and validates its transactions
+func ParseWorkload(data []byte) (*Workload, error) {
+	workload := &Workload{}
+	if err := json.Unmarshal(data, workload); err != nil {
+		return nil, err
+	}
+
+	if err := workload.validate(); err != nil {
+		return nil, err
+	}
+
+	return workload, nil
+}
+
+// Save writes the workload to the JSON file
+func (w *Workload) Save(path string) error {
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+func (w *Workload) validate() error {
+	if len(w.Transactions) == 0 {
+		return ErrEmptyWorkload
+	}
+
+	for i, tx := range w.Transactions {
+		switch {
+		case tx.Time < 0:
+			return fmt.Errorf("%w %d: negative time", ErrInvalidTx, i)
+		case tx.Gas == 0:
+			return fmt.Errorf("%w %d: no gas limit", ErrInvalidTx, i)
+		case tx.GasUsed > tx.Gas:
+			return fmt.Errorf("%w %d: the gas used exceeds the gas limit", ErrInvalidTx, i)
+		case tx.GasPrice == nil || tx.GasPrice.Sign() < 0:
+			return fmt.Errorf("%w %d: invalid gas price", ErrInvalidTx, i)
+		}
+	}
+
+	return nil
+}
+
+// sortedTxs returns the transactions in their submission order
+func (w *Workload) sortedTxs() []*Tx {
+	txs := append([]*Tx{}, w.Transactions...)
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Time < txs[j].Time
+	})
+
+	return txs
+}
+
+func (tx *Tx) gasUsed() uint64 {
+	if tx.GasUsed == 0 {
+		return tx.Gas
+	}
+
+	return tx.GasUsed
+}
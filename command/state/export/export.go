@@ -0,0 +1,83 @@
+package export
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	stateExportCmd := &cobra.Command{
+		Use: "export",
+		Short: "Exports the accounts, the storage slots and the code metadata of the state at a block " +
+			"into CSV or parquet files for analytics. The node must be stopped",
+		Long: `Exports the accounts, the storage slots and the code metadata of the state at a block
+into CSV or parquet files for analytics. The node must be stopped.
+
+The parquet files are written by row groups of uncompressed PLAIN encoded columns, so the memory used
+by the export is bounded by a row group per table whatever the size of the state.`,
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(stateExportCmd)
+	helper.SetRequiredFlags(stateExportCmd, params.getRequiredFlags())
+
+	return stateExportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.blockRaw,
+		blockFlag,
+		"",
+		"the height of the block whose state is exported, the head of the chain by default",
+	)
+
+	cmd.Flags().StringVar(
+		&params.format,
+		formatFlag,
+		csvFormat,
+		"the format of the exported files, csv or parquet",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputDir,
+		outputFlag,
+		"./state-export",
+		"the directory the accounts, storage and code files are written to",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.skipStorage,
+		skipStorageFlag,
+		false,
+		"skip the storage slots of the accounts",
+	)
+
+	helper.RegisterFlagValues(cmd, formatFlag, csvFormat, parquetFormat)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.exportState(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
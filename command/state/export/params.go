@@ -0,0 +1,425 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dataDirFlag     = "data-dir"
+	blockFlag       = "block"
+	formatFlag      = "format"
+	outputFlag      = "output"
+	skipStorageFlag = "skip-storage"
+)
+
+const (
+	csvFormat     = "csv"
+	parquetFormat = "parquet"
+)
+
+var (
+	params = &exportParams{}
+)
+
+var (
+	errDecodeBlock       = errors.New("unable to decode block value")
+	errEmptyChain        = errors.New("the chain data is empty")
+	errBlockNotFound     = errors.New("the block is not found")
+	errUnsupportedFormat = errors.New("unsupported format, only csv and parquet are supported")
+)
+
+var emptyCodeHash = types.BytesToHash(crypto.Keccak256(nil))
+
+type exportParams struct {
+	dataDir     string
+	blockRaw    string
+	format      string
+	outputDir   string
+	skipStorage bool
+
+	block *uint64
+
+	result *ExportResult
+}
+
+func (p *exportParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *exportParams) initRawParams() error {
+	if p.format != csvFormat && p.format != parquetFormat {
+		return fmt.Errorf("%w: %q", errUnsupportedFormat, p.format)
+	}
+
+	if p.blockRaw != "" {
+		block, err := types.ParseUint64orHex(&p.blockRaw)
+		if err != nil {
+			return errDecodeBlock
+		}
+
+		p.block = &block
+	}
+
+	return nil
+}
+
+func (p *exportParams) exportState() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "state-export",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	// the storages are locked by a running node
+	db, err := leveldb.NewLevelDBStorage(filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the blockchain storage, is the node stopped? %w", err)
+	}
+
+	defer db.Close()
+
+	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(p.dataDir, "trie"), logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the state storage, is the node stopped? %w", err)
+	}
+
+	defer stateStorage.Close()
+
+	number, ok := db.ReadHeadNumber()
+	if !ok {
+		return errEmptyChain
+	}
+
+	if p.block != nil {
+		number = *p.block
+	}
+
+	hash, ok := db.ReadCanonicalHash(number)
+	if !ok {
+		return fmt.Errorf("%w: %d", errBlockNotFound, number)
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return fmt.Errorf("%w: %d, %v", errBlockNotFound, number, err)
+	}
+
+	if err := os.MkdirAll(p.outputDir, 0755); err != nil {
+		return err
+	}
+
+	exporter, err := newStateExporter(
+		itrie.NewState(stateStorage),
+		stateStorage,
+		p.outputDir,
+		p.format,
+		!p.skipStorage,
+	)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("exporting the state", "block", number, "root", header.StateRoot, "output", p.outputDir)
+
+	exportErr := exporter.export(header.StateRoot)
+
+	if err := exporter.close(); err != nil && exportErr == nil {
+		exportErr = err
+	}
+
+	if exportErr != nil {
+		return exportErr
+	}
+
+	p.result = &ExportResult{
+		Block:     number,
+		StateRoot: header.StateRoot,
+		Accounts:  exporter.accounts,
+		Slots:     exporter.slots,
+		Codes:     exporter.codes,
+		Files:     exporter.files(),
+	}
+
+	return nil
+}
+
+func (p *exportParams) getResult() *ExportResult {
+	return p.result
+}
+
+// column is a column of an exported table
+type column struct {
+	name string
+	// integer is set for the columns of unsigned integers, typed in parquet
+	integer bool
+}
+
+// tableWriter writes the rows of an exported table, one by one
+type tableWriter interface {
+	write(record ...string) error
+	close() error
+	filePath() string
+}
+
+// newTableWriter returns the writer of the table in the format, nil on error
+func newTableWriter(dir, name, format string, columns []column) (tableWriter, error) {
+	if format == parquetFormat {
+		w, err := newParquetTableWriter(dir, name, columns)
+		if err != nil {
+			return nil, err
+		}
+
+		return w, nil
+	}
+
+	w, err := newCSVTableWriter(dir, name, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// csvTableWriter writes the rows of a table to a CSV file with a header
+type csvTableWriter struct {
+	path   string
+	file   *os.File
+	buffer *bufio.Writer
+	csv    *csv.Writer
+}
+
+func newCSVTableWriter(dir, name string, columns []column) (*csvTableWriter, error) {
+	path := filepath.Join(dir, name+"."+csvFormat)
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := bufio.NewWriter(file)
+
+	w := &csvTableWriter{
+		path:   path,
+		file:   file,
+		buffer: buffer,
+		csv:    csv.NewWriter(buffer),
+	}
+
+	header := make([]string, len(columns))
+	for idx, column := range columns {
+		header[idx] = column.name
+	}
+
+	if err := w.write(header...); err != nil {
+		_ = file.Close()
+
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *csvTableWriter) filePath() string {
+	return w.path
+}
+
+func (w *csvTableWriter) write(record ...string) error {
+	return w.csv.Write(record)
+}
+
+func (w *csvTableWriter) close() error {
+	w.csv.Flush()
+
+	if err := w.csv.Error(); err != nil {
+		_ = w.file.Close()
+
+		return err
+	}
+
+	if err := w.buffer.Flush(); err != nil {
+		_ = w.file.Close()
+
+		return err
+	}
+
+	return w.file.Close()
+}
+
+// stateExporter streams the entries of the state to the tables as the tries are walked,
+// the memory held being the paths walked and the hashes of the exported codes.
+// The state is keyed by the hashes of the addresses and of the slots, as the preimages aren't stored
+type stateExporter struct {
+	state   *itrie.State
+	storage itrie.Storage
+
+	accountsTable tableWriter
+	storageTable  tableWriter
+	codeTable     tableWriter
+
+	seenCodes map[types.Hash]struct{}
+
+	accounts uint64
+	slots    uint64
+	codes    uint64
+}
+
+func newStateExporter(
+	st *itrie.State,
+	storage itrie.Storage,
+	dir, format string,
+	withStorage bool,
+) (*stateExporter, error) {
+	e := &stateExporter{
+		state:     st,
+		storage:   storage,
+		seenCodes: map[types.Hash]struct{}{},
+	}
+
+	var err error
+
+	if e.accountsTable, err = newTableWriter(dir, "accounts", format, []column{
+		{name: "address_hash"},
+		{name: "nonce", integer: true},
+		{name: "balance"},
+		{name: "storage_root"},
+		{name: "code_hash"},
+	}); err != nil {
+		return nil, err
+	}
+
+	if e.codeTable, err = newTableWriter(dir, "code", format, []column{
+		{name: "code_hash"},
+		{name: "size", integer: true},
+	}); err != nil {
+		_ = e.close()
+
+		return nil, err
+	}
+
+	if withStorage {
+		if e.storageTable, err = newTableWriter(dir, "storage", format, []column{
+			{name: "address_hash"},
+			{name: "slot_hash"},
+			{name: "value"},
+		}); err != nil {
+			_ = e.close()
+
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+func (e *stateExporter) export(root types.Hash) error {
+	return e.state.IterateAccounts(root, func(addrHash types.Hash, account *state.Account) error {
+		codeHash := types.BytesToHash(account.CodeHash)
+
+		if err := e.accountsTable.write(
+			addrHash.String(),
+			strconv.FormatUint(account.Nonce, 10),
+			account.Balance.String(),
+			account.Root.String(),
+			codeHash.String(),
+		); err != nil {
+			return err
+		}
+
+		e.accounts++
+
+		if err := e.exportCode(codeHash); err != nil {
+			return err
+		}
+
+		if e.storageTable == nil {
+			return nil
+		}
+
+		return e.state.IterateStorage(account.Root, func(slotHash types.Hash, value []byte) error {
+			e.slots++
+
+			return e.storageTable.write(
+				addrHash.String(),
+				slotHash.String(),
+				hex.EncodeBig(new(big.Int).SetBytes(value)),
+			)
+		})
+	})
+}
+
+// exportCode writes the metadata of the code once per code hash
+func (e *stateExporter) exportCode(codeHash types.Hash) error {
+	if codeHash == types.ZeroHash || codeHash == emptyCodeHash {
+		return nil
+	}
+
+	if _, ok := e.seenCodes[codeHash]; ok {
+		return nil
+	}
+
+	e.seenCodes[codeHash] = struct{}{}
+
+	code, ok := e.storage.GetCode(codeHash)
+	if !ok {
+		return fmt.Errorf("%w: %s", itrie.ErrCodeMissing, codeHash)
+	}
+
+	if !bytes.Equal(crypto.Keccak256(code), codeHash.Bytes()) {
+		return fmt.Errorf("%w: %s", itrie.ErrCodeCorrupt, codeHash)
+	}
+
+	e.codes++
+
+	return e.codeTable.write(codeHash.String(), strconv.Itoa(len(code)))
+}
+
+func (e *stateExporter) tables() []tableWriter {
+	tables := []tableWriter{}
+
+	for _, table := range []tableWriter{e.accountsTable, e.storageTable, e.codeTable} {
+		if table != nil {
+			tables = append(tables, table)
+		}
+	}
+
+	return tables
+}
+
+func (e *stateExporter) files() []string {
+	files := []string{}
+
+	for _, table := range e.tables() {
+		files = append(files, table.filePath())
+	}
+
+	return files
+}
+
+func (e *stateExporter) close() error {
+	var closeErr error
+
+	for _, table := range e.tables() {
+		if err := table.close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+
+	return closeErr
+}
@@ -0,0 +1,39 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitRawParams_Format(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		format string
+		err    error
+	}{
+		{csvFormat, nil},
+		{parquetFormat, nil},
+		{"json", errUnsupportedFormat},
+		{"CSV", errUnsupportedFormat},
+		{"", errUnsupportedFormat},
+	}
+
+	for _, test := range tests {
+		p := &exportParams{format: test.format}
+
+		assert.ErrorIs(t, p.initRawParams(), test.err, test.format)
+	}
+}
+
+func TestInitRawParams_Block(t *testing.T) {
+	t.Parallel()
+
+	p := &exportParams{format: csvFormat, blockRaw: "0x10"}
+	assert.NoError(t, p.initRawParams())
+	assert.Equal(t, uint64(16), *p.block)
+
+	p = &exportParams{format: csvFormat, blockRaw: "latest"}
+	assert.ErrorIs(t, p.initRawParams(), errDecodeBlock)
+}
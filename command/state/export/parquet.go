@@ -0,0 +1,431 @@
+package export
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// parquetRowGroupSize is the size of the values buffered before they are written as a row group,
+// bounding the memory held by a parquet table
+const parquetRowGroupSize = 8 << 20
+
+var parquetMagic = []byte("PAR1")
+
+// The values of the parquet format enums written to the metadata, see parquet.thrift
+const (
+	parquetTypeInt64     = 2
+	parquetTypeByteArray = 6
+
+	parquetConvertedUTF8   = 0
+	parquetConvertedUint64 = 14
+
+	parquetRequired       = 0
+	parquetEncodingPlain  = 0
+	parquetEncodingRLE    = 3
+	parquetDataPage       = 0
+	parquetUncompressed   = 0
+	parquetFormatVersion  = 1
+	parquetCreatedBy      = "polygon-edge"
+	parquetRootSchemaName = "schema"
+)
+
+// parquetColumnChunk is the location of a column in a row group
+type parquetColumnChunk struct {
+	offset int64
+	size   int64
+}
+
+// parquetRowGroup is the metadata of a row group written to the file
+type parquetRowGroup struct {
+	rows   int64
+	size   int64
+	chunks []parquetColumnChunk
+}
+
+// parquetTableWriter writes the rows of a table to a parquet file. The values are PLAIN encoded by column
+// and flushed as an uncompressed row group once rowGroupSize bytes are buffered,
+// the memory held being a row group and the locations of the row groups written
+type parquetTableWriter struct {
+	path    string
+	file    *os.File
+	buffer  *bufio.Writer
+	columns []column
+
+	// rowGroupSize is the size of the values buffered before a row group is written
+	rowGroupSize int
+
+	// offset is the number of bytes written to the file
+	offset int64
+
+	values   [][]byte
+	buffered int
+	rows     int64
+
+	rowGroups []parquetRowGroup
+	totalRows int64
+}
+
+func newParquetTableWriter(dir, name string, columns []column) (*parquetTableWriter, error) {
+	path := filepath.Join(dir, name+"."+parquetFormat)
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &parquetTableWriter{
+		path:         path,
+		file:         file,
+		buffer:       bufio.NewWriter(file),
+		columns:      columns,
+		rowGroupSize: parquetRowGroupSize,
+		values:       make([][]byte, len(columns)),
+	}
+
+	if err := w.writeBytes(parquetMagic); err != nil {
+		_ = file.Close()
+
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *parquetTableWriter) filePath() string {
+	return w.path
+}
+
+func (w *parquetTableWriter) write(record ...string) error {
+	if len(record) != len(w.columns) {
+		return fmt.Errorf("%d values for the %d columns of %s", len(record), len(w.columns), w.path)
+	}
+
+	// the integers are parsed first, not to buffer a part of an invalid row
+	integers := make([]uint64, len(record))
+
+	for idx, value := range record {
+		if !w.columns[idx].integer {
+			continue
+		}
+
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", w.columns[idx].name, err)
+		}
+
+		integers[idx] = n
+	}
+
+	for idx, value := range record {
+		before := len(w.values[idx])
+
+		if w.columns[idx].integer {
+			w.values[idx] = appendUint64(w.values[idx], integers[idx])
+		} else {
+			w.values[idx] = appendUint32(w.values[idx], uint32(len(value)))
+			w.values[idx] = append(w.values[idx], value...)
+		}
+
+		w.buffered += len(w.values[idx]) - before
+	}
+
+	w.rows++
+
+	if w.buffered >= w.rowGroupSize {
+		return w.flushRowGroup()
+	}
+
+	return nil
+}
+
+// flushRowGroup writes the buffered values as a row group, a single data page per column
+func (w *parquetTableWriter) flushRowGroup() error {
+	if w.rows == 0 {
+		return nil
+	}
+
+	rowGroup := parquetRowGroup{
+		rows:   w.rows,
+		chunks: make([]parquetColumnChunk, len(w.columns)),
+	}
+
+	for idx, values := range w.values {
+		header := encodePageHeader(len(values), w.rows)
+
+		chunk := parquetColumnChunk{
+			offset: w.offset,
+			size:   int64(len(header) + len(values)),
+		}
+
+		if err := w.writeBytes(header); err != nil {
+			return err
+		}
+
+		if err := w.writeBytes(values); err != nil {
+			return err
+		}
+
+		rowGroup.chunks[idx] = chunk
+		rowGroup.size += chunk.size
+
+		w.values[idx] = w.values[idx][:0]
+	}
+
+	w.rowGroups = append(w.rowGroups, rowGroup)
+	w.totalRows += w.rows
+	w.rows = 0
+	w.buffered = 0
+
+	return nil
+}
+
+func (w *parquetTableWriter) writeBytes(data []byte) error {
+	n, err := w.buffer.Write(data)
+	w.offset += int64(n)
+
+	return err
+}
+
+func (w *parquetTableWriter) close() error {
+	if err := w.closeFile(); err != nil {
+		_ = w.file.Close()
+
+		return err
+	}
+
+	return w.file.Close()
+}
+
+// closeFile writes the last row group and the footer of the file
+func (w *parquetTableWriter) closeFile() error {
+	if err := w.flushRowGroup(); err != nil {
+		return err
+	}
+
+	footer := w.encodeFileMetadata()
+
+	if err := w.writeBytes(footer); err != nil {
+		return err
+	}
+
+	if err := w.writeBytes(appendUint32(nil, uint32(len(footer)))); err != nil {
+		return err
+	}
+
+	if err := w.writeBytes(parquetMagic); err != nil {
+		return err
+	}
+
+	return w.buffer.Flush()
+}
+
+func appendUint32(buf []byte, n uint32) []byte {
+	var tmp [4]byte
+
+	binary.LittleEndian.PutUint32(tmp[:], n)
+
+	return append(buf, tmp[:]...)
+}
+
+func appendUint64(buf []byte, n uint64) []byte {
+	var tmp [8]byte
+
+	binary.LittleEndian.PutUint64(tmp[:], n)
+
+	return append(buf, tmp[:]...)
+}
+
+// parquetType returns the physical and the converted types of the column
+func (c column) parquetType() (int32, int32) {
+	if c.integer {
+		return parquetTypeInt64, parquetConvertedUint64
+	}
+
+	return parquetTypeByteArray, parquetConvertedUTF8
+}
+
+// encodePageHeader returns the PageHeader of a data page of required PLAIN encoded values,
+// which have no definition and repetition levels
+func encodePageHeader(size int, rows int64) []byte {
+	e := &thriftEncoder{}
+
+	e.i32(1, parquetDataPage)
+	e.i32(2, int32(size))
+	e.i32(3, int32(size))
+
+	e.structBegin(5)
+	e.i32(1, int32(rows))
+	e.i32(2, parquetEncodingPlain)
+	e.i32(3, parquetEncodingRLE)
+	e.i32(4, parquetEncodingRLE)
+	e.structEnd()
+
+	e.structEnd()
+
+	return e.buf
+}
+
+// encodeFileMetadata returns the FileMetaData of the footer, with the flat schema of the table
+func (w *parquetTableWriter) encodeFileMetadata() []byte {
+	e := &thriftEncoder{}
+
+	e.i32(1, parquetFormatVersion)
+
+	e.listBegin(2, thriftStruct, len(w.columns)+1)
+	e.elemBegin()
+	e.binary(4, parquetRootSchemaName)
+	e.i32(5, int32(len(w.columns)))
+	e.structEnd()
+
+	for _, column := range w.columns {
+		physicalType, convertedType := column.parquetType()
+
+		e.elemBegin()
+		e.i32(1, physicalType)
+		e.i32(3, parquetRequired)
+		e.binary(4, column.name)
+		e.i32(6, convertedType)
+		e.structEnd()
+	}
+
+	e.i64(3, w.totalRows)
+
+	e.listBegin(4, thriftStruct, len(w.rowGroups))
+
+	for _, rowGroup := range w.rowGroups {
+		e.elemBegin()
+		e.listBegin(1, thriftStruct, len(rowGroup.chunks))
+
+		for idx, chunk := range rowGroup.chunks {
+			physicalType, _ := w.columns[idx].parquetType()
+
+			e.elemBegin()
+			e.i64(2, chunk.offset)
+
+			e.structBegin(3)
+			e.i32(1, physicalType)
+			e.listBegin(2, thriftI32, 1)
+			e.i32Elem(parquetEncodingPlain)
+			e.listBegin(3, thriftBinary, 1)
+			e.binaryElem(w.columns[idx].name)
+			e.i32(4, parquetUncompressed)
+			e.i64(5, rowGroup.rows)
+			e.i64(6, chunk.size)
+			e.i64(7, chunk.size)
+			e.i64(9, chunk.offset)
+			e.structEnd()
+
+			e.structEnd()
+		}
+
+		e.i64(2, rowGroup.size)
+		e.i64(3, rowGroup.rows)
+		e.structEnd()
+	}
+
+	e.binary(6, parquetCreatedBy)
+	e.structEnd()
+
+	return e.buf
+}
+
+// The types of the thrift compact protocol
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftEncoder encodes the parquet metadata with the thrift compact protocol.
+// The field ids are delta encoded from the previous field of the struct being encoded
+type thriftEncoder struct {
+	buf []byte
+
+	lastField  int16
+	lastFields []int16
+}
+
+func (e *thriftEncoder) uvarint(n uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+
+	e.buf = append(e.buf, tmp[:binary.PutUvarint(tmp[:], n)]...)
+}
+
+func (e *thriftEncoder) varint(n int64) {
+	e.uvarint(uint64((n << 1) ^ (n >> 63)))
+}
+
+func (e *thriftEncoder) field(id int16, typ byte) {
+	if delta := id - e.lastField; delta > 0 && delta <= 15 {
+		e.buf = append(e.buf, byte(delta)<<4|typ)
+	} else {
+		e.buf = append(e.buf, typ)
+		e.varint(int64(id))
+	}
+
+	e.lastField = id
+}
+
+func (e *thriftEncoder) i32(id int16, n int32) {
+	e.field(id, thriftI32)
+	e.varint(int64(n))
+}
+
+func (e *thriftEncoder) i64(id int16, n int64) {
+	e.field(id, thriftI64)
+	e.varint(n)
+}
+
+func (e *thriftEncoder) binary(id int16, s string) {
+	e.field(id, thriftBinary)
+	e.binaryElem(s)
+}
+
+// listBegin starts a list field, whose elements follow
+func (e *thriftEncoder) listBegin(id int16, elemType byte, size int) {
+	e.field(id, thriftList)
+
+	if size < 15 {
+		e.buf = append(e.buf, byte(size)<<4|elemType)
+	} else {
+		e.buf = append(e.buf, 0xf0|elemType)
+		e.uvarint(uint64(size))
+	}
+}
+
+func (e *thriftEncoder) i32Elem(n int32) {
+	e.varint(int64(n))
+}
+
+func (e *thriftEncoder) binaryElem(s string) {
+	e.uvarint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+// structBegin starts a struct field, ended by structEnd
+func (e *thriftEncoder) structBegin(id int16) {
+	e.field(id, thriftStruct)
+	e.elemBegin()
+}
+
+// elemBegin starts a struct element of a list, ended by structEnd
+func (e *thriftEncoder) elemBegin() {
+	e.lastFields = append(e.lastFields, e.lastField)
+	e.lastField = 0
+}
+
+// structEnd ends the current struct, the top level one included
+func (e *thriftEncoder) structEnd() {
+	e.buf = append(e.buf, 0)
+
+	if n := len(e.lastFields); n > 0 {
+		e.lastField = e.lastFields[n-1]
+		e.lastFields = e.lastFields[:n-1]
+	}
+}
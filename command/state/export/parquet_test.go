@@ -0,0 +1,120 @@
+package export
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testParquetColumns = []column{
+	{name: "code_hash"},
+	{name: "size", integer: true},
+}
+
+// readParquetFile checks the magic of the file and returns it with its footer
+func readParquetFile(t *testing.T, path string) ([]byte, []byte) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, len(data), 12)
+	assert.Equal(t, parquetMagic, data[:4])
+	assert.Equal(t, parquetMagic, data[len(data)-4:])
+
+	size := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	require.LessOrEqual(t, size, len(data)-12)
+
+	return data, data[len(data)-8-size : len(data)-8]
+}
+
+func TestEncodePageHeader(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []byte{
+		0x15, 0x00, // type: DATA_PAGE
+		0x15, 0x10, // uncompressed_page_size: 8
+		0x15, 0x10, // compressed_page_size: 8
+		0x2c,       // data_page_header
+		0x15, 0x02, // num_values: 1
+		0x15, 0x00, // encoding: PLAIN
+		0x15, 0x06, // definition_level_encoding: RLE
+		0x15, 0x06, // repetition_level_encoding: RLE
+		0x00,
+		0x00,
+	}, encodePageHeader(8, 1))
+}
+
+func TestParquetTableWriter(t *testing.T) {
+	t.Parallel()
+
+	w, err := newParquetTableWriter(t.TempDir(), "code", testParquetColumns)
+	require.NoError(t, err)
+
+	// a row group per row
+	w.rowGroupSize = 1
+
+	require.NoError(t, w.write("0x01", "3"))
+	require.NoError(t, w.write("0x0203", "1024"))
+	require.NoError(t, w.close())
+
+	data, footer := readParquetFile(t, w.filePath())
+	assert.Equal(t, w.encodeFileMetadata(), footer)
+
+	require.Len(t, w.rowGroups, 2)
+	assert.Equal(t, int64(2), w.totalRows)
+
+	expected := [][][]byte{
+		{
+			append(appendUint32(nil, 4), "0x01"...),
+			appendUint64(nil, 3),
+		},
+		{
+			append(appendUint32(nil, 6), "0x0203"...),
+			appendUint64(nil, 1024),
+		},
+	}
+
+	for i, rowGroup := range w.rowGroups {
+		assert.Equal(t, int64(1), rowGroup.rows)
+
+		for j, chunk := range rowGroup.chunks {
+			values := expected[i][j]
+			page := append(encodePageHeader(len(values), 1), values...)
+
+			assert.Equal(t, int64(len(page)), chunk.size)
+			assert.Equal(t, page, data[chunk.offset:chunk.offset+chunk.size])
+		}
+	}
+}
+
+func TestParquetTableWriter_Empty(t *testing.T) {
+	t.Parallel()
+
+	w, err := newParquetTableWriter(t.TempDir(), "code", testParquetColumns)
+	require.NoError(t, err)
+	require.NoError(t, w.close())
+
+	data, footer := readParquetFile(t, w.filePath())
+	assert.Len(t, data, 4+len(footer)+8)
+	assert.Empty(t, w.rowGroups)
+}
+
+func TestParquetTableWriter_InvalidInteger(t *testing.T) {
+	t.Parallel()
+
+	w, err := newParquetTableWriter(t.TempDir(), "code", testParquetColumns)
+	require.NoError(t, err)
+
+	assert.Error(t, w.write("0x01", "-1"))
+	assert.Error(t, w.write("0x01"))
+
+	// no value of the invalid rows is buffered
+	assert.Zero(t, w.buffered)
+	assert.Empty(t, w.values[0])
+
+	require.NoError(t, w.close())
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type ExportResult struct {
+	Block     uint64     `json:"block"`
+	StateRoot types.Hash `json:"state_root"`
+	Accounts  uint64     `json:"accounts"`
+	Slots     uint64     `json:"slots"`
+	Codes     uint64     `json:"codes"`
+	Files     []string   `json:"files"`
+}
+
+func (r *ExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE EXPORT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("State root|%s", r.StateRoot),
+		fmt.Sprintf("Accounts|%d", r.Accounts),
+		fmt.Sprintf("Storage slots|%d", r.Slots),
+		fmt.Sprintf("Codes|%d", r.Codes),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[FILES]\n")
+	buffer.WriteString(helper.FormatList(r.Files))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -3,6 +3,7 @@ package status
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
 )
@@ -27,3 +28,17 @@ func (r *StatusResult) GetOutput() string {
 
 	return buffer.String()
 }
+
+func (r *StatusResult) GetTable() ([]string, [][]string) {
+	return []string{"CHAIN ID", "BLOCK NUMBER", "BLOCK HASH", "LIBP2P ADDRESS"},
+		[][]string{{
+			strconv.FormatInt(r.ChainID, 10),
+			strconv.FormatInt(r.CurrentBlockNumber, 10),
+			r.CurrentBlockHash,
+			r.LibP2PAddress,
+		}}
+}
+
+func (r *StatusResult) GetQuietOutput() []string {
+	return []string{strconv.FormatInt(r.CurrentBlockNumber, 10)}
+}
@@ -0,0 +1,54 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ryanuber/columnize"
+)
+
+// TableOutput writes the results as tables without decoration, one row per line,
+// the results which are not tables are written as in the CLI output
+type TableOutput struct {
+	commonOutputFormatter
+}
+
+func newTableOutput() *TableOutput {
+	return &TableOutput{}
+}
+
+func (to *TableOutput) WriteOutput() {
+	if to.errorOutput != nil {
+		_, _ = fmt.Fprintln(os.Stderr, to.getErrorOutput())
+
+		return
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout, to.getCommandOutput())
+}
+
+func (to *TableOutput) getErrorOutput() string {
+	return to.errorOutput.Error()
+}
+
+func (to *TableOutput) getCommandOutput() string {
+	table, ok := to.commandOutput.(TableResult)
+	if !ok {
+		return to.commandOutput.GetOutput()
+	}
+
+	header, rows := table.GetTable()
+
+	lines := make([]string, 0, len(rows)+1)
+	lines = append(lines, strings.Join(header, "|"))
+
+	for _, row := range rows {
+		lines = append(lines, strings.Join(row, "|"))
+	}
+
+	columnConf := columnize.DefaultConfig()
+	columnConf.Empty = "-"
+
+	return columnize.Format(lines, columnConf)
+}
@@ -3,6 +3,7 @@ package status
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 
 	"github.com/0xPolygon/polygon-edge/command/helper"
 )
@@ -22,3 +23,11 @@ func (r *TxPoolStatusResult) GetOutput() string {
 
 	return buffer.String()
 }
+
+func (r *TxPoolStatusResult) GetTable() ([]string, [][]string) {
+	return []string{"TRANSACTIONS"}, [][]string{{strconv.FormatUint(r.Transactions, 10)}}
+}
+
+func (r *TxPoolStatusResult) GetQuietOutput() []string {
+	return []string{strconv.FormatUint(r.Transactions, 10)}
+}
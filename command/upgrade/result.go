@@ -0,0 +1,113 @@
+package upgrade
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+type UpgradeResult struct {
+	Version     string           `json:"version"`
+	BlockNumber uint64           `json:"block_number"`
+	Peers       int              `json:"peers"`
+	Upgrades    []*UpgradeStatus `json:"upgrades"`
+	Versions    []*VersionCount  `json:"versions"`
+}
+
+type VersionCount struct {
+	Version string `json:"version"`
+	Peers   uint64 `json:"peers"`
+}
+
+type UpgradeStatus struct {
+	Name       string `json:"name"`
+	Block      uint64 `json:"block"`
+	Version    string `json:"version"`
+	Supported  bool   `json:"supported"`
+	ReadyPeers uint64 `json:"ready_peers"`
+}
+
+func newUpgradeResult(status *proto.UpgradeStatus) *UpgradeResult {
+	res := &UpgradeResult{
+		Version:     status.Version,
+		BlockNumber: status.BlockNumber,
+		Peers:       len(status.Peers),
+		Upgrades:    make([]*UpgradeStatus, len(status.Upgrades)),
+		Versions:    make([]*VersionCount, len(status.Versions)),
+	}
+
+	for i, u := range status.Upgrades {
+		res.Upgrades[i] = &UpgradeStatus{
+			Name:       u.Name,
+			Block:      u.Block,
+			Version:    u.Version,
+			Supported:  u.Supported,
+			ReadyPeers: u.ReadyPeers,
+		}
+	}
+
+	for i, v := range status.Versions {
+		res.Versions[i] = &VersionCount{
+			Version: v.Version,
+			Peers:   v.Peers,
+		}
+	}
+
+	return res
+}
+
+func (r *UpgradeResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	version := r.Version
+	if version == "" {
+		version = "development"
+	}
+
+	buffer.WriteString("\n[UPGRADE STATUS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Version|%s", version),
+		fmt.Sprintf("Current Block Number (base 10)|%d", r.BlockNumber),
+		fmt.Sprintf("Peers|%d", r.Peers),
+	}))
+	buffer.WriteString("\n")
+
+	if len(r.Versions) > 0 {
+		versions := make([]string, len(r.Versions))
+
+		for i, v := range r.Versions {
+			version := v.Version
+			if version == "" {
+				version = "unknown"
+			}
+
+			versions[i] = fmt.Sprintf("%s|%d/%d", version, v.Peers, r.Peers)
+		}
+
+		buffer.WriteString("\n[PEER VERSIONS]\n")
+		buffer.WriteString(helper.FormatKV(versions))
+		buffer.WriteString("\n")
+	}
+
+	if len(r.Upgrades) == 0 {
+		buffer.WriteString("\n[SCHEDULED UPGRADES]\n")
+		buffer.WriteString("No scheduled upgrades\n")
+
+		return buffer.String()
+	}
+
+	for _, u := range r.Upgrades {
+		buffer.WriteString(fmt.Sprintf("\n[UPGRADE %s]\n", u.Name))
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("Block|%d", u.Block),
+			fmt.Sprintf("Required Version|%s", u.Version),
+			fmt.Sprintf("Supported|%t", u.Supported),
+			fmt.Sprintf("Ready Peers|%d/%d", u.ReadyPeers, r.Peers),
+		}))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
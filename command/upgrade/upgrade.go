@@ -0,0 +1,50 @@
+package upgrade
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+)
+
+func GetCommand() *cobra.Command {
+	upgradeCmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Returns the scheduled upgrades with the readiness of the node and its peers",
+		Args:  cobra.NoArgs,
+		Run:   runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(upgradeCmd)
+
+	return upgradeCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	upgradeStatus, err := getUpgradeStatus(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newUpgradeResult(upgradeStatus))
+}
+
+func getUpgradeStatus(grpcAddress string) (*proto.UpgradeStatus, error) {
+	client, err := helper.GetSystemClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetUpgradeStatus(context.Background(), &empty.Empty{})
+}
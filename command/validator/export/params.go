@@ -0,0 +1,269 @@
+package export
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	ibftOp "github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	secretsHelper "github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/0xPolygon/polygon-edge/validators/bundle"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	epochFlag     = "epoch"
+	epochSizeFlag = "epoch-size"
+	bundleFlag    = "bundle"
+	dataDirFlag   = "data-dir"
+	configFlag    = "config"
+
+	defaultBundlePath = "validators.json"
+)
+
+var (
+	params = &exportParams{}
+)
+
+var (
+	errInvalidConfig    = errors.New("invalid secrets configuration")
+	errInvalidParams    = errors.New("no config file or data directory passed in")
+	errUnsupportedType  = errors.New("unsupported secrets manager")
+	errInvalidEpoch     = errors.New("the epoch must be positive")
+	errInvalidEpochSize = errors.New("the epoch size must be positive")
+)
+
+type exportParams struct {
+	epoch      uint64
+	epochSize  uint64
+	bundlePath string
+
+	dataDir        string
+	configPath     string
+	jsonrpcAddress string
+	grpcAddress    string
+
+	validatorKey *ecdsa.PrivateKey
+
+	bundle *bundle.Bundle
+}
+
+func (p *exportParams) validateFlags() error {
+	if p.dataDir == "" && p.configPath == "" {
+		return errInvalidParams
+	}
+
+	if p.epoch == 0 {
+		return errInvalidEpoch
+	}
+
+	if p.epochSize == 0 {
+		return errInvalidEpochSize
+	}
+
+	return nil
+}
+
+func (p *exportParams) initSecrets() error {
+	secretsManager, err := p.initSecretsManager()
+	if err != nil {
+		return err
+	}
+
+	if p.validatorKey, err = crypto.ReadConsensusKey(secretsManager); err != nil {
+		return fmt.Errorf("unable to read validator key, %w", err)
+	}
+
+	return nil
+}
+
+func (p *exportParams) initSecretsManager() (secrets.SecretsManager, error) {
+	if p.configPath == "" {
+		return secretsHelper.SetupLocalSecretsManager(p.dataDir)
+	}
+
+	secretsConfig, readErr := secrets.ReadConfig(p.configPath)
+	if readErr != nil {
+		return nil, errInvalidConfig
+	}
+
+	if !secrets.SupportedServiceManager(secretsConfig.Type) {
+		return nil, errUnsupportedType
+	}
+
+	return secretsHelper.InitCloudSecretsManager(secretsConfig)
+}
+
+// export exports the validator set at the last block of the epoch, co-signing
+// the existing bundle of the same validator set
+func (p *exportParams) export() error {
+	b, err := p.queryBundle()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(p.bundlePath); err == nil {
+		existing, err := bundle.ReadFile(p.bundlePath)
+		if err != nil {
+			return err
+		}
+
+		if _, err := existing.Verify(); err != nil {
+			return err
+		}
+
+		if err := b.CoSign(existing); err != nil {
+			return fmt.Errorf("unable to co-sign %s, %w", p.bundlePath, err)
+		}
+	}
+
+	if err := b.Sign(p.validatorKey); err != nil {
+		return err
+	}
+
+	if err := b.WriteFile(p.bundlePath); err != nil {
+		return fmt.Errorf("unable to write bundle, %w", err)
+	}
+
+	p.bundle = b
+
+	return nil
+}
+
+// queryBundle queries the unsigned bundle of the validator set at the last block of the epoch
+func (p *exportParams) queryBundle() (*bundle.Bundle, error) {
+	block := p.epoch * p.epochSize
+
+	ibftClient, err := helper.GetIBFTOperatorClientConnection(p.grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := ibftClient.GetSnapshot(context.Background(), &ibftOp.SnapshotReq{Number: block})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query the validator set of block %d, %w", block, err)
+	}
+
+	client, err := jsonrpc.NewClient(p.jsonrpcAddress)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	defer client.Close()
+
+	chainID, err := client.Eth().ChainID()
+	if err != nil {
+		return nil, fmt.Errorf("unable to query chain ID, %w", err)
+	}
+
+	b := &bundle.Bundle{
+		ChainID:    chainID.Uint64(),
+		Epoch:      p.epoch,
+		Block:      block,
+		BlockHash:  types.StringToHash(snapshot.Hash),
+		Validators: make([]*bundle.Validator, len(snapshot.Validators)),
+		Signatures: []*bundle.Signature{},
+	}
+
+	for idx, v := range snapshot.Validators {
+		if b.Validators[idx], err = toBundleValidator(v); err != nil {
+			return nil, err
+		}
+
+		b.Type = validators.ValidatorType(v.Type)
+	}
+
+	if err := queryStakes(client, block, b.Validators); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func toBundleValidator(v *ibftOp.Snapshot_Validator) (*bundle.Validator, error) {
+	validator, err := validators.NewValidatorFromType(validators.ValidatorType(v.Type))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validator.SetFromBytes(v.Data); err != nil {
+		return nil, fmt.Errorf("invalid validator %s, %w", v.Address, err)
+	}
+
+	res := &bundle.Validator{
+		Address: validator.Addr(),
+	}
+
+	if bls, ok := validator.(*validators.BLSValidator); ok {
+		res.BLSPublicKey = bls.BLSPublicKey
+	}
+
+	return res, nil
+}
+
+// queryStakes queries the stakes of the validators at the block, if the chain has a staking contract
+func queryStakes(client *jsonrpc.Client, block uint64, vals []*bundle.Validator) error {
+	code, err := client.Eth().GetCode(ethgo.Address(staking.AddrStakingContract), ethgo.BlockNumber(block))
+	if err != nil {
+		return fmt.Errorf("unable to query staking contract, %w", err)
+	}
+
+	if raw, _ := hex.DecodeHex(code); len(raw) == 0 {
+		return nil
+	}
+
+	binding := staking.NewStaking(staking.AddrStakingContract, &jsonrpcCaller{client, block})
+
+	for _, v := range vals {
+		stake, err := binding.AccountStake(v.Address)
+		if err != nil {
+			return fmt.Errorf("unable to query the stake of %s, %w", v.Address, err)
+		}
+
+		v.Stake = new(big.Int).Set(stake)
+	}
+
+	return nil
+}
+
+// jsonrpcCaller applies the view calls of the contract bindings at a block over JSON-RPC
+type jsonrpcCaller struct {
+	client *jsonrpc.Client
+	block  uint64
+}
+
+// Call implements the abi.Caller interface
+func (c *jsonrpcCaller) Call(to types.Address, input []byte) ([]byte, error) {
+	res, err := c.client.Eth().Call(&ethgo.CallMsg{
+		To:   (*ethgo.Address)(&to),
+		Data: input,
+	}, ethgo.BlockNumber(c.block))
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeHex(res)
+}
+
+func (p *exportParams) getResult() *ExportResult {
+	return &ExportResult{
+		Bundle:     p.bundlePath,
+		Epoch:      p.bundle.Epoch,
+		Block:      p.bundle.Block,
+		BlockHash:  p.bundle.BlockHash.String(),
+		Validators: len(p.bundle.Validators),
+		Signer:     crypto.PubKeyToAddress(&p.validatorKey.PublicKey).String(),
+		Signatures: len(p.bundle.Signatures),
+	}
+}
@@ -0,0 +1,36 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ExportResult struct {
+	Bundle     string `json:"bundle"`
+	Epoch      uint64 `json:"epoch"`
+	Block      uint64 `json:"block"`
+	BlockHash  string `json:"block_hash"`
+	Validators int    `json:"validators"`
+	Signer     string `json:"signer"`
+	Signatures int    `json:"signatures"`
+}
+
+func (r *ExportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[VALIDATOR EXPORT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Bundle|%s", r.Bundle),
+		fmt.Sprintf("Epoch|%d", r.Epoch),
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("Block Hash|%s", r.BlockHash),
+		fmt.Sprintf("Validators|%d", r.Validators),
+		fmt.Sprintf("Signer|%s", r.Signer),
+		fmt.Sprintf("Signatures|%d", r.Signatures),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
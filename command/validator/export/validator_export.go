@@ -0,0 +1,96 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use: "export",
+		Short: "Exports the validator set, stakes and BLS keys at the last block of an epoch to a bundle " +
+			"signed by the validator key. An existing bundle of the same validator set is co-signed",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(exportCmd)
+
+	setFlags(exportCmd)
+
+	return exportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(
+		&params.epoch,
+		epochFlag,
+		0,
+		"the epoch whose last block the validator set is exported at",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.epochSize,
+		epochSizeFlag,
+		ibft.DefaultEpochSize,
+		"the epoch size of the chain",
+	)
+
+	cmd.Flags().StringVar(
+		&params.bundlePath,
+		bundleFlag,
+		defaultBundlePath,
+		"the path to the bundle, co-signed if it exists",
+	)
+
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the Polygon Edge data if the local FS is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the SecretsManager config file, "+
+			"if omitted, the local FS secrets manager is used",
+	)
+
+	_ = cmd.MarkFlagRequired(epochFlag)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	params.jsonrpcAddress = helper.GetJSONRPCAddress(cmd)
+	params.grpcAddress = helper.GetGRPCAddress(cmd)
+
+	if _, err := helper.ParseJSONRPCAddress(params.jsonrpcAddress); err != nil {
+		return fmt.Errorf("invalid JSON-RPC address, %w", err)
+	}
+
+	return params.initSecrets()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.export(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
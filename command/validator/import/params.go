@@ -0,0 +1,173 @@
+package validatorimport
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/0xPolygon/polygon-edge/validators/bundle"
+)
+
+const (
+	chainFlag  = "chain"
+	bundleFlag = "bundle"
+	fromFlag   = "from"
+
+	defaultBundlePath = "validators.json"
+)
+
+var (
+	params = &importParams{}
+)
+
+var (
+	ErrNoSupermajority    = errors.New("the bundle isn't signed by a supermajority of its validators")
+	ErrChainIDMismatch    = errors.New("the bundle belongs to another chain")
+	ErrIBFTConfigNotFound = errors.New(`"ibft" config doesn't exist in "engine" of genesis.json'`)
+	ErrFromBeforeBundle   = errors.New(`"from" must be after the block of the bundle`)
+	ErrFromBeforeLastFork = errors.New(`"from" must be greater than the beginning height of last fork`)
+)
+
+type importParams struct {
+	genesisPath string
+	bundlePath  string
+	from        uint64
+
+	bundle     *bundle.Bundle
+	signers    []types.Address
+	validators validators.Validators
+
+	genesisConfig *chain.Chain
+}
+
+func (p *importParams) initRawParams() error {
+	if err := p.initBundle(); err != nil {
+		return err
+	}
+
+	return p.initChain()
+}
+
+// initBundle reads the bundle and checks it's signed by a supermajority of its validators
+func (p *importParams) initBundle() error {
+	b, err := bundle.ReadFile(p.bundlePath)
+	if err != nil {
+		return err
+	}
+
+	if p.signers, err = b.Verify(); err != nil {
+		return err
+	}
+
+	if !b.HasSupermajority(p.signers) {
+		return fmt.Errorf("%w: %d signatures of %d validators", ErrNoSupermajority, len(p.signers), len(b.Validators))
+	}
+
+	if p.validators, err = b.ValidatorSet(); err != nil {
+		return err
+	}
+
+	if p.from == 0 {
+		p.from = b.Block + 1
+	}
+
+	if p.from <= b.Block {
+		return ErrFromBeforeBundle
+	}
+
+	p.bundle = b
+
+	return nil
+}
+
+func (p *importParams) initChain() error {
+	cc, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to load chain config from %s: %w",
+			p.genesisPath,
+			err,
+		)
+	}
+
+	if uint64(cc.Params.ChainID) != p.bundle.ChainID {
+		return fmt.Errorf("%w: %d", ErrChainIDMismatch, p.bundle.ChainID)
+	}
+
+	p.genesisConfig = cc
+
+	return nil
+}
+
+// updateGenesisConfig appends a PoA fork setting the validator set of the bundle
+func (p *importParams) updateGenesisConfig() error {
+	ibftConfig, ok := p.genesisConfig.Params.Engine["ibft"].(map[string]interface{})
+	if !ok {
+		return ErrIBFTConfigNotFound
+	}
+
+	ibftForks, err := fork.GetIBFTForks(ibftConfig)
+	if err != nil {
+		return err
+	}
+
+	lastFork := ibftForks[len(ibftForks)-1]
+
+	if p.from <= lastFork.From.Value {
+		return ErrFromBeforeLastFork
+	}
+
+	lastFork.To = &common.JSONNumber{Value: p.from - 1}
+
+	ibftForks = append(ibftForks, &fork.IBFTFork{
+		Type:          fork.PoA,
+		ValidatorType: p.bundle.Type,
+		From:          common.JSONNumber{Value: p.from},
+		Validators:    p.validators,
+	})
+
+	ibftConfig["types"] = ibftForks
+
+	// remove leftover config
+	delete(ibftConfig, "type")
+
+	p.genesisConfig.Params.Engine["ibft"] = ibftConfig
+
+	return nil
+}
+
+func (p *importParams) overrideGenesisConfig() error {
+	// Remove the current genesis configuration from disk
+	if err := os.Remove(p.genesisPath); err != nil {
+		return err
+	}
+
+	// Save the new genesis configuration
+	return helper.WriteGenesisConfigToDisk(
+		p.genesisConfig,
+		p.genesisPath,
+	)
+}
+
+func (p *importParams) getResult() *ImportResult {
+	signers := make([]string, len(p.signers))
+	for i, signer := range p.signers {
+		signers[i] = signer.String()
+	}
+
+	return &ImportResult{
+		Chain:         p.genesisPath,
+		Epoch:         p.bundle.Epoch,
+		Block:         p.bundle.Block,
+		From:          p.from,
+		ValidatorType: p.bundle.Type,
+		Validators:    p.validators.Len(),
+		Signers:       signers,
+	}
+}
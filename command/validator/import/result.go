@@ -0,0 +1,40 @@
+package validatorimport
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+type ImportResult struct {
+	Chain         string                   `json:"chain"`
+	Epoch         uint64                   `json:"epoch"`
+	Block         uint64                   `json:"block"`
+	From          uint64                   `json:"from"`
+	ValidatorType validators.ValidatorType `json:"validator_type"`
+	Validators    int                      `json:"validators"`
+	Signers       []string                 `json:"signers"`
+}
+
+func (r *ImportResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[VALIDATOR IMPORT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Chain|%s", r.Chain),
+		fmt.Sprintf("Epoch|%d", r.Epoch),
+		fmt.Sprintf("Block|%d", r.Block),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("Validator Type|%s", r.ValidatorType),
+		fmt.Sprintf("Validators|%d", r.Validators),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[SIGNERS]\n")
+	buffer.WriteString(helper.FormatList(r.Signers))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
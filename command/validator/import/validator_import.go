@@ -0,0 +1,69 @@
+package validatorimport
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	importCmd := &cobra.Command{
+		Use: "import",
+		Short: "Verifies a validator set bundle signed by a supermajority of its validators and adds a PoA fork " +
+			"to genesis.json restarting the chain with its validator set",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(importCmd)
+
+	return importCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file to update",
+	)
+
+	cmd.Flags().StringVar(
+		&params.bundlePath,
+		bundleFlag,
+		defaultBundlePath,
+		"the path to the validator set bundle",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.from,
+		fromFlag,
+		0,
+		"the height the chain restarts with the validator set of the bundle, "+
+			"the block following the bundle if omitted",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.updateGenesisConfig(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.overrideGenesisConfig(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
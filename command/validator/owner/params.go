@@ -0,0 +1,171 @@
+package owner
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	dataDirFlag  = "data-dir"
+	configFlag   = "config"
+	ownerFlag    = "owner"
+	gasPriceFlag = "gas-price"
+	gasLimitFlag = "gas-limit"
+)
+
+const (
+	defaultGasPrice = uint64(1000000000) // 1 Gwei
+	defaultGasLimit = uint64(200000)
+)
+
+var (
+	params = &ownerParams{}
+)
+
+var (
+	errInvalidConfig   = errors.New("invalid secrets configuration")
+	errInvalidParams   = errors.New("no config file or data directory passed in")
+	errUnsupportedType = errors.New("unsupported secrets manager")
+	errInvalidOwner    = errors.New("invalid owner address")
+	errNoOwner         = errors.New("no owner address passed in nor owner key initialized")
+)
+
+type ownerParams struct {
+	dataDir        string
+	configPath     string
+	ownerRaw       string
+	jsonrpcAddress string
+
+	gasPrice uint64
+	gasLimit uint64
+
+	validatorKey *ecdsa.PrivateKey
+	address      types.Address
+	owner        types.Address
+
+	txHash types.Hash
+}
+
+func (p *ownerParams) validateFlags() error {
+	if p.dataDir == "" && p.configPath == "" {
+		return errInvalidParams
+	}
+
+	if p.ownerRaw != "" {
+		if err := p.owner.UnmarshalText([]byte(p.ownerRaw)); err != nil {
+			return fmt.Errorf("%w: %s", errInvalidOwner, p.ownerRaw)
+		}
+	}
+
+	return nil
+}
+
+func (p *ownerParams) initSecrets() error {
+	secretsManager, err := p.initSecretsManager()
+	if err != nil {
+		return err
+	}
+
+	if p.validatorKey, err = crypto.ReadConsensusKey(secretsManager); err != nil {
+		return fmt.Errorf("unable to read validator key, %w", err)
+	}
+
+	p.address = crypto.PubKeyToAddress(&p.validatorKey.PublicKey)
+
+	// the owner defaults to the owner key initialized with the validator key
+	if p.ownerRaw == "" {
+		if p.owner, err = helper.LoadValidatorOwnerAddress(secretsManager); err != nil {
+			return fmt.Errorf("unable to read owner key, %w", err)
+		}
+	}
+
+	if p.owner == types.ZeroAddress || p.owner == p.address {
+		return errNoOwner
+	}
+
+	return nil
+}
+
+func (p *ownerParams) initSecretsManager() (secrets.SecretsManager, error) {
+	if p.configPath == "" {
+		return helper.SetupLocalSecretsManager(p.dataDir)
+	}
+
+	secretsConfig, readErr := secrets.ReadConfig(p.configPath)
+	if readErr != nil {
+		return nil, errInvalidConfig
+	}
+
+	if !secrets.SupportedServiceManager(secretsConfig.Type) {
+		return nil, errUnsupportedType
+	}
+
+	return helper.InitCloudSecretsManager(secretsConfig)
+}
+
+// registerOwner sends the transaction registering the owner of the validator, which controls
+// its stake from then on, while the validator key keeps signing the blocks
+func (p *ownerParams) registerOwner() error {
+	input, err := staking.NewStaking(staking.AddrStakingContract, nil).EncodeRegisterOwner(p.owner)
+	if err != nil {
+		return err
+	}
+
+	client, err := jsonrpc.NewClient(p.jsonrpcAddress)
+	if err != nil {
+		return fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	defer client.Close()
+
+	chainID, err := client.Eth().ChainID()
+	if err != nil {
+		return fmt.Errorf("unable to query chain ID, %w", err)
+	}
+
+	nonce, err := client.Eth().GetNonce(ethgo.Address(p.address), ethgo.Pending)
+	if err != nil {
+		return fmt.Errorf("unable to query nonce, %w", err)
+	}
+
+	signedTx, err := crypto.NewEIP155Signer(chainID.Uint64()).SignTx(&types.Transaction{
+		From:     p.address,
+		To:       &staking.AddrStakingContract,
+		Nonce:    nonce,
+		Gas:      p.gasLimit,
+		GasPrice: new(big.Int).SetUint64(p.gasPrice),
+		Value:    big.NewInt(0),
+		Input:    input,
+	}, p.validatorKey)
+	if err != nil {
+		return fmt.Errorf("unable to sign owner registration transaction, %w", err)
+	}
+
+	txHash, err := client.Eth().SendRawTransaction(signedTx.MarshalRLP())
+	if err != nil {
+		return fmt.Errorf("unable to send owner registration transaction, %w", err)
+	}
+
+	p.txHash = types.Hash(txHash)
+
+	return nil
+}
+
+func (p *ownerParams) getResult() command.CommandResult {
+	return &RegisterOwnerResult{
+		Address: p.address.String(),
+		Owner:   p.owner.String(),
+		TxHash:  p.txHash.String(),
+	}
+}
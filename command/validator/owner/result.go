@@ -0,0 +1,28 @@
+package owner
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RegisterOwnerResult struct {
+	Address string `json:"address"`
+	Owner   string `json:"owner"`
+	TxHash  string `json:"tx_hash"`
+}
+
+func (r *RegisterOwnerResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[VALIDATOR REGISTER OWNER]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Address|%s", r.Address),
+		fmt.Sprintf("Owner|%s", r.Owner),
+		fmt.Sprintf("Transaction Hash|%s", r.TxHash),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,88 @@
+package owner
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	ownerCmd := &cobra.Command{
+		Use: "register-owner",
+		Short: "Registers the owner of the validator in the staking contract, which controls " +
+			"the stake of the validator while the validator key signs the blocks",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(ownerCmd)
+
+	return ownerCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the Polygon Edge data if the local FS is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the SecretsManager config file, "+
+			"if omitted, the local FS secrets manager is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.ownerRaw,
+		ownerFlag,
+		"",
+		"the address of the owner, if omitted, the address of the owner key of the secrets manager",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.gasPrice,
+		gasPriceFlag,
+		defaultGasPrice,
+		"the gas price of the registration transaction",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.gasLimit,
+		gasLimitFlag,
+		defaultGasLimit,
+		"the gas limit of the registration transaction",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	params.jsonrpcAddress = helper.GetJSONRPCAddress(cmd)
+
+	if _, err := helper.ParseJSONRPCAddress(params.jsonrpcAddress); err != nil {
+		return err
+	}
+
+	return params.initSecrets()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.registerOwner(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
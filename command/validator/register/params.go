@@ -0,0 +1,168 @@
+package register
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	dataDirFlag  = "data-dir"
+	configFlag   = "config"
+	gasPriceFlag = "gas-price"
+	gasLimitFlag = "gas-limit"
+)
+
+const (
+	defaultGasPrice = uint64(1000000000) // 1 Gwei
+	defaultGasLimit = uint64(200000)
+)
+
+var (
+	params = &registerParams{}
+)
+
+var (
+	errInvalidConfig   = errors.New("invalid secrets configuration")
+	errInvalidParams   = errors.New("no config file or data directory passed in")
+	errUnsupportedType = errors.New("unsupported secrets manager")
+)
+
+type registerParams struct {
+	dataDir        string
+	configPath     string
+	jsonrpcAddress string
+
+	gasPrice uint64
+	gasLimit uint64
+
+	validatorKey *ecdsa.PrivateKey
+	blsKey       *bls_sig.SecretKey
+	address      types.Address
+
+	blsPublicKey []byte
+	txHash       types.Hash
+}
+
+func (p *registerParams) validateFlags() error {
+	if p.dataDir == "" && p.configPath == "" {
+		return errInvalidParams
+	}
+
+	return nil
+}
+
+func (p *registerParams) initSecrets() error {
+	secretsManager, err := p.initSecretsManager()
+	if err != nil {
+		return err
+	}
+
+	if p.validatorKey, err = crypto.ReadConsensusKey(secretsManager); err != nil {
+		return fmt.Errorf("unable to read validator key, %w", err)
+	}
+
+	if p.blsKey, err = crypto.ReadBLSKey(secretsManager); err != nil {
+		return fmt.Errorf("unable to read BLS key, %w", err)
+	}
+
+	p.address = crypto.PubKeyToAddress(&p.validatorKey.PublicKey)
+
+	return nil
+}
+
+func (p *registerParams) initSecretsManager() (secrets.SecretsManager, error) {
+	if p.configPath == "" {
+		return helper.SetupLocalSecretsManager(p.dataDir)
+	}
+
+	secretsConfig, readErr := secrets.ReadConfig(p.configPath)
+	if readErr != nil {
+		return nil, errInvalidConfig
+	}
+
+	if !secrets.SupportedServiceManager(secretsConfig.Type) {
+		return nil, errUnsupportedType
+	}
+
+	return helper.InitCloudSecretsManager(secretsConfig)
+}
+
+// register sends the transaction registering the BLS public key with its proof of possession,
+// the signature of the validator address by the BLS key
+func (p *registerParams) register() error {
+	var err error
+
+	if p.blsPublicKey, err = crypto.BLSSecretKeyToPubkeyBytes(p.blsKey); err != nil {
+		return err
+	}
+
+	pop, err := crypto.SignBLSProofOfPossession(p.blsKey, p.address)
+	if err != nil {
+		return fmt.Errorf("unable to sign the proof of possession, %w", err)
+	}
+
+	input, err := staking.EncodeRegisterBLSPublicKeyInput(p.address, p.blsPublicKey, pop)
+	if err != nil {
+		return err
+	}
+
+	client, err := jsonrpc.NewClient(p.jsonrpcAddress)
+	if err != nil {
+		return fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	defer client.Close()
+
+	chainID, err := client.Eth().ChainID()
+	if err != nil {
+		return fmt.Errorf("unable to query chain ID, %w", err)
+	}
+
+	nonce, err := client.Eth().GetNonce(ethgo.Address(p.address), ethgo.Pending)
+	if err != nil {
+		return fmt.Errorf("unable to query nonce, %w", err)
+	}
+
+	signedTx, err := crypto.NewEIP155Signer(chainID.Uint64()).SignTx(&types.Transaction{
+		From:     p.address,
+		To:       &staking.AddrStakingContract,
+		Nonce:    nonce,
+		Gas:      p.gasLimit,
+		GasPrice: new(big.Int).SetUint64(p.gasPrice),
+		Value:    big.NewInt(0),
+		Input:    input,
+	}, p.validatorKey)
+	if err != nil {
+		return fmt.Errorf("unable to sign registration transaction, %w", err)
+	}
+
+	txHash, err := client.Eth().SendRawTransaction(signedTx.MarshalRLP())
+	if err != nil {
+		return fmt.Errorf("unable to send registration transaction, %w", err)
+	}
+
+	p.txHash = types.Hash(txHash)
+
+	return nil
+}
+
+func (p *registerParams) getResult() command.CommandResult {
+	return &RegisterResult{
+		Address:      p.address.String(),
+		BLSPublicKey: hex.EncodeToHex(p.blsPublicKey),
+		TxHash:       p.txHash.String(),
+	}
+}
@@ -0,0 +1,28 @@
+package register
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RegisterResult struct {
+	Address      string `json:"address"`
+	BLSPublicKey string `json:"bls_public_key"`
+	TxHash       string `json:"tx_hash"`
+}
+
+func (r *RegisterResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[VALIDATOR REGISTER BLS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Address|%s", r.Address),
+		fmt.Sprintf("BLS Public Key|%s", r.BLSPublicKey),
+		fmt.Sprintf("Transaction Hash|%s", r.TxHash),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,41 @@
+package validator
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/validator/export"
+	validatorimport "github.com/0xPolygon/polygon-edge/command/validator/import"
+	"github.com/0xPolygon/polygon-edge/command/validator/owner"
+	"github.com/0xPolygon/polygon-edge/command/validator/register"
+	"github.com/0xPolygon/polygon-edge/command/validator/withdraw"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	validatorCmd := &cobra.Command{
+		Use:     "validator",
+		Aliases: []string{"validators"},
+		Short: "Top level command for interacting with the staking contract as a validator " +
+			"and exchanging the validator set bundles. Only accepts subcommands.",
+	}
+
+	helper.RegisterJSONRPCFlag(validatorCmd)
+
+	registerSubcommands(validatorCmd)
+
+	return validatorCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// validator withdraw
+		withdraw.GetCommand(),
+		// validator register-bls
+		register.GetCommand(),
+		// validator register-owner
+		owner.GetCommand(),
+		// validator export
+		export.GetCommand(),
+		// validator import
+		validatorimport.GetCommand(),
+	)
+}
@@ -0,0 +1,291 @@
+package withdraw
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/secrets/helper"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+)
+
+const (
+	dataDirFlag  = "data-dir"
+	configFlag   = "config"
+	gasPriceFlag = "gas-price"
+	gasLimitFlag = "gas-limit"
+	operatorFlag = "operator"
+)
+
+const (
+	methodWithdraw          = "withdraw"
+	methodPendingWithdrawal = "pendingWithdrawal"
+
+	defaultGasPrice = uint64(1000000000) // 1 Gwei
+	defaultGasLimit = uint64(200000)
+)
+
+var (
+	params = &withdrawParams{}
+)
+
+var (
+	errInvalidConfig        = errors.New("invalid secrets configuration")
+	errInvalidParams        = errors.New("no config file or data directory passed in")
+	errUnsupportedType      = errors.New("unsupported secrets manager")
+	errNoPendingWithdrawal  = errors.New("no pending withdrawal for the validator")
+	errMethodNotFoundInABI  = errors.New("method not found in staking ABI")
+	errWithdrawalNotMatured = errors.New("withdrawal is still in the unbonding period")
+	errInvalidOperator      = errors.New("invalid validator address")
+	errNotValidatorOwner    = errors.New("the owner key isn't the owner of the validator")
+)
+
+type withdrawParams struct {
+	dataDir        string
+	configPath     string
+	jsonrpcAddress string
+
+	gasPrice uint64
+	gasLimit uint64
+
+	operatorRaw string
+
+	// signerKey is the validator key, or the owner key withdrawing the stake of the operator
+	signerKey *ecdsa.PrivateKey
+	address   types.Address
+	operator  types.Address
+
+	pending *staking.PendingWithdrawal
+	txHash  types.Hash
+}
+
+func (p *withdrawParams) validateFlags() error {
+	if p.dataDir == "" && p.configPath == "" {
+		return errInvalidParams
+	}
+
+	if p.operatorRaw != "" {
+		if err := p.operator.UnmarshalText([]byte(p.operatorRaw)); err != nil {
+			return fmt.Errorf("%w: %s", errInvalidOperator, p.operatorRaw)
+		}
+	}
+
+	return nil
+}
+
+func (p *withdrawParams) initSecrets() error {
+	secretsManager, err := p.initSecretsManager()
+	if err != nil {
+		return err
+	}
+
+	// the owner of the validator withdraws its stake with the owner key
+	if p.operatorRaw != "" {
+		if p.signerKey, err = crypto.ReadValidatorOwnerKey(secretsManager); err != nil {
+			return fmt.Errorf("unable to read owner key, %w", err)
+		}
+
+		p.address = crypto.PubKeyToAddress(&p.signerKey.PublicKey)
+
+		return nil
+	}
+
+	if p.signerKey, err = crypto.ReadConsensusKey(secretsManager); err != nil {
+		return fmt.Errorf("unable to read validator key, %w", err)
+	}
+
+	p.address = crypto.PubKeyToAddress(&p.signerKey.PublicKey)
+	p.operator = p.address
+
+	return nil
+}
+
+func (p *withdrawParams) initSecretsManager() (secrets.SecretsManager, error) {
+	if p.configPath == "" {
+		return helper.SetupLocalSecretsManager(p.dataDir)
+	}
+
+	secretsConfig, readErr := secrets.ReadConfig(p.configPath)
+	if readErr != nil {
+		return nil, errInvalidConfig
+	}
+
+	if !secrets.SupportedServiceManager(secretsConfig.Type) {
+		return nil, errUnsupportedType
+	}
+
+	return helper.InitCloudSecretsManager(secretsConfig)
+}
+
+func (p *withdrawParams) withdraw() error {
+	client, err := jsonrpc.NewClient(p.jsonrpcAddress)
+	if err != nil {
+		return fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	defer client.Close()
+
+	if p.pending, err = queryPendingWithdrawal(client, p.operator); err != nil {
+		return err
+	}
+
+	if p.pending.Amount.Sign() == 0 {
+		return errNoPendingWithdrawal
+	}
+
+	input, err := p.withdrawInput(client)
+	if err != nil {
+		return err
+	}
+
+	// The staking contract reverts if the unbonding period is not over yet
+	if _, err := client.Eth().Call(&ethgo.CallMsg{
+		From: ethgo.Address(p.address),
+		To:   (*ethgo.Address)(&staking.AddrStakingContract),
+		Data: input,
+	}, ethgo.Latest); err != nil {
+		return fmt.Errorf(
+			"%w, release epoch %d: %v",
+			errWithdrawalNotMatured,
+			p.pending.ReleaseEpoch,
+			err,
+		)
+	}
+
+	chainID, err := client.Eth().ChainID()
+	if err != nil {
+		return fmt.Errorf("unable to query chain ID, %w", err)
+	}
+
+	nonce, err := client.Eth().GetNonce(ethgo.Address(p.address), ethgo.Pending)
+	if err != nil {
+		return fmt.Errorf("unable to query nonce, %w", err)
+	}
+
+	signedTx, err := crypto.NewEIP155Signer(chainID.Uint64()).SignTx(&types.Transaction{
+		From:     p.address,
+		To:       &staking.AddrStakingContract,
+		Nonce:    nonce,
+		Gas:      p.gasLimit,
+		GasPrice: new(big.Int).SetUint64(p.gasPrice),
+		Value:    big.NewInt(0),
+		Input:    input,
+	}, p.signerKey)
+	if err != nil {
+		return fmt.Errorf("unable to sign withdraw transaction, %w", err)
+	}
+
+	txHash, err := client.Eth().SendRawTransaction(signedTx.MarshalRLP())
+	if err != nil {
+		return fmt.Errorf("unable to send withdraw transaction, %w", err)
+	}
+
+	p.txHash = types.Hash(txHash)
+
+	return nil
+}
+
+// withdrawInput returns the call data of the withdrawal, the owner withdraws
+// the stake of the validator once checked it's its owner
+func (p *withdrawParams) withdrawInput(client *jsonrpc.Client) ([]byte, error) {
+	if p.operator == p.address {
+		return getMethodID(methodWithdraw)
+	}
+
+	binding := staking.NewStaking(staking.AddrStakingContract, nil)
+
+	ownerInput, err := binding.EncodeValidatorOwner(p.operator)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.Eth().Call(&ethgo.CallMsg{
+		From: ethgo.Address(p.address),
+		To:   (*ethgo.Address)(&staking.AddrStakingContract),
+		Data: ownerInput,
+	}, ethgo.Latest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query the validator owner, %w", err)
+	}
+
+	returnValue, err := hex.DecodeHex(response)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := binding.DecodeValidatorOwner(returnValue)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner != p.address {
+		return nil, fmt.Errorf("%w %s, owner %s", errNotValidatorOwner, p.operator, owner)
+	}
+
+	return binding.EncodeWithdrawFor(p.operator)
+}
+
+// queryPendingWithdrawal fetches the pending withdrawal of the account from the staking contract
+func queryPendingWithdrawal(
+	client *jsonrpc.Client,
+	account types.Address,
+) (*staking.PendingWithdrawal, error) {
+	method, ok := abis.StakingABI.Methods[methodPendingWithdrawal]
+	if !ok {
+		return nil, errMethodNotFoundInABI
+	}
+
+	input, err := staking.EncodePendingWithdrawalInput(account)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.Eth().Call(&ethgo.CallMsg{
+		From: ethgo.Address(account),
+		To:   (*ethgo.Address)(&staking.AddrStakingContract),
+		Data: input,
+	}, ethgo.Latest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query pending withdrawal, %w", err)
+	}
+
+	returnValue, err := hex.DecodeHex(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return staking.DecodePendingWithdrawal(method, returnValue)
+}
+
+func getMethodID(name string) ([]byte, error) {
+	method, ok := abis.StakingABI.Methods[name]
+	if !ok {
+		return nil, errMethodNotFoundInABI
+	}
+
+	return method.ID(), nil
+}
+
+func (p *withdrawParams) getResult() command.CommandResult {
+	res := &WithdrawResult{
+		Address:      p.operator.String(),
+		Amount:       p.pending.Amount.String(),
+		ReleaseEpoch: p.pending.ReleaseEpoch,
+		TxHash:       p.txHash.String(),
+	}
+
+	if p.operator != p.address {
+		res.Owner = p.address.String()
+	}
+
+	return res
+}
@@ -0,0 +1,41 @@
+package withdraw
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type WithdrawResult struct {
+	Address      string `json:"address"`
+	Owner        string `json:"owner,omitempty"`
+	Amount       string `json:"amount"`
+	ReleaseEpoch uint64 `json:"release_epoch"`
+	TxHash       string `json:"tx_hash"`
+}
+
+func (r *WithdrawResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[VALIDATOR WITHDRAW]\n")
+	vals := []string{
+		fmt.Sprintf("Address|%s", r.Address),
+	}
+
+	if r.Owner != "" {
+		vals = append(vals, fmt.Sprintf("Owner|%s", r.Owner))
+	}
+
+	vals = append(
+		vals,
+		fmt.Sprintf("Amount|%s", r.Amount),
+		fmt.Sprintf("Release Epoch|%d", r.ReleaseEpoch),
+		fmt.Sprintf("Transaction Hash|%s", r.TxHash),
+	)
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
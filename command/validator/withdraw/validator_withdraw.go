@@ -0,0 +1,88 @@
+package withdraw
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	withdrawCmd := &cobra.Command{
+		Use:     "withdraw",
+		Short:   "Claims the unstaked funds of the validator once the unbonding period is over",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(withdrawCmd)
+
+	return withdrawCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the directory for the Polygon Edge data if the local FS is used",
+	)
+
+	cmd.Flags().StringVar(
+		&params.configPath,
+		configFlag,
+		"",
+		"the path to the SecretsManager config file, "+
+			"if omitted, the local FS secrets manager is used",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.gasPrice,
+		gasPriceFlag,
+		defaultGasPrice,
+		"the gas price of the withdraw transaction",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.gasLimit,
+		gasLimitFlag,
+		defaultGasLimit,
+		"the gas limit of the withdraw transaction",
+	)
+
+	cmd.Flags().StringVar(
+		&params.operatorRaw,
+		operatorFlag,
+		"",
+		"the address of the validator whose stake is withdrawn with the owner key, "+
+			"if omitted, the stake of the validator key is withdrawn",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(dataDirFlag, configFlag)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	params.jsonrpcAddress = helper.GetJSONRPCAddress(cmd)
+
+	if _, err := helper.ParseJSONRPCAddress(params.jsonrpcAddress); err != nil {
+		return err
+	}
+
+	return params.initSecrets()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.withdraw(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -3,6 +3,7 @@ package consensus
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
@@ -59,6 +60,26 @@ type Config struct {
 	Path string
 }
 
+// FailoverRole is the role of a node in an active/standby validator pair
+type FailoverRole string
+
+const (
+	// FailoverActive is the node signing by default
+	FailoverActive FailoverRole = "active"
+
+	// FailoverStandby is the node taking over the signing once the active is fenced
+	FailoverStandby FailoverRole = "standby"
+)
+
+// FailoverConfig is the configuration of a node in an active/standby validator pair,
+// both nodes holding the same validator key
+type FailoverConfig struct {
+	Role FailoverRole
+
+	// PartnerAddr is the gRPC address of the other node of the pair
+	PartnerAddr string
+}
+
 type Params struct {
 	Context        context.Context
 	Config         *Config
@@ -70,6 +91,15 @@ type Params struct {
 	Logger         hclog.Logger
 	SecretsManager secrets.SecretsManager
 	BlockTime      uint64
+	Failover       *FailoverConfig
+
+	// TxExecutionBudget is the wall-clock time the execution of a transaction
+	// may take while building a block, unlimited if 0
+	TxExecutionBudget time.Duration
+
+	// ExtraDataVanity is the prefix of the extra data of the sealed blocks,
+	// such as the version of the binary, zeros if nil
+	ExtraDataVanity []byte
 }
 
 // Factory is the factory function to create a discovery consensus
@@ -1,7 +1,9 @@
 package dev
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
@@ -17,6 +19,10 @@ const (
 	devConsensus = "dev-consensus"
 )
 
+var (
+	ErrTimestampNotAfterHead = errors.New("the timestamp must be after the one of the latest block")
+)
+
 // Dev consensus protocol seals any new transaction immediately
 type Dev struct {
 	logger hclog.Logger
@@ -27,8 +33,30 @@ type Dev struct {
 	interval uint64
 	txpool   *txpool.TxPool
 
+	// txExecutionBudget is the wall-clock time the execution of a transaction may take, unlimited if 0
+	txExecutionBudget time.Duration
+
+	// extraDataVanity is the extra data of the sealed blocks
+	extraDataVanity []byte
+
 	blockchain *blockchain.Blockchain
 	executor   *state.Executor
+
+	// lock serializes the sealing of the blocks and the reverts of the chain
+	lock sync.Mutex
+
+	// snapshots are the heads of the chain saved by the snapshots, by id
+	snapshots []uint64
+
+	// manualMining disables the sealing of the blocks at each interval,
+	// they are then only sealed on demand
+	manualMining bool
+
+	// timeOffset is the number of seconds the timestamps of the blocks are moved forward by
+	timeOffset uint64
+
+	// nextTimestamp is the timestamp of the next block, if not zero
+	nextTimestamp uint64
 }
 
 // Factory implements the base factory method
@@ -44,6 +72,9 @@ func Factory(
 		blockchain: params.Blockchain,
 		executor:   params.Executor,
 		txpool:     params.TxPool,
+
+		txExecutionBudget: params.TxExecutionBudget,
+		extraDataVanity:   params.ExtraDataVanity,
 	}
 
 	rawInterval, ok := params.Config.Config["interval"]
@@ -98,11 +129,146 @@ func (d *Dev) run() {
 		}
 
 		// There are new transactions in the pool, try to seal them
-		header := d.blockchain.Header()
-		if err := d.writeNewBlock(header); err != nil {
-			d.logger.Error("failed to mine block", "err", err)
+		d.lock.Lock()
+
+		if !d.manualMining {
+			header := d.blockchain.Header()
+			if err := d.writeNewBlock(header); err != nil {
+				d.logger.Error("failed to mine block", "err", err)
+			}
+		}
+
+		d.lock.Unlock()
+	}
+}
+
+// Mine seals a block with the pending transactions, with the given timestamp if not zero
+func (d *Dev) Mine(timestamp uint64) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	header := d.blockchain.Header()
+
+	if timestamp != 0 {
+		if timestamp <= header.Timestamp {
+			return ErrTimestampNotAfterHead
+		}
+
+		d.nextTimestamp = timestamp
+	}
+
+	return d.writeNewBlock(header)
+}
+
+// IncreaseTime moves the timestamps of the next blocks forward,
+// it returns the total number of seconds they are moved forward by
+func (d *Dev) IncreaseTime(seconds uint64) uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.timeOffset += seconds
+
+	return d.timeOffset
+}
+
+// SetNextBlockTimestamp sets the timestamp of the next block, the timestamps
+// of the blocks after it are then moved forward by the same amount of time
+func (d *Dev) SetNextBlockTimestamp(timestamp uint64) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if timestamp <= d.blockchain.Header().Timestamp {
+		return ErrTimestampNotAfterHead
+	}
+
+	d.nextTimestamp = timestamp
+
+	return nil
+}
+
+// SetAutomine enables or disables the sealing of a block at each interval,
+// the blocks are otherwise only sealed by Mine
+func (d *Dev) SetAutomine(enabled bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.manualMining = !enabled
+}
+
+// ImpersonateAccount accepts the transactions of the account without a valid signature
+func (d *Dev) ImpersonateAccount(addr types.Address) {
+	d.txpool.ImpersonateAccount(addr)
+
+	d.logger.Info("impersonating account", "address", addr)
+}
+
+// StopImpersonatingAccount requires again the transactions of the account to be signed
+func (d *Dev) StopImpersonatingAccount(addr types.Address) {
+	d.txpool.StopImpersonatingAccount(addr)
+}
+
+// IsImpersonated returns true if the account is impersonated
+func (d *Dev) IsImpersonated(addr types.Address) bool {
+	return d.txpool.IsImpersonated(addr)
+}
+
+// blockTimestamp returns the timestamp of the block after the parent
+func (d *Dev) blockTimestamp(parent *types.Header) uint64 {
+	now := uint64(time.Now().Unix())
+	timestamp := now + d.timeOffset
+
+	if d.nextTimestamp != 0 {
+		timestamp = d.nextTimestamp
+		d.nextTimestamp = 0
+
+		// the next blocks go on from the timestamp
+		d.timeOffset = 0
+		if timestamp > now {
+			d.timeOffset = timestamp - now
 		}
 	}
+
+	// the timestamps never go back
+	if timestamp < parent.Timestamp {
+		timestamp = parent.Timestamp
+	}
+
+	return timestamp
+}
+
+// Snapshot saves the head of the chain, which can then be reverted to.
+// It returns the id of the snapshot
+func (d *Dev) Snapshot() uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.snapshots = append(d.snapshots, d.blockchain.Header().Number)
+
+	return uint64(len(d.snapshots) - 1)
+}
+
+// Revert rewinds the chain to the head saved by the snapshot, and clears the transaction pool.
+// The snapshot and the ones taken after it are removed, it returns false if the snapshot doesn't exist
+func (d *Dev) Revert(id uint64) (bool, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if id >= uint64(len(d.snapshots)) {
+		return false, nil
+	}
+
+	number := d.snapshots[id]
+	d.snapshots = d.snapshots[:id]
+
+	if err := d.blockchain.Rewind(number); err != nil {
+		return false, err
+	}
+
+	d.txpool.Clear()
+
+	d.logger.Info("chain reverted", "snapshot", id, "number", number)
+
+	return true, nil
 }
 
 type transitionInterface interface {
@@ -131,6 +297,8 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 				break
 			} else if appErr, ok := err.(*state.TransitionApplicationError); ok && appErr.IsRecoverable { //nolint:errorlint
 				d.txpool.Demote(tx)
+			} else if ok && errors.Is(appErr.Err, state.ErrExecutionTimeout) {
+				d.txpool.Quarantine(tx, err.Error())
 			} else {
 				d.txpool.Drop(tx)
 			}
@@ -152,13 +320,18 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 // writeNewBLock generates a new block based on transactions from the pool,
 // and writes them to the blockchain
 func (d *Dev) writeNewBlock(parent *types.Header) error {
+	if err := d.blockchain.CheckBlockGates(parent.Number + 1); err != nil {
+		return err
+	}
+
 	// Generate the base block
 	num := parent.Number
 	header := &types.Header{
 		ParentHash: parent.Hash,
 		Number:     num + 1,
 		GasLimit:   parent.GasLimit, // Inherit from parent for now, will need to adjust dynamically later.
-		Timestamp:  uint64(time.Now().Unix()),
+		Timestamp:  d.blockTimestamp(parent),
+		ExtraData:  append([]byte{}, d.extraDataVanity...),
 	}
 
 	// calculate gas limit based on parent header
@@ -174,12 +347,20 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 		return err
 	}
 
+	// the blob gas price of the block follows the blob gas of the parent
+	if d.executor.GetForksInTime(header.Number).BlobTx {
+		excessBlobGas := types.CalcExcessBlobGas(parent)
+		header.ExcessBlobGas = &excessBlobGas
+	}
+
 	transition, err := d.executor.BeginTxn(parent.StateRoot, header, miner)
 
 	if err != nil {
 		return err
 	}
 
+	transition.SetExecutionBudget(d.txExecutionBudget)
+
 	txns := d.writeTransactions(gasLimit, transition)
 
 	// Commit the changes
@@ -189,6 +370,11 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 	header.StateRoot = root
 	header.GasUsed = transition.TotalGas()
 
+	if header.ExcessBlobGas != nil {
+		blobGasUsed := transition.BlobGasUsed()
+		header.BlobGasUsed = &blobGasUsed
+	}
+
 	// Build the actual block
 	// The header hash is computed inside buildBlock
 	block := consensus.BuildBlock(consensus.BuildBlockParams{
@@ -201,6 +387,10 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 		return err
 	}
 
+	if err := d.blockchain.CheckSealHooks(block); err != nil {
+		return err
+	}
+
 	// Write the block to the blockchain
 	if err := d.blockchain.WriteBlock(block, devConsensus); err != nil {
 		return err
@@ -0,0 +1,175 @@
+//go:build byzantine
+// +build byzantine
+
+package ibft
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// byzantineEnv is the environment variable listing the byzantine behaviors of the node,
+// comma separated. It is only read by the binaries built with the byzantine tag
+const byzantineEnv = "EDGE_BYZANTINE"
+
+type byzantineBehavior string
+
+const (
+	// doublePropose gossips a second, conflicting proposal for the same view
+	doublePropose byzantineBehavior = "double-propose"
+
+	// withholdCommits never gossips the commit messages
+	withholdCommits byzantineBehavior = "withhold-commits"
+
+	// staleRounds gossips the messages with the view of the previous round
+	staleRounds byzantineBehavior = "stale-rounds"
+)
+
+var byzantineBehaviors = map[byzantineBehavior]bool{
+	doublePropose:   true,
+	withholdCommits: true,
+	staleRounds:     true,
+}
+
+// parseByzantineBehaviors parses the comma separated list of behaviors
+func parseByzantineBehaviors(raw string) (map[byzantineBehavior]bool, error) {
+	behaviors := map[byzantineBehavior]bool{}
+
+	for _, name := range strings.Split(raw, ",") {
+		behavior := byzantineBehavior(strings.TrimSpace(name))
+		if behavior == "" {
+			continue
+		}
+
+		if !byzantineBehaviors[behavior] {
+			return nil, fmt.Errorf("unknown byzantine behavior %s", behavior)
+		}
+
+		behaviors[behavior] = true
+	}
+
+	return behaviors, nil
+}
+
+// byzantineTransport tampers with the messages of the node before they are gossiped,
+// in order to test the resilience of the honest validators
+type byzantineTransport struct {
+	backend   *backendIBFT
+	transport transport
+	behaviors map[byzantineBehavior]bool
+}
+
+// wrapTransport returns the transport applying the byzantine behaviors set in EDGE_BYZANTINE, if any
+func (i *backendIBFT) wrapTransport(t transport) (transport, error) {
+	behaviors, err := parseByzantineBehaviors(os.Getenv(byzantineEnv))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(behaviors) == 0 {
+		return t, nil
+	}
+
+	i.logger.Warn("the node is byzantine", "behaviors", os.Getenv(byzantineEnv))
+
+	return &byzantineTransport{
+		backend:   i,
+		transport: t,
+		behaviors: behaviors,
+	}, nil
+}
+
+func (b *byzantineTransport) Multicast(msg *protoIBFT.Message) error {
+	if b.behaviors[withholdCommits] && msg.Type == protoIBFT.MessageType_COMMIT {
+		b.backend.logger.Debug("byzantine: withholding the commit", "height", msg.View.Height)
+
+		return nil
+	}
+
+	if b.behaviors[staleRounds] {
+		if msg = b.staleMessage(msg); msg == nil {
+			return nil
+		}
+	}
+
+	if err := b.transport.Multicast(msg); err != nil {
+		return err
+	}
+
+	if b.behaviors[doublePropose] && msg.Type == protoIBFT.MessageType_PREPREPARE {
+		if conflicting := b.conflictingProposal(msg); conflicting != nil {
+			b.backend.logger.Debug("byzantine: double proposing", "height", msg.View.Height)
+
+			return b.transport.Multicast(conflicting)
+		}
+	}
+
+	return nil
+}
+
+// staleMessage returns the message re-signed with the view of the previous round,
+// or the one of the first round of the previous height for the first round
+func (b *byzantineTransport) staleMessage(msg *protoIBFT.Message) *protoIBFT.Message {
+	view := &protoIBFT.View{
+		Height: msg.View.Height,
+		Round:  msg.View.Round,
+	}
+
+	switch {
+	case view.Round > 0:
+		view.Round--
+	case view.Height > 0:
+		view.Height--
+	default:
+		return msg
+	}
+
+	stale, ok := proto.Clone(msg).(*protoIBFT.Message)
+	if !ok {
+		return nil
+	}
+
+	stale.View = view
+	stale.Signature = nil
+
+	return b.backend.signMessage(stale)
+}
+
+// conflictingProposal returns the proposal message of the same view with another block,
+// the proposed block with a later timestamp sealed again by the node
+func (b *byzantineTransport) conflictingProposal(msg *protoIBFT.Message) *protoIBFT.Message {
+	data := msg.GetPreprepareData()
+	if data == nil {
+		return nil
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(data.Proposal); err != nil {
+		return nil
+	}
+
+	header := block.Header.Copy()
+	header.Timestamp++
+
+	header, err := b.backend.currentSigner.WriteProposerSeal(header)
+	if err != nil {
+		b.backend.logger.Error("byzantine: unable to seal the conflicting proposal", "err", err)
+
+		return nil
+	}
+
+	header.ComputeHash()
+
+	conflicting := &types.Block{
+		Header:       header,
+		Transactions: block.Transactions,
+		Uncles:       block.Uncles,
+	}
+
+	return b.backend.BuildPrePrepareMessage(conflicting.MarshalRLP(), data.Certificate, msg.View)
+}
@@ -0,0 +1,10 @@
+//go:build !byzantine
+// +build !byzantine
+
+package ibft
+
+// wrapTransport returns the transport as it is, the byzantine behaviors
+// are only available in the binaries built with the byzantine tag
+func (i *backendIBFT) wrapTransport(t transport) (transport, error) {
+	return t, nil
+}
@@ -0,0 +1,141 @@
+//go:build byzantine
+// +build byzantine
+
+package ibft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTransport records the gossiped messages
+type recordingTransport struct {
+	messages []*proto.Message
+}
+
+func (r *recordingTransport) Multicast(msg *proto.Message) error {
+	r.messages = append(r.messages, msg)
+
+	return nil
+}
+
+func newByzantineTransport(t *testing.T, behaviors ...byzantineBehavior) (*byzantineTransport, *recordingTransport) {
+	t.Helper()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	backend := &backendIBFT{
+		logger:        hclog.NewNullLogger(),
+		currentSigner: signer.NewSigner(signer.NewECDSAKeyManagerFromKey(key), nil),
+	}
+
+	recorder := &recordingTransport{}
+
+	transport := &byzantineTransport{
+		backend:   backend,
+		transport: recorder,
+		behaviors: map[byzantineBehavior]bool{},
+	}
+
+	for _, behavior := range behaviors {
+		transport.behaviors[behavior] = true
+	}
+
+	return transport, recorder
+}
+
+func TestParseByzantineBehaviors(t *testing.T) {
+	t.Parallel()
+
+	behaviors, err := parseByzantineBehaviors("double-propose, withhold-commits")
+	require.NoError(t, err)
+	assert.Equal(t, map[byzantineBehavior]bool{doublePropose: true, withholdCommits: true}, behaviors)
+
+	behaviors, err = parseByzantineBehaviors("")
+	require.NoError(t, err)
+	assert.Empty(t, behaviors)
+
+	_, err = parseByzantineBehaviors("double-commit")
+	assert.Error(t, err)
+}
+
+func TestByzantineTransport_WithholdCommits(t *testing.T) {
+	t.Parallel()
+
+	transport, recorder := newByzantineTransport(t, withholdCommits)
+	view := &proto.View{Height: 2, Round: 0}
+
+	require.NoError(t, transport.Multicast(transport.backend.BuildCommitMessage([]byte{0x1}, view)))
+	require.NoError(t, transport.Multicast(transport.backend.BuildPrepareMessage([]byte{0x1}, view)))
+
+	require.Len(t, recorder.messages, 1)
+	assert.Equal(t, proto.MessageType_PREPARE, recorder.messages[0].Type)
+}
+
+func TestByzantineTransport_StaleRounds(t *testing.T) {
+	t.Parallel()
+
+	transport, recorder := newByzantineTransport(t, staleRounds)
+
+	require.NoError(t, transport.Multicast(transport.backend.BuildPrepareMessage(
+		[]byte{0x1},
+		&proto.View{Height: 2, Round: 1},
+	)))
+	require.NoError(t, transport.Multicast(transport.backend.BuildPrepareMessage(
+		[]byte{0x1},
+		&proto.View{Height: 2, Round: 0},
+	)))
+
+	require.Len(t, recorder.messages, 2)
+	assert.Equal(t, &proto.View{Height: 2, Round: 0}, recorder.messages[0].View)
+	assert.Equal(t, &proto.View{Height: 1, Round: 0}, recorder.messages[1].View)
+
+	// the stale messages are signed again, the honest nodes relay them
+	for _, msg := range recorder.messages {
+		assert.NoError(t, validateGossipMessage(msg, ""))
+	}
+}
+
+func TestByzantineTransport_DoublePropose(t *testing.T) {
+	t.Parallel()
+
+	transport, recorder := newByzantineTransport(t, doublePropose)
+	backend := transport.backend
+
+	header := &types.Header{Number: 2, Timestamp: 100}
+	backend.currentSigner.InitIBFTExtra(header, validators.NewECDSAValidatorSet(), nil)
+
+	header, err := backend.currentSigner.WriteProposerSeal(header)
+	require.NoError(t, err)
+
+	header.ComputeHash()
+
+	block := &types.Block{Header: header}
+	view := &proto.View{Height: 2, Round: 0}
+
+	require.NoError(t, transport.Multicast(backend.BuildPrePrepareMessage(block.MarshalRLP(), nil, view)))
+	require.Len(t, recorder.messages, 2)
+
+	conflicting := &types.Block{}
+	require.NoError(t, conflicting.UnmarshalRLP(recorder.messages[1].GetPreprepareData().Proposal))
+
+	assert.Equal(t, view, recorder.messages[1].View)
+	assert.Equal(t, header.Timestamp+1, conflicting.Header.Timestamp)
+	assert.NotEqual(t, header.Hash, conflicting.Header.Hash)
+
+	// both proposals are sealed by the node
+	for _, proposal := range []*types.Header{header, conflicting.Header} {
+		proposer, err := backend.currentSigner.EcrecoverFromHeader(proposal)
+		require.NoError(t, err)
+		assert.Equal(t, backend.currentSigner.Address(), proposer)
+	}
+}
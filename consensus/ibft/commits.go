@@ -0,0 +1,84 @@
+package ibft
+
+import (
+	"sync"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// commit is the committed seal of a COMMIT message
+type commit struct {
+	proposalHash types.Hash
+	seal         []byte
+}
+
+// commitCollector collects the committed seals of the COMMIT messages received at the current height.
+// go-ibft inserts the block once it counts the quorum of COMMIT messages,
+// the collected seals complete the committed seals until their signers hold the quorum of the voting power
+type commitCollector struct {
+	lock     sync.Mutex
+	height   uint64
+	commits  map[types.Address]commit // the last commit of each sender
+	updateCh chan struct{}            // closed when a commit is collected
+}
+
+func newCommitCollector() *commitCollector {
+	return &commitCollector{
+		commits:  make(map[types.Address]commit),
+		updateCh: make(chan struct{}),
+	}
+}
+
+// reset drops the collected commits and collects the commits of the given height
+func (c *commitCollector) reset(height uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.height = height
+	c.commits = make(map[types.Address]commit)
+}
+
+// add collects the committed seal of the message if it's a COMMIT message of the current height
+func (c *commitCollector) add(msg *proto.Message) {
+	data := msg.GetCommitData()
+	if msg.Type != proto.MessageType_COMMIT || data == nil || msg.View == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if msg.View.Height != c.height {
+		return
+	}
+
+	c.commits[types.BytesToAddress(msg.From)] = commit{
+		proposalHash: types.BytesToHash(data.ProposalHash),
+		seal:         data.CommittedSeal,
+	}
+
+	close(c.updateCh)
+	c.updateCh = make(chan struct{})
+}
+
+// get returns the committed seals collected for the proposal at the height by signer,
+// and a channel closed once another commit is collected
+func (c *commitCollector) get(height uint64, proposalHash types.Hash) (map[types.Address][]byte, <-chan struct{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	seals := make(map[types.Address][]byte)
+
+	if height != c.height {
+		return seals, c.updateCh
+	}
+
+	for addr, collected := range c.commits {
+		if collected.proposalHash == proposalHash {
+			seals[addr] = collected.seal
+		}
+	}
+
+	return seals, c.updateCh
+}
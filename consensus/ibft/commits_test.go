@@ -0,0 +1,71 @@
+package ibft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCommitMessage(height uint64, from types.Address, proposalHash types.Hash, seal []byte) *proto.Message {
+	return &proto.Message{
+		View: &proto.View{Height: height},
+		From: from.Bytes(),
+		Type: proto.MessageType_COMMIT,
+		Payload: &proto.Message_CommitData{
+			CommitData: &proto.CommitMessage{
+				ProposalHash:  proposalHash.Bytes(),
+				CommittedSeal: seal,
+			},
+		},
+	}
+}
+
+func TestCommitCollector(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1     = types.StringToAddress("1")
+		addr2     = types.StringToAddress("2")
+		proposal  = types.StringToHash("0x1")
+		other     = types.StringToHash("0x2")
+		collector = newCommitCollector()
+	)
+
+	collector.reset(10)
+
+	_, updateCh := collector.get(10, proposal)
+
+	// the commits of the other heights and the other messages are ignored
+	collector.add(newTestCommitMessage(9, addr1, proposal, []byte{0x1}))
+	collector.add(newTestCommitMessage(11, addr1, proposal, []byte{0x1}))
+	collector.add(&proto.Message{View: &proto.View{Height: 10}, From: addr1.Bytes(), Type: proto.MessageType_PREPARE})
+
+	seals, _ := collector.get(10, proposal)
+	assert.Empty(t, seals)
+
+	select {
+	case <-updateCh:
+		t.Fatal("no commit should be collected")
+	default:
+	}
+
+	collector.add(newTestCommitMessage(10, addr1, proposal, []byte{0x1}))
+	collector.add(newTestCommitMessage(10, addr2, other, []byte{0x2}))
+
+	// the waiters are notified of the collected commits
+	<-updateCh
+
+	seals, _ = collector.get(10, proposal)
+	assert.Equal(t, map[types.Address][]byte{addr1: {0x1}}, seals)
+
+	seals, _ = collector.get(11, proposal)
+	assert.Empty(t, seals)
+
+	// the commits are dropped at the next height
+	collector.reset(11)
+
+	seals, _ = collector.get(11, other)
+	assert.Empty(t, seals)
+}
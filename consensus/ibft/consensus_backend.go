@@ -2,6 +2,7 @@ package ibft
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -57,6 +58,18 @@ func (i *backendIBFT) InsertBlock(
 		committedSealsMap[types.BytesToAddress(cm.Signer)] = cm.Signature
 	}
 
+	// go-ibft counts the COMMIT messages, the signers of the seals must hold the quorum of the voting power
+	if i.currentVotingPowers != nil &&
+		!i.awaitStakeQuorum(newBlock.Number(), newBlock.Hash(), committedSealsMap) {
+		i.logger.Error(
+			"cannot write block: the committed seals don't hold the quorum of the voting power",
+			"num", newBlock.Number(),
+			"committed", len(committedSealsMap),
+		)
+
+		return
+	}
+
 	// Copy extra data for debugging purposes
 	extraDataOriginal := newBlock.Header.ExtraData
 	extraDataBackup := make([]byte, len(extraDataOriginal))
@@ -111,7 +124,7 @@ func (i *backendIBFT) InsertBlock(
 		"hash", newBlock.Hash(),
 		"validation_type", i.currentSigner.Type(),
 		"validators", i.currentValidators.Len(),
-		"committed", len(committedSeals),
+		"committed", len(committedSealsMap),
 	)
 
 	if err := i.currentHooks.PostInsertBlock(newBlock); err != nil {
@@ -158,6 +171,10 @@ func (i *backendIBFT) Quorum(blockNumber uint64) uint64 {
 
 // buildBlock builds the block, based on the passed in snapshot and parent header
 func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
+	if err := i.blockchain.CheckBlockGates(parent.Number + 1); err != nil {
+		return nil, err
+	}
+
 	header := &types.Header{
 		ParentHash: parent.Hash,
 		Number:     parent.Number + 1,
@@ -192,13 +209,29 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 		return nil, err
 	}
 
+	// the vanity is padded with zeros by the IBFT extra
+	header.ExtraData = append([]byte{}, i.extraDataVanity...)
+
 	i.currentSigner.InitIBFTExtra(header, i.currentValidators, parentCommittedSeals)
 
+	header, err = i.writeRandomnessProof(parent, header)
+	if err != nil {
+		return nil, err
+	}
+
+	// the blob gas price of the block follows the blob gas of the parent
+	if i.executor.GetForksInTime(header.Number).BlobTx {
+		excessBlobGas := types.CalcExcessBlobGas(parent)
+		header.ExcessBlobGas = &excessBlobGas
+	}
+
 	transition, err := i.executor.BeginTxn(parent.StateRoot, header, i.currentSigner.Address())
 	if err != nil {
 		return nil, err
 	}
 
+	transition.SetExecutionBudget(i.txExecutionBudget)
+
 	// Get the block transactions
 	writeCtx, cancelFn := context.WithDeadline(context.Background(), potentialTimestamp)
 	defer cancelFn()
@@ -218,6 +251,11 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 	header.StateRoot = root
 	header.GasUsed = transition.TotalGas()
 
+	if header.ExcessBlobGas != nil {
+		blobGasUsed := transition.BlobGasUsed()
+		header.BlobGasUsed = &blobGasUsed
+	}
+
 	// build the block
 	block := consensus.BuildBlock(consensus.BuildBlockParams{
 		Header:   header,
@@ -237,6 +275,10 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 	// is sealed after all the committed seals
 	block.Header.ComputeHash()
 
+	if err := i.blockchain.CheckSealHooks(block); err != nil {
+		return nil, err
+	}
+
 	i.logger.Info("build block", "number", header.Number, "txs", len(txs))
 
 	return block, nil
@@ -313,6 +355,18 @@ func (i *backendIBFT) writeTransactions(
 		)
 	}()
 
+	// the transactions decrypted from the encrypted transactions of the parent lead the block
+	for _, tx := range i.txpool.Decrypted(writeCtx, blockNumber-1) {
+		if err := transition.Write(tx); err != nil {
+			failed++
+
+			continue
+		}
+
+		executed = append(executed, tx)
+		successful++
+	}
+
 	i.txpool.Prepare()
 
 write:
@@ -384,6 +438,11 @@ func (i *backendIBFT) writeTransaction(
 		} else if appErr, ok := err.(*state.TransitionApplicationError); ok && appErr.IsRecoverable { //nolint:errorlint
 			i.txpool.Demote(tx)
 
+			return &txExeResult{tx, skip}, true
+		} else if ok && errors.Is(appErr.Err, state.ErrExecutionTimeout) {
+			// a pathological transaction doesn't stall the block building again
+			i.txpool.Quarantine(tx, err.Error())
+
 			return &txExeResult{tx, skip}, true
 		} else {
 			i.txpool.Drop(tx)
@@ -0,0 +1,265 @@
+package ibft
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// failoverUnreachableChecks is the number of failed checks before the partner is considered down
+	failoverUnreachableChecks = 3
+
+	// failoverFenceBlocks is the number of blocks the chain must grow without any sign of life
+	// of an unreachable partner before it's considered stopped
+	failoverFenceBlocks = 3
+)
+
+var (
+	ErrFailoverDisabled = errors.New("failover is not enabled")
+)
+
+// failoverPartner is the other node of the active/standby pair
+type failoverPartner interface {
+	FailoverStatus(context.Context, *empty.Empty, ...grpc.CallOption) (*proto.FailoverStatusResp, error)
+}
+
+// failover guards the signing of a node in an active/standby pair sharing the validator key.
+// The node only signs once the partner is known to have stopped, which is the fencing:
+//   - the partner reports it doesn't sign, and is either the standby or has been fenced
+//   - the partner is unreachable and the chain grew by failoverFenceBlocks blocks
+//     without any consensus message of the shared key published by another node
+//
+// The node then signs only the heights above the last one the partner signed
+type failover struct {
+	logger  hclog.Logger
+	role    consensus.FailoverRole
+	partner failoverPartner
+	head    func() uint64
+
+	// changeCh is notified when the node starts or stops signing
+	changeCh chan struct{}
+
+	lock     sync.Mutex
+	signing  bool
+	fenced   bool
+	failures int
+
+	// minHeight is the lowest height the node signs once it took over
+	minHeight         uint64
+	lastSigned        uint64
+	partnerLastSigned uint64
+
+	// aliveHeight is the height of the chain at the last sign of life of the partner
+	aliveHeight uint64
+}
+
+func newFailover(
+	logger hclog.Logger,
+	role consensus.FailoverRole,
+	partner failoverPartner,
+	head func() uint64,
+) *failover {
+	return &failover{
+		logger:      logger.Named("failover"),
+		role:        role,
+		partner:     partner,
+		head:        head,
+		changeCh:    make(chan struct{}, 1),
+		aliveHeight: head(),
+	}
+}
+
+// dialFailoverPartner connects to the operator service of the partner
+func dialFailoverPartner(addr string) (proto.IbftOperatorClient, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.NewIbftOperatorClient(conn), nil
+}
+
+// canSign returns whether the node signs the height
+func (f *failover) canSign(height uint64) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.signing && height >= f.minHeight
+}
+
+// markSigned records the node runs a consensus sequence for the height
+func (f *failover) markSigned(height uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if height > f.lastSigned {
+		f.lastSigned = height
+	}
+}
+
+func (f *failover) status() *proto.FailoverStatusResp {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.statusLocked()
+}
+
+func (f *failover) statusLocked() *proto.FailoverStatusResp {
+	return &proto.FailoverStatusResp{
+		Role:             string(f.role),
+		Signing:          f.signing,
+		Fenced:           f.fenced,
+		LastSignedHeight: f.lastSigned,
+	}
+}
+
+// fence stops the signing until the node is restarted,
+// the returned status holds the last height the node signed
+func (f *failover) fence() *proto.FailoverStatusResp {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.fenceLocked()
+
+	return f.statusLocked()
+}
+
+func (f *failover) fenceLocked() {
+	f.fenced = true
+
+	if !f.signing {
+		return
+	}
+
+	f.signing = false
+	f.notifyChange()
+
+	f.logger.Info("stopped signing", "last signed", f.lastSigned)
+}
+
+// observeMessage records a consensus message of the shared key published by another node
+func (f *failover) observeMessage(height uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if !f.signing {
+		f.aliveHeight = f.head()
+
+		return
+	}
+
+	if height >= f.minHeight {
+		// the messages of the heights the partner signed before the take over may still
+		// be gossiped, any other one means both nodes sign. Stopping is the safe way out
+		f.logger.Error("another node signs with the validator key", "height", height)
+		f.fenceLocked()
+	}
+}
+
+// check queries the partner and takes over the signing once the partner is fenced
+func (f *failover) check(ctx context.Context) {
+	if status := f.status(); status.Signing || status.Fenced {
+		return
+	}
+
+	// the partner isn't queried under the lock, not to hold back the consensus
+	status, err := f.partner.FailoverStatus(ctx, &empty.Empty{})
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.signing || f.fenced {
+		return
+	}
+
+	if err == nil {
+		f.failures = 0
+		f.aliveHeight = f.head()
+
+		if status.LastSignedHeight > f.partnerLastSigned {
+			f.partnerLastSigned = status.LastSignedHeight
+		}
+
+		if status.Role == string(f.role) {
+			f.logger.Error("both nodes of the pair have the same role", "role", f.role)
+
+			return
+		}
+
+		if !status.Signing && (f.role == consensus.FailoverActive || status.Fenced) {
+			f.takeOverLocked("partner stopped signing")
+		}
+
+		return
+	}
+
+	f.failures++
+
+	if f.failures < failoverUnreachableChecks {
+		return
+	}
+
+	if head := f.head(); head < f.aliveHeight+failoverFenceBlocks {
+		f.logger.Warn(
+			"partner unreachable, waiting for the chain to grow without it",
+			"err", err,
+			"height", head,
+			"fenced at", f.aliveHeight+failoverFenceBlocks,
+		)
+
+		return
+	}
+
+	f.takeOverLocked("partner unreachable and silent")
+}
+
+// takeOverLocked starts the signing above the chain head and any height either node signed
+func (f *failover) takeOverLocked(reason string) {
+	f.signing = true
+	f.minHeight = f.head()
+
+	for _, height := range []uint64{f.partnerLastSigned, f.lastSigned} {
+		if height > f.minHeight {
+			f.minHeight = height
+		}
+	}
+
+	f.minHeight++
+	f.notifyChange()
+
+	f.logger.Info("took over signing", "reason", reason, "from height", f.minHeight)
+}
+
+func (f *failover) notifyChange() {
+	select {
+	case f.changeCh <- struct{}{}:
+	default:
+	}
+}
+
+// run checks the partner at every interval until closeCh is closed
+func (f *failover) run(interval time.Duration, closeCh <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		f.check(ctx)
+		cancel()
+
+		select {
+		case <-ticker.C:
+		case <-closeCh:
+			return
+		}
+	}
+}
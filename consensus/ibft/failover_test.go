@@ -0,0 +1,154 @@
+package ibft
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var errPartnerDown = errors.New("partner down")
+
+type mockFailoverPartner struct {
+	status *proto.FailoverStatusResp
+	err    error
+}
+
+func (m *mockFailoverPartner) FailoverStatus(
+	context.Context,
+	*empty.Empty,
+	...grpc.CallOption,
+) (*proto.FailoverStatusResp, error) {
+	return m.status, m.err
+}
+
+func newTestFailover(role consensus.FailoverRole, partner failoverPartner, head *uint64) *failover {
+	return newFailover(hclog.NewNullLogger(), role, partner, func() uint64 {
+		return *head
+	})
+}
+
+func TestFailover_PartnerReachable(t *testing.T) {
+	t.Parallel()
+
+	head := uint64(10)
+
+	t.Run("active takes over from an idle standby", func(t *testing.T) {
+		t.Parallel()
+
+		partner := &mockFailoverPartner{status: &proto.FailoverStatusResp{Role: "standby"}}
+		f := newTestFailover(consensus.FailoverActive, partner, &head)
+
+		f.check(context.Background())
+		assert.True(t, f.canSign(11))
+		assert.False(t, f.canSign(10))
+	})
+
+	t.Run("standby waits for the active to be fenced", func(t *testing.T) {
+		t.Parallel()
+
+		partner := &mockFailoverPartner{status: &proto.FailoverStatusResp{Role: "active", Signing: true}}
+		f := newTestFailover(consensus.FailoverStandby, partner, &head)
+
+		f.check(context.Background())
+		assert.False(t, f.status().Signing)
+
+		// an idle active still takes over by itself
+		partner.status = &proto.FailoverStatusResp{Role: "active", LastSignedHeight: 11}
+		f.check(context.Background())
+		assert.False(t, f.status().Signing)
+
+		// the fenced active signed up to height 12
+		partner.status = &proto.FailoverStatusResp{Role: "active", Fenced: true, LastSignedHeight: 12}
+		f.check(context.Background())
+		assert.True(t, f.status().Signing)
+		assert.False(t, f.canSign(12))
+		assert.True(t, f.canSign(13))
+	})
+
+	t.Run("same roles never sign", func(t *testing.T) {
+		t.Parallel()
+
+		partner := &mockFailoverPartner{status: &proto.FailoverStatusResp{Role: "active"}}
+		f := newTestFailover(consensus.FailoverActive, partner, &head)
+
+		f.check(context.Background())
+		assert.False(t, f.status().Signing)
+	})
+}
+
+func TestFailover_PartnerUnreachable(t *testing.T) {
+	t.Parallel()
+
+	head := uint64(10)
+	partner := &mockFailoverPartner{err: errPartnerDown}
+	f := newTestFailover(consensus.FailoverStandby, partner, &head)
+
+	check := func() bool {
+		f.check(context.Background())
+
+		return f.status().Signing
+	}
+
+	// the chain doesn't grow, the partner may still sign
+	for i := 0; i < failoverUnreachableChecks; i++ {
+		assert.False(t, check())
+	}
+
+	// the partner keeps on signing for the other validators
+	head += failoverFenceBlocks
+	f.observeMessage(head + 1)
+	assert.False(t, check())
+
+	// the chain grows without any message of the partner
+	head += failoverFenceBlocks
+	assert.True(t, check())
+	assert.True(t, f.canSign(head+1))
+	assert.False(t, f.canSign(head))
+}
+
+func TestFailover_Fence(t *testing.T) {
+	t.Parallel()
+
+	head := uint64(10)
+	partner := &mockFailoverPartner{status: &proto.FailoverStatusResp{Role: "standby"}}
+	f := newTestFailover(consensus.FailoverActive, partner, &head)
+
+	f.check(context.Background())
+	f.markSigned(11)
+
+	status := f.fence()
+	assert.False(t, status.Signing)
+	assert.True(t, status.Fenced)
+	assert.Equal(t, uint64(11), status.LastSignedHeight)
+	assert.Len(t, f.changeCh, 1)
+
+	// a fenced node doesn't take over until restarted
+	f.check(context.Background())
+	assert.False(t, f.canSign(12))
+}
+
+func TestFailover_DoubleSigning(t *testing.T) {
+	t.Parallel()
+
+	head := uint64(10)
+	partner := &mockFailoverPartner{status: &proto.FailoverStatusResp{Role: "standby"}}
+	f := newTestFailover(consensus.FailoverActive, partner, &head)
+
+	f.check(context.Background())
+
+	// a late message of a height signed before the take over
+	f.observeMessage(10)
+	assert.True(t, f.canSign(11))
+
+	// another node signs the heights of the node
+	f.observeMessage(11)
+	assert.False(t, f.canSign(11))
+	assert.True(t, f.status().Fenced)
+}
@@ -33,6 +33,8 @@ type IBFTFork struct {
 	// PoS
 	MaxValidatorCount *common.JSONNumber `json:"maxValidatorCount,omitempty"`
 	MinValidatorCount *common.JSONNumber `json:"minValidatorCount,omitempty"`
+	UnbondingPeriod   *common.JSONNumber `json:"unbondingPeriod,omitempty"`
+	MaxValidatorExits *common.JSONNumber `json:"maxValidatorExits,omitempty"`
 }
 
 func (f *IBFTFork) UnmarshalJSON(data []byte) error {
@@ -45,6 +47,8 @@ func (f *IBFTFork) UnmarshalJSON(data []byte) error {
 		Validators        interface{}               `json:"validators,omitempty"`
 		MaxValidatorCount *common.JSONNumber        `json:"maxValidatorCount,omitempty"`
 		MinValidatorCount *common.JSONNumber        `json:"minValidatorCount,omitempty"`
+		UnbondingPeriod   *common.JSONNumber        `json:"unbondingPeriod,omitempty"`
+		MaxValidatorExits *common.JSONNumber        `json:"maxValidatorExits,omitempty"`
 	}{}
 
 	if err := json.Unmarshal(data, &raw); err != nil {
@@ -57,6 +61,8 @@ func (f *IBFTFork) UnmarshalJSON(data []byte) error {
 	f.To = raw.To
 	f.MaxValidatorCount = raw.MaxValidatorCount
 	f.MinValidatorCount = raw.MinValidatorCount
+	f.UnbondingPeriod = raw.UnbondingPeriod
+	f.MaxValidatorExits = raw.MaxValidatorExits
 
 	f.ValidatorType = validators.ECDSAValidatorType
 	if raw.ValidatorType != nil {
@@ -143,6 +149,19 @@ func (fs *IBFTForks) getFork(height uint64) *IBFTFork {
 	return nil
 }
 
+// deployments returns the heights the staking contract is deployed or updated at
+func (fs *IBFTForks) deployments() []uint64 {
+	heights := make([]uint64, 0)
+
+	for _, fork := range *fs {
+		if fork.Deployment != nil {
+			heights = append(heights, fork.Deployment.Value)
+		}
+	}
+
+	return heights
+}
+
 // filterByType returns new list of IBFTFork whose type matches with the given type
 func (fs *IBFTForks) filterByType(ibftType IBFTType) IBFTForks {
 	filteredForks := make(IBFTForks, 0)
@@ -0,0 +1,49 @@
+package fork
+
+import (
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// HeaderHasher calculates IBFT header hashes from the chain configuration alone,
+// for the tools reading the chain data without the validator keys
+type HeaderHasher struct {
+	forks IBFTForks
+}
+
+// NewHeaderHasher is a constructor of HeaderHasher
+func NewHeaderHasher(ibftConfig map[string]interface{}) (*HeaderHasher, error) {
+	forks, err := GetIBFTForks(ibftConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HeaderHasher{forks: forks}, nil
+}
+
+// CalculateHeaderHash calculates the hash of the header with the signer of its height
+func (h *HeaderHasher) CalculateHeaderHash(header *types.Header) (types.Hash, error) {
+	keyManager, err := h.getKeyManager(header.Number)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	var parentKeyManager signer.KeyManager
+
+	if header.Number > 1 {
+		if parentKeyManager, err = h.getKeyManager(header.Number - 1); err != nil {
+			return types.ZeroHash, err
+		}
+	}
+
+	return signer.NewSigner(keyManager, parentKeyManager).CalculateHeaderHash(header)
+}
+
+func (h *HeaderHasher) getKeyManager(height uint64) (signer.KeyManager, error) {
+	fork := h.forks.getFork(height)
+	if fork == nil {
+		return nil, ErrForkNotFound
+	}
+
+	return signer.NewKeylessKeyManager(fork.ValidatorType)
+}
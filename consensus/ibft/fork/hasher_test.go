@@ -0,0 +1,49 @@
+package fork
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	testHelper "github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderHasher_CalculateHeaderHash(t *testing.T) {
+	t.Parallel()
+
+	key, addr := testHelper.GenerateKeyAndAddr(t)
+	keyManager := signer.NewECDSAKeyManagerFromKey(key)
+	ecdsaSigner := signer.NewSigner(keyManager, keyManager)
+
+	header := &types.Header{Number: 5, GasLimit: 100}
+	ecdsaSigner.InitIBFTExtra(
+		header,
+		validators.NewECDSAValidatorSet(validators.NewECDSAValidator(addr)),
+		&signer.SerializedSeal{},
+	)
+
+	header, err := ecdsaSigner.WriteProposerSeal(header)
+	require.NoError(t, err)
+
+	expected, err := ecdsaSigner.CalculateHeaderHash(header)
+	require.NoError(t, err)
+
+	hasher, err := NewHeaderHasher(map[string]interface{}{
+		KeyType:          "PoA",
+		KeyValidatorType: "ecdsa",
+	})
+	require.NoError(t, err)
+
+	hash, err := hasher.CalculateHeaderHash(header)
+	require.NoError(t, err)
+	assert.Equal(t, expected, hash)
+
+	// no fork covers the height
+	hasher.forks[0].From.Value = 10
+
+	_, err = hasher.CalculateHeaderHash(header)
+	assert.ErrorIs(t, err, ErrForkNotFound)
+}
@@ -88,15 +88,22 @@ func NewPoSHookRegister(
 	}
 }
 
-// RegisterHooks registers hooks of PoA for additional block verification and contract deployment
+// RegisterHooks registers hooks of PoA for additional block verification, contract deployment
+// and the auto-compounding of the rewards
 func (r *PoSHookRegister) RegisterHooks(hooks *hook.Hooks, height uint64) {
-	if currentFork := r.posForks.getFork(height); currentFork != nil {
+	currentFork := r.posForks.getFork(height)
+	if currentFork != nil {
 		// in PoS mode currently
 		registerTxInclusionGuardHooks(hooks, r.epochSize)
 	}
 
 	if deploymentFork, ok := r.deployContractForks[height]; ok {
 		// deploy or update staking contract in deployment height
-		registerStakingContractDeploymentHooks(hooks, deploymentFork)
+		registerStakingContractDeploymentHooks(hooks, deploymentFork, r.epochSize)
+	}
+
+	if currentFork != nil {
+		// restake the rewards of the block creator, after the deployment of the contract at its height
+		registerRewardCompoundingHooks(hooks)
 	}
 }
@@ -86,6 +86,7 @@ func registerTxInclusionGuardHooks(hooks *hook.Hooks, epochSize uint64) {
 func registerStakingContractDeploymentHooks(
 	hooks *hook.Hooks,
 	fork *IBFTFork,
+	epochSize uint64,
 ) {
 	hooks.PreCommitStateFunc = func(header *types.Header, txn *state.Transition) error {
 		// safe check
@@ -105,7 +106,7 @@ func registerStakingContractDeploymentHooks(
 			// deploy contract
 			contractState, err := stakingHelper.PredeployStakingSC(
 				fork.Validators,
-				getPreDeployParams(fork),
+				getPreDeployParams(fork, epochSize),
 			)
 
 			if err != nil {
@@ -117,11 +118,79 @@ func registerStakingContractDeploymentHooks(
 	}
 }
 
+// registerRewardCompoundingHooks registers the hook restaking the rewards of the block creator
+// at the end of the block, after the hooks already registered
+func registerRewardCompoundingHooks(hooks *hook.Hooks) {
+	preCommitState := hooks.PreCommitStateFunc
+
+	hooks.PreCommitStateFunc = func(header *types.Header, txn *state.Transition) error {
+		if preCommitState != nil {
+			if err := preCommitState(header, txn); err != nil {
+				return err
+			}
+		}
+
+		return compoundRewards(txn)
+	}
+}
+
+// compoundRewards restakes the transaction fees paid to the coinbase in the block, if it enabled
+// the auto-compounding in the staking contract. The system sends the rewards to the staking contract
+// in place of the coinbase, they stay paid out if they can't be staked, i.e. over the stake limits
+func compoundRewards(txn *state.Transition) error {
+	rewards := txn.CoinbaseRewards()
+	if rewards.Sign() == 0 {
+		return nil
+	}
+
+	coinbase := txn.GetTxContext().Coinbase
+
+	compounding, err := stakingHelper.ReadAutoCompounding(func(slot types.Hash) (types.Hash, error) {
+		return txn.GetStorage(staking.AddrStakingContract, slot), nil
+	}, coinbase)
+	if err != nil {
+		return err
+	}
+
+	if !compounding.Enabled {
+		return nil
+	}
+
+	input, err := staking.NewStaking(staking.AddrStakingContract, nil).EncodeCompoundRewards(coinbase)
+	if err != nil {
+		return err
+	}
+
+	snapshot := txn.Txn().Snapshot()
+
+	// the coinbase may have spent the rewards in the block
+	if err := txn.Txn().SubBalance(coinbase, rewards); err != nil {
+		return nil
+	}
+
+	txn.Txn().AddBalance(staking.AddrSystem, rewards)
+
+	if result := txn.Call2(
+		staking.AddrSystem,
+		staking.AddrStakingContract,
+		input,
+		rewards,
+		staking.SystemCallGasLimit,
+	); result.Failed() {
+		txn.Txn().RevertToSnapshot(snapshot)
+	}
+
+	return nil
+}
+
 // getPreDeployParams returns PredeployParams for Staking Contract from IBFTFork
-func getPreDeployParams(fork *IBFTFork) stakingHelper.PredeployParams {
+func getPreDeployParams(fork *IBFTFork, epochSize uint64) stakingHelper.PredeployParams {
 	params := stakingHelper.PredeployParams{
 		MinValidatorCount: stakingHelper.MinValidatorCount,
 		MaxValidatorCount: stakingHelper.MaxValidatorCount,
+		UnbondingPeriod:   stakingHelper.DefaultUnbondingPeriod,
+		EpochSize:         epochSize,
+		MaxValidatorExits: stakingHelper.DefaultMaxValidatorExits,
 	}
 
 	if fork.MinValidatorCount != nil {
@@ -132,5 +201,13 @@ func getPreDeployParams(fork *IBFTFork) stakingHelper.PredeployParams {
 		params.MaxValidatorCount = fork.MaxValidatorCount.Value
 	}
 
+	if fork.UnbondingPeriod != nil {
+		params.UnbondingPeriod = fork.UnbondingPeriod.Value
+	}
+
+	if fork.MaxValidatorExits != nil {
+		params.MaxValidatorExits = fork.MaxValidatorExits.Value
+	}
+
 	return params
 }
@@ -2,6 +2,7 @@ package fork
 
 import (
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/chain"
@@ -17,6 +18,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/validators/store"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockHeaderModifierStore struct {
@@ -308,7 +310,7 @@ func Test_registerStakingContractDeploymentHooks(t *testing.T) {
 		},
 	}
 
-	registerStakingContractDeploymentHooks(hooks, fork)
+	registerStakingContractDeploymentHooks(hooks, fork, 10)
 
 	assert.Nil(t, hooks.ShouldWriteTransactionFunc)
 	assert.Nil(t, hooks.ModifyHeaderFunc)
@@ -352,6 +354,127 @@ func Test_registerStakingContractDeploymentHooks(t *testing.T) {
 	)
 }
 
+func Test_registerRewardCompoundingHooks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		validator = types.StringToAddress("a11ce")
+		user      = types.StringToAddress("b0b")
+		balance   = big.NewInt(1e18)
+	)
+
+	contract, err := stakingHelper.PredeployStakingSC(nil, stakingHelper.PredeployParams{MaxValidatorCount: 10})
+	require.NoError(t, err)
+
+	ex := state.NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+	}, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger())
+
+	root := ex.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		staking.AddrStakingContract: contract,
+		validator:                   {Balance: balance},
+		user:                        {Balance: balance},
+	})
+	ex.GetHash = func(h *types.Header) state.GetHashByNumber {
+		return func(i uint64) types.Hash {
+			return root
+		}
+	}
+
+	preCommitStateCalls := 0
+
+	hooks := &hook.Hooks{
+		PreCommitStateFunc: func(*types.Header, *state.Transition) error {
+			preCommitStateCalls++
+
+			return nil
+		},
+	}
+
+	registerRewardCompoundingHooks(hooks)
+
+	binding := staking.NewStaking(staking.AddrStakingContract, nil)
+
+	// commitBlock applies the transactions of the block created by the validator, with the hooks,
+	// and returns the transaction fees paid to the validator
+	commitBlock := func(number uint64, txs ...*types.Transaction) *big.Int {
+		txn, err := ex.BeginTxn(root, &types.Header{Number: number, GasLimit: 10000000}, validator)
+		require.NoError(t, err)
+
+		for _, tx := range txs {
+			tx.Nonce = txn.GetNonce(tx.From)
+
+			res, err := txn.Apply(tx)
+			require.NoError(t, err)
+			require.True(t, res.Succeeded())
+		}
+
+		rewards := txn.CoinbaseRewards()
+		require.NoError(t, hooks.PreCommitState(&types.Header{Number: number}, txn))
+
+		_, root = txn.Commit()
+
+		return rewards
+	}
+
+	encode := func(input []byte, err error) []byte {
+		require.NoError(t, err)
+
+		return input
+	}
+
+	newTx := func(from types.Address, input []byte) *types.Transaction {
+		return &types.Transaction{
+			From:     from,
+			To:       &staking.AddrStakingContract,
+			Value:    big.NewInt(0),
+			Input:    input,
+			GasPrice: big.NewInt(1),
+			Gas:      100000,
+		}
+	}
+
+	query := func() (*big.Int, *big.Int, *stakingHelper.AutoCompounding) {
+		txn, err := ex.BeginTxn(root, &types.Header{GasLimit: 10000000}, types.ZeroAddress)
+		require.NoError(t, err)
+
+		stake, err := staking.QueryAccountStake(txn, user, validator)
+		require.NoError(t, err)
+
+		compounding, err := stakingHelper.ReadAutoCompounding(func(slot types.Hash) (types.Hash, error) {
+			return txn.GetStorage(staking.AddrStakingContract, slot), nil
+		}, validator)
+		require.NoError(t, err)
+
+		return txn.GetBalance(validator), stake, compounding
+	}
+
+	// the rewards are paid out without auto-compounding
+	rewards := commitBlock(1, newTx(user, encode(binding.EncodeStakedAmount())))
+	assert.Positive(t, rewards.Sign())
+
+	paid, stake, compounding := query()
+	assert.Equal(t, new(big.Int).Add(balance, rewards), paid)
+	assert.Zero(t, stake.Sign())
+	assert.False(t, compounding.Enabled)
+
+	// the rewards are restaked once the validator enables the auto-compounding
+	enable := newTx(validator, encode(binding.EncodeSetAutoCompound(validator, true)))
+	enable.GasPrice = big.NewInt(0)
+
+	commitBlock(2, enable)
+
+	rewards = commitBlock(3, newTx(user, encode(binding.EncodeStakedAmount())))
+
+	balanceAfter, stake, compounding := query()
+	assert.Equal(t, paid, balanceAfter)
+	assert.Equal(t, rewards, stake)
+	assert.True(t, compounding.Enabled)
+	assert.Equal(t, rewards, compounding.Rewards)
+
+	assert.Equal(t, 3, preCommitStateCalls)
+}
+
 func Test_getPreDeployParams(t *testing.T) {
 	t.Parallel()
 
@@ -365,10 +488,15 @@ func Test_getPreDeployParams(t *testing.T) {
 			fork: &IBFTFork{
 				MinValidatorCount: &common.JSONNumber{Value: 10},
 				MaxValidatorCount: &common.JSONNumber{Value: 20},
+				UnbondingPeriod:   &common.JSONNumber{Value: 3},
+				MaxValidatorExits: &common.JSONNumber{Value: 2},
 			},
 			params: stakingHelper.PredeployParams{
 				MinValidatorCount: 10,
 				MaxValidatorCount: 20,
+				UnbondingPeriod:   3,
+				EpochSize:         10,
+				MaxValidatorExits: 2,
 			},
 		},
 		{
@@ -377,6 +505,9 @@ func Test_getPreDeployParams(t *testing.T) {
 			params: stakingHelper.PredeployParams{
 				MinValidatorCount: stakingHelper.MinValidatorCount,
 				MaxValidatorCount: stakingHelper.MaxValidatorCount,
+				UnbondingPeriod:   stakingHelper.DefaultUnbondingPeriod,
+				EpochSize:         10,
+				MaxValidatorExits: stakingHelper.DefaultMaxValidatorExits,
 			},
 		},
 	}
@@ -390,7 +521,7 @@ func Test_getPreDeployParams(t *testing.T) {
 			assert.Equal(
 				t,
 				test.params,
-				getPreDeployParams(test.fork),
+				getPreDeployParams(test.fork, 10),
 			)
 		})
 	}
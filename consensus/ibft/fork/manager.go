@@ -2,6 +2,7 @@ package fork
 
 import (
 	"errors"
+	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/hook"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
@@ -36,6 +37,12 @@ type ValidatorStore interface {
 	GetValidators(height, epochSize, forkFrom uint64) (validators.Validators, error)
 }
 
+// StakeStore is an interface of the validator stores holding the stakes of the validators
+type StakeStore interface {
+	// GetStakes is a method to return the stakes of the validators at the given height
+	GetStakes(height, epochSize, forkFrom uint64) (map[types.Address]*big.Int, error)
+}
+
 // HookRegister is an interface that ForkManager calls for hook registrations
 type HooksRegister interface {
 	// RegisterHooks register hooks for the given block height
@@ -187,6 +194,31 @@ func (m *ForkManager) GetValidators(height uint64) (validators.Validators, error
 	)
 }
 
+// GetValidatorStakes returns the stakes of the validators at specified height,
+// nil if the validators of the fork have no stake
+func (m *ForkManager) GetValidatorStakes(height uint64) (map[types.Address]*big.Int, error) {
+	fork := m.forks.getFork(height)
+	if fork == nil {
+		return nil, ErrForkNotFound
+	}
+
+	set := m.getValidatorStoreByIBFTFork(fork)
+	if set == nil {
+		return nil, ErrValidatorStoreNotFound
+	}
+
+	stakeStore, ok := set.(StakeStore)
+	if !ok {
+		return nil, nil
+	}
+
+	return stakeStore.GetStakes(
+		height,
+		m.epochSize,
+		fork.From.Value,
+	)
+}
+
 // GetHooks returns a hooks at specified height
 func (m *ForkManager) GetHooks(height uint64) HooksInterface {
 	hooks := &hook.Hooks{}
@@ -286,6 +318,7 @@ func (m *ForkManager) initializeValidatorStore(setType store.SourceType) error {
 			m.blockchain,
 			m.executor,
 			m.GetSigner,
+			m.forks.deployments(),
 		)
 	}
 
@@ -1,16 +1,22 @@
 package fork
 
 import (
+	"errors"
+	"math/big"
 	"path/filepath"
 
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/0xPolygon/polygon-edge/validators/store"
 	"github.com/0xPolygon/polygon-edge/validators/store/contract"
 	"github.com/0xPolygon/polygon-edge/validators/store/snapshot"
 	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
 )
 
+var errInvalidStakesTypeAssertion = errors.New("invalid type assertion for stakes")
+
 // SnapshotValidatorStoreWrapper is a wrapper of store.SnapshotValidatorStore
 // in order to add initialization and closer process with side effect
 type SnapshotValidatorStoreWrapper struct {
@@ -92,6 +98,12 @@ func NewSnapshotValidatorStoreWrapper(
 type ContractValidatorStoreWrapper struct {
 	*contract.ContractValidatorStore
 	getSigner func(uint64) (signer.Signer, error)
+
+	// exitQueueCache caches the validator sets with the exit queue applied by fetching height
+	exitQueueCache *lru.Cache
+
+	// stakeCache caches the stakes of the validator sets by fetching height
+	stakeCache *lru.Cache
 }
 
 // NewContractValidatorStoreWrapper creates *ContractValidatorStoreWrapper
@@ -100,21 +112,35 @@ func NewContractValidatorStoreWrapper(
 	blockchain store.HeaderGetter,
 	executor contract.Executor,
 	getSigner func(uint64) (signer.Signer, error),
+	deployments []uint64,
 ) (*ContractValidatorStoreWrapper, error) {
 	contractStore, err := contract.NewContractValidatorStore(
 		logger,
 		blockchain,
 		executor,
 		contract.DefaultValidatorSetCacheSize,
+		deployments,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
+	exitQueueCache, err := lru.New(contract.DefaultValidatorSetCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	stakeCache, err := lru.New(contract.DefaultValidatorSetCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ContractValidatorStoreWrapper{
 		ContractValidatorStore: contractStore,
 		getSigner:              getSigner,
+		exitQueueCache:         exitQueueCache,
+		stakeCache:             stakeCache,
 	}, nil
 }
 
@@ -132,16 +158,105 @@ func (w *ContractValidatorStoreWrapper) GetValidators(
 		return nil, err
 	}
 
-	return w.GetValidatorsByHeight(
+	return w.getValidatorsWithExitQueue(
 		signer.Type(),
 		calculateContractStoreFetchingHeight(
 			height,
 			epochSize,
 			forkFrom,
 		),
+		epochSize,
+		forkFrom,
 	)
 }
 
+// GetStakes returns the stakes of the validators at the given height,
+// read at the same height as the validator set
+func (w *ContractValidatorStoreWrapper) GetStakes(
+	height, epochSize, forkFrom uint64,
+) (map[types.Address]*big.Int, error) {
+	fetchingHeight := calculateContractStoreFetchingHeight(height, epochSize, forkFrom)
+
+	if cached, ok := w.stakeCache.Get(fetchingHeight); ok {
+		stakes, ok := cached.(map[types.Address]*big.Int)
+		if !ok {
+			return nil, errInvalidStakesTypeAssertion
+		}
+
+		return stakes, nil
+	}
+
+	vals, err := w.GetValidators(height, epochSize, forkFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	stakes, err := w.GetValidatorStakes(fetchingHeight, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	w.stakeCache.Add(fetchingHeight, stakes)
+
+	return stakes, nil
+}
+
+// getValidatorsWithExitQueue returns the validators fetched at the height, with the validators leaving
+// beyond the limit of exits per epoch kept in the set. As the queued validators carry over from the previous
+// epochs, the sets are applied from the last cached set, the last epoch without limit or the first epoch
+// of the fork, whichever is the latest
+func (w *ContractValidatorStoreWrapper) getValidatorsWithExitQueue(
+	validatorType validators.ValidatorType,
+	height, epochSize, forkFrom uint64,
+) (validators.Validators, error) {
+	var (
+		set      validators.Validators
+		heights  = make([]uint64, 0, 1)
+		maxExits = make([]uint64, 0, 1)
+	)
+
+	for fetchingHeight := height; ; {
+		if cached, ok := w.exitQueueCache.Get(fetchingHeight); ok {
+			if set, ok = cached.(validators.Validators); !ok {
+				return nil, contract.ErrInvalidValidatorsTypeAssertion
+			}
+
+			break
+		}
+
+		limit, err := w.GetMaxValidatorExits(fetchingHeight)
+		if err != nil {
+			return nil, err
+		}
+
+		heights = append(heights, fetchingHeight)
+		maxExits = append(maxExits, limit)
+
+		// the fetching height of the previous epoch, the same height in the first epoch of the fork
+		previous := calculateContractStoreFetchingHeight(fetchingHeight, epochSize, forkFrom)
+		if limit == 0 || previous == fetchingHeight {
+			break
+		}
+
+		fetchingHeight = previous
+	}
+
+	for idx := len(heights) - 1; idx >= 0; idx-- {
+		fetched, err := w.GetValidatorsByHeight(validatorType, heights[idx])
+		if err != nil {
+			return nil, err
+		}
+
+		if set, err = contract.LimitValidatorExits(set, fetched, maxExits[idx]); err != nil {
+			return nil, err
+		}
+
+		w.exitQueueCache.Add(heights[idx], set)
+	}
+
+	return set, nil
+}
+
 // calculateContractStoreFetchingHeight calculates the block height at which ContractStore fetches validators
 // based on height, epoch, and fork beginning height
 func calculateContractStoreFetchingHeight(height, epochSize, forkFrom uint64) uint64 {
@@ -8,6 +8,7 @@ import (
 	"path"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
 	testHelper "github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/state"
@@ -257,13 +258,18 @@ func TestSnapshotValidatorStoreWrapperClose(t *testing.T) {
 }
 
 type MockExecutor struct {
-	BeginTxnFunc func(types.Hash, *types.Header, types.Address) (*state.Transition, error)
+	BeginTxnFunc       func(types.Hash, *types.Header, types.Address) (*state.Transition, error)
+	GetForksInTimeFunc func(uint64) chain.ForksInTime
 }
 
 func (m *MockExecutor) BeginTxn(hash types.Hash, header *types.Header, addr types.Address) (*state.Transition, error) {
 	return m.BeginTxnFunc(hash, header, addr)
 }
 
+func (m *MockExecutor) GetForksInTime(height uint64) chain.ForksInTime {
+	return m.GetForksInTimeFunc(height)
+}
+
 func TestNewContractValidatorStoreWrapper(t *testing.T) {
 	t.Parallel()
 
@@ -274,6 +280,7 @@ func TestNewContractValidatorStoreWrapper(t *testing.T) {
 		func(u uint64) (signer.Signer, error) {
 			return nil, nil
 		},
+		nil,
 	)
 
 	assert.NoError(t, err)
@@ -289,6 +296,7 @@ func TestNewContractValidatorStoreWrapperClose(t *testing.T) {
 		func(u uint64) (signer.Signer, error) {
 			return nil, nil
 		},
+		nil,
 	)
 
 	assert.NoError(t, err)
@@ -308,6 +316,7 @@ func TestNewContractValidatorStoreWrapperGetValidators(t *testing.T) {
 			func(u uint64) (signer.Signer, error) {
 				return nil, errTest
 			},
+			nil,
 		)
 
 		assert.NoError(t, err)
@@ -334,6 +343,7 @@ func TestNewContractValidatorStoreWrapperGetValidators(t *testing.T) {
 					nil,
 				), nil
 			},
+			nil,
 		)
 
 		assert.NoError(t, err)
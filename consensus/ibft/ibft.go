@@ -1,8 +1,10 @@
 package ibft
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
@@ -46,8 +48,10 @@ type txPoolInterface interface {
 	Pop(tx *types.Transaction)
 	Drop(tx *types.Transaction)
 	Demote(tx *types.Transaction)
+	Quarantine(tx *types.Transaction, reason string)
 	ResetWithHeaders(headers ...*types.Header)
 	SetSealing(bool)
+	Decrypted(ctx context.Context, number uint64) []*types.Transaction
 }
 
 type forkManagerInterface interface {
@@ -56,6 +60,7 @@ type forkManagerInterface interface {
 	GetSigner(uint64) (signer.Signer, error)
 	GetValidatorStore(uint64) (fork.ValidatorStore, error)
 	GetValidators(uint64) (validators.Validators, error)
+	GetValidatorStakes(uint64) (map[types.Address]*big.Int, error)
 	GetHooks(uint64) fork.HooksInterface
 }
 
@@ -74,6 +79,8 @@ type backendIBFT struct {
 	Grpc           *grpc.Server           // Reference to the gRPC manager
 	operator       *operator              // Reference to the gRPC service of IBFT
 	transport      transport              // Reference to the transport protocol
+	failover       *failover              // Guard of the signing in an active/standby pair, if any
+	commits        *commitCollector       // Committed seals received at the current height
 
 	// Dynamic References
 	forkManager       forkManagerInterface  // Manager to hold IBFT Forks
@@ -81,11 +88,17 @@ type backendIBFT struct {
 	currentValidators validators.Validators // signer at current sequence
 	currentHooks      fork.HooksInterface   // Hooks at current sequence
 
+	currentVotingPowers []*big.Int // Voting powers of the validators at current sequence, nil for equal powers
+
 	// Configurations
 	config             *consensus.Config // Consensus configuration
 	epochSize          uint64
 	quorumSizeBlockNum uint64
+	randomness         *randomnessConfig
+	votingPower        *votingPowerConfig
 	blockTime          time.Duration // Minimum block generation time in seconds
+	txExecutionBudget  time.Duration // Maximum execution time of a transaction while building a block
+	extraDataVanity    []byte        // Prefix of the extra data of the built blocks
 
 	// Channels
 	closeCh chan struct{} // Channel for closing
@@ -119,6 +132,16 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		quorumSizeBlockNum = uint64(readBlockNum)
 	}
 
+	randomness, err := parseRandomnessConfig(params.Config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	votingPower, err := parseVotingPowerConfig(params.Config.Config)
+	if err != nil {
+		return nil, err
+	}
+
 	logger := params.Logger.Named("ibft")
 
 	forkManager, err := fork.NewForkManager(
@@ -156,12 +179,28 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		config:             params.Config,
 		epochSize:          epochSize,
 		quorumSizeBlockNum: quorumSizeBlockNum,
+		randomness:         randomness,
+		votingPower:        votingPower,
+		commits:            newCommitCollector(),
 		blockTime:          time.Duration(params.BlockTime) * time.Second,
+		txExecutionBudget:  params.TxExecutionBudget,
+		extraDataVanity:    params.ExtraDataVanity,
 
 		// Channels
 		closeCh: make(chan struct{}),
 	}
 
+	if params.Failover != nil {
+		partner, err := dialFailoverPartner(params.Failover.PartnerAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		p.failover = newFailover(logger, params.Failover.Role, partner, func() uint64 {
+			return params.Blockchain.Header().Number
+		})
+	}
+
 	// Istanbul requires a different header hash function
 	p.SetHeaderHash()
 
@@ -173,6 +212,7 @@ func (i *backendIBFT) Initialize() error {
 	if i.Grpc != nil {
 		i.operator = &operator{ibft: i}
 		proto.RegisterIbftOperatorServer(i.Grpc, i.operator)
+		proto.RegisterLegacyIbftOperatorServer(i.Grpc, i.operator)
 	}
 
 	// start the transport protocol
@@ -239,6 +279,11 @@ func (i *backendIBFT) Start() error {
 	// Start the actual consensus protocol
 	go i.startConsensus()
 
+	// Watch the partner of the active/standby pair
+	if i.failover != nil {
+		go i.failover.run(i.failoverCheckInterval(), i.closeCh)
+	}
+
 	return nil
 }
 
@@ -276,9 +321,14 @@ func (i *backendIBFT) startConsensus() {
 
 	var (
 		sequenceCh  = make(<-chan struct{})
+		failoverCh  <-chan struct{}
 		isValidator bool
 	)
 
+	if i.failover != nil {
+		failoverCh = i.failover.changeCh
+	}
+
 	for {
 		var (
 			latest  = i.blockchain.Header().Number
@@ -293,14 +343,20 @@ func (i *backendIBFT) startConsensus() {
 			)
 		}
 
+		i.commits.reset(pending)
+
 		// Update the No.of validator metric
 		metrics.SetGauge([]string{"validators"}, float32(i.currentValidators.Len()))
 
-		isValidator = i.isActiveValidator()
+		isValidator = i.isActiveValidator() && i.canSign(pending)
 
 		i.txpool.SetSealing(isValidator)
 
 		if isValidator {
+			if i.failover != nil {
+				i.failover.markSigned(pending)
+			}
+
 			sequenceCh = i.consensus.runSequence(pending)
 		}
 
@@ -310,6 +366,11 @@ func (i *backendIBFT) startConsensus() {
 				i.consensus.stopSequence()
 				i.logger.Info("canceled sequence", "sequence", pending)
 			}
+		case <-failoverCh:
+			if isValidator {
+				i.consensus.stopSequence()
+				i.logger.Info("canceled sequence, the signing is fenced", "sequence", pending)
+			}
 		case <-sequenceCh:
 		case <-i.closeCh:
 			if isValidator {
@@ -326,6 +387,20 @@ func (i *backendIBFT) isActiveValidator() bool {
 	return i.currentValidators.Includes(i.currentSigner.Address())
 }
 
+// canSign returns whether the node signs the height, only one node of an active/standby pair does
+func (i *backendIBFT) canSign(height uint64) bool {
+	return i.failover == nil || i.failover.canSign(height)
+}
+
+// failoverCheckInterval is the interval of the partner checks, one per block
+func (i *backendIBFT) failoverCheckInterval() time.Duration {
+	if i.blockTime < time.Second {
+		return time.Second
+	}
+
+	return i.blockTime
+}
+
 // updateMetrics will update various metrics based on the given block
 // currently we capture No.of Txs and block interval metrics using this function
 func (i *backendIBFT) updateMetrics(block *types.Block) {
@@ -379,6 +454,15 @@ func (i *backendIBFT) verifyHeaderImpl(
 		return err
 	}
 
+	// verify the RandomnessProof
+	if err := i.verifyRandomnessProof(
+		parent, header,
+		headerSigner,
+		validators,
+	); err != nil {
+		return err
+	}
+
 	// verify the ParentCommittedSeals
 	if err := i.verifyParentCommittedSeals(
 		parent, header,
@@ -425,12 +509,17 @@ func (i *backendIBFT) VerifyHeader(header *types.Header) error {
 		return err
 	}
 
+	quorum, err := i.sealQuorum(header.Number, validators)
+	if err != nil {
+		return err
+	}
+
 	// verify the Committed Seals
 	// CommittedSeals exists only in the finalized header
 	if err := headerSigner.VerifyCommittedSeals(
 		header,
 		validators,
-		i.quorumSize(header.Number)(validators),
+		quorum,
 	); err != nil {
 		return err
 	}
@@ -476,7 +565,11 @@ func (i *backendIBFT) GetBlockCreator(header *types.Header) (types.Address, erro
 func (i *backendIBFT) PreCommitState(header *types.Header, txn *state.Transition) error {
 	hooks := i.forkManager.GetHooks(header.Number)
 
-	return hooks.PreCommitState(header, txn)
+	if err := hooks.PreCommitState(header, txn); err != nil {
+		return err
+	}
+
+	return i.writeRandomness(header, txn)
 }
 
 // GetEpoch returns the current epoch
@@ -488,11 +581,21 @@ func (i *backendIBFT) GetEpoch(number uint64) uint64 {
 	return number/i.epochSize + 1
 }
 
+// EpochSize returns the number of the blocks of an epoch
+func (i *backendIBFT) EpochSize() uint64 {
+	return i.epochSize
+}
+
 // IsLastOfEpoch checks if the block number is the last of the epoch
 func (i *backendIBFT) IsLastOfEpoch(number uint64) bool {
 	return number > 0 && number%i.epochSize == 0
 }
 
+// GetValidators returns the validator set of the given height
+func (i *backendIBFT) GetValidators(height uint64) (validators.Validators, error) {
+	return i.forkManager.GetValidators(height)
+}
+
 // Close closes the IBFT consensus mechanism, and does write back to disk
 func (i *backendIBFT) Close() error {
 	close(i.closeCh)
@@ -540,9 +643,15 @@ func (i *backendIBFT) updateCurrentModules(height uint64) error {
 		return err
 	}
 
+	votingPowers, err := i.getVotingPowers(height, validators)
+	if err != nil {
+		return err
+	}
+
 	i.currentSigner = signer
 	i.currentValidators = validators
 	i.currentHooks = hooks
+	i.currentVotingPowers = votingPowers
 
 	i.logFork(lastSigner, signer)
 
@@ -575,13 +684,18 @@ func (i *backendIBFT) verifyParentCommittedSeals(
 		return err
 	}
 
+	quorum, err := i.sealQuorum(parent.Number, parentValidators)
+	if err != nil {
+		return err
+	}
+
 	// if shouldVerifyParentCommittedSeals is false, skip the verification
 	// when header doesn't have Parent Committed Seals (Backward Compatibility)
 	return parentSigner.VerifyParentCommittedSeals(
 		parent,
 		header,
 		parentValidators,
-		i.quorumSize(parent.Number)(parentValidators),
+		quorum,
 		shouldVerifyParentCommittedSeals,
 	)
 }
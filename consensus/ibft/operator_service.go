@@ -44,6 +44,25 @@ func (o *operator) Status(ctx context.Context, req *empty.Empty) (*proto.IbftSta
 	}, nil
 }
 
+// FailoverStatus returns the failover status of the node in an active/standby pair
+func (o *operator) FailoverStatus(ctx context.Context, req *empty.Empty) (*proto.FailoverStatusResp, error) {
+	if o.ibft.failover == nil {
+		return nil, ErrFailoverDisabled
+	}
+
+	return o.ibft.failover.status(), nil
+}
+
+// Fence stops the signing of the node in an active/standby pair until it's restarted,
+// letting the partner take over
+func (o *operator) Fence(ctx context.Context, req *empty.Empty) (*proto.FailoverStatusResp, error) {
+	if o.ibft.failover == nil {
+		return nil, ErrFailoverDisabled
+	}
+
+	return o.ibft.failover.fence(), nil
+}
+
 // GetSnapshot returns the snapshot, based on the passed in request
 func (o *operator) GetSnapshot(ctx context.Context, req *proto.SnapshotReq) (*proto.Snapshot, error) {
 	height := req.Number
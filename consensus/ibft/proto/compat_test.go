@@ -0,0 +1,110 @@
+package proto_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/proto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// releasedIbftOperatorAPI is the IbftOperator API served under the unversioned v1 package,
+// which must stay wire compatible
+var releasedIbftOperatorAPI = map[string]string{
+	"IbftOperator.GetSnapshot":   "(SnapshotReq) returns (Snapshot)",
+	"IbftOperator.Propose":       "(Candidate) returns (google.protobuf.Empty)",
+	"IbftOperator.Candidates":    "(google.protobuf.Empty) returns (CandidatesResp)",
+	"IbftOperator.Status":        "(google.protobuf.Empty) returns (IbftStatusResp)",
+	"IbftStatusResp.key":         "1 string",
+	"SnapshotReq.latest":         "1 bool",
+	"SnapshotReq.number":         "2 uint64",
+	"Snapshot.validators":        "1 repeated message Snapshot.Validator",
+	"Snapshot.number":            "2 uint64",
+	"Snapshot.hash":              "3 string",
+	"Snapshot.votes":             "4 repeated message Snapshot.Vote",
+	"Snapshot.Validator.type":    "1 string",
+	"Snapshot.Validator.address": "2 string",
+	"Snapshot.Validator.data":    "3 bytes",
+	"Snapshot.Vote.validator":    "1 string",
+	"Snapshot.Vote.proposed":     "2 string",
+	"Snapshot.Vote.auth":         "3 bool",
+	"ProposeReq.address":         "1 string",
+	"ProposeReq.auth":            "2 bool",
+	"CandidatesResp.candidates":  "1 repeated message Candidate",
+	"Candidate.address":          "1 string",
+	"Candidate.bls_pubkey":       "2 bytes",
+	"Candidate.auth":             "3 bool",
+}
+
+func TestIbftOperatorAPICompatibility(t *testing.T) {
+	t.Parallel()
+
+	tests.AssertProtoCompatible(t, proto.File_consensus_ibft_proto_ibft_operator_proto, releasedIbftOperatorAPI)
+}
+
+type mockIbftOperatorServer struct {
+	proto.UnimplementedIbftOperatorServer
+}
+
+func (m *mockIbftOperatorServer) Status(context.Context, *emptypb.Empty) (*proto.IbftStatusResp, error) {
+	return &proto.IbftStatusResp{Key: "0x1"}, nil
+}
+
+func newTestIbftOperatorConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	srv := &mockIbftOperatorServer{}
+
+	proto.RegisterIbftOperatorServer(s, srv)
+	proto.RegisterLegacyIbftOperatorServer(s, srv)
+
+	go func() {
+		_ = s.Serve(lis)
+	}()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+
+	return conn
+}
+
+func TestLegacyIbftOperatorService(t *testing.T) {
+	t.Parallel()
+
+	conn := newTestIbftOperatorConn(t)
+
+	// versioned client
+	resp, err := proto.NewIbftOperatorClient(conn).Status(context.Background(), &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1", resp.Key)
+
+	// client generated from the unversioned package
+	legacyResp := &proto.IbftStatusResp{}
+	assert.NoError(t, conn.Invoke(
+		context.Background(),
+		"/"+proto.LegacyIbftOperatorServiceName+"/Status",
+		&emptypb.Empty{},
+		legacyResp,
+	))
+	assert.Equal(t, "0x1", legacyResp.Key)
+}
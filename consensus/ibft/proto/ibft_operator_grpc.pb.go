@@ -8,10 +8,10 @@ package proto
 
 import (
 	context "context"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -24,9 +24,11 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type IbftOperatorClient interface {
 	GetSnapshot(ctx context.Context, in *SnapshotReq, opts ...grpc.CallOption) (*Snapshot, error)
-	Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*empty.Empty, error)
-	Candidates(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CandidatesResp, error)
-	Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error)
+	Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Candidates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CandidatesResp, error)
+	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error)
+	FailoverStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FailoverStatusResp, error)
+	Fence(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FailoverStatusResp, error)
 }
 
 type ibftOperatorClient struct {
@@ -39,34 +41,52 @@ func NewIbftOperatorClient(cc grpc.ClientConnInterface) IbftOperatorClient {
 
 func (c *ibftOperatorClient) GetSnapshot(ctx context.Context, in *SnapshotReq, opts ...grpc.CallOption) (*Snapshot, error) {
 	out := new(Snapshot)
-	err := c.cc.Invoke(ctx, "/v1.IbftOperator/GetSnapshot", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/ibft.v1.IbftOperator/GetSnapshot", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *ibftOperatorClient) Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/v1.IbftOperator/Propose", in, out, opts...)
+func (c *ibftOperatorClient) Propose(ctx context.Context, in *Candidate, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/ibft.v1.IbftOperator/Propose", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *ibftOperatorClient) Candidates(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CandidatesResp, error) {
+func (c *ibftOperatorClient) Candidates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CandidatesResp, error) {
 	out := new(CandidatesResp)
-	err := c.cc.Invoke(ctx, "/v1.IbftOperator/Candidates", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/ibft.v1.IbftOperator/Candidates", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *ibftOperatorClient) Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error) {
+func (c *ibftOperatorClient) Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IbftStatusResp, error) {
 	out := new(IbftStatusResp)
-	err := c.cc.Invoke(ctx, "/v1.IbftOperator/Status", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/ibft.v1.IbftOperator/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) FailoverStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FailoverStatusResp, error) {
+	out := new(FailoverStatusResp)
+	err := c.cc.Invoke(ctx, "/ibft.v1.IbftOperator/FailoverStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ibftOperatorClient) Fence(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FailoverStatusResp, error) {
+	out := new(FailoverStatusResp)
+	err := c.cc.Invoke(ctx, "/ibft.v1.IbftOperator/Fence", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -78,9 +98,11 @@ func (c *ibftOperatorClient) Status(ctx context.Context, in *empty.Empty, opts .
 // for forward compatibility
 type IbftOperatorServer interface {
 	GetSnapshot(context.Context, *SnapshotReq) (*Snapshot, error)
-	Propose(context.Context, *Candidate) (*empty.Empty, error)
-	Candidates(context.Context, *empty.Empty) (*CandidatesResp, error)
-	Status(context.Context, *empty.Empty) (*IbftStatusResp, error)
+	Propose(context.Context, *Candidate) (*emptypb.Empty, error)
+	Candidates(context.Context, *emptypb.Empty) (*CandidatesResp, error)
+	Status(context.Context, *emptypb.Empty) (*IbftStatusResp, error)
+	FailoverStatus(context.Context, *emptypb.Empty) (*FailoverStatusResp, error)
+	Fence(context.Context, *emptypb.Empty) (*FailoverStatusResp, error)
 	mustEmbedUnimplementedIbftOperatorServer()
 }
 
@@ -91,15 +113,21 @@ type UnimplementedIbftOperatorServer struct {
 func (UnimplementedIbftOperatorServer) GetSnapshot(context.Context, *SnapshotReq) (*Snapshot, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSnapshot not implemented")
 }
-func (UnimplementedIbftOperatorServer) Propose(context.Context, *Candidate) (*empty.Empty, error) {
+func (UnimplementedIbftOperatorServer) Propose(context.Context, *Candidate) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Propose not implemented")
 }
-func (UnimplementedIbftOperatorServer) Candidates(context.Context, *empty.Empty) (*CandidatesResp, error) {
+func (UnimplementedIbftOperatorServer) Candidates(context.Context, *emptypb.Empty) (*CandidatesResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Candidates not implemented")
 }
-func (UnimplementedIbftOperatorServer) Status(context.Context, *empty.Empty) (*IbftStatusResp, error) {
+func (UnimplementedIbftOperatorServer) Status(context.Context, *emptypb.Empty) (*IbftStatusResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedIbftOperatorServer) FailoverStatus(context.Context, *emptypb.Empty) (*FailoverStatusResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FailoverStatus not implemented")
+}
+func (UnimplementedIbftOperatorServer) Fence(context.Context, *emptypb.Empty) (*FailoverStatusResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fence not implemented")
+}
 func (UnimplementedIbftOperatorServer) mustEmbedUnimplementedIbftOperatorServer() {}
 
 // UnsafeIbftOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -123,7 +151,7 @@ func _IbftOperator_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.IbftOperator/GetSnapshot",
+		FullMethod: "/ibft.v1.IbftOperator/GetSnapshot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(IbftOperatorServer).GetSnapshot(ctx, req.(*SnapshotReq))
@@ -141,7 +169,7 @@ func _IbftOperator_Propose_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.IbftOperator/Propose",
+		FullMethod: "/ibft.v1.IbftOperator/Propose",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(IbftOperatorServer).Propose(ctx, req.(*Candidate))
@@ -150,7 +178,7 @@ func _IbftOperator_Propose_Handler(srv interface{}, ctx context.Context, dec fun
 }
 
 func _IbftOperator_Candidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -159,16 +187,16 @@ func _IbftOperator_Candidates_Handler(srv interface{}, ctx context.Context, dec
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.IbftOperator/Candidates",
+		FullMethod: "/ibft.v1.IbftOperator/Candidates",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IbftOperatorServer).Candidates(ctx, req.(*empty.Empty))
+		return srv.(IbftOperatorServer).Candidates(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _IbftOperator_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -177,10 +205,46 @@ func _IbftOperator_Status_Handler(srv interface{}, ctx context.Context, dec func
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.IbftOperator/Status",
+		FullMethod: "/ibft.v1.IbftOperator/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).Status(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_FailoverStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).FailoverStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ibft.v1.IbftOperator/FailoverStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IbftOperatorServer).FailoverStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IbftOperator_Fence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IbftOperatorServer).Fence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ibft.v1.IbftOperator/Fence",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IbftOperatorServer).Status(ctx, req.(*empty.Empty))
+		return srv.(IbftOperatorServer).Fence(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -189,7 +253,7 @@ func _IbftOperator_Status_Handler(srv interface{}, ctx context.Context, dec func
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var IbftOperator_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "v1.IbftOperator",
+	ServiceName: "ibft.v1.IbftOperator",
 	HandlerType: (*IbftOperatorServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -208,6 +272,14 @@ var IbftOperator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _IbftOperator_Status_Handler,
 		},
+		{
+			MethodName: "FailoverStatus",
+			Handler:    _IbftOperator_FailoverStatus_Handler,
+		},
+		{
+			MethodName: "Fence",
+			Handler:    _IbftOperator_Fence_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "consensus/ibft/proto/ibft_operator.proto",
@@ -0,0 +1,16 @@
+package proto
+
+import "google.golang.org/grpc"
+
+// LegacyIbftOperatorServiceName is the name the IbftOperator service was served under
+// before it was moved to the versioned ibft.v1 package
+const LegacyIbftOperatorServiceName = "v1.IbftOperator"
+
+// RegisterLegacyIbftOperatorServer registers the IbftOperator service under its legacy name,
+// so the clients generated from the unversioned package keep working
+func RegisterLegacyIbftOperatorServer(s grpc.ServiceRegistrar, srv IbftOperatorServer) {
+	desc := IbftOperator_ServiceDesc
+	desc.ServiceName = LegacyIbftOperatorServiceName
+
+	s.RegisterService(&desc, srv)
+}
@@ -0,0 +1,221 @@
+package ibft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/contracts/randomness"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+const (
+	KeyRandomnessBlockNum     = "randomnessBlockNum"
+	KeyRandomProposerElection = "randomProposerElection"
+
+	// minRandomnessBlockNum is the first height that can have RandomnessProof,
+	// the proof follows ParentCommittedSeals in IBFT Extra
+	minRandomnessBlockNum = 2
+)
+
+var errRandomnessRequiresBLS = errors.New("the randomness beacon requires BLS validators")
+
+// randomnessConfig is the configuration of the randomness beacon
+type randomnessConfig struct {
+	enabled          bool
+	blockNum         uint64
+	proposerElection bool
+}
+
+// parseRandomnessConfig reads the randomness beacon configuration from the engine config
+func parseRandomnessConfig(engineConfig map[string]interface{}) (*randomnessConfig, error) {
+	config := &randomnessConfig{}
+
+	rawBlockNum, ok := engineConfig[KeyRandomnessBlockNum]
+	if !ok {
+		return config, nil
+	}
+
+	readBlockNum, ok := rawBlockNum.(float64)
+	if !ok {
+		return nil, errors.New("invalid type assertion")
+	}
+
+	config.enabled = true
+	config.blockNum = uint64(readBlockNum)
+
+	if config.blockNum < minRandomnessBlockNum {
+		config.blockNum = minRandomnessBlockNum
+	}
+
+	if err := CheckRandomnessValidators(engineConfig, config.blockNum); err != nil {
+		return nil, err
+	}
+
+	if rawElection, ok := engineConfig[KeyRandomProposerElection]; ok {
+		readElection, ok := rawElection.(bool)
+		if !ok {
+			return nil, errors.New("invalid type assertion")
+		}
+
+		config.proposerElection = readElection
+	}
+
+	return config, nil
+}
+
+// CheckRandomnessValidators ensures the validators are BLS validators from the first height of the beacon.
+// The randomness is the hash of a signature of the seed, which only BLS makes unique:
+// an ECDSA signer picks the nonce of the signature and could grind the randomness
+func CheckRandomnessValidators(engineConfig map[string]interface{}, blockNum uint64) error {
+	forks, err := fork.GetIBFTForks(engineConfig)
+	if err != nil {
+		return err
+	}
+
+	for _, ibftFork := range forks {
+		if ibftFork.To != nil && ibftFork.To.Value < blockNum {
+			continue
+		}
+
+		if ibftFork.ValidatorType != validators.BLSValidatorType {
+			return fmt.Errorf(
+				"%w: %s validators from block %d",
+				errRandomnessRequiresBLS,
+				ibftFork.ValidatorType,
+				ibftFork.From.Value,
+			)
+		}
+	}
+
+	return nil
+}
+
+// isRandomnessActive checks if the header at the given height must have RandomnessProof
+func (i *backendIBFT) isRandomnessActive(height uint64) bool {
+	return i.randomness != nil && i.randomness.enabled && height >= i.randomness.blockNum
+}
+
+// getRandomnessSeed returns the seed for the child of the given header,
+// which is the randomness of the parent or the parent hash in the first block of the beacon
+func (i *backendIBFT) getRandomnessSeed(parent *types.Header) (types.Hash, error) {
+	if !i.isRandomnessActive(parent.Number) {
+		return parent.Hash, nil
+	}
+
+	parentSigner, err := i.forkManager.GetSigner(parent.Number)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return parentSigner.GetRandomness(parent)
+}
+
+// writeRandomnessProof puts the proof of the randomness into the header being built
+func (i *backendIBFT) writeRandomnessProof(parent, header *types.Header) (*types.Header, error) {
+	if !i.isRandomnessActive(header.Number) {
+		return header, nil
+	}
+
+	seed, err := i.getRandomnessSeed(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.currentSigner.WriteRandomnessProof(header, seed)
+}
+
+// verifyRandomnessProof verifies the proof of the randomness has been created by the proposer
+func (i *backendIBFT) verifyRandomnessProof(
+	parent, header *types.Header,
+	headerSigner signer.Signer,
+	validators validators.Validators,
+) error {
+	if !i.isRandomnessActive(header.Number) {
+		return nil
+	}
+
+	proposer, err := headerSigner.EcrecoverFromHeader(header)
+	if err != nil {
+		return err
+	}
+
+	seed, err := i.getRandomnessSeed(parent)
+	if err != nil {
+		return err
+	}
+
+	if err := headerSigner.VerifyRandomnessProof(header, validators, proposer, seed); err != nil {
+		return fmt.Errorf("invalid randomness proof: %w", err)
+	}
+
+	return nil
+}
+
+// writeRandomness stores the randomness of the header into the beacon contract
+func (i *backendIBFT) writeRandomness(header *types.Header, txn *state.Transition) error {
+	if !i.isRandomnessActive(header.Number) {
+		return nil
+	}
+
+	headerSigner, err := i.forkManager.GetSigner(header.Number)
+	if err != nil {
+		return err
+	}
+
+	value, err := headerSigner.GetRandomness(header)
+	if err != nil {
+		return err
+	}
+
+	return randomness.WriteRandomness(txn, header.Number, value)
+}
+
+// calcNextProposer returns the proposer of the given round
+// after the given header
+func (i *backendIBFT) calcNextProposer(
+	previousHeader *types.Header,
+	round uint64,
+) (validators.Validator, error) {
+	isRandomElection := i.randomness != nil &&
+		i.randomness.proposerElection &&
+		i.isRandomnessActive(previousHeader.Number)
+
+	// the proposer is picked by the voting power, from the randomness if the beacon elects the proposer
+	if i.currentVotingPowers != nil {
+		seed := previousHeader.Hash
+
+		if isRandomElection {
+			var err error
+
+			if seed, err = i.getRandomnessSeed(previousHeader); err != nil {
+				return nil, err
+			}
+		}
+
+		return CalcWeightedProposer(i.currentValidators, i.currentVotingPowers, round, seed), nil
+	}
+
+	if isRandomElection {
+		seed, err := i.getRandomnessSeed(previousHeader)
+		if err != nil {
+			return nil, err
+		}
+
+		return CalcRandomProposer(i.currentValidators, round, seed), nil
+	}
+
+	previousProposer, err := i.extractProposer(previousHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	return CalcProposer(
+		i.currentValidators,
+		round,
+		previousProposer,
+	), nil
+}
@@ -0,0 +1,130 @@
+package ibft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/fork"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRandomnessConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected *randomnessConfig
+		isErr    bool
+	}{
+		{
+			name:     "should be disabled by default",
+			config:   map[string]interface{}{},
+			expected: &randomnessConfig{},
+		},
+		{
+			name: "should enable the beacon from the given height",
+			config: map[string]interface{}{
+				fork.KeyType:              "PoA",
+				fork.KeyValidatorType:     "bls",
+				KeyRandomnessBlockNum:     float64(10),
+				KeyRandomProposerElection: true,
+			},
+			expected: &randomnessConfig{
+				enabled:          true,
+				blockNum:         10,
+				proposerElection: true,
+			},
+		},
+		{
+			name: "should not start the beacon before the minimum height",
+			config: map[string]interface{}{
+				fork.KeyType:          "PoA",
+				fork.KeyValidatorType: "bls",
+				KeyRandomnessBlockNum: float64(0),
+			},
+			expected: &randomnessConfig{
+				enabled:  true,
+				blockNum: minRandomnessBlockNum,
+			},
+		},
+		{
+			name: "should enable the beacon once the validators switch to BLS",
+			config: map[string]interface{}{
+				fork.KeyTypes: []interface{}{
+					map[string]interface{}{"type": "PoA", "validator_type": "ecdsa", "from": "0x0", "to": "0x9"},
+					map[string]interface{}{"type": "PoA", "validator_type": "bls", "from": "0xa"},
+				},
+				KeyRandomnessBlockNum: float64(10),
+			},
+			expected: &randomnessConfig{
+				enabled:  true,
+				blockNum: 10,
+			},
+		},
+		{
+			name: "should return error for ECDSA validators",
+			config: map[string]interface{}{
+				fork.KeyType:          "PoA",
+				fork.KeyValidatorType: "ecdsa",
+				KeyRandomnessBlockNum: float64(10),
+			},
+			isErr: true,
+		},
+		{
+			name: "should return error for ECDSA validators after the beacon starts",
+			config: map[string]interface{}{
+				fork.KeyTypes: []interface{}{
+					map[string]interface{}{"type": "PoA", "validator_type": "bls", "from": "0x0", "to": "0x13"},
+					map[string]interface{}{"type": "PoA", "validator_type": "ecdsa", "from": "0x14"},
+				},
+				KeyRandomnessBlockNum: float64(10),
+			},
+			isErr: true,
+		},
+		{
+			name: "should return error for invalid type",
+			config: map[string]interface{}{
+				KeyRandomnessBlockNum: "10",
+			},
+			isErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			config, err := parseRandomnessConfig(test.config)
+
+			assert.Equal(t, test.expected, config)
+			assert.Equal(t, test.isErr, err != nil)
+		})
+	}
+
+	// the randomness of the ECDSA seals could be ground by the proposers
+	_, err := parseRandomnessConfig(map[string]interface{}{
+		fork.KeyType:          "PoA",
+		KeyRandomnessBlockNum: float64(10),
+	})
+	assert.ErrorIs(t, err, errRandomnessRequiresBLS)
+}
+
+func TestCalcRandomProposer(t *testing.T) {
+	t.Parallel()
+
+	set := validators.NewECDSAValidatorSet(
+		validators.NewECDSAValidator(types.StringToAddress("1")),
+		validators.NewECDSAValidator(types.StringToAddress("2")),
+		validators.NewECDSAValidator(types.StringToAddress("3")),
+	)
+
+	randomness := types.StringToHash("0x4")
+
+	assert.Equal(t, set.At(1), CalcRandomProposer(set, 0, randomness))
+	assert.Equal(t, set.At(2), CalcRandomProposer(set, 1, randomness))
+	assert.Equal(t, set.At(0), CalcRandomProposer(set, 2, randomness))
+}
@@ -99,7 +99,11 @@ func TestSign_CommittedSeals(t *testing.T) {
 
 		assert.NoError(t, err)
 
-		return signerA.VerifyCommittedSeals(sealed, correctValSet, OptimalQuorumSize(correctValSet))
+		return signerA.VerifyCommittedSeals(
+			sealed,
+			correctValSet,
+			signer.QuorumSize(OptimalQuorumSize(correctValSet)),
+		)
 	}
 
 	// Correct
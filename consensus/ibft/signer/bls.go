@@ -137,14 +137,14 @@ func (s *BLSKeyManager) VerifyCommittedSeals(
 	rawCommittedSeal Seals,
 	message []byte,
 	vals validators.Validators,
-) (int, error) {
+) ([]types.Address, error) {
 	committedSeal, ok := rawCommittedSeal.(*AggregatedSeal)
 	if !ok {
-		return 0, ErrInvalidCommittedSealType
+		return nil, ErrInvalidCommittedSealType
 	}
 
 	if vals.Type() != s.Type() {
-		return 0, ErrInvalidValidators
+		return nil, ErrInvalidValidators
 	}
 
 	return verifyBLSCommittedSealsImpl(committedSeal, message, vals)
@@ -242,11 +242,16 @@ func getBLSSignatures(
 	return blsSignatures, bitMap, nil
 }
 
+// createAggregatedBLSPubKeys aggregates the BLS public keys of the validators in the bitmap,
+// and returns the addresses of the validators
 func createAggregatedBLSPubKeys(
 	vals validators.Validators,
 	bitMap *big.Int,
-) (*bls_sig.MultiPublicKey, int, error) {
-	pubkeys := make([]*bls_sig.PublicKey, 0, vals.Len())
+) (*bls_sig.MultiPublicKey, []types.Address, error) {
+	var (
+		pubkeys = make([]*bls_sig.PublicKey, 0, vals.Len())
+		signers = make([]types.Address, 0, vals.Len())
+	)
 
 	for idx := 0; idx < vals.Len(); idx++ {
 		if bitMap.Bit(idx) == 0 {
@@ -255,59 +260,60 @@ func createAggregatedBLSPubKeys(
 
 		validator := vals.At(uint64(idx))
 		if validator == nil {
-			return nil, 0, ErrValidatorNotFound
+			return nil, nil, ErrValidatorNotFound
 		}
 
 		blsValidator, ok := validator.(*validators.BLSValidator)
 		if !ok {
-			return nil, 0, ErrInvalidValidator
+			return nil, nil, ErrInvalidValidator
 		}
 
 		pubKey, err := crypto.UnmarshalBLSPublicKey(blsValidator.BLSPublicKey)
 		if err != nil {
-			return nil, 0, err
+			return nil, nil, err
 		}
 
 		pubkeys = append(pubkeys, pubKey)
+		signers = append(signers, blsValidator.Addr())
 	}
 
 	key, err := bls_sig.NewSigPop().AggregatePublicKeys(pubkeys...)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	return key, len(pubkeys), nil
+	return key, signers, nil
 }
 
 func verifyBLSCommittedSealsImpl(
 	committedSeal *AggregatedSeal,
 	msg []byte,
 	vals validators.Validators,
-) (int, error) {
+) ([]types.Address, error) {
 	if len(committedSeal.Signature) == 0 ||
 		committedSeal.Bitmap == nil ||
 		committedSeal.Bitmap.BitLen() == 0 {
-		return 0, ErrEmptyCommittedSeals
+		return nil, ErrEmptyCommittedSeals
 	}
 
-	aggregatedPubKey, numKeys, err := createAggregatedBLSPubKeys(vals, committedSeal.Bitmap)
+	aggregatedPubKey, signers, err := createAggregatedBLSPubKeys(vals, committedSeal.Bitmap)
 	if err != nil {
-		return 0, fmt.Errorf("failed to aggregate BLS Public Keys: %w", err)
+		return nil, fmt.Errorf("failed to aggregate BLS Public Keys: %w", err)
 	}
 
 	signature := &bls_sig.MultiSignature{}
 	if err := signature.UnmarshalBinary(committedSeal.Signature); err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	ok, err := bls_sig.NewSigPop().VerifyMultiSignature(aggregatedPubKey, msg, signature)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	if !ok {
-		return 0, ErrInvalidSignature
+		return nil, ErrInvalidSignature
 	}
 
-	return numKeys, nil
+	return signers, nil
 }
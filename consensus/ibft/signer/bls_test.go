@@ -531,7 +531,7 @@ func TestBLSKeyManagerVerifyCommittedSeals(t *testing.T) {
 		rawCommittedSeals Seals
 		hash              []byte
 		validators        validators.Validators
-		expectedRes       int
+		expectedRes       []types.Address
 		expectedErr       error
 	}{
 		{
@@ -539,7 +539,7 @@ func TestBLSKeyManagerVerifyCommittedSeals(t *testing.T) {
 			rawCommittedSeals: &SerializedSeal{},
 			hash:              nil,
 			validators:        nil,
-			expectedRes:       0,
+			expectedRes:       nil,
 			expectedErr:       ErrInvalidCommittedSealType,
 		},
 		{
@@ -549,11 +549,11 @@ func TestBLSKeyManagerVerifyCommittedSeals(t *testing.T) {
 				Signature: aggregatedBLSSigBytes,
 			},
 			validators:  validators.NewECDSAValidatorSet(),
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: ErrInvalidValidators,
 		},
 		{
-			name: "should return size of AggregatedSeal if it's successful",
+			name: "should return signers of AggregatedSeal if it's successful",
 			rawCommittedSeals: &AggregatedSeal{
 				Bitmap:    big.NewInt(0).SetBit(new(big.Int), 0, 1),
 				Signature: aggregatedBLSSigBytes,
@@ -564,7 +564,7 @@ func TestBLSKeyManagerVerifyCommittedSeals(t *testing.T) {
 					blsKeyManager1,
 				),
 			),
-			expectedRes: 1,
+			expectedRes: []types.Address{blsKeyManager1.Address()},
 			expectedErr: nil,
 		},
 	}
@@ -800,7 +800,7 @@ func Test_createAggregatedBLSPubKeys(t *testing.T) {
 		bitMap := new(big.Int)
 
 		expectedBLSPublicKeys := []*bls_sig.PublicKey{}
-		expectedNumSigners := 0
+		expectedSigners := []types.Address{}
 
 		for idx, signed := range signerFlags {
 			blsKeyManager, _, blsSecretKey := newTestBLSKeyManager(t)
@@ -827,7 +827,7 @@ func Test_createAggregatedBLSPubKeys(t *testing.T) {
 			assert.NoError(t, err)
 
 			expectedBLSPublicKeys = append(expectedBLSPublicKeys, blsPubKey)
-			expectedNumSigners++
+			expectedSigners = append(expectedSigners, blsKeyManager.Address())
 		}
 
 		expectedAggregatedBLSPublicKeys, err := bls_sig.NewSigPop().AggregatePublicKeys(
@@ -835,13 +835,13 @@ func Test_createAggregatedBLSPubKeys(t *testing.T) {
 		)
 		assert.NoError(t, err)
 
-		aggregatedPubKey, num, err := createAggregatedBLSPubKeys(
+		aggregatedPubKey, signers, err := createAggregatedBLSPubKeys(
 			validators,
 			bitMap,
 		)
 
 		assert.NoError(t, err)
-		assert.Equal(t, expectedNumSigners, num)
+		assert.Equal(t, expectedSigners, signers)
 
 		assertEqualAggregatedBLSPublicKeys(t, expectedAggregatedBLSPublicKeys, aggregatedPubKey)
 	})
@@ -849,13 +849,13 @@ func Test_createAggregatedBLSPubKeys(t *testing.T) {
 	t.Run("should return error if bitMap is empty", func(t *testing.T) {
 		t.Parallel()
 
-		aggrecatedPubKeys, num, err := createAggregatedBLSPubKeys(
+		aggrecatedPubKeys, signers, err := createAggregatedBLSPubKeys(
 			validators.NewBLSValidatorSet(),
 			new(big.Int),
 		)
 
 		assert.Nil(t, aggrecatedPubKeys)
-		assert.Zero(t, num)
+		assert.Empty(t, signers)
 		assert.ErrorContains(t, err, "at least one public key is required")
 	})
 
@@ -873,7 +873,7 @@ func Test_createAggregatedBLSPubKeys(t *testing.T) {
 		)
 
 		assert.Nil(t, aggrecatedPubKeys)
-		assert.Zero(t, num)
+		assert.Empty(t, signers)
 		assert.ErrorContains(t, err, "public key must be 48 bytes")
 	})
 }
@@ -911,7 +911,7 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 		committedSeal *AggregatedSeal
 		msg           []byte
 		validators    validators.Validators
-		expectedRes   int
+		expectedRes   []types.Address
 		expectedErr   error
 	}{
 		{
@@ -920,7 +920,7 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 				Signature: []byte{},
 				Bitmap:    new(big.Int).SetBit(new(big.Int), 0, 1),
 			},
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: ErrEmptyCommittedSeals,
 		},
 		{
@@ -929,7 +929,7 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 				Signature: []byte("test"),
 				Bitmap:    nil,
 			},
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: ErrEmptyCommittedSeals,
 		},
 		{
@@ -938,7 +938,7 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 				Signature: []byte("test"),
 				Bitmap:    new(big.Int),
 			},
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: ErrEmptyCommittedSeals,
 		},
 		{
@@ -952,7 +952,7 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 					BLSPublicKey: []byte("test"),
 				},
 			),
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: errors.New("failed to aggregate BLS Public Keys: public key must be 48 bytes"),
 		},
 		{
@@ -965,7 +965,7 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 				testBLSKeyManagerToBLSValidator(t, validatorKeyManager1),
 				testBLSKeyManagerToBLSValidator(t, validatorKeyManager2),
 			),
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: errors.New("multi signature must be 96 bytes"),
 		},
 		{
@@ -979,7 +979,7 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 				testBLSKeyManagerToBLSValidator(t, validatorKeyManager2),
 			),
 			msg:         nil,
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: errors.New("signature and message and public key cannot be nil or zero"),
 		},
 		{
@@ -993,7 +993,7 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 				testBLSKeyManagerToBLSValidator(t, validatorKeyManager2),
 			),
 			msg:         msg,
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: ErrInvalidSignature,
 		},
 		{
@@ -1007,7 +1007,7 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 				testBLSKeyManagerToBLSValidator(t, validatorKeyManager4),
 			),
 			msg:         msg,
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: ErrInvalidSignature,
 		},
 		{
@@ -1020,7 +1020,7 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 				testBLSKeyManagerToBLSValidator(t, validatorKeyManager1),
 			),
 			msg:         msg,
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: ErrInvalidSignature,
 		},
 		{
@@ -1035,7 +1035,7 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 				testBLSKeyManagerToBLSValidator(t, validatorKeyManager3),
 			),
 			msg:         msg,
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: ErrInvalidSignature,
 		},
 		{
@@ -1048,8 +1048,11 @@ func Test_verifyBLSCommittedSealsImpl(t *testing.T) {
 				testBLSKeyManagerToBLSValidator(t, validatorKeyManager1),
 				testBLSKeyManagerToBLSValidator(t, validatorKeyManager2),
 			),
-			msg:         msg,
-			expectedRes: 2,
+			msg: msg,
+			expectedRes: []types.Address{
+				validatorKeyManager1.Address(),
+				validatorKeyManager2.Address(),
+			},
 			expectedErr: nil,
 		},
 	}
@@ -116,14 +116,14 @@ func (s *ECDSAKeyManager) VerifyCommittedSeals(
 	rawCommittedSeal Seals,
 	digest []byte,
 	vals validators.Validators,
-) (int, error) {
+) ([]types.Address, error) {
 	committedSeal, ok := rawCommittedSeal.(*SerializedSeal)
 	if !ok {
-		return 0, ErrInvalidCommittedSealType
+		return nil, ErrInvalidCommittedSealType
 	}
 
 	if vals.Type() != s.Type() {
-		return 0, ErrInvalidValidators
+		return nil, ErrInvalidValidators
 	}
 
 	return s.verifyCommittedSealsImpl(committedSeal, digest, vals)
@@ -141,32 +141,34 @@ func (s *ECDSAKeyManager) verifyCommittedSealsImpl(
 	committedSeal *SerializedSeal,
 	msg []byte,
 	validators validators.Validators,
-) (int, error) {
+) ([]types.Address, error) {
 	numSeals := committedSeal.Num()
 	if numSeals == 0 {
-		return 0, ErrEmptyCommittedSeals
+		return nil, ErrEmptyCommittedSeals
 	}
 
+	signers := make([]types.Address, 0, numSeals)
 	visited := make(map[types.Address]bool)
 
 	for _, seal := range *committedSeal {
 		addr, err := s.Ecrecover(seal, msg)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
 
 		if visited[addr] {
-			return 0, ErrRepeatedCommittedSeal
+			return nil, ErrRepeatedCommittedSeal
 		}
 
 		if !validators.Includes(addr) {
-			return 0, ErrNonValidatorCommittedSeal
+			return nil, ErrNonValidatorCommittedSeal
 		}
 
 		visited[addr] = true
+		signers = append(signers, addr)
 	}
 
-	return numSeals, nil
+	return signers, nil
 }
 
 type SerializedSeal [][]byte
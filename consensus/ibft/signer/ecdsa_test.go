@@ -371,7 +371,7 @@ func TestECDSAKeyManagerVerifyCommittedSeals(t *testing.T) {
 		committedSeals Seals
 		digest         []byte
 		rawSet         validators.Validators
-		expectedRes    int
+		expectedRes    []types.Address
 		expectedErr    error
 	}{
 		{
@@ -379,7 +379,7 @@ func TestECDSAKeyManagerVerifyCommittedSeals(t *testing.T) {
 			committedSeals: &AggregatedSeal{},
 			digest:         msg,
 			rawSet:         nil,
-			expectedRes:    0,
+			expectedRes:    nil,
 			expectedErr:    ErrInvalidCommittedSealType,
 		},
 		{
@@ -387,11 +387,11 @@ func TestECDSAKeyManagerVerifyCommittedSeals(t *testing.T) {
 			committedSeals: &SerializedSeal{},
 			digest:         msg,
 			rawSet:         validators.NewBLSValidatorSet(),
-			expectedRes:    0,
+			expectedRes:    nil,
 			expectedErr:    ErrInvalidValidators,
 		},
 		{
-			name: "should return size of CommittedSeals if verification is successful",
+			name: "should return signers of CommittedSeals if verification is successful",
 			committedSeals: &SerializedSeal{
 				correctCommittedSeal,
 			},
@@ -401,7 +401,7 @@ func TestECDSAKeyManagerVerifyCommittedSeals(t *testing.T) {
 					ecdsaKeyManager1.Address(),
 				),
 			),
-			expectedRes: 1,
+			expectedRes: []types.Address{ecdsaKeyManager1.Address()},
 			expectedErr: nil,
 		},
 	}
@@ -468,7 +468,7 @@ func TestECDSAKeyManager_verifyCommittedSealsImpl(t *testing.T) {
 		committedSeals *SerializedSeal
 		msg            []byte
 		validators     validators.Validators
-		expectedRes    int
+		expectedRes    []types.Address
 		expectedErr    error
 	}{
 		{
@@ -476,7 +476,7 @@ func TestECDSAKeyManager_verifyCommittedSealsImpl(t *testing.T) {
 			committedSeals: &SerializedSeal{},
 			msg:            msg,
 			validators:     validators.NewECDSAValidatorSet(),
-			expectedRes:    0,
+			expectedRes:    nil,
 			expectedErr:    ErrEmptyCommittedSeals,
 		},
 		{
@@ -486,7 +486,7 @@ func TestECDSAKeyManager_verifyCommittedSealsImpl(t *testing.T) {
 			},
 			msg:         msg,
 			validators:  validators.NewECDSAValidatorSet(),
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: errors.New("invalid compact signature size"),
 		},
 		{
@@ -501,7 +501,7 @@ func TestECDSAKeyManager_verifyCommittedSealsImpl(t *testing.T) {
 					ecdsaKeyManager1.Address(),
 				),
 			),
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: ErrRepeatedCommittedSeal,
 		},
 		{
@@ -516,11 +516,11 @@ func TestECDSAKeyManager_verifyCommittedSealsImpl(t *testing.T) {
 					ecdsaKeyManager1.Address(),
 				),
 			),
-			expectedRes: 0,
+			expectedRes: nil,
 			expectedErr: ErrNonValidatorCommittedSeal,
 		},
 		{
-			name: "should return the size of CommittedSeals if verification is successful",
+			name: "should return the signers of CommittedSeals if verification is successful",
 			committedSeals: &SerializedSeal{
 				correctCommittedSeal,
 			},
@@ -530,7 +530,7 @@ func TestECDSAKeyManager_verifyCommittedSealsImpl(t *testing.T) {
 					ecdsaKeyManager1.Address(),
 				),
 			),
-			expectedRes: 1,
+			expectedRes: []types.Address{ecdsaKeyManager1.Address()},
 			expectedErr: nil,
 		},
 	}
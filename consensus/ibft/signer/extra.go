@@ -28,6 +28,7 @@ type IstanbulExtra struct {
 	ProposerSeal         []byte
 	CommittedSeals       Seals
 	ParentCommittedSeals Seals
+	RandomnessProof      []byte
 }
 
 type Seals interface {
@@ -62,6 +63,11 @@ func (i *IstanbulExtra) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
 	// ParentCommittedSeal
 	if i.ParentCommittedSeals != nil {
 		vv.Set(i.ParentCommittedSeals.MarshalRLPWith(ar))
+
+		// RandomnessProof can only follow ParentCommittedSeals
+		if len(i.RandomnessProof) > 0 {
+			vv.Set(ar.NewCopyBytes(i.RandomnessProof))
+		}
 	}
 
 	return vv
@@ -105,6 +111,13 @@ func (i *IstanbulExtra) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) er
 		}
 	}
 
+	// RandomnessProof
+	if len(elems) >= 5 {
+		if i.RandomnessProof, err = elems[4].GetBytes(i.RandomnessProof[:0]); err != nil {
+			return fmt.Errorf("failed to decode RandomnessProof: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -208,9 +221,9 @@ func packProposerSealIntoExtra(
 			// CommittedSeal
 			newArrayValue.Set(oldValues[2])
 
-			// ParentCommittedSeal
-			if len(oldValues) >= 4 {
-				newArrayValue.Set(oldValues[3])
+			// ParentCommittedSeal and RandomnessProof
+			for _, oldValue := range oldValues[3:] {
+				newArrayValue.Set(oldValue)
 			}
 
 			return nil
@@ -239,11 +252,40 @@ func packCommittedSealsIntoExtra(
 			// CommittedSeal
 			newArrayValue.Set(committedSeal.MarshalRLPWith(ar))
 
-			// ParentCommittedSeal
-			if len(oldValues) >= 4 {
-				newArrayValue.Set(oldValues[3])
+			// ParentCommittedSeal and RandomnessProof
+			for _, oldValue := range oldValues[3:] {
+				newArrayValue.Set(oldValue)
+			}
+
+			return nil
+		},
+	)
+}
+
+// packRandomnessProofIntoExtra updates only RandomnessProof field in Extra
+func packRandomnessProofIntoExtra(
+	extraBytes []byte,
+	proof []byte,
+) []byte {
+	return packFieldsIntoExtra(
+		extraBytes,
+		func(
+			ar *fastrlp.Arena,
+			oldValues []*fastrlp.Value,
+			newArrayValue *fastrlp.Value,
+		) error {
+			if len(oldValues) < 4 {
+				return ErrEmptyParentCommittedSeals
 			}
 
+			// Validators, Seal, CommittedSeal, and ParentCommittedSeal
+			for _, oldValue := range oldValues[:4] {
+				newArrayValue.Set(oldValue)
+			}
+
+			// RandomnessProof
+			newArrayValue.Set(ar.NewBytes(proof))
+
 			return nil
 		},
 	)
@@ -2,6 +2,7 @@ package signer
 
 import (
 	"crypto/ecdsa"
+	"encoding/binary"
 	"fmt"
 	"testing"
 
@@ -22,11 +23,24 @@ const (
 	legacyCommitCode = 2
 )
 
+var (
+	// randomnessDomain separates the digest of RandomnessProof from the other signed digests
+	randomnessDomain = []byte("ibft-randomness")
+)
+
 // wrapCommitHash calculates digest for CommittedSeal
 func wrapCommitHash(data []byte) []byte {
 	return crypto.Keccak256(data, []byte{byte(legacyCommitCode)})
 }
 
+// wrapRandomnessSeed calculates digest for RandomnessProof
+func wrapRandomnessSeed(height uint64, seed types.Hash) []byte {
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, height)
+
+	return crypto.Keccak256(randomnessDomain, heightBytes, seed.Bytes())
+}
+
 // getOrCreateECDSAKey loads ECDSA key or creates a new key
 func getOrCreateECDSAKey(manager secrets.SecretsManager) (*ecdsa.PrivateKey, error) {
 	if !manager.HasSecret(secrets.ValidatorKey) {
@@ -79,11 +93,23 @@ func calculateHeaderHash(h *types.Header) types.Hash {
 	vv.Set(arena.NewUint(h.Timestamp))
 	vv.Set(arena.NewCopyBytes(h.ExtraData))
 
+	// the blob gas, only set once the blob transactions are enabled
+	if h.BlobGasUsed != nil && h.ExcessBlobGas != nil {
+		vv.Set(arena.NewUint(*h.BlobGasUsed))
+		vv.Set(arena.NewUint(*h.ExcessBlobGas))
+	}
+
 	buf := keccak.Keccak256Rlp(nil, vv)
 
 	return types.BytesToHash(buf)
 }
 
+// RecoverIBFTMessageSigner recovers the signer address of an IBFT message,
+// the messages are signed with the ECDSA key for every validator type
+func RecoverIBFTMessageSigner(signature, msgNoSig []byte) (types.Address, error) {
+	return ecrecover(signature, crypto.Keccak256(msgNoSig))
+}
+
 // ecrecover recovers signer address from the given digest and signature
 func ecrecover(sig, msg []byte) (types.Address, error) {
 	pub, err := crypto.RecoverPubkey(sig, msg)
@@ -109,6 +135,19 @@ func NewKeyManagerFromType(
 	}
 }
 
+// NewKeylessKeyManager creates KeyManager of the given type holding no key,
+// it can only decode IBFT Extra and calculate header hashes
+func NewKeylessKeyManager(validatorType validators.ValidatorType) (KeyManager, error) {
+	switch validatorType {
+	case validators.ECDSAValidatorType:
+		return &ECDSAKeyManager{}, nil
+	case validators.BLSValidatorType:
+		return &BLSKeyManager{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported validator type: %s", validatorType)
+	}
+}
+
 // verifyIBFTExtraSize checks whether header.ExtraData has enough size for IBFT Extra
 func verifyIBFTExtraSize(header *types.Header) error {
 	if len(header.ExtraData) < IstanbulExtraVanity {
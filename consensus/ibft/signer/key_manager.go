@@ -23,8 +23,8 @@ type KeyManager interface {
 	VerifyCommittedSeal(vals validators.Validators, signer types.Address, sig, hash []byte) error
 	// GenerateCommittedSeals creates CommittedSeals from committed seals
 	GenerateCommittedSeals(sealsByValidator map[types.Address][]byte, vals validators.Validators) (Seals, error)
-	// VerifyCommittedSeals verifies CommittedSeals
-	VerifyCommittedSeals(seals Seals, hash []byte, vals validators.Validators) (int, error)
+	// VerifyCommittedSeals verifies CommittedSeals and returns the validators who signed them
+	VerifyCommittedSeals(seals Seals, hash []byte, vals validators.Validators) ([]types.Address, error)
 	// SignIBFTMessage signs for arbitrary bytes message
 	SignIBFTMessage(msg []byte) ([]byte, error)
 	// Ecrecover recovers address from signature and message
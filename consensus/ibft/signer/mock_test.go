@@ -36,7 +36,7 @@ type MockKeyManager struct {
 	SignCommittedSealFunc      func([]byte) ([]byte, error)
 	VerifyCommittedSealFunc    func(validators.Validators, types.Address, []byte, []byte) error
 	GenerateCommittedSealsFunc func(map[types.Address][]byte, validators.Validators) (Seals, error)
-	VerifyCommittedSealsFunc   func(Seals, []byte, validators.Validators) (int, error)
+	VerifyCommittedSealsFunc   func(Seals, []byte, validators.Validators) ([]types.Address, error)
 	SignIBFTMessageFunc        func([]byte) ([]byte, error)
 	EcrecoverFunc              func([]byte, []byte) (types.Address, error)
 }
@@ -75,7 +75,11 @@ func (m *MockKeyManager) GenerateCommittedSeals(
 	return m.GenerateCommittedSealsFunc(sealsByValidator, vals)
 }
 
-func (m *MockKeyManager) VerifyCommittedSeals(seals Seals, hash []byte, vals validators.Validators) (int, error) {
+func (m *MockKeyManager) VerifyCommittedSeals(
+	seals Seals,
+	hash []byte,
+	vals validators.Validators,
+) ([]types.Address, error) {
 	return m.VerifyCommittedSealsFunc(seals, hash, vals)
 }
 
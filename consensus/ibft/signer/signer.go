@@ -21,8 +21,19 @@ var (
 	ErrInvalidValidators          = errors.New("invalid validators type")
 	ErrInvalidValidator           = errors.New("invalid validator type")
 	ErrInvalidSignature           = errors.New("invalid signature")
+	ErrEmptyRandomnessProof       = errors.New("empty randomness proof")
 )
 
+// Quorum checks if the validators who signed the committed seals reach the quorum
+type Quorum func(signers []types.Address) bool
+
+// QuorumSize returns the quorum reached by the given number of signers
+func QuorumSize(size int) Quorum {
+	return func(signers []types.Address) bool {
+		return len(signers) >= size
+	}
+}
+
 // Signer is responsible for signing for blocks and messages in IBFT
 type Signer interface {
 	Type() validators.ValidatorType
@@ -46,17 +57,27 @@ type Signer interface {
 	VerifyCommittedSeals(
 		header *types.Header,
 		validators validators.Validators,
-		quorumSize int,
+		quorum Quorum,
 	) error
 
 	// ParentCommittedSeals
 	VerifyParentCommittedSeals(
 		parent, header *types.Header,
 		parentValidators validators.Validators,
-		quorum int,
+		quorum Quorum,
 		mustExist bool,
 	) error
 
+	// RandomnessProof
+	WriteRandomnessProof(*types.Header, types.Hash) (*types.Header, error)
+	VerifyRandomnessProof(
+		header *types.Header,
+		validators validators.Validators,
+		proposer types.Address,
+		seed types.Hash,
+	) error
+	GetRandomness(*types.Header) (types.Hash, error)
+
 	// IBFTMessage
 	SignIBFTMessage([]byte) ([]byte, error)
 	EcrecoverFromIBFTMessage([]byte, []byte) (types.Address, error)
@@ -222,7 +243,7 @@ func (s *SignerImpl) WriteCommittedSeals(
 func (s *SignerImpl) VerifyCommittedSeals(
 	header *types.Header,
 	validators validators.Validators,
-	quorumSize int,
+	quorum Quorum,
 ) error {
 	extra, err := s.GetIBFTExtra(header)
 	if err != nil {
@@ -238,7 +259,7 @@ func (s *SignerImpl) VerifyCommittedSeals(
 		wrapCommitHash(hash[:]),
 	)
 
-	numSeals, err := s.keyManager.VerifyCommittedSeals(
+	signers, err := s.keyManager.VerifyCommittedSeals(
 		extra.CommittedSeals,
 		rawMsg,
 		validators,
@@ -247,7 +268,7 @@ func (s *SignerImpl) VerifyCommittedSeals(
 		return err
 	}
 
-	if numSeals < quorumSize {
+	if !quorum(signers) {
 		return ErrNotEnoughCommittedSeals
 	}
 
@@ -258,7 +279,7 @@ func (s *SignerImpl) VerifyCommittedSeals(
 func (s *SignerImpl) VerifyParentCommittedSeals(
 	parent, header *types.Header,
 	parentValidators validators.Validators,
-	quorum int,
+	quorum Quorum,
 	mustExist bool,
 ) error {
 	parentCommittedSeals, err := s.GetParentCommittedSeals(header)
@@ -281,7 +302,7 @@ func (s *SignerImpl) VerifyParentCommittedSeals(
 		wrapCommitHash(parent.Hash.Bytes()),
 	)
 
-	numSeals, err := s.keyManager.VerifyCommittedSeals(
+	signers, err := s.keyManager.VerifyCommittedSeals(
 		parentCommittedSeals,
 		rawMsg,
 		parentValidators,
@@ -290,13 +311,82 @@ func (s *SignerImpl) VerifyParentCommittedSeals(
 		return err
 	}
 
-	if numSeals < quorum {
+	if !quorum(signers) {
 		return ErrNotEnoughCommittedSeals
 	}
 
 	return nil
 }
 
+// WriteRandomnessProof signs the randomness seed of the header height
+// and sets the proof into IBFT Extra of the header
+func (s *SignerImpl) WriteRandomnessProof(header *types.Header, seed types.Hash) (*types.Header, error) {
+	parentCommittedSeals, err := s.GetParentCommittedSeals(header)
+	if err != nil {
+		return nil, err
+	}
+
+	// RandomnessProof is put after ParentCommittedSeals in IBFT Extra
+	if parentCommittedSeals == nil || parentCommittedSeals.Num() == 0 {
+		return nil, ErrEmptyParentCommittedSeals
+	}
+
+	// Proof is created in the same way as committed seal, the signature is
+	// deterministic for BLS keys so the proposer can't choose the randomness
+	proof, err := s.keyManager.SignCommittedSeal(
+		wrapRandomnessSeed(header.Number, seed),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	header.ExtraData = packRandomnessProofIntoExtra(
+		header.ExtraData,
+		proof,
+	)
+
+	return header, nil
+}
+
+// VerifyRandomnessProof verifies RandomnessProof in IBFT Extra of the header
+// has been created by the proposer from the given seed
+func (s *SignerImpl) VerifyRandomnessProof(
+	header *types.Header,
+	validators validators.Validators,
+	proposer types.Address,
+	seed types.Hash,
+) error {
+	extra, err := s.GetIBFTExtra(header)
+	if err != nil {
+		return err
+	}
+
+	if len(extra.RandomnessProof) == 0 {
+		return ErrEmptyRandomnessProof
+	}
+
+	return s.keyManager.VerifyCommittedSeal(
+		validators,
+		proposer,
+		extra.RandomnessProof,
+		wrapRandomnessSeed(header.Number, seed),
+	)
+}
+
+// GetRandomness returns the randomness derived from RandomnessProof in IBFT Extra of the header
+func (s *SignerImpl) GetRandomness(header *types.Header) (types.Hash, error) {
+	extra, err := s.GetIBFTExtra(header)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if len(extra.RandomnessProof) == 0 {
+		return types.ZeroHash, ErrEmptyRandomnessProof
+	}
+
+	return types.BytesToHash(crypto.Keccak256(extra.RandomnessProof)), nil
+}
+
 // SignIBFTMessage signs arbitrary message
 func (s *SignerImpl) SignIBFTMessage(msg []byte) ([]byte, error) {
 	return s.keyManager.SignIBFTMessage(crypto.Keccak256(msg))
@@ -1,6 +1,7 @@
 package signer
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
@@ -933,12 +934,12 @@ func TestSignerVerifyCommittedSeals(t *testing.T) {
 				NewEmptyCommittedSealsFunc: func() Seals {
 					return &SerializedSeal{}
 				},
-				VerifyCommittedSealsFunc: func(s Seals, b []byte, v validators.Validators) (int, error) {
+				VerifyCommittedSealsFunc: func(s Seals, b []byte, v validators.Validators) ([]types.Address, error) {
 					assert.Equal(t, testSerializedSeals1, s)
 					assert.Equal(t, ecdsaValidators, v)
 					assert.Equal(t, expectedSig, b)
 
-					return test.verifyCommittedSealsRes, test.verifyCommittedSealsErr
+					return make([]types.Address, test.verifyCommittedSealsRes), test.verifyCommittedSealsErr
 				},
 			})
 
@@ -953,7 +954,7 @@ func TestSignerVerifyCommittedSeals(t *testing.T) {
 			testHelper.AssertErrorMessageContains(
 				t,
 				test.expectedErr,
-				signer.VerifyCommittedSeals(test.header, test.validators, test.quorumSize),
+				signer.VerifyCommittedSeals(test.header, test.validators, QuorumSize(test.quorumSize)),
 			)
 		})
 	}
@@ -1115,12 +1116,12 @@ func TestSignerVerifyParentCommittedSeals(t *testing.T) {
 				NewEmptyCommittedSealsFunc: func() Seals {
 					return &SerializedSeal{}
 				},
-				VerifyCommittedSealsFunc: func(s Seals, b []byte, v validators.Validators) (int, error) {
+				VerifyCommittedSealsFunc: func(s Seals, b []byte, v validators.Validators) ([]types.Address, error) {
 					assert.Equal(t, testSerializedSeals2, s)
 					assert.Equal(t, ecdsaValidators, v)
 					assert.Equal(t, expectedSig, b)
 
-					return test.verifyCommittedSealsRes, test.verifyCommittedSealsErr
+					return make([]types.Address, test.verifyCommittedSealsRes), test.verifyCommittedSealsErr
 				},
 			})
 
@@ -1131,7 +1132,7 @@ func TestSignerVerifyParentCommittedSeals(t *testing.T) {
 					test.parentHeader,
 					test.header,
 					test.parentValidators,
-					test.quorumSize,
+					QuorumSize(test.quorumSize),
 					test.mustExist,
 				),
 			)
@@ -1246,3 +1247,114 @@ func TestSignerSignIBFTMessageAndEcrecoverFromIBFTMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestSignerWriteRandomnessProof(t *testing.T) {
+	testProof := crypto.Keccak256([]byte{0x2})
+	seed := types.StringToHash("0x3")
+
+	newTestSigner := func() *SignerImpl {
+		keyManager := &MockKeyManager{
+			NewEmptyValidatorsFunc: func() validators.Validators {
+				return ecdsaValidators
+			},
+			NewEmptyCommittedSealsFunc: func() Seals {
+				return &SerializedSeal{}
+			},
+			SignCommittedSealFunc: func(b []byte) ([]byte, error) {
+				assert.Equal(t, wrapRandomnessSeed(2, seed), b)
+
+				return testProof, nil
+			},
+			VerifyCommittedSealFunc: func(
+				vals validators.Validators,
+				signer types.Address,
+				sig, hash []byte,
+			) error {
+				assert.Equal(t, testAddr1, signer)
+				assert.Equal(t, wrapRandomnessSeed(2, seed), hash)
+
+				if !bytes.Equal(testProof, sig) {
+					return ErrInvalidSignature
+				}
+
+				return nil
+			},
+		}
+
+		return NewSigner(keyManager, keyManager)
+	}
+
+	t.Run("should return error if ParentCommittedSeals is empty", func(t *testing.T) {
+		signer := newTestSigner()
+
+		header, err := signer.WriteRandomnessProof(&types.Header{
+			Number: 2,
+			ExtraData: getTestExtraBytes(
+				ecdsaValidators,
+				testProposerSeal,
+				testSerializedSeals1,
+				nil,
+			),
+		}, seed)
+
+		assert.Nil(t, header)
+		assert.ErrorIs(t, err, ErrEmptyParentCommittedSeals)
+	})
+
+	t.Run("should write and verify RandomnessProof", func(t *testing.T) {
+		signer := newTestSigner()
+
+		header, err := signer.WriteRandomnessProof(&types.Header{
+			Number: 2,
+			ExtraData: getTestExtraBytes(
+				ecdsaValidators,
+				testProposerSeal,
+				testSerializedSeals1,
+				testSerializedSeals2,
+			),
+		}, seed)
+		assert.NoError(t, err)
+
+		extra, err := signer.GetIBFTExtra(header)
+		assert.NoError(t, err)
+		assert.Equal(t, testProof, extra.RandomnessProof)
+		assert.Equal(t, testProposerSeal, extra.ProposerSeal)
+		assert.Equal(t, testSerializedSeals2, extra.ParentCommittedSeals)
+
+		assert.NoError(t, signer.VerifyRandomnessProof(header, ecdsaValidators, testAddr1, seed))
+
+		randomness, err := signer.GetRandomness(header)
+		assert.NoError(t, err)
+		assert.Equal(t, types.BytesToHash(crypto.Keccak256(testProof)), randomness)
+
+		// RandomnessProof is kept after writing CommittedSeals
+		header.ExtraData = packCommittedSealsIntoExtra(header.ExtraData, testSerializedSeals1)
+
+		extra, err = signer.GetIBFTExtra(header)
+		assert.NoError(t, err)
+		assert.Equal(t, testProof, extra.RandomnessProof)
+	})
+
+	t.Run("should return error if RandomnessProof doesn't exist", func(t *testing.T) {
+		signer := newTestSigner()
+
+		header := &types.Header{
+			Number: 2,
+			ExtraData: getTestExtraBytes(
+				ecdsaValidators,
+				testProposerSeal,
+				testSerializedSeals1,
+				testSerializedSeals2,
+			),
+		}
+
+		assert.ErrorIs(
+			t,
+			signer.VerifyRandomnessProof(header, ecdsaValidators, testAddr1, seed),
+			ErrEmptyRandomnessProof,
+		)
+
+		_, err := signer.GetRandomness(header)
+		assert.ErrorIs(t, err, ErrEmptyRandomnessProof)
+	})
+}
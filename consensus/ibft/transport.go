@@ -1,12 +1,22 @@
 package ibft
 
 import (
+	"bytes"
+	"errors"
+
 	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+var (
+	errInvalidMessageType = errors.New("invalid message type")
+	errMissingView        = errors.New("message view is not set")
+	errInvalidSignature   = errors.New("message not signed by the sender")
+)
+
 type transport interface {
 	Multicast(msg *proto.Message) error
 }
@@ -35,7 +45,7 @@ func (i *backendIBFT) setupTransport() error {
 
 	// Subscribe to the newly created topic
 	if err := topic.Subscribe(
-		func(obj interface{}, _ peer.ID) {
+		func(obj interface{}, from peer.ID) {
 			if !i.isActiveValidator() {
 				return
 			}
@@ -47,6 +57,19 @@ func (i *backendIBFT) setupTransport() error {
 				return
 			}
 
+			// the messages of the shared key published by another node are the partner's
+			if i.failover != nil && from != i.network.AddrInfo().ID && bytes.Equal(msg.From, i.ID()) {
+				if i.IsValidSender(msg) {
+					i.failover.observeMessage(msg.GetView().GetHeight())
+				}
+
+				return
+			}
+
+			if i.commits != nil {
+				i.commits.add(msg)
+			}
+
 			i.consensus.AddMessage(msg)
 
 			i.logger.Debug(
@@ -61,7 +84,40 @@ func (i *backendIBFT) setupTransport() error {
 		return err
 	}
 
-	i.transport = &gossipTransport{topic: topic}
+	topic.SetValidator(validateGossipMessage)
+
+	if i.transport, err = i.wrapTransport(&gossipTransport{topic: topic}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateGossipMessage checks the message is signed by its sender before it's relayed.
+// The validator set isn't checked, the node may not know the one of the message height yet
+func validateGossipMessage(obj interface{}, _ peer.ID) error {
+	msg, ok := obj.(*proto.Message)
+	if !ok {
+		return errInvalidMessageType
+	}
+
+	if msg.View == nil {
+		return errMissingView
+	}
+
+	msgNoSig, err := msg.PayloadNoSig()
+	if err != nil {
+		return err
+	}
+
+	signerAddress, err := signer.RecoverIBFTMessageSigner(msg.Signature, msgNoSig)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(msg.From, signerAddress.Bytes()) {
+		return errInvalidSignature
+	}
 
 	return nil
 }
@@ -0,0 +1,50 @@
+package ibft
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGossipMessage(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	newSignedMessage := func() *proto.Message {
+		msg := &proto.Message{
+			View: &proto.View{Height: 1, Round: 0},
+			From: crypto.PubKeyToAddress(&key.PublicKey).Bytes(),
+			Type: proto.MessageType_PREPARE,
+		}
+
+		payload, err := msg.PayloadNoSig()
+		require.NoError(t, err)
+
+		msg.Signature, err = crypto.Sign(key, crypto.Keccak256(payload))
+		require.NoError(t, err)
+
+		return msg
+	}
+
+	assert.NoError(t, validateGossipMessage(newSignedMessage(), ""))
+
+	// the signature is not the one of the sender
+	msg := newSignedMessage()
+	msg.From = []byte{0x1}
+	assert.ErrorIs(t, validateGossipMessage(msg, ""), errInvalidSignature)
+
+	msg = newSignedMessage()
+	msg.View = nil
+	assert.ErrorIs(t, validateGossipMessage(msg, ""), errMissingView)
+
+	msg = newSignedMessage()
+	msg.Signature = []byte{0x1}
+	assert.Error(t, validateGossipMessage(msg, ""))
+
+	assert.ErrorIs(t, validateGossipMessage(&proto.View{}, ""), errInvalidMessageType)
+}
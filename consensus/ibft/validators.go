@@ -1,6 +1,7 @@
 package ibft
 
 import (
+	"encoding/binary"
 	"math"
 
 	"github.com/0xPolygon/polygon-edge/types"
@@ -71,3 +72,15 @@ func CalcProposer(
 
 	return validators.At(pick)
 }
+
+// CalcRandomProposer picks the proposer of the round from the randomness beacon
+func CalcRandomProposer(
+	validators validators.Validators,
+	round uint64,
+	randomness types.Hash,
+) validators.Validator {
+	seed := binary.BigEndian.Uint64(randomness[types.HashLength-8:]) + round
+	pick := seed % uint64(validators.Len())
+
+	return validators.At(pick)
+}
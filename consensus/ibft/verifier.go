@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xPolygon/go-ibft/messages"
 	protoIBFT "github.com/0xPolygon/go-ibft/messages/proto"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -54,6 +55,21 @@ func (i *backendIBFT) IsValidBlock(proposal []byte) bool {
 		return false
 	}
 
+	if i.config.Params.EncryptedMempool != nil {
+		parent, ok := i.blockchain.GetBlockByHash(latestHeader.Hash, true)
+		if !ok {
+			i.logger.Error("parent block not found", "hash", latestHeader.Hash)
+
+			return false
+		}
+
+		if err := txpool.VerifyDecryptedTxs(parent, newBlock); err != nil {
+			i.logger.Error("decrypted transactions verification failed", "err", err)
+
+			return false
+		}
+	}
+
 	if err := i.currentHooks.VerifyBlock(newBlock); err != nil {
 		i.logger.Error("additional block verification failed", "err", err)
 
@@ -118,19 +134,13 @@ func (i *backendIBFT) IsProposer(id []byte, height, round uint64) bool {
 		return false
 	}
 
-	previousProposer, err := i.extractProposer(previousHeader)
+	nextProposer, err := i.calcNextProposer(previousHeader, round)
 	if err != nil {
-		i.logger.Error("failed to extract the last proposer", "height", height-1, "err", err)
+		i.logger.Error("failed to calculate the next proposer", "height", height, "err", err)
 
 		return false
 	}
 
-	nextProposer := CalcProposer(
-		i.currentValidators,
-		round,
-		previousProposer,
-	)
-
 	return types.BytesToAddress(id) == nextProposer.Addr()
 }
 
@@ -0,0 +1,279 @@
+package ibft
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+const (
+	KeyVotingPowerBlockNum = "votingPowerBlockNum"
+	KeyVotingPowerCap      = "votingPowerCap"
+
+	// maxVotingPowerCap is the cap of the voting power in percentage of the total stake without effect
+	maxVotingPowerCap = 100
+
+	// stakeQuorumTimeout is the maximum time to wait for the committed seals holding the quorum of the voting power
+	stakeQuorumTimeout = 2 * time.Second
+)
+
+var errInvalidVotingPowerCap = errors.New("the voting power cap must be a percentage of the total stake")
+
+// votingPowerConfig is the configuration of the stake-weighted voting power
+type votingPowerConfig struct {
+	enabled  bool
+	blockNum uint64
+	// cap is the maximum voting power of a validator in percentage of the total stake, zero for no cap
+	cap uint64
+}
+
+// parseVotingPowerConfig reads the stake-weighted voting power configuration from the engine config
+func parseVotingPowerConfig(engineConfig map[string]interface{}) (*votingPowerConfig, error) {
+	config := &votingPowerConfig{}
+
+	rawBlockNum, ok := engineConfig[KeyVotingPowerBlockNum]
+	if !ok {
+		return config, nil
+	}
+
+	readBlockNum, ok := rawBlockNum.(float64)
+	if !ok {
+		return nil, errors.New("invalid type assertion")
+	}
+
+	config.enabled = true
+	config.blockNum = uint64(readBlockNum)
+
+	if rawCap, ok := engineConfig[KeyVotingPowerCap]; ok {
+		readCap, ok := rawCap.(float64)
+		if !ok {
+			return nil, errors.New("invalid type assertion")
+		}
+
+		if readCap < 0 || readCap > maxVotingPowerCap {
+			return nil, fmt.Errorf("%w: %v", errInvalidVotingPowerCap, readCap)
+		}
+
+		config.cap = uint64(readCap)
+	}
+
+	return config, nil
+}
+
+// isVotingPowerActive checks if the quorum at the given height is weighted by the stake of the validators
+func (i *backendIBFT) isVotingPowerActive(height uint64) bool {
+	return i.votingPower != nil && i.votingPower.enabled && height >= i.votingPower.blockNum
+}
+
+// getVotingPowers returns the voting powers of the validators at the given height,
+// nil if the validators have equal voting powers. Once the quorum is weighted by the stake,
+// it fails if the stakes can't be read rather than falling back to the count of the validators
+func (i *backendIBFT) getVotingPowers(height uint64, set validators.Validators) ([]*big.Int, error) {
+	if !i.isVotingPowerActive(height) {
+		return nil, nil
+	}
+
+	stakes, err := i.forkManager.GetValidatorStakes(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the voting powers at height %d: %w", height, err)
+	}
+
+	return CalcVotingPowers(set, stakes, i.votingPower.cap), nil
+}
+
+// CalcVotingPowers returns the voting powers of the validators in the order of the set, which are their stakes
+// capped at the percentage of the total stake. It returns nil if the validators have no stake,
+// the validators have equal voting powers then
+func CalcVotingPowers(
+	set validators.Validators,
+	stakes map[types.Address]*big.Int,
+	capPercent uint64,
+) []*big.Int {
+	if len(stakes) == 0 {
+		return nil
+	}
+
+	totalStake := new(big.Int)
+
+	for idx := 0; idx < set.Len(); idx++ {
+		if stake, ok := stakes[set.At(uint64(idx)).Addr()]; ok {
+			totalStake.Add(totalStake, stake)
+		}
+	}
+
+	var powerCap *big.Int
+
+	if capPercent != 0 && capPercent < maxVotingPowerCap {
+		powerCap = new(big.Int).Mul(totalStake, new(big.Int).SetUint64(capPercent))
+		powerCap.Div(powerCap, big.NewInt(maxVotingPowerCap))
+	}
+
+	var (
+		powers     = make([]*big.Int, set.Len())
+		totalPower = new(big.Int)
+	)
+
+	for idx := range powers {
+		power := new(big.Int)
+
+		if stake, ok := stakes[set.At(uint64(idx)).Addr()]; ok {
+			power.Set(stake)
+		}
+
+		if powerCap != nil && power.Cmp(powerCap) > 0 {
+			power.Set(powerCap)
+		}
+
+		powers[idx] = power
+		totalPower.Add(totalPower, power)
+	}
+
+	if totalPower.Sign() == 0 {
+		return nil
+	}
+
+	return powers
+}
+
+// totalVotingPower returns the sum of the voting powers
+func totalVotingPower(powers []*big.Int) *big.Int {
+	total := new(big.Int)
+
+	for _, power := range powers {
+		total.Add(total, power)
+	}
+
+	return total
+}
+
+// HasStakeQuorum checks if the signers hold more than 2/3 of the voting power of the validator set.
+// The voting powers are in the order of the set, the signers out of the set or repeated aren't counted
+func HasStakeQuorum(set validators.Validators, powers []*big.Int, signers []types.Address) bool {
+	var (
+		signed  = new(big.Int)
+		visited = make(map[types.Address]bool, len(signers))
+	)
+
+	for _, addr := range signers {
+		idx := set.Index(addr)
+		if idx < 0 || int(idx) >= len(powers) || visited[addr] {
+			continue
+		}
+
+		visited[addr] = true
+
+		signed.Add(signed, powers[idx])
+	}
+
+	// 3 * signed > 2 * total
+	return new(big.Int).Mul(signed, big.NewInt(3)).Cmp(
+		new(big.Int).Mul(totalVotingPower(powers), big.NewInt(2)),
+	) > 0
+}
+
+// sealQuorum returns the quorum of the committed seals at the given height: the number of signers
+// must reach the quorum size and, once the quorum is weighted by the stake, the signers must also
+// hold more than 2/3 of the voting power.
+//
+// go-ibft only counts the PREPARE and COMMIT messages, the stake is checked on the committed seals
+// when the block is inserted. The weighted quorum is then safe as long as less than 1/3 of the validators
+// and less than 1/3 of the voting power are faulty, both quorums of honest validators intersecting
+func (i *backendIBFT) sealQuorum(height uint64, set validators.Validators) (signer.Quorum, error) {
+	countQuorum := signer.QuorumSize(i.quorumSize(height)(set))
+
+	powers, err := i.getVotingPowers(height, set)
+	if err != nil {
+		return nil, err
+	}
+
+	if powers == nil {
+		return countQuorum, nil
+	}
+
+	return func(signers []types.Address) bool {
+		return countQuorum(signers) && HasStakeQuorum(set, powers, signers)
+	}, nil
+}
+
+// awaitStakeQuorum completes the committed seals of the proposal with the valid seals collected
+// from the COMMIT messages, until their signers hold the quorum of the voting power or the timeout expires
+func (i *backendIBFT) awaitStakeQuorum(height uint64, proposalHash types.Hash, seals map[types.Address][]byte) bool {
+	timeout := time.NewTimer(stakeQuorumTimeout)
+	defer timeout.Stop()
+
+	for {
+		collected, updateCh := i.commits.get(height, proposalHash)
+
+		for addr, seal := range collected {
+			if _, ok := seals[addr]; ok {
+				continue
+			}
+
+			if err := i.currentSigner.VerifyCommittedSeal(
+				i.currentValidators,
+				addr,
+				seal,
+				proposalHash.Bytes(),
+			); err != nil {
+				continue
+			}
+
+			seals[addr] = seal
+		}
+
+		signers := make([]types.Address, 0, len(seals))
+		for addr := range seals {
+			signers = append(signers, addr)
+		}
+
+		if HasStakeQuorum(i.currentValidators, i.currentVotingPowers, signers) {
+			return true
+		}
+
+		select {
+		case <-updateCh:
+		case <-timeout.C:
+			return false
+		case <-i.closeCh:
+			return false
+		}
+	}
+}
+
+// CalcWeightedProposer picks the proposer of the round from the seed
+// with the probability proportional to the voting power
+func CalcWeightedProposer(
+	set validators.Validators,
+	powers []*big.Int,
+	round uint64,
+	seed types.Hash,
+) validators.Validator {
+	if powers == nil {
+		return CalcRandomProposer(set, round, seed)
+	}
+
+	roundBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(roundBytes, round)
+
+	total := totalVotingPower(powers)
+
+	pick := new(big.Int).SetBytes(crypto.Keccak256(seed.Bytes(), roundBytes))
+	pick.Mod(pick, total)
+
+	for idx, power := range powers {
+		if pick.Cmp(power) < 0 {
+			return set.At(uint64(idx))
+		}
+
+		pick.Sub(pick, power)
+	}
+
+	return set.At(uint64(len(powers) - 1))
+}
@@ -0,0 +1,250 @@
+package ibft
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestVotingPowerSet(size int) validators.Validators {
+	set := validators.NewECDSAValidatorSet()
+
+	for idx := 0; idx < size; idx++ {
+		_ = set.Add(validators.NewECDSAValidator(types.StringToAddress(strconv.Itoa(idx + 1))))
+	}
+
+	return set
+}
+
+func newTestVotingPowers(powers ...int64) []*big.Int {
+	res := make([]*big.Int, len(powers))
+
+	for idx, power := range powers {
+		res[idx] = big.NewInt(power)
+	}
+
+	return res
+}
+
+func TestParseVotingPowerConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected *votingPowerConfig
+		isErr    bool
+	}{
+		{
+			name:     "should be disabled by default",
+			config:   map[string]interface{}{},
+			expected: &votingPowerConfig{},
+		},
+		{
+			name: "should weight the quorum from the given height with the cap",
+			config: map[string]interface{}{
+				KeyVotingPowerBlockNum: float64(10),
+				KeyVotingPowerCap:      float64(20),
+			},
+			expected: &votingPowerConfig{
+				enabled:  true,
+				blockNum: 10,
+				cap:      20,
+			},
+		},
+		{
+			name: "should return error for the cap above the total stake",
+			config: map[string]interface{}{
+				KeyVotingPowerBlockNum: float64(10),
+				KeyVotingPowerCap:      float64(101),
+			},
+			isErr: true,
+		},
+		{
+			name: "should return error for invalid type",
+			config: map[string]interface{}{
+				KeyVotingPowerBlockNum: "10",
+			},
+			isErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			config, err := parseVotingPowerConfig(test.config)
+
+			assert.Equal(t, test.expected, config)
+			assert.Equal(t, test.isErr, err != nil)
+		})
+	}
+}
+
+func TestCalcVotingPowers(t *testing.T) {
+	t.Parallel()
+
+	set := newTestVotingPowerSet(3)
+	stakes := map[types.Address]*big.Int{
+		set.At(0).Addr(): big.NewInt(10),
+		set.At(1).Addr(): big.NewInt(30),
+		set.At(2).Addr(): big.NewInt(60),
+	}
+
+	// no cap
+	assert.Equal(t, newTestVotingPowers(10, 30, 60), CalcVotingPowers(set, stakes, 0))
+
+	// the powers are capped at 40% of the total stake
+	assert.Equal(t, newTestVotingPowers(10, 30, 40), CalcVotingPowers(set, stakes, 40))
+
+	// equal powers without stake
+	assert.Nil(t, CalcVotingPowers(set, nil, 0))
+	assert.Nil(t, CalcVotingPowers(set, map[types.Address]*big.Int{set.At(0).Addr(): big.NewInt(0)}, 0))
+}
+
+func TestHasStakeQuorum(t *testing.T) {
+	t.Parallel()
+
+	set := newTestVotingPowerSet(4)
+	addrs := make([]types.Address, set.Len())
+
+	for idx := range addrs {
+		addrs[idx] = set.At(uint64(idx)).Addr()
+	}
+
+	tests := []struct {
+		name    string
+		powers  []*big.Int
+		signers []types.Address
+		quorum  bool
+	}{
+		{
+			name:    "3 of 4 equal powers hold the quorum",
+			powers:  newTestVotingPowers(5, 5, 5, 5),
+			signers: addrs[1:],
+			quorum:  true,
+		},
+		{
+			name:    "2 of 4 equal powers don't hold the quorum",
+			powers:  newTestVotingPowers(5, 5, 5, 5),
+			signers: addrs[2:],
+			quorum:  false,
+		},
+		{
+			name:    "the largest stake with one validator holds the quorum",
+			powers:  newTestVotingPowers(10, 1, 1, 1),
+			signers: addrs[:2],
+			quorum:  true,
+		},
+		{
+			name:    "the other validators don't hold the quorum without the largest stake",
+			powers:  newTestVotingPowers(10, 1, 1, 1),
+			signers: addrs[1:],
+			quorum:  false,
+		},
+		{
+			name:    "exactly 2/3 of the voting power isn't the quorum",
+			powers:  newTestVotingPowers(4, 2, 0, 0),
+			signers: addrs[:1],
+			quorum:  false,
+		},
+		{
+			name:    "the repeated signers and the non-validators aren't counted",
+			powers:  newTestVotingPowers(5, 5, 5, 5),
+			signers: []types.Address{addrs[0], addrs[0], addrs[1], types.StringToAddress("99")},
+			quorum:  false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.quorum, HasStakeQuorum(set, test.powers, test.signers))
+		})
+	}
+}
+
+// stakesForkManager is a fork manager returning the stakes of the validators
+type stakesForkManager struct {
+	forkManagerInterface
+
+	stakes map[types.Address]*big.Int
+	err    error
+}
+
+func (m *stakesForkManager) GetValidatorStakes(uint64) (map[types.Address]*big.Int, error) {
+	return m.stakes, m.err
+}
+
+func TestSealQuorum(t *testing.T) {
+	t.Parallel()
+
+	set := newTestVotingPowerSet(4)
+	addrs := make([]types.Address, set.Len())
+
+	for idx := range addrs {
+		addrs[idx] = set.At(uint64(idx)).Addr()
+	}
+
+	forkManager := &stakesForkManager{
+		stakes: map[types.Address]*big.Int{
+			addrs[0]: big.NewInt(10),
+			addrs[1]: big.NewInt(10),
+			addrs[2]: big.NewInt(1),
+			addrs[3]: big.NewInt(1),
+		},
+	}
+
+	backend := &backendIBFT{
+		forkManager: forkManager,
+		votingPower: &votingPowerConfig{enabled: true, blockNum: 10},
+	}
+
+	// the count quorum applies before the voting power
+	quorum, err := backend.sealQuorum(9, set)
+	assert.NoError(t, err)
+	assert.True(t, quorum(addrs[1:]))
+
+	// the signers hold both 2/3 of the validators and 2/3 of the voting power,
+	// the fault assumption being less than 1/3 of each
+	quorum, err = backend.sealQuorum(10, set)
+	assert.NoError(t, err)
+	assert.True(t, quorum(addrs[:3]))
+	assert.False(t, quorum(addrs[1:]))
+	assert.False(t, quorum(addrs[:2]))
+
+	// the quorum doesn't fall back to the count if the stakes can't be read
+	forkManager.err = errors.New("no stakes")
+
+	_, err = backend.sealQuorum(10, set)
+	assert.ErrorIs(t, err, forkManager.err)
+}
+
+func TestCalcWeightedProposer(t *testing.T) {
+	t.Parallel()
+
+	set := newTestVotingPowerSet(3)
+	powers := newTestVotingPowers(0, 1, 0)
+
+	// the validators without voting power never propose
+	for round := uint64(0); round < 10; round++ {
+		assert.Equal(t, set.At(1), CalcWeightedProposer(set, powers, round, types.StringToHash("0x4")))
+	}
+
+	// the proposer is picked randomly with equal powers
+	assert.Equal(
+		t,
+		CalcRandomProposer(set, 2, types.StringToHash("0x4")),
+		CalcWeightedProposer(set, nil, 2, types.StringToHash("0x4")),
+	)
+}
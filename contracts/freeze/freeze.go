@@ -0,0 +1,202 @@
+package freeze
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// freeze contract address
+	AddrFreezeContract = types.StringToAddress("1005")
+
+	// the selectors of the votes of the governors,
+	// `function freeze(address account)` and `function unfreeze(address account)`
+	freezeSelector   = keccak.Keccak256(nil, []byte("freeze(address)"))[:4]
+	unfreezeSelector = keccak.Keccak256(nil, []byte("unfreeze(address)"))[:4]
+
+	// the topics of the events of the freeze contract
+	VotedEventTopic    = types.BytesToHash(keccak.Keccak256(nil, []byte("Voted(address,address,bool)")))
+	FrozenEventTopic   = types.BytesToHash(keccak.Keccak256(nil, []byte("Frozen(address)")))
+	UnfrozenEventTopic = types.BytesToHash(keccak.Keccak256(nil, []byte("Unfrozen(address)")))
+
+	ErrNotGovernor = errors.New("the caller isn't a freeze governor")
+
+	// the storage value of the frozen status, an unfrozen account has no status
+	frozenValue = types.BytesToHash([]byte{1})
+
+	// the storage values of the votes, a governor who didn't vote has no vote
+	freezeVoteValue   = types.BytesToHash([]byte{1})
+	unfreezeVoteValue = types.BytesToHash([]byte{2})
+)
+
+const (
+	// VoteGas is the gas used by a vote of a governor
+	VoteGas = 50000
+
+	// FreezeSCBytecode is the runtime code of the freeze contract.
+	// It implements `function isFrozen(address account) external view returns (bool)`,
+	// reverting on calls with value and on any other selector.
+	// The votes of the governors are applied by the nodes
+	//
+	// CALLVALUE ISZERO PUSH1 0x0a JUMPI JUMPDEST PUSH1 0 DUP1 REVERT JUMPDEST
+	// PUSH1 4 PUSH1 0 PUSH1 0x1c CALLDATACOPY PUSH1 0 MLOAD PUSH4 0xe5839836 EQ ISZERO PUSH1 0x05 JUMPI
+	// PUSH1 4 CALLDATALOAD PUSH1 0 MSTORE PUSH1 0x20 PUSH1 0 SHA3 SLOAD PUSH1 1 EQ
+	// PUSH1 0 MSTORE PUSH1 0x20 PUSH1 0 RETURN
+	FreezeSCBytecode = "0x3415600a575b600080fd5b60046000601c3760005163e5839836141560055760043560005260206000205460011460005260206000f3"
+)
+
+// stateReader is an interface to read the freeze contract state directly
+type stateReader interface {
+	GetState(addr types.Address, key types.Hash) types.Hash
+}
+
+// stateReadWriter is an interface to access the freeze contract state directly and emit its events
+type stateReadWriter interface {
+	stateReader
+	SetState(addr types.Address, key, value types.Hash)
+	EmitLog(addr types.Address, topics []types.Hash, data []byte)
+}
+
+// PredeployFreezeSC returns the genesis account of the freeze contract without any frozen account
+func PredeployFreezeSC() *chain.GenesisAccount {
+	code, _ := hex.DecodeHex(FreezeSCBytecode)
+
+	return &chain.GenesisAccount{
+		Code:    code,
+		Storage: make(map[types.Hash]types.Hash),
+		Balance: big.NewInt(0),
+	}
+}
+
+// statusSlot returns the storage slot of the frozen status of the account,
+// the hash of the account padded to 32 bytes
+func statusSlot(account types.Address) types.Hash {
+	return types.BytesToHash(keccak.Keccak256(nil, types.BytesToHash(account.Bytes()).Bytes()))
+}
+
+// voteSlot returns the storage slot of the vote of the governor on the account,
+// the hash of the account and the governor padded to 32 bytes
+func voteSlot(governor, account types.Address) types.Hash {
+	return types.BytesToHash(keccak.Keccak256(
+		nil,
+		append(types.BytesToHash(account.Bytes()).Bytes(), types.BytesToHash(governor.Bytes()).Bytes()...),
+	))
+}
+
+// statusValue returns the storage value of the frozen status
+func statusValue(frozen bool) types.Hash {
+	if frozen {
+		return frozenValue
+	}
+
+	return types.ZeroHash
+}
+
+// voteValue returns the storage value of a vote to freeze or unfreeze
+func voteValue(frozen bool) types.Hash {
+	if frozen {
+		return freezeVoteValue
+	}
+
+	return unfreezeVoteValue
+}
+
+// IsFrozen returns true if the account is frozen
+func IsFrozen(state stateReader, account types.Address) bool {
+	return state.GetState(AddrFreezeContract, statusSlot(account)) == frozenValue
+}
+
+// ParseVote parses the input of a vote of a governor, returning the account
+// and whether the vote freezes or unfreezes it
+func ParseVote(input []byte) (types.Address, bool, bool) {
+	if len(input) != 4+types.HashLength {
+		return types.ZeroAddress, false, false
+	}
+
+	account := types.BytesToAddress(input[4:])
+
+	switch {
+	case bytes.Equal(input[:4], freezeSelector):
+		return account, true, true
+	case bytes.Equal(input[:4], unfreezeSelector):
+		return account, false, true
+	default:
+		return types.ZeroAddress, false, false
+	}
+}
+
+// EncodeVote returns the input of a vote of a governor freezing or unfreezing the account
+func EncodeVote(account types.Address, frozen bool) []byte {
+	selector := unfreezeSelector
+	if frozen {
+		selector = freezeSelector
+	}
+
+	return append(append([]byte{}, selector...), types.BytesToHash(account.Bytes()).Bytes()...)
+}
+
+// Vote records the vote of the governor to freeze or unfreeze the account. The account is frozen
+// or unfrozen once the threshold of the governors vote for it, their votes are then cleared
+func Vote(state stateReadWriter, config *chain.Freeze, governor, account types.Address, frozen bool) error {
+	if !config.IsGovernor(governor) {
+		return ErrNotGovernor
+	}
+
+	vote := voteValue(frozen)
+
+	state.SetState(AddrFreezeContract, voteSlot(governor, account), vote)
+	state.EmitLog(
+		AddrFreezeContract,
+		[]types.Hash{
+			VotedEventTopic,
+			types.BytesToHash(governor.Bytes()),
+			types.BytesToHash(account.Bytes()),
+		},
+		types.BytesToHash([]byte{boolToByte(frozen)}).Bytes(),
+	)
+
+	if IsFrozen(state, account) == frozen {
+		return nil
+	}
+
+	votes := uint64(0)
+
+	for _, voter := range config.Governors {
+		if state.GetState(AddrFreezeContract, voteSlot(voter, account)) == vote {
+			votes++
+		}
+	}
+
+	if votes < config.Threshold {
+		return nil
+	}
+
+	for _, voter := range config.Governors {
+		state.SetState(AddrFreezeContract, voteSlot(voter, account), types.ZeroHash)
+	}
+
+	state.SetState(AddrFreezeContract, statusSlot(account), statusValue(frozen))
+
+	topic := UnfrozenEventTopic
+	if frozen {
+		topic = FrozenEventTopic
+	}
+
+	state.EmitLog(AddrFreezeContract, []types.Hash{topic, types.BytesToHash(account.Bytes())}, nil)
+
+	return nil
+}
+
+func boolToByte(b bool) byte {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,86 @@
+package freeze
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockState struct {
+	storage map[types.Hash]types.Hash
+	logs    [][]types.Hash
+}
+
+func (m *mockState) GetState(addr types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockState) SetState(addr types.Address, key, value types.Hash) {
+	m.storage[key] = value
+}
+
+func (m *mockState) EmitLog(addr types.Address, topics []types.Hash, data []byte) {
+	m.logs = append(m.logs, topics)
+}
+
+func TestParseVote(t *testing.T) {
+	t.Parallel()
+
+	account := types.StringToAddress("1")
+
+	for _, frozen := range []bool{true, false} {
+		parsed, parsedFrozen, ok := ParseVote(EncodeVote(account, frozen))
+		assert.True(t, ok)
+		assert.Equal(t, account, parsed)
+		assert.Equal(t, frozen, parsedFrozen)
+	}
+
+	// the other calls of the contract aren't votes
+	_, _, ok := ParseVote(append([]byte{0x1, 0x2, 0x3, 0x4}, make([]byte, types.HashLength)...))
+	assert.False(t, ok)
+
+	_, _, ok = ParseVote(EncodeVote(account, true)[:4])
+	assert.False(t, ok)
+}
+
+func TestVote(t *testing.T) {
+	t.Parallel()
+
+	var (
+		governors = []types.Address{
+			types.StringToAddress("1"),
+			types.StringToAddress("2"),
+			types.StringToAddress("3"),
+		}
+		account = types.StringToAddress("4")
+		config  = &chain.Freeze{Governors: governors, Threshold: 2}
+		state   = &mockState{storage: make(map[types.Hash]types.Hash)}
+	)
+
+	assert.ErrorIs(t, Vote(state, config, account, account, true), ErrNotGovernor)
+
+	// the account is frozen by the second vote
+	assert.NoError(t, Vote(state, config, governors[0], account, true))
+	assert.False(t, IsFrozen(state, account))
+
+	assert.NoError(t, Vote(state, config, governors[1], account, true))
+	assert.True(t, IsFrozen(state, account))
+	assert.Equal(t, FrozenEventTopic, state.logs[len(state.logs)-1][0])
+
+	// the votes are cleared once applied, the account is unfrozen by new votes
+	assert.NoError(t, Vote(state, config, governors[2], account, false))
+	assert.True(t, IsFrozen(state, account))
+
+	assert.NoError(t, Vote(state, config, governors[0], account, false))
+	assert.False(t, IsFrozen(state, account))
+	assert.Equal(t, UnfrozenEventTopic, state.logs[len(state.logs)-1][0])
+
+	// the contract getter reads no status for an unfrozen account
+	assert.Equal(t, types.ZeroHash, state.storage[statusSlot(account)])
+
+	// a single vote doesn't freeze the account again
+	assert.NoError(t, Vote(state, config, governors[1], account, true))
+	assert.False(t, IsFrozen(state, account))
+}
@@ -0,0 +1,85 @@
+package gasquota
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// gas quota contract address
+	AddrGasQuotaContract = types.StringToAddress("1004")
+)
+
+const (
+	// DaySeconds is the duration of a quota period
+	DaySeconds = 86400
+
+	// GasQuotaSCBytecode is the runtime code of the gas quota contract.
+	// It rejects calls with value and returns the usage of the account, so it can be called as
+	// `function usage(address account) external view returns (uint256)`.
+	// The usage packs the day (high 128 bits) and the gas used on that day (low 128 bits)
+	//
+	// CALLVALUE ISZERO PUSH1 0x09 JUMPI PUSH1 0 DUP1 REVERT JUMPDEST
+	// PUSH1 4 CALLDATALOAD PUSH1 0 MSTORE PUSH1 0x20 PUSH1 0 SHA3 SLOAD
+	// PUSH1 0 MSTORE PUSH1 0x20 PUSH1 0 RETURN
+	GasQuotaSCBytecode = "0x3415600957600080fd5b60043560005260206000205460005260206000f3"
+)
+
+// stateReader is an interface to read the gas quota contract state directly
+type stateReader interface {
+	GetState(addr types.Address, key types.Hash) types.Hash
+}
+
+// stateReadWriter is an interface to access the gas quota contract state directly
+type stateReadWriter interface {
+	stateReader
+	SetState(addr types.Address, key, value types.Hash)
+}
+
+// PredeployGasQuotaSC returns the genesis account of the gas quota contract without any usage
+func PredeployGasQuotaSC() *chain.GenesisAccount {
+	code, _ := hex.DecodeHex(GasQuotaSCBytecode)
+
+	return &chain.GenesisAccount{
+		Code:    code,
+		Storage: make(map[types.Hash]types.Hash),
+		Balance: big.NewInt(0),
+	}
+}
+
+// Day returns the quota period of the given timestamp
+func Day(timestamp uint64) uint64 {
+	return timestamp / DaySeconds
+}
+
+// usageSlot returns the storage slot of the usage of the account,
+// the hash of the account padded to 32 bytes
+func usageSlot(account types.Address) types.Hash {
+	return types.BytesToHash(keccak.Keccak256(nil, types.BytesToHash(account.Bytes()).Bytes()))
+}
+
+// Usage returns the gas used by the account on the given day
+func Usage(state stateReader, account types.Address, day uint64) uint64 {
+	value := state.GetState(AddrGasQuotaContract, usageSlot(account))
+
+	if binary.BigEndian.Uint64(value[8:16]) != day {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(value[24:32])
+}
+
+// AddUsage adds the gas used by the account on the given day
+func AddUsage(state stateReadWriter, account types.Address, day, gas uint64) {
+	var value types.Hash
+
+	binary.BigEndian.PutUint64(value[8:16], day)
+	binary.BigEndian.PutUint64(value[24:32], Usage(state, account, day)+gas)
+
+	state.SetState(AddrGasQuotaContract, usageSlot(account), value)
+}
@@ -0,0 +1,45 @@
+package gasquota
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockState struct {
+	storage map[types.Hash]types.Hash
+}
+
+func (m *mockState) GetState(addr types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockState) SetState(addr types.Address, key, value types.Hash) {
+	m.storage[key] = value
+}
+
+func TestUsage(t *testing.T) {
+	t.Parallel()
+
+	state := &mockState{
+		storage: make(map[types.Hash]types.Hash),
+	}
+
+	account := types.StringToAddress("1")
+	day := Day(10 * DaySeconds)
+
+	assert.Zero(t, Usage(state, account, day))
+
+	AddUsage(state, account, day, 21000)
+	AddUsage(state, account, day, 1000)
+
+	assert.Equal(t, uint64(22000), Usage(state, account, day))
+	assert.Zero(t, Usage(state, types.StringToAddress("2"), day))
+
+	// the usage starts over the next day
+	assert.Zero(t, Usage(state, account, day+1))
+
+	AddUsage(state, account, day+1, 5)
+	assert.Equal(t, uint64(5), Usage(state, account, day+1))
+}
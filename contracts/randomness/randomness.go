@@ -0,0 +1,79 @@
+package randomness
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// randomness beacon contract address
+	AddrRandomnessContract = types.StringToAddress("1002")
+
+	// Slot definitions for SC storage
+	randomnessSlot  = types.BytesToHash(big.NewInt(0).Bytes()) // Slot 0
+	blockNumberSlot = types.BytesToHash(big.NewInt(1).Bytes()) // Slot 1
+)
+
+const (
+	// RandomnessSCBytecode is the runtime code of the beacon contract.
+	// It rejects calls with value and returns the latest randomness
+	// and its block number for any input, so it can be called as
+	// `function latest() external view returns (bytes32 randomness, uint256 blockNumber)`
+	//
+	// CALLVALUE ISZERO PUSH1 0x09 JUMPI PUSH1 0 DUP1 REVERT JUMPDEST
+	// PUSH1 0 SLOAD PUSH1 0 MSTORE PUSH1 1 SLOAD PUSH1 0x20 MSTORE PUSH1 0x40 PUSH1 0 RETURN
+	RandomnessSCBytecode = "0x3415600957600080fd5b60005460005260015460205260406000f3"
+)
+
+// stateWriter is an interface to update the beacon contract state directly
+type stateWriter interface {
+	AccountExists(types.Address) bool
+	SetAccountDirectly(types.Address, *chain.GenesisAccount) error
+	SetStorageDirectly(addr types.Address, key, value types.Hash) error
+}
+
+// PredeployRandomnessSC returns the genesis account of the beacon contract with empty randomness
+func PredeployRandomnessSC() *chain.GenesisAccount {
+	code, _ := hex.DecodeHex(RandomnessSCBytecode)
+
+	return &chain.GenesisAccount{
+		Code:    code,
+		Storage: make(map[types.Hash]types.Hash),
+		Balance: big.NewInt(0),
+	}
+}
+
+// WriteRandomness stores the randomness of the given block into the beacon contract,
+// the contract is deployed on the first write
+func WriteRandomness(txn stateWriter, height uint64, randomness types.Hash) error {
+	if !txn.AccountExists(AddrRandomnessContract) {
+		if err := txn.SetAccountDirectly(AddrRandomnessContract, PredeployRandomnessSC()); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.SetStorageDirectly(AddrRandomnessContract, randomnessSlot, randomness); err != nil {
+		return err
+	}
+
+	return txn.SetStorageDirectly(
+		AddrRandomnessContract,
+		blockNumberSlot,
+		types.BytesToHash(new(big.Int).SetUint64(height).Bytes()),
+	)
+}
+
+// DecodeRandomness parses the return value of the beacon contract
+func DecodeRandomness(returnValue []byte) (types.Hash, uint64) {
+	if len(returnValue) < 2*types.HashLength {
+		return types.ZeroHash, 0
+	}
+
+	randomness := types.BytesToHash(returnValue[:types.HashLength])
+	height := new(big.Int).SetBytes(returnValue[types.HashLength : 2*types.HashLength])
+
+	return randomness, height.Uint64()
+}
@@ -0,0 +1,71 @@
+package randomness
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockState struct {
+	accounts map[types.Address]*chain.GenesisAccount
+}
+
+func (m *mockState) AccountExists(addr types.Address) bool {
+	_, ok := m.accounts[addr]
+
+	return ok
+}
+
+func (m *mockState) SetAccountDirectly(addr types.Address, account *chain.GenesisAccount) error {
+	m.accounts[addr] = account
+
+	return nil
+}
+
+func (m *mockState) SetStorageDirectly(addr types.Address, key, value types.Hash) error {
+	m.accounts[addr].Storage[key] = value
+
+	return nil
+}
+
+func TestWriteRandomness(t *testing.T) {
+	t.Parallel()
+
+	state := &mockState{
+		accounts: make(map[types.Address]*chain.GenesisAccount),
+	}
+
+	randomness := types.StringToHash("0x1234")
+
+	assert.NoError(t, WriteRandomness(state, 10, randomness))
+
+	account, ok := state.accounts[AddrRandomnessContract]
+	assert.True(t, ok)
+	assert.NotEmpty(t, account.Code)
+	assert.Equal(t, randomness, account.Storage[randomnessSlot])
+	assert.Equal(t, types.StringToHash("0xa"), account.Storage[blockNumberSlot])
+
+	// overwrite by the next block
+	next := types.StringToHash("0x5678")
+
+	assert.NoError(t, WriteRandomness(state, 11, next))
+	assert.Equal(t, next, account.Storage[randomnessSlot])
+	assert.Equal(t, types.StringToHash("0xb"), account.Storage[blockNumberSlot])
+}
+
+func TestDecodeRandomness(t *testing.T) {
+	t.Parallel()
+
+	randomness := types.StringToHash("0x1234")
+	height := types.StringToHash("0x64")
+
+	res, num := DecodeRandomness(append(randomness.Bytes(), height.Bytes()...))
+	assert.Equal(t, randomness, res)
+	assert.Equal(t, uint64(100), num)
+
+	res, num = DecodeRandomness([]byte{0x1})
+	assert.Equal(t, types.ZeroHash, res)
+	assert.Equal(t, uint64(0), num)
+}
@@ -7,23 +7,29 @@ import (
 	"github.com/umbracle/ethgo"
 
 	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/helper/abi"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
-	"github.com/umbracle/ethgo/abi"
+	ethgoabi "github.com/umbracle/ethgo/abi"
 )
 
 const (
-	methodValidators             = "validators"
-	methodValidatorBLSPublicKeys = "validatorBLSPublicKeys"
+	methodPendingWithdrawal = "pendingWithdrawal"
 )
 
 var (
 	// staking contract address
 	AddrStakingContract = types.StringToAddress("1001")
 
+	// AddrSystem is the caller of the system calls applied to the staking contract by the consensus
+	AddrSystem = types.StringToAddress("0xfffffffffffffffffffffffffffffffffffffffe")
+
 	// Gas limit used when querying the validator set
 	queryGasLimit uint64 = 1000000
 
+	// SystemCallGasLimit is the gas limit of the system calls
+	SystemCallGasLimit uint64 = 1000000
+
 	ErrMethodNotFoundInABI = errors.New("method not found in ABI")
 	ErrFailedTypeAssertion = errors.New("failed type assertion")
 )
@@ -34,44 +40,8 @@ type TxQueryHandler interface {
 	GetNonce(types.Address) uint64
 }
 
-// decodeWeb3ArrayOfBytes is a helper function to parse the data
-// representing array of bytes in contract result
-func decodeWeb3ArrayOfBytes(
-	result interface{},
-) ([][]byte, error) {
-	mapResult, ok := result.(map[string]interface{})
-	if !ok {
-		return nil, ErrFailedTypeAssertion
-	}
-
-	bytesArray, ok := mapResult["0"].([][]byte)
-	if !ok {
-		return nil, ErrFailedTypeAssertion
-	}
-
-	return bytesArray, nil
-}
-
-// createCallViewTx is a helper function to create a transaction to call view method
-func createCallViewTx(
-	from types.Address,
-	contractAddress types.Address,
-	methodID []byte,
-	nonce uint64,
-) *types.Transaction {
-	return &types.Transaction{
-		From:     from,
-		To:       &contractAddress,
-		Input:    methodID,
-		Nonce:    nonce,
-		Gas:      queryGasLimit,
-		Value:    big.NewInt(0),
-		GasPrice: big.NewInt(0),
-	}
-}
-
 // DecodeValidators parses contract call result and returns array of address
-func DecodeValidators(method *abi.Method, returnValue []byte) ([]types.Address, error) {
+func DecodeValidators(method *ethgoabi.Method, returnValue []byte) ([]types.Address, error) {
 	decodedResults, err := method.Outputs.Decode(returnValue)
 	if err != nil {
 		return nil, err
@@ -98,68 +68,137 @@ func DecodeValidators(method *abi.Method, returnValue []byte) ([]types.Address,
 
 // QueryValidators is a helper function to get validator addresses from contract
 func QueryValidators(t TxQueryHandler, from types.Address) ([]types.Address, error) {
-	method, ok := abis.StakingABI.Methods[methodValidators]
-	if !ok {
-		return nil, ErrMethodNotFoundInABI
-	}
+	return newStakingBinding(t, from).Validators()
+}
 
-	res, err := t.Apply(createCallViewTx(
-		from,
-		AddrStakingContract,
-		method.ID(),
-		t.GetNonce(from),
-	))
+// QueryBLSPublicKeys is a helper function to get BLS Public Keys from contract
+func QueryBLSPublicKeys(t TxQueryHandler, from types.Address) ([][]byte, error) {
+	return newStakingBinding(t, from).ValidatorBLSPublicKeys()
+}
+
+// PendingWithdrawal is the amount unstaked by an account that can be claimed
+// once the unbonding period is over
+type PendingWithdrawal struct {
+	Amount       *big.Int
+	ReleaseEpoch uint64
+}
 
+// IsMatured returns true if the withdrawal can be claimed at the given epoch
+func (w *PendingWithdrawal) IsMatured(epoch uint64) bool {
+	return w.Amount.Sign() > 0 && epoch >= w.ReleaseEpoch
+}
+
+// newStakingBinding returns the binding of the staking contract applying the view calls from the address
+func newStakingBinding(t TxQueryHandler, from types.Address) *Staking {
+	return NewStaking(AddrStakingContract, abi.NewExecutorCaller(t, from, queryGasLimit))
+}
+
+// DecodeUnbondingPeriod parses contract call result and returns the unbonding period in epochs
+func DecodeUnbondingPeriod(method *ethgoabi.Method, returnValue []byte) (uint64, error) {
+	decodedResults, err := method.Outputs.Decode(returnValue)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	if res.Failed() {
-		return nil, res.Err
+	results, ok := decodedResults.(map[string]interface{})
+	if !ok {
+		return 0, ErrFailedTypeAssertion
 	}
 
-	return DecodeValidators(method, res.ReturnValue)
+	period, ok := results["0"].(*big.Int)
+	if !ok {
+		return 0, ErrFailedTypeAssertion
+	}
+
+	return period.Uint64(), nil
 }
 
-// decodeBLSPublicKeys parses contract call result and returns array of bytes
-func decodeBLSPublicKeys(
-	method *abi.Method,
-	returnValue []byte,
-) ([][]byte, error) {
-	decodedResults, err := method.Outputs.Decode(returnValue)
+// QueryUnbondingPeriod is a helper function to get the unbonding period from contract
+func QueryUnbondingPeriod(t TxQueryHandler, from types.Address) (uint64, error) {
+	period, err := newStakingBinding(t, from).UnbondingPeriod()
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	blsPublicKeys, err := decodeWeb3ArrayOfBytes(decodedResults)
+	return period.Uint64(), nil
+}
+
+// DecodePendingWithdrawal parses contract call result and returns the pending withdrawal
+func DecodePendingWithdrawal(method *ethgoabi.Method, returnValue []byte) (*PendingWithdrawal, error) {
+	decodedResults, err := method.Outputs.Decode(returnValue)
 	if err != nil {
 		return nil, err
 	}
 
-	return blsPublicKeys, nil
+	results, ok := decodedResults.(map[string]interface{})
+	if !ok {
+		return nil, ErrFailedTypeAssertion
+	}
+
+	amount, ok := results["amount"].(*big.Int)
+	if !ok {
+		return nil, ErrFailedTypeAssertion
+	}
+
+	releaseEpoch, ok := results["releaseEpoch"].(*big.Int)
+	if !ok {
+		return nil, ErrFailedTypeAssertion
+	}
+
+	return &PendingWithdrawal{
+		Amount:       amount,
+		ReleaseEpoch: releaseEpoch.Uint64(),
+	}, nil
 }
 
-// QueryBLSPublicKeys is a helper function to get BLS Public Keys from contract
-func QueryBLSPublicKeys(t TxQueryHandler, from types.Address) ([][]byte, error) {
-	method, ok := abis.StakingABI.Methods[methodValidatorBLSPublicKeys]
+// EncodePendingWithdrawalInput returns the call data to query the pending withdrawal of the account
+func EncodePendingWithdrawalInput(account types.Address) ([]byte, error) {
+	method, ok := abis.StakingABI.Methods[methodPendingWithdrawal]
 	if !ok {
 		return nil, ErrMethodNotFoundInABI
 	}
 
-	res, err := t.Apply(createCallViewTx(
-		from,
-		AddrStakingContract,
-		method.ID(),
-		t.GetNonce(from),
-	))
+	return method.Encode([]interface{}{ethgo.Address(account)})
+}
 
+// QueryPendingWithdrawal is a helper function to get the pending withdrawal of the account from contract
+func QueryPendingWithdrawal(
+	t TxQueryHandler,
+	from types.Address,
+	account types.Address,
+) (*PendingWithdrawal, error) {
+	withdrawal, err := newStakingBinding(t, from).PendingWithdrawal(account)
 	if err != nil {
 		return nil, err
 	}
 
-	if res.Failed() {
-		return nil, res.Err
+	return &PendingWithdrawal{
+		Amount:       withdrawal.Amount,
+		ReleaseEpoch: withdrawal.ReleaseEpoch.Uint64(),
+	}, nil
+}
+
+// QueryAccountStake is a helper function to get the amount staked by the account from contract
+func QueryAccountStake(t TxQueryHandler, from types.Address, account types.Address) (*big.Int, error) {
+	return newStakingBinding(t, from).AccountStake(account)
+}
+
+// QueryStakedAmount is a helper function to get the total amount staked in the contract
+func QueryStakedAmount(t TxQueryHandler, from types.Address) (*big.Int, error) {
+	return newStakingBinding(t, from).StakedAmount()
+}
+
+// QueryValidatorOwner is a helper function to get the owner controlling the stake of the validator
+// from contract, the validator itself if it registered no owner
+func QueryValidatorOwner(t TxQueryHandler, from types.Address, operator types.Address) (types.Address, error) {
+	owner, err := newStakingBinding(t, from).ValidatorOwner(operator)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	if owner == types.ZeroAddress {
+		return operator, nil
 	}
 
-	return decodeBLSPublicKeys(method, res.ReturnValue)
+	return owner, nil
 }
@@ -1,19 +1,41 @@
 package staking
 
 import (
+	"bytes"
 	"errors"
 	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/helper/abi"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
 	addr1 = types.StringToAddress("1")
 	addr2 = types.StringToAddress("2")
+
+	// the accounts calling the staking contract, the low addresses are the precompiled contracts
+	staker  = types.StringToAddress("a11ce")
+	staker2 = types.StringToAddress("b0b")
+	staker3 = types.StringToAddress("ca401")
+
+	testBalance = ether(100)
+
+	testPredeployParams = stakingHelper.PredeployParams{
+		MinValidatorCount: 0,
+		MaxValidatorCount: 10,
+	}
+	testBlockGasLimit uint64 = 10000000
 )
 
 func leftPad(buf []byte, n int) []byte {
@@ -220,3 +242,498 @@ func TestQueryValidators(t *testing.T) {
 		})
 	}
 }
+
+// testChain applies transactions block by block to the staking contract predeployed at genesis
+type testChain struct {
+	t *testing.T
+
+	executor   *state.Executor
+	transition *state.Transition
+	root       types.Hash
+	number     uint64
+}
+
+func newTestChain(
+	t *testing.T,
+	vals validators.Validators,
+	params stakingHelper.PredeployParams,
+	accounts ...types.Address,
+) *testChain {
+	t.Helper()
+
+	contract, err := stakingHelper.PredeployStakingSC(vals, params)
+	require.NoError(t, err)
+
+	alloc := map[types.Address]*chain.GenesisAccount{
+		AddrStakingContract: contract,
+	}
+
+	for _, account := range accounts {
+		alloc[account] = &chain.GenesisAccount{
+			Balance: new(big.Int).Set(testBalance),
+		}
+	}
+
+	c := &testChain{
+		t: t,
+		executor: state.NewExecutor(&chain.Params{
+			Forks: chain.AllForksEnabled,
+		}, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger()),
+	}
+
+	c.root = c.executor.WriteGenesis(alloc)
+	c.executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash {
+			return c.root
+		}
+	}
+
+	c.begin()
+
+	return c
+}
+
+func (c *testChain) begin() {
+	transition, err := c.executor.BeginTxn(
+		c.root,
+		&types.Header{
+			Number:   c.number,
+			GasLimit: testBlockGasLimit,
+		},
+		types.ZeroAddress,
+	)
+	require.NoError(c.t, err)
+
+	c.transition = transition
+}
+
+// advance commits the current block and begins the block of the given number
+func (c *testChain) advance(number uint64) {
+	c.t.Helper()
+
+	_, c.root = c.transition.Commit()
+	c.number = number
+
+	c.begin()
+}
+
+// call applies the transaction calling the staking contract from the account,
+// and returns its revert reason, empty if it succeeded
+func (c *testChain) call(from types.Address, value *big.Int, input []byte) string {
+	c.t.Helper()
+
+	res, err := c.transition.Apply(&types.Transaction{
+		From:     from,
+		To:       &AddrStakingContract,
+		Value:    value,
+		Input:    input,
+		GasPrice: big.NewInt(0),
+		Gas:      queryGasLimit,
+		Nonce:    c.transition.GetNonce(from),
+	})
+	require.NoError(c.t, err)
+
+	if res.Succeeded() {
+		return ""
+	}
+
+	reason, ok := runtime.UnpackRevertReason(res.ReturnValue)
+	require.True(c.t, ok, "failed without revert reason: %v", res.Err)
+
+	return reason
+}
+
+// input returns the encoded call data, failing the test on error
+func (c *testChain) input(input []byte, err error) []byte {
+	c.t.Helper()
+
+	require.NoError(c.t, err)
+
+	return input
+}
+
+func (c *testChain) balance(account types.Address) *big.Int {
+	return c.transition.GetBalance(account)
+}
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func TestStaking_ValidatorSet(t *testing.T) {
+	t.Parallel()
+
+	var (
+		genesisKey1 = bytes.Repeat([]byte{0x1}, 48)
+		genesisKey2 = bytes.Repeat([]byte{0x2}, 48)
+		stakerKey   = bytes.Repeat([]byte{0x3}, 48)
+		shortKey    = []byte{0x4, 0x5}
+	)
+
+	params := testPredeployParams
+	params.MinValidatorCount = 3
+	params.MaxValidatorCount = 4
+
+	c := newTestChain(t, validators.NewBLSValidatorSet(
+		validators.NewBLSValidator(addr1, genesisKey1),
+		validators.NewBLSValidator(addr2, genesisKey2),
+	), params, staker, staker2, staker3)
+	binding := NewStaking(AddrStakingContract, nil)
+
+	vals, err := QueryValidators(c.transition, staker)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{addr1, addr2}, vals)
+
+	keys, err := QueryBLSPublicKeys(c.transition, staker)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{genesisKey1, genesisKey2}, keys)
+
+	// a stake below the threshold doesn't join the validator set
+	stake := c.input(binding.EncodeStake())
+	assert.Empty(t, c.call(staker, big.NewInt(1), stake))
+	assert.Empty(t, c.call(staker, big.NewInt(0), c.input(binding.EncodeRegisterBLSPublicKey(shortKey))))
+	assert.Empty(t, c.call(staker, big.NewInt(0), c.input(binding.EncodeRegisterBLSPublicKey(stakerKey))))
+
+	vals, err = QueryValidators(c.transition, staker)
+	require.NoError(t, err)
+	assert.Len(t, vals, 2)
+
+	assert.Empty(t, c.call(staker, ether(1), stake))
+	assert.Empty(t, c.call(staker2, ether(1), stake))
+	assert.Equal(t, "Validator set has reached full capacity", c.call(staker3, ether(1), stake))
+
+	keys, err = QueryBLSPublicKeys(c.transition, staker)
+	require.NoError(t, err)
+	require.Len(t, keys, 4)
+	assert.Equal(t, [][]byte{genesisKey1, genesisKey2, stakerKey}, keys[:3])
+	assert.Empty(t, keys[3])
+
+	// the last validator takes the place of the leaving one
+	unstake := c.input(binding.EncodeUnstake())
+	assert.Empty(t, c.call(staker, big.NewInt(0), unstake))
+
+	vals, err = QueryValidators(c.transition, staker)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{addr1, addr2, staker2}, vals)
+
+	index, err := NewStaking(AddrStakingContract, abi.NewExecutorCaller(c.transition, staker, queryGasLimit)).
+		AddressToValidatorIndex(staker2)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2), index)
+
+	stakedAmount, err := QueryStakedAmount(c.transition, staker)
+	require.NoError(t, err)
+	assert.Equal(t, ether(1), stakedAmount)
+
+	// the stake of the genesis validators is empty
+	assert.Equal(t, "Only staker can call function", c.call(addr1, big.NewInt(0), unstake))
+
+	assert.Equal(t,
+		"Validators can't be less than the minimum required validator num",
+		c.call(staker2, big.NewInt(0), unstake),
+	)
+
+	// the short key replaces the registered one
+	assert.Empty(t, c.call(staker, big.NewInt(0), c.input(binding.EncodeRegisterBLSPublicKey(shortKey))))
+
+	key, err := NewStaking(AddrStakingContract, abi.NewExecutorCaller(c.transition, staker, queryGasLimit)).
+		AddressToBLSPublicKey(staker)
+	require.NoError(t, err)
+	assert.Equal(t, shortKey, key)
+}
+
+func TestStaking_UnstakeWithoutUnbondingPeriod(t *testing.T) {
+	t.Parallel()
+
+	c := newTestChain(t, nil, testPredeployParams, staker)
+	binding := NewStaking(AddrStakingContract, nil)
+
+	assert.Empty(t, c.call(staker, ether(2), nil))
+
+	vals, err := QueryValidators(c.transition, staker)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{staker}, vals)
+
+	// the stake is paid out right away
+	input := c.input(binding.EncodeUnstake())
+	assert.Empty(t, c.call(staker, big.NewInt(0), input))
+	assert.Equal(t, testBalance, c.balance(staker))
+
+	withdrawal, err := QueryPendingWithdrawal(c.transition, staker, staker)
+	require.NoError(t, err)
+	assert.Zero(t, withdrawal.Amount.Sign())
+
+	vals, err = QueryValidators(c.transition, staker)
+	require.NoError(t, err)
+	assert.Empty(t, vals)
+}
+
+func TestStaking_UnbondingPeriod(t *testing.T) {
+	t.Parallel()
+
+	params := testPredeployParams
+	params.UnbondingPeriod = 2
+	params.EpochSize = 10
+
+	c := newTestChain(t, nil, params, staker)
+	binding := NewStaking(AddrStakingContract, nil)
+
+	period, err := QueryUnbondingPeriod(c.transition, staker)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), period)
+
+	stake := c.input(binding.EncodeStake())
+	assert.Empty(t, c.call(staker, ether(2), stake))
+
+	c.advance(15)
+
+	unstake := c.input(binding.EncodeUnstake())
+	assert.Empty(t, c.call(staker, big.NewInt(0), unstake))
+	assert.Equal(t, new(big.Int).Sub(testBalance, ether(2)), c.balance(staker))
+
+	// the unstaked funds are released two epochs after the epoch 1
+	withdrawal, err := QueryPendingWithdrawal(c.transition, staker, staker)
+	require.NoError(t, err)
+	assert.Equal(t, &PendingWithdrawal{Amount: ether(2), ReleaseEpoch: 3}, withdrawal)
+
+	stakedAmount, err := QueryStakedAmount(c.transition, staker)
+	require.NoError(t, err)
+	assert.Zero(t, stakedAmount.Sign())
+
+	withdraw := c.input(binding.EncodeWithdraw())
+	assert.Equal(t, "Withdrawal is still in the unbonding period", c.call(staker, big.NewInt(0), withdraw))
+
+	c.advance(29)
+	assert.Equal(t, "Withdrawal is still in the unbonding period", c.call(staker, big.NewInt(0), withdraw))
+
+	c.advance(30)
+	assert.Empty(t, c.call(staker, big.NewInt(0), withdraw))
+	assert.Equal(t, testBalance, c.balance(staker))
+
+	withdrawal, err = QueryPendingWithdrawal(c.transition, staker, staker)
+	require.NoError(t, err)
+	assert.Zero(t, withdrawal.Amount.Sign())
+	assert.Zero(t, withdrawal.ReleaseEpoch)
+
+	assert.Equal(t, "No pending withdrawal", c.call(staker, big.NewInt(0), withdraw))
+}
+
+func TestStaking_UnbondingPeriod_Accumulates(t *testing.T) {
+	t.Parallel()
+
+	params := testPredeployParams
+	params.UnbondingPeriod = 1
+
+	c := newTestChain(t, nil, params, staker)
+	binding := NewStaking(AddrStakingContract, nil)
+
+	stake := c.input(binding.EncodeStake())
+	unstake := c.input(binding.EncodeUnstake())
+
+	// without epoch size, the epochs are the blocks
+	assert.Empty(t, c.call(staker, ether(1), stake))
+	assert.Empty(t, c.call(staker, big.NewInt(0), unstake))
+
+	c.advance(5)
+
+	assert.Equal(t, "Only staker can call function", c.call(staker, big.NewInt(0), unstake))
+	assert.Empty(t, c.call(staker, ether(2), stake))
+	assert.Empty(t, c.call(staker, big.NewInt(0), unstake))
+
+	// the release of the previous withdrawal is postponed
+	withdrawal, err := QueryPendingWithdrawal(c.transition, staker, staker)
+	require.NoError(t, err)
+	assert.Equal(t, &PendingWithdrawal{Amount: ether(3), ReleaseEpoch: 6}, withdrawal)
+}
+
+func TestStaking_ValidatorOwner(t *testing.T) {
+	t.Parallel()
+
+	owner := types.StringToAddress("d00d")
+
+	params := testPredeployParams
+	params.UnbondingPeriod = 1
+	params.Owners = map[types.Address]types.Address{
+		staker2: owner,
+	}
+
+	c := newTestChain(t, nil, params, staker, staker2, owner)
+	binding := NewStaking(AddrStakingContract, nil)
+
+	// the owners are registered at genesis
+	res, err := QueryValidatorOwner(c.transition, staker, staker2)
+	require.NoError(t, err)
+	assert.Equal(t, owner, res)
+
+	// the validator owns its stake without registered owner
+	res, err = QueryValidatorOwner(c.transition, staker, staker)
+	require.NoError(t, err)
+	assert.Equal(t, staker, res)
+
+	for _, invalid := range []types.Address{types.ZeroAddress, staker} {
+		assert.Equal(t, "Invalid owner", c.call(staker, big.NewInt(0), c.input(binding.EncodeRegisterOwner(invalid))))
+	}
+
+	assert.Empty(t, c.call(staker, big.NewInt(0), c.input(binding.EncodeRegisterOwner(owner))))
+	assert.Equal(t,
+		"Owner is already registered",
+		c.call(staker, big.NewInt(0), c.input(binding.EncodeRegisterOwner(staker2))),
+	)
+
+	res, err = QueryValidatorOwner(c.transition, staker, staker)
+	require.NoError(t, err)
+	assert.Equal(t, owner, res)
+
+	// only the owner unstakes and withdraws the stake of the validator
+	assert.Empty(t, c.call(staker, ether(2), c.input(binding.EncodeStake())))
+	assert.Equal(t, "Only the owner can call function", c.call(staker, big.NewInt(0), c.input(binding.EncodeUnstake())))
+	assert.Equal(t,
+		"Only the owner can call function",
+		c.call(staker2, big.NewInt(0), c.input(binding.EncodeUnstakeFor(staker))),
+	)
+	assert.Empty(t, c.call(owner, big.NewInt(0), c.input(binding.EncodeUnstakeFor(staker))))
+
+	withdrawal, err := QueryPendingWithdrawal(c.transition, owner, staker)
+	require.NoError(t, err)
+	assert.Equal(t, &PendingWithdrawal{Amount: ether(2), ReleaseEpoch: 1}, withdrawal)
+
+	c.advance(1)
+
+	assert.Equal(t, "Only the owner can call function", c.call(staker, big.NewInt(0), c.input(binding.EncodeWithdraw())))
+	assert.Empty(t, c.call(owner, big.NewInt(0), c.input(binding.EncodeWithdrawFor(staker))))
+
+	// the stake is paid out to the owner
+	assert.Equal(t, new(big.Int).Sub(testBalance, ether(2)), c.balance(staker))
+	assert.Equal(t, new(big.Int).Add(testBalance, ether(2)), c.balance(owner))
+}
+
+func TestStaking_MaximumValidatorExits(t *testing.T) {
+	t.Parallel()
+
+	params := testPredeployParams
+	params.EpochSize = 10
+	params.MaxValidatorExits = 1
+
+	c := newTestChain(t, nil, params, staker, staker2, staker3)
+	binding := NewStaking(AddrStakingContract, abi.NewExecutorCaller(c.transition, staker, queryGasLimit))
+
+	exits, err := binding.MaximumValidatorExits()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), exits)
+
+	stake := c.input(binding.EncodeStake())
+	unstake := c.input(binding.EncodeUnstake())
+
+	assert.Empty(t, c.call(staker, ether(1), stake))
+	assert.Empty(t, c.call(staker2, ether(1), stake))
+
+	// the stakers below the threshold aren't validators, they leave freely
+	assert.Empty(t, c.call(staker3, big.NewInt(1), stake))
+
+	c.advance(5)
+
+	assert.Empty(t, c.call(staker, big.NewInt(0), unstake))
+	assert.Equal(t, "Validator exits have reached the limit of the epoch", c.call(staker2, big.NewInt(0), unstake))
+	assert.Empty(t, c.call(staker3, big.NewInt(0), unstake))
+
+	c.advance(9)
+	assert.Equal(t, "Validator exits have reached the limit of the epoch", c.call(staker2, big.NewInt(0), unstake))
+
+	c.advance(10)
+	assert.Empty(t, c.call(staker2, big.NewInt(0), unstake))
+
+	vals, err := QueryValidators(c.transition, staker)
+	require.NoError(t, err)
+	assert.Empty(t, vals)
+}
+
+func TestStaking_StakeLimits(t *testing.T) {
+	t.Parallel()
+
+	params := testPredeployParams
+	params.MinSelfStake = ether(2)
+	params.MaxValidatorStake = ether(5)
+
+	c := newTestChain(t, nil, params, staker, staker2)
+	binding := NewStaking(AddrStakingContract, abi.NewExecutorCaller(c.transition, staker, queryGasLimit))
+
+	minSelfStake, err := binding.MinimumSelfStake()
+	require.NoError(t, err)
+	assert.Equal(t, ether(2), minSelfStake)
+
+	maxValidatorStake, err := binding.MaximumValidatorStake()
+	require.NoError(t, err)
+	assert.Equal(t, ether(5), maxValidatorStake)
+
+	stake := c.input(binding.EncodeStake())
+
+	assert.Equal(t, "Stake is below the minimum self-stake", c.call(staker, ether(1), stake))
+	assert.Equal(t, "Stake is below the minimum self-stake", c.call(staker, ether(1), nil))
+	assert.Equal(t, "Stake exceeds the maximum validator stake", c.call(staker2, ether(6), stake))
+
+	// the limits bound the total stake of the account
+	assert.Empty(t, c.call(staker, ether(2), stake))
+	assert.Empty(t, c.call(staker, ether(3), nil))
+	assert.Equal(t, "Stake exceeds the maximum validator stake", c.call(staker, big.NewInt(1), stake))
+
+	accountStake, err := QueryAccountStake(c.transition, staker, staker)
+	require.NoError(t, err)
+	assert.Equal(t, ether(5), accountStake)
+
+	vals, err := QueryValidators(c.transition, staker)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{staker}, vals)
+}
+
+func TestStaking_AutoCompound(t *testing.T) {
+	t.Parallel()
+
+	params := testPredeployParams
+	params.MaxValidatorStake = ether(2)
+
+	c := newTestChain(t, nil, params, staker, staker2, AddrSystem)
+	binding := NewStaking(AddrStakingContract, abi.NewExecutorCaller(c.transition, staker, queryGasLimit))
+
+	compound := func(account types.Address, amount *big.Int) string {
+		return c.call(AddrSystem, amount, c.input(binding.EncodeCompoundRewards(account)))
+	}
+
+	// only the owner of the account sets its auto-compounding
+	assert.Equal(t,
+		"Only the owner can call function",
+		c.call(staker2, big.NewInt(0), c.input(binding.EncodeSetAutoCompound(staker, true))),
+	)
+	assert.Empty(t, c.call(staker, big.NewInt(0), c.input(binding.EncodeSetAutoCompound(staker, true))))
+
+	enabled, err := binding.AutoCompound(staker)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	// only the system restakes the rewards, of the accounts with auto-compounding
+	assert.Equal(t,
+		"Only the system can call function",
+		c.call(staker, ether(1), c.input(binding.EncodeCompoundRewards(staker))),
+	)
+	assert.Equal(t, "Auto-compounding is not enabled", compound(staker2, ether(1)))
+
+	assert.Empty(t, compound(staker, ether(1)))
+	assert.Empty(t, compound(staker, ether(1)))
+
+	accountStake, err := QueryAccountStake(c.transition, staker, staker)
+	require.NoError(t, err)
+	assert.Equal(t, ether(2), accountStake)
+
+	compounded, err := binding.CompoundedRewards(staker)
+	require.NoError(t, err)
+	assert.Equal(t, ether(2), compounded)
+
+	vals, err := QueryValidators(c.transition, staker)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{staker}, vals)
+
+	// the restaked rewards are bound by the stake limits
+	assert.Equal(t, "Stake exceeds the maximum validator stake", compound(staker, big.NewInt(1)))
+
+	assert.Empty(t, c.call(staker, big.NewInt(0), c.input(binding.EncodeSetAutoCompound(staker, false))))
+	assert.Equal(t, "Auto-compounding is not enabled", compound(staker, big.NewInt(1)))
+}
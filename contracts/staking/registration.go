@@ -0,0 +1,59 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// BLSPublicKeySize is the size of a compressed BLS public key
+	BLSPublicKeySize = 48
+
+	// BLSProofOfPossessionSize is the size of a proof of possession, a compressed BLS signature
+	BLSProofOfPossessionSize = 96
+)
+
+var (
+	ErrInvalidBLSRegistration = errors.New("the BLS public key isn't registered with a valid proof of possession")
+)
+
+// EncodeBLSRegistration returns the bytes registered in the staking contract for a BLS key,
+// the public key followed by its proof of possession
+func EncodeBLSRegistration(pubkey, pop []byte) []byte {
+	registration := make([]byte, 0, len(pubkey)+len(pop))
+	registration = append(registration, pubkey...)
+
+	return append(registration, pop...)
+}
+
+// VerifyBLSRegistration verifies the proof of possession of the BLS key registered by the account
+// in the staking contract, and returns its public key
+func VerifyBLSRegistration(account types.Address, registration []byte) ([]byte, error) {
+	if len(registration) != BLSPublicKeySize+BLSProofOfPossessionSize {
+		return nil, fmt.Errorf("%w: unexpected length %d", ErrInvalidBLSRegistration, len(registration))
+	}
+
+	pubkey, pop := registration[:BLSPublicKeySize], registration[BLSPublicKeySize:]
+
+	if err := crypto.VerifyBLSProofOfPossession(pubkey, pop, account); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBLSRegistration, err)
+	}
+
+	return pubkey, nil
+}
+
+// EncodeRegisterBLSPublicKeyInput returns the call data registering the BLS public key of the account
+// with its proof of possession. The proof is verified first, as the validator sets ignore the keys
+// registered without a valid proof
+func EncodeRegisterBLSPublicKeyInput(account types.Address, pubkey, pop []byte) ([]byte, error) {
+	registration := EncodeBLSRegistration(pubkey, pop)
+
+	if _, err := VerifyBLSRegistration(account, registration); err != nil {
+		return nil, err
+	}
+
+	return NewStaking(AddrStakingContract, nil).EncodeRegisterBLSPublicKey(registration)
+}
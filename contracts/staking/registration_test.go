@@ -0,0 +1,44 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBLSRegistration(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateBLSKey()
+	require.NoError(t, err)
+
+	pubkey, err := crypto.BLSSecretKeyToPubkeyBytes(key)
+	require.NoError(t, err)
+
+	pop, err := crypto.SignBLSProofOfPossession(key, addr1)
+	require.NoError(t, err)
+
+	registration := EncodeBLSRegistration(pubkey, pop)
+
+	res, err := VerifyBLSRegistration(addr1, registration)
+	assert.NoError(t, err)
+	assert.Equal(t, pubkey, res)
+
+	// the proof of another account
+	_, err = VerifyBLSRegistration(addr2, registration)
+	assert.ErrorIs(t, err, ErrInvalidBLSRegistration)
+
+	// the bare public key
+	_, err = VerifyBLSRegistration(addr1, pubkey)
+	assert.ErrorIs(t, err, ErrInvalidBLSRegistration)
+
+	// the registration call data is only encoded for a valid proof
+	input, err := EncodeRegisterBLSPublicKeyInput(addr1, pubkey, pop)
+	assert.NoError(t, err)
+	assert.Equal(t, StakingABI.Methods["registerBLSPublicKey"].ID(), input[:4])
+
+	_, err = EncodeRegisterBLSPublicKeyInput(addr2, pubkey, pop)
+	assert.ErrorIs(t, err, ErrInvalidBLSRegistration)
+}
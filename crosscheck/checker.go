@@ -0,0 +1,315 @@
+package crosscheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+)
+
+// pollInterval is the interval the blocks the reference doesn't have yet are checked again
+var pollInterval = 2 * time.Second
+
+var ErrDiverged = errors.New("the chain diverged from the reference client")
+
+// Config is the configuration of the checker
+type Config struct {
+	// URL is the JSON-RPC endpoint of the reference client
+	URL string
+
+	// Halt refuses the blocks after the first divergence, until the node is restarted
+	Halt bool
+}
+
+// Blockchain is the interface of the blockchain the checker follows
+type Blockchain interface {
+	Header() *types.Header
+	GetBlockByNumber(number uint64, full bool) (*types.Block, bool)
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+	SubscribeEvents() blockchain.Subscription
+}
+
+// Divergence is an imported block which doesn't match the block of the reference
+type Divergence struct {
+	Number uint64
+	Hash   types.Hash
+
+	// Mismatches are the fields of the block which don't match
+	Mismatches []string
+
+	// TxHash is the first transaction whose receipt doesn't match, nil if not found
+	TxHash *types.Hash
+}
+
+func (d *Divergence) String() string {
+	s := fmt.Sprintf("block %d (%s): %v", d.Number, d.Hash, d.Mismatches)
+	if d.TxHash != nil {
+		s += fmt.Sprintf(", first diverging receipt of tx %s", *d.TxHash)
+	}
+
+	return s
+}
+
+// Checker compares the state roots and the receipts of the imported blocks with the blocks
+// of a reference client, to catch the consensus bugs of the custom forks early.
+// It is a gate of the blockchain, refusing the blocks after a divergence if it halts
+type Checker struct {
+	logger     hclog.Logger
+	blockchain Blockchain
+	reference  Reference
+	halt       bool
+
+	// next is the number of the next block to check
+	next uint64
+
+	// lock guards the divergence halting the chain
+	lock     sync.RWMutex
+	diverged *Divergence
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewChecker creates a checker comparing the imported blocks with the reference
+func NewChecker(logger hclog.Logger, config *Config, blockchain Blockchain, reference Reference) *Checker {
+	return &Checker{
+		logger:     logger.Named("crosscheck"),
+		blockchain: blockchain,
+		reference:  reference,
+		halt:       config.Halt,
+	}
+}
+
+// Start checks the blocks imported from now on
+func (c *Checker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.next = c.blockchain.Header().Number + 1
+
+	sub := c.blockchain.SubscribeEvents()
+
+	ticker := time.NewTicker(pollInterval)
+
+	c.wg.Add(1)
+
+	go func() {
+		defer c.wg.Done()
+		defer sub.Close()
+		defer ticker.Stop()
+
+		eventCh := sub.GetEventCh()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-eventCh:
+				if ev == nil {
+					return
+				}
+
+				if ev.Type == blockchain.EventFork {
+					// forks don't change the canonical chain
+					continue
+				}
+
+				// the new canonical blocks of a reorg are checked again
+				for _, header := range ev.NewChain {
+					if header.Number < c.next {
+						c.next = header.Number
+					}
+				}
+
+				c.sync(ctx)
+			case <-ticker.C:
+				c.sync(ctx)
+			}
+		}
+	}()
+}
+
+// Close stops the checker and closes the reference client
+func (c *Checker) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+
+	c.wg.Wait()
+
+	return c.reference.Close()
+}
+
+// CheckBlock returns ErrDiverged if the block of the given number is after a divergence
+// and the checker halts
+func (c *Checker) CheckBlock(number uint64) error {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.diverged != nil && number > c.diverged.Number {
+		return fmt.Errorf("%w at %s", ErrDiverged, c.diverged)
+	}
+
+	return nil
+}
+
+// Diverged returns the divergence halting the chain, nil if not halted
+func (c *Checker) Diverged() *Divergence {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.diverged
+}
+
+// sync checks the blocks imported since the last check, as long as the reference has them
+func (c *Checker) sync(ctx context.Context) {
+	for ; c.next <= c.blockchain.Header().Number; c.next++ {
+		if ctx.Err() != nil || c.Diverged() != nil {
+			return
+		}
+
+		block, ok := c.blockchain.GetBlockByNumber(c.next, true)
+		if !ok {
+			c.logger.Error("block not found", "number", c.next)
+
+			return
+		}
+
+		ref, err := c.reference.GetBlock(c.next)
+		if err != nil {
+			c.logger.Warn("failed to get reference block", "number", c.next, "err", err)
+
+			return
+		}
+
+		if ref == nil {
+			// the reference lags behind, the block is checked on the next poll
+			return
+		}
+
+		divergence, err := c.compare(block, ref)
+		if err != nil {
+			c.logger.Warn("failed to compare block", "number", c.next, "err", err)
+
+			return
+		}
+
+		metrics.IncrCounter([]string{"crosscheck", "checked_blocks"}, 1)
+
+		if divergence != nil {
+			c.onDivergence(divergence)
+		}
+	}
+}
+
+// compare returns the divergence of the block from the reference block, nil if they match
+func (c *Checker) compare(block *types.Block, ref *ReferenceBlock) (*Divergence, error) {
+	header := block.Header
+	mismatches := []string{}
+
+	if header.StateRoot != ref.StateRoot {
+		mismatches = append(mismatches, fmt.Sprintf("state root %s != %s", header.StateRoot, ref.StateRoot))
+	}
+
+	if header.ReceiptsRoot != ref.ReceiptsRoot {
+		mismatches = append(mismatches, fmt.Sprintf("receipts root %s != %s", header.ReceiptsRoot, ref.ReceiptsRoot))
+	}
+
+	if header.GasUsed != ref.GasUsed {
+		mismatches = append(mismatches, fmt.Sprintf("gas used %d != %d", header.GasUsed, ref.GasUsed))
+	}
+
+	if header.Hash != ref.Hash {
+		mismatches = append(mismatches, fmt.Sprintf("hash %s != %s", header.Hash, ref.Hash))
+	}
+
+	if len(mismatches) == 0 {
+		return nil, nil
+	}
+
+	divergence := &Divergence{
+		Number:     header.Number,
+		Hash:       header.Hash,
+		Mismatches: mismatches,
+	}
+
+	if header.ReceiptsRoot != ref.ReceiptsRoot {
+		txHash, err := c.findDivergingReceipt(block)
+		if err != nil {
+			return nil, err
+		}
+
+		divergence.TxHash = txHash
+	}
+
+	return divergence, nil
+}
+
+// findDivergingReceipt returns the first transaction of the block
+// whose receipt doesn't match the receipt of the reference
+func (c *Checker) findDivergingReceipt(block *types.Block) (*types.Hash, error) {
+	receipts, err := c.blockchain.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipts: %w", err)
+	}
+
+	for idx, receipt := range receipts {
+		if idx >= len(block.Transactions) {
+			break
+		}
+
+		txHash := block.Transactions[idx].Hash
+
+		ref, err := c.reference.GetReceipt(txHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reference receipt: %w", err)
+		}
+
+		var status uint64
+		if receipt.Status != nil {
+			status = uint64(*receipt.Status)
+		}
+
+		if ref == nil ||
+			ref.Status != status ||
+			ref.GasUsed != receipt.GasUsed ||
+			ref.CumulativeGasUsed != receipt.CumulativeGasUsed ||
+			ref.Logs != len(receipt.Logs) {
+			return &txHash, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Checker) onDivergence(divergence *Divergence) {
+	metrics.IncrCounter([]string{"crosscheck", "divergences"}, 1)
+
+	keyValues := []interface{}{
+		"number", divergence.Number,
+		"hash", divergence.Hash,
+		"mismatches", divergence.Mismatches,
+	}
+
+	if divergence.TxHash != nil {
+		keyValues = append(keyValues, "tx", *divergence.TxHash)
+	}
+
+	if !c.halt {
+		c.logger.Error("block diverged from the reference", keyValues...)
+
+		return
+	}
+
+	c.logger.Error("block diverged from the reference, halting the chain", keyValues...)
+
+	c.lock.Lock()
+	c.diverged = divergence
+	c.lock.Unlock()
+}
@@ -0,0 +1,173 @@
+package crosscheck
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBlockchain struct {
+	lock     sync.Mutex
+	sub      *blockchain.MockSubscription
+	blocks   []*types.Block
+	receipts map[types.Hash][]*types.Receipt
+}
+
+func (m *mockBlockchain) Header() *types.Header {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.blocks[len(m.blocks)-1].Header
+}
+
+func (m *mockBlockchain) GetBlockByNumber(number uint64, full bool) (*types.Block, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if number >= uint64(len(m.blocks)) {
+		return nil, false
+	}
+
+	return m.blocks[number], true
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.receipts[hash], nil
+}
+
+func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
+	return m.sub
+}
+
+// addBlock adds a block with a transaction, whose receipt used the given gas
+func (m *mockBlockchain) addBlock(stateRoot types.Hash, gasUsed uint64) *types.Block {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	parent := m.blocks[len(m.blocks)-1].Header
+
+	header := &types.Header{
+		Number:       parent.Number + 1,
+		ParentHash:   parent.Hash,
+		StateRoot:    stateRoot,
+		ReceiptsRoot: types.BytesToHash([]byte{byte(gasUsed)}),
+		GasUsed:      gasUsed,
+	}
+
+	block := &types.Block{
+		Header:       header.ComputeHash(),
+		Transactions: []*types.Transaction{{Hash: types.BytesToHash(append(header.Hash.Bytes(), 1))}},
+	}
+
+	receipt := &types.Receipt{GasUsed: gasUsed, CumulativeGasUsed: gasUsed}
+	receipt.SetStatus(types.ReceiptSuccess)
+
+	m.receipts[block.Hash()] = []*types.Receipt{receipt}
+	m.blocks = append(m.blocks, block)
+
+	return block
+}
+
+type mockReference struct {
+	lock     sync.Mutex
+	blocks   map[uint64]*ReferenceBlock
+	receipts map[types.Hash]*ReferenceReceipt
+}
+
+func (m *mockReference) GetBlock(number uint64) (*ReferenceBlock, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.blocks[number], nil
+}
+
+func (m *mockReference) GetReceipt(txHash types.Hash) (*ReferenceReceipt, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.receipts[txHash], nil
+}
+
+func (m *mockReference) Close() error {
+	return nil
+}
+
+// add adds the block to the reference, with the given state root and receipt gas
+func (m *mockReference) add(block *types.Block, stateRoot types.Hash, gasUsed uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.blocks[block.Number()] = &ReferenceBlock{
+		Hash:         block.Hash(),
+		StateRoot:    stateRoot,
+		ReceiptsRoot: types.BytesToHash([]byte{byte(gasUsed)}),
+		GasUsed:      gasUsed,
+	}
+
+	m.receipts[block.Transactions[0].Hash] = &ReferenceReceipt{
+		Status:            uint64(types.ReceiptSuccess),
+		GasUsed:           gasUsed,
+		CumulativeGasUsed: gasUsed,
+	}
+}
+
+func TestChecker_HaltsOnDivergence(t *testing.T) {
+	t.Parallel()
+
+	chain := &mockBlockchain{
+		sub:      blockchain.NewMockSubscription(),
+		blocks:   []*types.Block{{Header: (&types.Header{}).ComputeHash()}},
+		receipts: map[types.Hash][]*types.Receipt{},
+	}
+
+	reference := &mockReference{
+		blocks:   map[uint64]*ReferenceBlock{},
+		receipts: map[types.Hash]*ReferenceReceipt{},
+	}
+
+	checker := NewChecker(hclog.NewNullLogger(), &Config{Halt: true}, chain, reference)
+	checker.Start()
+
+	defer checker.Close()
+
+	root1 := types.StringToHash("1")
+	root2 := types.StringToHash("2")
+
+	// the reference doesn't have block 2 yet
+	block1 := chain.addBlock(root1, 100)
+	reference.add(block1, root1, 100)
+
+	block2 := chain.addBlock(root2, 100)
+
+	chain.sub.Push(&blockchain.Event{NewChain: []*types.Header{block2.Header}})
+
+	// the reference computed another state root and gas for block 2
+	reference.add(block2, types.StringToHash("3"), 200)
+
+	chain.sub.Push(&blockchain.Event{NewChain: []*types.Header{block2.Header}})
+
+	require.Eventually(t, func() bool {
+		return checker.Diverged() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	divergence := checker.Diverged()
+
+	assert.Equal(t, uint64(2), divergence.Number)
+	assert.Equal(t, block2.Hash(), divergence.Hash)
+	assert.Len(t, divergence.Mismatches, 3)
+	require.NotNil(t, divergence.TxHash)
+	assert.Equal(t, block2.Transactions[0].Hash, *divergence.TxHash)
+
+	// the blocks after the divergence are refused
+	assert.NoError(t, checker.CheckBlock(2))
+	assert.ErrorIs(t, checker.CheckBlock(3), ErrDiverged)
+}
@@ -0,0 +1,86 @@
+package crosscheck
+
+import (
+	"fmt"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Reference is the client the imported blocks are compared against
+type Reference interface {
+	// GetBlock returns the block of the number, nil if the reference doesn't have it yet
+	GetBlock(number uint64) (*ReferenceBlock, error)
+
+	// GetReceipt returns the receipt of the transaction, nil if not found
+	GetReceipt(txHash types.Hash) (*ReferenceReceipt, error)
+
+	Close() error
+}
+
+// ReferenceBlock are the fields of a block of the reference which are compared
+type ReferenceBlock struct {
+	Hash         types.Hash
+	StateRoot    types.Hash
+	ReceiptsRoot types.Hash
+	GasUsed      uint64
+}
+
+// ReferenceReceipt are the fields of a receipt of the reference which are compared
+type ReferenceReceipt struct {
+	Status            uint64
+	GasUsed           uint64
+	CumulativeGasUsed uint64
+	Logs              int
+}
+
+// JSONRPCReference reads the blocks of the reference client through its JSON-RPC endpoint
+type JSONRPCReference struct {
+	client *jsonrpc.Client
+}
+
+// NewJSONRPCReference connects to the JSON-RPC endpoint of the reference client
+func NewJSONRPCReference(url string) (*JSONRPCReference, error) {
+	client, err := jsonrpc.NewClient(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	return &JSONRPCReference{
+		client: client,
+	}, nil
+}
+
+func (r *JSONRPCReference) GetBlock(number uint64) (*ReferenceBlock, error) {
+	block, err := r.client.Eth().GetBlockByNumber(ethgo.BlockNumber(number), false)
+	if err != nil || block == nil {
+		return nil, err
+	}
+
+	return &ReferenceBlock{
+		Hash:         types.Hash(block.Hash),
+		StateRoot:    types.Hash(block.StateRoot),
+		ReceiptsRoot: types.Hash(block.ReceiptsRoot),
+		GasUsed:      block.GasUsed,
+	}, nil
+}
+
+func (r *JSONRPCReference) GetReceipt(txHash types.Hash) (*ReferenceReceipt, error) {
+	receipt, err := r.client.Eth().GetTransactionReceipt(ethgo.Hash(txHash))
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+
+	return &ReferenceReceipt{
+		Status:            receipt.Status,
+		GasUsed:           receipt.GasUsed,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		Logs:              len(receipt.Logs),
+	}, nil
+}
+
+func (r *JSONRPCReference) Close() error {
+	return r.client.Close()
+}
@@ -11,13 +11,13 @@ import (
 	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/0xPolygon/polygon-edge/helper/keystore"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
 	"github.com/umbracle/fastrlp"
-	"golang.org/x/crypto/sha3"
 )
 
 var (
@@ -32,6 +32,9 @@ var (
 	one        = []byte{0x01}
 
 	ErrInvalidBLSSignature = errors.New("invalid BLS Signature")
+
+	// blsPoPDomain separates the proofs of possession from the other messages signed by the BLS keys
+	blsPoPDomain = []byte("POLYGON_EDGE_BLS_POP_")
 )
 
 type KeyType string
@@ -228,6 +231,21 @@ func VerifyBLSSignatureFromBytes(rawPubkey, rawSig, message []byte) error {
 	return VerifyBLSSignature(pubkey, signature, message)
 }
 
+// SignBLSProofOfPossession signs the address by the BLS key, proving that the owner
+// of the address holds the secret key of the public key it registers
+func SignBLSProofOfPossession(prv *bls_sig.SecretKey, addr types.Address) ([]byte, error) {
+	return SignByBLS(prv, blsPoPMessage(addr))
+}
+
+// VerifyBLSProofOfPossession verifies the proof of possession of the BLS public key by the address
+func VerifyBLSProofOfPossession(rawPubkey, rawPoP []byte, addr types.Address) error {
+	return VerifyBLSSignatureFromBytes(rawPubkey, rawPoP, blsPoPMessage(addr))
+}
+
+func blsPoPMessage(addr types.Address) []byte {
+	return append(append([]byte{}, blsPoPDomain...), addr.Bytes()...)
+}
+
 // SigToPub returns the public key that created the given signature.
 func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
 	s, err := Ecrecover(hash, sig)
@@ -242,12 +260,7 @@ func SigToPub(hash, sig []byte) (*ecdsa.PublicKey, error) {
 
 // Keccak256 calculates the Keccak256
 func Keccak256(v ...[]byte) []byte {
-	h := sha3.NewLegacyKeccak256()
-	for _, i := range v {
-		h.Write(i)
-	}
-
-	return h.Sum(nil)
+	return keccak.Keccak256(nil, v...)
 }
 
 // PubKeyToAddress returns the Ethereum address of a public key
@@ -452,3 +465,23 @@ func ReadConsensusKey(manager secrets.SecretsManager) (*ecdsa.PrivateKey, error)
 
 	return BytesToECDSAPrivateKey(validatorKey)
 }
+
+// ReadValidatorOwnerKey reads the key of the owner of the validator, controlling its stake
+func ReadValidatorOwnerKey(manager secrets.SecretsManager) (*ecdsa.PrivateKey, error) {
+	ownerKey, err := manager.GetSecret(secrets.ValidatorOwnerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return BytesToECDSAPrivateKey(ownerKey)
+}
+
+// ReadBLSKey reads the BLS key of the validator
+func ReadBLSKey(manager secrets.SecretsManager) (*bls_sig.SecretKey, error) {
+	blsKey, err := manager.GetSecret(secrets.ValidatorBLSKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return BytesToBLSSecretKey(blsKey)
+}
@@ -255,3 +255,31 @@ func TestPrivateKeyGeneration(t *testing.T) {
 	assert.True(t, writtenKey.Equal(readKey))
 	assert.Equal(t, writtenAddress.String(), readAddress.String())
 }
+
+func TestBLSProofOfPossession(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+	)
+
+	key, err := GenerateBLSKey()
+	assert.NoError(t, err)
+
+	pubkey, err := BLSSecretKeyToPubkeyBytes(key)
+	assert.NoError(t, err)
+
+	pop, err := SignBLSProofOfPossession(key, addr1)
+	assert.NoError(t, err)
+
+	assert.NoError(t, VerifyBLSProofOfPossession(pubkey, pop, addr1))
+
+	// the proof is bound to the address
+	assert.ErrorIs(t, VerifyBLSProofOfPossession(pubkey, pop, addr2), ErrInvalidBLSSignature)
+
+	// a signature of the address alone isn't a proof
+	signature, err := SignByBLS(key, addr1.Bytes())
+	assert.NoError(t, err)
+	assert.ErrorIs(t, VerifyBLSProofOfPossession(pubkey, signature, addr1), ErrInvalidBLSSignature)
+}
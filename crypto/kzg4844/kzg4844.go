@@ -0,0 +1,132 @@
+package kzg4844
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+)
+
+const (
+	// BlobSize is the size of a blob, 4096 field elements of 32 bytes
+	BlobSize = len(gokzg4844.Blob{})
+
+	// CommitmentSize is the size of the KZG commitment to a blob, a compressed G1 point
+	CommitmentSize = len(gokzg4844.KZGCommitment{})
+
+	// ProofSize is the size of the KZG proof of a blob, a compressed G1 point
+	ProofSize = len(gokzg4844.KZGProof{})
+)
+
+var (
+	ErrInvalidSize  = errors.New("invalid size")
+	ErrInvalidProof = errors.New("invalid KZG proof")
+)
+
+var (
+	// context holds the trusted setup of the mainnet KZG ceremony,
+	// it's loaded on the first use as it takes a while
+	context     *gokzg4844.Context
+	contextOnce sync.Once
+)
+
+func getContext() *gokzg4844.Context {
+	contextOnce.Do(func() {
+		var err error
+
+		// the trusted setup is embedded in the library, it can't fail to load
+		if context, err = gokzg4844.NewContext4096Secure(); err != nil {
+			panic(fmt.Sprintf("unable to load the KZG trusted setup, %v", err))
+		}
+	})
+
+	return context
+}
+
+// toBlob returns the blob as the blob type of the library
+func toBlob(blob []byte) (gokzg4844.Blob, error) {
+	var res gokzg4844.Blob
+
+	if len(blob) != BlobSize {
+		return res, fmt.Errorf("%w: blob of %d bytes", ErrInvalidSize, len(blob))
+	}
+
+	copy(res[:], blob)
+
+	return res, nil
+}
+
+// toPoint returns the compressed G1 point of the commitment or the proof
+func toPoint(point []byte) ([CommitmentSize]byte, error) {
+	var res [CommitmentSize]byte
+
+	if len(point) != CommitmentSize {
+		return res, fmt.Errorf("%w: point of %d bytes", ErrInvalidSize, len(point))
+	}
+
+	copy(res[:], point)
+
+	return res, nil
+}
+
+// BlobToCommitment returns the KZG commitment to the polynomial of the blob
+func BlobToCommitment(blob []byte) ([]byte, error) {
+	b, err := toBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	commitment, err := getContext().BlobToKZGCommitment(b, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return commitment[:], nil
+}
+
+// ComputeBlobProof returns the KZG proof of the blob against its commitment,
+// the opening of the polynomial of the blob at the challenge derived from both
+func ComputeBlobProof(blob, commitment []byte) ([]byte, error) {
+	b, err := toBlob(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := toPoint(commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := getContext().ComputeBlobKZGProof(b, c, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return proof[:], nil
+}
+
+// VerifyBlobProof verifies that the blob matches the commitment with the KZG proof (EIP-4844).
+// The blob must hold canonical field elements, and the commitment and the proof valid G1 points
+func VerifyBlobProof(blob, commitment, proof []byte) error {
+	b, err := toBlob(blob)
+	if err != nil {
+		return err
+	}
+
+	c, err := toPoint(commitment)
+	if err != nil {
+		return err
+	}
+
+	p, err := toPoint(proof)
+	if err != nil {
+		return err
+	}
+
+	if err := getContext().VerifyBlobKZGProof(b, c, p); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+
+	return nil
+}
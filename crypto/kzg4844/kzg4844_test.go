@@ -0,0 +1,87 @@
+package kzg4844
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// randomBlob returns a blob of random field elements,
+// the first byte of each big endian element is cleared to keep it below the modulus
+func randomBlob(t *testing.T) []byte {
+	t.Helper()
+
+	blob := make([]byte, BlobSize)
+
+	_, err := rand.Read(blob)
+	require.NoError(t, err)
+
+	for i := 0; i < BlobSize; i += 32 {
+		blob[i] = 0
+	}
+
+	return blob
+}
+
+func TestVerifyBlobProof(t *testing.T) {
+	t.Parallel()
+
+	blob := randomBlob(t)
+
+	commitment, err := BlobToCommitment(blob)
+	require.NoError(t, err)
+	assert.Len(t, commitment, CommitmentSize)
+
+	proof, err := ComputeBlobProof(blob, commitment)
+	require.NoError(t, err)
+	assert.Len(t, proof, ProofSize)
+
+	assert.NoError(t, VerifyBlobProof(blob, commitment, proof))
+
+	// the proof of another blob
+	other := randomBlob(t)
+
+	otherCommitment, err := BlobToCommitment(other)
+	require.NoError(t, err)
+
+	otherProof, err := ComputeBlobProof(other, otherCommitment)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, VerifyBlobProof(blob, commitment, otherProof), ErrInvalidProof)
+	assert.ErrorIs(t, VerifyBlobProof(blob, otherCommitment, proof), ErrInvalidProof)
+
+	// a modified blob
+	blob[BlobSize-1]++
+	assert.ErrorIs(t, VerifyBlobProof(blob, commitment, proof), ErrInvalidProof)
+}
+
+func TestVerifyBlobProof_Invalid(t *testing.T) {
+	t.Parallel()
+
+	blob := randomBlob(t)
+
+	commitment, err := BlobToCommitment(blob)
+	require.NoError(t, err)
+
+	proof, err := ComputeBlobProof(blob, commitment)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, VerifyBlobProof(blob[1:], commitment, proof), ErrInvalidSize)
+	assert.ErrorIs(t, VerifyBlobProof(blob, commitment[1:], proof), ErrInvalidSize)
+	assert.ErrorIs(t, VerifyBlobProof(blob, commitment, append(proof, 0)), ErrInvalidSize)
+
+	// the field elements must be below the modulus
+	for i := 0; i < 32; i++ {
+		blob[i] = 0xff
+	}
+
+	assert.ErrorIs(t, VerifyBlobProof(blob, commitment, proof), ErrInvalidProof)
+
+	// the commitment must be a compressed point
+	_, err = BlobToCommitment(blob)
+	assert.Error(t, err)
+
+	assert.ErrorIs(t, VerifyBlobProof(randomBlob(t), make([]byte, CommitmentSize), proof), ErrInvalidProof)
+}
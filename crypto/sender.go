@@ -0,0 +1,142 @@
+package crypto
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// DefaultSenderCacheSize is the number of recovered senders kept by the sender cache
+const DefaultSenderCacheSize = 32768
+
+// SenderCache caches the senders recovered from the signatures of the transactions,
+// by transaction hash. The hash covers the signature, a sender is only reused for the same
+// signed transaction. It is populated by the first verification of a transaction, usually
+// its gossip, and looked up by the pool, the block import and the executor
+type SenderCache struct {
+	cache *lru.Cache
+}
+
+// NewSenderCache creates a sender cache holding up to size senders
+func NewSenderCache(size int) *SenderCache {
+	cache, _ := lru.New(size)
+
+	return &SenderCache{cache: cache}
+}
+
+// Get returns the cached sender of the transaction
+func (c *SenderCache) Get(hash types.Hash) (types.Address, bool) {
+	v, ok := c.cache.Get(hash)
+	if !ok {
+		metrics.IncrCounter([]string{"sender_cache", "misses"}, 1)
+
+		return types.ZeroAddress, false
+	}
+
+	metrics.IncrCounter([]string{"sender_cache", "hits"}, 1)
+
+	return v.(types.Address), true //nolint:forcetypeassert
+}
+
+// Add caches the sender recovered from the transaction
+func (c *SenderCache) Add(hash types.Hash, sender types.Address) {
+	c.cache.Add(hash, sender)
+}
+
+// CachedSigner is a signer looking up the sender cache before recovering the senders,
+// the recovered senders are added to it
+type CachedSigner struct {
+	TxSigner
+
+	cache *SenderCache
+}
+
+// NewCachedSigner wraps the signer with the sender cache. The signers sharing a cache
+// must recover the senders the same way
+func NewCachedSigner(signer TxSigner, cache *SenderCache) *CachedSigner {
+	return &CachedSigner{
+		TxSigner: signer,
+		cache:    cache,
+	}
+}
+
+// Sender returns the sender of the transaction, from the cache if it was already recovered
+func (s *CachedSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	if tx.Hash == types.ZeroHash {
+		return s.TxSigner.Sender(tx)
+	}
+
+	if sender, ok := s.cache.Get(tx.Hash); ok {
+		return sender, nil
+	}
+
+	sender, err := s.TxSigner.Sender(tx)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	s.cache.Add(tx.Hash, sender)
+
+	return sender, nil
+}
+
+// RecoverSenders sets the senders of the transactions whose sender isn't set yet,
+// recovered in parallel by a pool of workers. The senders of the transactions with
+// an invalid signature are left unset, the error is reported when they are processed
+func RecoverSenders(signer TxSigner, txs []*types.Transaction) {
+	pending := make([]*types.Transaction, 0, len(txs))
+
+	for _, tx := range txs {
+		if tx.From == types.ZeroAddress {
+			pending = append(pending, tx)
+		}
+	}
+
+	workers := runtime.NumCPU()
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+
+	if workers < 2 {
+		// not worth the workers
+		for _, tx := range pending {
+			if sender, err := signer.Sender(tx); err == nil {
+				tx.From = sender
+			}
+		}
+
+		return
+	}
+
+	var (
+		next int64 = -1
+		wg   sync.WaitGroup
+	)
+
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				idx := int(atomic.AddInt64(&next, 1))
+				if idx >= len(pending) {
+					return
+				}
+
+				tx := pending[idx]
+
+				if sender, err := signer.Sender(tx); err == nil {
+					tx.From = sender
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
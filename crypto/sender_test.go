@@ -0,0 +1,189 @@
+package crypto
+
+import (
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverSenders(t *testing.T) {
+	t.Parallel()
+
+	signer := NewEIP155Signer(100)
+
+	key, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sender := PubKeyToAddress(&key.PublicKey)
+	to := types.StringToAddress("1")
+
+	txs := make([]*types.Transaction, 0, 20)
+
+	for nonce := uint64(0); nonce < 20; nonce++ {
+		tx, err := signer.SignTx(&types.Transaction{
+			Nonce:    nonce,
+			To:       &to,
+			Value:    big.NewInt(1),
+			GasPrice: big.NewInt(1),
+			Gas:      21000,
+		}, key)
+		require.NoError(t, err)
+
+		txs = append(txs, tx.ComputeHash())
+	}
+
+	// the sender of the transaction with an invalid signature is left unset
+	txs[5].R = big.NewInt(0)
+
+	cache := NewSenderCache(DefaultSenderCacheSize)
+	cached := NewCachedSigner(signer, cache)
+
+	RecoverSenders(cached, txs)
+
+	for idx, tx := range txs {
+		if idx == 5 {
+			assert.Equal(t, types.ZeroAddress, tx.From)
+
+			continue
+		}
+
+		assert.Equal(t, sender, tx.From)
+
+		// the recovered senders are cached
+		cachedSender, ok := cache.Get(tx.Hash)
+		assert.True(t, ok)
+		assert.Equal(t, sender, cachedSender)
+	}
+
+	_, ok := cache.Get(txs[5].Hash)
+	assert.False(t, ok)
+}
+
+func TestCachedSigner_Sender(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSenderCache(DefaultSenderCacheSize)
+	cached := NewCachedSigner(NewEIP155Signer(100), cache)
+
+	tx := &types.Transaction{Hash: types.StringToHash("1"), V: big.NewInt(1)}
+
+	// the cached sender is returned without recovering the signature
+	cache.Add(tx.Hash, types.StringToAddress("2"))
+
+	sender, err := cached.Sender(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, types.StringToAddress("2"), sender)
+
+	// the transactions without hash are recovered
+	tx.Hash = types.ZeroHash
+
+	_, err = cached.Sender(tx)
+	assert.Error(t, err)
+}
+
+// recoveringSigner is a signer returning the sender set in the input of the transactions,
+// it counts the recoveries
+type recoveringSigner struct {
+	TxSigner
+
+	recoveries int64
+}
+
+var errTestRecovery = errors.New("recovery failed")
+
+func (s *recoveringSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	atomic.AddInt64(&s.recoveries, 1)
+
+	if len(tx.Input) == 0 {
+		return types.ZeroAddress, errTestRecovery
+	}
+
+	return types.BytesToAddress(tx.Input), nil
+}
+
+func TestRecoverSenders_Order(t *testing.T) {
+	t.Parallel()
+
+	var (
+		signer = &recoveringSigner{}
+		preset = types.StringToAddress("ff")
+		txs    = make([]*types.Transaction, 100)
+	)
+
+	for idx := range txs {
+		txs[idx] = &types.Transaction{
+			Nonce: uint64(idx),
+			Input: types.StringToAddress(big.NewInt(int64(idx + 1)).Text(16)).Bytes(),
+		}
+	}
+
+	// the senders already set aren't recovered again
+	txs[10].From = preset
+
+	// the failed recoveries leave the sender unset
+	txs[20].Input = nil
+	txs[30].Input = nil
+
+	RecoverSenders(signer, txs)
+
+	for idx, tx := range txs {
+		switch idx {
+		case 10:
+			assert.Equal(t, preset, tx.From)
+		case 20, 30:
+			assert.Equal(t, types.ZeroAddress, tx.From)
+		default:
+			// each transaction gets its own sender, whatever the worker recovering it
+			assert.Equal(t, types.BytesToAddress(tx.Input), tx.From, idx)
+		}
+	}
+
+	assert.Equal(t, int64(len(txs)-1), atomic.LoadInt64(&signer.recoveries))
+
+	// the error is returned when the transaction is processed
+	_, err := signer.Sender(txs[20])
+	assert.ErrorIs(t, err, errTestRecovery)
+}
+
+func TestCachedSigner_HitAndMiss(t *testing.T) {
+	t.Parallel()
+
+	var (
+		signer = &recoveringSigner{}
+		cached = NewCachedSigner(signer, NewSenderCache(DefaultSenderCacheSize))
+		tx     = &types.Transaction{
+			Hash:  types.StringToHash("1"),
+			Input: types.StringToAddress("2").Bytes(),
+		}
+	)
+
+	// the first lookup misses and recovers the sender
+	sender, err := cached.Sender(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, types.StringToAddress("2"), sender)
+	assert.Equal(t, int64(1), signer.recoveries)
+
+	// the next lookups hit the cache
+	for i := 0; i < 3; i++ {
+		sender, err = cached.Sender(tx)
+		assert.NoError(t, err)
+		assert.Equal(t, types.StringToAddress("2"), sender)
+	}
+
+	assert.Equal(t, int64(1), signer.recoveries)
+
+	// the failed recoveries aren't cached, the error is returned on every lookup
+	failing := &types.Transaction{Hash: types.StringToHash("3")}
+
+	for i := 0; i < 2; i++ {
+		_, err = cached.Sender(failing)
+		assert.ErrorIs(t, err, errTestRecovery)
+	}
+
+	assert.Equal(t, int64(3), signer.recoveries)
+}
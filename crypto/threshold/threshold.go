@@ -0,0 +1,437 @@
+// Package threshold implements the threshold encryption of the encrypted mempool.
+// Messages are encrypted to an aggregate secp256k1 key whose private key is split
+// in Shamir shares: any threshold of share holders decrypt them together, while fewer
+// learn nothing. Each decryption share comes with a proof of its correctness, so invalid
+// shares are rejected before combining them.
+package threshold
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/btcsuite/btcd/btcec"
+)
+
+const (
+	// pointSize is the size of a compressed curve point
+	pointSize = 33
+
+	// scalarSize is the size of a scalar modulo the curve order
+	scalarSize = 32
+
+	// nonceSize is the size of the AES-GCM nonce
+	nonceSize = 12
+
+	// shareSize is the size of a marshaled Share
+	shareSize = 8 + scalarSize
+
+	// DecryptionShareSize is the size of a marshaled DecryptionShare
+	DecryptionShareSize = 8 + pointSize + 2*scalarSize
+)
+
+var (
+	ErrInvalidThreshold       = errors.New("threshold must be between 1 and the number of shares")
+	ErrInvalidShare           = errors.New("invalid key share")
+	ErrInvalidPoint           = errors.New("invalid curve point")
+	ErrInvalidCiphertext      = errors.New("invalid ciphertext")
+	ErrInvalidProof           = errors.New("invalid decryption share proof")
+	ErrNotEnoughShares        = errors.New("not enough decryption shares")
+	ErrDuplicateShare         = errors.New("duplicate decryption share")
+	ErrDecryptionFailed       = errors.New("decryption failed")
+	ErrInvalidDecryptionShare = errors.New("invalid decryption share")
+)
+
+var curve = btcec.S256()
+
+// point is an affine point of the curve
+type point struct {
+	x, y *big.Int
+}
+
+func parsePoint(buf []byte) (*point, error) {
+	if len(buf) != pointSize {
+		return nil, ErrInvalidPoint
+	}
+
+	pub, err := btcec.ParsePubKey(buf, curve)
+	if err != nil {
+		return nil, ErrInvalidPoint
+	}
+
+	return &point{pub.X, pub.Y}, nil
+}
+
+func (p *point) marshal() []byte {
+	return (&btcec.PublicKey{Curve: curve, X: p.x, Y: p.y}).SerializeCompressed()
+}
+
+func (p *point) mul(k *big.Int) *point {
+	x, y := curve.ScalarMult(p.x, p.y, k.Bytes())
+
+	return &point{x, y}
+}
+
+func (p *point) add(q *point) *point {
+	x, y := curve.Add(p.x, p.y, q.x, q.y)
+
+	return &point{x, y}
+}
+
+func (p *point) sub(q *point) *point {
+	return p.add(&point{q.x, new(big.Int).Sub(curve.P, q.y)})
+}
+
+func baseMul(k *big.Int) *point {
+	x, y := curve.ScalarBaseMult(k.Bytes())
+
+	return &point{x, y}
+}
+
+func randScalar() (*big.Int, error) {
+	for {
+		k, err := rand.Int(rand.Reader, curve.N)
+		if err != nil {
+			return nil, err
+		}
+
+		if k.Sign() != 0 {
+			return k, nil
+		}
+	}
+}
+
+func scalarBytes(k *big.Int) []byte {
+	buf := make([]byte, scalarSize)
+
+	return k.FillBytes(buf)
+}
+
+// Share is the share of the aggregate private key held by a validator
+type Share struct {
+	// Index is the x coordinate of the share in the Shamir polynomial, starting at 1
+	Index uint64
+
+	// Key is the share of the private key
+	Key *big.Int
+}
+
+// Public returns the compressed public key of the share, used to verify its decryption shares
+func (s *Share) Public() []byte {
+	return baseMul(s.Key).marshal()
+}
+
+// Marshal encodes the share as its index followed by its key
+func (s *Share) Marshal() []byte {
+	buf := make([]byte, 8, shareSize)
+	binary.BigEndian.PutUint64(buf, s.Index)
+
+	return append(buf, scalarBytes(s.Key)...)
+}
+
+// UnmarshalShare decodes a share encoded by Marshal
+func UnmarshalShare(buf []byte) (*Share, error) {
+	if len(buf) != shareSize {
+		return nil, ErrInvalidShare
+	}
+
+	share := &Share{
+		Index: binary.BigEndian.Uint64(buf[:8]),
+		Key:   new(big.Int).SetBytes(buf[8:]),
+	}
+
+	if share.Index == 0 || share.Key.Sign() == 0 || share.Key.Cmp(curve.N) >= 0 {
+		return nil, ErrInvalidShare
+	}
+
+	return share, nil
+}
+
+// GenerateShares generates an aggregate key split in the given number of shares,
+// any threshold of them decrypting the messages encrypted to the returned public key
+func GenerateShares(threshold, total int) ([]byte, []*Share, error) {
+	if threshold < 1 || threshold > total {
+		return nil, nil, ErrInvalidThreshold
+	}
+
+	// the private key is the constant term of a random polynomial of degree threshold-1
+	coefficients := make([]*big.Int, threshold)
+
+	for i := range coefficients {
+		k, err := randScalar()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		coefficients[i] = k
+	}
+
+	shares := make([]*Share, total)
+
+	for i := range shares {
+		index := big.NewInt(int64(i + 1))
+		key := new(big.Int)
+
+		for j := len(coefficients) - 1; j >= 0; j-- {
+			key.Mul(key, index)
+			key.Add(key, coefficients[j])
+			key.Mod(key, curve.N)
+		}
+
+		shares[i] = &Share{Index: uint64(i + 1), Key: key}
+	}
+
+	return baseMul(coefficients[0]).marshal(), shares, nil
+}
+
+// deriveKey derives the AES key from the shared point
+func deriveKey(shared *point) []byte {
+	return keccak.Keccak256(nil, shared.marshal())
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Encrypt encrypts the message to the given aggregate public key. The ciphertext
+// is made of an ephemeral public key, the AES-GCM nonce and the sealed message
+func Encrypt(publicKey []byte, msg []byte) ([]byte, error) {
+	pub, err := parsePoint(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := randScalar()
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(deriveKey(pub.mul(r)))
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	ciphertext := append(baseMul(r).marshal(), nonce...)
+
+	return gcm.Seal(ciphertext, nonce, msg, nil), nil
+}
+
+// ephemeralKey returns the ephemeral public key of the ciphertext
+func ephemeralKey(ciphertext []byte) (*point, error) {
+	if len(ciphertext) < pointSize+nonceSize {
+		return nil, ErrInvalidCiphertext
+	}
+
+	return parsePoint(ciphertext[:pointSize])
+}
+
+// ValidateCiphertext checks that the ciphertext is well formed, otherwise it can't be decrypted
+func ValidateCiphertext(ciphertext []byte) error {
+	_, err := ephemeralKey(ciphertext)
+
+	return err
+}
+
+// DecryptionShare is the share of a validator decrypting a ciphertext,
+// with a proof that it was computed with the share of the aggregate key
+type DecryptionShare struct {
+	Index uint64
+	Point []byte
+
+	// C and Z are the Chaum-Pedersen proof of the equality of the discrete
+	// logarithms of the decryption share and of the public key share
+	C *big.Int
+	Z *big.Int
+}
+
+// Marshal encodes the decryption share in DecryptionShareSize bytes
+func (d *DecryptionShare) Marshal() []byte {
+	buf := make([]byte, 8, DecryptionShareSize)
+	binary.BigEndian.PutUint64(buf, d.Index)
+
+	buf = append(buf, d.Point...)
+	buf = append(buf, scalarBytes(d.C)...)
+
+	return append(buf, scalarBytes(d.Z)...)
+}
+
+// UnmarshalDecryptionShare decodes a decryption share encoded by Marshal
+func UnmarshalDecryptionShare(buf []byte) (*DecryptionShare, error) {
+	if len(buf) != DecryptionShareSize {
+		return nil, ErrInvalidDecryptionShare
+	}
+
+	offset := 8 + pointSize
+
+	return &DecryptionShare{
+		Index: binary.BigEndian.Uint64(buf[:8]),
+		Point: append([]byte{}, buf[8:offset]...),
+		C:     new(big.Int).SetBytes(buf[offset : offset+scalarSize]),
+		Z:     new(big.Int).SetBytes(buf[offset+scalarSize:]),
+	}, nil
+}
+
+// proofChallenge returns the challenge of the proof binding the public key share,
+// the ephemeral key, the decryption share and the commitments of the prover
+func proofChallenge(public, ephemeral, decrypted, commitG, commitR *point) *big.Int {
+	hash := keccak.Keccak256(
+		nil,
+		public.marshal(),
+		ephemeral.marshal(),
+		decrypted.marshal(),
+		commitG.marshal(),
+		commitR.marshal(),
+	)
+
+	return new(big.Int).Mod(new(big.Int).SetBytes(hash), curve.N)
+}
+
+// Decrypt returns the decryption share of the ciphertext
+func (s *Share) Decrypt(ciphertext []byte) (*DecryptionShare, error) {
+	ephemeral, err := ephemeralKey(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted := ephemeral.mul(s.Key)
+
+	k, err := randScalar()
+	if err != nil {
+		return nil, err
+	}
+
+	c := proofChallenge(baseMul(s.Key), ephemeral, decrypted, baseMul(k), ephemeral.mul(k))
+
+	// z = k + c * key
+	z := new(big.Int).Mul(c, s.Key)
+	z.Add(z, k)
+	z.Mod(z, curve.N)
+
+	return &DecryptionShare{
+		Index: s.Index,
+		Point: decrypted.marshal(),
+		C:     c,
+		Z:     z,
+	}, nil
+}
+
+// Verify checks that the decryption share of the ciphertext was computed
+// with the share whose public key is given
+func (d *DecryptionShare) Verify(publicShare []byte, ciphertext []byte) error {
+	public, err := parsePoint(publicShare)
+	if err != nil {
+		return err
+	}
+
+	ephemeral, err := ephemeralKey(ciphertext)
+	if err != nil {
+		return err
+	}
+
+	decrypted, err := parsePoint(d.Point)
+	if err != nil {
+		return err
+	}
+
+	if d.C.Cmp(curve.N) >= 0 || d.Z.Cmp(curve.N) >= 0 {
+		return ErrInvalidProof
+	}
+
+	// the commitments are recovered as z*G - c*X and z*R - c*D
+	commitG := baseMul(d.Z).sub(public.mul(d.C))
+	commitR := ephemeral.mul(d.Z).sub(decrypted.mul(d.C))
+
+	if proofChallenge(public, ephemeral, decrypted, commitG, commitR).Cmp(d.C) != 0 {
+		return ErrInvalidProof
+	}
+
+	return nil
+}
+
+// lagrangeCoefficient returns the coefficient of the given index
+// interpolating the polynomial at 0 from the given indexes
+func lagrangeCoefficient(index uint64, indexes []uint64) *big.Int {
+	num, den := big.NewInt(1), big.NewInt(1)
+	xi := new(big.Int).SetUint64(index)
+
+	for _, other := range indexes {
+		if other == index {
+			continue
+		}
+
+		xj := new(big.Int).SetUint64(other)
+
+		num.Mul(num, xj)
+		num.Mod(num, curve.N)
+
+		den.Mul(den, new(big.Int).Sub(xj, xi))
+		den.Mod(den, curve.N)
+	}
+
+	return num.Mul(num, den.ModInverse(den, curve.N)).Mod(num, curve.N)
+}
+
+// Combine decrypts the ciphertext from the threshold first decryption shares,
+// which must have been verified beforehand
+func Combine(ciphertext []byte, shares []*DecryptionShare, threshold int) ([]byte, error) {
+	if threshold < 1 || len(shares) < threshold {
+		return nil, ErrNotEnoughShares
+	}
+
+	if _, err := ephemeralKey(ciphertext); err != nil {
+		return nil, err
+	}
+
+	shares = shares[:threshold]
+	indexes := make([]uint64, len(shares))
+
+	for i, share := range shares {
+		for _, index := range indexes[:i] {
+			if index == share.Index {
+				return nil, ErrDuplicateShare
+			}
+		}
+
+		indexes[i] = share.Index
+	}
+
+	// the shared point is interpolated from the decryption shares
+	shared := &point{new(big.Int), new(big.Int)}
+
+	for _, share := range shares {
+		decrypted, err := parsePoint(share.Point)
+		if err != nil {
+			return nil, err
+		}
+
+		shared = shared.add(decrypted.mul(lagrangeCoefficient(share.Index, indexes)))
+	}
+
+	gcm, err := newGCM(deriveKey(shared))
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := ciphertext[pointSize : pointSize+nonceSize]
+
+	msg, err := gcm.Open(nil, nonce, ciphertext[pointSize+nonceSize:], nil)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+
+	return msg, nil
+}
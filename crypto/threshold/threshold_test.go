@@ -0,0 +1,100 @@
+package threshold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decryptionShares(t *testing.T, shares []*Share, ciphertext []byte) []*DecryptionShare {
+	t.Helper()
+
+	res := make([]*DecryptionShare, len(shares))
+
+	for i, share := range shares {
+		decryptionShare, err := share.Decrypt(ciphertext)
+		require.NoError(t, err)
+		require.NoError(t, decryptionShare.Verify(share.Public(), ciphertext))
+
+		res[i] = decryptionShare
+	}
+
+	return res
+}
+
+func TestThreshold_EncryptCombine(t *testing.T) {
+	t.Parallel()
+
+	publicKey, shares, err := GenerateShares(3, 4)
+	require.NoError(t, err)
+
+	msg := []byte("the hidden transaction")
+
+	ciphertext, err := Encrypt(publicKey, msg)
+	require.NoError(t, err)
+
+	decrypted := decryptionShares(t, shares, ciphertext)
+
+	// any threshold of shares decrypt the message
+	for _, subset := range [][]*DecryptionShare{
+		decrypted[:3],
+		decrypted[1:],
+		{decrypted[3], decrypted[0], decrypted[2]},
+	} {
+		res, err := Combine(ciphertext, subset, 3)
+		require.NoError(t, err)
+		assert.Equal(t, msg, res)
+	}
+
+	// fewer shares can't
+	_, err = Combine(ciphertext, decrypted[:2], 3)
+	assert.ErrorIs(t, err, ErrNotEnoughShares)
+
+	_, err = Combine(ciphertext, decrypted[:2], 2)
+	assert.ErrorIs(t, err, ErrDecryptionFailed)
+
+	_, err = Combine(ciphertext, []*DecryptionShare{decrypted[0], decrypted[0], decrypted[1]}, 3)
+	assert.ErrorIs(t, err, ErrDuplicateShare)
+}
+
+func TestThreshold_VerifyShare(t *testing.T) {
+	t.Parallel()
+
+	publicKey, shares, err := GenerateShares(2, 3)
+	require.NoError(t, err)
+
+	ciphertext, err := Encrypt(publicKey, []byte{0x1})
+	require.NoError(t, err)
+
+	decrypted, err := shares[0].Decrypt(ciphertext)
+	require.NoError(t, err)
+
+	// the share is bound to the public key of its share
+	assert.NoError(t, decrypted.Verify(shares[0].Public(), ciphertext))
+	assert.ErrorIs(t, decrypted.Verify(shares[1].Public(), ciphertext), ErrInvalidProof)
+
+	// and to the ciphertext
+	otherCiphertext, err := Encrypt(publicKey, []byte{0x1})
+	require.NoError(t, err)
+	assert.ErrorIs(t, decrypted.Verify(shares[0].Public(), otherCiphertext), ErrInvalidProof)
+
+	// the marshaled share is verified the same way
+	unmarshaled, err := UnmarshalDecryptionShare(decrypted.Marshal())
+	require.NoError(t, err)
+	assert.Equal(t, decrypted, unmarshaled)
+
+	share, err := UnmarshalShare(shares[2].Marshal())
+	require.NoError(t, err)
+	assert.Equal(t, shares[2].Public(), share.Public())
+}
+
+func TestThreshold_InvalidThreshold(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := GenerateShares(0, 3)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+
+	_, _, err = GenerateShares(4, 3)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+}
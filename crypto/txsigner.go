@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/bits"
@@ -25,8 +26,22 @@ type TxSigner interface {
 
 	// CalculateV calculates the V value based on the type of signer used
 	CalculateV(parity byte) []byte
+
+	// Sponsor returns the sponsor paying the gas of a sponsored transaction,
+	// whose sender must already be recovered
+	Sponsor(tx *types.Transaction) (types.Address, error)
+
+	// SignSponsor signs the transaction as its sponsor, before the sender signs it
+	SignSponsor(tx *types.Transaction, priv *ecdsa.PrivateKey) (*types.Transaction, error)
 }
 
+var (
+	ErrNotSponsored            = errors.New("transaction is not sponsored")
+	ErrInvalidSponsorSignature = errors.New("invalid sponsor signature")
+	ErrTxTypeNotSupported      = errors.New("transaction type not supported")
+	ErrInvalidChainID          = errors.New("invalid chain id")
+)
+
 // NewSigner creates a new signer object (EIP155 or FrontierSigner)
 func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 	var signer TxSigner
@@ -70,6 +85,106 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 		v.Set(a.NewUint(0))
 	}
 
+	// the sender of a sponsored transaction commits to the sponsor signature,
+	// so that the transaction can't be sent without it
+	if tx.IsSponsored() {
+		v.Set(a.NewBigInt(tx.SponsorV))
+		v.Set(a.NewBigInt(tx.SponsorR))
+		v.Set(a.NewBigInt(tx.SponsorS))
+	}
+
+	// the expiry can't be changed or removed without the sender
+	if tx.ValidUntil != 0 {
+		v.Set(a.NewUint(tx.ValidUntil))
+	}
+
+	hash := keccak.Keccak256Rlp(nil, v)
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// calcBlobTxHash calculates the hash signed by the sender of a blob transaction,
+// the keccak256 hash of its type followed by the RLP value of its unsigned payload
+func calcBlobTxHash(tx *types.Transaction) types.Hash {
+	a := signerPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewBigInt(tx.ChainID))
+	v.Set(a.NewUint(tx.Nonce))
+	v.Set(a.NewBigInt(tx.GasTipCap))
+	v.Set(a.NewBigInt(tx.GasPrice))
+	v.Set(a.NewUint(tx.Gas))
+
+	if tx.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tx.To).Bytes()))
+	}
+
+	v.Set(a.NewBigInt(tx.Value))
+	v.Set(a.NewCopyBytes(tx.Input))
+
+	accessList := a.NewNullArray()
+	if len(tx.AccessList) > 0 {
+		accessList = a.NewArray()
+	}
+
+	for _, tuple := range tx.AccessList {
+		keys := a.NewArray()
+		for _, key := range tuple.StorageKeys {
+			keys.Set(a.NewCopyBytes(key.Bytes()))
+		}
+
+		entry := a.NewArray()
+		entry.Set(a.NewCopyBytes(tuple.Address.Bytes()))
+		entry.Set(keys)
+		accessList.Set(entry)
+	}
+
+	v.Set(accessList)
+	v.Set(a.NewBigInt(tx.BlobFeeCap))
+
+	hashes := a.NewArray()
+	for _, hash := range tx.BlobHashes {
+		hashes.Set(a.NewCopyBytes(hash.Bytes()))
+	}
+
+	v.Set(hashes)
+
+	hash := keccak.Keccak256(nil, v.MarshalTo([]byte{byte(types.BlobTx)}))
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// calcSponsorHash calculates the hash signed by the sponsor of the transaction,
+// committing to the transaction fields, its sender and the chain
+func calcSponsorHash(tx *types.Transaction, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewUint(tx.Nonce))
+	v.Set(a.NewBigInt(tx.GasPrice))
+	v.Set(a.NewUint(tx.Gas))
+
+	if tx.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tx.To).Bytes()))
+	}
+
+	v.Set(a.NewBigInt(tx.Value))
+	v.Set(a.NewCopyBytes(tx.Input))
+	v.Set(a.NewCopyBytes(tx.From.Bytes()))
+	v.Set(a.NewUint(chainID))
+
+	if tx.ValidUntil != 0 {
+		v.Set(a.NewUint(tx.ValidUntil))
+	}
+
 	hash := keccak.Keccak256Rlp(nil, v)
 
 	signerPool.Put(a)
@@ -77,6 +192,49 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	return types.BytesToHash(hash)
 }
 
+// signSponsor signs the transaction as the sponsor paying its gas,
+// before the sender signs it with the sponsor signature
+func signSponsor(tx *types.Transaction, chainID uint64, privateKey *ecdsa.PrivateKey) (*types.Transaction, error) {
+	tx = tx.Copy()
+
+	h := calcSponsorHash(tx, chainID)
+
+	sig, err := Sign(privateKey, h[:])
+	if err != nil {
+		return nil, err
+	}
+
+	tx.SponsorR = new(big.Int).SetBytes(sig[:32])
+	tx.SponsorS = new(big.Int).SetBytes(sig[32:64])
+	tx.SponsorV = new(big.Int).Add(big.NewInt(int64(sig[64])), big27)
+
+	return tx, nil
+}
+
+// recoverSponsor returns the sponsor of the transaction, whose sender must be known
+func recoverSponsor(tx *types.Transaction, chainID uint64) (types.Address, error) {
+	if !tx.IsSponsored() || tx.SponsorV == nil {
+		return types.ZeroAddress, ErrNotSponsored
+	}
+
+	refV := new(big.Int).Sub(tx.SponsorV, big27)
+	if !refV.IsUint64() || refV.Uint64() > 1 {
+		return types.ZeroAddress, ErrInvalidSponsorSignature
+	}
+
+	sig, err := encodeSignature(tx.SponsorR, tx.SponsorS, byte(refV.Uint64()))
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	pub, err := Ecrecover(calcSponsorHash(tx, chainID).Bytes(), sig)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return types.BytesToAddress(Keccak256(pub[1:])[12:]), nil
+}
+
 // Hash is a wrapper function for the calcTxHash, with chainID 0
 func (f *FrontierSigner) Hash(tx *types.Transaction) types.Hash {
 	return calcTxHash(tx, 0)
@@ -90,6 +248,10 @@ var (
 
 // Sender decodes the signature and returns the sender of the transaction
 func (f *FrontierSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	if tx.Type != types.LegacyTx {
+		return types.Address{}, ErrTxTypeNotSupported
+	}
+
 	refV := big.NewInt(0)
 	if tx.V != nil {
 		refV.SetBytes(tx.V.Bytes())
@@ -117,6 +279,10 @@ func (f *FrontierSigner) SignTx(
 	tx *types.Transaction,
 	privateKey *ecdsa.PrivateKey,
 ) (*types.Transaction, error) {
+	if tx.Type != types.LegacyTx {
+		return nil, ErrTxTypeNotSupported
+	}
+
 	tx = tx.Copy()
 
 	h := f.Hash(tx)
@@ -133,6 +299,19 @@ func (f *FrontierSigner) SignTx(
 	return tx, nil
 }
 
+// Sponsor returns the sponsor of the transaction
+func (f *FrontierSigner) Sponsor(tx *types.Transaction) (types.Address, error) {
+	return recoverSponsor(tx, 0)
+}
+
+// SignSponsor signs the transaction as its sponsor
+func (f *FrontierSigner) SignSponsor(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	return signSponsor(tx, 0, privateKey)
+}
+
 // calculateV returns the V value for transactions pre EIP155
 func (f *FrontierSigner) CalculateV(parity byte) []byte {
 	reference := big.NewInt(int64(parity))
@@ -152,11 +331,23 @@ type EIP155Signer struct {
 
 // Hash is a wrapper function that calls calcTxHash with the EIP155Signer's chainID
 func (e *EIP155Signer) Hash(tx *types.Transaction) types.Hash {
+	if tx.Type == types.BlobTx {
+		return calcBlobTxHash(tx)
+	}
+
 	return calcTxHash(tx, e.chainID)
 }
 
 // Sender returns the transaction sender
 func (e *EIP155Signer) Sender(tx *types.Transaction) (types.Address, error) {
+	switch tx.Type {
+	case types.LegacyTx:
+	case types.BlobTx:
+		return e.blobTxSender(tx)
+	default:
+		return types.Address{}, ErrTxTypeNotSupported
+	}
+
 	protected := true
 
 	// Check if v value conforms to an earlier standard (before EIP155)
@@ -201,6 +392,10 @@ func (e *EIP155Signer) SignTx(
 ) (*types.Transaction, error) {
 	tx = tx.Copy()
 
+	if tx.Type == types.BlobTx {
+		tx.ChainID = new(big.Int).SetUint64(e.chainID)
+	}
+
 	h := e.Hash(tx)
 
 	sig, err := Sign(privateKey, h[:])
@@ -210,11 +405,53 @@ func (e *EIP155Signer) SignTx(
 
 	tx.R = new(big.Int).SetBytes(sig[:32])
 	tx.S = new(big.Int).SetBytes(sig[32:64])
-	tx.V = new(big.Int).SetBytes(e.CalculateV(sig[64]))
+
+	// the V value of a typed transaction is the parity of the signature
+	if tx.Type == types.BlobTx {
+		tx.V = new(big.Int).SetUint64(uint64(sig[64]))
+	} else {
+		tx.V = new(big.Int).SetBytes(e.CalculateV(sig[64]))
+	}
 
 	return tx, nil
 }
 
+// blobTxSender returns the sender of a blob transaction, signed for the chain
+func (e *EIP155Signer) blobTxSender(tx *types.Transaction) (types.Address, error) {
+	if tx.ChainID == nil || !tx.ChainID.IsUint64() || tx.ChainID.Uint64() != e.chainID {
+		return types.Address{}, ErrInvalidChainID
+	}
+
+	if tx.V == nil || !tx.V.IsUint64() || tx.V.Uint64() > 1 {
+		return types.Address{}, fmt.Errorf("invalid txn signature")
+	}
+
+	sig, err := encodeSignature(tx.R, tx.S, byte(tx.V.Uint64()))
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	pub, err := Ecrecover(e.Hash(tx).Bytes(), sig)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	return types.BytesToAddress(Keccak256(pub[1:])[12:]), nil
+}
+
+// Sponsor returns the sponsor of the transaction
+func (e *EIP155Signer) Sponsor(tx *types.Transaction) (types.Address, error) {
+	return recoverSponsor(tx, e.chainID)
+}
+
+// SignSponsor signs the transaction as its sponsor
+func (e *EIP155Signer) SignSponsor(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	return signSponsor(tx, e.chainID, privateKey)
+}
+
 // calculateV returns the V value for transaction signatures. Based on EIP155
 func (e *EIP155Signer) CalculateV(parity byte) []byte {
 	reference := big.NewInt(int64(parity))
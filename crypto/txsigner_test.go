@@ -143,3 +143,131 @@ func TestEIP155Signer_ChainIDMismatch(t *testing.T) {
 		}
 	}
 }
+
+func TestEIP155Signer_Sponsor(t *testing.T) {
+	t.Parallel()
+
+	toAddress := types.StringToAddress("1")
+	signer := NewEIP155Signer(100)
+
+	senderKey, err := GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	sponsorKey, err := GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	sender := PubKeyToAddress(&senderKey.PublicKey)
+
+	txn := &types.Transaction{
+		To:       &toAddress,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(1),
+		Gas:      21000,
+		From:     sender,
+	}
+
+	_, err = signer.Sponsor(txn)
+	assert.ErrorIs(t, err, ErrNotSponsored)
+
+	// the sponsor signs first, then the sender signs the sponsored transaction
+	sponsored, err := signer.SignSponsor(txn, sponsorKey)
+	assert.NoError(t, err)
+
+	signedTx, err := signer.SignTx(sponsored, senderKey)
+	assert.NoError(t, err)
+
+	from, err := signer.Sender(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, sender, from)
+
+	sponsor, err := signer.Sponsor(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&sponsorKey.PublicKey), sponsor)
+
+	// the sender signature doesn't hold without the sponsor one
+	stripped := signedTx.Copy()
+	stripped.SponsorV, stripped.SponsorR, stripped.SponsorS = nil, nil, nil
+
+	from, err = signer.Sender(stripped)
+	if err == nil {
+		assert.NotEqual(t, sender, from)
+	}
+
+	// the sponsor signature is bound to the chain
+	sponsor, err = NewEIP155Signer(101).Sponsor(signedTx)
+	if err == nil {
+		assert.NotEqual(t, PubKeyToAddress(&sponsorKey.PublicKey), sponsor)
+	}
+}
+
+func TestEIP155Signer_ValidUntil(t *testing.T) {
+	t.Parallel()
+
+	toAddress := types.StringToAddress("1")
+	signer := NewEIP155Signer(100)
+
+	senderKey, err := GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	sender := PubKeyToAddress(&senderKey.PublicKey)
+
+	signedTx, err := signer.SignTx(&types.Transaction{
+		To:         &toAddress,
+		Value:      big.NewInt(1),
+		GasPrice:   big.NewInt(1),
+		Gas:        21000,
+		ValidUntil: 10,
+	}, senderKey)
+	assert.NoError(t, err)
+
+	from, err := signer.Sender(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, sender, from)
+
+	// the sender signature doesn't hold with another expiry
+	extended := signedTx.Copy()
+	extended.ValidUntil = 0
+
+	from, err = signer.Sender(extended)
+	if err == nil {
+		assert.NotEqual(t, sender, from)
+	}
+}
+
+func TestEIP155Signer_BlobTx(t *testing.T) {
+	t.Parallel()
+
+	toAddress := types.StringToAddress("1")
+	signer := NewEIP155Signer(100)
+
+	key, err := GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	txn := &types.Transaction{
+		Type:       types.BlobTx,
+		To:         &toAddress,
+		Value:      big.NewInt(1),
+		GasPrice:   big.NewInt(2),
+		GasTipCap:  big.NewInt(1),
+		Gas:        21000,
+		BlobFeeCap: big.NewInt(1),
+		BlobHashes: []types.Hash{types.KZGToVersionedHash(make([]byte, types.KZGCommitmentSize))},
+	}
+
+	signedTx, err := signer.SignTx(txn, key)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), signedTx.ChainID.Uint64())
+	assert.True(t, signedTx.V.Uint64() <= 1)
+
+	from, err := signer.Sender(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+
+	// the signature is bound to the chain
+	_, err = NewEIP155Signer(101).Sender(signedTx)
+	assert.ErrorIs(t, err, ErrInvalidChainID)
+
+	// the frontier signer doesn't support the typed transactions
+	_, err = (&FrontierSigner{}).Sender(signedTx)
+	assert.ErrorIs(t, err, ErrTxTypeNotSupported)
+}
@@ -0,0 +1,513 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// eip712DomainType is the name of the type of the EIP-712 domain separator
+	eip712DomainType = "EIP712Domain"
+
+	// maxTypedDataDepth is the maximum nesting of the structs and arrays of a typed data value
+	maxTypedDataDepth = 32
+)
+
+var (
+	ErrInvalidTypedData  = errors.New("invalid typed data")
+	ErrInvalidDomain     = errors.New("invalid typed data domain")
+	ErrDomainChainID     = errors.New("typed data domain chain id doesn't match the chain")
+	errTypedDataEncoding = errors.New("invalid typed data value")
+)
+
+// domainFields are the fields a domain separator may have, in the order of the standard
+var domainFields = []TypedDataField{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+var (
+	arrayTypeRegex = regexp.MustCompile(`^(.+)\[(\d*)\]$`)
+	intTypeRegex   = regexp.MustCompile(`^(u?)int(\d*)$`)
+	bytesTypeRegex = regexp.MustCompile(`^bytes(\d+)$`)
+	typeNameRegex  = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+// TypedDataField is a field of a typed data struct
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is the structured data signed as defined by EIP-712
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// TextHash returns the hash of the message prefixed as defined by EIP-191 (version 0x45),
+// the hash signed by personal_sign and eth_sign
+func TextHash(data []byte) []byte {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(data))
+
+	return Keccak256([]byte(prefix), data)
+}
+
+// Validate checks the types of the typed data and its domain, which must declare
+// the given chain id. The domain must only use the standard fields in their standard order
+func (t *TypedData) Validate(chainID uint64) error {
+	if err := t.validateTypes(); err != nil {
+		return err
+	}
+
+	if _, ok := t.Types[t.PrimaryType]; !ok || t.PrimaryType == eip712DomainType {
+		return fmt.Errorf("%w: unknown primary type %q", ErrInvalidTypedData, t.PrimaryType)
+	}
+
+	if err := t.validateDomain(); err != nil {
+		return err
+	}
+
+	domainChainID, err := encodeInt(t.Domain["chainId"], false, 256)
+	if err != nil {
+		return fmt.Errorf("%w: chainId: %v", ErrInvalidDomain, err)
+	}
+
+	if new(big.Int).SetBytes(domainChainID).Cmp(new(big.Int).SetUint64(chainID)) != 0 {
+		return ErrDomainChainID
+	}
+
+	return nil
+}
+
+// validateTypes checks that the fields of all the types are primitive or declared types
+func (t *TypedData) validateTypes() error {
+	for name, fields := range t.Types {
+		if !typeNameRegex.MatchString(name) {
+			return fmt.Errorf("%w: invalid type name %q", ErrInvalidTypedData, name)
+		}
+
+		seen := make(map[string]struct{}, len(fields))
+
+		for _, field := range fields {
+			if field.Name == "" {
+				return fmt.Errorf("%w: type %s has a field without name", ErrInvalidTypedData, name)
+			}
+
+			if _, ok := seen[field.Name]; ok {
+				return fmt.Errorf("%w: type %s has duplicated field %s", ErrInvalidTypedData, name, field.Name)
+			}
+
+			seen[field.Name] = struct{}{}
+
+			elemType := baseType(field.Type)
+			if _, ok := t.Types[elemType]; ok {
+				continue
+			}
+
+			if !isPrimitiveType(elemType) {
+				return fmt.Errorf("%w: unknown type %q of %s.%s", ErrInvalidTypedData, field.Type, name, field.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateDomain checks that the domain type is a subsequence of the standard fields, including
+// the chain id, and that the domain values match the domain type
+func (t *TypedData) validateDomain() error {
+	fields, ok := t.Types[eip712DomainType]
+	if !ok {
+		return fmt.Errorf("%w: %s type is missing", ErrInvalidDomain, eip712DomainType)
+	}
+
+	next, hasChainID := 0, false
+
+	for _, field := range fields {
+		for next < len(domainFields) && domainFields[next].Name != field.Name {
+			next++
+		}
+
+		if next == len(domainFields) {
+			return fmt.Errorf("%w: unexpected or misplaced field %s", ErrInvalidDomain, field.Name)
+		}
+
+		if domainFields[next].Type != field.Type {
+			return fmt.Errorf("%w: field %s must be of type %s", ErrInvalidDomain, field.Name, domainFields[next].Type)
+		}
+
+		if field.Name == "chainId" {
+			hasChainID = true
+		}
+
+		next++
+	}
+
+	if !hasChainID {
+		return fmt.Errorf("%w: chainId is missing", ErrInvalidDomain)
+	}
+
+	if len(t.Domain) != len(fields) {
+		return fmt.Errorf("%w: domain values don't match the %s type", ErrInvalidDomain, eip712DomainType)
+	}
+
+	return nil
+}
+
+// Hash returns the hash of the typed data signed as defined by EIP-712
+func (t *TypedData) Hash() (types.Hash, error) {
+	domainSeparator, err := t.HashStruct(eip712DomainType, t.Domain)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	message, err := t.HashStruct(t.PrimaryType, t.Message)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(Keccak256([]byte{0x19, 0x01}, domainSeparator.Bytes(), message.Bytes())), nil
+}
+
+// HashStruct returns the hash of the struct value of the given type
+func (t *TypedData) HashStruct(typeName string, value map[string]interface{}) (types.Hash, error) {
+	encoded, err := t.encodeData(typeName, value, 0)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(Keccak256(encoded)), nil
+}
+
+// TypeHash returns the hash of the encoded type
+func (t *TypedData) TypeHash(typeName string) types.Hash {
+	return types.BytesToHash(Keccak256([]byte(t.EncodeType(typeName))))
+}
+
+// EncodeType returns the encoding of the type, followed by the encodings
+// of the struct types it references sorted by name
+func (t *TypedData) EncodeType(typeName string) string {
+	deps := t.dependencies(typeName, map[string]struct{}{})
+	sort.Strings(deps)
+
+	buf := new(strings.Builder)
+	for _, dep := range append([]string{typeName}, deps...) {
+		buf.WriteString(dep)
+		buf.WriteString("(")
+
+		for i, field := range t.Types[dep] {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+
+			buf.WriteString(field.Type)
+			buf.WriteString(" ")
+			buf.WriteString(field.Name)
+		}
+
+		buf.WriteString(")")
+	}
+
+	return buf.String()
+}
+
+// dependencies returns the struct types referenced by the type, excluding itself
+func (t *TypedData) dependencies(typeName string, found map[string]struct{}) []string {
+	found[typeName] = struct{}{}
+
+	deps := []string{}
+
+	for _, field := range t.Types[typeName] {
+		dep := baseType(field.Type)
+		if _, ok := t.Types[dep]; !ok {
+			continue
+		}
+
+		if _, ok := found[dep]; ok {
+			continue
+		}
+
+		deps = append(deps, dep)
+		deps = append(deps, t.dependencies(dep, found)...)
+	}
+
+	return deps
+}
+
+// encodeData encodes the fields of the struct value, after its type hash.
+// All the fields of the type must be set and no other field is allowed
+func (t *TypedData) encodeData(typeName string, value map[string]interface{}, depth int) ([]byte, error) {
+	if depth > maxTypedDataDepth {
+		return nil, fmt.Errorf("%w: max depth exceeded", errTypedDataEncoding)
+	}
+
+	fields, ok := t.Types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidTypedData, typeName)
+	}
+
+	if len(value) != len(fields) {
+		return nil, fmt.Errorf("%w: %s has %d fields, %d provided", errTypedDataEncoding, typeName, len(fields), len(value))
+	}
+
+	buf := bytes.NewBuffer(t.TypeHash(typeName).Bytes())
+
+	for _, field := range fields {
+		fieldValue, ok := value[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s.%s is missing", errTypedDataEncoding, typeName, field.Name)
+		}
+
+		encoded, err := t.encodeValue(field.Type, fieldValue, depth)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typeName, field.Name, err)
+		}
+
+		buf.Write(encoded)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encodeValue encodes the value of the given type in a 32 bytes word
+func (t *TypedData) encodeValue(typ string, value interface{}, depth int) ([]byte, error) {
+	if match := arrayTypeRegex.FindStringSubmatch(typ); match != nil {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %v is not an array", errTypedDataEncoding, value)
+		}
+
+		if match[2] != "" {
+			if size, err := strconv.Atoi(match[2]); err != nil || size != len(items) {
+				return nil, fmt.Errorf("%w: array of %d items, %s expected", errTypedDataEncoding, len(items), typ)
+			}
+		}
+
+		buf := new(bytes.Buffer)
+
+		for _, item := range items {
+			encoded, err := t.encodeValue(match[1], item, depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			buf.Write(encoded)
+		}
+
+		return Keccak256(buf.Bytes()), nil
+	}
+
+	if _, ok := t.Types[typ]; ok {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %v is not a %s struct", errTypedDataEncoding, value, typ)
+		}
+
+		encoded, err := t.encodeData(typ, fields, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		return Keccak256(encoded), nil
+	}
+
+	return encodePrimitive(typ, value)
+}
+
+// encodePrimitive encodes the value of the atomic or dynamic type in a 32 bytes word
+func encodePrimitive(typ string, value interface{}) ([]byte, error) {
+	switch typ {
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %v is not a string", errTypedDataEncoding, value)
+		}
+
+		return Keccak256([]byte(str)), nil
+
+	case "bytes":
+		buf, err := decodeBytesValue(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return Keccak256(buf), nil
+
+	case "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: %v is not a bool", errTypedDataEncoding, value)
+		}
+
+		word := make([]byte, 32)
+		if b {
+			word[31] = 1
+		}
+
+		return word, nil
+
+	case "address":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %v is not an address", errTypedDataEncoding, value)
+		}
+
+		addr := types.Address{}
+		if err := addr.UnmarshalText([]byte(str)); err != nil {
+			return nil, fmt.Errorf("%w: %s is not an address", errTypedDataEncoding, str)
+		}
+
+		return leftPad(addr.Bytes()), nil
+	}
+
+	if match := bytesTypeRegex.FindStringSubmatch(typ); match != nil {
+		size, _ := strconv.Atoi(match[1])
+
+		buf, err := decodeBytesValue(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(buf) != size {
+			return nil, fmt.Errorf("%w: %d bytes, %s expected", errTypedDataEncoding, len(buf), typ)
+		}
+
+		word := make([]byte, 32)
+		copy(word, buf)
+
+		return word, nil
+	}
+
+	if match := intTypeRegex.FindStringSubmatch(typ); match != nil {
+		bits := 256
+		if match[2] != "" {
+			bits, _ = strconv.Atoi(match[2])
+		}
+
+		return encodeInt(value, match[1] == "", bits)
+	}
+
+	return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidTypedData, typ)
+}
+
+// encodeInt encodes the integer value in a 32 bytes word, the signed integers in two's complement
+func encodeInt(value interface{}, signed bool, bits int) ([]byte, error) {
+	n := new(big.Int)
+
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return nil, fmt.Errorf("%w: %v is not an exact integer, use a string", errTypedDataEncoding, v)
+		}
+
+		n.SetInt64(int64(v))
+
+	case json.Number:
+		if _, ok := n.SetString(v.String(), 10); !ok {
+			return nil, fmt.Errorf("%w: %s is not an integer", errTypedDataEncoding, v)
+		}
+
+	case string:
+		if _, ok := n.SetString(v, 0); !ok {
+			return nil, fmt.Errorf("%w: %s is not an integer", errTypedDataEncoding, v)
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: %v is not an integer", errTypedDataEncoding, value)
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		limit.Rsh(limit, 1)
+
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("%w: %s overflows int%d", errTypedDataEncoding, n, bits)
+		}
+
+		if n.Sign() < 0 {
+			// two's complement on 256 bits
+			n.Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+	} else if n.Sign() < 0 || n.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("%w: %s overflows uint%d", errTypedDataEncoding, n, bits)
+	}
+
+	return leftPad(n.Bytes()), nil
+}
+
+// decodeBytesValue decodes the hex encoded bytes value
+func decodeBytesValue(value interface{}) ([]byte, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v is not hex encoded bytes", errTypedDataEncoding, value)
+	}
+
+	buf, err := hex.DecodeHex(str)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s is not hex encoded bytes", errTypedDataEncoding, str)
+	}
+
+	return buf, nil
+}
+
+// isPrimitiveType checks if the type is an atomic or dynamic type of EIP-712
+func isPrimitiveType(typ string) bool {
+	switch typ {
+	case "string", "bytes", "bool", "address":
+		return true
+	}
+
+	if match := bytesTypeRegex.FindStringSubmatch(typ); match != nil {
+		size, err := strconv.Atoi(match[1])
+
+		return err == nil && size >= 1 && size <= 32
+	}
+
+	if match := intTypeRegex.FindStringSubmatch(typ); match != nil {
+		if match[2] == "" {
+			return true
+		}
+
+		bits, err := strconv.Atoi(match[2])
+
+		return err == nil && bits >= 8 && bits <= 256 && bits%8 == 0
+	}
+
+	return false
+}
+
+// baseType strips the array suffixes of the type
+func baseType(typ string) string {
+	for {
+		match := arrayTypeRegex.FindStringSubmatch(typ)
+		if match == nil {
+			return typ
+		}
+
+		typ = match[1]
+	}
+}
+
+func leftPad(buf []byte) []byte {
+	word := make([]byte, 32)
+	copy(word[32-len(buf):], buf)
+
+	return word
+}
@@ -0,0 +1,227 @@
+package crypto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mailTypedData is the example of EIP-712
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func newMailTypedData(t *testing.T) *TypedData {
+	t.Helper()
+
+	typedData := new(TypedData)
+	require.NoError(t, json.Unmarshal([]byte(mailTypedData), typedData))
+
+	return typedData
+}
+
+func TestTypedData_Hash(t *testing.T) {
+	t.Parallel()
+
+	typedData := newMailTypedData(t)
+	require.NoError(t, typedData.Validate(1))
+
+	assert.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)",
+		typedData.EncodeType("Mail"))
+
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain)
+	require.NoError(t, err)
+	assert.Equal(t, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", domainSeparator.String())
+
+	hash, err := typedData.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hash.String())
+
+	// the signature of the example
+	key, err := ParseECDSAPrivateKey(Keccak256([]byte("cow")))
+	require.NoError(t, err)
+
+	sig, err := Sign(key, hash.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t,
+		"0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+			"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b9156201",
+		hex.EncodeToHex(sig),
+	)
+}
+
+func TestTypedData_Validate(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name   string
+		modify func(*TypedData)
+		err    error
+	}{
+		{
+			"chain id of another chain",
+			func(td *TypedData) {
+				td.Domain["chainId"] = "0x2"
+			},
+			ErrDomainChainID,
+		},
+		{
+			"missing chain id",
+			func(td *TypedData) {
+				td.Types["EIP712Domain"] = td.Types["EIP712Domain"][:2]
+				delete(td.Domain, "chainId")
+				delete(td.Domain, "verifyingContract")
+			},
+			ErrInvalidDomain,
+		},
+		{
+			"misplaced domain field",
+			func(td *TypedData) {
+				fields := td.Types["EIP712Domain"]
+				fields[0], fields[1] = fields[1], fields[0]
+			},
+			ErrInvalidDomain,
+		},
+		{
+			"unknown domain field",
+			func(td *TypedData) {
+				td.Types["EIP712Domain"] = append(td.Types["EIP712Domain"], TypedDataField{Name: "owner", Type: "address"})
+				td.Domain["owner"] = "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"
+			},
+			ErrInvalidDomain,
+		},
+		{
+			"wrong domain field type",
+			func(td *TypedData) {
+				td.Types["EIP712Domain"][2].Type = "uint64"
+			},
+			ErrInvalidDomain,
+		},
+		{
+			"undeclared domain value",
+			func(td *TypedData) {
+				td.Domain["salt"] = "0x01"
+			},
+			ErrInvalidDomain,
+		},
+		{
+			"unknown field type",
+			func(td *TypedData) {
+				td.Types["Person"][1].Type = "wallet"
+			},
+			ErrInvalidTypedData,
+		},
+		{
+			"unknown primary type",
+			func(td *TypedData) {
+				td.PrimaryType = "Letter"
+			},
+			ErrInvalidTypedData,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			typedData := newMailTypedData(t)
+			tt.modify(typedData)
+
+			assert.ErrorIs(t, typedData.Validate(1), tt.err)
+		})
+	}
+}
+
+func TestTypedData_EncodeValues(t *testing.T) {
+	t.Parallel()
+
+	typedData := &TypedData{
+		Types: map[string][]TypedDataField{
+			"Order": {
+				{Name: "amount", Type: "int8"},
+				{Name: "ids", Type: "uint256[2]"},
+				{Name: "tag", Type: "bytes4"},
+			},
+		},
+	}
+
+	_, err := typedData.HashStruct("Order", map[string]interface{}{
+		"amount": float64(-128),
+		"ids":    []interface{}{"0x1", "2"},
+		"tag":    "0x01020304",
+	})
+	assert.NoError(t, err)
+
+	testTable := []struct {
+		name  string
+		value map[string]interface{}
+	}{
+		{
+			"overflow",
+			map[string]interface{}{"amount": float64(128), "ids": []interface{}{"1", "2"}, "tag": "0x01020304"},
+		},
+		{
+			"array size",
+			map[string]interface{}{"amount": float64(1), "ids": []interface{}{"1"}, "tag": "0x01020304"},
+		},
+		{
+			"fixed bytes size",
+			map[string]interface{}{"amount": float64(1), "ids": []interface{}{"1", "2"}, "tag": "0x01"},
+		},
+		{
+			"missing field",
+			map[string]interface{}{"amount": float64(1), "ids": []interface{}{"1", "2"}},
+		},
+		{
+			"extra field",
+			map[string]interface{}{"amount": float64(1), "ids": []interface{}{"1", "2"}, "tag": "0x01020304", "x": "y"},
+		},
+	}
+
+	for _, tt := range testTable {
+		_, err := typedData.HashStruct("Order", tt.value)
+		assert.Error(t, err, tt.name)
+	}
+}
+
+func TestTextHash(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"0x50b2c43fd39106bafbba0da34fc430e1f91e3c96ea2acee2bc34119f92b37750",
+		hex.EncodeToHex(TextHash([]byte("hello"))),
+	)
+}
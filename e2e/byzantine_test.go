@@ -0,0 +1,61 @@
+//go:build byzantine
+// +build byzantine
+
+package e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/e2e/framework"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestByzantine_Resilience runs a cluster with one faulty validator out of four,
+// the honest ones must keep finalizing blocks and including transactions
+func TestByzantine_Resilience(t *testing.T) {
+	behaviors := []string{
+		"double-propose",
+		"withhold-commits",
+		"stale-rounds",
+	}
+
+	for _, behavior := range behaviors {
+		behavior := behavior
+
+		t.Run(behavior, func(t *testing.T) {
+			senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+			_, receiverAddr := tests.GenerateKeyAndAddr(t)
+
+			cluster := framework.NewCluster(t, &framework.ClusterConfig{
+				Validators:    IBFTMinNodes,
+				IBFTDirPrefix: IBFTDirPrefix,
+				Premine: map[types.Address]*big.Int{
+					senderAddr: framework.EthToWei(10),
+				},
+				Callback: func(i int, config *framework.TestServerConfig) {
+					if i == 0 {
+						config.SetByzantine(behavior)
+					}
+				},
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			cluster.Start(ctx)
+
+			// the faulty validator proposes in turn, the honest ones must change the round
+			require.NoError(t, cluster.WaitForBlock(ctx, 2*IBFTMinNodes))
+
+			receipt, err := cluster.Transfer(ctx, senderKey, receiverAddr, framework.EthToWei(1))
+			require.NoError(t, err)
+			assert.Equal(t, uint64(types.ReceiptSuccess), receipt.Status)
+		})
+	}
+}
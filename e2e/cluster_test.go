@@ -0,0 +1,51 @@
+package e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/e2e/framework"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+// TestCluster_Transfer funds an account in the genesis of the cluster,
+// transfers from it and verifies the balances on all the validators
+func TestCluster_Transfer(t *testing.T) {
+	var (
+		senderKey, senderAddr = tests.GenerateKeyAndAddr(t)
+		_, receiverAddr       = tests.GenerateKeyAndAddr(t)
+		amount                = framework.EthToWei(1)
+	)
+
+	cluster := framework.NewCluster(t, &framework.ClusterConfig{
+		Validators:    IBFTMinNodes,
+		IBFTDirPrefix: IBFTDirPrefix,
+		Premine: map[types.Address]*big.Int{
+			senderAddr: framework.EthToWei(10),
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cluster.Start(ctx)
+
+	receipt, err := cluster.Transfer(ctx, senderKey, receiverAddr, amount)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+
+	// the validators import the block of the transfer
+	require.NoError(t, cluster.WaitForBlock(ctx, receipt.BlockNumber))
+
+	for _, srv := range cluster.Servers() {
+		balance, err := srv.JSONRPC().Eth().GetBalance(ethgo.Address(receiverAddr), ethgo.Latest)
+		require.NoError(t, err)
+		assert.Equal(t, amount, balance)
+	}
+}
@@ -0,0 +1,124 @@
+package e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/e2e/framework"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+func TestDevnet_SnapshotRevert(t *testing.T) {
+	senderKey, sender := tests.GenerateKeyAndAddr(t)
+	_, receiver := tests.GenerateKeyAndAddr(t)
+
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.Premine(sender, framework.EthToWei(10))
+	})
+	srv := srvs[0]
+
+	client := srv.JSONRPC()
+
+	var snapshotID string
+	require.NoError(t, client.Call("evm_snapshot", &snapshotID))
+
+	head, err := client.Eth().BlockNumber()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), framework.DefaultTimeout)
+	defer cancel()
+
+	receipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+		From:     sender,
+		To:       &receiver,
+		GasPrice: big.NewInt(1048576),
+		Gas:      1000000,
+		Value:    framework.EthToWei(1),
+	}, senderKey)
+	require.NoError(t, err)
+
+	balance, err := client.Eth().GetBalance(ethgo.Address(receiver), ethgo.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, framework.EthToWei(1), balance)
+
+	var reverted bool
+	require.NoError(t, client.Call("evm_revert", &reverted, snapshotID))
+	assert.True(t, reverted)
+
+	// the chain is back to the snapshot, the transfer is no longer found
+	balance, err = client.Eth().GetBalance(ethgo.Address(receiver), ethgo.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(0), balance)
+
+	nonce, err := client.Eth().GetNonce(ethgo.Address(sender), ethgo.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), nonce)
+
+	revertedReceipt, err := client.Eth().GetTransactionReceipt(receipt.TransactionHash)
+	require.NoError(t, err)
+	assert.Nil(t, revertedReceipt)
+
+	// the dev consensus keeps sealing blocks from the reverted head
+	newHead, err := client.Eth().BlockNumber()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, newHead, head)
+
+	// the reverted snapshot is removed
+	require.NoError(t, client.Call("evm_revert", &reverted, snapshotID))
+	assert.False(t, reverted)
+
+	// the same transfer can be sent again
+	_, err = srv.SendRawTx(ctx, &framework.PreparedTransaction{
+		From:     sender,
+		To:       &receiver,
+		GasPrice: big.NewInt(1048576),
+		Gas:      1000000,
+		Value:    framework.EthToWei(1),
+	}, senderKey)
+	require.NoError(t, err)
+
+	balance, err = client.Eth().GetBalance(ethgo.Address(receiver), ethgo.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, framework.EthToWei(1), balance)
+}
+
+func TestDevnet_Time(t *testing.T) {
+	srvs := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+	})
+	client := srvs[0].JSONRPC()
+
+	var result interface{}
+
+	// the blocks are only sealed on demand
+	require.NoError(t, client.Call("evm_setAutomine", &result, false))
+
+	head, err := client.Eth().BlockNumber()
+	require.NoError(t, err)
+
+	require.NoError(t, client.Call("evm_increaseTime", &result, 3600))
+	require.NoError(t, client.Call("evm_mine", &result))
+
+	block, err := client.Eth().GetBlockByNumber(ethgo.Latest, false)
+	require.NoError(t, err)
+	assert.Equal(t, head+1, block.Number)
+	assert.GreaterOrEqual(t, block.Timestamp, uint64(time.Now().Unix())+3600)
+
+	next := block.Timestamp + 86400
+	require.NoError(t, client.Call("evm_setNextBlockTimestamp", &result, next))
+	require.NoError(t, client.Call("evm_mine", &result))
+
+	block, err = client.Eth().GetBlockByNumber(ethgo.Latest, false)
+	require.NoError(t, err)
+	assert.Equal(t, head+2, block.Number)
+	assert.Equal(t, next, block.Timestamp)
+
+	// the timestamp of a block can't go back
+	assert.Error(t, client.Call("evm_mine", &result, block.Timestamp))
+}
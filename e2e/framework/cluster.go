@@ -0,0 +1,149 @@
+// Package framework runs the nodes of the polygon-edge binary for the integration tests,
+// either as single test servers or as a cluster of IBFT validators. It is used by the e2e
+// tests of the repository and by the chains built on polygon-edge to test against the node
+// binary, which is resolved from the EDGE_BINARY environment variable
+package framework
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+	"github.com/umbracle/ethgo/wallet"
+)
+
+// Default settings of the clusters
+const (
+	DefaultClusterValidators    = 4
+	DefaultClusterIBFTDirPrefix = "e2e-ibft-"
+)
+
+// ClusterConfig is the configuration of a cluster of IBFT validators
+type ClusterConfig struct {
+	// Validators is the number of validators, DefaultClusterValidators if not set
+	Validators int
+
+	// IBFTDirPrefix is the prefix of the data directories of the validators,
+	// DefaultClusterIBFTDirPrefix if not set
+	IBFTDirPrefix string
+
+	// Premine are the balances of the accounts funded in the genesis (in WEI)
+	Premine map[types.Address]*big.Int
+
+	// Callback customizes the configuration of each validator
+	Callback IBFTServerConfigCallback
+}
+
+// Cluster is a cluster of IBFT validators, sharing the same genesis and
+// stopped with the test
+type Cluster struct {
+	t       testing.TB
+	manager *IBFTServersManager
+}
+
+// NewCluster initializes the validators of the cluster and generates their genesis.
+// The validators are started with Start
+func NewCluster(t testing.TB, config *ClusterConfig) *Cluster {
+	t.Helper()
+
+	if config == nil {
+		config = &ClusterConfig{}
+	}
+
+	validators := config.Validators
+	if validators == 0 {
+		validators = DefaultClusterValidators
+	}
+
+	ibftDirPrefix := config.IBFTDirPrefix
+	if ibftDirPrefix == "" {
+		ibftDirPrefix = DefaultClusterIBFTDirPrefix
+	}
+
+	manager := NewIBFTServersManager(t, validators, ibftDirPrefix, func(i int, c *TestServerConfig) {
+		for addr, balance := range config.Premine {
+			c.Premine(addr, balance)
+		}
+
+		if config.Callback != nil {
+			config.Callback(i, c)
+		}
+	})
+
+	return &Cluster{
+		t:       t,
+		manager: manager,
+	}
+}
+
+// Start starts the validators and waits until they produce the first block
+func (c *Cluster) Start(ctx context.Context) {
+	c.t.Helper()
+
+	c.manager.StartServers(ctx)
+}
+
+// Stop stops the validators
+func (c *Cluster) Stop() {
+	c.manager.StopServers()
+}
+
+// Servers returns the validators of the cluster
+func (c *Cluster) Servers() []*TestServer {
+	return c.manager.servers
+}
+
+// Server returns the i-th validator of the cluster, nil if there is none
+func (c *Cluster) Server(i int) *TestServer {
+	return c.manager.GetServer(i)
+}
+
+// JSONRPC returns the JSON-RPC client of the first validator
+func (c *Cluster) JSONRPC() *jsonrpc.Client {
+	return c.Server(0).JSONRPC()
+}
+
+// WaitForBlock waits until all the validators reach the block height
+func (c *Cluster) WaitForBlock(ctx context.Context, height uint64) error {
+	for i, srv := range c.Servers() {
+		if _, err := WaitUntilBlockMined(ctx, srv, height); err != nil {
+			return fmt.Errorf("server %d didn't reach block %d: %w", i, height, err)
+		}
+	}
+
+	return nil
+}
+
+// Transfer sends the value from the account of the key to the address through the first
+// validator and returns the receipt of the transaction once it is mined
+func (c *Cluster) Transfer(
+	ctx context.Context,
+	key *ecdsa.PrivateKey,
+	to types.Address,
+	value *big.Int,
+) (*ethgo.Receipt, error) {
+	from, err := crypto.GetAddressFromKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Server(0).SendRawTx(ctx, &PreparedTransaction{
+		From:     from,
+		To:       &to,
+		Value:    value,
+		Gas:      DefaultGasLimit,
+		GasPrice: big.NewInt(DefaultGasPrice),
+	}, key)
+}
+
+// Txn returns the builder of a transaction from the account of the key,
+// sent through the first validator
+func (c *Cluster) Txn(key *wallet.Key) *Txn {
+	return c.Server(0).Txn(key)
+}
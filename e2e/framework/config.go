@@ -60,6 +60,7 @@ type TestServerConfig struct {
 	MaxValidatorCount       uint64                   // Max validator count
 	BlockTime               uint64                   // Minimum block generation time (in s)
 	IBFTBaseTimeout         uint64                   // Base Timeout in seconds for IBFT
+	Byzantine               []string                 // Byzantine behaviors (byzantine builds only)
 	PredeployParams         *PredeployParams
 }
 
@@ -206,3 +207,9 @@ func (t *TestServerConfig) SetLogsDir(dir string) {
 func (t *TestServerConfig) SetName(name string) {
 	t.Name = name
 }
+
+// SetByzantine sets the byzantine behaviors of the server, i.e. double-propose, withhold-commits
+// and stale-rounds. They are only applied by the binaries built with the byzantine tag
+func (t *TestServerConfig) SetByzantine(behaviors ...string) {
+	t.Byzantine = behaviors
+}
@@ -68,7 +68,7 @@ func EthToWeiPrecise(ethValue int64, decimals int64) *big.Int {
 }
 
 // GetAccountBalance is a helper method for fetching the Balance field of an account
-func GetAccountBalance(t *testing.T, address types.Address, rpcClient *jsonrpc.Client) *big.Int {
+func GetAccountBalance(t testing.TB, address types.Address, rpcClient *jsonrpc.Client) *big.Int {
 	t.Helper()
 
 	accountBalance, err := rpcClient.Eth().GetBalance(
@@ -211,7 +211,7 @@ func EcrecoverFromBlockhash(hash types.Hash, signature []byte) (types.Address, e
 	return crypto.PubKeyToAddress(pubKey), nil
 }
 
-func MultiJoinSerial(t *testing.T, srvs []*TestServer) {
+func MultiJoinSerial(t testing.TB, srvs []*TestServer) {
 	t.Helper()
 
 	dials := []*TestServer{}
@@ -223,7 +223,7 @@ func MultiJoinSerial(t *testing.T, srvs []*TestServer) {
 	MultiJoin(t, dials...)
 }
 
-func MultiJoin(t *testing.T, srvs ...*TestServer) {
+func MultiJoin(t testing.TB, srvs ...*TestServer) {
 	t.Helper()
 
 	if len(srvs)%2 != 0 {
@@ -446,7 +446,7 @@ func FindAvailablePorts(n, from, to int) ([]ReservedPort, error) {
 	return ports, nil
 }
 
-func NewTestServers(t *testing.T, num int, conf func(*TestServerConfig)) []*TestServer {
+func NewTestServers(t testing.TB, num int, conf func(*TestServerConfig)) []*TestServer {
 	t.Helper()
 
 	srvs := make([]*TestServer, 0, num)
@@ -12,7 +12,7 @@ import (
 )
 
 type IBFTServersManager struct {
-	t       *testing.T
+	t       testing.TB
 	servers []*TestServer
 }
 
@@ -25,7 +25,7 @@ func init() {
 }
 
 func NewIBFTServersManager(
-	t *testing.T,
+	t testing.TB,
 	numNodes int,
 	ibftDirPrefix string,
 	callback IBFTServerConfigCallback,
@@ -132,9 +132,17 @@ func (m *IBFTServersManager) GetServer(i int) *TestServer {
 	return m.servers[i]
 }
 
-func initLogsDir(t *testing.T) (string, error) {
+// initLogsDir creates the logs directory of the test, in EDGE_LOGS_DIR if it is set
+// or in the e2e-logs directory next to the package of the tests
+func initLogsDir(t testing.TB) (string, error) {
 	t.Helper()
-	logsDir := path.Join("..", "e2e-logs", fmt.Sprintf("e2e-logs-%d", startTime), t.Name())
+
+	root := os.Getenv("EDGE_LOGS_DIR")
+	if root == "" {
+		root = path.Join("..", "e2e-logs")
+	}
+
+	logsDir := path.Join(root, fmt.Sprintf("e2e-logs-%d", startTime), t.Name())
 
 	if err := os.MkdirAll(logsDir, 0755); err != nil {
 		return "", err
@@ -59,14 +59,14 @@ const (
 )
 
 type TestServer struct {
-	t *testing.T
+	t testing.TB
 
 	Config  *TestServerConfig
 	cmd     *exec.Cmd
 	chainID *big.Int
 }
 
-func NewTestServer(t *testing.T, rootDir string, callback TestServerConfigCallback) *TestServer {
+func NewTestServer(t testing.TB, rootDir string, callback TestServerConfigCallback) *TestServer {
 	t.Helper()
 
 	// Reserve ports
@@ -441,6 +441,10 @@ func (t *TestServer) Start(ctx context.Context) error {
 	t.cmd = exec.Command(resolveBinary(), args...) //nolint:gosec
 	t.cmd.Dir = t.Config.RootDir
 
+	if len(t.Config.Byzantine) > 0 {
+		t.cmd.Env = append(os.Environ(), "EDGE_BYZANTINE="+strings.Join(t.Config.Byzantine, ","))
+	}
+
 	stdout := t.GetStdout()
 	t.cmd.Stdout = stdout
 	t.cmd.Stderr = stdout
@@ -505,6 +509,10 @@ func (t *TestServer) SwitchIBFTType(typ fork.IBFTType, from uint64, to, deployme
 	t.cmd = exec.Command(resolveBinary(), args...) //nolint:gosec
 	t.cmd.Dir = t.Config.RootDir
 
+	if len(t.Config.Byzantine) > 0 {
+		t.cmd.Env = append(os.Environ(), "EDGE_BYZANTINE="+strings.Join(t.Config.Byzantine, ","))
+	}
+
 	stdout := t.GetStdout()
 	t.cmd.Stdout = stdout
 	t.cmd.Stderr = stdout
@@ -666,7 +674,7 @@ func (t *Txn) Receipt() *ethgo.Receipt {
 }
 
 //nolint:thelper
-func (t *Txn) NoFail(tt *testing.T) {
+func (t *Txn) NoFail(tt testing.TB) {
 	t.Wait()
 
 	if t.sendErr != nil {
@@ -0,0 +1,11 @@
+package firehose
+
+// Config is the configuration of the firehose
+type Config struct {
+	// Sink is the location the records are streamed to, either a local directory
+	// or a url whose scheme names a registered sink
+	Sink string
+
+	// Traces streams the call trees of the transactions, re-executing the blocks
+	Traces bool
+}
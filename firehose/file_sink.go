@@ -0,0 +1,152 @@
+package firehose
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	streamFileName     = "stream.bin"
+	checkpointFileName = "checkpoint.json"
+)
+
+// fileSink appends the records to a stream file of a local directory, each prefixed with its
+// varint length. The checkpoint is written next to it once the records are synced,
+// holding the committed size of the file
+type fileSink struct {
+	dir    string
+	stream *os.File
+
+	checkpoint *Checkpoint
+}
+
+func openFileSink(dir string) (Sink, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	checkpoint, err := readCheckpoint(filepath.Join(dir, checkpointFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := os.OpenFile(filepath.Join(dir, streamFileName), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	// discard the records written after the last commit
+	var size uint64
+	if checkpoint != nil {
+		size = checkpoint.Position
+	}
+
+	if err := stream.Truncate(int64(size)); err != nil {
+		_ = stream.Close()
+
+		return nil, err
+	}
+
+	if _, err := stream.Seek(int64(size), io.SeekStart); err != nil {
+		_ = stream.Close()
+
+		return nil, err
+	}
+
+	return &fileSink{
+		dir:        dir,
+		stream:     stream,
+		checkpoint: checkpoint,
+	}, nil
+}
+
+func readCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+func (s *fileSink) Checkpoint() (*Checkpoint, error) {
+	return s.checkpoint, nil
+}
+
+func (s *fileSink) Commit(records [][]byte, checkpoint *Checkpoint) error {
+	var position uint64
+	if s.checkpoint != nil {
+		position = s.checkpoint.Position
+	}
+
+	for _, record := range records {
+		framed := protowire.AppendBytes(nil, record)
+
+		if _, err := s.stream.Write(framed); err != nil {
+			return s.rollback(err)
+		}
+
+		position += uint64(len(framed))
+	}
+
+	if err := s.stream.Sync(); err != nil {
+		return s.rollback(err)
+	}
+
+	committed := *checkpoint
+	committed.Position = position
+
+	data, err := json.Marshal(&committed)
+	if err != nil {
+		return s.rollback(err)
+	}
+
+	// the checkpoint is replaced atomically, the records are committed once it is renamed
+	path := filepath.Join(s.dir, checkpointFileName)
+	if err := os.WriteFile(path+".tmp", data, 0600); err != nil {
+		return s.rollback(err)
+	}
+
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return s.rollback(err)
+	}
+
+	s.checkpoint = &committed
+
+	return nil
+}
+
+// rollback discards the records written since the last commit
+func (s *fileSink) rollback(err error) error {
+	var size uint64
+	if s.checkpoint != nil {
+		size = s.checkpoint.Position
+	}
+
+	if truncateErr := s.stream.Truncate(int64(size)); truncateErr != nil {
+		return fmt.Errorf("%w, failed to discard the uncommitted records: %v", err, truncateErr)
+	}
+
+	if _, seekErr := s.stream.Seek(int64(size), io.SeekStart); seekErr != nil {
+		return fmt.Errorf("%w, failed to discard the uncommitted records: %v", err, seekErr)
+	}
+
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.stream.Close()
+}
@@ -0,0 +1,317 @@
+package firehose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+)
+
+// trackedBlocks is the number of the recent streamed blocks kept in the checkpoint,
+// deeper reorgs can't be unwound
+const trackedBlocks = 256
+
+var errReorgTooDeep = errors.New("the reorg is deeper than the tracked blocks")
+
+// Blockchain is the interface of the blockchain the firehose follows
+type Blockchain interface {
+	Header() *types.Header
+	GetHeaderByNumber(number uint64) (*types.Header, bool)
+	GetBlockByNumber(number uint64, full bool) (*types.Block, bool)
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+	GetStateDiffByHash(hash types.Hash) (*types.StateDiff, error)
+	SubscribeEvents() blockchain.Subscription
+}
+
+// BlockTracer re-executes the blocks with a tracer
+type BlockTracer interface {
+	TraceBlock(block *types.Block, tracer tracer.Tracer) ([]interface{}, error)
+}
+
+// Firehose follows the canonical chain and streams its blocks with their transactions,
+// receipts, call trees and state diffs to a sink, for the external indexing pipelines.
+// The blocks removed by a reorg are streamed as undo records before the new canonical blocks
+type Firehose struct {
+	logger     hclog.Logger
+	blockchain Blockchain
+	tracer     BlockTracer
+	sink       Sink
+
+	traces bool
+
+	// checkpoint is the last committed checkpoint
+	checkpoint *Checkpoint
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewFirehose creates a firehose resuming the stream of the sink
+func NewFirehose(
+	logger hclog.Logger,
+	config *Config,
+	blockchain Blockchain,
+	tracer BlockTracer,
+) (*Firehose, error) {
+	sink, err := OpenSink(config.Sink)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open firehose sink: %w", err)
+	}
+
+	checkpoint, err := sink.Checkpoint()
+	if err != nil {
+		_ = sink.Close()
+
+		return nil, fmt.Errorf("failed to read firehose checkpoint: %w", err)
+	}
+
+	if checkpoint == nil {
+		checkpoint = &Checkpoint{}
+	}
+
+	return &Firehose{
+		logger:     logger.Named("firehose"),
+		blockchain: blockchain,
+		tracer:     tracer,
+		sink:       sink,
+		traces:     config.Traces,
+		checkpoint: checkpoint,
+	}, nil
+}
+
+// Start streams the blocks missing from the stream and follows the chain
+func (f *Firehose) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+
+	sub := f.blockchain.SubscribeEvents()
+
+	f.wg.Add(1)
+
+	go func() {
+		defer f.wg.Done()
+		defer sub.Close()
+
+		f.sync(ctx)
+
+		eventCh := sub.GetEventCh()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-eventCh:
+				if ev == nil {
+					return
+				}
+
+				if ev.Type == blockchain.EventFork {
+					// forks don't change the canonical chain
+					continue
+				}
+
+				f.sync(ctx)
+			}
+		}
+	}()
+}
+
+// Close stops the firehose and closes its sink
+func (f *Firehose) Close() error {
+	if f.cancel != nil {
+		f.cancel()
+	}
+
+	f.wg.Wait()
+
+	return f.sink.Close()
+}
+
+// sync streams the undo records of the blocks replaced by a reorg and the new canonical blocks
+func (f *Firehose) sync(ctx context.Context) {
+	if err := f.unwind(); err != nil {
+		f.logger.Error("failed to unwind reorged blocks", "err", err)
+
+		return
+	}
+
+	for number := f.next(); number <= f.blockchain.Header().Number; number++ {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := f.streamBlock(number); err != nil {
+			f.logger.Error("failed to stream block", "number", number, "err", err)
+
+			return
+		}
+
+		metrics.SetGauge([]string{"firehose", "head"}, float32(number))
+	}
+}
+
+// next returns the number of the next block to stream
+func (f *Firehose) next() uint64 {
+	blocks := f.checkpoint.Blocks
+	if len(blocks) == 0 {
+		return 0
+	}
+
+	return blocks[len(blocks)-1].Number + 1
+}
+
+// unwind streams the undo records of the streamed blocks which are no longer canonical,
+// from the head of the stream down
+func (f *Firehose) unwind() error {
+	blocks := f.checkpoint.Blocks
+	records := []*Record{}
+
+	for len(blocks) > 0 {
+		last := blocks[len(blocks)-1]
+
+		header, ok := f.blockchain.GetHeaderByNumber(last.Number)
+		if ok && header.Hash == last.Hash {
+			break
+		}
+
+		records = append(records, &Record{
+			Type:   RecordUndo,
+			Number: last.Number,
+			Hash:   last.Hash,
+		})
+
+		blocks = blocks[:len(blocks)-1]
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	if len(blocks) == 0 && records[len(records)-1].Number > 0 {
+		return fmt.Errorf("%w, the stream is at block %d", errReorgTooDeep, records[0].Number)
+	}
+
+	if err := f.commit(records, blocks); err != nil {
+		return err
+	}
+
+	metrics.IncrCounter([]string{"firehose", "undo_records"}, float32(len(records)))
+
+	return nil
+}
+
+// streamBlock streams the canonical block of the number
+func (f *Firehose) streamBlock(number uint64) error {
+	block, ok := f.blockchain.GetBlockByNumber(number, true)
+	if !ok {
+		return fmt.Errorf("block %d not found", number)
+	}
+
+	record, err := f.blockRecord(block)
+	if err != nil {
+		return err
+	}
+
+	blocks := append([]BlockRef{}, f.checkpoint.Blocks...)
+	blocks = append(blocks, BlockRef{Number: number, Hash: block.Hash()})
+
+	if len(blocks) > trackedBlocks {
+		blocks = blocks[len(blocks)-trackedBlocks:]
+	}
+
+	return f.commit([]*Record{record}, blocks)
+}
+
+// blockRecord returns the record of the block with its receipts, call trees and state diff
+func (f *Firehose) blockRecord(block *types.Block) (*Record, error) {
+	receipts, err := f.blockchain.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipts: %w", err)
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("expected %d receipts, got %d", len(block.Transactions), len(receipts))
+	}
+
+	record := &Record{
+		Type:     RecordBlock,
+		Number:   block.Number(),
+		Hash:     block.Hash(),
+		Block:    block,
+		Receipts: receipts,
+	}
+
+	// the diffs aren't persisted if disabled, nor for the blocks written before they were enabled
+	diff, err := f.blockchain.GetStateDiffByHash(block.Hash())
+	if err == nil {
+		record.StateDiff = diff
+	} else if !errors.Is(err, blockchain.ErrStateDiffsDisabled) && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get state diff: %w", err)
+	}
+
+	if f.traces && len(block.Transactions) > 0 {
+		results, err := f.tracer.TraceBlock(block, calltracer.NewCallTracer())
+		if err != nil {
+			return nil, fmt.Errorf("failed to trace block: %w", err)
+		}
+
+		record.Traces = make([]*calltracer.Call, len(results))
+
+		for idx, result := range results {
+			record.Traces[idx], _ = result.(*calltracer.Call)
+		}
+	}
+
+	return record, nil
+}
+
+// commit assigns the offsets of the records and commits them to the sink,
+// the checkpoint of the firehose is updated once they are committed
+func (f *Firehose) commit(records []*Record, blocks []BlockRef) error {
+	checkpoint := &Checkpoint{
+		Offset:   f.checkpoint.Offset,
+		Blocks:   blocks,
+		Position: f.checkpoint.Position,
+	}
+
+	encoded := make([][]byte, len(records))
+
+	for idx, record := range records {
+		record.Offset = checkpoint.Offset
+		checkpoint.Offset++
+
+		data, err := record.Marshal()
+		if err != nil {
+			return fmt.Errorf("failed to encode record: %w", err)
+		}
+
+		encoded[idx] = data
+	}
+
+	if err := f.sink.Commit(encoded, checkpoint); err != nil {
+		// the sink may hold the records of an attempt whose outcome was lost,
+		// the stream resumes from the checkpoint the sink committed
+		if committed, cpErr := f.sink.Checkpoint(); cpErr == nil && committed != nil {
+			f.checkpoint = committed
+		}
+
+		return fmt.Errorf("failed to commit records: %w", err)
+	}
+
+	// the sink sets the position of the committed records
+	if committed, err := f.sink.Checkpoint(); err == nil && committed != nil {
+		checkpoint = committed
+	}
+
+	f.checkpoint = checkpoint
+
+	return nil
+}
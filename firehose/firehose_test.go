@@ -0,0 +1,371 @@
+package firehose
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/firehose/proto"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+)
+
+type mockBlockchain struct {
+	sub      *blockchain.MockSubscription
+	blocks   []*types.Block
+	receipts map[types.Hash][]*types.Receipt
+}
+
+func (m *mockBlockchain) Header() *types.Header {
+	return m.blocks[len(m.blocks)-1].Header
+}
+
+func (m *mockBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	if number >= uint64(len(m.blocks)) {
+		return nil, false
+	}
+
+	return m.blocks[number].Header, true
+}
+
+func (m *mockBlockchain) GetBlockByNumber(number uint64, full bool) (*types.Block, bool) {
+	if number >= uint64(len(m.blocks)) {
+		return nil, false
+	}
+
+	return m.blocks[number], true
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.receipts[hash], nil
+}
+
+func (m *mockBlockchain) GetStateDiffByHash(types.Hash) (*types.StateDiff, error) {
+	return nil, blockchain.ErrStateDiffsDisabled
+}
+
+func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
+	return m.sub
+}
+
+type mockTracer struct{}
+
+func (mockTracer) TraceBlock(block *types.Block, _ tracer.Tracer) ([]interface{}, error) {
+	results := make([]interface{}, len(block.Transactions))
+
+	for idx, tx := range block.Transactions {
+		results[idx] = &calltracer.Call{Type: calltracer.CallTypeCall, From: tx.From, To: *tx.To, Value: tx.Value}
+	}
+
+	return results, nil
+}
+
+// newBlock returns a block with a transaction from addr1 to addr2 per nonce
+func newBlock(parent *types.Header, timestamp uint64, nonces ...uint64) *types.Block {
+	header := &types.Header{Timestamp: timestamp}
+	if parent != nil {
+		header.Number = parent.Number + 1
+		header.ParentHash = parent.Hash
+	}
+
+	block := &types.Block{Header: header.ComputeHash()}
+
+	for idx, nonce := range nonces {
+		tx := &types.Transaction{
+			Nonce: nonce,
+			From:  addr1,
+			To:    &addr2,
+			Value: big.NewInt(1),
+		}
+		tx.Hash = types.BytesToHash(append(header.Hash.Bytes(), byte(idx)))
+
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	return block
+}
+
+func (m *mockBlockchain) addBlocks(blocks ...*types.Block) {
+	for _, block := range blocks {
+		receipts := make([]*types.Receipt, len(block.Transactions))
+
+		for idx, tx := range block.Transactions {
+			receipts[idx] = &types.Receipt{TxHash: tx.Hash, GasUsed: 21000}
+			receipts[idx].SetStatus(types.ReceiptSuccess)
+		}
+
+		m.receipts[block.Hash()] = receipts
+		m.blocks = append(m.blocks, block)
+	}
+}
+
+// streamedRecord is the header of a record read back from the stream
+type streamedRecord struct {
+	offset   uint64
+	typ      RecordType
+	number   uint64
+	hash     types.Hash
+	hasBlock bool
+}
+
+func readStream(t *testing.T, dir string) []streamedRecord {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(dir, streamFileName))
+	require.NoError(t, err)
+
+	records := []streamedRecord{}
+
+	for len(data) > 0 {
+		msg, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			// the record being written
+			break
+		}
+
+		data = data[n:]
+
+		record := &proto.Record{}
+		require.NoError(t, gproto.Unmarshal(msg, record))
+
+		records = append(records, streamedRecord{
+			offset:   record.Offset,
+			typ:      RecordType(record.Type),
+			number:   record.Number,
+			hash:     types.BytesToHash(record.Hash),
+			hasBlock: record.Block != nil,
+		})
+	}
+
+	return records
+}
+
+func waitForRecords(t *testing.T, dir string, count int) []streamedRecord {
+	t.Helper()
+
+	var records []streamedRecord
+
+	require.Eventually(t, func() bool {
+		records = readStream(t, dir)
+
+		return len(records) == count
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return records
+}
+
+func newTestFirehose(t *testing.T, dir string, chain *mockBlockchain) *Firehose {
+	t.Helper()
+
+	f, err := NewFirehose(hclog.NewNullLogger(), &Config{Sink: dir, Traces: true}, chain, mockTracer{})
+	require.NoError(t, err)
+
+	return f
+}
+
+func TestFirehose_StreamAndReorg(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	genesis := newBlock(nil, 0)
+	block1 := newBlock(genesis.Header, 1, 0)
+	block2 := newBlock(block1.Header, 2, 1)
+
+	chain := &mockBlockchain{
+		sub:      blockchain.NewMockSubscription(),
+		receipts: map[types.Hash][]*types.Receipt{},
+	}
+	chain.addBlocks(genesis, block1, block2)
+
+	f := newTestFirehose(t, dir, chain)
+	f.Start()
+
+	defer f.Close()
+
+	records := waitForRecords(t, dir, 3)
+
+	for idx, block := range []*types.Block{genesis, block1, block2} {
+		assert.Equal(t, streamedRecord{
+			offset:   uint64(idx),
+			typ:      RecordBlock,
+			number:   block.Number(),
+			hash:     block.Hash(),
+			hasBlock: true,
+		}, records[idx])
+	}
+
+	// block 2 is replaced by a fork
+	fork2 := newBlock(block1.Header, 3, 1)
+	fork3 := newBlock(fork2.Header, 4)
+
+	chain.blocks = chain.blocks[:2]
+	chain.addBlocks(fork2, fork3)
+
+	chain.sub.Push(&blockchain.Event{
+		Type:     blockchain.EventReorg,
+		OldChain: []*types.Header{block2.Header},
+		NewChain: []*types.Header{fork2.Header, fork3.Header},
+	})
+
+	records = waitForRecords(t, dir, 6)
+
+	assert.Equal(t, streamedRecord{offset: 3, typ: RecordUndo, number: 2, hash: block2.Hash()}, records[3])
+	assert.Equal(t, fork2.Hash(), records[4].hash)
+	assert.Equal(t, fork3.Hash(), records[5].hash)
+	assert.Equal(t, uint64(5), records[5].offset)
+}
+
+func TestFirehose_ResumesExactlyOnce(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	genesis := newBlock(nil, 0)
+	block1 := newBlock(genesis.Header, 1, 0)
+
+	chain := &mockBlockchain{
+		sub:      blockchain.NewMockSubscription(),
+		receipts: map[types.Hash][]*types.Receipt{},
+	}
+	chain.addBlocks(genesis, block1)
+
+	f := newTestFirehose(t, dir, chain)
+	f.Start()
+
+	waitForRecords(t, dir, 2)
+	require.NoError(t, f.Close())
+
+	// a crash leaves a record written but not committed
+	stream, err := os.OpenFile(filepath.Join(dir, streamFileName), os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+
+	data, err := (&Record{Offset: 2, Type: RecordBlock, Number: 2}).Marshal()
+	require.NoError(t, err)
+
+	_, err = stream.Write(protowire.AppendBytes(nil, data))
+	require.NoError(t, err)
+	require.NoError(t, stream.Close())
+
+	block2 := newBlock(block1.Header, 2, 1)
+	chain.addBlocks(block2)
+
+	f = newTestFirehose(t, dir, chain)
+	f.Start()
+
+	defer f.Close()
+
+	records := waitForRecords(t, dir, 3)
+
+	for idx, record := range records {
+		assert.Equal(t, uint64(idx), record.offset)
+		assert.Equal(t, uint64(idx), record.number)
+	}
+
+	assert.Equal(t, block2.Hash(), records[2].hash)
+}
+
+func TestOpenSink(t *testing.T) {
+	t.Parallel()
+
+	_, err := OpenSink("amqp://localhost:5672/blocks")
+	assert.ErrorIs(t, err, ErrUnknownSink)
+
+	_, err = OpenSink("kafka://localhost:9092")
+	assert.ErrorIs(t, err, errInvalidKafkaLocation)
+
+	sink, err := OpenSink("file://" + t.TempDir())
+	require.NoError(t, err)
+
+	checkpoint, err := sink.Checkpoint()
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+	assert.NoError(t, sink.Close())
+}
+
+func TestRecord_Marshal(t *testing.T) {
+	t.Parallel()
+
+	block := newBlock(newBlock(nil, 0).Header, 1, 7)
+	tx := block.Transactions[0]
+
+	receipt := &types.Receipt{
+		GasUsed:           21000,
+		CumulativeGasUsed: 21000,
+		Logs:              []*types.Log{{Address: addr2, Topics: []types.Hash{types.StringToHash("1")}, Data: []byte{1}}},
+	}
+	receipt.SetStatus(types.ReceiptSuccess)
+
+	record := &Record{
+		Offset:   3,
+		Type:     RecordBlock,
+		Number:   block.Number(),
+		Hash:     block.Hash(),
+		Block:    block,
+		Receipts: []*types.Receipt{receipt},
+		Traces: []*calltracer.Call{{
+			Type:  calltracer.CallTypeCall,
+			From:  addr1,
+			To:    addr2,
+			Value: big.NewInt(1),
+			Calls: []*calltracer.Call{{Type: calltracer.CallTypeCall, From: addr2, To: addr1}},
+		}},
+		StateDiff: &types.StateDiff{
+			Accounts: []*types.AccountDiff{{Address: addr1, PrevNonce: 7, Nonce: 8}},
+		},
+	}
+
+	data, err := record.Marshal()
+	require.NoError(t, err)
+
+	decoded := &proto.Record{}
+	require.NoError(t, gproto.Unmarshal(data, decoded))
+
+	assert.Equal(t, uint64(3), decoded.Offset)
+	assert.Equal(t, proto.RecordType_BLOCK, decoded.Type)
+	assert.Equal(t, block.Hash().Bytes(), decoded.Hash)
+	assert.Equal(t, block.ParentHash().Bytes(), decoded.Block.ParentHash)
+
+	require.Len(t, decoded.Block.Transactions, 1)
+
+	decodedTx := decoded.Block.Transactions[0]
+	assert.Equal(t, tx.Hash.Bytes(), decodedTx.Hash)
+	assert.Equal(t, addr2.Bytes(), decodedTx.To)
+	assert.Equal(t, uint64(7), decodedTx.Nonce)
+	assert.Equal(t, []byte{1}, decodedTx.Value)
+
+	assert.True(t, decodedTx.Receipt.Success)
+	assert.Equal(t, uint64(21000), decodedTx.Receipt.GasUsed)
+	require.Len(t, decodedTx.Receipt.Logs, 1)
+	assert.Equal(t, [][]byte{types.StringToHash("1").Bytes()}, decodedTx.Receipt.Logs[0].Topics)
+
+	assert.Equal(t, calltracer.CallTypeCall, decodedTx.Trace.Type)
+	require.Len(t, decodedTx.Trace.Calls, 1)
+	assert.Equal(t, addr1.Bytes(), decodedTx.Trace.Calls[0].To)
+
+	require.Len(t, decoded.Block.StateDiff, 1)
+	assert.Equal(t, uint64(8), decoded.Block.StateDiff[0].Nonce)
+
+	// the undo records carry the block reference only
+	data, err = (&Record{Offset: 4, Type: RecordUndo, Number: 1, Hash: block.Hash(), Block: block}).Marshal()
+	require.NoError(t, err)
+
+	decoded = &proto.Record{}
+	require.NoError(t, gproto.Unmarshal(data, decoded))
+	assert.Equal(t, proto.RecordType_UNDO, decoded.Type)
+	assert.Nil(t, decoded.Block)
+}
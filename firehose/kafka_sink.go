@@ -0,0 +1,331 @@
+package firehose
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	kafkaScheme = "kafka"
+
+	// kafkaPartitionID is the partition the records are produced to, a single one keeps them ordered
+	kafkaPartitionID = 0
+
+	// checkpointHeader is the header of the last message of each commit holding the checkpoint
+	checkpointHeader = "firehose-checkpoint"
+
+	// kafkaTimeout bounds each request to the broker
+	kafkaTimeout = 10 * time.Second
+
+	// kafkaMaxMessageBytes bounds the size of the last message read to resume the stream
+	kafkaMaxMessageBytes = 64 << 20
+)
+
+var (
+	errInvalidKafkaLocation = errors.New("invalid kafka location, expected kafka://<broker>[,<broker>...]/<topic>")
+	errMissingCheckpoint    = errors.New("the last message of the topic doesn't hold a firehose checkpoint")
+	errUnexpectedCommit     = errors.New("the topic holds another commit than the one being written")
+)
+
+// kafkaPartition is the partition of the topic the records are produced to
+type kafkaPartition interface {
+	// ReadLastOffset returns the offset of the next message of the partition
+	ReadLastOffset() (int64, error)
+
+	// ReadMessage returns the message at the offset
+	ReadMessage(offset int64) (kafka.Message, error)
+
+	// WriteMessages appends the messages to the partition, all of them or none
+	WriteMessages(msgs ...kafka.Message) error
+
+	Close() error
+}
+
+// kafkaSink produces the records to a Kafka topic. Each commit is produced as a single batch
+// appended atomically by the broker, whose last message holds the checkpoint in a header.
+// The checkpoint is read back from the last message of the partition when opened,
+// and compared with the end of the partition before each commit,
+// so a commit whose acknowledgment was lost isn't produced twice
+type kafkaSink struct {
+	dial      func() (kafkaPartition, error)
+	partition kafkaPartition
+
+	checkpoint *Checkpoint
+}
+
+// parseKafkaLocation returns the brokers and the topic of the location <broker>[,<broker>...]/<topic>
+func parseKafkaLocation(location string) ([]string, string, error) {
+	idx := strings.Index(location, "/")
+	if idx <= 0 || idx == len(location)-1 || strings.Contains(location[idx+1:], "/") {
+		return nil, "", fmt.Errorf("%w: %s", errInvalidKafkaLocation, location)
+	}
+
+	brokers := strings.Split(location[:idx], ",")
+	for _, broker := range brokers {
+		if broker == "" {
+			return nil, "", fmt.Errorf("%w: %s", errInvalidKafkaLocation, location)
+		}
+	}
+
+	return brokers, location[idx+1:], nil
+}
+
+func openKafkaSink(location string) (Sink, error) {
+	brokers, topic, err := parseKafkaLocation(location)
+	if err != nil {
+		return nil, err
+	}
+
+	return newKafkaSink(func() (kafkaPartition, error) {
+		return dialKafkaPartition(brokers, topic)
+	})
+}
+
+func newKafkaSink(dial func() (kafkaPartition, error)) (*kafkaSink, error) {
+	s := &kafkaSink{dial: dial}
+
+	partition, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.checkpoint, err = readKafkaCheckpoint(partition); err != nil {
+		_ = s.Close()
+
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// connect returns the connection to the partition, dialing it again after a failure
+func (s *kafkaSink) connect() (kafkaPartition, error) {
+	if s.partition == nil {
+		partition, err := s.dial()
+		if err != nil {
+			return nil, err
+		}
+
+		s.partition = partition
+	}
+
+	return s.partition, nil
+}
+
+// fail drops the connection, whose state is unknown after an error
+func (s *kafkaSink) fail(err error) error {
+	if s.partition != nil {
+		_ = s.partition.Close()
+		s.partition = nil
+	}
+
+	return err
+}
+
+// readKafkaCheckpoint returns the checkpoint of the last message of the partition, nil if it is empty
+func readKafkaCheckpoint(partition kafkaPartition) (*Checkpoint, error) {
+	end, err := partition.ReadLastOffset()
+	if err != nil {
+		return nil, err
+	}
+
+	if end == 0 {
+		return nil, nil
+	}
+
+	msg, err := partition.ReadMessage(end - 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the last message: %w", err)
+	}
+
+	for _, header := range msg.Headers {
+		if header.Key != checkpointHeader {
+			continue
+		}
+
+		checkpoint := &Checkpoint{}
+		if err := json.Unmarshal(header.Value, checkpoint); err != nil {
+			return nil, fmt.Errorf("failed to decode checkpoint: %w", err)
+		}
+
+		checkpoint.Position = uint64(end)
+
+		return checkpoint, nil
+	}
+
+	return nil, fmt.Errorf("%w, at offset %d", errMissingCheckpoint, end-1)
+}
+
+func (s *kafkaSink) Checkpoint() (*Checkpoint, error) {
+	return s.checkpoint, nil
+}
+
+func (s *kafkaSink) Commit(records [][]byte, checkpoint *Checkpoint) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	partition, err := s.connect()
+	if err != nil {
+		return err
+	}
+
+	var position uint64
+	if s.checkpoint != nil {
+		position = s.checkpoint.Position
+	}
+
+	end, err := partition.ReadLastOffset()
+	if err != nil {
+		return s.fail(err)
+	}
+
+	if uint64(end) != position {
+		// the previous attempt was appended although it failed, it's the commit
+		// being written if the checkpoints match
+		committed, err := readKafkaCheckpoint(partition)
+		if err != nil {
+			return s.fail(err)
+		}
+
+		s.checkpoint = committed
+
+		if committed == nil || !sameCheckpoint(committed, checkpoint) {
+			return fmt.Errorf("%w, the partition ends at offset %d instead of %d", errUnexpectedCommit, end, position)
+		}
+
+		return nil
+	}
+
+	committed := *checkpoint
+	committed.Position = position + uint64(len(records))
+
+	data, err := json.Marshal(&committed)
+	if err != nil {
+		return err
+	}
+
+	msgs := make([]kafka.Message, len(records))
+	for idx, record := range records {
+		msgs[idx] = kafka.Message{Value: record}
+	}
+
+	msgs[len(msgs)-1].Headers = []kafka.Header{{Key: checkpointHeader, Value: data}}
+
+	if err := partition.WriteMessages(msgs...); err != nil {
+		return s.fail(err)
+	}
+
+	s.checkpoint = &committed
+
+	return nil
+}
+
+// sameCheckpoint checks whether the checkpoints are at the same offset with the same streamed blocks
+func sameCheckpoint(a, b *Checkpoint) bool {
+	if a.Offset != b.Offset || len(a.Blocks) != len(b.Blocks) {
+		return false
+	}
+
+	for idx, block := range a.Blocks {
+		if block != b.Blocks[idx] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *kafkaSink) Close() error {
+	if s.partition == nil {
+		return nil
+	}
+
+	err := s.partition.Close()
+	s.partition = nil
+
+	return err
+}
+
+// kafkaConn is the connection to the leader of the partition of the topic
+type kafkaConn struct {
+	conn *kafka.Conn
+}
+
+// dialKafkaPartition connects to the leader of the partition through the first reachable broker,
+// the messages are acknowledged once replicated to all the in-sync replicas
+func dialKafkaPartition(brokers []string, topic string) (kafkaPartition, error) {
+	var errs []string
+
+	for _, broker := range brokers {
+		ctx, cancel := context.WithTimeout(context.Background(), kafkaTimeout)
+		conn, err := kafka.DialLeader(ctx, "tcp", broker, topic, kafkaPartitionID)
+
+		cancel()
+
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", broker, err))
+
+			continue
+		}
+
+		if err := conn.SetRequiredAcks(-1); err != nil {
+			_ = conn.Close()
+
+			return nil, err
+		}
+
+		return &kafkaConn{conn: conn}, nil
+	}
+
+	return nil, fmt.Errorf("failed to connect to the kafka brokers, %s", strings.Join(errs, ", "))
+}
+
+func (c *kafkaConn) ReadLastOffset() (int64, error) {
+	if err := c.conn.SetDeadline(time.Now().Add(kafkaTimeout)); err != nil {
+		return 0, err
+	}
+
+	return c.conn.ReadLastOffset()
+}
+
+func (c *kafkaConn) ReadMessage(offset int64) (kafka.Message, error) {
+	if err := c.conn.SetDeadline(time.Now().Add(kafkaTimeout)); err != nil {
+		return kafka.Message{}, err
+	}
+
+	if _, err := c.conn.Seek(offset, kafka.SeekAbsolute); err != nil {
+		return kafka.Message{}, err
+	}
+
+	msg, err := c.conn.ReadMessage(kafkaMaxMessageBytes)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+
+	if msg.Offset != offset {
+		return kafka.Message{}, fmt.Errorf("read the message at offset %d instead of %d", msg.Offset, offset)
+	}
+
+	return msg, nil
+}
+
+func (c *kafkaConn) WriteMessages(msgs ...kafka.Message) error {
+	if err := c.conn.SetDeadline(time.Now().Add(kafkaTimeout)); err != nil {
+		return err
+	}
+
+	_, err := c.conn.WriteMessages(msgs...)
+
+	return err
+}
+
+func (c *kafkaConn) Close() error {
+	return c.conn.Close()
+}
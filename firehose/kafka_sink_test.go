@@ -0,0 +1,183 @@
+package firehose
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errBrokerDown = errors.New("broker down")
+
+// mockPartition is a partition in memory, whose writes can fail before or after appending
+type mockPartition struct {
+	msgs []kafka.Message
+
+	// failWrite fails the next write without appending the messages
+	failWrite bool
+	// loseAck appends the messages of the next write but fails it
+	loseAck bool
+
+	dials int
+}
+
+func (m *mockPartition) dial() (kafkaPartition, error) {
+	m.dials++
+
+	return m, nil
+}
+
+func (m *mockPartition) ReadLastOffset() (int64, error) {
+	return int64(len(m.msgs)), nil
+}
+
+func (m *mockPartition) ReadMessage(offset int64) (kafka.Message, error) {
+	return m.msgs[offset], nil
+}
+
+func (m *mockPartition) WriteMessages(msgs ...kafka.Message) error {
+	if m.failWrite {
+		m.failWrite = false
+
+		return errBrokerDown
+	}
+
+	for _, msg := range msgs {
+		msg.Offset = int64(len(m.msgs))
+		m.msgs = append(m.msgs, msg)
+	}
+
+	if m.loseAck {
+		m.loseAck = false
+
+		return errBrokerDown
+	}
+
+	return nil
+}
+
+func (m *mockPartition) Close() error {
+	return nil
+}
+
+func testCheckpoint(offset uint64, number uint64) *Checkpoint {
+	return &Checkpoint{
+		Offset: offset,
+		Blocks: []BlockRef{{Number: number, Hash: types.BytesToHash([]byte{byte(number) + 1})}},
+	}
+}
+
+func TestParseKafkaLocation(t *testing.T) {
+	t.Parallel()
+
+	brokers, topic, err := parseKafkaLocation("broker1:9092,broker2:9092/blocks")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"broker1:9092", "broker2:9092"}, brokers)
+	assert.Equal(t, "blocks", topic)
+
+	for _, location := range []string{"", "broker:9092", "broker:9092/", "/blocks", "a,,b/blocks", "broker/a/b"} {
+		_, _, err := parseKafkaLocation(location)
+		assert.ErrorIs(t, err, errInvalidKafkaLocation, location)
+	}
+}
+
+func TestKafkaSink_Commit(t *testing.T) {
+	t.Parallel()
+
+	partition := &mockPartition{}
+
+	sink, err := newKafkaSink(partition.dial)
+	require.NoError(t, err)
+
+	checkpoint, err := sink.Checkpoint()
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	require.NoError(t, sink.Commit([][]byte{{0x1}, {0x2}}, testCheckpoint(2, 0)))
+	require.NoError(t, sink.Commit([][]byte{{0x3}}, testCheckpoint(3, 1)))
+
+	require.Len(t, partition.msgs, 3)
+	assert.Equal(t, []byte{0x3}, partition.msgs[2].Value)
+
+	// only the last message of each commit holds the checkpoint
+	assert.Empty(t, partition.msgs[0].Headers)
+	assert.Equal(t, checkpointHeader, partition.msgs[1].Headers[0].Key)
+
+	// the stream resumes from the last message
+	resumed, err := newKafkaSink(partition.dial)
+	require.NoError(t, err)
+
+	checkpoint, err = resumed.Checkpoint()
+	require.NoError(t, err)
+
+	expected := testCheckpoint(3, 1)
+	expected.Position = 3
+
+	assert.Equal(t, expected, checkpoint)
+}
+
+func TestKafkaSink_FailedWrite(t *testing.T) {
+	t.Parallel()
+
+	partition := &mockPartition{}
+
+	sink, err := newKafkaSink(partition.dial)
+	require.NoError(t, err)
+
+	partition.failWrite = true
+	assert.ErrorIs(t, sink.Commit([][]byte{{0x1}}, testCheckpoint(1, 0)), errBrokerDown)
+	assert.Empty(t, partition.msgs)
+
+	// the retry is written once, on a new connection
+	require.NoError(t, sink.Commit([][]byte{{0x1}}, testCheckpoint(1, 0)))
+	assert.Len(t, partition.msgs, 1)
+	assert.Equal(t, 2, partition.dials)
+}
+
+func TestKafkaSink_LostAcknowledgment(t *testing.T) {
+	t.Parallel()
+
+	partition := &mockPartition{}
+
+	sink, err := newKafkaSink(partition.dial)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Commit([][]byte{{0x1}}, testCheckpoint(1, 0)))
+
+	// the records are appended but the write fails
+	partition.loseAck = true
+	assert.ErrorIs(t, sink.Commit([][]byte{{0x2}, {0x3}}, testCheckpoint(3, 1)), errBrokerDown)
+	assert.Len(t, partition.msgs, 3)
+
+	// the retry isn't produced twice
+	require.NoError(t, sink.Commit([][]byte{{0x2}, {0x3}}, testCheckpoint(3, 1)))
+	assert.Len(t, partition.msgs, 3)
+
+	checkpoint, err := sink.Checkpoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), checkpoint.Position)
+
+	// another commit than the appended one is rejected, the sink resumes from the appended one
+	partition.loseAck = true
+	assert.ErrorIs(t, sink.Commit([][]byte{{0x4}}, testCheckpoint(4, 2)), errBrokerDown)
+
+	assert.ErrorIs(t, sink.Commit([][]byte{{0x5}}, testCheckpoint(4, 3)), errUnexpectedCommit)
+	assert.Len(t, partition.msgs, 4)
+
+	checkpoint, err = sink.Checkpoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), checkpoint.Position)
+	assert.Equal(t, uint64(2), checkpoint.Blocks[0].Number)
+}
+
+func TestKafkaSink_ForeignTopic(t *testing.T) {
+	t.Parallel()
+
+	partition := &mockPartition{msgs: []kafka.Message{{Value: []byte{0x1}}}}
+
+	_, err := newKafkaSink(partition.dial)
+	assert.ErrorIs(t, err, errMissingCheckpoint)
+}
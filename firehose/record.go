@@ -0,0 +1,211 @@
+package firehose
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/firehose/proto"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// RecordType is the type of a record of the stream
+type RecordType uint64
+
+const (
+	// RecordBlock is a block added to the canonical chain
+	RecordBlock RecordType = 1
+
+	// RecordUndo is a streamed block removed from the canonical chain by a reorg
+	RecordUndo RecordType = 2
+)
+
+// Record is an entry of the stream, encoded as the Record message of proto/firehose.proto,
+// the Go types generated from it decode the records
+type Record struct {
+	// Offset is the position of the record in the stream, the offsets are consecutive
+	Offset uint64
+	Type   RecordType
+	Number uint64
+	Hash   types.Hash
+
+	// the content of the block records
+	Block    *types.Block
+	Receipts []*types.Receipt
+
+	// Traces are the call trees of the transactions, nil if the traces aren't streamed
+	Traces []*calltracer.Call
+
+	// StateDiff is nil if the node doesn't persist the state diffs
+	StateDiff *types.StateDiff
+}
+
+// Marshal encodes the record as the Record message of proto/firehose.proto
+func (r *Record) Marshal() ([]byte, error) {
+	return gproto.Marshal(r.toProto())
+}
+
+func (r *Record) toProto() *proto.Record {
+	msg := &proto.Record{
+		Offset: r.Offset,
+		Type:   proto.RecordType(r.Type),
+		Number: r.Number,
+		Hash:   r.Hash.Bytes(),
+	}
+
+	if r.Type == RecordBlock && r.Block != nil {
+		msg.Block = r.blockToProto()
+	}
+
+	return msg
+}
+
+func (r *Record) blockToProto() *proto.Block {
+	header := r.Block.Header
+
+	msg := &proto.Block{
+		ParentHash: header.ParentHash.Bytes(),
+		Miner:      header.Miner,
+		StateRoot:  header.StateRoot.Bytes(),
+		GasLimit:   header.GasLimit,
+		GasUsed:    header.GasUsed,
+		Timestamp:  header.Timestamp,
+		ExtraData:  header.ExtraData,
+	}
+
+	for idx, tx := range r.Block.Transactions {
+		var (
+			receipt *types.Receipt
+			trace   *calltracer.Call
+		)
+
+		if idx < len(r.Receipts) {
+			receipt = r.Receipts[idx]
+		}
+
+		if idx < len(r.Traces) {
+			trace = r.Traces[idx]
+		}
+
+		msg.Transactions = append(msg.Transactions, transactionToProto(tx, receipt, trace))
+	}
+
+	if r.StateDiff != nil {
+		for _, account := range r.StateDiff.Accounts {
+			msg.StateDiff = append(msg.StateDiff, accountDiffToProto(account))
+		}
+	}
+
+	return msg
+}
+
+func transactionToProto(tx *types.Transaction, receipt *types.Receipt, trace *calltracer.Call) *proto.Transaction {
+	msg := &proto.Transaction{
+		Hash:     tx.Hash.Bytes(),
+		From:     tx.From.Bytes(),
+		Nonce:    tx.Nonce,
+		Value:    bigBytes(tx.Value),
+		Gas:      tx.Gas,
+		GasPrice: bigBytes(tx.GasPrice),
+		Input:    tx.Input,
+		Type:     uint32(tx.Type),
+	}
+
+	if tx.To != nil {
+		msg.To = tx.To.Bytes()
+	}
+
+	if receipt != nil {
+		msg.Receipt = receiptToProto(receipt)
+	}
+
+	if trace != nil {
+		msg.Trace = callToProto(trace)
+	}
+
+	return msg
+}
+
+func receiptToProto(receipt *types.Receipt) *proto.Receipt {
+	msg := &proto.Receipt{
+		Success:           receipt.Status != nil && *receipt.Status == types.ReceiptSuccess,
+		GasUsed:           receipt.GasUsed,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		RevertReason:      receipt.RevertReason,
+	}
+
+	if receipt.ContractAddress != nil {
+		msg.ContractAddress = receipt.ContractAddress.Bytes()
+	}
+
+	for _, log := range receipt.Logs {
+		logMsg := &proto.Log{
+			Address: log.Address.Bytes(),
+			Data:    log.Data,
+		}
+
+		for _, topic := range log.Topics {
+			logMsg.Topics = append(logMsg.Topics, topic.Bytes())
+		}
+
+		msg.Logs = append(msg.Logs, logMsg)
+	}
+
+	return msg
+}
+
+func callToProto(call *calltracer.Call) *proto.Call {
+	msg := &proto.Call{
+		Type:    call.Type,
+		From:    call.From.Bytes(),
+		To:      call.To.Bytes(),
+		Value:   bigBytes(call.Value),
+		Gas:     call.Gas,
+		GasUsed: call.GasUsed,
+		Input:   call.Input,
+		Output:  call.Output,
+	}
+
+	if call.Err != nil {
+		msg.Error = calltracer.ErrorString(call.Err)
+	}
+
+	for _, sub := range call.Calls {
+		msg.Calls = append(msg.Calls, callToProto(sub))
+	}
+
+	return msg
+}
+
+func accountDiffToProto(diff *types.AccountDiff) *proto.AccountDiff {
+	msg := &proto.AccountDiff{
+		Address:      diff.Address.Bytes(),
+		Created:      diff.Created,
+		Deleted:      diff.Deleted,
+		PrevNonce:    diff.PrevNonce,
+		Nonce:        diff.Nonce,
+		PrevBalance:  bigBytes(diff.PrevBalance),
+		Balance:      bigBytes(diff.Balance),
+		PrevCodeHash: diff.PrevCodeHash.Bytes(),
+		CodeHash:     diff.CodeHash.Bytes(),
+	}
+
+	for _, slot := range diff.Storage {
+		msg.Storage = append(msg.Storage, &proto.StorageDiff{
+			Key:       slot.Key.Bytes(),
+			PrevValue: slot.PrevValue.Bytes(),
+			Value:     slot.Value.Bytes(),
+		})
+	}
+
+	return msg
+}
+
+// bigBytes returns the big-endian bytes of the value, nil if unset
+func bigBytes(v *big.Int) []byte {
+	if v == nil {
+		return nil
+	}
+
+	return v.Bytes()
+}
@@ -0,0 +1,112 @@
+package firehose
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	fileScheme = "file"
+
+	schemeSeparator = "://"
+)
+
+var ErrUnknownSink = errors.New("unknown sink")
+
+// Sink is where the records are streamed to. The records are written with the checkpoint
+// of the stream atomically, which is what makes the stream exactly once: after a crash,
+// the stream resumes from the last committed checkpoint, the records written after it being discarded
+type Sink interface {
+	// Checkpoint returns the last committed checkpoint, nil if nothing is committed
+	Checkpoint() (*Checkpoint, error)
+
+	// Commit writes the encoded records and commits them with the checkpoint,
+	// either all of them are committed or none
+	Commit(records [][]byte, checkpoint *Checkpoint) error
+
+	Close() error
+}
+
+// Checkpoint is the position of the stream
+type Checkpoint struct {
+	// Offset is the offset of the next record
+	Offset uint64 `json:"offset"`
+
+	// Blocks are the most recent streamed blocks, the last one being the head of the stream.
+	// They are compared to the canonical chain to detect the reorgs
+	Blocks []BlockRef `json:"blocks"`
+
+	// Position is the position of the committed records in the sink, such as the size of a file
+	Position uint64 `json:"position"`
+}
+
+// BlockRef is a streamed block
+type BlockRef struct {
+	Number uint64     `json:"number"`
+	Hash   types.Hash `json:"hash"`
+}
+
+// SinkFactory opens the sink at the location, stripped of its scheme
+type SinkFactory func(location string) (Sink, error)
+
+var (
+	sinksLock sync.RWMutex
+	sinks     = map[string]SinkFactory{
+		fileScheme:  openFileSink,
+		kafkaScheme: openKafkaSink,
+	}
+)
+
+// RegisterSink makes the sink available for the locations of the scheme, such as the producer
+// of another message broker registered from the init function of a package compiled in.
+// It panics if a sink of the same scheme is registered, as it's a programming error
+func RegisterSink(scheme string, factory SinkFactory) {
+	sinksLock.Lock()
+	defer sinksLock.Unlock()
+
+	if _, ok := sinks[scheme]; ok {
+		panic(fmt.Sprintf("sink %s registered twice", scheme))
+	}
+
+	sinks[scheme] = factory
+}
+
+// RegisteredSinks returns the schemes of the registered sinks, sorted
+func RegisteredSinks() []string {
+	sinksLock.RLock()
+	defer sinksLock.RUnlock()
+
+	schemes := make([]string, 0, len(sinks))
+	for scheme := range sinks {
+		schemes = append(schemes, scheme)
+	}
+
+	sort.Strings(schemes)
+
+	return schemes
+}
+
+// OpenSink opens the sink at the location, which is either a local directory, a Kafka topic
+// kafka://<broker>[,<broker>...]/<topic>, or a url whose scheme names a registered sink
+func OpenSink(location string) (Sink, error) {
+	scheme, path := fileScheme, location
+
+	if idx := strings.Index(location, schemeSeparator); idx >= 0 {
+		scheme, path = location[:idx], location[idx+len(schemeSeparator):]
+	}
+
+	sinksLock.RLock()
+	factory, ok := sinks[scheme]
+	sinksLock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w %s, the registered sinks are %v", ErrUnknownSink, scheme, RegisteredSinks())
+	}
+
+	return factory(path)
+}
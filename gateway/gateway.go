@@ -0,0 +1,173 @@
+package gateway
+
+import (
+	_ "embed"
+	"net/http"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	gproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// apiPrefix is the path prefix of the REST endpoints
+	apiPrefix = "/v1/"
+
+	// blockQueryParam is the query parameter selecting the block of the state queries
+	blockQueryParam = "block"
+)
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+var marshaler = protojson.MarshalOptions{
+	EmitUnpopulated: true,
+}
+
+// Gateway is a REST/JSON gateway that translates the HTTP requests
+// to the read endpoints of the System gRPC service, described by openapi.json:
+//
+// GET /v1/status
+//
+// GET /v1/blocks/{block}
+//
+// GET /v1/transactions/{hash}
+//
+// GET /v1/accounts/{address}/balance?block={block}
+//
+// GET /v1/validators?block={block}
+//
+// GET /v1/openapi.json
+//
+// The block is a number, a hash or "latest"
+type Gateway struct {
+	logger hclog.Logger
+	system proto.SystemServer
+}
+
+// NewGateway creates a gateway that serves the given System service in-process
+func NewGateway(logger hclog.Logger, system proto.SystemServer) *Gateway {
+	return &Gateway{
+		logger: logger.Named("gateway"),
+		system: system,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		g.writeResponse(
+			w,
+			http.StatusMethodNotAllowed,
+			status.Newf(codes.Unimplemented, "method %s not allowed", r.Method).Proto(),
+		)
+
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		g.writeError(w, status.Errorf(codes.NotFound, "path %s not found", r.URL.Path))
+
+		return
+	}
+
+	var (
+		ctx   = r.Context()
+		block = r.URL.Query().Get(blockQueryParam)
+		parts = strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/"), "/")
+
+		resp gproto.Message
+		err  error
+	)
+
+	switch {
+	case len(parts) == 1 && parts[0] == "openapi.json":
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openAPISpec)
+
+		return
+	case len(parts) == 1 && parts[0] == "status":
+		resp, err = g.system.GetStatus(ctx, &emptypb.Empty{})
+	case len(parts) == 2 && parts[0] == "blocks":
+		resp, err = g.system.GetBlock(ctx, &proto.GetBlockRequest{Block: parts[1]})
+	case len(parts) == 2 && parts[0] == "transactions":
+		resp, err = g.system.GetTransaction(ctx, &proto.GetTransactionRequest{Hash: parts[1]})
+	case len(parts) == 3 && parts[0] == "accounts" && parts[2] == "balance":
+		resp, err = g.system.GetBalance(ctx, &proto.GetBalanceRequest{Address: parts[1], Block: block})
+	case len(parts) == 1 && parts[0] == "validators":
+		resp, err = g.system.GetValidators(ctx, &proto.GetValidatorsRequest{Block: block})
+	default:
+		err = status.Errorf(codes.NotFound, "path %s not found", r.URL.Path)
+	}
+
+	if err != nil {
+		g.writeError(w, err)
+
+		return
+	}
+
+	g.writeResponse(w, http.StatusOK, resp)
+}
+
+// writeResponse writes the JSON encoded message with the given HTTP status code
+func (g *Gateway) writeResponse(w http.ResponseWriter, code int, msg gproto.Message) {
+	data, err := marshaler.Marshal(msg)
+	if err != nil {
+		g.logger.Error("failed to marshal response", "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if _, err := w.Write(data); err != nil {
+		g.logger.Debug("failed to write response", "err", err)
+	}
+}
+
+// writeError writes the gRPC status of the error with the matching HTTP status code
+func (g *Gateway) writeError(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.New(codes.Unknown, err.Error())
+	}
+
+	g.writeResponse(w, httpStatusFromCode(st.Code()), st.Proto())
+}
+
+// httpStatusFromCode maps the gRPC status code to the HTTP status code
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,183 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testAddress = "0x0000000000000000000000000000000000000001"
+	testHash    = "0x0000000000000000000000000000000000000000000000000000000000000001"
+)
+
+type mockSystem struct {
+	proto.UnimplementedSystemServer
+
+	blocks map[string]*proto.Block
+}
+
+func (m *mockSystem) GetBlock(ctx context.Context, req *proto.GetBlockRequest) (*proto.Block, error) {
+	block, ok := m.blocks[req.Block]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "block %s not found", req.Block)
+	}
+
+	return block, nil
+}
+
+func (m *mockSystem) GetBalance(ctx context.Context, req *proto.GetBalanceRequest) (*proto.Balance, error) {
+	if req.Address != testAddress {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address %q", req.Address)
+	}
+
+	return &proto.Balance{
+		Address:     req.Address,
+		Balance:     "100",
+		Nonce:       1,
+		BlockNumber: m.blocks[req.Block].Number,
+	}, nil
+}
+
+func (m *mockSystem) GetValidators(
+	ctx context.Context,
+	req *proto.GetValidatorsRequest,
+) (*proto.ValidatorSet, error) {
+	return &proto.ValidatorSet{
+		BlockNumber: m.blocks[req.Block].Number,
+		Validators:  []string{testAddress},
+	}, nil
+}
+
+func newTestGateway() *Gateway {
+	block := &proto.Block{
+		Number:       2,
+		Hash:         testHash,
+		Transactions: []string{testHash},
+	}
+
+	return NewGateway(hclog.NewNullLogger(), &mockSystem{
+		blocks: map[string]*proto.Block{
+			"":       block,
+			"latest": block,
+			"2":      block,
+		},
+	})
+}
+
+func doRequest(t *testing.T, g *Gateway, method, path string) (int, map[string]interface{}) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, nil)
+	rec := httptest.NewRecorder()
+
+	g.ServeHTTP(rec, req)
+
+	body := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	return rec.Code, body
+}
+
+func TestGateway_Routes(t *testing.T) {
+	t.Parallel()
+
+	g := newTestGateway()
+
+	cases := []struct {
+		name     string
+		path     string
+		code     int
+		expected map[string]interface{}
+	}{
+		{
+			"block by number",
+			"/v1/blocks/2",
+			http.StatusOK,
+			map[string]interface{}{"number": "2", "hash": testHash},
+		},
+		{
+			"latest block",
+			"/v1/blocks/latest",
+			http.StatusOK,
+			map[string]interface{}{"number": "2"},
+		},
+		{
+			"unknown block",
+			"/v1/blocks/10",
+			http.StatusNotFound,
+			map[string]interface{}{"code": float64(codes.NotFound), "message": "block 10 not found"},
+		},
+		{
+			"balance",
+			"/v1/accounts/" + testAddress + "/balance?block=2",
+			http.StatusOK,
+			map[string]interface{}{"balance": "100", "nonce": "1", "blockNumber": "2"},
+		},
+		{
+			"invalid address",
+			"/v1/accounts/0x1/balance",
+			http.StatusBadRequest,
+			map[string]interface{}{"code": float64(codes.InvalidArgument)},
+		},
+		{
+			"validators",
+			"/v1/validators",
+			http.StatusOK,
+			map[string]interface{}{"validators": []interface{}{testAddress}},
+		},
+		{
+			"unimplemented",
+			"/v1/transactions/" + testHash,
+			http.StatusNotImplemented,
+			map[string]interface{}{"code": float64(codes.Unimplemented)},
+		},
+		{
+			"unknown path",
+			"/v1/unknown",
+			http.StatusNotFound,
+			map[string]interface{}{"code": float64(codes.NotFound)},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, body := doRequest(t, g, http.MethodGet, c.path)
+			assert.Equal(t, c.code, code)
+
+			for key, value := range c.expected {
+				assert.Equal(t, value, body[key], key)
+			}
+		})
+	}
+}
+
+func TestGateway_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	code, body := doRequest(t, newTestGateway(), http.MethodPost, "/v1/blocks/latest")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+	assert.Equal(t, float64(codes.Unimplemented), body["code"])
+}
+
+func TestGateway_OpenAPISpec(t *testing.T) {
+	t.Parallel()
+
+	code, body := doRequest(t, newTestGateway(), http.MethodGet, "/v1/openapi.json")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "2.0", body["swagger"])
+	assert.Contains(t, body["paths"], "/blocks/{block}")
+}
@@ -0,0 +1,382 @@
+package halt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/intent"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// stateFileName is the file of the data directory the halt is persisted to,
+	// the node stays halted across restarts until it's resumed
+	stateFileName = "halt.json"
+
+	// haltKey is the key of the halt intents
+	haltKey = "chain"
+)
+
+var (
+	ErrChainHalted         = errors.New("the chain is halted")
+	ErrNotHalted           = errors.New("the chain isn't halted")
+	ErrIntentsDisabled     = errors.New("the halts are signaled by the validator intents, which are disabled")
+	ErrInvalidHaltHeight   = errors.New("the halt height must be after the head")
+	ErrChainIDMismatch     = errors.New("the manifest belongs to another chain")
+	ErrHaltHeightMismatch  = errors.New("the manifest resumes another halt")
+	ErrChainConfigMismatch = errors.New("the node doesn't run the chain config of the manifest")
+	ErrNoSupermajority     = errors.New("the manifest isn't signed by a supermajority of the validators")
+)
+
+// Blockchain is the interface of the blockchain halted by the manager
+type Blockchain interface {
+	Header() *types.Header
+	SubscribeEvents() blockchain.Subscription
+}
+
+// ValidatorSetProvider returns the validator set of a height
+type ValidatorSetProvider interface {
+	GetValidators(height uint64) (validators.Validators, error)
+}
+
+// IntentStore signs and collects the halt intents of the validators
+type IntentStore interface {
+	Signals() ([]*intent.Signal, error)
+	Signal(i *intent.Intent) (*intent.SignedIntent, error)
+}
+
+// Status is the halt status of the node
+type Status struct {
+	// Height is the last block before the halt, 0 if the chain isn't halted
+	Height uint64
+
+	// Halted is set once the head reached the halt height
+	Halted bool
+
+	// Signers are the validators which signaled the halt
+	Signers []types.Address
+
+	// Resumed is the height of the last resumed halt
+	Resumed uint64
+
+	// Signals are the pending halt signals of the current validators
+	Signals []*intent.Signal
+}
+
+// state is the persisted halt state
+type state struct {
+	Height  uint64          `json:"height"`
+	Signers []types.Address `json:"signers"`
+	Resumed uint64          `json:"resumed"`
+}
+
+// Manager halts the chain at the height signaled by a supermajority of the validators:
+// the blocks past the halt height are neither produced nor imported until the chain
+// is resumed by a restart manifest signed by a supermajority of the validators
+type Manager struct {
+	logger          hclog.Logger
+	path            string
+	chainID         uint64
+	chainConfigHash types.Hash
+
+	blockchain    Blockchain
+	validatorSets ValidatorSetProvider
+
+	// intents exchanges the halt signals, nil if the validator intents are disabled
+	intents IntentStore
+
+	lock  sync.RWMutex
+	state state
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewManager creates the halt manager of the chain, loading the halt persisted in the data directory.
+// The halts are only signaled if intents is not nil
+func NewManager(
+	logger hclog.Logger,
+	dataDir string,
+	chainID uint64,
+	chainConfigHash types.Hash,
+	blockchain Blockchain,
+	validatorSets ValidatorSetProvider,
+	intents IntentStore,
+) (*Manager, error) {
+	m := &Manager{
+		logger:          logger.Named("halt"),
+		path:            filepath.Join(dataDir, stateFileName),
+		chainID:         chainID,
+		chainConfigHash: chainConfigHash,
+		blockchain:      blockchain,
+		validatorSets:   validatorSets,
+		intents:         intents,
+	}
+
+	raw, err := os.ReadFile(m.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if err == nil {
+		if err := json.Unmarshal(raw, &m.state); err != nil {
+			return nil, fmt.Errorf("invalid halt state %s: %w", m.path, err)
+		}
+	}
+
+	if m.state.Height != 0 {
+		m.logger.Warn("the chain is halted, waiting for a restart manifest", "height", m.state.Height)
+	}
+
+	return m, nil
+}
+
+// Start starts following the halt signals as the blocks are written
+func (m *Manager) Start() {
+	if m.intents == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+
+	sub := m.blockchain.SubscribeEvents()
+
+	m.wg.Add(1)
+
+	go func() {
+		defer m.wg.Done()
+		defer sub.Close()
+
+		eventCh := sub.GetEventCh()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-eventCh:
+				if ev == nil {
+					return
+				}
+
+				m.refresh()
+			}
+		}
+	}()
+}
+
+// Close stops following the halt signals
+func (m *Manager) Close() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+
+	m.wg.Wait()
+}
+
+// CheckBlock returns ErrChainHalted if the block of the given number is past the halt height
+func (m *Manager) CheckBlock(number uint64) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if m.state.Height != 0 && number > m.state.Height {
+		return fmt.Errorf("%w at block %d, waiting for a restart manifest", ErrChainHalted, m.state.Height)
+	}
+
+	return nil
+}
+
+// Signal signs the intent of the validator of the node to halt the chain after the given height
+func (m *Manager) Signal(height uint64) (*intent.SignedIntent, error) {
+	if m.intents == nil {
+		return nil, ErrIntentsDisabled
+	}
+
+	if height <= m.blockchain.Header().Number {
+		return nil, ErrInvalidHaltHeight
+	}
+
+	signed, err := m.intents.Signal(&intent.Intent{
+		Kind:   intent.KindHalt,
+		Key:    haltKey,
+		Value:  strconv.FormatUint(height, 10),
+		Expiry: height,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m.refresh()
+
+	return signed, nil
+}
+
+// Status returns the halt status of the node
+func (m *Manager) Status() (*Status, error) {
+	signals, err := m.haltSignals()
+	if err != nil {
+		return nil, err
+	}
+
+	head := m.blockchain.Header().Number
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return &Status{
+		Height:  m.state.Height,
+		Halted:  m.state.Height != 0 && head >= m.state.Height,
+		Signers: m.state.Signers,
+		Resumed: m.state.Resumed,
+		Signals: signals,
+	}, nil
+}
+
+// Resume resumes the halted chain with the manifest signed by a supermajority of the validators at the halt height
+func (m *Manager) Resume(manifest *Manifest) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.state.Height == 0 {
+		return ErrNotHalted
+	}
+
+	if manifest.ChainID != m.chainID {
+		return ErrChainIDMismatch
+	}
+
+	if manifest.HaltHeight != m.state.Height {
+		return fmt.Errorf("%w at block %d", ErrHaltHeightMismatch, manifest.HaltHeight)
+	}
+
+	if manifest.ChainConfigHash != types.ZeroHash && manifest.ChainConfigHash != m.chainConfigHash {
+		return ErrChainConfigMismatch
+	}
+
+	signers, err := manifest.Verify()
+	if err != nil {
+		return err
+	}
+
+	vals, err := m.validatorSets.GetValidators(m.state.Height)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+
+	for _, signer := range signers {
+		if vals.Includes(signer) {
+			count++
+		}
+	}
+
+	if 3*count <= 2*vals.Len() {
+		return fmt.Errorf("%w: %d signatures of %d validators", ErrNoSupermajority, count, vals.Len())
+	}
+
+	resumed := state{Resumed: m.state.Height}
+	if err := m.persist(resumed); err != nil {
+		return err
+	}
+
+	m.state = resumed
+
+	m.logger.Info("the chain is resumed", "height", resumed.Resumed, "signers", count)
+
+	return nil
+}
+
+// haltSignals returns the halt signals of the current validators
+func (m *Manager) haltSignals() ([]*intent.Signal, error) {
+	if m.intents == nil {
+		return nil, nil
+	}
+
+	signals, err := m.intents.Signals()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*intent.Signal, 0)
+
+	for _, signal := range signals {
+		if signal.Kind == intent.KindHalt && signal.Key == haltKey {
+			res = append(res, signal)
+		}
+	}
+
+	return res, nil
+}
+
+// refresh halts the chain at the lowest height signaled by a supermajority of the validators,
+// the heights of the resumed halts are ignored
+func (m *Manager) refresh() {
+	signals, err := m.haltSignals()
+	if err != nil {
+		m.logger.Error("failed to get the halt signals", "err", err)
+
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.state.Height != 0 {
+		return
+	}
+
+	var halt *intent.Signal
+
+	height := uint64(0)
+
+	for _, signal := range signals {
+		h, err := strconv.ParseUint(signal.Value, 10, 64)
+		if err != nil || h <= m.state.Resumed || !signal.Supermajority() {
+			continue
+		}
+
+		if halt == nil || h < height {
+			halt, height = signal, h
+		}
+	}
+
+	if halt == nil {
+		return
+	}
+
+	halted := state{
+		Height:  height,
+		Signers: halt.Validators,
+		Resumed: m.state.Resumed,
+	}
+
+	if err := m.persist(halted); err != nil {
+		m.logger.Error("failed to persist the halt", "err", err)
+
+		return
+	}
+
+	m.state = halted
+
+	m.logger.Warn("the validators signaled a halt, the chain stops after its block", "height", height)
+}
+
+// persist writes the halt state to the data directory
+func (m *Manager) persist(s state) error {
+	raw, err := json.Marshal(&s)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(m.path, raw, 0600)
+}
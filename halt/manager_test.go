@@ -0,0 +1,195 @@
+package halt
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/intent"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = 100
+
+type mockBlockchain struct {
+	head uint64
+}
+
+func (m *mockBlockchain) Header() *types.Header {
+	return &types.Header{Number: m.head}
+}
+
+func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
+	return blockchain.NewMockSubscription()
+}
+
+type mockValidatorSetProvider struct {
+	vals validators.Validators
+}
+
+func (m *mockValidatorSetProvider) GetValidators(uint64) (validators.Validators, error) {
+	return m.vals, nil
+}
+
+// mockIntentStore signals the intents of the node on behalf of the validators
+type mockIntentStore struct {
+	signers []types.Address
+	total   int
+	signals []*intent.Signal
+}
+
+func (m *mockIntentStore) Signals() ([]*intent.Signal, error) {
+	return m.signals, nil
+}
+
+func (m *mockIntentStore) Signal(i *intent.Intent) (*intent.SignedIntent, error) {
+	m.signals = append(m.signals, &intent.Signal{
+		Kind:       i.Kind,
+		Key:        i.Key,
+		Value:      i.Value,
+		Validators: m.signers,
+		Total:      m.total,
+	})
+
+	return &intent.SignedIntent{Intent: *i}, nil
+}
+
+func newTestKeys(t *testing.T, n int) ([]*ecdsa.PrivateKey, validators.Validators) {
+	t.Helper()
+
+	keys := make([]*ecdsa.PrivateKey, n)
+	vals := validators.NewECDSAValidatorSet()
+
+	for i := range keys {
+		key, err := crypto.GenerateECDSAKey()
+		require.NoError(t, err)
+
+		keys[i] = key
+
+		require.NoError(t, vals.Add(validators.NewECDSAValidator(crypto.PubKeyToAddress(&key.PublicKey))))
+	}
+
+	return keys, vals
+}
+
+func TestManifest_SignAndCoSign(t *testing.T) {
+	t.Parallel()
+
+	keys, _ := newTestKeys(t, 2)
+
+	manifest := &Manifest{ChainID: testChainID, HaltHeight: 100}
+	require.NoError(t, manifest.Sign(keys[0]))
+	assert.ErrorIs(t, manifest.Sign(keys[0]), ErrAlreadySigned)
+
+	other := &Manifest{ChainID: testChainID, HaltHeight: 100}
+	require.NoError(t, other.Sign(keys[1]))
+	require.NoError(t, manifest.CoSign(other))
+
+	signers, err := manifest.Verify()
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{
+		crypto.PubKeyToAddress(&keys[0].PublicKey),
+		crypto.PubKeyToAddress(&keys[1].PublicKey),
+	}, signers)
+
+	// the manifests of another halt can't be merged
+	assert.ErrorIs(t, manifest.CoSign(&Manifest{ChainID: testChainID, HaltHeight: 200}), ErrManifestMismatch)
+
+	// the signatures don't hold for a tampered manifest
+	manifest.HaltHeight = 101
+
+	_, err = manifest.Verify()
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestManager_HaltAndResume(t *testing.T) {
+	t.Parallel()
+
+	keys, vals := newTestKeys(t, 4)
+	dataDir := t.TempDir()
+	chainConfigHash := types.StringToHash("0x1")
+
+	blockchain := &mockBlockchain{head: 10}
+	intents := &mockIntentStore{
+		signers: []types.Address{vals.At(0).Addr(), vals.At(1).Addr()},
+		total:   4,
+	}
+
+	newManager := func() *Manager {
+		m, err := NewManager(
+			hclog.NewNullLogger(),
+			dataDir,
+			testChainID,
+			chainConfigHash,
+			blockchain,
+			&mockValidatorSetProvider{vals},
+			intents,
+		)
+		require.NoError(t, err)
+
+		return m
+	}
+
+	manager := newManager()
+
+	_, err := manager.Signal(10)
+	assert.ErrorIs(t, err, ErrInvalidHaltHeight)
+
+	// the chain doesn't halt until a supermajority signals it
+	_, err = manager.Signal(20)
+	require.NoError(t, err)
+	assert.NoError(t, manager.CheckBlock(21))
+
+	intents.signals[0].Validators = append(intents.signals[0].Validators, vals.At(2).Addr())
+	manager.refresh()
+
+	assert.NoError(t, manager.CheckBlock(20))
+	assert.ErrorIs(t, manager.CheckBlock(21), ErrChainHalted)
+
+	// the halt survives the restarts of the node
+	manager = newManager()
+	assert.ErrorIs(t, manager.CheckBlock(21), ErrChainHalted)
+
+	blockchain.head = 20
+
+	status, err := manager.Status()
+	require.NoError(t, err)
+	assert.True(t, status.Halted)
+	assert.Equal(t, uint64(20), status.Height)
+	assert.Len(t, status.Signers, 3)
+
+	sign := func(manifest *Manifest, keys ...*ecdsa.PrivateKey) *Manifest {
+		for _, key := range keys {
+			require.NoError(t, manifest.Sign(key))
+		}
+
+		return manifest
+	}
+
+	assert.ErrorIs(t, manager.Resume(sign(&Manifest{ChainID: testChainID + 1, HaltHeight: 20})), ErrChainIDMismatch)
+	assert.ErrorIs(t, manager.Resume(sign(&Manifest{ChainID: testChainID, HaltHeight: 19})), ErrHaltHeightMismatch)
+	assert.ErrorIs(t, manager.Resume(sign(&Manifest{
+		ChainID:         testChainID,
+		HaltHeight:      20,
+		ChainConfigHash: types.StringToHash("0x2"),
+	})), ErrChainConfigMismatch)
+	assert.ErrorIs(t, manager.Resume(sign(&Manifest{ChainID: testChainID, HaltHeight: 20}, keys[0], keys[1])), ErrNoSupermajority)
+
+	require.NoError(t, manager.Resume(sign(&Manifest{
+		ChainID:         testChainID,
+		HaltHeight:      20,
+		ChainConfigHash: chainConfigHash,
+	}, keys[0], keys[1], keys[2])))
+	assert.NoError(t, manager.CheckBlock(21))
+
+	// the signals of the resumed halt are ignored
+	manager.refresh()
+	assert.NoError(t, manager.CheckBlock(21))
+
+	assert.ErrorIs(t, manager.Resume(&Manifest{ChainID: testChainID, HaltHeight: 20}), ErrNotHalted)
+}
@@ -0,0 +1,178 @@
+package halt
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrAlreadySigned    = errors.New("the manifest is already signed by the key")
+	ErrInvalidSignature = errors.New("invalid manifest signature")
+	ErrManifestMismatch = errors.New("the manifests differ")
+)
+
+// Manifest resumes a halted chain once signed by a supermajority of the validators at the halt height
+type Manifest struct {
+	ChainID    uint64 `json:"chainId"`
+	HaltHeight uint64 `json:"haltHeight"`
+
+	// ChainConfigHash is the hash of the patched chain config the nodes restart with,
+	// zero if the chain resumes with its config
+	ChainConfigHash types.Hash `json:"chainConfigHash"`
+
+	Signatures []*Signature `json:"signatures"`
+}
+
+// Signature is the signature of the manifest by a validator key
+type Signature struct {
+	Signer    types.Address `json:"signer"`
+	Signature string        `json:"signature"`
+}
+
+// ChainConfigHash returns the hash of the chain config committed to by the manifests
+func ChainConfigHash(config *chain.Chain) (types.Hash, error) {
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(crypto.Keccak256(raw)), nil
+}
+
+// Hash returns the hash of the manifest signed by the validators, its signatures excluded
+func (m *Manifest) Hash() (types.Hash, error) {
+	unsigned := *m
+	unsigned.Signatures = nil
+
+	raw, err := json.Marshal(&unsigned)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(crypto.Keccak256(raw)), nil
+}
+
+// Sign adds the signature of the key to the manifest
+func (m *Manifest) Sign(key *ecdsa.PrivateKey) error {
+	signer := crypto.PubKeyToAddress(&key.PublicKey)
+	if m.signedBy(signer) {
+		return ErrAlreadySigned
+	}
+
+	hash, err := m.Hash()
+	if err != nil {
+		return err
+	}
+
+	sig, err := crypto.Sign(key, hash.Bytes())
+	if err != nil {
+		return err
+	}
+
+	m.Signatures = append(m.Signatures, &Signature{
+		Signer:    signer,
+		Signature: hex.EncodeToHex(sig),
+	})
+
+	return nil
+}
+
+// CoSign adds the signatures of the other copy of the manifest
+func (m *Manifest) CoSign(other *Manifest) error {
+	hash, err := m.Hash()
+	if err != nil {
+		return err
+	}
+
+	otherHash, err := other.Hash()
+	if err != nil {
+		return err
+	}
+
+	if hash != otherHash {
+		return ErrManifestMismatch
+	}
+
+	for _, sig := range other.Signatures {
+		if !m.signedBy(sig.Signer) {
+			m.Signatures = append(m.Signatures, sig)
+		}
+	}
+
+	return nil
+}
+
+// Verify checks the signatures of the manifest, returning the signers
+func (m *Manifest) Verify() ([]types.Address, error) {
+	hash, err := m.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	signers := make([]types.Address, 0, len(m.Signatures))
+	seen := make(map[types.Address]bool, len(m.Signatures))
+
+	for _, sig := range m.Signatures {
+		raw, err := hex.DecodeHex(sig.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("%w of %s: %v", ErrInvalidSignature, sig.Signer, err)
+		}
+
+		pub, err := crypto.RecoverPubkey(raw, hash.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%w of %s: %v", ErrInvalidSignature, sig.Signer, err)
+		}
+
+		if crypto.PubKeyToAddress(pub) != sig.Signer || seen[sig.Signer] {
+			return nil, fmt.Errorf("%w of %s", ErrInvalidSignature, sig.Signer)
+		}
+
+		seen[sig.Signer] = true
+		signers = append(signers, sig.Signer)
+	}
+
+	return signers, nil
+}
+
+func (m *Manifest) signedBy(signer types.Address) bool {
+	for _, sig := range m.Signatures {
+		if sig.Signer == signer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReadManifest reads the manifest of the file
+func ReadManifest(path string) (*Manifest, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	if err := json.Unmarshal(raw, m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// WriteFile writes the manifest to the file
+func (m *Manifest) WriteFile(path string) error {
+	raw, err := json.MarshalIndent(m, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, raw, 0600)
+}
@@ -0,0 +1,137 @@
+// Package abi encodes and decodes the calls, outputs and events of the contracts
+// into typed Go values. It is the runtime of the bindings generated by Generate,
+// which are used for the system calls of the node and by the users automating
+// their interactions with the chain
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/ethgo"
+	ethgoabi "github.com/umbracle/ethgo/abi"
+)
+
+var (
+	ErrMethodNotFound = errors.New("method not found in ABI")
+	ErrEventNotFound  = errors.New("event not found in ABI")
+	ErrEventMismatch  = errors.New("log does not match the event")
+)
+
+// Caller executes a read-only call of a contract and returns its output
+type Caller interface {
+	Call(to types.Address, input []byte) ([]byte, error)
+}
+
+// Executor applies the transactions of the system calls, i.e. a state transition
+type Executor interface {
+	Apply(*types.Transaction) (*runtime.ExecutionResult, error)
+	GetNonce(types.Address) uint64
+}
+
+type executorCaller struct {
+	executor Executor
+	from     types.Address
+	gas      uint64
+}
+
+// NewExecutorCaller returns the caller applying the calls from the address in the executor,
+// with the gas limit and no gas price
+func NewExecutorCaller(executor Executor, from types.Address, gas uint64) Caller {
+	return &executorCaller{
+		executor: executor,
+		from:     from,
+		gas:      gas,
+	}
+}
+
+// Call implements the Caller interface
+func (c *executorCaller) Call(to types.Address, input []byte) ([]byte, error) {
+	res, err := c.executor.Apply(&types.Transaction{
+		From:     c.from,
+		To:       &to,
+		Input:    input,
+		Nonce:    c.executor.GetNonce(c.from),
+		Gas:      c.gas,
+		Value:    big.NewInt(0),
+		GasPrice: big.NewInt(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Failed() {
+		return nil, res.Err
+	}
+
+	return res.ReturnValue, nil
+}
+
+// Encode returns the call data of the method with the arguments
+func Encode(method *ethgoabi.Method, args ...interface{}) ([]byte, error) {
+	if method == nil {
+		return nil, ErrMethodNotFound
+	}
+
+	return method.Encode(args)
+}
+
+// Decode decodes the output of the method into out, the pointer to the value
+// of its single output or to the struct of its outputs
+func Decode(method *ethgoabi.Method, data []byte, out interface{}) error {
+	if method == nil {
+		return ErrMethodNotFound
+	}
+
+	results, err := method.Decode(data)
+	if err != nil {
+		return err
+	}
+
+	if len(method.Outputs.TupleElems()) == 1 {
+		return Convert(results[outputName(method.Outputs.TupleElems()[0], 0)], out)
+	}
+
+	return Convert(results, out)
+}
+
+// ParseLog decodes the log of the event into out, the pointer to the struct of its inputs
+func ParseLog(event *ethgoabi.Event, log *types.Log, out interface{}) error {
+	if event == nil {
+		return ErrEventNotFound
+	}
+
+	topics := make([]ethgo.Hash, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = ethgo.Hash(topic)
+	}
+
+	ethLog := &ethgo.Log{
+		Address: ethgo.Address(log.Address),
+		Topics:  topics,
+		Data:    log.Data,
+	}
+
+	if !event.Match(ethLog) {
+		return fmt.Errorf("%w %s", ErrEventMismatch, event.Name)
+	}
+
+	results, err := event.ParseLog(ethLog)
+	if err != nil {
+		return err
+	}
+
+	return Convert(results, out)
+}
+
+// outputName returns the key of the output in the decoded results
+func outputName(elem *ethgoabi.TupleElem, index int) string {
+	if elem.Name == "" {
+		return fmt.Sprintf("%d", index)
+	}
+
+	return elem.Name
+}
@@ -0,0 +1,110 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	ethgoabi "github.com/umbracle/ethgo/abi"
+)
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	type withdrawal struct {
+		Amount  *big.Int
+		Release uint64 `abi:"releaseEpoch"`
+	}
+
+	var (
+		addresses []types.Address
+		amount    uint32
+		w         withdrawal
+	)
+
+	require.NoError(t, Convert([]ethgo.Address{{0x1}, {0x2}}, &addresses))
+	assert.Equal(t, []types.Address{{0x1}, {0x2}}, addresses)
+
+	require.NoError(t, Convert(big.NewInt(10), &amount))
+	assert.Equal(t, uint32(10), amount)
+
+	require.NoError(t, Convert(map[string]interface{}{
+		"amount":       big.NewInt(5),
+		"releaseEpoch": big.NewInt(7),
+	}, &w))
+	assert.Equal(t, withdrawal{Amount: big.NewInt(5), Release: 7}, w)
+
+	assert.ErrorIs(t, Convert(new(big.Int).Lsh(big.NewInt(1), 32), &amount), ErrInvalidConversion)
+	assert.ErrorIs(t, Convert(big.NewInt(-1), &amount), ErrInvalidConversion)
+	assert.ErrorIs(t, Convert("string", &amount), ErrInvalidConversion)
+	assert.ErrorIs(t, Convert(map[string]interface{}{"amount": big.NewInt(5)}, &w), ErrInvalidConversion)
+	assert.ErrorIs(t, Convert(big.NewInt(1), amount), ErrInvalidConversion)
+}
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	method, err := ethgoabi.NewMethod("function pending(address) returns (uint256 amount, uint256 releaseEpoch)")
+	require.NoError(t, err)
+
+	input, err := Encode(method, types.Address{0x1})
+	require.NoError(t, err)
+	assert.Equal(t, method.ID(), input[:4])
+
+	output, err := method.Outputs.Encode([]interface{}{big.NewInt(3), big.NewInt(4)})
+	require.NoError(t, err)
+
+	var out struct {
+		Amount       *big.Int
+		ReleaseEpoch *big.Int
+	}
+
+	require.NoError(t, Decode(method, output, &out))
+	assert.Equal(t, big.NewInt(3), out.Amount)
+	assert.Equal(t, big.NewInt(4), out.ReleaseEpoch)
+
+	// the single output is decoded into the value itself
+	single, err := ethgoabi.NewMethod("function validators() returns (address[])")
+	require.NoError(t, err)
+
+	output, err = single.Outputs.Encode([]interface{}{[]ethgo.Address{{0x2}}})
+	require.NoError(t, err)
+
+	var validators []types.Address
+
+	require.NoError(t, Decode(single, output, &validators))
+	assert.Equal(t, []types.Address{{0x2}}, validators)
+
+	_, err = Encode(nil)
+	assert.ErrorIs(t, err, ErrMethodNotFound)
+}
+
+func TestParseLog(t *testing.T) {
+	t.Parallel()
+
+	event, err := ethgoabi.NewEvent("event Staked(address indexed account, uint256 amount)")
+	require.NoError(t, err)
+
+	data, err := ethgoabi.Encode([]interface{}{big.NewInt(9)}, ethgoabi.MustNewType("tuple(uint256)"))
+	require.NoError(t, err)
+
+	log := &types.Log{
+		Topics: []types.Hash{types.Hash(event.ID()), types.BytesToHash(types.Address{0x3}.Bytes())},
+		Data:   data,
+	}
+
+	var out struct {
+		Account types.Address
+		Amount  *big.Int
+	}
+
+	require.NoError(t, ParseLog(event, log, &out))
+	assert.Equal(t, types.Address{0x3}, out.Account)
+	assert.Equal(t, big.NewInt(9), out.Amount)
+
+	log.Topics[0] = types.Hash{0x1}
+	assert.ErrorIs(t, ParseLog(event, log, &out), ErrEventMismatch)
+}
@@ -0,0 +1,185 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+var (
+	ErrInvalidConversion = errors.New("invalid conversion")
+
+	bigIntT = reflect.TypeOf(new(big.Int))
+)
+
+// Convert converts the value decoded by the ABI into out, the pointer to its typed value.
+// The addresses and fixed bytes are converted to the byte arrays of the same size (i.e. types.Address),
+// the tuples to the structs with the fields named after their elements or tagged with abi:"name",
+// and the numbers to any integer type they fit in
+func Convert(value interface{}, out interface{}) error {
+	dst := reflect.ValueOf(out)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("%w: out must be a non-nil pointer", ErrInvalidConversion)
+	}
+
+	return convert(reflect.ValueOf(value), dst.Elem())
+}
+
+func convert(src, dst reflect.Value) error {
+	for src.Kind() == reflect.Interface && !src.IsNil() {
+		src = src.Elem()
+	}
+
+	if !src.IsValid() {
+		return fmt.Errorf("%w: no value for %s", ErrInvalidConversion, dst.Type())
+	}
+
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+
+		return nil
+	}
+
+	switch {
+	case dst.Type() == bigIntT:
+		n, err := toBigInt(src)
+		if err != nil {
+			return err
+		}
+
+		dst.Set(reflect.ValueOf(n))
+
+		return nil
+	case dst.Kind() == reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+
+		return convert(src, dst.Elem())
+	}
+
+	switch dst.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := toBigInt(src)
+		if err != nil {
+			return err
+		}
+
+		if n.Sign() < 0 || !n.IsUint64() || dst.OverflowUint(n.Uint64()) {
+			return fmt.Errorf("%w: %s overflows %s", ErrInvalidConversion, n, dst.Type())
+		}
+
+		dst.SetUint(n.Uint64())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toBigInt(src)
+		if err != nil {
+			return err
+		}
+
+		if !n.IsInt64() || dst.OverflowInt(n.Int64()) {
+			return fmt.Errorf("%w: %s overflows %s", ErrInvalidConversion, n, dst.Type())
+		}
+
+		dst.SetInt(n.Int64())
+	case reflect.Array:
+		return convertList(src, dst, dst.Len())
+	case reflect.Slice:
+		if src.Kind() != reflect.Array && src.Kind() != reflect.Slice {
+			return conversionErr(src, dst)
+		}
+
+		dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+
+		return convertList(src, dst, src.Len())
+	case reflect.Struct:
+		return convertStruct(src, dst)
+	default:
+		if !src.Type().ConvertibleTo(dst.Type()) || src.Kind() != dst.Kind() {
+			return conversionErr(src, dst)
+		}
+
+		dst.Set(src.Convert(dst.Type()))
+	}
+
+	return nil
+}
+
+// convertList converts the elements of the array or slice into the array or slice of the given length
+func convertList(src, dst reflect.Value, length int) error {
+	if (src.Kind() != reflect.Array && src.Kind() != reflect.Slice) || src.Len() != length {
+		return conversionErr(src, dst)
+	}
+
+	for i := 0; i < length; i++ {
+		if err := convert(src.Index(i), dst.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// convertStruct converts the decoded tuple into the struct
+func convertStruct(src, dst reflect.Value) error {
+	if src.Kind() != reflect.Map || src.Type().Key().Kind() != reflect.String {
+		return conversionErr(src, dst)
+	}
+
+	typ := dst.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Tag.Get("abi")
+		if name == "-" {
+			continue
+		}
+
+		value := src.MapIndex(reflect.ValueOf(name))
+
+		if name == "" {
+			// the elements are matched by their names, regardless of the case
+			for _, key := range src.MapKeys() {
+				if strings.EqualFold(key.String(), field.Name) {
+					value = src.MapIndex(key)
+
+					break
+				}
+			}
+		}
+
+		if !value.IsValid() {
+			return fmt.Errorf("%w: no value for the field %s", ErrInvalidConversion, field.Name)
+		}
+
+		if err := convert(value, dst.Field(i)); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func toBigInt(src reflect.Value) (*big.Int, error) {
+	switch src.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(src.Uint()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(src.Int()), nil
+	}
+
+	if n, ok := src.Interface().(*big.Int); ok && n != nil {
+		return n, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s is not a number", ErrInvalidConversion, src.Type())
+}
+
+func conversionErr(src, dst reflect.Value) error {
+	return fmt.Errorf("%w: %s to %s", ErrInvalidConversion, src.Type(), dst.Type())
+}
@@ -0,0 +1,512 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+
+	ethgoabi "github.com/umbracle/ethgo/abi"
+)
+
+var (
+	ErrInvalidContractName = errors.New("invalid contract name")
+	ErrInvalidPackageName  = errors.New("invalid package name")
+	ErrUnsupportedABI      = errors.New("unsupported ABI")
+)
+
+// GenerateConfig is the configuration of the bindings of a contract
+type GenerateConfig struct {
+	// Package is the name of the package of the generated file
+	Package string
+
+	// Contract is the name of the contract, which prefixes the generated types
+	Contract string
+
+	// ABI is the JSON ABI of the contract
+	ABI []byte
+}
+
+// abiEntry is an entry of the JSON ABI
+type abiEntry struct {
+	Type            string    `json:"type"`
+	Name            string    `json:"name"`
+	Inputs          []*abiArg `json:"inputs"`
+	Outputs         []*abiArg `json:"outputs"`
+	StateMutability string    `json:"stateMutability"`
+	Constant        bool      `json:"constant"`
+	Anonymous       bool      `json:"anonymous"`
+}
+
+// abiArg is an argument of the JSON ABI
+type abiArg struct {
+	Name         string    `json:"name"`
+	Type         string    `json:"type"`
+	InternalType string    `json:"internalType"`
+	Indexed      bool      `json:"indexed"`
+	Components   []*abiArg `json:"components"`
+}
+
+func (a *abiArg) argumentStr() *ethgoabi.ArgumentStr {
+	arg := &ethgoabi.ArgumentStr{
+		Name:    a.Name,
+		Type:    a.Type,
+		Indexed: a.Indexed,
+	}
+
+	for _, c := range a.Components {
+		arg.Components = append(arg.Components, c.argumentStr())
+	}
+
+	return arg
+}
+
+// generator writes the bindings of a contract
+type generator struct {
+	config *GenerateConfig
+
+	body    *bytes.Buffer
+	structs map[string]string
+}
+
+// Generate returns the source of the typed Go bindings of the contract, with the methods
+// encoding the calls and decoding the outputs of all its functions, calling its view functions
+// through an abi.Caller and parsing the logs of its events
+func Generate(config *GenerateConfig) ([]byte, error) {
+	if !token.IsIdentifier(config.Contract) || !token.IsExported(config.Contract) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidContractName, config.Contract)
+	}
+
+	if !token.IsIdentifier(config.Package) || config.Package == "abi" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPackageName, config.Package)
+	}
+
+	if bytes.ContainsRune(config.ABI, '`') {
+		return nil, fmt.Errorf("%w: the ABI contains a backtick", ErrUnsupportedABI)
+	}
+
+	// the ABI must be valid for the bindings to load it
+	if _, err := ethgoabi.NewABI(string(config.ABI)); err != nil {
+		return nil, err
+	}
+
+	var entries []*abiEntry
+	if err := json.Unmarshal(config.ABI, &entries); err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		config:  config,
+		body:    new(bytes.Buffer),
+		structs: map[string]string{},
+	}
+
+	if err := g.generate(entries); err != nil {
+		return nil, err
+	}
+
+	return g.source()
+}
+
+func (g *generator) generate(entries []*abiEntry) error {
+	var (
+		methods, events       []string
+		methodKeys, eventKeys = map[string]bool{}, map[string]bool{}
+		entryByKey            = map[string]*abiEntry{}
+	)
+
+	// the overloaded functions and events are keyed as in the ethgo ABI
+	for _, entry := range entries {
+		switch entry.Type {
+		case "function", "":
+			key := overloadedKey(entry.Name, methodKeys)
+			methods = append(methods, key)
+			entryByKey["function "+key] = entry
+		case "event":
+			key := overloadedKey(entry.Name, eventKeys)
+			events = append(events, key)
+			entryByKey["event "+key] = entry
+		}
+	}
+
+	methodNames := goNames(methods)
+	for _, key := range methods {
+		if err := g.method(key, methodNames[key], entryByKey["function "+key]); err != nil {
+			return fmt.Errorf("method %s: %w", key, err)
+		}
+	}
+
+	eventNames := goNames(events)
+	for _, key := range events {
+		if err := g.event(key, eventNames[key], entryByKey["event "+key]); err != nil {
+			return fmt.Errorf("event %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) method(key, name string, entry *abiEntry) error {
+	var (
+		contract = g.config.Contract
+		lookup   = fmt.Sprintf("%sABI.Methods[%q]", contract, key)
+		isView   = entry.Constant || entry.StateMutability == "view" || entry.StateMutability == "pure"
+	)
+
+	params, args, err := g.params(entry.Inputs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(g.body, "// Encode%s returns the call data of the %s method\n", name, entry.Name)
+	fmt.Fprintf(g.body, "func (c *%s) Encode%s(%s) ([]byte, error) {\n", contract, name, params)
+	fmt.Fprintf(g.body, "\treturn abi.Encode(%s%s)\n}\n\n", lookup, prefixComma(args))
+
+	var ret string
+
+	switch len(entry.Outputs) {
+	case 0:
+	case 1:
+		if ret, err = g.goType(entry.Outputs[0], name+"Output"); err != nil {
+			return err
+		}
+	default:
+		structName := contract + name + "Output"
+		if err := g.defineStruct(structName, "holds the outputs of the "+entry.Name+" method", entry.Outputs); err != nil {
+			return err
+		}
+
+		ret = "*" + structName
+	}
+
+	if ret != "" {
+		fmt.Fprintf(g.body, "// Decode%s decodes the output of the %s method\n", name, entry.Name)
+		fmt.Fprintf(g.body, "func (c *%s) Decode%s(data []byte) (%s, error) {\n", contract, name, ret)
+		fmt.Fprintf(g.body, "\tvar out %s\n\n\terr := abi.Decode(%s, data, &out)\n\n\treturn out, err\n}\n\n", ret, lookup)
+	}
+
+	if !isView {
+		return nil
+	}
+
+	fmt.Fprintf(g.body, "// %s calls the %s method\n", name, entry.Name)
+
+	if ret == "" {
+		fmt.Fprintf(g.body, "func (c *%s) %s(%s) error {\n", contract, name, params)
+		fmt.Fprintf(g.body, "\tinput, err := c.Encode%s(%s)\n\tif err != nil {\n\t\treturn err\n\t}\n\n", name, args)
+		fmt.Fprintf(g.body, "\t_, err = c.caller.Call(c.Address, input)\n\n\treturn err\n}\n\n")
+
+		return nil
+	}
+
+	fmt.Fprintf(g.body, "func (c *%s) %s(%s) (%s, error) {\n", contract, name, params, ret)
+	fmt.Fprintf(g.body, "\tvar out %s\n\n", ret)
+	fmt.Fprintf(g.body, "\tinput, err := c.Encode%s(%s)\n\tif err != nil {\n\t\treturn out, err\n\t}\n\n", name, args)
+	fmt.Fprintf(g.body, "\toutput, err := c.caller.Call(c.Address, input)\n")
+	fmt.Fprintf(g.body, "\tif err != nil {\n\t\treturn out, err\n\t}\n\n")
+	fmt.Fprintf(g.body, "\treturn c.Decode%s(output)\n}\n\n", name)
+
+	return nil
+}
+
+func (g *generator) event(key, name string, entry *abiEntry) error {
+	var (
+		contract   = g.config.Contract
+		structName = contract + name + "Event"
+	)
+
+	doc := "is the " + entry.Name + " event of the " + contract + " contract"
+	if err := g.defineStruct(structName, doc, entry.Inputs); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(g.body, "// Parse%sEvent decodes the log of the %s event\n", name, entry.Name)
+	fmt.Fprintf(g.body, "func (c *%s) Parse%sEvent(log *types.Log) (*%s, error) {\n", contract, name, structName)
+	fmt.Fprintf(g.body, "\tevent := &%s{}\n", structName)
+	fmt.Fprintf(g.body, "\tif err := abi.ParseLog(%sABI.Events[%q], log, event); err != nil {\n", contract, key)
+	fmt.Fprintf(g.body, "\t\treturn nil, err\n\t}\n\n\treturn event, nil\n}\n\n")
+
+	return nil
+}
+
+// params returns the parameters of the method and the arguments passing them
+func (g *generator) params(inputs []*abiArg) (string, string, error) {
+	var (
+		params = make([]string, len(inputs))
+		args   = make([]string, len(inputs))
+	)
+
+	for i, input := range inputs {
+		typ, err := g.goType(input, exportedName(input.Name))
+		if err != nil {
+			return "", "", err
+		}
+
+		args[i] = paramName(input.Name, i)
+		params[i] = args[i] + " " + typ
+	}
+
+	return strings.Join(params, ", "), strings.Join(args, ", "), nil
+}
+
+// defineStruct defines the struct with a field for each argument, tagged with its key in the decoded values
+func (g *generator) defineStruct(name, doc string, args []*abiArg) error {
+	if _, ok := g.structs[name]; ok {
+		return nil
+	}
+
+	// reserve the name, as the fields may refer to the struct
+	g.structs[name] = ""
+
+	def := new(bytes.Buffer)
+
+	fmt.Fprintf(def, "// %s %s\n", name, doc)
+	fmt.Fprintf(def, "type %s struct {\n", name)
+
+	for i, arg := range args {
+		typ, err := g.goType(arg, name+exportedName(arg.Name))
+		if err != nil {
+			return err
+		}
+
+		key := arg.Name
+		if key == "" {
+			key = fmt.Sprintf("%d", i)
+		}
+
+		fmt.Fprintf(def, "\t%s %s `abi:%q`\n", fieldName(arg.Name, i), typ, key)
+	}
+
+	fmt.Fprintf(def, "}\n\n")
+
+	g.structs[name] = def.String()
+
+	return nil
+}
+
+// goType returns the Go type of the argument, defining the structs of its tuples
+func (g *generator) goType(arg *abiArg, tupleName string) (string, error) {
+	t, err := ethgoabi.NewTypeFromArgument(arg.argumentStr())
+	if err != nil {
+		return "", err
+	}
+
+	return g.typeOf(t, arg, tupleName)
+}
+
+func (g *generator) typeOf(t *ethgoabi.Type, arg *abiArg, tupleName string) (string, error) {
+	switch t.Kind() {
+	case ethgoabi.KindBool:
+		return "bool", nil
+	case ethgoabi.KindString:
+		return "string", nil
+	case ethgoabi.KindAddress:
+		return "types.Address", nil
+	case ethgoabi.KindBytes:
+		return "[]byte", nil
+	case ethgoabi.KindFixedBytes:
+		if t.Size() == 32 {
+			return "types.Hash", nil
+		}
+
+		return fmt.Sprintf("[%d]byte", t.Size()), nil
+	case ethgoabi.KindUInt, ethgoabi.KindInt:
+		return t.GoType().String(), nil
+	case ethgoabi.KindFunction:
+		return "[24]byte", nil
+	case ethgoabi.KindSlice:
+		elem, err := g.typeOf(t.Elem(), arg, tupleName)
+		if err != nil {
+			return "", err
+		}
+
+		return "[]" + elem, nil
+	case ethgoabi.KindArray:
+		elem, err := g.typeOf(t.Elem(), arg, tupleName)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("[%d]%s", t.Size(), elem), nil
+	case ethgoabi.KindTuple:
+		name := g.config.Contract + tupleName
+		doc := "is a tuple of the " + g.config.Contract + " contract"
+
+		// the structs are named after their Solidity name, i.e. struct Staking.Withdrawal
+		if structName := strings.TrimPrefix(arg.InternalType, "struct "); structName != arg.InternalType {
+			structName = strings.SplitN(structName, "[", 2)[0]
+			structName = structName[strings.LastIndex(structName, ".")+1:]
+			name = g.config.Contract + exportedName(structName)
+			doc = "is the " + structName + " struct of the " + g.config.Contract + " contract"
+		}
+
+		if err := g.defineStruct(name, doc, arg.Components); err != nil {
+			return "", err
+		}
+
+		return "*" + name, nil
+	}
+
+	return "", fmt.Errorf("%w: type %s", ErrUnsupportedABI, arg.Type)
+}
+
+// source returns the formatted source of the bindings
+func (g *generator) source() ([]byte, error) {
+	var (
+		contract = g.config.Contract
+		out      = new(bytes.Buffer)
+		abiConst = unexportedName(contract) + "JSONABI"
+	)
+
+	fmt.Fprintf(out, "// Code generated by polygon-edge abigen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(out, "package %s\n\n", g.config.Package)
+
+	structs := make([]string, 0, len(g.structs))
+	for name := range g.structs {
+		structs = append(structs, name)
+	}
+
+	sort.Strings(structs)
+
+	defs := new(bytes.Buffer)
+	for _, name := range structs {
+		defs.WriteString(g.structs[name])
+	}
+
+	imports := []string{`"github.com/0xPolygon/polygon-edge/types"`}
+	if bytes.Contains(defs.Bytes(), []byte("big.Int")) || bytes.Contains(g.body.Bytes(), []byte("big.Int")) {
+		imports = append([]string{`"math/big"`, ""}, imports...)
+	}
+
+	imports = append(
+		imports,
+		`"github.com/0xPolygon/polygon-edge/helper/abi"`,
+		`ethgoabi "github.com/umbracle/ethgo/abi"`,
+	)
+
+	fmt.Fprintf(out, "import (\n\t%s\n)\n\n", strings.Join(imports, "\n\t"))
+
+	fmt.Fprintf(out, "// %sABI is the ABI of the %s contract\n", contract, contract)
+	fmt.Fprintf(out, "var %sABI = ethgoabi.MustNewABI(%s)\n\n", contract, abiConst)
+	fmt.Fprintf(out, "const %s = `%s`\n\n", abiConst, bytes.TrimSpace(g.config.ABI))
+
+	fmt.Fprintf(out, "// %s is the binding of the %s contract\n", contract, contract)
+	fmt.Fprintf(out, "type %s struct {\n\tAddress types.Address\n\tcaller  abi.Caller\n}\n\n", contract)
+	fmt.Fprintf(out, "// New%s returns the binding of the %s contract at the address, calling it with the caller\n",
+		contract, contract)
+	fmt.Fprintf(out, "func New%s(address types.Address, caller abi.Caller) *%s {\n", contract, contract)
+	fmt.Fprintf(out, "\treturn &%s{\n\t\tAddress: address,\n\t\tcaller:  caller,\n\t}\n}\n\n", contract)
+
+	out.Write(defs.Bytes())
+	out.Write(g.body.Bytes())
+
+	return format.Source(out.Bytes())
+}
+
+// overloadedKey returns the key of the overloaded function or event in the ethgo ABI
+func overloadedKey(name string, used map[string]bool) string {
+	key := name
+	for i := 0; used[key]; i++ {
+		key = fmt.Sprintf("%s%d", name, i)
+	}
+
+	used[key] = true
+
+	return key
+}
+
+// goNames returns the exported Go names of the keys. The names of the keys prefixed
+// with an underscore, usually the public state variables, are numbered when they are taken
+func goNames(keys []string) map[string]string {
+	sorted := append([]string{}, keys...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return !strings.HasPrefix(sorted[i], "_") && strings.HasPrefix(sorted[j], "_")
+	})
+
+	var (
+		names = make(map[string]string, len(keys))
+		used  = map[string]bool{}
+	)
+
+	for _, key := range sorted {
+		name := exportedName(key)
+		for i := 0; used[name]; i++ {
+			name = fmt.Sprintf("%s%d", exportedName(key), i)
+		}
+
+		used[name] = true
+		names[key] = name
+	}
+
+	return names
+}
+
+// exportedName returns the exported Go name of the Solidity name, i.e. _stakedAmount to StakedAmount
+// and VALIDATOR_THRESHOLD to ValidatorThreshold
+func exportedName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, part := range parts {
+		// the constants are in upper snake case, i.e. VALIDATOR_THRESHOLD
+		if len(parts) > 1 && part == strings.ToUpper(part) {
+			part = strings.ToLower(part)
+		}
+
+		parts[i] = strings.ToUpper(part[:1]) + part[1:]
+	}
+
+	return strings.Join(parts, "")
+}
+
+// unexportedName returns the unexported Go name of the Solidity name
+func unexportedName(name string) string {
+	exported := exportedName(name)
+	if exported == "" {
+		return ""
+	}
+
+	return strings.ToLower(exported[:1]) + exported[1:]
+}
+
+// reservedNames are the names of the receiver, the local variables and the packages of the generated methods
+var reservedNames = map[string]bool{
+	"c": true, "input": true, "output": true, "out": true, "err": true, "data": true, "log": true, "event": true,
+	"abi": true, "ethgoabi": true, "types": true, "big": true,
+}
+
+// paramName returns the name of the parameter, the unnamed ones, the keywords
+// and the reserved names are numbered
+func paramName(name string, index int) string {
+	param := unexportedName(name)
+	if param == "" || token.IsKeyword(param) || reservedNames[param] {
+		return fmt.Sprintf("arg%d", index)
+	}
+
+	return param
+}
+
+// fieldName returns the name of the field, the unnamed ones are numbered
+func fieldName(name string, index int) string {
+	field := exportedName(name)
+	if field == "" || !unicode.IsLetter(rune(field[0])) {
+		return fmt.Sprintf("Arg%d", index)
+	}
+
+	return field
+}
+
+// prefixComma returns the list prefixed with a comma, if it is not empty
+func prefixComma(list string) string {
+	if list == "" {
+		return ""
+	}
+
+	return ", " + list
+}
@@ -0,0 +1,94 @@
+package abi
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testABI = `[
+	{"type": "function", "name": "_validators", "stateMutability": "view",
+		"inputs": [{"name": "", "type": "uint256"}], "outputs": [{"name": "", "type": "address"}]},
+	{"type": "function", "name": "validators", "stateMutability": "view",
+		"inputs": [], "outputs": [{"name": "", "type": "address[]"}]},
+	{"type": "function", "name": "VALIDATOR_THRESHOLD", "stateMutability": "view",
+		"inputs": [], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "stake", "stateMutability": "payable", "inputs": [], "outputs": []},
+	{"type": "function", "name": "pendingWithdrawal", "stateMutability": "view",
+		"inputs": [{"name": "addr", "type": "address"}],
+		"outputs": [{"name": "amount", "type": "uint256"}, {"name": "releaseEpoch", "type": "uint256"}]},
+	{"type": "event", "name": "Staked", "anonymous": false,
+		"inputs": [{"name": "account", "type": "address", "indexed": true},
+			{"name": "amount", "type": "uint256", "indexed": false}]}
+]`
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	src, err := Generate(&GenerateConfig{
+		Package:  "staking",
+		Contract: "Staking",
+		ABI:      []byte(testABI),
+	})
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "binding.go", src, 0)
+	require.NoError(t, err)
+
+	for _, decl := range []string{
+		"func NewStaking(address types.Address, caller abi.Caller) *Staking",
+		"func (c *Staking) Validators() ([]types.Address, error)",
+		"func (c *Staking) Validators0(arg0 *big.Int) (types.Address, error)",
+		"func (c *Staking) ValidatorThreshold() (*big.Int, error)",
+		"func (c *Staking) EncodeStake() ([]byte, error)",
+		"func (c *Staking) PendingWithdrawal(addr types.Address) (*StakingPendingWithdrawalOutput, error)",
+		"type StakingStakedEvent struct",
+		"func (c *Staking) ParseStakedEvent(log *types.Log) (*StakingStakedEvent, error)",
+	} {
+		assert.Contains(t, string(src), decl)
+	}
+
+	// the non-view functions are not called
+	assert.NotContains(t, string(src), "func (c *Staking) Stake(")
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		config *GenerateConfig
+		err    error
+	}{
+		{
+			"unexported contract",
+			&GenerateConfig{Package: "staking", Contract: "staking", ABI: []byte("[]")},
+			ErrInvalidContractName,
+		},
+		{
+			"invalid package",
+			&GenerateConfig{Package: "my-pkg", Contract: "Staking", ABI: []byte("[]")},
+			ErrInvalidPackageName,
+		},
+		{"abi package", &GenerateConfig{Package: "abi", Contract: "Staking", ABI: []byte("[]")}, ErrInvalidPackageName},
+		{"backtick", &GenerateConfig{Package: "staking", Contract: "Staking", ABI: []byte("[`]")}, ErrUnsupportedABI},
+	}
+
+	for _, c := range cases {
+		_, err := Generate(c.config)
+		assert.ErrorIs(t, err, c.err, c.name)
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "StakedAmount", exportedName("_stakedAmount"))
+	assert.Equal(t, "ValidatorThreshold", exportedName("VALIDATOR_THRESHOLD"))
+	assert.Equal(t, "BLS", exportedName("BLS"))
+	assert.Equal(t, "arg1", paramName("type", 1))
+	assert.Equal(t, "addr", paramName("addr", 0))
+}
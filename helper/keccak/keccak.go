@@ -53,6 +53,22 @@ func (k *Keccak) Sum(dst []byte) []byte {
 	return dst
 }
 
+// SumTo writes the hash to the array, the keccak has to be reset before writing to it again
+func (k *Keccak) SumTo(dst *[32]byte) {
+	k.hash.Read(k.tmp)
+	copy(dst[:], k.tmp)
+}
+
+// Size implements the hash interface
+func (k *Keccak) Size() int {
+	return k.hash.Size()
+}
+
+// BlockSize implements the hash interface
+func (k *Keccak) BlockSize() int {
+	return k.hash.BlockSize()
+}
+
 func newKeccak(hash hashImpl) *Keccak {
 	return &Keccak{
 		hash: hash,
@@ -1 +1,130 @@
 package keccak
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/sha3"
+)
+
+func legacyKeccak256(src ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range src {
+		h.Write(b)
+	}
+
+	return h.Sum(nil)
+}
+
+func TestKeccak256(t *testing.T) {
+	t.Parallel()
+
+	a, b := []byte("hello"), []byte("world")
+
+	expected := legacyKeccak256(a, b)
+
+	assert.Equal(t, expected, Keccak256(nil, a, b))
+	assert.Equal(t, expected, Keccak256(nil, append(append([]byte{}, a...), b...)))
+
+	sum := Sum256(a, b)
+	assert.Equal(t, expected, sum[:])
+
+	// the hash is appended to dst
+	assert.Equal(t, append([]byte{0x1}, expected...), Keccak256([]byte{0x1}, a, b))
+
+	// the empty input
+	assert.Equal(t, legacyKeccak256(), Keccak256(nil))
+}
+
+func TestKeccak256_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			input := bytes.Repeat([]byte{byte(i)}, 100)
+			expected := legacyKeccak256(input)
+
+			for j := 0; j < 100; j++ {
+				sum := Sum256(input)
+
+				assert.Equal(t, expected, sum[:])
+				assert.Equal(t, expected, Keccak256(nil, input[:50], input[50:]))
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func TestPool(t *testing.T) {
+	t.Parallel()
+
+	var pool Pool
+
+	k := pool.Get()
+	k.Write([]byte("hello"))
+	assert.Equal(t, legacyKeccak256([]byte("hello")), k.Sum(nil))
+
+	// the keccaks are reset when they are released
+	pool.Put(k)
+
+	k = pool.Get()
+	k.Write([]byte("world"))
+	assert.Equal(t, legacyKeccak256([]byte("world")), k.Sum(nil))
+}
+
+var benchmarkInput = bytes.Repeat([]byte{0x1}, 64)
+
+func BenchmarkNewLegacyKeccak256(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		legacyKeccak256(benchmarkInput)
+	}
+}
+
+func BenchmarkKeccak256(b *testing.B) {
+	b.ReportAllocs()
+
+	dst := make([]byte, 0, 32)
+
+	for i := 0; i < b.N; i++ {
+		Keccak256(dst[:0], benchmarkInput)
+	}
+}
+
+func BenchmarkSum256(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		Sum256(benchmarkInput)
+	}
+}
+
+func BenchmarkNewLegacyKeccak256_Parallel(b *testing.B) {
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			legacyKeccak256(benchmarkInput)
+		}
+	})
+}
+
+func BenchmarkSum256_Parallel(b *testing.B) {
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			Sum256(benchmarkInput)
+		}
+	})
+}
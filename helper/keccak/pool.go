@@ -9,24 +9,19 @@ import (
 // DefaultKeccakPool is a default pool
 var DefaultKeccakPool Pool
 
-// Pool is a pool of keccaks
+// Pool is a pool of keccaks, the zero value is ready to use and safe for concurrent use.
+// The keccaks are reset when they are put back, so each goroutine streams into its own hasher
 type Pool struct {
 	pool sync.Pool
 }
 
-// Get returns the keccak
+// Get returns a reset keccak
 func (p *Pool) Get() *Keccak {
-	v := p.pool.Get()
-	if v == nil {
-		return NewKeccak256()
+	if k, ok := p.pool.Get().(*Keccak); ok {
+		return k
 	}
 
-	keccakVal, ok := v.(*Keccak)
-	if !ok {
-		return nil
-	}
-
-	return keccakVal
+	return NewKeccak256()
 }
 
 // Put releases the keccak
@@ -35,16 +30,34 @@ func (p *Pool) Put(k *Keccak) {
 	p.pool.Put(k)
 }
 
-// Keccak256 hashes a src with keccak-256
-func Keccak256(dst, src []byte) []byte {
+// Keccak256 hashes the concatenation of the sources with keccak-256 and appends the hash to dst
+func Keccak256(dst []byte, src ...[]byte) []byte {
 	h := DefaultKeccakPool.Get()
-	h.Write(src)
+
+	for _, b := range src {
+		h.Write(b)
+	}
+
 	dst = h.Sum(dst)
 	DefaultKeccakPool.Put(h)
 
 	return dst
 }
 
+// Sum256 hashes the concatenation of the sources with keccak-256 without allocating
+func Sum256(src ...[]byte) (res [32]byte) {
+	h := DefaultKeccakPool.Get()
+
+	for _, b := range src {
+		h.Write(b)
+	}
+
+	h.SumTo(&res)
+	DefaultKeccakPool.Put(h)
+
+	return res
+}
+
 // Keccak256Rlp hashes a fastrlp.Value with keccak-256
 func Keccak256Rlp(dst []byte, src *fastrlp.Value) []byte {
 	h := DefaultKeccakPool.Get()
@@ -0,0 +1,136 @@
+// Package merkle builds and verifies the merkle proofs of the state, storage,
+// transactions and receipts tries, so that external programs (bridges, auditors)
+// can check the data served by a node against the roots of its headers
+package merkle
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	// ErrNotFound is returned when the proof shows the entry is not in the trie
+	ErrNotFound = errors.New("entry not found in the trie")
+
+	// ErrIndexOutOfRange is returned when a proof is built for an index out of the list
+	ErrIndexOutOfRange = errors.New("index out of range")
+)
+
+// ProveAccount returns the proof of the account in the state trie with the given root
+func ProveAccount(prover state.Prover, stateRoot types.Hash, addr types.Address) ([][]byte, error) {
+	return prover.GetProof(stateRoot, crypto.Keccak256(addr.Bytes()))
+}
+
+// VerifyAccount verifies the proof of the account against the state root and returns
+// the account, or nil if the proof shows the account does not exist
+func VerifyAccount(stateRoot types.Hash, addr types.Address, proof [][]byte) (*state.Account, error) {
+	value, err := itrie.VerifyProof(stateRoot, crypto.Keccak256(addr.Bytes()), proof)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	account := &state.Account{}
+	if err := account.UnmarshalRlp(value); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// ProveStorage returns the proof of the slot in the storage trie with the given root
+func ProveStorage(prover state.Prover, storageRoot types.Hash, slot types.Hash) ([][]byte, error) {
+	return prover.GetProof(storageRoot, crypto.Keccak256(slot.Bytes()))
+}
+
+// VerifyStorage verifies the proof of the slot against the storage root of the account
+// and returns its value, the zero hash if the slot is not set
+func VerifyStorage(storageRoot types.Hash, slot types.Hash, proof [][]byte) (types.Hash, error) {
+	value, err := itrie.VerifyProof(storageRoot, crypto.Keccak256(slot.Bytes()), proof)
+	if err != nil || value == nil {
+		return types.ZeroHash, err
+	}
+
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(value)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	data, err := v.Bytes()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(data), nil
+}
+
+// ProveTransaction returns the proof of the transaction at the index in the transactions trie of the block
+func ProveTransaction(transactions []*types.Transaction, index int) ([][]byte, error) {
+	if index < 0 || index >= len(transactions) {
+		return nil, ErrIndexOutOfRange
+	}
+
+	return buildroot.CalculateTransactionProof(transactions, index)
+}
+
+// VerifyTransaction verifies the proof of the transaction at the index against the transactions root
+func VerifyTransaction(txRoot types.Hash, index uint64, proof [][]byte) (*types.Transaction, error) {
+	value, err := verifyIndex(txRoot, index, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalRLP(value); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// ProveReceipt returns the proof of the receipt at the index in the receipts trie of the block
+func ProveReceipt(receipts []*types.Receipt, index int) ([][]byte, error) {
+	if index < 0 || index >= len(receipts) {
+		return nil, ErrIndexOutOfRange
+	}
+
+	return buildroot.CalculateReceiptProof(receipts, index)
+}
+
+// VerifyReceipt verifies the proof of the receipt at the index against the receipts root
+func VerifyReceipt(receiptsRoot types.Hash, index uint64, proof [][]byte) (*types.Receipt, error) {
+	value, err := verifyIndex(receiptsRoot, index, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &types.Receipt{}
+	if err := receipt.UnmarshalRLP(value); err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// verifyIndex verifies the proof of an entry of a list trie, keyed by the RLP encoded index
+func verifyIndex(root types.Hash, index uint64, proof [][]byte) ([]byte, error) {
+	key := (&fastrlp.Arena{}).NewUint(index).MarshalTo(nil)
+
+	value, err := itrie.VerifyProof(root, key, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		return nil, ErrNotFound
+	}
+
+	return value, nil
+}
@@ -0,0 +1,241 @@
+package merkle
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildState commits the accounts with a storage slot each and returns the state and its root
+func buildState(t *testing.T, addrs []types.Address, balances []uint64) (*itrie.State, types.Hash) {
+	t.Helper()
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+
+	objs := make([]*state.Object, len(addrs))
+	for i, addr := range addrs {
+		objs[i] = &state.Object{
+			Address:  addr,
+			Balance:  new(big.Int).SetUint64(balances[i]),
+			Nonce:    uint64(i),
+			CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+			Root:     types.EmptyRootHash,
+			Storage: []*state.StorageObject{
+				{
+					Key: types.BytesToHash(addr.Bytes()).Bytes(),
+					Val: types.BytesToHash(new(big.Int).SetUint64(balances[i] + 1).Bytes()).Bytes(),
+				},
+			},
+		}
+	}
+
+	_, root := st.NewSnapshot().Commit(objs)
+
+	return st, types.BytesToHash(root)
+}
+
+func buildTransactions(seed []byte, num int) []*types.Transaction {
+	txs := make([]*types.Transaction, num)
+
+	for i := range txs {
+		to := types.BytesToAddress(append(seed, byte(i)))
+
+		txs[i] = &types.Transaction{
+			Nonce:    uint64(i),
+			GasPrice: big.NewInt(int64(len(seed))),
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(int64(i)),
+			Input:    seed,
+			V:        big.NewInt(27),
+			R:        big.NewInt(1),
+			S:        big.NewInt(2),
+		}
+	}
+
+	return txs
+}
+
+func buildReceipts(seed []byte, num int) []*types.Receipt {
+	receipts := make([]*types.Receipt, num)
+
+	for i := range receipts {
+		status := types.ReceiptSuccess
+
+		receipts[i] = &types.Receipt{
+			Status:            &status,
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			Logs: []*types.Log{
+				{
+					Address: types.BytesToAddress(seed),
+					Topics:  []types.Hash{types.BytesToHash(append(seed, byte(i)))},
+					Data:    seed,
+				},
+			},
+		}
+	}
+
+	return receipts
+}
+
+func TestAccountAndStorageProof(t *testing.T) {
+	t.Parallel()
+
+	addrs := []types.Address{types.StringToAddress("1"), types.StringToAddress("2"), types.StringToAddress("3")}
+	st, root := buildState(t, addrs, []uint64{10, 20, 30})
+
+	proof, err := ProveAccount(st, root, addrs[1])
+	require.NoError(t, err)
+
+	account, err := VerifyAccount(root, addrs[1], proof)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(20), account.Balance)
+	assert.Equal(t, uint64(1), account.Nonce)
+
+	slot := types.BytesToHash(addrs[1].Bytes())
+
+	storageProof, err := ProveStorage(st, account.Root, slot)
+	require.NoError(t, err)
+
+	value, err := VerifyStorage(account.Root, slot, storageProof)
+	require.NoError(t, err)
+	assert.Equal(t, types.BytesToHash([]byte{21}), value)
+
+	// the absence of an account is proven too
+	missing := types.StringToAddress("4")
+
+	proof, err = ProveAccount(st, root, missing)
+	require.NoError(t, err)
+
+	account, err = VerifyAccount(root, missing, proof)
+	require.NoError(t, err)
+	assert.Nil(t, account)
+
+	// a proof does not verify against another root
+	_, otherRoot := buildState(t, addrs, []uint64{11, 20, 30})
+
+	_, err = VerifyAccount(otherRoot, addrs[1], proof)
+	assert.ErrorIs(t, err, itrie.ErrProofNodeNotFound)
+}
+
+func TestTransactionAndReceiptProof(t *testing.T) {
+	t.Parallel()
+
+	txs := buildTransactions([]byte{0x1}, 5)
+	txRoot := buildroot.CalculateTransactionsRoot(txs)
+
+	proof, err := ProveTransaction(txs, 3)
+	require.NoError(t, err)
+
+	tx, err := VerifyTransaction(txRoot, 3, proof)
+	require.NoError(t, err)
+	assert.Equal(t, txs[3].MarshalRLP(), tx.MarshalRLP())
+
+	// the proof of an index proves the absence of another one
+	_, err = VerifyTransaction(txRoot, 7, proof)
+	assert.Error(t, err)
+
+	_, err = ProveTransaction(txs, 5)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+
+	receipts := buildReceipts([]byte{0x1}, 5)
+	receiptsRoot := buildroot.CalculateReceiptsRoot(receipts)
+
+	proof, err = ProveReceipt(receipts, 0)
+	require.NoError(t, err)
+
+	receipt, err := VerifyReceipt(receiptsRoot, 0, proof)
+	require.NoError(t, err)
+	assert.Equal(t, receipts[0].MarshalRLP(), receipt.MarshalRLP())
+
+	_, err = ProveReceipt(receipts, -1)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+}
+
+func FuzzAccountProof(f *testing.F) {
+	f.Add([]byte{0x1, 0x2, 0x3}, uint64(10), uint8(1))
+	f.Add([]byte("accounts"), uint64(0), uint8(200))
+
+	f.Fuzz(func(t *testing.T, seed []byte, balance uint64, num uint8) {
+		addrs := make([]types.Address, int(num)+1)
+		balances := make([]uint64, len(addrs))
+
+		for i := range addrs {
+			addrs[i] = types.BytesToAddress(crypto.Keccak256(seed, []byte{byte(i)}))
+			balances[i] = balance + uint64(i)
+		}
+
+		st, root := buildState(t, addrs, balances)
+
+		for i, addr := range addrs {
+			proof, err := ProveAccount(st, root, addr)
+			require.NoError(t, err)
+
+			account, err := VerifyAccount(root, addr, proof)
+			require.NoError(t, err)
+			require.NotNil(t, account)
+			require.Equal(t, balances[i], account.Balance.Uint64())
+
+			slot := types.BytesToHash(addr.Bytes())
+
+			storageProof, err := ProveStorage(st, account.Root, slot)
+			require.NoError(t, err)
+
+			value, err := VerifyStorage(account.Root, slot, storageProof)
+			require.NoError(t, err)
+			require.Equal(t, types.BytesToHash(new(big.Int).SetUint64(balances[i]+1).Bytes()), value)
+		}
+	})
+}
+
+func FuzzListProof(f *testing.F) {
+	f.Add([]byte{0x1}, uint8(1), uint8(0))
+	f.Add([]byte("transactions"), uint8(129), uint8(128))
+	f.Add([]byte{}, uint8(20), uint8(17))
+
+	f.Fuzz(func(t *testing.T, seed []byte, num uint8, index uint8) {
+		if num == 0 {
+			return
+		}
+
+		index %= num
+
+		// the roots are the ones the node computes for its headers
+		txs := buildTransactions(seed, int(num))
+		txRoot := buildroot.CalculateTransactionsRoot(txs)
+
+		proof, err := ProveTransaction(txs, int(index))
+		require.NoError(t, err)
+
+		tx, err := VerifyTransaction(txRoot, uint64(index), proof)
+		require.NoError(t, err)
+		require.Equal(t, txs[index].MarshalRLP(), tx.MarshalRLP())
+
+		receipts := buildReceipts(seed, int(num))
+		receiptsRoot := buildroot.CalculateReceiptsRoot(receipts)
+
+		proof, err = ProveReceipt(receipts, int(index))
+		require.NoError(t, err)
+
+		receipt, err := VerifyReceipt(receiptsRoot, uint64(index), proof)
+		require.NoError(t, err)
+		require.Equal(t, receipts[index].MarshalRLP(), receipt.MarshalRLP())
+
+		// a tampered proof never verifies a different entry
+		if len(proof) > 0 {
+			last := proof[len(proof)-1]
+			tampered := append(append([][]byte{}, proof[:len(proof)-1]...), append([]byte{}, last...))
+			tampered[len(tampered)-1][len(last)/2] ^= 0xff
+
+			_, err = VerifyReceipt(receiptsRoot, uint64(index), tampered)
+			require.Error(t, err)
+		}
+	})
+}
@@ -0,0 +1,11 @@
+//go:build windows
+// +build windows
+
+package resources
+
+import "time"
+
+// processCPUTime isn't supported on windows, only the memory usage is sampled
+func processCPUTime() (time.Duration, bool) {
+	return 0, false
+}
@@ -0,0 +1,102 @@
+package resources
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// cgroupRoot is the mount point of the cgroup hierarchy, the cgroup of the process
+// when it runs in a container
+const cgroupRoot = "/sys/fs/cgroup"
+
+// unlimitedMemory is the limit above which the cgroup v1 memory is unlimited,
+// the kernel reports the maximum value rounded down to the page size
+const unlimitedMemory = 1 << 62
+
+// Limits are the resources the process is limited to, 0 when unlimited
+type Limits struct {
+	// CPUs is the CPU quota, in CPUs
+	CPUs float64
+
+	// Memory is the memory limit, in bytes
+	Memory uint64
+}
+
+// DetectLimits returns the CPU and memory limits of the cgroup of the process, v2 or v1
+func DetectLimits() Limits {
+	return detectLimits(cgroupRoot)
+}
+
+func detectLimits(root string) Limits {
+	limits := Limits{}
+
+	// cgroup v2
+	if data, err := os.ReadFile(filepath.Join(root, "cpu.max")); err == nil {
+		// the quota and the period, the quota is max when unlimited
+		if fields := strings.Fields(string(data)); len(fields) == 2 {
+			quota, quotaOk := parseLimit(fields[0])
+			period, periodOk := parseLimit(fields[1])
+
+			if quotaOk && periodOk {
+				limits.CPUs = float64(quota) / float64(period)
+			}
+		}
+
+		if memory, ok := readLimit(filepath.Join(root, "memory.max")); ok {
+			limits.Memory = memory
+		}
+
+		return limits
+	}
+
+	// cgroup v1, the quota is -1 when unlimited
+	quota, quotaOk := readLimit(filepath.Join(root, "cpu", "cpu.cfs_quota_us"))
+	period, periodOk := readLimit(filepath.Join(root, "cpu", "cpu.cfs_period_us"))
+
+	if quotaOk && periodOk {
+		limits.CPUs = float64(quota) / float64(period)
+	}
+
+	if memory, ok := readLimit(filepath.Join(root, "memory", "memory.limit_in_bytes")); ok && memory < unlimitedMemory {
+		limits.Memory = memory
+	}
+
+	return limits
+}
+
+func readLimit(path string) (uint64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+
+	return parseLimit(strings.TrimSpace(string(data)))
+}
+
+// parseLimit parses a strictly positive limit, max and -1 are the unlimited values
+func parseLimit(value string) (uint64, bool) {
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || limit <= 0 {
+		return 0, false
+	}
+
+	return uint64(limit), true
+}
+
+// MaxProcs returns the GOMAXPROCS fitting the CPU quota, rounded up,
+// at most the number of CPUs of the host
+func MaxProcs(limits Limits) int {
+	procs := runtime.NumCPU()
+
+	if limits.CPUs > 0 {
+		if quota := int(math.Ceil(limits.CPUs)); quota < procs {
+			procs = quota
+		}
+	}
+
+	return procs
+}
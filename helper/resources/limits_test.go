@@ -0,0 +1,126 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+}
+
+func TestDetectLimits(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		files  map[string]string
+		limits Limits
+	}{
+		{
+			"cgroup v2",
+			map[string]string{
+				"cpu.max":    "150000 100000\n",
+				"memory.max": "2147483648\n",
+			},
+			Limits{CPUs: 1.5, Memory: 2147483648},
+		},
+		{
+			"cgroup v2 unlimited",
+			map[string]string{
+				"cpu.max":    "max 100000\n",
+				"memory.max": "max\n",
+			},
+			Limits{},
+		},
+		{
+			"cgroup v1",
+			map[string]string{
+				"cpu/cpu.cfs_quota_us":         "200000\n",
+				"cpu/cpu.cfs_period_us":        "100000\n",
+				"memory/memory.limit_in_bytes": "1073741824\n",
+			},
+			Limits{CPUs: 2, Memory: 1073741824},
+		},
+		{
+			"cgroup v1 unlimited",
+			map[string]string{
+				"cpu/cpu.cfs_quota_us":         "-1\n",
+				"cpu/cpu.cfs_period_us":        "100000\n",
+				"memory/memory.limit_in_bytes": "9223372036854771712\n",
+			},
+			Limits{},
+		},
+		{
+			"no cgroup",
+			map[string]string{},
+			Limits{},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			writeFiles(t, root, c.files)
+
+			assert.Equal(t, c.limits, detectLimits(root))
+		})
+	}
+}
+
+func TestMaxProcs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1, MaxProcs(Limits{CPUs: 0.5}))
+
+	// the quota doesn't raise the number of CPUs
+	assert.LessOrEqual(t, MaxProcs(Limits{CPUs: 1 << 20}), MaxProcs(Limits{}))
+}
+
+func TestPruneProfiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"20240101-000000-cpu.pprof":  "",
+		"20240101-000000-heap.pprof": "",
+		"20240102-000000-cpu.pprof":  "",
+		"20240102-000000-heap.pprof": "",
+		"20240103-000000-cpu.pprof":  "",
+		"other":                      "",
+	})
+
+	require.NoError(t, pruneProfiles(dir, 2))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	// the oldest set is removed, the other files are left
+	assert.Equal(t, []string{
+		"20240102-000000-cpu.pprof",
+		"20240102-000000-heap.pprof",
+		"20240103-000000-cpu.pprof",
+		"other",
+	}, names)
+}
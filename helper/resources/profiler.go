@@ -0,0 +1,269 @@
+package resources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// sampleInterval is the interval the load is sampled at
+	sampleInterval = 10 * time.Second
+
+	// sustainedSamples is the number of consecutive samples the load must be high for
+	sustainedSamples = 6
+
+	// cpuProfileDuration is the duration of the CPU profiles
+	cpuProfileDuration = 30 * time.Second
+
+	// profileCooldown is the minimum interval between two sets of profiles
+	profileCooldown = 30 * time.Minute
+
+	// retainedProfiles is the number of sets of profiles kept in the directory
+	retainedProfiles = 10
+
+	// memoryThreshold is the share of the memory limit, in percent, above which the load is high
+	memoryThreshold = 90
+
+	// profileSuffix is the suffix of the profile files
+	profileSuffix = ".pprof"
+)
+
+// ProfilerConfig is the configuration of the self-profiling on sustained high load
+type ProfilerConfig struct {
+	// Dir is the directory the profiles are written to
+	Dir string
+
+	// CPUThreshold is the CPU usage, in percent of GOMAXPROCS, above which the load is high
+	CPUThreshold uint64
+
+	// MemoryLimit is the memory limit of the process, the memory usage isn't checked if 0
+	MemoryLimit uint64
+}
+
+// Profiler writes CPU, heap and goroutine profiles of the process when its load stays high,
+// so that the production incidents can be diagnosed afterwards. The profiles are written
+// at most once per cooldown and only the most recent ones are kept
+type Profiler struct {
+	logger hclog.Logger
+	config *ProfilerConfig
+
+	lastCPU    time.Duration
+	lastSample time.Time
+
+	// high is the number of consecutive samples the load was high for
+	high        int
+	lastProfile time.Time
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+// NewProfiler creates a self-profiler, started with Start
+func NewProfiler(logger hclog.Logger, config *ProfilerConfig) *Profiler {
+	return &Profiler{
+		logger:  logger.Named("profiler"),
+		config:  config,
+		closeCh: make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+// Start starts sampling the load of the process
+func (p *Profiler) Start() {
+	p.lastCPU, _ = processCPUTime()
+	p.lastSample = time.Now()
+
+	go p.run()
+}
+
+// Close stops the profiler, interrupting the CPU profile in progress
+func (p *Profiler) Close() {
+	close(p.closeCh)
+	<-p.doneCh
+}
+
+func (p *Profiler) run() {
+	defer close(p.doneCh)
+
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-p.closeCh:
+			return
+		}
+
+		reason := p.sample()
+		if reason == "" {
+			p.high = 0
+
+			continue
+		}
+
+		p.high++
+
+		if p.high < sustainedSamples || time.Since(p.lastProfile) < profileCooldown {
+			continue
+		}
+
+		p.lastProfile = time.Now()
+
+		if err := p.writeProfiles(reason); err != nil {
+			p.logger.Error("failed to write the profiles", "err", err)
+		}
+
+		// the CPU profile took several samples
+		p.high = 0
+		p.lastCPU, _ = processCPUTime()
+		p.lastSample = time.Now()
+	}
+}
+
+// sample returns the reason the load is high, empty if it isn't
+func (p *Profiler) sample() string {
+	var reason string
+
+	if cpu, ok := processCPUTime(); ok {
+		now := time.Now()
+		usage := 100 * float64(cpu-p.lastCPU) / float64(now.Sub(p.lastSample)) / float64(runtime.GOMAXPROCS(0))
+
+		p.lastCPU, p.lastSample = cpu, now
+
+		metrics.SetGauge([]string{"process", "cpu_usage"}, float32(usage))
+
+		if usage >= float64(p.config.CPUThreshold) {
+			reason = fmt.Sprintf("cpu usage %.0f%%", usage)
+		}
+	}
+
+	if p.config.MemoryLimit == 0 {
+		return reason
+	}
+
+	var stats runtime.MemStats
+
+	runtime.ReadMemStats(&stats)
+
+	// the memory obtained from the OS and not returned to it
+	memory := stats.Sys - stats.HeapReleased
+
+	metrics.SetGauge([]string{"process", "memory_usage"}, float32(100*memory/p.config.MemoryLimit))
+
+	if reason == "" && 100*memory >= memoryThreshold*p.config.MemoryLimit {
+		reason = fmt.Sprintf("memory usage %d MB", memory/1024/1024)
+	}
+
+	return reason
+}
+
+// writeProfiles writes the heap and goroutine profiles, then profiles the CPU
+func (p *Profiler) writeProfiles(reason string) error {
+	if err := os.MkdirAll(p.config.Dir, 0755); err != nil {
+		return err
+	}
+
+	prefix := filepath.Join(p.config.Dir, time.Now().UTC().Format("20060102-150405"))
+
+	p.logger.Warn("sustained high load, profiling", "reason", reason, "profiles", prefix)
+
+	for _, name := range []string{"heap", "goroutine"} {
+		profile := pprof.Lookup(name)
+
+		err := writeProfile(prefix+"-"+name+profileSuffix, func(f *os.File) error {
+			return profile.WriteTo(f, 0)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := writeProfile(prefix+"-cpu"+profileSuffix, func(f *os.File) error {
+		// fails if a CPU profile is already in progress, such as one requested by the pprof endpoint
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return err
+		}
+
+		defer pprof.StopCPUProfile()
+
+		select {
+		case <-time.After(cpuProfileDuration):
+		case <-p.closeCh:
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return pruneProfiles(p.config.Dir, retainedProfiles)
+}
+
+func writeProfile(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		os.Remove(path)
+
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return f.Close()
+}
+
+// pruneProfiles removes the profiles but the most recent sets, the profiles of a set
+// share the same timestamp prefix
+func pruneProfiles(dir string, retained int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	sets := map[string][]string{}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, profileSuffix) {
+			continue
+		}
+
+		if idx := strings.LastIndex(name, "-"); idx > 0 {
+			sets[name[:idx]] = append(sets[name[:idx]], name)
+		}
+	}
+
+	prefixes := make([]string, 0, len(sets))
+	for prefix := range sets {
+		prefixes = append(prefixes, prefix)
+	}
+
+	// the timestamps sort chronologically
+	sort.Strings(prefixes)
+
+	for len(prefixes) > retained {
+		for _, name := range sets[prefixes[0]] {
+			if err := os.Remove(filepath.Join(dir, name)); err != nil {
+				return err
+			}
+		}
+
+		prefixes = prefixes[1:]
+	}
+
+	return nil
+}
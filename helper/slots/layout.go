@@ -0,0 +1,404 @@
+package slots
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// The encodings of the types in the storage layout
+const (
+	EncodingInplace      = "inplace"
+	EncodingMapping      = "mapping"
+	EncodingDynamicArray = "dynamic_array"
+	EncodingBytes        = "bytes"
+)
+
+var (
+	ErrVariableNotFound = errors.New("variable not found")
+	ErrTypeNotFound     = errors.New("type not found")
+	ErrInvalidAccess    = errors.New("invalid access")
+	ErrInvalidValue     = errors.New("invalid value")
+)
+
+// Layout is the storage layout of a contract, as given by solc --storage-layout
+type Layout struct {
+	Storage []*Variable      `json:"storage"`
+	Types   map[string]*Type `json:"types"`
+}
+
+// Variable is a state variable of the contract, or a member of a struct
+type Variable struct {
+	Label  string `json:"label"`
+	Offset int    `json:"offset"`
+	Slot   string `json:"slot"`
+	Type   string `json:"type"`
+}
+
+// Type is a type of the storage layout
+type Type struct {
+	Encoding      string      `json:"encoding"`
+	Label         string      `json:"label"`
+	NumberOfBytes string      `json:"numberOfBytes"`
+	Key           string      `json:"key,omitempty"`
+	Value         string      `json:"value,omitempty"`
+	Base          string      `json:"base,omitempty"`
+	Members       []*Variable `json:"members,omitempty"`
+}
+
+// size returns the number of bytes of the type
+func (t *Type) size() (int, error) {
+	size, err := strconv.Atoi(t.NumberOfBytes)
+	if err != nil {
+		return 0, fmt.Errorf("%w: size of %s", ErrInvalidValue, t.Label)
+	}
+
+	return size, nil
+}
+
+// isFixedBytes returns true for the bytes1 to bytes32 types, which are left aligned
+func (t *Type) isFixedBytes() bool {
+	return strings.HasPrefix(t.Label, "bytes") && t.Encoding == EncodingInplace
+}
+
+// isStaticArray returns true for the fixed size arrays
+func (t *Type) isStaticArray() bool {
+	return t.Base != "" && t.Encoding == EncodingInplace
+}
+
+// ParseLayout decodes the storage layout JSON of the compiler
+func ParseLayout(data []byte) (*Layout, error) {
+	layout := &Layout{}
+	if err := json.Unmarshal(data, layout); err != nil {
+		return nil, err
+	}
+
+	return layout, nil
+}
+
+// Var returns the reference to the state variable
+func (l *Layout) Var(label string) *Ref {
+	for _, v := range l.Storage {
+		if v.Label == label {
+			return l.ref(new(big.Int), v)
+		}
+	}
+
+	return &Ref{err: fmt.Errorf("%w: %s", ErrVariableNotFound, label)}
+}
+
+func (l *Layout) ref(base *big.Int, v *Variable) *Ref {
+	slot, ok := new(big.Int).SetString(v.Slot, 10)
+	if !ok {
+		return &Ref{err: fmt.Errorf("%w: slot %s of %s", ErrInvalidValue, v.Slot, v.Label)}
+	}
+
+	return &Ref{
+		layout: l,
+		slot:   types.BytesToHash(slot.Add(slot, base).Mod(slot, slotModulus).Bytes()),
+		offset: v.Offset,
+		typ:    v.Type,
+	}
+}
+
+// Ref is the location of a value in the storage. The accessors return a new reference,
+// the first error is kept and returned when the reference is used
+type Ref struct {
+	layout *Layout
+	slot   types.Hash
+	offset int
+	typ    string
+	err    error
+}
+
+func (r *Ref) typeOf(name string) (*Type, error) {
+	t, ok := r.layout.Types[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTypeNotFound, name)
+	}
+
+	return t, nil
+}
+
+func (r *Ref) fail(err error) *Ref {
+	return &Ref{err: err}
+}
+
+// Key returns the reference to the value of the key in the mapping
+func (r *Ref) Key(key interface{}) *Ref {
+	if r.err != nil {
+		return r
+	}
+
+	t, err := r.typeOf(r.typ)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	if t.Encoding != EncodingMapping {
+		return r.fail(fmt.Errorf("%w: %s is not a mapping", ErrInvalidAccess, t.Label))
+	}
+
+	keyType, err := r.typeOf(t.Key)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	var encoded []byte
+
+	if keyType.Encoding == EncodingBytes {
+		switch k := key.(type) {
+		case string:
+			encoded = []byte(k)
+		case []byte:
+			encoded = k
+		default:
+			return r.fail(fmt.Errorf("%w: %T key of %s", ErrInvalidValue, key, keyType.Label))
+		}
+	} else {
+		word, err := encodeWord(keyType, key)
+		if err != nil {
+			return r.fail(err)
+		}
+
+		encoded = word[:]
+	}
+
+	return &Ref{
+		layout: r.layout,
+		slot:   MappingSlot(r.slot, encoded),
+		typ:    t.Value,
+	}
+}
+
+// Index returns the reference to the element of the static or dynamic array
+func (r *Ref) Index(i uint64) *Ref {
+	if r.err != nil {
+		return r
+	}
+
+	t, err := r.typeOf(r.typ)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	var start types.Hash
+
+	switch {
+	case t.Encoding == EncodingDynamicArray:
+		start = ArraySlot(r.slot)
+	case t.isStaticArray():
+		start = r.slot
+
+		length, err := staticArrayLength(t)
+		if err != nil {
+			return r.fail(err)
+		}
+
+		if i >= length {
+			return r.fail(fmt.Errorf("%w: index %d out of %s", ErrInvalidAccess, i, t.Label))
+		}
+	default:
+		return r.fail(fmt.Errorf("%w: %s is not an array", ErrInvalidAccess, t.Label))
+	}
+
+	base, err := r.typeOf(t.Base)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	size, err := base.size()
+	if err != nil {
+		return r.fail(err)
+	}
+
+	res := &Ref{layout: r.layout, typ: t.Base}
+
+	if size <= wordSize/2 {
+		// the small elements are packed in the slots
+		perSlot := uint64(wordSize / size)
+
+		res.slot = AddOffset(start, i/perSlot)
+		res.offset = int(i%perSlot) * size
+	} else {
+		res.slot = AddOffset(start, i*uint64((size+wordSize-1)/wordSize))
+	}
+
+	return res
+}
+
+// Field returns the reference to the member of the struct
+func (r *Ref) Field(label string) *Ref {
+	if r.err != nil {
+		return r
+	}
+
+	t, err := r.typeOf(r.typ)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	for _, member := range t.Members {
+		if member.Label == label {
+			return r.layout.ref(new(big.Int).SetBytes(r.slot.Bytes()), member)
+		}
+	}
+
+	return r.fail(fmt.Errorf("%w: %s in %s", ErrVariableNotFound, label, t.Label))
+}
+
+// Slot returns the slot of the value
+func (r *Ref) Slot() (types.Hash, error) {
+	return r.slot, r.err
+}
+
+// Offset returns the offset of the value in its slot, counted from the lower-order byte
+func (r *Ref) Offset() (int, error) {
+	return r.offset, r.err
+}
+
+// Set sets the value type (integer, bool, address, fixed bytes) in the storage
+func (r *Ref) Set(storage map[types.Hash]types.Hash, value interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	t, err := r.typeOf(r.typ)
+	if err != nil {
+		return err
+	}
+
+	if t.Encoding != EncodingInplace || t.Base != "" || len(t.Members) > 0 {
+		return fmt.Errorf("%w: %s is not a value type", ErrInvalidAccess, t.Label)
+	}
+
+	size, err := t.size()
+	if err != nil {
+		return err
+	}
+
+	word, err := encodeWord(t, value)
+	if err != nil {
+		return err
+	}
+
+	if t.isFixedBytes() {
+		SetPacked(storage, r.slot, r.offset, word[:size])
+	} else {
+		SetPacked(storage, r.slot, r.offset, word[wordSize-size:])
+	}
+
+	return nil
+}
+
+// SetBytes sets the string or bytes value in the storage
+func (r *Ref) SetBytes(storage map[types.Hash]types.Hash, data []byte) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	t, err := r.typeOf(r.typ)
+	if err != nil {
+		return err
+	}
+
+	if t.Encoding != EncodingBytes {
+		return fmt.Errorf("%w: %s is not a string or bytes", ErrInvalidAccess, t.Label)
+	}
+
+	SetBytes(storage, r.slot, data)
+
+	return nil
+}
+
+// SetLength sets the length of the dynamic array in the storage
+func (r *Ref) SetLength(storage map[types.Hash]types.Hash, length uint64) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	t, err := r.typeOf(r.typ)
+	if err != nil {
+		return err
+	}
+
+	if t.Encoding != EncodingDynamicArray {
+		return fmt.Errorf("%w: %s is not a dynamic array", ErrInvalidAccess, t.Label)
+	}
+
+	storage[r.slot] = Slot(length)
+
+	return nil
+}
+
+// staticArrayLength parses the length of the static array from its label, i.e. uint256[3]
+func staticArrayLength(t *Type) (uint64, error) {
+	start, end := strings.LastIndex(t.Label, "["), strings.LastIndex(t.Label, "]")
+	if start < 0 || end < start {
+		return 0, fmt.Errorf("%w: length of %s", ErrInvalidValue, t.Label)
+	}
+
+	length, err := strconv.ParseUint(t.Label[start+1:end], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: length of %s", ErrInvalidValue, t.Label)
+	}
+
+	return length, nil
+}
+
+// encodeWord encodes the value type as a 32 bytes word, the numbers are in two's complement
+// and the fixed bytes are left aligned
+func encodeWord(t *Type, value interface{}) (types.Hash, error) {
+	var n *big.Int
+
+	switch v := value.(type) {
+	case types.Address:
+		return types.BytesToHash(v.Bytes()), nil
+	case types.Hash:
+		return v, nil
+	case bool:
+		if v {
+			n = big.NewInt(1)
+		} else {
+			n = big.NewInt(0)
+		}
+	case []byte:
+		if len(v) > wordSize {
+			return types.Hash{}, fmt.Errorf("%w: %d bytes for %s", ErrInvalidValue, len(v), t.Label)
+		}
+
+		if t.isFixedBytes() {
+			word := types.Hash{}
+			copy(word[:], v)
+
+			return word, nil
+		}
+
+		return types.BytesToHash(v), nil
+	case int:
+		n = big.NewInt(int64(v))
+	case int64:
+		n = big.NewInt(v)
+	case uint64:
+		n = new(big.Int).SetUint64(v)
+	case *big.Int:
+		n = v
+	default:
+		return types.Hash{}, fmt.Errorf("%w: %T for %s", ErrInvalidValue, value, t.Label)
+	}
+
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, slotModulus)
+	}
+
+	if n.Sign() < 0 || n.BitLen() > 8*wordSize {
+		return types.Hash{}, fmt.Errorf("%w: %s out of range for %s", ErrInvalidValue, n, t.Label)
+	}
+
+	return types.BytesToHash(n.Bytes()), nil
+}
@@ -0,0 +1,81 @@
+// Package slots computes the storage slots of the state variables of Solidity contracts,
+// following the storage layout of the compiler:
+// https://docs.soliditylang.org/en/latest/internals/layout_in_storage.html
+package slots
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// wordSize is the size of a storage slot
+const wordSize = 32
+
+var slotModulus = new(big.Int).Lsh(big.NewInt(1), 8*wordSize)
+
+// Slot returns the storage slot with the given number
+func Slot(n uint64) types.Hash {
+	return types.BytesToHash(new(big.Int).SetUint64(n).Bytes())
+}
+
+// MappingSlot returns the slot of the value of the key in the mapping at the slot.
+// Value type keys are padded to 32 bytes, string and bytes keys are not
+func MappingSlot(slot types.Hash, key []byte) types.Hash {
+	return types.BytesToHash(keccak.Keccak256(nil, key, slot.Bytes()))
+}
+
+// AddressMappingSlot returns the slot of the value of the address in the mapping at the slot
+func AddressMappingSlot(slot types.Hash, addr types.Address) types.Hash {
+	return MappingSlot(slot, types.BytesToHash(addr.Bytes()).Bytes())
+}
+
+// ArraySlot returns the slot of the first element of the dynamic array
+// or of the long string or bytes at the slot
+func ArraySlot(slot types.Hash) types.Hash {
+	return types.BytesToHash(keccak.Keccak256(nil, slot.Bytes()))
+}
+
+// AddOffset returns the slot the given number of slots after the slot, wrapping around
+func AddOffset(slot types.Hash, offset uint64) types.Hash {
+	res := new(big.Int).SetBytes(slot.Bytes())
+	res.Add(res, new(big.Int).SetUint64(offset))
+	res.Mod(res, slotModulus)
+
+	return types.BytesToHash(res.Bytes())
+}
+
+// SetBytes sets the string or bytes value at the slot, the short values are stored
+// in the slot with twice their length, the long ones from ArraySlot with their length
+func SetBytes(storage map[types.Hash]types.Hash, slot types.Hash, data []byte) {
+	if len(data) < wordSize {
+		value := types.Hash{}
+		copy(value[:], data)
+
+		value[wordSize-1] = byte(2 * len(data))
+		storage[slot] = value
+
+		return
+	}
+
+	storage[slot] = types.BytesToHash(big.NewInt(int64(2*len(data) + 1)).Bytes())
+
+	start := ArraySlot(slot)
+
+	for i := 0; i*wordSize < len(data); i++ {
+		value := types.Hash{}
+		copy(value[:], data[i*wordSize:])
+
+		storage[AddOffset(start, uint64(i))] = value
+	}
+}
+
+// SetPacked sets the value in the size bytes of the slot at the offset,
+// counted from its lower-order byte, keeping the other values packed in it
+func SetPacked(storage map[types.Hash]types.Hash, slot types.Hash, offset int, value []byte) {
+	word := storage[slot]
+	copy(word[wordSize-offset-len(value):wordSize-offset], value)
+
+	storage[slot] = word
+}
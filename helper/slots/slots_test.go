@@ -0,0 +1,216 @@
+package slots
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// layoutJSON is the storage layout solc outputs for:
+//
+//	struct Info { uint128 a; uint64 b; address owner; string name; }
+//	uint256 total;                                        // slot 0
+//	bool paused; uint8 level;                             // slot 1
+//	mapping(address => mapping(uint256 => Info)) infos;   // slot 2
+//	address[] owners;                                     // slot 3
+//	uint16[3] small;                                      // slot 4
+//	Info single;                                          // slots 5 to 7
+//	mapping(string => uint256) byName;                    // slot 8
+//	string label;                                         // slot 9
+//	bytes4 tag; int64 delta;                              // slot 10
+//
+//nolint:lll
+const layoutJSON = `{
+  "storage": [
+    {"astId": 1, "contract": "Test.sol:Test", "label": "total", "offset": 0, "slot": "0", "type": "t_uint256"},
+    {"astId": 2, "contract": "Test.sol:Test", "label": "paused", "offset": 0, "slot": "1", "type": "t_bool"},
+    {"astId": 3, "contract": "Test.sol:Test", "label": "level", "offset": 1, "slot": "1", "type": "t_uint8"},
+    {"astId": 4, "contract": "Test.sol:Test", "label": "infos", "offset": 0, "slot": "2", "type": "t_mapping(t_address,t_mapping(t_uint256,t_struct(Info)1_storage))"},
+    {"astId": 5, "contract": "Test.sol:Test", "label": "owners", "offset": 0, "slot": "3", "type": "t_array(t_address)dyn_storage"},
+    {"astId": 6, "contract": "Test.sol:Test", "label": "small", "offset": 0, "slot": "4", "type": "t_array(t_uint16)3_storage"},
+    {"astId": 7, "contract": "Test.sol:Test", "label": "single", "offset": 0, "slot": "5", "type": "t_struct(Info)1_storage"},
+    {"astId": 8, "contract": "Test.sol:Test", "label": "byName", "offset": 0, "slot": "8", "type": "t_mapping(t_string_memory_ptr,t_uint256)"},
+    {"astId": 9, "contract": "Test.sol:Test", "label": "label", "offset": 0, "slot": "9", "type": "t_string_storage"},
+    {"astId": 10, "contract": "Test.sol:Test", "label": "tag", "offset": 0, "slot": "10", "type": "t_bytes4"},
+    {"astId": 11, "contract": "Test.sol:Test", "label": "delta", "offset": 4, "slot": "10", "type": "t_int64"}
+  ],
+  "types": {
+    "t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+    "t_bool": {"encoding": "inplace", "label": "bool", "numberOfBytes": "1"},
+    "t_bytes4": {"encoding": "inplace", "label": "bytes4", "numberOfBytes": "4"},
+    "t_int64": {"encoding": "inplace", "label": "int64", "numberOfBytes": "8"},
+    "t_uint8": {"encoding": "inplace", "label": "uint8", "numberOfBytes": "1"},
+    "t_uint16": {"encoding": "inplace", "label": "uint16", "numberOfBytes": "2"},
+    "t_uint64": {"encoding": "inplace", "label": "uint64", "numberOfBytes": "8"},
+    "t_uint128": {"encoding": "inplace", "label": "uint128", "numberOfBytes": "16"},
+    "t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+    "t_string_storage": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+    "t_string_memory_ptr": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+    "t_array(t_address)dyn_storage": {"base": "t_address", "encoding": "dynamic_array", "label": "address[]", "numberOfBytes": "32"},
+    "t_array(t_uint16)3_storage": {"base": "t_uint16", "encoding": "inplace", "label": "uint16[3]", "numberOfBytes": "32"},
+    "t_mapping(t_address,t_mapping(t_uint256,t_struct(Info)1_storage))": {"encoding": "mapping", "key": "t_address", "label": "mapping(address => mapping(uint256 => struct Test.Info))", "numberOfBytes": "32", "value": "t_mapping(t_uint256,t_struct(Info)1_storage)"},
+    "t_mapping(t_uint256,t_struct(Info)1_storage)": {"encoding": "mapping", "key": "t_uint256", "label": "mapping(uint256 => struct Test.Info)", "numberOfBytes": "32", "value": "t_struct(Info)1_storage"},
+    "t_mapping(t_string_memory_ptr,t_uint256)": {"encoding": "mapping", "key": "t_string_memory_ptr", "label": "mapping(string => uint256)", "numberOfBytes": "32", "value": "t_uint256"},
+    "t_struct(Info)1_storage": {
+      "encoding": "inplace", "label": "struct Test.Info", "numberOfBytes": "96",
+      "members": [
+        {"astId": 12, "contract": "Test.sol:Test", "label": "a", "offset": 0, "slot": "0", "type": "t_uint128"},
+        {"astId": 13, "contract": "Test.sol:Test", "label": "b", "offset": 16, "slot": "0", "type": "t_uint64"},
+        {"astId": 14, "contract": "Test.sol:Test", "label": "owner", "offset": 0, "slot": "1", "type": "t_address"},
+        {"astId": 15, "contract": "Test.sol:Test", "label": "name", "offset": 0, "slot": "2", "type": "t_string_storage"}
+      ]
+    }
+  }
+}`
+
+func word(n uint64) []byte {
+	return types.BytesToHash(new(big.Int).SetUint64(n).Bytes()).Bytes()
+}
+
+func addSlot(slot []byte, offset int64) types.Hash {
+	res := new(big.Int).SetBytes(slot)
+
+	return types.BytesToHash(res.Add(res, big.NewInt(offset)).Bytes())
+}
+
+func TestSlots_Primitives(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+
+	assert.Equal(t, types.BytesToHash([]byte{5}), Slot(5))
+	assert.Equal(
+		t,
+		types.BytesToHash(crypto.Keccak256(types.BytesToHash(addr.Bytes()).Bytes(), word(3))),
+		AddressMappingSlot(Slot(3), addr),
+	)
+	assert.Equal(t, types.BytesToHash(crypto.Keccak256(word(0))), ArraySlot(Slot(0)))
+
+	// the offset wraps around the last slot
+	last := types.Hash{}
+	for i := range last {
+		last[i] = 0xff
+	}
+
+	assert.Equal(t, Slot(1), AddOffset(last, 2))
+}
+
+func TestSlots_SetBytes(t *testing.T) {
+	t.Parallel()
+
+	storage := map[types.Hash]types.Hash{}
+
+	// the short values are in the slot with twice their length
+	SetBytes(storage, Slot(1), []byte("abc"))
+
+	expected := types.Hash{'a', 'b', 'c'}
+	expected[31] = 6
+
+	assert.Equal(t, expected, storage[Slot(1)])
+
+	// the long values are from the keccak of the slot
+	data := bytes.Repeat([]byte{0x1, 0x2, 0x3}, 15)
+	SetBytes(storage, Slot(2), data)
+
+	start := crypto.Keccak256(word(2))
+
+	assert.Equal(t, types.BytesToHash([]byte{91}), storage[Slot(2)])
+	assert.Equal(t, types.BytesToHash(data[:32]), storage[types.BytesToHash(start)])
+	assert.Equal(t, types.Hash{}.Bytes()[:19], storage[addSlot(start, 1)].Bytes()[13:])
+	assert.Equal(t, data[32:], storage[addSlot(start, 1)].Bytes()[:13])
+}
+
+func TestLayout_Slots(t *testing.T) {
+	t.Parallel()
+
+	layout, err := ParseLayout([]byte(layoutJSON))
+	require.NoError(t, err)
+
+	addr := types.StringToAddress("1")
+	infoSlot := crypto.Keccak256(word(7), crypto.Keccak256(types.BytesToHash(addr.Bytes()).Bytes(), word(2)))
+
+	cases := []struct {
+		name   string
+		ref    *Ref
+		slot   types.Hash
+		offset int
+	}{
+		{"value", layout.Var("total"), Slot(0), 0},
+		{"packed value", layout.Var("level"), Slot(1), 1},
+		{"nested mapping", layout.Var("infos").Key(addr).Key(uint64(7)).Field("b"), types.BytesToHash(infoSlot), 16},
+		{"struct in mapping", layout.Var("infos").Key(addr).Key(uint64(7)).Field("name"), addSlot(infoSlot, 2), 0},
+		{"dynamic array", layout.Var("owners").Index(4), addSlot(crypto.Keccak256(word(3)), 4), 0},
+		{"packed array", layout.Var("small").Index(2), Slot(4), 4},
+		{"struct", layout.Var("single").Field("owner"), Slot(6), 0},
+		{"string key", layout.Var("byName").Key("alice"), types.BytesToHash(crypto.Keccak256([]byte("alice"), word(8))), 0},
+	}
+
+	for _, c := range cases {
+		slot, err := c.ref.Slot()
+		require.NoError(t, err, c.name)
+		assert.Equal(t, c.slot, slot, c.name)
+
+		offset, err := c.ref.Offset()
+		require.NoError(t, err, c.name)
+		assert.Equal(t, c.offset, offset, c.name)
+	}
+}
+
+func TestLayout_Errors(t *testing.T) {
+	t.Parallel()
+
+	layout, err := ParseLayout([]byte(layoutJSON))
+	require.NoError(t, err)
+
+	_, err = layout.Var("missing").Slot()
+	assert.ErrorIs(t, err, ErrVariableNotFound)
+
+	// the first error is kept along the accessors
+	_, err = layout.Var("total").Key(uint64(1)).Field("a").Slot()
+	assert.ErrorIs(t, err, ErrInvalidAccess)
+
+	_, err = layout.Var("small").Index(3).Slot()
+	assert.ErrorIs(t, err, ErrInvalidAccess)
+
+	_, err = layout.Var("single").Field("missing").Slot()
+	assert.ErrorIs(t, err, ErrVariableNotFound)
+
+	assert.ErrorIs(t, layout.Var("label").Set(map[types.Hash]types.Hash{}, uint64(1)), ErrInvalidAccess)
+	assert.ErrorIs(t, layout.Var("total").Set(map[types.Hash]types.Hash{}, "1"), ErrInvalidValue)
+}
+
+func TestLayout_Set(t *testing.T) {
+	t.Parallel()
+
+	layout, err := ParseLayout([]byte(layoutJSON))
+	require.NoError(t, err)
+
+	storage := map[types.Hash]types.Hash{}
+	owner := types.StringToAddress("2")
+
+	require.NoError(t, layout.Var("paused").Set(storage, true))
+	require.NoError(t, layout.Var("level").Set(storage, uint64(3)))
+	require.NoError(t, layout.Var("tag").Set(storage, []byte{0xde, 0xad, 0xbe, 0xef}))
+	require.NoError(t, layout.Var("delta").Set(storage, int64(-2)))
+	require.NoError(t, layout.Var("single").Field("owner").Set(storage, owner))
+	require.NoError(t, layout.Var("label").SetBytes(storage, []byte("label")))
+	require.NoError(t, layout.Var("owners").SetLength(storage, 1))
+	require.NoError(t, layout.Var("owners").Index(0).Set(storage, owner))
+
+	// the values are packed from the lower-order bytes of the slot
+	assert.Equal(t, types.BytesToHash([]byte{0x03, 0x01}), storage[Slot(1)])
+	assert.Equal(
+		t,
+		types.BytesToHash([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe, 0xde, 0xad, 0xbe, 0xef}),
+		storage[Slot(10)],
+	)
+	assert.Equal(t, types.BytesToHash(owner.Bytes()), storage[Slot(6)])
+	assert.Equal(t, types.Hash{'l', 'a', 'b', 'e', 'l', 31: 10}, storage[Slot(9)])
+	assert.Equal(t, Slot(1), storage[Slot(3)])
+	assert.Equal(t, types.BytesToHash(owner.Bytes()), storage[types.BytesToHash(crypto.Keccak256(word(3)))])
+}
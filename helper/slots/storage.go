@@ -0,0 +1,235 @@
+package slots
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// GenerateStorage returns the storage of a contract with the given values of its state variables,
+// i.e. for a genesis account. The values are the ones decoded from JSON:
+//   - the numbers are decimal or hex strings, or JSON numbers
+//   - the addresses and the fixed and dynamic bytes are hex strings
+//   - the mappings and the structs are objects, keyed by the mapping keys or the members
+//   - the arrays are lists
+func GenerateStorage(layout *Layout, values map[string]interface{}) (map[types.Hash]types.Hash, error) {
+	storage := make(map[types.Hash]types.Hash)
+
+	if err := layout.SetValues(storage, values); err != nil {
+		return nil, err
+	}
+
+	return storage, nil
+}
+
+// ParseValues decodes the JSON of the values of the state variables
+func ParseValues(data []byte) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// SetValues sets the values of the state variables in the storage
+func (l *Layout) SetValues(storage map[types.Hash]types.Hash, values map[string]interface{}) error {
+	// the variables are set in a deterministic order, as the packed ones share their slots
+	for _, label := range sortedKeys(values) {
+		if err := l.Var(label).Assign(storage, values[label]); err != nil {
+			return fmt.Errorf("unable to set %s: %w", label, err)
+		}
+	}
+
+	return nil
+}
+
+// Assign sets the value of any type in the storage, following the encoding of its type
+func (r *Ref) Assign(storage map[types.Hash]types.Hash, value interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	t, err := r.typeOf(r.typ)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case t.Encoding == EncodingMapping:
+		return r.assignMapping(storage, t, value)
+	case t.Encoding == EncodingDynamicArray || t.isStaticArray():
+		return r.assignArray(storage, t, value)
+	case len(t.Members) > 0:
+		return r.assignStruct(storage, value)
+	case t.Encoding == EncodingBytes:
+		data, err := parseBytes(t, value)
+		if err != nil {
+			return err
+		}
+
+		return r.SetBytes(storage, data)
+	default:
+		v, err := parseValue(t, value)
+		if err != nil {
+			return err
+		}
+
+		return r.Set(storage, v)
+	}
+}
+
+func (r *Ref) assignMapping(storage map[types.Hash]types.Hash, t *Type, value interface{}) error {
+	entries, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%w: %T for %s", ErrInvalidValue, value, t.Label)
+	}
+
+	keyType, err := r.typeOf(t.Key)
+	if err != nil {
+		return err
+	}
+
+	for _, rawKey := range sortedKeys(entries) {
+		var key interface{}
+
+		if keyType.Encoding == EncodingBytes {
+			key, err = parseBytes(keyType, rawKey)
+		} else {
+			key, err = parseValue(keyType, rawKey)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := r.Key(key).Assign(storage, entries[rawKey]); err != nil {
+			return fmt.Errorf("key %s: %w", rawKey, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Ref) assignArray(storage map[types.Hash]types.Hash, t *Type, value interface{}) error {
+	elems, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("%w: %T for %s", ErrInvalidValue, value, t.Label)
+	}
+
+	if t.Encoding == EncodingDynamicArray {
+		if err := r.SetLength(storage, uint64(len(elems))); err != nil {
+			return err
+		}
+	}
+
+	for i, elem := range elems {
+		if err := r.Index(uint64(i)).Assign(storage, elem); err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Ref) assignStruct(storage map[types.Hash]types.Hash, value interface{}) error {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%w: %T for a struct", ErrInvalidValue, value)
+	}
+
+	for _, label := range sortedKeys(fields) {
+		if err := r.Field(label).Assign(storage, fields[label]); err != nil {
+			return fmt.Errorf("field %s: %w", label, err)
+		}
+	}
+
+	return nil
+}
+
+// parseValue converts the JSON value to the value type, the other values are kept as they are
+func parseValue(t *Type, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		switch {
+		case t.Label == "bool":
+			return strconv.ParseBool(v)
+		case t.isAddress():
+			raw, err := hex.DecodeHex(v)
+			if err != nil || len(raw) != types.AddressLength {
+				return nil, fmt.Errorf("%w: %s for %s", ErrInvalidValue, v, t.Label)
+			}
+
+			return types.BytesToAddress(raw), nil
+		case t.isFixedBytes():
+			return parseBytes(t, v)
+		default:
+			n, ok := new(big.Int).SetString(v, 0)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s for %s", ErrInvalidValue, v, t.Label)
+			}
+
+			return n, nil
+		}
+	case json.Number:
+		n, ok := new(big.Int).SetString(v.String(), 10)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s for %s", ErrInvalidValue, v, t.Label)
+		}
+
+		return n, nil
+	case float64:
+		n, accuracy := big.NewFloat(v).Int(nil)
+		if accuracy != big.Exact {
+			return nil, fmt.Errorf("%w: %v for %s", ErrInvalidValue, v, t.Label)
+		}
+
+		return n, nil
+	}
+
+	return value, nil
+}
+
+// parseBytes converts the value to bytes, the strings are kept as they are
+// and the other types are decoded from hex
+func parseBytes(t *Type, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		if t.Label == "string" {
+			return []byte(v), nil
+		}
+
+		data, err := hex.DecodeHex(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s for %s", ErrInvalidValue, v, t.Label)
+		}
+
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("%w: %T for %s", ErrInvalidValue, value, t.Label)
+}
+
+// isAddress returns true for the address and contract types
+func (t *Type) isAddress() bool {
+	return strings.HasPrefix(t.Label, "address") || strings.HasPrefix(t.Label, "contract ")
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
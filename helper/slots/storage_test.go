@@ -0,0 +1,88 @@
+package slots
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateStorage(t *testing.T) {
+	t.Parallel()
+
+	layout, err := ParseLayout([]byte(layoutJSON))
+	require.NoError(t, err)
+
+	owner := types.StringToAddress("2")
+
+	values, err := ParseValues([]byte(`{
+		"total": "0x10",
+		"paused": true,
+		"level": 3,
+		"infos": {
+			"` + owner.String() + `": {
+				"7": {"a": "1", "b": 2, "owner": "` + owner.String() + `", "name": "info"}
+			}
+		},
+		"owners": ["` + owner.String() + `"],
+		"small": [1, 2, 3],
+		"byName": {"alice": "-1"},
+		"tag": "0xdeadbeef"
+	}`))
+	require.NoError(t, err)
+
+	storage, err := GenerateStorage(layout, values)
+	require.NoError(t, err)
+
+	infoSlot := crypto.Keccak256(word(7), crypto.Keccak256(types.BytesToHash(owner.Bytes()).Bytes(), word(2)))
+	minusOne := types.Hash{}
+
+	for i := range minusOne {
+		minusOne[i] = 0xff
+	}
+
+	assert.Equal(t, types.BytesToHash([]byte{0x10}), storage[Slot(0)])
+	assert.Equal(t, types.BytesToHash([]byte{0x03, 0x01}), storage[Slot(1)])
+	assert.Equal(
+		t,
+		types.BytesToHash([]byte{2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}),
+		storage[types.BytesToHash(infoSlot)],
+	)
+	assert.Equal(t, types.BytesToHash(owner.Bytes()), storage[addSlot(infoSlot, 1)])
+	assert.Equal(t, types.Hash{'i', 'n', 'f', 'o', 31: 8}, storage[addSlot(infoSlot, 2)])
+	assert.Equal(t, Slot(1), storage[Slot(3)])
+	assert.Equal(t, types.BytesToHash(owner.Bytes()), storage[types.BytesToHash(crypto.Keccak256(word(3)))])
+	assert.Equal(t, types.BytesToHash([]byte{0, 3, 0, 2, 0, 1}), storage[Slot(4)])
+	assert.Equal(t, minusOne, storage[types.BytesToHash(crypto.Keccak256([]byte("alice"), word(8)))])
+	assert.Equal(t, types.BytesToHash([]byte{0xde, 0xad, 0xbe, 0xef}), storage[Slot(10)])
+}
+
+func TestGenerateStorage_Errors(t *testing.T) {
+	t.Parallel()
+
+	layout, err := ParseLayout([]byte(layoutJSON))
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		values string
+		err    error
+	}{
+		{"unknown variable", `{"missing": 1}`, ErrVariableNotFound},
+		{"fractional number", `{"total": 1.5}`, ErrInvalidValue},
+		{"invalid address", `{"owners": ["0x01"]}`, ErrInvalidValue},
+		{"mapping as a list", `{"byName": [1]}`, ErrInvalidValue},
+		{"static array overflow", `{"small": [1, 2, 3, 4]}`, ErrInvalidAccess},
+		{"unknown member", `{"single": {"c": 1}}`, ErrVariableNotFound},
+	}
+
+	for _, c := range cases {
+		values, err := ParseValues([]byte(c.values))
+		require.NoError(t, err, c.name)
+
+		_, err = GenerateStorage(layout, values)
+		assert.ErrorIs(t, err, c.err, c.name)
+	}
+}
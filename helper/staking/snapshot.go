@@ -0,0 +1,166 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/helper/slots"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// wordSize is the size of a storage slot
+const wordSize = 32
+
+var errInvalidValidatorCount = errors.New("invalid number of validators in the staking contract")
+
+// StorageReader reads a slot of the storage of the staking contract
+type StorageReader func(slot types.Hash) (types.Hash, error)
+
+// Snapshot is the storage of the staking contract copied at a block
+type Snapshot struct {
+	// Storage holds the non-empty slots copied
+	Storage map[types.Hash]types.Hash
+
+	// Validators is the validator set of the contract
+	Validators []types.Address
+
+	// Accounts are the validators and the given accounts whose entries were copied
+	Accounts []types.Address
+}
+
+// SnapshotStakingSC reads the storage of the staking contract: the parameters, the validator set and the stakes,
+// BLS public keys, owners, withdrawals and auto-compounding of the validators and the given accounts. The storage
+// is copied slot by slot, so that a new genesis carries over the state of the contract exactly.
+// The mappings can't be enumerated, the accounts which aren't validators must be given.
+// The validator exits counted per epoch aren't copied, the epochs restart with the new genesis
+func SnapshotStakingSC(read StorageReader, accounts []types.Address) (*Snapshot, error) {
+	layout, err := slots.ParseLayout(storageLayout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the storage layout, %w", err)
+	}
+
+	storage := make(map[types.Hash]types.Hash)
+
+	copySlot := func(slot types.Hash) (types.Hash, error) {
+		value, err := read(slot)
+		if err != nil {
+			return types.ZeroHash, fmt.Errorf("unable to read slot %s, %w", slot, err)
+		}
+
+		// the genesis storage omits the empty slots
+		if value != types.ZeroHash {
+			storage[slot] = value
+		}
+
+		return value, nil
+	}
+
+	copyRef := func(ref *slots.Ref) (types.Hash, error) {
+		slot, err := ref.Slot()
+		if err != nil {
+			return types.ZeroHash, err
+		}
+
+		return copySlot(slot)
+	}
+
+	// copyBytes copies the bytes value, whose head slot holds the short values
+	// or the length of the long values stored from the hash of the slot
+	copyBytes := func(ref *slots.Ref) error {
+		head, err := copyRef(ref)
+		if err != nil {
+			return err
+		}
+
+		if head[wordSize-1]&1 == 0 {
+			return nil
+		}
+
+		slot, _ := ref.Slot()
+		length := new(big.Int).Rsh(new(big.Int).SetBytes(head.Bytes()), 1).Uint64()
+		data := slots.ArraySlot(slot)
+
+		for i := uint64(0); i*wordSize < length; i++ {
+			if _, err := copySlot(slots.AddOffset(data, i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, label := range []string{
+		"_stakedAmount",
+		"_minimumNumValidators",
+		"_maximumNumValidators",
+		"_unbondingPeriod",
+		"_epochSize",
+		"_maximumValidatorExits",
+		"_minimumSelfStake",
+		"_maximumValidatorStake",
+	} {
+		if _, err := copyRef(layout.Var(label)); err != nil {
+			return nil, err
+		}
+	}
+
+	length, err := copyRef(layout.Var("_validators"))
+	if err != nil {
+		return nil, err
+	}
+
+	count := new(big.Int).SetBytes(length.Bytes())
+	if !count.IsUint64() || count.Uint64() > MaxValidatorCount {
+		return nil, fmt.Errorf("%w: %s", errInvalidValidatorCount, count)
+	}
+
+	snapshot := &Snapshot{
+		Storage:    storage,
+		Validators: make([]types.Address, 0, count.Uint64()),
+		Accounts:   make([]types.Address, 0, count.Uint64()+uint64(len(accounts))),
+	}
+
+	seen := make(map[types.Address]struct{}, cap(snapshot.Accounts))
+
+	for i := uint64(0); i < count.Uint64(); i++ {
+		word, err := copyRef(layout.Var("_validators").Index(i))
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot.Validators = append(snapshot.Validators, types.BytesToAddress(word.Bytes()))
+	}
+
+	for _, account := range append(snapshot.Validators, accounts...) {
+		if _, ok := seen[account]; ok {
+			continue
+		}
+
+		seen[account] = struct{}{}
+		snapshot.Accounts = append(snapshot.Accounts, account)
+
+		withdrawal := layout.Var("_withdrawals").Key(account)
+
+		for _, ref := range []*slots.Ref{
+			layout.Var("_addressToIsValidator").Key(account),
+			layout.Var("_addressToStakedAmount").Key(account),
+			layout.Var("_addressToValidatorIndex").Key(account),
+			layout.Var("_addressToOwner").Key(account),
+			layout.Var("_addressToAutoCompound").Key(account),
+			layout.Var("_addressToCompoundedRewards").Key(account),
+			withdrawal.Field("amount"),
+			withdrawal.Field("releaseEpoch"),
+		} {
+			if _, err := copyRef(ref); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := copyBytes(layout.Var("_addressToBLSPublicKey").Key(account)); err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshot, nil
+}
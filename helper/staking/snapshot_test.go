@@ -0,0 +1,111 @@
+package staking
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/slots"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nonZeroSlots returns the slots of the storage which aren't empty
+func nonZeroSlots(storage map[types.Hash]types.Hash) map[types.Hash]types.Hash {
+	res := make(map[types.Hash]types.Hash)
+
+	for slot, value := range storage {
+		if value != types.ZeroHash {
+			res[slot] = value
+		}
+	}
+
+	return res
+}
+
+func TestSnapshotStakingSC(t *testing.T) {
+	t.Parallel()
+
+	var (
+		validator1 = types.StringToAddress("1")
+		validator2 = types.StringToAddress("2")
+		staker     = types.StringToAddress("3")
+		owner      = types.StringToAddress("4")
+	)
+
+	layout, err := slots.ParseLayout(storageLayout)
+	require.NoError(t, err)
+
+	account, err := PredeployStakingSC(
+		validators.NewBLSValidatorSet(
+			validators.NewBLSValidator(validator1, bytes.Repeat([]byte{0x1}, 48)),
+			validators.NewBLSValidator(validator2, bytes.Repeat([]byte{0x2}, 48)),
+		),
+		PredeployParams{
+			MinValidatorCount: 1,
+			MaxValidatorCount: 10,
+			UnbondingPeriod:   2,
+			EpochSize:         10,
+			MaxValidatorExits: 1,
+			MinSelfStake:      big.NewInt(100),
+			MaxValidatorStake: big.NewInt(1000),
+			Owners:            map[types.Address]types.Address{validator1: owner},
+		},
+	)
+	require.NoError(t, err)
+
+	ownerSlot, err := layout.Var("_addressToOwner").Key(validator1).Slot()
+	require.NoError(t, err)
+	assert.Equal(t, types.BytesToHash(owner.Bytes()), account.Storage[ownerSlot])
+
+	// a staker below the validator threshold, with a pending withdrawal
+
+	require.NoError(t, layout.SetValues(account.Storage, map[string]interface{}{
+		"_addressToStakedAmount": map[string]interface{}{staker.String(): "100"},
+		"_withdrawals": map[string]interface{}{
+			staker.String(): map[string]interface{}{"amount": "50", "releaseEpoch": "3"},
+		},
+	}))
+
+	read := func(slot types.Hash) (types.Hash, error) {
+		return account.Storage[slot], nil
+	}
+
+	maxExits, err := ReadMaxValidatorExits(read)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), maxExits)
+
+	limits, err := ReadStakeLimits(read)
+	require.NoError(t, err)
+	assert.Equal(t, &StakeLimits{MinSelfStake: big.NewInt(100), MaxValidatorStake: big.NewInt(1000)}, limits)
+
+	snapshot, err := SnapshotStakingSC(read, []types.Address{staker, validator1})
+	require.NoError(t, err)
+	assert.Equal(t, nonZeroSlots(account.Storage), snapshot.Storage)
+	assert.Equal(t, []types.Address{validator1, validator2}, snapshot.Validators)
+	assert.Equal(t, []types.Address{validator1, validator2, staker}, snapshot.Accounts)
+
+	// the accounts which aren't given are missed
+	snapshot, err = SnapshotStakingSC(read, nil)
+	require.NoError(t, err)
+
+	slot, err := layout.Var("_addressToStakedAmount").Key(staker).Slot()
+	require.NoError(t, err)
+	assert.NotContains(t, snapshot.Storage, slot)
+	assert.Len(t, snapshot.Storage, len(nonZeroSlots(account.Storage))-3)
+
+	// the validator count is bounded
+	count, err := layout.Var("_validators").Slot()
+	require.NoError(t, err)
+
+	_, err = SnapshotStakingSC(func(slot types.Hash) (types.Hash, error) {
+		if slot == count {
+			return types.BytesToHash(new(big.Int).Lsh(big.NewInt(1), 70).Bytes()), nil
+		}
+
+		return types.ZeroHash, nil
+	}, nil)
+	assert.ErrorIs(t, err, errInvalidValidatorCount)
+}
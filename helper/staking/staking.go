@@ -1,13 +1,15 @@
 package staking
 
 import (
+	_ "embed"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
-	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/helper/slots"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 )
@@ -15,152 +17,53 @@ import (
 var (
 	MinValidatorCount = uint64(1)
 	MaxValidatorCount = common.MaxSafeJSInt
-)
-
-// getAddressMapping returns the key for the SC storage mapping (address => something)
-//
-// More information:
-// https://docs.soliditylang.org/en/latest/internals/layout_in_storage.html
-func getAddressMapping(address types.Address, slot int64) []byte {
-	bigSlot := big.NewInt(slot)
-
-	finalSlice := append(
-		common.PadLeftOrTrim(address.Bytes(), 32),
-		common.PadLeftOrTrim(bigSlot.Bytes(), 32)...,
-	)
-
-	return keccak.Keccak256(nil, finalSlice)
-}
-
-// getIndexWithOffset is a helper method for adding an offset to the already found keccak hash
-func getIndexWithOffset(keccakHash []byte, offset uint64) []byte {
-	bigOffset := big.NewInt(int64(offset))
-	bigKeccak := big.NewInt(0).SetBytes(keccakHash)
-
-	bigKeccak.Add(bigKeccak, bigOffset)
-
-	return bigKeccak.Bytes()
-}
-
-// getStorageIndexes is a helper function for getting the correct indexes
-// of the storage slots which need to be modified during bootstrap.
-//
-// It is SC dependant, and based on the SC located at:
-// https://github.com/0xPolygon/staking-contracts/
-func getStorageIndexes(validator validators.Validator, index int) *StorageIndexes {
-	storageIndexes := &StorageIndexes{}
-	address := validator.Addr()
-
-	// Get the indexes for the mappings
-	// The index for the mapping is retrieved with:
-	// keccak(address . slot)
-	// . stands for concatenation (basically appending the bytes)
-	storageIndexes.AddressToIsValidatorIndex = getAddressMapping(
-		address,
-		addressToIsValidatorSlot,
-	)
-
-	storageIndexes.AddressToStakedAmountIndex = getAddressMapping(
-		address,
-		addressToStakedAmountSlot,
-	)
-
-	storageIndexes.AddressToValidatorIndexIndex = getAddressMapping(
-		address,
-		addressToValidatorIndexSlot,
-	)
-
-	storageIndexes.ValidatorBLSPublicKeyIndex = getAddressMapping(
-		address,
-		addressToBLSPublicKeySlot,
-	)
-
-	// Index for array types is calculated as keccak(slot) + index
-	// The slot for the dynamic arrays that's put in the keccak needs to be in hex form (padded 64 chars)
-	storageIndexes.ValidatorsIndex = getIndexWithOffset(
-		keccak.Keccak256(nil, common.PadLeftOrTrim(big.NewInt(validatorsSlot).Bytes(), 32)),
-		uint64(index),
-	)
-
-	return storageIndexes
-}
-
-// setBytesToStorage sets bytes data into storage map from specified base index
-func setBytesToStorage(
-	storageMap map[types.Hash]types.Hash,
-	baseIndexBytes []byte,
-	data []byte,
-) {
-	dataLen := len(data)
-	baseIndex := types.BytesToHash(baseIndexBytes)
-
-	if dataLen <= 31 {
-		bytes := types.Hash{}
-
-		copy(bytes[:len(data)], data)
-
-		// Set 2*Size at the first byte
-		bytes[len(bytes)-1] = byte(dataLen * 2)
-
-		storageMap[baseIndex] = bytes
-
-		return
-	}
 
-	// Set size at the base index
-	baseSlot := types.Hash{}
-	baseSlot[31] = byte(2*dataLen + 1)
-	storageMap[baseIndex] = baseSlot
+	// DefaultUnbondingPeriod is the number of epochs unstaked funds stay locked
+	DefaultUnbondingPeriod = uint64(0)
 
-	zeroIndex := keccak.Keccak256(nil, baseIndexBytes)
-	numBytesInSlot := 256 / 8
+	// DefaultMaxValidatorExits is the number of validators which can leave the validator set per epoch,
+	// zero for no limit
+	DefaultMaxValidatorExits = uint64(0)
 
-	for i := 0; i < dataLen; i++ {
-		offset := i / numBytesInSlot
-
-		slotIndex := types.BytesToHash(getIndexWithOffset(zeroIndex, uint64(offset)))
-		byteIndex := i % numBytesInSlot
-
-		slot := storageMap[slotIndex]
-		slot[byteIndex] = data[i]
-
-		storageMap[slotIndex] = slot
-	}
-}
+	errInvalidStakeLimits = errors.New("the minimum self-stake exceeds the maximum validator stake")
+)
 
 // PredeployParams contains the values used to predeploy the PoS staking contract
 type PredeployParams struct {
 	MinValidatorCount uint64
 	MaxValidatorCount uint64
-}
+	UnbondingPeriod   uint64
+	EpochSize         uint64
+	MaxValidatorExits uint64
+
+	// MinSelfStake is the minimum stake of a validator, the genesis validators are staked with it.
+	// MaxValidatorStake caps the stake of a validator. Both are optional
+	MinSelfStake      *big.Int
+	MaxValidatorStake *big.Int
 
-// StorageIndexes is a wrapper for different storage indexes that
-// need to be modified
-type StorageIndexes struct {
-	ValidatorsIndex              []byte // []address
-	ValidatorBLSPublicKeyIndex   []byte // mapping(address => byte[])
-	AddressToIsValidatorIndex    []byte // mapping(address => bool)
-	AddressToStakedAmountIndex   []byte // mapping(address => uint256)
-	AddressToValidatorIndexIndex []byte // mapping(address => uint256)
+	// Owners are the owners of the validators, controlling their stake apart from the validator keys
+	Owners map[types.Address]types.Address
 }
 
-// Slot definitions for SC storage
-var (
-	validatorsSlot              = int64(0) // Slot 0
-	addressToIsValidatorSlot    = int64(1) // Slot 1
-	addressToStakedAmountSlot   = int64(2) // Slot 2
-	addressToValidatorIndexSlot = int64(3) // Slot 3
-	stakedAmountSlot            = int64(4) // Slot 4
-	minNumValidatorSlot         = int64(5) // Slot 5
-	maxNumValidatorSlot         = int64(6) // Slot 6
-	addressToBLSPublicKeySlot   = int64(7) // Slot 7
-)
+// storageLayout is the storage layout of the staking SC, in the format of solc --storage-layout.
+// It's maintained with StakingSCBytecode, which has no Solidity source to generate it from
+//
+//go:embed layout.json
+var storageLayout []byte
 
 const (
 	DefaultStakedBalance = "0x0" // 0 ETH
-	//nolint: lll
-	StakingSCBytecode = "0x6080604052600436106101185760003560e01c80637a6eea37116100a0578063d94c111b11610064578063d94c111b1461040a578063e387a7ed14610433578063e804fbf61461045e578063f90ecacc14610489578063facd743b146104c657610186565b80637a6eea37146103215780637dceceb81461034c578063af6da36e14610389578063c795c077146103b4578063ca1e7819146103df57610186565b8063373d6132116100e7578063373d6132146102595780633a4b66f1146102845780633c561f041461028e57806351a9ab32146102b9578063714ff425146102f657610186565b806302b751991461018b578063065ae171146101c85780632367f6b5146102055780632def66201461024257610186565b366101865761013c3373ffffffffffffffffffffffffffffffffffffffff16610503565b1561017c576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610173906117ec565b60405180910390fd5b610184610526565b005b600080fd5b34801561019757600080fd5b506101b260048036038101906101ad91906113e2565b6105fd565b6040516101bf9190611847565b60405180910390f35b3480156101d457600080fd5b506101ef60048036038101906101ea91906113e2565b610615565b6040516101fc919061174f565b60405180910390f35b34801561021157600080fd5b5061022c600480360381019061022791906113e2565b610635565b6040516102399190611847565b60405180910390f35b34801561024e57600080fd5b5061025761067e565b005b34801561026557600080fd5b5061026e610769565b60405161027b9190611847565b60405180910390f35b61028c610773565b005b34801561029a57600080fd5b506102a36107dc565b6040516102b0919061172d565b60405180910390f35b3480156102c557600080fd5b506102e060048036038101906102db91906113e2565b610982565b6040516102ed919061176a565b60405180910390f35b34801561030257600080fd5b5061030b610a22565b6040516103189190611847565b60405180910390f35b34801561032d57600080fd5b50610336610a2c565b604051610343919061182c565b60405180910390f35b34801561035857600080fd5b50610373600480360381019061036e91906113e2565b610a3a565b6040516103809190611847565b60405180910390f35b34801561039557600080fd5b5061039e610a52565b6040516103ab9190611847565b60405180910390f35b3480156103c057600080fd5b506103c9610a58565b6040516103d69190611847565b60405180910390f35b3480156103eb57600080fd5b506103f4610a5e565b604051610401919061170b565b60405180910390f35b34801561041657600080fd5b50610431600480360381019061042c919061140f565b610aec565b005b34801561043f57600080fd5b50610448610b91565b6040516104559190611847565b60405180910390f35b34801561046a57600080fd5b50610473610b97565b6040516104809190611847565b60405180910390f35b34801561049557600080fd5b506104b060048036038101906104ab9190611458565b610ba1565b6040516104bd91906116f0565b60405180910390f35b3480156104d257600080fd5b506104ed60048036038101906104e891906113e2565b610be0565b6040516104fa919061174f565b60405180910390f35b6000808273ffffffffffffffffffffffffffffffffffffffff163b119050919050565b34600460008282546105389190611968565b9250508190555034600260003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600082825461058e9190611968565b9250508190555061059e33610c36565b156105ad576105ac33610cb0565b5b3373ffffffffffffffffffffffffffffffffffffffff167f9e71bc8eea02a63969f509818f2dafb9254532904319f9dbda79b67bd34a5f3d346040516105f39190611847565b60405180910390a2565b60036020528060005260406000206000915090505481565b60016020528060005260406000206000915054906101000a900460ff1681565b6000600260008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020549050919050565b61069d3373ffffffffffffffffffffffffffffffffffffffff16610503565b156106dd576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016106d4906117ec565b60405180910390fd5b6000600260003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020541161075f576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016107569061178c565b60405180910390fd5b610767610dff565b565b6000600454905090565b6107923373ffffffffffffffffffffffffffffffffffffffff16610503565b156107d2576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016107c9906117ec565b60405180910390fd5b6107da610526565b565b60606000808054905067ffffffffffffffff8111156107fe576107fd611c00565b5b60405190808252806020026020018201604052801561083157816020015b606081526020019060019003908161081c5790505b50905060005b60008054905081101561097a576007600080838154811061085b5761085a611bd1565b5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002080546108cb90611a98565b80601f01602080910402602001604051908101604052809291908181526020018280546108f790611a98565b80156109445780601f1061091957610100808354040283529160200191610944565b820191906000526020600020905b81548152906001019060200180831161092757829003601f168201915b505050505082828151811061095c5761095b611bd1565b5b6020026020010181905250808061097290611afb565b915050610837565b508091505090565b600760205280600052604060002060009150905080546109a190611a98565b80601f01602080910402602001604051908101604052809291908181526020018280546109cd90611a98565b8015610a1a5780601f106109ef57610100808354040283529160200191610a1a565b820191906000526020600020905b8154815290600101906020018083116109fd57829003601f168201915b505050505081565b6000600554905090565b69054b40b1f852bda0000081565b60026020528060005260406000206000915090505481565b60065481565b60055481565b60606000805480602002602001604051908101604052809291908181526020018280548015610ae257602002820191906000526020600020905b8160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019060010190808311610a98575b5050505050905090565b80600760003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000209080519060200190610b3f9291906112a5565b503373ffffffffffffffffffffffffffffffffffffffff167f472da4d064218fa97032725fbcff922201fa643fed0765b5ffe0ceef63d7b3dc82604051610b86919061176a565b60405180910390a250565b60045481565b6000600654905090565b60008181548110610bb157600080fd5b906000526020600020016000915054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b6000600160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff169050919050565b6000610c4182610f51565b158015610ca9575069054b40b1f852bda000006fffffffffffffffffffffffffffffffff16600260008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205410155b9050919050565b60065460008054905010610cf9576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610cf0906117ac565b60405180910390fd5b60018060008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060006101000a81548160ff021916908315150217905550600080549050600360008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055506000819080600181540180825580915050600190039060005260206000200160009091909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555050565b6000600260003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205490506000600260003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055508060046000828254610e9a91906119be565b92505081905550610eaa33610f51565b15610eb957610eb833610fa7565b5b3373ffffffffffffffffffffffffffffffffffffffff166108fc829081150290604051600060405180830381858888f19350505050158015610eff573d6000803e3d6000fd5b503373ffffffffffffffffffffffffffffffffffffffff167f0f5bb82176feb1b5e747e28471aa92156a04d9f3ab9f45f28e2d704232b93f7582604051610f469190611847565b60405180910390a250565b6000600160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff169050919050565b60055460008054905011610ff0576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610fe79061180c565b60405180910390fd5b600080549050600360008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205410611076576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161106d906117cc565b60405180910390fd5b6000600360008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020549050600060016000805490506110ce91906119be565b90508082146111bc5760008082815481106110ec576110eb611bd1565b5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff169050806000848154811061112e5761112d611bd1565b5b9060005260206000200160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555082600360008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002081905550505b6000600160008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060006101000a81548160ff0219169083151502179055506000600360008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002081905550600080548061126b5761126a611ba2565b5b6001900381819060005260206000200160006101000a81549073ffffffffffffffffffffffffffffffffffffffff02191690559055505050565b8280546112b190611a98565b90600052602060002090601f0160209004810192826112d3576000855561131a565b82601f106112ec57805160ff191683800117855561131a565b8280016001018555821561131a579182015b828111156113195782518255916020019190600101906112fe565b5b509050611327919061132b565b5090565b5b8082111561134457600081600090555060010161132c565b5090565b600061135b61135684611887565b611862565b90508281526020810184848401111561137757611376611c34565b5b611382848285611a56565b509392505050565b60008135905061139981611d6d565b92915050565b600082601f8301126113b4576113b3611c2f565b5b81356113c4848260208601611348565b91505092915050565b6000813590506113dc81611d84565b92915050565b6000602082840312156113f8576113f7611c3e565b5b60006114068482850161138a565b91505092915050565b60006020828403121561142557611424611c3e565b5b600082013567ffffffffffffffff81111561144357611442611c39565b5b61144f8482850161139f565b91505092915050565b60006020828403121561146e5761146d611c3e565b5b600061147c848285016113cd565b91505092915050565b600061149183836114b1565b60208301905092915050565b60006114a983836115b1565b905092915050565b6114ba816119f2565b82525050565b6114c9816119f2565b82525050565b60006114da826118d8565b6114e48185611913565b93506114ef836118b8565b8060005b838110156115205781516115078882611485565b9750611512836118f9565b9250506001810190506114f3565b5085935050505092915050565b6000611538826118e3565b6115428185611924565b935083602082028501611554856118c8565b8060005b858110156115905784840389528151611571858261149d565b945061157c83611906565b925060208a01995050600181019050611558565b50829750879550505050505092915050565b6115ab81611a04565b82525050565b60006115bc826118ee565b6115c68185611935565b93506115d6818560208601611a65565b6115df81611c43565b840191505092915050565b60006115f5826118ee565b6115ff8185611946565b935061160f818560208601611a65565b61161881611c43565b840191505092915050565b6000611630601d83611957565b915061163b82611c54565b602082019050919050565b6000611653602783611957565b915061165e82611c7d565b604082019050919050565b6000611676601283611957565b915061168182611ccc565b602082019050919050565b6000611699601a83611957565b91506116a482611cf5565b602082019050919050565b60006116bc604083611957565b91506116c782611d1e565b604082019050919050565b6116db81611a10565b82525050565b6116ea81611a4c565b82525050565b600060208201905061170560008301846114c0565b92915050565b6000602082019050818103600083015261172581846114cf565b905092915050565b60006020820190508181036000830152611747818461152d565b905092915050565b600060208201905061176460008301846115a2565b92915050565b6000602082019050818103600083015261178481846115ea565b905092915050565b600060208201905081810360008301526117a581611623565b9050919050565b600060208201905081810360008301526117c581611646565b9050919050565b600060208201905081810360008301526117e581611669565b9050919050565b600060208201905081810360008301526118058161168c565b9050919050565b60006020820190508181036000830152611825816116af565b9050919050565b600060208201905061184160008301846116d2565b92915050565b600060208201905061185c60008301846116e1565b92915050565b600061186c61187d565b90506118788282611aca565b919050565b6000604051905090565b600067ffffffffffffffff8211156118a2576118a1611c00565b5b6118ab82611c43565b9050602081019050919050565b6000819050602082019050919050565b6000819050602082019050919050565b600081519050919050565b600081519050919050565b600081519050919050565b6000602082019050919050565b6000602082019050919050565b600082825260208201905092915050565b600082825260208201905092915050565b600082825260208201905092915050565b600082825260208201905092915050565b600082825260208201905092915050565b600061197382611a4c565b915061197e83611a4c565b9250827fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff038211156119b3576119b2611b44565b5b828201905092915050565b60006119c982611a4c565b91506119d483611a4c565b9250828210156119e7576119e6611b44565b5b828203905092915050565b60006119fd82611a2c565b9050919050565b60008115159050919050565b60006fffffffffffffffffffffffffffffffff82169050919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000819050919050565b82818337600083830152505050565b60005b83811015611a83578082015181840152602081019050611a68565b83811115611a92576000848401525b50505050565b60006002820490506001821680611ab057607f821691505b60208210811415611ac457611ac3611b73565b5b50919050565b611ad382611c43565b810181811067ffffffffffffffff82111715611af257611af1611c00565b5b80604052505050565b6000611b0682611a4c565b91507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff821415611b3957611b38611b44565b5b600182019050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603160045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b600080fd5b600080fd5b600080fd5b600080fd5b6000601f19601f8301169050919050565b7f4f6e6c79207374616b65722063616e2063616c6c2066756e6374696f6e000000600082015250565b7f56616c696461746f72207365742068617320726561636865642066756c6c206360008201527f6170616369747900000000000000000000000000000000000000000000000000602082015250565b7f696e646578206f7574206f662072616e67650000000000000000000000000000600082015250565b7f4f6e6c7920454f412063616e2063616c6c2066756e6374696f6e000000000000600082015250565b7f56616c696461746f72732063616e2774206265206c657373207468616e20746860008201527f65206d696e696d756d2072657175697265642076616c696461746f72206e756d602082015250565b611d76816119f2565b8114611d8157600080fd5b50565b611d8d81611a4c565b8114611d9857600080fd5b5056fea26469706673582212203d21b6639ac0e2f7ad3b2028a115c8d88f41b534d866e20e10685a8450f2c5bf64736f6c63430008070033"
 
+	// StakingSCBytecode is the runtime code of the staking SC. The bytecode is the source of truth
+	// of the contract, it's maintained by hand and isn't compiled from Solidity.
+	// It extends https://github.com/0xPolygon/staking-contracts with the unbonding of the unstaked funds,
+	// the owners controlling the stake of the validators, the limit of the validator exits per epoch,
+	// the limits of the stake of a validator and the auto-compounding of the rewards restaked by the system.
+	// Its interface is contracts/staking/abi.json and its storage layout is layout.json,
+	// a change of the code must keep them and the staking tests in sync
+	//
+	//nolint: lll
+	StakingSCBytecode = "0x361561097a5760043610610f065760003560e01c80637a6eea371461018357806351a9ab321461019a578063065ae171146101f35780637dceceb81461022857806302b751991461025d578063af6da36e14610292578063c795c077146102a3578063e387a7ed146102b4578063f90ecacc146102c55780632367f6b5146102fc578063facd743b14610331578063e804fbf614610366578063714ff42514610377578063d94c111b146103885780633a4b66f1146104dd578063373d6132146104e85780632def6620146104f95780633c561f041461050d578063ca1e7819146105be5780630964c95b146106205780636cf6d675146106655780633ccfd60b146106765780631220c6ed1461068a5780632cbb26191461070b5780632944aedc146107315780639eca672c14610766578063fb1486571461078c578063d92245df1461079d5780638968f572146107ae5780633ee84f44146107bf578063412b12f6146107f45780631df6e83e1461089e578063601c2669146108d357610f06565b34610f0657670de0b6b3a764000060005260206000f35b34610f065760243610610f06576004358060a01c610f065760c05260206104005260c051600052600760205260406000206101c0526104206101e0526101de610e44565b60206020601f61020051010402604001610400f35b34610f065760243610610f06576004358060a01c610f065760c05260c051600052600160205260406000205460005260206000f35b34610f065760243610610f06576004358060a01c610f065760c05260c051600052600260205260406000205460005260206000f35b34610f065760243610610f06576004358060a01c610f065760c05260c051600052600360205260406000205460005260206000f35b34610f065760065460005260206000f35b34610f065760055460005260206000f35b34610f065760045460005260206000f35b34610f065760243610610f065760043560c05260005460c05110156115305760c05160006000526020600020015460005260206000f35b34610f065760243610610f06576004358060a01c610f065760c05260c051600052600260205260406000205460005260206000f35b34610f065760243610610f06576004358060a01c610f065760c05260c051600052600160205260406000205460005260206000f35b34610f065760065460005260206000f35b34610f065760055460005260206000f35b34610f065760243610610f065760043560c0526801000000000000000060c0511015610f0657602360c05101361115610f0657600460c051013560e0526801000000000000000060e0511015610f06573660e051602460c0510101111515610f065760e051602460c05101610400373360005260076020526040600020610100526000610120526101005154610140526001610140511615610436576020601f6101405160011c0104610120525b61010051600052602060002061014052602060e051101561046b57600260e0510261040051176101005155600060c0526104b4565b6001600260e05102016101005155600060c0525b6020601f60e051010460c05110156104b357602060c05102610400015160c051610140510155600160c0510160c05261047f565b5b5b6101205160c05110156104db57600060c051610140510155600160c0510160c0526104b5565b005b333b610f0c57610981565b34610f065760045460005260206000f35b34610f0657333b610f0c5733608052610a9d565b34610f0657602061040052600054610420526000600052602060002060c052600060e0526020610420510261044001610100525b6104205160e05110156105b1576104406101005103602060e05102610440015260e05160c0510154600052600760205260406000206101c052610100516101e05261058a610e44565b60206020601f61020051010402602001610100510161010052600160e0510160e052610541565b6104006101005103610400f35b34610f0657602061040052600054610420526000600052602060002060c052600060e0525b6104205160e05110156106115760e05160c0510154602060e051026104400152600160e0510160e0526105e3565b60206104205102604001610400f35b34610f065760243610610f06576004358060a01c610f065760c05260c051600052600a602052604060002060e05260e05154600052600160e051015460205260406000f35b34610f065760085460005260206000f35b34610f0657333b610f0c5733608052610d52565b34610f065760243610610f06576004358060a01c610f065760c052333b610f0c573360c051141560c05115151615610f645733600052600b602052604060002060e05260e051541515610fbc5760c05160e0515560c051337fa5e1f8b4009110f5525798d04ae2125421a12d0590aa52c13682ff1bd3c492ca6000610260a3005b34610f065760243610610f06576004358060a01c610f0657608052333b610f0c57610a9d565b34610f065760243610610f06576004358060a01c610f065760c05260c051600052600b60205260406000205460005260206000f35b34610f065760243610610f06576004358060a01c610f0657608052333b610f0c57610d52565b34610f0657600c5460005260206000f35b34610f0657600e5460005260206000f35b34610f0657600d5460005260206000f35b34610f065760243610610f06576004358060a01c610f065760c05260c051600052601060205260406000205460005260206000f35b60243610610f06576004358060a01c610f065760805273fffffffffffffffffffffffffffffffffffffffe3314156110145760805160005260106020526040600020541561109057608051600052601160205260406000205434810180821161155f579050608051600052601160205260406000205534610260526080517f8ce0a2fad3764c1fd039c26f784a2d7490f96db7928a834db2330abc1efd29446020610260a2610986565b34610f065760243610610f06576004358060a01c610f065760c05260c051600052601160205260406000205460005260206000f35b34610f065760443610610f06576004358060a01c610f065760805260243560e052600260e0511015610f0657333b610f0c57608051600052600b60205260406000205460c05260c05115156109295760805160c0525b3360c05114156110e85760e051608051600052601060205260406000205560e051610260526080517f340976c63b0d2b8167206dc5fd284bd24d2b0526ac89867a32450568b6e6d9866020610260a2005b333b610f0c575b336080525b608051600052600260205260406000205434810180821161155f57905060a052600d5460a05110151561114057600e5460c05260c05160a051111560c0511517156111bc5760045434810180821161155f57905060045560a0516080516000526002602052604060002055670de0b6b3a764000060a05110156080516000526001602052604060002054151615610a6c5760005460c05260065460c0511015611238576001608051600052600160205260406000205560c051608051600052600360205260406000205560805160c051600060005260206000200155600160c051016000555b34610260526080517f9e71bc8eea02a63969f509818f2dafb9254532904319f9dbda79b67bd34a5f3d6020610260a2005b608051600052600b60205260406000205460c05260c0511515610ac15760805160c0525b3360c05114156110e857608051600052600260205260406000205460a05260a051156112b4576000608051600052600260205260406000205560045460a05181811161155f579003600455608051600052600160205260406000205415610c2b57600c5460c05260c05115610b6d57610b38610e22565b6101a051600052600f602052604060002060e05260e051546101005260c05161010051101561130c576001610100510160e051555b60005460c05260055460c051111561138857608051600052600360205260406000205460e05260c05160e051101561140457600160c0510360c052600060005260206000206101005260c05160e051141515610bf35760c051610100510154610120526101205160e05161010051015560e0516101205160005260036020526040600020555b6000608051600052600160205260406000205560006080516000526003602052604060002055600060c05161010051015560c0516000555b60085415610cfa57610c3b610e22565b608051600052600a602052604060002060c05260c0515460a051810180821161155f57905060e0526101a051600854810180821161155f5790506101005260e05160c0515561010051600160c051015560a051610260526080517f0f5bb82176feb1b5e747e28471aa92156a04d9f3ab9f45f28e2d704232b93f756020610260a260e0516102605261010051610280526080517f24b91f4f47caf44230a57777a9be744924e82bf666f2d5702faf97df35e60f9f6040610260a2610d50565b600060006000600060a0513360a051156108fc02f1610d1e573d600060003e3d6000fd5b60a051610260526080517f0f5bb82176feb1b5e747e28471aa92156a04d9f3ab9f45f28e2d704232b93f756020610260a25b005b608051600052600b60205260406000205460c05260c0511515610d765760805160c0525b3360c05114156110e857608051600052600a602052604060002060c05260c0515460a05260a0511561145c57610daa610e22565b600160c05101546101a0511015156114b457600060c051556000600160c0510155600060006000600060a0513360a051156108fc02f1610def573d600060003e3d6000fd5b60a051610260526080517f7084f5476618d8e60b11ef0d7d3f06914655adb8793e28ff7f018d4c76d505d56020610260a2005b436101a052600954610140526101405115610e42576101405143046101a0525b565b6101c05154610240526001610240511615610ebe576102405160011c610200526101c0516000526020600020610240526000610220525b6020601f610200510104610220511015610eb95761022051610240510154602061022051026020016101e05101526001610220510161022052610e7b565b610efb565b60ff610240511660011c610200527fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff00610240511660206101e05101525b610200516101e05152565b60006000fd5b7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452601a6024527f4f6e6c7920454f412063616e2063616c6c2066756e6374696f6e00000000000060445260646000fd5b7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452600d6024527f496e76616c6964206f776e65720000000000000000000000000000000000000060445260646000fd5b7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452601b6024527f4f776e657220697320616c72656164792072656769737465726564000000000060445260646000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260216024527f4f6e6c79207468652073797374656d2063616e2063616c6c2066756e6374696f6044527f6e0000000000000000000000000000000000000000000000000000000000000060645260846000fd5b7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452601f6024527f4175746f2d636f6d706f756e64696e67206973206e6f7420656e61626c65640060445260646000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260206024527f4f6e6c7920746865206f776e65722063616e2063616c6c2066756e6374696f6e60445260646000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260256024527f5374616b652069732062656c6f7720746865206d696e696d756d2073656c662d6044527f7374616b6500000000000000000000000000000000000000000000000000000060645260846000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260296024527f5374616b65206578636565647320746865206d6178696d756d2076616c6964616044527f746f72207374616b65000000000000000000000000000000000000000000000060645260846000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260276024527f56616c696461746f72207365742068617320726561636865642066756c6c20636044527f617061636974790000000000000000000000000000000000000000000000000060645260846000fd5b7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452601d6024527f4f6e6c79207374616b65722063616e2063616c6c2066756e6374696f6e00000060445260646000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260336024527f56616c696461746f7220657869747320686176652072656163686564207468656044527f206c696d6974206f66207468652065706f63680000000000000000000000000060645260846000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260406024527f56616c696461746f72732063616e2774206265206c657373207468616e2074686044527f65206d696e696d756d2072657175697265642076616c696461746f72206e756d60645260846000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260126024527f696e646578206f7574206f662072616e6765000000000000000000000000000060445260646000fd5b7f08c379a000000000000000000000000000000000000000000000000000000000600052602060045260156024527f4e6f2070656e64696e67207769746864726177616c000000000000000000000060445260646000fd5b7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452602b6024527f5769746864726177616c206973207374696c6c20696e2074686520756e626f6e6044527f64696e6720706572696f6400000000000000000000000000000000000000000060645260846000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd"
 )
 
 // PredeployStakingSC is a helper method for setting up the staking smart contract account,
@@ -170,7 +73,6 @@ func PredeployStakingSC(
 	params PredeployParams,
 ) (*chain.GenesisAccount, error) {
 	// Set the code for the staking smart contract
-	// Code retrieved from https://github.com/0xPolygon/staking-contracts
 	scHex, _ := hex.DecodeHex(StakingSCBytecode)
 	stakingAccount := &chain.GenesisAccount{
 		Code: scHex,
@@ -184,69 +86,75 @@ func PredeployStakingSC(
 		return nil, fmt.Errorf("unable to generate DefaultStatkedBalance, %w", err)
 	}
 
-	// Generate the empty account storage map
-	storageMap := make(map[types.Hash]types.Hash)
-	bigTrueValue := big.NewInt(1)
-	stakedAmount := big.NewInt(0)
-	bigMinNumValidators := big.NewInt(int64(params.MinValidatorCount))
-	bigMaxNumValidators := big.NewInt(int64(params.MaxValidatorCount))
-	valsLen := big.NewInt(0)
+	limits := newStakeLimits(params.MinSelfStake, params.MaxValidatorStake)
 
-	if vals != nil {
-		valsLen = big.NewInt(int64(vals.Len()))
+	// The genesis validators hold the minimum self-stake, so that they stay in the validator set
+	if bigDefaultStakedBalance.Cmp(limits.MinSelfStake) < 0 {
+		bigDefaultStakedBalance = limits.MinSelfStake
+	}
 
+	if !limits.Allows(bigDefaultStakedBalance) {
+		return nil, fmt.Errorf("%w: %s", errInvalidStakeLimits, limits)
+	}
+
+	var (
+		stakedAmount     = big.NewInt(0)
+		validatorsArray  = []interface{}{}
+		isValidator      = map[string]interface{}{}
+		stakedAmounts    = map[string]interface{}{}
+		validatorIndexes = map[string]interface{}{}
+		blsPublicKeys    = map[string]interface{}{}
+		owners           = map[string]interface{}{}
+	)
+
+	if vals != nil {
 		for idx := 0; idx < vals.Len(); idx++ {
 			validator := vals.At(uint64(idx))
+			address := validator.Addr().String()
 
 			// Update the total staked amount
 			stakedAmount = stakedAmount.Add(stakedAmount, bigDefaultStakedBalance)
 
-			// Get the storage indexes
-			storageIndexes := getStorageIndexes(validator, idx)
-
-			// Set the value for the validators array
-			storageMap[types.BytesToHash(storageIndexes.ValidatorsIndex)] =
-				types.BytesToHash(
-					validator.Addr().Bytes(),
-				)
+			validatorsArray = append(validatorsArray, validator.Addr())
+			isValidator[address] = true
+			stakedAmounts[address] = bigDefaultStakedBalance
+			validatorIndexes[address] = uint64(idx)
 
 			if blsValidator, ok := validator.(*validators.BLSValidator); ok {
-				setBytesToStorage(
-					storageMap,
-					storageIndexes.ValidatorBLSPublicKeyIndex,
-					blsValidator.BLSPublicKey,
-				)
+				blsPublicKeys[address] = []byte(blsValidator.BLSPublicKey)
 			}
-
-			// Set the value for the address -> validator array index mapping
-			storageMap[types.BytesToHash(storageIndexes.AddressToIsValidatorIndex)] =
-				types.BytesToHash(bigTrueValue.Bytes())
-
-			// Set the value for the address -> staked amount mapping
-			storageMap[types.BytesToHash(storageIndexes.AddressToStakedAmountIndex)] =
-				types.StringToHash(hex.EncodeBig(bigDefaultStakedBalance))
-
-			// Set the value for the address -> validator index mapping
-			storageMap[types.BytesToHash(storageIndexes.AddressToValidatorIndexIndex)] =
-				types.StringToHash(hex.EncodeUint64(uint64(idx)))
 		}
 	}
 
-	// Set the value for the total staked amount
-	storageMap[types.BytesToHash(big.NewInt(stakedAmountSlot).Bytes())] =
-		types.BytesToHash(stakedAmount.Bytes())
-
-	// Set the value for the size of the validators array
-	storageMap[types.BytesToHash(big.NewInt(validatorsSlot).Bytes())] =
-		types.BytesToHash(valsLen.Bytes())
+	for operator, owner := range params.Owners {
+		owners[operator.String()] = owner
+	}
 
-	// Set the value for the minimum number of validators
-	storageMap[types.BytesToHash(big.NewInt(minNumValidatorSlot).Bytes())] =
-		types.BytesToHash(bigMinNumValidators.Bytes())
+	layout, err := slots.ParseLayout(storageLayout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the storage layout, %w", err)
+	}
 
-	// Set the value for the maximum number of validators
-	storageMap[types.BytesToHash(big.NewInt(maxNumValidatorSlot).Bytes())] =
-		types.BytesToHash(bigMaxNumValidators.Bytes())
+	// The withdrawals are empty at genesis
+	storageMap, err := slots.GenerateStorage(layout, map[string]interface{}{
+		"_validators":              validatorsArray,
+		"_addressToIsValidator":    isValidator,
+		"_addressToStakedAmount":   stakedAmounts,
+		"_addressToValidatorIndex": validatorIndexes,
+		"_addressToBLSPublicKey":   blsPublicKeys,
+		"_addressToOwner":          owners,
+		"_stakedAmount":            stakedAmount,
+		"_minimumNumValidators":    params.MinValidatorCount,
+		"_maximumNumValidators":    params.MaxValidatorCount,
+		"_unbondingPeriod":         params.UnbondingPeriod,
+		"_epochSize":               params.EpochSize,
+		"_maximumValidatorExits":   params.MaxValidatorExits,
+		"_minimumSelfStake":        limits.MinSelfStake,
+		"_maximumValidatorStake":   limits.MaxValidatorStake,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate the storage, %w", err)
+	}
 
 	// Save the storage map
 	stakingAccount.Storage = storageMap
@@ -256,3 +164,132 @@ func PredeployStakingSC(
 
 	return stakingAccount, nil
 }
+
+// ReadMaxValidatorExits reads the number of validators which can leave the validator set per epoch
+// from the storage of the staking contract, zero for no limit
+func ReadMaxValidatorExits(read StorageReader) (uint64, error) {
+	exits, err := readUint256(read, "_maximumValidatorExits")
+	if err != nil {
+		return 0, err
+	}
+
+	if !exits.IsUint64() {
+		return 0, fmt.Errorf("invalid maximum number of validator exits: %s", exits)
+	}
+
+	return exits.Uint64(), nil
+}
+
+// StakeLimits are the limits of the stake of the validators, zero for no limit
+type StakeLimits struct {
+	MinSelfStake      *big.Int
+	MaxValidatorStake *big.Int
+}
+
+func newStakeLimits(minSelfStake, maxValidatorStake *big.Int) *StakeLimits {
+	limits := &StakeLimits{
+		MinSelfStake:      new(big.Int),
+		MaxValidatorStake: new(big.Int),
+	}
+
+	if minSelfStake != nil {
+		limits.MinSelfStake.Set(minSelfStake)
+	}
+
+	if maxValidatorStake != nil {
+		limits.MaxValidatorStake.Set(maxValidatorStake)
+	}
+
+	return limits
+}
+
+// IsEmpty returns true if no stake is limited
+func (l *StakeLimits) IsEmpty() bool {
+	return l.MinSelfStake.Sign() == 0 && l.MaxValidatorStake.Sign() == 0
+}
+
+// Allows returns true if the stake is within the limits
+func (l *StakeLimits) Allows(stake *big.Int) bool {
+	if stake.Cmp(l.MinSelfStake) < 0 {
+		return false
+	}
+
+	return l.MaxValidatorStake.Sign() == 0 || stake.Cmp(l.MaxValidatorStake) <= 0
+}
+
+func (l *StakeLimits) String() string {
+	return fmt.Sprintf("minimum self-stake %s, maximum validator stake %s", l.MinSelfStake, l.MaxValidatorStake)
+}
+
+// ReadStakeLimits reads the limits of the stake of the validators from the storage of the staking contract
+func ReadStakeLimits(read StorageReader) (*StakeLimits, error) {
+	minSelfStake, err := readUint256(read, "_minimumSelfStake")
+	if err != nil {
+		return nil, err
+	}
+
+	maxValidatorStake, err := readUint256(read, "_maximumValidatorStake")
+	if err != nil {
+		return nil, err
+	}
+
+	return newStakeLimits(minSelfStake, maxValidatorStake), nil
+}
+
+// AutoCompounding is the auto-compounding of the rewards of an account
+type AutoCompounding struct {
+	// Enabled is set if the rewards of the account are restaked instead of paid out
+	Enabled bool
+
+	// Rewards is the sum of the rewards restaked
+	Rewards *big.Int
+}
+
+// ReadAutoCompounding reads the auto-compounding of the rewards of the account
+// from the storage of the staking contract
+func ReadAutoCompounding(read StorageReader, account types.Address) (*AutoCompounding, error) {
+	layout, err := slots.ParseLayout(storageLayout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the storage layout, %w", err)
+	}
+
+	enabled, err := readRef(read, layout.Var("_addressToAutoCompound").Key(account))
+	if err != nil {
+		return nil, err
+	}
+
+	rewards, err := readRef(read, layout.Var("_addressToCompoundedRewards").Key(account))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AutoCompounding{
+		Enabled: enabled.Sign() != 0,
+		Rewards: rewards,
+	}, nil
+}
+
+// readUint256 reads the uint256 variable of the staking contract
+func readUint256(read StorageReader, label string) (*big.Int, error) {
+	layout, err := slots.ParseLayout(storageLayout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the storage layout, %w", err)
+	}
+
+	return readRef(read, layout.Var(label))
+}
+
+// readRef reads the value type of the staking contract as an unsigned integer
+func readRef(read StorageReader, ref *slots.Ref) (*big.Int, error) {
+	slot, err := ref.Slot()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := read(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(value.Bytes()), nil
+}
@@ -0,0 +1,101 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/slots"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStakeLimitsAllows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		limits   *StakeLimits
+		stake    int64
+		expected bool
+	}{
+		{
+			name:     "should allow any stake without limits",
+			limits:   newStakeLimits(nil, nil),
+			stake:    0,
+			expected: true,
+		},
+		{
+			name:     "should not allow the stake below the minimum self-stake",
+			limits:   newStakeLimits(big.NewInt(10), nil),
+			stake:    9,
+			expected: false,
+		},
+		{
+			name:     "should allow the minimum self-stake",
+			limits:   newStakeLimits(big.NewInt(10), nil),
+			stake:    10,
+			expected: true,
+		},
+		{
+			name:     "should allow the maximum validator stake",
+			limits:   newStakeLimits(big.NewInt(10), big.NewInt(20)),
+			stake:    20,
+			expected: true,
+		},
+		{
+			name:     "should not allow the stake above the maximum validator stake",
+			limits:   newStakeLimits(big.NewInt(10), big.NewInt(20)),
+			stake:    21,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.limits.Allows(big.NewInt(test.stake)))
+		})
+	}
+}
+
+func TestPredeployStakingSCStakeLimits(t *testing.T) {
+	t.Parallel()
+
+	validator := types.StringToAddress("1")
+	vals := validators.NewECDSAValidatorSet(validators.NewECDSAValidator(validator))
+
+	layout, err := slots.ParseLayout(storageLayout)
+	require.NoError(t, err)
+
+	stakeSlot, err := layout.Var("_addressToStakedAmount").Key(validator).Slot()
+	require.NoError(t, err)
+
+	t.Run("should stake the genesis validators with the minimum self-stake", func(t *testing.T) {
+		t.Parallel()
+
+		account, err := PredeployStakingSC(vals, PredeployParams{
+			MaxValidatorCount: 10,
+			MinSelfStake:      big.NewInt(100),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, types.BytesToHash(big.NewInt(100).Bytes()), account.Storage[stakeSlot])
+		assert.Equal(t, big.NewInt(100), account.Balance)
+	})
+
+	t.Run("should return error if the minimum self-stake exceeds the maximum validator stake", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := PredeployStakingSC(vals, PredeployParams{
+			MaxValidatorCount: 10,
+			MinSelfStake:      big.NewInt(100),
+			MaxValidatorStake: big.NewInt(99),
+		})
+		assert.ErrorIs(t, err, errInvalidStakeLimits)
+	})
+}
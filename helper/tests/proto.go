@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ProtoAPI returns the wire shape of the services, messages and enums declared in the file,
+// keyed by their names relative to the proto package:
+//
+// Service.Method -> (Request) returns (stream Response)
+//
+// Message.field -> 1 repeated message Message.Nested
+//
+// Enum.VALUE -> 0
+func ProtoAPI(fd protoreflect.FileDescriptor) map[string]string {
+	api := map[string]string{}
+	pkg := string(fd.Package()) + "."
+
+	relName := func(d protoreflect.Descriptor) string {
+		return strings.TrimPrefix(string(d.FullName()), pkg)
+	}
+
+	messageName := func(md protoreflect.MessageDescriptor, stream bool) string {
+		if stream {
+			return "stream " + relName(md)
+		}
+
+		return relName(md)
+	}
+
+	for i := 0; i < fd.Services().Len(); i++ {
+		sd := fd.Services().Get(i)
+
+		for j := 0; j < sd.Methods().Len(); j++ {
+			md := sd.Methods().Get(j)
+
+			api[relName(md)] = fmt.Sprintf(
+				"(%s) returns (%s)",
+				messageName(md.Input(), md.IsStreamingClient()),
+				messageName(md.Output(), md.IsStreamingServer()),
+			)
+		}
+	}
+
+	var (
+		addEnum    func(ed protoreflect.EnumDescriptor)
+		addMessage func(md protoreflect.MessageDescriptor)
+	)
+
+	addEnum = func(ed protoreflect.EnumDescriptor) {
+		for i := 0; i < ed.Values().Len(); i++ {
+			vd := ed.Values().Get(i)
+
+			api[relName(ed)+"."+string(vd.Name())] = fmt.Sprintf("%d", vd.Number())
+		}
+	}
+
+	addMessage = func(md protoreflect.MessageDescriptor) {
+		for i := 0; i < md.Fields().Len(); i++ {
+			field := md.Fields().Get(i)
+			typ := field.Kind().String()
+
+			switch field.Kind() {
+			case protoreflect.MessageKind, protoreflect.GroupKind:
+				typ += " " + relName(field.Message())
+			case protoreflect.EnumKind:
+				typ += " " + relName(field.Enum())
+			default:
+			}
+
+			if field.Cardinality() == protoreflect.Repeated {
+				typ = "repeated " + typ
+			}
+
+			api[relName(md)+"."+string(field.Name())] = fmt.Sprintf("%d %s", field.Number(), typ)
+		}
+
+		for i := 0; i < md.Enums().Len(); i++ {
+			addEnum(md.Enums().Get(i))
+		}
+
+		for i := 0; i < md.Messages().Len(); i++ {
+			addMessage(md.Messages().Get(i))
+		}
+	}
+
+	for i := 0; i < fd.Enums().Len(); i++ {
+		addEnum(fd.Enums().Get(i))
+	}
+
+	for i := 0; i < fd.Messages().Len(); i++ {
+		addMessage(fd.Messages().Get(i))
+	}
+
+	return api
+}
+
+// AssertProtoCompatible makes sure every service method, field and enum value
+// of the released API is still declared in the file with the same shape.
+// New declarations are allowed, as they don't break the existing clients
+func AssertProtoCompatible(t *testing.T, fd protoreflect.FileDescriptor, released map[string]string) {
+	t.Helper()
+
+	api := ProtoAPI(fd)
+
+	for name, shape := range released {
+		current, ok := api[name]
+		if !assert.Truef(t, ok, "%s was removed", name) {
+			continue
+		}
+
+		assert.Equalf(t, shape, current, "%s was changed", name)
+	}
+}
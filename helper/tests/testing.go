@@ -28,7 +28,7 @@ var (
 	ErrTimeout = errors.New("timeout")
 )
 
-func GenerateKeyAndAddr(t *testing.T) (*ecdsa.PrivateKey, types.Address) {
+func GenerateKeyAndAddr(t testing.TB) (*ecdsa.PrivateKey, types.Address) {
 	t.Helper()
 
 	key, err := crypto.GenerateECDSAKey()
@@ -40,7 +40,7 @@ func GenerateKeyAndAddr(t *testing.T) (*ecdsa.PrivateKey, types.Address) {
 	return key, addr
 }
 
-func GenerateTestMultiAddr(t *testing.T) multiaddr.Multiaddr {
+func GenerateTestMultiAddr(t testing.TB) multiaddr.Multiaddr {
 	t.Helper()
 
 	priv, _, err := libp2pCrypto.GenerateKeyPair(libp2pCrypto.Secp256k1, 256)
@@ -0,0 +1,8 @@
+package indexer
+
+// Config is the configuration of the indexer
+type Config struct {
+	// TraceBlocks is the number of the most recent blocks whose call trees
+	// are kept, no call trees are kept if 0
+	TraceBlocks uint64
+}
@@ -0,0 +1,359 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Blockchain is the interface of the blockchain the indexer follows
+type Blockchain interface {
+	Header() *types.Header
+	GetHeaderByNumber(number uint64) (*types.Header, bool)
+	GetBlockByNumber(number uint64, full bool) (*types.Block, bool)
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+	SubscribeEvents() blockchain.Subscription
+}
+
+// BlockTracer re-executes the blocks with a tracer
+type BlockTracer interface {
+	TraceBlock(block *types.Block, tracer tracer.Tracer) ([]interface{}, error)
+}
+
+// Indexer follows the canonical chain and maintains the secondary indexes of the transactions,
+// the internal transfers, the token transfers and the staking events by address
+type Indexer struct {
+	logger     hclog.Logger
+	blockchain Blockchain
+	tracer     BlockTracer
+	storage    *storage
+
+	// traceBlocks is the number of the recent blocks whose call trees are kept
+	traceBlocks uint64
+
+	// lock guards the storage against the queries while a reorg is unwound
+	lock sync.RWMutex
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewIndexer creates an indexer storing the indexes at the given path
+func NewIndexer(
+	logger hclog.Logger,
+	path string,
+	config *Config,
+	blockchain Blockchain,
+	tracer BlockTracer,
+) (*Indexer, error) {
+	storage, err := newStorage(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index storage: %w", err)
+	}
+
+	return &Indexer{
+		logger:      logger.Named("indexer"),
+		blockchain:  blockchain,
+		tracer:      tracer,
+		storage:     storage,
+		traceBlocks: config.TraceBlocks,
+	}, nil
+}
+
+// Start indexes the blocks missing from the indexes and follows the chain
+func (i *Indexer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cancel = cancel
+
+	sub := i.blockchain.SubscribeEvents()
+
+	i.wg.Add(1)
+
+	go func() {
+		defer i.wg.Done()
+		defer sub.Close()
+
+		i.sync(ctx)
+
+		eventCh := sub.GetEventCh()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-eventCh:
+				if ev == nil {
+					return
+				}
+
+				if ev.Type == blockchain.EventFork {
+					// forks don't change the canonical chain
+					continue
+				}
+
+				i.sync(ctx)
+			}
+		}
+	}()
+}
+
+// Close stops the indexer and closes its storage
+func (i *Indexer) Close() error {
+	if i.cancel != nil {
+		i.cancel()
+	}
+
+	i.wg.Wait()
+
+	return i.storage.close()
+}
+
+// Head returns the number of the last indexed block
+func (i *Indexer) Head() (uint64, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.storage.head()
+}
+
+// GetTransactions returns up to limit transactions sent or received by the address
+// in the block range, in chain order
+func (i *Indexer) GetTransactions(addr types.Address, from, to uint64, limit int) ([]*Transaction, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.storage.getTransactions(addr, from, to, limit)
+}
+
+// GetInternalTransfers returns up to limit internal transfers sent or received by the address
+// in the block range, in chain order
+func (i *Indexer) GetInternalTransfers(
+	addr types.Address,
+	from, to uint64,
+	limit int,
+) ([]*InternalTransfer, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.storage.getInternalTransfers(addr, from, to, limit)
+}
+
+// GetTokenTransfers returns up to limit token transfers sent or received by the address
+// in the block range, in chain order
+func (i *Indexer) GetTokenTransfers(addr types.Address, from, to uint64, limit int) ([]*TokenTransfer, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.storage.getTokenTransfers(addr, from, to, limit)
+}
+
+// GetTokenTransferPage returns a page of the token transfers sent or received by the address
+// and the cursor of the next page, nil if it is the last page
+func (i *Indexer) GetTokenTransferPage(addr types.Address, filter *HistoryFilter) ([]*TokenTransfer, []byte, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.storage.getTokenTransferPage(addr, filter)
+}
+
+// GetStakeEvents returns up to limit events of the staking contract of the account
+// in the block range, in chain order
+func (i *Indexer) GetStakeEvents(account types.Address, from, to uint64, limit int) ([]*StakeEvent, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.storage.getStakeEvents(account, from, to, limit)
+}
+
+// GetStakingStats returns the cumulative staking stats of the chain at the indexed block
+func (i *Indexer) GetStakingStats(number uint64) (*StakingStats, bool, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.storage.getStakingStats(number)
+}
+
+// GetCallTrace returns the call tree of the transaction,
+// only the call trees of the recent blocks are kept
+func (i *Indexer) GetCallTrace(txHash types.Hash) (*CallFrame, bool, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.storage.getCallTrace(txHash)
+}
+
+// sync removes the indexed blocks replaced by a reorg and indexes the new canonical blocks
+func (i *Indexer) sync(ctx context.Context) {
+	head, err := i.unwind()
+	if err != nil {
+		i.logger.Error("failed to unwind reorged blocks", "err", err)
+
+		return
+	}
+
+	for number := head + 1; number <= i.blockchain.Header().Number; number++ {
+		if ctx.Err() != nil {
+			return
+		}
+
+		block, ok := i.blockchain.GetBlockByNumber(number, true)
+		if !ok {
+			i.logger.Error("block not found", "number", number)
+
+			return
+		}
+
+		if err := i.indexBlock(block); err != nil {
+			i.logger.Error("failed to index block", "number", number, "err", err)
+
+			return
+		}
+
+		metrics.SetGauge([]string{"indexer", "head"}, float32(number))
+	}
+}
+
+// unwind removes the indexed blocks which are no longer canonical
+// and returns the number of the last indexed canonical block
+func (i *Indexer) unwind() (uint64, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	head, err := i.storage.head()
+	if err != nil {
+		return 0, err
+	}
+
+	for ; head > 0; head-- {
+		hash, ok, err := i.storage.blockHash(head)
+		if err != nil {
+			return 0, err
+		}
+
+		header, found := i.blockchain.GetHeaderByNumber(head)
+		if ok && found && header.Hash == hash {
+			break
+		}
+
+		if err := i.storage.removeBlock(head); err != nil {
+			return 0, err
+		}
+
+		metrics.IncrCounter([]string{"indexer", "unwound_blocks"}, 1)
+	}
+
+	return head, nil
+}
+
+// indexBlock indexes the transactions, the internal transfers, the token transfers
+// and the staking events of the block
+func (i *Indexer) indexBlock(block *types.Block) error {
+	receipts, err := i.blockchain.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to get receipts: %w", err)
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return fmt.Errorf("expected %d receipts, got %d", len(block.Transactions), len(receipts))
+	}
+
+	index := &blockIndex{
+		timestamp: block.Header.Timestamp,
+		rewards:   blockRewards(block, receipts),
+	}
+
+	var logIndex uint64
+
+	for txIndex, tx := range block.Transactions {
+		receipt := receipts[txIndex]
+
+		index.transactions = append(index.transactions, &Transaction{
+			BlockNumber:     block.Number(),
+			BlockHash:       block.Hash(),
+			TxHash:          tx.Hash,
+			TxIndex:         uint64(txIndex),
+			From:            tx.From,
+			To:              tx.To,
+			Value:           tx.Value,
+			ContractAddress: receipt.ContractAddress,
+			Failed:          receipt.Status != nil && *receipt.Status == types.ReceiptFailed,
+		})
+
+		for _, log := range receipt.Logs {
+			if transfer, ok := parseTokenTransfer(log); ok {
+				transfer.BlockNumber = block.Number()
+				transfer.BlockHash = block.Hash()
+				transfer.TxHash = tx.Hash
+				transfer.TxIndex = uint64(txIndex)
+				transfer.LogIndex = logIndex
+
+				index.tokenTransfers = append(index.tokenTransfers, transfer)
+			}
+
+			if event, ok := parseStakeEvent(log); ok {
+				event.BlockNumber = block.Number()
+				event.BlockHash = block.Hash()
+				event.TxHash = tx.Hash
+				event.TxIndex = uint64(txIndex)
+				event.LogIndex = logIndex
+
+				index.stakeEvents = append(index.stakeEvents, event)
+			}
+
+			logIndex++
+		}
+	}
+
+	if len(block.Transactions) > 0 {
+		if err := i.traceBlock(block, index); err != nil {
+			return fmt.Errorf("failed to trace block: %w", err)
+		}
+	}
+
+	return i.storage.writeBlock(block.Number(), block.Hash(), index, i.traceBlocks)
+}
+
+// traceBlock re-executes the block and indexes the internal transfers of its call trees,
+// the call trees are kept if the block is one of the recent blocks
+func (i *Indexer) traceBlock(block *types.Block, index *blockIndex) error {
+	results, err := i.tracer.TraceBlock(block, newCallTracer())
+	if err != nil {
+		return err
+	}
+
+	keepTraces := i.traceBlocks > 0 && block.Number()+i.traceBlocks > i.blockchain.Header().Number
+	if keepTraces {
+		index.callTraces = make(map[types.Hash]*CallFrame, len(results))
+	}
+
+	for txIndex, result := range results {
+		root, _ := result.(*CallFrame)
+		if root == nil {
+			continue
+		}
+
+		txHash := block.Transactions[txIndex].Hash
+
+		if keepTraces {
+			index.callTraces[txHash] = root
+		}
+
+		for idx, transfer := range internalTransfers(root) {
+			transfer.BlockNumber = block.Number()
+			transfer.BlockHash = block.Hash()
+			transfer.TxHash = txHash
+			transfer.TxIndex = uint64(txIndex)
+			transfer.Index = uint64(idx)
+
+			index.internalTransfers = append(index.internalTransfers, transfer)
+		}
+	}
+
+	return nil
+}
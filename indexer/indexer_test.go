@@ -0,0 +1,695 @@
+package indexer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+	token = types.StringToAddress("4")
+)
+
+type mockBlockchain struct {
+	sub      *blockchain.MockSubscription
+	blocks   []*types.Block
+	receipts map[types.Hash][]*types.Receipt
+}
+
+func (m *mockBlockchain) Header() *types.Header {
+	return m.blocks[len(m.blocks)-1].Header
+}
+
+func (m *mockBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	if number >= uint64(len(m.blocks)) {
+		return nil, false
+	}
+
+	return m.blocks[number].Header, true
+}
+
+func (m *mockBlockchain) GetBlockByNumber(number uint64, full bool) (*types.Block, bool) {
+	if number >= uint64(len(m.blocks)) {
+		return nil, false
+	}
+
+	return m.blocks[number], true
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.receipts[hash], nil
+}
+
+func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
+	return m.sub
+}
+
+// mockTracer returns the call trees of the transactions by hash
+type mockTracer map[types.Hash]*CallFrame
+
+func (m mockTracer) TraceBlock(block *types.Block, _ tracer.Tracer) ([]interface{}, error) {
+	results := make([]interface{}, len(block.Transactions))
+
+	for idx, tx := range block.Transactions {
+		if root, ok := m[tx.Hash]; ok {
+			results[idx] = root
+		} else {
+			results[idx] = &CallFrame{Type: CallTypeCall, From: tx.From, To: *tx.To, Value: tx.Value}
+		}
+	}
+
+	return results, nil
+}
+
+// newBlock returns a block with transactions from addr1 to the given addresses
+func newBlock(parent *types.Header, timestamp uint64, to ...types.Address) *types.Block {
+	header := &types.Header{Timestamp: timestamp}
+	if parent != nil {
+		header.Number = parent.Number + 1
+		header.ParentHash = parent.Hash
+	}
+
+	block := &types.Block{Header: header.ComputeHash()}
+
+	for idx, addr := range to {
+		addr := addr
+
+		tx := &types.Transaction{
+			Nonce:    uint64(idx),
+			From:     addr1,
+			To:       &addr,
+			Value:    big.NewInt(1),
+			GasPrice: big.NewInt(2),
+		}
+		tx.Hash = types.BytesToHash(append(header.Hash.Bytes(), byte(idx)))
+
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	return block
+}
+
+func successReceipts(block *types.Block, logs ...[]*types.Log) []*types.Receipt {
+	receipts := make([]*types.Receipt, len(block.Transactions))
+
+	for idx, tx := range block.Transactions {
+		receipts[idx] = &types.Receipt{TxHash: tx.Hash}
+		receipts[idx].SetStatus(types.ReceiptSuccess)
+
+		if idx < len(logs) {
+			receipts[idx].Logs = logs[idx]
+		}
+	}
+
+	return receipts
+}
+
+func newTestIndexer(t *testing.T, chain *mockBlockchain, tracer BlockTracer) *Indexer {
+	t.Helper()
+
+	idx, err := NewIndexer(hclog.NewNullLogger(), t.TempDir(), &Config{TraceBlocks: 2}, chain, tracer)
+	require.NoError(t, err)
+
+	return idx
+}
+
+func waitForHead(t *testing.T, idx *Indexer, number uint64) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		head, err := idx.Head()
+
+		return err == nil && head == number
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func erc20Transfer(from, to types.Address, value int64) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics: []types.Hash{
+			transferEventTopic,
+			types.BytesToHash(from.Bytes()),
+			types.BytesToHash(to.Bytes()),
+		},
+		Data: types.BytesToHash(big.NewInt(value).Bytes()).Bytes(),
+	}
+}
+
+func erc721Transfer(from, to types.Address, tokenID int64) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics: []types.Hash{
+			transferEventTopic,
+			types.BytesToHash(from.Bytes()),
+			types.BytesToHash(to.Bytes()),
+			types.BytesToHash(big.NewInt(tokenID).Bytes()),
+		},
+	}
+}
+
+func stakeEvent(event string, account types.Address, values ...int64) *types.Log {
+	log := &types.Log{
+		Address: staking.AddrStakingContract,
+		Topics: []types.Hash{
+			types.Hash(abis.StakingABI.Events[event].ID()),
+			types.BytesToHash(account.Bytes()),
+		},
+	}
+
+	for _, value := range values {
+		log.Data = append(log.Data, types.BytesToHash(big.NewInt(value).Bytes()).Bytes()...)
+	}
+
+	return log
+}
+
+func TestIndexer_Transactions(t *testing.T) {
+	t.Parallel()
+
+	genesis := newBlock(nil, 0)
+	block1 := newBlock(genesis.Header, 1, addr2)
+	block2 := newBlock(block1.Header, 2, addr3, addr2)
+
+	chain := &mockBlockchain{
+		sub:    blockchain.NewMockSubscription(),
+		blocks: []*types.Block{genesis, block1, block2},
+		receipts: map[types.Hash][]*types.Receipt{
+			block1.Hash(): successReceipts(block1),
+			block2.Hash(): successReceipts(block2),
+		},
+	}
+
+	idx := newTestIndexer(t, chain, mockTracer{})
+	idx.Start()
+
+	defer idx.Close()
+
+	waitForHead(t, idx, 2)
+
+	txs, err := idx.GetTransactions(addr2, 0, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+
+	assert.Equal(t, block1.Transactions[0].Hash, txs[0].TxHash)
+	assert.Equal(t, block2.Transactions[1].Hash, txs[1].TxHash)
+	assert.Equal(t, uint64(1), txs[1].TxIndex)
+	assert.Equal(t, addr1, txs[1].From)
+
+	// the block range and the limit are applied
+	txs, err = idx.GetTransactions(addr1, 2, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, block2.Transactions[0].Hash, txs[0].TxHash)
+
+	txs, err = idx.GetTransactions(addr3, 0, 1, 10)
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+}
+
+func TestIndexer_TokenAndInternalTransfers(t *testing.T) {
+	t.Parallel()
+
+	genesis := newBlock(nil, 0)
+	block1 := newBlock(genesis.Header, 1, token, addr3)
+
+	chain := &mockBlockchain{
+		sub:    blockchain.NewMockSubscription(),
+		blocks: []*types.Block{genesis, block1},
+		receipts: map[types.Hash][]*types.Receipt{
+			block1.Hash(): successReceipts(
+				block1,
+				[]*types.Log{
+					erc20Transfer(addr1, addr2, 100),
+					{Address: token, Topics: []types.Hash{types.StringToHash("5")}},
+				},
+				[]*types.Log{
+					erc721Transfer(addr2, addr3, 7),
+				},
+			),
+		},
+	}
+
+	tracer := mockTracer{
+		block1.Transactions[1].Hash: {
+			Type:  CallTypeCall,
+			From:  addr1,
+			To:    addr3,
+			Value: big.NewInt(1),
+			Calls: []*CallFrame{
+				{Type: CallTypeCall, From: addr3, To: addr2, Value: big.NewInt(5)},
+			},
+		},
+	}
+
+	idx := newTestIndexer(t, chain, tracer)
+	idx.Start()
+
+	defer idx.Close()
+
+	waitForHead(t, idx, 1)
+
+	transfers, err := idx.GetTokenTransfers(addr2, 0, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, transfers, 2)
+
+	assert.Equal(t, ERC20, transfers[0].Standard)
+	assert.Equal(t, big.NewInt(100), transfers[0].Value)
+	assert.Equal(t, uint64(0), transfers[0].LogIndex)
+
+	assert.Equal(t, ERC721, transfers[1].Standard)
+	assert.Equal(t, big.NewInt(7), transfers[1].TokenID)
+	assert.Equal(t, uint64(2), transfers[1].LogIndex)
+	assert.Equal(t, uint64(1), transfers[1].TxIndex)
+
+	internal, err := idx.GetInternalTransfers(addr2, 0, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, internal, 1)
+
+	assert.Equal(t, block1.Transactions[1].Hash, internal[0].TxHash)
+	assert.Equal(t, addr3, internal[0].From)
+	assert.Equal(t, big.NewInt(5), internal[0].Value)
+	assert.Equal(t, []uint64{0}, internal[0].TraceAddress)
+
+	trace, ok, err := idx.GetCallTrace(block1.Transactions[1].Hash)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, addr3, trace.To)
+	assert.Len(t, trace.Calls, 1)
+}
+
+func TestIndexer_Reorg(t *testing.T) {
+	t.Parallel()
+
+	genesis := newBlock(nil, 0)
+	block1 := newBlock(genesis.Header, 1, addr2)
+	block2 := newBlock(block1.Header, 2, addr2)
+
+	chain := &mockBlockchain{
+		sub:    blockchain.NewMockSubscription(),
+		blocks: []*types.Block{genesis, block1, block2},
+		receipts: map[types.Hash][]*types.Receipt{
+			block1.Hash(): successReceipts(block1),
+			block2.Hash(): successReceipts(block2, []*types.Log{erc20Transfer(addr1, addr2, 5)}),
+		},
+	}
+
+	idx := newTestIndexer(t, chain, mockTracer{})
+	idx.Start()
+
+	defer idx.Close()
+
+	waitForHead(t, idx, 2)
+
+	transfers, _, err := idx.GetTokenTransferPage(addr2, &HistoryFilter{To: 2, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+
+	// block 2 is replaced by a fork sending to addr3
+	fork2 := newBlock(block1.Header, 3, addr3)
+	fork3 := newBlock(fork2.Header, 4)
+
+	chain.receipts[fork2.Hash()] = successReceipts(fork2, []*types.Log{erc20Transfer(addr1, addr3, 6)})
+	chain.receipts[fork3.Hash()] = successReceipts(fork3)
+	chain.blocks = []*types.Block{genesis, block1, fork2, fork3}
+
+	chain.sub.Push(&blockchain.Event{
+		Type:     blockchain.EventReorg,
+		OldChain: []*types.Header{block2.Header},
+		NewChain: []*types.Header{fork2.Header, fork3.Header},
+	})
+
+	waitForHead(t, idx, 3)
+
+	txs, err := idx.GetTransactions(addr2, 0, 3, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, block1.Transactions[0].Hash, txs[0].TxHash)
+
+	txs, err = idx.GetTransactions(addr3, 0, 3, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, fork2.Transactions[0].Hash, txs[0].TxHash)
+
+	// the token transfers of the removed block are removed
+	transfers, _, err = idx.GetTokenTransferPage(addr2, &HistoryFilter{To: 3, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+
+	transfers, _, err = idx.GetTokenTransferPage(addr3, &HistoryFilter{To: 3, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, big.NewInt(6), transfers[0].Value)
+}
+
+func TestStorage_TokenTransferPages(t *testing.T) {
+	t.Parallel()
+
+	s, err := newStorage(t.TempDir())
+	require.NoError(t, err)
+
+	// the parallel subtests run after the test function returns
+	t.Cleanup(func() {
+		_ = s.close()
+	})
+
+	otherToken := types.StringToAddress("5")
+
+	// 2 transfers per block, the second of another token in the even blocks
+	for number := uint64(1); number <= 5; number++ {
+		second := token
+		if number%2 == 0 {
+			second = otherToken
+		}
+
+		index := &blockIndex{
+			tokenTransfers: []*TokenTransfer{
+				{BlockNumber: number, LogIndex: 0, Contract: token, From: addr1, To: addr2, Value: big.NewInt(1)},
+				{BlockNumber: number, LogIndex: 1, Contract: second, From: addr2, To: addr3, Value: big.NewInt(2)},
+			},
+		}
+
+		require.NoError(t, s.writeBlock(number, types.StringToHash(string(rune(number))), index, 0))
+	}
+
+	type position struct {
+		number   uint64
+		logIndex uint64
+	}
+
+	collect := func(filter *HistoryFilter) [][]position {
+		pages := [][]position{}
+
+		for {
+			transfers, next, err := s.getTokenTransferPage(addr2, filter)
+			require.NoError(t, err)
+
+			page := []position{}
+			for _, transfer := range transfers {
+				page = append(page, position{transfer.BlockNumber, transfer.LogIndex})
+			}
+
+			pages = append(pages, page)
+
+			if next == nil {
+				return pages
+			}
+
+			filter.Cursor = next
+		}
+	}
+
+	t.Run("ascending", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, [][]position{
+			{{2, 0}, {2, 1}, {3, 0}},
+			{{3, 1}, {4, 0}, {4, 1}},
+		}, collect(&HistoryFilter{From: 2, To: 4, Limit: 3}))
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, [][]position{
+			{{5, 1}, {5, 0}, {4, 1}, {4, 0}},
+			{{3, 1}, {3, 0}, {2, 1}, {2, 0}},
+			{{1, 1}, {1, 0}},
+		}, collect(&HistoryFilter{From: 0, To: 5, Limit: 4, Descending: true}))
+	})
+
+	t.Run("contract filter", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, [][]position{
+			{{4, 1}, {2, 1}},
+		}, collect(&HistoryFilter{To: 5, Limit: 10, Contract: &otherToken, Descending: true}))
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := s.getTokenTransferPage(addr2, &HistoryFilter{To: 5, Limit: 1, Cursor: []byte{0x1}})
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
+func TestIndexer_ResumesFromStorage(t *testing.T) {
+	t.Parallel()
+
+	genesis := newBlock(nil, 0)
+	block1 := newBlock(genesis.Header, 1, addr2)
+
+	chain := &mockBlockchain{
+		sub:    blockchain.NewMockSubscription(),
+		blocks: []*types.Block{genesis, block1},
+		receipts: map[types.Hash][]*types.Receipt{
+			block1.Hash(): successReceipts(block1),
+		},
+	}
+
+	path := t.TempDir()
+
+	idx, err := NewIndexer(hclog.NewNullLogger(), path, &Config{}, chain, mockTracer{})
+	require.NoError(t, err)
+
+	idx.Start()
+	waitForHead(t, idx, 1)
+	require.NoError(t, idx.Close())
+
+	block2 := newBlock(block1.Header, 2, addr2)
+	chain.receipts[block2.Hash()] = successReceipts(block2)
+	chain.blocks = append(chain.blocks, block2)
+	chain.sub = blockchain.NewMockSubscription()
+
+	idx, err = NewIndexer(hclog.NewNullLogger(), path, &Config{}, chain, mockTracer{})
+	require.NoError(t, err)
+
+	idx.Start()
+
+	defer idx.Close()
+
+	waitForHead(t, idx, 2)
+
+	txs, err := idx.GetTransactions(addr2, 0, 2, 10)
+	require.NoError(t, err)
+	assert.Len(t, txs, 2)
+}
+
+func TestIndexer_StakingEvents(t *testing.T) {
+	t.Parallel()
+
+	genesis := newBlock(nil, 0)
+	block1 := newBlock(genesis.Header, 10, staking.AddrStakingContract, staking.AddrStakingContract)
+	block2 := newBlock(block1.Header, 20, staking.AddrStakingContract, addr3)
+
+	receipts1 := successReceipts(block1,
+		[]*types.Log{stakeEvent("Staked", addr2, 100)},
+		[]*types.Log{stakeEvent("Staked", addr3, 50)},
+	)
+	receipts2 := successReceipts(block2,
+		[]*types.Log{
+			stakeEvent("Unstaked", addr2, 100),
+			stakeEvent("WithdrawalRequested", addr2, 100, 7),
+		},
+	)
+
+	for _, receipt := range append(receipts1, receipts2...) {
+		receipt.GasUsed = 1000
+	}
+
+	chain := &mockBlockchain{
+		sub:    blockchain.NewMockSubscription(),
+		blocks: []*types.Block{genesis, block1, block2},
+		receipts: map[types.Hash][]*types.Receipt{
+			block1.Hash(): receipts1,
+			block2.Hash(): receipts2,
+		},
+	}
+
+	idx := newTestIndexer(t, chain, mockTracer{})
+	idx.Start()
+
+	defer idx.Close()
+
+	waitForHead(t, idx, 2)
+
+	events, err := idx.GetStakeEvents(addr2, 0, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, StakeEventStaked, events[0].Type)
+	assert.Equal(t, big.NewInt(100), events[0].Amount)
+	assert.Equal(t, block1.Transactions[0].Hash, events[0].TxHash)
+
+	assert.Equal(t, StakeEventUnstaked, events[1].Type)
+	assert.Equal(t, StakeEventWithdrawalRequested, events[2].Type)
+	assert.Equal(t, uint64(7), events[2].ReleaseEpoch)
+	assert.Equal(t, uint64(1), events[2].LogIndex)
+
+	// the stats accumulate the stake changes and the fees of the blocks
+	stats, ok, err := idx.GetStakingStats(1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, big.NewInt(150), stats.StakeDelta)
+	assert.Equal(t, big.NewInt(4000), stats.Rewards)
+	assert.Equal(t, uint64(10), stats.Timestamp)
+
+	stats, ok, err = idx.GetStakingStats(2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, big.NewInt(50), stats.StakeDelta)
+	assert.Equal(t, big.NewInt(8000), stats.Rewards)
+
+	// the events of other contracts are ignored
+	foreign := stakeEvent("Staked", addr2, 100)
+	foreign.Address = token
+
+	_, ok = parseStakeEvent(foreign)
+	assert.False(t, ok)
+}
+
+func TestParseTokenTransfer(t *testing.T) {
+	t.Parallel()
+
+	transfer, ok := parseTokenTransfer(erc20Transfer(addr1, addr2, 3))
+	require.True(t, ok)
+	assert.Equal(t, &TokenTransfer{
+		Standard: ERC20,
+		Contract: token,
+		From:     addr1,
+		To:       addr2,
+		Value:    big.NewInt(3),
+	}, transfer)
+
+	transfer, ok = parseTokenTransfer(erc721Transfer(addr1, addr2, 3))
+	require.True(t, ok)
+	assert.Equal(t, &TokenTransfer{
+		Standard: ERC721,
+		Contract: token,
+		From:     addr1,
+		To:       addr2,
+		TokenID:  big.NewInt(3),
+	}, transfer)
+
+	// the ERC-20 transfers carry the value in the data
+	invalid := erc20Transfer(addr1, addr2, 3)
+	invalid.Data = nil
+
+	_, ok = parseTokenTransfer(invalid)
+	assert.False(t, ok)
+
+	_, ok = parseTokenTransfer(&types.Log{Topics: []types.Hash{types.StringToHash("1")}})
+	assert.False(t, ok)
+}
+
+func TestIndexer_PrunesCallTraces(t *testing.T) {
+	t.Parallel()
+
+	blocks := []*types.Block{newBlock(nil, 0)}
+	receipts := map[types.Hash][]*types.Receipt{}
+
+	for number := uint64(1); number <= 4; number++ {
+		block := newBlock(blocks[number-1].Header, number, addr2)
+
+		blocks = append(blocks, block)
+		receipts[block.Hash()] = successReceipts(block)
+	}
+
+	chain := &mockBlockchain{
+		sub:      blockchain.NewMockSubscription(),
+		blocks:   blocks,
+		receipts: receipts,
+	}
+
+	idx := newTestIndexer(t, chain, mockTracer{})
+	idx.Start()
+
+	defer idx.Close()
+
+	waitForHead(t, idx, 4)
+
+	// only the call trees of the last 2 blocks are kept
+	for number, expected := range []bool{false, false, true, true} {
+		_, ok, err := idx.GetCallTrace(blocks[number+1].Transactions[0].Hash)
+		require.NoError(t, err)
+		assert.Equal(t, expected, ok, "block %d", number+1)
+	}
+}
+
+func TestCallTracer(t *testing.T) {
+	t.Parallel()
+
+	tr := newCallTracer()
+
+	tr.TxStart(100000)
+	tr.CallStart(1, addr1, token, int(runtime.Call), 100000, big.NewInt(10), nil)
+
+	// transfer of a successful sub call
+	tr.CallStart(2, token, addr2, int(runtime.Call), 1000, big.NewInt(1), nil)
+	tr.CallEnd(2, nil, 0, nil)
+
+	// delegate calls don't move value
+	tr.CallStart(2, token, addr3, int(runtime.DelegateCall), 1000, big.NewInt(10), nil)
+	tr.CallEnd(2, nil, 0, nil)
+
+	// the transfers of a reverted call are discarded with the ones of its sub calls
+	tr.CallStart(2, token, addr3, int(runtime.Call), 1000, big.NewInt(2), nil)
+	tr.CallStart(3, addr3, addr2, int(runtime.Call), 1000, big.NewInt(1), nil)
+	tr.CallEnd(3, nil, 0, nil)
+	tr.CallEnd(2, nil, 0, errors.New("reverted"))
+
+	tr.CallEnd(1, []byte{0x1}, 0, nil)
+	tr.TxEnd(0)
+
+	result, err := tr.GetResult()
+	require.NoError(t, err)
+
+	root, ok := result.(*CallFrame)
+	require.True(t, ok)
+
+	assert.Equal(t, []byte{0x1}, root.Output)
+	require.Len(t, root.Calls, 3)
+	assert.Equal(t, CallTypeDelegateCall, root.Calls[1].Type)
+	assert.Equal(t, "reverted", root.Calls[2].Error)
+	assert.Len(t, root.Calls[2].Calls, 1)
+
+	transfers := internalTransfers(root)
+	require.Len(t, transfers, 1)
+
+	assert.Equal(t, &InternalTransfer{
+		Depth:        2,
+		CallType:     CallTypeCall,
+		From:         token,
+		To:           addr2,
+		Value:        big.NewInt(1),
+		TraceAddress: []uint64{0},
+	}, transfers[0])
+
+	// the transfers are dropped if the transaction fails
+	tr.Clear()
+	tr.CallStart(1, addr1, token, int(runtime.Call), 100000, big.NewInt(0), nil)
+	tr.CallStart(2, token, addr2, int(runtime.Call), 1000, big.NewInt(1), nil)
+	tr.CallEnd(2, nil, 0, nil)
+	tr.CallEnd(1, nil, 0, errors.New("reverted"))
+
+	result, err = tr.GetResult()
+	require.NoError(t, err)
+
+	root, ok = result.(*CallFrame)
+	require.True(t, ok)
+	assert.Empty(t, internalTransfers(root))
+}
@@ -0,0 +1,81 @@
+package indexer
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/contracts/abis"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var stakingContract = staking.NewStaking(staking.AddrStakingContract, nil)
+
+// stakeEventTypes are the types of the events of the staking contract by topic
+var stakeEventTypes = map[types.Hash]StakeEventType{
+	types.Hash(abis.StakingABI.Events["Staked"].ID()):              StakeEventStaked,
+	types.Hash(abis.StakingABI.Events["Unstaked"].ID()):            StakeEventUnstaked,
+	types.Hash(abis.StakingABI.Events["WithdrawalRequested"].ID()): StakeEventWithdrawalRequested,
+	types.Hash(abis.StakingABI.Events["Withdrawn"].ID()):           StakeEventWithdrawn,
+}
+
+// parseStakeEvent decodes the event of the staking contract of the log
+func parseStakeEvent(log *types.Log) (*StakeEvent, bool) {
+	if log.Address != staking.AddrStakingContract || len(log.Topics) == 0 {
+		return nil, false
+	}
+
+	eventType, ok := stakeEventTypes[log.Topics[0]]
+	if !ok {
+		return nil, false
+	}
+
+	event := &StakeEvent{
+		Type: eventType,
+	}
+
+	switch eventType {
+	case StakeEventStaked:
+		staked, err := stakingContract.ParseStakedEvent(log)
+		if err != nil {
+			return nil, false
+		}
+
+		event.Account, event.Amount = staked.Account, staked.Amount
+	case StakeEventUnstaked:
+		unstaked, err := stakingContract.ParseUnstakedEvent(log)
+		if err != nil {
+			return nil, false
+		}
+
+		event.Account, event.Amount = unstaked.Account, unstaked.Amount
+	case StakeEventWithdrawalRequested:
+		requested, err := stakingContract.ParseWithdrawalRequestedEvent(log)
+		if err != nil {
+			return nil, false
+		}
+
+		event.Account, event.Amount = requested.Account, requested.Amount
+		event.ReleaseEpoch = requested.ReleaseEpoch.Uint64()
+	case StakeEventWithdrawn:
+		withdrawn, err := stakingContract.ParseWithdrawnEvent(log)
+		if err != nil {
+			return nil, false
+		}
+
+		event.Account, event.Amount = withdrawn.Account, withdrawn.Amount
+	}
+
+	return event, true
+}
+
+// blockRewards returns the transaction fees of the block, paid to its creator
+func blockRewards(block *types.Block, receipts []*types.Receipt) *big.Int {
+	rewards := new(big.Int)
+
+	for idx, tx := range block.Transactions {
+		fee := new(big.Int).SetUint64(receipts[idx].GasUsed)
+		rewards.Add(rewards, fee.Mul(fee, tx.EffectiveGasPrice()))
+	}
+
+	return rewards
+}
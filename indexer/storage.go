@@ -0,0 +1,547 @@
+package indexer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Key layout of the index tables, the entries of an address are ordered by
+// their position in the chain:
+//
+//	prefix | address (20) | block number (8) | tx index (4) | index (4)
+var (
+	// headKey is the key of the number of the last indexed block
+	headKey = []byte("head")
+
+	// blockPrefix is the prefix of the undo records of the indexed blocks
+	blockPrefix = []byte("b")
+
+	transactionPrefix      = []byte("t")
+	internalTransferPrefix = []byte("i")
+	tokenTransferPrefix    = []byte("k")
+	stakeEventPrefix       = []byte("s")
+
+	// stakingStatsPrefix is the prefix of the staking stats by block number
+	stakingStatsPrefix = []byte("r")
+
+	// callTracePrefix is the prefix of the call trees by transaction hash
+	callTracePrefix = []byte("c")
+)
+
+const entryKeyLength = 1 + types.AddressLength + 8 + 4 + 4
+
+// ErrInvalidCursor is returned for the cursors not returned by a previous page
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// blockRecord is the record of an indexed block, holding the keys of its
+// entries so that the block can be removed on a reorg
+type blockRecord struct {
+	Hash types.Hash `json:"hash"`
+	Keys [][]byte   `json:"keys"`
+
+	// Traces are the keys of the call trees, removed once the block is
+	// older than the retained blocks
+	Traces [][]byte `json:"traces,omitempty"`
+}
+
+// storage stores the indexes in a leveldb database
+type storage struct {
+	db *leveldb.DB
+}
+
+func newStorage(path string) (*storage, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storage{db: db}, nil
+}
+
+func (s *storage) close() error {
+	return s.db.Close()
+}
+
+// head returns the number of the last indexed block, 0 if nothing is indexed
+func (s *storage) head() (uint64, error) {
+	data, err := s.db.Get(headKey, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(data), nil
+}
+
+// blockHash returns the hash of the indexed block of the given height
+func (s *storage) blockHash(number uint64) (types.Hash, bool, error) {
+	record, err := s.readBlockRecord(number)
+	if err != nil || record == nil {
+		return types.ZeroHash, false, err
+	}
+
+	return record.Hash, true, nil
+}
+
+// writeBlock writes the entries of the block and sets it as the head,
+// the call trees of the block traceBlocks before it are pruned
+func (s *storage) writeBlock(number uint64, hash types.Hash, index *blockIndex, traceBlocks uint64) error {
+	batch := new(leveldb.Batch)
+	record := &blockRecord{Hash: hash, Keys: [][]byte{}}
+
+	put := func(prefix []byte, addresses []types.Address, txIndex, index uint64, entry interface{}) error {
+		value, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		for i, addr := range addresses {
+			if i > 0 && addr == addresses[0] {
+				// self transfers are indexed once
+				continue
+			}
+
+			key := entryKey(prefix, addr, number, txIndex, index)
+
+			batch.Put(key, value)
+			record.Keys = append(record.Keys, key)
+		}
+
+		return nil
+	}
+
+	for _, tx := range index.transactions {
+		addresses := []types.Address{tx.From}
+
+		if tx.To != nil {
+			addresses = append(addresses, *tx.To)
+		} else if tx.ContractAddress != nil {
+			addresses = append(addresses, *tx.ContractAddress)
+		}
+
+		if err := put(transactionPrefix, addresses, tx.TxIndex, 0, tx); err != nil {
+			return err
+		}
+	}
+
+	for _, transfer := range index.internalTransfers {
+		addresses := []types.Address{transfer.From, transfer.To}
+
+		if err := put(internalTransferPrefix, addresses, transfer.TxIndex, transfer.Index, transfer); err != nil {
+			return err
+		}
+	}
+
+	for _, transfer := range index.tokenTransfers {
+		addresses := []types.Address{transfer.From, transfer.To}
+
+		if err := put(tokenTransferPrefix, addresses, transfer.TxIndex, transfer.LogIndex, transfer); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range index.stakeEvents {
+		addresses := []types.Address{event.Account}
+
+		if err := put(stakeEventPrefix, addresses, event.TxIndex, event.LogIndex, event); err != nil {
+			return err
+		}
+	}
+
+	if err := s.putStakingStats(batch, record, number, index); err != nil {
+		return err
+	}
+
+	for txHash, trace := range index.callTraces {
+		value, err := json.Marshal(trace)
+		if err != nil {
+			return err
+		}
+
+		key := callTraceKey(txHash)
+
+		batch.Put(key, value)
+		record.Traces = append(record.Traces, key)
+	}
+
+	if traceBlocks > 0 && number > traceBlocks {
+		if err := s.pruneTraces(batch, number-traceBlocks); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	batch.Put(blockKey(number), data)
+	batch.Put(headKey, encodeUint64(number))
+
+	return s.db.Write(batch, nil)
+}
+
+// putStakingStats adds the staking stats of the block, accumulated on the stats of its parent, to the batch
+func (s *storage) putStakingStats(batch *leveldb.Batch, record *blockRecord, number uint64, index *blockIndex) error {
+	stats := &StakingStats{
+		BlockNumber: number,
+		Timestamp:   index.timestamp,
+		StakeDelta:  new(big.Int),
+		Rewards:     new(big.Int).Set(index.rewards),
+	}
+
+	parent, ok, err := s.getStakingStats(number - 1)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		stats.StakeDelta.Set(parent.StakeDelta)
+		stats.Rewards.Add(stats.Rewards, parent.Rewards)
+	}
+
+	for _, event := range index.stakeEvents {
+		stats.StakeDelta.Add(stats.StakeDelta, event.StakeDelta())
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+
+	key := stakingStatsKey(number)
+
+	batch.Put(key, data)
+	record.Keys = append(record.Keys, key)
+
+	return nil
+}
+
+// pruneTraces adds the removal of the call trees of the block to the batch
+func (s *storage) pruneTraces(batch *leveldb.Batch, number uint64) error {
+	record, err := s.readBlockRecord(number)
+	if err != nil || record == nil || len(record.Traces) == 0 {
+		return err
+	}
+
+	for _, key := range record.Traces {
+		batch.Delete(key)
+	}
+
+	record.Traces = nil
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	batch.Put(blockKey(number), data)
+
+	return nil
+}
+
+// removeBlock removes the entries of the block of the given height,
+// which must be the head, and sets its parent as the head
+func (s *storage) removeBlock(number uint64) error {
+	record, err := s.readBlockRecord(number)
+	if err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+
+	if record != nil {
+		for _, key := range record.Keys {
+			batch.Delete(key)
+		}
+
+		for _, key := range record.Traces {
+			batch.Delete(key)
+		}
+	}
+
+	batch.Delete(blockKey(number))
+	batch.Put(headKey, encodeUint64(number-1))
+
+	return s.db.Write(batch, nil)
+}
+
+func (s *storage) readBlockRecord(number uint64) (*blockRecord, error) {
+	data, err := s.db.Get(blockKey(number), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	record := &blockRecord{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// getTransactions returns the transactions of the address in the block range
+func (s *storage) getTransactions(addr types.Address, from, to uint64, limit int) ([]*Transaction, error) {
+	txs := []*Transaction{}
+
+	err := s.iterate(transactionPrefix, addr, from, to, limit, func(value []byte) error {
+		tx := &Transaction{}
+		if err := json.Unmarshal(value, tx); err != nil {
+			return err
+		}
+
+		txs = append(txs, tx)
+
+		return nil
+	})
+
+	return txs, err
+}
+
+// getInternalTransfers returns the internal transfers of the address in the block range
+func (s *storage) getInternalTransfers(addr types.Address, from, to uint64, limit int) ([]*InternalTransfer, error) {
+	transfers := []*InternalTransfer{}
+
+	err := s.iterate(internalTransferPrefix, addr, from, to, limit, func(value []byte) error {
+		transfer := &InternalTransfer{}
+		if err := json.Unmarshal(value, transfer); err != nil {
+			return err
+		}
+
+		transfers = append(transfers, transfer)
+
+		return nil
+	})
+
+	return transfers, err
+}
+
+// getTokenTransfers returns the token transfers of the address in the block range
+func (s *storage) getTokenTransfers(addr types.Address, from, to uint64, limit int) ([]*TokenTransfer, error) {
+	transfers := []*TokenTransfer{}
+
+	err := s.iterate(tokenTransferPrefix, addr, from, to, limit, func(value []byte) error {
+		transfer := &TokenTransfer{}
+		if err := json.Unmarshal(value, transfer); err != nil {
+			return err
+		}
+
+		transfers = append(transfers, transfer)
+
+		return nil
+	})
+
+	return transfers, err
+}
+
+// getStakeEvents returns the staking events of the account in the block range
+func (s *storage) getStakeEvents(account types.Address, from, to uint64, limit int) ([]*StakeEvent, error) {
+	events := []*StakeEvent{}
+
+	err := s.iterate(stakeEventPrefix, account, from, to, limit, func(value []byte) error {
+		event := &StakeEvent{}
+		if err := json.Unmarshal(value, event); err != nil {
+			return err
+		}
+
+		events = append(events, event)
+
+		return nil
+	})
+
+	return events, err
+}
+
+// getStakingStats returns the staking stats of the block, if indexed
+func (s *storage) getStakingStats(number uint64) (*StakingStats, bool, error) {
+	data, err := s.db.Get(stakingStatsKey(number), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	stats := &StakingStats{}
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, false, err
+	}
+
+	return stats, true, nil
+}
+
+// getCallTrace returns the call tree of the transaction, if retained
+func (s *storage) getCallTrace(txHash types.Hash) (*CallFrame, bool, error) {
+	data, err := s.db.Get(callTraceKey(txHash), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	trace := &CallFrame{}
+	if err := json.Unmarshal(data, trace); err != nil {
+		return nil, false, err
+	}
+
+	return trace, true, nil
+}
+
+// getTokenTransferPage returns a page of the token transfers of the address matching the filter
+// and the cursor of the next page, nil if there are no more transfers
+func (s *storage) getTokenTransferPage(
+	addr types.Address,
+	filter *HistoryFilter,
+) ([]*TokenTransfer, []byte, error) {
+	transfers := []*TokenTransfer{}
+
+	next, err := s.page(tokenTransferPrefix, addr, filter, func(value []byte) (bool, error) {
+		transfer := &TokenTransfer{}
+		if err := json.Unmarshal(value, transfer); err != nil {
+			return false, err
+		}
+
+		if filter.Contract != nil && transfer.Contract != *filter.Contract {
+			return false, nil
+		}
+
+		transfers = append(transfers, transfer)
+
+		return true, nil
+	})
+
+	return transfers, next, err
+}
+
+// page calls fn with the values of the entries of the address in the block range of the filter,
+// starting from the cursor, until fn accepted the limit of the filter. It returns the cursor
+// of the entry following the last accepted one
+func (s *storage) page(
+	prefix []byte,
+	addr types.Address,
+	filter *HistoryFilter,
+	fn func(value []byte) (bool, error),
+) ([]byte, error) {
+	addrPrefixLength := len(prefix) + types.AddressLength
+
+	start := entryKey(prefix, addr, filter.From, 0, 0)
+	end := rangeEndKey(prefix, addr, filter.To)
+
+	if filter.Cursor != nil {
+		if len(filter.Cursor) != entryKeyLength-addrPrefixLength {
+			return nil, ErrInvalidCursor
+		}
+
+		cursor := append(append([]byte{}, start[:addrPrefixLength]...), filter.Cursor...)
+
+		if filter.Descending {
+			// the cursor entry is the first one of the page
+			if cursorEnd := append(cursor, 0x00); bytes.Compare(cursorEnd, end) < 0 {
+				end = cursorEnd
+			}
+		} else if bytes.Compare(cursor, start) > 0 {
+			start = cursor
+		}
+	}
+
+	iter := s.db.NewIterator(&util.Range{Start: start, Limit: end}, nil)
+	defer iter.Release()
+
+	move, advance := iter.First, iter.Next
+	if filter.Descending {
+		move, advance = iter.Last, iter.Prev
+	}
+
+	accepted := 0
+
+	for ok := move(); ok; ok = advance() {
+		if accepted == filter.Limit {
+			return append([]byte{}, iter.Key()[addrPrefixLength:]...), iter.Error()
+		}
+
+		match, err := fn(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		if match {
+			accepted++
+		}
+	}
+
+	return nil, iter.Error()
+}
+
+// iterate calls fn with the values of the entries of the address
+// in the block range, in chain order and up to the limit
+func (s *storage) iterate(
+	prefix []byte,
+	addr types.Address,
+	from, to uint64,
+	limit int,
+	fn func(value []byte) error,
+) error {
+	start := entryKey(prefix, addr, from, 0, 0)
+	end := rangeEndKey(prefix, addr, to)
+
+	iter := s.db.NewIterator(&util.Range{Start: start, Limit: end}, nil)
+	defer iter.Release()
+
+	for count := 0; count < limit && iter.Next(); count++ {
+		if err := fn(iter.Value()); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func entryKey(prefix []byte, addr types.Address, number, txIndex, index uint64) []byte {
+	key := make([]byte, entryKeyLength)
+
+	offset := copy(key, prefix)
+	offset += copy(key[offset:], addr.Bytes())
+
+	binary.BigEndian.PutUint64(key[offset:], number)
+	binary.BigEndian.PutUint32(key[offset+8:], uint32(txIndex))
+	binary.BigEndian.PutUint32(key[offset+12:], uint32(index))
+
+	return key
+}
+
+// rangeEndKey returns a key greater than any entry of the address in the block
+func rangeEndKey(prefix []byte, addr types.Address, number uint64) []byte {
+	end := make([]byte, 0, entryKeyLength+1)
+	end = append(end, entryKey(prefix, addr, number, 0, 0)[:len(prefix)+types.AddressLength+8]...)
+	end = append(end, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff)
+
+	return end
+}
+
+func callTraceKey(txHash types.Hash) []byte {
+	return append(append([]byte{}, callTracePrefix...), txHash.Bytes()...)
+}
+
+func stakingStatsKey(number uint64) []byte {
+	return append(append([]byte{}, stakingStatsPrefix...), encodeUint64(number)...)
+}
+
+func blockKey(number uint64) []byte {
+	return append(append([]byte{}, blockPrefix...), encodeUint64(number)...)
+}
+
+func encodeUint64(n uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, n)
+
+	return buf
+}
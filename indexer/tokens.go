@@ -0,0 +1,40 @@
+package indexer
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// transferEventTopic is the topic of the Transfer(address,address,uint256) event,
+// shared by the ERC-20 and the ERC-721 standards
+var transferEventTopic = types.BytesToHash(crypto.Keccak256([]byte("Transfer(address,address,uint256)")))
+
+// parseTokenTransfer decodes the Transfer event of the log, the ERC-721 transfers
+// are told from the ERC-20 ones by the indexed token id
+func parseTokenTransfer(log *types.Log) (*TokenTransfer, bool) {
+	if len(log.Topics) == 0 || log.Topics[0] != transferEventTopic {
+		return nil, false
+	}
+
+	transfer := &TokenTransfer{
+		Contract: log.Address,
+	}
+
+	switch {
+	case len(log.Topics) == 3 && len(log.Data) == types.HashLength:
+		transfer.Standard = ERC20
+		transfer.Value = new(big.Int).SetBytes(log.Data)
+	case len(log.Topics) == 4 && len(log.Data) == 0:
+		transfer.Standard = ERC721
+		transfer.TokenID = new(big.Int).SetBytes(log.Topics[3].Bytes())
+	default:
+		return nil, false
+	}
+
+	transfer.From = types.BytesToAddress(log.Topics[1].Bytes())
+	transfer.To = types.BytesToAddress(log.Topics[2].Bytes())
+
+	return transfer, true
+}
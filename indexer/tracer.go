@@ -0,0 +1,178 @@
+package indexer
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// CallFrame is a call of the call tree of a transaction
+type CallFrame struct {
+	Type   CallType      `json:"type"`
+	From   types.Address `json:"from"`
+	To     types.Address `json:"to"`
+	Value  *big.Int      `json:"value"`
+	Gas    uint64        `json:"gas"`
+	Input  []byte        `json:"input"`
+	Output []byte        `json:"output"`
+
+	// Error is the reason of the failure of the call, the state changes
+	// of the failed calls and their sub calls are reverted
+	Error string `json:"error,omitempty"`
+
+	Calls []*CallFrame `json:"calls,omitempty"`
+}
+
+// callTracer builds the call tree of a transaction
+type callTracer struct {
+	frames []*CallFrame
+	root   *CallFrame
+}
+
+func newCallTracer() *callTracer {
+	return &callTracer{}
+}
+
+func (t *callTracer) Cancel(error) {}
+
+func (t *callTracer) Clear() {
+	t.frames = nil
+	t.root = nil
+}
+
+// GetResult returns the root call of the traced transaction
+func (t *callTracer) GetResult() (interface{}, error) {
+	return t.root, nil
+}
+
+func (t *callTracer) TxStart(uint64) {}
+
+func (t *callTracer) TxEnd(uint64) {}
+
+func (t *callTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	frame := &CallFrame{
+		Type:  toCallType(callType),
+		From:  from,
+		To:    to,
+		Value: new(big.Int),
+		Gas:   gas,
+		Input: append([]byte{}, input...),
+	}
+
+	if value != nil {
+		frame.Value.Set(value)
+	}
+
+	if len(t.frames) == 0 {
+		t.root = frame
+	} else {
+		parent := t.frames[len(t.frames)-1]
+		parent.Calls = append(parent.Calls, frame)
+	}
+
+	t.frames = append(t.frames, frame)
+}
+
+func (t *callTracer) CallEnd(depth int, output []byte, gasUsed uint64, err error) {
+	if len(t.frames) == 0 {
+		return
+	}
+
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	frame.Output = append([]byte{}, output...)
+
+	if err != nil {
+		frame.Error = err.Error()
+	}
+}
+
+func (t *callTracer) CaptureState(
+	[]byte,
+	[]*big.Int,
+	int,
+	types.Address,
+	int,
+	tracer.RuntimeHost,
+	tracer.VMState,
+) {
+}
+
+func (t *callTracer) ExecuteState(
+	types.Address,
+	uint64,
+	string,
+	uint64,
+	uint64,
+	[]byte,
+	int,
+	error,
+	tracer.RuntimeHost,
+) {
+}
+
+// internalTransfers returns the value transfers of the sub calls of the tree
+// which are not reverted, in execution order
+func internalTransfers(root *CallFrame) []*InternalTransfer {
+	transfers := []*InternalTransfer{}
+
+	var walk func(frame *CallFrame, traceAddress []uint64)
+
+	walk = func(frame *CallFrame, traceAddress []uint64) {
+		if frame.Error != "" {
+			return
+		}
+
+		if len(traceAddress) > 0 && frame.Type.transfersValue() && frame.Value.Sign() > 0 {
+			transfers = append(transfers, &InternalTransfer{
+				Depth:        uint64(len(traceAddress) + 1),
+				CallType:     frame.Type,
+				From:         frame.From,
+				To:           frame.To,
+				Value:        frame.Value,
+				TraceAddress: traceAddress,
+			})
+		}
+
+		for idx, call := range frame.Calls {
+			address := make([]uint64, len(traceAddress), len(traceAddress)+1)
+			copy(address, traceAddress)
+
+			walk(call, append(address, uint64(idx)))
+		}
+	}
+
+	if root != nil {
+		walk(root, []uint64{})
+	}
+
+	return transfers
+}
+
+// toCallType returns the call type of the runtime call type, the creations
+// are reported with the opcode by the executor
+func toCallType(callType int) CallType {
+	switch callType {
+	case int(runtime.CallCode):
+		return CallTypeCallCode
+	case int(runtime.DelegateCall):
+		return CallTypeDelegateCall
+	case int(runtime.StaticCall):
+		return CallTypeStaticCall
+	case int(runtime.Create), int(runtime.Create2), int(evm.CREATE), int(evm.CREATE2):
+		return CallTypeCreate
+	default:
+		return CallTypeCall
+	}
+}
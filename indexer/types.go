@@ -0,0 +1,178 @@
+package indexer
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Transaction is a transaction sent or received by an address
+type Transaction struct {
+	BlockNumber uint64     `json:"blockNumber"`
+	BlockHash   types.Hash `json:"blockHash"`
+	TxHash      types.Hash `json:"txHash"`
+	TxIndex     uint64     `json:"txIndex"`
+
+	From  types.Address  `json:"from"`
+	To    *types.Address `json:"to"`
+	Value *big.Int       `json:"value"`
+
+	// ContractAddress is the address of the contract created by the transaction
+	ContractAddress *types.Address `json:"contractAddress"`
+
+	// Failed is set if the transaction was reverted
+	Failed bool `json:"failed"`
+}
+
+// CallType is the type of a call of the call tree
+type CallType string
+
+const (
+	CallTypeCall         CallType = "call"
+	CallTypeCallCode     CallType = "callcode"
+	CallTypeDelegateCall CallType = "delegatecall"
+	CallTypeStaticCall   CallType = "staticcall"
+	CallTypeCreate       CallType = "create"
+)
+
+// transfersValue returns whether the calls of the type move value between accounts,
+// the value of the delegate calls and the call codes stays in the caller
+func (t CallType) transfersValue() bool {
+	return t == CallTypeCall || t == CallTypeCreate
+}
+
+// InternalTransfer is a value transfer made by a contract during the execution of a transaction
+type InternalTransfer struct {
+	BlockNumber uint64     `json:"blockNumber"`
+	BlockHash   types.Hash `json:"blockHash"`
+	TxHash      types.Hash `json:"txHash"`
+	TxIndex     uint64     `json:"txIndex"`
+
+	// Index is the position of the transfer in the transaction
+	Index uint64 `json:"index"`
+
+	// Depth is the call depth of the transfer, starting from 2
+	Depth    uint64        `json:"depth"`
+	CallType CallType      `json:"callType"`
+	From     types.Address `json:"from"`
+	To       types.Address `json:"to"`
+	Value    *big.Int      `json:"value"`
+
+	// TraceAddress is the path to the call in the call tree of the transaction
+	TraceAddress []uint64 `json:"traceAddress"`
+}
+
+// TokenStandard is the standard of the token of a transfer
+type TokenStandard string
+
+const (
+	ERC20  TokenStandard = "erc20"
+	ERC721 TokenStandard = "erc721"
+)
+
+// TokenTransfer is an ERC-20 or ERC-721 Transfer event
+type TokenTransfer struct {
+	BlockNumber uint64     `json:"blockNumber"`
+	BlockHash   types.Hash `json:"blockHash"`
+	TxHash      types.Hash `json:"txHash"`
+	TxIndex     uint64     `json:"txIndex"`
+	LogIndex    uint64     `json:"logIndex"`
+
+	Standard TokenStandard `json:"standard"`
+	Contract types.Address `json:"contract"`
+	From     types.Address `json:"from"`
+	To       types.Address `json:"to"`
+
+	// Value is the amount of the ERC-20 transfers
+	Value *big.Int `json:"value,omitempty"`
+
+	// TokenID is the token of the ERC-721 transfers
+	TokenID *big.Int `json:"tokenId,omitempty"`
+}
+
+// StakeEventType is the type of an event of the staking contract
+type StakeEventType string
+
+const (
+	StakeEventStaked              StakeEventType = "staked"
+	StakeEventUnstaked            StakeEventType = "unstaked"
+	StakeEventWithdrawalRequested StakeEventType = "withdrawalRequested"
+	StakeEventWithdrawn           StakeEventType = "withdrawn"
+)
+
+// StakeEvent is an event of the staking contract
+type StakeEvent struct {
+	BlockNumber uint64     `json:"blockNumber"`
+	BlockHash   types.Hash `json:"blockHash"`
+	TxHash      types.Hash `json:"txHash"`
+	TxIndex     uint64     `json:"txIndex"`
+	LogIndex    uint64     `json:"logIndex"`
+
+	Type    StakeEventType `json:"type"`
+	Account types.Address  `json:"account"`
+	Amount  *big.Int       `json:"amount"`
+
+	// ReleaseEpoch is the epoch the requested withdrawals are released at
+	ReleaseEpoch uint64 `json:"releaseEpoch,omitempty"`
+}
+
+// StakeDelta returns the change of the stake of the account made by the event,
+// the withdrawals move the already unstaked amounts
+func (e *StakeEvent) StakeDelta() *big.Int {
+	switch e.Type {
+	case StakeEventStaked:
+		return new(big.Int).Set(e.Amount)
+	case StakeEventUnstaked:
+		return new(big.Int).Neg(e.Amount)
+	default:
+		return new(big.Int)
+	}
+}
+
+// StakingStats are the cumulative staking stats of the chain at a block, the stakes
+// of the genesis aren't included since they have no events
+type StakingStats struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	Timestamp   uint64 `json:"timestamp"`
+
+	// StakeDelta is the sum of the changes of the stakes up to the block
+	StakeDelta *big.Int `json:"stakeDelta"`
+
+	// Rewards is the sum of the transaction fees paid to the block creators up to the block
+	Rewards *big.Int `json:"rewards"`
+}
+
+// HistoryFilter selects a page of the history of an address
+type HistoryFilter struct {
+	// From and To are the block range of the history
+	From uint64
+	To   uint64
+
+	// Contract selects the transfers of a single token contract, if set
+	Contract *types.Address
+
+	// Cursor is the position of the page in the history, returned along
+	// with the previous page. The history starts from its first entry if nil
+	Cursor []byte
+
+	// Limit is the maximum number of the entries of the page
+	Limit int
+
+	// Descending pages through the history from the most recent entries
+	Descending bool
+}
+
+// blockIndex is the indexed data of a block
+type blockIndex struct {
+	transactions      []*Transaction
+	internalTransfers []*InternalTransfer
+	tokenTransfers    []*TokenTransfer
+	stakeEvents       []*StakeEvent
+
+	// timestamp and rewards are the timestamp and the transaction fees of the block
+	timestamp uint64
+	rewards   *big.Int
+
+	// callTraces are the call trees of the transactions by hash
+	callTraces map[types.Hash]*CallFrame
+}
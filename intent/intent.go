@@ -0,0 +1,212 @@
+package intent
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/intent/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Kinds of the intents known to the validators, any other kind is relayed as is
+const (
+	// KindParam proposes a value for a parameter of the next epochs
+	KindParam = "param"
+
+	// KindUpgrade signals the readiness for an upgrade, its value being the activation block
+	KindUpgrade = "upgrade"
+
+	// KindHalt signals a coordinated halt of the chain, its value being the last block before the halt
+	KindHalt = "halt"
+)
+
+const (
+	// domainName is the name of the EIP-712 domain of the intents
+	domainName = "Polygon Edge Validator Intents"
+
+	// domainVersion is the version of the EIP-712 domain of the intents
+	domainVersion = "1"
+
+	// primaryType is the EIP-712 type of the intents
+	primaryType = "Intent"
+
+	// maxFieldLength is the maximum length of the kind, key and value of an intent
+	maxFieldLength = 256
+)
+
+var (
+	ErrInvalidIntent    = errors.New("invalid intent")
+	ErrInvalidSignature = errors.New("invalid intent signature")
+)
+
+// intentTypes are the EIP-712 types of the intents
+var intentTypes = map[string][]crypto.TypedDataField{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+	},
+	primaryType: {
+		{Name: "kind", Type: "string"},
+		{Name: "key", Type: "string"},
+		{Name: "value", Type: "string"},
+		{Name: "expiry", Type: "uint64"},
+		{Name: "timestamp", Type: "uint64"},
+	},
+}
+
+// Intent is an off-chain intent of a validator, such as a proposed parameter change
+// or an upgrade signal. It's signed by the validator key as EIP-712 typed data
+type Intent struct {
+	Kind  string
+	Key   string
+	Value string
+
+	// Expiry is the number of the last block the intent holds for
+	Expiry uint64
+
+	// Timestamp is the unix time of the signature, a newer intent of a validator
+	// replaces the older one of the same kind and key
+	Timestamp uint64
+}
+
+// Validate checks the fields of the intent
+func (i *Intent) Validate() error {
+	if i.Kind == "" || i.Key == "" {
+		return fmt.Errorf("%w: kind and key are required", ErrInvalidIntent)
+	}
+
+	if len(i.Kind) > maxFieldLength || len(i.Key) > maxFieldLength || len(i.Value) > maxFieldLength {
+		return fmt.Errorf("%w: fields are limited to %d bytes", ErrInvalidIntent, maxFieldLength)
+	}
+
+	if i.Kind == KindUpgrade || i.Kind == KindHalt {
+		if _, err := strconv.ParseUint(i.Value, 10, 64); err != nil {
+			return fmt.Errorf("%w: the value of the %s intents must be a block number", ErrInvalidIntent, i.Kind)
+		}
+	}
+
+	return nil
+}
+
+// TypedData returns the EIP-712 typed data of the intent on the given chain
+func (i *Intent) TypedData(chainID uint64) *crypto.TypedData {
+	return &crypto.TypedData{
+		Types:       intentTypes,
+		PrimaryType: primaryType,
+		Domain: map[string]interface{}{
+			"name":    domainName,
+			"version": domainVersion,
+			"chainId": strconv.FormatUint(chainID, 10),
+		},
+		Message: map[string]interface{}{
+			"kind":      i.Kind,
+			"key":       i.Key,
+			"value":     i.Value,
+			"expiry":    strconv.FormatUint(i.Expiry, 10),
+			"timestamp": strconv.FormatUint(i.Timestamp, 10),
+		},
+	}
+}
+
+// Hash returns the EIP-712 hash of the intent signed by the validators
+func (i *Intent) Hash(chainID uint64) (types.Hash, error) {
+	return i.TypedData(chainID).Hash()
+}
+
+// subject identifies the intents replacing each other
+func (i *Intent) subject() string {
+	return i.Kind + "/" + i.Key
+}
+
+// SignedIntent is an intent with the signature of its validator
+type SignedIntent struct {
+	Intent
+
+	Validator types.Address
+	Signature []byte
+}
+
+// Sign signs the intent with the validator key
+func Sign(key *ecdsa.PrivateKey, chainID uint64, intent *Intent) (*SignedIntent, error) {
+	hash, err := intent.Hash(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := crypto.Sign(key, hash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedIntent{
+		Intent:    *intent,
+		Validator: crypto.PubKeyToAddress(&key.PublicKey),
+		Signature: sig,
+	}, nil
+}
+
+// Recover returns the intent signed by the given signature with its validator.
+// The recovery id of the signature may be either 0/1 or 27/28, as produced by the Ethereum tooling
+func Recover(chainID uint64, intent *Intent, signature []byte) (*SignedIntent, error) {
+	if err := intent.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(signature) != 65 {
+		return nil, ErrInvalidSignature
+	}
+
+	sig := make([]byte, 65)
+	copy(sig, signature)
+
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	if sig[64] > 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	hash, err := intent.Hash(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := crypto.RecoverPubkey(sig, hash.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	return &SignedIntent{
+		Intent:    *intent,
+		Validator: crypto.PubKeyToAddress(pub),
+		Signature: sig,
+	}, nil
+}
+
+// toProto returns the gossiped message of the signed intent
+func (s *SignedIntent) toProto() *proto.Intent {
+	return &proto.Intent{
+		Kind:      s.Kind,
+		Key:       s.Key,
+		Value:     s.Value,
+		Expiry:    s.Expiry,
+		Timestamp: s.Timestamp,
+		Signature: s.Signature,
+	}
+}
+
+// fromProto returns the intent of the gossiped message with its validator
+func fromProto(chainID uint64, msg *proto.Intent) (*SignedIntent, error) {
+	return Recover(chainID, &Intent{
+		Kind:      msg.Kind,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Expiry:    msg.Expiry,
+		Timestamp: msg.Timestamp,
+	}, msg.Signature)
+}
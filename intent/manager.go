@@ -0,0 +1,350 @@
+package intent
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/intent/proto"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+const (
+	// topicNameV1 is the gossip topic of the validator intents
+	topicNameV1 = "validator-intents/0.1"
+
+	// maxIntentsPerValidator is the maximum number of the intents of a validator held at once
+	maxIntentsPerValidator = 64
+)
+
+var (
+	ErrNotValidator   = errors.New("the signer of the intent isn't a validator")
+	ErrIntentExpired  = errors.New("intent expired")
+	ErrStaleIntent    = errors.New("a newer intent of the validator is known for the same subject")
+	ErrTooManyIntents = errors.New("too many intents of the validator")
+	ErrNoValidatorKey = errors.New("the node has no validator key")
+	ErrMalformedMsg   = errors.New("malformed intent message")
+)
+
+// Blockchain is the interface of the blockchain the intents expire on
+type Blockchain interface {
+	Header() *types.Header
+}
+
+// ValidatorSetProvider returns the validator set of a height
+type ValidatorSetProvider interface {
+	GetValidators(height uint64) (validators.Validators, error)
+}
+
+// Network is the interface of the network gossiping the intents
+type Network interface {
+	NewTopic(protoID string, obj gproto.Message) (*network.Topic, error)
+}
+
+// Signal is the support of the current validators for a value of a subject
+type Signal struct {
+	Kind  string
+	Key   string
+	Value string
+
+	// Validators are the validators signaling the value, sorted by address
+	Validators []types.Address
+
+	// Total is the number of the current validators
+	Total int
+}
+
+// Supermajority returns true if more than 2/3 of the current validators signal the value
+func (s *Signal) Supermajority() bool {
+	return 3*len(s.Validators) > 2*s.Total
+}
+
+// Manager holds the latest intents of the current validators, exchanged over the gossip network
+type Manager struct {
+	logger        hclog.Logger
+	chainID       uint64
+	blockchain    Blockchain
+	validatorSets ValidatorSetProvider
+
+	// key signs the intents of this node, nil if it isn't a validator
+	key *ecdsa.PrivateKey
+
+	topic *network.Topic
+
+	lock    sync.RWMutex
+	intents map[types.Address]map[string]*SignedIntent
+}
+
+// NewManager creates a manager of the intents of the given chain.
+// The node signals intents only if key is not nil
+func NewManager(
+	logger hclog.Logger,
+	chainID uint64,
+	blockchain Blockchain,
+	validatorSets ValidatorSetProvider,
+	key *ecdsa.PrivateKey,
+) *Manager {
+	return &Manager{
+		logger:        logger.Named("intent"),
+		chainID:       chainID,
+		blockchain:    blockchain,
+		validatorSets: validatorSets,
+		key:           key,
+		intents:       make(map[types.Address]map[string]*SignedIntent),
+	}
+}
+
+// Start subscribes to the intents gossiped by the other nodes
+func (m *Manager) Start(net Network) error {
+	topic, err := net.NewTopic(topicNameV1, &proto.Intent{})
+	if err != nil {
+		return err
+	}
+
+	topic.SetValidator(m.validateGossipIntent)
+
+	if err := topic.Subscribe(m.addGossipIntent); err != nil {
+		return err
+	}
+
+	m.topic = topic
+
+	return nil
+}
+
+// Signal signs the intent with the key of the node and publishes it
+func (m *Manager) Signal(intent *Intent) (*SignedIntent, error) {
+	if m.key == nil {
+		return nil, ErrNoValidatorKey
+	}
+
+	if err := intent.Validate(); err != nil {
+		return nil, err
+	}
+
+	signed := *intent
+	signed.Timestamp = uint64(time.Now().Unix())
+
+	res, err := Sign(m.key, m.chainID, &signed)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.Submit(res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Submit adds an intent signed by a validator and publishes it
+func (m *Manager) Submit(signed *SignedIntent) error {
+	if err := m.Add(signed); err != nil {
+		return err
+	}
+
+	if m.topic == nil {
+		return nil
+	}
+
+	return m.topic.Publish(signed.toProto())
+}
+
+// Add adds an intent of a current validator, replacing its older intent of the same subject
+func (m *Manager) Add(signed *SignedIntent) error {
+	head := m.blockchain.Header().Number
+
+	if signed.Expiry < head {
+		return ErrIntentExpired
+	}
+
+	vals, err := m.validatorSets.GetValidators(head)
+	if err != nil {
+		return err
+	}
+
+	if !vals.Includes(signed.Validator) {
+		return ErrNotValidator
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	intents, ok := m.intents[signed.Validator]
+	if !ok {
+		intents = make(map[string]*SignedIntent)
+		m.intents[signed.Validator] = intents
+	}
+
+	subject := signed.subject()
+
+	if prev, ok := intents[subject]; ok {
+		if prev.Timestamp >= signed.Timestamp {
+			return ErrStaleIntent
+		}
+	} else {
+		for key, intent := range intents {
+			if intent.Expiry < head {
+				delete(intents, key)
+			}
+		}
+
+		if len(intents) >= maxIntentsPerValidator {
+			return ErrTooManyIntents
+		}
+	}
+
+	intents[subject] = signed
+
+	return nil
+}
+
+// Intents returns the unexpired intents of the current validators,
+// sorted by validator then subject
+func (m *Manager) Intents() ([]*SignedIntent, error) {
+	head := m.blockchain.Header().Number
+
+	vals, err := m.validatorSets.GetValidators(head)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.activeIntents(head, vals), nil
+}
+
+// activeIntents returns the intents of the given validators unexpired at the head
+func (m *Manager) activeIntents(head uint64, vals validators.Validators) []*SignedIntent {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	res := make([]*SignedIntent, 0)
+
+	for validator, intents := range m.intents {
+		if !vals.Includes(validator) {
+			continue
+		}
+
+		for _, intent := range intents {
+			if intent.Expiry >= head {
+				res = append(res, intent)
+			}
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Validator != res[j].Validator {
+			return res[i].Validator.String() < res[j].Validator.String()
+		}
+
+		return res[i].subject() < res[j].subject()
+	})
+
+	return res
+}
+
+// Signals returns the values signaled by the current validators, with their support
+func (m *Manager) Signals() ([]*Signal, error) {
+	head := m.blockchain.Header().Number
+
+	vals, err := m.validatorSets.GetValidators(head)
+	if err != nil {
+		return nil, err
+	}
+
+	intents := m.activeIntents(head, vals)
+
+	type signalKey struct {
+		kind, key, value string
+	}
+
+	res := make([]*Signal, 0)
+	signals := make(map[signalKey]*Signal)
+
+	// the intents are sorted by validator, so are the validators of the signals
+	for _, intent := range intents {
+		key := signalKey{intent.Kind, intent.Key, intent.Value}
+
+		signal, ok := signals[key]
+		if !ok {
+			signal = &Signal{
+				Kind:       intent.Kind,
+				Key:        intent.Key,
+				Value:      intent.Value,
+				Validators: []types.Address{},
+				Total:      vals.Len(),
+			}
+
+			signals[key] = signal
+			res = append(res, signal)
+		}
+
+		signal.Validators = append(signal.Validators, intent.Validator)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Kind != res[j].Kind {
+			return res[i].Kind < res[j].Kind
+		}
+
+		if res[i].Key != res[j].Key {
+			return res[i].Key < res[j].Key
+		}
+
+		return len(res[i].Validators) > len(res[j].Validators)
+	})
+
+	return res, nil
+}
+
+// validateGossipIntent checks the signature of the gossiped intent. The intents of
+// the non-validators and the stale intents are ignored, the other nodes may be at another height
+func (m *Manager) validateGossipIntent(obj interface{}, _ peer.ID) error {
+	msg, ok := obj.(*proto.Intent)
+	if !ok {
+		return ErrMalformedMsg
+	}
+
+	signed, err := fromProto(m.chainID, msg)
+	if err != nil {
+		return err
+	}
+
+	head := m.blockchain.Header().Number
+	if signed.Expiry < head {
+		return network.ErrIgnoreMessage
+	}
+
+	vals, err := m.validatorSets.GetValidators(head)
+	if err != nil || !vals.Includes(signed.Validator) {
+		return network.ErrIgnoreMessage
+	}
+
+	return nil
+}
+
+func (m *Manager) addGossipIntent(obj interface{}, _ peer.ID) {
+	msg, ok := obj.(*proto.Intent)
+	if !ok {
+		m.logger.Error("failed to cast gossiped message to intent")
+
+		return
+	}
+
+	signed, err := fromProto(m.chainID, msg)
+	if err != nil {
+		m.logger.Debug("invalid gossiped intent", "err", err)
+
+		return
+	}
+
+	if err := m.Add(signed); err != nil && !errors.Is(err, ErrStaleIntent) {
+		m.logger.Debug("failed to add gossiped intent", "validator", signed.Validator, "err", err)
+	}
+}
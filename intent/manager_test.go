@@ -0,0 +1,147 @@
+package intent
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = 100
+
+type mockBlockchain struct {
+	head uint64
+}
+
+func (m *mockBlockchain) Header() *types.Header {
+	return &types.Header{Number: m.head}
+}
+
+type mockValidatorSetProvider struct {
+	vals validators.Validators
+}
+
+func (m *mockValidatorSetProvider) GetValidators(uint64) (validators.Validators, error) {
+	return m.vals, nil
+}
+
+func newTestKeys(t *testing.T, n int) ([]*ecdsa.PrivateKey, validators.Validators) {
+	t.Helper()
+
+	keys := make([]*ecdsa.PrivateKey, n)
+	vals := validators.NewECDSAValidatorSet()
+
+	for i := range keys {
+		key, err := crypto.GenerateECDSAKey()
+		require.NoError(t, err)
+
+		keys[i] = key
+
+		require.NoError(t, vals.Add(validators.NewECDSAValidator(crypto.PubKeyToAddress(&key.PublicKey))))
+	}
+
+	return keys, vals
+}
+
+func TestIntent_SignAndRecover(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	intent := &Intent{Kind: KindUpgrade, Key: "london", Value: "1000", Expiry: 900, Timestamp: 1}
+
+	signed, err := Sign(key, testChainID, intent)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&key.PublicKey), signed.Validator)
+
+	// the signatures of the Ethereum tooling have a recovery id of 27 or 28
+	sig := append([]byte{}, signed.Signature...)
+	sig[64] += 27
+
+	recovered, err := Recover(testChainID, intent, sig)
+	require.NoError(t, err)
+	assert.Equal(t, signed, recovered)
+
+	// the intents of another chain are signed by another domain
+	recovered, err = Recover(testChainID+1, intent, signed.Signature)
+	require.NoError(t, err)
+	assert.NotEqual(t, signed.Validator, recovered.Validator)
+
+	_, err = Recover(testChainID, intent, signed.Signature[:64])
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	_, err = Recover(testChainID, &Intent{Kind: KindUpgrade, Key: "london", Value: "soon"}, signed.Signature)
+	assert.ErrorIs(t, err, ErrInvalidIntent)
+}
+
+func TestManager_AddAndSignals(t *testing.T) {
+	t.Parallel()
+
+	keys, vals := newTestKeys(t, 4)
+	blockchain := &mockBlockchain{head: 10}
+
+	manager := NewManager(hclog.NewNullLogger(), testChainID, blockchain, &mockValidatorSetProvider{vals}, keys[0])
+
+	add := func(key *ecdsa.PrivateKey, value string, expiry, timestamp uint64) error {
+		signed, err := Sign(key, testChainID, &Intent{
+			Kind:      KindParam,
+			Key:       "epochSize",
+			Value:     value,
+			Expiry:    expiry,
+			Timestamp: timestamp,
+		})
+		require.NoError(t, err)
+
+		return manager.Add(signed)
+	}
+
+	require.NoError(t, add(keys[1], "100", 20, 1))
+	require.NoError(t, add(keys[2], "100", 20, 1))
+	require.NoError(t, add(keys[3], "200", 20, 1))
+
+	// the intent of the node is signed with its key
+	signed, err := manager.Signal(&Intent{Kind: KindParam, Key: "epochSize", Value: "100", Expiry: 20})
+	require.NoError(t, err)
+	assert.Equal(t, vals.At(0).Addr(), signed.Validator)
+
+	// a validator changes its mind, the older intent can't be replayed
+	require.NoError(t, add(keys[3], "100", 20, 2))
+	assert.ErrorIs(t, add(keys[3], "200", 20, 1), ErrStaleIntent)
+
+	assert.ErrorIs(t, add(keys[2], "100", 5, 3), ErrIntentExpired)
+
+	outsider, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+	assert.ErrorIs(t, add(outsider, "100", 20, 1), ErrNotValidator)
+
+	signals, err := manager.Signals()
+	require.NoError(t, err)
+	require.Len(t, signals, 1)
+	assert.Equal(t, "100", signals[0].Value)
+	assert.Len(t, signals[0].Validators, 4)
+	assert.True(t, signals[0].Supermajority())
+
+	// the intents lapse after their expiry
+	blockchain.head = 21
+
+	intents, err := manager.Intents()
+	require.NoError(t, err)
+	assert.Empty(t, intents)
+}
+
+func TestManager_SignalWithoutKey(t *testing.T) {
+	t.Parallel()
+
+	_, vals := newTestKeys(t, 1)
+
+	manager := NewManager(hclog.NewNullLogger(), testChainID, &mockBlockchain{}, &mockValidatorSetProvider{vals}, nil)
+
+	_, err := manager.Signal(&Intent{Kind: KindParam, Key: "epochSize", Value: "100"})
+	assert.ErrorIs(t, err, ErrNoValidatorKey)
+}
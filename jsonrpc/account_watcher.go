@@ -0,0 +1,174 @@
+package jsonrpc
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// maxWatchedAccounts is the maximum number of the accounts of a single subscription
+const maxWatchedAccounts = 10000
+
+var (
+	ErrNoWatchedAccounts      = errors.New("no accounts to watch")
+	ErrTooManyWatchedAccounts = errors.New("too many accounts to watch")
+)
+
+// accountStateReader reads the accounts from the state
+type accountStateReader interface {
+	// GetAccount returns the account at the state root, ErrStateNotFound if it doesn't exist
+	GetAccount(root types.Hash, addr types.Address) (*Account, error)
+}
+
+// AccountChange is the new balance and nonce of a watched account
+type AccountChange struct {
+	Address     types.Address
+	Balance     *big.Int
+	Nonce       uint64
+	BlockNumber uint64
+	BlockHash   types.Hash
+
+	// Reorg is set if the change is caused by the replacement of canonical blocks,
+	// such as the revert of the state of the removed blocks
+	Reorg bool
+}
+
+// accountChange is the JSON form of AccountChange
+type accountChange struct {
+	Address     types.Address `json:"address"`
+	Balance     argBig        `json:"balance"`
+	Nonce       argUint64     `json:"nonce"`
+	BlockNumber argUint64     `json:"blockNumber"`
+	BlockHash   types.Hash    `json:"blockHash"`
+	Reorg       bool          `json:"reorg"`
+}
+
+func toAccountChange(change *AccountChange) *accountChange {
+	return &accountChange{
+		Address:     change.Address,
+		Balance:     argBig(*change.Balance),
+		Nonce:       argUint64(change.Nonce),
+		BlockNumber: argUint64(change.BlockNumber),
+		BlockHash:   change.BlockHash,
+		Reorg:       change.Reorg,
+	}
+}
+
+// accountState is the last known state of a watched account
+type accountState struct {
+	balance *big.Int
+	nonce   uint64
+}
+
+// AccountWatcher tracks the balances and the nonces of a set of accounts
+// and reports their changes as the canonical chain moves
+type AccountWatcher struct {
+	store     accountStateReader
+	addresses []types.Address
+	states    map[types.Address]accountState
+}
+
+// NewAccountWatcher creates a watcher of the accounts starting from their state at the header
+func NewAccountWatcher(
+	store accountStateReader,
+	addresses []types.Address,
+	header *types.Header,
+) (*AccountWatcher, error) {
+	if len(addresses) == 0 {
+		return nil, ErrNoWatchedAccounts
+	}
+
+	if len(addresses) > maxWatchedAccounts {
+		return nil, ErrTooManyWatchedAccounts
+	}
+
+	w := &AccountWatcher{
+		store:     store,
+		addresses: make([]types.Address, 0, len(addresses)),
+		states:    make(map[types.Address]accountState, len(addresses)),
+	}
+
+	for _, addr := range addresses {
+		if _, ok := w.states[addr]; ok {
+			continue
+		}
+
+		state, err := w.readState(header.StateRoot, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		w.addresses = append(w.addresses, addr)
+		w.states[addr] = state
+	}
+
+	return w, nil
+}
+
+// Snapshot returns the last known state of the watched accounts at the header
+func (w *AccountWatcher) Snapshot(header *types.Header) []*AccountChange {
+	changes := make([]*AccountChange, 0, len(w.addresses))
+
+	for _, addr := range w.addresses {
+		changes = append(changes, w.toChange(addr, header, false))
+	}
+
+	return changes
+}
+
+// Update reads the watched accounts at the new canonical header
+// and returns the accounts whose balance or nonce changed
+func (w *AccountWatcher) Update(header *types.Header, reorg bool) ([]*AccountChange, error) {
+	changes := []*AccountChange{}
+
+	for _, addr := range w.addresses {
+		state, err := w.readState(header.StateRoot, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		last := w.states[addr]
+		if last.nonce == state.nonce && last.balance.Cmp(state.balance) == 0 {
+			continue
+		}
+
+		w.states[addr] = state
+
+		changes = append(changes, w.toChange(addr, header, reorg))
+	}
+
+	return changes, nil
+}
+
+func (w *AccountWatcher) toChange(addr types.Address, header *types.Header, reorg bool) *AccountChange {
+	state := w.states[addr]
+
+	return &AccountChange{
+		Address:     addr,
+		Balance:     new(big.Int).Set(state.balance),
+		Nonce:       state.nonce,
+		BlockNumber: header.Number,
+		BlockHash:   header.Hash,
+		Reorg:       reorg,
+	}
+}
+
+// readState reads the account at the state root, the missing accounts are empty
+func (w *AccountWatcher) readState(root types.Hash, addr types.Address) (accountState, error) {
+	acc, err := w.store.GetAccount(root, addr)
+	if errors.Is(err, ErrStateNotFound) {
+		return accountState{balance: new(big.Int)}, nil
+	}
+
+	if err != nil {
+		return accountState{}, err
+	}
+
+	state := accountState{balance: new(big.Int), nonce: acc.Nonce}
+	if acc.Balance != nil {
+		state.balance.Set(acc.Balance)
+	}
+
+	return state, nil
+}
@@ -0,0 +1,122 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAccountStates is the state of the accounts by state root
+type mockAccountStates map[types.Hash]map[types.Address]*Account
+
+func (m mockAccountStates) GetAccount(root types.Hash, addr types.Address) (*Account, error) {
+	if acc, ok := m[root][addr]; ok {
+		return acc, nil
+	}
+
+	return nil, ErrStateNotFound
+}
+
+func TestAccountWatcher_Update(t *testing.T) {
+	t.Parallel()
+
+	rootA, rootB, rootC := types.StringToHash("a"), types.StringToHash("b"), types.StringToHash("c")
+
+	states := mockAccountStates{
+		rootA: {
+			addr1: {Balance: big.NewInt(10), Nonce: 1},
+		},
+		rootB: {
+			addr1: {Balance: big.NewInt(10), Nonce: 1},
+			addr2: {Balance: big.NewInt(5)},
+		},
+		rootC: {
+			addr1: {Balance: big.NewInt(7), Nonce: 2},
+		},
+	}
+
+	genesis := &types.Header{Number: 0, Hash: hash1, StateRoot: rootA}
+
+	watcher, err := NewAccountWatcher(states, []types.Address{addr1, addr2, addr1}, genesis)
+	require.NoError(t, err)
+
+	// the duplicated addresses are watched once
+	snapshot := watcher.Snapshot(genesis)
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, big.NewInt(10), snapshot[0].Balance)
+	assert.Equal(t, uint64(1), snapshot[0].Nonce)
+	assert.Equal(t, big.NewInt(0), snapshot[1].Balance)
+
+	// only the changed accounts are reported
+	changes, err := watcher.Update(&types.Header{Number: 1, Hash: hash2, StateRoot: rootB}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []*AccountChange{
+		{Address: addr2, Balance: big.NewInt(5), BlockNumber: 1, BlockHash: hash2},
+	}, changes)
+
+	// the reorg reverts the balance of the second account
+	changes, err = watcher.Update(&types.Header{Number: 1, Hash: hash3, StateRoot: rootC}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []*AccountChange{
+		{Address: addr1, Balance: big.NewInt(7), Nonce: 2, BlockNumber: 1, BlockHash: hash3, Reorg: true},
+		{Address: addr2, Balance: big.NewInt(0), BlockNumber: 1, BlockHash: hash3, Reorg: true},
+	}, changes)
+
+	changes, err = watcher.Update(&types.Header{Number: 2, StateRoot: rootC}, false)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	_, err = NewAccountWatcher(states, nil, genesis)
+	assert.ErrorIs(t, err, ErrNoWatchedAccounts)
+}
+
+func TestFilterManager_AccountFilter(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	store.SetAccount(addr1, &Account{Balance: big.NewInt(1)})
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	id, err := m.NewAccountFilter([]types.Address{addr1}, nil)
+	require.NoError(t, err)
+
+	// the current state of the accounts comes first
+	res, err := m.GetFilterChanges(id)
+	require.NoError(t, err)
+
+	changes, ok := res.([]*accountChange)
+	require.True(t, ok)
+	require.Len(t, changes, 1)
+	assert.Equal(t, argBig(*big.NewInt(1)), changes[0].Balance)
+
+	store.SetAccount(addr1, &Account{Balance: big.NewInt(2), Nonce: 1})
+
+	require.NoError(t, m.dispatchEvent(&blockchain.Event{
+		Type:     blockchain.EventReorg,
+		NewChain: []*types.Header{{Number: 1, Hash: hash1}},
+	}))
+
+	res, err = m.GetFilterChanges(id)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	var updates []map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &updates))
+	require.Len(t, updates, 1)
+
+	assert.Equal(t, addr1.String(), updates[0]["address"])
+	assert.Equal(t, "0x2", updates[0]["balance"])
+	assert.Equal(t, "0x1", updates[0]["nonce"])
+	assert.Equal(t, "0x1", updates[0]["blockNumber"])
+	assert.Equal(t, true, updates[0]["reorg"])
+}
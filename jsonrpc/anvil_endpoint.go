@@ -0,0 +1,52 @@
+package jsonrpc
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Impersonator manages the accounts impersonated in the dev consensus,
+// whose transactions are accepted without a valid signature
+type Impersonator interface {
+	// ImpersonateAccount accepts the transactions of the account without a valid signature
+	ImpersonateAccount(addr types.Address)
+
+	// StopImpersonatingAccount requires again the transactions of the account to be signed
+	StopImpersonatingAccount(addr types.Address)
+
+	// IsImpersonated returns true if the account is impersonated
+	IsImpersonated(addr types.Address) bool
+}
+
+// Anvil is the jsonrpc endpoint of the account impersonation in the dev consensus,
+// served in both the anvil and the hardhat namespaces
+type Anvil struct {
+	store DevStore
+}
+
+// ImpersonateAccount lets eth_sendTransaction send the transactions of the account,
+// without the node holding its key
+func (a *Anvil) ImpersonateAccount(addr types.Address) (interface{}, error) {
+	a.store.ImpersonateAccount(addr)
+
+	return nil, nil
+}
+
+// StopImpersonatingAccount stops the impersonation of the account
+func (a *Anvil) StopImpersonatingAccount(addr types.Address) (interface{}, error) {
+	a.store.StopImpersonatingAccount(addr)
+
+	return nil, nil
+}
+
+// impersonatedTx returns the transaction of an impersonated account with a placeholder signature.
+// The signature is made of the sender address, so that the transactions of two accounts
+// with the same fields still have different hashes
+func impersonatedTx(tx *types.Transaction) (*types.Transaction, error) {
+	tx.V = big.NewInt(0)
+	tx.R = new(big.Int).SetBytes(tx.From.Bytes())
+	tx.S = big.NewInt(1)
+
+	return tx, nil
+}
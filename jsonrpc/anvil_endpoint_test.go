@@ -0,0 +1,62 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnvilEndpoint_Impersonation(t *testing.T) {
+	t.Parallel()
+
+	params := &dispatcherParams{jsonRPCBatchLengthLimit: 20, blockRangeLimit: 1000}
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), params)
+
+	store := &mockDevStore{}
+	dispatcher.registerDevEndpoints(store)
+
+	addr := types.StringToAddress("0xabcd")
+
+	// both namespaces are served
+	_, err := dispatcher.Handle([]byte(`{"method": "hardhat_impersonateAccount", "params": ["` + addr.String() + `"], "id": 1}`))
+	require.NoError(t, err)
+	assert.True(t, store.IsImpersonated(addr))
+
+	_, err = dispatcher.Handle([]byte(`{"method": "anvil_stopImpersonatingAccount", "params": ["` + addr.String() + `"], "id": 1}`))
+	require.NoError(t, err)
+	assert.False(t, store.IsImpersonated(addr))
+}
+
+func TestEth_SendTransactionImpersonated(t *testing.T) {
+	t.Parallel()
+
+	txStore := &mockStoreTxn{}
+	eth := newTestEthEndpoint(txStore)
+
+	dev := &mockDevStore{}
+	eth.impersonator = dev
+
+	// the node doesn't manage the account
+	_, err := eth.SendTransaction(newTestTxnArgs(addr0))
+	assert.Error(t, err)
+
+	dev.ImpersonateAccount(addr0)
+
+	res, err := eth.SendTransaction(newTestTxnArgs(addr0))
+	require.NoError(t, err)
+
+	assert.Equal(t, txStore.txn.Hash.String(), res)
+	assert.Equal(t, addr0, txStore.txn.From)
+	assert.Equal(t, new(big.Int).SetBytes(addr0.Bytes()), txStore.txn.R)
+
+	// the same transaction of another account has another hash
+	dev.ImpersonateAccount(addr1)
+
+	_, err = eth.SendTransaction(newTestTxnArgs(addr1))
+	require.NoError(t, err)
+	assert.NotEqual(t, res, txStore.txn.Hash.String())
+}
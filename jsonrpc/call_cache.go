@@ -0,0 +1,162 @@
+package jsonrpc
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+)
+
+// callCacheKey identifies a call executed on the state of a block. The state root alone
+// isn't enough, the calls also see the context of the block, such as its number and timestamp
+type callCacheKey struct {
+	block types.Hash
+	call  types.Hash
+}
+
+// newCallCacheKey returns the key of the call on the block, the call is hashed
+// with all the fields the execution depends on
+func newCallCacheKey(block types.Hash, txn *types.Transaction) callCacheKey {
+	var to []byte
+	if txn.To != nil {
+		to = txn.To.Bytes()
+	}
+
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	buf := make([]byte, 8)
+
+	writeUint := func(n uint64) {
+		binary.BigEndian.PutUint64(buf, n)
+		hasher.Write(buf) //nolint:errcheck
+	}
+
+	writeUint(txn.Nonce)
+	writeUint(txn.Gas)
+
+	// the variable length fields are prefixed with their length
+	for _, field := range [][]byte{
+		txn.From.Bytes(),
+		to,
+		txn.GasPrice.Bytes(),
+		txn.Value.Bytes(),
+		txn.Input,
+	} {
+		writeUint(uint64(len(field)))
+		hasher.Write(field) //nolint:errcheck
+	}
+
+	key := callCacheKey{block: block}
+	hasher.SumTo((*[32]byte)(&key.call))
+
+	return key
+}
+
+// callCacheEntry is a cached call result. Its priority is the gas the call used
+// on top of the clock of the cache when last accessed
+type callCacheEntry struct {
+	key      callCacheKey
+	result   *runtime.ExecutionResult
+	priority uint64
+	index    int
+}
+
+// callCache caches the results of the eth_call requests, so that the identical calls polled on the
+// same block are only executed once. It is bounded by its number of entries, the entries are weighted
+// by the gas used by their call (GreedyDual): the cheapest calls are evicted first, the clock is raised
+// to the priority of the evicted entries so that the expensive calls not accessed anymore age out
+type callCache struct {
+	lock    sync.Mutex
+	size    int
+	clock   uint64
+	entries map[callCacheKey]*callCacheEntry
+	queue   callCacheQueue
+}
+
+func newCallCache(size int) *callCache {
+	return &callCache{
+		size:    size,
+		entries: make(map[callCacheKey]*callCacheEntry, size),
+		queue:   make(callCacheQueue, 0, size),
+	}
+}
+
+// get returns the cached result of the call
+func (c *callCache) get(key callCacheKey) (*runtime.ExecutionResult, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		metrics.IncrCounter([]string{"jsonrpc", "call_cache", "misses"}, 1)
+
+		return nil, false
+	}
+
+	metrics.IncrCounter([]string{"jsonrpc", "call_cache", "hits"}, 1)
+
+	entry.priority = c.clock + entry.result.GasUsed
+	heap.Fix(&c.queue, entry.index)
+
+	return entry.result, true
+}
+
+// add caches the result of the call, evicting the entry of the lowest priority if the cache is full
+func (c *callCache) add(key callCacheKey, result *runtime.ExecutionResult) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+
+	if len(c.entries) >= c.size {
+		evicted, _ := heap.Pop(&c.queue).(*callCacheEntry)
+		delete(c.entries, evicted.key)
+
+		c.clock = evicted.priority
+	}
+
+	entry := &callCacheEntry{
+		key:      key,
+		result:   result,
+		priority: c.clock + result.GasUsed,
+	}
+
+	c.entries[key] = entry
+	heap.Push(&c.queue, entry)
+}
+
+// callCacheQueue is a min-heap of the entries by priority
+type callCacheQueue []*callCacheEntry
+
+func (q callCacheQueue) Len() int { return len(q) }
+
+func (q callCacheQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+
+func (q callCacheQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *callCacheQueue) Push(x interface{}) {
+	entry, _ := x.(*callCacheEntry)
+	entry.index = len(*q)
+	*q = append(*q, entry)
+}
+
+func (q *callCacheQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+
+	return entry
+}
@@ -0,0 +1,73 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallCache_Key(t *testing.T) {
+	t.Parallel()
+
+	to := types.StringToAddress("1")
+	txn := &types.Transaction{
+		From:     types.StringToAddress("2"),
+		To:       &to,
+		Gas:      100000,
+		GasPrice: big.NewInt(1),
+		Value:    big.NewInt(0),
+		Input:    []byte{0x1, 0x2},
+	}
+
+	block := types.StringToHash("1")
+	key := newCallCacheKey(block, txn)
+
+	assert.Equal(t, key, newCallCacheKey(block, txn.Copy()))
+
+	// the calls on another block or with another field are distinct
+	assert.NotEqual(t, key, newCallCacheKey(types.StringToHash("2"), txn))
+
+	other := txn.Copy()
+	other.From = types.StringToAddress("3")
+	assert.NotEqual(t, key, newCallCacheKey(block, other))
+
+	other = txn.Copy()
+	other.Input = []byte{0x1}
+	other.Value = big.NewInt(2)
+	assert.NotEqual(t, key, newCallCacheKey(block, other))
+}
+
+func TestCallCache_GasWeightedEviction(t *testing.T) {
+	t.Parallel()
+
+	cache := newCallCache(2)
+
+	key := func(i byte) callCacheKey {
+		return callCacheKey{call: types.BytesToHash([]byte{i})}
+	}
+
+	cache.add(key(1), &runtime.ExecutionResult{GasUsed: 1000})
+	cache.add(key(2), &runtime.ExecutionResult{GasUsed: 10})
+
+	// the cheapest call is evicted first, though it's the most recent
+	cache.add(key(3), &runtime.ExecutionResult{GasUsed: 100})
+
+	_, ok := cache.get(key(2))
+	assert.False(t, ok)
+
+	result, ok := cache.get(key(1))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1000), result.GasUsed)
+
+	// the accessed expensive call outranks the calls added since
+	cache.add(key(4), &runtime.ExecutionResult{GasUsed: 10})
+	cache.add(key(5), &runtime.ExecutionResult{GasUsed: 10})
+
+	_, ok = cache.get(key(3))
+	assert.False(t, ok)
+
+	assert.Len(t, cache.entries, 2)
+}
@@ -209,7 +209,12 @@ func NewRPCResponse(id interface{}, jsonrpcver string, reply []byte, err Error)
 	case nil:
 		response = &SuccessResponse{JSONRPC: jsonrpcver, ID: id, Result: reply}
 	default:
-		response = NewRPCErrorResponse(id, err.ErrorCode(), err.Error(), jsonrpcver)
+		errObject := &ObjectError{Code: err.ErrorCode(), Message: err.Error()}
+		if dataErr, ok := err.(DataError); ok {
+			errObject.Data = dataErr.ErrorData()
+		}
+
+		response = &ErrorResponse{JSONRPC: jsonrpcver, ID: id, Error: errObject}
 	}
 
 	return response
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/types"
@@ -106,3 +107,27 @@ func TestBlockNumberOrHash_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRPCResponse_ErrorData(t *testing.T) {
+	t.Parallel()
+
+	res, err := NewRPCResponse(1, "2.0", nil, &revertError{
+		reason: "not allowed",
+		data:   []byte{0x1, 0x2},
+	}).Bytes()
+	assert.NoError(t, err)
+
+	var decoded struct {
+		Error ObjectError `json:"error"`
+	}
+
+	assert.NoError(t, json.Unmarshal(res, &decoded))
+	assert.Equal(t, 3, decoded.Error.Code)
+	assert.Equal(t, "execution was reverted: not allowed", decoded.Error.Message)
+	assert.Equal(t, "0x0102", decoded.Error.Data)
+
+	// the errors without data don't have the data field
+	res, err = NewRPCResponse(1, "2.0", nil, NewInvalidRequestError("invalid")).Bytes()
+	assert.NoError(t, err)
+	assert.NotContains(t, string(res), "data")
+}
@@ -6,19 +6,29 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/jstracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/profiler"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
 var (
-	defaultTraceTimeout = 5 * time.Second
+	defaultTraceTimeout   = 5 * time.Second
+	defaultProfileTimeout = time.Minute
+
+	// maxProfileBlockRange is the maximum number of blocks profiled by a request
+	maxProfileBlockRange uint64 = 1000
 
 	// ErrExecutionTimeout indicates the execution was terminated due to timeout
 	ErrExecutionTimeout = errors.New("execution timeout")
 	// ErrTraceGenesisBlock is an error returned when tracing genesis block which can't be traced
 	ErrTraceGenesisBlock = errors.New("genesis is not traceable")
+	// ErrInvalidProfileRange is an error returned when the profiled block range is invalid
+	ErrInvalidProfileRange = errors.New("invalid block range")
 )
 
 type debugBlockchainStore interface {
@@ -45,6 +55,21 @@ type debugBlockchainStore interface {
 
 	// TraceCall traces a single call at the point when the given header is mined
 	TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
+
+	// GetStateDiffByHash returns the persisted state diff of the block
+	GetStateDiffByHash(hash types.Hash) (*types.StateDiff, error)
+
+	// GetConflictStats re-executes the block recording the accesses of its transactions
+	GetConflictStats(*types.Block) (*state.ConflictStats, error)
+
+	// GetWitness re-executes the block recording the parent state it touches
+	GetWitness(*types.Block) (*state.Witness, error)
+
+	// GetReceiptsByHash returns the receipts for a block hash
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+
+	// ReplayBlock re-executes the block against its parent state, tracing all its transactions or the target one
+	ReplayBlock(*types.Block, *types.Hash, tracer.Tracer) (*state.Replay, error)
 }
 
 type debugTxPoolStore interface {
@@ -72,6 +97,28 @@ type TraceConfig struct {
 	DisableStorage   bool    `json:"disableStorage"`
 	EnableReturnData bool    `json:"enableReturnData"`
 	Timeout          *string `json:"timeout"`
+	// Tracer is the JavaScript expression of a tracer object, as the tracers of go-ethereum,
+	// the struct logger is used if omitted
+	Tracer *string `json:"tracer"`
+}
+
+// ProfileConfig is the configuration of a block range profiling
+type ProfileConfig struct {
+	Timeout *string `json:"timeout"`
+}
+
+// ReplayConfig is the configuration of a block replay
+type ReplayConfig struct {
+	// TxHash is the transaction traced, all the transactions of the block are traced if omitted
+	TxHash *types.Hash `json:"txHash"`
+	TraceConfig
+}
+
+// blocksProfile is the debug_profileBlocks result
+type blocksProfile struct {
+	From argUint64 `json:"from"`
+	To   argUint64 `json:"to"`
+	*profiler.Profile
 }
 
 func (d *Debug) TraceBlockByNumber(
@@ -173,6 +220,183 @@ func (d *Debug) TraceCall(
 	return d.store.TraceCall(tx, header, tracer)
 }
 
+// ProfileBlocks aggregates the gas and the time spent per opcode and per contract
+// by the transactions of the block range, both ends included
+func (d *Debug) ProfileBlocks(
+	fromBlock BlockNumber,
+	toBlock BlockNumber,
+	config *ProfileConfig,
+) (interface{}, error) {
+	from, err := GetNumericBlockNumber(fromBlock, d.store)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := GetNumericBlockNumber(toBlock, d.store)
+	if err != nil {
+		return nil, err
+	}
+
+	// genesis has no transactions
+	if from == 0 {
+		from = 1
+	}
+
+	if to < from {
+		return nil, fmt.Errorf("%w, %d is before %d", ErrInvalidProfileRange, to, from)
+	}
+
+	if to-from+1 > maxProfileBlockRange {
+		return nil, fmt.Errorf("%w, at most %d blocks can be profiled", ErrInvalidProfileRange, maxProfileBlockRange)
+	}
+
+	timeout := defaultProfileTimeout
+
+	if config != nil && config.Timeout != nil {
+		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+			return nil, err
+		}
+	}
+
+	profile := profiler.NewProfiler()
+
+	cancel := cancelOnTimeout(profile, timeout)
+	defer cancel()
+
+	for num := from; num <= to; num++ {
+		block, ok := d.store.GetBlockByNumber(num, true)
+		if !ok {
+			return nil, fmt.Errorf("block %d not found", num)
+		}
+
+		if _, err := d.store.TraceBlock(block, profile); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := profile.GetResult()
+	if err != nil {
+		return nil, err
+	}
+
+	return &blocksProfile{
+		From:    argUint64(from),
+		To:      argUint64(to),
+		Profile: result.(*profiler.Profile), //nolint:forcetypeassert
+	}, nil
+}
+
+// GetStateDiff returns the accounts and storage slots changed by the block
+func (d *Debug) GetStateDiff(blockHash types.Hash) (interface{}, error) {
+	if _, ok := d.store.GetBlockByHash(blockHash, false); !ok {
+		return nil, fmt.Errorf("block %s not found", blockHash)
+	}
+
+	diff, err := d.store.GetStateDiffByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStateDiff(diff), nil
+}
+
+// GetConflictStats returns the read/write conflicts between the transactions of the block,
+// a measure of how much of it an optimistic parallel executor could run concurrently
+func (d *Debug) GetConflictStats(blockNumber BlockNumber) (interface{}, error) {
+	num, err := GetNumericBlockNumber(blockNumber, d.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if num == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	block, ok := d.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	stats, err := d.store.GetConflictStats(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return toConflictStats(block, stats), nil
+}
+
+// GetWitness returns the trie nodes and the contract codes of the parent state touched by the block,
+// which are enough to re-execute it without the state
+func (d *Debug) GetWitness(blockNumber BlockNumber) (interface{}, error) {
+	num, err := GetNumericBlockNumber(blockNumber, d.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if num == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	block, ok := d.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	witness, err := d.store.GetWitness(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWitness(block, witness), nil
+}
+
+// ReplayBlock re-executes the block against its parent state with tracing, and compares the
+// state root, the receipts and the state diff it results in with the ones stored by the node
+func (d *Debug) ReplayBlock(blockNumber BlockNumber, config *ReplayConfig) (interface{}, error) {
+	num, err := GetNumericBlockNumber(blockNumber, d.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if num == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	block, ok := d.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	if config == nil {
+		config = &ReplayConfig{}
+	}
+
+	receipts, err := d.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	// the state diff is compared only when the node persists them
+	storedDiff, err := d.store.GetStateDiffByHash(block.Hash())
+	if err != nil && !errors.Is(err, blockchain.ErrStateDiffsDisabled) {
+		return nil, err
+	}
+
+	tracer, cancel, err := newTracer(&config.TraceConfig)
+	defer cancel()
+
+	if err != nil {
+		return nil, err
+	}
+
+	replay, err := d.store.ReplayBlock(block, config.TxHash, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBlockReplay(block, receipts, storedDiff, replay), nil
+}
+
 func (d *Debug) traceBlock(
 	block *types.Block,
 	config *TraceConfig,
@@ -202,12 +426,25 @@ func newTracer(config *TraceConfig) (
 		err     error
 	)
 
+	// the callers defer the cancellation before checking the error
+	noopCancel := func() {}
+
 	if config.Timeout != nil {
 		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
-			return nil, nil, err
+			return nil, noopCancel, err
 		}
 	}
 
+	// the JavaScript tracer is interrupted on timeout as the struct logger
+	if config.Tracer != nil && *config.Tracer != "" {
+		jsTracer, err := jstracer.NewJSTracer(*config.Tracer)
+		if err != nil {
+			return nil, noopCancel, err
+		}
+
+		return jsTracer, cancelOnTimeout(jsTracer, timeout), nil
+	}
+
 	tracer := structtracer.NewStructTracer(structtracer.Config{
 		EnableMemory:     config.EnableMemory,
 		EnableStack:      !config.DisableStack,
@@ -215,16 +452,22 @@ func newTracer(config *TraceConfig) (
 		EnableReturnData: config.EnableReturnData,
 	})
 
+	// cancellation of context is done by caller
+	return tracer, cancelOnTimeout(tracer, timeout), nil
+}
+
+// cancelOnTimeout cancels the tracing once the timeout is over,
+// unless the returned function is called before
+func cancelOnTimeout(t tracer.Tracer, timeout time.Duration) context.CancelFunc {
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	go func() {
 		<-timeoutCtx.Done()
 
 		if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
-			tracer.Cancel(ErrExecutionTimeout)
+			t.Cancel(ErrExecutionTimeout)
 		}
 	}()
 
-	// cancellation of context is done by caller
-	return tracer, cancel, nil
+	return cancel
 }
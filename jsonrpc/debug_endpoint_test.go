@@ -2,14 +2,20 @@ package jsonrpc
 
 import (
 	"encoding/json"
+	"errors"
 	"math/big"
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/jstracer"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type debugEndpointMockStore struct {
@@ -23,6 +29,11 @@ type debugEndpointMockStore struct {
 	traceCallFn         func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
 	getNonceFn          func(types.Address) uint64
 	getAccountFn        func(types.Hash, types.Address) (*Account, error)
+	getStateDiffFn      func(types.Hash) (*types.StateDiff, error)
+	getConflictStatsFn  func(*types.Block) (*state.ConflictStats, error)
+	getWitnessFn        func(*types.Block) (*state.Witness, error)
+	getReceiptsFn       func(types.Hash) ([]*types.Receipt, error)
+	replayBlockFn       func(*types.Block, *types.Hash, tracer.Tracer) (*state.Replay, error)
 }
 
 func (s *debugEndpointMockStore) Header() *types.Header {
@@ -65,6 +76,30 @@ func (s *debugEndpointMockStore) GetAccount(root types.Hash, addr types.Address)
 	return s.getAccountFn(root, addr)
 }
 
+func (s *debugEndpointMockStore) GetStateDiffByHash(hash types.Hash) (*types.StateDiff, error) {
+	return s.getStateDiffFn(hash)
+}
+
+func (s *debugEndpointMockStore) GetConflictStats(block *types.Block) (*state.ConflictStats, error) {
+	return s.getConflictStatsFn(block)
+}
+
+func (s *debugEndpointMockStore) GetWitness(block *types.Block) (*state.Witness, error) {
+	return s.getWitnessFn(block)
+}
+
+func (s *debugEndpointMockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return s.getReceiptsFn(hash)
+}
+
+func (s *debugEndpointMockStore) ReplayBlock(
+	block *types.Block,
+	txHash *types.Hash,
+	tracer tracer.Tracer,
+) (*state.Replay, error) {
+	return s.replayBlockFn(block, txHash, tracer)
+}
+
 func TestDebugTraceConfigDecode(t *testing.T) {
 	timeout15s := "15s"
 
@@ -688,6 +723,194 @@ func TestTraceCall(t *testing.T) {
 	}
 }
 
+func TestProfileBlocks(t *testing.T) {
+	t.Parallel()
+
+	contract := types.StringToAddress("1")
+
+	// each block runs a transaction storing a value
+	traceBlockFn := func(block *types.Block, tr tracer.Tracer) ([]interface{}, error) {
+		tr.Clear()
+		tr.TxStart(100000)
+		tr.CallStart(1, types.ZeroAddress, contract, 0, 1000, nil, nil)
+		tr.ExecuteState(contract, 0, "SSTORE", 1000, 0, nil, 1, nil, nil)
+		tr.ExecuteState(contract, 1, "STOP", 900, 0, nil, 1, nil, nil)
+		tr.CallEnd(1, nil, 0, nil)
+		tr.TxEnd(900)
+
+		return nil, nil
+	}
+
+	t.Run("should aggregate the blocks of the range", func(t *testing.T) {
+		t.Parallel()
+
+		traced := []uint64{}
+
+		endpoint := &Debug{&debugEndpointMockStore{
+			headerFn: func() *types.Header {
+				return testLatestHeader
+			},
+			getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+				assert.True(t, full)
+
+				return &types.Block{Header: &types.Header{Number: num}}, true
+			},
+			traceBlockFn: func(block *types.Block, tr tracer.Tracer) ([]interface{}, error) {
+				traced = append(traced, block.Number())
+
+				return traceBlockFn(block, tr)
+			},
+		}}
+
+		res, err := endpoint.ProfileBlocks(EarliestBlockNumber, BlockNumber(3), nil)
+		require.NoError(t, err)
+
+		// genesis is skipped
+		assert.Equal(t, []uint64{1, 2, 3}, traced)
+
+		profile, ok := res.(*blocksProfile)
+		require.True(t, ok)
+
+		assert.Equal(t, argUint64(1), profile.From)
+		assert.Equal(t, argUint64(3), profile.To)
+		assert.Equal(t, uint64(3), profile.Transactions)
+		assert.Equal(t, uint64(300), profile.Gas)
+		assert.Equal(t, "SSTORE", profile.Opcodes[0].Opcode)
+		assert.Equal(t, uint64(3), profile.Opcodes[0].Count)
+		assert.Equal(t, contract, profile.Contracts[0].Address)
+	})
+
+	t.Run("should return an error if the range is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Debug{&debugEndpointMockStore{}}
+
+		_, err := endpoint.ProfileBlocks(BlockNumber(5), BlockNumber(3), nil)
+		assert.ErrorIs(t, err, ErrInvalidProfileRange)
+
+		_, err = endpoint.ProfileBlocks(BlockNumber(1), BlockNumber(maxProfileBlockRange+1), nil)
+		assert.ErrorIs(t, err, ErrInvalidProfileRange)
+	})
+
+	t.Run("should return an error if a block is not found", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Debug{&debugEndpointMockStore{
+			getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+				return nil, false
+			},
+		}}
+
+		_, err := endpoint.ProfileBlocks(BlockNumber(1), BlockNumber(2), nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetStateDiff(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+
+	tests := []struct {
+		name      string
+		blockHash types.Hash
+		store     *debugEndpointMockStore
+		result    interface{}
+		err       bool
+	}{
+		{
+			name:      "should return the state diff of the block",
+			blockHash: testHeader10.Hash,
+			store: &debugEndpointMockStore{
+				getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+					assert.Equal(t, testHeader10.Hash, hash)
+
+					return testBlock10, true
+				},
+				getStateDiffFn: func(hash types.Hash) (*types.StateDiff, error) {
+					assert.Equal(t, testHeader10.Hash, hash)
+
+					return &types.StateDiff{
+						Accounts: []*types.AccountDiff{
+							{
+								Address:      addr,
+								PrevNonce:    1,
+								Nonce:        2,
+								PrevBalance:  big.NewInt(10),
+								Balance:      big.NewInt(5),
+								PrevCodeHash: types.ZeroHash,
+								CodeHash:     types.ZeroHash,
+								Storage: []*types.StorageDiff{
+									{Key: testHash11, PrevValue: types.ZeroHash, Value: testHash11},
+								},
+							},
+						},
+					}, nil
+				},
+			},
+			result: &stateDiff{
+				Accounts: []*accountDiff{
+					{
+						Address:  addr,
+						Nonce:    uint64Change{From: 1, To: 2},
+						Balance:  bigChange{From: argBig(*big.NewInt(10)), To: argBig(*big.NewInt(5))},
+						CodeHash: hashChange{},
+						Storage: []*storageDiff{
+							{Key: testHash11, hashChange: hashChange{From: types.ZeroHash, To: testHash11}},
+						},
+					},
+				},
+			},
+			err: false,
+		},
+		{
+			name:      "should return an error if the block is not found",
+			blockHash: testHash11,
+			store: &debugEndpointMockStore{
+				getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+					return nil, false
+				},
+			},
+			result: nil,
+			err:    true,
+		},
+		{
+			name:      "should return an error if the state diffs are not persisted",
+			blockHash: testHeader10.Hash,
+			store: &debugEndpointMockStore{
+				getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+					return testBlock10, true
+				},
+				getStateDiffFn: func(hash types.Hash) (*types.StateDiff, error) {
+					return nil, errors.New("state diffs are not persisted")
+				},
+			},
+			result: nil,
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint := &Debug{test.store}
+
+			res, err := endpoint.GetStateDiff(test.blockHash)
+
+			assert.Equal(t, test.result, res)
+
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_newTracer(t *testing.T) {
 	t.Parallel()
 
@@ -756,4 +979,327 @@ func Test_newTracer(t *testing.T) {
 		assert.NotNil(t, res)
 		assert.NoError(t, err)
 	})
+
+	t.Run("should return the JavaScript tracer if a tracer is requested", func(t *testing.T) {
+		t.Parallel()
+
+		jsTracer := "{data: [], fault: function(log) {}, step: function(log) {}, result: function() { return this.data; }}"
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer: &jsTracer,
+		})
+
+		cancel()
+
+		assert.NoError(t, err)
+		assert.IsType(t, &jstracer.JSTracer{}, tracer)
+
+		res, err := tracer.GetResult()
+		assert.NoError(t, err)
+		assert.Equal(t, json.RawMessage("[]"), res)
+	})
+
+	t.Run("should return an error if the tracer is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		jsTracer := "callTracer"
+		_, cancel, err := newTracer(&TraceConfig{
+			Tracer: &jsTracer,
+		})
+
+		cancel()
+
+		assert.ErrorIs(t, err, jstracer.ErrInvalidTracer)
+	})
+
+	t.Run("should interrupt the JavaScript tracer on timeout", func(t *testing.T) {
+		t.Parallel()
+
+		timeout := "50ms"
+		jsTracer := "{fault: function(log) {}, result: function() { while (true) {} }}"
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:  &jsTracer,
+			Timeout: &timeout,
+		})
+
+		t.Cleanup(func() {
+			cancel()
+		})
+
+		require.NoError(t, err)
+
+		res, err := tracer.GetResult()
+		assert.Nil(t, res)
+		assert.Equal(t, ErrExecutionTimeout, err)
+	})
+}
+
+func TestGetConflictStats(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr = types.StringToAddress("1")
+		slot = types.StringToHash("2")
+	)
+
+	block := &types.Block{
+		Header: testHeader10,
+		Transactions: []*types.Transaction{
+			{Hash: types.StringToHash("a")},
+			{Hash: types.StringToHash("b")},
+		},
+	}
+
+	t.Run("should return the conflicts of the block", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Debug{&debugEndpointMockStore{
+			getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+				assert.Equal(t, testHeader10.Number, num)
+				assert.True(t, full)
+
+				return block, true
+			},
+			getConflictStatsFn: func(b *types.Block) (*state.ConflictStats, error) {
+				assert.Equal(t, block, b)
+
+				return &state.ConflictStats{
+					Transactions:            2,
+					ConflictingTransactions: 1,
+					ConflictRate:            0.5,
+					CriticalPath:            2,
+					Parallelism:             1,
+					Txs: []*state.TxConflicts{
+						{Reads: 1, Writes: 1},
+						{Reads: 1, Writes: 1, Dependencies: []int{0}},
+					},
+					HotLocations: []*state.LocationConflicts{
+						{Address: addr, Slot: &slot, Conflicts: 1},
+					},
+				}, nil
+			},
+		}}
+
+		res, err := endpoint.GetConflictStats(BlockNumber(testHeader10.Number))
+		require.NoError(t, err)
+
+		assert.Equal(t, &conflictStats{
+			BlockNumber:             argUint64(testHeader10.Number),
+			BlockHash:               testHeader10.Hash,
+			Transactions:            2,
+			ConflictingTransactions: 1,
+			ConflictRate:            0.5,
+			CriticalPath:            2,
+			Parallelism:             1,
+			Txs: []*txConflicts{
+				{TxHash: types.StringToHash("a"), Reads: 1, Writes: 1, Dependencies: []int{}},
+				{TxHash: types.StringToHash("b"), Reads: 1, Writes: 1, Dependencies: []int{0}},
+			},
+			HotLocations: []*locationConflicts{
+				{Address: addr, Slot: &slot, Conflicts: 1},
+			},
+		}, res)
+	})
+
+	t.Run("should return an error for the genesis block", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Debug{&debugEndpointMockStore{}}
+
+		_, err := endpoint.GetConflictStats(EarliestBlockNumber)
+		assert.ErrorIs(t, err, ErrTraceGenesisBlock)
+	})
+
+	t.Run("should return an error if the block is not found", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Debug{&debugEndpointMockStore{
+			getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+				return nil, false
+			},
+		}}
+
+		_, err := endpoint.GetConflictStats(BlockNumber(testHeader10.Number))
+		assert.Error(t, err)
+	})
+}
+
+func TestGetWitness(t *testing.T) {
+	t.Parallel()
+
+	block := &types.Block{
+		Header: testHeader10,
+	}
+
+	t.Run("should return the witness of the block", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Debug{&debugEndpointMockStore{
+			getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+				assert.Equal(t, testHeader10.Number, num)
+
+				return block, true
+			},
+			getWitnessFn: func(b *types.Block) (*state.Witness, error) {
+				assert.Equal(t, block, b)
+
+				return &state.Witness{
+					Nodes: [][]byte{{0x1, 0x2}},
+					Codes: [][]byte{{0x3}},
+				}, nil
+			},
+		}}
+
+		res, err := endpoint.GetWitness(BlockNumber(testHeader10.Number))
+		require.NoError(t, err)
+
+		assert.Equal(t, &blockWitness{
+			BlockNumber: argUint64(testHeader10.Number),
+			BlockHash:   testHeader10.Hash,
+			Nodes:       []argBytes{{0x1, 0x2}},
+			Codes:       []argBytes{{0x3}},
+		}, res)
+	})
+
+	t.Run("should return an error for the genesis block", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Debug{&debugEndpointMockStore{}}
+
+		_, err := endpoint.GetWitness(EarliestBlockNumber)
+		assert.ErrorIs(t, err, ErrTraceGenesisBlock)
+	})
+}
+
+func TestReplayBlock(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tx = &types.Transaction{
+			Hash: types.StringToHash("1"),
+		}
+		block = &types.Block{
+			Header:       testHeader10.Copy(),
+			Transactions: []*types.Transaction{tx},
+		}
+		addr = types.StringToAddress("1")
+	)
+
+	receipt := &types.Receipt{CumulativeGasUsed: 21000}
+	receipt.SetStatus(types.ReceiptSuccess)
+
+	block.Header.GasUsed = 21000
+	block.Header.StateRoot = types.StringToHash("2")
+	block.Header.ReceiptsRoot = buildroot.CalculateReceiptsRoot([]*types.Receipt{receipt})
+
+	replayedDiff := &types.StateDiff{
+		Accounts: []*types.AccountDiff{
+			{Address: addr, PrevBalance: big.NewInt(0), Balance: big.NewInt(1)},
+		},
+	}
+
+	newStore := func(storedDiff *types.StateDiff, stateRoot types.Hash) *debugEndpointMockStore {
+		return &debugEndpointMockStore{
+			getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+				assert.Equal(t, testHeader10.Number, num)
+
+				return block, true
+			},
+			getReceiptsFn: func(hash types.Hash) ([]*types.Receipt, error) {
+				return []*types.Receipt{receipt}, nil
+			},
+			getStateDiffFn: func(hash types.Hash) (*types.StateDiff, error) {
+				if storedDiff == nil {
+					return nil, blockchain.ErrStateDiffsDisabled
+				}
+
+				return storedDiff, nil
+			},
+			replayBlockFn: func(b *types.Block, txHash *types.Hash, tracer tracer.Tracer) (*state.Replay, error) {
+				assert.Equal(t, block, b)
+				assert.Equal(t, &tx.Hash, txHash)
+
+				return &state.Replay{
+					Traces:    []interface{}{testTraceResult},
+					Receipts:  []*types.Receipt{receipt},
+					GasUsed:   21000,
+					StateRoot: stateRoot,
+					StateDiff: replayedDiff,
+				}, nil
+			},
+		}
+	}
+
+	config := &ReplayConfig{TxHash: &tx.Hash}
+
+	t.Run("should match the stored block", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Debug{newStore(replayedDiff, block.Header.StateRoot)}
+
+		res, err := endpoint.ReplayBlock(BlockNumber(testHeader10.Number), config)
+		require.NoError(t, err)
+
+		replay, ok := res.(*blockReplay)
+		require.True(t, ok)
+
+		assert.True(t, replay.Valid)
+		assert.Equal(t, replayedHash{block.Header.StateRoot, block.Header.StateRoot}, replay.StateRoot)
+		assert.Equal(t, []*txReplay{
+			{
+				TxHash:            tx.Hash,
+				Status:            replayedUint64{1, 1},
+				CumulativeGasUsed: replayedUint64{21000, 21000},
+				Trace:             testTraceResult,
+			},
+		}, replay.Txs)
+		assert.Empty(t, replay.StateMismatches)
+	})
+
+	t.Run("should report the state mismatches", func(t *testing.T) {
+		t.Parallel()
+
+		storedDiff := &types.StateDiff{
+			Accounts: []*types.AccountDiff{
+				{Address: addr, PrevBalance: big.NewInt(0), Balance: big.NewInt(2)},
+			},
+		}
+
+		endpoint := &Debug{newStore(storedDiff, types.StringToHash("3"))}
+
+		res, err := endpoint.ReplayBlock(BlockNumber(testHeader10.Number), config)
+		require.NoError(t, err)
+
+		replay, ok := res.(*blockReplay)
+		require.True(t, ok)
+
+		assert.False(t, replay.Valid)
+		assert.Equal(t, types.StringToHash("3"), replay.StateRoot.Replayed)
+		assert.Equal(t, []*stateMismatch{
+			{Address: addr, Field: state.MismatchBalance, Stored: "2", Replayed: "1"},
+		}, replay.StateMismatches)
+	})
+
+	t.Run("should not compare the state diff when it is not persisted", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Debug{newStore(nil, block.Header.StateRoot)}
+
+		res, err := endpoint.ReplayBlock(BlockNumber(testHeader10.Number), config)
+		require.NoError(t, err)
+
+		replay, ok := res.(*blockReplay)
+		require.True(t, ok)
+
+		assert.True(t, replay.Valid)
+		assert.Nil(t, replay.StateMismatches)
+	})
+
+	t.Run("should return an error for the genesis block", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Debug{&debugEndpointMockStore{}}
+
+		_, err := endpoint.ReplayBlock(EarliestBlockNumber, nil)
+		assert.ErrorIs(t, err, ErrTraceGenesisBlock)
+	})
 }
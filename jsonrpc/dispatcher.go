@@ -8,6 +8,7 @@ import (
 	"math"
 	"reflect"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/hashicorp/go-hclog"
@@ -30,11 +31,21 @@ func (f *funcData) numParams() int {
 }
 
 type endpoints struct {
-	Eth    *Eth
-	Web3   *Web3
-	Net    *Net
-	TxPool *TxPool
-	Debug  *Debug
+	Eth      *Eth
+	Web3     *Web3
+	Net      *Net
+	TxPool   *TxPool
+	Debug    *Debug
+	Trace    *Trace
+	Index    *Index
+	Personal *Personal
+	Evm      *Evm
+	Anvil    *Anvil
+	Nonce    *Nonce
+	Intent   *Intent
+	Edge     *Edge
+	Staking  *Staking
+	Freeze   *Freeze
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -45,6 +56,9 @@ type Dispatcher struct {
 	filterManager *FilterManager
 	endpoints     endpoints
 
+	// pools limit the concurrent requests of each method class
+	pools *execPools
+
 	params *dispatcherParams
 }
 
@@ -55,6 +69,16 @@ type dispatcherParams struct {
 	priceLimit              uint64
 	jsonRPCBatchLengthLimit uint64
 	blockRangeLimit         uint64
+
+	filterTimeout       time.Duration
+	maxFiltersPerClient uint64
+	filterStorePath     string
+
+	readConcurrency  uint64
+	callConcurrency  uint64
+	traceConcurrency uint64
+
+	callCacheSize int
 }
 
 func newDispatcher(
@@ -64,11 +88,26 @@ func newDispatcher(
 ) *Dispatcher {
 	d := &Dispatcher{
 		logger: logger.Named("dispatcher"),
+		pools:  newExecPools(params.readConcurrency, params.callConcurrency, params.traceConcurrency),
 		params: params,
 	}
 
 	if store != nil {
 		d.filterManager = NewFilterManager(logger, store, params.blockRangeLimit)
+
+		if params.filterTimeout != 0 {
+			d.filterManager.SetTimeout(params.filterTimeout)
+		}
+
+		d.filterManager.SetMaxFiltersPerClient(params.maxFiltersPerClient)
+
+		if params.filterStorePath != "" {
+			// the node serves without the previous filters rather than failing on a corrupted file
+			if err := d.filterManager.EnableStore(params.filterStorePath); err != nil {
+				d.logger.Error("failed to restore filters", "err", err)
+			}
+		}
+
 		go d.filterManager.Run()
 	}
 
@@ -84,6 +123,15 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) {
 		d.params.chainID,
 		d.filterManager,
 		d.params.priceLimit,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	}
+
+	if d.params.callCacheSize > 0 {
+		d.endpoints.Eth.callCache = newCallCache(d.params.callCacheSize)
 	}
 	d.endpoints.Net = &Net{
 		store,
@@ -99,12 +147,85 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) {
 	d.endpoints.Debug = &Debug{
 		store,
 	}
+	d.endpoints.Trace = &Trace{
+		store,
+		d.params.blockRangeLimit,
+	}
 
 	d.registerService("eth", d.endpoints.Eth)
 	d.registerService("net", d.endpoints.Net)
 	d.registerService("web3", d.endpoints.Web3)
 	d.registerService("txpool", d.endpoints.TxPool)
 	d.registerService("debug", d.endpoints.Debug)
+	d.registerService("trace", d.endpoints.Trace)
+}
+
+// registerIndexEndpoint registers the index endpoint of the indexer mode
+func (d *Dispatcher) registerIndexEndpoint(store IndexStore) {
+	d.endpoints.Index = &Index{store}
+	d.endpoints.Eth.index = d.endpoints.Index
+
+	d.registerService("index", d.endpoints.Index)
+}
+
+// registerPersonalEndpoint registers the personal endpoint managing the node accounts,
+// whose transactions are then signed by the node
+func (d *Dispatcher) registerPersonalEndpoint(manager AccountManager) {
+	d.endpoints.Personal = &Personal{manager, d.endpoints.Eth}
+	d.endpoints.Eth.signer = manager
+
+	d.registerService("personal", d.endpoints.Personal)
+}
+
+// registerNonceEndpoint registers the endpoint of the nonce manager, whose resubmissions are started
+func (d *Dispatcher) registerNonceEndpoint(store nonceManagerStore) {
+	manager := NewNonceManager(d.logger, store)
+	go manager.Run()
+
+	d.endpoints.Nonce = &Nonce{manager}
+
+	d.registerService("nonce", d.endpoints.Nonce)
+}
+
+// registerIntentEndpoint registers the endpoint of the off-chain intents of the validators
+func (d *Dispatcher) registerIntentEndpoint(store IntentStore) {
+	d.endpoints.Intent = &Intent{store, d.params.chainID}
+
+	d.registerService("intent", d.endpoints.Intent)
+}
+
+// registerEdgeEndpoint registers the endpoint of the chain specific extensions, such as the validator sets
+func (d *Dispatcher) registerEdgeEndpoint(store ValidatorStore) {
+	d.endpoints.Edge = &Edge{store}
+
+	d.registerService("edge", d.endpoints.Edge)
+}
+
+// registerStakingEndpoint registers the endpoint of the staking dashboards
+func (d *Dispatcher) registerStakingEndpoint(store StakingStore) {
+	d.endpoints.Staking = &Staking{store}
+
+	d.registerService("staking", d.endpoints.Staking)
+}
+
+// registerFreezeEndpoint registers the endpoint querying the accounts frozen by the governors
+func (d *Dispatcher) registerFreezeEndpoint(store FreezeStore) {
+	d.endpoints.Freeze = &Freeze{store}
+
+	d.registerService("freeze", d.endpoints.Freeze)
+}
+
+// registerDevEndpoints registers the endpoints controlling the chain of the dev consensus
+func (d *Dispatcher) registerDevEndpoints(store DevStore) {
+	d.endpoints.Evm = &Evm{store}
+	d.endpoints.Anvil = &Anvil{store}
+	d.endpoints.Eth.impersonator = store
+
+	d.registerService("evm", d.endpoints.Evm)
+
+	// the same methods are served in the namespaces of both development nodes
+	d.registerService("anvil", d.endpoints.Anvil)
+	d.registerService("hardhat", d.endpoints.Anvil)
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -126,6 +247,10 @@ func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error)
 		return nil, nil, NewMethodNotFoundError(req.Method)
 	}
 
+	if isExplorerMethod(req.Method) && (d.endpoints.Eth == nil || d.endpoints.Eth.explorer == nil) {
+		return nil, nil, NewMethodNotFoundError(req.Method)
+	}
+
 	return service, fd, nil
 }
 
@@ -177,6 +302,15 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 			return "", NewInternalError(err.Error())
 		}
 		filterID = d.filterManager.NewLogFilter(logQuery, conn)
+	} else if subscribeMethod == "accounts" {
+		addresses, err := decodeAddressesFromInterface(params[1:])
+		if err != nil {
+			return "", NewInvalidParamsError(err.Error())
+		}
+
+		if filterID, err = d.filterManager.NewAccountFilter(addresses, conn); err != nil {
+			return "", NewInvalidParamsError(err.Error())
+		}
 	} else {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
@@ -206,6 +340,13 @@ func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
 }
 
+// Close stops the filter manager, persisting the polling filters
+func (d *Dispatcher) Close() {
+	if d.filterManager != nil {
+		d.filterManager.Close()
+	}
+}
+
 func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	var req Request
 	if err := json.Unmarshal(reqBody, &req); err != nil {
@@ -258,6 +399,11 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 }
 
 func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+	return d.HandleFrom(reqBody, "")
+}
+
+// HandleFrom handles the requests of the client, whose polling filters are limited
+func (d *Dispatcher) HandleFrom(reqBody []byte, client string) ([]byte, error) {
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -273,7 +419,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
-		resp, err := d.handleReq(req)
+		resp, err := d.handleClientReq(req, client)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 	}
@@ -302,7 +448,7 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	responses := make([]Response, 0)
 
 	for _, req := range requests {
-		var response, err = d.handleReq(req)
+		var response, err = d.handleClientReq(req, client)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", nil, err)
 			responses = append(responses, errorResponse)
@@ -322,14 +468,61 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	return respBytes, nil
 }
 
+// handleClientReq handles the request of the client,
+// installing the polling filters on its behalf so that they count against its limit
+func (d *Dispatcher) handleClientReq(req Request, client string) ([]byte, Error) {
+	if client == "" || d.filterManager == nil {
+		return d.handleReq(req)
+	}
+
+	var (
+		filterID string
+		err      error
+	)
+
+	switch req.Method {
+	case "eth_newFilter":
+		var params []*LogQuery
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) != 1 || params[0] == nil {
+			return nil, NewInvalidParamsError("Invalid Params")
+		}
+
+		filterID, err = d.filterManager.NewClientLogFilter(client, params[0])
+	case "eth_newBlockFilter":
+		filterID, err = d.filterManager.NewClientBlockFilter(client)
+	default:
+		return d.handleReq(req)
+	}
+
+	if err != nil {
+		d.logInternalError(req.Method, err)
+
+		return nil, NewInvalidRequestError(err.Error())
+	}
+
+	data, err := json.Marshal(filterID)
+	if err != nil {
+		return nil, NewInternalError("Internal error")
+	}
+
+	return data, nil
+}
+
 func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	d.logger.Debug("request", "method", req.Method, "id", req.ID)
 
+	if data, ok := d.handlePendingReq(req); ok {
+		return data, nil
+	}
+
 	service, fd, ferr := d.getFnHandler(req)
 	if ferr != nil {
 		return nil, ferr
 	}
 
+	release := d.pools.acquire(req.Method)
+	defer release()
+
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
 
@@ -349,6 +542,12 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 
 	output := fd.fv.Call(inArgs)
 	if err := getError(output[1]); err != nil {
+		// the reverts are returned with the revert data, as expected by the Ethereum tooling
+		var revertErr *revertError
+		if errors.As(err, &revertErr) {
+			return nil, revertErr
+		}
+
 		d.logInternalError(req.Method, err)
 
 		return nil, NewInvalidRequestError(err.Error())
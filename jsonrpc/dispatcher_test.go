@@ -99,6 +99,47 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 			t.Fatal("\"newHeads\" event not received in 2 seconds")
 		}
 	})
+
+	t.Run("clients should be able to receive \"accounts\" event thru eth_subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		dispatcher := newDispatcher(
+			hclog.NewNullLogger(),
+			store,
+			&dispatcherParams{
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+			},
+		)
+
+		mockConnection, msgCh := newMockWsConnWithMsgCh()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["accounts", {"addresses": ["` + addr1.String() + `"]}]
+	}`)
+		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+			t.Fatal(err)
+		}
+
+		store.emitEvent(&mockEvent{
+			NewChain: []*mockHeader{
+				{
+					header: &types.Header{
+						Hash: types.StringToHash("1"),
+					},
+				},
+			},
+		})
+
+		select {
+		case msg := <-msgCh:
+			assert.Contains(t, string(msg), addr1.String())
+		case <-time.After(2 * time.Second):
+			t.Fatal("\"accounts\" event not received in 2 seconds")
+		}
+	})
 }
 
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
@@ -0,0 +1,79 @@
+package jsonrpc
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Validator is a validator of a block
+type Validator struct {
+	Address types.Address
+
+	// Stake is the amount staked by the validator, nil if the validators aren't staked
+	Stake *big.Int
+
+	// BLSPublicKey is the BLS public key of the validator, nil for the ECDSA validators
+	BLSPublicKey []byte
+}
+
+// ValidatorStore provides the validator sets of the blocks
+type ValidatorStore interface {
+	headerGetter
+
+	// GetValidatorSet returns the validators of the block, read from the validator set snapshots
+	GetValidatorSet(header *types.Header) ([]*Validator, error)
+}
+
+// Edge is the jsonrpc endpoint of the chain specific extensions
+type Edge struct {
+	store ValidatorStore
+}
+
+type validatorResult struct {
+	Address      types.Address `json:"address"`
+	Stake        *argBig       `json:"stake"`
+	BLSPublicKey *argBytes     `json:"blsPublicKey"`
+}
+
+type validatorSetResult struct {
+	BlockNumber argUint64          `json:"blockNumber"`
+	BlockHash   types.Hash         `json:"blockHash"`
+	Validators  []*validatorResult `json:"validators"`
+}
+
+// GetValidatorsByBlockNumber returns the active validators of the block, with their stake
+// and BLS public key, so that they don't have to be decoded from the staking contract storage
+func (e *Edge) GetValidatorsByBlockNumber(number BlockNumber) (interface{}, error) {
+	header, err := GetBlockHeader(number, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := e.store.GetValidatorSet(header)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &validatorSetResult{
+		BlockNumber: argUint64(header.Number),
+		BlockHash:   header.Hash,
+		Validators:  make([]*validatorResult, len(validators)),
+	}
+
+	for idx, validator := range validators {
+		res.Validators[idx] = &validatorResult{
+			Address: validator.Address,
+		}
+
+		if validator.Stake != nil {
+			res.Validators[idx].Stake = argBigPtr(validator.Stake)
+		}
+
+		if validator.BLSPublicKey != nil {
+			res.Validators[idx].BLSPublicKey = argBytesPtr(validator.BLSPublicKey)
+		}
+	}
+
+	return res, nil
+}
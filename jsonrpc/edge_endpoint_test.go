@@ -0,0 +1,55 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockValidatorStore struct {
+	*mockStore
+
+	validators map[uint64][]*Validator
+}
+
+func (m *mockValidatorStore) GetValidatorSet(header *types.Header) ([]*Validator, error) {
+	return m.validators[header.Number], nil
+}
+
+func TestEdgeEndpoint_GetValidatorsByBlockNumber(t *testing.T) {
+	t.Parallel()
+
+	store := &mockValidatorStore{
+		mockStore: newMockStore(),
+		validators: map[uint64][]*Validator{
+			0: {
+				{Address: addr0, Stake: big.NewInt(100), BLSPublicKey: []byte{0x1}},
+				{Address: addr1},
+			},
+		},
+	}
+	endpoint := &Edge{store}
+
+	res, err := endpoint.GetValidatorsByBlockNumber(LatestBlockNumber)
+	require.NoError(t, err)
+
+	set, ok := res.(*validatorSetResult)
+	require.True(t, ok)
+	require.Len(t, set.Validators, 2)
+
+	assert.Equal(t, argUint64(0), set.BlockNumber)
+	assert.Equal(t, addr0, set.Validators[0].Address)
+	assert.Equal(t, argBigPtr(big.NewInt(100)), set.Validators[0].Stake)
+	assert.Equal(t, argBytesPtr([]byte{0x1}), set.Validators[0].BLSPublicKey)
+
+	// the stake and the BLS public key are omitted if unknown
+	assert.Equal(t, addr1, set.Validators[1].Address)
+	assert.Nil(t, set.Validators[1].Stake)
+	assert.Nil(t, set.Validators[1].BLSPublicKey)
+
+	_, err = endpoint.GetValidatorsByBlockNumber(BlockNumber(5))
+	assert.Error(t, err)
+}
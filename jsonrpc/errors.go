@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
-	"github.com/umbracle/ethgo/abi"
 )
 
 var (
@@ -16,6 +16,12 @@ type Error interface {
 	Error() string
 	ErrorCode() int
 }
+
+// DataError is an error carrying additional data in its response
+type DataError interface {
+	Error
+	ErrorData() interface{}
+}
 type invalidParamsError struct {
 	err string
 }
@@ -94,11 +100,43 @@ func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
 
-func constructErrorFromRevert(result *runtime.ExecutionResult) error {
-	revertErrMsg, unpackErr := abi.UnpackRevertError(result.ReturnValue)
-	if unpackErr != nil {
-		return result.Err
+// revertError is the error of a reverted execution, carrying the revert reason
+// and the raw revert data, which holds the custom errors of the contracts
+type revertError struct {
+	reason string
+	data   []byte
+}
+
+func (e *revertError) Error() string {
+	if e.reason == "" {
+		return runtime.ErrExecutionReverted.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", runtime.ErrExecutionReverted, e.reason)
+}
+
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+func (e *revertError) ErrorData() interface{} {
+	if len(e.data) == 0 {
+		return nil
 	}
 
-	return fmt.Errorf("%w: %s", result.Err, revertErrMsg)
+	return hex.EncodeToHex(e.data)
+}
+
+func (e *revertError) Unwrap() error {
+	return runtime.ErrExecutionReverted
+}
+
+func constructErrorFromRevert(result *runtime.ExecutionResult) error {
+	// the revert data is neither an Error(string) nor a Panic(uint256) for the custom errors, only the data is returned then
+	reason, _ := runtime.UnpackRevertReason(result.ReturnValue)
+
+	return &revertError{
+		reason: reason,
+		data:   result.ReturnValue,
+	}
 }
@@ -6,10 +6,12 @@ import (
 	"testing"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -212,6 +214,54 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 		assert.Equal(t, txn.Hash, response.TxHash)
 		assert.Equal(t, block.Hash(), response.BlockHash)
 		assert.NotNil(t, response.Logs)
+		assert.Nil(t, response.RevertReason)
+	})
+
+	t.Run("returns the revert reason of a reverted transaction", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		eth := newTestEthEndpoint(store)
+		block := newTestBlock(1, hash4)
+		store.add(block)
+		txn := newTestTransaction(uint64(0), addr0)
+		block.Transactions = append(block.Transactions, txn)
+
+		// Error("denied")
+		output := hex.MustDecodeHex(
+			"0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000006" +
+				"64656e6965640000000000000000000000000000000000000000000000000000",
+		)
+		custom := hex.MustDecodeHex("0x12345678")
+
+		failed := &types.Receipt{RevertReason: output}
+		failed.SetStatus(types.ReceiptFailed)
+
+		failedCustom := &types.Receipt{RevertReason: custom}
+		failedCustom.SetStatus(types.ReceiptFailed)
+
+		store.receipts[hash4] = []*types.Receipt{failed}
+
+		res, err := eth.GetTransactionReceipt(txn.Hash)
+		require.NoError(t, err)
+
+		//nolint:forcetypeassert
+		response := res.(*receipt)
+		require.NotNil(t, response.RevertReason)
+		assert.Equal(t, "denied", *response.RevertReason)
+
+		// the raw output is returned for the custom errors
+		store.receipts[hash4] = []*types.Receipt{failedCustom}
+
+		res, err = eth.GetTransactionReceipt(txn.Hash)
+		require.NoError(t, err)
+
+		//nolint:forcetypeassert
+		response = res.(*receipt)
+		require.NotNil(t, response.RevertReason)
+		assert.Equal(t, "0x12345678", *response.RevertReason)
 	})
 }
 
@@ -335,6 +385,56 @@ func TestEth_Call(t *testing.T) {
 	})
 }
 
+func TestEth_CallMany(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	store.add(newTestBlock(100, hash1))
+	eth := newTestEthEndpoint(store)
+
+	newCall := func(data []byte) *txnArgs {
+		return &txnArgs{
+			From:  &addr0,
+			To:    &addr1,
+			Data:  argBytesPtr(data),
+			Nonce: argUintPtr(0),
+		}
+	}
+
+	t.Run("returns the result of each call", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.CallMany([]*txnArgs{
+			newCall([]byte{0x1, 0x2}),
+			newCall([]byte{0x3}),
+		}, BlockNumberOrHash{})
+		require.NoError(t, err)
+
+		results, ok := res.([]*callResult)
+		require.True(t, ok)
+		require.Len(t, results, 2)
+
+		assert.Equal(t, argBytes{0x1, 0x2}, *results[0].Value)
+		assert.Empty(t, results[0].Error)
+
+		assert.Nil(t, results[1].Value)
+		assert.Contains(t, results[1].Error, runtime.ErrExecutionReverted.Error())
+		assert.Equal(t, argBytes{0x3}, *results[1].Data)
+	})
+
+	t.Run("returns an error if there are too many calls", func(t *testing.T) {
+		t.Parallel()
+
+		calls := make([]*txnArgs, maxCallManyLength+1)
+		for idx := range calls {
+			calls[idx] = newCall(nil)
+		}
+
+		_, err := eth.CallMany(calls, BlockNumberOrHash{})
+		assert.ErrorIs(t, err, ErrTooManyCalls)
+	})
+}
+
 type testStore interface {
 	ethStore
 }
@@ -521,6 +621,21 @@ func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction)
 	return &runtime.ExecutionResult{Err: m.ethCallError}, nil
 }
 
+// ApplyTxns returns the input of the transactions, reverting those of a single byte
+func (m *mockBlockStore) ApplyTxns(header *types.Header, txns []*types.Transaction) ([]*runtime.ExecutionResult, error) {
+	results := make([]*runtime.ExecutionResult, len(txns))
+
+	for idx, txn := range txns {
+		results[idx] = &runtime.ExecutionResult{ReturnValue: txn.Input}
+
+		if len(txn.Input) == 1 {
+			results[idx].Err = runtime.ErrExecutionReverted
+		}
+	}
+
+	return results, nil
+}
+
 func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
@@ -9,7 +9,9 @@ import (
 	"github.com/umbracle/fastrlp"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
@@ -20,6 +22,12 @@ type ethTxPoolStore interface {
 	// AddTx adds a new transaction to the tx pool
 	AddTx(tx *types.Transaction) error
 
+	// AddPrivateTx adds a new transaction to the tx pool without gossiping it
+	AddPrivateTx(tx *types.Transaction) error
+
+	// ReplaceTx replaces the pending transaction with a transaction of the same sender and nonce
+	ReplaceTx(hash types.Hash, tx *types.Transaction) error
+
 	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
 	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
 
@@ -27,16 +35,40 @@ type ethTxPoolStore interface {
 	GetNonce(addr types.Address) uint64
 }
 
+// TxSigner signs the transactions of the accounts managed by the node
+type TxSigner interface {
+	// SignTx signs the transaction with the key of its sender,
+	// an error if the node doesn't hold the key or the account is locked
+	SignTx(tx *types.Transaction) (*types.Transaction, error)
+}
+
 type Account struct {
 	Balance *big.Int
 	Nonce   uint64
 }
 
+// StorageProof is the merkle proof of a storage slot of an account
+type StorageProof struct {
+	Key   types.Hash
+	Value types.Hash
+	Proof [][]byte
+}
+
+// AccountProof is the merkle proof of an account and a set of its storage slots
+type AccountProof struct {
+	Account      *Account
+	CodeHash     types.Hash
+	StorageRoot  types.Hash
+	Proof        [][]byte
+	StorageProof []*StorageProof
+}
+
 type ethStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+	GetProof(root types.Hash, addr types.Address, slots []types.Hash) (*AccountProof, error)
 }
 
 type ethBlockchainStore interface {
@@ -64,6 +96,9 @@ type ethBlockchainStore interface {
 	// ApplyTxn applies a transaction object to the blockchain
 	ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
 
+	// ApplyTxns applies the transactions independently on the same state
+	ApplyTxns(header *types.Header, txns []*types.Transaction) ([]*runtime.ExecutionResult, error)
+
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 }
@@ -82,12 +117,38 @@ type Eth struct {
 	chainID       uint64
 	filterManager *FilterManager
 	priceLimit    uint64
+
+	// index serves the indexer queries, nil if the indexer is disabled
+	index *Index
+
+	// signer signs the transactions of the managed accounts, nil if the node doesn't manage accounts
+	signer TxSigner
+
+	// impersonator tells the accounts whose transactions are sent unsigned, nil outside of the dev consensus
+	impersonator Impersonator
+
+	// explorer serves the compatibility mode of the block explorers, nil if disabled
+	explorer ExplorerStore
+
+	// callCache caches the results of the calls on the same block, nil if disabled
+	callCache *callCache
 }
 
+// maxCallManyLength is the maximum number of the calls of an eth_callMany request
+const maxCallManyLength = 100
+
 var (
-	ErrInsufficientFunds = errors.New("insufficient funds for execution")
+	ErrInsufficientFunds  = errors.New("insufficient funds for execution")
+	ErrAccountNotManaged  = errors.New("account not managed by the node")
+	ErrTxNotPending       = errors.New("transaction is not pending")
+	ErrCancelNotSupported = errors.New("transaction cancellation requires the node to manage the sender account")
+	ErrMissingSender      = errors.New("transaction sender is required")
+	ErrTooManyCalls       = fmt.Errorf("too many calls, the limit is %d", maxCallManyLength)
 )
 
+// cancelPriceBump is the percentage the gas price of a cancellation is raised by
+const cancelPriceBump = 10
+
 // ChainId returns the chain id of the client
 //
 //nolint:stylecheck
@@ -122,7 +183,10 @@ func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, er
 		return nil, nil
 	}
 
-	return toBlock(block, fullTx), nil
+	res := toBlock(block, fullTx)
+	e.setTotalDifficulty(res, block.Hash())
+
+	return res, nil
 }
 
 // GetBlockByHash returns information about a block by hash
@@ -132,7 +196,10 @@ func (e *Eth) GetBlockByHash(hash types.Hash, fullTx bool) (interface{}, error)
 		return nil, nil
 	}
 
-	return toBlock(block, fullTx), nil
+	res := toBlock(block, fullTx)
+	e.setTotalDifficulty(res, block.Hash())
+
+	return res, nil
 }
 
 func (e *Eth) GetBlockTransactionCountByNumber(number BlockNumber) (interface{}, error) {
@@ -176,10 +243,194 @@ func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
 	return tx.Hash.String(), nil
 }
 
-// SendTransaction rejects eth_sendTransaction json-rpc call as we don't support wallet management
-func (e *Eth) SendTransaction(_ *txnArgs) (interface{}, error) {
-	return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
-		" use eth_sendRawTransaction insead")
+// SendPrivateTransaction sends a transaction held only by the designated validators,
+// it's not gossiped until its inclusion so that it can't be front-run
+func (e *Eth) SendPrivateTransaction(buf argBytes) (interface{}, error) {
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalRLP(buf); err != nil {
+		return nil, err
+	}
+
+	tx.ComputeHash()
+
+	if err := e.store.AddPrivateTx(tx); err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
+}
+
+// CancelTransaction replaces the pending transaction with a zero value transfer to its sender
+// at the same nonce and a bumped gas price, signed with the sender key held by the node.
+// The original transaction is dropped from the local pool immediately
+func (e *Eth) CancelTransaction(hash types.Hash) (interface{}, error) {
+	if e.signer == nil {
+		return nil, ErrCancelNotSupported
+	}
+
+	pending, ok := e.store.GetPendingTx(hash)
+	if !ok {
+		return nil, ErrTxNotPending
+	}
+
+	cancelTx, err := e.signer.SignTx(&types.Transaction{
+		From:     pending.From,
+		To:       &pending.From,
+		Nonce:    pending.Nonce,
+		Value:    big.NewInt(0),
+		Gas:      state.TxGas,
+		GasPrice: bumpGasPrice(pending.GasPrice, e.priceLimit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cancelTx.ComputeHash()
+
+	if err := e.store.ReplaceTx(hash, cancelTx); err != nil {
+		return nil, err
+	}
+
+	return cancelTx.Hash.String(), nil
+}
+
+// bumpGasPrice returns the gas price raised by cancelPriceBump percent,
+// at least by 1 wei and not lower than the price limit
+func bumpGasPrice(price *big.Int, priceLimit uint64) *big.Int {
+	bumped := new(big.Int)
+	if price != nil {
+		bumped.Mul(price, big.NewInt(100+cancelPriceBump))
+		bumped.Div(bumped, big.NewInt(100))
+
+		if bumped.Cmp(price) <= 0 {
+			bumped.Add(price, big.NewInt(1))
+		}
+	}
+
+	if limit := new(big.Int).SetUint64(priceLimit); bumped.Cmp(limit) < 0 {
+		bumped = limit
+	}
+
+	return bumped
+}
+
+// SendTransaction signs the transaction with the unlocked key of its sender held by the node
+// and sends it. The call is rejected if the node doesn't manage accounts.
+// The transactions of the accounts impersonated in the dev consensus are sent unsigned
+func (e *Eth) SendTransaction(arg *txnArgs) (interface{}, error) {
+	if e.impersonator != nil && arg.From != nil && e.impersonator.IsImpersonated(*arg.From) {
+		return e.sendTransaction(arg, impersonatedTx)
+	}
+
+	if e.signer == nil {
+		return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
+			" use eth_sendRawTransaction insead")
+	}
+
+	return e.sendTransaction(arg, e.signer.SignTx)
+}
+
+// Sign signs the EIP-191 prefixed message with the unlocked key of the account held by the node
+func (e *Eth) Sign(addr types.Address, data argBytes) (interface{}, error) {
+	signer, err := e.hashSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := signer.SignHash(addr, crypto.TextHash(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return toEthSignature(sig), nil
+}
+
+// SignTypedData_v4 signs the EIP-712 typed data with the unlocked key of the account held by the node.
+// The domain must declare the chain id of the node
+//
+//nolint:stylecheck
+func (e *Eth) SignTypedData_v4(addr types.Address, typedData typedDataArg) (interface{}, error) {
+	signer, err := e.hashSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := typedData.hash(e.chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := signer.SignHash(addr, hash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return toEthSignature(sig), nil
+}
+
+// hashSigner returns the signer of the messages of the accounts managed by the node
+func (e *Eth) hashSigner() (HashSigner, error) {
+	signer, ok := e.signer.(HashSigner)
+	if !ok {
+		return nil, ErrSignNotSupported
+	}
+
+	return signer, nil
+}
+
+// sendTransaction fills in the gas price, the gas limit and the nonce of the transaction
+// if missing, signs it with the given function and adds it to the pool.
+// The transaction gets the next nonce of the sender in the pool by default
+func (e *Eth) sendTransaction(
+	arg *txnArgs,
+	sign func(*types.Transaction) (*types.Transaction, error),
+) (interface{}, error) {
+	if arg.From == nil {
+		return nil, ErrMissingSender
+	}
+
+	if arg.GasPrice == nil {
+		price, err := e.GasPrice()
+		if err != nil {
+			return nil, err
+		}
+
+		priceValue, _ := price.(argUint64)
+		arg.GasPrice = argBytesPtr(new(big.Int).SetUint64(uint64(priceValue)).Bytes())
+	}
+
+	if arg.Gas == nil {
+		gas, err := e.EstimateGas(arg, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		gasValue, _ := gas.(argUint64)
+		arg.Gas = &gasValue
+	}
+
+	// the transaction follows the pending transactions of the sender
+	if arg.Nonce == nil {
+		arg.Nonce = argUintPtr(e.store.GetNonce(*arg.From))
+	}
+
+	tx, err := DecodeTxn(arg, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := sign(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signed.ComputeHash()
+
+	if err := e.store.AddTx(signed); err != nil {
+		return nil, err
+	}
+
+	return signed.Hash.String(), nil
 }
 
 // GetTransactionByHash returns a transaction by its hash.
@@ -334,9 +585,40 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 		Logs:              logs,
 	}
 
+	if len(raw.RevertReason) > 0 {
+		// the raw output is returned for the custom errors
+		reason, ok := runtime.UnpackRevertReason(raw.RevertReason)
+		if !ok {
+			reason = hex.EncodeToHex(raw.RevertReason)
+		}
+
+		res.RevertReason = &reason
+	}
+
 	return res, nil
 }
 
+// GetBlockReceipts returns the receipts of all the transactions of the block,
+// served in the compatibility mode of the block explorers only
+func (e *Eth) GetBlockReceipts(filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := e.store.GetBlockByHash(header.Hash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	return toBlockReceipts(block, receipts), nil
+}
+
 // GetStorageAt returns the contract storage at the index position
 func (e *Eth) GetStorageAt(
 	address types.Address,
@@ -377,6 +659,26 @@ func (e *Eth) GetStorageAt(
 	return argBytesPtr(types.BytesToHash(data).Bytes()), nil
 }
 
+// GetProof returns the merkle proof of the account and the given storage slots
+// at the given block, as defined in EIP-1186
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountProof(address, proof), nil
+}
+
 // GasPrice returns the average gas price based on the last x blocks
 // taking into consideration operator defined price limit
 func (e *Eth) GasPrice() (interface{}, error) {
@@ -404,7 +706,7 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 	}
 
 	// The return value of the execution is saved in the transition (returnValue field)
-	result, err := e.store.ApplyTxn(header, transaction)
+	result, err := e.applyCall(header, transaction)
 	if err != nil {
 		return nil, err
 	}
@@ -421,25 +723,123 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 	return argBytesPtr(result.ReturnValue), nil
 }
 
-// EstimateGas estimates the gas needed to execute a transaction
-func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
-	transaction, err := DecodeTxn(arg, e.store)
+// applyCall executes the call on the state of the block, or returns its result
+// cached from an identical call on the same block
+func (e *Eth) applyCall(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+	if e.callCache == nil {
+		return e.store.ApplyTxn(header, txn)
+	}
+
+	key := newCallCacheKey(header.Hash, txn)
+
+	if result, ok := e.callCache.get(key); ok {
+		return result, nil
+	}
+
+	result, err := e.store.ApplyTxn(header, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	e.callCache.add(key, result)
+
+	return result, nil
+}
+
+// CallMany executes the calls independently on the state of the same block, in a single round trip.
+// The result of each call holds its return value, or its error along with the revert data if any
+func (e *Eth) CallMany(args []*txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+	if len(args) > maxCallManyLength {
+		return nil, ErrTooManyCalls
+	}
+
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	txns := make([]*types.Transaction, len(args))
+
+	for idx, arg := range args {
+		if arg == nil {
+			return nil, fmt.Errorf("call %d is empty", idx)
+		}
+
+		if txns[idx], err = DecodeTxn(arg, e.store); err != nil {
+			return nil, fmt.Errorf("call %d: %w", idx, err)
+		}
+
+		// the calls without gas limit get the block gas limit, as for eth_call
+		if txns[idx].Gas == 0 {
+			txns[idx].Gas = header.GasLimit
+		}
+	}
+
+	results, err := e.store.ApplyTxns(header, txns)
 	if err != nil {
 		return nil, err
 	}
 
-	number := LatestBlockNumber
-	if rawNum != nil {
-		number = *rawNum
+	res := make([]*callResult, len(results))
+
+	for idx, result := range results {
+		switch {
+		case result.Reverted():
+			res[idx] = &callResult{
+				Error: constructErrorFromRevert(result).Error(),
+				Data:  argBytesPtr(result.ReturnValue),
+			}
+		case result.Failed():
+			res[idx] = &callResult{
+				Error: fmt.Sprintf("unable to execute call: %s", result.Err),
+			}
+		default:
+			res[idx] = &callResult{
+				Value: argBytesPtr(result.ReturnValue),
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// EstimateGas estimates the gas needed to execute a transaction, by searching for the lowest gas limit
+// the transaction succeeds with. The transaction is executed on the state of the requested block
+// (latest by default), with the nonce of the sender at that block. If the transaction fails even
+// with the highest gas limit, the error is returned along with the revert reason and data if any
+func (e *Eth) EstimateGas(arg *txnArgs, filter *BlockNumberOrHash) (interface{}, error) {
+	bnh := BlockNumberOrHash{}
+	if filter != nil {
+		bnh = *filter
 	}
 
 	// Fetch the requested header
-	header, err := GetBlockHeader(number, e.store)
+	header, err := GetHeaderFromBlockNumberOrHash(bnh, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	// The arguments of the caller are left untouched
+	argCopy := *arg
+	arg = &argCopy
+
+	// The transaction is applied on the state of the requested block,
+	// it must have the nonce of the sender at that block
+	if arg.From != nil {
+		nonce, err := e.getNonceAt(header, *arg.From)
+		if err != nil {
+			return nil, err
+		}
+
+		arg.Nonce = argUintPtr(nonce)
+	}
+
+	transaction, err := DecodeTxn(arg, e.store)
 	if err != nil {
 		return nil, err
 	}
 
-	forksInTime := e.store.GetForksInTime(uint64(number))
+	forksInTime := e.store.GetForksInTime(header.Number)
 
 	var standardGas uint64
 	if transaction.IsContractCreation() && forksInTime.Homestead {
@@ -515,26 +915,11 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		}
 	}
 
-	// Checks if executor level valid gas errors occurred
-	isGasApplyError := func(err error) bool {
-		// Not linting this as the underlying error is actually wrapped
-		return errors.Is(err, state.ErrNotEnoughIntrinsicGas)
-	}
-
-	// Checks if EVM level valid gas errors occurred
-	isGasEVMError := func(err error) bool {
-		return errors.Is(err, runtime.ErrOutOfGas) ||
-			errors.Is(err, runtime.ErrCodeStoreOutOfGas)
-	}
-
-	// Checks if the EVM reverted during execution
-	isEVMRevertError := func(err error) bool {
-		return errors.Is(err, runtime.ErrExecutionReverted)
-	}
-
 	// Run the transaction with the specified gas value.
-	// Returns a status indicating if the transaction failed and the accompanying error
-	testTransaction := func(gas uint64, shouldOmitErr bool) (bool, error) {
+	// Returns a status indicating if the transaction failed and the accompanying error,
+	// the failures caused by a too low gas limit (including the reverts) come without error
+	// unless they are reported
+	testTransaction := func(gas uint64, reportFailure bool) (bool, error) {
 		// Create a dummy transaction with the new gas
 		txn := transaction.Copy()
 		txn.Gas = gas
@@ -542,48 +927,54 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		result, applyErr := e.store.ApplyTxn(header, txn)
 
 		if applyErr != nil {
-			// Check the application error.
-			// Gas apply errors are valid, and should be ignored
-			if isGasApplyError(applyErr) && shouldOmitErr {
-				// Specifying the transaction failed, but not providing an error
-				// is an indication that a valid error occurred due to low gas,
-				// which will increase the lower bound for the search
+			// Gas apply errors are valid, and increase the lower bound of the search
+			if errors.Is(applyErr, state.ErrNotEnoughIntrinsicGas) && !reportFailure {
 				return true, nil
 			}
 
 			return true, applyErr
 		}
 
-		// Check if an out of gas error happened during EVM execution
-		if result.Failed() {
-			if isGasEVMError(result.Err) && shouldOmitErr {
-				// Specifying the transaction failed, but not providing an error
-				// is an indication that a valid error occurred due to low gas,
-				// which will increase the lower bound for the search
-				return true, nil
-			}
+		if result.Succeeded() {
+			return false, nil
+		}
 
-			if isEVMRevertError(result.Err) {
-				// The EVM reverted during execution, attempt to extract the
-				// error message and return it
-				return true, constructErrorFromRevert(result)
-			}
+		if !reportFailure {
+			// Out of gas errors and reverts are expected below the lowest gas limit,
+			// a contract may revert when it's given too little gas
+			return true, nil
+		}
 
-			return true, result.Err
+		if result.Reverted() {
+			// The EVM reverted during execution, return the revert reason and data
+			return true, constructErrorFromRevert(result)
 		}
 
-		return false, nil
+		return true, result.Err
+	}
+
+	// Execute the transaction with the highest gas limit first,
+	// if it fails there's no gas limit the transaction succeeds with
+	if failed, err := testTransaction(highEnd, true); failed {
+		var revertErr *revertError
+		if errors.As(err, &revertErr) {
+			return 0, revertErr
+		}
+
+		return 0, fmt.Errorf(
+			"unable to apply transaction even for the highest gas limit %d: %w",
+			highEnd,
+			err,
+		)
 	}
 
-	// Start the binary search for the lowest possible gas price
+	// Binary search for the lowest gas limit the transaction succeeds with,
+	// the search is deterministic for a given block
 	for lowEnd < highEnd {
-		mid := (lowEnd + highEnd) / 2
+		mid := lowEnd + (highEnd-lowEnd)/2
 
-		failed, testErr := testTransaction(mid, true)
-		if testErr != nil &&
-			!isEVMRevertError(testErr) {
-			// Reverts are ignored in the binary search, but are checked later on
-			// during the execution for the optimal gas limit found
+		failed, testErr := testTransaction(mid, false)
+		if testErr != nil {
 			return 0, testErr
 		}
 
@@ -596,18 +987,19 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		}
 	}
 
-	// Check if the highEnd is a good value to make the transaction pass
-	failed, err := testTransaction(highEnd, false)
-	if failed {
-		// The transaction shouldn't fail, for whatever reason, at highEnd
-		return 0, fmt.Errorf(
-			"unable to apply transaction even for the highest gas limit %d: %w",
-			highEnd,
-			err,
-		)
+	return argUint64(highEnd), nil
+}
+
+// getNonceAt returns the nonce of the account in the state of the block
+func (e *Eth) getNonceAt(header *types.Header, addr types.Address) (uint64, error) {
+	acc, err := e.store.GetAccount(header.StateRoot, addr)
+	if errors.Is(err, ErrStateNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
 	}
 
-	return argUint64(highEnd), nil
+	return acc.Nonce, nil
 }
 
 // GetFilterLogs returns an array of logs for the specified filter
@@ -625,6 +1017,16 @@ func (e *Eth) GetLogs(query *LogQuery) (interface{}, error) {
 	return e.filterManager.GetLogsForQuery(query)
 }
 
+// GetInternalTransactions returns the value transfers made by contracts to or from
+// the address in the block range, it requires the indexer mode
+func (e *Eth) GetInternalTransactions(query *IndexQuery) (interface{}, error) {
+	if e.index == nil {
+		return nil, ErrIndexerDisabled
+	}
+
+	return e.index.GetInternalTransfers(query)
+}
+
 // GetBalance returns the account's balance at the referenced block.
 func (e *Eth) GetBalance(address types.Address, filter BlockNumberOrHash) (interface{}, error) {
 	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
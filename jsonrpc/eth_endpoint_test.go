@@ -232,13 +232,13 @@ func TestEth_GetNextNonce(t *testing.T) {
 
 func newTestEthEndpoint(store testStore) *Eth {
 	return &Eth{
-		hclog.NewNullLogger(), store, 100, nil, 0,
+		hclog.NewNullLogger(), store, 100, nil, 0, nil, nil, nil, nil, nil,
 	}
 }
 
 func newTestEthEndpointWithPriceLimit(store testStore, priceLimit uint64) *Eth {
 	return &Eth{
-		hclog.NewNullLogger(), store, 100, nil, priceLimit,
+		hclog.NewNullLogger(), store, 100, nil, priceLimit, nil, nil, nil, nil, nil,
 	}
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/umbracle/fastrlp"
 )
 
@@ -579,6 +580,43 @@ func TestEth_State_GetStorageAt(t *testing.T) {
 	}
 }
 
+func TestEth_State_GetProof(t *testing.T) {
+	store := getExampleStore()
+	store.account.Storage(hash1, types.BytesToHash(big.NewInt(7).Bytes()).Bytes())
+	store.proofs = [][]byte{{0x1, 0x2}, {0x3}}
+
+	eth := newTestEthEndpoint(store)
+
+	latest := LatestBlockNumber
+
+	t.Run("existing account", func(t *testing.T) {
+		res, err := eth.GetProof(addr0, []types.Hash{hash1, hash2}, BlockNumberOrHash{BlockNumber: &latest})
+		assert.NoError(t, err)
+
+		proof, ok := res.(*accountProof)
+		assert.True(t, ok)
+
+		assert.Equal(t, addr0, proof.Address)
+		assert.Equal(t, []argBytes{{0x1, 0x2}, {0x3}}, proof.AccountProof)
+		assert.Equal(t, big.NewInt(100), (*big.Int)(&proof.Balance))
+		assert.Equal(t, argUint64(0), proof.Nonce)
+		assert.Len(t, proof.StorageProof, 2)
+
+		assert.Equal(t, hash1, proof.StorageProof[0].Key)
+		assert.Equal(t, big.NewInt(7), (*big.Int)(&proof.StorageProof[0].Value))
+
+		assert.Equal(t, hash2, proof.StorageProof[1].Key)
+		assert.Zero(t, (*big.Int)(&proof.StorageProof[1].Value).Sign())
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		blockNumber := BlockNumber(10)
+
+		_, err := eth.GetProof(addr0, nil, BlockNumberOrHash{BlockNumber: &blockNumber})
+		assert.Error(t, err)
+	})
+}
+
 func constructMockTx(gasLimit *argUint64, data *argBytes) *txnArgs {
 	return &txnArgs{
 		From:     &addr0,
@@ -765,10 +803,84 @@ func TestEth_EstimateGas_Errors(t *testing.T) {
 	assert.ErrorIs(t, estimateErr, ErrInsufficientFunds)
 }
 
+func TestEth_EstimateGas_RequestedBlock(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	// the sender has sent transactions at the requested block
+	store.account.account.Nonce = 5
+
+	var (
+		appliedHeader *types.Header
+		appliedNonces = map[uint64]struct{}{}
+	)
+
+	store.applyTxnHook = func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+		appliedHeader = header
+		appliedNonces[txn.Nonce] = struct{}{}
+
+		// the contract reverts when it's given too little gas
+		if txn.Gas < 40000 {
+			return &runtime.ExecutionResult{Err: runtime.ErrExecutionReverted}, nil
+		}
+
+		return &runtime.ExecutionResult{}, nil
+	}
+
+	arg := constructMockTx(nil, nil)
+
+	estimate, err := ethEndpoint.EstimateGas(arg, &BlockNumberOrHash{BlockHash: &hash1})
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(40000), estimate)
+
+	// the transaction is executed on the state of the requested block
+	assert.Equal(t, store.block.Header, appliedHeader)
+	assert.Equal(t, map[uint64]struct{}{5: {}}, appliedNonces)
+
+	// the arguments of the caller are untouched
+	assert.Equal(t, argUintPtr(0), arg.Nonce)
+	assert.Nil(t, arg.Gas)
+
+	unknownBlock := BlockNumber(10)
+
+	_, err = ethEndpoint.EstimateGas(arg, &BlockNumberOrHash{BlockNumber: &unknownBlock})
+	assert.Error(t, err)
+}
+
+func TestEth_EstimateGas_CustomError(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	// InsufficientBalance(uint256,uint256) custom error
+	revertData := append(
+		hex.MustDecodeHex("0xcf479181"),
+		append(types.StringToHash("1").Bytes(), types.StringToHash("2").Bytes()...)...,
+	)
+
+	store.applyTxnHook = func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
+		return &runtime.ExecutionResult{
+			ReturnValue: revertData,
+			Err:         runtime.ErrExecutionReverted,
+		}, nil
+	}
+
+	_, err := ethEndpoint.EstimateGas(constructMockTx(nil, nil), nil)
+	assert.ErrorIs(t, err, runtime.ErrExecutionReverted)
+
+	var revertErr *revertError
+	require.ErrorAs(t, err, &revertErr)
+
+	// the custom error is returned in the data of the error
+	assert.Equal(t, 3, revertErr.ErrorCode())
+	assert.Equal(t, hex.EncodeToHex(revertData), revertErr.ErrorData())
+	assert.Equal(t, runtime.ErrExecutionReverted.Error(), revertErr.Error())
+}
+
 type mockSpecialStore struct {
 	ethStore
 	account *mockAccount
 	block   *types.Block
+	proofs  [][]byte
 
 	applyTxnHook func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
 }
@@ -836,6 +948,38 @@ func (m *mockSpecialStore) GetCode(root types.Hash, addr types.Address) ([]byte,
 	return m.account.code, nil
 }
 
+func (m *mockSpecialStore) GetProof(
+	root types.Hash,
+	addr types.Address,
+	slots []types.Hash,
+) (*AccountProof, error) {
+	account := &Account{Balance: big.NewInt(0)}
+	if m.account.address == addr {
+		account = m.account.account
+	}
+
+	res := &AccountProof{
+		Account:     account,
+		StorageRoot: types.EmptyRootHash,
+		Proof:       m.proofs,
+	}
+
+	for _, slot := range slots {
+		var value types.Hash
+		if m.account.address == addr {
+			value = types.BytesToHash(m.account.storage[slot])
+		}
+
+		res.StorageProof = append(res.StorageProof, &StorageProof{
+			Key:   slot,
+			Value: value,
+			Proof: m.proofs,
+		})
+	}
+
+	return res, nil
+}
+
 func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return chain.ForksInTime{}
 }
@@ -29,6 +29,73 @@ func TestEth_TxnPool_SendRawTransaction(t *testing.T) {
 	}
 }
 
+func TestEth_TxnPool_SendPrivateTransaction(t *testing.T) {
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	txn := &types.Transaction{
+		From: addr0,
+		V:    big.NewInt(1),
+	}
+	txn.ComputeHash()
+
+	res, err := eth.SendPrivateTransaction(txn.MarshalRLP())
+	assert.NoError(t, err)
+	assert.Equal(t, txn.Hash.String(), res)
+
+	// the transaction is not added through the gossiping path
+	assert.True(t, store.private)
+	assert.Equal(t, txn.Hash, store.txn.Hash)
+}
+
+func TestEth_TxnPool_CancelTransaction(t *testing.T) {
+	pending := &types.Transaction{
+		From:     addr0,
+		To:       argAddrPtr(types.StringToAddress("1")),
+		Nonce:    3,
+		Value:    big.NewInt(100),
+		Gas:      50000,
+		GasPrice: big.NewInt(1000),
+	}
+	pending.ComputeHash()
+
+	store := &mockStoreTxn{txn: pending}
+	eth := newTestEthEndpoint(store)
+
+	// the node doesn't manage any account
+	_, err := eth.CancelTransaction(pending.Hash)
+	assert.ErrorIs(t, err, ErrCancelNotSupported)
+
+	eth.signer = &mockTxSigner{managed: types.StringToAddress("2")}
+
+	_, err = eth.CancelTransaction(pending.Hash)
+	assert.ErrorIs(t, err, ErrAccountNotManaged)
+
+	_, err = eth.CancelTransaction(types.StringToHash("1"))
+	assert.ErrorIs(t, err, ErrTxNotPending)
+
+	eth.signer = &mockTxSigner{managed: addr0}
+
+	res, err := eth.CancelTransaction(pending.Hash)
+	assert.NoError(t, err)
+
+	cancelTx := store.txn
+	assert.Equal(t, pending.Hash, store.replaced)
+	assert.Equal(t, cancelTx.Hash.String(), res)
+
+	// a zero value self transfer at the same nonce with a bumped fee
+	assert.Equal(t, addr0, *cancelTx.To)
+	assert.Equal(t, uint64(3), cancelTx.Nonce)
+	assert.Equal(t, big.NewInt(0), cancelTx.Value)
+	assert.Equal(t, big.NewInt(1100), cancelTx.GasPrice)
+}
+
+func TestEth_BumpGasPrice(t *testing.T) {
+	assert.Equal(t, big.NewInt(110), bumpGasPrice(big.NewInt(100), 0))
+	assert.Equal(t, big.NewInt(2), bumpGasPrice(big.NewInt(1), 0))
+	assert.Equal(t, big.NewInt(500), bumpGasPrice(big.NewInt(100), 500))
+}
+
 func TestEth_TxnPool_SendTransaction(t *testing.T) {
 	store := &mockStoreTxn{}
 	store.AddAccount(addr0)
@@ -50,6 +117,38 @@ type mockStoreTxn struct {
 	ethStore
 	accounts map[types.Address]*mockAccount
 	txn      *types.Transaction
+	private  bool
+	replaced types.Hash
+}
+
+func (m *mockStoreTxn) GetPendingTx(hash types.Hash) (*types.Transaction, bool) {
+	if m.txn == nil || m.txn.Hash != hash {
+		return nil, false
+	}
+
+	return m.txn, true
+}
+
+func (m *mockStoreTxn) ReplaceTx(hash types.Hash, tx *types.Transaction) error {
+	m.replaced = hash
+	m.txn = tx
+
+	return nil
+}
+
+type mockTxSigner struct {
+	managed types.Address
+}
+
+func (m *mockTxSigner) SignTx(tx *types.Transaction) (*types.Transaction, error) {
+	if tx.From != m.managed {
+		return nil, ErrAccountNotManaged
+	}
+
+	signed := tx.Copy()
+	signed.V = big.NewInt(1)
+
+	return signed, nil
 }
 
 func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
@@ -58,6 +157,13 @@ func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
 	return nil
 }
 
+func (m *mockStoreTxn) AddPrivateTx(tx *types.Transaction) error {
+	m.txn = tx
+	m.private = true
+
+	return nil
+}
+
 func (m *mockStoreTxn) GetNonce(addr types.Address) uint64 {
 	return 1
 }
@@ -0,0 +1,107 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// DevStore provides the control of the chain of the dev consensus
+type DevStore interface {
+	Impersonator
+
+	// Snapshot saves the head of the chain, it returns the id of the snapshot
+	Snapshot() uint64
+
+	// Revert rewinds the chain to the head saved by the snapshot,
+	// it returns false if the snapshot doesn't exist
+	Revert(id uint64) (bool, error)
+
+	// Mine seals a block with the pending transactions, with the given timestamp if not zero
+	Mine(timestamp uint64) error
+
+	// IncreaseTime moves the timestamps of the next blocks forward,
+	// it returns the total number of seconds they are moved forward by
+	IncreaseTime(seconds uint64) uint64
+
+	// SetNextBlockTimestamp sets the timestamp of the next block
+	SetNextBlockTimestamp(timestamp uint64) error
+
+	// SetAutomine enables or disables the sealing of a block at each interval
+	SetAutomine(enabled bool)
+}
+
+// Evm is the evm jsonrpc endpoint, available in the dev consensus.
+// It follows the evm namespace of the development nodes of the other clients,
+// so that the test suites can run against the chain
+type Evm struct {
+	store DevStore
+}
+
+// quantityArg is a quantity given either as a number or as a hex or decimal string,
+// the test suites send both of them
+type quantityArg uint64
+
+func (q *quantityArg) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		str = string(data)
+	}
+
+	n, err := types.ParseUint64orHex(&str)
+	if err != nil {
+		return err
+	}
+
+	*q = quantityArg(n)
+
+	return nil
+}
+
+// Snapshot saves the current chain, it returns the id of the snapshot
+func (e *Evm) Snapshot() (interface{}, error) {
+	return argUint64(e.store.Snapshot()), nil
+}
+
+// Revert rewinds the chain to the snapshot and clears the transaction pool.
+// The snapshot and the ones taken after it are removed
+func (e *Evm) Revert(id argUint64) (interface{}, error) {
+	return e.store.Revert(uint64(id))
+}
+
+// Mine seals a block with the pending transactions, optionally with the given timestamp
+func (e *Evm) Mine(timestamp *quantityArg) (interface{}, error) {
+	var t uint64
+	if timestamp != nil {
+		t = uint64(*timestamp)
+	}
+
+	if err := e.store.Mine(t); err != nil {
+		return nil, err
+	}
+
+	return "0x0", nil
+}
+
+// IncreaseTime moves the timestamps of the next blocks forward by the given number of seconds,
+// it returns the total number of seconds they are moved forward by
+func (e *Evm) IncreaseTime(seconds quantityArg) (interface{}, error) {
+	return argUint64(e.store.IncreaseTime(uint64(seconds))), nil
+}
+
+// SetNextBlockTimestamp sets the timestamp of the next block, which must be after the latest one
+func (e *Evm) SetNextBlockTimestamp(timestamp quantityArg) (interface{}, error) {
+	if err := e.store.SetNextBlockTimestamp(uint64(timestamp)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// SetAutomine enables or disables the sealing of a block at each interval,
+// the blocks are otherwise only sealed by evm_mine
+func (e *Evm) SetAutomine(enabled bool) (interface{}, error) {
+	e.store.SetAutomine(enabled)
+
+	return nil, nil
+}
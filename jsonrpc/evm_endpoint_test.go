@@ -0,0 +1,160 @@
+package jsonrpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTimestampNotAfterHead = errors.New("timestamp not after head")
+
+type mockDevStore struct {
+	head      uint64
+	snapshots []uint64
+
+	mined         []uint64
+	timestamp     uint64
+	timeOffset    uint64
+	nextTimestamp uint64
+	manualMining  bool
+
+	impersonated map[types.Address]bool
+}
+
+func (m *mockDevStore) ImpersonateAccount(addr types.Address) {
+	if m.impersonated == nil {
+		m.impersonated = map[types.Address]bool{}
+	}
+
+	m.impersonated[addr] = true
+}
+
+func (m *mockDevStore) StopImpersonatingAccount(addr types.Address) {
+	delete(m.impersonated, addr)
+}
+
+func (m *mockDevStore) IsImpersonated(addr types.Address) bool {
+	return m.impersonated[addr]
+}
+
+func (m *mockDevStore) Snapshot() uint64 {
+	m.snapshots = append(m.snapshots, m.head)
+
+	return uint64(len(m.snapshots) - 1)
+}
+
+func (m *mockDevStore) Revert(id uint64) (bool, error) {
+	if id >= uint64(len(m.snapshots)) {
+		return false, nil
+	}
+
+	m.head = m.snapshots[id]
+	m.snapshots = m.snapshots[:id]
+
+	return true, nil
+}
+
+func (m *mockDevStore) Mine(timestamp uint64) error {
+	if timestamp != 0 && timestamp <= m.timestamp {
+		return errTimestampNotAfterHead
+	}
+
+	m.mined = append(m.mined, timestamp)
+
+	return nil
+}
+
+func (m *mockDevStore) IncreaseTime(seconds uint64) uint64 {
+	m.timeOffset += seconds
+
+	return m.timeOffset
+}
+
+func (m *mockDevStore) SetNextBlockTimestamp(timestamp uint64) error {
+	if timestamp <= m.timestamp {
+		return errTimestampNotAfterHead
+	}
+
+	m.nextTimestamp = timestamp
+
+	return nil
+}
+
+func (m *mockDevStore) SetAutomine(enabled bool) {
+	m.manualMining = !enabled
+}
+
+func TestEvmEndpoint_SnapshotRevert(t *testing.T) {
+	t.Parallel()
+
+	params := &dispatcherParams{jsonRPCBatchLengthLimit: 20, blockRangeLimit: 1000}
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), params)
+
+	handle := func(req string) string {
+		t.Helper()
+
+		res, err := dispatcher.Handle([]byte(req))
+		require.NoError(t, err)
+
+		return string(res)
+	}
+
+	// the evm endpoint is only available in the dev consensus
+	assert.Contains(t, handle(`{"method": "evm_snapshot", "params": [], "id": 1}`), "error")
+
+	store := &mockDevStore{head: 3}
+	dispatcher.registerDevEndpoints(store)
+
+	assert.Contains(t, handle(`{"method": "evm_snapshot", "params": [], "id": 1}`), `"result":"0x0"`)
+
+	store.head = 7
+
+	assert.Contains(t, handle(`{"method": "evm_snapshot", "params": [], "id": 1}`), `"result":"0x1"`)
+
+	store.head = 9
+
+	assert.Contains(t, handle(`{"method": "evm_revert", "params": ["0x0"], "id": 1}`), `"result":true`)
+	assert.Equal(t, uint64(3), store.head)
+
+	// the later snapshots are removed with the reverted one
+	assert.Contains(t, handle(`{"method": "evm_revert", "params": ["0x1"], "id": 1}`), `"result":false`)
+}
+
+func TestEvmEndpoint_Time(t *testing.T) {
+	t.Parallel()
+
+	params := &dispatcherParams{jsonRPCBatchLengthLimit: 20, blockRangeLimit: 1000}
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), params)
+
+	store := &mockDevStore{timestamp: 1000}
+	dispatcher.registerDevEndpoints(store)
+
+	handle := func(req string) string {
+		t.Helper()
+
+		res, err := dispatcher.Handle([]byte(req))
+		require.NoError(t, err)
+
+		return string(res)
+	}
+
+	// the quantities are accepted as numbers and as strings
+	assert.Contains(t, handle(`{"method": "evm_increaseTime", "params": [3600], "id": 1}`), `"result":"0xe10"`)
+	assert.Contains(t, handle(`{"method": "evm_increaseTime", "params": ["0x10"], "id": 1}`), `"result":"0xe20"`)
+
+	assert.Contains(t, handle(`{"method": "evm_setNextBlockTimestamp", "params": [2000], "id": 1}`), `"result":null`)
+	assert.Equal(t, uint64(2000), store.nextTimestamp)
+	assert.Contains(t, handle(`{"method": "evm_setNextBlockTimestamp", "params": [500], "id": 1}`), "error")
+
+	assert.Contains(t, handle(`{"method": "evm_mine", "params": [], "id": 1}`), `"result":"0x0"`)
+	assert.Contains(t, handle(`{"method": "evm_mine", "params": ["3000"], "id": 1}`), `"result":"0x0"`)
+	assert.Contains(t, handle(`{"method": "evm_mine", "params": [999], "id": 1}`), "error")
+	assert.Equal(t, []uint64{0, 3000}, store.mined)
+
+	handle(`{"method": "evm_setAutomine", "params": [false], "id": 1}`)
+	assert.True(t, store.manualMining)
+}
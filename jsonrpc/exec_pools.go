@@ -0,0 +1,88 @@
+package jsonrpc
+
+import "strings"
+
+// execClass is the priority class of a method, whose requests share an execution pool
+type execClass int
+
+const (
+	// execClassRead is the class of the cheap reads, the methods of no other class
+	execClassRead execClass = iota
+	// execClassCall is the class of the calls executing transactions on a state
+	execClassCall
+	// execClassTrace is the class of the traces re-executing blocks
+	execClassTrace
+)
+
+var (
+	callMethods = map[string]struct{}{
+		"eth_call":        {},
+		"eth_callMany":    {},
+		"eth_estimateGas": {},
+	}
+
+	traceMethods = map[string]struct{}{
+		"debug_traceBlockByNumber": {},
+		"debug_traceBlockByHash":   {},
+		"debug_traceBlock":         {},
+		"debug_traceTransaction":   {},
+		"debug_traceCall":          {},
+		"debug_profileBlocks":      {},
+		"debug_getConflictStats":   {},
+		"debug_getWitness":         {},
+		"debug_replayBlock":        {},
+	}
+)
+
+// methodClass returns the class of the method, all the methods of the trace namespace are traces
+func methodClass(method string) execClass {
+	if _, ok := callMethods[method]; ok {
+		return execClassCall
+	}
+
+	if _, ok := traceMethods[method]; ok || strings.HasPrefix(method, "trace_") {
+		return execClassTrace
+	}
+
+	return execClassRead
+}
+
+// execPools limits the number of the requests of each class executed concurrently,
+// so that the expensive requests can't starve the cheap reads
+type execPools struct {
+	// slots are the free slots of the pools, nil for the classes without limit
+	slots map[execClass]chan struct{}
+}
+
+// newExecPools creates the pools of the given concurrencies, 0 for no limit
+func newExecPools(read, call, trace uint64) *execPools {
+	p := &execPools{
+		slots: map[execClass]chan struct{}{},
+	}
+
+	for class, concurrency := range map[execClass]uint64{
+		execClassRead:  read,
+		execClassCall:  call,
+		execClassTrace: trace,
+	} {
+		if concurrency != 0 {
+			p.slots[class] = make(chan struct{}, concurrency)
+		}
+	}
+
+	return p
+}
+
+// acquire waits for a slot of the pool of the method, and returns the function releasing it
+func (p *execPools) acquire(method string) func() {
+	slots, ok := p.slots[methodClass(method)]
+	if !ok {
+		return func() {}
+	}
+
+	slots <- struct{}{}
+
+	return func() {
+		<-slots
+	}
+}
@@ -0,0 +1,56 @@
+package jsonrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMethodClass(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, execClassRead, methodClass("eth_getBalance"))
+	assert.Equal(t, execClassRead, methodClass("debug_getStateDiff"))
+	assert.Equal(t, execClassCall, methodClass("eth_call"))
+	assert.Equal(t, execClassCall, methodClass("eth_estimateGas"))
+	assert.Equal(t, execClassTrace, methodClass("debug_traceTransaction"))
+	assert.Equal(t, execClassTrace, methodClass("trace_filter"))
+}
+
+func TestExecPools_Acquire(t *testing.T) {
+	t.Parallel()
+
+	pools := newExecPools(0, 1, 1)
+
+	// the reads are not limited
+	releaseRead := pools.acquire("eth_blockNumber")
+	pools.acquire("eth_blockNumber")()
+	releaseRead()
+
+	releaseTrace := pools.acquire("trace_block")
+
+	// the calls don't wait for the traces
+	pools.acquire("eth_call")()
+
+	acquired := make(chan struct{})
+
+	go func() {
+		pools.acquire("debug_traceCall")()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("the trace pool is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	releaseTrace()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("the released slot was not acquired")
+	}
+}
@@ -0,0 +1,208 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ExplorerStore is the interface of the compatibility mode of the block explorers
+type ExplorerStore interface {
+	// GetTD returns the total difficulty of the chain at the block
+	GetTD(hash types.Hash) (*big.Int, bool)
+
+	// GetTxs gets tx pool transactions currently pending for inclusion and currently queued for validation
+	GetTxs(inclQueued bool) (map[types.Address][]*types.Transaction, map[types.Address][]*types.Transaction)
+}
+
+// explorerMethods are the non-standard methods served in the compatibility mode only
+var explorerMethods = map[string]struct{}{
+	"eth_getBlockReceipts": {},
+}
+
+// pendingBlock is the block built from the pending transactions of the pool on top of the head,
+// which is not sealed and has no hash, nonce and miner
+type pendingBlock struct {
+	*block
+	Hash  *types.Hash  `json:"hash"`
+	Nonce *types.Nonce `json:"nonce"`
+	Miner *argBytes    `json:"miner"`
+}
+
+// enableExplorerCompat enables the non-standard endpoints and response shapes expected by the block explorers
+func (d *Dispatcher) enableExplorerCompat(store ExplorerStore) {
+	d.endpoints.Eth.explorer = store
+}
+
+// isExplorerMethod checks whether the method is served in the compatibility mode only
+func isExplorerMethod(method string) bool {
+	_, ok := explorerMethods[method]
+
+	return ok
+}
+
+// handlePendingReq handles the requests of the pending block in the compatibility mode,
+// as the pending block tag is otherwise decoded as the latest block
+func (d *Dispatcher) handlePendingReq(req Request) ([]byte, bool) {
+	eth := d.endpoints.Eth
+	if eth == nil || eth.explorer == nil {
+		return nil, false
+	}
+
+	var params []json.RawMessage
+	if err := json.Unmarshal(req.Params, &params); err != nil || len(params) == 0 {
+		return nil, false
+	}
+
+	var tag string
+	if err := json.Unmarshal(params[0], &tag); err != nil || tag != pending {
+		return nil, false
+	}
+
+	var res interface{}
+
+	switch req.Method {
+	case "eth_getBlockByNumber":
+		var fullTx bool
+		if len(params) > 1 {
+			if err := json.Unmarshal(params[1], &fullTx); err != nil {
+				return nil, false
+			}
+		}
+
+		res = eth.pendingBlock(fullTx)
+	case "eth_getBlockTransactionCountByNumber":
+		res = argUint64(len(eth.pendingTxs()))
+	default:
+		return nil, false
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// pendingBlock returns the block of the pending transactions on top of the head
+func (e *Eth) pendingBlock(fullTx bool) *pendingBlock {
+	head := e.store.Header()
+
+	b := &types.Block{
+		Header: &types.Header{
+			ParentHash: head.Hash,
+			Number:     head.Number + 1,
+			GasLimit:   head.GasLimit,
+			Timestamp:  uint64(time.Now().Unix()),
+			Sha3Uncles: types.EmptyUncleHash,
+		},
+		Transactions: e.pendingTxs(),
+	}
+
+	res := toBlock(b, false)
+
+	if fullTx {
+		res.Transactions = make([]transactionOrHash, len(b.Transactions))
+
+		for idx, txn := range b.Transactions {
+			idx := idx
+			res.Transactions[idx] = toTransaction(txn, argUintPtr(b.Number()), nil, &idx)
+		}
+	}
+
+	e.setTotalDifficulty(res, head.Hash)
+
+	return &pendingBlock{block: res}
+}
+
+// pendingTxs returns the pending transactions of the pool, by sender and nonce
+func (e *Eth) pendingTxs() []*types.Transaction {
+	pending, _ := e.explorer.GetTxs(false)
+
+	senders := make([]types.Address, 0, len(pending))
+	for sender := range pending {
+		senders = append(senders, sender)
+	}
+
+	sort.Slice(senders, func(i, j int) bool {
+		return bytes.Compare(senders[i].Bytes(), senders[j].Bytes()) < 0
+	})
+
+	txs := []*types.Transaction{}
+	for _, sender := range senders {
+		txs = append(txs, pending[sender]...)
+	}
+
+	return txs
+}
+
+// setTotalDifficulty sets the total difficulty of the chain at the block in the compatibility mode,
+// the difficulty of the block is returned otherwise
+func (e *Eth) setTotalDifficulty(b *block, hash types.Hash) {
+	if e.explorer == nil {
+		return
+	}
+
+	if td, ok := e.explorer.GetTD(hash); ok {
+		b.TotalDifficulty = argUint64(td.Uint64())
+	}
+}
+
+// toBlockReceipts returns the receipts of the transactions of the block,
+// whose logs are indexed within the block
+func toBlockReceipts(b *types.Block, receipts []*types.Receipt) []*receipt {
+	res := make([]*receipt, 0, len(receipts))
+	logIndex := 0
+
+	for idx, raw := range receipts {
+		if idx >= len(b.Transactions) {
+			break
+		}
+
+		txn := b.Transactions[idx]
+
+		logs := make([]*Log, len(raw.Logs))
+		for i, elem := range raw.Logs {
+			logs[i] = &Log{
+				Address:     elem.Address,
+				Topics:      elem.Topics,
+				Data:        argBytes(elem.Data),
+				BlockHash:   b.Hash(),
+				BlockNumber: argUint64(b.Number()),
+				TxHash:      txn.Hash,
+				TxIndex:     argUint64(idx),
+				LogIndex:    argUint64(logIndex),
+			}
+
+			logIndex++
+		}
+
+		r := &receipt{
+			Root:              raw.Root,
+			CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
+			LogsBloom:         raw.LogsBloom,
+			TxHash:            txn.Hash,
+			TxIndex:           argUint64(idx),
+			BlockHash:         b.Hash(),
+			BlockNumber:       argUint64(b.Number()),
+			GasUsed:           argUint64(raw.GasUsed),
+			ContractAddress:   raw.ContractAddress,
+			FromAddr:          txn.From,
+			ToAddr:            txn.To,
+			Logs:              logs,
+		}
+
+		if raw.Status != nil {
+			r.Status = argUint64(*raw.Status)
+		}
+
+		res = append(res, r)
+	}
+
+	return res
+}
@@ -0,0 +1,134 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockExplorerStore struct {
+	td      map[types.Hash]*big.Int
+	pending map[types.Address][]*types.Transaction
+}
+
+func (m *mockExplorerStore) GetTD(hash types.Hash) (*big.Int, bool) {
+	td, ok := m.td[hash]
+
+	return td, ok
+}
+
+func (m *mockExplorerStore) GetTxs(inclQueued bool) (
+	map[types.Address][]*types.Transaction,
+	map[types.Address][]*types.Transaction,
+) {
+	return m.pending, nil
+}
+
+func TestEth_GetBlockReceipts(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+
+	block := newTestBlock(1, hash4)
+	block.Transactions = []*types.Transaction{
+		newTestTransaction(0, addr0),
+		newTestTransaction(1, addr0),
+	}
+	store.add(block)
+
+	first := &types.Receipt{
+		Logs: []*types.Log{{Topics: []types.Hash{hash1}}, {Topics: []types.Hash{hash2}}},
+	}
+	first.SetStatus(types.ReceiptSuccess)
+
+	second := &types.Receipt{
+		Logs: []*types.Log{{Topics: []types.Hash{hash3}}},
+	}
+	second.SetStatus(types.ReceiptFailed)
+
+	store.receipts[hash4] = []*types.Receipt{first, second}
+
+	res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash4})
+	require.NoError(t, err)
+
+	receipts, ok := res.([]*receipt)
+	require.True(t, ok)
+	require.Len(t, receipts, 2)
+
+	assert.Equal(t, block.Transactions[1].Hash, receipts[1].TxHash)
+	assert.Equal(t, argUint64(1), receipts[1].TxIndex)
+	assert.Equal(t, argUint64(types.ReceiptFailed), receipts[1].Status)
+
+	// the logs are indexed within the block
+	assert.Equal(t, argUint64(1), receipts[0].Logs[1].LogIndex)
+	assert.Equal(t, argUint64(2), receipts[1].Logs[0].LogIndex)
+	assert.Equal(t, argUint64(1), receipts[1].Logs[0].TxIndex)
+}
+
+func TestDispatcher_ExplorerCompat(t *testing.T) {
+	t.Parallel()
+
+	head := &types.Header{Number: 5, Hash: hash1, GasLimit: 1000}
+
+	store := newMockStore()
+	store.header = head
+
+	newRequest := func(method string, params ...interface{}) Request {
+		raw, err := json.Marshal(params)
+		require.NoError(t, err)
+
+		return Request{Method: method, Params: raw}
+	}
+
+	t.Run("should not serve the quirks if disabled", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newDispatcher(hclog.NewNullLogger(), store, &dispatcherParams{})
+
+		_, err := dispatcher.handleReq(newRequest("eth_getBlockReceipts", "latest"))
+		require.Error(t, err)
+		assert.Equal(t, NewMethodNotFoundError("").ErrorCode(), err.ErrorCode())
+
+		_, ok := dispatcher.handlePendingReq(newRequest("eth_getBlockByNumber", "pending", false))
+		assert.False(t, ok)
+	})
+
+	t.Run("should serve the pending block", func(t *testing.T) {
+		t.Parallel()
+
+		pending := newTestTransaction(0, addr1)
+		explorer := &mockExplorerStore{
+			td: map[types.Hash]*big.Int{hash1: big.NewInt(100)},
+			pending: map[types.Address][]*types.Transaction{
+				addr1: {pending},
+			},
+		}
+
+		dispatcher := newDispatcher(hclog.NewNullLogger(), store, &dispatcherParams{})
+		dispatcher.enableExplorerCompat(explorer)
+
+		data, err := dispatcher.handleReq(newRequest("eth_getBlockByNumber", "pending", false))
+		require.Nil(t, err)
+
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &res))
+
+		assert.Equal(t, "0x6", res["number"])
+		assert.Equal(t, hash1.String(), res["parentHash"])
+		assert.Equal(t, "0x64", res["totalDifficulty"])
+		assert.Nil(t, res["hash"])
+		assert.Nil(t, res["nonce"])
+		assert.Nil(t, res["miner"])
+		assert.Equal(t, []interface{}{pending.Hash.String()}, res["transactions"])
+
+		data, err = dispatcher.handleReq(newRequest("eth_getBlockTransactionCountByNumber", "pending"))
+		require.Nil(t, err)
+		assert.Equal(t, `"0x1"`, string(data))
+	})
+}
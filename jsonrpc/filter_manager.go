@@ -15,6 +15,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
 )
 
 var (
@@ -25,6 +26,7 @@ var (
 	ErrIncorrectBlockRange              = errors.New("incorrect range")
 	ErrBlockRangeTooHigh                = errors.New("block range too high")
 	ErrNoWSConnection                   = errors.New("no websocket connection")
+	ErrTooManyFilters                   = errors.New("too many filters installed by the client")
 )
 
 // defaultTimeout is the timeout to remove the filters that don't have a web socket stream
@@ -33,6 +35,9 @@ var defaultTimeout = 1 * time.Minute
 const (
 	// The index in heap which is indicating the element is not in the heap
 	NoIndexInHeap = -1
+
+	// bloomCacheSize is the number of recent blocks whose log blooms are cached
+	bloomCacheSize = 4096
 )
 
 // filter is an interface that BlockFilter and LogFilter implement
@@ -63,6 +68,9 @@ type filterBase struct {
 
 	// websocket connection
 	ws wsConn
+
+	// address of the client polling the filter, empty for the filters not limited per client
+	client string
 }
 
 // newFilterBase initializes filterBase with unique ID
@@ -212,6 +220,59 @@ func (f *logFilter) sendUpdates() error {
 	return nil
 }
 
+// accountFilter is a filter to store the balance and nonce changes of the watched accounts
+type accountFilter struct {
+	filterBase
+	sync.Mutex
+
+	watcher *AccountWatcher
+	changes []*AccountChange
+}
+
+// appendChanges appends new account changes
+func (f *accountFilter) appendChanges(changes []*AccountChange) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.changes = append(f.changes, changes...)
+}
+
+// takeChanges returns all saved account changes in filter and set new change slice
+func (f *accountFilter) takeChanges() []*accountChange {
+	f.Lock()
+	defer f.Unlock()
+
+	changes := make([]*accountChange, len(f.changes))
+	for idx, change := range f.changes {
+		changes[idx] = toAccountChange(change)
+	}
+
+	f.changes = nil
+
+	return changes
+}
+
+// getUpdates returns stored account changes
+func (f *accountFilter) getUpdates() (interface{}, error) {
+	return f.takeChanges(), nil
+}
+
+// sendUpdates writes stored account changes to web socket stream
+func (f *accountFilter) sendUpdates() error {
+	for _, change := range f.takeChanges() {
+		res, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(res)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
@@ -228,6 +289,9 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// GetAccount returns the account at the state root
+	GetAccount(root types.Hash, addr types.Address) (*Account, error)
 }
 
 // FilterManager manages all running filters
@@ -243,21 +307,37 @@ type FilterManager struct {
 	blockStream     *blockStream
 	blockRangeLimit uint64
 
+	// blooms caches the log blooms computed from the receipts of the blocks
+	blooms *lru.Cache
+
 	filters  map[string]filter
 	timeouts timeHeapImpl
 
+	// clients counts the polling filters of each client, limited to maxFiltersPerClient if not 0
+	clients             map[string]uint64
+	maxFiltersPerClient uint64
+
+	// storePath is the file persisting the polling filters, which are not persisted if empty
+	storePath string
+	dirty     int32
+
 	updateCh chan struct{}
 	closeCh  chan struct{}
 }
 
 func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeLimit uint64) *FilterManager {
+	// the cache cannot fail with a positive size
+	blooms, _ := lru.New(bloomCacheSize)
+
 	m := &FilterManager{
 		logger:          logger.Named("filter"),
 		timeout:         defaultTimeout,
 		store:           store,
 		blockRangeLimit: blockRangeLimit,
+		blooms:          blooms,
 		filters:         make(map[string]filter),
 		timeouts:        timeHeapImpl{},
+		clients:         make(map[string]uint64),
 		updateCh:        make(chan struct{}),
 		closeCh:         make(chan struct{}),
 	}
@@ -275,6 +355,19 @@ func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeL
 	return m
 }
 
+// SetTimeout sets the time after which the filters not polled are removed, it must be called before Run
+func (f *FilterManager) SetTimeout(timeout time.Duration) {
+	f.timeout = timeout
+}
+
+// SetMaxFiltersPerClient limits the polling filters installed by each client, 0 for no limit
+func (f *FilterManager) SetMaxFiltersPerClient(max uint64) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.maxFiltersPerClient = max
+}
+
 // Run starts worker process to handle events
 func (f *FilterManager) Run() {
 	// watch for new events in the blockchain
@@ -292,6 +385,9 @@ func (f *FilterManager) Run() {
 
 	var timeoutCh <-chan time.Time
 
+	storeTicker := time.NewTicker(filterStoreInterval)
+	defer storeTicker.Stop()
+
 	for {
 		// check for the next filter to be removed
 		filterID, filterExpiresAt := f.nextTimeoutFilter()
@@ -318,6 +414,14 @@ func (f *FilterManager) Run() {
 		case <-f.updateCh:
 			// filters change, reset the loop to start the timeout timer
 
+		case <-storeTicker.C:
+			// persist the polling filters if they changed
+			if f.storePath != "" && atomic.LoadInt32(&f.dirty) == 1 {
+				if err := f.saveFilters(); err != nil {
+					f.logger.Error("failed to save filters", "err", err)
+				}
+			}
+
 		case <-f.closeCh:
 			// stop the filter manager
 			return
@@ -325,9 +429,15 @@ func (f *FilterManager) Run() {
 	}
 }
 
-// Close closed closeCh so that terminate worker
+// Close closed closeCh so that terminate worker, and persists the polling filters
 func (f *FilterManager) Close() {
 	close(f.closeCh)
+
+	if f.storePath != "" {
+		if err := f.saveFilters(); err != nil {
+			f.logger.Error("failed to save filters", "err", err)
+		}
+	}
 }
 
 // NewBlockFilter adds new BlockFilter
@@ -358,6 +468,55 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewClientBlockFilter adds new BlockFilter polled by the client,
+// unless the client reached its limit of filters
+func (f *FilterManager) NewClientBlockFilter(client string) (string, error) {
+	filter := &blockFilter{
+		filterBase: newFilterBase(nil),
+		block:      f.blockStream.getHead(),
+	}
+
+	filter.client = client
+
+	return f.addClientFilter(filter)
+}
+
+// NewClientLogFilter adds new LogFilter polled by the client,
+// unless the client reached its limit of filters
+func (f *FilterManager) NewClientLogFilter(client string, logQuery *LogQuery) (string, error) {
+	filter := &logFilter{
+		filterBase: newFilterBase(nil),
+		query:      logQuery,
+	}
+
+	filter.client = client
+
+	return f.addClientFilter(filter)
+}
+
+// NewAccountFilter adds new AccountFilter watching the balances and the nonces of the addresses,
+// the current state of the accounts comes before the first changes
+func (f *FilterManager) NewAccountFilter(addresses []types.Address, ws wsConn) (string, error) {
+	header := f.store.Header()
+
+	watcher, err := NewAccountWatcher(f.store, addresses, header)
+	if err != nil {
+		return "", err
+	}
+
+	filter := &accountFilter{
+		filterBase: newFilterBase(ws),
+		watcher:    watcher,
+		changes:    watcher.Snapshot(header),
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter), nil
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.RLock()
@@ -368,8 +527,14 @@ func (f *FilterManager) Exists(id string) bool {
 	return ok
 }
 
+// getLogsFromBlock returns the logs of the block matching the query,
+// the receipts are not read if the bloom of the block doesn't match
 func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([]*Log, error) {
-	receipts, err := f.store.GetReceiptsByHash(block.Header.Hash)
+	if bloom, ok := f.blockBloom(block.Header.Hash, block.Header.LogsBloom); ok && !query.MatchBloom(bloom) {
+		return []*Log{}, nil
+	}
+
+	receipts, err := f.getReceipts(block.Header.Hash)
 	if err != nil {
 		return nil, err
 	}
@@ -377,6 +542,11 @@ func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([
 	logs := make([]*Log, 0)
 
 	for idx, receipt := range receipts {
+		txHash := receipt.TxHash
+		if txHash == types.ZeroHash {
+			txHash = block.Transactions[idx].Hash
+		}
+
 		for logIdx, log := range receipt.Logs {
 			if query.Match(log) {
 				logs = append(logs, &Log{
@@ -385,7 +555,7 @@ func (f *FilterManager) getLogsFromBlock(query *LogQuery, block *types.Block) ([
 					Data:        log.Data,
 					BlockNumber: argUint64(block.Header.Number),
 					BlockHash:   block.Header.Hash,
-					TxHash:      block.Transactions[idx].Hash,
+					TxHash:      txHash,
 					TxIndex:     argUint64(idx),
 					LogIndex:    argUint64(logIdx),
 				})
@@ -467,6 +637,32 @@ func (f *FilterManager) GetLogsForQuery(query *LogQuery) ([]*Log, error) {
 	return f.getLogsFromBlocks(query)
 }
 
+// blockBloom returns the bloom of the logs in the block, either from its header or from the blooms
+// computed from the receipts of the recent blocks, false if it is unknown
+func (f *FilterManager) blockBloom(hash types.Hash, headerBloom types.Bloom) (types.Bloom, bool) {
+	if headerBloom != (types.Bloom{}) {
+		return headerBloom, true
+	}
+
+	if bloom, ok := f.blooms.Get(hash); ok {
+		return bloom.(types.Bloom), true //nolint:forcetypeassert
+	}
+
+	return types.Bloom{}, false
+}
+
+// getReceipts returns the receipts of the block, caching the bloom of their logs
+func (f *FilterManager) getReceipts(hash types.Hash) ([]*types.Receipt, error) {
+	receipts, err := f.store.GetReceiptsByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	f.blooms.Add(hash, types.CreateBloom(receipts))
+
+	return receipts, nil
+}
+
 // getFilterByID fetches the filter by the ID
 func (f *FilterManager) getFilterByID(filterID string) filter {
 	f.RLock()
@@ -500,6 +696,8 @@ func (f *FilterManager) GetFilterChanges(id string) (interface{}, error) {
 		f.Lock()
 		f.refreshFilterTimeout(filter.getFilterBase())
 		f.Unlock()
+
+		f.markDirty()
 	}
 
 	return res, err
@@ -547,6 +745,16 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 
 	delete(f.filters, id)
 
+	if client := filter.getFilterBase().client; client != "" {
+		if f.clients[client]--; f.clients[client] == 0 {
+			delete(f.clients, client)
+		}
+	}
+
+	if !filter.hasWSConn() {
+		f.markDirty()
+	}
+
 	if removed := f.timeouts.removeFilter(filter.getFilterBase()); removed {
 		f.emitSignalToUpdateCh()
 	}
@@ -580,18 +788,46 @@ func (f *FilterManager) addFilter(filter filter) string {
 	f.Lock()
 	defer f.Unlock()
 
+	return f.insertFilter(filter)
+}
+
+// addClientFilter adds the filter installed by a client, unless the client reached its limit of filters
+func (f *FilterManager) addClientFilter(filter filter) (string, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	client := filter.getFilterBase().client
+	if f.maxFiltersPerClient != 0 && f.clients[client] >= f.maxFiltersPerClient {
+		return "", ErrTooManyFilters
+	}
+
+	return f.insertFilter(filter), nil
+}
+
+// insertFilter adds given filter to list and heap [NOT Thread Safe]
+func (f *FilterManager) insertFilter(filter filter) string {
 	base := filter.getFilterBase()
 
 	f.filters[base.id] = filter
 
+	if base.client != "" {
+		f.clients[base.client]++
+	}
+
 	// Set timeout and add to heap if filter doesn't have web socket connection
 	if !filter.hasWSConn() {
 		f.addFilterTimeout(base)
+		f.markDirty()
 	}
 
 	return base.id
 }
 
+// markDirty flags the polling filters to be persisted
+func (f *FilterManager) markDirty() {
+	atomic.StoreInt32(&f.dirty, 1)
+}
+
 func (f *FilterManager) emitSignalToUpdateCh() {
 	select {
 	// notify worker of new filter with timeout
@@ -620,6 +856,7 @@ func (f *FilterManager) nextTimeoutFilter() (string, time.Time) {
 func (f *FilterManager) dispatchEvent(evnt *blockchain.Event) error {
 	// store new event in each filters
 	f.processEvent(evnt)
+	f.markDirty()
 
 	// send data to web socket stream
 	if err := f.flushWsFilters(); err != nil {
@@ -634,6 +871,15 @@ func (f *FilterManager) processEvent(evnt *blockchain.Event) {
 	f.RLock()
 	defer f.RUnlock()
 
+	// the logs of the blocks removed by a reorg are retracted before the logs of the new chain
+	for _, header := range evnt.OldChain {
+		block := toBlock(&types.Block{Header: header}, false)
+
+		if processErr := f.appendLogsToFilters(block, true); processErr != nil {
+			f.logger.Error(fmt.Sprintf("Unable to process removed block, %v", processErr))
+		}
+	}
+
 	for _, header := range evnt.NewChain {
 		block := toBlock(&types.Block{Header: header}, false)
 
@@ -641,24 +887,46 @@ func (f *FilterManager) processEvent(evnt *blockchain.Event) {
 		f.blockStream.push(block)
 
 		// process new chain to include new logs for LogFilter
-		if processErr := f.appendLogsToFilters(block); processErr != nil {
+		if processErr := f.appendLogsToFilters(block, false); processErr != nil {
 			f.logger.Error(fmt.Sprintf("Unable to process block, %v", processErr))
 		}
+
+		// process new chain to include account changes for AccountFilter
+		f.appendAccountChangesToFilters(header, evnt.Type == blockchain.EventReorg)
 	}
 }
 
-// appendLogsToFilters makes each LogFilters append logs in the header
-func (f *FilterManager) appendLogsToFilters(header *block) error {
-	receipts, err := f.store.GetReceiptsByHash(header.Hash)
-	if err != nil {
-		return err
+// appendAccountChangesToFilters makes each AccountFilter append the changes of its accounts
+// at the header, the changes of a reorg include the reverts of the removed blocks
+func (f *FilterManager) appendAccountChangesToFilters(header *types.Header, reorg bool) {
+	for _, filter := range f.filters {
+		accountFilter, ok := filter.(*accountFilter)
+		if !ok {
+			continue
+		}
+
+		changes, err := accountFilter.watcher.Update(header, reorg)
+		if err != nil {
+			f.logger.Error("Unable to read watched accounts", "block", header.Number, "err", err)
+
+			continue
+		}
+
+		accountFilter.appendChanges(changes)
 	}
+}
+
+// appendLogsToFilters makes each LogFilters append logs in the header,
+// the logs of a block removed by a reorg are appended as removed
+func (f *FilterManager) appendLogsToFilters(header *block, removed bool) error {
+	// the filters not matching the bloom of the block are not interested in its logs
+	bloom, hasBloom := f.blockBloom(header.Hash, header.LogsBloom)
 
 	// Get logFilters from filters
 	logFilters := make([]*logFilter, 0)
 
 	for _, f := range f.filters {
-		if logFilter, ok := f.(*logFilter); ok {
+		if logFilter, ok := f.(*logFilter); ok && (!hasBloom || logFilter.query.MatchBloom(bloom)) {
 			logFilters = append(logFilters, logFilter)
 		}
 	}
@@ -667,6 +935,11 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 		return nil
 	}
 
+	receipts, err := f.getReceipts(header.Hash)
+	if err != nil {
+		return err
+	}
+
 	block, ok := f.store.GetBlockByHash(header.Hash, true)
 	if !ok {
 		f.logger.Error("could not find block in store", "hash", header.Hash.String())
@@ -680,7 +953,7 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 			receipt.TxHash = block.Transactions[indx].Hash
 		}
 		// check the logs with the filters
-		for _, log := range receipt.Logs {
+		for logIdx, log := range receipt.Logs {
 			for _, f := range logFilters {
 				if f.query.Match(log) {
 					f.appendLog(&Log{
@@ -691,7 +964,8 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 						BlockHash:   header.Hash,
 						TxHash:      receipt.TxHash,
 						TxIndex:     argUint64(indx),
-						Removed:     false,
+						LogIndex:    argUint64(logIdx),
+						Removed:     removed,
 					})
 				}
 			}
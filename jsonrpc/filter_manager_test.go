@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"math/rand"
 	"net"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -638,3 +639,195 @@ func TestClosedFilterDeletion(t *testing.T) {
 	// false because filter was removed automatically
 	assert.False(t, m.Exists(id))
 }
+
+func TestFilterLog_Reorg(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	id := m.NewLogFilter(&LogQuery{
+		Topics: [][]types.Hash{
+			{hash1},
+		},
+	}, nil)
+
+	newHeader := &types.Header{Number: 1, Hash: hash1}
+	oldHeader := &types.Header{Number: 1, Hash: hash2}
+
+	store.addHeader(newHeader)
+	store.addHeader(oldHeader)
+
+	receipts := []*types.Receipt{
+		{
+			Logs: []*types.Log{
+				{
+					Topics: []types.Hash{
+						hash1,
+					},
+				},
+			},
+			TxHash: hash3,
+		},
+	}
+
+	store.emitEvent(&mockEvent{
+		NewChain: []*mockHeader{{header: newHeader, receipts: receipts}},
+		OldChain: []*mockHeader{{header: oldHeader, receipts: receipts}},
+	})
+
+	time.Sleep(500 * time.Millisecond)
+
+	res, err := m.GetFilterChanges(id)
+	assert.NoError(t, err)
+
+	logs, ok := res.([]*Log)
+	assert.True(t, ok)
+	assert.Len(t, logs, 2)
+
+	// the logs of the removed block come first
+	assert.Equal(t, hash2, logs[0].BlockHash)
+	assert.True(t, logs[0].Removed)
+	assert.Equal(t, hash1, logs[1].BlockHash)
+	assert.False(t, logs[1].Removed)
+}
+
+func TestFilterLog_Bloom(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	log := &types.Log{
+		Topics: []types.Hash{
+			hash1,
+		},
+	}
+
+	// the bloom of the header doesn't include the log of the receipts
+	header := &types.Header{
+		Number:    1,
+		Hash:      hash1,
+		LogsBloom: types.CreateBloom([]*types.Receipt{{Logs: []*types.Log{{Topics: []types.Hash{hash2}}}}}),
+	}
+
+	store.addHeader(header)
+	store.receipts = map[types.Hash][]*types.Receipt{
+		hash1: {{Logs: []*types.Log{log}, TxHash: hash3}},
+	}
+
+	logs, err := m.getLogsFromBlock(&LogQuery{Topics: [][]types.Hash{{hash1}}}, &types.Block{Header: header})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 0)
+
+	// the bloom of the receipts is used for the blocks without header bloom
+	header.LogsBloom = types.Bloom{}
+
+	logs, err = m.getLogsFromBlock(&LogQuery{Topics: [][]types.Hash{{hash1}}}, &types.Block{Header: header})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+
+	bloom, ok := m.blockBloom(hash1, types.Bloom{})
+	assert.True(t, ok)
+	assert.True(t, bloom.IsLogInBloom(log))
+}
+
+func TestFilterClientLimit(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	m.SetMaxFiltersPerClient(2)
+
+	id, err := m.NewClientBlockFilter("client1")
+	assert.NoError(t, err)
+
+	_, err = m.NewClientLogFilter("client1", &LogQuery{})
+	assert.NoError(t, err)
+
+	_, err = m.NewClientBlockFilter("client1")
+	assert.ErrorIs(t, err, ErrTooManyFilters)
+
+	// the limit is per client
+	_, err = m.NewClientBlockFilter("client2")
+	assert.NoError(t, err)
+
+	// the uninstalled filters free the slots of the client
+	assert.True(t, m.Uninstall(id))
+
+	_, err = m.NewClientBlockFilter("client1")
+	assert.NoError(t, err)
+}
+
+func TestFilterStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), FilterStoreFile)
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	assert.NoError(t, m.EnableStore(path))
+
+	blockID, err := m.NewClientBlockFilter("client1")
+	assert.NoError(t, err)
+
+	logID, err := m.NewClientLogFilter("client1", &LogQuery{Topics: [][]types.Hash{{hash1}}})
+	assert.NoError(t, err)
+
+	// the filters are saved on close
+	m.Close()
+
+	// two blocks are added while the node is down
+	headers := []*types.Header{
+		{Number: 1, Hash: types.StringToHash("1")},
+		{Number: 2, Hash: types.StringToHash("2")},
+	}
+
+	store.receipts = map[types.Hash][]*types.Receipt{}
+
+	for _, header := range headers {
+		store.addHeader(header)
+		store.receipts[header.Hash] = []*types.Receipt{
+			{
+				Logs:   []*types.Log{{Topics: []types.Hash{hash1}}},
+				TxHash: hash3,
+			},
+		}
+	}
+
+	store.header = headers[1]
+
+	restored := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer restored.Close()
+
+	assert.NoError(t, restored.EnableStore(path))
+	assert.True(t, restored.Exists(blockID))
+	assert.True(t, restored.Exists(logID))
+
+	// the restored filters catch up with the added blocks
+	res, err := restored.GetFilterChanges(blockID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{headers[0].Hash.String(), headers[1].Hash.String()}, res)
+
+	res, err = restored.GetFilterChanges(logID)
+	assert.NoError(t, err)
+
+	logs, ok := res.([]*Log)
+	assert.True(t, ok)
+	assert.Len(t, logs, 2)
+
+	// the client limit counts the restored filters
+	restored.SetMaxFiltersPerClient(2)
+
+	_, err = restored.NewClientBlockFilter("client1")
+	assert.ErrorIs(t, err, ErrTooManyFilters)
+}
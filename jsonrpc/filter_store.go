@@ -0,0 +1,190 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// FilterStoreFile is the name of the file persisting the polling filters in the data directory
+	FilterStoreFile = "filters.json"
+
+	// filterStoreInterval is the interval between the saves of the changed polling filters
+	filterStoreInterval = 10 * time.Second
+)
+
+// storedFilter is the persisted state of a polling filter,
+// the subscriptions and the account filters end with their connection and are not persisted
+type storedFilter struct {
+	ID     string `json:"id"`
+	Client string `json:"client,omitempty"`
+
+	// Query is the query of a log filter, nil for a block filter
+	Query *LogQuery `json:"query,omitempty"`
+
+	// Cursor is the last block whose updates were taken by the filter
+	Cursor uint64 `json:"cursor"`
+
+	// Logs are the logs of a log filter not polled yet
+	Logs []*Log `json:"logs,omitempty"`
+}
+
+// EnableStore persists the polling filters in the file and restores the filters saved by the previous run,
+// which catch up with the blocks added since then. It must be called before Run
+func (f *FilterManager) EnableStore(path string) error {
+	f.storePath = path
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var stored []*storedFilter
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		return err
+	}
+
+	for _, filter := range stored {
+		if err := f.restoreFilter(filter); err != nil {
+			return err
+		}
+	}
+
+	f.logger.Info("restored filters", "count", len(stored))
+
+	return nil
+}
+
+// restoreFilter adds the stored filter with the updates of the blocks after its cursor,
+// at most the block range limit of the queries
+func (f *FilterManager) restoreFilter(stored *storedFilter) error {
+	head := uint64(f.blockStream.getHead().header.Number)
+
+	from := stored.Cursor + 1
+	if f.blockRangeLimit != 0 && head > f.blockRangeLimit && from < head-f.blockRangeLimit {
+		from = head - f.blockRangeLimit
+	}
+
+	base := filterBase{
+		id:        stored.ID,
+		client:    stored.Client,
+		heapIndex: NoIndexInHeap,
+	}
+
+	var restored filter
+
+	if stored.Query == nil {
+		restored = &blockFilter{
+			filterBase: base,
+			block:      f.catchUpBlocks(from, head),
+		}
+	} else {
+		logs := stored.Logs
+
+		for num := from; num <= head; num++ {
+			block, ok := f.store.GetBlockByNumber(num, true)
+			if !ok {
+				break
+			}
+
+			blockLogs, err := f.getLogsFromBlock(stored.Query, block)
+			if err != nil {
+				return err
+			}
+
+			logs = append(logs, blockLogs...)
+		}
+
+		restored = &logFilter{
+			filterBase: base,
+			query:      stored.Query,
+			logs:       logs,
+		}
+	}
+
+	f.addFilter(restored)
+
+	return nil
+}
+
+// catchUpBlocks returns the element of a block filter taking the blocks from the given number,
+// followed by the blocks of the stream
+func (f *FilterManager) catchUpBlocks(from, head uint64) *headElem {
+	live := f.blockStream.getHead()
+	if from > head {
+		return live
+	}
+
+	start := &headElem{header: &block{Number: argUint64(from - 1)}}
+	last := start
+
+	for num := from; num < head; num++ {
+		b, ok := f.store.GetBlockByNumber(num, false)
+		if !ok {
+			break
+		}
+
+		elem := &headElem{header: toBlock(b, false)}
+		last.next.Store(elem)
+		last = elem
+	}
+
+	last.next.Store(live)
+
+	return start
+}
+
+// saveFilters persists the polling filters, with the cursor of the stream
+// as the events are not processed while saving [Thread safe]
+func (f *FilterManager) saveFilters() error {
+	f.Lock()
+	defer f.Unlock()
+
+	head := uint64(f.blockStream.getHead().header.Number)
+	stored := make([]*storedFilter, 0, len(f.filters))
+
+	for _, filter := range f.filters {
+		if filter.hasWSConn() {
+			continue
+		}
+
+		switch filter := filter.(type) {
+		case *blockFilter:
+			filter.Lock()
+			cursor := uint64(filter.block.header.Number)
+			filter.Unlock()
+
+			stored = append(stored, &storedFilter{
+				ID:     filter.id,
+				Client: filter.client,
+				Cursor: cursor,
+			})
+		case *logFilter:
+			filter.Lock()
+			logs := append([]*Log{}, filter.logs...)
+			filter.Unlock()
+
+			stored = append(stored, &storedFilter{
+				ID:     filter.id,
+				Client: filter.client,
+				Query:  filter.query,
+				Cursor: head,
+				Logs:   logs,
+			})
+		}
+	}
+
+	raw, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	atomic.StoreInt32(&f.dirty, 0)
+
+	return os.WriteFile(f.storePath, raw, 0600)
+}
@@ -0,0 +1,29 @@
+package jsonrpc
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// FreezeStore provides the frozen status of the accounts in the freeze contract
+type FreezeStore interface {
+	blockGetter
+
+	// IsFrozen returns true if the account is frozen at the block
+	IsFrozen(header *types.Header, account types.Address) (bool, error)
+}
+
+// Freeze is the jsonrpc endpoint of the emergency freeze, available if the chain enables it
+type Freeze struct {
+	store FreezeStore
+}
+
+// IsFrozen returns true if the account is frozen at the block,
+// the transactions from and to the account are rejected then
+func (f *Freeze) IsFrozen(account types.Address, filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, f.store)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.store.IsFrozen(header, account)
+}
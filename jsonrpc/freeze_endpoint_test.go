@@ -0,0 +1,46 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFreezeStore struct {
+	*mockStore
+
+	frozen map[types.Address]bool
+}
+
+func (m *mockFreezeStore) IsFrozen(header *types.Header, account types.Address) (bool, error) {
+	return m.frozen[account], nil
+}
+
+func TestFreezeEndpoint_IsFrozen(t *testing.T) {
+	t.Parallel()
+
+	store := &mockFreezeStore{
+		mockStore: newMockStore(),
+		frozen: map[types.Address]bool{
+			addr0: true,
+		},
+	}
+	endpoint := &Freeze{store}
+
+	latest := LatestBlockNumber
+
+	res, err := endpoint.IsFrozen(addr0, BlockNumberOrHash{BlockNumber: &latest})
+	require.NoError(t, err)
+	assert.Equal(t, true, res)
+
+	res, err = endpoint.IsFrozen(addr1, BlockNumberOrHash{})
+	require.NoError(t, err)
+	assert.Equal(t, false, res)
+
+	unknown := BlockNumber(5)
+
+	_, err = endpoint.IsFrozen(addr0, BlockNumberOrHash{BlockNumber: &unknown})
+	assert.Error(t, err)
+}
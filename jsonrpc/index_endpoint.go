@@ -0,0 +1,434 @@
+package jsonrpc
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/indexer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// defaultIndexQueryLimit is the number of the returned entries if the query has no limit
+	defaultIndexQueryLimit = 100
+
+	// maxIndexQueryLimit is the maximum number of the entries returned by a query
+	maxIndexQueryLimit = 1000
+)
+
+var (
+	ErrIndexQueryMissing    = errors.New("index query is required")
+	ErrIndexQueryLimit      = errors.New("limit exceeds the maximum of 1000 entries")
+	ErrIndexQueryBlockRange = errors.New("fromBlock is greater than toBlock")
+	ErrIndexerDisabled      = errors.New("the indexer is not enabled")
+	ErrIndexQueryOrder      = errors.New("order must be either asc or desc")
+)
+
+const (
+	orderAsc  = "asc"
+	orderDesc = "desc"
+)
+
+// IndexStore provides access to the secondary indexes of the indexer mode
+type IndexStore interface {
+	// Head returns the number of the last indexed block
+	Head() (uint64, error)
+
+	// GetTransactions returns the transactions of an address in a block range
+	GetTransactions(addr types.Address, from, to uint64, limit int) ([]*indexer.Transaction, error)
+
+	// GetInternalTransfers returns the internal transfers of an address in a block range
+	GetInternalTransfers(addr types.Address, from, to uint64, limit int) ([]*indexer.InternalTransfer, error)
+
+	// GetTokenTransfers returns the token transfers of an address in a block range
+	GetTokenTransfers(addr types.Address, from, to uint64, limit int) ([]*indexer.TokenTransfer, error)
+
+	// GetTokenTransferPage returns a page of the token transfer history of an address
+	GetTokenTransferPage(addr types.Address, filter *indexer.HistoryFilter) ([]*indexer.TokenTransfer, []byte, error)
+
+	// GetCallTrace returns the call tree of a transaction of the recent blocks
+	GetCallTrace(txHash types.Hash) (*indexer.CallFrame, bool, error)
+}
+
+// Index is the index jsonrpc endpoint, available in the indexer mode
+type Index struct {
+	store IndexStore
+}
+
+// IndexQuery selects the entries of an address in a block range.
+// The block range defaults to the whole indexed chain
+type IndexQuery struct {
+	Address   types.Address `json:"address"`
+	FromBlock *BlockNumber  `json:"fromBlock"`
+	ToBlock   *BlockNumber  `json:"toBlock"`
+	Limit     *argUint64    `json:"limit"`
+}
+
+// TokenHistoryQuery selects a page of the token transfer history of an address,
+// from the most recent transfers unless the order is asc
+type TokenHistoryQuery struct {
+	IndexQuery
+
+	// Contract selects the transfers of a single token
+	Contract *types.Address `json:"contract"`
+
+	// Cursor is the nextCursor of the previous page
+	Cursor *argBytes `json:"cursor"`
+
+	Order string `json:"order"`
+}
+
+type indexTokenHistory struct {
+	Transfers  []*indexTokenHistoryEntry `json:"transfers"`
+	NextCursor *argBytes                 `json:"nextCursor"`
+}
+
+type indexTokenHistoryEntry struct {
+	*indexTokenTransfer
+
+	// Direction is in, out or self relative to the queried address
+	Direction    string        `json:"direction"`
+	Counterparty types.Address `json:"counterparty"`
+}
+
+type indexTransaction struct {
+	BlockNumber     argUint64      `json:"blockNumber"`
+	BlockHash       types.Hash     `json:"blockHash"`
+	TxHash          types.Hash     `json:"transactionHash"`
+	TxIndex         argUint64      `json:"transactionIndex"`
+	From            types.Address  `json:"from"`
+	To              *types.Address `json:"to"`
+	Value           argBig         `json:"value"`
+	ContractAddress *types.Address `json:"contractAddress"`
+	Status          argUint64      `json:"status"`
+}
+
+type indexInternalTransfer struct {
+	BlockNumber  argUint64     `json:"blockNumber"`
+	BlockHash    types.Hash    `json:"blockHash"`
+	TxHash       types.Hash    `json:"transactionHash"`
+	TxIndex      argUint64     `json:"transactionIndex"`
+	Index        argUint64     `json:"index"`
+	Depth        argUint64     `json:"depth"`
+	CallType     string        `json:"callType"`
+	From         types.Address `json:"from"`
+	To           types.Address `json:"to"`
+	Value        argBig        `json:"value"`
+	TraceAddress []uint64      `json:"traceAddress"`
+}
+
+type indexCallFrame struct {
+	Type   string            `json:"type"`
+	From   types.Address     `json:"from"`
+	To     types.Address     `json:"to"`
+	Value  argBig            `json:"value"`
+	Gas    argUint64         `json:"gas"`
+	Input  argBytes          `json:"input"`
+	Output argBytes          `json:"output"`
+	Error  string            `json:"error,omitempty"`
+	Calls  []*indexCallFrame `json:"calls,omitempty"`
+}
+
+type indexTokenTransfer struct {
+	BlockNumber argUint64     `json:"blockNumber"`
+	BlockHash   types.Hash    `json:"blockHash"`
+	TxHash      types.Hash    `json:"transactionHash"`
+	TxIndex     argUint64     `json:"transactionIndex"`
+	LogIndex    argUint64     `json:"logIndex"`
+	Standard    string        `json:"standard"`
+	Contract    types.Address `json:"contract"`
+	From        types.Address `json:"from"`
+	To          types.Address `json:"to"`
+	Value       *argBig       `json:"value,omitempty"`
+	TokenID     *argBig       `json:"tokenId,omitempty"`
+}
+
+// BlockNumber returns the number of the last indexed block
+func (i *Index) BlockNumber() (interface{}, error) {
+	head, err := i.store.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	return argUintPtr(head), nil
+}
+
+// GetTransactions returns the transactions sent or received by the address
+func (i *Index) GetTransactions(query *IndexQuery) (interface{}, error) {
+	from, to, limit, err := resolveIndexQuery(i.store, query)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := i.store.GetTransactions(query.Address, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*indexTransaction, len(txs))
+
+	for idx, tx := range txs {
+		status := argUint64(types.ReceiptSuccess)
+		if tx.Failed {
+			status = argUint64(types.ReceiptFailed)
+		}
+
+		res[idx] = &indexTransaction{
+			BlockNumber:     argUint64(tx.BlockNumber),
+			BlockHash:       tx.BlockHash,
+			TxHash:          tx.TxHash,
+			TxIndex:         argUint64(tx.TxIndex),
+			From:            tx.From,
+			To:              tx.To,
+			Value:           indexValue(tx.Value),
+			ContractAddress: tx.ContractAddress,
+			Status:          status,
+		}
+	}
+
+	return res, nil
+}
+
+// GetInternalTransfers returns the value transfers made by contracts to or from the address
+func (i *Index) GetInternalTransfers(query *IndexQuery) (interface{}, error) {
+	from, to, limit, err := resolveIndexQuery(i.store, query)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, err := i.store.GetInternalTransfers(query.Address, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*indexInternalTransfer, len(transfers))
+
+	for idx, transfer := range transfers {
+		res[idx] = &indexInternalTransfer{
+			BlockNumber:  argUint64(transfer.BlockNumber),
+			BlockHash:    transfer.BlockHash,
+			TxHash:       transfer.TxHash,
+			TxIndex:      argUint64(transfer.TxIndex),
+			Index:        argUint64(transfer.Index),
+			Depth:        argUint64(transfer.Depth),
+			CallType:     string(transfer.CallType),
+			From:         transfer.From,
+			To:           transfer.To,
+			Value:        indexValue(transfer.Value),
+			TraceAddress: transfer.TraceAddress,
+		}
+	}
+
+	return res, nil
+}
+
+// GetCallTrace returns the call tree of the transaction,
+// null if the transaction is not one of the recent blocks
+func (i *Index) GetCallTrace(txHash types.Hash) (interface{}, error) {
+	root, ok, err := i.store.GetCallTrace(txHash)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return toIndexCallFrame(root), nil
+}
+
+// GetTokenTransfers returns the ERC-20 and ERC-721 transfers to or from the address
+func (i *Index) GetTokenTransfers(query *IndexQuery) (interface{}, error) {
+	from, to, limit, err := resolveIndexQuery(i.store, query)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, err := i.store.GetTokenTransfers(query.Address, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*indexTokenTransfer, len(transfers))
+	for idx, transfer := range transfers {
+		res[idx] = toIndexTokenTransfer(transfer)
+	}
+
+	return res, nil
+}
+
+// GetTokenTransferHistory returns a page of the ERC-20 and ERC-721 transfers to or from
+// the address, with the cursor of the next page
+func (i *Index) GetTokenTransferHistory(query *TokenHistoryQuery) (interface{}, error) {
+	if query == nil {
+		return nil, ErrIndexQueryMissing
+	}
+
+	from, to, limit, err := resolveIndexQuery(i.store, &query.IndexQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &indexer.HistoryFilter{
+		From:     from,
+		To:       to,
+		Contract: query.Contract,
+		Limit:    limit,
+	}
+
+	switch query.Order {
+	case "", orderDesc:
+		filter.Descending = true
+	case orderAsc:
+	default:
+		return nil, ErrIndexQueryOrder
+	}
+
+	if query.Cursor != nil {
+		filter.Cursor = *query.Cursor
+	}
+
+	transfers, next, err := i.store.GetTokenTransferPage(query.Address, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &indexTokenHistory{
+		Transfers: make([]*indexTokenHistoryEntry, len(transfers)),
+	}
+
+	for idx, transfer := range transfers {
+		entry := &indexTokenHistoryEntry{
+			indexTokenTransfer: toIndexTokenTransfer(transfer),
+		}
+
+		switch {
+		case transfer.From == transfer.To:
+			entry.Direction, entry.Counterparty = "self", transfer.To
+		case transfer.From == query.Address:
+			entry.Direction, entry.Counterparty = "out", transfer.To
+		default:
+			entry.Direction, entry.Counterparty = "in", transfer.From
+		}
+
+		res.Transfers[idx] = entry
+	}
+
+	if next != nil {
+		res.NextCursor = argBytesPtr(next)
+	}
+
+	return res, nil
+}
+
+func toIndexTokenTransfer(transfer *indexer.TokenTransfer) *indexTokenTransfer {
+	res := &indexTokenTransfer{
+		BlockNumber: argUint64(transfer.BlockNumber),
+		BlockHash:   transfer.BlockHash,
+		TxHash:      transfer.TxHash,
+		TxIndex:     argUint64(transfer.TxIndex),
+		LogIndex:    argUint64(transfer.LogIndex),
+		Standard:    string(transfer.Standard),
+		Contract:    transfer.Contract,
+		From:        transfer.From,
+		To:          transfer.To,
+	}
+
+	if transfer.Value != nil {
+		res.Value = argBigPtr(transfer.Value)
+	}
+
+	if transfer.TokenID != nil {
+		res.TokenID = argBigPtr(transfer.TokenID)
+	}
+
+	return res
+}
+
+func toIndexCallFrame(frame *indexer.CallFrame) *indexCallFrame {
+	res := &indexCallFrame{
+		Type:   strings.ToUpper(string(frame.Type)),
+		From:   frame.From,
+		To:     frame.To,
+		Value:  indexValue(frame.Value),
+		Gas:    argUint64(frame.Gas),
+		Input:  argBytes(frame.Input),
+		Output: argBytes(frame.Output),
+		Error:  frame.Error,
+	}
+
+	for _, call := range frame.Calls {
+		res.Calls = append(res.Calls, toIndexCallFrame(call))
+	}
+
+	return res
+}
+
+// indexHead returns the number of the last indexed block
+type indexHead interface {
+	Head() (uint64, error)
+}
+
+// resolveIndexQuery returns the block range and the limit of the query,
+// latest and pending refer to the last indexed block
+func resolveIndexQuery(store indexHead, query *IndexQuery) (uint64, uint64, int, error) {
+	if query == nil {
+		return 0, 0, 0, ErrIndexQueryMissing
+	}
+
+	head, err := store.Head()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	resolve := func(number *BlockNumber, defaultNumber uint64) (uint64, error) {
+		if number == nil {
+			return defaultNumber, nil
+		}
+
+		switch *number {
+		case LatestBlockNumber, PendingBlockNumber:
+			return head, nil
+		case EarliestBlockNumber:
+			return 0, nil
+		default:
+			if *number < 0 {
+				return 0, ErrNegativeBlockNumber
+			}
+
+			return uint64(*number), nil
+		}
+	}
+
+	from, err := resolve(query.FromBlock, 0)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	to, err := resolve(query.ToBlock, head)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	if from > to {
+		return 0, 0, 0, ErrIndexQueryBlockRange
+	}
+
+	limit := defaultIndexQueryLimit
+
+	if query.Limit != nil {
+		if *query.Limit > maxIndexQueryLimit {
+			return 0, 0, 0, ErrIndexQueryLimit
+		}
+
+		limit = int(*query.Limit)
+	}
+
+	return from, to, limit, nil
+}
+
+// indexValue returns the value of an entry, the missing values are zero
+func indexValue(value *big.Int) argBig {
+	if value == nil {
+		return argBig{}
+	}
+
+	return argBig(*value)
+}
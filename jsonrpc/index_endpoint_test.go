@@ -0,0 +1,352 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/indexer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type indexQueryCall struct {
+	addr     types.Address
+	from, to uint64
+	limit    int
+}
+
+type mockIndexStore struct {
+	head  uint64
+	calls []indexQueryCall
+
+	transactions      []*indexer.Transaction
+	internalTransfers []*indexer.InternalTransfer
+	tokenTransfers    []*indexer.TokenTransfer
+	callTraces        map[types.Hash]*indexer.CallFrame
+
+	historyFilter *indexer.HistoryFilter
+	nextCursor    []byte
+}
+
+func (m *mockIndexStore) Head() (uint64, error) {
+	return m.head, nil
+}
+
+func (m *mockIndexStore) GetTransactions(
+	addr types.Address,
+	from, to uint64,
+	limit int,
+) ([]*indexer.Transaction, error) {
+	m.calls = append(m.calls, indexQueryCall{addr, from, to, limit})
+
+	return m.transactions, nil
+}
+
+func (m *mockIndexStore) GetInternalTransfers(
+	addr types.Address,
+	from, to uint64,
+	limit int,
+) ([]*indexer.InternalTransfer, error) {
+	m.calls = append(m.calls, indexQueryCall{addr, from, to, limit})
+
+	return m.internalTransfers, nil
+}
+
+func (m *mockIndexStore) GetTokenTransfers(
+	addr types.Address,
+	from, to uint64,
+	limit int,
+) ([]*indexer.TokenTransfer, error) {
+	m.calls = append(m.calls, indexQueryCall{addr, from, to, limit})
+
+	return m.tokenTransfers, nil
+}
+
+func (m *mockIndexStore) GetTokenTransferPage(
+	addr types.Address,
+	filter *indexer.HistoryFilter,
+) ([]*indexer.TokenTransfer, []byte, error) {
+	m.historyFilter = filter
+
+	return m.tokenTransfers, m.nextCursor, nil
+}
+
+func (m *mockIndexStore) GetCallTrace(txHash types.Hash) (*indexer.CallFrame, bool, error) {
+	trace, ok := m.callTraces[txHash]
+
+	return trace, ok, nil
+}
+
+func TestIndexEndpoint_Query(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("1")
+
+	blockNumber := func(n BlockNumber) *BlockNumber {
+		return &n
+	}
+
+	cases := []struct {
+		name  string
+		query *IndexQuery
+		call  indexQueryCall
+		err   error
+	}{
+		{
+			"defaults to the indexed chain",
+			&IndexQuery{Address: addr},
+			indexQueryCall{addr, 0, 10, defaultIndexQueryLimit},
+			nil,
+		},
+		{
+			"block range and limit",
+			&IndexQuery{Address: addr, FromBlock: blockNumber(2), ToBlock: blockNumber(5), Limit: argUintPtr(3)},
+			indexQueryCall{addr, 2, 5, 3},
+			nil,
+		},
+		{
+			"latest is the indexed head",
+			&IndexQuery{Address: addr, FromBlock: blockNumber(3), ToBlock: blockNumber(LatestBlockNumber)},
+			indexQueryCall{addr, 3, 10, defaultIndexQueryLimit},
+			nil,
+		},
+		{
+			"invalid block range",
+			&IndexQuery{Address: addr, FromBlock: blockNumber(5), ToBlock: blockNumber(2)},
+			indexQueryCall{},
+			ErrIndexQueryBlockRange,
+		},
+		{
+			"limit too high",
+			&IndexQuery{Address: addr, Limit: argUintPtr(maxIndexQueryLimit + 1)},
+			indexQueryCall{},
+			ErrIndexQueryLimit,
+		},
+		{
+			"missing query",
+			nil,
+			indexQueryCall{},
+			ErrIndexQueryMissing,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := &mockIndexStore{head: 10}
+			endpoint := &Index{store}
+
+			_, err := endpoint.GetTransactions(c.query)
+			if c.err != nil {
+				assert.ErrorIs(t, err, c.err)
+				assert.Empty(t, store.calls)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []indexQueryCall{c.call}, store.calls)
+		})
+	}
+}
+
+func TestIndexEndpoint_TokenTransfers(t *testing.T) {
+	t.Parallel()
+
+	store := &mockIndexStore{
+		tokenTransfers: []*indexer.TokenTransfer{
+			{
+				BlockNumber: 1,
+				LogIndex:    2,
+				Standard:    indexer.ERC20,
+				Value:       big.NewInt(100),
+			},
+			{
+				BlockNumber: 3,
+				Standard:    indexer.ERC721,
+				TokenID:     big.NewInt(7),
+			},
+		},
+	}
+
+	res, err := (&Index{store}).GetTokenTransfers(&IndexQuery{})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	var transfers []map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &transfers))
+	require.Len(t, transfers, 2)
+
+	assert.Equal(t, "0x1", transfers[0]["blockNumber"])
+	assert.Equal(t, "0x2", transfers[0]["logIndex"])
+	assert.Equal(t, "erc20", transfers[0]["standard"])
+	assert.Equal(t, "0x64", transfers[0]["value"])
+	assert.NotContains(t, transfers[0], "tokenId")
+
+	assert.Equal(t, "erc721", transfers[1]["standard"])
+	assert.Equal(t, "0x7", transfers[1]["tokenId"])
+	assert.NotContains(t, transfers[1], "value")
+}
+
+func TestIndexEndpoint_Registration(t *testing.T) {
+	t.Parallel()
+
+	params := &dispatcherParams{jsonRPCBatchLengthLimit: 20, blockRangeLimit: 1000}
+	req := []byte(`{"method": "index_blockNumber", "params": [], "id": 1}`)
+
+	// the index endpoint is only available in the indexer mode
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), params)
+
+	res, err := dispatcher.Handle(req)
+	require.NoError(t, err)
+	assert.Contains(t, string(res), "error")
+
+	dispatcher.registerIndexEndpoint(&mockIndexStore{head: 5})
+
+	res, err = dispatcher.Handle(req)
+	require.NoError(t, err)
+	assert.Contains(t, string(res), `"result":"0x5"`)
+}
+
+func TestIndexEndpoint_CallTrace(t *testing.T) {
+	t.Parallel()
+
+	txHash := types.StringToHash("1")
+	store := &mockIndexStore{
+		callTraces: map[types.Hash]*indexer.CallFrame{
+			txHash: {
+				Type:  indexer.CallTypeCall,
+				Value: big.NewInt(16),
+				Gas:   100,
+				Input: []byte{0x1},
+				Calls: []*indexer.CallFrame{
+					{Type: indexer.CallTypeDelegateCall, Value: big.NewInt(0), Error: "execution reverted"},
+				},
+			},
+		},
+	}
+
+	endpoint := &Index{store}
+
+	res, err := endpoint.GetCallTrace(txHash)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	var trace map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &trace))
+
+	assert.Equal(t, "CALL", trace["type"])
+	assert.Equal(t, "0x10", trace["value"])
+	assert.Equal(t, "0x64", trace["gas"])
+	assert.Equal(t, "0x01", trace["input"])
+
+	calls, ok := trace["calls"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, calls, 1)
+
+	call, ok := calls[0].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "DELEGATECALL", call["type"])
+	assert.Equal(t, "execution reverted", call["error"])
+
+	// the call trees of the old blocks are not kept
+	res, err = endpoint.GetCallTrace(types.StringToHash("2"))
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestEth_GetInternalTransactions(t *testing.T) {
+	t.Parallel()
+
+	query := &IndexQuery{Address: types.StringToAddress("1")}
+
+	eth := newTestEthEndpoint(newMockStore())
+
+	_, err := eth.GetInternalTransactions(query)
+	assert.ErrorIs(t, err, ErrIndexerDisabled)
+
+	eth.index = &Index{&mockIndexStore{
+		internalTransfers: []*indexer.InternalTransfer{
+			{BlockNumber: 2, Value: big.NewInt(1), TraceAddress: []uint64{0, 1}},
+		},
+	}}
+
+	res, err := eth.GetInternalTransactions(query)
+	require.NoError(t, err)
+
+	transfers, ok := res.([]*indexInternalTransfer)
+	require.True(t, ok)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, argUint64(2), transfers[0].BlockNumber)
+	assert.Equal(t, []uint64{0, 1}, transfers[0].TraceAddress)
+}
+
+func TestIndexEndpoint_TokenTransferHistory(t *testing.T) {
+	t.Parallel()
+
+	wallet := types.StringToAddress("1")
+	other := types.StringToAddress("2")
+	token := types.StringToAddress("3")
+
+	store := &mockIndexStore{
+		head: 10,
+		tokenTransfers: []*indexer.TokenTransfer{
+			{BlockNumber: 9, Contract: token, From: wallet, To: other, Value: big.NewInt(1)},
+			{BlockNumber: 8, Contract: token, From: other, To: wallet, Value: big.NewInt(2)},
+			{BlockNumber: 7, Contract: token, From: wallet, To: wallet, Value: big.NewInt(3)},
+		},
+		nextCursor: []byte{0x1, 0x2},
+	}
+
+	endpoint := &Index{store}
+
+	var query TokenHistoryQuery
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"address": "`+wallet.String()+`",
+		"contract": "`+token.String()+`",
+		"limit": "0x3",
+		"cursor": "0x0a0b"
+	}`), &query))
+
+	res, err := endpoint.GetTokenTransferHistory(&query)
+	require.NoError(t, err)
+
+	// the history defaults to the most recent transfers first
+	assert.Equal(t, &indexer.HistoryFilter{
+		From:       0,
+		To:         10,
+		Contract:   &token,
+		Cursor:     []byte{0xa, 0xb},
+		Limit:      3,
+		Descending: true,
+	}, store.historyFilter)
+
+	history, ok := res.(*indexTokenHistory)
+	require.True(t, ok)
+	require.Len(t, history.Transfers, 3)
+
+	assert.Equal(t, "out", history.Transfers[0].Direction)
+	assert.Equal(t, other, history.Transfers[0].Counterparty)
+	assert.Equal(t, "in", history.Transfers[1].Direction)
+	assert.Equal(t, other, history.Transfers[1].Counterparty)
+	assert.Equal(t, "self", history.Transfers[2].Direction)
+	assert.Equal(t, argBytesPtr([]byte{0x1, 0x2}), history.NextCursor)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"nextCursor":"0x0102"`)
+	assert.Contains(t, string(data), `"direction":"out"`)
+
+	_, err = endpoint.GetTokenTransferHistory(&TokenHistoryQuery{Order: "newest"})
+	assert.ErrorIs(t, err, ErrIndexQueryOrder)
+}
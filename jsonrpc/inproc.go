@@ -0,0 +1,73 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+)
+
+var errNoResult = errors.New("the response has neither result nor error")
+
+// InProcClient calls the endpoints of the server from the same process,
+// without going through a transport
+type InProcClient struct {
+	server *JSONRPC
+	id     uint64
+}
+
+// InProcClient returns a client of the server in the same process
+func (j *JSONRPC) InProcClient() *InProcClient {
+	return &InProcClient{server: j}
+}
+
+// Call calls the method with the params, and decodes its result into out, if not nil.
+// The signature matches the one of the ethgo client
+func (c *InProcClient) Call(method string, out interface{}, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	request, err := json.Marshal(&struct {
+		JSONRPC string `json:"jsonrpc"`
+		Request
+	}{
+		JSONRPC: "2.0",
+		Request: Request{
+			ID:     atomic.AddUint64(&c.id, 1),
+			Method: method,
+			Params: rawParams,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := c.server.dispatcher.Handle(request)
+	if err != nil {
+		return err
+	}
+
+	var response SuccessResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	if response.Error != nil {
+		return response.Error
+	}
+
+	if response.Result == nil {
+		return errNoResult
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(response.Result, out)
+}
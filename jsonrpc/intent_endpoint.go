@@ -0,0 +1,168 @@
+package jsonrpc
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/intent"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrIntentTimestampMissing = errors.New("the timestamp of the intent is required")
+
+// IntentStore holds the off-chain intents exchanged by the validators
+type IntentStore interface {
+	// Intents returns the unexpired intents of the current validators
+	Intents() ([]*intent.SignedIntent, error)
+
+	// Signals returns the values signaled by the current validators, with their support
+	Signals() ([]*intent.Signal, error)
+
+	// Submit adds an intent signed by a validator and gossips it
+	Submit(signed *intent.SignedIntent) error
+
+	// Signal signs the intent with the validator key of the node and gossips it
+	Signal(i *intent.Intent) (*intent.SignedIntent, error)
+}
+
+// Intent is the jsonrpc endpoint of the off-chain intents of the validators
+type Intent struct {
+	store   IntentStore
+	chainID uint64
+}
+
+// intentArg is an intent given to the endpoint, its timestamp is set by the node when signaled
+type intentArg struct {
+	Kind      string     `json:"kind"`
+	Key       string     `json:"key"`
+	Value     string     `json:"value"`
+	Expiry    argUint64  `json:"expiry"`
+	Timestamp *argUint64 `json:"timestamp"`
+}
+
+func (a *intentArg) toIntent() *intent.Intent {
+	i := &intent.Intent{
+		Kind:   a.Kind,
+		Key:    a.Key,
+		Value:  a.Value,
+		Expiry: uint64(a.Expiry),
+	}
+
+	if a.Timestamp != nil {
+		i.Timestamp = uint64(*a.Timestamp)
+	}
+
+	return i
+}
+
+// signedIntent is the JSON form of a signed intent
+type signedIntent struct {
+	Kind      string        `json:"kind"`
+	Key       string        `json:"key"`
+	Value     string        `json:"value"`
+	Expiry    argUint64     `json:"expiry"`
+	Timestamp argUint64     `json:"timestamp"`
+	Validator types.Address `json:"validator"`
+	Signature argBytes      `json:"signature"`
+}
+
+func toSignedIntent(s *intent.SignedIntent) *signedIntent {
+	return &signedIntent{
+		Kind:      s.Kind,
+		Key:       s.Key,
+		Value:     s.Value,
+		Expiry:    argUint64(s.Expiry),
+		Timestamp: argUint64(s.Timestamp),
+		Validator: s.Validator,
+		Signature: toEthSignature(s.Signature),
+	}
+}
+
+// signal is the JSON form of the support of a value
+type signal struct {
+	Kind          string          `json:"kind"`
+	Key           string          `json:"key"`
+	Value         string          `json:"value"`
+	Validators    []types.Address `json:"validators"`
+	Total         argUint64       `json:"total"`
+	Supermajority bool            `json:"supermajority"`
+}
+
+// GetSignals returns the values signaled by the current validators, the most supported first
+func (i *Intent) GetSignals() (interface{}, error) {
+	signals, err := i.store.Signals()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*signal, len(signals))
+	for idx, s := range signals {
+		res[idx] = &signal{
+			Kind:          s.Kind,
+			Key:           s.Key,
+			Value:         s.Value,
+			Validators:    s.Validators,
+			Total:         argUint64(s.Total),
+			Supermajority: s.Supermajority(),
+		}
+	}
+
+	return res, nil
+}
+
+// GetIntents returns the unexpired intents of the current validators
+func (i *Intent) GetIntents() (interface{}, error) {
+	intents, err := i.store.Intents()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*signedIntent, len(intents))
+	for idx, s := range intents {
+		res[idx] = toSignedIntent(s)
+	}
+
+	return res, nil
+}
+
+// GetTypedData returns the EIP-712 typed data of the intent, to be signed
+// by a validator key with eth_signTypedData_v4 and given to intent_submit
+func (i *Intent) GetTypedData(arg intentArg) (interface{}, error) {
+	if arg.Timestamp == nil {
+		return nil, ErrIntentTimestampMissing
+	}
+
+	in := arg.toIntent()
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	return in.TypedData(i.chainID), nil
+}
+
+// Submit gossips the intent signed by a validator, returning the validator
+func (i *Intent) Submit(arg intentArg, sig argBytes) (interface{}, error) {
+	if arg.Timestamp == nil {
+		return nil, ErrIntentTimestampMissing
+	}
+
+	signed, err := intent.Recover(i.chainID, arg.toIntent(), sig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.store.Submit(signed); err != nil {
+		return nil, err
+	}
+
+	return signed.Validator, nil
+}
+
+// Signal signs the intent with the validator key of the node and gossips it
+func (i *Intent) Signal(arg intentArg) (interface{}, error) {
+	signed, err := i.store.Signal(arg.toIntent())
+	if err != nil {
+		return nil, err
+	}
+
+	return toSignedIntent(signed), nil
+}
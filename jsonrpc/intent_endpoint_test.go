@@ -0,0 +1,103 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/intent"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockIntentStore struct {
+	submitted []*intent.SignedIntent
+}
+
+func (m *mockIntentStore) Intents() ([]*intent.SignedIntent, error) {
+	return m.submitted, nil
+}
+
+func (m *mockIntentStore) Signals() ([]*intent.Signal, error) {
+	res := make([]*intent.Signal, 0, len(m.submitted))
+	for _, s := range m.submitted {
+		res = append(res, &intent.Signal{Kind: s.Kind, Key: s.Key, Value: s.Value, Total: 1})
+	}
+
+	return res, nil
+}
+
+func (m *mockIntentStore) Submit(signed *intent.SignedIntent) error {
+	m.submitted = append(m.submitted, signed)
+
+	return nil
+}
+
+func (m *mockIntentStore) Signal(*intent.Intent) (*intent.SignedIntent, error) {
+	return nil, intent.ErrNoValidatorKey
+}
+
+func TestIntentEndpoint_Submit(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	timestamp := argUint64(1)
+	arg := intentArg{Kind: intent.KindUpgrade, Key: "london", Value: "1000", Expiry: 900, Timestamp: &timestamp}
+
+	store := &mockIntentStore{}
+	endpoint := &Intent{store, 100}
+
+	// the typed data is signed by the Ethereum tooling, whose signatures end with 27 or 28
+	res, err := endpoint.GetTypedData(arg)
+	require.NoError(t, err)
+
+	typedData, ok := res.(*crypto.TypedData)
+	require.True(t, ok)
+	require.NoError(t, typedData.Validate(100))
+
+	hash, err := typedData.Hash()
+	require.NoError(t, err)
+
+	sig, err := crypto.Sign(key, hash.Bytes())
+	require.NoError(t, err)
+
+	res, err = endpoint.Submit(arg, toEthSignature(sig))
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&key.PublicKey), res)
+	require.Len(t, store.submitted, 1)
+
+	res, err = endpoint.GetIntents()
+	require.NoError(t, err)
+
+	intents, ok := res.([]*signedIntent)
+	require.True(t, ok)
+	assert.Equal(t, []*signedIntent{toSignedIntent(store.submitted[0])}, intents)
+
+	// the timestamp is part of the signed intent
+	arg.Timestamp = nil
+
+	_, err = endpoint.Submit(arg, toEthSignature(sig))
+	assert.ErrorIs(t, err, ErrIntentTimestampMissing)
+}
+
+func TestIntentEndpoint_Disabled(t *testing.T) {
+	t.Parallel()
+
+	params := &dispatcherParams{jsonRPCBatchLengthLimit: 20, blockRangeLimit: 1000}
+	req := []byte(`{"method": "intent_getSignals", "params": [], "id": 1}`)
+
+	// the intent endpoint is only available if the validator intents are enabled
+	dispatcher := newDispatcher(hclog.NewNullLogger(), newMockStore(), params)
+
+	res, err := dispatcher.Handle(req)
+	require.NoError(t, err)
+	assert.Contains(t, string(res), "error")
+
+	dispatcher.registerIntentEndpoint(&mockIntentStore{})
+
+	res, err = dispatcher.Handle(req)
+	require.NoError(t, err)
+	assert.Contains(t, string(res), `"result":[]`)
+}
@@ -0,0 +1,161 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// ipcSocketMode restricts the IPC endpoint to the user running the node
+const ipcSocketMode = 0600
+
+// ipcConn is the connection of an IPC client, whose messages are newline delimited JSON values
+type ipcConn struct {
+	sync.Mutex
+
+	conn     net.Conn
+	logger   hclog.Logger
+	filterID string
+}
+
+func (c *ipcConn) SetFilterID(filterID string) {
+	c.filterID = filterID
+}
+
+func (c *ipcConn) GetFilterID() string {
+	return c.filterID
+}
+
+// WriteMessage writes out the message to the IPC client, the message type is ignored
+func (c *ipcConn) WriteMessage(_ int, data []byte) error {
+	c.Lock()
+	defer c.Unlock()
+
+	_, err := c.conn.Write(append(data, '\n'))
+	if err != nil {
+		c.logger.Error("unable to write IPC message", "err", err)
+	}
+
+	return err
+}
+
+// setupIPC listens on the unix socket of the IPC endpoint, which only the user running the node can access
+func (j *JSONRPC) setupIPC() error {
+	path := j.config.IPCPath
+
+	if err := removeStaleSocket(path); err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chmod(path, ipcSocketMode); err != nil {
+		_ = lis.Close()
+
+		return err
+	}
+
+	j.ipcListener = lis
+
+	j.logger.Info("ipc server started", "path", path)
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					j.logger.Error("closed ipc listener", "err", err)
+				}
+
+				return
+			}
+
+			go j.handleIPC(conn)
+		}
+	}()
+
+	return nil
+}
+
+// closeIPC stops the IPC endpoint, whose socket is removed
+func (j *JSONRPC) closeIPC() {
+	if j.ipcListener == nil {
+		return
+	}
+
+	if err := j.ipcListener.Close(); err != nil {
+		j.logger.Error("failed to close ipc listener", "err", err)
+	}
+
+	j.ipcListener = nil
+}
+
+// removeStaleSocket removes the socket left by a previous run, any other file is kept
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+
+	return os.Remove(path)
+}
+
+// handleIPC serves the requests of the IPC client until it disconnects,
+// the subscriptions are served as over the WS endpoint
+func (j *JSONRPC) handleIPC(conn net.Conn) {
+	defer conn.Close()
+
+	wrapConn := &ipcConn{conn: conn, logger: j.logger}
+	decoder := json.NewDecoder(conn)
+
+	for {
+		var message json.RawMessage
+		if err := decoder.Decode(&message); err != nil {
+			if !errors.Is(err, io.EOF) {
+				j.logger.Error("unable to read IPC message", "err", err)
+			}
+
+			j.dispatcher.RemoveFilterByWs(wrapConn)
+
+			return
+		}
+
+		go func() {
+			resp, err := j.handleIPCRequest(message, wrapConn)
+			if err != nil {
+				j.logger.Error("unable to handle IPC request", "err", err)
+
+				return
+			}
+
+			_ = wrapConn.WriteMessage(0, resp)
+		}()
+	}
+}
+
+// handleIPCRequest handles the request, or the batch of requests, of the IPC client
+func (j *JSONRPC) handleIPCRequest(message []byte, conn *ipcConn) ([]byte, error) {
+	var req Request
+
+	if err := json.Unmarshal(message, &req); err == nil &&
+		(req.Method == "eth_subscribe" || req.Method == "eth_unsubscribe") {
+		return j.dispatcher.HandleWs(message, conn)
+	}
+
+	return j.dispatcher.Handle(message)
+}
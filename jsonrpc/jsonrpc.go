@@ -1,7 +1,9 @@
 package jsonrpc
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -40,12 +42,20 @@ type JSONRPC struct {
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
+
+	// httpServer serves the http and the ws endpoints
+	httpServer *http.Server
+
+	// ipcListener accepts the connections of the IPC endpoint, nil if disabled
+	ipcListener net.Listener
 }
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
 	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
 	Handle(reqBody []byte) ([]byte, error)
+	HandleFrom(reqBody []byte, client string) ([]byte, error)
+	Close()
 }
 
 // JSONRPCStore defines all the methods required
@@ -67,24 +77,118 @@ type Config struct {
 	PriceLimit               uint64
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+
+	// FilterTimeout is the time after which the filters not polled are removed, the default if 0
+	FilterTimeout time.Duration
+
+	// MaxFiltersPerClient limits the polling filters installed from a client address, 0 for no limit
+	MaxFiltersPerClient uint64
+
+	// FilterStorePath is the file persisting the polling filters across restarts, not persisted if empty
+	FilterStorePath string
+
+	// ReadConcurrency, CallConcurrency and TraceConcurrency limit the requests executed concurrently
+	// of the cheap reads, of the calls and of the traces, 0 for no limit
+	ReadConcurrency  uint64
+	CallConcurrency  uint64
+	TraceConcurrency uint64
+
+	// CallCacheSize is the number of the eth_call results cached, the cache is disabled if 0
+	CallCacheSize int
+
+	// IndexStore serves the index endpoint, which is disabled if not set
+	IndexStore IndexStore
+
+	// AccountManager serves the personal endpoint and signs the transactions
+	// of the node accounts, which are disabled if not set
+	AccountManager AccountManager
+
+	// DevStore serves the endpoints of the dev consensus, which are disabled if not set
+	DevStore DevStore
+
+	// NonceManager enables the nonce endpoint assigning the nonces of concurrent submitters
+	NonceManager bool
+
+	// IntentStore serves the intent endpoint, which is disabled if not set
+	IntentStore IntentStore
+
+	// IPCPath is the unix socket of the IPC endpoint, which is disabled if empty
+	IPCPath string
+
+	// ExplorerStore serves the compatibility mode of the block explorers, which is disabled if not set
+	ExplorerStore ExplorerStore
+
+	// ValidatorStore serves the edge endpoint, which is disabled if not set
+	ValidatorStore ValidatorStore
+
+	// StakingStore serves the staking endpoint, which is disabled if not set
+	StakingStore StakingStore
+
+	// FreezeStore serves the freeze endpoint, which is disabled if not set
+	FreezeStore FreezeStore
 }
 
 // NewJSONRPC returns the JSONRPC http server
 func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
+	d := newDispatcher(
+		logger,
+		config.Store,
+		&dispatcherParams{
+			chainID:                 config.ChainID,
+			chainName:               config.ChainName,
+			priceLimit:              config.PriceLimit,
+			jsonRPCBatchLengthLimit: config.BatchLengthLimit,
+			blockRangeLimit:         config.BlockRangeLimit,
+			filterTimeout:           config.FilterTimeout,
+			maxFiltersPerClient:     config.MaxFiltersPerClient,
+			filterStorePath:         config.FilterStorePath,
+			readConcurrency:         config.ReadConcurrency,
+			callConcurrency:         config.CallConcurrency,
+			traceConcurrency:        config.TraceConcurrency,
+			callCacheSize:           config.CallCacheSize,
+		},
+	)
+
+	if config.IndexStore != nil {
+		d.registerIndexEndpoint(config.IndexStore)
+	}
+
+	if config.AccountManager != nil {
+		d.registerPersonalEndpoint(config.AccountManager)
+	}
+
+	if config.DevStore != nil {
+		d.registerDevEndpoints(config.DevStore)
+	}
+
+	if config.NonceManager {
+		d.registerNonceEndpoint(config.Store)
+	}
+
+	if config.IntentStore != nil {
+		d.registerIntentEndpoint(config.IntentStore)
+	}
+
+	if config.ExplorerStore != nil {
+		d.enableExplorerCompat(config.ExplorerStore)
+	}
+
+	if config.ValidatorStore != nil {
+		d.registerEdgeEndpoint(config.ValidatorStore)
+	}
+
+	if config.StakingStore != nil {
+		d.registerStakingEndpoint(config.StakingStore)
+	}
+
+	if config.FreezeStore != nil {
+		d.registerFreezeEndpoint(config.FreezeStore)
+	}
+
 	srv := &JSONRPC{
-		logger: logger.Named("jsonrpc"),
-		config: config,
-		dispatcher: newDispatcher(
-			logger,
-			config.Store,
-			&dispatcherParams{
-				chainID:                 config.ChainID,
-				chainName:               config.ChainName,
-				priceLimit:              config.PriceLimit,
-				jsonRPCBatchLengthLimit: config.BatchLengthLimit,
-				blockRangeLimit:         config.BlockRangeLimit,
-			},
-		),
+		logger:     logger.Named("jsonrpc"),
+		config:     config,
+		dispatcher: d,
 	}
 
 	// start http server
@@ -92,9 +196,33 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		return nil, err
 	}
 
+	if config.IPCPath != "" {
+		if err := srv.setupIPC(); err != nil {
+			return nil, err
+		}
+	}
+
 	return srv, nil
 }
 
+// Close stops the filters, persisting the polling filters if enabled, and the IPC endpoint
+func (j *JSONRPC) Close() {
+	j.dispatcher.Close()
+	j.closeIPC()
+}
+
+// Drain stops accepting the requests, and waits for the http requests in flight
+// until the context is done. The websocket connections are not waited for
+func (j *JSONRPC) Drain(ctx context.Context) error {
+	j.closeIPC()
+
+	if j.httpServer == nil {
+		return nil
+	}
+
+	return j.httpServer.Shutdown(ctx)
+}
+
 func (j *JSONRPC) setupHTTP() error {
 	j.logger.Info("http server started", "addr", j.config.Addr.String())
 
@@ -114,13 +242,15 @@ func (j *JSONRPC) setupHTTP() error {
 
 	mux.HandleFunc("/ws", j.handleWs)
 
-	srv := http.Server{
+	srv := &http.Server{
 		Handler:           mux,
 		ReadHeaderTimeout: 60 * time.Second,
 	}
 
+	j.httpServer = srv
+
 	go func() {
-		if err := srv.Serve(lis); err != nil {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			j.logger.Error("closed http connection", "err", err)
 		}
 	}()
@@ -296,7 +426,7 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	resp, err := j.dispatcher.HandleFrom(data, clientAddr(req))
 
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
@@ -307,6 +437,16 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	j.logger.Debug("handle", "response", string(resp))
 }
 
+// clientAddr returns the address of the client limiting its filters, the host without the port
+func clientAddr(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
 type GetResponse struct {
 	Name    string `json:"name"`
 	ChainID uint64 `json:"chain_id"`
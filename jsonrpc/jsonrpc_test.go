@@ -1,14 +1,23 @@
 package jsonrpc
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/versioning"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/go-hclog"
 )
@@ -66,3 +75,176 @@ func Test_handleGetRequest(t *testing.T) {
 		response,
 	)
 }
+
+func TestIPCServer(t *testing.T) {
+	port, err := tests.GetFreePort()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "edge.ipc")
+
+	srv, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:   newMockStore(),
+		Addr:    &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
+		ChainID: 100,
+		IPCPath: path,
+	})
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(ipcSocketMode), info.Mode().Perm())
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`))
+	require.NoError(t, err)
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	require.NoError(t, err)
+
+	var resp SuccessResponse
+	require.NoError(t, json.Unmarshal(line, &resp))
+	assert.Equal(t, float64(1), resp.ID)
+	assert.Equal(t, `"0x64"`, string(resp.Result))
+
+	srv.Close()
+
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestInProcClient(t *testing.T) {
+	params := &dispatcherParams{chainID: 100, jsonRPCBatchLengthLimit: 20, blockRangeLimit: 1000}
+
+	srv := &JSONRPC{
+		logger:     hclog.NewNullLogger(),
+		dispatcher: newDispatcher(hclog.NewNullLogger(), newMockStore(), params),
+	}
+
+	client := srv.InProcClient()
+
+	var chainID string
+	require.NoError(t, client.Call("eth_chainId", &chainID))
+	assert.Equal(t, "0x64", chainID)
+
+	// the errors of the endpoints are returned
+	err := client.Call("eth_unknown", nil)
+
+	var objErr *ObjectError
+
+	require.ErrorAs(t, err, &objErr)
+	assert.Contains(t, objErr.Message, "eth_unknown")
+}
+
+func TestRemoveStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "edge.ipc")
+
+	require.NoError(t, os.WriteFile(path, []byte{}, 0600))
+
+	// the files other than sockets are kept
+	assert.Error(t, removeStaleSocket(path))
+	assert.FileExists(t, path)
+}
+
+// blockingDispatcher handles the requests once released
+type blockingDispatcher struct {
+	dispatcher
+
+	startedCh chan struct{}
+	releaseCh chan struct{}
+}
+
+func (d *blockingDispatcher) HandleFrom([]byte, string) ([]byte, error) {
+	close(d.startedCh)
+	<-d.releaseCh
+
+	return []byte(`"done"`), nil
+}
+
+// startBlockingServer starts the http endpoint with a dispatcher handling the requests once released,
+// and sends a request to it
+func startBlockingServer(t *testing.T) (*JSONRPC, *blockingDispatcher, string, <-chan string) {
+	t.Helper()
+
+	port, err := tests.GetFreePort()
+	require.NoError(t, err)
+
+	d := &blockingDispatcher{
+		startedCh: make(chan struct{}),
+		releaseCh: make(chan struct{}),
+	}
+
+	srv := &JSONRPC{
+		logger:     hclog.NewNullLogger(),
+		config:     &Config{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port}},
+		dispatcher: d,
+	}
+
+	require.NoError(t, srv.setupHTTP())
+
+	addr := srv.config.Addr.String()
+	respCh := make(chan string, 1)
+
+	go func() {
+		resp, err := http.Post(fmt.Sprintf("http://%s", addr), "application/json", bytes.NewReader([]byte("{}")))
+		if err != nil {
+			respCh <- err.Error()
+
+			return
+		}
+
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+		respCh <- string(body)
+	}()
+
+	<-d.startedCh
+
+	return srv, d, addr, respCh
+}
+
+func TestDrain(t *testing.T) {
+	srv, d, addr, respCh := startBlockingServer(t)
+
+	drainedCh := make(chan error, 1)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		drainedCh <- srv.Drain(ctx)
+	}()
+
+	// the request in flight is waited for
+	select {
+	case <-drainedCh:
+		t.Fatal("drained before the request in flight is done")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the new connections are refused
+	_, err := net.Dial("tcp", addr)
+	assert.Error(t, err)
+
+	close(d.releaseCh)
+
+	assert.Equal(t, `"done"`, <-respCh)
+	assert.NoError(t, <-drainedCh)
+}
+
+func TestDrain_Timeout(t *testing.T) {
+	srv, d, _, respCh := startBlockingServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// the request in flight isn't done within the timeout
+	assert.ErrorIs(t, srv.Drain(ctx), context.DeadlineExceeded)
+
+	close(d.releaseCh)
+	<-respCh
+}
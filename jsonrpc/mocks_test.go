@@ -172,6 +172,10 @@ func (m *mockStore) GetCapacity() (uint64, uint64) {
 	return 0, 0
 }
 
+func (m *mockStore) GetQuarantinedTxs() []*QuarantinedTx {
+	return nil
+}
+
 func (m *mockStore) GetPeers() int {
 	return 20
 }
@@ -0,0 +1,72 @@
+package jsonrpc
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Nonce is the jsonrpc endpoint of the nonce manager, assigning the nonces
+// of the submitters sharing the same sender
+type Nonce struct {
+	manager *NonceManager
+}
+
+// nonceStatus is the JSON form of NonceStatus
+type nonceStatus struct {
+	Nonce     argUint64   `json:"nonce"`
+	Next      argUint64   `json:"next"`
+	Reserved  []argUint64 `json:"reserved"`
+	Submitted []argUint64 `json:"submitted"`
+	Gaps      []argUint64 `json:"gaps"`
+}
+
+func toArgUint64s(nums []uint64) []argUint64 {
+	res := make([]argUint64, len(nums))
+	for i, num := range nums {
+		res[i] = argUint64(num)
+	}
+
+	return res
+}
+
+// Reserve returns the nonce to sign the next transaction of the sender with
+func (n *Nonce) Reserve(addr types.Address) (interface{}, error) {
+	return argUint64(n.manager.Reserve(addr)), nil
+}
+
+// Release gives back a reserved nonce, which is then reserved again
+func (n *Nonce) Release(addr types.Address, nonce argUint64) (interface{}, error) {
+	if err := n.manager.Release(addr, uint64(nonce)); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// SendRawTransaction sends the signed transaction, resubmitting it until its inclusion
+func (n *Nonce) SendRawTransaction(buf argBytes) (interface{}, error) {
+	tx := &types.Transaction{}
+	if err := tx.UnmarshalRLP(buf); err != nil {
+		return nil, err
+	}
+
+	tx.ComputeHash()
+
+	if err := n.manager.Submit(tx); err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
+}
+
+// Status returns the reserved nonces of the sender and the gaps in the sequence of its transactions
+func (n *Nonce) Status(addr types.Address) (interface{}, error) {
+	status := n.manager.Status(addr)
+
+	return &nonceStatus{
+		Nonce:     argUint64(status.Nonce),
+		Next:      argUint64(status.Next),
+		Reserved:  toArgUint64s(status.Reserved),
+		Submitted: toArgUint64s(status.Submitted),
+		Gaps:      toArgUint64s(status.Gaps),
+	}, nil
+}
@@ -0,0 +1,313 @@
+package jsonrpc
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// defaultReservationTimeout is the time after which a reserved nonce
+	// that wasn't used is handed out again
+	defaultReservationTimeout = 60 * time.Second
+
+	// defaultResubmitInterval is the interval between the resubmissions
+	// of the managed transactions dropped by the pool
+	defaultResubmitInterval = 10 * time.Second
+)
+
+var (
+	ErrNonceNotReserved = errors.New("the nonce of the transaction was not reserved")
+	ErrUnknownSender    = errors.New("unable to recover the sender of the transaction")
+)
+
+// nonceManagerStore provides the nonces of the accounts and the pool of their transactions
+type nonceManagerStore interface {
+	ethTxPoolStore
+
+	// Header returns the current header of the chain
+	Header() *types.Header
+
+	// GetAccount returns the account at the state root
+	GetAccount(root types.Hash, addr types.Address) (*Account, error)
+}
+
+// senderNonces are the nonces handed out for a sender
+type senderNonces struct {
+	// next is the next nonce to reserve
+	next uint64
+
+	// reserved are the nonces not used yet, with their reservation time
+	reserved map[uint64]time.Time
+
+	// submitted are the transactions sent with the reserved nonces, until their inclusion
+	submitted map[uint64]*types.Transaction
+}
+
+// NonceStatus is the state of the nonces of a sender
+type NonceStatus struct {
+	// Nonce is the nonce of the sender in the latest state
+	Nonce uint64
+
+	// Next is the next nonce to be reserved
+	Next uint64
+
+	// Reserved are the nonces reserved but not used yet
+	Reserved []uint64
+
+	// Submitted are the nonces of the transactions waiting for their inclusion
+	Submitted []uint64
+
+	// Gaps are the nonces after the pending transactions of the pool which have
+	// no transaction yet, blocking the execution of the following ones
+	Gaps []uint64
+}
+
+// NonceManager assigns the nonces of the transactions of concurrent submitters
+// sharing the same sender, and resubmits their transactions until their inclusion
+type NonceManager struct {
+	logger hclog.Logger
+	store  nonceManagerStore
+
+	reservationTimeout time.Duration
+	resubmitInterval   time.Duration
+
+	lock    sync.Mutex
+	senders map[types.Address]*senderNonces
+
+	closeCh chan struct{}
+}
+
+func NewNonceManager(logger hclog.Logger, store nonceManagerStore) *NonceManager {
+	return &NonceManager{
+		logger:             logger.Named("nonce-manager"),
+		store:              store,
+		reservationTimeout: defaultReservationTimeout,
+		resubmitInterval:   defaultResubmitInterval,
+		senders:            make(map[types.Address]*senderNonces),
+		closeCh:            make(chan struct{}),
+	}
+}
+
+// Run resubmits periodically the transactions dropped by the pool
+func (m *NonceManager) Run() {
+	ticker := time.NewTicker(m.resubmitInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.resubmit()
+
+		case <-m.closeCh:
+			return
+		}
+	}
+}
+
+// Close stops the resubmissions
+func (m *NonceManager) Close() {
+	close(m.closeCh)
+}
+
+// stateNonce returns the nonce of the account in the latest state
+func (m *NonceManager) stateNonce(addr types.Address) uint64 {
+	account, err := m.store.GetAccount(m.store.Header().StateRoot, addr)
+	if err != nil {
+		return 0
+	}
+
+	return account.Nonce
+}
+
+// getSender returns the nonces of the sender, whose included ones are pruned
+func (m *NonceManager) getSender(addr types.Address) *senderNonces {
+	sender, ok := m.senders[addr]
+	if !ok {
+		sender = &senderNonces{
+			reserved:  make(map[uint64]time.Time),
+			submitted: make(map[uint64]*types.Transaction),
+		}
+		m.senders[addr] = sender
+	}
+
+	nonce := m.stateNonce(addr)
+
+	for reserved := range sender.reserved {
+		if reserved < nonce {
+			delete(sender.reserved, reserved)
+		}
+	}
+
+	for submitted := range sender.submitted {
+		if submitted < nonce {
+			delete(sender.submitted, submitted)
+		}
+	}
+
+	// the nonces sent outside of the manager are skipped
+	if poolNonce := m.store.GetNonce(addr); sender.next < poolNonce {
+		sender.next = poolNonce
+	}
+
+	return sender
+}
+
+// Reserve returns the nonce of the next transaction of the sender.
+// The reservations not used in time are handed out again, so that they don't leave a gap
+func (m *NonceManager) Reserve(addr types.Address) uint64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sender := m.getSender(addr)
+	now := time.Now()
+
+	expired := make([]uint64, 0)
+
+	for nonce, reservedAt := range sender.reserved {
+		if now.Sub(reservedAt) > m.reservationTimeout {
+			expired = append(expired, nonce)
+		}
+	}
+
+	if len(expired) > 0 {
+		sort.Slice(expired, func(i, j int) bool { return expired[i] < expired[j] })
+
+		sender.reserved[expired[0]] = now
+
+		return expired[0]
+	}
+
+	nonce := sender.next
+	sender.reserved[nonce] = now
+	sender.next++
+
+	return nonce
+}
+
+// Release gives back a reserved nonce which won't be used
+func (m *NonceManager) Release(addr types.Address, nonce uint64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sender := m.getSender(addr)
+
+	if _, ok := sender.reserved[nonce]; !ok {
+		return ErrNonceNotReserved
+	}
+
+	delete(sender.reserved, nonce)
+
+	// roll back the last nonces, so that they don't leave a gap
+	for sender.next > 0 {
+		last := sender.next - 1
+
+		_, reserved := sender.reserved[last]
+		_, submitted := sender.submitted[last]
+
+		if reserved || submitted || last < m.store.GetNonce(addr) {
+			break
+		}
+
+		sender.next = last
+	}
+
+	return nil
+}
+
+// Submit adds the transaction to the pool, using up its nonce if it was reserved.
+// The transaction is resubmitted if it's dropped before its inclusion
+func (m *NonceManager) Submit(tx *types.Transaction) error {
+	if err := m.store.AddTx(tx); err != nil {
+		return err
+	}
+
+	// the sender is recovered by the pool
+	if tx.From == types.ZeroAddress {
+		return ErrUnknownSender
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sender := m.getSender(tx.From)
+
+	delete(sender.reserved, tx.Nonce)
+	sender.submitted[tx.Nonce] = tx
+
+	if sender.next <= tx.Nonce {
+		sender.next = tx.Nonce + 1
+	}
+
+	return nil
+}
+
+// Status returns the state of the nonces of the sender
+func (m *NonceManager) Status(addr types.Address) *NonceStatus {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sender := m.getSender(addr)
+
+	status := &NonceStatus{
+		Nonce:     m.stateNonce(addr),
+		Next:      sender.next,
+		Reserved:  make([]uint64, 0, len(sender.reserved)),
+		Submitted: make([]uint64, 0, len(sender.submitted)),
+		Gaps:      make([]uint64, 0),
+	}
+
+	for nonce := range sender.reserved {
+		status.Reserved = append(status.Reserved, nonce)
+	}
+
+	for nonce := range sender.submitted {
+		status.Submitted = append(status.Submitted, nonce)
+	}
+
+	sort.Slice(status.Reserved, func(i, j int) bool { return status.Reserved[i] < status.Reserved[j] })
+	sort.Slice(status.Submitted, func(i, j int) bool { return status.Submitted[i] < status.Submitted[j] })
+
+	for nonce := m.store.GetNonce(addr); nonce < sender.next; nonce++ {
+		if _, ok := sender.submitted[nonce]; !ok {
+			status.Gaps = append(status.Gaps, nonce)
+		}
+	}
+
+	return status
+}
+
+// resubmit adds again to the pool the managed transactions it dropped
+func (m *NonceManager) resubmit() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for addr := range m.senders {
+		sender := m.getSender(addr)
+
+		if len(sender.reserved) == 0 && len(sender.submitted) == 0 {
+			delete(m.senders, addr)
+
+			continue
+		}
+
+		for _, tx := range sender.submitted {
+			if _, ok := m.store.GetPendingTx(tx.Hash); ok {
+				continue
+			}
+
+			if err := m.store.AddTx(tx.Copy()); err != nil {
+				m.logger.Debug("failed to resubmit the transaction", "hash", tx.Hash, "err", err)
+
+				continue
+			}
+
+			m.logger.Debug("resubmitted the transaction", "hash", tx.Hash, "from", addr, "nonce", tx.Nonce)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockNonceStore struct {
+	ethTxPoolStore
+
+	nonce     uint64
+	poolNonce uint64
+	pool      map[types.Hash]*types.Transaction
+	added     int
+}
+
+func (m *mockNonceStore) Header() *types.Header {
+	return &types.Header{}
+}
+
+func (m *mockNonceStore) GetAccount(root types.Hash, addr types.Address) (*Account, error) {
+	return &Account{Balance: big.NewInt(0), Nonce: m.nonce}, nil
+}
+
+func (m *mockNonceStore) GetNonce(addr types.Address) uint64 {
+	return m.poolNonce
+}
+
+func (m *mockNonceStore) AddTx(tx *types.Transaction) error {
+	if m.pool == nil {
+		m.pool = map[types.Hash]*types.Transaction{}
+	}
+
+	tx.From = addr0
+	m.pool[tx.Hash] = tx
+	m.added++
+
+	return nil
+}
+
+func (m *mockNonceStore) GetPendingTx(hash types.Hash) (*types.Transaction, bool) {
+	tx, ok := m.pool[hash]
+
+	return tx, ok
+}
+
+func newTestNonceTx(nonce uint64) *types.Transaction {
+	tx := &types.Transaction{
+		Nonce:    nonce,
+		GasPrice: big.NewInt(1),
+		Value:    big.NewInt(0),
+	}
+	tx.ComputeHash()
+
+	return tx
+}
+
+func TestNonceManager_Reserve(t *testing.T) {
+	t.Parallel()
+
+	store := &mockNonceStore{nonce: 3, poolNonce: 5}
+	manager := NewNonceManager(hclog.NewNullLogger(), store)
+
+	// the reservations start after the pending transactions
+	assert.Equal(t, uint64(5), manager.Reserve(addr0))
+	assert.Equal(t, uint64(6), manager.Reserve(addr0))
+	assert.Equal(t, uint64(7), manager.Reserve(addr0))
+
+	// the last nonce is rolled back, the other one leaves a gap
+	require.NoError(t, manager.Release(addr0, 7))
+	require.NoError(t, manager.Release(addr0, 5))
+	assert.ErrorIs(t, manager.Release(addr0, 5), ErrNonceNotReserved)
+
+	status := manager.Status(addr0)
+	assert.Equal(t, uint64(7), status.Next)
+	assert.Equal(t, []uint64{6}, status.Reserved)
+	assert.Equal(t, []uint64{5, 6}, status.Gaps)
+
+	// the expired reservations are handed out again
+	manager.reservationTimeout = 0
+
+	time.Sleep(time.Millisecond)
+	assert.Equal(t, uint64(6), manager.Reserve(addr0))
+}
+
+func TestNonceManager_Resubmit(t *testing.T) {
+	t.Parallel()
+
+	store := &mockNonceStore{}
+	manager := NewNonceManager(hclog.NewNullLogger(), store)
+
+	nonce := manager.Reserve(addr0)
+	tx := newTestNonceTx(nonce)
+
+	require.NoError(t, manager.Submit(tx))
+
+	status := manager.Status(addr0)
+	assert.Empty(t, status.Reserved)
+	assert.Equal(t, []uint64{0}, status.Submitted)
+	assert.Empty(t, status.Gaps)
+
+	// the transaction still in the pool isn't resubmitted
+	manager.resubmit()
+	assert.Equal(t, 1, store.added)
+
+	// the transaction dropped by the pool is resubmitted
+	delete(store.pool, tx.Hash)
+
+	manager.resubmit()
+	assert.Equal(t, 2, store.added)
+
+	// the included transaction isn't tracked anymore
+	store.nonce = 1
+
+	assert.Empty(t, manager.Status(addr0).Submitted)
+}
@@ -0,0 +1,181 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// defaultUnlockDuration is the time an account stays unlocked if no duration is given
+const defaultUnlockDuration = 300 * time.Second
+
+var (
+	ErrSignNotSupported = errors.New("signing requires the node to manage the account")
+	ErrInvalidSignature = errors.New("invalid signature, should be 65B with V being 27 or 28")
+)
+
+// HashSigner signs the hashes with the keys of the accounts managed by the node
+type HashSigner interface {
+	// SignHash signs the hash with the unlocked key of the account,
+	// the signature is in the [R || S || V] format, V being 0 or 1
+	SignHash(addr types.Address, hash []byte) ([]byte, error)
+}
+
+// AccountManager manages the accounts whose keys are held encrypted by the node
+type AccountManager interface {
+	TxSigner
+	HashSigner
+
+	// Accounts returns the addresses of the managed accounts
+	Accounts() []types.Address
+
+	// NewAccount creates an account whose key is encrypted with the passphrase
+	NewAccount(passphrase string) (types.Address, error)
+
+	// ImportRawKey imports the private key, encrypted with the passphrase
+	ImportRawKey(key []byte, passphrase string) (types.Address, error)
+
+	// Unlock holds the decrypted key of the account for the timeout, indefinitely if 0
+	Unlock(addr types.Address, passphrase string, timeout time.Duration) error
+
+	// Lock drops the decrypted key of the account
+	Lock(addr types.Address) error
+
+	// SignTxWithPassphrase signs the transaction without unlocking the sender account
+	SignTxWithPassphrase(tx *types.Transaction, passphrase string) (*types.Transaction, error)
+
+	// SignHashWithPassphrase signs the hash without unlocking the account
+	SignHashWithPassphrase(addr types.Address, passphrase string, hash []byte) ([]byte, error)
+}
+
+// Personal is the personal jsonrpc endpoint, managing the accounts held by the node
+type Personal struct {
+	manager AccountManager
+	eth     *Eth
+}
+
+// ListAccounts returns the addresses of the accounts held by the node
+func (p *Personal) ListAccounts() (interface{}, error) {
+	return p.manager.Accounts(), nil
+}
+
+// NewAccount creates an account whose key is encrypted with the passphrase
+func (p *Personal) NewAccount(passphrase string) (interface{}, error) {
+	return p.manager.NewAccount(passphrase)
+}
+
+// ImportRawKey imports the hex encoded private key, encrypted with the passphrase
+func (p *Personal) ImportRawKey(key argBytes, passphrase string) (interface{}, error) {
+	return p.manager.ImportRawKey(key, passphrase)
+}
+
+// UnlockAccount unlocks the account for the duration in seconds, 300 seconds by default
+// and until it's locked if the duration is 0
+func (p *Personal) UnlockAccount(addr types.Address, passphrase string, duration *argUint64) (interface{}, error) {
+	timeout := defaultUnlockDuration
+	if duration != nil {
+		timeout = time.Duration(*duration) * time.Second
+	}
+
+	if err := p.manager.Unlock(addr, passphrase, timeout); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// LockAccount locks the account
+func (p *Personal) LockAccount(addr types.Address) (interface{}, error) {
+	if err := p.manager.Lock(addr); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SendTransaction signs the transaction with the sender key decrypted with the passphrase
+// and sends it, the sender account isn't unlocked
+func (p *Personal) SendTransaction(arg *txnArgs, passphrase string) (interface{}, error) {
+	return p.eth.sendTransaction(arg, func(tx *types.Transaction) (*types.Transaction, error) {
+		return p.manager.SignTxWithPassphrase(tx, passphrase)
+	})
+}
+
+// Sign signs the EIP-191 prefixed message with the key of the account decrypted with the passphrase
+func (p *Personal) Sign(data argBytes, addr types.Address, passphrase string) (interface{}, error) {
+	sig, err := p.manager.SignHashWithPassphrase(addr, passphrase, crypto.TextHash(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return toEthSignature(sig), nil
+}
+
+// SignTypedData signs the EIP-712 typed data with the key of the account decrypted with the passphrase
+func (p *Personal) SignTypedData(typedData typedDataArg, addr types.Address, passphrase string) (interface{}, error) {
+	hash, err := typedData.hash(p.eth.chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := p.manager.SignHashWithPassphrase(addr, passphrase, hash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return toEthSignature(sig), nil
+}
+
+// EcRecover returns the address of the account which signed the EIP-191 prefixed message
+func (p *Personal) EcRecover(data argBytes, sig argBytes) (interface{}, error) {
+	if len(sig) != 65 || (sig[64] != 27 && sig[64] != 28) {
+		return nil, ErrInvalidSignature
+	}
+
+	raw := make([]byte, 65)
+	copy(raw, sig)
+	raw[64] -= 27
+
+	pub, err := crypto.RecoverPubkey(raw, crypto.TextHash(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.PubKeyToAddress(pub), nil
+}
+
+// typedDataArg is the EIP-712 typed data argument, given either as an object
+// or as its JSON encoding in a string
+type typedDataArg struct {
+	crypto.TypedData
+}
+
+func (t *typedDataArg) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err == nil {
+		data = []byte(encoded)
+	}
+
+	return json.Unmarshal(data, &t.TypedData)
+}
+
+// hash validates the typed data against the chain and returns its signing hash
+func (t *typedDataArg) hash(chainID uint64) (types.Hash, error) {
+	if err := t.Validate(chainID); err != nil {
+		return types.ZeroHash, err
+	}
+
+	return t.Hash()
+}
+
+// toEthSignature returns the signature with V being 27 or 28, as expected by the Ethereum tooling
+func toEthSignature(sig []byte) argBytes {
+	ethSig := make([]byte, len(sig))
+	copy(ethSig, sig)
+	ethSig[len(ethSig)-1] += 27
+
+	return ethSig
+}
@@ -0,0 +1,288 @@
+package jsonrpc
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errMockLocked     = errors.New("locked")
+	errMockPassphrase = errors.New("wrong passphrase")
+)
+
+// mockAccountManager manages a single account of the passphrase "secret"
+type mockAccountManager struct {
+	mockTxSigner
+
+	key      *ecdsa.PrivateKey
+	unlocked bool
+	timeout  time.Duration
+}
+
+func newMockAccountManager(t *testing.T) *mockAccountManager {
+	t.Helper()
+
+	key, addr := tests.GenerateKeyAndAddr(t)
+
+	return &mockAccountManager{mockTxSigner: mockTxSigner{managed: addr}, key: key}
+}
+
+func (m *mockAccountManager) Accounts() []types.Address {
+	return []types.Address{m.managed}
+}
+
+func (m *mockAccountManager) NewAccount(string) (types.Address, error) {
+	return m.managed, nil
+}
+
+func (m *mockAccountManager) ImportRawKey([]byte, string) (types.Address, error) {
+	return m.managed, nil
+}
+
+func (m *mockAccountManager) Unlock(_ types.Address, passphrase string, timeout time.Duration) error {
+	if passphrase != "secret" {
+		return errMockPassphrase
+	}
+
+	m.unlocked, m.timeout = true, timeout
+
+	return nil
+}
+
+func (m *mockAccountManager) Lock(types.Address) error {
+	m.unlocked = false
+
+	return nil
+}
+
+func (m *mockAccountManager) SignTx(tx *types.Transaction) (*types.Transaction, error) {
+	if !m.unlocked {
+		return nil, errMockLocked
+	}
+
+	return m.mockTxSigner.SignTx(tx)
+}
+
+func (m *mockAccountManager) SignTxWithPassphrase(tx *types.Transaction, passphrase string) (*types.Transaction, error) {
+	if passphrase != "secret" {
+		return nil, errMockPassphrase
+	}
+
+	return m.mockTxSigner.SignTx(tx)
+}
+
+func (m *mockAccountManager) SignHash(_ types.Address, hash []byte) ([]byte, error) {
+	if !m.unlocked {
+		return nil, errMockLocked
+	}
+
+	return crypto.Sign(m.key, hash)
+}
+
+func (m *mockAccountManager) SignHashWithPassphrase(_ types.Address, passphrase string, hash []byte) ([]byte, error) {
+	if passphrase != "secret" {
+		return nil, errMockPassphrase
+	}
+
+	return crypto.Sign(m.key, hash)
+}
+
+func newTestTxnArgs(from types.Address) *txnArgs {
+	return &txnArgs{
+		From:     argAddrPtr(from),
+		To:       argAddrPtr(types.StringToAddress("1")),
+		Gas:      argUintPtr(21000),
+		GasPrice: argBytesPtr(big.NewInt(10).Bytes()),
+		Value:    argBytesPtr(big.NewInt(5).Bytes()),
+		Nonce:    argUintPtr(2),
+	}
+}
+
+func TestPersonal_UnlockAccount(t *testing.T) {
+	manager := &mockAccountManager{mockTxSigner: mockTxSigner{managed: addr0}}
+	personal := &Personal{manager, newTestEthEndpoint(&mockStoreTxn{})}
+
+	res, err := personal.UnlockAccount(addr0, "secret", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, true, res)
+	assert.Equal(t, defaultUnlockDuration, manager.timeout)
+
+	_, err = personal.UnlockAccount(addr0, "secret", argUintPtr(0))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), manager.timeout)
+
+	res, err = personal.UnlockAccount(addr0, "wrong", argUintPtr(60))
+	assert.ErrorIs(t, err, errMockPassphrase)
+	assert.Equal(t, false, res)
+
+	_, err = personal.LockAccount(addr0)
+	assert.NoError(t, err)
+	assert.False(t, manager.unlocked)
+}
+
+func TestPersonal_SendTransaction(t *testing.T) {
+	store := &mockStoreTxn{}
+	manager := &mockAccountManager{mockTxSigner: mockTxSigner{managed: addr0}}
+	personal := &Personal{manager, newTestEthEndpoint(store)}
+
+	_, err := personal.SendTransaction(newTestTxnArgs(addr0), "wrong")
+	assert.ErrorIs(t, err, errMockPassphrase)
+
+	// the account doesn't need to be unlocked
+	res, err := personal.SendTransaction(newTestTxnArgs(addr0), "secret")
+	require.NoError(t, err)
+
+	assert.Equal(t, store.txn.Hash.String(), res)
+	assert.Equal(t, uint64(2), store.txn.Nonce)
+	assert.Equal(t, big.NewInt(5), store.txn.Value)
+	assert.Equal(t, big.NewInt(1), store.txn.V)
+	assert.False(t, manager.unlocked)
+}
+
+func TestEth_TxnPool_SendTransactionWithManagedAccount(t *testing.T) {
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	// the node doesn't manage any account
+	_, err := eth.SendTransaction(newTestTxnArgs(addr0))
+	assert.Error(t, err)
+
+	manager := &mockAccountManager{mockTxSigner: mockTxSigner{managed: addr0}}
+	eth.signer = manager
+
+	_, err = eth.SendTransaction(newTestTxnArgs(addr0))
+	assert.ErrorIs(t, err, errMockLocked)
+
+	_, err = eth.SendTransaction(&txnArgs{})
+	assert.ErrorIs(t, err, ErrMissingSender)
+
+	require.NoError(t, manager.Unlock(addr0, "secret", 0))
+
+	res, err := eth.SendTransaction(newTestTxnArgs(addr0))
+	require.NoError(t, err)
+	assert.Equal(t, store.txn.Hash.String(), res)
+	assert.Equal(t, addr0, store.txn.From)
+}
+
+func TestPersonal_Sign(t *testing.T) {
+	manager := newMockAccountManager(t)
+	personal := &Personal{manager, newTestEthEndpoint(&mockStoreTxn{})}
+
+	data := []byte("hello")
+
+	_, err := personal.Sign(data, manager.managed, "wrong")
+	assert.ErrorIs(t, err, errMockPassphrase)
+
+	res, err := personal.Sign(data, manager.managed, "secret")
+	require.NoError(t, err)
+
+	sig, ok := res.(argBytes)
+	require.True(t, ok)
+	require.Len(t, sig, 65)
+	assert.Contains(t, []byte{27, 28}, sig[64])
+
+	signer, err := personal.EcRecover(data, sig)
+	require.NoError(t, err)
+	assert.Equal(t, manager.managed, signer)
+
+	// the recovered signer differs for another message
+	signer, err = personal.EcRecover([]byte("world"), sig)
+	require.NoError(t, err)
+	assert.NotEqual(t, manager.managed, signer)
+
+	_, err = personal.EcRecover(data, sig[:64])
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+// testTypedData is the example of EIP-712 on the chain of the test endpoint
+const testTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": %d,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func newTestTypedDataArg(t *testing.T, chainID uint64) typedDataArg {
+	t.Helper()
+
+	raw, err := json.Marshal(fmt.Sprintf(testTypedData, chainID))
+	require.NoError(t, err)
+
+	// the typed data is given as a JSON string by the wallets
+	arg := typedDataArg{}
+	require.NoError(t, json.Unmarshal(raw, &arg))
+
+	return arg
+}
+
+func TestEth_SignTypedData(t *testing.T) {
+	eth := newTestEthEndpoint(&mockStoreTxn{})
+	typedData := newTestTypedDataArg(t, eth.chainID)
+
+	// the node doesn't manage any account
+	_, err := eth.SignTypedData_v4(addr0, typedData)
+	assert.ErrorIs(t, err, ErrSignNotSupported)
+
+	manager := newMockAccountManager(t)
+	eth.signer = manager
+
+	_, err = eth.SignTypedData_v4(manager.managed, typedData)
+	assert.ErrorIs(t, err, errMockLocked)
+
+	require.NoError(t, manager.Unlock(manager.managed, "secret", 0))
+
+	_, err = eth.SignTypedData_v4(manager.managed, newTestTypedDataArg(t, 1))
+	assert.ErrorIs(t, err, crypto.ErrDomainChainID)
+
+	res, err := eth.SignTypedData_v4(manager.managed, typedData)
+	require.NoError(t, err)
+
+	sig, ok := res.(argBytes)
+	require.True(t, ok)
+
+	hash, err := typedData.Hash()
+	require.NoError(t, err)
+
+	raw := append([]byte{}, sig...)
+	raw[64] -= 27
+
+	pub, err := crypto.RecoverPubkey(raw, hash.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, manager.managed, crypto.PubKeyToAddress(pub))
+}
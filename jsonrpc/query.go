@@ -72,6 +72,29 @@ func decodeLogQueryFromInterface(i interface{}) (*LogQuery, error) {
 	return query, nil
 }
 
+// decodeAddressesFromInterface decodes the accounts subscription params,
+// an object with the list of the watched addresses
+func decodeAddressesFromInterface(params []interface{}) ([]types.Address, error) {
+	if len(params) == 0 {
+		return nil, ErrNoWatchedAccounts
+	}
+
+	raw, err := json.Marshal(params[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var obj struct {
+		Addresses []types.Address `json:"addresses"`
+	}
+
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+
+	return obj.Addresses, nil
+}
+
 // UnmarshalJSON decodes a json object
 func (q *LogQuery) UnmarshalJSON(data []byte) error {
 	var obj struct {
@@ -174,6 +197,73 @@ func (q *LogQuery) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON encodes the query in the format decoded by UnmarshalJSON
+func (q *LogQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		BlockHash *types.Hash     `json:"blockHash,omitempty"`
+		FromBlock string          `json:"fromBlock"`
+		ToBlock   string          `json:"toBlock"`
+		Address   []types.Address `json:"address,omitempty"`
+		Topics    [][]types.Hash  `json:"topics,omitempty"`
+	}{
+		BlockHash: q.BlockHash,
+		FromBlock: blockNumberToString(q.fromBlock),
+		ToBlock:   blockNumberToString(q.toBlock),
+		Address:   q.Addresses,
+		Topics:    q.Topics,
+	})
+}
+
+// blockNumberToString encodes the block number as accepted by stringToBlockNumber
+func blockNumberToString(num BlockNumber) string {
+	switch num {
+	case PendingBlockNumber, LatestBlockNumber:
+		return latest
+	case EarliestBlockNumber:
+		return earliest
+	}
+
+	return fmt.Sprintf("0x%x", uint64(num))
+}
+
+// MatchBloom returns whether the logs of a block with the given bloom may match the query,
+// the blocks whose bloom doesn't match can be skipped without reading their receipts
+func (q *LogQuery) MatchBloom(bloom types.Bloom) bool {
+	if len(q.Addresses) > 0 {
+		match := false
+
+		for _, addr := range q.Addresses {
+			if bloom.IsPresent(addr.Bytes()) {
+				match = true
+
+				break
+			}
+		}
+
+		if !match {
+			return false
+		}
+	}
+
+	for _, sub := range q.Topics {
+		match := len(sub) == 0
+
+		for _, topic := range sub {
+			if bloom.IsPresent(topic.Bytes()) {
+				match = true
+
+				break
+			}
+		}
+
+		if !match {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Match returns whether the receipt includes topics for this filter
 func (q *LogQuery) Match(log *types.Log) bool {
 	// check addresses
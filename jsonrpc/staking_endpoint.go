@@ -0,0 +1,397 @@
+package jsonrpc
+
+import (
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/indexer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// defaultStakeHistoryEpochs is the number of the epochs of the stake history if the query has no range
+	defaultStakeHistoryEpochs = 100
+
+	// maxStakeHistoryEpochs is the maximum number of the epochs of a stake history
+	maxStakeHistoryEpochs = 1000
+
+	// secondsPerYear annualizes the reward rates
+	secondsPerYear = 365 * 24 * 60 * 60
+)
+
+var ErrStakeHistoryRange = errors.New("fromEpoch is greater than toEpoch or the range exceeds 1000 epochs")
+
+// StakingStore provides the staking events of the indexer and the stakes of the staking contract
+type StakingStore interface {
+	headerGetter
+	indexHead
+
+	// GetStakeEvents returns the staking contract events of an account in a block range
+	GetStakeEvents(account types.Address, from, to uint64, limit int) ([]*indexer.StakeEvent, error)
+
+	// GetStakingStats returns the cumulative staking stats of the chain at an indexed block
+	GetStakingStats(number uint64) (*indexer.StakingStats, bool, error)
+
+	// GetStake returns the amount staked by the account at the block, the total staked amount if nil
+	GetStake(header *types.Header, account *types.Address) (*big.Int, error)
+
+	// GetAutoCompounding returns whether the rewards of the account are restaked at the block,
+	// and the sum of its rewards restaked up to the block
+	GetAutoCompounding(header *types.Header, account types.Address) (bool, *big.Int, error)
+
+	// EpochSize returns the number of the blocks of an epoch
+	EpochSize() uint64
+}
+
+// Staking is the staking jsonrpc endpoint, available in the indexer mode. It serves the stats
+// of the staking dashboards from the indexed staking events, without an external indexer
+type Staking struct {
+	store StakingStore
+}
+
+type stakingStats struct {
+	BlockNumber argUint64 `json:"blockNumber"`
+	Epoch       argUint64 `json:"epoch"`
+	TotalStaked argBig    `json:"totalStaked"`
+
+	// FromBlock is the first block of the window the rewards are summed over
+	FromBlock argUint64 `json:"fromBlock"`
+	Rewards   argBig    `json:"rewards"`
+
+	// RewardRate is the annual rate, in percent, of the rewards of the window over the total stake
+	RewardRate float64 `json:"rewardRate"`
+}
+
+type stakeHistoryEntry struct {
+	Epoch argUint64 `json:"epoch"`
+
+	// BlockNumber is the last block of the epoch, the last indexed block for the current epoch
+	BlockNumber argUint64 `json:"blockNumber"`
+	Stake       argBig    `json:"stake"`
+}
+
+type autoCompounding struct {
+	BlockNumber argUint64 `json:"blockNumber"`
+	Enabled     bool      `json:"enabled"`
+
+	// CompoundedRewards is the sum of the rewards restaked up to the block
+	CompoundedRewards argBig `json:"compoundedRewards"`
+}
+
+type stakeEvent struct {
+	BlockNumber  argUint64     `json:"blockNumber"`
+	BlockHash    types.Hash    `json:"blockHash"`
+	TxHash       types.Hash    `json:"transactionHash"`
+	TxIndex      argUint64     `json:"transactionIndex"`
+	LogIndex     argUint64     `json:"logIndex"`
+	Type         string        `json:"type"`
+	Account      types.Address `json:"account"`
+	Amount       argBig        `json:"amount"`
+	ReleaseEpoch *argUint64    `json:"releaseEpoch,omitempty"`
+
+	// Pending is set on the withdrawal requests not withdrawn yet
+	Pending *bool `json:"pending,omitempty"`
+}
+
+// GetStats returns the total stake at the last indexed block and the rewards of the window, the number
+// of the last blocks, with their estimated annual rate. The window defaults to the last epoch
+func (s *Staking) GetStats(window *argUint64) (interface{}, error) {
+	header, err := s.indexHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.store.GetStake(header, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := s.store.EpochSize()
+	if window != nil {
+		blocks = uint64(*window)
+	}
+
+	from := uint64(0)
+	if header.Number > blocks {
+		from = header.Number - blocks
+	}
+
+	first, err := s.statsAt(from)
+	if err != nil {
+		return nil, err
+	}
+
+	last, err := s.statsAt(header.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	rewards := new(big.Int).Sub(last.Rewards, first.Rewards)
+
+	res := &stakingStats{
+		BlockNumber: argUint64(header.Number),
+		Epoch:       argUint64(epochOf(header.Number, s.store.EpochSize())),
+		TotalStaked: argBig(*total),
+		FromBlock:   argUint64(from),
+		Rewards:     argBig(*rewards),
+	}
+
+	if elapsed := last.Timestamp - first.Timestamp; elapsed > 0 && total.Sign() > 0 {
+		rate := new(big.Float).SetInt(rewards)
+		rate.Mul(rate, big.NewFloat(100*secondsPerYear/float64(elapsed)))
+		rate.Quo(rate, new(big.Float).SetInt(total))
+
+		res.RewardRate, _ = rate.Float64()
+	}
+
+	return res, nil
+}
+
+// GetStakeHistory returns the stake of the account at the end of the epochs of the range,
+// which defaults to the last 100 epochs
+func (s *Staking) GetStakeHistory(account types.Address, fromEpoch, toEpoch *argUint64) (interface{}, error) {
+	header, err := s.indexHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	epochSize := s.store.EpochSize()
+
+	to := epochOf(header.Number, epochSize)
+	if toEpoch != nil && uint64(*toEpoch) < to {
+		to = uint64(*toEpoch)
+	}
+
+	from := uint64(0)
+	if to >= defaultStakeHistoryEpochs {
+		from = to - defaultStakeHistoryEpochs + 1
+	}
+
+	if fromEpoch != nil {
+		from = uint64(*fromEpoch)
+	}
+
+	if from > to || to-from >= maxStakeHistoryEpochs {
+		return nil, ErrStakeHistoryRange
+	}
+
+	stake, err := s.store.GetStake(header, &account)
+	if err != nil {
+		return nil, err
+	}
+
+	// the restaked rewards aren't logged in the receipts, they are unwound
+	// from the rewards restaked up to the end of the epochs
+	_, compounded, err := s.store.GetAutoCompounding(header, account)
+	if err != nil {
+		return nil, err
+	}
+
+	// the stakes of the past epochs are unwound from the current stake, so that
+	// the stakes of the genesis and of the blocks not indexed are accounted
+	events, err := s.store.GetStakeEvents(account, from*epochSize+1, header.Number, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*stakeHistoryEntry, to-from+1)
+	next := len(events) - 1
+
+	for epoch := to; ; epoch-- {
+		end := epoch * epochSize
+		if end > header.Number {
+			end = header.Number
+		}
+
+		for ; next >= 0 && events[next].BlockNumber > end; next-- {
+			stake.Sub(stake, events[next].StakeDelta())
+		}
+
+		entryStake := new(big.Int).Set(stake)
+
+		if compounded.Sign() > 0 {
+			restaked, err := s.compoundedSince(end, account, compounded)
+			if err != nil {
+				return nil, err
+			}
+
+			entryStake.Sub(entryStake, restaked)
+		}
+
+		res[epoch-from] = &stakeHistoryEntry{
+			Epoch:       argUint64(epoch),
+			BlockNumber: argUint64(end),
+			Stake:       argBig(*entryStake),
+		}
+
+		if epoch == from {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+// GetAutoCompounding returns whether the rewards of the account are restaked instead of paid out,
+// and the sum of its rewards restaked up to the last indexed block
+func (s *Staking) GetAutoCompounding(account types.Address) (interface{}, error) {
+	header, err := s.indexHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, compounded, err := s.store.GetAutoCompounding(header, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &autoCompounding{
+		BlockNumber:       argUint64(header.Number),
+		Enabled:           enabled,
+		CompoundedRewards: argBig(*compounded),
+	}, nil
+}
+
+// GetStakeEvents returns the staking contract events of the account, the withdrawal
+// requests are flagged as pending until the account withdraws
+func (s *Staking) GetStakeEvents(query *IndexQuery) (interface{}, error) {
+	from, to, limit, err := resolveIndexQuery(s.store, query)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.store.GetStakeEvents(query.Address, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	lastWithdrawal, err := s.lastWithdrawal(query.Address, events)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*stakeEvent, len(events))
+
+	for idx, event := range events {
+		res[idx] = &stakeEvent{
+			BlockNumber: argUint64(event.BlockNumber),
+			BlockHash:   event.BlockHash,
+			TxHash:      event.TxHash,
+			TxIndex:     argUint64(event.TxIndex),
+			LogIndex:    argUint64(event.LogIndex),
+			Type:        string(event.Type),
+			Account:     event.Account,
+			Amount:      indexValue(event.Amount),
+		}
+
+		if event.Type == indexer.StakeEventWithdrawalRequested {
+			pending := lastWithdrawal == nil || isEventBefore(lastWithdrawal, event)
+
+			res[idx].ReleaseEpoch = argUintPtr(event.ReleaseEpoch)
+			res[idx].Pending = &pending
+		}
+	}
+
+	return res, nil
+}
+
+// lastWithdrawal returns the last withdrawal of the account following the first withdrawal request
+// of the events, up to the last indexed block
+func (s *Staking) lastWithdrawal(account types.Address, events []*indexer.StakeEvent) (*indexer.StakeEvent, error) {
+	for _, event := range events {
+		if event.Type != indexer.StakeEventWithdrawalRequested {
+			continue
+		}
+
+		head, err := s.store.Head()
+		if err != nil {
+			return nil, err
+		}
+
+		later, err := s.store.GetStakeEvents(account, event.BlockNumber, head, math.MaxInt32)
+		if err != nil {
+			return nil, err
+		}
+
+		for idx := len(later) - 1; idx >= 0; idx-- {
+			if later[idx].Type == indexer.StakeEventWithdrawn {
+				return later[idx], nil
+			}
+		}
+
+		return nil, nil
+	}
+
+	return nil, nil
+}
+
+// compoundedSince returns the rewards of the account restaked after the block,
+// out of the rewards compounded up to the last indexed block
+func (s *Staking) compoundedSince(number uint64, account types.Address, compounded *big.Int) (*big.Int, error) {
+	header, ok := s.store.GetHeaderByNumber(number)
+	if !ok {
+		return nil, ErrHeaderNotFound
+	}
+
+	_, past, err := s.store.GetAutoCompounding(header, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Sub(compounded, past), nil
+}
+
+// indexHeader returns the header of the last indexed block
+func (s *Staking) indexHeader() (*types.Header, error) {
+	head, err := s.store.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	header, ok := s.store.GetHeaderByNumber(head)
+	if !ok {
+		return nil, ErrHeaderNotFound
+	}
+
+	return header, nil
+}
+
+// statsAt returns the staking stats of the block, which are zero for the blocks
+// without stats such as the genesis
+func (s *Staking) statsAt(number uint64) (*indexer.StakingStats, error) {
+	stats, ok, err := s.store.GetStakingStats(number)
+	if err != nil || ok {
+		return stats, err
+	}
+
+	header, ok := s.store.GetHeaderByNumber(number)
+	if !ok {
+		return nil, ErrHeaderNotFound
+	}
+
+	return &indexer.StakingStats{
+		BlockNumber: number,
+		Timestamp:   header.Timestamp,
+		StakeDelta:  new(big.Int),
+		Rewards:     new(big.Int),
+	}, nil
+}
+
+// epochOf returns the epoch of the block, the last block of an epoch being a multiple of the epoch size
+func epochOf(number, epochSize uint64) uint64 {
+	if number%epochSize == 0 {
+		return number / epochSize
+	}
+
+	return number/epochSize + 1
+}
+
+// isEventBefore returns whether the event a precedes the event b in the chain
+func isEventBefore(a, b *indexer.StakeEvent) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber < b.BlockNumber
+	}
+
+	return a.LogIndex < b.LogIndex
+}
@@ -0,0 +1,240 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/indexer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStakingStore struct {
+	*mockStore
+
+	events map[types.Address][]*indexer.StakeEvent
+	stats  map[uint64]*indexer.StakingStats
+	stakes map[types.Address]*big.Int
+	total  *big.Int
+
+	// compounded holds the rewards restaked up to the blocks, for the accounts with auto-compounding
+	compounded map[types.Address]map[uint64]*big.Int
+}
+
+func newMockStakingStore(blocks int) *mockStakingStore {
+	store := &mockStakingStore{
+		mockStore:  newMockStore(),
+		events:     map[types.Address][]*indexer.StakeEvent{},
+		stats:      map[uint64]*indexer.StakingStats{},
+		stakes:     map[types.Address]*big.Int{},
+		compounded: map[types.Address]map[uint64]*big.Int{},
+	}
+
+	// a block every 10 seconds
+	for number := 1; number <= blocks; number++ {
+		store.header = &types.Header{Number: uint64(number), Timestamp: uint64(number * 10)}
+		store.addHeader(store.header)
+	}
+
+	return store
+}
+
+func (m *mockStakingStore) Head() (uint64, error) {
+	return m.header.Number, nil
+}
+
+func (m *mockStakingStore) GetStakeEvents(
+	account types.Address,
+	from, to uint64,
+	limit int,
+) ([]*indexer.StakeEvent, error) {
+	events := []*indexer.StakeEvent{}
+
+	for _, event := range m.events[account] {
+		if event.BlockNumber >= from && event.BlockNumber <= to && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func (m *mockStakingStore) GetStakingStats(number uint64) (*indexer.StakingStats, bool, error) {
+	stats, ok := m.stats[number]
+
+	return stats, ok, nil
+}
+
+func (m *mockStakingStore) GetStake(header *types.Header, account *types.Address) (*big.Int, error) {
+	if account == nil {
+		return new(big.Int).Set(m.total), nil
+	}
+
+	return new(big.Int).Set(m.stakes[*account]), nil
+}
+
+func (m *mockStakingStore) GetAutoCompounding(header *types.Header, account types.Address) (bool, *big.Int, error) {
+	compounded := new(big.Int)
+
+	for number, rewards := range m.compounded[account] {
+		if number <= header.Number && rewards.Cmp(compounded) > 0 {
+			compounded.Set(rewards)
+		}
+	}
+
+	_, enabled := m.compounded[account]
+
+	return enabled, compounded, nil
+}
+
+func (m *mockStakingStore) EpochSize() uint64 {
+	return 4
+}
+
+func TestStakingEndpoint_GetStats(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStakingStore(10)
+	store.total = big.NewInt(1000)
+	store.stats[6] = &indexer.StakingStats{Timestamp: 60, StakeDelta: new(big.Int), Rewards: big.NewInt(5)}
+	store.stats[10] = &indexer.StakingStats{Timestamp: 100, StakeDelta: new(big.Int), Rewards: big.NewInt(15)}
+
+	endpoint := &Staking{store}
+
+	// the window defaults to the last epoch
+	res, err := endpoint.GetStats(nil)
+	require.NoError(t, err)
+
+	stats, ok := res.(*stakingStats)
+	require.True(t, ok)
+
+	assert.Equal(t, argUint64(10), stats.BlockNumber)
+	assert.Equal(t, argUint64(3), stats.Epoch)
+	assert.Equal(t, argUint64(6), stats.FromBlock)
+	assert.Equal(t, argBig(*big.NewInt(1000)), stats.TotalStaked)
+	assert.Equal(t, argBig(*big.NewInt(10)), stats.Rewards)
+
+	// 10 of rewards over 40 seconds on a stake of 1000
+	assert.InDelta(t, 100*10.0/1000*secondsPerYear/40, stats.RewardRate, 1e-6)
+
+	// the blocks without stats have no rewards
+	window := argUint64(20)
+
+	res, err = endpoint.GetStats(&window)
+	require.NoError(t, err)
+
+	stats, ok = res.(*stakingStats)
+	require.True(t, ok)
+
+	assert.Equal(t, argUint64(0), stats.FromBlock)
+	assert.Equal(t, argBig(*big.NewInt(15)), stats.Rewards)
+}
+
+func TestStakingEndpoint_GetStakeHistory(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStakingStore(10)
+	store.stakes[addr0] = big.NewInt(30)
+	store.events[addr0] = []*indexer.StakeEvent{
+		{BlockNumber: 3, Type: indexer.StakeEventStaked, Amount: big.NewInt(20)},
+		{BlockNumber: 5, Type: indexer.StakeEventUnstaked, Amount: big.NewInt(40)},
+		{BlockNumber: 9, Type: indexer.StakeEventStaked, Amount: big.NewInt(30)},
+		{BlockNumber: 10, Type: indexer.StakeEventWithdrawn, Amount: big.NewInt(40)},
+	}
+
+	endpoint := &Staking{store}
+
+	res, err := endpoint.GetStakeHistory(addr0, nil, nil)
+	require.NoError(t, err)
+
+	history, ok := res.([]*stakeHistoryEntry)
+	require.True(t, ok)
+	require.Len(t, history, 4)
+
+	// the stake of the genesis is unwound from the current stake
+	expected := []struct {
+		block uint64
+		stake int64
+	}{
+		{0, 20},
+		{4, 40},
+		{8, 0},
+		{10, 30},
+	}
+
+	for epoch, entry := range history {
+		assert.Equal(t, argUint64(epoch), entry.Epoch)
+		assert.Equal(t, argUint64(expected[epoch].block), entry.BlockNumber)
+		assert.Equal(t, big.NewInt(expected[epoch].stake).String(), (*big.Int)(&entry.Stake).String())
+	}
+
+	from, to := argUint64(2), argUint64(1)
+
+	_, err = endpoint.GetStakeHistory(addr0, &from, &to)
+	assert.ErrorIs(t, err, ErrStakeHistoryRange)
+}
+
+func TestStakingEndpoint_GetStakeHistory_CompoundedRewards(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStakingStore(10)
+	store.stakes[addr0] = big.NewInt(35)
+	store.events[addr0] = []*indexer.StakeEvent{
+		{BlockNumber: 3, Type: indexer.StakeEventStaked, Amount: big.NewInt(20)},
+	}
+	store.compounded[addr0] = map[uint64]*big.Int{
+		6: big.NewInt(5),
+	}
+
+	endpoint := &Staking{store}
+
+	res, err := endpoint.GetAutoCompounding(addr0)
+	require.NoError(t, err)
+
+	compounding, ok := res.(*autoCompounding)
+	require.True(t, ok)
+
+	assert.Equal(t, argUint64(10), compounding.BlockNumber)
+	assert.True(t, compounding.Enabled)
+	assert.Equal(t, argBig(*big.NewInt(5)), compounding.CompoundedRewards)
+
+	res, err = endpoint.GetStakeHistory(addr0, nil, nil)
+	require.NoError(t, err)
+
+	history, ok := res.([]*stakeHistoryEntry)
+	require.True(t, ok)
+	require.Len(t, history, 4)
+
+	// the restaked rewards aren't staking events, they are unwound from the compounded rewards
+	for epoch, stake := range []int64{10, 30, 35, 35} {
+		assert.Equal(t, big.NewInt(stake).String(), (*big.Int)(&history[epoch].Stake).String())
+	}
+}
+
+func TestStakingEndpoint_GetStakeEvents(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStakingStore(10)
+	store.events[addr0] = []*indexer.StakeEvent{
+		{BlockNumber: 2, Type: indexer.StakeEventWithdrawalRequested, Amount: big.NewInt(10), ReleaseEpoch: 2},
+		{BlockNumber: 6, Type: indexer.StakeEventWithdrawn, Amount: big.NewInt(10)},
+		{BlockNumber: 7, Type: indexer.StakeEventWithdrawalRequested, Amount: big.NewInt(5), ReleaseEpoch: 3},
+	}
+
+	endpoint := &Staking{store}
+
+	res, err := endpoint.GetStakeEvents(&IndexQuery{Address: addr0})
+	require.NoError(t, err)
+
+	events, ok := res.([]*stakeEvent)
+	require.True(t, ok)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, "withdrawalRequested", events[0].Type)
+	assert.Equal(t, argUintPtr(2), events[0].ReleaseEpoch)
+	assert.False(t, *events[0].Pending)
+
+	assert.Nil(t, events[1].Pending)
+	assert.True(t, *events[2].Pending)
+}
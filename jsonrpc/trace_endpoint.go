@@ -0,0 +1,347 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// defaultTraceFilterTimeout is the timeout of the tracing of the blocks of a trace_filter request
+var defaultTraceFilterTimeout = time.Minute
+
+type traceStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// ReadTxLookup returns a block hash in which a given txn was mined
+	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
+
+	// GetBlockByHash gets a block using the provided hash
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+
+	// GetBlockByNumber gets a block using the provided height
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// TraceBlock traces all transactions in the given block
+	TraceBlock(*types.Block, tracer.Tracer) ([]interface{}, error)
+
+	// TraceTxn traces a transaction in the block, associated with the given hash
+	TraceTxn(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
+}
+
+// Trace is the OpenEthereum compatible trace jsonrpc endpoint,
+// returning the calls of the transactions as flat lists
+type Trace struct {
+	store           traceStore
+	blockRangeLimit uint64
+}
+
+// TraceFilter is the query of the traces of a block range
+type TraceFilter struct {
+	FromBlock   *BlockNumber    `json:"fromBlock"`
+	ToBlock     *BlockNumber    `json:"toBlock"`
+	FromAddress []types.Address `json:"fromAddress"`
+	ToAddress   []types.Address `json:"toAddress"`
+
+	// After is the number of the matching traces skipped
+	After uint64 `json:"after"`
+	// Count is the maximum number of the traces returned, all if omitted
+	Count *uint64 `json:"count"`
+}
+
+// match checks whether the trace is a call from and to the addresses of the filter
+func (f *TraceFilter) match(trace *flatTrace) bool {
+	if len(f.FromAddress) > 0 && !containsAddress(f.FromAddress, trace.Action.From) {
+		return false
+	}
+
+	if len(f.ToAddress) == 0 {
+		return true
+	}
+
+	to := trace.Action.To
+	if trace.Result != nil && trace.Result.Address != nil {
+		to = trace.Result.Address
+	}
+
+	return to != nil && containsAddress(f.ToAddress, *to)
+}
+
+type traceAction struct {
+	CallType string         `json:"callType,omitempty"`
+	From     types.Address  `json:"from"`
+	To       *types.Address `json:"to,omitempty"`
+	Gas      argUint64      `json:"gas"`
+	Input    *argBytes      `json:"input,omitempty"`
+	Init     *argBytes      `json:"init,omitempty"`
+	Value    *argBig        `json:"value"`
+}
+
+type traceResult struct {
+	GasUsed argUint64      `json:"gasUsed"`
+	Output  *argBytes      `json:"output,omitempty"`
+	Address *types.Address `json:"address,omitempty"`
+	Code    *argBytes      `json:"code,omitempty"`
+}
+
+// flatTrace is a call of a transaction, located in the call tree by its trace address
+type flatTrace struct {
+	Action              traceAction  `json:"action"`
+	BlockHash           types.Hash   `json:"blockHash"`
+	BlockNumber         uint64       `json:"blockNumber"`
+	Error               string       `json:"error,omitempty"`
+	Result              *traceResult `json:"result"`
+	Subtraces           int          `json:"subtraces"`
+	TraceAddress        []int        `json:"traceAddress"`
+	TransactionHash     types.Hash   `json:"transactionHash"`
+	TransactionPosition int          `json:"transactionPosition"`
+	Type                string       `json:"type"`
+}
+
+// Block returns the traces of the transactions of the block
+func (t *Trace) Block(number BlockNumber) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := t.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	tracer := calltracer.NewCallTracer()
+
+	cancel := cancelOnTimeout(tracer, defaultTraceTimeout)
+	defer cancel()
+
+	return t.traceBlock(block, tracer)
+}
+
+// Transaction returns the traces of the transaction
+func (t *Trace) Transaction(txHash types.Hash) (interface{}, error) {
+	tx, block := GetTxAndBlockByTxHash(txHash, t.store)
+	if tx == nil {
+		return nil, fmt.Errorf("tx %s not found", txHash.String())
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	tracer := calltracer.NewCallTracer()
+
+	cancel := cancelOnTimeout(tracer, defaultTraceTimeout)
+	defer cancel()
+
+	result, err := t.store.TraceTxn(block, tx.Hash, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	position := 0
+
+	for idx, blockTx := range block.Transactions {
+		if blockTx.Hash == tx.Hash {
+			position = idx
+
+			break
+		}
+	}
+
+	return flattenCalls(result, block, position), nil
+}
+
+// Filter returns the traces of the block range matching the filter,
+// the range is limited as for the logs
+func (t *Trace) Filter(filter *TraceFilter) (interface{}, error) {
+	head := t.store.Header().Number
+
+	from, err := resolveTraceBlock(filter.FromBlock, head)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := resolveTraceBlock(filter.ToBlock, head)
+	if err != nil {
+		return nil, err
+	}
+
+	if to < from {
+		return nil, ErrIncorrectBlockRange
+	}
+
+	if t.blockRangeLimit != 0 && to-from > t.blockRangeLimit {
+		return nil, ErrBlockRangeTooHigh
+	}
+
+	traces := []*flatTrace{}
+	if filter.Count != nil && *filter.Count == 0 {
+		return traces, nil
+	}
+
+	tracer := calltracer.NewCallTracer()
+
+	cancel := cancelOnTimeout(tracer, defaultTraceFilterTimeout)
+	defer cancel()
+
+	var skipped uint64
+
+	for num := from; num <= to; num++ {
+		block, ok := t.store.GetBlockByNumber(num, true)
+		if !ok {
+			return nil, fmt.Errorf("block %d not found", num)
+		}
+
+		blockTraces, err := t.traceBlock(block, tracer)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, trace := range blockTraces {
+			if !filter.match(trace) {
+				continue
+			}
+
+			if skipped < filter.After {
+				skipped++
+
+				continue
+			}
+
+			traces = append(traces, trace)
+
+			if filter.Count != nil && uint64(len(traces)) >= *filter.Count {
+				return traces, nil
+			}
+		}
+	}
+
+	return traces, nil
+}
+
+// traceBlock returns the traces of the transactions of the block, the genesis has none
+func (t *Trace) traceBlock(block *types.Block, tracer *calltracer.CallTracer) ([]*flatTrace, error) {
+	traces := []*flatTrace{}
+
+	if block.Number() == 0 || len(block.Transactions) == 0 {
+		return traces, nil
+	}
+
+	results, err := t.store.TraceBlock(block, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, result := range results {
+		traces = append(traces, flattenCalls(result, block, idx)...)
+	}
+
+	return traces, nil
+}
+
+// resolveTraceBlock returns the number of the block of the filter, the latest if omitted
+func resolveTraceBlock(number *BlockNumber, head uint64) (uint64, error) {
+	if number == nil {
+		return head, nil
+	}
+
+	switch *number {
+	case LatestBlockNumber, PendingBlockNumber:
+		return head, nil
+	case EarliestBlockNumber:
+		return 0, nil
+	}
+
+	if *number < 0 {
+		return 0, fmt.Errorf("invalid block number %d", *number)
+	}
+
+	return uint64(*number), nil
+}
+
+// flattenCalls returns the calls of the call tree of the transaction at the position of the block
+// in depth-first order, the root call first
+func flattenCalls(result interface{}, block *types.Block, position int) []*flatTrace {
+	traces := []*flatTrace{}
+
+	root, ok := result.(*calltracer.Call)
+	if !ok || root == nil {
+		return traces
+	}
+
+	tx := block.Transactions[position]
+
+	var walk func(call *calltracer.Call, traceAddress []int)
+
+	walk = func(call *calltracer.Call, traceAddress []int) {
+		trace := &flatTrace{
+			Action: traceAction{
+				From:  call.From,
+				Gas:   argUint64(call.Gas),
+				Value: argBigPtr(call.Value),
+			},
+			BlockHash:           block.Hash(),
+			BlockNumber:         block.Number(),
+			Error:               calltracer.ErrorString(call.Err),
+			Subtraces:           len(call.Calls),
+			TraceAddress:        traceAddress,
+			TransactionHash:     tx.Hash,
+			TransactionPosition: position,
+		}
+
+		if call.IsCreate() {
+			trace.Type = calltracer.CallTypeCreate
+			trace.Action.Init = argBytesPtr(call.Input)
+		} else {
+			to := call.To
+
+			trace.Type = calltracer.CallTypeCall
+			trace.Action.CallType = call.Type
+			trace.Action.To = &to
+			trace.Action.Input = argBytesPtr(call.Input)
+		}
+
+		// the failed calls have no result
+		if call.Err == nil {
+			trace.Result = &traceResult{
+				GasUsed: argUint64(call.GasUsed),
+			}
+
+			if call.IsCreate() {
+				address := call.To
+
+				trace.Result.Address = &address
+				trace.Result.Code = argBytesPtr(call.Output)
+			} else {
+				trace.Result.Output = argBytesPtr(call.Output)
+			}
+		}
+
+		traces = append(traces, trace)
+
+		for idx, sub := range call.Calls {
+			address := make([]int, len(traceAddress), len(traceAddress)+1)
+			copy(address, traceAddress)
+
+			walk(sub, append(address, idx))
+		}
+	}
+
+	walk(root, []int{})
+
+	return traces
+}
+
+func containsAddress(addresses []types.Address, addr types.Address) bool {
+	for _, a := range addresses {
+		if a == addr {
+			return true
+		}
+	}
+
+	return false
+}
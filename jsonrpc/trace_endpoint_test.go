@@ -0,0 +1,236 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	traceSender   = types.StringToAddress("1")
+	traceContract = types.StringToAddress("2")
+	traceCallee   = types.StringToAddress("3")
+	traceCreated  = types.StringToAddress("4")
+)
+
+// runTraceCalls runs on the tracer a call to the contract, which calls the callee,
+// creates a contract and fails a static call
+func runTraceCalls(tr tracer.Tracer) (interface{}, error) {
+	tr.Clear()
+	tr.TxStart(100000)
+	tr.CallStart(1, traceSender, traceContract, int(runtime.Call), 50000, big.NewInt(5), []byte{0x1})
+	tr.CallStart(2, traceContract, traceCallee, int(runtime.DelegateCall), 10000, nil, []byte{0x2})
+	tr.CallEnd(2, []byte{0x3}, 100, nil)
+	tr.CallStart(2, traceContract, traceCreated, int(evm.CREATE), 20000, big.NewInt(1), []byte{0x4})
+	tr.CallEnd(2, []byte{0x5}, 200, nil)
+	tr.CallStart(2, traceContract, traceCallee, int(runtime.StaticCall), 1000, nil, nil)
+	tr.CallEnd(2, nil, 1000, runtime.ErrExecutionReverted)
+	tr.CallEnd(1, []byte{0x6}, 3000, nil)
+	tr.TxEnd(40000)
+
+	return tr.GetResult()
+}
+
+func newTraceBlock(num uint64, txs int) *types.Block {
+	block := &types.Block{
+		Header: &types.Header{
+			Number: num,
+			Hash:   types.StringToHash(big.NewInt(int64(num + 0x10)).Text(16)),
+		},
+	}
+
+	for i := 0; i < txs; i++ {
+		block.Transactions = append(block.Transactions, &types.Transaction{
+			Hash: types.StringToHash(big.NewInt(int64(num*0x100 + uint64(i) + 1)).Text(16)),
+		})
+	}
+
+	return block
+}
+
+func newTraceEndpoint(blocks map[uint64]*types.Block, blockRangeLimit uint64) *Trace {
+	return &Trace{
+		store: &debugEndpointMockStore{
+			headerFn: func() *types.Header {
+				return blocks[uint64(len(blocks)-1)].Header
+			},
+			getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+				block, ok := blocks[num]
+
+				return block, ok
+			},
+			traceBlockFn: func(block *types.Block, tr tracer.Tracer) ([]interface{}, error) {
+				results := make([]interface{}, len(block.Transactions))
+
+				for idx := range block.Transactions {
+					result, err := runTraceCalls(tr)
+					if err != nil {
+						return nil, err
+					}
+
+					results[idx] = result
+				}
+
+				return results, nil
+			},
+		},
+		blockRangeLimit: blockRangeLimit,
+	}
+}
+
+func TestTraceBlock_Flatten(t *testing.T) {
+	t.Parallel()
+
+	block := newTraceBlock(1, 1)
+	endpoint := newTraceEndpoint(map[uint64]*types.Block{
+		0: newTraceBlock(0, 0),
+		1: block,
+	}, 0)
+
+	res, err := endpoint.Block(BlockNumber(1))
+	require.NoError(t, err)
+
+	traces, ok := res.([]*flatTrace)
+	require.True(t, ok)
+	require.Len(t, traces, 4)
+
+	for _, trace := range traces {
+		assert.Equal(t, block.Hash(), trace.BlockHash)
+		assert.Equal(t, uint64(1), trace.BlockNumber)
+		assert.Equal(t, block.Transactions[0].Hash, trace.TransactionHash)
+		assert.Equal(t, 0, trace.TransactionPosition)
+	}
+
+	root := traces[0]
+	assert.Equal(t, "call", root.Type)
+	assert.Equal(t, "call", root.Action.CallType)
+	assert.Equal(t, traceSender, root.Action.From)
+	assert.Equal(t, traceContract, *root.Action.To)
+	assert.Equal(t, argUint64(50000), root.Action.Gas)
+	assert.Equal(t, argUint64(3000), root.Result.GasUsed)
+	assert.Equal(t, argBytes{0x6}, *root.Result.Output)
+	assert.Equal(t, 3, root.Subtraces)
+	assert.Equal(t, []int{}, root.TraceAddress)
+
+	delegate := traces[1]
+	assert.Equal(t, "delegatecall", delegate.Action.CallType)
+	assert.Equal(t, []int{0}, delegate.TraceAddress)
+	assert.Equal(t, 0, delegate.Subtraces)
+
+	create := traces[2]
+	assert.Equal(t, "create", create.Type)
+	assert.Empty(t, create.Action.CallType)
+	assert.Nil(t, create.Action.To)
+	assert.Equal(t, argBytes{0x4}, *create.Action.Init)
+	assert.Equal(t, traceCreated, *create.Result.Address)
+	assert.Equal(t, argBytes{0x5}, *create.Result.Code)
+	assert.Equal(t, []int{1}, create.TraceAddress)
+
+	failed := traces[3]
+	assert.Equal(t, "staticcall", failed.Action.CallType)
+	assert.Equal(t, "Reverted", failed.Error)
+	assert.Nil(t, failed.Result)
+	assert.Equal(t, []int{2}, failed.TraceAddress)
+
+	// the genesis has no traces
+	res, err = endpoint.Block(BlockNumber(0))
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestTraceFilter(t *testing.T) {
+	t.Parallel()
+
+	blocks := map[uint64]*types.Block{
+		0: newTraceBlock(0, 0),
+		1: newTraceBlock(1, 1),
+		2: newTraceBlock(2, 2),
+		3: newTraceBlock(3, 1),
+	}
+
+	blockNumber := func(num BlockNumber) *BlockNumber {
+		return &num
+	}
+
+	count := func(n uint64) *uint64 {
+		return &n
+	}
+
+	t.Run("should return the traces of the range", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := newTraceEndpoint(blocks, 0).Filter(&TraceFilter{
+			FromBlock: blockNumber(1),
+			ToBlock:   blockNumber(2),
+		})
+		require.NoError(t, err)
+
+		traces, ok := res.([]*flatTrace)
+		require.True(t, ok)
+		require.Len(t, traces, 12)
+
+		assert.Equal(t, uint64(2), traces[11].BlockNumber)
+		assert.Equal(t, 1, traces[11].TransactionPosition)
+	})
+
+	t.Run("should match the addresses", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := newTraceEndpoint(blocks, 0).Filter(&TraceFilter{
+			FromAddress: []types.Address{traceContract},
+			ToAddress:   []types.Address{traceCallee, traceCreated},
+		})
+		require.NoError(t, err)
+
+		// the latest block only
+		traces, ok := res.([]*flatTrace)
+		require.True(t, ok)
+		require.Len(t, traces, 3)
+
+		assert.Equal(t, uint64(3), traces[0].BlockNumber)
+		assert.Equal(t, []int{1}, traces[1].TraceAddress)
+	})
+
+	t.Run("should paginate the traces", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := newTraceEndpoint(blocks, 0).Filter(&TraceFilter{
+			FromBlock:   blockNumber(EarliestBlockNumber),
+			FromAddress: []types.Address{traceSender},
+			After:       1,
+			Count:       count(2),
+		})
+		require.NoError(t, err)
+
+		traces, ok := res.([]*flatTrace)
+		require.True(t, ok)
+		require.Len(t, traces, 2)
+
+		assert.Equal(t, blocks[2].Transactions[0].Hash, traces[0].TransactionHash)
+		assert.Equal(t, blocks[2].Transactions[1].Hash, traces[1].TransactionHash)
+	})
+
+	t.Run("should limit the range", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := newTraceEndpoint(blocks, 1)
+
+		_, err := endpoint.Filter(&TraceFilter{
+			FromBlock: blockNumber(1),
+			ToBlock:   blockNumber(3),
+		})
+		assert.ErrorIs(t, err, ErrBlockRangeTooHigh)
+
+		_, err = endpoint.Filter(&TraceFilter{
+			FromBlock: blockNumber(3),
+			ToBlock:   blockNumber(2),
+		})
+		assert.ErrorIs(t, err, ErrIncorrectBlockRange)
+	})
+}
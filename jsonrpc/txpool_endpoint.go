@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -14,6 +15,19 @@ type txPoolStore interface {
 
 	// GetCapacity returns the current and max capacity of the pool in slots
 	GetCapacity() (uint64, uint64)
+
+	// GetQuarantinedTxs returns the transactions excluded from the block building, the most recent first
+	GetQuarantinedTxs() []*QuarantinedTx
+}
+
+// QuarantinedTx is a transaction the pool excluded from the block building,
+// such as a transaction whose execution exceeded the time budget
+type QuarantinedTx struct {
+	Hash   types.Hash
+	From   types.Address
+	Nonce  uint64
+	Reason string
+	Time   time.Time
 }
 
 // TxPool is the txpool jsonrpc endpoint
@@ -38,6 +52,14 @@ type StatusResponse struct {
 	Queued  uint64 `json:"queued"`
 }
 
+type quarantinedTransaction struct {
+	Hash      types.Hash    `json:"hash"`
+	From      types.Address `json:"from"`
+	Nonce     argUint64     `json:"nonce"`
+	Reason    string        `json:"reason"`
+	Timestamp argUint64     `json:"timestamp"`
+}
+
 type txpoolTransaction struct {
 	Nonce       argUint64      `json:"nonce"`
 	GasPrice    argBig         `json:"gasPrice"`
@@ -175,3 +197,22 @@ func (t *TxPool) Status() (interface{}, error) {
 
 	return resp, nil
 }
+
+// Quarantine returns the transactions the pool excluded from the block building,
+// with the reason of their exclusion
+func (t *TxPool) Quarantine() (interface{}, error) {
+	txs := t.store.GetQuarantinedTxs()
+
+	resp := make([]*quarantinedTransaction, len(txs))
+	for i, tx := range txs {
+		resp[i] = &quarantinedTransaction{
+			Hash:      tx.Hash,
+			From:      tx.From,
+			Nonce:     argUint64(tx.Nonce),
+			Reason:    tx.Reason,
+			Timestamp: argUint64(tx.Time.Unix()),
+		}
+	}
+
+	return resp, nil
+}
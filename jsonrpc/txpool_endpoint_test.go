@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/types"
 
@@ -228,9 +229,42 @@ func TestStatusEndpoint(t *testing.T) {
 	})
 }
 
+func TestQuarantineEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+	mockStore.quarantined = []*QuarantinedTx{
+		{
+			Hash:   types.StringToHash("0x1"),
+			From:   types.Address{0x1},
+			Nonce:  3,
+			Reason: "transaction execution exceeded the time budget",
+			Time:   time.Unix(1000, 0),
+		},
+	}
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, err := txPoolEndpoint.Quarantine()
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	response := result.([]*quarantinedTransaction)
+
+	assert.Equal(t, []*quarantinedTransaction{
+		{
+			Hash:      types.StringToHash("0x1"),
+			From:      types.Address{0x1},
+			Nonce:     3,
+			Reason:    "transaction execution exceeded the time budget",
+			Timestamp: 1000,
+		},
+	}, response)
+}
+
 type mockTxPoolStore struct {
 	pending       map[types.Address][]*types.Transaction
 	queued        map[types.Address][]*types.Transaction
+	quarantined   []*QuarantinedTx
 	capacity      uint64
 	maxSlots      uint64
 	includeQueued bool
@@ -253,6 +287,10 @@ func (s *mockTxPoolStore) GetCapacity() (uint64, uint64) {
 	return s.capacity, s.maxSlots
 }
 
+func (s *mockTxPoolStore) GetQuarantinedTxs() []*QuarantinedTx {
+	return s.quarantined
+}
+
 func newTestTransaction(nonce uint64, from types.Address) *types.Transaction {
 	txn := &types.Transaction{
 		Nonce:    nonce,
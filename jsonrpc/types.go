@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
 )
 
 // For union type of transaction and types.Hash
@@ -26,9 +28,25 @@ type transaction struct {
 	S           argBig         `json:"s"`
 	Hash        types.Hash     `json:"hash"`
 	From        types.Address  `json:"from"`
+	Sponsor     *types.Address `json:"sponsor,omitempty"`
+	ValidUntil  *argUint64     `json:"validUntil,omitempty"`
 	BlockHash   *types.Hash    `json:"blockHash"`
 	BlockNumber *argUint64     `json:"blockNumber"`
 	TxIndex     *argUint64     `json:"transactionIndex"`
+
+	// the fields of the typed transactions
+	Type                 *argUint64     `json:"type,omitempty"`
+	ChainID              *argBig        `json:"chainId,omitempty"`
+	MaxPriorityFeePerGas *argBig        `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         *argBig        `json:"maxFeePerGas,omitempty"`
+	AccessList           []*accessTuple `json:"accessList,omitempty"`
+	MaxFeePerBlobGas     *argBig        `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes  []types.Hash   `json:"blobVersionedHashes,omitempty"`
+}
+
+type accessTuple struct {
+	Address     types.Address `json:"address"`
+	StorageKeys []types.Hash  `json:"storageKeys"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -66,6 +84,29 @@ func toTransaction(
 		From:     t.From,
 	}
 
+	if t.IsSponsored() {
+		sponsor := t.Sponsor
+		res.Sponsor = &sponsor
+	}
+
+	if t.ValidUntil != 0 {
+		res.ValidUntil = argUintPtr(t.ValidUntil)
+	}
+
+	if t.Type == types.BlobTx {
+		res.Type = argUintPtr(uint64(t.Type))
+		res.ChainID = argBigPtr(t.ChainID)
+		res.MaxPriorityFeePerGas = argBigPtr(t.GasTipCap)
+		res.MaxFeePerGas = argBigPtr(t.GasPrice)
+		res.MaxFeePerBlobGas = argBigPtr(t.BlobFeeCap)
+		res.BlobVersionedHashes = t.BlobHashes
+		res.AccessList = make([]*accessTuple, len(t.AccessList))
+
+		for i, tuple := range t.AccessList {
+			res.AccessList[i] = &accessTuple{Address: tuple.Address, StorageKeys: tuple.StorageKeys}
+		}
+	}
+
 	if blockNumber != nil {
 		res.BlockNumber = blockNumber
 	}
@@ -102,6 +143,8 @@ type block struct {
 	Hash            types.Hash          `json:"hash"`
 	Transactions    []transactionOrHash `json:"transactions"`
 	Uncles          []types.Hash        `json:"uncles"`
+	BlobGasUsed     *argUint64          `json:"blobGasUsed,omitempty"`
+	ExcessBlobGas   *argUint64          `json:"excessBlobGas,omitempty"`
 }
 
 func (b *block) Copy() *block {
@@ -142,6 +185,11 @@ func toBlock(b *types.Block, fullTx bool) *block {
 		Uncles:          []types.Hash{},
 	}
 
+	if h.BlobGasUsed != nil && h.ExcessBlobGas != nil {
+		res.BlobGasUsed = argUintPtr(*h.BlobGasUsed)
+		res.ExcessBlobGas = argUintPtr(*h.ExcessBlobGas)
+	}
+
 	for idx, txn := range b.Transactions {
 		if fullTx {
 			res.Transactions = append(
@@ -182,6 +230,7 @@ type receipt struct {
 	ContractAddress   *types.Address `json:"contractAddress"`
 	FromAddr          types.Address  `json:"from"`
 	ToAddr            *types.Address `json:"to"`
+	RevertReason      *string        `json:"revertReason,omitempty"`
 }
 
 type Log struct {
@@ -196,6 +245,332 @@ type Log struct {
 	Removed     bool          `json:"removed"`
 }
 
+// callResult is the result of a call of eth_callMany
+type callResult struct {
+	Value *argBytes `json:"value,omitempty"`
+	Error string    `json:"error,omitempty"`
+
+	// Data is the revert data of a reverted call
+	Data *argBytes `json:"data,omitempty"`
+}
+
+// accountProof is the eth_getProof result as defined in EIP-1186
+type accountProof struct {
+	Address      types.Address   `json:"address"`
+	AccountProof []argBytes      `json:"accountProof"`
+	Balance      argBig          `json:"balance"`
+	CodeHash     types.Hash      `json:"codeHash"`
+	Nonce        argUint64       `json:"nonce"`
+	StorageHash  types.Hash      `json:"storageHash"`
+	StorageProof []*storageProof `json:"storageProof"`
+}
+
+type storageProof struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+func toArgBytesList(list [][]byte) []argBytes {
+	res := make([]argBytes, len(list))
+	for i, b := range list {
+		res[i] = argBytes(b)
+	}
+
+	return res
+}
+
+func toAccountProof(address types.Address, proof *AccountProof) *accountProof {
+	res := &accountProof{
+		Address:      address,
+		AccountProof: toArgBytesList(proof.Proof),
+		Balance:      argBig(*proof.Account.Balance),
+		CodeHash:     proof.CodeHash,
+		Nonce:        argUint64(proof.Account.Nonce),
+		StorageHash:  proof.StorageRoot,
+		StorageProof: make([]*storageProof, len(proof.StorageProof)),
+	}
+
+	for i, slot := range proof.StorageProof {
+		res.StorageProof[i] = &storageProof{
+			Key:   slot.Key,
+			Value: argBig(*new(big.Int).SetBytes(slot.Value.Bytes())),
+			Proof: toArgBytesList(slot.Proof),
+		}
+	}
+
+	return res
+}
+
+// stateDiff is the debug_getStateDiff result
+type stateDiff struct {
+	Accounts []*accountDiff `json:"accounts"`
+}
+
+// accountDiff is the change of an account, a deleted account has all of its storage cleared
+type accountDiff struct {
+	Address  types.Address  `json:"address"`
+	Created  bool           `json:"created"`
+	Deleted  bool           `json:"deleted"`
+	Nonce    uint64Change   `json:"nonce"`
+	Balance  bigChange      `json:"balance"`
+	CodeHash hashChange     `json:"codeHash"`
+	Storage  []*storageDiff `json:"storage"`
+}
+
+type storageDiff struct {
+	Key types.Hash `json:"key"`
+	hashChange
+}
+
+type uint64Change struct {
+	From argUint64 `json:"from"`
+	To   argUint64 `json:"to"`
+}
+
+type bigChange struct {
+	From argBig `json:"from"`
+	To   argBig `json:"to"`
+}
+
+type hashChange struct {
+	From types.Hash `json:"from"`
+	To   types.Hash `json:"to"`
+}
+
+func toStateDiff(diff *types.StateDiff) *stateDiff {
+	res := &stateDiff{
+		Accounts: make([]*accountDiff, len(diff.Accounts)),
+	}
+
+	for i, account := range diff.Accounts {
+		res.Accounts[i] = &accountDiff{
+			Address:  account.Address,
+			Created:  account.Created,
+			Deleted:  account.Deleted,
+			Nonce:    uint64Change{From: argUint64(account.PrevNonce), To: argUint64(account.Nonce)},
+			Balance:  bigChange{From: argBig(*account.PrevBalance), To: argBig(*account.Balance)},
+			CodeHash: hashChange{From: account.PrevCodeHash, To: account.CodeHash},
+			Storage:  make([]*storageDiff, len(account.Storage)),
+		}
+
+		for j, slot := range account.Storage {
+			res.Accounts[i].Storage[j] = &storageDiff{
+				Key:        slot.Key,
+				hashChange: hashChange{From: slot.PrevValue, To: slot.Value},
+			}
+		}
+	}
+
+	return res
+}
+
+// conflictStats is the debug_getConflictStats result
+type conflictStats struct {
+	BlockNumber             argUint64            `json:"blockNumber"`
+	BlockHash               types.Hash           `json:"blockHash"`
+	Transactions            int                  `json:"transactions"`
+	ConflictingTransactions int                  `json:"conflictingTransactions"`
+	ConflictRate            float64              `json:"conflictRate"`
+	CriticalPath            int                  `json:"criticalPath"`
+	Parallelism             float64              `json:"parallelism"`
+	Txs                     []*txConflicts       `json:"txs"`
+	HotLocations            []*locationConflicts `json:"hotLocations"`
+}
+
+type txConflicts struct {
+	TxHash       types.Hash `json:"txHash"`
+	Reads        int        `json:"reads"`
+	Writes       int        `json:"writes"`
+	Dependencies []int      `json:"dependencies"`
+}
+
+type locationConflicts struct {
+	Address   types.Address `json:"address"`
+	Slot      *types.Hash   `json:"slot,omitempty"`
+	Conflicts int           `json:"conflicts"`
+}
+
+func toConflictStats(block *types.Block, stats *state.ConflictStats) *conflictStats {
+	res := &conflictStats{
+		BlockNumber:             argUint64(block.Number()),
+		BlockHash:               block.Hash(),
+		Transactions:            stats.Transactions,
+		ConflictingTransactions: stats.ConflictingTransactions,
+		ConflictRate:            stats.ConflictRate,
+		CriticalPath:            stats.CriticalPath,
+		Parallelism:             stats.Parallelism,
+		Txs:                     make([]*txConflicts, len(stats.Txs)),
+		HotLocations:            make([]*locationConflicts, len(stats.HotLocations)),
+	}
+
+	for i, tx := range stats.Txs {
+		res.Txs[i] = &txConflicts{
+			TxHash:       block.Transactions[i].Hash,
+			Reads:        tx.Reads,
+			Writes:       tx.Writes,
+			Dependencies: tx.Dependencies,
+		}
+
+		if res.Txs[i].Dependencies == nil {
+			res.Txs[i].Dependencies = []int{}
+		}
+	}
+
+	for i, hot := range stats.HotLocations {
+		res.HotLocations[i] = &locationConflicts{
+			Address:   hot.Address,
+			Slot:      hot.Slot,
+			Conflicts: hot.Conflicts,
+		}
+	}
+
+	return res
+}
+
+// blockWitness is the debug_getWitness result
+type blockWitness struct {
+	BlockNumber argUint64  `json:"blockNumber"`
+	BlockHash   types.Hash `json:"blockHash"`
+	Nodes       []argBytes `json:"nodes"`
+	Codes       []argBytes `json:"codes"`
+}
+
+func toWitness(block *types.Block, witness *state.Witness) *blockWitness {
+	res := &blockWitness{
+		BlockNumber: argUint64(block.Number()),
+		BlockHash:   block.Hash(),
+		Nodes:       make([]argBytes, len(witness.Nodes)),
+		Codes:       make([]argBytes, len(witness.Codes)),
+	}
+
+	for i, node := range witness.Nodes {
+		res.Nodes[i] = node
+	}
+
+	for i, code := range witness.Codes {
+		res.Codes[i] = code
+	}
+
+	return res
+}
+
+// blockReplay is the debug_replayBlock result, the values of the block stored by the node
+// along with the ones of its re-execution
+type blockReplay struct {
+	BlockNumber  argUint64      `json:"blockNumber"`
+	BlockHash    types.Hash     `json:"blockHash"`
+	Valid        bool           `json:"valid"`
+	StateRoot    replayedHash   `json:"stateRoot"`
+	ReceiptsRoot replayedHash   `json:"receiptsRoot"`
+	GasUsed      replayedUint64 `json:"gasUsed"`
+	Txs          []*txReplay    `json:"txs"`
+	StateDiff    *stateDiff     `json:"stateDiff"`
+	// StateMismatches are the differences with the stored state diff, nil if it is not persisted
+	StateMismatches []*stateMismatch `json:"stateMismatches"`
+}
+
+type replayedHash struct {
+	Stored   types.Hash `json:"stored"`
+	Replayed types.Hash `json:"replayed"`
+}
+
+type replayedUint64 struct {
+	Stored   argUint64 `json:"stored"`
+	Replayed argUint64 `json:"replayed"`
+}
+
+type txReplay struct {
+	TxHash            types.Hash     `json:"txHash"`
+	Status            replayedUint64 `json:"status"`
+	CumulativeGasUsed replayedUint64 `json:"cumulativeGasUsed"`
+	Trace             interface{}    `json:"trace,omitempty"`
+}
+
+type stateMismatch struct {
+	Address  types.Address `json:"address"`
+	Field    string        `json:"field"`
+	Key      *types.Hash   `json:"key,omitempty"`
+	Stored   string        `json:"stored"`
+	Replayed string        `json:"replayed"`
+}
+
+func toBlockReplay(
+	block *types.Block,
+	receipts []*types.Receipt,
+	storedDiff *types.StateDiff,
+	replay *state.Replay,
+) *blockReplay {
+	res := &blockReplay{
+		BlockNumber: argUint64(block.Number()),
+		BlockHash:   block.Hash(),
+		StateRoot: replayedHash{
+			Stored:   block.Header.StateRoot,
+			Replayed: replay.StateRoot,
+		},
+		ReceiptsRoot: replayedHash{
+			Stored:   block.Header.ReceiptsRoot,
+			Replayed: buildroot.CalculateReceiptsRoot(replay.Receipts),
+		},
+		GasUsed: replayedUint64{
+			Stored:   argUint64(block.Header.GasUsed),
+			Replayed: argUint64(replay.GasUsed),
+		},
+		Txs:       make([]*txReplay, len(block.Transactions)),
+		StateDiff: toStateDiff(replay.StateDiff),
+	}
+
+	res.Valid = res.StateRoot.Stored == res.StateRoot.Replayed &&
+		res.ReceiptsRoot.Stored == res.ReceiptsRoot.Replayed &&
+		res.GasUsed.Stored == res.GasUsed.Replayed
+
+	for i, tx := range block.Transactions {
+		txRes := &txReplay{
+			TxHash: tx.Hash,
+			Trace:  replay.Traces[i],
+		}
+
+		if i < len(receipts) {
+			txRes.Status.Stored = receiptStatus(receipts[i])
+			txRes.CumulativeGasUsed.Stored = argUint64(receipts[i].CumulativeGasUsed)
+		}
+
+		if i < len(replay.Receipts) {
+			txRes.Status.Replayed = receiptStatus(replay.Receipts[i])
+			txRes.CumulativeGasUsed.Replayed = argUint64(replay.Receipts[i].CumulativeGasUsed)
+		}
+
+		res.Txs[i] = txRes
+	}
+
+	if storedDiff != nil {
+		mismatches := state.CompareStateDiffs(storedDiff, replay.StateDiff)
+
+		res.StateMismatches = make([]*stateMismatch, len(mismatches))
+		for i, mismatch := range mismatches {
+			res.StateMismatches[i] = &stateMismatch{
+				Address:  mismatch.Address,
+				Field:    mismatch.Field,
+				Key:      mismatch.Key,
+				Stored:   mismatch.Stored,
+				Replayed: mismatch.Replayed,
+			}
+		}
+
+		res.Valid = res.Valid && len(mismatches) == 0
+	}
+
+	return res
+}
+
+func receiptStatus(receipt *types.Receipt) argUint64 {
+	if receipt.Status == nil {
+		return 0
+	}
+
+	return argUint64(*receipt.Status)
+}
+
 type argBig big.Int
 
 func argBigPtr(b *big.Int) *argBig {
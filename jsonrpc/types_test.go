@@ -249,4 +249,16 @@ func TestTransaction_Encoding(t *testing.T) {
 
 		testTransaction("testsuite/transaction-pending.json")
 	})
+
+	t.Run("blob", func(t *testing.T) {
+		tt.Type = argUintPtr(uint64(types.BlobTx))
+		tt.ChainID = argBigPtr(big.NewInt(100))
+		tt.MaxPriorityFeePerGas = argBigPtr(big.NewInt(5))
+		tt.MaxFeePerGas = argBigPtr(big.NewInt(10))
+		tt.AccessList = []*accessTuple{}
+		tt.MaxFeePerBlobGas = argBigPtr(big.NewInt(1))
+		tt.BlobVersionedHashes = []types.Hash{{0x1}}
+
+		testTransaction("testsuite/transaction-blob.json")
+	})
 }
@@ -0,0 +1,339 @@
+package lightclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/lightclient/proto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/umbracle/fastrlp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// verifiedHeadersCacheSize is the number of the latest verified headers
+	// the client keeps to verify the proofs against
+	verifiedHeadersCacheSize = 1024
+)
+
+// HeaderVerifier verifies the header is sealed by the validator set of its height.
+// The verification is consensus specific and is provided by the embedder
+type HeaderVerifier interface {
+	VerifyHeader(header *types.Header, validators []*Validator) error
+}
+
+// Account is an account verified against the state root of a verified header
+type Account struct {
+	Nonce       uint64
+	Balance     *big.Int
+	CodeHash    types.Hash
+	StorageRoot types.Hash
+	Storage     map[types.Hash]types.Hash
+}
+
+// Client is a light client that follows the chain by verifying the headers
+// against the tracked validator set, and verifies the state and receipts
+// served by a light client server against them.
+//
+// The header hash is computed with types.HeaderHash, which must be set
+// to the hash function of the consensus of the chain
+type Client struct {
+	client   proto.LightClientClient
+	verifier HeaderVerifier
+
+	lock       sync.RWMutex
+	header     *types.Header
+	headers    *lru.Cache
+	validators []*Validator
+}
+
+// NewClient creates a light client that trusts the given header and its validator set
+func NewClient(
+	conn grpc.ClientConnInterface,
+	verifier HeaderVerifier,
+	trusted *types.Header,
+	validators []*Validator,
+) (*Client, error) {
+	headers, err := lru.New(verifiedHeadersCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	headers.Add(trusted.Number, trusted)
+
+	return &Client{
+		client:     proto.NewLightClientClient(conn),
+		verifier:   verifier,
+		header:     trusted,
+		headers:    headers,
+		validators: append([]*Validator{}, validators...),
+	}, nil
+}
+
+// Header returns the latest verified header
+func (c *Client) Header() *types.Header {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.header
+}
+
+// Validators returns the validator set of the latest verified header
+func (c *Client) Validators() []*Validator {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return append([]*Validator{}, c.validators...)
+}
+
+// GetHeader returns the verified header by number, if it's still cached
+func (c *Client) GetHeader(number uint64) (*types.Header, bool) {
+	header, ok := c.headers.Get(number)
+	if !ok {
+		return nil, false
+	}
+
+	h, ok := header.(*types.Header)
+
+	return h, ok
+}
+
+// Sync fetches and verifies the headers up to the latest header of the server
+// and returns the number of the newly verified headers
+func (c *Client) Sync(ctx context.Context) (uint64, error) {
+	status, err := c.client.GetStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, err
+	}
+
+	latest := c.Header()
+	if status.Number <= latest.Number {
+		return 0, nil
+	}
+
+	stream, err := c.client.GetHeaders(ctx, &proto.GetHeadersRequest{
+		From: latest.Number + 1,
+		To:   status.Number,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var synced uint64
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return synced, nil
+		}
+
+		if err != nil {
+			return synced, err
+		}
+
+		if err := c.processHeader(resp); err != nil {
+			return synced, err
+		}
+
+		synced++
+	}
+}
+
+// processHeader verifies the header extends the latest verified header
+// and is sealed by the validator set of its height
+func (c *Client) processHeader(resp *proto.SignedHeader) error {
+	header := &types.Header{}
+	if err := header.UnmarshalRLP(resp.Header); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if header.Number != c.header.Number+1 || header.ParentHash != c.header.Hash {
+		return fmt.Errorf("%w: expected child of %d (%s), got %d", ErrUnexpectedHeader,
+			c.header.Number, c.header.Hash, header.Number)
+	}
+
+	validators := applyValidatorsDiff(c.validators, resp.AddedValidators, resp.RemovedValidators)
+
+	if err := c.verifier.VerifyHeader(header, validators); err != nil {
+		return fmt.Errorf("failed to verify header %d: %w", header.Number, err)
+	}
+
+	c.header = header
+	c.validators = validators
+	c.headers.Add(header.Number, header)
+
+	return nil
+}
+
+// GetAccount fetches the account and its storage slots at the given height
+// and verifies them against the state root of the verified header
+func (c *Client) GetAccount(
+	ctx context.Context,
+	number uint64,
+	addr types.Address,
+	keys []types.Hash,
+) (*Account, error) {
+	header, ok := c.GetHeader(number)
+	if !ok {
+		return nil, ErrHeaderNotVerified
+	}
+
+	req := &proto.AccountProofRequest{
+		Number:      number,
+		Address:     addr.Bytes(),
+		StorageKeys: make([][]byte, len(keys)),
+	}
+
+	for i, key := range keys {
+		req.StorageKeys[i] = key.Bytes()
+	}
+
+	resp, err := c.client.GetAccountProof(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := itrie.VerifyProof(header.StateRoot, crypto.Keccak256(addr.Bytes()), resp.Proof)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &Account{
+		Balance:     big.NewInt(0),
+		CodeHash:    types.BytesToHash(crypto.Keccak256(nil)),
+		StorageRoot: types.EmptyRootHash,
+		Storage:     make(map[types.Hash]types.Hash, len(keys)),
+	}
+
+	if value != nil {
+		var stateAccount state.Account
+		if err := stateAccount.UnmarshalRlp(value); err != nil {
+			return nil, err
+		}
+
+		account.Nonce = stateAccount.Nonce
+		account.Balance = stateAccount.Balance
+		account.CodeHash = types.BytesToHash(stateAccount.CodeHash)
+		account.StorageRoot = stateAccount.Root
+	}
+
+	proofs := make(map[types.Hash][][]byte, len(resp.StorageProofs))
+	for _, storageProof := range resp.StorageProofs {
+		proofs[types.BytesToHash(storageProof.Key)] = storageProof.Proof
+	}
+
+	for _, key := range keys {
+		proof, ok := proofs[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrStorageProofMissing, key)
+		}
+
+		value, err := itrie.VerifyProof(account.StorageRoot, crypto.Keccak256(key.Bytes()), proof)
+		if err != nil {
+			return nil, err
+		}
+
+		if account.Storage[key], err = decodeStorageValue(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return account, nil
+}
+
+// GetReceipt fetches the receipt of the block at the given height
+// and verifies it against the receipts root of the verified header
+func (c *Client) GetReceipt(ctx context.Context, number, index uint64) (*types.Receipt, error) {
+	header, ok := c.GetHeader(number)
+	if !ok {
+		return nil, ErrHeaderNotVerified
+	}
+
+	resp, err := c.client.GetReceiptProof(ctx, &proto.ReceiptProofRequest{
+		Number: number,
+		Index:  index,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key := (&fastrlp.Arena{}).NewUint(index).MarshalTo(nil)
+
+	value, err := itrie.VerifyProof(header.ReceiptsRoot, key, resp.Proof)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		return nil, ErrReceiptNotFound
+	}
+
+	receipt := &types.Receipt{}
+	if err := receipt.UnmarshalRLP(value); err != nil {
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// decodeStorageValue decodes the RLP encoded storage slot value
+func decodeStorageValue(value []byte) (types.Hash, error) {
+	if value == nil {
+		return types.ZeroHash, nil
+	}
+
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(value)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	data, err := v.Bytes()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(data), nil
+}
+
+// applyValidatorsDiff returns a new validator set with the given changes applied
+func applyValidatorsDiff(
+	validators []*Validator,
+	added []*proto.Validator,
+	removed [][]byte,
+) []*Validator {
+	removedSet := make(map[types.Address]struct{}, len(removed))
+	for _, addr := range removed {
+		removedSet[types.BytesToAddress(addr)] = struct{}{}
+	}
+
+	res := make([]*Validator, 0, len(validators)+len(added))
+
+	for _, val := range validators {
+		if _, ok := removedSet[val.Address]; !ok {
+			res = append(res, val)
+		}
+	}
+
+	for _, val := range added {
+		res = append(res, &Validator{
+			Address: types.BytesToAddress(val.Address),
+			Data:    val.Data,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,126 @@
+package lightclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var errInvalidSeals = errors.New("invalid seals")
+
+type mockVerifier struct {
+	failAt     uint64
+	validators map[uint64][]types.Address
+}
+
+func (m *mockVerifier) VerifyHeader(header *types.Header, validators []*Validator) error {
+	if m.failAt != 0 && header.Number == m.failAt {
+		return errInvalidSeals
+	}
+
+	addrs := make([]types.Address, len(validators))
+	for i, val := range validators {
+		addrs[i] = val.Address
+	}
+
+	m.validators[header.Number] = addrs
+
+	return nil
+}
+
+func newTestClient(t *testing.T, verifier HeaderVerifier) (*Client, *mockBlockchain) {
+	t.Helper()
+
+	server, blockchain := newTestServer(t)
+
+	client, err := NewClient(
+		newMockGrpcConn(t, server),
+		verifier,
+		blockchain.headers[0],
+		[]*Validator{{Address: addr1}, {Address: addr2}},
+	)
+	assert.NoError(t, err)
+
+	return client, blockchain
+}
+
+func TestClient_Sync(t *testing.T) {
+	t.Parallel()
+
+	verifier := &mockVerifier{validators: map[uint64][]types.Address{}}
+	client, blockchain := newTestClient(t, verifier)
+
+	synced, err := client.Sync(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), synced)
+	assert.Equal(t, blockchain.Header().Hash, client.Header().Hash)
+
+	assert.Equal(t, map[uint64][]types.Address{
+		1: {addr1, addr2},
+		2: {addr1, addr2, addr3},
+		3: {addr2, addr3},
+	}, verifier.validators)
+
+	// nothing new to sync
+	synced, err = client.Sync(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, synced)
+}
+
+func TestClient_Sync_InvalidHeader(t *testing.T) {
+	t.Parallel()
+
+	verifier := &mockVerifier{failAt: 2, validators: map[uint64][]types.Address{}}
+	client, _ := newTestClient(t, verifier)
+
+	synced, err := client.Sync(context.Background())
+	assert.ErrorIs(t, err, errInvalidSeals)
+	assert.Equal(t, uint64(1), synced)
+	assert.Equal(t, uint64(1), client.Header().Number)
+
+	_, ok := client.GetHeader(2)
+	assert.False(t, ok)
+}
+
+func TestClient_GetAccount(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newTestClient(t, &mockVerifier{validators: map[uint64][]types.Address{}})
+
+	// the header must be verified first
+	_, err := client.GetAccount(context.Background(), 2, addr1, nil)
+	assert.ErrorIs(t, err, ErrHeaderNotVerified)
+
+	_, err = client.Sync(context.Background())
+	assert.NoError(t, err)
+
+	account, err := client.GetAccount(context.Background(), 2, addr1, []types.Hash{slot1, types.StringToHash("2")})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), account.Nonce)
+	assert.Equal(t, big.NewInt(100), account.Balance)
+	assert.Equal(t, types.BytesToHash([]byte{0x5}), account.Storage[slot1])
+	assert.Equal(t, types.ZeroHash, account.Storage[types.StringToHash("2")])
+
+	// unknown account
+	account, err = client.GetAccount(context.Background(), 2, addr2, nil)
+	assert.NoError(t, err)
+	assert.Zero(t, account.Balance.Sign())
+	assert.Equal(t, types.EmptyRootHash, account.StorageRoot)
+}
+
+func TestClient_GetReceipt(t *testing.T) {
+	t.Parallel()
+
+	client, blockchain := newTestClient(t, &mockVerifier{validators: map[uint64][]types.Address{}})
+
+	_, err := client.Sync(context.Background())
+	assert.NoError(t, err)
+
+	receipt, err := client.GetReceipt(context.Background(), 3, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, blockchain.receipts[blockchain.headers[3].Hash][0].CumulativeGasUsed, receipt.CumulativeGasUsed)
+}
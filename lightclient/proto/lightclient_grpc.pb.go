@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: lightclient/proto/lightclient.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// LightClientClient is the client API for LightClient service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LightClientClient interface {
+	// Returns the latest block height and hash
+	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Status, error)
+	// Returns stream of signed headers with the validator set changes
+	GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (LightClient_GetHeadersClient, error)
+	// Returns merkle proof of an account and its storage slots
+	GetAccountProof(ctx context.Context, in *AccountProofRequest, opts ...grpc.CallOption) (*AccountProof, error)
+	// Returns merkle proof of a receipt in a block
+	GetReceiptProof(ctx context.Context, in *ReceiptProofRequest, opts ...grpc.CallOption) (*ReceiptProof, error)
+}
+
+type lightClientClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLightClientClient(cc grpc.ClientConnInterface) LightClientClient {
+	return &lightClientClient{cc}
+}
+
+func (c *lightClientClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/lightclient.v1.LightClient/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightClientClient) GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (LightClient_GetHeadersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LightClient_ServiceDesc.Streams[0], "/lightclient.v1.LightClient/GetHeaders", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightClientGetHeadersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LightClient_GetHeadersClient interface {
+	Recv() (*SignedHeader, error)
+	grpc.ClientStream
+}
+
+type lightClientGetHeadersClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightClientGetHeadersClient) Recv() (*SignedHeader, error) {
+	m := new(SignedHeader)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightClientClient) GetAccountProof(ctx context.Context, in *AccountProofRequest, opts ...grpc.CallOption) (*AccountProof, error) {
+	out := new(AccountProof)
+	err := c.cc.Invoke(ctx, "/lightclient.v1.LightClient/GetAccountProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightClientClient) GetReceiptProof(ctx context.Context, in *ReceiptProofRequest, opts ...grpc.CallOption) (*ReceiptProof, error) {
+	out := new(ReceiptProof)
+	err := c.cc.Invoke(ctx, "/lightclient.v1.LightClient/GetReceiptProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LightClientServer is the server API for LightClient service.
+// All implementations must embed UnimplementedLightClientServer
+// for forward compatibility
+type LightClientServer interface {
+	// Returns the latest block height and hash
+	GetStatus(context.Context, *emptypb.Empty) (*Status, error)
+	// Returns stream of signed headers with the validator set changes
+	GetHeaders(*GetHeadersRequest, LightClient_GetHeadersServer) error
+	// Returns merkle proof of an account and its storage slots
+	GetAccountProof(context.Context, *AccountProofRequest) (*AccountProof, error)
+	// Returns merkle proof of a receipt in a block
+	GetReceiptProof(context.Context, *ReceiptProofRequest) (*ReceiptProof, error)
+	mustEmbedUnimplementedLightClientServer()
+}
+
+// UnimplementedLightClientServer must be embedded to have forward compatible implementations.
+type UnimplementedLightClientServer struct {
+}
+
+func (UnimplementedLightClientServer) GetStatus(context.Context, *emptypb.Empty) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedLightClientServer) GetHeaders(*GetHeadersRequest, LightClient_GetHeadersServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetHeaders not implemented")
+}
+func (UnimplementedLightClientServer) GetAccountProof(context.Context, *AccountProofRequest) (*AccountProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountProof not implemented")
+}
+func (UnimplementedLightClientServer) GetReceiptProof(context.Context, *ReceiptProofRequest) (*ReceiptProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceiptProof not implemented")
+}
+func (UnimplementedLightClientServer) mustEmbedUnimplementedLightClientServer() {}
+
+// UnsafeLightClientServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LightClientServer will
+// result in compilation errors.
+type UnsafeLightClientServer interface {
+	mustEmbedUnimplementedLightClientServer()
+}
+
+func RegisterLightClientServer(s grpc.ServiceRegistrar, srv LightClientServer) {
+	s.RegisterService(&LightClient_ServiceDesc, srv)
+}
+
+func _LightClient_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightClientServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lightclient.v1.LightClient/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightClientServer).GetStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightClient_GetHeaders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetHeadersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightClientServer).GetHeaders(m, &lightClientGetHeadersServer{stream})
+}
+
+type LightClient_GetHeadersServer interface {
+	Send(*SignedHeader) error
+	grpc.ServerStream
+}
+
+type lightClientGetHeadersServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightClientGetHeadersServer) Send(m *SignedHeader) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LightClient_GetAccountProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightClientServer).GetAccountProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lightclient.v1.LightClient/GetAccountProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightClientServer).GetAccountProof(ctx, req.(*AccountProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LightClient_GetReceiptProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiptProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightClientServer).GetReceiptProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lightclient.v1.LightClient/GetReceiptProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightClientServer).GetReceiptProof(ctx, req.(*ReceiptProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LightClient_ServiceDesc is the grpc.ServiceDesc for LightClient service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LightClient_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lightclient.v1.LightClient",
+	HandlerType: (*LightClientServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    _LightClient_GetStatus_Handler,
+		},
+		{
+			MethodName: "GetAccountProof",
+			Handler:    _LightClient_GetAccountProof_Handler,
+		},
+		{
+			MethodName: "GetReceiptProof",
+			Handler:    _LightClient_GetReceiptProof_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetHeaders",
+			Handler:       _LightClient_GetHeaders_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "lightclient/proto/lightclient.proto",
+}
@@ -0,0 +1,251 @@
+package lightclient
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/lightclient/proto"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Server serves headers and state proofs to light clients
+type Server struct {
+	proto.UnimplementedLightClientServer
+
+	logger     hclog.Logger
+	network    Network
+	blockchain Blockchain
+	state      State
+	validators ValidatorSetProvider // optional, no validator set changes are sent if nil
+	stream     *grpc.GrpcStream
+}
+
+// NewServer creates a new light client server
+func NewServer(
+	logger hclog.Logger,
+	network Network,
+	blockchain Blockchain,
+	state State,
+	validators ValidatorSetProvider,
+) *Server {
+	return &Server{
+		logger:     logger.Named("light-server"),
+		network:    network,
+		blockchain: blockchain,
+		state:      state,
+		validators: validators,
+	}
+}
+
+// Start registers the light client protocol on the network
+func (s *Server) Start() {
+	s.stream = grpc.NewGrpcStream()
+
+	proto.RegisterLightClientServer(s.stream.GrpcServer(), s)
+	s.stream.Serve()
+	s.network.RegisterProtocol(LightClientProto, s.stream)
+}
+
+// Close stops the light client server
+func (s *Server) Close() error {
+	if s.stream == nil {
+		return nil
+	}
+
+	return s.stream.Close()
+}
+
+// GetStatus is a gRPC endpoint to return the latest block of the server
+func (s *Server) GetStatus(
+	ctx context.Context,
+	req *emptypb.Empty,
+) (*proto.Status, error) {
+	header := s.blockchain.Header()
+
+	return &proto.Status{
+		Number: header.Number,
+		Hash:   header.Hash.Bytes(),
+	}, nil
+}
+
+// GetHeaders is a gRPC endpoint to stream the headers in the given range
+// together with the validator set changes at their heights
+func (s *Server) GetHeaders(
+	req *proto.GetHeadersRequest,
+	stream proto.LightClient_GetHeadersServer,
+) error {
+	to := req.To
+	if latest := s.blockchain.Header().Number; to == 0 || to > latest {
+		to = latest
+	}
+
+	if req.From > to {
+		return ErrInvalidRange
+	}
+
+	var (
+		prevValidators validators.Validators
+		err            error
+	)
+
+	if s.validators != nil && req.From > 0 {
+		if prevValidators, err = s.validators.GetValidators(req.From - 1); err != nil {
+			return err
+		}
+	}
+
+	for height := req.From; height <= to; height++ {
+		header, ok := s.blockchain.GetHeaderByNumber(height)
+		if !ok {
+			return ErrHeaderNotFound
+		}
+
+		resp := &proto.SignedHeader{
+			Header: header.MarshalRLP(),
+		}
+
+		if s.validators != nil {
+			currValidators, err := s.validators.GetValidators(height)
+			if err != nil {
+				return err
+			}
+
+			resp.AddedValidators, resp.RemovedValidators = diffValidators(prevValidators, currValidators)
+			prevValidators = currValidators
+		}
+
+		// if client closes stream, context.Canceled is given
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetAccountProof is a gRPC endpoint to return the merkle proof
+// of an account and its storage slots at the given height
+func (s *Server) GetAccountProof(
+	ctx context.Context,
+	req *proto.AccountProofRequest,
+) (*proto.AccountProof, error) {
+	if len(req.Address) != types.AddressLength {
+		return nil, ErrInvalidAddress
+	}
+
+	header, ok := s.blockchain.GetHeaderByNumber(req.Number)
+	if !ok {
+		return nil, ErrHeaderNotFound
+	}
+
+	addr := types.BytesToAddress(req.Address)
+
+	snap, err := s.state.NewSnapshotAt(header.StateRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := snap.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := s.state.GetProof(header.StateRoot, crypto.Keccak256(addr.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.AccountProof{
+		Proof:         proof,
+		StorageProofs: make([]*proto.StorageProof, 0, len(req.StorageKeys)),
+	}
+
+	storageRoot := types.EmptyRootHash
+	if account != nil {
+		storageRoot = account.Root
+	}
+
+	for _, key := range req.StorageKeys {
+		slot := types.BytesToHash(key)
+
+		storageProof, err := s.state.GetProof(storageRoot, crypto.Keccak256(slot.Bytes()))
+		if err != nil {
+			return nil, err
+		}
+
+		resp.StorageProofs = append(resp.StorageProofs, &proto.StorageProof{
+			Key:   slot.Bytes(),
+			Proof: storageProof,
+		})
+	}
+
+	return resp, nil
+}
+
+// GetReceiptProof is a gRPC endpoint to return the merkle proof
+// of a receipt of the block at the given height
+func (s *Server) GetReceiptProof(
+	ctx context.Context,
+	req *proto.ReceiptProofRequest,
+) (*proto.ReceiptProof, error) {
+	header, ok := s.blockchain.GetHeaderByNumber(req.Number)
+	if !ok {
+		return nil, ErrHeaderNotFound
+	}
+
+	receipts, err := s.blockchain.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Index >= uint64(len(receipts)) {
+		return nil, ErrReceiptNotFound
+	}
+
+	proof, err := buildroot.CalculateReceiptProof(receipts, int(req.Index))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ReceiptProof{
+		Proof: proof,
+	}, nil
+}
+
+// diffValidators returns the validators added to and the addresses removed from the set
+func diffValidators(prev, curr validators.Validators) ([]*proto.Validator, [][]byte) {
+	var (
+		added   = []*proto.Validator{}
+		removed = [][]byte{}
+	)
+
+	for i := 0; i < curr.Len(); i++ {
+		val := curr.At(uint64(i))
+
+		if prev == nil || !prev.Includes(val.Addr()) {
+			added = append(added, &proto.Validator{
+				Address: val.Addr().Bytes(),
+				Data:    val.Bytes(),
+			})
+		}
+	}
+
+	if prev == nil {
+		return added, removed
+	}
+
+	for i := 0; i < prev.Len(); i++ {
+		val := prev.At(uint64(i))
+
+		if !curr.Includes(val.Addr()) {
+			removed = append(removed, val.Addr().Bytes())
+		}
+	}
+
+	return added, removed
+}
@@ -0,0 +1,290 @@
+package lightclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/lightclient/proto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const bufSize = 1024 * 1024
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+
+	slot1 = types.StringToHash("1")
+)
+
+type mockBlockchain struct {
+	headers  []*types.Header
+	receipts map[types.Hash][]*types.Receipt
+}
+
+func (m *mockBlockchain) Header() *types.Header {
+	return m.headers[len(m.headers)-1]
+}
+
+func (m *mockBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	if number >= uint64(len(m.headers)) {
+		return nil, false
+	}
+
+	return m.headers[number], true
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.receipts[hash], nil
+}
+
+type mockValidatorSetProvider map[uint64]validators.Validators
+
+func (m mockValidatorSetProvider) GetValidators(height uint64) (validators.Validators, error) {
+	vals, ok := m[height]
+	if !ok {
+		return nil, errors.New("validators not found")
+	}
+
+	return vals, nil
+}
+
+// newTestChain creates a chain of 4 blocks with the same state,
+// an account with a storage slot, and a receipt in each block
+func newTestChain(t *testing.T) (*mockBlockchain, *itrie.State, mockValidatorSetProvider) {
+	t.Helper()
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+
+	_, root := st.NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  addr1,
+			Balance:  big.NewInt(100),
+			Nonce:    2,
+			CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+			Root:     types.EmptyRootHash,
+			Storage: []*state.StorageObject{
+				{
+					Key: slot1.Bytes(),
+					Val: types.BytesToHash([]byte{0x5}).Bytes(),
+				},
+			},
+		},
+	})
+
+	blockchain := &mockBlockchain{
+		receipts: map[types.Hash][]*types.Receipt{},
+	}
+
+	var parent *types.Header
+
+	for i := uint64(0); i < 4; i++ {
+		receipt := &types.Receipt{
+			CumulativeGasUsed: 21000 * (i + 1),
+		}
+		receipt.SetStatus(types.ReceiptSuccess)
+
+		receipts := []*types.Receipt{receipt}
+
+		header := &types.Header{
+			Number:       i,
+			StateRoot:    types.BytesToHash(root),
+			ReceiptsRoot: buildroot.CalculateReceiptsRoot(receipts),
+		}
+
+		if parent != nil {
+			header.ParentHash = parent.Hash
+		}
+
+		header.ComputeHash()
+
+		blockchain.headers = append(blockchain.headers, header)
+		blockchain.receipts[header.Hash] = receipts
+		parent = header
+	}
+
+	validatorSets := mockValidatorSetProvider{
+		0: validators.NewECDSAValidatorSet(validators.NewECDSAValidator(addr1), validators.NewECDSAValidator(addr2)),
+		1: validators.NewECDSAValidatorSet(validators.NewECDSAValidator(addr1), validators.NewECDSAValidator(addr2)),
+		2: validators.NewECDSAValidatorSet(
+			validators.NewECDSAValidator(addr1),
+			validators.NewECDSAValidator(addr2),
+			validators.NewECDSAValidator(addr3),
+		),
+		3: validators.NewECDSAValidatorSet(validators.NewECDSAValidator(addr2), validators.NewECDSAValidator(addr3)),
+	}
+
+	return blockchain, st, validatorSets
+}
+
+func newMockGrpcConn(t *testing.T, server *Server) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer()
+	proto.RegisterLightClientServer(s, server)
+
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(
+			func(ctx context.Context, address string) (net.Conn, error) {
+				return lis.Dial()
+			},
+		),
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+
+	return conn
+}
+
+func newTestServer(t *testing.T) (*Server, *mockBlockchain) {
+	t.Helper()
+
+	blockchain, st, validatorSets := newTestChain(t)
+
+	return NewServer(hclog.NewNullLogger(), nil, blockchain, st, validatorSets), blockchain
+}
+
+func TestServer_GetStatus(t *testing.T) {
+	t.Parallel()
+
+	server, blockchain := newTestServer(t)
+	client := proto.NewLightClientClient(newMockGrpcConn(t, server))
+
+	status, err := client.GetStatus(context.Background(), &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), status.Number)
+	assert.Equal(t, blockchain.Header().Hash.Bytes(), status.Hash)
+}
+
+func TestServer_GetHeaders(t *testing.T) {
+	t.Parallel()
+
+	server, blockchain := newTestServer(t)
+	client := proto.NewLightClientClient(newMockGrpcConn(t, server))
+
+	stream, err := client.GetHeaders(context.Background(), &proto.GetHeadersRequest{From: 1})
+	assert.NoError(t, err)
+
+	received := []*proto.SignedHeader{}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		assert.NoError(t, err)
+
+		received = append(received, resp)
+	}
+
+	assert.Len(t, received, 3)
+
+	for i, resp := range received {
+		header := &types.Header{}
+		assert.NoError(t, header.UnmarshalRLP(resp.Header))
+		assert.Equal(t, blockchain.headers[i+1].Hash, header.Hash)
+	}
+
+	// no changes at height 1
+	assert.Empty(t, received[0].AddedValidators)
+	assert.Empty(t, received[0].RemovedValidators)
+
+	// addr3 joined at height 2
+	assert.Len(t, received[1].AddedValidators, 1)
+	assert.Equal(t, addr3.Bytes(), received[1].AddedValidators[0].Address)
+	assert.Empty(t, received[1].RemovedValidators)
+
+	// addr1 left at height 3
+	assert.Empty(t, received[2].AddedValidators)
+	assert.Equal(t, [][]byte{addr1.Bytes()}, received[2].RemovedValidators)
+}
+
+func TestServer_GetHeaders_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTestServer(t)
+	client := proto.NewLightClientClient(newMockGrpcConn(t, server))
+
+	stream, err := client.GetHeaders(context.Background(), &proto.GetHeadersRequest{From: 10})
+	assert.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.ErrorContains(t, err, ErrInvalidRange.Error())
+}
+
+func TestServer_GetAccountProof(t *testing.T) {
+	t.Parallel()
+
+	server, blockchain := newTestServer(t)
+	client := proto.NewLightClientClient(newMockGrpcConn(t, server))
+
+	resp, err := client.GetAccountProof(context.Background(), &proto.AccountProofRequest{
+		Number:      2,
+		Address:     addr1.Bytes(),
+		StorageKeys: [][]byte{slot1.Bytes()},
+	})
+	assert.NoError(t, err)
+
+	value, err := itrie.VerifyProof(blockchain.headers[2].StateRoot, crypto.Keccak256(addr1.Bytes()), resp.Proof)
+	assert.NoError(t, err)
+	assert.NotNil(t, value)
+
+	assert.Len(t, resp.StorageProofs, 1)
+	assert.Equal(t, slot1.Bytes(), resp.StorageProofs[0].Key)
+
+	_, err = client.GetAccountProof(context.Background(), &proto.AccountProofRequest{
+		Number:  2,
+		Address: []byte{0x1},
+	})
+	assert.ErrorContains(t, err, ErrInvalidAddress.Error())
+}
+
+func TestServer_GetReceiptProof(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTestServer(t)
+	client := proto.NewLightClientClient(newMockGrpcConn(t, server))
+
+	resp, err := client.GetReceiptProof(context.Background(), &proto.ReceiptProofRequest{Number: 1})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Proof)
+
+	_, err = client.GetReceiptProof(context.Background(), &proto.ReceiptProofRequest{Number: 1, Index: 1})
+	assert.ErrorContains(t, err, ErrReceiptNotFound.Error())
+
+	_, err = client.GetReceiptProof(context.Background(), &proto.ReceiptProofRequest{Number: 10})
+	assert.ErrorContains(t, err, ErrHeaderNotFound.Error())
+}
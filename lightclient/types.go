@@ -0,0 +1,61 @@
+package lightclient
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+const (
+	// LightClientProto is the libp2p protocol the light client server is served on
+	LightClientProto = "/light/0.1"
+)
+
+var (
+	ErrHeaderNotFound      = errors.New("header not found")
+	ErrInvalidRange        = errors.New("invalid header range")
+	ErrInvalidAddress      = errors.New("invalid address")
+	ErrReceiptNotFound     = errors.New("receipt not found")
+	ErrHeaderNotVerified   = errors.New("header hasn't been verified")
+	ErrUnexpectedHeader    = errors.New("header doesn't extend the verified chain")
+	ErrStorageProofMissing = errors.New("storage proof missing")
+)
+
+// Blockchain is the chain the light client server reads from
+type Blockchain interface {
+	// Header returns the latest header
+	Header() *types.Header
+	// GetHeaderByNumber returns the header by number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+	// GetReceiptsByHash returns the receipts of the block by hash
+	GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
+}
+
+// State is the world state the light client server builds the proofs from
+type State interface {
+	state.Prover
+
+	// NewSnapshotAt returns the snapshot of the state at the given root
+	NewSnapshotAt(types.Hash) (state.Snapshot, error)
+}
+
+// ValidatorSetProvider returns the validator set of the given height
+type ValidatorSetProvider interface {
+	GetValidators(height uint64) (validators.Validators, error)
+}
+
+// Network is the network the light client server is registered on
+type Network interface {
+	// RegisterProtocol registers gRPC service
+	RegisterProtocol(string, network.Protocol)
+}
+
+// Validator is a member of the validator set tracked by the light client
+type Validator struct {
+	Address types.Address
+	// Data is the RLP encoded validator, its contents depend on the validator type
+	Data []byte
+}
@@ -0,0 +1,249 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// ACL is the access control list of the peer connections.
+// The denied ranges and peers are never connected. When an allow list is set,
+// only the peers listed or connecting from an allowed range are connected
+type ACL struct {
+	AllowCIDRs []*net.IPNet
+	DenyCIDRs  []*net.IPNet
+	AllowPeers []peer.ID
+	DenyPeers  []peer.ID
+}
+
+// ParseACL parses the CIDR ranges and peer IDs of the access control list
+func ParseACL(allowCIDRs, denyCIDRs, allowPeers, denyPeers []string) (*ACL, error) {
+	acl := &ACL{}
+
+	for _, ranges := range []struct {
+		raw    []string
+		parsed *[]*net.IPNet
+	}{
+		{allowCIDRs, &acl.AllowCIDRs},
+		{denyCIDRs, &acl.DenyCIDRs},
+	} {
+		for _, rawCIDR := range ranges.raw {
+			_, ipNet, err := net.ParseCIDR(rawCIDR)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR range %q: %w", rawCIDR, err)
+			}
+
+			*ranges.parsed = append(*ranges.parsed, ipNet)
+		}
+	}
+
+	for _, peers := range []struct {
+		raw    []string
+		parsed *[]peer.ID
+	}{
+		{allowPeers, &acl.AllowPeers},
+		{denyPeers, &acl.DenyPeers},
+	} {
+		for _, rawID := range peers.raw {
+			id, err := peer.Decode(rawID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid peer ID %q: %w", rawID, err)
+			}
+
+			*peers.parsed = append(*peers.parsed, id)
+		}
+	}
+
+	return acl, nil
+}
+
+func containsIP(ranges []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range ranges {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsPeer(peers []peer.ID, id peer.ID) bool {
+	for _, listed := range peers {
+		if listed == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isDeniedIP checks if the IP is in a denied range, the unknown IPs never are
+func (a *ACL) isDeniedIP(ip net.IP) bool {
+	return ip != nil && containsIP(a.DenyCIDRs, ip)
+}
+
+func (a *ACL) hasAllowList() bool {
+	return len(a.AllowCIDRs) > 0 || len(a.AllowPeers) > 0
+}
+
+// allowsIP checks if a connection from the IP may be allowed, before the peer is known
+func (a *ACL) allowsIP(ip net.IP) bool {
+	if a.isDeniedIP(ip) {
+		return false
+	}
+
+	// the listed peers may connect from any IP
+	if len(a.AllowCIDRs) == 0 || len(a.AllowPeers) > 0 {
+		return true
+	}
+
+	return ip != nil && containsIP(a.AllowCIDRs, ip)
+}
+
+// Allows checks if the peer connecting from the IP is allowed, the IP is nil if unknown
+func (a *ACL) Allows(id peer.ID, ip net.IP) bool {
+	if containsPeer(a.DenyPeers, id) || a.isDeniedIP(ip) {
+		return false
+	}
+
+	if !a.hasAllowList() {
+		return true
+	}
+
+	return containsPeer(a.AllowPeers, id) || (ip != nil && containsIP(a.AllowCIDRs, ip))
+}
+
+// aclGater enforces the access control list, which can be replaced at runtime
+type aclGater struct {
+	lock sync.RWMutex
+	acl  *ACL
+}
+
+func newACLGater(acl *ACL) *aclGater {
+	if acl == nil {
+		acl = &ACL{}
+	}
+
+	return &aclGater{acl: acl}
+}
+
+func (g *aclGater) getACL() *ACL {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return g.acl
+}
+
+func (g *aclGater) setACL(acl *ACL) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.acl = acl
+}
+
+// remoteIP returns the IP of the address, nil if it has none
+func remoteIP(addr multiaddr.Multiaddr) net.IP {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return nil
+	}
+
+	return ip
+}
+
+func (g *aclGater) InterceptPeerDial(id peer.ID) bool {
+	return !containsPeer(g.getACL().DenyPeers, id)
+}
+
+func (g *aclGater) InterceptAddrDial(id peer.ID, addr multiaddr.Multiaddr) bool {
+	return g.getACL().Allows(id, remoteIP(addr))
+}
+
+func (g *aclGater) InterceptAccept(conn network.ConnMultiaddrs) bool {
+	return g.getACL().allowsIP(remoteIP(conn.RemoteMultiaddr()))
+}
+
+func (g *aclGater) InterceptSecured(_ network.Direction, id peer.ID, conn network.ConnMultiaddrs) bool {
+	return g.getACL().Allows(id, remoteIP(conn.RemoteMultiaddr()))
+}
+
+func (g *aclGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// gaters chains the connection gaters, a connection is allowed if all of them allow it
+type gaters []connmgr.ConnectionGater
+
+func (gs gaters) InterceptPeerDial(id peer.ID) bool {
+	for _, g := range gs {
+		if !g.InterceptPeerDial(id) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (gs gaters) InterceptAddrDial(id peer.ID, addr multiaddr.Multiaddr) bool {
+	for _, g := range gs {
+		if !g.InterceptAddrDial(id, addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (gs gaters) InterceptAccept(conn network.ConnMultiaddrs) bool {
+	for _, g := range gs {
+		if !g.InterceptAccept(conn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (gs gaters) InterceptSecured(dir network.Direction, id peer.ID, conn network.ConnMultiaddrs) bool {
+	for _, g := range gs {
+		if !g.InterceptSecured(dir, id, conn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (gs gaters) InterceptUpgraded(conn network.Conn) (bool, control.DisconnectReason) {
+	for _, g := range gs {
+		if allow, reason := g.InterceptUpgraded(conn); !allow {
+			return false, reason
+		}
+	}
+
+	return true, 0
+}
+
+// ACL returns the access control list of the peer connections
+func (s *Server) ACL() *ACL {
+	return s.aclGater.getACL()
+}
+
+// SetACL replaces the access control list of the peer connections,
+// the connected peers it denies are disconnected
+func (s *Server) SetACL(acl *ACL) {
+	s.aclGater.setACL(acl)
+
+	for _, conn := range s.host.Network().Conns() {
+		if !acl.Allows(conn.RemotePeer(), remoteIP(conn.RemoteMultiaddr())) {
+			s.DisconnectFromPeer(conn.RemotePeer(), "denied by the access control list")
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseACL(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseACL([]string{"10.0.0.0/8"}, []string{"10.1.0.0"}, nil, nil)
+	assert.Error(t, err)
+
+	_, err = ParseACL(nil, nil, []string{"invalid"}, nil)
+	assert.Error(t, err)
+
+	acl, err := ParseACL([]string{"10.0.0.0/8"}, []string{"10.1.0.0/16", "::1/128"}, nil, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, acl.AllowCIDRs, 1)
+	assert.Len(t, acl.DenyCIDRs, 2)
+}
+
+func TestACL_Allows(t *testing.T) {
+	t.Parallel()
+
+	allowedKey, _ := GenerateTestLibp2pKey(t)
+	deniedKey, _ := GenerateTestLibp2pKey(t)
+	otherKey, _ := GenerateTestLibp2pKey(t)
+
+	allowedID, err := peer.IDFromPrivateKey(allowedKey)
+	require.NoError(t, err)
+
+	deniedID, err := peer.IDFromPrivateKey(deniedKey)
+	require.NoError(t, err)
+
+	otherID, err := peer.IDFromPrivateKey(otherKey)
+	require.NoError(t, err)
+
+	var (
+		allowedIP = net.ParseIP("10.0.0.1")
+		deniedIP  = net.ParseIP("10.1.0.1")
+		otherIP   = net.ParseIP("192.168.0.1")
+	)
+
+	t.Run("empty list allows every peer", func(t *testing.T) {
+		t.Parallel()
+
+		acl := &ACL{}
+
+		assert.True(t, acl.Allows(otherID, otherIP))
+		assert.True(t, acl.Allows(otherID, nil))
+		assert.True(t, acl.allowsIP(otherIP))
+	})
+
+	t.Run("deny list wins over allow list", func(t *testing.T) {
+		t.Parallel()
+
+		acl, err := ParseACL(
+			[]string{"10.0.0.0/8"},
+			[]string{"10.1.0.0/16"},
+			[]string{allowedID.String()},
+			[]string{deniedID.String()},
+		)
+		require.NoError(t, err)
+
+		assert.False(t, acl.Allows(deniedID, allowedIP))
+		assert.False(t, acl.Allows(allowedID, deniedIP))
+		assert.False(t, acl.allowsIP(deniedIP))
+	})
+
+	t.Run("allowed peer or allowed range", func(t *testing.T) {
+		t.Parallel()
+
+		acl, err := ParseACL(
+			[]string{"10.0.0.0/8"},
+			nil,
+			[]string{allowedID.String()},
+			nil,
+		)
+		require.NoError(t, err)
+
+		assert.True(t, acl.Allows(allowedID, otherIP))
+		assert.True(t, acl.Allows(allowedID, nil))
+		assert.True(t, acl.Allows(otherID, allowedIP))
+		assert.False(t, acl.Allows(otherID, otherIP))
+		assert.False(t, acl.Allows(otherID, nil))
+
+		// the allowed peer is only known after the handshake
+		assert.True(t, acl.allowsIP(otherIP))
+	})
+
+	t.Run("allowed range only", func(t *testing.T) {
+		t.Parallel()
+
+		acl, err := ParseACL([]string{"10.0.0.0/8"}, nil, nil, nil)
+		require.NoError(t, err)
+
+		assert.True(t, acl.allowsIP(allowedIP))
+		assert.False(t, acl.allowsIP(otherIP))
+		assert.False(t, acl.allowsIP(nil))
+	})
+}
@@ -3,11 +3,13 @@ package common
 import (
 	"errors"
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
 )
 
 type DialPriority uint64
@@ -82,6 +84,62 @@ func AddrInfoToString(addr *peer.AddrInfo) string {
 	return dialAddress + "/p2p/" + addr.ID.String()
 }
 
+// AddrInfoToStrings converts an AddrInfo into the string representations of all its addresses
+// that can be dialed from another node, so the nodes can pick the IP version they reach
+func AddrInfoToStrings(addr *peer.AddrInfo) []string {
+	dialAddresses := make([]string, 0, len(addr.Addrs))
+
+	for _, address := range addr.Addrs {
+		if !loopbackRegex.MatchString(address.String()) {
+			dialAddresses = append(dialAddresses, address.String()+"/p2p/"+addr.ID.String())
+		}
+	}
+
+	if len(dialAddresses) == 0 {
+		return []string{AddrInfoToString(addr)}
+	}
+
+	return dialAddresses
+}
+
+// IP versions of the addresses
+const (
+	IPv4 = 4
+	IPv6 = 6
+)
+
+// AddrIPVersion returns the IP version of the address,
+// 0 if it can be either like the /dns addresses
+func AddrIPVersion(addr multiaddr.Multiaddr) int {
+	protocols := addr.Protocols()
+	if len(protocols) == 0 {
+		return 0
+	}
+
+	switch protocols[0].Code {
+	case multiaddr.P_IP4, multiaddr.P_DNS4:
+		return IPv4
+	case multiaddr.P_IP6, multiaddr.P_DNS6:
+		return IPv6
+	default:
+		return 0
+	}
+}
+
+// IPVersion returns the IP version of the IP address
+func IPVersion(ip net.IP) int {
+	if ip.To4() != nil {
+		return IPv4
+	}
+
+	return IPv6
+}
+
+// MultiAddrFromIP constructs a TCP multiAddr of the IP version of the IP address
+func MultiAddrFromIP(ip net.IP, port int) (multiaddr.Multiaddr, error) {
+	return manet.FromNetAddr(&net.TCPAddr{IP: ip, Port: port})
+}
+
 // MultiAddrFromDNS constructs a multiAddr from the passed in DNS address and port combination
 func MultiAddrFromDNS(addr string, port int) (multiaddr.Multiaddr, error) {
 	var (
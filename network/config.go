@@ -5,6 +5,8 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/pnet"
 	"github.com/multiformats/go-multiaddr"
 )
 
@@ -12,7 +14,9 @@ import (
 type Config struct {
 	NoDiscover       bool                   // flag indicating if the discovery mechanism should be turned on
 	Addr             *net.TCPAddr           // the base address
+	Addr6            *net.TCPAddr           // the IPv6 address of a dual-stack node, the base address is an IPv4 one
 	NatAddr          net.IP                 // the NAT address
+	NatAddr6         net.IP                 // the IPv6 NAT address of a dual-stack node
 	DNS              multiaddr.Multiaddr    // the DNS address
 	DataDir          string                 // the base data directory for the client
 	MaxPeers         int64                  // the maximum number of peer connections
@@ -20,6 +24,11 @@ type Config struct {
 	MaxOutboundPeers int64                  // the maximum number of outbound peer connections
 	Chain            *chain.Chain           // the reference to the chain configuration
 	SecretsManager   secrets.SecretsManager // the secrets manager used for key storage
+	Sentries         []*peer.AddrInfo       // the sentries of a validator, the only peers it connects to
+	PrivatePeers     []peer.ID              // the peers behind the sentry, never shared through discovery
+	Compressions     []string               // the compressions of the protocol messages, by preference
+	ACL              *ACL                   // the access control list of the peer connections
+	PSK              pnet.PSK               // the pre-shared key of a private network
 }
 
 func DefaultConfig() *Config {
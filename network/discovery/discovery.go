@@ -423,8 +423,9 @@ func (d *DiscoveryService) FindPeers(
 			continue
 		}
 
+		// every address of the peer is shared, the requester keeps the ones it can reach
 		if info := d.baseServer.GetPeerInfo(id); len(info.Addrs) > 0 {
-			filteredPeers = append(filteredPeers, common.AddrInfoToString(info))
+			filteredPeers = append(filteredPeers, common.AddrInfoToStrings(info)...)
 		}
 	}
 
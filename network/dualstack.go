@@ -0,0 +1,112 @@
+package network
+
+import (
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// A dual-stack node listens on an IPv4 and an IPv6 address and advertises both.
+// The addresses of the peers are kept for the IP versions the node listens on,
+// so it doesn't dial the ones it can't reach
+
+// listenAddrs returns the addresses the node listens on
+func listenAddrs(config *Config) ([]multiaddr.Multiaddr, error) {
+	listenAddr, err := common.MultiAddrFromIP(config.Addr.IP, config.Addr.Port)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := []multiaddr.Multiaddr{listenAddr}
+
+	if config.Addr6 != nil {
+		listenAddr6, err := common.MultiAddrFromIP(config.Addr6.IP, config.Addr6.Port)
+		if err != nil {
+			return nil, err
+		}
+
+		addrs = append(addrs, listenAddr6)
+	}
+
+	return addrs, nil
+}
+
+// advertisedAddrs returns the factory of the addresses advertised to the peers.
+// The NAT address of an IP version replaces the listen addresses of the version,
+// the DNS address replaces all of them when there's no NAT address
+func advertisedAddrs(config *Config) func([]multiaddr.Multiaddr) []multiaddr.Multiaddr {
+	natAddrs := make([]multiaddr.Multiaddr, 0, 2)
+
+	if config.NatAddr != nil {
+		if addr, err := common.MultiAddrFromIP(config.NatAddr, config.Addr.Port); err == nil {
+			natAddrs = append(natAddrs, addr)
+		}
+	}
+
+	if config.NatAddr6 != nil && config.Addr6 != nil {
+		if addr, err := common.MultiAddrFromIP(config.NatAddr6, config.Addr6.Port); err == nil {
+			natAddrs = append(natAddrs, addr)
+		}
+	}
+
+	return func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+		if len(natAddrs) == 0 {
+			if config.DNS != nil {
+				return []multiaddr.Multiaddr{config.DNS}
+			}
+
+			return addrs
+		}
+
+		natVersions := make(map[int]bool, len(natAddrs))
+		for _, addr := range natAddrs {
+			natVersions[common.AddrIPVersion(addr)] = true
+		}
+
+		advertised := append([]multiaddr.Multiaddr{}, natAddrs...)
+
+		for _, addr := range addrs {
+			if !natVersions[common.AddrIPVersion(addr)] {
+				advertised = append(advertised, addr)
+			}
+		}
+
+		return advertised
+	}
+}
+
+// isReachableIPVersion checks if the node listens on the IP version,
+// the addresses of any version like the /dns ones are always reachable
+func (s *Server) isReachableIPVersion(version int) bool {
+	if version == 0 {
+		return true
+	}
+
+	if common.IPVersion(s.config.Addr.IP) == version {
+		return true
+	}
+
+	return s.config.Addr6 != nil && version == common.IPv6
+}
+
+// reachableAddrs returns the addresses of the IP versions the node listens on
+func (s *Server) reachableAddrs(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+	reachable := make([]multiaddr.Multiaddr, 0, len(addrs))
+
+	for _, addr := range addrs {
+		if s.isReachableIPVersion(common.AddrIPVersion(addr)) {
+			reachable = append(reachable, addr)
+		}
+	}
+
+	return reachable
+}
+
+// preferReachableAddrs returns the reachable addresses, or all of them if none is.
+// It's used for the peers set by the operator, which may be reachable all the same
+func (s *Server) preferReachableAddrs(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+	if reachable := s.reachableAddrs(addrs); len(reachable) > 0 {
+		return reachable
+	}
+
+	return addrs
+}
@@ -0,0 +1,161 @@
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func toMultiAddrs(t *testing.T, addrs ...string) []multiaddr.Multiaddr {
+	t.Helper()
+
+	multiAddrs, err := constructMultiAddrs(addrs)
+	require.NoError(t, err)
+
+	return multiAddrs
+}
+
+func dualStackConfig() *Config {
+	return &Config{
+		Addr:  &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 1478},
+		Addr6: &net.TCPAddr{IP: net.ParseIP("::"), Port: 1479},
+	}
+}
+
+func TestListenAddrs(t *testing.T) {
+	t.Parallel()
+
+	addrs, err := listenAddrs(dualStackConfig())
+	require.NoError(t, err)
+
+	assert.Equal(t, toMultiAddrs(t, "/ip4/0.0.0.0/tcp/1478", "/ip6/::/tcp/1479"), addrs)
+}
+
+func TestAdvertisedAddrs(t *testing.T) {
+	t.Parallel()
+
+	hostAddrs := toMultiAddrs(t, "/ip4/10.0.0.1/tcp/1478", "/ip6/fd00::1/tcp/1479")
+
+	t.Run("listen addresses", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, hostAddrs, advertisedAddrs(dualStackConfig())(hostAddrs))
+	})
+
+	t.Run("IPv4 NAT address", func(t *testing.T) {
+		t.Parallel()
+
+		config := dualStackConfig()
+		config.NatAddr = net.ParseIP("1.2.3.4")
+
+		assert.Equal(
+			t,
+			toMultiAddrs(t, "/ip4/1.2.3.4/tcp/1478", "/ip6/fd00::1/tcp/1479"),
+			advertisedAddrs(config)(hostAddrs),
+		)
+	})
+
+	t.Run("NAT addresses of both versions", func(t *testing.T) {
+		t.Parallel()
+
+		config := dualStackConfig()
+		config.NatAddr = net.ParseIP("1.2.3.4")
+		config.NatAddr6 = net.ParseIP("2001:db8::1")
+
+		assert.Equal(
+			t,
+			toMultiAddrs(t, "/ip4/1.2.3.4/tcp/1478", "/ip6/2001:db8::1/tcp/1479"),
+			advertisedAddrs(config)(hostAddrs),
+		)
+	})
+
+	t.Run("DNS address", func(t *testing.T) {
+		t.Parallel()
+
+		config := dualStackConfig()
+		config.DNS = toMultiAddrs(t, "/dns/example.com/tcp/1478")[0]
+
+		assert.Equal(t, []multiaddr.Multiaddr{config.DNS}, advertisedAddrs(config)(hostAddrs))
+	})
+}
+
+func TestReachableAddrs(t *testing.T) {
+	t.Parallel()
+
+	peerAddrs := toMultiAddrs(
+		t,
+		"/ip4/1.2.3.4/tcp/1478",
+		"/ip6/2001:db8::1/tcp/1478",
+		"/dns/example.com/tcp/1478",
+	)
+
+	testTable := []struct {
+		name     string
+		config   *Config
+		expected []multiaddr.Multiaddr
+	}{
+		{
+			"IPv4 node",
+			&Config{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1")}},
+			[]multiaddr.Multiaddr{peerAddrs[0], peerAddrs[2]},
+		},
+		{
+			"IPv6 node",
+			&Config{Addr: &net.TCPAddr{IP: net.ParseIP("::1")}},
+			[]multiaddr.Multiaddr{peerAddrs[1], peerAddrs[2]},
+		},
+		{
+			"dual-stack node",
+			dualStackConfig(),
+			peerAddrs,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := &Server{config: testCase.config}
+
+			assert.Equal(t, testCase.expected, server.reachableAddrs(peerAddrs))
+		})
+	}
+
+	// the unreachable addresses are still dialed when the peer has no other
+	server := &Server{config: &Config{Addr: &net.TCPAddr{IP: net.ParseIP("::1")}}}
+	assert.Equal(t, peerAddrs[:1], server.preferReachableAddrs(peerAddrs[:1]))
+}
+
+func TestAddrInfoToStrings(t *testing.T) {
+	t.Parallel()
+
+	key, _ := GenerateTestLibp2pKey(t)
+
+	id, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	info := &peer.AddrInfo{
+		ID:    id,
+		Addrs: toMultiAddrs(t, "/ip4/127.0.0.1/tcp/1478", "/ip4/1.2.3.4/tcp/1478", "/ip6/2001:db8::1/tcp/1478"),
+	}
+
+	assert.Equal(
+		t,
+		[]string{
+			"/ip4/1.2.3.4/tcp/1478/p2p/" + id.String(),
+			"/ip6/2001:db8::1/tcp/1478/p2p/" + id.String(),
+		},
+		common.AddrInfoToStrings(info),
+	)
+
+	// the loopback address is shared when there is no other
+	info.Addrs = info.Addrs[:1]
+	assert.Equal(t, []string{"/ip4/127.0.0.1/tcp/1478/p2p/" + id.String()}, common.AddrInfoToStrings(info))
+}
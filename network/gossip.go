@@ -2,7 +2,10 @@ package network
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -15,14 +18,40 @@ const (
 	// we should have enough capacity of the queue
 	// because when queue is full, if the consumer does not read fast enough, new messages are dropped
 	subscribeOutputBufferSize = 1024
+
+	// invalidMessageWeight is the score penalty of a peer for the square of the number
+	// of invalid messages it sent on a topic, scoreGraylistThreshold is crossed at the third
+	invalidMessageWeight = -10
+	invalidMessageDecay  = 0.9
+
+	// the gossip of the peers below the thresholds is dropped:
+	// the gossip about messages, the published messages, then all the messages
+	scoreGossipThreshold   = -20
+	scorePublishThreshold  = -40
+	scoreGraylistThreshold = -80
+
+	scoreDecayInterval = time.Second
+	scoreDecayToZero   = 0.01
+	scoreRetention     = time.Hour
 )
 
+// ErrIgnoreMessage is returned by a MessageValidator for the messages that are dropped
+// without penalizing the peer, as they may be valid for the other nodes
+var ErrIgnoreMessage = errors.New("message ignored")
+
+// MessageValidator checks a decoded gossip message before it's delivered and propagated.
+// The peers sending a message failing the validation are penalized, unless ErrIgnoreMessage is returned
+type MessageValidator func(obj interface{}, from peer.ID) error
+
 type Topic struct {
 	logger hclog.Logger
 
 	topic   *pubsub.Topic
 	typ     reflect.Type
 	closeCh chan struct{}
+
+	validator     MessageValidator
+	validatorLock sync.RWMutex
 }
 
 func (t *Topic) createObj() proto.Message {
@@ -71,9 +100,10 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 		}
 
 		go func() {
-			obj := t.createObj()
-			if err := proto.Unmarshal(msg.Data, obj); err != nil {
-				t.logger.Error("failed to unmarshal topic", "err", err)
+			// the message is decoded by the validation
+			obj, ok := msg.ValidatorData.(proto.Message)
+			if !ok {
+				t.logger.Error("message not decoded by the validation")
 
 				return
 			}
@@ -83,6 +113,45 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 	}
 }
 
+// SetValidator sets the checks of the topic messages run before they are propagated
+func (t *Topic) SetValidator(validator MessageValidator) {
+	t.validatorLock.Lock()
+	defer t.validatorLock.Unlock()
+
+	t.validator = validator
+}
+
+// validate decodes the message and runs the validator of the topic,
+// the messages failing to decode are always rejected
+func (t *Topic) validate(_ context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	obj := t.createObj()
+	if err := proto.Unmarshal(msg.Data, obj); err != nil {
+		t.logger.Debug("rejected undecodable message", "from", from, "err", err)
+
+		return pubsub.ValidationReject
+	}
+
+	t.validatorLock.RLock()
+	validator := t.validator
+	t.validatorLock.RUnlock()
+
+	if validator != nil {
+		if err := validator(obj, msg.GetFrom()); err != nil {
+			if errors.Is(err, ErrIgnoreMessage) {
+				return pubsub.ValidationIgnore
+			}
+
+			t.logger.Debug("rejected invalid message", "from", from, "err", err)
+
+			return pubsub.ValidationReject
+		}
+	}
+
+	msg.ValidatorData = obj
+
+	return pubsub.ValidationAccept
+}
+
 func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
 	topic, err := s.ps.Join(protoID)
 	if err != nil {
@@ -95,5 +164,39 @@ func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
 		typ:    reflect.TypeOf(obj).Elem(),
 	}
 
+	// the peers are scored by topic on the invalid messages they send
+	if err := topic.SetScoreParams(topicScoreParams()); err != nil {
+		return nil, err
+	}
+
+	if err := s.ps.RegisterTopicValidator(protoID, tt.validate); err != nil {
+		return nil, err
+	}
+
 	return tt, nil
 }
+
+func topicScoreParams() *pubsub.TopicScoreParams {
+	return &pubsub.TopicScoreParams{
+		SkipAtomicValidation:           true,
+		TopicWeight:                    1,
+		InvalidMessageDeliveriesWeight: invalidMessageWeight,
+		InvalidMessageDeliveriesDecay:  invalidMessageDecay,
+	}
+}
+
+// peerScoreParams only scores the peers on the topics they are penalized in
+func peerScoreParams() (*pubsub.PeerScoreParams, *pubsub.PeerScoreThresholds) {
+	return &pubsub.PeerScoreParams{
+		SkipAtomicValidation: true,
+		Topics:               map[string]*pubsub.TopicScoreParams{},
+		DecayInterval:        scoreDecayInterval,
+		DecayToZero:          scoreDecayToZero,
+		RetainScore:          scoreRetention,
+	}, &pubsub.PeerScoreThresholds{
+		SkipAtomicValidation: true,
+		GossipThreshold:      scoreGossipThreshold,
+		PublishThreshold:     scorePublishThreshold,
+		GraylistThreshold:    scoreGraylistThreshold,
+	}
+}
@@ -107,3 +107,92 @@ func TestSimpleGossip(t *testing.T) {
 		}
 	}
 }
+
+func TestGossipValidation(t *testing.T) {
+	// the servers are joined in a line, the messages of the first reach the last through the second
+	noDiscover := &CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = true
+		},
+	}
+
+	servers, createErr := createServers(3, map[int]*CreateServerParams{
+		0: noDiscover,
+		1: noDiscover,
+		2: noDiscover,
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	for i := 0; i < len(servers)-1; i++ {
+		if joinErr := JoinAndWait(servers[i], servers[i+1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+			t.Fatalf("Unable to join servers, %v", joinErr)
+		}
+	}
+
+	topicName := "msg-validation"
+	topics := make([]*Topic, len(servers))
+
+	for i, server := range servers {
+		topic, topicErr := server.NewTopic(topicName, &testproto.GenericMessage{})
+		if topicErr != nil {
+			t.Fatalf("Unable to create topic, %v", topicErr)
+		}
+
+		topics[i] = topic
+	}
+
+	errInvalidMessage := errors.New("invalid message")
+
+	topics[1].SetValidator(func(obj interface{}, _ peer.ID) error {
+		if obj.(*testproto.GenericMessage).Message == "invalid" {
+			return errInvalidMessage
+		}
+
+		return nil
+	})
+
+	messageCh := make(chan string, 2)
+
+	for i, topic := range topics {
+		handler := func(interface{}, peer.ID) {}
+
+		if i == len(topics)-1 {
+			handler = func(obj interface{}, _ peer.ID) {
+				messageCh <- obj.(*testproto.GenericMessage).Message
+			}
+		}
+
+		if subscribeErr := topic.Subscribe(handler); subscribeErr != nil {
+			t.Fatalf("Unable to subscribe to topic, %v", subscribeErr)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if waitErr := WaitForSubscribers(ctx, servers[1], topicName, 2); waitErr != nil {
+		t.Fatalf("Unable to wait for subscribers, %v", waitErr)
+	}
+
+	for _, message := range []string{"invalid", "valid"} {
+		if publishErr := topics[0].Publish(&testproto.GenericMessage{Message: message}); publishErr != nil {
+			t.Fatalf("Unable to publish message, %v", publishErr)
+		}
+	}
+
+	select {
+	case message := <-messageCh:
+		// the invalid message isn't relayed by the second server
+		if message != "valid" {
+			t.Fatalf("Invalid message relayed, %s", message)
+		}
+	case <-time.After(15 * time.Second):
+		t.Fatalf("Valid message not received before timeout")
+	}
+}
@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"io"
+
+	"github.com/golang/snappy"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+const (
+	SnappyCompression = "snappy"
+	GzipCompression   = gzip.Name
+)
+
+// SupportedCompressions are the compressions of the protocol messages, by preference.
+// They are always registered, the peers can use them whether the node enables them or not
+var SupportedCompressions = []string{SnappyCompression, GzipCompression}
+
+func init() {
+	encoding.RegisterCompressor(&snappyCompressor{})
+}
+
+// IsSupportedCompression checks if the compression can be used for the protocol messages
+func IsSupportedCompression(compression string) bool {
+	for _, supported := range SupportedCompressions {
+		if supported == compression {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NegotiateCompression returns the first of the local compressions supported by the peer,
+// an empty string if there's none and the messages are not compressed
+func NegotiateCompression(local, remote []string) string {
+	for _, compression := range local {
+		for _, remoteCompression := range remote {
+			if compression == remoteCompression && IsSupportedCompression(compression) {
+				return compression
+			}
+		}
+	}
+
+	return ""
+}
+
+// snappyCompressor compresses the messages in the snappy framing format
+type snappyCompressor struct{}
+
+func (c *snappyCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return snappy.NewBufferedWriter(w), nil
+}
+
+func (c *snappyCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return snappy.NewReader(r), nil
+}
+
+func (c *snappyCompressor) Name() string {
+	return SnappyCompression
+}
@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestNegotiateCompression(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		local    []string
+		remote   []string
+		expected string
+	}{
+		{"disabled locally", nil, SupportedCompressions, ""},
+		{"disabled by the peer", SupportedCompressions, []string{""}, ""},
+		{"local preference", []string{GzipCompression, SnappyCompression}, SupportedCompressions, GzipCompression},
+		{"only common compression", SupportedCompressions, []string{GzipCompression}, GzipCompression},
+		{"unknown compression", []string{"zstd"}, []string{"zstd"}, ""},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.expected, NegotiateCompression(testCase.local, testCase.remote))
+		})
+	}
+}
+
+func TestCompressors(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("block body"), 1024)
+
+	for _, name := range SupportedCompressions {
+		compressor := encoding.GetCompressor(name)
+		require.NotNil(t, compressor, name)
+
+		var buf bytes.Buffer
+
+		writer, err := compressor.Compress(&buf)
+		require.NoError(t, err)
+
+		_, err = writer.Write(payload)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		assert.Less(t, buf.Len(), len(payload), name)
+
+		reader, err := compressor.Decompress(&buf)
+		require.NoError(t, err)
+
+		decompressed, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, payload, decompressed, name)
+	}
+}
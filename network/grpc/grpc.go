@@ -68,8 +68,8 @@ func interceptor(
 	)
 }
 
-func (g *GrpcStream) Client(stream network.Stream) *grpc.ClientConn {
-	return WrapClient(stream)
+func (g *GrpcStream) Client(stream network.Stream, opts ...grpc.DialOption) *grpc.ClientConn {
+	return WrapClient(stream, opts...)
 }
 
 func (g *GrpcStream) Serve() {
@@ -118,11 +118,15 @@ func (g *GrpcStream) Close() error {
 
 // --- conn ---
 
-func WrapClient(s network.Stream) *grpc.ClientConn {
-	opts := grpc.WithContextDialer(func(ctx context.Context, peerIdStr string) (net.Conn, error) {
-		return &streamConn{s}, nil
-	})
-	conn, err := grpc.Dial("", grpc.WithTransportCredentials(insecure.NewCredentials()), opts)
+func WrapClient(s network.Stream, opts ...grpc.DialOption) *grpc.ClientConn {
+	opts = append(
+		opts,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, peerIdStr string) (net.Conn, error) {
+			return &streamConn{s}, nil
+		}),
+	)
+	conn, err := grpc.Dial("", opts...)
 
 	if err != nil {
 		// TODO: this should not fail at all
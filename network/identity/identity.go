@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/network/event"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/versioning"
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/network/proto"
@@ -14,7 +17,15 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
-const PeerID = "peerID"
+const (
+	PeerID = "peerID"
+
+	// Compressions are the compressions of the protocol messages the peer supports
+	Compressions = "compressions"
+
+	// Version is the version of the binary of the peer, empty for the development builds
+	Version = "version"
+)
 
 var (
 	ErrInvalidChainID   = errors.New("invalid chain ID")
@@ -37,6 +48,12 @@ type networkingServer interface {
 	// AddPeer adds a peer to the networking server's peer store
 	AddPeer(id peer.ID, direction network.Direction)
 
+	// SetPeerCompression sets the compression of the protocol messages sent to the peer
+	SetPeerCompression(id peer.ID, compression string)
+
+	// SetPeerVersion sets the version of the binary of the peer
+	SetPeerVersion(id peer.ID, version string)
+
 	// UpdatePendingConnCount updates the pendingPeerConnections connection count for the direction [Thread safe]
 	UpdatePendingConnCount(delta int64, direction network.Direction)
 
@@ -52,6 +69,9 @@ type networkingServer interface {
 
 	// HasFreeConnectionSlot checks if there are available outbound connection slots [Thread safe]
 	HasFreeConnectionSlot(direction network.Direction) bool
+
+	// IsReservedPeer checks if the peer is accepted regardless of the connection slots
+	IsReservedPeer(peerID peer.ID) bool
 }
 
 // IdentityService is a networking service used to handle peer handshaking.
@@ -63,8 +83,9 @@ type IdentityService struct {
 	logger                 hclog.Logger     // The IdentityService logger
 	baseServer             networkingServer // The interface towards the base networking server
 
-	chainID int64   // The chain ID of the network
-	hostID  peer.ID // The base networking server's host peer ID
+	chainID      int64    // The chain ID of the network
+	hostID       peer.ID  // The base networking server's host peer ID
+	compressions []string // The compressions of the protocol messages, by preference
 }
 
 // NewIdentityService returns a new instance of the IdentityService
@@ -73,12 +94,14 @@ func NewIdentityService(
 	logger hclog.Logger,
 	chainID int64,
 	hostID peer.ID,
+	compressions []string,
 ) *IdentityService {
 	return &IdentityService{
-		logger:     logger.Named("identity"),
-		baseServer: server,
-		chainID:    chainID,
-		hostID:     hostID,
+		logger:       logger.Named("identity"),
+		baseServer:   server,
+		chainID:      chainID,
+		hostID:       hostID,
+		compressions: compressions,
 	}
 }
 
@@ -93,7 +116,7 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				return
 			}
 
-			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
+			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) && !i.baseServer.IsReservedPeer(peerID) {
 				i.disconnectFromPeer(peerID, ErrNoAvailableSlots.Error())
 
 				return
@@ -185,6 +208,16 @@ func (i *IdentityService) handleConnected(peerID peer.ID, direction network.Dire
 	// If this is a NOT temporary connection, save it
 	if !resp.TemporaryDial && !status.TemporaryDial {
 		i.baseServer.AddPeer(peerID, direction)
+
+		compression := grpc.NegotiateCompression(
+			i.compressions,
+			strings.Split(resp.Metadata[Compressions], ","),
+		)
+		if compression != "" {
+			i.baseServer.SetPeerCompression(peerID, compression)
+		}
+
+		i.baseServer.SetPeerVersion(peerID, resp.Metadata[Version])
 	}
 
 	return nil
@@ -207,7 +240,9 @@ func (i *IdentityService) Hello(_ context.Context, req *proto.Status) (*proto.St
 func (i *IdentityService) constructStatus(peerID peer.ID) *proto.Status {
 	return &proto.Status{
 		Metadata: map[string]string{
-			PeerID: i.hostID.Pretty(),
+			PeerID:       i.hostID.Pretty(),
+			Compressions: strings.Join(i.compressions, ","),
+			Version:      versioning.Version,
 		},
 		Chain:         i.chainID,
 		TemporaryDial: i.baseServer.IsTemporaryDial(peerID),
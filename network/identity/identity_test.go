@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	networkGrpc "github.com/0xPolygon/polygon-edge/network/grpc"
 	"github.com/0xPolygon/polygon-edge/network/proto"
 	networkTesting "github.com/0xPolygon/polygon-edge/network/testing"
 	"github.com/hashicorp/go-hclog"
@@ -122,3 +123,95 @@ func TestHandshake_Errors(t *testing.T) {
 	// Make sure no peers have been  added to the base networking server
 	assert.Len(t, peersArray, 0)
 }
+
+// TestHandshake_Compression tests the compression of the protocol messages
+// is negotiated during handshaking
+func TestHandshake_Compression(t *testing.T) {
+	testTable := []struct {
+		name                string
+		localCompressions   []string
+		remoteCompressions  string
+		expectedCompression string
+	}{
+		{
+			"compression negotiated",
+			[]string{networkGrpc.GzipCompression, networkGrpc.SnappyCompression},
+			"snappy,gzip",
+			networkGrpc.GzipCompression,
+		},
+		{
+			"peer without compression",
+			[]string{networkGrpc.SnappyCompression},
+			"",
+			"",
+		},
+		{
+			"compression disabled",
+			nil,
+			"snappy,gzip",
+			"",
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			compression := ""
+
+			identityService := newIdentityService(
+				func(server *networkTesting.MockNetworkingServer) {
+					server.HookSetPeerCompression(func(_ peer.ID, c string) {
+						compression = c
+					})
+
+					server.GetMockIdentityClient().HookHello(func(
+						ctx context.Context,
+						in *proto.Status,
+						opts ...grpc.CallOption,
+					) (*proto.Status, error) {
+						return &proto.Status{
+							Metadata: map[string]string{
+								Compressions: testCase.remoteCompressions,
+							},
+						}, nil
+					})
+				},
+			)
+
+			identityService.compressions = testCase.localCompressions
+
+			assert.NoError(t, identityService.handleConnected("TestPeer", network.DirOutbound))
+			assert.Equal(t, testCase.expectedCompression, compression)
+		})
+	}
+}
+
+// TestHandshake_Version tests the version of the binary of the peer
+// is recorded during handshaking
+func TestHandshake_Version(t *testing.T) {
+	version := ""
+
+	identityService := newIdentityService(
+		func(server *networkTesting.MockNetworkingServer) {
+			server.HookSetPeerVersion(func(_ peer.ID, v string) {
+				version = v
+			})
+
+			server.GetMockIdentityClient().HookHello(func(
+				ctx context.Context,
+				in *proto.Status,
+				opts ...grpc.CallOption,
+			) (*proto.Status, error) {
+				return &proto.Status{
+					Metadata: map[string]string{
+						Version: "0.7.0",
+					},
+				}, nil
+			})
+		},
+	)
+
+	assert.NoError(t, identityService.handleConnected("TestPeer", network.DirOutbound))
+	assert.Equal(t, "0.7.0", version)
+}
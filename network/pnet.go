@@ -0,0 +1,63 @@
+package network
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/libp2p/go-libp2p/core/pnet"
+)
+
+// PSKLength is the length of the pre-shared key of a private network
+const PSKLength = 32
+
+var (
+	errInvalidPSKLength = fmt.Errorf("the pre-shared key must be %d bytes long", PSKLength)
+)
+
+// GeneratePSK generates a random pre-shared key of a private network, hex encoded
+func GeneratePSK() (string, error) {
+	psk := make([]byte, PSKLength)
+
+	if _, err := rand.Read(psk); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToHex(psk), nil
+}
+
+// ParsePSK parses the hex encoded pre-shared key of a private network
+func ParsePSK(raw string) (pnet.PSK, error) {
+	psk, err := hex.DecodeHex(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pre-shared key: %w", err)
+	}
+
+	if len(psk) != PSKLength {
+		return nil, errInvalidPSKLength
+	}
+
+	return psk, nil
+}
+
+// ReadPSKFile reads the pre-shared key of a private network from the file,
+// either hex encoded or in the /key/swarm/psk/1.0.0/ format of the libp2p tooling
+func ReadPSKFile(path string) (pnet.PSK, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the pre-shared key file: %w", err)
+	}
+
+	if !strings.HasPrefix(string(content), "/key/") {
+		return ParsePSK(string(content))
+	}
+
+	psk, err := pnet.DecodeV1PSK(strings.NewReader(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pre-shared key file: %w", err)
+	}
+
+	return psk, nil
+}
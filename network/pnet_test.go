@@ -0,0 +1,96 @@
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePSK(t *testing.T) {
+	t.Parallel()
+
+	key, err := GeneratePSK()
+	require.NoError(t, err)
+
+	psk, err := ParsePSK(key)
+	require.NoError(t, err)
+	assert.Len(t, psk, PSKLength)
+
+	_, err = ParsePSK("0x1234")
+	assert.ErrorIs(t, err, errInvalidPSKLength)
+
+	_, err = ParsePSK("invalid")
+	assert.Error(t, err)
+}
+
+func TestReadPSKFile(t *testing.T) {
+	t.Parallel()
+
+	key, err := GeneratePSK()
+	require.NoError(t, err)
+
+	expected, err := ParsePSK(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"hex": key + "\n",
+		"v1": strings.Join([]string{
+			"/key/swarm/psk/1.0.0/",
+			"/base16/",
+			strings.TrimPrefix(key, "0x"),
+		}, "\n"),
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+		psk, err := ReadPSKFile(path)
+		require.NoError(t, err, name)
+		assert.Equal(t, hex.EncodeToHex(expected), hex.EncodeToHex(psk), name)
+	}
+
+	_, err = ReadPSKFile(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestPrivateNetwork(t *testing.T) {
+	keys := make([]string, 2)
+
+	for i := range keys {
+		var err error
+
+		keys[i], err = GeneratePSK()
+		require.NoError(t, err)
+	}
+
+	servers := make([]*Server, 3)
+
+	for i, key := range []string{keys[0], keys[0], keys[1]} {
+		psk, err := ParsePSK(key)
+		require.NoError(t, err)
+
+		servers[i], err = CreateServer(&CreateServerParams{
+			ConfigCallback: func(c *Config) {
+				c.PSK = psk
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	// the nodes holding the same key connect
+	require.NoError(t, JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout))
+
+	// the node holding another key can't join
+	assert.Error(t, JoinAndWait(servers[2], servers[0], DefaultBufferTimeout, DefaultBufferTimeout))
+	assert.False(t, servers[0].hasPeer(servers[2].AddrInfo().ID))
+}
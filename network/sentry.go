@@ -0,0 +1,112 @@
+package network
+
+import (
+	"github.com/0xPolygon/polygon-edge/network/common"
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// A validator behind sentries only connects to its sentries, which handle the public
+// networking for it. The sentries keep the validator as a private peer: they accept it
+// past the connection limits and never share it through discovery.
+// Both sides are direct gossip peers, so the consensus messages are forwarded between them
+// as soon as they are received, without waiting for the gossip mesh
+
+// sentryGater restricts the connections of a validator behind sentries to the sentries
+type sentryGater struct {
+	sentries map[peer.ID]struct{}
+}
+
+func newSentryGater(sentries []*peer.AddrInfo) connmgr.ConnectionGater {
+	gater := &sentryGater{
+		sentries: make(map[peer.ID]struct{}, len(sentries)),
+	}
+
+	for _, sentry := range sentries {
+		gater.sentries[sentry.ID] = struct{}{}
+	}
+
+	return gater
+}
+
+func (g *sentryGater) isSentry(id peer.ID) bool {
+	_, ok := g.sentries[id]
+
+	return ok
+}
+
+func (g *sentryGater) InterceptPeerDial(id peer.ID) bool {
+	return g.isSentry(id)
+}
+
+func (g *sentryGater) InterceptAddrDial(id peer.ID, _ multiaddr.Multiaddr) bool {
+	return g.isSentry(id)
+}
+
+// InterceptAccept accepts any inbound connection, the peer is only known once secured
+func (g *sentryGater) InterceptAccept(network.ConnMultiaddrs) bool {
+	return true
+}
+
+func (g *sentryGater) InterceptSecured(_ network.Direction, id peer.ID, _ network.ConnMultiaddrs) bool {
+	return g.isSentry(id)
+}
+
+func (g *sentryGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// isBehindSentries checks if the node only connects to its sentries
+func (s *Server) isBehindSentries() bool {
+	return len(s.config.Sentries) > 0
+}
+
+// isPrivatePeer checks if the peer is a private peer of the sentry
+func (s *Server) isPrivatePeer(id peer.ID) bool {
+	_, ok := s.privatePeers[id]
+
+	return ok
+}
+
+// IsReservedPeer checks if the peer is a sentry of the node or a private peer behind it,
+// which are always accepted regardless of the connection limits
+func (s *Server) IsReservedPeer(id peer.ID) bool {
+	if s.isPrivatePeer(id) {
+		return true
+	}
+
+	for _, sentry := range s.config.Sentries {
+		if sentry.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// directPeers are the gossip peers the messages are forwarded to right away
+func directPeers(config *Config) []peer.AddrInfo {
+	peers := make([]peer.AddrInfo, 0, len(config.Sentries)+len(config.PrivatePeers))
+
+	for _, sentry := range config.Sentries {
+		peers = append(peers, *sentry)
+	}
+
+	for _, id := range config.PrivatePeers {
+		peers = append(peers, peer.AddrInfo{ID: id})
+	}
+
+	return peers
+}
+
+// dialSentries dials the sentries the node isn't connected to
+func (s *Server) dialSentries() {
+	for _, sentry := range s.config.Sentries {
+		if !s.hasPeer(sentry.ID) {
+			s.addToDialQueue(sentry, common.PriorityRequestedDial)
+		}
+	}
+}
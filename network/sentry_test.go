@@ -0,0 +1,119 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testproto "github.com/0xPolygon/polygon-edge/network/proto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSentryGater(t *testing.T) {
+	t.Parallel()
+
+	sentryKey, _ := GenerateTestLibp2pKey(t)
+	otherKey, _ := GenerateTestLibp2pKey(t)
+
+	sentryID, err := peer.IDFromPrivateKey(sentryKey)
+	require.NoError(t, err)
+
+	otherID, err := peer.IDFromPrivateKey(otherKey)
+	require.NoError(t, err)
+
+	gater := newSentryGater([]*peer.AddrInfo{{ID: sentryID}})
+
+	assert.True(t, gater.InterceptPeerDial(sentryID))
+	assert.False(t, gater.InterceptPeerDial(otherID))
+	assert.True(t, gater.InterceptSecured(0, sentryID, nil))
+	assert.False(t, gater.InterceptSecured(0, otherID, nil))
+}
+
+// TestSentryTopology checks a validator behind a sentry is only reachable through it
+func TestSentryTopology(t *testing.T) {
+	validatorKey, validatorDir := GenerateTestLibp2pKey(t)
+
+	validatorID, err := peer.IDFromPrivateKey(validatorKey)
+	require.NoError(t, err)
+
+	sentry, err := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.PrivatePeers = []peer.ID{validatorID}
+		},
+	})
+	require.NoError(t, err)
+
+	validator, err := CreateServer(&CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.DataDir = validatorDir
+			c.Sentries = []*peer.AddrInfo{sentry.AddrInfo()}
+		},
+	})
+	require.NoError(t, err)
+
+	public, err := CreateServer(nil)
+	require.NoError(t, err)
+
+	servers := []*Server{sentry, validator, public}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	// the validator dials its sentry by itself
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancel()
+
+	_, err = WaitUntilPeerConnectsTo(ctx, validator, sentry.AddrInfo().ID)
+	require.NoError(t, err)
+
+	require.NoError(t, JoinAndWait(public, sentry, DefaultBufferTimeout, DefaultJoinTimeout))
+
+	// the validator isn't reachable from the public network
+	assert.Error(t, JoinAndWait(public, validator, DefaultBufferTimeout, DefaultBufferTimeout))
+	assert.False(t, validator.hasPeer(public.AddrInfo().ID))
+
+	// the sentry doesn't share the validator through discovery
+	assert.NotContains(t, sentry.discovery.RoutingTablePeers(), validatorID)
+
+	// the messages of the public network reach the validator through the sentry
+	topicName := "sentry-msg"
+	topics := make([]*Topic, len(servers))
+
+	for i, server := range servers {
+		topics[i], err = server.NewTopic(topicName, &testproto.GenericMessage{})
+		require.NoError(t, err)
+	}
+
+	messageCh := make(chan string, 1)
+
+	require.NoError(t, topics[1].Subscribe(func(obj interface{}, _ peer.ID) {
+		if msg, ok := obj.(*testproto.GenericMessage); ok {
+			select {
+			case messageCh <- msg.Message:
+			default:
+			}
+		}
+	}))
+
+	for _, topic := range []*Topic{topics[0], topics[2]} {
+		require.NoError(t, topic.Subscribe(func(interface{}, peer.ID) {}))
+	}
+
+	subscribeCtx, subscribeCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer subscribeCancel()
+
+	require.NoError(t, WaitForSubscribers(subscribeCtx, public, topicName, 1))
+	require.NoError(t, WaitForSubscribers(subscribeCtx, sentry, topicName, 2))
+
+	require.NoError(t, topics[2].Publish(&testproto.GenericMessage{Message: "relayed"}))
+
+	select {
+	case msg := <-messageCh:
+		assert.Equal(t, "relayed", msg)
+	case <-time.After(15 * time.Second):
+		t.Fatal("message not relayed to the validator")
+	}
+}
@@ -85,6 +85,10 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	privatePeers map[peer.ID]struct{} // the peers behind the sentry
+
+	aclGater *aclGater // the gater enforcing the access control list of the peer connections
 }
 
 // NewServer returns a new instance of the networking server
@@ -96,32 +100,37 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		return nil, err
 	}
 
-	listenAddr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", config.Addr.IP.String(), config.Addr.Port))
+	listenAddrs, err := listenAddrs(config)
 	if err != nil {
 		return nil, err
 	}
 
-	addrsFactory := func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
-		if config.NatAddr != nil {
-			addr, _ := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", config.NatAddr.String(), config.Addr.Port))
+	options := []libp2p.Option{
+		// Use noise as the encryption protocol
+		libp2p.Security(noise.ID, noise.New),
+		libp2p.ListenAddrs(listenAddrs...),
+		libp2p.AddrsFactory(advertisedAddrs(config)),
+		libp2p.Identity(key),
+	}
 
-			if addr != nil {
-				addrs = []multiaddr.Multiaddr{addr}
-			}
-		} else if config.DNS != nil {
-			addrs = []multiaddr.Multiaddr{config.DNS}
-		}
+	if len(config.PSK) > 0 {
+		// Only the peers holding the pre-shared key can connect
+		options = append(options, libp2p.PrivateNetwork(config.PSK))
+	}
 
-		return addrs
+	acl := newACLGater(config.ACL)
+	connectionGaters := gaters{acl}
+
+	if len(config.Sentries) > 0 {
+		// The validator behind sentries is only known to them
+		config.NoDiscover = true
+
+		connectionGaters = append(connectionGaters, newSentryGater(config.Sentries))
 	}
 
-	host, err := libp2p.New(
-		// Use noise as the encryption protocol
-		libp2p.Security(noise.ID, noise.New),
-		libp2p.ListenAddrs(listenAddr),
-		libp2p.AddrsFactory(addrsFactory),
-		libp2p.Identity(key),
-	)
+	options = append(options, libp2p.ConnectionGater(connectionGaters))
+
+	host, err := libp2p.New(options...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create libp2p stack: %w", err)
 	}
@@ -151,6 +160,12 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
 		),
+		privatePeers: make(map[peer.ID]struct{}, len(config.PrivatePeers)),
+		aclGater:     acl,
+	}
+
+	for _, id := range config.PrivatePeers {
+		srv.privatePeers[id] = struct{}{}
 	}
 
 	// start gossip protocol
@@ -158,6 +173,8 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		context.Background(),
 		host, pubsub.WithPeerOutboundQueueSize(peerOutboundBufferSize),
 		pubsub.WithValidateQueueSize(validateBufferSize),
+		pubsub.WithDirectPeers(directPeers(config)),
+		pubsub.WithPeerScore(peerScoreParams()),
 	)
 	if err != nil {
 		return nil, err
@@ -179,6 +196,12 @@ type PeerConnInfo struct {
 
 	connDirections  map[network.Direction]bool
 	protocolStreams map[string]*rawGrpc.ClientConn
+
+	// compression is the compression of the protocol messages sent to the peer
+	compression string
+
+	// version is the version of the binary of the peer advertised in the handshake
+	version string
 }
 
 // addProtocolStream adds a protocol stream
@@ -261,6 +284,10 @@ func (s *Server) Start() error {
 	go s.runDial()
 	go s.keepAliveMinimumPeerConnections()
 
+	if s.isBehindSentries() {
+		s.dialSentries()
+	}
+
 	// watch for disconnected peers
 	s.host.Network().Notify(&network.NotifyBundle{
 		DisconnectedF: func(net network.Network, conn network.Conn) {
@@ -325,6 +352,13 @@ func (s *Server) keepAliveMinimumPeerConnections() {
 			return
 		}
 
+		if s.isBehindSentries() {
+			// the sentries are the only peers, they are kept connected
+			s.dialSentries()
+
+			continue
+		}
+
 		if s.numPeers() < MinimumPeerConnections {
 			if s.config.NoDiscover || !s.bootnodes.hasBootnodes() {
 				// dial unconnected peer
@@ -407,7 +441,12 @@ func (s *Server) runDial() {
 			if !s.IsConnected(peerInfo.ID) {
 				// the connection process is async because it involves connection (here) +
 				// the handshake done in the identity service.
-				if err := s.host.Connect(context.Background(), *peerInfo); err != nil {
+				dialInfo := peer.AddrInfo{
+					ID:    peerInfo.ID,
+					Addrs: s.preferReachableAddrs(peerInfo.Addrs),
+				}
+
+				if err := s.host.Connect(context.Background(), dialInfo); err != nil {
 					s.logger.Debug("failed to dial", "addr", peerInfo.String(), "err", err.Error())
 
 					s.emitEvent(peerInfo.ID, peerEvent.PeerFailedToConnect)
@@ -543,6 +582,14 @@ func (s *Server) DisconnectFromPeer(peer peer.ID, reason string) {
 	}
 }
 
+// DisconnectFromPeers closes the connections to all the peers, which are notified
+// at once rather than on the timeout of the connections
+func (s *Server) DisconnectFromPeers(reason string) {
+	for _, peerID := range s.host.Network().Peers() {
+		s.DisconnectFromPeer(peerID, reason)
+	}
+}
+
 var (
 	// Anything below 35s is prone to false timeouts, as seen from empirical test data
 	DefaultJoinTimeout   = 100 * time.Second
@@ -596,6 +643,12 @@ func (s *Server) Close() error {
 // NewProtoConnection opens up a new stream on the set protocol to the peer,
 // and returns a reference to the connection
 func (s *Server) NewProtoConnection(protocol string, peerID peer.ID) (*rawGrpc.ClientConn, error) {
+	var opts []rawGrpc.DialOption
+
+	if compression := s.getPeerCompression(peerID); compression != "" {
+		opts = append(opts, rawGrpc.WithDefaultCallOptions(rawGrpc.UseCompressor(compression)))
+	}
+
 	s.protocolsLock.Lock()
 	defer s.protocolsLock.Unlock()
 
@@ -609,7 +662,7 @@ func (s *Server) NewProtoConnection(protocol string, peerID peer.ID) (*rawGrpc.C
 		return nil, err
 	}
 
-	return p.Client(stream), nil
+	return p.Client(stream, opts...), nil
 }
 
 func (s *Server) NewStream(proto string, id peer.ID) (network.Stream, error) {
@@ -617,7 +670,7 @@ func (s *Server) NewStream(proto string, id peer.ID) (network.Stream, error) {
 }
 
 type Protocol interface {
-	Client(network.Stream) *rawGrpc.ClientConn
+	Client(network.Stream, ...rawGrpc.DialOption) *rawGrpc.ClientConn
 	Handler() func(network.Stream)
 }
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/network/discovery"
+	peerEvent "github.com/0xPolygon/polygon-edge/network/event"
 	"github.com/0xPolygon/polygon-edge/network/grpc"
 	"github.com/0xPolygon/polygon-edge/network/proto"
 	kb "github.com/libp2p/go-libp2p-kbucket"
@@ -131,7 +132,8 @@ func (s *Server) CloseProtocolStream(protocol string, peerID peer.ID) error {
 
 // AddToPeerStore adds peer information to the node's peer store
 func (s *Server) AddToPeerStore(peerInfo *peer.AddrInfo) {
-	s.host.Peerstore().AddAddr(peerInfo.ID, peerInfo.Addrs[0], peerstore.AddressTTL)
+	// the addresses of the IP versions the node doesn't listen on are never dialed
+	s.host.Peerstore().AddAddrs(peerInfo.ID, s.reachableAddrs(peerInfo.Addrs), peerstore.AddressTTL)
 }
 
 // RemoveFromPeerStore removes peer information from the node's peer store
@@ -223,7 +225,14 @@ func (s *Server) setupDiscovery() error {
 	)
 
 	// Register a network event handler
-	if subscribeErr := s.SubscribeFn(context.Background(), discoveryService.HandleNetworkEvent); subscribeErr != nil {
+	handleNetworkEvent := func(event *peerEvent.PeerEvent) {
+		// The private peers are never added to the routing table, so never shared
+		if !s.isPrivatePeer(event.PeerID) {
+			discoveryService.HandleNetworkEvent(event)
+		}
+	}
+
+	if subscribeErr := s.SubscribeFn(context.Background(), handleNetworkEvent); subscribeErr != nil {
 		return fmt.Errorf("unable to subscribe to network events, %w", subscribeErr)
 	}
 
@@ -87,6 +87,52 @@ func (s *Server) addPeerInfo(id peer.ID, direction network.Direction) bool {
 	return false
 }
 
+// SetPeerCompression sets the compression of the protocol messages sent to the peer [Thread safe]
+func (s *Server) SetPeerCompression(id peer.ID, compression string) {
+	s.peersLock.Lock()
+	defer s.peersLock.Unlock()
+
+	if connectionInfo, ok := s.peers[id]; ok {
+		connectionInfo.compression = compression
+	}
+}
+
+// getPeerCompression returns the compression of the protocol messages sent to the peer [Thread safe]
+func (s *Server) getPeerCompression(id peer.ID) string {
+	s.peersLock.Lock()
+	defer s.peersLock.Unlock()
+
+	if connectionInfo, ok := s.peers[id]; ok {
+		return connectionInfo.compression
+	}
+
+	return ""
+}
+
+// SetPeerVersion sets the version of the binary of the peer [Thread safe]
+func (s *Server) SetPeerVersion(id peer.ID, version string) {
+	s.peersLock.Lock()
+	defer s.peersLock.Unlock()
+
+	if connectionInfo, ok := s.peers[id]; ok {
+		connectionInfo.version = version
+	}
+}
+
+// PeerVersions returns the versions of the binaries of the connected peers,
+// empty for the peers which didn't advertise their version [Thread safe]
+func (s *Server) PeerVersions() map[peer.ID]string {
+	s.peersLock.Lock()
+	defer s.peersLock.Unlock()
+
+	versions := make(map[peer.ID]string, len(s.peers))
+	for id, connectionInfo := range s.peers {
+		versions[id] = connectionInfo.version
+	}
+
+	return versions
+}
+
 // UpdatePendingConnCount updates the pending connection count in the specified direction [Thread safe]
 func (s *Server) UpdatePendingConnCount(delta int64, direction network.Direction) {
 	s.connectionCounts.UpdatePendingConnCountByDirection(delta, direction)
@@ -114,6 +160,7 @@ func (s *Server) setupIdentity() error {
 		s.logger,
 		int64(s.config.Chain.Params.ChainID),
 		s.host.ID(),
+		s.config.Compressions,
 	)
 
 	// Register the identity service protocol
@@ -26,10 +26,13 @@ type MockNetworkingServer struct {
 	newIdentityClientFn      newIdentityClientDelegate
 	disconnectFromPeerFn     disconnectFromPeerDelegate
 	addPeerFn                addPeerDelegate
+	setPeerCompressionFn     setPeerCompressionDelegate
+	setPeerVersionFn         setPeerVersionDelegate
 	updatePendingConnCountFn updatePendingConnCountDelegate
 	emitEventFn              emitEventDelegate
 	isTemporaryDialFn        isTemporaryDialDelegate
 	hasFreeConnectionSlotFn  hasFreeConnectionSlotDelegate
+	isReservedPeerFn         isReservedPeerDelegate
 
 	// Discovery Hooks
 	newDiscoveryClientFn       newDiscoveryClientDelegate
@@ -70,10 +73,13 @@ func (m *MockNetworkingServer) GetMockPeerMetrics() *MockPeerMetrics {
 type newIdentityClientDelegate func(peer.ID) (proto.IdentityClient, error)
 type disconnectFromPeerDelegate func(peer.ID, string)
 type addPeerDelegate func(peer.ID, network.Direction)
+type setPeerCompressionDelegate func(peer.ID, string)
+type setPeerVersionDelegate func(peer.ID, string)
 type updatePendingConnCountDelegate func(int64, network.Direction)
 type emitEventDelegate func(*event.PeerEvent)
 type isTemporaryDialDelegate func(peer.ID) bool
 type hasFreeConnectionSlotDelegate func(network.Direction) bool
+type isReservedPeerDelegate func(peer.ID) bool
 
 // Required for Discovery
 type getRandomBootnodeDelegate func() *peer.AddrInfo
@@ -130,6 +136,26 @@ func (m *MockNetworkingServer) HookAddPeer(fn addPeerDelegate) {
 	m.addPeerFn = fn
 }
 
+func (m *MockNetworkingServer) SetPeerCompression(id peer.ID, compression string) {
+	if m.setPeerCompressionFn != nil {
+		m.setPeerCompressionFn(id, compression)
+	}
+}
+
+func (m *MockNetworkingServer) HookSetPeerCompression(fn setPeerCompressionDelegate) {
+	m.setPeerCompressionFn = fn
+}
+
+func (m *MockNetworkingServer) SetPeerVersion(id peer.ID, version string) {
+	if m.setPeerVersionFn != nil {
+		m.setPeerVersionFn(id, version)
+	}
+}
+
+func (m *MockNetworkingServer) HookSetPeerVersion(fn setPeerVersionDelegate) {
+	m.setPeerVersionFn = fn
+}
+
 func (m *MockNetworkingServer) UpdatePendingConnCount(delta int64, direction network.Direction) {
 	if m.updatePendingConnCountFn != nil {
 		m.updatePendingConnCountFn(delta, direction)
@@ -174,6 +200,18 @@ func (m *MockNetworkingServer) HookHasFreeConnectionSlot(fn hasFreeConnectionSlo
 	m.hasFreeConnectionSlotFn = fn
 }
 
+func (m *MockNetworkingServer) IsReservedPeer(peerID peer.ID) bool {
+	if m.isReservedPeerFn != nil {
+		return m.isReservedPeerFn(peerID)
+	}
+
+	return false
+}
+
+func (m *MockNetworkingServer) HookIsReservedPeer(fn isReservedPeerDelegate) {
+	m.isReservedPeerFn = fn
+}
+
 func (m *MockNetworkingServer) GetRandomBootnode() *peer.AddrInfo {
 	if m.getRandomBootnodeFn != nil {
 		return m.getRandomBootnodeFn()
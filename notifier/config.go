@@ -0,0 +1,156 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+)
+
+const (
+	// DefaultMaxRetries is the number of the delivery retries of a notification
+	DefaultMaxRetries uint64 = 5
+
+	// DefaultTimeout is the timeout of a single delivery attempt
+	DefaultTimeout = 10 * time.Second
+)
+
+var (
+	ErrMissingURL       = errors.New("webhook url is missing")
+	ErrMissingEvents    = errors.New("webhook has no events")
+	ErrUnknownEventType = errors.New("unknown event type")
+)
+
+// EventType is the type of the notified event
+type EventType string
+
+const (
+	// EventBlock is notified for every new canonical block
+	EventBlock EventType = "block"
+
+	// EventReorg is notified when canonical blocks are replaced
+	EventReorg EventType = "reorg"
+
+	// EventLog is notified for every log of a new canonical block matching the filters
+	EventLog EventType = "log"
+
+	// EventValidators is notified when the validator set changes
+	EventValidators EventType = "validators"
+)
+
+// eventTypes is the map used for easy string -> EventType lookups
+var eventTypes = map[EventType]struct{}{
+	EventBlock:      {},
+	EventReorg:      {},
+	EventLog:        {},
+	EventValidators: {},
+}
+
+// Config is the notifier configuration read from the notifier config file
+type Config struct {
+	Webhooks []*WebhookConfig `json:"webhooks"`
+}
+
+// WebhookConfig is the configuration of a single webhook
+type WebhookConfig struct {
+	// URL is the endpoint the notifications are POSTed to
+	URL string `json:"url"`
+
+	// Events are the event types delivered to the webhook
+	Events []EventType `json:"events"`
+
+	// Logs are the eth_getLogs style filters of the log events.
+	// All the logs are delivered if no filters are set
+	Logs []*jsonrpc.LogQuery `json:"logs"`
+
+	// Headers are the additional HTTP headers of the requests, e.g. authorization
+	Headers map[string]string `json:"headers"`
+
+	// MaxRetries is the number of the retries of a failed delivery
+	MaxRetries *uint64 `json:"max_retries"`
+
+	// Timeout is the timeout of a delivery attempt, e.g. "5s"
+	Timeout string `json:"timeout"`
+}
+
+// ReadConfig reads the notifier Config from the specified path
+func ReadConfig(path string) (*Config, error) {
+	configFile, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	config := &Config{}
+
+	if unmarshalErr := json.Unmarshal(configFile, config); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Validate checks the webhooks are correctly configured
+func (c *Config) Validate() error {
+	for i, webhook := range c.Webhooks {
+		if err := webhook.validate(); err != nil {
+			return fmt.Errorf("invalid webhook #%d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *WebhookConfig) validate() error {
+	if c.URL == "" {
+		return ErrMissingURL
+	}
+
+	if _, err := url.ParseRequestURI(c.URL); err != nil {
+		return err
+	}
+
+	if len(c.Events) == 0 {
+		return ErrMissingEvents
+	}
+
+	for _, event := range c.Events {
+		if _, ok := eventTypes[event]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownEventType, event)
+		}
+	}
+
+	if c.Timeout != "" {
+		if _, err := time.ParseDuration(c.Timeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// maxRetries returns the configured number of retries or the default one
+func (c *WebhookConfig) maxRetries() uint64 {
+	if c.MaxRetries == nil {
+		return DefaultMaxRetries
+	}
+
+	return *c.MaxRetries
+}
+
+// timeout returns the configured delivery timeout or the default one
+func (c *WebhookConfig) timeout() time.Duration {
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil || timeout <= 0 {
+		return DefaultTimeout
+	}
+
+	return timeout
+}
@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Notification is the JSON payload delivered to the webhooks
+type Notification struct {
+	Type EventType `json:"type"`
+
+	// Block is the block of the event, the new head for the reorgs
+	Block *Block `json:"block"`
+
+	// Removed and Added are the replaced and the new canonical blocks of a reorg
+	Removed []*Block `json:"removed,omitempty"`
+	Added   []*Block `json:"added,omitempty"`
+
+	// Log is the matched log
+	Log *Log `json:"log,omitempty"`
+
+	// Validators is the new validator set and its changes
+	Validators *ValidatorSet `json:"validators,omitempty"`
+}
+
+// Block is the reference to a block
+type Block struct {
+	Number     uint64     `json:"number"`
+	Hash       types.Hash `json:"hash"`
+	ParentHash types.Hash `json:"parentHash"`
+	Timestamp  uint64     `json:"timestamp"`
+}
+
+// Log is the log emitted by a transaction
+type Log struct {
+	Address  types.Address `json:"address"`
+	Topics   []types.Hash  `json:"topics"`
+	Data     string        `json:"data"`
+	TxHash   types.Hash    `json:"transactionHash"`
+	TxIndex  uint64        `json:"transactionIndex"`
+	LogIndex uint64        `json:"logIndex"`
+}
+
+// ValidatorSet is the validator set of a block and its changes from the parent block
+type ValidatorSet struct {
+	Validators []types.Address `json:"validators"`
+	Added      []types.Address `json:"added"`
+	Removed    []types.Address `json:"removed"`
+}
+
+func toBlock(header *types.Header) *Block {
+	return &Block{
+		Number:     header.Number,
+		Hash:       header.Hash,
+		ParentHash: header.ParentHash,
+		Timestamp:  header.Timestamp,
+	}
+}
+
+func toBlocks(headers []*types.Header) []*Block {
+	blocks := make([]*Block, len(headers))
+	for i, header := range headers {
+		blocks[i] = toBlock(header)
+	}
+
+	return blocks
+}
@@ -0,0 +1,279 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Blockchain is the interface of the blockchain the notifier follows
+type Blockchain interface {
+	SubscribeEvents() blockchain.Subscription
+	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+}
+
+// ValidatorSetProvider returns the validator set of a height
+type ValidatorSetProvider interface {
+	GetValidators(height uint64) (validators.Validators, error)
+}
+
+// Notifier follows the canonical chain and delivers the block, reorg,
+// log and validator set change notifications to the configured webhooks
+type Notifier struct {
+	logger        hclog.Logger
+	blockchain    Blockchain
+	validatorSets ValidatorSetProvider
+
+	sinks []*sink
+
+	// lastValidators is the validator set of the latest notified block
+	lastValidators validators.Validators
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewNotifier creates a notifier delivering to the webhooks of the config.
+// The validator set changes are only notified if validatorSets is not nil
+func NewNotifier(
+	logger hclog.Logger,
+	config *Config,
+	blockchain Blockchain,
+	validatorSets ValidatorSetProvider,
+) *Notifier {
+	n := &Notifier{
+		logger:        logger.Named("notifier"),
+		blockchain:    blockchain,
+		validatorSets: validatorSets,
+		sinks:         make([]*sink, 0, len(config.Webhooks)),
+	}
+
+	for _, webhook := range config.Webhooks {
+		n.sinks = append(n.sinks, newSink(
+			n.logger.With("url", webhook.URL),
+			newWebhookPublisher(webhook),
+			webhook,
+		))
+	}
+
+	return n
+}
+
+// Start starts following the chain and delivering the notifications
+func (n *Notifier) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancel = cancel
+
+	for _, s := range n.sinks {
+		s := s
+
+		n.wg.Add(1)
+
+		go func() {
+			defer n.wg.Done()
+
+			s.run(ctx)
+		}()
+	}
+
+	sub := n.blockchain.SubscribeEvents()
+
+	n.wg.Add(1)
+
+	go func() {
+		defer n.wg.Done()
+		defer sub.Close()
+
+		eventCh := sub.GetEventCh()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-eventCh:
+				if ev == nil {
+					return
+				}
+
+				n.handleEvent(ev)
+			}
+		}
+	}()
+}
+
+// Close stops the notifier, the pending notifications are not delivered
+func (n *Notifier) Close() {
+	if n.cancel != nil {
+		n.cancel()
+	}
+
+	n.wg.Wait()
+}
+
+// handleEvent notifies the changes of the canonical chain
+func (n *Notifier) handleEvent(ev *blockchain.Event) {
+	if ev.Type == blockchain.EventFork || len(ev.NewChain) == 0 {
+		// forks don't change the canonical chain
+		return
+	}
+
+	if ev.Type == blockchain.EventReorg {
+		// the last validator set may belong to a removed block
+		n.lastValidators = nil
+
+		n.notify(&Notification{
+			Type:    EventReorg,
+			Block:   toBlock(ev.Header()),
+			Removed: toBlocks(ev.OldChain),
+			Added:   toBlocks(ev.NewChain),
+		}, nil)
+	}
+
+	for _, header := range ev.NewChain {
+		n.notify(&Notification{
+			Type:  EventBlock,
+			Block: toBlock(header),
+		}, nil)
+
+		n.notifyLogs(header)
+		n.notifyValidators(header)
+	}
+}
+
+// notifyLogs notifies the logs of the block matching the filters of the subscribers
+func (n *Notifier) notifyLogs(header *types.Header) {
+	if !n.hasSubscribers(EventLog) {
+		return
+	}
+
+	receipts, err := n.blockchain.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		n.logger.Error("failed to get receipts", "hash", header.Hash, "err", err)
+
+		return
+	}
+
+	var logIndex uint64
+
+	for txIndex, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			log := log
+
+			n.notify(&Notification{
+				Type:  EventLog,
+				Block: toBlock(header),
+				Log: &Log{
+					Address:  log.Address,
+					Topics:   log.Topics,
+					Data:     hex.EncodeToHex(log.Data),
+					TxHash:   receipt.TxHash,
+					TxIndex:  uint64(txIndex),
+					LogIndex: logIndex,
+				},
+			}, func(s *sink) bool {
+				return s.matchLog(log)
+			})
+
+			logIndex++
+		}
+	}
+}
+
+// notifyValidators notifies the validator set of the block if it differs from the parent one
+func (n *Notifier) notifyValidators(header *types.Header) {
+	if n.validatorSets == nil || !n.hasSubscribers(EventValidators) || header.Number == 0 {
+		return
+	}
+
+	prev := n.lastValidators
+	if prev == nil {
+		var err error
+
+		if prev, err = n.validatorSets.GetValidators(header.Number - 1); err != nil {
+			n.logger.Error("failed to get validators", "height", header.Number-1, "err", err)
+
+			return
+		}
+	}
+
+	curr, err := n.validatorSets.GetValidators(header.Number)
+	if err != nil {
+		n.logger.Error("failed to get validators", "height", header.Number, "err", err)
+
+		return
+	}
+
+	n.lastValidators = curr
+
+	set := &ValidatorSet{
+		Validators: make([]types.Address, 0, curr.Len()),
+		Added:      []types.Address{},
+		Removed:    []types.Address{},
+	}
+
+	for i := 0; i < curr.Len(); i++ {
+		addr := curr.At(uint64(i)).Addr()
+
+		set.Validators = append(set.Validators, addr)
+
+		if !prev.Includes(addr) {
+			set.Added = append(set.Added, addr)
+		}
+	}
+
+	for i := 0; i < prev.Len(); i++ {
+		if addr := prev.At(uint64(i)).Addr(); !curr.Includes(addr) {
+			set.Removed = append(set.Removed, addr)
+		}
+	}
+
+	if len(set.Added) == 0 && len(set.Removed) == 0 {
+		return
+	}
+
+	n.notify(&Notification{
+		Type:       EventValidators,
+		Block:      toBlock(header),
+		Validators: set,
+	}, nil)
+}
+
+// hasSubscribers returns whether any destination is subscribed to the event type
+func (n *Notifier) hasSubscribers(typ EventType) bool {
+	for _, s := range n.sinks {
+		if s.subscribed(typ) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notify queues the notification to the subscribed destinations accepted by the filter
+func (n *Notifier) notify(notification *Notification, filter func(*sink) bool) {
+	var payload []byte
+
+	for _, s := range n.sinks {
+		if !s.subscribed(notification.Type) || (filter != nil && !filter(s)) {
+			continue
+		}
+
+		if payload == nil {
+			var err error
+
+			if payload, err = json.Marshal(notification); err != nil {
+				n.logger.Error("failed to encode notification", "type", notification.Type, "err", err)
+
+				return
+			}
+		}
+
+		s.enqueue(payload)
+	}
+}
@@ -0,0 +1,352 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+
+	topic1 = types.StringToHash("1")
+)
+
+type mockBlockchain struct {
+	sub      *blockchain.MockSubscription
+	receipts map[types.Hash][]*types.Receipt
+}
+
+func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
+	return m.sub
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.receipts[hash], nil
+}
+
+type mockValidatorSetProvider map[uint64]validators.Validators
+
+func (m mockValidatorSetProvider) GetValidators(height uint64) (validators.Validators, error) {
+	vals, ok := m[height]
+	if !ok {
+		return nil, errors.New("validators not found")
+	}
+
+	return vals, nil
+}
+
+// newTestWebhook starts a webhook collecting the received notifications
+func newTestWebhook(t *testing.T) (string, <-chan *Notification) {
+	t.Helper()
+
+	received := make(chan *Notification, 16)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		notification := &Notification{}
+		assert.NoError(t, json.Unmarshal(body, notification))
+
+		received <- notification
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv.URL, received
+}
+
+func receiveNotification(t *testing.T, received <-chan *Notification) *Notification {
+	t.Helper()
+
+	select {
+	case notification := <-received:
+		return notification
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification not received")
+	}
+
+	return nil
+}
+
+func newHeader(number uint64, parent *types.Header) *types.Header {
+	header := &types.Header{
+		Number:    number,
+		Timestamp: number,
+	}
+
+	if parent != nil {
+		header.ParentHash = parent.Hash
+	}
+
+	return header.ComputeHash()
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		webhook *WebhookConfig
+		err     error
+	}{
+		{
+			"valid",
+			&WebhookConfig{URL: "http://localhost:8080/hook", Events: []EventType{EventBlock, EventLog}},
+			nil,
+		},
+		{
+			"missing url",
+			&WebhookConfig{Events: []EventType{EventBlock}},
+			ErrMissingURL,
+		},
+		{
+			"missing events",
+			&WebhookConfig{URL: "http://localhost:8080/hook"},
+			ErrMissingEvents,
+		},
+		{
+			"unknown event",
+			&WebhookConfig{URL: "http://localhost:8080/hook", Events: []EventType{"transaction"}},
+			ErrUnknownEventType,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := &Config{Webhooks: []*WebhookConfig{c.webhook}}
+			assert.ErrorIs(t, config.Validate(), c.err)
+		})
+	}
+}
+
+func TestNotifier_BlocksAndReorgs(t *testing.T) {
+	t.Parallel()
+
+	url, received := newTestWebhook(t)
+	chain := &mockBlockchain{sub: blockchain.NewMockSubscription()}
+
+	n := NewNotifier(hclog.NewNullLogger(), &Config{
+		Webhooks: []*WebhookConfig{{URL: url, Events: []EventType{EventBlock, EventReorg}}},
+	}, chain, nil)
+
+	n.Start()
+	defer n.Close()
+
+	genesis := newHeader(0, nil)
+	block1 := newHeader(1, genesis)
+
+	chain.sub.Push(&blockchain.Event{Type: blockchain.EventHead, NewChain: []*types.Header{block1}})
+
+	notification := receiveNotification(t, received)
+	assert.Equal(t, EventBlock, notification.Type)
+	assert.Equal(t, block1.Hash, notification.Block.Hash)
+
+	// forks are not notified
+	fork := newHeader(1, genesis)
+	fork.Timestamp = 10
+	fork.ComputeHash()
+
+	chain.sub.Push(&blockchain.Event{Type: blockchain.EventFork, NewChain: []*types.Header{fork}})
+
+	reorged := newHeader(2, fork)
+
+	chain.sub.Push(&blockchain.Event{
+		Type:     blockchain.EventReorg,
+		OldChain: []*types.Header{block1},
+		NewChain: []*types.Header{fork, reorged},
+	})
+
+	notification = receiveNotification(t, received)
+	assert.Equal(t, EventReorg, notification.Type)
+	assert.Equal(t, reorged.Hash, notification.Block.Hash)
+	assert.Equal(t, []*Block{toBlock(block1)}, notification.Removed)
+	assert.Equal(t, []*Block{toBlock(fork), toBlock(reorged)}, notification.Added)
+
+	for _, header := range []*types.Header{fork, reorged} {
+		notification = receiveNotification(t, received)
+		assert.Equal(t, EventBlock, notification.Type)
+		assert.Equal(t, header.Hash, notification.Block.Hash)
+	}
+}
+
+func TestNotifier_Logs(t *testing.T) {
+	t.Parallel()
+
+	url, received := newTestWebhook(t)
+
+	header := newHeader(1, nil)
+	chain := &mockBlockchain{
+		sub: blockchain.NewMockSubscription(),
+		receipts: map[types.Hash][]*types.Receipt{
+			header.Hash: {
+				{
+					TxHash: types.StringToHash("a"),
+					Logs: []*types.Log{
+						{Address: addr1, Topics: []types.Hash{topic1}, Data: []byte{0x1}},
+						{Address: addr2, Topics: []types.Hash{topic1}},
+					},
+				},
+				{
+					TxHash: types.StringToHash("b"),
+					Logs: []*types.Log{
+						{Address: addr1, Data: []byte{0x2}},
+					},
+				},
+			},
+		},
+	}
+
+	n := NewNotifier(hclog.NewNullLogger(), &Config{
+		Webhooks: []*WebhookConfig{{
+			URL:    url,
+			Events: []EventType{EventLog},
+			Logs:   []*jsonrpc.LogQuery{{Addresses: []types.Address{addr1}}},
+		}},
+	}, chain, nil)
+
+	n.Start()
+	defer n.Close()
+
+	chain.sub.Push(&blockchain.Event{Type: blockchain.EventHead, NewChain: []*types.Header{header}})
+
+	notification := receiveNotification(t, received)
+	assert.Equal(t, EventLog, notification.Type)
+	assert.Equal(t, &Log{
+		Address:  addr1,
+		Topics:   []types.Hash{topic1},
+		Data:     "0x01",
+		TxHash:   types.StringToHash("a"),
+		TxIndex:  0,
+		LogIndex: 0,
+	}, notification.Log)
+
+	// the log of addr2 is filtered out
+	notification = receiveNotification(t, received)
+	assert.Equal(t, uint64(1), notification.Log.TxIndex)
+	assert.Equal(t, uint64(2), notification.Log.LogIndex)
+	assert.Equal(t, "0x02", notification.Log.Data)
+}
+
+func TestNotifier_Validators(t *testing.T) {
+	t.Parallel()
+
+	url, received := newTestWebhook(t)
+	chain := &mockBlockchain{sub: blockchain.NewMockSubscription()}
+
+	validatorSets := mockValidatorSetProvider{
+		0: validators.NewECDSAValidatorSet(validators.NewECDSAValidator(addr1), validators.NewECDSAValidator(addr2)),
+		1: validators.NewECDSAValidatorSet(validators.NewECDSAValidator(addr1), validators.NewECDSAValidator(addr2)),
+		2: validators.NewECDSAValidatorSet(validators.NewECDSAValidator(addr2), validators.NewECDSAValidator(addr3)),
+	}
+
+	n := NewNotifier(hclog.NewNullLogger(), &Config{
+		Webhooks: []*WebhookConfig{{URL: url, Events: []EventType{EventValidators}}},
+	}, chain, validatorSets)
+
+	n.Start()
+	defer n.Close()
+
+	block1 := newHeader(1, nil)
+	block2 := newHeader(2, block1)
+
+	// no changes at height 1
+	chain.sub.Push(&blockchain.Event{Type: blockchain.EventHead, NewChain: []*types.Header{block1}})
+	chain.sub.Push(&blockchain.Event{Type: blockchain.EventHead, NewChain: []*types.Header{block2}})
+
+	notification := receiveNotification(t, received)
+	assert.Equal(t, EventValidators, notification.Type)
+	assert.Equal(t, block2.Hash, notification.Block.Hash)
+	assert.Equal(t, &ValidatorSet{
+		Validators: []types.Address{addr2, addr3},
+		Added:      []types.Address{addr3},
+		Removed:    []types.Address{addr1},
+	}, notification.Validators)
+}
+
+type mockPublisher struct {
+	failures int
+	attempts int
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, payload []byte) error {
+	m.attempts++
+
+	if m.attempts <= m.failures {
+		return errors.New("unavailable")
+	}
+
+	return nil
+}
+
+func TestSink_Retry(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		failures   int
+		maxRetries uint64
+		attempts   int
+	}{
+		{"delivered at first attempt", 0, 3, 1},
+		{"delivered after retries", 2, 3, 3},
+		{"retries exhausted", 10, 3, 4},
+		{"no retries", 10, 0, 1},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			pub := &mockPublisher{failures: c.failures}
+			maxRetries := c.maxRetries
+
+			s := newSink(hclog.NewNullLogger(), pub, &WebhookConfig{
+				Events:     []EventType{EventBlock},
+				MaxRetries: &maxRetries,
+			})
+			s.initialBackoff = time.Millisecond
+			s.maxBackoff = 2 * time.Millisecond
+
+			s.deliver(context.Background(), []byte("{}"))
+			assert.Equal(t, c.attempts, pub.attempts)
+		})
+	}
+}
+
+func TestSink_EnqueueDropsWhenFull(t *testing.T) {
+	t.Parallel()
+
+	s := newSink(hclog.NewNullLogger(), &mockPublisher{}, &WebhookConfig{Events: []EventType{EventBlock}})
+
+	for i := 0; i < sinkQueueSize+10; i++ {
+		s.enqueue([]byte("{}"))
+	}
+
+	require.Len(t, s.queue, sinkQueueSize)
+}
@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// publisher delivers the encoded notifications to a single destination,
+// such as a webhook or a message broker topic
+type publisher interface {
+	Publish(ctx context.Context, payload []byte) error
+}
+
+// webhookPublisher POSTs the notifications to a webhook
+type webhookPublisher struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+func newWebhookPublisher(config *WebhookConfig) *webhookPublisher {
+	return &webhookPublisher{
+		client: &http.Client{
+			Timeout: config.timeout(),
+		},
+		url:     config.URL,
+		headers: config.Headers,
+	}
+}
+
+// Publish POSTs the payload to the webhook, any non 2xx response is a failed delivery
+func (p *webhookPublisher) Publish(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for key, value := range p.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// sinkQueueSize is the number of the notifications waiting for delivery,
+	// the new notifications are dropped when a destination falls behind
+	sinkQueueSize = 1024
+
+	// initialBackoff is the delay before the first retry, doubled on every retry
+	initialBackoff = 500 * time.Millisecond
+
+	// maxBackoff is the maximum delay between the retries
+	maxBackoff = 30 * time.Second
+)
+
+// sink queues the notifications a destination is subscribed to
+// and delivers them in order, retrying the failed deliveries
+type sink struct {
+	logger    hclog.Logger
+	publisher publisher
+
+	events     map[EventType]struct{}
+	logs       []*jsonrpc.LogQuery
+	maxRetries uint64
+
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	queue chan []byte
+}
+
+func newSink(logger hclog.Logger, pub publisher, config *WebhookConfig) *sink {
+	events := make(map[EventType]struct{}, len(config.Events))
+	for _, event := range config.Events {
+		events[event] = struct{}{}
+	}
+
+	return &sink{
+		logger:         logger,
+		publisher:      pub,
+		events:         events,
+		logs:           config.Logs,
+		maxRetries:     config.maxRetries(),
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		queue:          make(chan []byte, sinkQueueSize),
+	}
+}
+
+// subscribed returns whether the destination is subscribed to the event type
+func (s *sink) subscribed(typ EventType) bool {
+	_, ok := s.events[typ]
+
+	return ok
+}
+
+// matchLog returns whether the log matches any of the log filters
+func (s *sink) matchLog(log *types.Log) bool {
+	if len(s.logs) == 0 {
+		return true
+	}
+
+	for _, query := range s.logs {
+		if query.Match(log) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enqueue queues the payload without blocking the caller
+func (s *sink) enqueue(payload []byte) {
+	select {
+	case s.queue <- payload:
+	default:
+		metrics.IncrCounter([]string{"notifier", "dropped"}, 1)
+		s.logger.Warn("notification queue is full, dropping notification")
+	}
+}
+
+// run delivers the queued notifications until the context is canceled
+func (s *sink) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case payload := <-s.queue:
+			s.deliver(ctx, payload)
+		}
+	}
+}
+
+// deliver publishes the payload, retrying with an exponential backoff
+func (s *sink) deliver(ctx context.Context, payload []byte) {
+	backoff := s.initialBackoff
+
+	for attempt := uint64(0); ; attempt++ {
+		start := time.Now()
+		err := s.publisher.Publish(ctx, payload)
+
+		metrics.MeasureSince([]string{"notifier", "delivery_time"}, start)
+
+		if err == nil {
+			metrics.IncrCounter([]string{"notifier", "delivered"}, 1)
+
+			return
+		}
+
+		if attempt >= s.maxRetries {
+			metrics.IncrCounter([]string{"notifier", "failed"}, 1)
+			s.logger.Error("failed to deliver notification", "attempts", attempt+1, "err", err)
+
+			return
+		}
+
+		metrics.IncrCounter([]string{"notifier", "retries"}, 1)
+		s.logger.Debug("failed to deliver notification, retrying", "backoff", backoff, "err", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+}
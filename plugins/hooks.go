@@ -0,0 +1,147 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+var ErrUnknownPlugin = errors.New("unknown plugin")
+
+// Hooks calls the hooks of the enabled plugins, in the order the plugins are enabled.
+// It vets the transactions of the txpool, and the blocks sealed and written
+type Hooks struct {
+	logger  hclog.Logger
+	plugins []Plugin
+}
+
+// Load enables the registered plugins of the given names
+func Load(logger hclog.Logger, names []string) (*Hooks, error) {
+	h := &Hooks{
+		logger:  logger.Named("plugins"),
+		plugins: make([]Plugin, 0, len(names)),
+	}
+
+	for _, name := range names {
+		plugin, ok := lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("%w %s, the registered plugins are %v", ErrUnknownPlugin, name, Registered())
+		}
+
+		h.plugins = append(h.plugins, plugin)
+
+		h.logger.Info("plugin enabled", "name", name)
+	}
+
+	return h, nil
+}
+
+// AdmitTx calls the admission hooks on the transaction from the given origin
+func (h *Hooks) AdmitTx(tx *types.Transaction, origin string) error {
+	event := &TxEvent{
+		Tx:     tx,
+		Origin: origin,
+	}
+
+	for _, plugin := range h.plugins {
+		hook, ok := plugin.(TxAdmissionHook)
+		if !ok {
+			continue
+		}
+
+		if err := hook.AdmitTx(event); err != nil {
+			h.logger.Debug("transaction vetoed", append([]interface{}{
+				"plugin", plugin.Name(), "hash", tx.Hash, "err", err,
+			}, event.keyValues()...)...)
+
+			return fmt.Errorf("%s: %w", plugin.Name(), err)
+		}
+	}
+
+	if len(event.labels) > 0 {
+		h.logger.Debug("transaction annotated", append([]interface{}{"hash", tx.Hash}, event.keyValues()...)...)
+	}
+
+	return nil
+}
+
+// SealBlock calls the seal hooks on the block sealed by the node
+func (h *Hooks) SealBlock(block *types.Block) error {
+	event := &BlockEvent{
+		Block: block,
+	}
+
+	return h.callBlockHooks(event, "sealed", func(plugin Plugin) (func(*BlockEvent) error, bool) {
+		hook, ok := plugin.(BlockSealHook)
+		if !ok {
+			return nil, false
+		}
+
+		return hook.SealBlock, true
+	})
+}
+
+// ImportBlock calls the import hooks on the block from the given source
+func (h *Hooks) ImportBlock(block *types.Block, source string) error {
+	event := &BlockEvent{
+		Block:  block,
+		Source: source,
+	}
+
+	return h.callBlockHooks(event, "imported", func(plugin Plugin) (func(*BlockEvent) error, bool) {
+		hook, ok := plugin.(BlockImportHook)
+		if !ok {
+			return nil, false
+		}
+
+		return hook.ImportBlock, true
+	})
+}
+
+func (h *Hooks) callBlockHooks(
+	event *BlockEvent,
+	action string,
+	getHook func(Plugin) (func(*BlockEvent) error, bool),
+) error {
+	number := event.Block.Number()
+
+	for _, plugin := range h.plugins {
+		hook, ok := getHook(plugin)
+		if !ok {
+			continue
+		}
+
+		if err := hook(event); err != nil {
+			h.logger.Warn("block vetoed", append([]interface{}{
+				"plugin", plugin.Name(), "action", action, "number", number, "err", err,
+			}, event.keyValues()...)...)
+
+			return fmt.Errorf("%s: %w", plugin.Name(), err)
+		}
+	}
+
+	if len(event.labels) > 0 {
+		h.logger.Info("block annotated", append([]interface{}{
+			"action", action, "number", number,
+		}, event.keyValues()...)...)
+	}
+
+	return nil
+}
+
+// Close closes the enabled plugins implementing io.Closer
+func (h *Hooks) Close() {
+	for _, plugin := range h.plugins {
+		closer, ok := plugin.(io.Closer)
+		if !ok {
+			continue
+		}
+
+		if err := closer.Close(); err != nil {
+			h.logger.Error("failed to close plugin", "name", plugin.Name(), "err", err)
+		}
+	}
+}
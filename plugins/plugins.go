@@ -0,0 +1,136 @@
+package plugins
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Plugin is an integration compiled into the binary, such as a compliance or an analytics one.
+// It registers itself from the init function of its package, imported for its side effects,
+// and implements the hooks it is called on. It may also implement io.Closer
+type Plugin interface {
+	// Name is the name the plugin is enabled with
+	Name() string
+}
+
+// TxAdmissionHook is called on the transactions passing the validation of the txpool,
+// an error vetoes the transaction
+type TxAdmissionHook interface {
+	AdmitTx(event *TxEvent) error
+}
+
+// BlockSealHook is called on the blocks sealed by the node before they are proposed,
+// an error vetoes the block
+type BlockSealHook interface {
+	SealBlock(event *BlockEvent) error
+}
+
+// BlockImportHook is called on the blocks before they are written,
+// an error vetoes the block
+type BlockImportHook interface {
+	ImportBlock(event *BlockEvent) error
+}
+
+// TxEvent is the admission of a transaction to the txpool
+type TxEvent struct {
+	Tx *types.Transaction
+
+	// Origin is the origin of the transaction: local, gossip, reorg or private
+	Origin string
+
+	annotations
+}
+
+// BlockEvent is the sealing or the import of a block
+type BlockEvent struct {
+	Block *types.Block
+
+	// Source is the source of the imported block, such as the consensus or the syncer,
+	// empty for the sealed blocks
+	Source string
+
+	annotations
+}
+
+// annotations are the labels the hooks attach to an event. They are seen by the next hooks
+// and logged with the event
+type annotations struct {
+	labels map[string]string
+}
+
+// Annotate labels the event with the value
+func (a *annotations) Annotate(key, value string) {
+	if a.labels == nil {
+		a.labels = map[string]string{}
+	}
+
+	a.labels[key] = value
+}
+
+// Annotation returns the label of the event, empty if not set
+func (a *annotations) Annotation(key string) string {
+	return a.labels[key]
+}
+
+// keyValues returns the labels as the key values of a log line, sorted by key
+func (a *annotations) keyValues() []interface{} {
+	keys := make([]string, 0, len(a.labels))
+	for key := range a.labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	keyValues := make([]interface{}, 0, 2*len(keys))
+	for _, key := range keys {
+		keyValues = append(keyValues, key, a.labels[key])
+	}
+
+	return keyValues
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]Plugin{}
+)
+
+// Register makes the plugin available to be enabled by its name.
+// It panics if a plugin of the same name is registered, as it's a programming error
+func Register(plugin Plugin) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	name := plugin.Name()
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("plugin %s registered twice", name))
+	}
+
+	registry[name] = plugin
+}
+
+// Registered returns the names of the registered plugins, sorted
+func Registered() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func lookup(name string) (Plugin, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	plugin, ok := registry[name]
+
+	return plugin, ok
+}
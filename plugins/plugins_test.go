@@ -0,0 +1,121 @@
+package plugins
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDenied = errors.New("denied")
+
+// denyPlugin vetoes the transactions to the denied address, and annotates the blocks
+type denyPlugin struct {
+	name   string
+	denied types.Address
+	closed bool
+}
+
+func (p *denyPlugin) Name() string {
+	return p.name
+}
+
+func (p *denyPlugin) AdmitTx(event *TxEvent) error {
+	if event.Tx.To != nil && *event.Tx.To == p.denied {
+		event.Annotate("reason", "sanctioned")
+
+		return errDenied
+	}
+
+	return nil
+}
+
+func (p *denyPlugin) ImportBlock(event *BlockEvent) error {
+	event.Annotate(p.name, event.Source)
+
+	return nil
+}
+
+func (p *denyPlugin) Close() error {
+	p.closed = true
+
+	return nil
+}
+
+// sealPlugin vetoes the empty sealed blocks, and the imported blocks annotated by the previous hooks
+type sealPlugin struct{}
+
+func (p *sealPlugin) Name() string {
+	return "seal"
+}
+
+func (p *sealPlugin) SealBlock(event *BlockEvent) error {
+	if len(event.Block.Transactions) == 0 {
+		return errDenied
+	}
+
+	return nil
+}
+
+func (p *sealPlugin) ImportBlock(event *BlockEvent) error {
+	if event.Annotation("deny") == "restore" {
+		return errDenied
+	}
+
+	return nil
+}
+
+func TestHooks(t *testing.T) {
+	denied := types.StringToAddress("0x1")
+	deny := &denyPlugin{name: "deny", denied: denied}
+
+	Register(deny)
+	Register(&sealPlugin{})
+
+	assert.Panics(t, func() { Register(&denyPlugin{name: "deny"}) })
+	assert.Equal(t, []string{"deny", "seal"}, Registered())
+
+	_, err := Load(hclog.NewNullLogger(), []string{"deny", "unknown"})
+	assert.ErrorIs(t, err, ErrUnknownPlugin)
+
+	hooks, err := Load(hclog.NewNullLogger(), []string{"deny", "seal"})
+	require.NoError(t, err)
+
+	// the admission hook vetoes the transaction
+	allowed := types.StringToAddress("0x2")
+
+	assert.NoError(t, hooks.AdmitTx(&types.Transaction{To: &allowed}, "local"))
+	assert.ErrorIs(t, hooks.AdmitTx(&types.Transaction{To: &denied}, "gossip"), errDenied)
+
+	// the annotations are seen by the next hooks
+	block := &types.Block{Header: &types.Header{Number: 1}}
+
+	assert.NoError(t, hooks.ImportBlock(block, "syncer"))
+	assert.ErrorIs(t, hooks.ImportBlock(block, "restore"), errDenied)
+
+	// the plugins without the hook are skipped
+	assert.ErrorIs(t, hooks.SealBlock(block), errDenied)
+
+	block.Transactions = []*types.Transaction{{To: &allowed}}
+	assert.NoError(t, hooks.SealBlock(block))
+
+	hooks.Close()
+	assert.True(t, deny.closed)
+}
+
+func TestAnnotations(t *testing.T) {
+	t.Parallel()
+
+	event := &BlockEvent{}
+
+	assert.Equal(t, "", event.Annotation("a"))
+
+	event.Annotate("b", "2")
+	event.Annotate("a", "1")
+
+	assert.Equal(t, "1", event.Annotation("a"))
+	assert.Equal(t, []interface{}{"a", "1", "b", "2"}, event.keyValues())
+}
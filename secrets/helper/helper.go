@@ -91,6 +91,28 @@ func InitECDSAValidatorKey(secretsManager secrets.SecretsManager) (types.Address
 	return address, nil
 }
 
+// InitValidatorOwnerKey creates new ECDSA key and set as the key of the owner of the validator
+func InitValidatorOwnerKey(secretsManager secrets.SecretsManager) (types.Address, error) {
+	if secretsManager.HasSecret(secrets.ValidatorOwnerKey) {
+		return types.ZeroAddress, fmt.Errorf(`secrets "%s" has been already initialized`, secrets.ValidatorOwnerKey)
+	}
+
+	ownerKey, ownerKeyEncoded, err := crypto.GenerateAndEncodeECDSAPrivateKey()
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	// Write the owner private key to the secrets manager storage
+	if setErr := secretsManager.SetSecret(
+		secrets.ValidatorOwnerKey,
+		ownerKeyEncoded,
+	); setErr != nil {
+		return types.ZeroAddress, setErr
+	}
+
+	return crypto.PubKeyToAddress(&ownerKey.PublicKey), nil
+}
+
 func InitBLSValidatorKey(secretsManager secrets.SecretsManager) ([]byte, error) {
 	if secretsManager.HasSecret(secrets.ValidatorBLSKey) {
 		return nil, fmt.Errorf(`secrets "%s" has been already initialized`, secrets.ValidatorBLSKey)
@@ -158,6 +180,25 @@ func LoadValidatorAddress(secretsManager secrets.SecretsManager) (types.Address,
 	return crypto.PubKeyToAddress(&privateKey.PublicKey), nil
 }
 
+// LoadValidatorOwnerAddress loads the owner ECDSA key by SecretsManager and returns the owner address
+func LoadValidatorOwnerAddress(secretsManager secrets.SecretsManager) (types.Address, error) {
+	if !secretsManager.HasSecret(secrets.ValidatorOwnerKey) {
+		return types.ZeroAddress, nil
+	}
+
+	encodedKey, err := secretsManager.GetSecret(secrets.ValidatorOwnerKey)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	privateKey, err := crypto.BytesToECDSAPrivateKey(encodedKey)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return crypto.PubKeyToAddress(&privateKey.PublicKey), nil
+}
+
 // LoadValidatorAddress loads BLS key by SecretsManager and returns BLS Public Key
 func LoadBLSPublicKey(secretsManager secrets.SecretsManager) (string, error) {
 	if !secretsManager.HasSecret(secrets.ValidatorBLSKey) {
@@ -84,6 +84,20 @@ func (l *LocalSecretsManager) Setup() error {
 		secrets.ValidatorBLSKeyLocal,
 	)
 
+	// baseDir/consensus/validator-owner.key
+	l.secretPathMap[secrets.ValidatorOwnerKey] = filepath.Join(
+		l.path,
+		secrets.ConsensusFolderLocal,
+		secrets.ValidatorOwnerKeyLocal,
+	)
+
+	// baseDir/consensus/encryption-share.key
+	l.secretPathMap[secrets.EncryptionKeyShare] = filepath.Join(
+		l.path,
+		secrets.ConsensusFolderLocal,
+		secrets.EncryptionKeyShareLocal,
+	)
+
 	// baseDir/libp2p/libp2p.key
 	l.secretPathMap[secrets.NetworkKey] = filepath.Join(
 		l.path,
@@ -29,15 +29,24 @@ const (
 	// ValidatorBLSKey is the bls secret key of the validator node
 	ValidatorBLSKey = "validator-bls-key"
 
+	// ValidatorOwnerKey is the private key secret of the owner of the validator, controlling its stake
+	ValidatorOwnerKey = "validator-owner-key"
+
 	// NetworkKey is the libp2p private key secret used for networking
 	NetworkKey = "network-key"
+
+	// EncryptionKeyShare is the share of the key decrypting the transactions of the encrypted mempool
+	EncryptionKeyShare = "encryption-key-share"
 )
 
 // Define constant file names for the local StorageManager
 const (
-	ValidatorKeyLocal    = "validator.key"
-	ValidatorBLSKeyLocal = "validator-bls.key"
-	NetworkKeyLocal      = "libp2p.key"
+	ValidatorKeyLocal      = "validator.key"
+	ValidatorBLSKeyLocal   = "validator-bls.key"
+	ValidatorOwnerKeyLocal = "validator-owner.key"
+	NetworkKeyLocal        = "libp2p.key"
+
+	EncryptionKeyShareLocal = "encryption-share.key"
 )
 
 // Define constant folder names for the local StorageManager
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"sort"
+
 	"github.com/0xPolygon/polygon-edge/consensus"
 	consensusDev "github.com/0xPolygon/polygon-edge/consensus/dev"
 	consensusDummy "github.com/0xPolygon/polygon-edge/consensus/dummy"
@@ -35,6 +37,18 @@ var secretsManagerBackends = map[secrets.SecretsManagerType]secrets.SecretsManag
 	secrets.GCPSSM:         gcpssm.SecretsManagerFactory,
 }
 
+// SupportedConsensuses returns the types of the supported consensus backends, in order
+func SupportedConsensuses() []string {
+	types := make([]string, 0, len(consensusBackends))
+	for consensusType := range consensusBackends {
+		types = append(types, string(consensusType))
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
 func ConsensusSupported(value string) bool {
 	_, ok := consensusBackends[ConsensusType(value)]
 
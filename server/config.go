@@ -2,12 +2,21 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/crosscheck"
+	"github.com/0xPolygon/polygon-edge/firehose"
+	"github.com/0xPolygon/polygon-edge/helper/resources"
+	"github.com/0xPolygon/polygon-edge/indexer"
 	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/notifier"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 )
 
 const DefaultGRPCPort int = 9632
@@ -20,12 +29,16 @@ type Config struct {
 	JSONRPC    *JSONRPC
 	GRPCAddr   *net.TCPAddr
 	LibP2PAddr *net.TCPAddr
+	RESTAddr   *net.TCPAddr
 
 	PriceLimit         uint64
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
 	BlockTime          uint64
 
+	// PrivateTxPeers are the validators holding the private transactions
+	PrivateTxPeers []peer.ID
+
 	Telemetry *Telemetry
 	Network   *network.Config
 
@@ -34,8 +47,71 @@ type Config struct {
 
 	Seal bool
 
+	LightServer bool
+
+	Indexer *indexer.Config
+
+	// Firehose streams the blocks to a sink, nil if disabled
+	Firehose *firehose.Config
+
+	// CrossCheck compares the imported blocks with a reference client, nil if disabled
+	CrossCheck *crosscheck.Config
+
+	// StateDiffs persists the state changes of each block
+	StateDiffs bool
+
+	// WarmCache preloads the most accessed accounts and codes of the previous run
+	WarmCache        bool
+	WarmCacheEntries int
+
+	// TrieCacheSize is the size in bytes of the cache of the trie nodes, disabled if 0
+	TrieCacheSize int
+
+	// BlobRetention is the number of blocks the blob sidecars are kept for, they aren't kept if 0
+	BlobRetention uint64
+
+	// TxExecutionBudget is the wall-clock time the execution of a transaction
+	// may take while building a block, unlimited if 0
+	TxExecutionBudget time.Duration
+
+	// ExtraDataVersion embeds the version of the binary in the extra data of the sealed blocks
+	ExtraDataVersion bool
+
+	// StateRegenBudget is the maximum number of blocks replayed to regenerate
+	// a missing state for the historical calls, which are not regenerated if 0
+	StateRegenBudget uint64
+
+	// StorageSweep sweeps the storage of the deleted accounts in the background, nil if disabled
+	StorageSweep *itrie.SweepConfig
+
+	// ShutdownGracePeriod bounds the draining and the closing of the node on a termination signal
+	ShutdownGracePeriod time.Duration
+
+	// MaxProcs is the GOMAXPROCS of the node, fitting the CPU quota of its cgroup if 0
+	MaxProcs int
+
+	// SelfProfile profiles the node on sustained high load, nil if disabled
+	SelfProfile *resources.ProfilerConfig
+
+	// AccountManager enables the accounts held in the node keystore
+	AccountManager bool
+
+	// ValidatorIntents enables the exchange of the off-chain intents of the validators
+	ValidatorIntents bool
+
+	// Plugins are the names of the compiled-in plugins enabled, whose hooks are called in this order
+	Plugins []string
+
+	// Fork is the remote chain the dev chain is forked from, if any
+	Fork *ForkConfig
+
+	// Failover is the configuration of the node in an active/standby validator pair, if any
+	Failover *consensus.FailoverConfig
+
 	SecretsManager *secrets.SecretsManagerConfig
 
+	Notifier *notifier.Config
+
 	LogLevel hclog.Level
 
 	JSONLogFormat bool
@@ -43,9 +119,21 @@ type Config struct {
 	LogFilePath string
 }
 
+// ForkConfig is the remote chain whose state the dev chain runs on top of
+type ForkConfig struct {
+	// URL is the JSON-RPC endpoint of the remote chain
+	URL string
+
+	// Block is the block of the remote chain whose state is forked, the latest one if 0
+	Block uint64
+}
+
 // Telemetry holds the config details for metric services
 type Telemetry struct {
 	PrometheusAddr *net.TCPAddr
+
+	// PprofAddr is the address of the pprof endpoint, disabled if nil
+	PprofAddr *net.TCPAddr
 }
 
 // JSONRPC holds the config details for the JSON-RPC server
@@ -54,4 +142,14 @@ type JSONRPC struct {
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	NonceManager             bool
+	FilterTimeout            time.Duration
+	MaxFiltersPerClient      uint64
+	PersistFilters           bool
+	ExplorerCompat           bool
+	ReadConcurrency          uint64
+	CallConcurrency          uint64
+	TraceConcurrency         uint64
+	CallCacheSize            uint64
+	IPCPath                  string
 }
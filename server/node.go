@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// inProcBufferSize is the size of the buffer of the in-process grpc connections
+const inProcBufferSize = 1024 * 1024
+
+var (
+	errNodeChainMissing = errors.New("the chain of the node is not set")
+	errNodeStarted      = errors.New("the node is already started")
+	errNodeNotStarted   = errors.New("the node is not started")
+)
+
+// Node is the full node embedded in another process, such as a test harness
+// or an orchestration binary. It runs the server built from its config,
+// and serves its JSON-RPC and GRPC endpoints to the clients in the same process
+type Node struct {
+	config *Config
+
+	lock   sync.Mutex
+	server *Server
+}
+
+// NewNode creates the node with the config, which is started with Start
+func NewNode(config *Config) (*Node, error) {
+	if config == nil || config.Chain == nil {
+		return nil, errNodeChainMissing
+	}
+
+	return &Node{
+		config: config,
+	}, nil
+}
+
+// Start starts the server of the node
+func (n *Node) Start() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.server != nil {
+		return errNodeStarted
+	}
+
+	server, err := NewServer(n.config)
+	if err != nil {
+		return err
+	}
+
+	n.server = server
+
+	return nil
+}
+
+// Stop drains and closes the server of the node, which can be started again
+func (n *Node) Stop() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.server == nil {
+		return
+	}
+
+	n.server.Close()
+	n.server = nil
+}
+
+// Server returns the server of the node, nil if the node is not started
+func (n *Node) Server() *Server {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.server
+}
+
+// JSONRPCClient returns a client calling the JSON-RPC endpoints of the node without a transport
+func (n *Node) JSONRPCClient() (*jsonrpc.InProcClient, error) {
+	server := n.Server()
+	if server == nil {
+		return nil, errNodeNotStarted
+	}
+
+	return server.jsonrpcServer.InProcClient(), nil
+}
+
+// GRPCConn returns a connection to the GRPC services of the node through an in-memory buffer,
+// such as the system, the operator and the txpool services. The connection is closed by the caller
+func (n *Node) GRPCConn() (*grpc.ClientConn, error) {
+	server := n.Server()
+	if server == nil {
+		return nil, errNodeNotStarted
+	}
+
+	listener := server.inProcListener
+
+	return grpc.Dial(
+		"inproc",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+	)
+}
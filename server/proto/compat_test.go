@@ -0,0 +1,119 @@
+package proto_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// releasedSystemAPI is the System API served under the unversioned v1 package,
+// which must stay wire compatible
+var releasedSystemAPI = map[string]string{
+	"System.GetStatus":              "(google.protobuf.Empty) returns (ServerStatus)",
+	"System.PeersAdd":               "(PeersAddRequest) returns (PeersAddResponse)",
+	"System.PeersList":              "(google.protobuf.Empty) returns (PeersListResponse)",
+	"System.PeersStatus":            "(PeersStatusRequest) returns (Peer)",
+	"System.Subscribe":              "(google.protobuf.Empty) returns (stream BlockchainEvent)",
+	"System.BlockByNumber":          "(BlockByNumberRequest) returns (BlockResponse)",
+	"System.Export":                 "(ExportRequest) returns (stream ExportEvent)",
+	"BlockchainEvent.added":         "1 repeated message BlockchainEvent.Header",
+	"BlockchainEvent.removed":       "2 repeated message BlockchainEvent.Header",
+	"BlockchainEvent.Header.number": "1 int64",
+	"BlockchainEvent.Header.hash":   "2 string",
+	"ServerStatus.network":          "1 int64",
+	"ServerStatus.genesis":          "2 string",
+	"ServerStatus.current":          "3 message ServerStatus.Block",
+	"ServerStatus.p2pAddr":          "4 string",
+	"ServerStatus.Block.number":     "1 int64",
+	"ServerStatus.Block.hash":       "2 string",
+	"Peer.id":                       "1 string",
+	"Peer.protocols":                "2 repeated string",
+	"Peer.addrs":                    "3 repeated string",
+	"PeersAddRequest.id":            "1 string",
+	"PeersAddResponse.message":      "1 string",
+	"PeersStatusRequest.id":         "1 string",
+	"PeersListResponse.peers":       "1 repeated message Peer",
+	"BlockByNumberRequest.number":   "1 uint64",
+	"BlockResponse.data":            "1 bytes",
+	"ExportRequest.from":            "1 uint64",
+	"ExportRequest.to":              "2 uint64",
+	"ExportEvent.from":              "1 uint64",
+	"ExportEvent.to":                "2 uint64",
+	"ExportEvent.latest":            "3 uint64",
+	"ExportEvent.data":              "4 bytes",
+}
+
+func TestSystemAPICompatibility(t *testing.T) {
+	t.Parallel()
+
+	tests.AssertProtoCompatible(t, proto.File_server_proto_system_proto, releasedSystemAPI)
+}
+
+type mockSystemServer struct {
+	proto.UnimplementedSystemServer
+}
+
+func (m *mockSystemServer) GetStatus(context.Context, *emptypb.Empty) (*proto.ServerStatus, error) {
+	return &proto.ServerStatus{Network: 100}, nil
+}
+
+func newTestSystemConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	srv := &mockSystemServer{}
+
+	proto.RegisterSystemServer(s, srv)
+	proto.RegisterLegacySystemServer(s, srv)
+
+	go func() {
+		_ = s.Serve(lis)
+	}()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+
+	return conn
+}
+
+func TestLegacySystemService(t *testing.T) {
+	t.Parallel()
+
+	conn := newTestSystemConn(t)
+
+	// versioned client
+	resp, err := proto.NewSystemClient(conn).GetStatus(context.Background(), &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), resp.Network)
+
+	// client generated from the unversioned package
+	legacyResp := &proto.ServerStatus{}
+	assert.NoError(t, conn.Invoke(
+		context.Background(),
+		"/"+proto.LegacySystemServiceName+"/GetStatus",
+		&emptypb.Empty{},
+		legacyResp,
+	))
+	assert.Equal(t, int64(100), legacyResp.Network)
+}
@@ -0,0 +1,17 @@
+package proto
+
+import "google.golang.org/grpc"
+
+// LegacySystemServiceName is the name the System service was served under
+// before it was moved to the versioned system.v1 package
+const LegacySystemServiceName = "v1.System"
+
+// RegisterLegacySystemServer registers the System service under its legacy name,
+// so the clients generated from the unversioned package keep working.
+// The messages are unchanged, so both names share the same implementation
+func RegisterLegacySystemServer(s grpc.ServiceRegistrar, srv SystemServer) {
+	desc := System_ServiceDesc
+	desc.ServiceName = LegacySystemServiceName
+
+	s.RegisterService(&desc, srv)
+}
@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: server/proto/system.proto
 
 package proto
 
@@ -27,12 +31,38 @@ type SystemClient interface {
 	PeersList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListResponse, error)
 	// PeersInfo returns the info of a peer
 	PeersStatus(ctx context.Context, in *PeersStatusRequest, opts ...grpc.CallOption) (*Peer, error)
+	// PeersACLGet returns the access control list of the peer connections
+	PeersACLGet(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersACL, error)
+	// PeersACLSet replaces the access control list of the peer connections,
+	// the connected peers it denies are disconnected
+	PeersACLSet(ctx context.Context, in *PeersACL, opts ...grpc.CallOption) (*PeersACL, error)
 	// Subscribe subscribes to blockchain events
 	Subscribe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SubscribeClient, error)
 	// Export returns blockchain data
 	BlockByNumber(ctx context.Context, in *BlockByNumberRequest, opts ...grpc.CallOption) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (System_ExportClient, error)
+	// GetBlock returns the block by number, hash or tag
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error)
+	// GetTransaction returns the sealed transaction by hash
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	// GetBalance returns the balance and nonce of an account
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*Balance, error)
+	// GetValidators returns the validator set of a block
+	GetValidators(ctx context.Context, in *GetValidatorsRequest, opts ...grpc.CallOption) (*ValidatorSet, error)
+	// SubscribeAccounts streams the balance and nonce changes of the watched accounts,
+	// starting with their current state
+	SubscribeAccounts(ctx context.Context, in *SubscribeAccountsRequest, opts ...grpc.CallOption) (System_SubscribeAccountsClient, error)
+	// Snapshot streams the entries of a consistent snapshot of the node databases
+	Snapshot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SnapshotClient, error)
+	// GetUpgradeStatus returns the scheduled upgrades with the readiness of the node and its peers
+	GetUpgradeStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UpgradeStatus, error)
+	// HaltSignal signs the intent of the validator of the node to halt the chain after a block
+	HaltSignal(ctx context.Context, in *HaltRequest, opts ...grpc.CallOption) (*HaltStatus, error)
+	// GetHaltStatus returns the halt status of the chain
+	GetHaltStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HaltStatus, error)
+	// Resume resumes the halted chain with a restart manifest signed by the validators
+	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*HaltStatus, error)
 }
 
 type systemClient struct {
@@ -45,7 +75,7 @@ func NewSystemClient(cc grpc.ClientConnInterface) SystemClient {
 
 func (c *systemClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServerStatus, error) {
 	out := new(ServerStatus)
-	err := c.cc.Invoke(ctx, "/v1.System/GetStatus", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/system.v1.System/GetStatus", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +84,7 @@ func (c *systemClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts ..
 
 func (c *systemClient) PeersAdd(ctx context.Context, in *PeersAddRequest, opts ...grpc.CallOption) (*PeersAddResponse, error) {
 	out := new(PeersAddResponse)
-	err := c.cc.Invoke(ctx, "/v1.System/PeersAdd", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/system.v1.System/PeersAdd", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +93,7 @@ func (c *systemClient) PeersAdd(ctx context.Context, in *PeersAddRequest, opts .
 
 func (c *systemClient) PeersList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersListResponse, error) {
 	out := new(PeersListResponse)
-	err := c.cc.Invoke(ctx, "/v1.System/PeersList", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/system.v1.System/PeersList", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +102,25 @@ func (c *systemClient) PeersList(ctx context.Context, in *emptypb.Empty, opts ..
 
 func (c *systemClient) PeersStatus(ctx context.Context, in *PeersStatusRequest, opts ...grpc.CallOption) (*Peer, error) {
 	out := new(Peer)
-	err := c.cc.Invoke(ctx, "/v1.System/PeersStatus", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/system.v1.System/PeersStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) PeersACLGet(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersACL, error) {
+	out := new(PeersACL)
+	err := c.cc.Invoke(ctx, "/system.v1.System/PeersACLGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) PeersACLSet(ctx context.Context, in *PeersACL, opts ...grpc.CallOption) (*PeersACL, error) {
+	out := new(PeersACL)
+	err := c.cc.Invoke(ctx, "/system.v1.System/PeersACLSet", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +128,7 @@ func (c *systemClient) PeersStatus(ctx context.Context, in *PeersStatusRequest,
 }
 
 func (c *systemClient) Subscribe(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SubscribeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[0], "/v1.System/Subscribe", opts...)
+	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[0], "/system.v1.System/Subscribe", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +161,7 @@ func (x *systemSubscribeClient) Recv() (*BlockchainEvent, error) {
 
 func (c *systemClient) BlockByNumber(ctx context.Context, in *BlockByNumberRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
 	out := new(BlockResponse)
-	err := c.cc.Invoke(ctx, "/v1.System/BlockByNumber", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/system.v1.System/BlockByNumber", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +169,7 @@ func (c *systemClient) BlockByNumber(ctx context.Context, in *BlockByNumberReque
 }
 
 func (c *systemClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (System_ExportClient, error) {
-	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[1], "/v1.System/Export", opts...)
+	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[1], "/system.v1.System/Export", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -152,6 +200,142 @@ func (x *systemExportClient) Recv() (*ExportEvent, error) {
 	return m, nil
 }
 
+func (c *systemClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, "/system.v1.System/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/system.v1.System/GetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*Balance, error) {
+	out := new(Balance)
+	err := c.cc.Invoke(ctx, "/system.v1.System/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) GetValidators(ctx context.Context, in *GetValidatorsRequest, opts ...grpc.CallOption) (*ValidatorSet, error) {
+	out := new(ValidatorSet)
+	err := c.cc.Invoke(ctx, "/system.v1.System/GetValidators", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) SubscribeAccounts(ctx context.Context, in *SubscribeAccountsRequest, opts ...grpc.CallOption) (System_SubscribeAccountsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[2], "/system.v1.System/SubscribeAccounts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &systemSubscribeAccountsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type System_SubscribeAccountsClient interface {
+	Recv() (*AccountChange, error)
+	grpc.ClientStream
+}
+
+type systemSubscribeAccountsClient struct {
+	grpc.ClientStream
+}
+
+func (x *systemSubscribeAccountsClient) Recv() (*AccountChange, error) {
+	m := new(AccountChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *systemClient) Snapshot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (System_SnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &System_ServiceDesc.Streams[3], "/system.v1.System/Snapshot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &systemSnapshotClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type System_SnapshotClient interface {
+	Recv() (*SnapshotEvent, error)
+	grpc.ClientStream
+}
+
+type systemSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *systemSnapshotClient) Recv() (*SnapshotEvent, error) {
+	m := new(SnapshotEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *systemClient) GetUpgradeStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UpgradeStatus, error) {
+	out := new(UpgradeStatus)
+	err := c.cc.Invoke(ctx, "/system.v1.System/GetUpgradeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) HaltSignal(ctx context.Context, in *HaltRequest, opts ...grpc.CallOption) (*HaltStatus, error) {
+	out := new(HaltStatus)
+	err := c.cc.Invoke(ctx, "/system.v1.System/HaltSignal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) GetHaltStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HaltStatus, error) {
+	out := new(HaltStatus)
+	err := c.cc.Invoke(ctx, "/system.v1.System/GetHaltStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *systemClient) Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*HaltStatus, error) {
+	out := new(HaltStatus)
+	err := c.cc.Invoke(ctx, "/system.v1.System/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SystemServer is the server API for System service.
 // All implementations must embed UnimplementedSystemServer
 // for forward compatibility
@@ -164,12 +348,38 @@ type SystemServer interface {
 	PeersList(context.Context, *emptypb.Empty) (*PeersListResponse, error)
 	// PeersInfo returns the info of a peer
 	PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error)
+	// PeersACLGet returns the access control list of the peer connections
+	PeersACLGet(context.Context, *emptypb.Empty) (*PeersACL, error)
+	// PeersACLSet replaces the access control list of the peer connections,
+	// the connected peers it denies are disconnected
+	PeersACLSet(context.Context, *PeersACL) (*PeersACL, error)
 	// Subscribe subscribes to blockchain events
 	Subscribe(*emptypb.Empty, System_SubscribeServer) error
 	// Export returns blockchain data
 	BlockByNumber(context.Context, *BlockByNumberRequest) (*BlockResponse, error)
 	// Export returns blockchain data
 	Export(*ExportRequest, System_ExportServer) error
+	// GetBlock returns the block by number, hash or tag
+	GetBlock(context.Context, *GetBlockRequest) (*Block, error)
+	// GetTransaction returns the sealed transaction by hash
+	GetTransaction(context.Context, *GetTransactionRequest) (*Transaction, error)
+	// GetBalance returns the balance and nonce of an account
+	GetBalance(context.Context, *GetBalanceRequest) (*Balance, error)
+	// GetValidators returns the validator set of a block
+	GetValidators(context.Context, *GetValidatorsRequest) (*ValidatorSet, error)
+	// SubscribeAccounts streams the balance and nonce changes of the watched accounts,
+	// starting with their current state
+	SubscribeAccounts(*SubscribeAccountsRequest, System_SubscribeAccountsServer) error
+	// Snapshot streams the entries of a consistent snapshot of the node databases
+	Snapshot(*emptypb.Empty, System_SnapshotServer) error
+	// GetUpgradeStatus returns the scheduled upgrades with the readiness of the node and its peers
+	GetUpgradeStatus(context.Context, *emptypb.Empty) (*UpgradeStatus, error)
+	// HaltSignal signs the intent of the validator of the node to halt the chain after a block
+	HaltSignal(context.Context, *HaltRequest) (*HaltStatus, error)
+	// GetHaltStatus returns the halt status of the chain
+	GetHaltStatus(context.Context, *emptypb.Empty) (*HaltStatus, error)
+	// Resume resumes the halted chain with a restart manifest signed by the validators
+	Resume(context.Context, *ResumeRequest) (*HaltStatus, error)
 	mustEmbedUnimplementedSystemServer()
 }
 
@@ -189,6 +399,12 @@ func (UnimplementedSystemServer) PeersList(context.Context, *emptypb.Empty) (*Pe
 func (UnimplementedSystemServer) PeersStatus(context.Context, *PeersStatusRequest) (*Peer, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeersStatus not implemented")
 }
+func (UnimplementedSystemServer) PeersACLGet(context.Context, *emptypb.Empty) (*PeersACL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersACLGet not implemented")
+}
+func (UnimplementedSystemServer) PeersACLSet(context.Context, *PeersACL) (*PeersACL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersACLSet not implemented")
+}
 func (UnimplementedSystemServer) Subscribe(*emptypb.Empty, System_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
@@ -198,6 +414,36 @@ func (UnimplementedSystemServer) BlockByNumber(context.Context, *BlockByNumberRe
 func (UnimplementedSystemServer) Export(*ExportRequest, System_ExportServer) error {
 	return status.Errorf(codes.Unimplemented, "method Export not implemented")
 }
+func (UnimplementedSystemServer) GetBlock(context.Context, *GetBlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedSystemServer) GetTransaction(context.Context, *GetTransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedSystemServer) GetBalance(context.Context, *GetBalanceRequest) (*Balance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedSystemServer) GetValidators(context.Context, *GetValidatorsRequest) (*ValidatorSet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidators not implemented")
+}
+func (UnimplementedSystemServer) SubscribeAccounts(*SubscribeAccountsRequest, System_SubscribeAccountsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeAccounts not implemented")
+}
+func (UnimplementedSystemServer) Snapshot(*emptypb.Empty, System_SnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method Snapshot not implemented")
+}
+func (UnimplementedSystemServer) GetUpgradeStatus(context.Context, *emptypb.Empty) (*UpgradeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUpgradeStatus not implemented")
+}
+func (UnimplementedSystemServer) HaltSignal(context.Context, *HaltRequest) (*HaltStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HaltSignal not implemented")
+}
+func (UnimplementedSystemServer) GetHaltStatus(context.Context, *emptypb.Empty) (*HaltStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHaltStatus not implemented")
+}
+func (UnimplementedSystemServer) Resume(context.Context, *ResumeRequest) (*HaltStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
 func (UnimplementedSystemServer) mustEmbedUnimplementedSystemServer() {}
 
 // UnsafeSystemServer may be embedded to opt out of forward compatibility for this service.
@@ -221,7 +467,7 @@ func _System_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(in
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.System/GetStatus",
+		FullMethod: "/system.v1.System/GetStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(SystemServer).GetStatus(ctx, req.(*emptypb.Empty))
@@ -239,7 +485,7 @@ func _System_PeersAdd_Handler(srv interface{}, ctx context.Context, dec func(int
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.System/PeersAdd",
+		FullMethod: "/system.v1.System/PeersAdd",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(SystemServer).PeersAdd(ctx, req.(*PeersAddRequest))
@@ -257,7 +503,7 @@ func _System_PeersList_Handler(srv interface{}, ctx context.Context, dec func(in
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.System/PeersList",
+		FullMethod: "/system.v1.System/PeersList",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(SystemServer).PeersList(ctx, req.(*emptypb.Empty))
@@ -275,7 +521,7 @@ func _System_PeersStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.System/PeersStatus",
+		FullMethod: "/system.v1.System/PeersStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(SystemServer).PeersStatus(ctx, req.(*PeersStatusRequest))
@@ -283,6 +529,42 @@ func _System_PeersStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _System_PeersACLGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersACLGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/system.v1.System/PeersACLGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersACLGet(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_PeersACLSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersACL)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).PeersACLSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/system.v1.System/PeersACLSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).PeersACLSet(ctx, req.(*PeersACL))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _System_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(emptypb.Empty)
 	if err := stream.RecvMsg(m); err != nil {
@@ -314,7 +596,7 @@ func _System_BlockByNumber_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.System/BlockByNumber",
+		FullMethod: "/system.v1.System/BlockByNumber",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(SystemServer).BlockByNumber(ctx, req.(*BlockByNumberRequest))
@@ -343,11 +625,197 @@ func (x *systemExportServer) Send(m *ExportEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _System_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/system.v1.System/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/system.v1.System/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/system.v1.System/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_GetValidators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetValidatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).GetValidators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/system.v1.System/GetValidators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).GetValidators(ctx, req.(*GetValidatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_SubscribeAccounts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeAccountsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemServer).SubscribeAccounts(m, &systemSubscribeAccountsServer{stream})
+}
+
+type System_SubscribeAccountsServer interface {
+	Send(*AccountChange) error
+	grpc.ServerStream
+}
+
+type systemSubscribeAccountsServer struct {
+	grpc.ServerStream
+}
+
+func (x *systemSubscribeAccountsServer) Send(m *AccountChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _System_Snapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SystemServer).Snapshot(m, &systemSnapshotServer{stream})
+}
+
+type System_SnapshotServer interface {
+	Send(*SnapshotEvent) error
+	grpc.ServerStream
+}
+
+type systemSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *systemSnapshotServer) Send(m *SnapshotEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _System_GetUpgradeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).GetUpgradeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/system.v1.System/GetUpgradeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).GetUpgradeStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_HaltSignal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HaltRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).HaltSignal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/system.v1.System/HaltSignal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).HaltSignal(ctx, req.(*HaltRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_GetHaltStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).GetHaltStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/system.v1.System/GetHaltStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).GetHaltStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _System_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SystemServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/system.v1.System/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SystemServer).Resume(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // System_ServiceDesc is the grpc.ServiceDesc for System service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var System_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "v1.System",
+	ServiceName: "system.v1.System",
 	HandlerType: (*SystemServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -366,10 +834,50 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PeersStatus",
 			Handler:    _System_PeersStatus_Handler,
 		},
+		{
+			MethodName: "PeersACLGet",
+			Handler:    _System_PeersACLGet_Handler,
+		},
+		{
+			MethodName: "PeersACLSet",
+			Handler:    _System_PeersACLSet_Handler,
+		},
 		{
 			MethodName: "BlockByNumber",
 			Handler:    _System_BlockByNumber_Handler,
 		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _System_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _System_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _System_GetBalance_Handler,
+		},
+		{
+			MethodName: "GetValidators",
+			Handler:    _System_GetValidators_Handler,
+		},
+		{
+			MethodName: "GetUpgradeStatus",
+			Handler:    _System_GetUpgradeStatus_Handler,
+		},
+		{
+			MethodName: "HaltSignal",
+			Handler:    _System_HaltSignal_Handler,
+		},
+		{
+			MethodName: "GetHaltStatus",
+			Handler:    _System_GetHaltStatus_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _System_Resume_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -382,6 +890,16 @@ var System_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _System_Export_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeAccounts",
+			Handler:       _System_SubscribeAccounts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Snapshot",
+			Handler:       _System_Snapshot_Handler,
+			ServerStreams: true,
+		},
 	},
-	Metadata: "system.proto",
+	Metadata: "server/proto/system.proto",
 }
@@ -1,7 +1,9 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -11,28 +13,51 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/accounts"
 	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/contracts/freeze"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/crosscheck"
 	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/crypto/threshold"
+	"github.com/0xPolygon/polygon-edge/firehose"
+	"github.com/0xPolygon/polygon-edge/gateway"
+	"github.com/0xPolygon/polygon-edge/halt"
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	configHelper "github.com/0xPolygon/polygon-edge/helper/config"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/helper/resources"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
+	"github.com/0xPolygon/polygon-edge/indexer"
+	"github.com/0xPolygon/polygon-edge/intent"
 	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/0xPolygon/polygon-edge/lightclient"
 	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/notifier"
+	"github.com/0xPolygon/polygon-edge/plugins"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/fork"
 	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/state/regen"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/upgrade"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/0xPolygon/polygon-edge/versioning"
 	"github.com/hashicorp/go-hclog"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 // Server is the central manager of the blockchain client
@@ -42,6 +67,9 @@ type Server struct {
 	state        state.State
 	stateStorage itrie.Storage
 
+	// remote chain the state of the dev chain is forked from, if any
+	forkRemote *fork.JSONRPCRemote
+
 	consensus consensus.Consensus
 
 	// blockchain stack
@@ -57,6 +85,9 @@ type Server struct {
 	// system grpc server
 	grpcServer *grpc.Server
 
+	// inProcListener serves the grpc server to the clients in the same process
+	inProcListener *bufconn.Listener
+
 	// libp2p network
 	network *network.Server
 
@@ -65,11 +96,56 @@ type Server struct {
 
 	prometheusServer *http.Server
 
+	// pprof endpoint and self-profiler
+	pprofServer *http.Server
+	profiler    *resources.Profiler
+
+	// REST gateway
+	restServer *http.Server
+
 	// secrets manager
 	secretsManager secrets.SecretsManager
 
 	// restore
 	restoreProgression *progress.ProgressionWrapper
+
+	// light client server
+	lightServer *lightclient.Server
+
+	// event notifier
+	notifier *notifier.Notifier
+
+	// secondary indexes of the indexer mode
+	indexer *indexer.Indexer
+
+	// stream of the chain data for the external indexing pipelines
+	firehose *firehose.Firehose
+
+	// accounts held in the node keystore
+	accountManager *accounts.Manager
+
+	// off-chain intents of the validators
+	intents *intent.Manager
+
+	// hooks of the enabled plugins
+	plugins *plugins.Hooks
+
+	// coordinator of the scheduled upgrades
+	upgrades *upgrade.Coordinator
+
+	// coordinated halt of the chain
+	halt *halt.Manager
+
+	// checker of the imported blocks against the reference client
+	crossCheck *crosscheck.Checker
+
+	// regenerator of the states missing at the old blocks
+	regen *regen.Regenerator
+
+	// sweeper of the storage of the deleted accounts
+	sweeper     *itrie.Sweeper
+	sweepSub    blockchain.Subscription
+	sweepDoneCh chan struct{}
 }
 
 var dirPaths = []string{
@@ -149,6 +225,20 @@ func NewServer(config *Config) (*Server, error) {
 		m.prometheusServer = m.startPrometheusServer(config.Telemetry.PrometheusAddr)
 	}
 
+	limits := m.tuneResources()
+
+	if config.Telemetry.PprofAddr != nil {
+		m.pprofServer = m.startPprofServer(config.Telemetry.PprofAddr)
+	}
+
+	if config.SelfProfile != nil {
+		profileConfig := *config.SelfProfile
+		profileConfig.MemoryLimit = limits.Memory
+
+		m.profiler = resources.NewProfiler(m.logger, &profileConfig)
+		m.profiler.Start()
+	}
+
 	// Set up datadog profiler
 	if ddErr := m.enableDataDogProfiler(); err != nil {
 		m.logger.Error("DataDog profiler setup failed", "err", ddErr.Error())
@@ -181,17 +271,52 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.stateStorage = stateStorage
 
-	st := itrie.NewState(stateStorage)
+	// the trie nodes are cached in front of the storage, for the reads of the recent states
+	trieStorage := stateStorage
+	if config.TrieCacheSize != 0 {
+		trieStorage = itrie.NewCachedStorage(stateStorage, config.TrieCacheSize)
+	}
+
+	st := itrie.NewState(trieStorage)
 	m.state = st
 
-	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
+	// the storage trie nodes are marked from now on, to be swept once deleted
+	if config.StorageSweep != nil {
+		if m.sweeper, err = st.EnableSweeping(config.StorageSweep); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Fork != nil {
+		if m.state, err = m.setupFork(st); err != nil {
+			return nil, err
+		}
+	}
+
+	m.executor = state.NewExecutor(config.Chain.Params, m.state, logger)
+	m.executor.SetCodeAnalysisStore(stateStorage)
+
+	if overrides := config.Chain.Params.GasOverrides; overrides != nil {
+		// the genesis hash commits to the overrides through the mix hash,
+		// the nodes with different overrides don't share the chain
+		if config.Chain.Genesis.Mixhash != overrides.Hash() {
+			return nil, errors.New("the genesis mix hash doesn't match the gas overrides")
+		}
+
+		if err := m.executor.SetGasOverrides(overrides); err != nil {
+			return nil, fmt.Errorf("invalid gas overrides: %w", err)
+		}
+	}
 
 	// compute the genesis root state
 	genesisRoot := m.executor.WriteGenesis(config.Chain.Genesis.Alloc)
 	config.Chain.Genesis.StateRoot = genesisRoot
 
-	// use the eip155 signer
-	signer := crypto.NewEIP155Signer(uint64(m.config.Chain.Params.ChainID))
+	// use the eip155 signer, the senders recovered by the pool are reused by the block import
+	senderCache := crypto.NewSenderCache(crypto.DefaultSenderCacheSize)
+	signer := crypto.NewCachedSigner(crypto.NewEIP155Signer(uint64(m.config.Chain.Params.ChainID)), senderCache)
+
+	m.executor.SetSenderCache(senderCache)
 
 	// blockchain object
 	m.blockchain, err = blockchain.NewBlockchain(logger, m.config.DataDir, config.Chain, nil, m.executor, signer)
@@ -201,6 +326,25 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.executor.GetHash = m.blockchain.GetHashHelper
 
+	if config.StateDiffs {
+		m.blockchain.EnableStateDiffs()
+	}
+
+	// the blocks of the scheduled upgrades are refused if the binary doesn't support them
+	m.upgrades = upgrade.NewCoordinator(logger, config.Chain.Params.Upgrades, versioning.Version)
+	m.blockchain.AddBlockGate(m.upgrades)
+
+	// the blocks after a divergence from the reference client are refused
+	if config.CrossCheck != nil {
+		if err := m.setupCrossCheck(); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.WarmCache {
+		m.warmUpCaches(st)
+	}
+
 	{
 		hub := &txpoolHub{
 			state:      m.state,
@@ -212,6 +356,23 @@ func NewServer(config *Config) (*Server, error) {
 			return nil, err
 		}
 
+		encryption, err := m.setupEncryptedMempool()
+		if err != nil {
+			return nil, err
+		}
+
+		// every node accepts the free transactions of a free-gas chain
+		if m.config.Chain.Params.FreeGas != nil && m.config.PriceLimit != 0 {
+			logger.Warn("ignoring the price limit in free-gas mode", "price-limit", m.config.PriceLimit)
+
+			m.config.PriceLimit = 0
+		}
+
+		var dailyGasQuota uint64
+		if m.config.Chain.Params.FreeGas != nil {
+			dailyGasQuota = m.config.Chain.Params.FreeGas.DailyGasQuota
+		}
+
 		// start transaction pool
 		m.txpool, err = txpool.NewTxPool(
 			logger,
@@ -224,6 +385,12 @@ func NewServer(config *Config) (*Server, error) {
 				PriceLimit:          m.config.PriceLimit,
 				MaxAccountEnqueued:  m.config.MaxAccountEnqueued,
 				DeploymentWhitelist: deploymentWhitelist,
+				PrivateTxPeers:      m.config.PrivateTxPeers,
+				Ordering:            m.config.Chain.Params.TxOrdering,
+				Encryption:          encryption,
+				MaxInitCodeSize:     m.config.Chain.Params.MaxInitCodeSize,
+				Freeze:              m.config.Chain.Params.Freeze != nil,
+				DailyGasQuota:       dailyGasQuota,
 			},
 		)
 		if err != nil {
@@ -231,6 +398,21 @@ func NewServer(config *Config) (*Server, error) {
 		}
 
 		m.txpool.SetSigner(signer)
+
+		// the sidecars of the blob transactions received by the pool are kept with the blocks
+		m.blockchain.SetBlobRetention(m.config.BlobRetention, m.txpool)
+	}
+
+	// the hooks of the enabled plugins vet the transactions and the blocks
+	if len(m.config.Plugins) > 0 {
+		hooks, err := plugins.Load(logger, m.config.Plugins)
+		if err != nil {
+			return nil, err
+		}
+
+		m.plugins = hooks
+		m.txpool.AddAdmissionHook(hooks)
+		m.blockchain.AddBlockHook(hooks)
 	}
 
 	{
@@ -241,6 +423,11 @@ func NewServer(config *Config) (*Server, error) {
 		m.blockchain.SetConsensus(m.consensus)
 	}
 
+	// the states missing at the old blocks are regenerated for the historical calls
+	if m.config.StateRegenBudget != 0 {
+		m.regen = regen.NewRegenerator(logger, m.blockchain, m.executor, m.consensus, m.config.StateRegenBudget)
+	}
+
 	// after consensus is done, we can mine the genesis block in blockchain
 	// This is done because consensus might use a custom Hash function so we need
 	// to wait for consensus because we do any block hashing like genesis
@@ -258,10 +445,55 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// setup and start REST gateway
+	if m.config.RESTAddr != nil {
+		m.restServer = m.startRESTServer(m.config.RESTAddr)
+	}
+
 	if err := m.network.Start(); err != nil {
 		return nil, err
 	}
 
+	// setup and start light client server
+	if m.config.LightServer {
+		if err := m.setupLightServer(); err != nil {
+			return nil, err
+		}
+	}
+
+	// setup and start indexer
+	if m.config.Indexer != nil {
+		if err := m.setupIndexer(); err != nil {
+			return nil, err
+		}
+	}
+
+	// setup and start firehose
+	if m.config.Firehose != nil {
+		if err := m.setupFirehose(); err != nil {
+			return nil, err
+		}
+	}
+
+	// setup account manager
+	if m.config.AccountManager {
+		if err := m.setupAccountManager(); err != nil {
+			return nil, err
+		}
+	}
+
+	// setup and start the exchange of the validator intents
+	if m.config.ValidatorIntents {
+		if err := m.setupIntents(); err != nil {
+			return nil, err
+		}
+	}
+
+	// setup the coordinated halt of the chain
+	if err := m.setupHalt(); err != nil {
+		return nil, err
+	}
+
 	// setup and start jsonrpc server
 	if err := m.setupJSONRPC(); err != nil {
 		return nil, err
@@ -272,6 +504,29 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// start event notifier
+	if m.config.Notifier != nil {
+		m.setupNotifier()
+	}
+
+	// start counting down the scheduled upgrades
+	m.upgrades.Start(m.blockchain)
+
+	// start following the halt signals
+	if m.halt != nil {
+		m.halt.Start()
+	}
+
+	// start comparing the imported blocks with the reference client
+	if m.crossCheck != nil {
+		m.crossCheck.Start()
+	}
+
+	// start sweeping the storage of the deleted accounts
+	if m.sweeper != nil {
+		m.startStorageSweep()
+	}
+
 	// start consensus
 	if err := m.consensus.Start(); err != nil {
 		return nil, err
@@ -318,6 +573,38 @@ func getAccountImpl(state state.State, root types.Hash, addr types.Address) (*st
 	return account, nil
 }
 
+// getStorageImpl is used for fetching contract storage from both TxPool and JSON-RPC
+func getStorageImpl(state state.State, root types.Hash, addr types.Address, slot types.Hash) (types.Hash, error) {
+	account, err := getAccountImpl(state, root, addr)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	snap, err := state.NewSnapshotAt(root)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return snap.GetStorage(addr, account.Root, slot), nil
+}
+
+// stateAt reads the contract storage at a state root, the storage of the missing accounts is empty.
+// It keeps the first error reading the storage
+type stateAt struct {
+	state state.State
+	root  types.Hash
+	err   error
+}
+
+func (s *stateAt) GetState(addr types.Address, key types.Hash) types.Hash {
+	value, err := getStorageImpl(s.state, s.root, addr, key)
+	if err != nil && !errors.Is(err, jsonrpc.ErrStateNotFound) && s.err == nil {
+		s.err = err
+	}
+
+	return value
+}
+
 func (t *txpoolHub) GetNonce(root types.Hash, addr types.Address) uint64 {
 	account, err := getAccountImpl(t.state, root, addr)
 
@@ -342,6 +629,12 @@ func (t *txpoolHub) GetBalance(root types.Hash, addr types.Address) (*big.Int, e
 	return account.Balance, nil
 }
 
+func (t *txpoolHub) GetStorage(root types.Hash, addr types.Address, slot types.Hash) types.Hash {
+	value, _ := getStorageImpl(t.state, root, addr, slot)
+
+	return value
+}
+
 // setupSecretsManager sets up the secrets manager
 func (s *Server) setupSecretsManager() error {
 	secretsManagerConfig := s.config.SecretsManager
@@ -418,6 +711,10 @@ func (s *Server) setupConsensus() error {
 			Logger:         s.logger,
 			SecretsManager: s.secretsManager,
 			BlockTime:      s.config.BlockTime,
+			Failover:       s.config.Failover,
+
+			TxExecutionBudget: s.config.TxExecutionBudget,
+			ExtraDataVanity:   s.extraDataVanity(),
 		},
 	)
 
@@ -434,6 +731,9 @@ type jsonRPCHub struct {
 	state              state.State
 	restoreProgression *progress.ProgressionWrapper
 
+	// regen regenerates the missing states, nil if disabled
+	regen *regen.Regenerator
+
 	*blockchain.Blockchain
 	*txpool.TxPool
 	*state.Executor
@@ -459,25 +759,44 @@ func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*jsonrpc.A
 	return account, nil
 }
 
+// GetQuarantinedTxs returns the transactions the pool excluded from the block building
+func (j *jsonRPCHub) GetQuarantinedTxs() []*jsonrpc.QuarantinedTx {
+	quarantined := j.TxPool.GetQuarantined()
+
+	txs := make([]*jsonrpc.QuarantinedTx, len(quarantined))
+	for i, tx := range quarantined {
+		txs[i] = &jsonrpc.QuarantinedTx{
+			Hash:   tx.Hash,
+			From:   tx.From,
+			Nonce:  tx.Nonce,
+			Reason: tx.Reason,
+			Time:   tx.Time,
+		}
+	}
+
+	return txs
+}
+
 // GetForksInTime returns the active forks at the given block height
 func (j *jsonRPCHub) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return j.Executor.GetForksInTime(blockNumber)
 }
 
 func (j *jsonRPCHub) GetStorage(stateRoot types.Hash, addr types.Address, slot types.Hash) ([]byte, error) {
-	account, err := getAccountImpl(j.state, stateRoot, addr)
+	res, err := getStorageImpl(j.state, stateRoot, addr, slot)
 	if err != nil {
 		return nil, err
 	}
 
-	snap, err := j.state.NewSnapshotAt(stateRoot)
-	if err != nil {
-		return nil, err
-	}
+	return res.Bytes(), nil
+}
 
-	res := snap.GetStorage(addr, account.Root, slot)
+// IsFrozen returns true if the account is frozen in the freeze contract at the block
+func (j *jsonRPCHub) IsFrozen(header *types.Header, account types.Address) (bool, error) {
+	reader := &stateAt{state: j.state, root: header.StateRoot}
+	frozen := freeze.IsFrozen(reader, account)
 
-	return res.Bytes(), nil
+	return frozen, reader.err
 }
 
 func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error) {
@@ -494,6 +813,196 @@ func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error
 	return code, nil
 }
 
+// GetProof returns the merkle proof of the account and the given storage slots
+func (j *jsonRPCHub) GetProof(
+	root types.Hash,
+	addr types.Address,
+	slots []types.Hash,
+) (*jsonrpc.AccountProof, error) {
+	prover, ok := j.state.(state.Prover)
+	if !ok {
+		return nil, errors.New("state doesn't support merkle proofs")
+	}
+
+	snap, err := j.state.NewSnapshotAt(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot for root '%s': %w", root, err)
+	}
+
+	account, err := snap.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		// the proof shows the absence of the account
+		account = &state.Account{
+			Balance:  big.NewInt(0),
+			Root:     types.EmptyRootHash,
+			CodeHash: crypto.Keccak256(nil),
+		}
+	}
+
+	accountProof, err := prover.GetProof(root, crypto.Keccak256(addr.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &jsonrpc.AccountProof{
+		Account: &jsonrpc.Account{
+			Nonce:   account.Nonce,
+			Balance: new(big.Int).Set(account.Balance),
+		},
+		CodeHash:     types.BytesToHash(account.CodeHash),
+		StorageRoot:  account.Root,
+		Proof:        accountProof,
+		StorageProof: make([]*jsonrpc.StorageProof, 0, len(slots)),
+	}
+
+	for _, slot := range slots {
+		proof, err := prover.GetProof(account.Root, crypto.Keccak256(slot.Bytes()))
+		if err != nil {
+			return nil, err
+		}
+
+		res.StorageProof = append(res.StorageProof, &jsonrpc.StorageProof{
+			Key:   slot,
+			Value: snap.GetStorage(addr, account.Root, slot),
+			Proof: proof,
+		})
+	}
+
+	return res, nil
+}
+
+// GetValidatorSet returns the validators of the block from the validator set snapshots
+// of the consensus, with their stake if the chain has the staking contract
+func (j *jsonRPCHub) GetValidatorSet(header *types.Header) ([]*jsonrpc.Validator, error) {
+	provider, ok := j.Consensus.(lightclient.ValidatorSetProvider)
+	if !ok {
+		return nil, errors.New("the consensus has no validator sets")
+	}
+
+	set, err := provider.GetValidators(header.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*jsonrpc.Validator, set.Len())
+
+	for idx := range res {
+		validator := set.At(uint64(idx))
+
+		res[idx] = &jsonrpc.Validator{
+			Address: validator.Addr(),
+		}
+
+		if blsValidator, ok := validator.(*validators.BLSValidator); ok {
+			res[idx].BLSPublicKey = blsValidator.BLSPublicKey
+		}
+	}
+
+	transition, err := j.beginStakingQuery(header)
+	if err != nil || transition == nil {
+		return res, err
+	}
+
+	for _, validator := range res {
+		if validator.Stake, err = staking.QueryAccountStake(transition, types.ZeroAddress, validator.Address); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// GetStake returns the amount staked by the account at the block, the total staked amount if nil
+func (j *jsonRPCHub) GetStake(header *types.Header, account *types.Address) (*big.Int, error) {
+	transition, err := j.beginStakingQuery(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if transition == nil {
+		return nil, errors.New("the chain has no staking contract")
+	}
+
+	if account == nil {
+		return staking.QueryStakedAmount(transition, types.ZeroAddress)
+	}
+
+	return staking.QueryAccountStake(transition, types.ZeroAddress, *account)
+}
+
+// GetAutoCompounding returns whether the rewards of the account are restaked at the block, and the sum
+// of its rewards restaked up to the block. It's read from the storage, the contracts deployed without
+// the auto-compounding have none
+func (j *jsonRPCHub) GetAutoCompounding(header *types.Header, account types.Address) (bool, *big.Int, error) {
+	reader := &stateAt{state: j.state, root: header.StateRoot}
+
+	compounding, err := stakingHelper.ReadAutoCompounding(func(slot types.Hash) (types.Hash, error) {
+		value := reader.GetState(staking.AddrStakingContract, slot)
+
+		return value, reader.err
+	}, account)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return compounding.Enabled, compounding.Rewards, nil
+}
+
+// beginStakingQuery begins a transition on the state of the header to query the staking contract,
+// nil if the chain has no staking contract
+func (j *jsonRPCHub) beginStakingQuery(header *types.Header) (*state.Transition, error) {
+	// the PoA chains have no staking contract
+	if code, err := j.GetCode(header.StateRoot, staking.AddrStakingContract); err != nil || len(code) == 0 {
+		return nil, nil
+	}
+
+	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return j.beginTxnAt(header, header, blockCreator)
+}
+
+// stakingStore serves the staking endpoint from the staking events of the indexer
+// and the stakes of the staking contract
+type stakingStore struct {
+	*indexer.Indexer
+	*jsonRPCHub
+
+	epochSize uint64
+}
+
+// EpochSize returns the number of the blocks of an epoch of the consensus
+func (s *stakingStore) EpochSize() uint64 {
+	return s.epochSize
+}
+
+// epochSizer is the consensus with fixed size epochs
+type epochSizer interface {
+	EpochSize() uint64
+}
+
+// beginTxnAt begins a transition of the header on the state at the parent header,
+// which is regenerated if it isn't retained
+func (j *jsonRPCHub) beginTxnAt(
+	parentHeader *types.Header,
+	header *types.Header,
+	blockCreator types.Address,
+) (*state.Transition, error) {
+	if j.regen != nil {
+		if err := j.regen.Regenerate(parentHeader); err != nil {
+			return nil, err
+		}
+	}
+
+	return j.BeginTxn(parentHeader.StateRoot, header, blockCreator)
+}
+
 func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
@@ -503,7 +1012,7 @@ func (j *jsonRPCHub) ApplyTxn(
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(header.StateRoot, header, blockCreator)
+	transition, err := j.beginTxnAt(header, header, blockCreator)
 	if err != nil {
 		return
 	}
@@ -513,6 +1022,36 @@ func (j *jsonRPCHub) ApplyTxn(
 	return
 }
 
+// ApplyTxns applies the transactions independently on the state of the given header,
+// the failure to apply a transaction is returned as the error of its result
+func (j *jsonRPCHub) ApplyTxns(
+	header *types.Header,
+	txns []*types.Transaction,
+) ([]*runtime.ExecutionResult, error) {
+	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := j.beginTxnAt(header, header, blockCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*runtime.ExecutionResult, len(txns))
+
+	for idx, txn := range txns {
+		result, err := transition.ApplyIsolated(txn)
+		if err != nil {
+			result = &runtime.ExecutionResult{Err: err}
+		}
+
+		results[idx] = result
+	}
+
+	return results, nil
+}
+
 // TraceBlock traces all transactions in the given block and returns all results
 func (j *jsonRPCHub) TraceBlock(
 	block *types.Block,
@@ -532,7 +1071,7 @@ func (j *jsonRPCHub) TraceBlock(
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(parentHeader.StateRoot, block.Header, blockCreator)
+	transition, err := j.beginTxnAt(parentHeader, block.Header, blockCreator)
 	if err != nil {
 		return nil, err
 	}
@@ -556,12 +1095,8 @@ func (j *jsonRPCHub) TraceBlock(
 	return results, nil
 }
 
-// TraceTxn traces a transaction in the block, associated with the given hash
-func (j *jsonRPCHub) TraceTxn(
-	block *types.Block,
-	targetTxHash types.Hash,
-	tracer tracer.Tracer,
-) (interface{}, error) {
+// GetConflictStats re-executes the block recording the accesses of its transactions
+func (j *jsonRPCHub) GetConflictStats(block *types.Block) (*state.ConflictStats, error) {
 	if block.Number() == 0 {
 		return nil, errors.New("genesis block can't have transaction")
 	}
@@ -576,18 +1111,165 @@ func (j *jsonRPCHub) TraceTxn(
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(parentHeader.StateRoot, block.Header, blockCreator)
+	transition, err := j.beginTxnAt(parentHeader, block.Header, blockCreator)
 	if err != nil {
 		return nil, err
 	}
 
-	var targetTx *types.Transaction
+	transition.RecordAccesses()
 
 	for _, tx := range block.Transactions {
-		if tx.Hash == targetTxHash {
-			targetTx = tx
-
-			break
+		if _, err := transition.Apply(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	return transition.ConflictStats(), nil
+}
+
+// GetWitness re-executes the block recording the parent state it touches
+func (j *jsonRPCHub) GetWitness(block *types.Block) (*state.Witness, error) {
+	if block.Number() == 0 {
+		return nil, errors.New("genesis block can't have transaction")
+	}
+
+	parentHeader, ok := j.GetHeaderByHash(block.ParentHash())
+	if !ok {
+		return nil, errors.New("parent header not found")
+	}
+
+	blockCreator, err := j.GetConsensus().GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := j.beginTxnAt(parentHeader, block.Header, blockCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	transition.RecordWitness()
+
+	for _, tx := range block.Transactions {
+		if _, err := transition.Apply(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	return transition.Witness(parentHeader.StateRoot)
+}
+
+// ReplayBlock re-executes the block against its parent state as its import does, tracing all
+// its transactions or only the target one, and returns the state root and the state diff it results in
+func (j *jsonRPCHub) ReplayBlock(
+	block *types.Block,
+	targetTxHash *types.Hash,
+	tracer tracer.Tracer,
+) (*state.Replay, error) {
+	if block.Number() == 0 {
+		return nil, errors.New("genesis block can't have transaction")
+	}
+
+	parentHeader, ok := j.GetHeaderByHash(block.ParentHash())
+	if !ok {
+		return nil, errors.New("parent header not found")
+	}
+
+	blockCreator, err := j.GetConsensus().GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := j.beginTxnAt(parentHeader, block.Header, blockCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := &state.Replay{
+		Traces: make([]interface{}, len(block.Transactions)),
+	}
+
+	found := targetTxHash == nil
+
+	for idx, tx := range block.Transactions {
+		if targetTxHash != nil && tx.Hash != *targetTxHash {
+			transition.SetTracer(nil)
+
+			if _, err := transition.Apply(tx); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		found = true
+
+		tracer.Clear()
+		transition.SetTracer(tracer)
+
+		if _, err := transition.Apply(tx); err != nil {
+			return nil, err
+		}
+
+		if replay.Traces[idx], err = tracer.GetResult(); err != nil {
+			return nil, err
+		}
+	}
+
+	if !found {
+		return nil, errors.New("target tx not found")
+	}
+
+	transition.SetTracer(nil)
+
+	if err := j.GetConsensus().PreCommitState(block.Header, transition); err != nil {
+		return nil, err
+	}
+
+	_, replay.StateRoot = transition.Commit()
+
+	if replay.StateDiff, err = transition.StateDiff(); err != nil {
+		return nil, err
+	}
+
+	replay.Receipts = transition.Receipts()
+	replay.GasUsed = transition.TotalGas()
+
+	return replay, nil
+}
+
+// TraceTxn traces a transaction in the block, associated with the given hash
+func (j *jsonRPCHub) TraceTxn(
+	block *types.Block,
+	targetTxHash types.Hash,
+	tracer tracer.Tracer,
+) (interface{}, error) {
+	if block.Number() == 0 {
+		return nil, errors.New("genesis block can't have transaction")
+	}
+
+	parentHeader, ok := j.GetHeaderByHash(block.ParentHash())
+	if !ok {
+		return nil, errors.New("parent header not found")
+	}
+
+	blockCreator, err := j.GetConsensus().GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := j.beginTxnAt(parentHeader, block.Header, blockCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	var targetTx *types.Transaction
+
+	for _, tx := range block.Transactions {
+		if tx.Hash == targetTxHash {
+			targetTx = tx
+
+			break
 		}
 
 		// Execute transactions without tracer until reaching the target transaction
@@ -619,7 +1301,7 @@ func (j *jsonRPCHub) TraceCall(
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(parentHeader.StateRoot, parentHeader, blockCreator)
+	transition, err := j.beginTxnAt(parentHeader, parentHeader, blockCreator)
 	if err != nil {
 		return nil, err
 	}
@@ -651,15 +1333,7 @@ func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 
 // setupJSONRCP sets up the JSONRPC server, using the set configuration
 func (s *Server) setupJSONRPC() error {
-	hub := &jsonRPCHub{
-		state:              s.state,
-		restoreProgression: s.restoreProgression,
-		Blockchain:         s.blockchain,
-		TxPool:             s.txpool,
-		Executor:           s.executor,
-		Consensus:          s.consensus,
-		Server:             s.network,
-	}
+	hub := s.newJSONRPCHub()
 
 	conf := &jsonrpc.Config{
 		Store:                    hub,
@@ -670,6 +1344,64 @@ func (s *Server) setupJSONRPC() error {
 		PriceLimit:               s.config.PriceLimit,
 		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
+		NonceManager:             s.config.JSONRPC.NonceManager,
+		FilterTimeout:            s.config.JSONRPC.FilterTimeout,
+		MaxFiltersPerClient:      s.config.JSONRPC.MaxFiltersPerClient,
+		ReadConcurrency:          s.config.JSONRPC.ReadConcurrency,
+		CallConcurrency:          s.config.JSONRPC.CallConcurrency,
+		TraceConcurrency:         s.config.JSONRPC.TraceConcurrency,
+		CallCacheSize:            int(s.config.JSONRPC.CallCacheSize),
+	}
+
+	if s.config.JSONRPC.PersistFilters {
+		conf.FilterStorePath = filepath.Join(s.config.DataDir, jsonrpc.FilterStoreFile)
+	}
+
+	if s.config.JSONRPC.ExplorerCompat {
+		conf.ExplorerStore = hub
+	}
+
+	// a relative socket path is resolved in the data directory
+	if ipcPath := s.config.JSONRPC.IPCPath; ipcPath != "" {
+		if !filepath.IsAbs(ipcPath) {
+			ipcPath = filepath.Join(s.config.DataDir, ipcPath)
+		}
+
+		conf.IPCPath = ipcPath
+	}
+
+	if s.indexer != nil {
+		conf.IndexStore = s.indexer
+
+		// the stake histories are served by the epochs of the consensus
+		if epochs, ok := s.consensus.(epochSizer); ok {
+			conf.StakingStore = &stakingStore{
+				Indexer:    s.indexer,
+				jsonRPCHub: hub,
+				epochSize:  epochs.EpochSize(),
+			}
+		}
+	}
+
+	if s.accountManager != nil {
+		conf.AccountManager = s.accountManager
+	}
+
+	if s.intents != nil {
+		conf.IntentStore = s.intents
+	}
+
+	if _, ok := s.consensus.(lightclient.ValidatorSetProvider); ok {
+		conf.ValidatorStore = hub
+	}
+
+	if s.config.Chain.Params.Freeze != nil {
+		conf.FreezeStore = hub
+	}
+
+	// the chain can only be controlled with the dev consensus
+	if dev, ok := s.consensus.(jsonrpc.DevStore); ok {
+		conf.DevStore = dev
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -682,9 +1414,241 @@ func (s *Server) setupJSONRPC() error {
 	return nil
 }
 
+// newJSONRPCHub returns the hub giving the jsonrpc endpoints access to the server components
+func (s *Server) newJSONRPCHub() *jsonRPCHub {
+	return &jsonRPCHub{
+		state:              s.state,
+		restoreProgression: s.restoreProgression,
+		regen:              s.regen,
+		Blockchain:         s.blockchain,
+		TxPool:             s.txpool,
+		Executor:           s.executor,
+		Consensus:          s.consensus,
+		Server:             s.network,
+	}
+}
+
+// setupIndexer sets up and starts the indexer, storing the indexes in the data directory
+func (s *Server) setupIndexer() error {
+	idx, err := indexer.NewIndexer(
+		s.logger,
+		filepath.Join(s.config.DataDir, "indexer"),
+		s.config.Indexer,
+		s.blockchain,
+		s.newJSONRPCHub(),
+	)
+	if err != nil {
+		return err
+	}
+
+	s.indexer = idx
+	s.indexer.Start()
+
+	return nil
+}
+
+// setupFirehose sets up and starts the firehose, resuming the stream of its sink
+func (s *Server) setupFirehose() error {
+	f, err := firehose.NewFirehose(
+		s.logger,
+		s.config.Firehose,
+		s.blockchain,
+		s.newJSONRPCHub(),
+	)
+	if err != nil {
+		return err
+	}
+
+	s.firehose = f
+	s.firehose.Start()
+
+	return nil
+}
+
+// setupAccountManager sets up the manager of the accounts stored in the keystore of the data directory
+func (s *Server) setupAccountManager() error {
+	manager, err := accounts.NewManager(
+		s.logger,
+		filepath.Join(s.config.DataDir, "keystore"),
+		crypto.NewEIP155Signer(uint64(s.config.Chain.Params.ChainID)),
+	)
+	if err != nil {
+		return err
+	}
+
+	s.accountManager = manager
+
+	return nil
+}
+
+// setupIntents sets up the exchange of the off-chain intents of the validators
+// on the libp2p network, the node signing intents if it has a validator key
+func (s *Server) setupIntents() error {
+	validatorSets, ok := s.consensus.(intent.ValidatorSetProvider)
+	if !ok {
+		return errors.New("the consensus has no validators to exchange intents")
+	}
+
+	var key *ecdsa.PrivateKey
+
+	if s.secretsManager.HasSecret(secrets.ValidatorKey) {
+		validatorKey, err := crypto.ReadConsensusKey(s.secretsManager)
+		if err != nil {
+			return err
+		}
+
+		key = validatorKey
+	}
+
+	s.intents = intent.NewManager(
+		s.logger,
+		uint64(s.config.Chain.Params.ChainID),
+		s.blockchain,
+		validatorSets,
+		key,
+	)
+
+	return s.intents.Start(s.network)
+}
+
+// setupHalt sets up the coordinated halt of the chain, the blocks past the halt
+// height being refused until the chain is resumed. The halts are signaled
+// by the validator intents if they're enabled
+func (s *Server) setupHalt() error {
+	validatorSets, ok := s.consensus.(halt.ValidatorSetProvider)
+	if !ok {
+		return nil
+	}
+
+	chainConfigHash, err := halt.ChainConfigHash(s.config.Chain)
+	if err != nil {
+		return err
+	}
+
+	var intents halt.IntentStore
+	if s.intents != nil {
+		intents = s.intents
+	}
+
+	manager, err := halt.NewManager(
+		s.logger,
+		s.config.DataDir,
+		uint64(s.config.Chain.Params.ChainID),
+		chainConfigHash,
+		s.blockchain,
+		validatorSets,
+		intents,
+	)
+	if err != nil {
+		return err
+	}
+
+	s.halt = manager
+	s.blockchain.AddBlockGate(s.halt)
+
+	return nil
+}
+
+// extraDataVanity returns the prefix of the extra data of the sealed blocks
+func (s *Server) extraDataVanity() []byte {
+	if !s.config.ExtraDataVersion {
+		return nil
+	}
+
+	return versioning.Vanity()
+}
+
+// startStorageSweep sweeps the storage of the deleted accounts on each new head,
+// up to the budget of the sweeper
+func (s *Server) startStorageSweep() {
+	s.sweepSub = s.blockchain.SubscribeEvents()
+	s.sweepDoneCh = make(chan struct{})
+
+	go func() {
+		defer close(s.sweepDoneCh)
+
+		for {
+			ev := s.sweepSub.GetEvent()
+			if ev == nil {
+				return
+			}
+
+			if ev.Type == blockchain.EventFork || len(ev.NewChain) == 0 {
+				continue
+			}
+
+			head := ev.NewChain[len(ev.NewChain)-1]
+
+			deleted, err := s.sweeper.Sweep(head.StateRoot, head.Number)
+			if err != nil {
+				s.logger.Error("failed to sweep the storage of the deleted accounts", "err", err)
+
+				continue
+			}
+
+			if deleted != 0 {
+				s.logger.Debug("swept storage of the deleted accounts", "nodes", deleted, "pending", s.sweeper.Pending())
+			}
+		}
+	}()
+}
+
+// setupCrossCheck sets up the checker comparing the imported blocks with the reference client
+func (s *Server) setupCrossCheck() error {
+	reference, err := crosscheck.NewJSONRPCReference(s.config.CrossCheck.URL)
+	if err != nil {
+		return err
+	}
+
+	s.crossCheck = crosscheck.NewChecker(s.logger, s.config.CrossCheck, s.blockchain, reference)
+	s.blockchain.AddBlockGate(s.crossCheck)
+
+	return nil
+}
+
+// setupLightServer sets up the light client server on the libp2p network
+func (s *Server) setupLightServer() error {
+	lightState, ok := s.state.(lightclient.State)
+	if !ok {
+		return errors.New("state doesn't support merkle proofs")
+	}
+
+	// the validator set changes are served only if the consensus has validators
+	validatorSet, _ := s.consensus.(lightclient.ValidatorSetProvider)
+
+	s.lightServer = lightclient.NewServer(
+		s.logger,
+		s.network,
+		s.blockchain,
+		lightState,
+		validatorSet,
+	)
+	s.lightServer.Start()
+
+	return nil
+}
+
+// setupNotifier sets up and starts the event notifier
+func (s *Server) setupNotifier() {
+	// validator set changes are only notified for the consensus providing them
+	var validatorSets notifier.ValidatorSetProvider
+	if provider, ok := s.consensus.(notifier.ValidatorSetProvider); ok {
+		validatorSets = provider
+	}
+
+	s.notifier = notifier.NewNotifier(s.logger, s.config.Notifier, s.blockchain, validatorSets)
+	s.notifier.Start()
+}
+
 // setupGRPC sets up the grpc server and listens on tcp
 func (s *Server) setupGRPC() error {
-	proto.RegisterSystemServer(s.grpcServer, &systemService{server: s})
+	system := &systemService{server: s}
+
+	proto.RegisterSystemServer(s.grpcServer, system)
+	proto.RegisterLegacySystemServer(s.grpcServer, system)
+
+	// expose the registered services to the code generating tooling
+	reflection.Register(s.grpcServer)
 
 	lis, err := net.Listen("tcp", s.config.GRPCAddr.String())
 	if err != nil {
@@ -697,6 +1661,14 @@ func (s *Server) setupGRPC() error {
 		}
 	}()
 
+	s.inProcListener = bufconn.Listen(inProcBufferSize)
+
+	go func() {
+		if err := s.grpcServer.Serve(s.inProcListener); err != nil {
+			s.logger.Error("closed in-process grpc listener", "err", err)
+		}
+	}()
+
 	s.logger.Info("GRPC server running", "addr", s.config.GRPCAddr.String())
 
 	return nil
@@ -712,21 +1684,109 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 	return s.network.JoinPeer(rawPeerMultiaddr)
 }
 
-// Close closes the Minimal server (blockchain, networking, consensus)
+// Close drains and closes the Minimal server (blockchain, networking, consensus),
+// in the order keeping the data directory consistent: the RPC endpoints stop accepting requests,
+// the consensus stops, the block import in flight is finished, the peers are disconnected
+// and the caches are flushed before the storages are closed
 func (s *Server) Close() {
-	// Close the blockchain layer
+	// Stop accepting the requests, the requests in flight
+	// have half of the grace period to complete
+	s.drainRPC(s.config.ShutdownGracePeriod / 2)
+
+	// Close the event notifier
+	if s.notifier != nil {
+		s.notifier.Close()
+	}
+
+	// Persist the polling filters
+	if s.jsonrpcServer != nil {
+		s.jsonrpcServer.Close()
+	}
+
+	// Stop the countdown of the upgrades
+	if s.upgrades != nil {
+		s.upgrades.Close()
+	}
+
+	// Stop following the halt signals
+	if s.halt != nil {
+		s.halt.Close()
+	}
+
+	// Stop comparing the imported blocks with the reference client
+	if s.crossCheck != nil {
+		if err := s.crossCheck.Close(); err != nil {
+			s.logger.Error("failed to close reference client", "err", err.Error())
+		}
+	}
+
+	// Stop sweeping before the state storage is closed
+	if s.sweepSub != nil {
+		s.sweepSub.Close()
+		<-s.sweepDoneCh
+	}
+
+	// Close the consensus layer, no block is sealed nor synced afterwards
+	if err := s.consensus.Close(); err != nil {
+		s.logger.Error("failed to close consensus", "err", err.Error())
+	}
+
+	// close the txpool's main loop
+	s.txpool.Close()
+
+	// Close the light client server
+	if s.lightServer != nil {
+		if err := s.lightServer.Close(); err != nil {
+			s.logger.Error("failed to close light client server", "err", err.Error())
+		}
+	}
+
+	// Close the indexer
+	if s.indexer != nil {
+		if err := s.indexer.Close(); err != nil {
+			s.logger.Error("failed to close indexer", "err", err.Error())
+		}
+	}
+
+	// Close the firehose
+	if s.firehose != nil {
+		if err := s.firehose.Close(); err != nil {
+			s.logger.Error("failed to close firehose", "err", err.Error())
+		}
+	}
+
+	// Lock the node accounts
+	if s.accountManager != nil {
+		s.accountManager.Close()
+	}
+
+	// Close the blockchain layer, once the block import in flight is written
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
 	}
 
-	// Close the networking layer
+	// Disconnect from the peers, and close the networking layer
+	s.network.DisconnectFromPeers("node shutting down")
+
 	if err := s.network.Close(); err != nil {
 		s.logger.Error("failed to close networking", "err", err.Error())
 	}
 
-	// Close the consensus layer
-	if err := s.consensus.Close(); err != nil {
-		s.logger.Error("failed to close consensus", "err", err.Error())
+	// Persist the access frequencies for the next warm up
+	if s.config.WarmCache {
+		s.saveAccessFrequencies()
+	}
+
+	// Close the enabled plugins
+	if s.plugins != nil {
+		s.plugins.Close()
+	}
+
+	// Close the connection to the forked chain
+	if s.forkRemote != nil {
+		if err := s.forkRemote.Close(); err != nil {
+			s.logger.Error("failed to close the connection to the forked chain", "err", err.Error())
+		}
 	}
 
 	// Close the state storage
@@ -740,11 +1800,160 @@ func (s *Server) Close() {
 		}
 	}
 
-	// close the txpool's main loop
-	s.txpool.Close()
+	if s.pprofServer != nil {
+		if err := s.pprofServer.Shutdown(context.Background()); err != nil {
+			s.logger.Error("pprof server shutdown error", "err", err)
+		}
+	}
+
+	if s.profiler != nil {
+		s.profiler.Close()
+	}
 
 	// close DataDog profiler
 	s.closeDataDogProfiler()
+
+	s.logger.Info("node closed")
+}
+
+// drainRPC stops accepting the JSON-RPC, GRPC and REST requests,
+// and waits for the requests in flight until the timeout
+func (s *Server) drainRPC(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	s.logger.Info("draining the rpc endpoints", "timeout", timeout)
+
+	if s.jsonrpcServer != nil {
+		if err := s.jsonrpcServer.Drain(ctx); err != nil {
+			s.logger.Error("failed to drain the json-rpc server", "err", err)
+		}
+	}
+
+	if s.restServer != nil {
+		if err := s.restServer.Shutdown(ctx); err != nil {
+			s.logger.Error("REST gateway shutdown error", "err", err)
+		}
+	}
+
+	// the streams are cut once the context is done
+	stoppedCh := make(chan struct{})
+
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stoppedCh)
+	}()
+
+	select {
+	case <-stoppedCh:
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+	}
+}
+
+// warmUpCaches preloads the accounts and the contract codes accessed the most
+// in the previous run and starts tracking the accesses of this one
+// setupFork layers the local state on top of the state of the forked chain
+func (s *Server) setupFork(st *itrie.State) (state.State, error) {
+	remote, err := fork.NewJSONRPCRemote(s.config.Fork.URL, s.config.Fork.Block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the forked chain, %w", err)
+	}
+
+	forked, err := fork.NewState(s.logger, st, remote, fork.DefaultCacheSize)
+	if err != nil {
+		_ = remote.Close()
+
+		return nil, err
+	}
+
+	s.forkRemote = remote
+
+	s.logger.Info("forking the remote chain", "url", s.config.Fork.URL, "block", remote.Block())
+
+	return forked, nil
+}
+
+// setupEncryptedMempool reads the configuration of the encrypted mempool from the chain params
+// and the key share of the node from the secrets manager, if any
+func (s *Server) setupEncryptedMempool() (*txpool.EncryptionConfig, error) {
+	params := s.config.Chain.Params.EncryptedMempool
+	if params == nil {
+		return nil, nil
+	}
+
+	config := &txpool.EncryptionConfig{
+		Threshold:    int(params.Threshold),
+		PublicShares: make([][]byte, len(params.PublicShares)),
+	}
+
+	for i, publicShare := range params.PublicShares {
+		buf, err := hex.DecodeHex(publicShare)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public share %d of the encrypted mempool, %w", i+1, err)
+		}
+
+		config.PublicShares[i] = buf
+	}
+
+	if !s.secretsManager.HasSecret(secrets.EncryptionKeyShare) {
+		return config, nil
+	}
+
+	encoded, err := s.secretsManager.GetSecret(secrets.EncryptionKeyShare)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := hex.DecodeHex(string(encoded))
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := threshold.UnmarshalShare(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if share.Index > uint64(len(config.PublicShares)) ||
+		!bytes.Equal(share.Public(), config.PublicShares[share.Index-1]) {
+		return nil, errors.New("the encryption key share doesn't match the public shares of the chain")
+	}
+
+	config.Share = share
+
+	return config, nil
+}
+
+func (s *Server) warmUpCaches(st *itrie.State) {
+	st.TrackAccesses()
+
+	stats, err := st.WarmUp(
+		filepath.Join(s.config.DataDir, itrie.WarmCacheFile),
+		s.blockchain.Header().StateRoot,
+	)
+	if err != nil {
+		s.logger.Error("failed to warm up the caches", "err", err)
+
+		return
+	}
+
+	s.logger.Info("warmed up the caches", "accounts", stats.Accounts, "codes", stats.Codes)
+}
+
+// saveAccessFrequencies persists the most accessed accounts and contract codes
+func (s *Server) saveAccessFrequencies() {
+	st, ok := s.state.(*itrie.State)
+	if !ok {
+		return
+	}
+
+	if err := st.SaveAccessFrequencies(
+		filepath.Join(s.config.DataDir, itrie.WarmCacheFile),
+		s.config.WarmCacheEntries,
+	); err != nil {
+		s.logger.Error("failed to save the access frequencies", "err", err)
+	}
 }
 
 // Entry is a consensus configuration entry
@@ -775,3 +1984,22 @@ func (s *Server) startPrometheusServer(listenAddr *net.TCPAddr) *http.Server {
 
 	return srv
 }
+
+// startRESTServer starts the REST gateway to the system service
+func (s *Server) startRESTServer(listenAddr *net.TCPAddr) *http.Server {
+	srv := &http.Server{
+		Addr:              listenAddr.String(),
+		Handler:           gateway.NewGateway(s.logger, &systemService{server: s}),
+		ReadHeaderTimeout: 60 * time.Second,
+	}
+
+	go func() {
+		s.logger.Info("REST gateway started", "addr", listenAddr.String())
+
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("REST gateway ListenAndServe", "err", err)
+		}
+	}()
+
+	return srv
+}
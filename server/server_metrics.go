@@ -1,10 +1,16 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
 	"os"
+	"runtime"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/helper/resources"
 	"github.com/armon/go-metrics"
 	"github.com/armon/go-metrics/prometheus"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
@@ -80,3 +86,59 @@ func (s *Server) closeDataDogProfiler() {
 	s.logger.Debug("closing DataDog tracer")
 	tracer.Stop()
 }
+
+// tuneResources sets the GOMAXPROCS of the node from its configuration, the GOMAXPROCS env var
+// or the CPU quota of its cgroup, in this order. It returns the limits of the cgroup
+func (s *Server) tuneResources() resources.Limits {
+	limits := resources.DetectLimits()
+
+	procs := s.config.MaxProcs
+	if procs == 0 {
+		if os.Getenv("GOMAXPROCS") != "" {
+			procs = runtime.GOMAXPROCS(0)
+		} else {
+			procs = resources.MaxProcs(limits)
+		}
+	}
+
+	runtime.GOMAXPROCS(procs)
+
+	s.logger.Info(
+		"Resources",
+		"gomaxprocs", procs,
+		"cpu_quota", limits.CPUs,
+		"memory_limit_mb", limits.Memory/1024/1024,
+	)
+
+	metrics.SetGauge([]string{"process", "gomaxprocs"}, float32(procs))
+	metrics.SetGauge([]string{"process", "memory_limit"}, float32(limits.Memory))
+
+	return limits
+}
+
+// startPprofServer serves the pprof profiles on their own mux,
+// they are never exposed by the other endpoints
+func (s *Server) startPprofServer(listenAddr *net.TCPAddr) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	srv := &http.Server{
+		Addr:              listenAddr.String(),
+		Handler:           mux,
+		ReadHeaderTimeout: 60 * time.Second,
+	}
+
+	go func() {
+		s.logger.Info("pprof server started", "addr", listenAddr.String())
+
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("pprof HTTP server ListenAndServe", "err", err)
+		}
+	}()
+
+	return srv
+}
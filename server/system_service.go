@@ -3,17 +3,32 @@ package server
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/halt"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/intent"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
+	"github.com/0xPolygon/polygon-edge/lightclient"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/versioning"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
+const latestBlockTag = "latest"
+
 type systemService struct {
 	proto.UnimplementedSystemServer
 
@@ -83,6 +98,81 @@ func (s *systemService) Subscribe(req *empty.Empty, stream proto.System_Subscrib
 	return nil
 }
 
+// SubscribeAccounts implements the account subscription service, streaming the current state
+// of the watched accounts followed by their balance and nonce changes as the blocks are executed
+func (s *systemService) SubscribeAccounts(
+	req *proto.SubscribeAccountsRequest,
+	stream proto.System_SubscribeAccountsServer,
+) error {
+	addresses := make([]types.Address, len(req.Addresses))
+
+	for idx, str := range req.Addresses {
+		addr, err := parseAddress(str)
+		if err != nil {
+			return err
+		}
+
+		addresses[idx] = addr
+	}
+
+	// subscribe before reading the current state so that no block is missed
+	sub := s.server.blockchain.SubscribeEvents()
+	defer sub.Close()
+
+	header := s.server.blockchain.Header()
+
+	watcher, err := jsonrpc.NewAccountWatcher(s.server.newJSONRPCHub(), addresses, header)
+	if errors.Is(err, jsonrpc.ErrNoWatchedAccounts) || errors.Is(err, jsonrpc.ErrTooManyWatchedAccounts) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if err := sendAccountChanges(stream, watcher.Snapshot(header)); err != nil {
+		return err
+	}
+
+	for {
+		evnt := sub.GetEvent()
+		if evnt == nil {
+			return nil
+		}
+
+		for _, h := range evnt.NewChain {
+			if h.Number <= header.Number && evnt.Type != blockchain.EventReorg {
+				// already included in the current state
+				continue
+			}
+
+			changes, err := watcher.Update(h, evnt.Type == blockchain.EventReorg)
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+
+			if err := sendAccountChanges(stream, changes); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func sendAccountChanges(stream proto.System_SubscribeAccountsServer, changes []*jsonrpc.AccountChange) error {
+	for _, change := range changes {
+		if err := stream.Send(&proto.AccountChange{
+			Address:     change.Address.String(),
+			Balance:     change.Balance.String(),
+			Nonce:       change.Nonce,
+			BlockNumber: change.BlockNumber,
+			BlockHash:   change.BlockHash.String(),
+			Reorg:       change.Reorg,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // PeersAdd implements the 'peers add' operator service
 func (s *systemService) PeersAdd(_ context.Context, req *proto.PeersAddRequest) (*proto.PeersAddResponse, error) {
 	if joinErr := s.server.JoinPeer(req.Id); joinErr != nil {
@@ -111,6 +201,45 @@ func (s *systemService) PeersStatus(ctx context.Context, req *proto.PeersStatusR
 	return peer, nil
 }
 
+// PeersACLGet implements the 'peers acl' operator service
+func (s *systemService) PeersACLGet(_ context.Context, _ *empty.Empty) (*proto.PeersACL, error) {
+	return toProtoACL(s.server.network.ACL()), nil
+}
+
+// PeersACLSet implements the 'peers acl --set' operator service
+func (s *systemService) PeersACLSet(_ context.Context, req *proto.PeersACL) (*proto.PeersACL, error) {
+	acl, err := network.ParseACL(req.AllowCidrs, req.DenyCidrs, req.AllowPeers, req.DenyPeers)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s.server.network.SetACL(acl)
+
+	return toProtoACL(acl), nil
+}
+
+func toProtoACL(acl *network.ACL) *proto.PeersACL {
+	resp := &proto.PeersACL{}
+
+	for _, ipNet := range acl.AllowCIDRs {
+		resp.AllowCidrs = append(resp.AllowCidrs, ipNet.String())
+	}
+
+	for _, ipNet := range acl.DenyCIDRs {
+		resp.DenyCidrs = append(resp.DenyCidrs, ipNet.String())
+	}
+
+	for _, id := range acl.AllowPeers {
+		resp.AllowPeers = append(resp.AllowPeers, id.String())
+	}
+
+	for _, id := range acl.DenyPeers {
+		resp.DenyPeers = append(resp.DenyPeers, id.String())
+	}
+
+	return resp
+}
+
 // getPeer returns a specific proto.Peer using the peer ID
 func (s *systemService) getPeer(id peer.ID) (*proto.Peer, error) {
 	protocols, err := s.server.network.GetProtocols(id)
@@ -222,6 +351,428 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	return nil
 }
 
+// Snapshot implements the Snapshot operator service. The blockchain snapshot is taken
+// before the trie one, the states being written before their blocks the streamed trie holds
+// the state of every block in the streamed blockchain
+func (s *systemService) Snapshot(_ *empty.Empty, stream proto.System_SnapshotServer) error {
+	chainSnapshot, err := s.server.blockchain.KVSnapshot()
+	if err != nil {
+		return status.Errorf(codes.Unimplemented, "blockchain snapshot: %v", err)
+	}
+
+	defer chainSnapshot.Release()
+
+	snapshotter, ok := s.server.stateStorage.(storage.KVSnapshotter)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "trie snapshot: %v", storage.ErrKVSnapshotNotSupported)
+	}
+
+	trieSnapshot, err := snapshotter.KVSnapshot()
+	if err != nil {
+		return err
+	}
+
+	defer trieSnapshot.Release()
+
+	for _, db := range []struct {
+		name     string
+		snapshot storage.KVSnapshot
+	}{
+		{"blockchain", chainSnapshot},
+		{"trie", trieSnapshot},
+	} {
+		if err := sendSnapshot(stream, db.name, db.snapshot, defaultMaxGRPCPayloadSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sendSnapshot streams the entries of the snapshot in events of up to maxPayloadSize bytes
+func sendSnapshot(
+	stream proto.System_SnapshotServer,
+	database string,
+	snapshot storage.KVSnapshot,
+	maxPayloadSize uint64,
+) error {
+	event := &proto.SnapshotEvent{Database: database}
+	size := uint64(0)
+
+	flush := func() error {
+		if len(event.Keys) == 0 {
+			return nil
+		}
+
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+
+		event = &proto.SnapshotEvent{Database: database}
+		size = 0
+
+		return nil
+	}
+
+	err := snapshot.Iterate(func(key, value []byte) error {
+		// the iterator reuses the buffers of the entries
+		event.Keys = append(event.Keys, append([]byte{}, key...))
+		event.Values = append(event.Values, append([]byte{}, value...))
+
+		if size += uint64(len(key) + len(value)); size >= maxPayloadSize {
+			return flush()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+// GetBlock implements the GetBlock query service
+func (s *systemService) GetBlock(ctx context.Context, req *proto.GetBlockRequest) (*proto.Block, error) {
+	header, err := s.resolveHeader(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := s.server.blockchain.GetBlockByHash(header.Hash, true)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "block %s not found", header.Hash)
+	}
+
+	resp := &proto.Block{
+		Number:           header.Number,
+		Hash:             header.Hash.String(),
+		ParentHash:       header.ParentHash.String(),
+		Miner:            types.BytesToAddress(header.Miner).String(),
+		StateRoot:        header.StateRoot.String(),
+		TransactionsRoot: header.TxRoot.String(),
+		ReceiptsRoot:     header.ReceiptsRoot.String(),
+		Timestamp:        header.Timestamp,
+		GasLimit:         header.GasLimit,
+		GasUsed:          header.GasUsed,
+		Transactions:     make([]string, len(block.Transactions)),
+	}
+
+	for i, txn := range block.Transactions {
+		resp.Transactions[i] = txn.Hash.String()
+	}
+
+	return resp, nil
+}
+
+// GetTransaction implements the GetTransaction query service
+func (s *systemService) GetTransaction(
+	ctx context.Context,
+	req *proto.GetTransactionRequest,
+) (*proto.Transaction, error) {
+	hash, err := parseHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHash, ok := s.server.blockchain.ReadTxLookup(hash)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "transaction %s not found", hash)
+	}
+
+	block, ok := s.server.blockchain.GetBlockByHash(blockHash, true)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "block %s not found", blockHash)
+	}
+
+	for idx, txn := range block.Transactions {
+		if txn.Hash != hash {
+			continue
+		}
+
+		resp := &proto.Transaction{
+			Hash:        txn.Hash.String(),
+			From:        txn.From.String(),
+			Nonce:       txn.Nonce,
+			Value:       txn.Value.String(),
+			Gas:         txn.Gas,
+			GasPrice:    txn.GasPrice.String(),
+			Input:       hex.EncodeToHex(txn.Input),
+			BlockNumber: block.Number(),
+			BlockHash:   block.Hash().String(),
+			Index:       uint64(idx),
+		}
+
+		if txn.To != nil {
+			resp.To = txn.To.String()
+		}
+
+		return resp, nil
+	}
+
+	return nil, status.Errorf(codes.NotFound, "transaction %s not found", hash)
+}
+
+// GetBalance implements the GetBalance query service
+func (s *systemService) GetBalance(ctx context.Context, req *proto.GetBalanceRequest) (*proto.Balance, error) {
+	addr, err := parseAddress(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := s.resolveHeader(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.Balance{
+		Address:     addr.String(),
+		Balance:     "0",
+		BlockNumber: header.Number,
+	}
+
+	account, err := getAccountImpl(s.server.state, header.StateRoot, addr)
+	if errors.Is(err, jsonrpc.ErrStateNotFound) {
+		// the account doesn't exist yet
+		return resp, nil
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp.Balance = account.Balance.String()
+	resp.Nonce = account.Nonce
+
+	return resp, nil
+}
+
+// GetValidators implements the GetValidators query service
+func (s *systemService) GetValidators(
+	ctx context.Context,
+	req *proto.GetValidatorsRequest,
+) (*proto.ValidatorSet, error) {
+	provider, ok := s.server.consensus.(lightclient.ValidatorSetProvider)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "consensus doesn't provide validator sets")
+	}
+
+	header, err := s.resolveHeader(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := provider.GetValidators(header.Number)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &proto.ValidatorSet{
+		BlockNumber: header.Number,
+		Validators:  make([]string, validators.Len()),
+	}
+
+	for i := 0; i < validators.Len(); i++ {
+		resp.Validators[i] = validators.At(uint64(i)).Addr().String()
+	}
+
+	return resp, nil
+}
+
+// GetUpgradeStatus returns the scheduled upgrades with the readiness of the node and its peers
+func (s *systemService) GetUpgradeStatus(_ context.Context, _ *empty.Empty) (*proto.UpgradeStatus, error) {
+	peerVersions := s.server.network.PeerVersions()
+
+	resp := &proto.UpgradeStatus{
+		Version:     versioning.Version,
+		BlockNumber: s.server.blockchain.Header().Number,
+		Upgrades:    []*proto.UpgradeStatus_Upgrade{},
+		Peers:       make([]*proto.UpgradeStatus_PeerVersion, 0, len(peerVersions)),
+	}
+
+	versions := make([]string, 0, len(peerVersions))
+	versionPeers := map[string]uint64{}
+
+	for id, version := range peerVersions {
+		versions = append(versions, version)
+		versionPeers[version]++
+		resp.Peers = append(resp.Peers, &proto.UpgradeStatus_PeerVersion{
+			Id:      id.String(),
+			Version: version,
+		})
+	}
+
+	sort.Slice(resp.Peers, func(i, j int) bool {
+		return resp.Peers[i].Id < resp.Peers[j].Id
+	})
+
+	// the distribution of the versions shows the adoption of an upgrade across the network
+	resp.Versions = make([]*proto.UpgradeStatus_VersionCount, 0, len(versionPeers))
+
+	for version, peers := range versionPeers {
+		resp.Versions = append(resp.Versions, &proto.UpgradeStatus_VersionCount{
+			Version: version,
+			Peers:   peers,
+		})
+	}
+
+	sort.Slice(resp.Versions, func(i, j int) bool {
+		if resp.Versions[i].Peers != resp.Versions[j].Peers {
+			return resp.Versions[i].Peers > resp.Versions[j].Peers
+		}
+
+		return resp.Versions[i].Version < resp.Versions[j].Version
+	})
+
+	for _, u := range s.server.upgrades.Status(versions) {
+		resp.Upgrades = append(resp.Upgrades, &proto.UpgradeStatus_Upgrade{
+			Name:       u.Name,
+			Block:      u.Block,
+			Version:    u.Version,
+			Supported:  u.Supported,
+			ReadyPeers: uint64(u.ReadyPeers),
+		})
+	}
+
+	return resp, nil
+}
+
+// HaltSignal signs the intent of the validator of the node to halt the chain after a block
+func (s *systemService) HaltSignal(_ context.Context, req *proto.HaltRequest) (*proto.HaltStatus, error) {
+	if s.server.halt == nil {
+		return nil, status.Error(codes.Unimplemented, "consensus doesn't provide validator sets")
+	}
+
+	if _, err := s.server.halt.Signal(req.Height); err != nil {
+		if errors.Is(err, halt.ErrInvalidHaltHeight) || errors.Is(err, intent.ErrInvalidIntent) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return s.haltStatus()
+}
+
+// GetHaltStatus returns the halt status of the chain
+func (s *systemService) GetHaltStatus(_ context.Context, _ *empty.Empty) (*proto.HaltStatus, error) {
+	if s.server.halt == nil {
+		return nil, status.Error(codes.Unimplemented, "consensus doesn't provide validator sets")
+	}
+
+	return s.haltStatus()
+}
+
+// Resume resumes the halted chain with a restart manifest signed by the validators
+func (s *systemService) Resume(_ context.Context, req *proto.ResumeRequest) (*proto.HaltStatus, error) {
+	if s.server.halt == nil {
+		return nil, status.Error(codes.Unimplemented, "consensus doesn't provide validator sets")
+	}
+
+	manifest := &halt.Manifest{}
+	if err := json.Unmarshal(req.Manifest, manifest); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid manifest: %v", err)
+	}
+
+	if err := s.server.halt.Resume(manifest); err != nil {
+		if errors.Is(err, halt.ErrNotHalted) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return s.haltStatus()
+}
+
+func (s *systemService) haltStatus() (*proto.HaltStatus, error) {
+	st, err := s.server.halt.Status()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &proto.HaltStatus{
+		Height:        st.Height,
+		Halted:        st.Halted,
+		BlockNumber:   s.server.blockchain.Header().Number,
+		Signers:       make([]string, 0, len(st.Signers)),
+		ResumedHeight: st.Resumed,
+		Signals:       make([]*proto.HaltStatus_Signal, 0, len(st.Signals)),
+	}
+
+	for _, signer := range st.Signers {
+		resp.Signers = append(resp.Signers, signer.String())
+	}
+
+	for _, signal := range st.Signals {
+		height, err := strconv.ParseUint(signal.Value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		resp.Signals = append(resp.Signals, &proto.HaltStatus_Signal{
+			Height:     height,
+			Validators: uint64(len(signal.Validators)),
+			Total:      uint64(signal.Total),
+		})
+	}
+
+	return resp, nil
+}
+
+// resolveHeader returns the header by number, hash or the latest tag.
+// An empty block defaults to the latest header
+func (s *systemService) resolveHeader(block string) (*types.Header, error) {
+	if block == "" || block == latestBlockTag {
+		return s.server.blockchain.Header(), nil
+	}
+
+	var (
+		header *types.Header
+		ok     bool
+	)
+
+	if len(block) == 2+2*types.HashLength {
+		hash, err := parseHash(block)
+		if err != nil {
+			return nil, err
+		}
+
+		header, ok = s.server.blockchain.GetHeaderByHash(hash)
+	} else {
+		number, err := types.ParseUint64orHex(&block)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid block %q", block)
+		}
+
+		header, ok = s.server.blockchain.GetHeaderByNumber(number)
+	}
+
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "block %s not found", block)
+	}
+
+	return header, nil
+}
+
+// parseHash parses the 0x prefixed hex encoded hash
+func parseHash(str string) (types.Hash, error) {
+	raw, err := hex.DecodeHex(str)
+	if err != nil || len(raw) != types.HashLength {
+		return types.ZeroHash, status.Errorf(codes.InvalidArgument, "invalid hash %q", str)
+	}
+
+	return types.BytesToHash(raw), nil
+}
+
+// parseAddress parses the 0x prefixed hex encoded address
+func parseAddress(str string) (types.Address, error) {
+	raw, err := hex.DecodeHex(str)
+	if err != nil || len(raw) != types.AddressLength {
+		return types.ZeroAddress, status.Errorf(codes.InvalidArgument, "invalid address %q", str)
+	}
+
+	return types.BytesToAddress(raw), nil
+}
+
 const (
 	defaultMaxGRPCPayloadSize uint64 = 512 * 1024 // 4MB
 
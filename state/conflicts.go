@@ -0,0 +1,219 @@
+package state
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// maxHotLocations is the number of the most conflicting locations reported
+const maxHotLocations = 10
+
+// location is an account or one of its storage slots
+type location struct {
+	addr    types.Address
+	slot    types.Hash
+	storage bool
+}
+
+// AccessSet are the state locations read and written by a transaction.
+// The reads served by an earlier write of the same transaction are not recorded,
+// the writes of the reverted calls are dropped
+type AccessSet struct {
+	reads  map[location]struct{}
+	writes []location
+
+	// the number of the writes of each location
+	written map[location]int
+
+	// the number of writes at each snapshot of the state
+	snapshots map[int]int
+}
+
+func newAccessSet() *AccessSet {
+	return &AccessSet{
+		reads:     make(map[location]struct{}),
+		written:   make(map[location]int),
+		snapshots: make(map[int]int),
+	}
+}
+
+func (a *AccessSet) read(loc location) {
+	if a.written[loc] > 0 {
+		return
+	}
+
+	a.reads[loc] = struct{}{}
+}
+
+func (a *AccessSet) write(loc location) {
+	a.writes = append(a.writes, loc)
+	a.written[loc]++
+}
+
+func (a *AccessSet) snapshot(id int) {
+	a.snapshots[id] = len(a.writes)
+}
+
+func (a *AccessSet) revertToSnapshot(id int) {
+	n, ok := a.snapshots[id]
+	if !ok {
+		return
+	}
+
+	for _, loc := range a.writes[n:] {
+		if a.written[loc]--; a.written[loc] == 0 {
+			delete(a.written, loc)
+		}
+	}
+
+	a.writes = a.writes[:n]
+}
+
+// TxConflicts are the accesses of a transaction of the block
+type TxConflicts struct {
+	Reads  int
+	Writes int
+
+	// the indexes of the earlier transactions that wrote a location read by the transaction
+	Dependencies []int
+}
+
+// LocationConflicts is the number of transactions reading a location written
+// by an earlier transaction of the block, Slot is nil for an account
+type LocationConflicts struct {
+	Address   types.Address
+	Slot      *types.Hash
+	Conflicts int
+}
+
+// ConflictStats measure how much of a block could be executed in parallel.
+// A transaction conflicts when it reads a location written by an earlier transaction,
+// it would have to be re-executed after it by an optimistic parallel executor
+type ConflictStats struct {
+	Transactions            int
+	ConflictingTransactions int
+	ConflictRate            float64
+
+	// CriticalPath is the longest chain of dependent transactions,
+	// Parallelism is the speedup over a sequential execution it allows
+	CriticalPath int
+	Parallelism  float64
+
+	Txs          []*TxConflicts
+	HotLocations []*LocationConflicts
+}
+
+// RecordAccesses records the accesses of the transactions applied from now on
+func (t *Transition) RecordAccesses() {
+	t.recordAccesses = true
+}
+
+// ConflictStats returns the conflicts of the transactions applied since RecordAccesses,
+// the coinbase is ignored since the fees paid to it are commutative
+func (t *Transition) ConflictStats() *ConflictStats {
+	return newConflictStats(t.accessSets, t.ctx.Coinbase)
+}
+
+func newConflictStats(sets []*AccessSet, coinbase types.Address) *ConflictStats {
+	stats := &ConflictStats{
+		Transactions: len(sets),
+		Txs:          make([]*TxConflicts, len(sets)),
+	}
+
+	var (
+		lastWriter = make(map[location]int)
+		conflicts  = make(map[location]int)
+		depth      = make([]int, len(sets))
+	)
+
+	for i, set := range sets {
+		tx := &TxConflicts{
+			Reads:  len(set.reads),
+			Writes: len(set.written),
+		}
+
+		deps := make(map[int]struct{})
+
+		for loc := range set.reads {
+			if loc.addr == coinbase {
+				continue
+			}
+
+			if writer, ok := lastWriter[loc]; ok {
+				deps[writer] = struct{}{}
+				conflicts[loc]++
+			}
+		}
+
+		for dep := range deps {
+			tx.Dependencies = append(tx.Dependencies, dep)
+
+			if depth[dep] > depth[i] {
+				depth[i] = depth[dep]
+			}
+		}
+
+		sort.Ints(tx.Dependencies)
+
+		depth[i]++
+
+		if depth[i] > stats.CriticalPath {
+			stats.CriticalPath = depth[i]
+		}
+
+		if len(tx.Dependencies) > 0 {
+			stats.ConflictingTransactions++
+		}
+
+		for loc := range set.written {
+			lastWriter[loc] = i
+		}
+
+		stats.Txs[i] = tx
+	}
+
+	if stats.Transactions > 0 {
+		stats.ConflictRate = float64(stats.ConflictingTransactions) / float64(stats.Transactions)
+		stats.Parallelism = float64(stats.Transactions) / float64(stats.CriticalPath)
+	}
+
+	for loc, count := range conflicts {
+		hot := &LocationConflicts{
+			Address:   loc.addr,
+			Conflicts: count,
+		}
+
+		if loc.storage {
+			slot := loc.slot
+			hot.Slot = &slot
+		}
+
+		stats.HotLocations = append(stats.HotLocations, hot)
+	}
+
+	sort.Slice(stats.HotLocations, func(i, j int) bool {
+		a, b := stats.HotLocations[i], stats.HotLocations[j]
+
+		if a.Conflicts != b.Conflicts {
+			return a.Conflicts > b.Conflicts
+		}
+
+		if cmp := bytes.Compare(a.Address.Bytes(), b.Address.Bytes()); cmp != 0 {
+			return cmp < 0
+		}
+
+		if a.Slot == nil || b.Slot == nil {
+			return a.Slot == nil && b.Slot != nil
+		}
+
+		return bytes.Compare(a.Slot.Bytes(), b.Slot.Bytes()) < 0
+	})
+
+	if len(stats.HotLocations) > maxHotLocations {
+		stats.HotLocations = stats.HotLocations[:maxHotLocations]
+	}
+
+	return stats
+}
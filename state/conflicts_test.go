@@ -0,0 +1,128 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordTxs applies each function as a transaction and returns its accesses
+func recordTxs(txn *Txn, txs ...func()) []*AccessSet {
+	sets := make([]*AccessSet, 0, len(txs))
+
+	for _, tx := range txs {
+		txn.accesses = newAccessSet()
+		tx()
+		sets = append(sets, txn.accesses)
+	}
+
+	txn.accesses = nil
+
+	return sets
+}
+
+func TestAccessSet(t *testing.T) {
+	t.Parallel()
+
+	txn := newTxn(newStateWithPreState(map[types.Address]*PreState{
+		addr1: {Balance: 100},
+	}))
+
+	sets := recordTxs(txn, func() {
+		// the read of its own write is not recorded
+		txn.SetState(addr1, hash1, hash2)
+		txn.GetState(addr1, hash1)
+
+		// the write of a reverted call is dropped
+		s := txn.Snapshot()
+		txn.SetState(addr1, hash2, hash1)
+		txn.IncrNonce(addr2)
+		txn.RevertToSnapshot(s)
+
+		txn.GetBalance(addr1)
+	})
+
+	set := sets[0]
+
+	assert.Equal(t, map[location]struct{}{
+		{addr: addr1}: {},
+		{addr: addr2}: {},
+	}, set.reads)
+
+	assert.Equal(t, map[location]int{
+		{addr: addr1, slot: hash1, storage: true}: 1,
+	}, set.written)
+}
+
+func TestConflictStats(t *testing.T) {
+	t.Parallel()
+
+	var (
+		coinbase = types.StringToAddress("c")
+		addr3    = types.StringToAddress("3")
+		addr4    = types.StringToAddress("4")
+	)
+
+	txn := newTxn(newStateWithPreState(map[types.Address]*PreState{
+		addr1: {Balance: 100},
+		addr2: {Balance: 100},
+		addr4: {Balance: 100},
+	}))
+
+	fee := big.NewInt(1)
+
+	sets := recordTxs(
+		txn,
+		// 0: writes a slot
+		func() {
+			txn.IncrNonce(addr1)
+			txn.SetState(addr3, hash1, hash1)
+			txn.AddBalance(coinbase, fee)
+		},
+		// 1: independent of 0, the coinbase is ignored
+		func() {
+			txn.IncrNonce(addr2)
+			txn.SetState(addr3, hash2, hash1)
+			txn.AddBalance(coinbase, fee)
+		},
+		// 2: reads the slot of 0
+		func() {
+			require.NoError(t, txn.SubBalance(addr4, fee))
+			txn.SetState(addr3, hash0, txn.GetState(addr3, hash1))
+		},
+		// 3: reads the account of 1 and the slot of 2
+		func() {
+			txn.GetNonce(addr2)
+			txn.GetState(addr3, hash0)
+		},
+	)
+
+	stats := newConflictStats(sets, coinbase)
+
+	assert.Equal(t, 4, stats.Transactions)
+	assert.Equal(t, 2, stats.ConflictingTransactions)
+	assert.Equal(t, 0.5, stats.ConflictRate)
+	assert.Equal(t, 3, stats.CriticalPath)
+	assert.InDelta(t, 4.0/3.0, stats.Parallelism, 1e-9)
+
+	assert.Empty(t, stats.Txs[0].Dependencies)
+	assert.Empty(t, stats.Txs[1].Dependencies)
+	assert.Equal(t, []int{0}, stats.Txs[2].Dependencies)
+	assert.Equal(t, []int{1, 2}, stats.Txs[3].Dependencies)
+
+	assert.Equal(t, 2, stats.Txs[2].Reads)
+	assert.Equal(t, 2, stats.Txs[2].Writes)
+
+	require.Len(t, stats.HotLocations, 3)
+
+	for _, hot := range stats.HotLocations {
+		assert.Equal(t, 1, hot.Conflicts)
+	}
+
+	// the account is listed before its slots
+	assert.Equal(t, addr2, stats.HotLocations[0].Address)
+	assert.Nil(t, stats.HotLocations[0].Slot)
+}
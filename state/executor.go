@@ -5,20 +5,23 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sync/atomic"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/freeze"
+	"github.com/0xPolygon/polygon-edge/contracts/gasquota"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
 	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/wasm"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 )
 
 const (
-	spuriousDragonMaxCodeSize = 24576
-
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
 )
@@ -37,16 +40,66 @@ type Executor struct {
 	state   State
 	GetHash GetHashByNumberHelper
 
+	// wasm runs the contracts registered in the genesis, it is shared by the transitions
+	// so that the decoded modules are cached
+	wasm *wasm.WASM
+
+	// analysis caches the jumpdest analysis of the deployed contracts between the transitions
+	analysis *evm.AnalysisCache
+
+	// gasOverrides override the gas costs from their block, gasTable holds their opcode costs
+	gasOverrides *chain.GasOverrides
+	gasTable     *evm.GasTable
+
+	// senderCache holds the senders already recovered by the pool, nil if not shared
+	senderCache *crypto.SenderCache
+
 	PostHook func(txn *Transition)
 }
 
 // NewExecutor creates a new executor
 func NewExecutor(config *chain.Params, s State, logger hclog.Logger) *Executor {
+	analysis, _ := evm.NewAnalysisCache(evm.DefaultAnalysisCacheSize, nil)
+
 	return &Executor{
-		logger: logger,
-		config: config,
-		state:  s,
+		logger:   logger,
+		config:   config,
+		state:    s,
+		wasm:     wasm.NewWASM(config.WasmContracts),
+		analysis: analysis,
+	}
+}
+
+// SetCodeAnalysisStore persists the code analyses in the store so that they survive restarts
+func (e *Executor) SetCodeAnalysisStore(store evm.AnalysisStore) {
+	e.analysis.SetStore(store)
+}
+
+// SetSenderCache shares the cache of the senders recovered by the pool with the block processing
+func (e *Executor) SetSenderCache(cache *crypto.SenderCache) {
+	e.senderCache = cache
+}
+
+// SetGasOverrides overrides the gas costs of the opcodes and precompiled contracts from the block
+// of the overrides, it fails if they name an unknown opcode or precompiled contract
+func (e *Executor) SetGasOverrides(overrides *chain.GasOverrides) error {
+	if overrides == nil {
+		return nil
+	}
+
+	table, err := evm.NewGasTable(overrides.Opcodes)
+	if err != nil {
+		return err
 	}
+
+	if err := precompiled.NewPrecompiled().SetGasCosts(overrides.Precompiles); err != nil {
+		return err
+	}
+
+	e.gasOverrides = overrides
+	e.gasTable = table
+
+	return nil
 }
 
 func (e *Executor) WriteGenesis(alloc map[types.Address]*chain.GenesisAccount) types.Hash {
@@ -94,6 +147,9 @@ func (e *Executor) ProcessBlock(
 		return nil, err
 	}
 
+	// the senders are recovered in parallel ahead of the execution
+	crypto.RecoverSenders(txn.signer, block.Transactions)
+
 	for _, t := range block.Transactions {
 		if t.ExceedsBlockGasLimit(block.Header.GasLimit) {
 			if err := txn.WriteFailedReceipt(t); err != nil {
@@ -111,6 +167,19 @@ func (e *Executor) ProcessBlock(
 	return txn, nil
 }
 
+// blockSigner returns the signer of the transactions of the block. The sender cache is only looked
+// up by the EIP-155 signer, the signer of the pool, the other signers recover the senders differently
+func (e *Executor) blockSigner(number uint64) crypto.TxSigner {
+	forks := e.config.Forks.At(number)
+	signer := crypto.NewSigner(forks, uint64(e.config.ChainID))
+
+	if forks.EIP155 && e.senderCache != nil {
+		return crypto.NewCachedSigner(signer, e.senderCache)
+	}
+
+	return signer
+}
+
 // StateAt returns snapshot at given root
 func (e *Executor) State() State {
 	return e.state
@@ -157,16 +226,46 @@ func (e *Executor) BeginTxn(
 		getHash:  e.GetHash(header),
 		auxState: e.state,
 		config:   forkConfig,
+		signer:   e.blockSigner(header.Number),
 		gasPool:  uint64(txCtx.GasLimit),
 
+		maxCodeSize:     e.config.GetMaxCodeSize(),
+		maxInitCodeSize: e.config.MaxInitCodeSize,
+
 		receipts: []*types.Receipt{},
 		totalGas: 0,
 
-		evm:         evm.NewEVM(),
+		coinbaseRewards: new(big.Int),
+
+		evm:         evm.NewEVMWithAnalysisCache(e.analysis),
 		precompiles: precompiled.NewPrecompiled(),
+		wasm:        e.wasm,
 		PostHook:    e.PostHook,
 	}
 
+	if e.config.FreeGas != nil {
+		txn.dailyGasQuota = e.config.FreeGas.DailyGasQuota
+	}
+
+	txn.freeze = e.config.Freeze
+
+	if forkConfig.BlobTx {
+		var excessBlobGas uint64
+		if header.ExcessBlobGas != nil {
+			excessBlobGas = *header.ExcessBlobGas
+		}
+
+		txn.blobGasPrice = types.CalcBlobGasPrice(excessBlobGas)
+	}
+
+	if e.gasOverrides.Active(header.Number) {
+		txn.evm.SetGasTable(e.gasTable)
+
+		if err := txn.precompiles.SetGasCosts(e.gasOverrides.Precompiles); err != nil {
+			return nil, err
+		}
+	}
+
 	return txn, nil
 }
 
@@ -183,15 +282,44 @@ type Transition struct {
 	ctx     runtime.TxContext
 	gasPool uint64
 
+	// signer recovers the senders of the transactions, through the sender cache if shared
+	signer crypto.TxSigner
+
+	// the limits of the contract codes, the init code is unlimited if 0
+	maxCodeSize     uint64
+	maxInitCodeSize uint64
+
+	// dailyGasQuota is the gas an account can use per day with free transactions, unlimited if 0
+	dailyGasQuota uint64
+
+	// freeze is the configuration of the emergency freeze of the accounts, nil if disabled
+	freeze *chain.Freeze
+
+	// blobGasPrice is the price of the blob gas of the block, set once the blob transactions are enabled
+	blobGasPrice *big.Int
+
+	// execBudget is the wall-clock time the execution of a transaction may take, unlimited if 0
+	execBudget time.Duration
+
 	// result
-	receipts []*types.Receipt
-	totalGas uint64
+	receipts    []*types.Receipt
+	totalGas    uint64
+	blobGasUsed uint64
+	committed   []*Object
+
+	// coinbaseRewards are the fees paid to the coinbase by the applied transactions
+	coinbaseRewards *big.Int
+
+	// the accesses of the applied transactions, if recorded
+	recordAccesses bool
+	accessSets     []*AccessSet
 
 	PostHook func(t *Transition)
 
 	// runtimes
 	evm         *evm.EVM
 	precompiles *precompiled.Precompiled
+	wasm        *wasm.WASM
 }
 
 func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transition {
@@ -199,8 +327,11 @@ func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transit
 		config:      config,
 		state:       radix,
 		snap:        snap,
+		maxCodeSize: chain.DefaultMaxCodeSize,
 		evm:         evm.NewEVM(),
 		precompiles: precompiled.NewPrecompiled(),
+
+		coinbaseRewards: new(big.Int),
 	}
 }
 
@@ -208,14 +339,33 @@ func (t *Transition) TotalGas() uint64 {
 	return t.totalGas
 }
 
+// BlobGasUsed returns the blob gas used by the blob transactions
+func (t *Transition) BlobGasUsed() uint64 {
+	return t.blobGasUsed
+}
+
 func (t *Transition) Receipts() []*types.Receipt {
 	return t.receipts
 }
 
+// CoinbaseRewards returns the fees paid to the coinbase by the transactions applied so far
+func (t *Transition) CoinbaseRewards() *big.Int {
+	return new(big.Int).Set(t.coinbaseRewards)
+}
+
 var emptyFrom = types.Address{}
 
+// txSigner returns the signer of the transactions written to the transition
+func (t *Transition) txSigner() crypto.TxSigner {
+	if t.signer != nil {
+		return t.signer
+	}
+
+	return crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
+}
+
 func (t *Transition) WriteFailedReceipt(txn *types.Transaction) error {
-	signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
+	signer := t.txSigner()
 
 	if txn.From == emptyFrom {
 		// Decrypt the from address
@@ -244,9 +394,32 @@ func (t *Transition) WriteFailedReceipt(txn *types.Transaction) error {
 	return nil
 }
 
+// SetExecutionBudget limits the wall-clock time of the execution of each written transaction,
+// the transactions exceeding it are rejected with ErrExecutionTimeout. It is only meant for
+// the block building, the execution time of a transaction isn't deterministic
+func (t *Transition) SetExecutionBudget(budget time.Duration) {
+	t.execBudget = budget
+}
+
 // Write writes another transaction to the executor
 func (t *Transition) Write(txn *types.Transaction) error {
-	signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
+	signer := t.txSigner()
+
+	if t.execBudget > 0 {
+		// each transaction has its own flag, a late timer doesn't interrupt the next one
+		interrupt := new(int32)
+		timer := time.AfterFunc(t.execBudget, func() {
+			atomic.StoreInt32(interrupt, 1)
+		})
+
+		t.ctx.Interrupt = interrupt
+
+		defer func() {
+			timer.Stop()
+
+			t.ctx.Interrupt = nil
+		}()
+	}
 
 	var err error
 	if txn.From == emptyFrom {
@@ -257,8 +430,38 @@ func (t *Transition) Write(txn *types.Transaction) error {
 		}
 	}
 
-	// Make a local copy and apply the transaction
+	if txn.IsSponsored() {
+		if !t.config.SponsoredTx {
+			return NewTransitionApplicationError(ErrSponsoredTxDisabled, false)
+		}
+
+		if txn.Sponsor == emptyFrom {
+			// Decrypt the address of the sponsor paying the gas
+			txn.Sponsor, err = signer.Sponsor(txn)
+			if err != nil {
+				return NewTransitionApplicationError(err, false)
+			}
+		}
+	}
+
+	if txn.Type == types.BlobTx && !t.config.BlobTx {
+		return NewTransitionApplicationError(ErrBlobTxDisabled, false)
+	}
+
+	if txn.ValidUntil != 0 {
+		if !t.config.TxExpiry {
+			return NewTransitionApplicationError(ErrTxExpiryDisabled, false)
+		}
+
+		if txn.IsExpired(uint64(t.ctx.Number)) {
+			return NewTransitionApplicationError(ErrTxExpired, false)
+		}
+	}
+
+	// Make a local copy and apply the transaction,
+	// paying the effective gas price of a blob transaction
 	msg := txn.Copy()
+	msg.GasPrice = txn.EffectiveGasPrice()
 
 	result, e := t.Apply(msg)
 	if e != nil {
@@ -282,6 +485,10 @@ func (t *Transition) Write(txn *types.Transaction) error {
 
 	if result.Failed() {
 		receipt.SetStatus(types.ReceiptFailed)
+
+		if result.Reverted() {
+			receipt.RevertReason = result.ReturnValue
+		}
 	} else {
 		receipt.SetStatus(types.ReceiptSuccess)
 	}
@@ -304,6 +511,8 @@ func (t *Transition) Commit() (Snapshot, types.Hash) {
 	objs := t.state.Commit(t.config.EIP155)
 	s2, root := t.snap.Commit(objs)
 
+	t.committed = objs
+
 	return s2, types.BytesToHash(root)
 }
 
@@ -327,11 +536,20 @@ func (t *Transition) Txn() *Txn {
 
 // Apply applies a new transaction
 func (t *Transition) Apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
+	if t.recordAccesses {
+		t.state.accesses = newAccessSet()
+		defer func() {
+			t.state.accesses = nil
+		}()
+	}
+
 	s := t.state.Snapshot()
 
 	result, err := t.apply(msg)
 	if err != nil {
 		t.state.RevertToSnapshot(s)
+	} else if t.recordAccesses {
+		t.accessSets = append(t.accessSets, t.state.accesses)
 	}
 
 	if t.PostHook != nil {
@@ -341,6 +559,23 @@ func (t *Transition) Apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	return result, err
 }
 
+// ApplyIsolated applies the message and reverts its state changes and the gas it consumed,
+// so that the messages applied on the same transition run independently on its state,
+// sharing the caches warmed by the previous ones
+func (t *Transition) ApplyIsolated(msg *types.Transaction) (*runtime.ExecutionResult, error) {
+	s := t.state.Snapshot()
+	gasPool, blobGasUsed := t.gasPool, t.blobGasUsed
+	coinbaseRewards := new(big.Int).Set(t.coinbaseRewards)
+
+	defer func() {
+		t.state.RevertToSnapshot(s)
+		t.gasPool, t.blobGasUsed = gasPool, blobGasUsed
+		t.coinbaseRewards = coinbaseRewards
+	}()
+
+	return t.Apply(msg)
+}
+
 // ContextPtr returns reference of context
 // This method is called only by test
 func (t *Transition) ContextPtr() *runtime.TxContext {
@@ -352,7 +587,7 @@ func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	upfrontGasCost := new(big.Int).Set(msg.GasPrice)
 	upfrontGasCost.Mul(upfrontGasCost, new(big.Int).SetUint64(msg.Gas))
 
-	if err := t.state.SubBalance(msg.From, upfrontGasCost); err != nil {
+	if err := t.state.SubBalance(msg.Payer(), upfrontGasCost); err != nil {
 		if errors.Is(err, runtime.ErrNotEnoughFunds) {
 			return ErrNotEnoughFundsForGas
 		}
@@ -383,6 +618,16 @@ var (
 	ErrIntrinsicGasOverflow  = fmt.Errorf("overflow in intrinsic gas calculation")
 	ErrNotEnoughIntrinsicGas = fmt.Errorf("not enough gas supplied for intrinsic gas costs")
 	ErrNotEnoughFunds        = fmt.Errorf("not enough funds for transfer with given value")
+	ErrSponsoredTxDisabled   = fmt.Errorf("sponsored transactions are not enabled")
+	ErrMaxInitCodeSize       = fmt.Errorf("max initcode size exceeded")
+	ErrGasQuotaExceeded      = fmt.Errorf("daily gas quota exceeded")
+	ErrBlobTxDisabled        = fmt.Errorf("blob transactions are not enabled")
+	ErrBlobFeeCapTooLow      = fmt.Errorf("max fee per blob gas lower than the blob gas price")
+	ErrBlobGasLimitReached   = fmt.Errorf("blob gas limit reached in the block")
+	ErrExecutionTimeout      = fmt.Errorf("transaction execution exceeded the time budget")
+	ErrTxExpiryDisabled      = fmt.Errorf("transaction expiry is not enabled")
+	ErrTxExpired             = fmt.Errorf("transaction expired")
+	ErrAccountFrozen         = fmt.Errorf("account frozen")
 )
 
 type TransitionApplicationError struct {
@@ -428,12 +673,19 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		return nil, NewTransitionApplicationError(err, true)
 	}
 
-	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
+	// 2. caller, or the sponsor, has enough balance to cover transaction fee(gaslimit * gasprice)
 	if err := t.subGasLimitPrice(msg); err != nil {
 		return nil, NewTransitionApplicationError(err, true)
 	}
 
-	// 3. the amount of gas required is available in the block
+	// 3. a free transaction doesn't exceed the daily gas quota of the caller,
+	// checked before the block gas pool is charged
+	if t.hasGasQuota(msg) &&
+		gasquota.Usage(txn, msg.From, t.quotaDay())+msg.Gas > t.dailyGasQuota {
+		return nil, NewTransitionApplicationError(ErrGasQuotaExceeded, false)
+	}
+
+	// 4. the amount of gas required is available in the block
 	if err := t.subGasPool(msg.Gas); err != nil {
 		return nil, NewGasLimitReachedTransitionApplicationError(err)
 	}
@@ -442,24 +694,41 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		t.ctx.Tracer.TxStart(msg.Gas)
 	}
 
-	// 4. there is no overflow when calculating intrinsic gas
+	// 5. there is no overflow when calculating intrinsic gas
 	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul)
 	if err != nil {
 		return nil, NewTransitionApplicationError(err, false)
 	}
 
-	// 5. the purchased gas is enough to cover intrinsic usage
+	// 6. the purchased gas is enough to cover intrinsic usage
 	gasLeft := msg.Gas - intrinsicGasCost
 	// Because we are working with unsigned integers for gas, the `>` operator is used instead of the more intuitive `<`
 	if gasLeft > msg.Gas {
 		return nil, NewTransitionApplicationError(ErrNotEnoughIntrinsicGas, false)
 	}
 
-	// 6. caller has enough balance to cover asset transfer for **topmost** call
+	// 7. caller has enough balance to cover asset transfer for **topmost** call
 	if balance := txn.GetBalance(msg.From); balance.Cmp(msg.Value) < 0 {
 		return nil, NewTransitionApplicationError(ErrNotEnoughFunds, true)
 	}
 
+	// 8. the creation code doesn't exceed the limit of the chain
+	if msg.IsContractCreation() && t.exceedsMaxInitCodeSize(msg.Input) {
+		return nil, NewTransitionApplicationError(ErrMaxInitCodeSize, false)
+	}
+
+	// 9. the caller, the payer and the recipient aren't frozen
+	if t.isFrozen(msg) {
+		return nil, NewTransitionApplicationError(ErrAccountFrozen, false)
+	}
+
+	// 10. a blob transaction pays the blob gas of the block, whose fee is burned
+	if msg.Type == types.BlobTx {
+		if err := t.subBlobGas(msg); err != nil {
+			return nil, err
+		}
+	}
+
 	gasPrice := new(big.Int).Set(msg.GasPrice)
 	value := new(big.Int).Set(msg.Value)
 
@@ -470,32 +739,151 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	var result *runtime.ExecutionResult
 	if msg.IsContractCreation() {
 		result = t.Create2(msg.From, msg.Input, value, gasLeft)
+	} else if account, frozen, ok := t.parseFreezeVote(msg); ok {
+		txn.IncrNonce(msg.From)
+		result = t.applyFreezeVote(msg.From, account, frozen, gasLeft)
 	} else {
 		txn.IncrNonce(msg.From)
 		result = t.Call2(msg.From, *msg.To, msg.Input, value, gasLeft)
 	}
 
+	// 11. the execution didn't exceed the time budget, the interrupted
+	// transaction is discarded with its state changes
+	if t.interrupted() {
+		t.addGasPool(msg.Gas)
+
+		if msg.Type == types.BlobTx {
+			t.blobGasUsed -= msg.BlobGas()
+		}
+
+		return nil, NewTransitionApplicationError(ErrExecutionTimeout, false)
+	}
+
+	// the refund can go up to half the gas used, a fifth since EIP-3529
+	refundQuotient := uint64(2)
+	if t.config.EIP3529 {
+		refundQuotient = 5
+	}
+
 	refund := txn.GetRefund()
-	result.UpdateGasUsed(msg.Gas, refund)
+	result.UpdateGasUsed(msg.Gas, refund, refundQuotient)
 
 	if t.ctx.Tracer != nil {
 		t.ctx.Tracer.TxEnd(result.GasLeft)
 	}
 
-	// refund the sender
+	// refund the sender, or the sponsor which paid the gas
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
-	txn.AddBalance(msg.From, remaining)
+	txn.AddBalance(msg.Payer(), remaining)
 
 	// pay the coinbase
 	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), gasPrice)
 	txn.AddBalance(t.ctx.Coinbase, coinbaseFee)
+	t.coinbaseRewards.Add(t.coinbaseRewards, coinbaseFee)
 
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
 
+	// account the gas of a free transaction
+	if t.hasGasQuota(msg) {
+		gasquota.AddUsage(txn, msg.From, t.quotaDay(), result.GasUsed)
+	}
+
 	return result, nil
 }
 
+// subBlobGas burns the blob fee of a blob transaction from its caller
+// and accounts its blob gas in the block
+func (t *Transition) subBlobGas(msg *types.Transaction) error {
+	if err := msg.ValidateBlobHashes(); err != nil {
+		return NewTransitionApplicationError(err, false)
+	}
+
+	if msg.BlobFeeCap == nil || msg.BlobFeeCap.Cmp(t.blobGasPrice) < 0 {
+		return NewTransitionApplicationError(ErrBlobFeeCapTooLow, true)
+	}
+
+	// the block goes on with the transactions without blobs
+	blobGas := msg.BlobGas()
+	if t.blobGasUsed+blobGas > types.MaxBlobGasPerBlock {
+		return NewTransitionApplicationError(ErrBlobGasLimitReached, true)
+	}
+
+	blobFee := new(big.Int).Mul(new(big.Int).SetUint64(blobGas), t.blobGasPrice)
+	if err := t.state.SubBalance(msg.From, blobFee); err != nil {
+		return NewTransitionApplicationError(ErrNotEnoughFundsForGas, true)
+	}
+
+	t.blobGasUsed += blobGas
+
+	return nil
+}
+
+// interrupted returns true if the execution of the transaction exceeded the time budget
+func (t *Transition) interrupted() bool {
+	return t.ctx.Interrupt != nil && atomic.LoadInt32(t.ctx.Interrupt) != 0
+}
+
+// isFrozen returns true if the caller, the payer or the recipient of the transaction is frozen
+func (t *Transition) isFrozen(msg *types.Transaction) bool {
+	if t.freeze == nil {
+		return false
+	}
+
+	if freeze.IsFrozen(t.state, msg.From) || freeze.IsFrozen(t.state, msg.Payer()) {
+		return true
+	}
+
+	return msg.To != nil && freeze.IsFrozen(t.state, *msg.To)
+}
+
+// parseFreezeVote returns the account voted by a transaction to the freeze contract,
+// and whether it is voted to be frozen or unfrozen
+func (t *Transition) parseFreezeVote(msg *types.Transaction) (types.Address, bool, bool) {
+	if t.freeze == nil || *msg.To != freeze.AddrFreezeContract || msg.Value.Sign() != 0 {
+		return types.ZeroAddress, false, false
+	}
+
+	return freeze.ParseVote(msg.Input)
+}
+
+// applyFreezeVote records the vote of a governor in the freeze contract,
+// it consumes all the gas like a precompiled contract if it fails
+func (t *Transition) applyFreezeVote(
+	governor,
+	account types.Address,
+	frozen bool,
+	gas uint64,
+) *runtime.ExecutionResult {
+	if gas < freeze.VoteGas {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrOutOfGas,
+		}
+	}
+
+	if err := freeze.Vote(t.state, t.freeze, governor, account, frozen); err != nil {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     err,
+		}
+	}
+
+	return &runtime.ExecutionResult{
+		GasLeft: gas - freeze.VoteGas,
+	}
+}
+
+// hasGasQuota returns true if the gas of the transaction counts towards the daily quota of the caller
+func (t *Transition) hasGasQuota(msg *types.Transaction) bool {
+	return t.dailyGasQuota != 0 && msg.GasPrice.Sign() == 0
+}
+
+// quotaDay returns the gas quota period of the block
+func (t *Transition) quotaDay() uint64 {
+	return gasquota.Day(uint64(t.ctx.Timestamp))
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -525,6 +913,10 @@ func (t *Transition) run(contract *runtime.Contract, host runtime.Host) *runtime
 	if t.precompiles.CanRun(contract, host, &t.config) {
 		return t.precompiles.Run(contract, host, &t.config)
 	}
+	// check the wasm contracts
+	if t.wasm != nil && t.wasm.CanRun(contract, host, &t.config) {
+		return t.wasm.Run(contract, host, &t.config)
+	}
 	// check the evm
 	if t.evm.CanRun(contract, host, &t.config) {
 		return t.evm.Run(contract, host, &t.config)
@@ -619,6 +1011,13 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		}
 	}
 
+	if t.exceedsMaxInitCodeSize(c.Code) {
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrMaxInitCodeSizeExceeded,
+		}
+	}
+
 	// Increment the nonce of the caller
 	t.state.IncrNonce(c.Caller)
 
@@ -633,6 +1032,8 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	// Take snapshot of the current state
 	snapshot := t.state.Snapshot()
 
+	t.state.MarkCreated(c.Address)
+
 	if t.config.EIP158 {
 		// Force the creation of the account
 		t.state.CreateAccount(c.Address)
@@ -663,8 +1064,8 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		return result
 	}
 
-	if t.config.EIP158 && len(result.ReturnValue) > spuriousDragonMaxCodeSize {
-		// Contract size exceeds 'SpuriousDragon' size limit
+	if t.config.EIP158 && uint64(len(result.ReturnValue)) > t.maxCodeSize {
+		// Contract size exceeds the EIP-170 size limit of the chain
 		t.state.RevertToSnapshot(snapshot)
 
 		return &runtime.ExecutionResult{
@@ -695,6 +1096,11 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	return result
 }
 
+// exceedsMaxInitCodeSize checks the creation code against the EIP-3860 limit of the chain, if any
+func (t *Transition) exceedsMaxInitCodeSize(code []byte) bool {
+	return t.maxInitCodeSize != 0 && uint64(len(code)) > t.maxInitCodeSize
+}
+
 func (t *Transition) SetStorage(
 	addr types.Address,
 	key types.Hash,
@@ -749,7 +1155,19 @@ func (t *Transition) GetNonce(addr types.Address) uint64 {
 }
 
 func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address) {
-	if !t.state.HasSuicided(addr) {
+	if t.config.EIP6780 && !t.state.IsCreated(addr) {
+		// the account isn't deleted, its balance is sent to the beneficiary
+		if addr != beneficiary {
+			balance := t.state.GetBalance(addr)
+
+			t.state.AddBalance(beneficiary, balance)
+			t.state.SetBalance(addr, big.NewInt(0))
+		}
+
+		return
+	}
+
+	if !t.config.EIP3529 && !t.state.HasSuicided(addr) {
 		t.state.AddRefund(24000)
 	}
 
@@ -796,6 +1214,18 @@ func (t *Transition) SetCodeDirectly(addr types.Address, code []byte) error {
 	return nil
 }
 
+// SetStorageDirectly sets the storage value of the account with the specified address
+// NOTE: SetStorageDirectly changes the world state without a transaction
+func (t *Transition) SetStorageDirectly(addr types.Address, key, value types.Hash) error {
+	if !t.AccountExists(addr) {
+		return fmt.Errorf("account doesn't exist at %s", addr)
+	}
+
+	t.state.SetState(addr, key, value)
+
+	return nil
+}
+
 // SetTracer sets tracer to the context in order to enable it
 func (t *Transition) SetTracer(tracer tracer.Tracer) {
 	t.ctx.Tracer = tracer
@@ -879,6 +1309,7 @@ func (t *Transition) captureCallEnd(c *runtime.Contract, result *runtime.Executi
 	t.ctx.Tracer.CallEnd(
 		c.Depth,
 		result.ReturnValue,
+		c.Gas-result.GasLeft,
 		result.Err,
 	)
 }
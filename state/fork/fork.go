@@ -0,0 +1,268 @@
+package fork
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// DefaultCacheSize is the number of remote accounts and storage slots kept in memory
+const DefaultCacheSize = 8192
+
+var emptyCodeHash = crypto.Keccak256(nil)
+
+// Remote reads the state of the forked chain at the fork block
+type Remote interface {
+	GetBalance(addr types.Address) (*big.Int, error)
+	GetNonce(addr types.Address) (uint64, error)
+	GetCode(addr types.Address) ([]byte, error)
+	GetStorage(addr types.Address, key types.Hash) (types.Hash, error)
+}
+
+// Local is the state holding the local writes on top of the forked chain
+type Local interface {
+	state.State
+
+	SetCode(hash types.Hash, code []byte)
+}
+
+type slotKey struct {
+	addr types.Address
+	key  types.Hash
+}
+
+// State is a state whose reads fall through to a remote chain when the local state
+// doesn't hold the entry, so that the local chain runs on top of the remote one.
+// The entries deleted locally are remembered so that they are not read again from the remote chain
+type State struct {
+	logger hclog.Logger
+
+	local  Local
+	remote Remote
+
+	// accounts caches the remote accounts, nil if the account doesn't exist
+	accounts *lru.Cache
+
+	// slots caches the remote storage slots
+	slots *lru.Cache
+
+	lock    sync.RWMutex
+	deleted map[types.Address]struct{}
+	cleared map[slotKey]struct{}
+}
+
+// NewState creates a state layering the local state on top of the remote one
+func NewState(logger hclog.Logger, local Local, remote Remote, cacheSize int) (*State, error) {
+	accounts, err := lru.New(cacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := lru.New(cacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &State{
+		logger:   logger.Named("fork"),
+		local:    local,
+		remote:   remote,
+		accounts: accounts,
+		slots:    slots,
+		deleted:  make(map[types.Address]struct{}),
+		cleared:  make(map[slotKey]struct{}),
+	}, nil
+}
+
+func (s *State) NewSnapshot() state.Snapshot {
+	return &Snapshot{state: s, local: s.local.NewSnapshot()}
+}
+
+func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
+	local, err := s.local.NewSnapshotAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{state: s, local: local}, nil
+}
+
+// GetCode returns the code by its hash. The remote codes are stored
+// in the local state as soon as their accounts are read
+func (s *State) GetCode(hash types.Hash) ([]byte, bool) {
+	return s.local.GetCode(hash)
+}
+
+func (s *State) isDeleted(addr types.Address) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, ok := s.deleted[addr]
+
+	return ok
+}
+
+func (s *State) isCleared(addr types.Address, key types.Hash) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if _, ok := s.deleted[addr]; ok {
+		return true
+	}
+
+	_, ok := s.cleared[slotKey{addr, key}]
+
+	return ok
+}
+
+// trackDeletions remembers the accounts and the slots deleted by the committed objects
+func (s *State) trackDeletions(objs []*state.Object) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, obj := range objs {
+		if obj.Deleted {
+			s.deleted[obj.Address] = struct{}{}
+
+			continue
+		}
+
+		for _, entry := range obj.Storage {
+			if entry.Deleted {
+				s.cleared[slotKey{obj.Address, types.BytesToHash(entry.Key)}] = struct{}{}
+			}
+		}
+	}
+}
+
+// getRemoteAccount returns the account of the remote chain, nil if it doesn't exist
+func (s *State) getRemoteAccount(addr types.Address) (*state.Account, error) {
+	if v, ok := s.accounts.Get(addr); ok {
+		account, _ := v.(*state.Account)
+
+		return account, nil
+	}
+
+	balance, err := s.remote.GetBalance(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := s.remote.GetNonce(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := s.remote.GetCode(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var account *state.Account
+
+	if nonce != 0 || balance.Sign() != 0 || len(code) != 0 {
+		account = &state.Account{
+			Nonce:    nonce,
+			Balance:  balance,
+			Root:     types.EmptyRootHash,
+			CodeHash: emptyCodeHash,
+		}
+
+		if len(code) != 0 {
+			account.CodeHash = crypto.Keccak256(code)
+			s.local.SetCode(types.BytesToHash(account.CodeHash), code)
+		}
+	}
+
+	s.accounts.Add(addr, account)
+
+	return account, nil
+}
+
+// getRemoteStorage returns the storage slot of the remote chain
+func (s *State) getRemoteStorage(addr types.Address, key types.Hash) (types.Hash, error) {
+	slot := slotKey{addr, key}
+
+	if v, ok := s.slots.Get(slot); ok {
+		val, _ := v.(types.Hash)
+
+		return val, nil
+	}
+
+	val, err := s.remote.GetStorage(addr, key)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	s.slots.Add(slot, val)
+
+	return val, nil
+}
+
+// Snapshot is a snapshot of the local state falling through to the remote chain
+type Snapshot struct {
+	state *State
+	local state.Snapshot
+}
+
+func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
+	account, err := s.local.GetAccount(addr)
+	if err != nil || account != nil {
+		return account, err
+	}
+
+	if s.state.isDeleted(addr) {
+		return nil, nil
+	}
+
+	account, err = s.state.getRemoteAccount(addr)
+	if err != nil {
+		s.state.logger.Error("failed to read the remote account", "address", addr, "err", err)
+
+		return nil, err
+	}
+
+	if account == nil {
+		return nil, nil
+	}
+
+	return account.Copy(), nil
+}
+
+// GetStorage returns the local slot, or the remote one if the slot is empty and was never cleared locally
+func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, key types.Hash) types.Hash {
+	if val := s.local.GetStorage(addr, root, key); val != types.ZeroHash {
+		return val
+	}
+
+	if s.state.isCleared(addr, key) {
+		return types.ZeroHash
+	}
+
+	val, err := s.state.getRemoteStorage(addr, key)
+	if err != nil {
+		s.state.logger.Error("failed to read the remote storage", "address", addr, "key", key, "err", err)
+
+		return types.ZeroHash
+	}
+
+	return val
+}
+
+func (s *Snapshot) GetCode(hash types.Hash) ([]byte, bool) {
+	return s.state.GetCode(hash)
+}
+
+func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
+	s.state.trackDeletions(objs)
+
+	local, root := s.local.Commit(objs)
+
+	return &Snapshot{state: s.state, local: local}, root
+}
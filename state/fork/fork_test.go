@@ -0,0 +1,132 @@
+package fork
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+
+	key1 = types.StringToHash("1")
+	key2 = types.StringToHash("2")
+)
+
+type mockRemote struct {
+	balances map[types.Address]*big.Int
+	codes    map[types.Address][]byte
+	storage  map[types.Address]map[types.Hash]types.Hash
+
+	calls int
+}
+
+func (m *mockRemote) GetBalance(addr types.Address) (*big.Int, error) {
+	m.calls++
+
+	if balance, ok := m.balances[addr]; ok {
+		return balance, nil
+	}
+
+	return big.NewInt(0), nil
+}
+
+func (m *mockRemote) GetNonce(addr types.Address) (uint64, error) {
+	return 0, nil
+}
+
+func (m *mockRemote) GetCode(addr types.Address) ([]byte, error) {
+	return m.codes[addr], nil
+}
+
+func (m *mockRemote) GetStorage(addr types.Address, key types.Hash) (types.Hash, error) {
+	m.calls++
+
+	return m.storage[addr][key], nil
+}
+
+func newTestState(t *testing.T, remote Remote) *State {
+	t.Helper()
+
+	st, err := NewState(hclog.NewNullLogger(), itrie.NewState(itrie.NewMemoryStorage()), remote, 16)
+	require.NoError(t, err)
+
+	return st
+}
+
+func TestState_RemoteAccount(t *testing.T) {
+	t.Parallel()
+
+	code := []byte{0x1, 0x2}
+
+	remote := &mockRemote{
+		balances: map[types.Address]*big.Int{addr1: big.NewInt(100)},
+		codes:    map[types.Address][]byte{addr1: code},
+	}
+
+	snap := newTestState(t, remote).NewSnapshot()
+
+	account, err := snap.GetAccount(addr1)
+	require.NoError(t, err)
+	require.NotNil(t, account)
+
+	assert.Equal(t, big.NewInt(100), account.Balance)
+	assert.Equal(t, crypto.Keccak256(code), account.CodeHash)
+
+	// the code is stored locally
+	res, ok := snap.GetCode(types.BytesToHash(account.CodeHash))
+	assert.True(t, ok)
+	assert.Equal(t, code, res)
+
+	// the account is cached
+	_, err = snap.GetAccount(addr1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, remote.calls)
+
+	// the empty accounts don't exist
+	account, err = snap.GetAccount(addr2)
+	require.NoError(t, err)
+	assert.Nil(t, account)
+}
+
+func TestState_LocalWrites(t *testing.T) {
+	t.Parallel()
+
+	remote := &mockRemote{
+		balances: map[types.Address]*big.Int{addr1: big.NewInt(100)},
+		storage: map[types.Address]map[types.Hash]types.Hash{
+			addr1: {key1: types.StringToHash("10"), key2: types.StringToHash("20")},
+		},
+	}
+
+	st := newTestState(t, remote)
+
+	txn := state.NewTxn(st.NewSnapshot())
+
+	// the remote state is updated locally
+	txn.AddBalance(addr1, big.NewInt(1))
+	txn.SetState(addr1, key1, types.StringToHash("11"))
+	txn.SetState(addr1, key2, types.ZeroHash)
+
+	_, root := st.NewSnapshot().Commit(txn.Commit(false))
+
+	local, err := st.NewSnapshotAt(types.BytesToHash(root))
+	require.NoError(t, err)
+
+	txn = state.NewTxn(local)
+
+	assert.Equal(t, big.NewInt(101), txn.GetBalance(addr1))
+	assert.Equal(t, types.StringToHash("11"), txn.GetState(addr1, key1))
+
+	// the slot cleared locally isn't read from the remote chain
+	assert.Equal(t, types.ZeroHash, txn.GetState(addr1, key2))
+}
@@ -0,0 +1,84 @@
+package fork
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// JSONRPCRemote reads the state of the forked chain through its JSON-RPC endpoint
+type JSONRPCRemote struct {
+	client *jsonrpc.Client
+	block  ethgo.BlockNumber
+}
+
+// NewJSONRPCRemote connects to the JSON-RPC endpoint of the forked chain.
+// The state is read at the given block, or at the latest block of the chain if it is 0
+func NewJSONRPCRemote(url string, block uint64) (*JSONRPCRemote, error) {
+	client, err := jsonrpc.NewClient(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create JSON-RPC client, %w", err)
+	}
+
+	latest, err := client.Eth().BlockNumber()
+	if err != nil {
+		_ = client.Close()
+
+		return nil, fmt.Errorf("unable to get the latest block of the forked chain, %w", err)
+	}
+
+	if block == 0 {
+		// pin the latest block so that all the reads see the same state
+		block = latest
+	} else if block > latest {
+		_ = client.Close()
+
+		return nil, fmt.Errorf("fork block %d is after the latest block %d of the forked chain", block, latest)
+	}
+
+	return &JSONRPCRemote{
+		client: client,
+		block:  ethgo.BlockNumber(block),
+	}, nil
+}
+
+// Block returns the block of the forked chain whose state is read
+func (r *JSONRPCRemote) Block() uint64 {
+	return uint64(r.block)
+}
+
+func (r *JSONRPCRemote) GetBalance(addr types.Address) (*big.Int, error) {
+	return r.client.Eth().GetBalance(ethgo.Address(addr), r.block)
+}
+
+func (r *JSONRPCRemote) GetNonce(addr types.Address) (uint64, error) {
+	return r.client.Eth().GetNonce(ethgo.Address(addr), r.block)
+}
+
+func (r *JSONRPCRemote) GetCode(addr types.Address) ([]byte, error) {
+	code, err := r.client.Eth().GetCode(ethgo.Address(addr), r.block)
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeHex(code)
+}
+
+func (r *JSONRPCRemote) GetStorage(addr types.Address, key types.Hash) (types.Hash, error) {
+	val, err := r.client.Eth().GetStorageAt(ethgo.Address(addr), ethgo.Hash(key), r.block)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.Hash(val), nil
+}
+
+// Close closes the connection to the forked chain
+func (r *JSONRPCRemote) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,232 @@
+package itrie
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/golang-lru/simplelru"
+)
+
+// dirtyLayers is the number of the most recent commits whose nodes are kept in the dirty layers
+const dirtyLayers = 32
+
+// NodeCacheStats are the lookups of the trie nodes served by each tier of the cache
+type NodeCacheStats struct {
+	DirtyHits uint64
+	CleanHits uint64
+	Misses    uint64
+}
+
+// CachedStorage is a trie storage caching the encoded trie nodes in two tiers: the nodes written
+// by the most recent commits are kept in per-commit dirty layers, the nodes read from the storage
+// and the nodes of the layers rotated out in a clean cache of a bounded size shared by the readers.
+// The nodes are content addressed and never change, they are only invalidated when deleted.
+// The other entries, such as the codes, aren't cached
+type CachedStorage struct {
+	Storage
+
+	// layersLock guards the dirty layers, the most recent last
+	layersLock sync.RWMutex
+	layers     []map[string][]byte
+
+	// cleanLock guards the clean cache, bounded by the size of the nodes
+	cleanLock sync.Mutex
+	clean     *simplelru.LRU
+	size      int
+	maxSize   int
+
+	dirtyHits uint64
+	cleanHits uint64
+	misses    uint64
+
+	// published are the stats already published to the metrics
+	published NodeCacheStats
+}
+
+// NewCachedStorage wraps the storage with a node cache whose clean tier holds up to size bytes
+func NewCachedStorage(storage Storage, size int) *CachedStorage {
+	c := &CachedStorage{
+		Storage: storage,
+		maxSize: size,
+	}
+
+	// the clean cache is bounded by the size of the nodes, not their number
+	c.clean, _ = simplelru.NewLRU(math.MaxInt32, func(_, value interface{}) {
+		c.size -= len(value.([]byte)) //nolint:forcetypeassert
+	})
+
+	return c
+}
+
+func isNodeKey(k []byte) bool {
+	return len(k) == types.HashLength
+}
+
+func (c *CachedStorage) Get(k []byte) ([]byte, bool) {
+	if !isNodeKey(k) {
+		return c.Storage.Get(k)
+	}
+
+	if v, ok := c.getDirty(k); ok {
+		atomic.AddUint64(&c.dirtyHits, 1)
+
+		return v, true
+	}
+
+	c.cleanLock.Lock()
+	v, ok := c.clean.Get(string(k))
+	c.cleanLock.Unlock()
+
+	if ok {
+		atomic.AddUint64(&c.cleanHits, 1)
+
+		return v.([]byte), true //nolint:forcetypeassert
+	}
+
+	atomic.AddUint64(&c.misses, 1)
+
+	data, ok := c.Storage.Get(k)
+	if ok {
+		c.addClean(string(k), data)
+	}
+
+	return data, ok
+}
+
+func (c *CachedStorage) getDirty(k []byte) ([]byte, bool) {
+	c.layersLock.RLock()
+	defer c.layersLock.RUnlock()
+
+	for i := len(c.layers) - 1; i >= 0; i-- {
+		if v, ok := c.layers[i][string(k)]; ok {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *CachedStorage) addClean(k string, v []byte) {
+	c.cleanLock.Lock()
+	defer c.cleanLock.Unlock()
+
+	if c.clean.Contains(k) || len(v) > c.maxSize {
+		return
+	}
+
+	c.clean.Add(k, v)
+	c.size += len(v)
+
+	for c.size > c.maxSize {
+		c.clean.RemoveOldest()
+	}
+}
+
+func (c *CachedStorage) Put(k, v []byte) {
+	c.Storage.Put(k, v)
+
+	if isNodeKey(k) {
+		c.addClean(string(k), append([]byte{}, v...))
+	}
+}
+
+// Delete deletes the entry from the storage and from the cache
+func (c *CachedStorage) Delete(k []byte) {
+	if d, ok := c.Storage.(deleter); ok {
+		d.Delete(k)
+	}
+
+	if !isNodeKey(k) {
+		return
+	}
+
+	c.layersLock.Lock()
+	for _, layer := range c.layers {
+		delete(layer, string(k))
+	}
+	c.layersLock.Unlock()
+
+	c.cleanLock.Lock()
+	c.clean.Remove(string(k))
+	c.cleanLock.Unlock()
+}
+
+// Batch returns a batch whose nodes are kept in a new dirty layer once written
+func (c *CachedStorage) Batch() Batch {
+	return &layerBatch{
+		Batch:   c.Storage.Batch(),
+		storage: c,
+		layer:   map[string][]byte{},
+	}
+}
+
+// pushLayer adds the dirty layer of a commit, the oldest layer is moved to the clean cache
+func (c *CachedStorage) pushLayer(layer map[string][]byte) {
+	c.layersLock.Lock()
+
+	c.layers = append(c.layers, layer)
+
+	var rotated map[string][]byte
+
+	if len(c.layers) > dirtyLayers {
+		rotated = c.layers[0]
+		c.layers = append(c.layers[:0:0], c.layers[1:]...)
+	}
+
+	c.layersLock.Unlock()
+
+	for k, v := range rotated {
+		c.addClean(k, v)
+	}
+
+	c.publishMetrics()
+}
+
+// Stats returns the lookups served by each tier of the cache
+func (c *CachedStorage) Stats() NodeCacheStats {
+	return NodeCacheStats{
+		DirtyHits: atomic.LoadUint64(&c.dirtyHits),
+		CleanHits: atomic.LoadUint64(&c.cleanHits),
+		Misses:    atomic.LoadUint64(&c.misses),
+	}
+}
+
+// publishMetrics publishes the lookups since the last commit, the lookups aren't published
+// one by one, they are too frequent
+func (c *CachedStorage) publishMetrics() {
+	stats := c.Stats()
+
+	c.cleanLock.Lock()
+	published := c.published
+	c.published = stats
+	size := c.size
+	c.cleanLock.Unlock()
+
+	metrics.IncrCounter([]string{"state", "trie_cache", "dirty_hits"}, float32(stats.DirtyHits-published.DirtyHits))
+	metrics.IncrCounter([]string{"state", "trie_cache", "clean_hits"}, float32(stats.CleanHits-published.CleanHits))
+	metrics.IncrCounter([]string{"state", "trie_cache", "misses"}, float32(stats.Misses-published.Misses))
+	metrics.SetGauge([]string{"state", "trie_cache", "clean_size"}, float32(size))
+}
+
+// layerBatch keeps the nodes it writes in a dirty layer
+type layerBatch struct {
+	Batch
+	storage *CachedStorage
+	layer   map[string][]byte
+}
+
+func (b *layerBatch) Put(k, v []byte) {
+	b.Batch.Put(k, v)
+
+	if isNodeKey(k) {
+		b.layer[string(k)] = append([]byte{}, v...)
+	}
+}
+
+func (b *layerBatch) Write() {
+	b.Batch.Write()
+	b.storage.pushLayer(b.layer)
+}
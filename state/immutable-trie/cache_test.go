@@ -0,0 +1,81 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedStorage_Tiers(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	cached := NewCachedStorage(storage, 1024*1024)
+
+	objs := []*state.Object{}
+
+	for i := 0; i < 20; i++ {
+		objs = append(objs, &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(i + 1)),
+			CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+			Root:     types.EmptyRootHash,
+		})
+	}
+
+	_, root := NewState(cached).NewSnapshot().Commit(objs)
+
+	// the nodes of the commit are served by its dirty layer
+	data, ok := cached.Get(root)
+	require.True(t, ok)
+
+	expected, ok := storage.Get(root)
+	require.True(t, ok)
+	assert.Equal(t, expected, data)
+	assert.Equal(t, NodeCacheStats{DirtyHits: 1}, cached.Stats())
+
+	// the nodes read from the storage are kept in the clean cache
+	other := NewCachedStorage(storage, 1024*1024)
+
+	_, ok = other.Get(root)
+	require.True(t, ok)
+
+	_, ok = other.Get(root)
+	require.True(t, ok)
+
+	assert.Equal(t, NodeCacheStats{CleanHits: 1, Misses: 1}, other.Stats())
+
+	// the deleted nodes are invalidated
+	cached.Delete(root)
+
+	_, ok = cached.Get(root)
+	assert.False(t, ok)
+
+	// the other entries aren't cached
+	cached.SetCode(types.StringToHash("1"), []byte{0x1})
+
+	_, ok = cached.Get([]byte("other"))
+	assert.False(t, ok)
+	assert.Equal(t, uint64(1), cached.Stats().Misses)
+}
+
+func TestCachedStorage_CleanSize(t *testing.T) {
+	t.Parallel()
+
+	cached := NewCachedStorage(NewMemoryStorage(), 100)
+
+	for i := 0; i < 10; i++ {
+		cached.Put(types.BytesToHash([]byte{byte(i)}).Bytes(), make([]byte, 30))
+	}
+
+	// the clean cache keeps the most recent nodes within its size
+	assert.Equal(t, 90, cached.size)
+	assert.Equal(t, 3, cached.clean.Len())
+	assert.True(t, cached.clean.Contains(string(types.BytesToHash([]byte{9}).Bytes())))
+	assert.False(t, cached.clean.Contains(string(types.BytesToHash([]byte{0}).Bytes())))
+}
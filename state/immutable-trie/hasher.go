@@ -3,12 +3,11 @@ package itrie
 import (
 	"errors"
 	"fmt"
-	"hash"
 	"sync"
 
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/umbracle/fastrlp"
-	"golang.org/x/crypto/sha3"
 )
 
 var arenaPool fastrlp.ArenaPool
@@ -19,26 +18,16 @@ var (
 
 var hasherPool = sync.Pool{
 	New: func() interface{} {
-		impl, ok := sha3.NewLegacyKeccak256().(hashImpl)
-		if !ok {
-			return nil
-		}
-
 		return &hasher{
-			hash: impl,
+			hash: keccak.NewKeccak256(),
 		}
 	},
 }
 
-type hashImpl interface {
-	hash.Hash
-	Read([]byte) (int, error)
-}
-
 type hasher struct {
 	arena []*fastrlp.Arena
 	buf   []byte
-	hash  hashImpl
+	hash  *keccak.Keccak
 	tmp   [32]byte
 }
 
@@ -72,15 +61,7 @@ func (h *hasher) AcquireArena() (*fastrlp.Arena, int) {
 func (h *hasher) Hash(data []byte) []byte {
 	h.hash.Reset()
 	h.hash.Write(data)
-	n, err := h.hash.Read(h.tmp[:])
-
-	if err != nil {
-		panic(err)
-	}
-
-	if n != 32 {
-		panic("incorrect length")
-	}
+	h.hash.SumTo(&h.tmp)
 
 	return h.tmp[:]
 }
@@ -0,0 +1,139 @@
+package itrie
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// IterateAccounts walks the account trie of the given root in the order of the hashed addresses,
+// and passes each account to onAccount with the hash of its address. Only the nodes on the path
+// being walked are held in memory. The walk stops at the first error, such as a missing node
+func (s *State) IterateAccounts(root types.Hash, onAccount func(addrHash types.Hash, account *state.Account) error) error {
+	return s.iterate(root, func(key types.Hash, value []byte) error {
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return fmt.Errorf("%w: %s", ErrAccountCorrupt, key)
+		}
+
+		return onAccount(key, &account)
+	})
+}
+
+// IterateStorage walks the storage trie of the given root in the order of the hashed slots,
+// and passes each value, without its leading zeros, to onSlot with the hash of its slot.
+// The value is only valid during the call
+func (s *State) IterateStorage(root types.Hash, onSlot func(slotHash types.Hash, value []byte) error) error {
+	parser := &fastrlp.Parser{}
+
+	return s.iterate(root, func(key types.Hash, value []byte) error {
+		v, err := parser.Parse(value)
+		if err != nil {
+			return fmt.Errorf("%w: slot %s", ErrTrieNodeCorrupt, key)
+		}
+
+		slot, err := v.Bytes()
+		if err != nil {
+			return fmt.Errorf("%w: slot %s", ErrTrieNodeCorrupt, key)
+		}
+
+		return onSlot(key, slot)
+	})
+}
+
+func (s *State) iterate(root types.Hash, onValue func(key types.Hash, value []byte) error) error {
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	it := &trieIterator{
+		storage: s.storage,
+		onValue: onValue,
+	}
+
+	return it.node(root.Bytes(), nil)
+}
+
+// trieIterator walks a trie depth first from the encoding of its nodes in the storage,
+// keeping the nibbles of the path to the current node
+type trieIterator struct {
+	storage Storage
+	onValue func(key types.Hash, value []byte) error
+}
+
+// node walks the node stored by its hash
+func (it *trieIterator) node(ref []byte, path []byte) error {
+	data, ok := it.storage.Get(ref)
+	if !ok {
+		return fmt.Errorf("%w: %x", ErrTrieNodeMissing, ref)
+	}
+
+	// the parser can't be shared, the values of a node are in use while walking its children
+	node, err := new(fastrlp.Parser).Parse(data)
+	if err != nil {
+		return fmt.Errorf("%w: %x", ErrTrieNodeCorrupt, ref)
+	}
+
+	return it.walk(node, path)
+}
+
+// walk walks the node and the nodes embedded in it. The path is extended in place,
+// the siblings being walked after the children of the previous ones
+func (it *trieIterator) walk(node *fastrlp.Value, path []byte) error {
+	if node.Type() == fastrlp.TypeBytes {
+		switch node.Len() {
+		case 0:
+			// empty child
+			return nil
+		case types.HashLength:
+			return it.node(node.Raw(), path)
+		default:
+			return fmt.Errorf("%w: invalid reference at %x", ErrTrieNodeCorrupt, path)
+		}
+	}
+
+	switch node.Elems() {
+	case 2:
+		key := node.Get(0)
+		if key.Type() != fastrlp.TypeBytes || key.Len() == 0 {
+			return fmt.Errorf("%w: invalid key at %x", ErrTrieNodeCorrupt, path)
+		}
+
+		nibbles := decodeCompact(key.Raw())
+		if hasTerminator(nibbles) {
+			return it.leaf(append(path, nibbles[:len(nibbles)-1]...), node.Get(1).Raw())
+		}
+
+		return it.walk(node.Get(1), append(path, nibbles...))
+	case 17:
+		for i := 0; i < 16; i++ {
+			if err := it.walk(node.Get(i), append(path, byte(i))); err != nil {
+				return err
+			}
+		}
+
+		if value := node.Get(16).Raw(); len(value) != 0 {
+			return it.leaf(path, value)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: invalid node at %x", ErrTrieNodeCorrupt, path)
+	}
+}
+
+// leaf passes the value to the callback with the key packed from the nibbles of its path
+func (it *trieIterator) leaf(path []byte, value []byte) error {
+	if len(path) != 2*types.HashLength {
+		return fmt.Errorf("%w: invalid key length at %x", ErrTrieNodeCorrupt, path)
+	}
+
+	var key types.Hash
+	for i := range key {
+		key[i] = path[2*i]<<4 | path[2*i+1]
+	}
+
+	return it.onValue(key, value)
+}
@@ -0,0 +1,77 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_Iterate(t *testing.T) {
+	t.Parallel()
+
+	st, root, objs := buildProofState(t, 30)
+
+	accounts := map[types.Hash]*state.Account{}
+	keys := []types.Hash{}
+
+	require.NoError(t, st.IterateAccounts(root, func(addrHash types.Hash, account *state.Account) error {
+		accounts[addrHash] = account
+		keys = append(keys, addrHash)
+
+		return nil
+	}))
+
+	require.Len(t, accounts, len(objs))
+
+	// the accounts are walked in the order of their keys
+	for i := 1; i < len(keys); i++ {
+		assert.Less(t, keys[i-1].String(), keys[i].String())
+	}
+
+	for _, obj := range objs {
+		account, ok := accounts[types.BytesToHash(crypto.Keccak256(obj.Address.Bytes()))]
+		require.True(t, ok)
+
+		assert.Equal(t, obj.Nonce, account.Nonce)
+		assert.Equal(t, obj.Balance, account.Balance)
+
+		slots := map[types.Hash][]byte{}
+
+		require.NoError(t, st.IterateStorage(account.Root, func(slotHash types.Hash, value []byte) error {
+			slots[slotHash] = append([]byte{}, value...)
+
+			return nil
+		}))
+
+		entry := obj.Storage[0]
+
+		assert.Equal(t, map[types.Hash][]byte{
+			types.BytesToHash(crypto.Keccak256(entry.Key)): big.NewInt(0).SetBytes(entry.Val).Bytes(),
+		}, slots)
+	}
+
+	// the empty tries have no entries
+	assert.NoError(t, st.IterateStorage(types.EmptyRootHash, func(types.Hash, []byte) error {
+		t.Fatal("unexpected slot")
+
+		return nil
+	}))
+
+	// the walk stops at a missing node
+	proof, err := st.GetProof(root, crypto.Keccak256(objs[0].Address.Bytes()))
+	require.NoError(t, err)
+
+	db := st.storage.(*memStorage) //nolint:forcetypeassert
+	delete(db.db, hex.EncodeToHex(crypto.Keccak256(proof[1])))
+
+	err = st.IterateAccounts(root, func(types.Hash, *state.Account) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrTrieNodeMissing)
+}
@@ -0,0 +1,179 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	ErrProofNodeNotFound = errors.New("proof node not found")
+	ErrInvalidProofNode  = errors.New("invalid proof node")
+)
+
+// GetProof returns the merkle proof of the key in the trie with the given root.
+// The proof is the list of RLP encoded nodes on the path from the root
+// towards the key and proves the absence of the key if it's not in the trie
+func (s *State) GetProof(root types.Hash, key []byte) ([][]byte, error) {
+	if root == types.EmptyRootHash {
+		return [][]byte{}, nil
+	}
+
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	var (
+		proof  = [][]byte{}
+		search = bytesToHexNibbles(key)
+		ref    = root.Bytes()
+	)
+
+	for ref != nil {
+		data, ok := s.storage.Get(ref)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrProofNodeNotFound, hex.EncodeToHex(ref))
+		}
+
+		node := make([]byte, len(data))
+		copy(node, data)
+
+		proof = append(proof, node)
+
+		v, err := p.Parse(node)
+		if err != nil {
+			return nil, err
+		}
+
+		if ref, _, search, err = walkNode(v, search); err != nil {
+			return nil, err
+		}
+	}
+
+	return proof, nil
+}
+
+// GetProofFromList builds an in-memory trie of the given keys and values
+// and returns the merkle proof of the key in it
+func GetProofFromList(keys, values [][]byte, key []byte) ([][]byte, error) {
+	storage := NewMemoryStorage()
+	batch := storage.Batch()
+
+	txn := (&Trie{storage: storage}).Txn()
+	txn.batch = batch
+
+	for i := range keys {
+		txn.Insert(keys[i], values[i])
+	}
+
+	root, err := txn.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	batch.Write()
+
+	return NewState(storage).GetProof(types.BytesToHash(root), key)
+}
+
+// VerifyProof verifies the merkle proof of the key against the root and returns
+// the value stored under the key. It returns nil value if the proof shows
+// the key doesn't exist in the trie
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	if root == types.EmptyRootHash {
+		return nil, nil
+	}
+
+	nodes := make(map[types.Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[types.BytesToHash(crypto.Keccak256(node))] = node
+	}
+
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	var (
+		search = bytesToHexNibbles(key)
+		ref    = root.Bytes()
+		value  []byte
+	)
+
+	for ref != nil {
+		node, ok := nodes[types.BytesToHash(ref)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrProofNodeNotFound, hex.EncodeToHex(ref))
+		}
+
+		v, err := p.Parse(node)
+		if err != nil {
+			return nil, err
+		}
+
+		if ref, value, search, err = walkNode(v, search); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	return append([]byte{}, value...), nil
+}
+
+// walkNode follows the search key through the node and the nodes embedded in it.
+// It returns either the hash of the next node to resolve with the remaining key,
+// or the value if the walk ended (nil value if the key is not in the trie)
+func walkNode(v *fastrlp.Value, search []byte) ([]byte, []byte, []byte, error) {
+	for {
+		if v.Type() == fastrlp.TypeBytes {
+			// reference to a node stored by its hash
+			if v.Len() == types.HashLength {
+				return v.Raw(), nil, search, nil
+			}
+
+			if v.Len() == 0 {
+				return nil, nil, search, nil
+			}
+
+			return nil, nil, nil, ErrInvalidProofNode
+		}
+
+		switch v.Elems() {
+		case 2:
+			// short node
+			key := v.Get(0)
+			if key.Type() != fastrlp.TypeBytes {
+				return nil, nil, nil, ErrInvalidProofNode
+			}
+
+			nibbles := decodeCompact(key.Raw())
+			if !bytes.HasPrefix(search, nibbles) {
+				return nil, nil, nil, nil
+			}
+
+			if hasTerminator(nibbles) {
+				return nil, v.Get(1).Raw(), nil, nil
+			}
+
+			search = search[len(nibbles):]
+			v = v.Get(1)
+
+		case 17:
+			// full node, the search key always ends with the terminator
+			if len(search) == 0 || search[0] == 16 {
+				return nil, v.Get(16).Raw(), nil, nil
+			}
+
+			v = v.Get(int(search[0]))
+			search = search[1:]
+
+		default:
+			return nil, nil, nil, ErrInvalidProofNode
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/fastrlp"
+)
+
+func buildProofState(t *testing.T, numAccounts int) (*State, types.Hash, []*state.Object) {
+	t.Helper()
+
+	st := NewState(NewMemoryStorage())
+
+	objs := make([]*state.Object, 0, numAccounts)
+
+	for i := 0; i < numAccounts; i++ {
+		objs = append(objs, &state.Object{
+			Address:  types.BytesToAddress(big.NewInt(int64(i + 1)).Bytes()),
+			Balance:  big.NewInt(int64(1000 * (i + 1))),
+			Nonce:    uint64(i),
+			CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+			Root:     types.EmptyRootHash,
+			Storage: []*state.StorageObject{
+				{
+					Key: types.BytesToHash([]byte{0x1}).Bytes(),
+					Val: types.BytesToHash(big.NewInt(int64(i + 10)).Bytes()).Bytes(),
+				},
+			},
+		})
+	}
+
+	_, root := st.NewSnapshot().Commit(objs)
+
+	return st, types.BytesToHash(root), objs
+}
+
+func TestProof_Account(t *testing.T) {
+	t.Parallel()
+
+	for _, numAccounts := range []int{1, 2, 50} {
+		st, root, objs := buildProofState(t, numAccounts)
+
+		for _, obj := range objs {
+			proof, err := st.GetProof(root, crypto.Keccak256(obj.Address.Bytes()))
+			assert.NoError(t, err)
+			assert.NotEmpty(t, proof)
+
+			value, err := VerifyProof(root, crypto.Keccak256(obj.Address.Bytes()), proof)
+			assert.NoError(t, err)
+
+			var account state.Account
+			assert.NoError(t, account.UnmarshalRlp(value))
+			assert.Equal(t, obj.Balance, account.Balance)
+			assert.Equal(t, obj.Nonce, account.Nonce)
+		}
+	}
+}
+
+func TestProof_Absence(t *testing.T) {
+	t.Parallel()
+
+	st, root, _ := buildProofState(t, 20)
+
+	key := crypto.Keccak256(types.StringToAddress("0xdead").Bytes())
+
+	proof, err := st.GetProof(root, key)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, proof)
+
+	value, err := VerifyProof(root, key, proof)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestProof_Storage(t *testing.T) {
+	t.Parallel()
+
+	st, root, objs := buildProofState(t, 3)
+
+	snap, err := st.NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	account, err := snap.GetAccount(objs[1].Address)
+	assert.NoError(t, err)
+
+	key := crypto.Keccak256(types.BytesToHash([]byte{0x1}).Bytes())
+
+	proof, err := st.GetProof(account.Root, key)
+	assert.NoError(t, err)
+
+	value, err := VerifyProof(account.Root, key, proof)
+	assert.NoError(t, err)
+
+	p := &fastrlp.Parser{}
+	v, err := p.Parse(value)
+	assert.NoError(t, err)
+
+	slot, err := v.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(11), new(big.Int).SetBytes(slot))
+}
+
+func TestProof_Invalid(t *testing.T) {
+	t.Parallel()
+
+	st, root, objs := buildProofState(t, 20)
+
+	key := crypto.Keccak256(objs[0].Address.Bytes())
+
+	proof, err := st.GetProof(root, key)
+	assert.NoError(t, err)
+
+	// missing root node
+	_, err = VerifyProof(root, key, proof[1:])
+	assert.ErrorIs(t, err, ErrProofNodeNotFound)
+
+	// proof for another root
+	_, err = VerifyProof(types.StringToHash("0x1"), key, proof)
+	assert.ErrorIs(t, err, ErrProofNodeNotFound)
+}
+
+func TestProof_EmptyTrie(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+
+	proof, err := st.GetProof(types.EmptyRootHash, []byte{0x1})
+	assert.NoError(t, err)
+	assert.Empty(t, proof)
+
+	value, err := VerifyProof(types.EmptyRootHash, []byte{0x1}, proof)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
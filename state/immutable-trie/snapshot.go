@@ -52,6 +52,10 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 }
 
 func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
+	if s.state.accesses != nil {
+		s.state.accesses.account(addr, 1)
+	}
+
 	key := crypto.Keccak256(addr.Bytes())
 
 	data, ok := s.trie.Get(key)
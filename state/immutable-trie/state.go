@@ -9,17 +9,29 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// codeCacheSize is the number of contract codes kept in memory
+const codeCacheSize = 1024
+
 type State struct {
-	storage Storage
-	cache   *lru.Cache
+	storage   Storage
+	cache     *lru.Cache
+	codeCache *lru.Cache
+
+	// accesses counts the accesses to the accounts and the codes, nil if they are not tracked
+	accesses *accessCounter
+
+	// sweeper sweeps the storage of the deleted accounts, nil if disabled
+	sweeper *Sweeper
 }
 
 func NewState(storage Storage) *State {
 	cache, _ := lru.New(128)
+	codeCache, _ := lru.New(codeCacheSize)
 
 	s := &State{
-		storage: storage,
-		cache:   cache,
+		storage:   storage,
+		cache:     cache,
+		codeCache: codeCache,
 	}
 
 	return s
@@ -51,7 +63,20 @@ func (s *State) SetCode(hash types.Hash, code []byte) {
 }
 
 func (s *State) GetCode(hash types.Hash) ([]byte, bool) {
-	return s.storage.GetCode(hash)
+	if s.accesses != nil {
+		s.accesses.code(hash, 1)
+	}
+
+	if code, ok := s.codeCache.Get(hash); ok {
+		return code.([]byte), true //nolint:forcetypeassert
+	}
+
+	code, ok := s.storage.GetCode(hash)
+	if ok {
+		s.codeCache.Add(hash, code)
+	}
+
+	return code, ok
 }
 
 func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
@@ -83,7 +108,7 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 	}
 
 	if !ok {
-		return nil, fmt.Errorf("state not found at hash %s", root)
+		return nil, fmt.Errorf("%w at hash %s", state.ErrStateNotFound, root)
 	}
 
 	t := &Trie{
@@ -3,6 +3,8 @@ package itrie
 import (
 	"fmt"
 
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	leveldbstore "github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -68,6 +70,10 @@ func (kv *KVStorage) Put(k, v []byte) {
 	_ = kv.db.Put(k, v, nil)
 }
 
+func (kv *KVStorage) Delete(k []byte) {
+	_ = kv.db.Delete(k, nil)
+}
+
 func (kv *KVStorage) Get(k []byte) ([]byte, bool) {
 	data, err := kv.db.Get(k, nil)
 	if err != nil {
@@ -81,6 +87,11 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool) {
 	return data, true
 }
 
+// KVSnapshot takes a consistent snapshot of the trie and the code entries
+func (kv *KVStorage) KVSnapshot() (storage.KVSnapshot, error) {
+	return leveldbstore.NewKVSnapshot(kv.db)
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -123,6 +134,10 @@ func (m *memStorage) Get(p []byte) ([]byte, bool) {
 	return v, true
 }
 
+func (m *memStorage) Delete(p []byte) {
+	delete(m.db, hex.EncodeToHex(p))
+}
+
 func (m *memStorage) SetCode(hash types.Hash, code []byte) {
 	m.code[hash.String()] = code
 }
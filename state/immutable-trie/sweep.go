@@ -0,0 +1,391 @@
+package itrie
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	// refsPrefix is the prefix of the reference marks of the storage trie nodes
+	refsPrefix = []byte("refs")
+
+	// sweepQueueKey is the key of the queue of the storage tries to sweep
+	sweepQueueKey = []byte("sweepqueue")
+)
+
+const (
+	// refOwned marks a node written by a single commit, only referenced by the tries
+	// of the account which wrote it
+	refOwned byte = 1
+
+	// refShared marks a node written more than once, it is never swept
+	refShared byte = 2
+)
+
+var ErrSweepUnsupported = errors.New("the storage doesn't support deletions")
+
+// SweepConfig is the configuration of the sweeping of the storage of the deleted accounts
+type SweepConfig struct {
+	// Budget is the maximum number of nodes deleted per block
+	Budget uint64
+
+	// Delay is the number of blocks an account must stay deleted before its storage is swept
+	Delay uint64
+}
+
+// deleter is a storage supporting the deletion of its entries
+type deleter interface {
+	Delete(k []byte)
+}
+
+// sweepEntry is the storage trie of a deleted account, queued to be swept
+type sweepEntry struct {
+	Address types.Address `json:"address"`
+	Root    types.Hash    `json:"root"`
+
+	// Queued is the head the entry was first checked at
+	Queued uint64 `json:"queued"`
+
+	// Absent is set once the account is missing from the head, since the Since block
+	Absent bool   `json:"absent"`
+	Since  uint64 `json:"since"`
+
+	checked bool
+
+	// stack is the post-order walk of the trie, rebuilt from the root after a restart
+	stack    [][]byte
+	expanded map[string]struct{}
+}
+
+// Sweeper deletes the storage tries of the deleted accounts in the background. Deleting an account
+// only removes it from the account trie, the nodes of its storage are left in the database.
+// They are swept lazily once the deletion is final, at most Budget nodes per block, so that
+// deleting a contract with a huge storage doesn't stall a single block.
+//
+// The nodes are content addressed and may be shared by several tries, so each storage trie node
+// is marked when written: only the nodes written once are swept, together with their subtrees.
+// The nodes written before the sweeping was enabled are unmarked and never swept.
+// The historical states referencing the swept nodes can't be queried anymore
+type Sweeper struct {
+	state   *State
+	storage Storage
+	deleter deleter
+	budget  uint64
+	delay   uint64
+
+	// lock guards the queue and the reference marks
+	lock  sync.Mutex
+	queue []*sweepEntry
+}
+
+// EnableSweeping marks the storage trie nodes written from now on
+// and returns the sweeper of the storage of the deleted accounts
+func (s *State) EnableSweeping(config *SweepConfig) (*Sweeper, error) {
+	d, ok := s.storage.(deleter)
+	if !ok {
+		return nil, ErrSweepUnsupported
+	}
+
+	sweeper := &Sweeper{
+		state:   s,
+		storage: s.storage,
+		deleter: d,
+		budget:  config.Budget,
+		delay:   config.Delay,
+		queue:   []*sweepEntry{},
+	}
+
+	if data, ok := s.storage.Get(sweepQueueKey); ok {
+		if err := json.Unmarshal(data, &sweeper.queue); err != nil {
+			return nil, fmt.Errorf("failed to decode sweep queue: %w", err)
+		}
+	}
+
+	s.sweeper = sweeper
+
+	return sweeper, nil
+}
+
+// Pending returns the number of storage tries queued to be swept
+func (s *Sweeper) Pending() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.queue)
+}
+
+// batch returns the batch marking the storage trie nodes it writes
+func (s *Sweeper) batch(batch Batch) Batch {
+	return &refBatch{Batch: batch, sweeper: s}
+}
+
+// mark marks the node as written once more. The mark is written right away,
+// before the node, so that a node being written is never swept
+func (s *Sweeper) mark(k []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ref := refOwned
+	if _, ok := s.storage.Get(refKey(k)); ok {
+		ref = refShared
+	}
+
+	s.storage.Put(refKey(k), []byte{ref})
+}
+
+// enqueue queues the storage trie of a deleted account. The deletion is checked against the head
+// before sweeping, the tries committed by the blocks which don't become canonical are dropped
+func (s *Sweeper) enqueue(addr types.Address, root types.Hash) {
+	if root == types.EmptyRootHash {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, entry := range s.queue {
+		if entry.Address == addr && entry.Root == root {
+			return
+		}
+	}
+
+	s.queue = append(s.queue, &sweepEntry{Address: addr, Root: root})
+}
+
+// Sweep sweeps the queued storage tries whose accounts have been missing from the head state
+// for the delay, up to the budget. It returns the number of deleted nodes
+func (s *Sweeper) Sweep(stateRoot types.Hash, number uint64) (uint64, error) {
+	head, err := s.state.newTrieAt(stateRoot)
+	if err != nil {
+		return 0, err
+	}
+
+	s.lock.Lock()
+	queue := append([]*sweepEntry{}, s.queue...)
+	s.lock.Unlock()
+
+	var (
+		deleted uint64
+		done    = map[*sweepEntry]struct{}{}
+	)
+
+	for _, entry := range queue {
+		if !entry.checked {
+			entry.checked = true
+			entry.Queued = number
+		}
+
+		if _, ok := head.Get(hashit(entry.Address.Bytes())); ok {
+			// the deletion isn't canonical, or the account was created again
+			entry.Absent = false
+
+			if number >= entry.Queued+s.delay {
+				done[entry] = struct{}{}
+			}
+
+			continue
+		}
+
+		if !entry.Absent {
+			entry.Absent = true
+			entry.Since = number
+		}
+
+		if number < entry.Since+s.delay || deleted >= s.budget {
+			continue
+		}
+
+		n, finished, err := s.sweepTrie(entry, s.budget-deleted)
+		deleted += n
+
+		if err != nil {
+			return deleted, err
+		}
+
+		if finished {
+			done[entry] = struct{}{}
+		}
+	}
+
+	if err := s.dequeue(done); err != nil {
+		return deleted, err
+	}
+
+	metrics.IncrCounter([]string{"state", "sweep", "deleted_nodes"}, float32(deleted))
+	metrics.SetGauge([]string{"state", "sweep", "pending_tries"}, float32(s.Pending()))
+
+	return deleted, nil
+}
+
+// dequeue removes the done entries and persists the queue
+func (s *Sweeper) dequeue(done map[*sweepEntry]struct{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	queue := make([]*sweepEntry, 0, len(s.queue))
+
+	for _, entry := range s.queue {
+		if _, ok := done[entry]; !ok {
+			queue = append(queue, entry)
+		}
+	}
+
+	s.queue = queue
+
+	data, err := json.Marshal(s.queue)
+	if err != nil {
+		return err
+	}
+
+	s.storage.Put(sweepQueueKey, data)
+
+	return nil
+}
+
+// sweepTrie deletes up to limit nodes of the trie, the children before their parent
+// so that an interrupted sweep resumes from the root. It returns true once the trie is swept
+func (s *Sweeper) sweepTrie(entry *sweepEntry, limit uint64) (uint64, bool, error) {
+	if entry.stack == nil {
+		entry.stack = [][]byte{entry.Root.Bytes()}
+		entry.expanded = map[string]struct{}{}
+	}
+
+	var deleted uint64
+
+	for len(entry.stack) > 0 && deleted < limit {
+		ref := entry.stack[len(entry.stack)-1]
+
+		data, ok := s.owned(ref)
+		if !ok {
+			// already swept, or shared with another trie
+			entry.stack = entry.stack[:len(entry.stack)-1]
+
+			continue
+		}
+
+		if _, ok := entry.expanded[string(ref)]; !ok {
+			children, err := nodeRefs(data)
+			if err != nil {
+				return deleted, false, fmt.Errorf("%w: %x", err, ref)
+			}
+
+			entry.expanded[string(ref)] = struct{}{}
+			entry.stack = append(entry.stack, children...)
+
+			continue
+		}
+
+		if s.deleteNode(ref) {
+			deleted++
+		}
+
+		delete(entry.expanded, string(ref))
+		entry.stack = entry.stack[:len(entry.stack)-1]
+	}
+
+	return deleted, len(entry.stack) == 0, nil
+}
+
+// owned returns the node if it is stored and only written once
+func (s *Sweeper) owned(ref []byte) ([]byte, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	mark, ok := s.storage.Get(refKey(ref))
+	if !ok || len(mark) != 1 || mark[0] != refOwned {
+		return nil, false
+	}
+
+	return s.storage.Get(ref)
+}
+
+// deleteNode deletes the node, unless it was written again since it was walked
+func (s *Sweeper) deleteNode(ref []byte) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	mark, ok := s.storage.Get(refKey(ref))
+	if !ok || len(mark) != 1 || mark[0] != refOwned {
+		return false
+	}
+
+	s.deleter.Delete(ref)
+	s.deleter.Delete(refKey(ref))
+
+	return true
+}
+
+// nodeRefs returns the hashes of the nodes referenced by the encoded node
+func nodeRefs(data []byte) ([][]byte, error) {
+	v, err := new(fastrlp.Parser).Parse(data)
+	if err != nil {
+		return nil, ErrTrieNodeCorrupt
+	}
+
+	refs := [][]byte{}
+
+	var walk func(v *fastrlp.Value) error
+
+	walk = func(v *fastrlp.Value) error {
+		if v.Type() == fastrlp.TypeBytes {
+			if v.Len() == types.HashLength {
+				refs = append(refs, append([]byte{}, v.Raw()...))
+			}
+
+			return nil
+		}
+
+		switch v.Elems() {
+		case 2:
+			key := v.Get(0)
+			if key.Type() != fastrlp.TypeBytes || key.Len() == 0 {
+				return ErrTrieNodeCorrupt
+			}
+
+			if hasTerminator(decodeCompact(key.Raw())) {
+				// the value of a leaf
+				return nil
+			}
+
+			return walk(v.Get(1))
+		case 17:
+			// the value of the full node isn't a reference
+			for i := 0; i < 16; i++ {
+				if err := walk(v.Get(i)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		default:
+			return ErrTrieNodeCorrupt
+		}
+	}
+
+	if err := walk(v); err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+func refKey(k []byte) []byte {
+	return append(append([]byte{}, refsPrefix...), k...)
+}
+
+// refBatch marks the storage trie nodes written by the batch
+type refBatch struct {
+	Batch
+	sweeper *Sweeper
+}
+
+func (b *refBatch) Put(k, v []byte) {
+	b.sweeper.mark(k)
+	b.Batch.Put(k, v)
+}
@@ -0,0 +1,150 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweeper_SweepsDeletedStorage(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+
+	sweeper, err := st.EnableSweeping(&SweepConfig{Budget: 4, Delay: 2})
+	require.NoError(t, err)
+
+	addrA := types.StringToAddress("a")
+	addrB := types.StringToAddress("b")
+	addrC := types.StringToAddress("c")
+
+	newObject := func(addr types.Address, first int) *state.Object {
+		obj := &state.Object{
+			Address:  addr,
+			Balance:  big.NewInt(1),
+			CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+			Root:     types.EmptyRootHash,
+		}
+
+		for i := first; i < first+20; i++ {
+			obj.Storage = append(obj.Storage, &state.StorageObject{
+				Key: types.BytesToHash(big.NewInt(int64(i)).Bytes()).Bytes(),
+				Val: types.BytesToHash(big.NewInt(int64(i + 1)).Bytes()).Bytes(),
+			})
+		}
+
+		return obj
+	}
+
+	// the storage of A and B is the same, their nodes are shared
+	_, root := st.NewSnapshot().Commit([]*state.Object{
+		newObject(addrA, 0),
+		newObject(addrB, 0),
+		newObject(addrC, 100),
+	})
+
+	snap, err := st.NewSnapshotAt(types.BytesToHash(root))
+	require.NoError(t, err)
+
+	storageRoot := func(addr types.Address) types.Hash {
+		account, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+
+		return account.Root
+	}
+
+	rootA, rootC := storageRoot(addrA), storageRoot(addrC)
+
+	_, root = snap.Commit([]*state.Object{
+		{Address: addrA, Root: rootA, Deleted: true},
+		{Address: addrC, Root: rootC, Deleted: true},
+	})
+
+	head := types.BytesToHash(root)
+
+	assert.Equal(t, 2, sweeper.Pending())
+
+	// the storage is kept until the accounts stay deleted for the delay
+	deleted, err := sweeper.Sweep(head, 10)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	var total uint64
+
+	for number := uint64(12); sweeper.Pending() > 0; number++ {
+		deleted, err := sweeper.Sweep(head, number)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, deleted, uint64(4))
+
+		total += deleted
+
+		require.Less(t, number, uint64(100))
+	}
+
+	assert.NotZero(t, total)
+
+	// the storage of C is swept, the storage shared with B is kept
+	assert.ErrorIs(t, st.IterateStorage(rootC, func(types.Hash, []byte) error {
+		return nil
+	}), ErrTrieNodeMissing)
+
+	slots := 0
+
+	require.NoError(t, st.IterateStorage(storageRoot(addrB), func(types.Hash, []byte) error {
+		slots++
+
+		return nil
+	}))
+
+	assert.Equal(t, 20, slots)
+}
+
+func TestSweeper_DropsNonCanonicalDeletions(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+
+	sweeper, err := st.EnableSweeping(&SweepConfig{Budget: 100, Delay: 2})
+	require.NoError(t, err)
+
+	addr := types.StringToAddress("a")
+
+	_, root := st.NewSnapshot().Commit([]*state.Object{{
+		Address:  addr,
+		Balance:  big.NewInt(1),
+		CodeHash: types.BytesToHash(crypto.Keccak256(nil)),
+		Root:     types.EmptyRootHash,
+		Storage: []*state.StorageObject{{
+			Key: types.BytesToHash([]byte{0x1}).Bytes(),
+			Val: types.BytesToHash([]byte{0x2}).Bytes(),
+		}},
+	}})
+
+	head := types.BytesToHash(root)
+
+	snap, err := st.NewSnapshotAt(head)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(addr)
+	require.NoError(t, err)
+
+	// the block deleting the account is built but not imported
+	snap.Commit([]*state.Object{{Address: addr, Root: account.Root, Deleted: true}})
+	assert.Equal(t, 1, sweeper.Pending())
+
+	for number := uint64(1); number <= 3; number++ {
+		deleted, err := sweeper.Sweep(head, number)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+	}
+
+	assert.Zero(t, sweeper.Pending())
+	assert.NoError(t, st.IterateStorage(account.Root, func(types.Hash, []byte) error {
+		return nil
+	}))
+}
@@ -4,10 +4,10 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/umbracle/fastrlp"
-	"golang.org/x/crypto/sha3"
 )
 
 // Node represents a node reference
@@ -108,10 +108,7 @@ func (t *Trie) Get(k []byte) ([]byte, bool) {
 }
 
 func hashit(k []byte) []byte {
-	h := sha3.NewLegacyKeccak256()
-	h.Write(k)
-
-	return h.Sum(nil)
+	return keccak.Keccak256(nil, k)
 }
 
 var accountArenaPool fastrlp.ArenaPool
@@ -134,6 +131,11 @@ func (t *Trie) Commit(objs []*state.Object) (*Trie, []byte) {
 	for _, obj := range objs {
 		if obj.Deleted {
 			tt.Delete(hashit(obj.Address.Bytes()))
+
+			// the storage is left in the database, it is swept in the background
+			if t.state.sweeper != nil {
+				t.state.sweeper.enqueue(obj.Address, obj.Root)
+			}
 		} else {
 			account := state.Account{
 				Balance:  obj.Balance,
@@ -151,6 +153,10 @@ func (t *Trie) Commit(objs []*state.Object) (*Trie, []byte) {
 				localTxn := trie.Txn()
 				localTxn.batch = batch
 
+				if t.state.sweeper != nil {
+					localTxn.batch = t.state.sweeper.batch(batch)
+				}
+
 				for _, entry := range obj.Storage {
 					k := hashit(entry.Key)
 					if entry.Deleted {
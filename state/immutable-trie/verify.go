@@ -0,0 +1,161 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	ErrTrieNodeMissing = errors.New("trie node missing")
+	ErrTrieNodeCorrupt = errors.New("trie node corrupt")
+	ErrAccountCorrupt  = errors.New("account corrupt")
+	ErrCodeMissing     = errors.New("code missing")
+	ErrCodeCorrupt     = errors.New("code corrupt")
+)
+
+var emptyCodeHash = crypto.Keccak256(nil)
+
+// VerifyState walks the account trie of the given root, along with the storage tries
+// and the code of the accounts, and checks every entry is stored and matches its hash.
+// The walk goes on past the corrupted entries, each of them is passed to onError
+// with its key in the storage. It returns the number of verified trie nodes
+func (s *State) VerifyState(root types.Hash, onError func(key []byte, err error)) int {
+	v := &stateVerifier{
+		storage: s.storage,
+		onError: onError,
+		seen:    make(map[types.Hash]struct{}),
+	}
+
+	if root != types.EmptyRootHash {
+		v.verifyNode(root.Bytes(), v.verifyAccount)
+	}
+
+	return v.nodes
+}
+
+// stateVerifier walks the tries node by node from their encoding in the storage
+type stateVerifier struct {
+	storage Storage
+	onError func(key []byte, err error)
+
+	// seen are the verified nodes, the identical storage tries being walked once
+	seen  map[types.Hash]struct{}
+	nodes int
+}
+
+// verifyNode checks the node stored by its hash and walks its children,
+// the values of the leaves are passed to onValue
+func (v *stateVerifier) verifyNode(ref []byte, onValue func(ref, value []byte)) {
+	hash := types.BytesToHash(ref)
+	if _, ok := v.seen[hash]; ok {
+		return
+	}
+
+	v.seen[hash] = struct{}{}
+
+	data, ok := v.storage.Get(ref)
+	if !ok {
+		v.onError(ref, ErrTrieNodeMissing)
+
+		return
+	}
+
+	if !bytes.Equal(crypto.Keccak256(data), ref) {
+		v.onError(ref, ErrTrieNodeCorrupt)
+
+		return
+	}
+
+	// the parser can't be shared, the values of a node are in use while walking its children
+	node, err := new(fastrlp.Parser).Parse(data)
+	if err != nil {
+		v.onError(ref, ErrTrieNodeCorrupt)
+
+		return
+	}
+
+	v.nodes++
+
+	v.walk(ref, node, onValue)
+}
+
+// walk walks the node and the nodes embedded in it
+func (v *stateVerifier) walk(ref []byte, node *fastrlp.Value, onValue func(ref, value []byte)) {
+	if node.Type() == fastrlp.TypeBytes {
+		switch node.Len() {
+		case 0:
+			// empty child
+		case types.HashLength:
+			v.verifyNode(node.Raw(), onValue)
+		default:
+			v.onError(ref, ErrTrieNodeCorrupt)
+		}
+
+		return
+	}
+
+	switch node.Elems() {
+	case 2:
+		key := node.Get(0)
+		if key.Type() != fastrlp.TypeBytes {
+			v.onError(ref, ErrTrieNodeCorrupt)
+
+			return
+		}
+
+		if hasTerminator(decodeCompact(key.Raw())) {
+			onValue(ref, node.Get(1).Raw())
+		} else {
+			v.walk(ref, node.Get(1), onValue)
+		}
+
+	case 17:
+		for i := 0; i < 16; i++ {
+			v.walk(ref, node.Get(i), onValue)
+		}
+
+		if value := node.Get(16).Raw(); len(value) != 0 {
+			onValue(ref, value)
+		}
+
+	default:
+		v.onError(ref, ErrTrieNodeCorrupt)
+	}
+}
+
+// verifyAccount walks the storage trie and checks the code of the account stored in the leaf
+func (v *stateVerifier) verifyAccount(ref, value []byte) {
+	var account state.Account
+	if err := account.UnmarshalRlp(value); err != nil {
+		v.onError(ref, ErrAccountCorrupt)
+
+		return
+	}
+
+	if account.Root != types.EmptyRootHash {
+		v.verifyNode(account.Root.Bytes(), func([]byte, []byte) {})
+	}
+
+	if len(account.CodeHash) == 0 || bytes.Equal(account.CodeHash, emptyCodeHash) {
+		return
+	}
+
+	codeHash := types.BytesToHash(account.CodeHash)
+	if _, ok := v.seen[codeHash]; ok {
+		return
+	}
+
+	v.seen[codeHash] = struct{}{}
+
+	code, ok := v.storage.GetCode(codeHash)
+	if !ok {
+		v.onError(append(codePrefix, account.CodeHash...), ErrCodeMissing)
+	} else if !bytes.Equal(crypto.Keccak256(code), account.CodeHash) {
+		v.onError(append(codePrefix, account.CodeHash...), ErrCodeCorrupt)
+	}
+}
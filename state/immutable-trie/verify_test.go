@@ -0,0 +1,70 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_VerifyState(t *testing.T) {
+	t.Parallel()
+
+	st, _, objs := buildProofState(t, 20)
+
+	code := []byte{0x60, 0x00}
+	contract := &state.Object{
+		Address:   types.StringToAddress("0xc0de"),
+		Balance:   big.NewInt(0),
+		CodeHash:  types.BytesToHash(crypto.Keccak256(code)),
+		Code:      code,
+		DirtyCode: true,
+		Root:      types.EmptyRootHash,
+	}
+
+	_, rawRoot := st.NewSnapshot().Commit(append(objs, contract))
+	root := types.BytesToHash(rawRoot)
+
+	type corruption struct {
+		key []byte
+		err error
+	}
+
+	verify := func() ([]corruption, int) {
+		found := []corruption{}
+
+		nodes := st.VerifyState(root, func(key []byte, err error) {
+			found = append(found, corruption{key, err})
+		})
+
+		return found, nodes
+	}
+
+	found, nodes := verify()
+	assert.Empty(t, found)
+	assert.Greater(t, nodes, len(objs))
+
+	db := st.storage.(*memStorage) //nolint:forcetypeassert
+
+	// drop a node below the root
+	proof, err := st.GetProof(root, crypto.Keccak256(objs[0].Address.Bytes()))
+	require.NoError(t, err)
+	require.Greater(t, len(proof), 1)
+
+	missing := crypto.Keccak256(proof[1])
+	delete(db.db, hex.EncodeToHex(missing))
+
+	// corrupt the code of the contract
+	db.code[contract.CodeHash.String()] = []byte{0x00}
+
+	found, _ = verify()
+	assert.ElementsMatch(t, []corruption{
+		{missing, ErrTrieNodeMissing},
+		{append([]byte("code"), contract.CodeHash.Bytes()...), ErrCodeCorrupt},
+	}, found)
+}
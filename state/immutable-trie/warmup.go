@@ -0,0 +1,194 @@
+package itrie
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// WarmCacheFile is the name of the file the access frequencies are persisted in
+const WarmCacheFile = "warm-cache.json"
+
+// AccessFrequencies are the most accessed accounts and contract codes,
+// sorted by the number of accesses
+type AccessFrequencies struct {
+	Accounts []AccountFrequency `json:"accounts"`
+	Codes    []CodeFrequency    `json:"codes"`
+}
+
+type AccountFrequency struct {
+	Address types.Address `json:"address"`
+	Count   uint64        `json:"count"`
+}
+
+type CodeFrequency struct {
+	Hash  types.Hash `json:"hash"`
+	Count uint64     `json:"count"`
+}
+
+// WarmUpStats are the entries loaded by a warm up
+type WarmUpStats struct {
+	Accounts int
+	Codes    int
+}
+
+// accessCounter counts the accesses to the accounts and the contract codes
+type accessCounter struct {
+	lock     sync.Mutex
+	accounts map[types.Address]uint64
+	codes    map[types.Hash]uint64
+}
+
+func newAccessCounter() *accessCounter {
+	return &accessCounter{
+		accounts: map[types.Address]uint64{},
+		codes:    map[types.Hash]uint64{},
+	}
+}
+
+func (a *accessCounter) account(addr types.Address, n uint64) {
+	a.lock.Lock()
+	a.accounts[addr] += n
+	a.lock.Unlock()
+}
+
+func (a *accessCounter) code(hash types.Hash, n uint64) {
+	a.lock.Lock()
+	a.codes[hash] += n
+	a.lock.Unlock()
+}
+
+// frequencies returns the limit most accessed accounts and codes
+func (a *accessCounter) frequencies(limit int) *AccessFrequencies {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	res := &AccessFrequencies{
+		Accounts: make([]AccountFrequency, 0, len(a.accounts)),
+		Codes:    make([]CodeFrequency, 0, len(a.codes)),
+	}
+
+	for addr, count := range a.accounts {
+		res.Accounts = append(res.Accounts, AccountFrequency{Address: addr, Count: count})
+	}
+
+	for hash, count := range a.codes {
+		res.Codes = append(res.Codes, CodeFrequency{Hash: hash, Count: count})
+	}
+
+	sort.Slice(res.Accounts, func(i, j int) bool {
+		if res.Accounts[i].Count != res.Accounts[j].Count {
+			return res.Accounts[i].Count > res.Accounts[j].Count
+		}
+
+		return res.Accounts[i].Address.String() < res.Accounts[j].Address.String()
+	})
+
+	sort.Slice(res.Codes, func(i, j int) bool {
+		if res.Codes[i].Count != res.Codes[j].Count {
+			return res.Codes[i].Count > res.Codes[j].Count
+		}
+
+		return res.Codes[i].Hash.String() < res.Codes[j].Hash.String()
+	})
+
+	if len(res.Accounts) > limit {
+		res.Accounts = res.Accounts[:limit]
+	}
+
+	if len(res.Codes) > limit {
+		res.Codes = res.Codes[:limit]
+	}
+
+	return res
+}
+
+// TrackAccesses starts counting the accesses to the accounts and the contract codes
+func (s *State) TrackAccesses() {
+	s.accesses = newAccessCounter()
+}
+
+// AccessFrequencies returns the limit most accessed accounts and codes since the accesses
+// are tracked, including the ones loaded by the warm up
+func (s *State) AccessFrequencies(limit int) *AccessFrequencies {
+	if s.accesses == nil {
+		return &AccessFrequencies{}
+	}
+
+	return s.accesses.frequencies(limit)
+}
+
+// SaveAccessFrequencies writes the limit most accessed accounts and codes to the file
+func (s *State) SaveAccessFrequencies(path string, limit int) error {
+	data, err := json.Marshal(s.AccessFrequencies(limit))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+// WarmUp reads the access frequencies from the file and loads the accounts of the state
+// at the root and their codes into the caches. The previous counts are halved so that
+// the old accesses fade out. A missing file is not an error
+func (s *State) WarmUp(path string, root types.Hash) (*WarmUpStats, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &WarmUpStats{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var frequencies AccessFrequencies
+	if err := json.Unmarshal(data, &frequencies); err != nil {
+		return nil, fmt.Errorf("failed to decode the access frequencies: %w", err)
+	}
+
+	trie, err := s.newTrieAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &WarmUpStats{}
+
+	// the lookups resolve the nodes on the path of the accounts, which
+	// stay in the trie cached for the root
+	txn := trie.Txn()
+
+	for _, entry := range frequencies.Accounts {
+		if txn.Lookup(crypto.Keccak256(entry.Address.Bytes())) == nil {
+			continue
+		}
+
+		if s.accesses != nil {
+			s.accesses.account(entry.Address, entry.Count/2)
+		}
+
+		stats.Accounts++
+	}
+
+	for _, entry := range frequencies.Codes {
+		code, ok := s.storage.GetCode(entry.Hash)
+		if !ok {
+			continue
+		}
+
+		s.codeCache.Add(entry.Hash, code)
+
+		if s.accesses != nil {
+			s.accesses.code(entry.Hash, entry.Count/2)
+		}
+
+		stats.Codes++
+	}
+
+	s.AddState(root, trie)
+
+	return stats, nil
+}
@@ -0,0 +1,61 @@
+package itrie
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_WarmUp(t *testing.T) {
+	t.Parallel()
+
+	st, root, objs := buildProofState(t, 10)
+
+	codeHash := types.StringToHash("1")
+	st.SetCode(codeHash, []byte{0x1, 0x2})
+
+	st.TrackAccesses()
+
+	snap, err := st.NewSnapshotAt(root)
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := snap.GetAccount(objs[0].Address)
+		assert.NoError(t, err)
+	}
+
+	_, err = snap.GetAccount(objs[1].Address)
+	assert.NoError(t, err)
+
+	_, ok := st.GetCode(codeHash)
+	assert.True(t, ok)
+
+	frequencies := st.AccessFrequencies(1)
+	assert.Equal(t, []AccountFrequency{{Address: objs[0].Address, Count: 3}}, frequencies.Accounts)
+	assert.Equal(t, []CodeFrequency{{Hash: codeHash, Count: 1}}, frequencies.Codes)
+
+	path := filepath.Join(t.TempDir(), WarmCacheFile)
+	assert.NoError(t, st.SaveAccessFrequencies(path, 10))
+
+	// a restarted state over the same storage
+	restarted := NewState(st.storage)
+	restarted.TrackAccesses()
+
+	stats, err := restarted.WarmUp(path, root)
+	assert.NoError(t, err)
+	assert.Equal(t, &WarmUpStats{Accounts: 2, Codes: 1}, stats)
+
+	assert.True(t, restarted.cache.Contains(root))
+	assert.True(t, restarted.codeCache.Contains(codeHash))
+
+	// the previous counts are halved
+	frequencies = restarted.AccessFrequencies(10)
+	assert.Equal(t, uint64(1), frequencies.Accounts[0].Count)
+
+	// a missing file is not an error
+	stats, err = NewState(st.storage).WarmUp(filepath.Join(t.TempDir(), WarmCacheFile), root)
+	assert.NoError(t, err)
+	assert.Equal(t, &WarmUpStats{}, stats)
+}
@@ -0,0 +1,24 @@
+package itrie
+
+import (
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// NewWitnessStorage creates an in-memory trie storage holding only the nodes and the codes
+// of the witness. The state outside of the witness reads as empty, so only the block
+// the witness was generated for can be re-executed over it
+func NewWitnessStorage(witness *state.Witness) Storage {
+	storage := NewMemoryStorage()
+
+	for _, node := range witness.Nodes {
+		storage.Put(crypto.Keccak256(node), node)
+	}
+
+	for _, code := range witness.Codes {
+		storage.SetCode(types.BytesToHash(crypto.Keccak256(code)), code)
+	}
+
+	return storage
+}
@@ -0,0 +1,92 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeBlock applies the transactions over the state at the root, recording the witness
+func executeBlock(
+	t *testing.T,
+	st *State,
+	root types.Hash,
+	txs []*types.Transaction,
+) (types.Hash, *state.Witness) {
+	t.Helper()
+
+	ex := state.NewExecutor(&chain.Params{Forks: chain.AllForksEnabled}, st, hclog.NewNullLogger())
+	ex.GetHash = func(h *types.Header) state.GetHashByNumber {
+		return func(i uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	transition, err := ex.BeginTxn(root, &types.Header{Number: 1, GasLimit: 1000000}, types.StringToAddress("c"))
+	require.NoError(t, err)
+
+	transition.RecordWitness()
+
+	for _, tx := range txs {
+		_, err := transition.Apply(tx)
+		require.NoError(t, err)
+	}
+
+	witness, err := transition.Witness(root)
+	require.NoError(t, err)
+
+	_, newRoot := transition.Commit()
+
+	return newRoot, witness
+}
+
+func TestState_Witness(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("1")
+		receiver = types.StringToAddress("2")
+		contract = types.StringToAddress("3")
+	)
+
+	alloc := map[types.Address]*chain.GenesisAccount{
+		sender: {Balance: big.NewInt(1000000)},
+		// increments the slot 0
+		contract: {
+			Code: []byte{0x60, 0x01, 0x60, 0x00, 0x54, 0x01, 0x60, 0x00, 0x55, 0x00},
+			Storage: map[types.Hash]types.Hash{
+				types.ZeroHash: types.BytesToHash([]byte{5}),
+			},
+		},
+	}
+
+	// the accounts the block does not touch
+	for i := 0; i < 50; i++ {
+		alloc[types.BytesToAddress(big.NewInt(int64(100+i)).Bytes())] = &chain.GenesisAccount{
+			Balance: big.NewInt(1),
+		}
+	}
+
+	st := NewState(NewMemoryStorage())
+	root := state.NewExecutor(&chain.Params{}, st, hclog.NewNullLogger()).WriteGenesis(alloc)
+
+	txs := []*types.Transaction{
+		{From: sender, To: &receiver, Value: big.NewInt(10), Gas: 21000, GasPrice: big.NewInt(1), Nonce: 0},
+		{From: sender, To: &contract, Value: big.NewInt(0), Gas: 100000, GasPrice: big.NewInt(1), Nonce: 1},
+	}
+
+	newRoot, witness := executeBlock(t, st, root, txs)
+	assert.NotEqual(t, root, newRoot)
+	assert.NotEmpty(t, witness.Nodes)
+	assert.Len(t, witness.Codes, 1)
+
+	// the witness is enough to re-execute the block without the state
+	statelessRoot, _ := executeBlock(t, NewState(NewWitnessStorage(witness)), root, txs)
+	assert.Equal(t, newRoot, statelessRoot)
+}
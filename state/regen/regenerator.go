@@ -0,0 +1,201 @@
+package regen
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// regeneratedCacheSize is the number of the regenerated state roots kept in memory
+const regeneratedCacheSize = 1024
+
+var (
+	ErrReplayBudgetExceeded = errors.New("the state is older than the replay budget")
+	ErrAncestorNotFound     = errors.New("ancestor block not found")
+	ErrStateRootMismatch    = errors.New("regenerated state root mismatch")
+)
+
+// Blockchain is the interface of the blockchain whose blocks are replayed
+type Blockchain interface {
+	GetHeaderByHash(hash types.Hash) (*types.Header, bool)
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+}
+
+// Executor is the interface of the executor replaying the blocks
+type Executor interface {
+	StateAt(root types.Hash) (state.Snapshot, error)
+	ProcessBlock(parentRoot types.Hash, block *types.Block, blockCreator types.Address) (*state.Transition, error)
+}
+
+// Verifier is the interface of the consensus finalizing the replayed blocks as their import does
+type Verifier interface {
+	GetBlockCreator(header *types.Header) (types.Address, error)
+	PreCommitState(header *types.Header, txn *state.Transition) error
+}
+
+// Regenerator regenerates on demand the states missing at the old blocks, such as those of
+// a node without the history of the state, by replaying the blocks from the nearest ancestor
+// whose state is retained. The regenerated states are committed to the state storage
+type Regenerator struct {
+	logger     hclog.Logger
+	blockchain Blockchain
+	executor   Executor
+	verifier   Verifier
+
+	// budget is the maximum number of blocks replayed to regenerate a state
+	budget uint64
+
+	// regenerated caches the roots of the regenerated states, which are not looked up again
+	regenerated *lru.Cache
+
+	// lock serializes the regenerations, which share their replayed blocks
+	lock sync.Mutex
+}
+
+// NewRegenerator creates a regenerator replaying at most budget blocks
+func NewRegenerator(
+	logger hclog.Logger,
+	blockchain Blockchain,
+	executor Executor,
+	verifier Verifier,
+	budget uint64,
+) *Regenerator {
+	// the cache cannot fail with a positive size
+	regenerated, _ := lru.New(regeneratedCacheSize)
+
+	return &Regenerator{
+		logger:      logger.Named("regen"),
+		blockchain:  blockchain,
+		executor:    executor,
+		verifier:    verifier,
+		budget:      budget,
+		regenerated: regenerated,
+	}
+}
+
+// Regenerate makes the state at the header available,
+// replaying the blocks after the nearest ancestor whose state is retained
+func (r *Regenerator) Regenerate(header *types.Header) error {
+	if ok, err := r.hasState(header.StateRoot); ok || err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// the state may have been regenerated while waiting for the lock
+	missing, err := r.missingHeaders(header)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	parent, ok := r.blockchain.GetHeaderByHash(missing[len(missing)-1].ParentHash)
+	if !ok {
+		return ErrAncestorNotFound
+	}
+
+	parentRoot := parent.StateRoot
+
+	for i := len(missing) - 1; i >= 0; i-- {
+		root, err := r.replay(parentRoot, missing[i])
+		if err != nil {
+			return fmt.Errorf("failed to replay block %d: %w", missing[i].Number, err)
+		}
+
+		r.regenerated.Add(root, struct{}{})
+
+		parentRoot = root
+	}
+
+	r.logger.Info(
+		"regenerated state",
+		"block", header.Number,
+		"root", header.StateRoot,
+		"replayed", len(missing),
+	)
+
+	return nil
+}
+
+// missingHeaders returns the headers from the given one back to the nearest ancestor
+// whose state is retained, excluded, at most the replay budget
+func (r *Regenerator) missingHeaders(header *types.Header) ([]*types.Header, error) {
+	missing := []*types.Header{}
+
+	for cur := header; ; {
+		ok, err := r.hasState(cur.StateRoot)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			return missing, nil
+		}
+
+		// the genesis state is always written
+		if cur.Number == 0 {
+			return nil, fmt.Errorf("%w: genesis", state.ErrStateNotFound)
+		}
+
+		if uint64(len(missing)) >= r.budget {
+			return nil, fmt.Errorf("%w: %d blocks", ErrReplayBudgetExceeded, r.budget)
+		}
+
+		missing = append(missing, cur)
+
+		parent, ok := r.blockchain.GetHeaderByHash(cur.ParentHash)
+		if !ok {
+			return nil, ErrAncestorNotFound
+		}
+
+		cur = parent
+	}
+}
+
+// replay re-executes the block on its parent state as its import does,
+// and returns the root of the committed state
+func (r *Regenerator) replay(parentRoot types.Hash, header *types.Header) (types.Hash, error) {
+	block, ok := r.blockchain.GetBlockByHash(header.Hash, true)
+	if !ok {
+		return types.ZeroHash, ErrAncestorNotFound
+	}
+
+	blockCreator, err := r.verifier.GetBlockCreator(header)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	txn, err := r.executor.ProcessBlock(parentRoot, block, blockCreator)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if err := r.verifier.PreCommitState(header, txn); err != nil {
+		return types.ZeroHash, err
+	}
+
+	_, root := txn.Commit()
+	if root != header.StateRoot {
+		return types.ZeroHash, fmt.Errorf("%w: expected %s, got %s", ErrStateRootMismatch, header.StateRoot, root)
+	}
+
+	return root, nil
+}
+
+// hasState checks whether the state at the root is stored
+func (r *Regenerator) hasState(root types.Hash) (bool, error) {
+	if r.regenerated.Contains(root) {
+		return true, nil
+	}
+
+	if _, err := r.executor.StateAt(root); errors.Is(err, state.ErrStateNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,173 @@
+package regen
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the receiver is past the precompiles, whose calls cost more than the transfer gas
+var (
+	sender   = types.StringToAddress("1")
+	receiver = types.StringToAddress("1000")
+	creator  = types.StringToAddress("3")
+)
+
+type mockBlockchain struct {
+	blocks map[types.Hash]*types.Block
+}
+
+func (m *mockBlockchain) GetHeaderByHash(hash types.Hash) (*types.Header, bool) {
+	block, ok := m.blocks[hash]
+	if !ok {
+		return nil, false
+	}
+
+	return block.Header, true
+}
+
+func (m *mockBlockchain) GetBlockByHash(hash types.Hash, _ bool) (*types.Block, bool) {
+	block, ok := m.blocks[hash]
+
+	return block, ok
+}
+
+type mockVerifier struct{}
+
+func (m *mockVerifier) GetBlockCreator(*types.Header) (types.Address, error) {
+	return creator, nil
+}
+
+func (m *mockVerifier) PreCommitState(*types.Header, *state.Transition) error {
+	return nil
+}
+
+func newExecutor(st state.State) *state.Executor {
+	ex := state.NewExecutor(&chain.Params{Forks: chain.AllForksEnabled}, st, hclog.NewNullLogger())
+	ex.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	return ex
+}
+
+// buildChain executes the blocks of a transfer each on the archive state,
+// and returns the blockchain and the executor of a node retaining only the genesis state
+func buildChain(t *testing.T, n int) (*mockBlockchain, *state.Executor, []*types.Header) {
+	t.Helper()
+
+	alloc := map[types.Address]*chain.GenesisAccount{
+		sender: {Balance: big.NewInt(1000000)},
+	}
+
+	archive := newExecutor(itrie.NewState(itrie.NewMemoryStorage()))
+	pruned := newExecutor(itrie.NewState(itrie.NewMemoryStorage()))
+
+	genesis := &types.Header{
+		Number:    0,
+		Hash:      types.StringToHash("ff"),
+		StateRoot: archive.WriteGenesis(alloc),
+	}
+
+	require.Equal(t, genesis.StateRoot, pruned.WriteGenesis(alloc))
+
+	bc := &mockBlockchain{
+		blocks: map[types.Hash]*types.Block{genesis.Hash: {Header: genesis}},
+	}
+
+	headers := []*types.Header{genesis}
+
+	for i := 1; i <= n; i++ {
+		parent := headers[i-1]
+		block := &types.Block{
+			Header: &types.Header{
+				Number:     uint64(i),
+				Hash:       types.StringToHash(strconv.Itoa(i)),
+				ParentHash: parent.Hash,
+				GasLimit:   1000000,
+			},
+			Transactions: []*types.Transaction{
+				{
+					From:     sender,
+					To:       &receiver,
+					Value:    big.NewInt(10),
+					Gas:      21000,
+					GasPrice: big.NewInt(1),
+					Nonce:    uint64(i - 1),
+				},
+			},
+		}
+
+		txn, err := archive.ProcessBlock(parent.StateRoot, block, creator)
+		require.NoError(t, err)
+
+		_, block.Header.StateRoot = txn.Commit()
+
+		bc.blocks[block.Header.Hash] = block
+		headers = append(headers, block.Header)
+	}
+
+	return bc, pruned, headers
+}
+
+func TestRegenerator_Regenerate(t *testing.T) {
+	t.Parallel()
+
+	bc, ex, headers := buildChain(t, 3)
+	head := headers[3]
+
+	_, err := ex.StateAt(head.StateRoot)
+	require.ErrorIs(t, err, state.ErrStateNotFound)
+
+	r := NewRegenerator(hclog.NewNullLogger(), bc, ex, &mockVerifier{}, 3)
+	require.NoError(t, r.Regenerate(head))
+
+	snap, err := ex.StateAt(head.StateRoot)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(receiver)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(30), account.Balance)
+
+	// the intermediate states are regenerated as well
+	_, err = ex.StateAt(headers[2].StateRoot)
+	assert.NoError(t, err)
+
+	// the retained states are not replayed again
+	assert.NoError(t, r.Regenerate(head))
+}
+
+func TestRegenerator_ReplayBudget(t *testing.T) {
+	t.Parallel()
+
+	bc, ex, headers := buildChain(t, 3)
+
+	r := NewRegenerator(hclog.NewNullLogger(), bc, ex, &mockVerifier{}, 2)
+	assert.ErrorIs(t, r.Regenerate(headers[3]), ErrReplayBudgetExceeded)
+
+	// the states within the budget can be regenerated
+	assert.NoError(t, r.Regenerate(headers[2]))
+
+	// and shorten the replays of the later ones
+	assert.NoError(t, r.Regenerate(headers[3]))
+}
+
+func TestRegenerator_StateRootMismatch(t *testing.T) {
+	t.Parallel()
+
+	bc, ex, headers := buildChain(t, 1)
+	headers[1].StateRoot = types.StringToHash("ee")
+
+	r := NewRegenerator(hclog.NewNullLogger(), bc, ex, &mockVerifier{}, 1)
+	assert.ErrorIs(t, r.Regenerate(headers[1]), ErrStateRootMismatch)
+}
@@ -0,0 +1,193 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Replay is the result of the re-execution of a block against its parent state
+type Replay struct {
+	// Traces are the tracer results of the transactions of the block, nil for the ones not traced
+	Traces []interface{}
+
+	Receipts  []*types.Receipt
+	GasUsed   uint64
+	StateRoot types.Hash
+	StateDiff *types.StateDiff
+}
+
+// The fields of the state mismatches
+const (
+	MismatchAccount  = "account"
+	MismatchCreated  = "created"
+	MismatchDeleted  = "deleted"
+	MismatchNonce    = "nonce"
+	MismatchBalance  = "balance"
+	MismatchCodeHash = "codeHash"
+	MismatchStorage  = "storage"
+)
+
+// StateMismatch is a change of an account that differs between the stored and the replayed state diffs
+type StateMismatch struct {
+	Address types.Address
+	Field   string
+
+	// Key is the slot of the storage mismatches
+	Key *types.Hash
+
+	Stored   string
+	Replayed string
+}
+
+// CompareStateDiffs returns the changes that differ between the stored state diff of a block
+// and the one of its replay, ordered by address. Both are taken against the same parent state,
+// so only the resulting values are compared
+func CompareStateDiffs(stored, replayed *types.StateDiff) []*StateMismatch {
+	var (
+		storedAccounts   = indexAccountDiffs(stored)
+		replayedAccounts = indexAccountDiffs(replayed)
+		addresses        = make([]types.Address, 0, len(storedAccounts)+len(replayedAccounts))
+	)
+
+	for addr := range storedAccounts {
+		addresses = append(addresses, addr)
+	}
+
+	for addr := range replayedAccounts {
+		if _, ok := storedAccounts[addr]; !ok {
+			addresses = append(addresses, addr)
+		}
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+
+	mismatches := []*StateMismatch{}
+
+	for _, addr := range addresses {
+		s, r := storedAccounts[addr], replayedAccounts[addr]
+
+		switch {
+		case s == nil:
+			mismatches = append(mismatches, &StateMismatch{
+				Address:  addr,
+				Field:    MismatchAccount,
+				Stored:   "unchanged",
+				Replayed: "changed",
+			})
+		case r == nil:
+			mismatches = append(mismatches, &StateMismatch{
+				Address:  addr,
+				Field:    MismatchAccount,
+				Stored:   "changed",
+				Replayed: "unchanged",
+			})
+		default:
+			mismatches = append(mismatches, compareAccountDiffs(s, r)...)
+		}
+	}
+
+	return mismatches
+}
+
+func compareAccountDiffs(stored, replayed *types.AccountDiff) []*StateMismatch {
+	var mismatches []*StateMismatch
+
+	add := func(field string, key *types.Hash, storedValue, replayedValue interface{}) {
+		mismatches = append(mismatches, &StateMismatch{
+			Address:  stored.Address,
+			Field:    field,
+			Key:      key,
+			Stored:   fmt.Sprint(storedValue),
+			Replayed: fmt.Sprint(replayedValue),
+		})
+	}
+
+	if stored.Created != replayed.Created {
+		add(MismatchCreated, nil, stored.Created, replayed.Created)
+	}
+
+	if stored.Deleted != replayed.Deleted {
+		add(MismatchDeleted, nil, stored.Deleted, replayed.Deleted)
+	}
+
+	if stored.Nonce != replayed.Nonce {
+		add(MismatchNonce, nil, stored.Nonce, replayed.Nonce)
+	}
+
+	if stored.Balance.Cmp(replayed.Balance) != 0 {
+		add(MismatchBalance, nil, stored.Balance, replayed.Balance)
+	}
+
+	if stored.CodeHash != replayed.CodeHash {
+		add(MismatchCodeHash, nil, stored.CodeHash, replayed.CodeHash)
+	}
+
+	var (
+		storedSlots   = indexStorageDiffs(stored)
+		replayedSlots = indexStorageDiffs(replayed)
+		keys          = make([]types.Hash, 0, len(storedSlots)+len(replayedSlots))
+	)
+
+	for key := range storedSlots {
+		keys = append(keys, key)
+	}
+
+	for key := range replayedSlots {
+		if _, ok := storedSlots[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) < 0
+	})
+
+	for _, key := range keys {
+		s, r := storedSlots[key], replayedSlots[key]
+
+		// the slot missing from a diff is unchanged, it keeps the previous value of the other one
+		if s == nil {
+			s = &types.StorageDiff{Key: key, Value: r.PrevValue}
+		}
+
+		if r == nil {
+			r = &types.StorageDiff{Key: key, Value: s.PrevValue}
+		}
+
+		if s.Value != r.Value {
+			key := key
+			add(MismatchStorage, &key, s.Value, r.Value)
+		}
+	}
+
+	return mismatches
+}
+
+func indexAccountDiffs(diff *types.StateDiff) map[types.Address]*types.AccountDiff {
+	accounts := map[types.Address]*types.AccountDiff{}
+
+	if diff == nil {
+		return accounts
+	}
+
+	for _, account := range diff.Accounts {
+		accounts[account.Address] = account
+	}
+
+	return accounts
+}
+
+func indexStorageDiffs(account *types.AccountDiff) map[types.Hash]*types.StorageDiff {
+	slots := make(map[types.Hash]*types.StorageDiff, len(account.Storage))
+
+	for _, slot := range account.Storage {
+		slots[slot.Key] = slot
+	}
+
+	return slots
+}
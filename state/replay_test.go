@@ -0,0 +1,81 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareStateDiffs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		addr3 = types.StringToAddress("3")
+
+		key1 = types.StringToHash("1")
+		key2 = types.StringToHash("2")
+	)
+
+	account := func(addr types.Address, balance int64, storage ...*types.StorageDiff) *types.AccountDiff {
+		return &types.AccountDiff{
+			Address:     addr,
+			Nonce:       1,
+			PrevBalance: big.NewInt(0),
+			Balance:     big.NewInt(balance),
+			Storage:     storage,
+		}
+	}
+
+	stored := &types.StateDiff{
+		Accounts: []*types.AccountDiff{
+			account(addr1, 10, &types.StorageDiff{Key: key1, Value: types.StringToHash("a")}),
+			account(addr2, 10),
+		},
+	}
+
+	t.Run("matching diffs", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, CompareStateDiffs(stored, stored))
+	})
+
+	t.Run("mismatching diffs", func(t *testing.T) {
+		t.Parallel()
+
+		replayed := &types.StateDiff{
+			Accounts: []*types.AccountDiff{
+				account(
+					addr1,
+					20,
+					&types.StorageDiff{Key: key1, Value: types.StringToHash("b")},
+					&types.StorageDiff{Key: key2, PrevValue: types.StringToHash("c"), Value: types.StringToHash("d")},
+				),
+				account(addr3, 10),
+			},
+		}
+
+		assert.Equal(t, []*StateMismatch{
+			{Address: addr1, Field: MismatchBalance, Stored: "10", Replayed: "20"},
+			{
+				Address:  addr1,
+				Field:    MismatchStorage,
+				Key:      &key1,
+				Stored:   types.StringToHash("a").String(),
+				Replayed: types.StringToHash("b").String(),
+			},
+			{
+				Address:  addr1,
+				Field:    MismatchStorage,
+				Key:      &key2,
+				Stored:   types.StringToHash("c").String(),
+				Replayed: types.StringToHash("d").String(),
+			},
+			{Address: addr2, Field: MismatchAccount, Stored: "changed", Replayed: "unchanged"},
+			{Address: addr3, Field: MismatchAccount, Stored: "unchanged", Replayed: "changed"},
+		}, CompareStateDiffs(stored, replayed))
+	})
+}
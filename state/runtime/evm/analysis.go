@@ -0,0 +1,105 @@
+package evm
+
+import (
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	// DefaultAnalysisCacheSize is the number of code analyses kept in memory
+	DefaultAnalysisCacheSize = 4096
+
+	// minAnalysisCodeSize is the size below which analyzing the code
+	// is cheaper than looking it up
+	minAnalysisCodeSize = 256
+)
+
+// analysisPrefix is the prefix of the persisted code analyses
+var analysisPrefix = []byte("analysis")
+
+// AnalysisStore persists the code analyses across restarts
+type AnalysisStore interface {
+	Put(k, v []byte)
+	Get(k []byte) ([]byte, bool)
+}
+
+// AnalysisCache caches the jumpdest analysis of the deployed contracts by their code hash,
+// it is safe to share it between executions. The cached bitmaps are never modified
+type AnalysisCache struct {
+	cache *lru.Cache
+	store AnalysisStore
+}
+
+// NewAnalysisCache creates a cache of the given size, the analyses are
+// persisted in the store if it is not nil
+func NewAnalysisCache(size int, store AnalysisStore) (*AnalysisCache, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnalysisCache{
+		cache: cache,
+		store: store,
+	}, nil
+}
+
+// SetStore sets the store the analyses are persisted in
+func (a *AnalysisCache) SetStore(store AnalysisStore) {
+	a.store = store
+}
+
+// Len returns the number of analyses in memory
+func (a *AnalysisCache) Len() int {
+	return a.cache.Len()
+}
+
+// get returns the jumpdest analysis of the contract code, or nil if the
+// code cannot be cached (i.e. the init code of a contract creation)
+func (a *AnalysisCache) get(c *runtime.Contract, host runtime.Host) *bitmap {
+	if a == nil || len(c.Code) < minAnalysisCodeSize {
+		return nil
+	}
+
+	if c.Type == runtime.Create || c.Type == runtime.Create2 {
+		return nil
+	}
+
+	codeHash := host.GetCodeHash(c.CodeAddress)
+	if codeHash == types.ZeroHash {
+		return nil
+	}
+
+	if cached, ok := a.cache.Get(codeHash); ok {
+		if analysis, _ := cached.(*bitmap); analysis.fits(c.Code) {
+			return analysis
+		}
+
+		return nil
+	}
+
+	key := append(append([]byte{}, analysisPrefix...), codeHash.Bytes()...)
+
+	if a.store != nil {
+		if buf, ok := a.store.Get(key); ok {
+			analysis := &bitmap{buf: buf}
+			if analysis.fits(c.Code) {
+				a.cache.Add(codeHash, analysis)
+
+				return analysis
+			}
+		}
+	}
+
+	analysis := &bitmap{}
+	analysis.setCode(c.Code)
+
+	a.cache.Add(codeHash, analysis)
+
+	if a.store != nil {
+		a.store.Put(key, analysis.buf)
+	}
+
+	return analysis
+}
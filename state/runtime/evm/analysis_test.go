@@ -0,0 +1,113 @@
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type codeHashHost struct {
+	mockHost
+
+	codeHash types.Hash
+}
+
+func (m *codeHashHost) GetCodeHash(addr types.Address) types.Hash {
+	return m.codeHash
+}
+
+type memoryAnalysisStore map[string][]byte
+
+func (m memoryAnalysisStore) Put(k, v []byte) {
+	m[string(k)] = append([]byte{}, v...)
+}
+
+func (m memoryAnalysisStore) Get(k []byte) ([]byte, bool) {
+	v, ok := m[string(k)]
+
+	return v, ok
+}
+
+// jumpCode jumps over the push data to the jumpdest at the end of the code
+func jumpCode() []byte {
+	code := make([]byte, minAnalysisCodeSize+2)
+	dest := len(code) - 2
+
+	copy(code, []byte{PUSH1 + 1, byte(dest >> 8), byte(dest), JUMP, PUSH1, JUMPDEST})
+	code[dest] = JUMPDEST
+	code[dest+1] = byte(STOP)
+
+	return code
+}
+
+func TestAnalysisCache(t *testing.T) {
+	t.Parallel()
+
+	code := jumpCode()
+	host := &codeHashHost{codeHash: types.StringToHash("1")}
+
+	store := memoryAnalysisStore{}
+
+	cache, err := NewAnalysisCache(DefaultAnalysisCacheSize, store)
+	assert.NoError(t, err)
+
+	contract := newMockContract(big.NewInt(0), 1000, code)
+
+	analysis := cache.get(contract, host)
+	assert.NotNil(t, analysis)
+	assert.False(t, analysis.isSet(5))
+	assert.True(t, analysis.isSet(uint(len(code)-2)))
+
+	// the analysis is shared and persisted
+	assert.Same(t, analysis, cache.get(contract, host))
+	assert.Equal(t, 1, cache.Len())
+	assert.Len(t, store, 1)
+
+	// a new cache loads it from the store
+	restarted, err := NewAnalysisCache(DefaultAnalysisCacheSize, store)
+	assert.NoError(t, err)
+	assert.Equal(t, analysis.buf, restarted.get(contract, host).buf)
+
+	// the init code and the small contracts are not cached
+	create := newMockContract(big.NewInt(0), 1000, code)
+	create.Type = runtime.Create
+	assert.Nil(t, cache.get(create, host))
+
+	assert.Nil(t, cache.get(newMockContract(big.NewInt(0), 1000, code[:10]), host))
+
+	// an analysis of another size is not used
+	other := newMockContract(big.NewInt(0), 1000, append(code, make([]byte, 64)...))
+	assert.Nil(t, cache.get(other, host))
+
+	var nilCache *AnalysisCache
+	assert.Nil(t, nilCache.get(contract, host))
+}
+
+func TestRunWithAnalysisCache(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewAnalysisCache(DefaultAnalysisCacheSize, nil)
+	assert.NoError(t, err)
+
+	evm := NewEVMWithAnalysisCache(cache)
+	host := &codeHashHost{codeHash: types.StringToHash("1")}
+
+	for i := 0; i < 2; i++ {
+		res := evm.Run(newMockContract(big.NewInt(0), 1000, jumpCode()), host, &chain.ForksInTime{})
+		assert.NoError(t, res.Err)
+		assert.Equal(t, 1, cache.Len())
+	}
+
+	// the jump into the push data is still rejected
+	code := jumpCode()
+	code[2] = 5
+
+	host = &codeHashHost{codeHash: types.StringToHash("2")}
+
+	res := evm.Run(newMockContract(big.NewInt(0), 1000, code), host, &chain.ForksInTime{})
+	assert.ErrorIs(t, res.Err, errInvalidJump)
+}
@@ -42,6 +42,11 @@ func (b *bitmap) setCode(code []byte) {
 	}
 }
 
+// fits returns true if the bitmap has the size of the analysis of the code
+func (b *bitmap) fits(code []byte) bool {
+	return b != nil && len(b.buf) == len(code)/int(bitmapSize)+1
+}
+
 func isPushOp(i byte) bool {
 	// From PUSH1 (0x60) to PUSH32(0x7F)
 	return i>>5 == 3
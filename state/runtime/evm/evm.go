@@ -11,6 +11,11 @@ var _ runtime.Runtime = &EVM{}
 
 // EVM is the ethereum virtual machine
 type EVM struct {
+	// analysis caches the jumpdest analysis of the deployed contracts, it may be nil
+	analysis *AnalysisCache
+
+	// gasTable overrides the constant gas costs of the opcodes, it may be nil
+	gasTable *GasTable
 }
 
 // NewEVM creates a new EVM
@@ -18,6 +23,16 @@ func NewEVM() *EVM {
 	return &EVM{}
 }
 
+// NewEVMWithAnalysisCache creates a new EVM that looks up the code analyses in the cache
+func NewEVMWithAnalysisCache(analysis *AnalysisCache) *EVM {
+	return &EVM{analysis: analysis}
+}
+
+// SetGasTable overrides the constant gas costs of the opcodes
+func (e *EVM) SetGasTable(table *GasTable) {
+	e.gasTable = table
+}
+
 // CanRun implements the runtime interface
 func (e *EVM) CanRun(*runtime.Contract, runtime.Host, *chain.ForksInTime) bool {
 	return true
@@ -39,8 +54,13 @@ func (e *EVM) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInT
 	contract.gas = c.Gas
 	contract.host = host
 	contract.config = config
+	contract.gasTable = e.gasTable
+	contract.interrupt = host.GetTxContext().Interrupt
 
-	contract.bitmap.setCode(c.Code)
+	if contract.jumpdests = e.analysis.get(c, host); contract.jumpdests == nil {
+		contract.bitmap.setCode(c.Code)
+		contract.jumpdests = &contract.bitmap
+	}
 
 	ret, err := contract.Run()
 
@@ -1,8 +1,11 @@
 package evm
 
 import (
+	"math"
 	"math/big"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
@@ -26,7 +29,8 @@ func newMockContract(value *big.Int, gas uint64, code []byte) *runtime.Contract
 // mockHost is a struct which meets the requirements of runtime.Host interface but throws panic in each methods
 // we don't test all opcodes in this test
 type mockHost struct {
-	tracer runtime.VMTracer
+	tracer    runtime.VMTracer
+	interrupt *int32
 }
 
 func (m *mockHost) AccountExists(addr types.Address) bool {
@@ -67,7 +71,7 @@ func (m *mockHost) Selfdestruct(addr types.Address, beneficiary types.Address) {
 }
 
 func (m *mockHost) GetTxContext() runtime.TxContext {
-	panic("Not implemented in tests")
+	return runtime.TxContext{Interrupt: m.interrupt}
 }
 
 func (m *mockHost) GetBlockHash(number int64) types.Hash {
@@ -240,6 +244,25 @@ func (m *mockTracer) ExecuteState(
 	})
 }
 
+func TestRun_Interrupt(t *testing.T) {
+	t.Parallel()
+
+	interrupt := new(int32)
+	host := &mockHost{interrupt: interrupt}
+
+	// JUMPDEST PUSH1 0 JUMP, an endless loop until the gas runs out
+	code := []byte{JUMPDEST, PUSH1, 0x00, JUMP}
+
+	time.AfterFunc(10*time.Millisecond, func() {
+		atomic.StoreInt32(interrupt, 1)
+	})
+
+	res := NewEVM().Run(newMockContract(big.NewInt(0), math.MaxUint64, code), host, &chain.ForksInTime{})
+
+	assert.ErrorIs(t, res.Err, runtime.ErrExecutionInterrupted)
+	assert.Zero(t, res.GasLeft)
+}
+
 func TestRunWithTracer(t *testing.T) {
 	t.Parallel()
 
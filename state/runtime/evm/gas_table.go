@@ -0,0 +1,43 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errUnknownOpCode    = errors.New("unknown opcode")
+	errDynamicGasOpCode = errors.New("opcode without constant gas cost")
+)
+
+// GasTable holds the constant gas costs of the opcodes, charged before they run
+type GasTable [256]uint64
+
+// NewGasTable returns the gas table of the default costs with the given overrides, by opcode mnemonic.
+// Only the opcodes with a constant cost can be overridden, their dynamic costs are left unchanged
+func NewGasTable(overrides map[string]uint64) (*GasTable, error) {
+	table := &GasTable{}
+	for op, h := range dispatchTable {
+		table[op] = h.gas
+	}
+
+	opCodes := make(map[string]OpCode, len(opCodeToString))
+	for op, name := range opCodeToString {
+		opCodes[name] = op
+	}
+
+	for name, gas := range overrides {
+		op, ok := opCodes[name]
+		if !ok || dispatchTable[op].inst == nil {
+			return nil, fmt.Errorf("%w: %s", errUnknownOpCode, name)
+		}
+
+		if dispatchTable[op].gas == 0 {
+			return nil, fmt.Errorf("%w: %s", errDynamicGasOpCode, name)
+		}
+
+		table[op] = gas
+	}
+
+	return table, nil
+}
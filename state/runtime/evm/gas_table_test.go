@@ -0,0 +1,70 @@
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGasTable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		overrides map[string]uint64
+		err       error
+	}{
+		{
+			name:      "should override the constant costs",
+			overrides: map[string]uint64{"ADD": 10, "PUSH1": 1},
+		},
+		{
+			name:      "should fail on an unknown opcode",
+			overrides: map[string]uint64{"FOO": 10},
+			err:       errUnknownOpCode,
+		},
+		{
+			name:      "should fail on an opcode with a dynamic cost only",
+			overrides: map[string]uint64{"SLOAD": 100},
+			err:       errDynamicGasOpCode,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			table, err := NewGasTable(tt.overrides)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(10), table[ADD])
+			assert.Equal(t, uint64(1), table[PUSH1])
+			assert.Equal(t, uint64(5), table[MUL])
+		})
+	}
+}
+
+func TestRun_GasTable(t *testing.T) {
+	t.Parallel()
+
+	table, err := NewGasTable(map[string]uint64{"PUSH1": 10})
+	assert.NoError(t, err)
+
+	evm := NewEVM()
+	evm.SetGasTable(table)
+
+	// PUSH1 0x01 PUSH1 0x02 ADD
+	code := []byte{byte(PUSH1), 0x01, byte(PUSH1), 0x02, byte(ADD)}
+	result := evm.Run(newMockContract(big.NewInt(0), 100, code), &mockHost{}, &chain.ForksInTime{})
+
+	assert.NoError(t, result.Err)
+	assert.Equal(t, uint64(23), result.GasUsed)
+}
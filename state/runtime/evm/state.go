@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"sync"
+	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
@@ -72,12 +73,21 @@ type state struct {
 	err  error
 	stop bool
 
+	// interrupt aborts the execution once set, it may be nil
+	interrupt *int32
+
 	gas                uint64
 	currentConsumedGas uint64
 
 	// bitvec bitvec
 	bitmap bitmap
 
+	// jumpdests is the analysis of the code, either the bitmap or a cached one
+	jumpdests *bitmap
+
+	// gasTable overrides the constant gas costs of the opcodes, it may be nil
+	gasTable *GasTable
+
 	returnData []byte
 	ret        []byte
 }
@@ -90,9 +100,12 @@ func (c *state) reset() {
 	c.lastGasCost = 0
 	c.stop = false
 	c.err = nil
+	c.interrupt = nil
 
 	// reset bitmap
 	c.bitmap.reset()
+	c.jumpdests = nil
+	c.gasTable = nil
 
 	// reset memory
 	for i := range c.memory {
@@ -113,7 +126,7 @@ func (c *state) validJumpdest(dest *big.Int) bool {
 		return false
 	}
 
-	return c.bitmap.isSet(uint(udest))
+	return c.jumpdests.isSet(uint(udest))
 }
 
 func (c *state) Halt() {
@@ -223,6 +236,12 @@ func (c *state) Run() ([]byte, error) {
 	)
 
 	for !c.stop {
+		if c.interrupt != nil && atomic.LoadInt32(c.interrupt) != 0 {
+			c.exit(runtime.ErrExecutionInterrupted)
+
+			break
+		}
+
 		op, ok = c.CurrentOpCode()
 		gasCopy := c.gas
 
@@ -250,8 +269,13 @@ func (c *state) Run() ([]byte, error) {
 			break
 		}
 
+		gas := inst.gas
+		if c.gasTable != nil {
+			gas = c.gasTable[op]
+		}
+
 		// consume the gas of the instruction
-		if !c.consumeGas(inst.gas) {
+		if !c.consumeGas(gas) {
 			c.exit(errOutOfGas)
 			c.captureExecutionError(op.String(), c.ip, gasCopy)
 
@@ -1,11 +1,15 @@
 package precompiled
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 type precompiledTest struct {
@@ -76,3 +80,19 @@ func TestIdentity(t *testing.T) {
 
 	testPrecompiled(t, &identity{}, tests)
 }
+
+func TestPrecompiled_GasCosts(t *testing.T) {
+	p := NewPrecompiled()
+	identityAddr := types.StringToAddress("4")
+
+	assert.ErrorIs(t, p.SetGasCosts(map[types.Address]uint64{types.StringToAddress("1234"): 1}), errUnknownPrecompile)
+	assert.NoError(t, p.SetGasCosts(map[types.Address]uint64{identityAddr: 100}))
+
+	contract := runtime.NewContractCall(
+		1, types.ZeroAddress, types.ZeroAddress, identityAddr, big.NewInt(0), 150, nil, []byte{0x1},
+	)
+	result := p.Run(contract, nil, &chain.ForksInTime{})
+
+	assert.NoError(t, result.Err)
+	assert.Equal(t, uint64(50), result.GasLeft)
+}
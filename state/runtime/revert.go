@@ -0,0 +1,81 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// revertErrorSelector is the selector of Error(string), the revert output of require and revert
+	revertErrorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+	// revertPanicSelector is the selector of Panic(uint256), the revert output of the failed assertions
+	revertPanicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// panicReasons are the descriptions of the Solidity panic codes
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array accessed",
+	0x31: "out-of-bounds array access; popping on an empty array",
+	0x32: "out-of-bounds access of an array or bytesN",
+	0x41: "out of memory",
+	0x51: "uninitialized function",
+}
+
+// UnpackRevertReason decodes the Error(string) and Panic(uint256) revert outputs of the contracts,
+// false if the output is none of them, e.g. a custom error
+func UnpackRevertReason(output []byte) (string, bool) {
+	if len(output) < 4 {
+		return "", false
+	}
+
+	selector, data := output[:4], output[4:]
+
+	switch {
+	case bytes.Equal(selector, revertErrorSelector):
+		return unpackABIString(data)
+
+	case bytes.Equal(selector, revertPanicSelector):
+		if len(data) != 32 {
+			return "", false
+		}
+
+		code := new(big.Int).SetBytes(data)
+		if code.IsUint64() {
+			if reason, ok := panicReasons[code.Uint64()]; ok {
+				return fmt.Sprintf("panic: %s (0x%x)", reason, code), true
+			}
+		}
+
+		return fmt.Sprintf("panic: unknown code 0x%x", code), true
+	}
+
+	return "", false
+}
+
+// unpackABIString decodes the ABI encoding of a single string
+func unpackABIString(data []byte) (string, bool) {
+	if len(data) < 64 {
+		return "", false
+	}
+
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(data)-32) {
+		return "", false
+	}
+
+	start := offset.Uint64()
+
+	length := new(big.Int).SetBytes(data[start : start+32])
+	if !length.IsUint64() || length.Uint64() > uint64(len(data))-start-32 {
+		return "", false
+	}
+
+	return string(data[start+32 : start+32+length.Uint64()]), true
+}
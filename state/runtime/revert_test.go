@@ -0,0 +1,71 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnpackRevertReason(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name   string
+		output string
+		reason string
+		ok     bool
+	}{
+		{
+			"error string",
+			"0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000006" +
+				"64656e6965640000000000000000000000000000000000000000000000000000",
+			"denied",
+			true,
+		},
+		{
+			"error string out of bounds",
+			"0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000040" +
+				"64656e6965640000000000000000000000000000000000000000000000000000",
+			"",
+			false,
+		},
+		{
+			"panic",
+			"0x4e487b71" +
+				"0000000000000000000000000000000000000000000000000000000000000011",
+			"panic: arithmetic underflow or overflow (0x11)",
+			true,
+		},
+		{
+			"unknown panic",
+			"0x4e487b71" +
+				"00000000000000000000000000000000000000000000000000000000000000ff",
+			"panic: unknown code 0xff",
+			true,
+		},
+		{
+			"custom error",
+			"0x12345678",
+			"",
+			false,
+		},
+		{
+			"empty",
+			"0x",
+			"",
+			false,
+		},
+	}
+
+	for _, tt := range testTable {
+		reason, ok := UnpackRevertReason(hex.MustDecodeHex(tt.output))
+
+		assert.Equal(t, tt.ok, ok, tt.name)
+		assert.Equal(t, tt.reason, reason, tt.name)
+	}
+}
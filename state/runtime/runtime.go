@@ -20,6 +20,9 @@ type TxContext struct {
 	ChainID    int64
 	Difficulty types.Hash
 	Tracer     tracer.Tracer
+
+	// Interrupt aborts the execution with ErrExecutionInterrupted once set to a non-zero value, it may be nil
+	Interrupt *int32
 }
 
 // StorageStatus is the status of the storage access
@@ -111,11 +114,12 @@ func (r *ExecutionResult) Succeeded() bool { return r.Err == nil }
 func (r *ExecutionResult) Failed() bool    { return r.Err != nil }
 func (r *ExecutionResult) Reverted() bool  { return errors.Is(r.Err, ErrExecutionReverted) }
 
-func (r *ExecutionResult) UpdateGasUsed(gasLimit uint64, refund uint64) {
+// UpdateGasUsed sets the gas used by the execution, minus the refund
+// capped to the gas used divided by the quotient
+func (r *ExecutionResult) UpdateGasUsed(gasLimit uint64, refund uint64, refundQuotient uint64) {
 	r.GasUsed = gasLimit - r.GasLeft
 
-	// Refund can go up to half the gas used
-	if maxRefund := r.GasUsed / 2; refund > maxRefund {
+	if maxRefund := r.GasUsed / refundQuotient; refund > maxRefund {
 		refund = maxRefund
 	}
 
@@ -130,10 +134,12 @@ var (
 	ErrNotEnoughFunds           = errors.New("not enough funds")
 	ErrInsufficientBalance      = errors.New("insufficient balance for transfer")
 	ErrMaxCodeSizeExceeded      = errors.New("evm: max code size exceeded")
+	ErrMaxInitCodeSizeExceeded  = errors.New("evm: max initcode size exceeded")
 	ErrContractAddressCollision = errors.New("contract address collision")
 	ErrDepth                    = errors.New("max call depth exceeded")
 	ErrExecutionReverted        = errors.New("execution was reverted")
 	ErrCodeStoreOutOfGas        = errors.New("contract creation code storage out of gas")
+	ErrExecutionInterrupted     = errors.New("execution interrupted")
 )
 
 type CallType int
@@ -0,0 +1,202 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	CallTypeCall         = "call"
+	CallTypeCallCode     = "callcode"
+	CallTypeDelegateCall = "delegatecall"
+	CallTypeStaticCall   = "staticcall"
+	CallTypeCreate       = "create"
+)
+
+// Call is a call of the call tree of a transaction
+type Call struct {
+	Type    string
+	From    types.Address
+	To      types.Address
+	Value   *big.Int
+	Gas     uint64
+	GasUsed uint64
+	Input   []byte
+	Output  []byte
+
+	// Err is the reason of the failure of the call, the state changes
+	// of the failed calls and their sub calls are reverted
+	Err error
+
+	Calls []*Call
+}
+
+// IsCreate returns true if the call is a contract creation
+func (c *Call) IsCreate() bool {
+	return c.Type == CallTypeCreate
+}
+
+// CallTracer builds the call tree of the traced transaction
+type CallTracer struct {
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	frames []*Call
+	root   *Call
+}
+
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+func (t *CallTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *CallTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+// Clear clears the call tree of the previous transaction,
+// the cancellation applies to all the transactions traced afterwards
+func (t *CallTracer) Clear() {
+	t.frames = nil
+	t.root = nil
+}
+
+// GetResult returns the root call of the traced transaction
+func (t *CallTracer) GetResult() (interface{}, error) {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	return t.root, nil
+}
+
+func (t *CallTracer) TxStart(gasLimit uint64) {}
+
+func (t *CallTracer) TxEnd(gasLeft uint64) {}
+
+func (t *CallTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	call := &Call{
+		Type:  toCallType(callType),
+		From:  from,
+		To:    to,
+		Value: new(big.Int),
+		Gas:   gas,
+		Input: append([]byte{}, input...),
+	}
+
+	if value != nil {
+		call.Value.Set(value)
+	}
+
+	if len(t.frames) == 0 {
+		t.root = call
+	} else {
+		parent := t.frames[len(t.frames)-1]
+		parent.Calls = append(parent.Calls, call)
+	}
+
+	t.frames = append(t.frames, call)
+}
+
+func (t *CallTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasUsed uint64,
+	err error,
+) {
+	if len(t.frames) == 0 {
+		return
+	}
+
+	call := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	call.GasUsed = gasUsed
+	call.Output = append([]byte{}, output...)
+	call.Err = err
+}
+
+func (t *CallTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+	}
+}
+
+func (t *CallTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opcode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+// ErrorString returns the OpenEthereum representation of the failure of the call
+func ErrorString(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, runtime.ErrExecutionReverted):
+		return "Reverted"
+	case errors.Is(err, runtime.ErrOutOfGas), errors.Is(err, runtime.ErrCodeStoreOutOfGas):
+		return "Out of gas"
+	default:
+		return err.Error()
+	}
+}
+
+// toCallType returns the call type of the runtime call type, the creations
+// are reported with the opcode by the executor
+func toCallType(callType int) string {
+	switch callType {
+	case int(runtime.CallCode):
+		return CallTypeCallCode
+	case int(runtime.DelegateCall):
+		return CallTypeDelegateCall
+	case int(runtime.StaticCall):
+		return CallTypeStaticCall
+	case int(runtime.Create), int(runtime.Create2), int(evm.CREATE), int(evm.CREATE2):
+		return CallTypeCreate
+	default:
+		return CallTypeCall
+	}
+}
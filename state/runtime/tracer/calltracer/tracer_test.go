@@ -0,0 +1,95 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testFrom   = types.StringToAddress("1")
+	testCaller = types.StringToAddress("2")
+	testCallee = types.StringToAddress("3")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+func TestCallTracer_CallTree(t *testing.T) {
+	t.Parallel()
+
+	tr := NewCallTracer()
+
+	tr.TxStart(100000)
+	tr.CallStart(1, testFrom, testCaller, int(runtime.Call), 50000, big.NewInt(1), []byte{0x1})
+	tr.CallStart(2, testCaller, testCallee, int(evm.CREATE), 20000, nil, []byte{0x2})
+	tr.CallEnd(2, []byte{0x3}, 100, nil)
+	tr.CallStart(2, testCaller, testCallee, int(runtime.StaticCall), 1000, nil, nil)
+	tr.CallEnd(2, nil, 1000, runtime.ErrOutOfGas)
+	tr.CallEnd(1, []byte{0x4}, 3000, nil)
+	tr.TxEnd(40000)
+
+	res, err := tr.GetResult()
+	require.NoError(t, err)
+
+	root, ok := res.(*Call)
+	require.True(t, ok)
+
+	assert.Equal(t, CallTypeCall, root.Type)
+	assert.Equal(t, big.NewInt(1), root.Value)
+	assert.Equal(t, uint64(3000), root.GasUsed)
+	assert.Equal(t, []byte{0x4}, root.Output)
+	require.Len(t, root.Calls, 2)
+
+	assert.True(t, root.Calls[0].IsCreate())
+	assert.Equal(t, big.NewInt(0), root.Calls[0].Value)
+	assert.Equal(t, uint64(100), root.Calls[0].GasUsed)
+
+	assert.Equal(t, CallTypeStaticCall, root.Calls[1].Type)
+	assert.Equal(t, "Out of gas", ErrorString(root.Calls[1].Err))
+
+	tr.Clear()
+
+	res, err = tr.GetResult()
+	require.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestCallTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	tr := NewCallTracer()
+	reason := errors.New("timeout")
+
+	tr.Cancel(reason)
+
+	// the cancellation is kept for the next transactions
+	tr.Clear()
+
+	state := &mockState{}
+	tr.CaptureState(nil, nil, 0, testCallee, 0, nil, state)
+	assert.True(t, state.halted)
+
+	_, err := tr.GetResult()
+	assert.ErrorIs(t, err, reason)
+}
+
+func TestErrorString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "", ErrorString(nil))
+	assert.Equal(t, "Reverted", ErrorString(runtime.ErrExecutionReverted))
+	assert.Equal(t, "Out of gas", ErrorString(runtime.ErrCodeStoreOutOfGas))
+	assert.Equal(t, runtime.ErrDepth.Error(), ErrorString(runtime.ErrDepth))
+}
@@ -0,0 +1,646 @@
+package jstracer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/dop251/goja"
+)
+
+const (
+	// maxCallStackSize is the maximum depth of the calls of the tracer functions,
+	// so an unbounded recursion fails instead of exhausting the node memory
+	maxCallStackSize = 512
+)
+
+var (
+	// ErrInvalidTracer is returned if the tracer isn't a JavaScript object with the result and fault functions
+	ErrInvalidTracer = errors.New("invalid tracer")
+	// ErrTracerFailed is returned if a function of the tracer throws
+	ErrTracerFailed = errors.New("tracer failed")
+)
+
+// stateHost is the state read by the db object of the tracer, the runtime host implements it
+type stateHost interface {
+	tracer.RuntimeHost
+	AccountExists(addr types.Address) bool
+	GetBalance(addr types.Address) *big.Int
+	GetNonce(addr types.Address) uint64
+	GetCode(addr types.Address) []byte
+}
+
+// frame is a call being executed
+type frame struct {
+	callType int
+	from     types.Address
+	to       types.Address
+	value    *big.Int
+	gas      uint64
+	input    []byte
+}
+
+// JSTracer runs a tracer written in JavaScript, as the tracer objects of go-ethereum:
+// step(log, db) is called before each opcode, fault(log, db) when an opcode fails,
+// enter(frame) and exit(frameResult) around the sub calls, and result(ctx, db) returns the trace.
+// The tracer runs in a goja runtime without access to the node beyond these objects,
+// and is interrupted once cancelled
+type JSTracer struct {
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	vm      *goja.Runtime
+	program *goja.Program
+
+	obj    *goja.Object
+	step   goja.Callable
+	fault  goja.Callable
+	result goja.Callable
+	enter  goja.Callable
+	exit   goja.Callable
+
+	// the objects passed to the tracer functions, reading the current opcode
+	log *goja.Object
+	db  *goja.Object
+
+	// the state of the current opcode
+	host    tracer.RuntimeHost
+	memory  []byte
+	stack   []*big.Int
+	opCode  int
+	opName  string
+	pc      uint64
+	gas     uint64
+	cost    uint64
+	depth   int
+	opError error
+
+	// the state of the current transaction
+	frames   []*frame
+	root     *frame
+	gasLimit uint64
+	gasUsed  uint64
+	output   []byte
+	err      error
+}
+
+// NewJSTracer compiles the tracer, the JavaScript expression of the tracer object
+func NewJSTracer(code string) (*JSTracer, error) {
+	program, err := goja.Compile("tracer", "("+code+")", false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTracer, err)
+	}
+
+	t := &JSTracer{
+		vm:      goja.New(),
+		program: program,
+	}
+
+	t.vm.SetMaxCallStackSize(maxCallStackSize)
+
+	if err := t.setupGlobals(); err != nil {
+		return nil, err
+	}
+
+	t.log = t.newLog()
+	t.db = t.newDB()
+
+	if err := t.instantiate(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// instantiate evaluates the tracer object, a new one is evaluated for each transaction
+func (t *JSTracer) instantiate() error {
+	value, err := t.vm.RunProgram(t.program)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTracer, err)
+	}
+
+	obj, ok := value.(*goja.Object)
+	if !ok {
+		return fmt.Errorf("%w: the tracer isn't an object", ErrInvalidTracer)
+	}
+
+	t.obj = obj
+	t.step, _ = goja.AssertFunction(obj.Get("step"))
+	t.enter, _ = goja.AssertFunction(obj.Get("enter"))
+	t.exit, _ = goja.AssertFunction(obj.Get("exit"))
+
+	if t.result, ok = goja.AssertFunction(obj.Get("result")); !ok {
+		return fmt.Errorf("%w: the tracer has no result function", ErrInvalidTracer)
+	}
+
+	if t.fault, ok = goja.AssertFunction(obj.Get("fault")); !ok {
+		return fmt.Errorf("%w: the tracer has no fault function", ErrInvalidTracer)
+	}
+
+	if (t.enter == nil) != (t.exit == nil) {
+		return fmt.Errorf("%w: the tracer must have both the enter and the exit functions or none", ErrInvalidTracer)
+	}
+
+	return nil
+}
+
+func (t *JSTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+
+	// the function of the tracer running, if any, is stopped
+	t.vm.Interrupt(err)
+}
+
+func (t *JSTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *JSTracer) cancelReason() error {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.reason
+}
+
+// Clear evaluates a new tracer object for the next transaction,
+// the cancellation applies to all the transactions traced afterwards
+func (t *JSTracer) Clear() {
+	t.frames = nil
+	t.root = nil
+	t.gasLimit = 0
+	t.gasUsed = 0
+	t.output = nil
+	t.err = nil
+
+	if t.cancelled() {
+		return
+	}
+
+	if err := t.instantiate(); err != nil {
+		t.Cancel(err)
+	}
+}
+
+// call calls the function of the tracer, the tracing is cancelled if it throws
+func (t *JSTracer) call(fn goja.Callable, args ...goja.Value) goja.Value {
+	if t.cancelled() {
+		return nil
+	}
+
+	value, err := fn(t.obj, args...)
+	if err != nil {
+		// an interruption is already a cancellation
+		var interrupted *goja.InterruptedError
+		if !errors.As(err, &interrupted) {
+			t.Cancel(fmt.Errorf("%w: %v", ErrTracerFailed, err))
+		}
+
+		return nil
+	}
+
+	return value
+}
+
+// GetResult returns the JSON encoding of the value returned by the result function
+func (t *JSTracer) GetResult() (interface{}, error) {
+	if reason := t.cancelReason(); reason != nil {
+		return nil, reason
+	}
+
+	value := t.call(t.result, t.newContext(), t.db)
+	if reason := t.cancelReason(); reason != nil {
+		return nil, reason
+	}
+
+	stringify, _ := goja.AssertFunction(t.vm.Get("JSON").ToObject(t.vm).Get("stringify"))
+
+	encoded, err := stringify(goja.Undefined(), value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTracerFailed, err)
+	}
+
+	// undefined isn't encoded
+	if goja.IsUndefined(encoded) {
+		return json.RawMessage("null"), nil
+	}
+
+	return json.RawMessage(encoded.String()), nil
+}
+
+func (t *JSTracer) TxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+func (t *JSTracer) TxEnd(gasLeft uint64) {
+	t.gasUsed = t.gasLimit - gasLeft
+}
+
+func (t *JSTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	call := &frame{
+		callType: callType,
+		from:     from,
+		to:       to,
+		value:    new(big.Int),
+		gas:      gas,
+		input:    append([]byte{}, input...),
+	}
+
+	if value != nil {
+		call.value.Set(value)
+	}
+
+	if len(t.frames) == 0 {
+		t.root = call
+	} else if t.enter != nil {
+		t.call(t.enter, t.newFrame(call))
+	}
+
+	t.frames = append(t.frames, call)
+}
+
+func (t *JSTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasUsed uint64,
+	err error,
+) {
+	if len(t.frames) == 0 {
+		return
+	}
+
+	t.frames = t.frames[:len(t.frames)-1]
+
+	if len(t.frames) == 0 {
+		t.output = append([]byte{}, output...)
+		t.err = err
+	} else if t.exit != nil {
+		t.call(t.exit, t.newFrameResult(output, gasUsed, err))
+	}
+}
+
+func (t *JSTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	// the memory and the stack are read by the step called before the execution of the opcode
+	t.memory = memory
+	t.stack = stack[:sp]
+	t.opCode = opCode
+}
+
+func (t *JSTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+	t.host = host
+	t.opName = opCode
+	t.pc = ip
+	t.gas = availableGas
+	t.cost = cost
+	t.depth = depth
+	t.opError = err
+
+	if err != nil {
+		t.call(t.fault, t.log, t.db)
+
+		return
+	}
+
+	if t.step != nil {
+		t.call(t.step, t.log, t.db)
+	}
+}
+
+// throw throws the error in the tracer
+func (t *JSTracer) throw(err error) {
+	panic(t.vm.NewGoError(err))
+}
+
+// toBytes returns the bytes of the byte array, the array buffer or the hex string
+func (t *JSTracer) toBytes(value goja.Value) []byte {
+	switch exported := value.Export().(type) {
+	case []byte:
+		return append([]byte{}, exported...)
+	case goja.ArrayBuffer:
+		return append([]byte{}, exported.Bytes()...)
+	case string:
+		data, err := hex.DecodeHex(exported)
+		if err != nil {
+			t.throw(fmt.Errorf("invalid hex string %q", exported))
+		}
+
+		return data
+	default:
+		t.throw(fmt.Errorf("expected a byte array or a hex string, got %v", value))
+	}
+
+	return nil
+}
+
+func (t *JSTracer) toAddress(value goja.Value) types.Address {
+	return types.BytesToAddress(t.toBytes(value))
+}
+
+// bytesValue returns the bytes as a Uint8Array
+func (t *JSTracer) bytesValue(data []byte) goja.Value {
+	array, err := t.vm.New(
+		t.vm.Get("Uint8Array"),
+		t.vm.ToValue(t.vm.NewArrayBuffer(append([]byte{}, data...))),
+	)
+	if err != nil {
+		t.throw(err)
+	}
+
+	return array
+}
+
+// bigValue returns the number as an object converted with toString(radix) and toNumber()
+func (t *JSTracer) bigValue(value *big.Int) goja.Value {
+	value = new(big.Int).Set(value)
+
+	toNumber := func() float64 {
+		number, _ := new(big.Float).SetInt(value).Float64()
+
+		return number
+	}
+
+	obj := t.vm.NewObject()
+
+	_ = obj.Set("toString", func(call goja.FunctionCall) goja.Value {
+		radix := int64(10)
+		if arg := call.Argument(0); !goja.IsUndefined(arg) {
+			radix = arg.ToInteger()
+		}
+
+		if radix < 2 || radix > 36 {
+			t.throw(fmt.Errorf("invalid radix %d", radix))
+		}
+
+		return t.vm.ToValue(value.Text(int(radix)))
+	})
+	_ = obj.Set("toNumber", toNumber)
+	_ = obj.Set("valueOf", toNumber)
+
+	return obj
+}
+
+// setupGlobals defines the helpers of the go-ethereum tracers
+func (t *JSTracer) setupGlobals() error {
+	globals := map[string]interface{}{
+		"toHex": func(value goja.Value) string {
+			return hex.EncodeToHex(t.toBytes(value))
+		},
+		"toWord": func(value goja.Value) goja.Value {
+			return t.bytesValue(types.BytesToHash(t.toBytes(value)).Bytes())
+		},
+		"toAddress": func(value goja.Value) goja.Value {
+			return t.bytesValue(t.toAddress(value).Bytes())
+		},
+		"slice": func(value goja.Value, start, end int64) goja.Value {
+			data := t.toBytes(value)
+			if start < 0 || start > end || end > int64(len(data)) {
+				t.throw(fmt.Errorf("slice [%d:%d] out of bounds of %d bytes", start, end, len(data)))
+			}
+
+			return t.bytesValue(data[start:end])
+		},
+	}
+
+	for name, fn := range globals {
+		if err := t.vm.Set(name, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newLog returns the log object passed to step and fault, reading the current opcode
+func (t *JSTracer) newLog() *goja.Object {
+	op := t.vm.NewObject()
+	_ = op.Set("toNumber", func() int { return t.opCode })
+	_ = op.Set("toString", func() string { return t.opName })
+	_ = op.Set("isPush", func() bool { return t.opCode >= evm.PUSH1 && t.opCode <= evm.PUSH32 })
+
+	stack := t.vm.NewObject()
+	_ = stack.Set("length", func() int { return len(t.stack) })
+	_ = stack.Set("peek", func(index int) goja.Value {
+		if index < 0 || index >= len(t.stack) {
+			t.throw(fmt.Errorf("stack index %d out of bounds of %d items", index, len(t.stack)))
+		}
+
+		return t.bigValue(t.stack[len(t.stack)-1-index])
+	})
+
+	memory := t.vm.NewObject()
+	_ = memory.Set("length", func() int { return len(t.memory) })
+	_ = memory.Set("slice", func(start, end int64) goja.Value {
+		if start < 0 || start > end || end > int64(len(t.memory)) {
+			t.throw(fmt.Errorf("memory [%d:%d] out of bounds of %d bytes", start, end, len(t.memory)))
+		}
+
+		return t.bytesValue(t.memory[start:end])
+	})
+	_ = memory.Set("getUint", func(offset int64) goja.Value {
+		if offset < 0 || offset+types.HashLength > int64(len(t.memory)) {
+			t.throw(fmt.Errorf("memory word at %d out of bounds of %d bytes", offset, len(t.memory)))
+		}
+
+		return t.bigValue(new(big.Int).SetBytes(t.memory[offset : offset+types.HashLength]))
+	})
+
+	contract := t.vm.NewObject()
+	_ = contract.Set("getAddress", func() goja.Value { return t.bytesValue(t.currentFrame().to.Bytes()) })
+	_ = contract.Set("getCaller", func() goja.Value { return t.bytesValue(t.currentFrame().from.Bytes()) })
+	_ = contract.Set("getValue", func() goja.Value { return t.bigValue(t.currentFrame().value) })
+	_ = contract.Set("getInput", func() goja.Value { return t.bytesValue(t.currentFrame().input) })
+
+	log := t.vm.NewObject()
+	_ = log.Set("op", op)
+	_ = log.Set("stack", stack)
+	_ = log.Set("memory", memory)
+	_ = log.Set("contract", contract)
+	_ = log.Set("getPC", func() uint64 { return t.pc })
+	_ = log.Set("getGas", func() uint64 { return t.gas })
+	_ = log.Set("getCost", func() uint64 { return t.cost })
+	_ = log.Set("getDepth", func() int { return t.depth })
+	_ = log.Set("getRefund", func() uint64 { return t.host.GetRefund() })
+	_ = log.Set("getError", func() goja.Value {
+		if t.opError == nil {
+			return goja.Undefined()
+		}
+
+		return t.vm.ToValue(t.opError.Error())
+	})
+
+	return log
+}
+
+// currentFrame returns the call being executed
+func (t *JSTracer) currentFrame() *frame {
+	if len(t.frames) == 0 {
+		return &frame{value: new(big.Int)}
+	}
+
+	return t.frames[len(t.frames)-1]
+}
+
+// stateHost returns the host as a state reader, the tracer throws if it can't read the state
+func (t *JSTracer) stateHost() stateHost {
+	host, ok := t.host.(stateHost)
+	if !ok {
+		t.throw(errors.New("the state isn't available"))
+	}
+
+	return host
+}
+
+// newDB returns the db object passed to step, fault and result, reading the state
+func (t *JSTracer) newDB() *goja.Object {
+	db := t.vm.NewObject()
+	_ = db.Set("getBalance", func(addr goja.Value) goja.Value {
+		return t.bigValue(t.stateHost().GetBalance(t.toAddress(addr)))
+	})
+	_ = db.Set("getNonce", func(addr goja.Value) uint64 {
+		return t.stateHost().GetNonce(t.toAddress(addr))
+	})
+	_ = db.Set("getCode", func(addr goja.Value) goja.Value {
+		return t.bytesValue(t.stateHost().GetCode(t.toAddress(addr)))
+	})
+	_ = db.Set("getState", func(addr, slot goja.Value) goja.Value {
+		value := t.stateHost().GetStorage(t.toAddress(addr), types.BytesToHash(t.toBytes(slot)))
+
+		return t.bytesValue(value.Bytes())
+	})
+	_ = db.Set("exists", func(addr goja.Value) bool {
+		return t.stateHost().AccountExists(t.toAddress(addr))
+	})
+
+	return db
+}
+
+// newFrame returns the frame object passed to enter
+func (t *JSTracer) newFrame(call *frame) *goja.Object {
+	obj := t.vm.NewObject()
+	_ = obj.Set("getType", func() string { return callTypeName(call.callType) })
+	_ = obj.Set("getFrom", func() goja.Value { return t.bytesValue(call.from.Bytes()) })
+	_ = obj.Set("getTo", func() goja.Value { return t.bytesValue(call.to.Bytes()) })
+	_ = obj.Set("getInput", func() goja.Value { return t.bytesValue(call.input) })
+	_ = obj.Set("getGas", func() uint64 { return call.gas })
+	_ = obj.Set("getValue", func() goja.Value { return t.bigValue(call.value) })
+
+	return obj
+}
+
+// newFrameResult returns the frame result object passed to exit
+func (t *JSTracer) newFrameResult(output []byte, gasUsed uint64, err error) *goja.Object {
+	obj := t.vm.NewObject()
+	_ = obj.Set("getGasUsed", func() uint64 { return gasUsed })
+	_ = obj.Set("getOutput", func() goja.Value { return t.bytesValue(output) })
+	_ = obj.Set("getError", func() goja.Value {
+		if err == nil {
+			return goja.Undefined()
+		}
+
+		return t.vm.ToValue(err.Error())
+	})
+
+	return obj
+}
+
+// newContext returns the context object passed to result, describing the transaction
+func (t *JSTracer) newContext() *goja.Object {
+	ctx := t.vm.NewObject()
+
+	root := t.root
+	if root == nil {
+		root = &frame{value: new(big.Int)}
+	}
+
+	callType := "CALL"
+	if isCreate(root.callType) {
+		callType = "CREATE"
+	}
+
+	_ = ctx.Set("type", callType)
+	_ = ctx.Set("from", t.bytesValue(root.from.Bytes()))
+	_ = ctx.Set("to", t.bytesValue(root.to.Bytes()))
+	_ = ctx.Set("input", t.bytesValue(root.input))
+	_ = ctx.Set("value", t.bigValue(root.value))
+	_ = ctx.Set("gas", t.gasLimit)
+	_ = ctx.Set("gasUsed", t.gasUsed)
+	_ = ctx.Set("output", t.bytesValue(t.output))
+
+	if t.err != nil {
+		_ = ctx.Set("error", t.err.Error())
+	}
+
+	return ctx
+}
+
+func isCreate(callType int) bool {
+	switch callType {
+	case int(runtime.Create), int(runtime.Create2), int(evm.CREATE), int(evm.CREATE2):
+		return true
+	default:
+		return false
+	}
+}
+
+// callTypeName returns the opcode name of the call type, as the frames of go-ethereum
+func callTypeName(callType int) string {
+	switch callType {
+	case int(runtime.CallCode):
+		return "CALLCODE"
+	case int(runtime.DelegateCall):
+		return "DELEGATECALL"
+	case int(runtime.StaticCall):
+		return "STATICCALL"
+	case int(runtime.Create), int(evm.CREATE):
+		return "CREATE"
+	case int(runtime.Create2), int(evm.CREATE2):
+		return "CREATE2"
+	default:
+		return "CALL"
+	}
+}
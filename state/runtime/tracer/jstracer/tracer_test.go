@@ -0,0 +1,352 @@
+package jstracer
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testFrom   = types.StringToAddress("1")
+	testCaller = types.StringToAddress("2")
+	testCallee = types.StringToAddress("3")
+
+	errTimeout = errors.New("timeout")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+type mockHost struct{}
+
+func (m *mockHost) GetRefund() uint64 {
+	return 10
+}
+
+func (m *mockHost) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	return types.BytesToHash(append(addr.Bytes()[19:], slot.Bytes()[31:]...))
+}
+
+func (m *mockHost) AccountExists(addr types.Address) bool {
+	return addr == testCallee
+}
+
+func (m *mockHost) GetBalance(addr types.Address) *big.Int {
+	return big.NewInt(1000)
+}
+
+func (m *mockHost) GetNonce(addr types.Address) uint64 {
+	return 7
+}
+
+func (m *mockHost) GetCode(addr types.Address) []byte {
+	return []byte{0x60, 0x00}
+}
+
+// runOp runs an opcode of the traced transaction
+func runOp(tr *JSTracer, state *mockState, op int, name string, pc uint64, stack []*big.Int, memory []byte, err error) {
+	host := &mockHost{}
+
+	tr.CaptureState(memory, stack, op, testCallee, len(stack), host, state)
+	tr.ExecuteState(testCallee, pc, name, 1000-pc, 3, nil, 1, err, host)
+}
+
+func getResult(t *testing.T, tr *JSTracer) string {
+	t.Helper()
+
+	res, err := tr.GetResult()
+	require.NoError(t, err)
+
+	raw, ok := res.(json.RawMessage)
+	require.True(t, ok)
+
+	return string(raw)
+}
+
+func TestJSTracer_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, code := range []string{
+		"",
+		"{",
+		"1",
+		"callTracer",
+		"{result: function() {}}",
+		"{fault: function() {}}",
+		"{result: function() {}, fault: function() {}, enter: function() {}}",
+		"{result: function() {}, fault: function() {}, x: (function() { throw 'failed' })()}",
+	} {
+		_, err := NewJSTracer(code)
+
+		assert.ErrorIs(t, err, ErrInvalidTracer, code)
+	}
+}
+
+func TestJSTracer_Step(t *testing.T) {
+	t.Parallel()
+
+	tr, err := NewJSTracer(`{
+		ops: [],
+		step: function(log, db) {
+			var op = {
+				op: log.op.toString(),
+				number: log.op.toNumber(),
+				push: log.op.isPush(),
+				pc: log.getPC(),
+				gas: log.getGas(),
+				cost: log.getCost(),
+				depth: log.getDepth(),
+				refund: log.getRefund(),
+				stack: log.stack.length(),
+				memory: log.memory.length(),
+			};
+			if (log.stack.length() > 0) {
+				op.top = log.stack.peek(0).toString(16);
+				op.topNumber = log.stack.peek(0).toNumber();
+			}
+			if (log.memory.length() >= 32) {
+				op.word = log.memory.getUint(0).toString();
+				op.slice = toHex(log.memory.slice(30, 32));
+			}
+			this.ops.push(op);
+		},
+		fault: function(log, db) { this.ops.push({fault: log.getError()}); },
+		result: function(ctx, db) { return this.ops; }
+	}`)
+	require.NoError(t, err)
+
+	var (
+		state  = &mockState{}
+		memory = make([]byte, 32)
+	)
+
+	memory[31] = 0xff
+
+	runOp(tr, state, evm.PUSH1, "PUSH1", 0, nil, nil, nil)
+	runOp(tr, state, evm.MSTORE, "MSTORE", 2, []*big.Int{big.NewInt(0), big.NewInt(255)}, memory, nil)
+	runOp(tr, state, evm.SLOAD, "SLOAD", 3, nil, memory, runtime.ErrStackUnderflow)
+
+	assert.JSONEq(t, `[
+		{"op":"PUSH1","number":96,"push":true,"pc":0,"gas":1000,"cost":3,"depth":1,"refund":10,"stack":0,"memory":0},
+		{"op":"MSTORE","number":82,"push":false,"pc":2,"gas":998,"cost":3,"depth":1,"refund":10,"stack":2,"memory":32,
+		 "top":"ff","topNumber":255,"word":"255","slice":"0x00ff"},
+		{"fault":"stack underflow"}
+	]`, getResult(t, tr))
+	assert.False(t, state.halted)
+}
+
+func TestJSTracer_Frames(t *testing.T) {
+	t.Parallel()
+
+	tr, err := NewJSTracer(`{
+		calls: [],
+		enter: function(frame) {
+			this.calls.push({
+				type: frame.getType(),
+				from: toHex(frame.getFrom()),
+				to: toHex(frame.getTo()),
+				input: toHex(frame.getInput()),
+				gas: frame.getGas(),
+				value: frame.getValue().toString(),
+			});
+		},
+		exit: function(res) {
+			this.calls.push({gasUsed: res.getGasUsed(), output: toHex(res.getOutput()), error: res.getError()});
+		},
+		fault: function(log, db) {},
+		result: function(ctx, db) {
+			return {
+				type: ctx.type,
+				from: toHex(ctx.from),
+				to: toHex(ctx.to),
+				input: toHex(ctx.input),
+				value: ctx.value.toString(16),
+				gas: ctx.gas,
+				gasUsed: ctx.gasUsed,
+				output: toHex(ctx.output),
+				error: ctx.error,
+				calls: this.calls,
+			};
+		}
+	}`)
+	require.NoError(t, err)
+
+	tr.TxStart(100000)
+	tr.CallStart(1, testFrom, testCaller, int(runtime.Call), 50000, big.NewInt(16), []byte{0x1})
+	tr.CallStart(2, testCaller, testCallee, int(evm.CREATE2), 20000, nil, []byte{0x2})
+	tr.CallEnd(2, []byte{0x3}, 100, nil)
+	tr.CallStart(2, testCaller, testCallee, int(runtime.StaticCall), 1000, nil, nil)
+	tr.CallEnd(2, nil, 1000, runtime.ErrOutOfGas)
+	tr.CallEnd(1, []byte{0x4}, 3000, runtime.ErrExecutionReverted)
+	tr.TxEnd(40000)
+
+	assert.JSONEq(t, `{
+		"type": "CALL",
+		"from": "0x0000000000000000000000000000000000000001",
+		"to": "0x0000000000000000000000000000000000000002",
+		"input": "0x01",
+		"value": "10",
+		"gas": 100000,
+		"gasUsed": 60000,
+		"output": "0x04",
+		"error": "execution was reverted",
+		"calls": [
+			{"type": "CREATE2", "from": "0x0000000000000000000000000000000000000002",
+			 "to": "0x0000000000000000000000000000000000000003", "input": "0x02", "gas": 20000, "value": "0"},
+			{"gasUsed": 100, "output": "0x03"},
+			{"type": "STATICCALL", "from": "0x0000000000000000000000000000000000000002",
+			 "to": "0x0000000000000000000000000000000000000003", "input": "0x", "gas": 1000, "value": "0"},
+			{"gasUsed": 1000, "output": "0x", "error": "out of gas"}
+		]
+	}`, getResult(t, tr))
+}
+
+func TestJSTracer_DB(t *testing.T) {
+	t.Parallel()
+
+	tr, err := NewJSTracer(`{
+		res: {},
+		step: function(log, db) {
+			var addr = log.contract.getAddress();
+			this.res = {
+				address: toHex(addr),
+				balance: db.getBalance(addr).toString(),
+				nonce: db.getNonce(addr),
+				code: toHex(db.getCode(addr)),
+				state: toHex(db.getState(addr, toWord("0x02"))),
+				exists: db.exists(addr),
+				missing: db.exists(toAddress("0x04")),
+				caller: toHex(log.contract.getCaller()),
+				value: log.contract.getValue().toString(),
+				input: toHex(slice(log.contract.getInput(), 1, 2)),
+			};
+		},
+		fault: function(log, db) {},
+		result: function(ctx, db) { return this.res; }
+	}`)
+	require.NoError(t, err)
+
+	tr.CallStart(1, testCaller, testCallee, int(runtime.Call), 50000, big.NewInt(5), []byte{0x1, 0x2})
+	runOp(tr, &mockState{}, int(evm.STOP), "STOP", 0, nil, nil, nil)
+	tr.CallEnd(1, nil, 0, nil)
+
+	assert.JSONEq(t, `{
+		"address": "0x0000000000000000000000000000000000000003",
+		"balance": "1000",
+		"nonce": 7,
+		"code": "0x6000",
+		"state": "0x0000000000000000000000000000000000000000000000000000000000000302",
+		"exists": true,
+		"missing": false,
+		"caller": "0x0000000000000000000000000000000000000002",
+		"value": "5",
+		"input": "0x02"
+	}`, getResult(t, tr))
+}
+
+func TestJSTracer_Throw(t *testing.T) {
+	t.Parallel()
+
+	tr, err := NewJSTracer(`{
+		step: function(log, db) { log.stack.peek(0); },
+		fault: function(log, db) {},
+		result: function(ctx, db) { return 1; }
+	}`)
+	require.NoError(t, err)
+
+	state := &mockState{}
+
+	// the stack is empty
+	runOp(tr, state, evm.PUSH1, "PUSH1", 0, nil, nil, nil)
+
+	_, err = tr.GetResult()
+	assert.ErrorIs(t, err, ErrTracerFailed)
+
+	// the execution is halted
+	runOp(tr, state, evm.PUSH1, "PUSH1", 2, nil, nil, nil)
+	assert.True(t, state.halted)
+}
+
+func TestJSTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	tr, err := NewJSTracer(`{
+		step: function(log, db) { while (true) {} },
+		fault: function(log, db) {},
+		result: function(ctx, db) { return 1; }
+	}`)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		tr.Cancel(errTimeout)
+	}()
+
+	state := &mockState{}
+
+	// the endless step is interrupted
+	runOp(tr, state, evm.PUSH1, "PUSH1", 0, nil, nil, nil)
+
+	_, err = tr.GetResult()
+	assert.ErrorIs(t, err, errTimeout)
+
+	// the cancellation applies to the next transactions
+	tr.Clear()
+	runOp(tr, state, evm.PUSH1, "PUSH1", 0, nil, nil, nil)
+	assert.True(t, state.halted)
+
+	_, err = tr.GetResult()
+	assert.ErrorIs(t, err, errTimeout)
+}
+
+func TestJSTracer_Clear(t *testing.T) {
+	t.Parallel()
+
+	tr, err := NewJSTracer(`{
+		count: 0,
+		step: function(log, db) { this.count++; },
+		fault: function(log, db) {},
+		result: function(ctx, db) { return this.count; }
+	}`)
+	require.NoError(t, err)
+
+	state := &mockState{}
+
+	runOp(tr, state, evm.PUSH1, "PUSH1", 0, nil, nil, nil)
+	runOp(tr, state, evm.PUSH1, "PUSH1", 2, nil, nil, nil)
+	assert.Equal(t, "2", getResult(t, tr))
+
+	// each transaction is traced by a new tracer object
+	tr.Clear()
+	runOp(tr, state, evm.PUSH1, "PUSH1", 0, nil, nil, nil)
+	assert.Equal(t, "1", getResult(t, tr))
+}
+
+func TestJSTracer_Recursion(t *testing.T) {
+	t.Parallel()
+
+	tr, err := NewJSTracer(`{
+		step: function(log, db) { var f = function() { return f(); }; f(); },
+		fault: function(log, db) {},
+		result: function(ctx, db) { return 1; }
+	}`)
+	require.NoError(t, err)
+
+	runOp(tr, &mockState{}, evm.PUSH1, "PUSH1", 0, nil, nil, nil)
+
+	_, err = tr.GetResult()
+	assert.ErrorIs(t, err, ErrTracerFailed)
+}
@@ -0,0 +1,307 @@
+package profiler
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// OpcodeStats are the aggregated executions of an opcode
+type OpcodeStats struct {
+	Opcode string `json:"opcode"`
+	Count  uint64 `json:"count"`
+	Gas    uint64 `json:"gas"`
+	TimeNs int64  `json:"timeNs"`
+}
+
+// ContractStats are the aggregated executions of the code running at an address
+type ContractStats struct {
+	Address types.Address `json:"address"`
+	Calls   uint64        `json:"calls"`
+	Opcodes uint64        `json:"opcodes"`
+	Gas     uint64        `json:"gas"`
+	TimeNs  int64         `json:"timeNs"`
+}
+
+// Profile is the result of the profiler, the opcodes and the contracts
+// are sorted by the gas they used, descending
+type Profile struct {
+	Transactions uint64           `json:"transactions"`
+	Gas          uint64           `json:"gas"`
+	TimeNs       int64            `json:"timeNs"`
+	Opcodes      []*OpcodeStats   `json:"opcodes"`
+	Contracts    []*ContractStats `json:"contracts"`
+}
+
+// frame is a call being executed
+type frame struct {
+	contract types.Address
+
+	// the opcode executed last, whose gas and time are known
+	// once the next opcode of the frame runs or the frame ends
+	pending      bool
+	opcode       string
+	availableGas uint64
+	start        time.Time
+
+	// the gas and time used by the calls of the pending opcode
+	childGas  uint64
+	childTime time.Duration
+
+	// the gas and time used by the frame, including its calls
+	gasUsed   uint64
+	frameTime time.Time
+}
+
+// Profiler is a tracer aggregating the gas and the time spent per opcode and per contract.
+// Unlike the other tracers, the aggregates are kept by Clear, so a profiler
+// can be run over all the transactions of a block range.
+//
+// The gas of an opcode excludes the gas used by the calls it makes. The cost of the
+// opcode ending a call is only known if the call fails: the memory expansion of
+// a final RETURN or REVERT is accounted to the calling opcode, if any
+type Profiler struct {
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	frames []*frame
+
+	transactions uint64
+	opcodes      map[string]*OpcodeStats
+	contracts    map[types.Address]*ContractStats
+}
+
+func NewProfiler() *Profiler {
+	return &Profiler{
+		opcodes:   make(map[string]*OpcodeStats),
+		contracts: make(map[types.Address]*ContractStats),
+	}
+}
+
+func (p *Profiler) Cancel(err error) {
+	p.cancelLock.Lock()
+	defer p.cancelLock.Unlock()
+
+	p.reason = err
+	p.interrupt = true
+}
+
+func (p *Profiler) cancelled() bool {
+	p.cancelLock.RLock()
+	defer p.cancelLock.RUnlock()
+
+	return p.interrupt
+}
+
+// Clear drops the calls of the interrupted transaction, the aggregates are kept
+func (p *Profiler) Clear() {
+	p.frames = p.frames[:0]
+}
+
+// GetResult returns the profile aggregated so far
+func (p *Profiler) GetResult() (interface{}, error) {
+	p.cancelLock.RLock()
+	defer p.cancelLock.RUnlock()
+
+	if p.reason != nil {
+		return nil, p.reason
+	}
+
+	profile := &Profile{
+		Transactions: p.transactions,
+		Opcodes:      make([]*OpcodeStats, 0, len(p.opcodes)),
+		Contracts:    make([]*ContractStats, 0, len(p.contracts)),
+	}
+
+	for _, stats := range p.opcodes {
+		copied := *stats
+
+		profile.Opcodes = append(profile.Opcodes, &copied)
+		profile.Gas += stats.Gas
+		profile.TimeNs += stats.TimeNs
+	}
+
+	for _, stats := range p.contracts {
+		copied := *stats
+
+		profile.Contracts = append(profile.Contracts, &copied)
+	}
+
+	sort.Slice(profile.Opcodes, func(i, j int) bool {
+		if profile.Opcodes[i].Gas != profile.Opcodes[j].Gas {
+			return profile.Opcodes[i].Gas > profile.Opcodes[j].Gas
+		}
+
+		return profile.Opcodes[i].Opcode < profile.Opcodes[j].Opcode
+	})
+
+	sort.Slice(profile.Contracts, func(i, j int) bool {
+		if profile.Contracts[i].Gas != profile.Contracts[j].Gas {
+			return profile.Contracts[i].Gas > profile.Contracts[j].Gas
+		}
+
+		return profile.Contracts[i].Address.String() < profile.Contracts[j].Address.String()
+	})
+
+	return profile, nil
+}
+
+func (p *Profiler) TxStart(gasLimit uint64) {
+	p.transactions++
+	p.frames = p.frames[:0]
+}
+
+func (p *Profiler) TxEnd(gasLeft uint64) {
+}
+
+func (p *Profiler) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	p.contractStats(to).Calls++
+
+	p.frames = append(p.frames, &frame{
+		contract:  to,
+		frameTime: time.Now(),
+	})
+}
+
+func (p *Profiler) CallEnd(
+	depth int,
+	output []byte,
+	gasUsed uint64,
+	err error,
+) {
+	if len(p.frames) == 0 {
+		return
+	}
+
+	now := time.Now()
+
+	f := p.frames[len(p.frames)-1]
+	p.frames = p.frames[:len(p.frames)-1]
+
+	if f.pending {
+		var gas uint64
+
+		// a failed call, not reverted, consumes all of its gas
+		if err != nil && !errors.Is(err, runtime.ErrExecutionReverted) {
+			gas = f.availableGas
+		}
+
+		p.record(f, gas, now)
+
+		f.gasUsed += gas
+	}
+
+	if len(p.frames) == 0 {
+		return
+	}
+
+	// the call is accounted to the opcode of the parent making it
+	parent := p.frames[len(p.frames)-1]
+	parent.childGas += f.gasUsed
+	parent.childTime += now.Sub(f.frameTime)
+}
+
+func (p *Profiler) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if p.cancelled() {
+		state.Halt()
+	}
+}
+
+func (p *Profiler) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+	if len(p.frames) == 0 {
+		return
+	}
+
+	now := time.Now()
+	f := p.frames[len(p.frames)-1]
+
+	if f.pending {
+		// the gas used by the previous opcode, including its calls
+		used := f.availableGas - availableGas
+
+		gas := used
+		if gas >= f.childGas {
+			gas -= f.childGas
+		} else {
+			gas = 0
+		}
+
+		p.record(f, gas, now)
+
+		f.gasUsed += used
+	}
+
+	f.pending = true
+	f.opcode = opCode
+	f.availableGas = availableGas
+	f.start = now
+	f.childGas = 0
+	f.childTime = 0
+}
+
+// record aggregates the pending opcode of the frame
+func (p *Profiler) record(f *frame, gas uint64, now time.Time) {
+	elapsed := now.Sub(f.start) - f.childTime
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	opcode, ok := p.opcodes[f.opcode]
+	if !ok {
+		opcode = &OpcodeStats{Opcode: f.opcode}
+		p.opcodes[f.opcode] = opcode
+	}
+
+	opcode.Count++
+	opcode.Gas += gas
+	opcode.TimeNs += elapsed.Nanoseconds()
+
+	contract := p.contractStats(f.contract)
+	contract.Opcodes++
+	contract.Gas += gas
+	contract.TimeNs += elapsed.Nanoseconds()
+
+	f.pending = false
+}
+
+func (p *Profiler) contractStats(addr types.Address) *ContractStats {
+	stats, ok := p.contracts[addr]
+	if !ok {
+		stats = &ContractStats{Address: addr}
+		p.contracts[addr] = stats
+	}
+
+	return stats
+}
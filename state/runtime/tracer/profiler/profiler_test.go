@@ -0,0 +1,191 @@
+package profiler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testFrom   = types.StringToAddress("1")
+	testCaller = types.StringToAddress("2")
+	testCallee = types.StringToAddress("3")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+func executeState(p *Profiler, addr types.Address, opcode string, availableGas uint64, depth int, err error) {
+	p.ExecuteState(addr, 0, opcode, availableGas, 0, nil, depth, err, nil)
+}
+
+func getProfile(t *testing.T, p *Profiler) *Profile {
+	t.Helper()
+
+	res, err := p.GetResult()
+	require.NoError(t, err)
+
+	profile, ok := res.(*Profile)
+	require.True(t, ok)
+
+	return profile
+}
+
+func findOpcode(profile *Profile, opcode string) *OpcodeStats {
+	for _, stats := range profile.Opcodes {
+		if stats.Opcode == opcode {
+			return stats
+		}
+	}
+
+	return nil
+}
+
+func findContract(profile *Profile, addr types.Address) *ContractStats {
+	for _, stats := range profile.Contracts {
+		if stats.Address == addr {
+			return stats
+		}
+	}
+
+	return nil
+}
+
+func TestProfiler_NestedCall(t *testing.T) {
+	t.Parallel()
+
+	p := NewProfiler()
+
+	p.TxStart(100000)
+	p.CallStart(1, testFrom, testCaller, 0, 1000, nil, nil)
+	executeState(p, testCaller, "PUSH1", 1000, 1, nil)
+	executeState(p, testCaller, "CALL", 997, 1, nil)
+
+	p.CallStart(2, testCaller, testCallee, 0, 500, nil, nil)
+	executeState(p, testCallee, "PUSH1", 500, 2, nil)
+	executeState(p, testCallee, "SSTORE", 497, 2, nil)
+	executeState(p, testCallee, "STOP", 400, 2, nil)
+	p.CallEnd(2, nil, 0, nil)
+
+	// the CALL costs 700, the callee used 100 of the forwarded gas
+	executeState(p, testCaller, "POP", 197, 1, nil)
+	executeState(p, testCaller, "STOP", 195, 1, nil)
+	p.CallEnd(1, nil, 0, nil)
+	p.TxEnd(195)
+
+	profile := getProfile(t, p)
+
+	assert.Equal(t, uint64(1), profile.Transactions)
+	assert.Equal(t, uint64(805), profile.Gas)
+
+	for opcode, expected := range map[string]struct {
+		count uint64
+		gas   uint64
+	}{
+		"CALL":   {1, 700},
+		"SSTORE": {1, 97},
+		"PUSH1":  {2, 6},
+		"POP":    {1, 2},
+		"STOP":   {2, 0},
+	} {
+		stats := findOpcode(profile, opcode)
+		require.NotNil(t, stats, opcode)
+
+		assert.Equal(t, expected.count, stats.Count, opcode)
+		assert.Equal(t, expected.gas, stats.Gas, opcode)
+	}
+
+	// sorted by gas
+	assert.Equal(t, "CALL", profile.Opcodes[0].Opcode)
+	assert.Equal(t, testCaller, profile.Contracts[0].Address)
+
+	caller := findContract(profile, testCaller)
+	assert.Equal(t, &ContractStats{
+		Address: testCaller,
+		Calls:   1,
+		Opcodes: 4,
+		Gas:     705,
+		TimeNs:  caller.TimeNs,
+	}, caller)
+
+	callee := findContract(profile, testCallee)
+	assert.Equal(t, &ContractStats{
+		Address: testCallee,
+		Calls:   1,
+		Opcodes: 3,
+		Gas:     100,
+		TimeNs:  callee.TimeNs,
+	}, callee)
+}
+
+func TestProfiler_FailedCall(t *testing.T) {
+	t.Parallel()
+
+	p := NewProfiler()
+
+	// the failing opcode consumes all the gas left
+	p.TxStart(100000)
+	p.CallStart(1, testFrom, testCallee, 0, 1000, nil, nil)
+	executeState(p, testCallee, "PUSH1", 1000, 1, nil)
+	executeState(p, testCallee, "SSTORE", 997, 1, runtime.ErrOutOfGas)
+	p.CallEnd(1, nil, 0, runtime.ErrOutOfGas)
+
+	// a reverted call keeps its gas
+	p.TxStart(100000)
+	p.CallStart(1, testFrom, testCallee, 0, 1000, nil, nil)
+	executeState(p, testCallee, "REVERT", 1000, 1, nil)
+	p.CallEnd(1, nil, 0, runtime.ErrExecutionReverted)
+
+	profile := getProfile(t, p)
+
+	assert.Equal(t, uint64(2), profile.Transactions)
+	assert.Equal(t, uint64(997), findOpcode(profile, "SSTORE").Gas)
+	assert.Equal(t, uint64(0), findOpcode(profile, "REVERT").Gas)
+	assert.Equal(t, uint64(2), findContract(profile, testCallee).Calls)
+}
+
+func TestProfiler_ClearKeepsAggregates(t *testing.T) {
+	t.Parallel()
+
+	p := NewProfiler()
+
+	p.TxStart(100000)
+	p.CallStart(1, testFrom, testCallee, 0, 1000, nil, nil)
+	executeState(p, testCallee, "PUSH1", 1000, 1, nil)
+	executeState(p, testCallee, "STOP", 997, 1, nil)
+	p.CallEnd(1, nil, 0, nil)
+
+	p.Clear()
+
+	profile := getProfile(t, p)
+
+	assert.Equal(t, uint64(1), profile.Transactions)
+	assert.Equal(t, uint64(3), profile.Gas)
+}
+
+func TestProfiler_Cancel(t *testing.T) {
+	t.Parallel()
+
+	errCancelled := errors.New("cancelled")
+
+	p := NewProfiler()
+	p.Cancel(errCancelled)
+
+	state := &mockState{}
+	p.CaptureState(nil, nil, 0, testCallee, 0, nil, state)
+
+	assert.True(t, state.halted)
+
+	res, err := p.GetResult()
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, errCancelled)
+}
@@ -118,6 +118,7 @@ func (t *StructTracer) CallStart(
 func (t *StructTracer) CallEnd(
 	depth int,
 	output []byte,
+	gasUsed uint64,
 	err error,
 ) {
 	if depth == 1 {
@@ -286,7 +286,7 @@ func TestStructTracerCallEnd(t *testing.T) {
 
 			tracer := NewStructTracer(testEmptyConfig)
 
-			tracer.CallEnd(test.depth, test.output, test.err)
+			tracer.CallEnd(test.depth, test.output, 0, test.err)
 
 			assert.Equal(
 				t,
@@ -43,6 +43,7 @@ type Tracer interface {
 	CallEnd(
 		depth int, // begins from 1
 		output []byte,
+		gasUsed uint64,
 		err error,
 	)
 
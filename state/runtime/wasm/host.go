@@ -0,0 +1,307 @@
+package wasm
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// hostModule is the module the host functions are imported from
+const hostModule = "env"
+
+const (
+	maxTopics = 4
+
+	// the gas costs follow the ones of the equivalent EVM opcodes
+	gasBase          = 2
+	gasCopyWord      = 3
+	gasBalance       = 700
+	gasSloadLegacy   = 200
+	gasSloadIstanbul = 800
+	gasSstoreSet     = 20000
+	gasSstoreReset   = 5000
+	gasLog           = 375
+	gasLogTopic      = 375
+	gasLogData       = 8
+)
+
+var (
+	errWriteProtection = errors.New("wasm: write protection")
+	errTooManyTopics   = errors.New("wasm: too many log topics")
+	errInputOutOfRange = errors.New("wasm: input copy out of range")
+)
+
+// hostFunc is a function the contracts import to interact with the state,
+// its gas is charged before it is called
+type hostFunc struct {
+	typ  *funcType
+	gas  uint64
+	call func(in *instance, args []uint64) (uint64, error)
+}
+
+func signature(params []byte, results ...byte) *funcType {
+	return &funcType{params: params, results: results}
+}
+
+// i32s returns n i32 parameters
+func i32s(n int) []byte {
+	params := make([]byte, n)
+	for i := range params {
+		params[i] = valueTypeI32
+	}
+
+	return params
+}
+
+// hostFuncs are the functions of the host module, the pointers are
+// offsets in the memory of the instance and the words are big endian
+var hostFuncs = map[string]*hostFunc{
+	// input_size() -> i32 returns the size of the call input
+	"input_size": {
+		typ: signature(nil, valueTypeI32),
+		gas: gasBase,
+		call: func(in *instance, _ []uint64) (uint64, error) {
+			return uint64(len(in.contract.Input)), nil
+		},
+	},
+	// input_copy(dst, offset, length) copies the input at the offset to the memory
+	"input_copy": {
+		typ: signature(i32s(3)),
+		gas: gasBase,
+		call: func(in *instance, args []uint64) (uint64, error) {
+			offset, length := uint64(uint32(args[1])), uint64(uint32(args[2]))
+			if offset+length > uint64(len(in.contract.Input)) {
+				return 0, errInputOutOfRange
+			}
+
+			return 0, in.writeMemory(args[0], in.contract.Input[offset:offset+length])
+		},
+	},
+	// caller(dst) writes the 20 bytes address of the caller
+	"caller": {
+		typ: signature(i32s(1)),
+		gas: gasBase,
+		call: func(in *instance, args []uint64) (uint64, error) {
+			return 0, in.writeMemory(args[0], in.contract.Caller.Bytes())
+		},
+	},
+	// address(dst) writes the 20 bytes address of the contract
+	"address": {
+		typ: signature(i32s(1)),
+		gas: gasBase,
+		call: func(in *instance, args []uint64) (uint64, error) {
+			return 0, in.writeMemory(args[0], in.contract.Address.Bytes())
+		},
+	},
+	// call_value(dst) writes the 32 bytes value sent with the call
+	"call_value": {
+		typ: signature(i32s(1)),
+		gas: gasBase,
+		call: func(in *instance, args []uint64) (uint64, error) {
+			return 0, in.writeMemory(args[0], bigToWord(in.contract.Value))
+		},
+	},
+	// balance(addr, dst) writes the 32 bytes balance of the account
+	"balance": {
+		typ: signature(i32s(2)),
+		gas: gasBalance,
+		call: func(in *instance, args []uint64) (uint64, error) {
+			addr, err := in.memoryRange(args[0], 0, types.AddressLength)
+			if err != nil {
+				return 0, err
+			}
+
+			balance := in.host.GetBalance(types.BytesToAddress(addr))
+
+			return 0, in.writeMemory(args[1], bigToWord(balance))
+		},
+	},
+	// block_number() -> i64 returns the number of the block
+	"block_number": {
+		typ: signature(nil, valueTypeI64),
+		gas: gasBase,
+		call: func(in *instance, _ []uint64) (uint64, error) {
+			return uint64(in.host.GetTxContext().Number), nil
+		},
+	},
+	// timestamp() -> i64 returns the timestamp of the block
+	"timestamp": {
+		typ: signature(nil, valueTypeI64),
+		gas: gasBase,
+		call: func(in *instance, _ []uint64) (uint64, error) {
+			return uint64(in.host.GetTxContext().Timestamp), nil
+		},
+	},
+	// storage_load(key, dst) writes the 32 bytes value of the storage slot
+	"storage_load": {
+		typ: signature(i32s(2)),
+		call: func(in *instance, args []uint64) (uint64, error) {
+			gas := uint64(gasSloadLegacy)
+			if in.config.Istanbul {
+				gas = gasSloadIstanbul
+			}
+
+			if err := in.useGas(gas); err != nil {
+				return 0, err
+			}
+
+			key, err := in.memoryRange(args[0], 0, types.HashLength)
+			if err != nil {
+				return 0, err
+			}
+
+			value := in.host.GetStorage(in.contract.Address, types.BytesToHash(key))
+
+			return 0, in.writeMemory(args[1], value.Bytes())
+		},
+	},
+	// storage_store(key, value) sets the storage slot to the 32 bytes value
+	"storage_store": {
+		typ: signature(i32s(2)),
+		call: func(in *instance, args []uint64) (uint64, error) {
+			if in.contract.Static {
+				return 0, errWriteProtection
+			}
+
+			key, err := in.memoryRange(args[0], 0, types.HashLength)
+			if err != nil {
+				return 0, err
+			}
+
+			value, err := in.memoryRange(args[1], 0, types.HashLength)
+			if err != nil {
+				return 0, err
+			}
+
+			status := in.host.SetStorage(
+				in.contract.Address,
+				types.BytesToHash(key),
+				types.BytesToHash(value),
+				in.config,
+			)
+
+			var gas uint64
+
+			switch status {
+			case runtime.StorageAdded:
+				gas = gasSstoreSet
+			case runtime.StorageModified, runtime.StorageDeleted:
+				gas = gasSstoreReset
+			default:
+				gas = gasSloadIstanbul
+			}
+
+			return 0, in.useGas(gas)
+		},
+	},
+	// emit_log(topics, count, data, length) emits a log with the count 32 bytes topics
+	"emit_log": {
+		typ: signature(i32s(4)),
+		gas: gasLog,
+		call: func(in *instance, args []uint64) (uint64, error) {
+			if in.contract.Static {
+				return 0, errWriteProtection
+			}
+
+			count, length := uint64(uint32(args[1])), uint64(uint32(args[3]))
+			if count > maxTopics {
+				return 0, errTooManyTopics
+			}
+
+			if err := in.useGas(count*gasLogTopic + length*gasLogData); err != nil {
+				return 0, err
+			}
+
+			buf, err := in.memoryRange(args[0], 0, count*types.HashLength)
+			if err != nil {
+				return 0, err
+			}
+
+			topics := make([]types.Hash, count)
+			for i := range topics {
+				topics[i] = types.BytesToHash(buf[i*types.HashLength : (i+1)*types.HashLength])
+			}
+
+			data, err := in.memoryRange(args[2], 0, length)
+			if err != nil {
+				return 0, err
+			}
+
+			in.host.EmitLog(in.contract.Address, topics, append([]byte{}, data...))
+
+			return 0, nil
+		},
+	},
+	// finish(src, length) stops the execution returning the memory range
+	"finish": {
+		typ: signature(i32s(2)),
+		gas: gasBase,
+		call: func(in *instance, args []uint64) (uint64, error) {
+			if err := in.setOutput(args[0], args[1]); err != nil {
+				return 0, err
+			}
+
+			return 0, errFinish
+		},
+	},
+	// revert(src, length) stops the execution reverting its changes, the memory range is returned
+	"revert": {
+		typ: signature(i32s(2)),
+		gas: gasBase,
+		call: func(in *instance, args []uint64) (uint64, error) {
+			if err := in.setOutput(args[0], args[1]); err != nil {
+				return 0, err
+			}
+
+			return 0, runtime.ErrExecutionReverted
+		},
+	},
+}
+
+// writeMemory copies the data to the memory at the address, the copied words are charged
+func (in *instance) writeMemory(addr uint64, data []byte) error {
+	if err := in.useGas(copyGas(uint64(len(data)))); err != nil {
+		return err
+	}
+
+	buf, err := in.memoryRange(addr, 0, uint64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	copy(buf, data)
+
+	return nil
+}
+
+func (in *instance) setOutput(addr, length uint64) error {
+	length = uint64(uint32(length))
+
+	if err := in.useGas(copyGas(length)); err != nil {
+		return err
+	}
+
+	buf, err := in.memoryRange(addr, 0, length)
+	if err != nil {
+		return err
+	}
+
+	in.output = append([]byte{}, buf...)
+
+	return nil
+}
+
+func copyGas(size uint64) uint64 {
+	return (size + 31) / 32 * gasCopyWord
+}
+
+func bigToWord(v *big.Int) []byte {
+	word := make([]byte, types.HashLength)
+	if v != nil {
+		v.FillBytes(word)
+	}
+
+	return word
+}
@@ -0,0 +1,507 @@
+package wasm
+
+import (
+	"fmt"
+)
+
+const (
+	opUnreachable = 0x00
+	opNop         = 0x01
+	opBlock       = 0x02
+	opLoop        = 0x03
+	opIf          = 0x04
+	opElse        = 0x05
+	opEnd         = 0x0b
+	opBr          = 0x0c
+	opBrIf        = 0x0d
+	opBrTable     = 0x0e
+	opReturn      = 0x0f
+	opCall        = 0x10
+
+	opDrop   = 0x1a
+	opSelect = 0x1b
+
+	opLocalGet  = 0x20
+	opLocalSet  = 0x21
+	opLocalTee  = 0x22
+	opGlobalGet = 0x23
+	opGlobalSet = 0x24
+
+	opI32Load    = 0x28
+	opI64Load    = 0x29
+	opI32Load8S  = 0x2c
+	opI32Load8U  = 0x2d
+	opI32Load16S = 0x2e
+	opI32Load16U = 0x2f
+	opI64Load8S  = 0x30
+	opI64Load8U  = 0x31
+	opI64Load16S = 0x32
+	opI64Load16U = 0x33
+	opI64Load32S = 0x34
+	opI64Load32U = 0x35
+	opI32Store   = 0x36
+	opI64Store   = 0x37
+	opI32Store8  = 0x3a
+	opI32Store16 = 0x3b
+	opI64Store8  = 0x3c
+	opI64Store16 = 0x3d
+	opI64Store32 = 0x3e
+	opMemorySize = 0x3f
+	opMemoryGrow = 0x40
+
+	opI32Const = 0x41
+	opI64Const = 0x42
+
+	opI32Eqz = 0x45
+	opI32Eq  = 0x46
+	opI32Ne  = 0x47
+	opI32LtS = 0x48
+	opI32LtU = 0x49
+	opI32GtS = 0x4a
+	opI32GtU = 0x4b
+	opI32LeS = 0x4c
+	opI32LeU = 0x4d
+	opI32GeS = 0x4e
+	opI32GeU = 0x4f
+
+	opI64Eqz = 0x50
+	opI64Eq  = 0x51
+	opI64Ne  = 0x52
+	opI64LtS = 0x53
+	opI64LtU = 0x54
+	opI64GtS = 0x55
+	opI64GtU = 0x56
+	opI64LeS = 0x57
+	opI64LeU = 0x58
+	opI64GeS = 0x59
+	opI64GeU = 0x5a
+
+	opI32Clz    = 0x67
+	opI32Ctz    = 0x68
+	opI32Popcnt = 0x69
+	opI32Add    = 0x6a
+	opI32Sub    = 0x6b
+	opI32Mul    = 0x6c
+	opI32DivS   = 0x6d
+	opI32DivU   = 0x6e
+	opI32RemS   = 0x6f
+	opI32RemU   = 0x70
+	opI32And    = 0x71
+	opI32Or     = 0x72
+	opI32Xor    = 0x73
+	opI32Shl    = 0x74
+	opI32ShrS   = 0x75
+	opI32ShrU   = 0x76
+	opI32Rotl   = 0x77
+	opI32Rotr   = 0x78
+
+	opI64Clz    = 0x79
+	opI64Ctz    = 0x7a
+	opI64Popcnt = 0x7b
+	opI64Add    = 0x7c
+	opI64Sub    = 0x7d
+	opI64Mul    = 0x7e
+	opI64DivS   = 0x7f
+	opI64DivU   = 0x80
+	opI64RemS   = 0x81
+	opI64RemU   = 0x82
+	opI64And    = 0x83
+	opI64Or     = 0x84
+	opI64Xor    = 0x85
+	opI64Shl    = 0x86
+	opI64ShrS   = 0x87
+	opI64ShrU   = 0x88
+	opI64Rotl   = 0x89
+	opI64Rotr   = 0x8a
+
+	opI32WrapI64    = 0xa7
+	opI64ExtendI32S = 0xac
+	opI64ExtendI32U = 0xad
+
+	opI32Extend8S  = 0xc0
+	opI32Extend16S = 0xc1
+	opI64Extend8S  = 0xc2
+	opI64Extend16S = 0xc3
+	opI64Extend32S = 0xc4
+)
+
+const (
+	// blockTypeEmpty is the type of a block without results
+	blockTypeEmpty = 0x40
+)
+
+// instr is a decoded instruction, the targets of the branches are resolved
+// when the function is compiled
+type instr struct {
+	op byte
+
+	// the index, the constant, the memory offset or the branch depth,
+	// for a block the number of its results
+	a uint64
+
+	// the position of the end of a block, the default depth of a br_table
+	b uint32
+
+	// the position of the else of an if, 0 if there is none
+	c uint32
+
+	table []uint32
+}
+
+// compile decodes the body of a function, checking its indexes, the nesting of its blocks
+// and the types of the operands of its instructions
+//
+//nolint:gocyclo
+func (m *module) compile(fn *function, r *reader) ([]instr, error) {
+	var (
+		code = []instr{}
+
+		// the positions of the open blocks
+		blocks = []int{}
+
+		v = &validator{}
+
+		terminated bool
+	)
+
+	// the function body is the outermost block
+	v.pushFrame(opBlock, fn.typ.results)
+
+	for !r.eof() {
+		op, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+
+		in := instr{op: op}
+
+		switch op {
+		case opUnreachable:
+			v.setUnreachable()
+		case opNop:
+		case opBlock, opLoop, opIf:
+			typ, err := r.byte()
+			if err != nil {
+				return nil, err
+			}
+
+			var results []byte
+
+			if typ != blockTypeEmpty {
+				if typ != valueTypeI32 && typ != valueTypeI64 {
+					return nil, fmt.Errorf("%w: block type 0x%x", errUnsupportedFeature, typ)
+				}
+
+				in.a = 1
+				results = []byte{typ}
+			}
+
+			if op == opIf {
+				if _, err := v.pop(valueTypeI32); err != nil {
+					return nil, err
+				}
+			}
+
+			blocks = append(blocks, len(code))
+			v.pushFrame(op, results)
+		case opElse:
+			if len(blocks) == 0 || code[blocks[len(blocks)-1]].op != opIf || code[blocks[len(blocks)-1]].c != 0 {
+				return nil, fmt.Errorf("%w: else outside of an if", errInvalidModule)
+			}
+
+			frame, err := v.popFrame()
+			if err != nil {
+				return nil, err
+			}
+
+			v.pushFrame(opElse, frame.results)
+
+			code[blocks[len(blocks)-1]].c = uint32(len(code))
+		case opEnd:
+			frame, err := v.popFrame()
+			if err != nil {
+				return nil, err
+			}
+
+			// without else, the results of an if are missing when its condition is false
+			if frame.op == opIf && len(frame.results) > 0 {
+				return nil, fmt.Errorf("%w: type mismatch, if with results but without else", errInvalidModule)
+			}
+
+			v.push(frame.results...)
+
+			if len(blocks) > 0 {
+				start := blocks[len(blocks)-1]
+				blocks = blocks[:len(blocks)-1]
+
+				code[start].b = uint32(len(code))
+
+				if code[start].c != 0 {
+					// the else jumps over to the end
+					code[code[start].c].b = uint32(len(code))
+				}
+			} else if !r.eof() {
+				return nil, fmt.Errorf("%w: code after the end of the function", errInvalidModule)
+			} else {
+				terminated = true
+			}
+		case opBr, opBrIf:
+			if in.a, err = r.uleb(32); err != nil {
+				return nil, err
+			}
+
+			if in.a > uint64(len(blocks)) {
+				return nil, fmt.Errorf("%w: branch depth %d", errInvalidModule, in.a)
+			}
+
+			if op == opBrIf {
+				if _, err := v.pop(valueTypeI32); err != nil {
+					return nil, err
+				}
+			}
+
+			labelTypes := v.labelTypes(int(in.a))
+			if _, err := v.popN(labelTypes); err != nil {
+				return nil, err
+			}
+
+			if op == opBr {
+				v.setUnreachable()
+			} else {
+				v.push(labelTypes...)
+			}
+		case opBrTable:
+			targets, err := decodeIndexes(r)
+			if err != nil {
+				return nil, err
+			}
+
+			if in.b, err = r.u32(); err != nil {
+				return nil, err
+			}
+
+			for _, depth := range append(targets, in.b) {
+				if int(depth) > len(blocks) {
+					return nil, fmt.Errorf("%w: branch depth %d", errInvalidModule, depth)
+				}
+			}
+
+			if _, err := v.pop(valueTypeI32); err != nil {
+				return nil, err
+			}
+
+			// the targets have the arity of the default one
+			arity := len(v.labelTypes(int(in.b)))
+
+			for _, depth := range targets {
+				labelTypes := v.labelTypes(int(depth))
+				if len(labelTypes) != arity {
+					return nil, fmt.Errorf("%w: type mismatch, br_table targets of different arities", errInvalidModule)
+				}
+
+				types, err := v.popN(labelTypes)
+				if err != nil {
+					return nil, err
+				}
+
+				v.push(types...)
+			}
+
+			if _, err := v.popN(v.labelTypes(int(in.b))); err != nil {
+				return nil, err
+			}
+
+			v.setUnreachable()
+
+			in.table = targets
+		case opReturn:
+			if _, err := v.popN(fn.typ.results); err != nil {
+				return nil, err
+			}
+
+			v.setUnreachable()
+		case opCall:
+			if in.a, err = r.uleb(32); err != nil {
+				return nil, err
+			}
+
+			if in.a >= uint64(m.numFunctions()) {
+				return nil, fmt.Errorf("%w: function %d not found", errInvalidModule, in.a)
+			}
+
+			typ := m.funcType(uint32(in.a))
+			if _, err := v.popN(typ.params); err != nil {
+				return nil, err
+			}
+
+			v.push(typ.results...)
+		case opDrop:
+			if _, err := v.pop(unknownType); err != nil {
+				return nil, err
+			}
+		case opSelect:
+			if _, err := v.pop(valueTypeI32); err != nil {
+				return nil, err
+			}
+
+			first, err := v.pop(unknownType)
+			if err != nil {
+				return nil, err
+			}
+
+			second, err := v.pop(first)
+			if err != nil {
+				return nil, err
+			}
+
+			if first == unknownType {
+				first = second
+			}
+
+			v.push(first)
+		case opLocalGet, opLocalSet, opLocalTee:
+			if in.a, err = r.uleb(32); err != nil {
+				return nil, err
+			}
+
+			if in.a >= uint64(len(fn.locals)) {
+				return nil, fmt.Errorf("%w: local %d not found", errInvalidModule, in.a)
+			}
+
+			typ := fn.locals[in.a]
+
+			if op != opLocalGet {
+				if _, err := v.pop(typ); err != nil {
+					return nil, err
+				}
+			}
+
+			if op != opLocalSet {
+				v.push(typ)
+			}
+		case opGlobalGet, opGlobalSet:
+			if in.a, err = r.uleb(32); err != nil {
+				return nil, err
+			}
+
+			if in.a >= uint64(len(m.globals)) {
+				return nil, fmt.Errorf("%w: global %d not found", errInvalidModule, in.a)
+			}
+
+			typ := m.globals[in.a].typ
+
+			if op == opGlobalGet {
+				v.push(typ)
+			} else if !m.globals[in.a].mutable {
+				return nil, fmt.Errorf("%w: global %d is immutable", errInvalidModule, in.a)
+			} else if _, err := v.pop(typ); err != nil {
+				return nil, err
+			}
+		case opI32Load, opI64Load, opI32Load8S, opI32Load8U, opI32Load16S, opI32Load16U,
+			opI64Load8S, opI64Load8U, opI64Load16S, opI64Load16U, opI64Load32S, opI64Load32U,
+			opI32Store, opI64Store, opI32Store8, opI32Store16, opI64Store8, opI64Store16, opI64Store32:
+			if !m.hasMemory {
+				return nil, fmt.Errorf("%w: memory access without a memory", errInvalidModule)
+			}
+
+			// the alignment is only a hint, at most the natural one
+			align, err := r.uleb(32)
+			if err != nil {
+				return nil, err
+			}
+
+			if align >= 64 || 1<<align > accessSizes[op] {
+				return nil, fmt.Errorf("%w: alignment larger than natural", errInvalidModule)
+			}
+
+			if in.a, err = r.uleb(32); err != nil {
+				return nil, err
+			}
+
+			typ := memoryType(op)
+
+			if op >= opI32Store {
+				if _, err := v.pop(typ); err != nil {
+					return nil, err
+				}
+			}
+
+			if _, err := v.pop(valueTypeI32); err != nil {
+				return nil, err
+			}
+
+			if op < opI32Store {
+				v.push(typ)
+			}
+		case opMemorySize, opMemoryGrow:
+			if !m.hasMemory {
+				return nil, fmt.Errorf("%w: memory access without a memory", errInvalidModule)
+			}
+
+			if index, err := r.byte(); err != nil {
+				return nil, err
+			} else if index != 0 {
+				return nil, fmt.Errorf("%w: memory %d not found", errInvalidModule, index)
+			}
+
+			if op == opMemoryGrow {
+				if _, err := v.pop(valueTypeI32); err != nil {
+					return nil, err
+				}
+			}
+
+			v.push(valueTypeI32)
+		case opI32Const:
+			c, err := r.s32()
+			if err != nil {
+				return nil, err
+			}
+
+			in.a = uint64(uint32(c))
+			v.push(valueTypeI32)
+		case opI64Const:
+			c, err := r.s64()
+			if err != nil {
+				return nil, err
+			}
+
+			in.a = uint64(c)
+			v.push(valueTypeI64)
+		default:
+			if !isNumericInstruction(op) {
+				return nil, fmt.Errorf("%w: instruction 0x%x", errUnsupportedFeature, op)
+			}
+
+			params, result := numericSignature(op)
+			if _, err := v.popN(params); err != nil {
+				return nil, err
+			}
+
+			v.push(result)
+		}
+
+		code = append(code, in)
+	}
+
+	if !terminated {
+		return nil, fmt.Errorf("%w: function not terminated", errInvalidModule)
+	}
+
+	return code, nil
+}
+
+// isNumericInstruction returns true for the supported numeric instructions, without immediates
+func isNumericInstruction(op byte) bool {
+	switch {
+	case op >= opI32Eqz && op <= opI64GeU:
+		return true
+	case op >= opI32Clz && op <= opI64Rotr:
+		return true
+	case op == opI32WrapI64, op == opI64ExtendI32S, op == opI64ExtendI32U:
+		return true
+	case op >= opI32Extend8S && op <= opI64Extend32S:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,730 @@
+package wasm
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+)
+
+const (
+	pageSize = 64 * 1024
+
+	// maxPages bounds the memory of an instance to 1MB
+	maxPages = 16
+
+	maxLocals    = 1024
+	maxStack     = 16 * 1024
+	maxCallDepth = 256
+
+	gasPerInstruction = 1
+	gasPerPage        = 1024
+)
+
+var (
+	errUnreachable        = errors.New("wasm: unreachable executed")
+	errStackUnderflow     = errors.New("wasm: stack underflow")
+	errCallStackExhausted = errors.New("wasm: call stack exhausted")
+	errMemoryOutOfBounds  = errors.New("wasm: memory access out of bounds")
+	errDivisionByZero     = errors.New("wasm: integer division by zero")
+	errIntegerOverflow    = errors.New("wasm: integer overflow")
+
+	// errFinish halts the execution successfully
+	errFinish = errors.New("wasm: finish")
+)
+
+// label is the target of the branches out of a block
+type label struct {
+	// the number of values the block leaves on the stack
+	arity int
+	// the height of the stack when the block was entered
+	height int
+	// the position to continue from
+	target int
+	loop   bool
+}
+
+// instance is a module being executed by a contract call
+type instance struct {
+	module  *module
+	memory  []byte
+	globals []uint64
+
+	stack []uint64
+	base  int
+	depth int
+	gas   uint64
+
+	contract *runtime.Contract
+	host     runtime.Host
+	config   *chain.ForksInTime
+	output   []byte
+}
+
+func newInstance(
+	m *module,
+	contract *runtime.Contract,
+	host runtime.Host,
+	config *chain.ForksInTime,
+) *instance {
+	in := &instance{
+		module:   m,
+		globals:  make([]uint64, len(m.globals)),
+		stack:    make([]uint64, 0, 64),
+		gas:      contract.Gas,
+		contract: contract,
+		host:     host,
+		config:   config,
+	}
+
+	for i, g := range m.globals {
+		in.globals[i] = g.init
+	}
+
+	return in
+}
+
+// run instantiates the module and calls its entrypoint
+func (in *instance) run() error {
+	if err := in.instantiate(); err != nil {
+		return err
+	}
+
+	return in.call(in.module.entrypoint)
+}
+
+// instantiate allocates the memory of the instance and copies the data segments to it
+func (in *instance) instantiate() error {
+	if err := in.useGas(uint64(in.module.memoryPages) * gasPerPage); err != nil {
+		return err
+	}
+
+	in.memory = make([]byte, uint64(in.module.memoryPages)*pageSize)
+
+	for _, segment := range in.module.data {
+		copy(in.memory[segment.offset:], segment.data)
+	}
+
+	return nil
+}
+
+func (in *instance) useGas(gas uint64) error {
+	if in.gas < gas {
+		in.gas = 0
+
+		return runtime.ErrOutOfGas
+	}
+
+	in.gas -= gas
+
+	return nil
+}
+
+func (in *instance) push(v uint64) error {
+	if len(in.stack) >= maxStack {
+		return runtime.ErrStackOverflow
+	}
+
+	in.stack = append(in.stack, v)
+
+	return nil
+}
+
+func (in *instance) pop() (uint64, error) {
+	if len(in.stack) <= in.base {
+		return 0, errStackUnderflow
+	}
+
+	v := in.stack[len(in.stack)-1]
+	in.stack = in.stack[:len(in.stack)-1]
+
+	return v, nil
+}
+
+func (in *instance) pop2() (uint64, uint64, error) {
+	b, err := in.pop()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	a, err := in.pop()
+
+	return a, b, err
+}
+
+// popN pops the arguments of a call, in order
+func (in *instance) popN(n int) ([]uint64, error) {
+	if len(in.stack)-n < in.base {
+		return nil, errStackUnderflow
+	}
+
+	values := make([]uint64, n)
+	copy(values, in.stack[len(in.stack)-n:])
+	in.stack = in.stack[:len(in.stack)-n]
+
+	return values, nil
+}
+
+// unwind drops the values of the stack above the height, keeping the top arity ones
+func (in *instance) unwind(height, arity int) error {
+	if len(in.stack)-arity < height {
+		return errStackUnderflow
+	}
+
+	copy(in.stack[height:], in.stack[len(in.stack)-arity:])
+	in.stack = in.stack[:height+arity]
+
+	return nil
+}
+
+func (in *instance) call(index uint32) error {
+	args, err := in.popN(len(in.module.funcType(index).params))
+	if err != nil {
+		return err
+	}
+
+	if int(index) < len(in.module.imports) {
+		return in.callHost(in.module.imports[index], args)
+	}
+
+	if in.depth >= maxCallDepth {
+		return errCallStackExhausted
+	}
+
+	fn := in.module.functions[int(index)-len(in.module.imports)]
+
+	locals := make([]uint64, len(fn.locals))
+	copy(locals, args)
+
+	prevBase := in.base
+	in.base = len(in.stack)
+	in.depth++
+
+	err = in.execute(fn, locals)
+
+	in.base = prevBase
+	in.depth--
+
+	return err
+}
+
+func (in *instance) callHost(fn *hostFunc, args []uint64) error {
+	if err := in.useGas(fn.gas); err != nil {
+		return err
+	}
+
+	result, err := fn.call(in, args)
+	if err != nil {
+		return err
+	}
+
+	if len(fn.typ.results) > 0 {
+		return in.push(result)
+	}
+
+	return nil
+}
+
+// execute runs the body of the function, its results are left on the stack
+//
+//nolint:gocyclo
+func (in *instance) execute(fn *function, locals []uint64) error {
+	code := fn.code
+
+	// the function body is the outermost block
+	labels := []label{{arity: len(fn.typ.results), height: in.base, target: len(code)}}
+
+	// branch unwinds the stack to the label at the depth and returns the position to continue from
+	branch := func(depth int) (int, error) {
+		l := labels[len(labels)-1-depth]
+
+		arity := l.arity
+		if l.loop {
+			arity = 0
+		}
+
+		if err := in.unwind(l.height, arity); err != nil {
+			return 0, err
+		}
+
+		if l.loop {
+			labels = labels[:len(labels)-depth]
+		} else {
+			labels = labels[:len(labels)-1-depth]
+		}
+
+		// positions are incremented by the loop
+		return l.target - 1, nil
+	}
+
+	for pc := 0; pc < len(code) && len(labels) > 0; pc++ {
+		if err := in.useGas(gasPerInstruction); err != nil {
+			return err
+		}
+
+		ins := &code[pc]
+
+		var err error
+
+		switch ins.op {
+		case opUnreachable:
+			return errUnreachable
+		case opNop:
+		case opBlock:
+			labels = append(labels, label{arity: int(ins.a), height: len(in.stack), target: int(ins.b) + 1})
+		case opLoop:
+			labels = append(labels, label{arity: int(ins.a), height: len(in.stack), target: pc + 1, loop: true})
+		case opIf:
+			var cond uint64
+			if cond, err = in.pop(); err != nil {
+				return err
+			}
+
+			labels = append(labels, label{arity: int(ins.a), height: len(in.stack), target: int(ins.b) + 1})
+
+			if uint32(cond) == 0 {
+				if ins.c != 0 {
+					pc = int(ins.c)
+				} else {
+					pc = int(ins.b) - 1
+				}
+			}
+		case opElse:
+			// the end of the then branch, continue from the end of the if
+			pc = int(ins.b) - 1
+		case opEnd:
+			l := labels[len(labels)-1]
+			if err = in.unwind(l.height, l.arity); err != nil {
+				return err
+			}
+
+			labels = labels[:len(labels)-1]
+		case opBr:
+			pc, err = branch(int(ins.a))
+		case opBrIf:
+			var cond uint64
+			if cond, err = in.pop(); err == nil && uint32(cond) != 0 {
+				pc, err = branch(int(ins.a))
+			}
+		case opBrTable:
+			var index uint64
+			if index, err = in.pop(); err == nil {
+				depth := ins.b
+				if uint64(uint32(index)) < uint64(len(ins.table)) {
+					depth = ins.table[uint32(index)]
+				}
+
+				pc, err = branch(int(depth))
+			}
+		case opReturn:
+			pc, err = branch(len(labels) - 1)
+		case opCall:
+			err = in.call(uint32(ins.a))
+		case opDrop:
+			_, err = in.pop()
+		case opSelect:
+			var a, b, cond uint64
+			if cond, err = in.pop(); err == nil {
+				if a, b, err = in.pop2(); err == nil {
+					if uint32(cond) == 0 {
+						a = b
+					}
+
+					err = in.push(a)
+				}
+			}
+		case opLocalGet:
+			err = in.push(locals[ins.a])
+		case opLocalSet:
+			locals[ins.a], err = in.pop()
+		case opLocalTee:
+			var v uint64
+			if v, err = in.pop(); err == nil {
+				locals[ins.a] = v
+				err = in.push(v)
+			}
+		case opGlobalGet:
+			err = in.push(in.globals[ins.a])
+		case opGlobalSet:
+			in.globals[ins.a], err = in.pop()
+		case opI32Const, opI64Const:
+			err = in.push(ins.a)
+		case opMemorySize:
+			err = in.push(uint64(len(in.memory) / pageSize))
+		case opMemoryGrow:
+			err = in.memoryGrow()
+		default:
+			switch {
+			case ins.op >= opI32Load && ins.op <= opI64Load32U:
+				err = in.load(ins)
+			case ins.op >= opI32Store && ins.op <= opI64Store32:
+				err = in.store(ins)
+			default:
+				err = in.numeric(ins.op)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (in *instance) memoryGrow() error {
+	delta, err := in.pop()
+	if err != nil {
+		return err
+	}
+
+	pages := uint64(len(in.memory) / pageSize)
+
+	if pages+uint64(uint32(delta)) > uint64(in.module.maxMemoryPages) {
+		return in.push(uint64(math.MaxUint32))
+	}
+
+	if err := in.useGas(uint64(uint32(delta)) * gasPerPage); err != nil {
+		return err
+	}
+
+	in.memory = append(in.memory, make([]byte, uint64(uint32(delta))*pageSize)...)
+
+	return in.push(pages)
+}
+
+// memoryRange returns the memory at the address, checking its bounds
+func (in *instance) memoryRange(addr, offset uint64, size uint64) ([]byte, error) {
+	start := uint64(uint32(addr)) + offset
+	if start+size > uint64(len(in.memory)) {
+		return nil, errMemoryOutOfBounds
+	}
+
+	return in.memory[start : start+size], nil
+}
+
+// accessSizes are the number of bytes accessed by the memory instructions
+var accessSizes = map[byte]uint64{
+	opI32Load: 4, opI64Load: 8,
+	opI32Load8S: 1, opI32Load8U: 1, opI32Load16S: 2, opI32Load16U: 2,
+	opI64Load8S: 1, opI64Load8U: 1, opI64Load16S: 2, opI64Load16U: 2, opI64Load32S: 4, opI64Load32U: 4,
+	opI32Store: 4, opI64Store: 8,
+	opI32Store8: 1, opI32Store16: 2,
+	opI64Store8: 1, opI64Store16: 2, opI64Store32: 4,
+}
+
+func (in *instance) load(ins *instr) error {
+	addr, err := in.pop()
+	if err != nil {
+		return err
+	}
+
+	size := accessSizes[ins.op]
+
+	buf, err := in.memoryRange(addr, ins.a, size)
+	if err != nil {
+		return err
+	}
+
+	var v uint64
+
+	switch size {
+	case 1:
+		v = uint64(buf[0])
+	case 2:
+		v = uint64(binary.LittleEndian.Uint16(buf))
+	case 4:
+		v = uint64(binary.LittleEndian.Uint32(buf))
+	case 8:
+		v = binary.LittleEndian.Uint64(buf)
+	}
+
+	switch ins.op {
+	case opI32Load8S:
+		v = uint64(uint32(int32(int8(v))))
+	case opI32Load16S:
+		v = uint64(uint32(int32(int16(v))))
+	case opI64Load8S:
+		v = uint64(int64(int8(v)))
+	case opI64Load16S:
+		v = uint64(int64(int16(v)))
+	case opI64Load32S:
+		v = uint64(int64(int32(v)))
+	}
+
+	return in.push(v)
+}
+
+func (in *instance) store(ins *instr) error {
+	addr, v, err := in.pop2()
+	if err != nil {
+		return err
+	}
+
+	size := accessSizes[ins.op]
+
+	buf, err := in.memoryRange(addr, ins.a, size)
+	if err != nil {
+		return err
+	}
+
+	switch size {
+	case 1:
+		buf[0] = byte(v)
+	case 2:
+		binary.LittleEndian.PutUint16(buf, uint16(v))
+	case 4:
+		binary.LittleEndian.PutUint32(buf, uint32(v))
+	case 8:
+		binary.LittleEndian.PutUint64(buf, v)
+	}
+
+	return nil
+}
+
+func boolToUint(b bool) uint64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// numeric runs the arithmetic, comparison and conversion instructions
+//
+//nolint:gocyclo
+func (in *instance) numeric(op byte) error {
+	// the unary instructions
+	switch op {
+	case opI32Eqz, opI64Eqz, opI32Clz, opI32Ctz, opI32Popcnt, opI64Clz, opI64Ctz, opI64Popcnt,
+		opI32WrapI64, opI64ExtendI32S, opI64ExtendI32U,
+		opI32Extend8S, opI32Extend16S, opI64Extend8S, opI64Extend16S, opI64Extend32S:
+		v, err := in.pop()
+		if err != nil {
+			return err
+		}
+
+		switch op {
+		case opI32Eqz:
+			v = boolToUint(uint32(v) == 0)
+		case opI64Eqz:
+			v = boolToUint(v == 0)
+		case opI32Clz:
+			v = uint64(bits.LeadingZeros32(uint32(v)))
+		case opI32Ctz:
+			v = uint64(bits.TrailingZeros32(uint32(v)))
+		case opI32Popcnt:
+			v = uint64(bits.OnesCount32(uint32(v)))
+		case opI64Clz:
+			v = uint64(bits.LeadingZeros64(v))
+		case opI64Ctz:
+			v = uint64(bits.TrailingZeros64(v))
+		case opI64Popcnt:
+			v = uint64(bits.OnesCount64(v))
+		case opI32WrapI64:
+			v = uint64(uint32(v))
+		case opI64ExtendI32S:
+			v = uint64(int64(int32(v)))
+		case opI64ExtendI32U:
+			v = uint64(uint32(v))
+		case opI32Extend8S:
+			v = uint64(uint32(int32(int8(v))))
+		case opI32Extend16S:
+			v = uint64(uint32(int32(int16(v))))
+		case opI64Extend8S:
+			v = uint64(int64(int8(v)))
+		case opI64Extend16S:
+			v = uint64(int64(int16(v)))
+		case opI64Extend32S:
+			v = uint64(int64(int32(v)))
+		}
+
+		return in.push(v)
+	}
+
+	a, b, err := in.pop2()
+	if err != nil {
+		return err
+	}
+
+	var v uint64
+
+	if op < opI64Eqz || (op >= opI32Clz && op < opI64Clz) {
+		v, err = binaryI32(op, uint32(a), uint32(b))
+	} else {
+		v, err = binaryI64(op, a, b)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return in.push(v)
+}
+
+//nolint:gocyclo
+func binaryI32(op byte, a, b uint32) (uint64, error) {
+	var v uint32
+
+	switch op {
+	case opI32Eq:
+		return boolToUint(a == b), nil
+	case opI32Ne:
+		return boolToUint(a != b), nil
+	case opI32LtS:
+		return boolToUint(int32(a) < int32(b)), nil
+	case opI32LtU:
+		return boolToUint(a < b), nil
+	case opI32GtS:
+		return boolToUint(int32(a) > int32(b)), nil
+	case opI32GtU:
+		return boolToUint(a > b), nil
+	case opI32LeS:
+		return boolToUint(int32(a) <= int32(b)), nil
+	case opI32LeU:
+		return boolToUint(a <= b), nil
+	case opI32GeS:
+		return boolToUint(int32(a) >= int32(b)), nil
+	case opI32GeU:
+		return boolToUint(a >= b), nil
+	case opI32Add:
+		v = a + b
+	case opI32Sub:
+		v = a - b
+	case opI32Mul:
+		v = a * b
+	case opI32DivS:
+		if b == 0 {
+			return 0, errDivisionByZero
+		}
+
+		if int32(a) == math.MinInt32 && int32(b) == -1 {
+			return 0, errIntegerOverflow
+		}
+
+		v = uint32(int32(a) / int32(b))
+	case opI32DivU:
+		if b == 0 {
+			return 0, errDivisionByZero
+		}
+
+		v = a / b
+	case opI32RemS:
+		if b == 0 {
+			return 0, errDivisionByZero
+		}
+
+		if int32(b) != -1 {
+			v = uint32(int32(a) % int32(b))
+		}
+	case opI32RemU:
+		if b == 0 {
+			return 0, errDivisionByZero
+		}
+
+		v = a % b
+	case opI32And:
+		v = a & b
+	case opI32Or:
+		v = a | b
+	case opI32Xor:
+		v = a ^ b
+	case opI32Shl:
+		v = a << (b % 32)
+	case opI32ShrS:
+		v = uint32(int32(a) >> (b % 32))
+	case opI32ShrU:
+		v = a >> (b % 32)
+	case opI32Rotl:
+		v = bits.RotateLeft32(a, int(b%32))
+	case opI32Rotr:
+		v = bits.RotateLeft32(a, -int(b%32))
+	}
+
+	return uint64(v), nil
+}
+
+//nolint:gocyclo
+func binaryI64(op byte, a, b uint64) (uint64, error) {
+	switch op {
+	case opI64Eq:
+		return boolToUint(a == b), nil
+	case opI64Ne:
+		return boolToUint(a != b), nil
+	case opI64LtS:
+		return boolToUint(int64(a) < int64(b)), nil
+	case opI64LtU:
+		return boolToUint(a < b), nil
+	case opI64GtS:
+		return boolToUint(int64(a) > int64(b)), nil
+	case opI64GtU:
+		return boolToUint(a > b), nil
+	case opI64LeS:
+		return boolToUint(int64(a) <= int64(b)), nil
+	case opI64LeU:
+		return boolToUint(a <= b), nil
+	case opI64GeS:
+		return boolToUint(int64(a) >= int64(b)), nil
+	case opI64GeU:
+		return boolToUint(a >= b), nil
+	case opI64Add:
+		return a + b, nil
+	case opI64Sub:
+		return a - b, nil
+	case opI64Mul:
+		return a * b, nil
+	case opI64DivS:
+		if b == 0 {
+			return 0, errDivisionByZero
+		}
+
+		if int64(a) == math.MinInt64 && int64(b) == -1 {
+			return 0, errIntegerOverflow
+		}
+
+		return uint64(int64(a) / int64(b)), nil
+	case opI64DivU:
+		if b == 0 {
+			return 0, errDivisionByZero
+		}
+
+		return a / b, nil
+	case opI64RemS:
+		if b == 0 {
+			return 0, errDivisionByZero
+		}
+
+		if int64(b) == -1 {
+			return 0, nil
+		}
+
+		return uint64(int64(a) % int64(b)), nil
+	case opI64RemU:
+		if b == 0 {
+			return 0, errDivisionByZero
+		}
+
+		return a % b, nil
+	case opI64And:
+		return a & b, nil
+	case opI64Or:
+		return a | b, nil
+	case opI64Xor:
+		return a ^ b, nil
+	case opI64Shl:
+		return a << (b % 64), nil
+	case opI64ShrS:
+		return uint64(int64(a) >> (b % 64)), nil
+	case opI64ShrU:
+		return a >> (b % 64), nil
+	case opI64Rotl:
+		return bits.RotateLeft64(a, int(b%64)), nil
+	case opI64Rotr:
+		return bits.RotateLeft64(a, -int(b%64)), nil
+	}
+
+	return 0, nil
+}
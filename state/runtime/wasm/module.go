@@ -0,0 +1,793 @@
+package wasm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+var (
+	// magic is the preamble of the WASM binary format, followed by the version
+	magic   = []byte{0x00, 0x61, 0x73, 0x6d}
+	version = []byte{0x01, 0x00, 0x00, 0x00}
+)
+
+const (
+	sectionCustom    = 0
+	sectionType      = 1
+	sectionImport    = 2
+	sectionFunction  = 3
+	sectionTable     = 4
+	sectionMemory    = 5
+	sectionGlobal    = 6
+	sectionExport    = 7
+	sectionStart     = 8
+	sectionElement   = 9
+	sectionCode      = 10
+	sectionData      = 11
+	sectionDataCount = 12
+)
+
+const (
+	valueTypeI32 = 0x7f
+	valueTypeI64 = 0x7e
+
+	typeFunc = 0x60
+
+	externalFunc   = 0x00
+	externalTable  = 0x01
+	externalMemory = 0x02
+	externalGlobal = 0x03
+
+	// specMaxPages is the number of pages of the largest memory of the specification
+	specMaxPages = 65536
+
+	// entrypoint is the exported function run on every call of the contract
+	entrypoint = "main"
+)
+
+var (
+	errInvalidModule      = errors.New("invalid wasm module")
+	errUnsupportedFeature = errors.New("unsupported wasm feature")
+	errNoEntrypoint       = fmt.Errorf("wasm module does not export a %q function", entrypoint)
+)
+
+// IsModule returns true if the code is a WASM binary
+func IsModule(code []byte) bool {
+	return bytes.HasPrefix(code, magic)
+}
+
+// funcType is the signature of a function, only the integer types are supported
+type funcType struct {
+	params  []byte
+	results []byte
+}
+
+func (f *funcType) equals(other *funcType) bool {
+	return bytes.Equal(f.params, other.params) && bytes.Equal(f.results, other.results)
+}
+
+// function is a function defined by the module
+type function struct {
+	typ    *funcType
+	locals []byte
+	code   []instr
+}
+
+type global struct {
+	typ     byte
+	mutable bool
+	init    uint64
+}
+
+type dataSegment struct {
+	offset uint32
+	data   []byte
+}
+
+// export is a definition exported by the module
+type export struct {
+	kind  byte
+	index uint32
+}
+
+// importResolver returns the host function imported by the module, the contracts only import
+// the functions of the host module
+type importResolver func(moduleName, name string) (*hostFunc, error)
+
+// module is a decoded WASM binary, it is immutable and shared by its instances
+type module struct {
+	types []*funcType
+
+	// the functions are indexed after the imported ones
+	imports   []*hostFunc
+	functions []*function
+
+	hasMemory   bool
+	memoryPages uint32
+	// the memory can't grow beyond its declared maximum, nor beyond maxPages
+	maxMemoryPages uint32
+
+	globals []*global
+	data    []*dataSegment
+	exports map[string]*export
+
+	entrypoint uint32
+}
+
+// funcType returns the signature of the function at the index
+func (m *module) funcType(index uint32) *funcType {
+	if int(index) < len(m.imports) {
+		return m.imports[index].typ
+	}
+
+	return m.functions[int(index)-len(m.imports)].typ
+}
+
+func (m *module) numFunctions() int {
+	return len(m.imports) + len(m.functions)
+}
+
+// resolveHostFunc resolves the imports of the contracts to the functions of the host module
+func resolveHostFunc(moduleName, name string) (*hostFunc, error) {
+	fn, ok := hostFuncs[name]
+	if moduleName != hostModule || !ok {
+		return nil, fmt.Errorf("%w: unknown import %s.%s", errInvalidModule, moduleName, name)
+	}
+
+	return fn, nil
+}
+
+// decodeModule decodes and validates the WASM binary of a contract, which must export its entrypoint
+func decodeModule(code []byte) (*module, error) {
+	m, err := decodeBinary(code, resolveHostFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	main, ok := m.exports[entrypoint]
+	if !ok || main.kind != externalFunc {
+		return nil, errNoEntrypoint
+	}
+
+	m.entrypoint = main.index
+
+	if typ := m.funcType(m.entrypoint); len(typ.params) != 0 || len(typ.results) != 0 {
+		return nil, fmt.Errorf("%w: %q must not have parameters or results", errInvalidModule, entrypoint)
+	}
+
+	return m, nil
+}
+
+// decodeBinary decodes and validates the WASM binary, resolving its imports
+//
+//nolint:gocyclo
+func decodeBinary(code []byte, resolve importResolver) (*module, error) {
+	if !IsModule(code) || len(code) < 8 || !bytes.Equal(code[4:8], version) {
+		return nil, fmt.Errorf("%w: bad preamble", errInvalidModule)
+	}
+
+	var (
+		m      = &module{exports: map[string]*export{}}
+		r      = &reader{buf: code[8:]}
+		funcs  []uint32
+		bodies [][]byte
+		lastID byte
+	)
+
+	for !r.eof() {
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := r.vec()
+		if err != nil {
+			return nil, err
+		}
+
+		if id != sectionCustom {
+			if id <= lastID && id != sectionDataCount {
+				return nil, fmt.Errorf("%w: section %d out of order", errInvalidModule, id)
+			}
+
+			lastID = id
+		}
+
+		s := &reader{buf: payload}
+
+		switch id {
+		case sectionCustom:
+			// the content of a custom section is ignored, after its name
+			if _, err = s.name(); err != nil {
+				return nil, err
+			}
+
+			continue
+		case sectionDataCount:
+			continue
+		case sectionType:
+			err = m.decodeTypes(s)
+		case sectionImport:
+			err = m.decodeImports(s, resolve)
+		case sectionFunction:
+			funcs, err = decodeIndexes(s)
+		case sectionMemory:
+			err = m.decodeMemory(s)
+		case sectionGlobal:
+			err = m.decodeGlobals(s)
+		case sectionExport:
+			err = m.decodeExports(s, len(m.imports)+len(funcs))
+		case sectionCode:
+			bodies, err = decodeBodies(s)
+		case sectionData:
+			err = m.decodeData(s)
+		case sectionTable, sectionStart, sectionElement:
+			err = fmt.Errorf("%w: section %d", errUnsupportedFeature, id)
+		default:
+			err = fmt.Errorf("%w: unknown section %d", errInvalidModule, id)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !s.eof() {
+			return nil, fmt.Errorf("%w: trailing bytes in section %d", errInvalidModule, id)
+		}
+	}
+
+	if len(funcs) != len(bodies) {
+		return nil, fmt.Errorf("%w: %d functions but %d bodies", errInvalidModule, len(funcs), len(bodies))
+	}
+
+	for _, typeIndex := range funcs {
+		if int(typeIndex) >= len(m.types) {
+			return nil, fmt.Errorf("%w: type %d not found", errInvalidModule, typeIndex)
+		}
+
+		m.functions = append(m.functions, &function{typ: m.types[typeIndex]})
+	}
+
+	// the bodies are compiled once all the signatures are known
+	for i, body := range bodies {
+		if err := m.decodeBody(m.functions[i], body); err != nil {
+			return nil, fmt.Errorf("function %d: %w", len(m.imports)+i, err)
+		}
+	}
+
+	for _, segment := range m.data {
+		if !m.hasMemory || uint64(segment.offset)+uint64(len(segment.data)) > uint64(m.memoryPages)*pageSize {
+			return nil, fmt.Errorf("%w: data segment out of memory", errInvalidModule)
+		}
+	}
+
+	return m, nil
+}
+
+func decodeValueType(r *reader) (byte, error) {
+	typ, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+
+	if typ != valueTypeI32 && typ != valueTypeI64 {
+		return 0, fmt.Errorf("%w: value type 0x%x", errUnsupportedFeature, typ)
+	}
+
+	return typ, nil
+}
+
+func decodeValueTypes(r *reader) ([]byte, error) {
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]byte, 0, n)
+
+	for i := uint32(0); i < n; i++ {
+		typ, err := decodeValueType(r)
+		if err != nil {
+			return nil, err
+		}
+
+		types = append(types, typ)
+	}
+
+	return types, nil
+}
+
+func (m *module) decodeTypes(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < n; i++ {
+		if form, err := r.byte(); err != nil {
+			return err
+		} else if form != typeFunc {
+			return fmt.Errorf("%w: type form 0x%x", errInvalidModule, form)
+		}
+
+		typ := &funcType{}
+
+		if typ.params, err = decodeValueTypes(r); err != nil {
+			return err
+		}
+
+		if typ.results, err = decodeValueTypes(r); err != nil {
+			return err
+		}
+
+		if len(typ.results) > 1 {
+			return fmt.Errorf("%w: multiple results", errUnsupportedFeature)
+		}
+
+		m.types = append(m.types, typ)
+	}
+
+	return nil
+}
+
+func (m *module) decodeImports(r *reader, resolve importResolver) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < n; i++ {
+		moduleName, err := r.name()
+		if err != nil {
+			return err
+		}
+
+		name, err := r.name()
+		if err != nil {
+			return err
+		}
+
+		kind, err := r.byte()
+		if err != nil {
+			return err
+		}
+
+		if kind != externalFunc {
+			return fmt.Errorf("%w: only functions can be imported", errUnsupportedFeature)
+		}
+
+		typeIndex, err := r.u32()
+		if err != nil {
+			return err
+		}
+
+		if int(typeIndex) >= len(m.types) {
+			return fmt.Errorf("%w: type %d not found", errInvalidModule, typeIndex)
+		}
+
+		fn, err := resolve(moduleName, name)
+		if err != nil {
+			return err
+		}
+
+		if !fn.typ.equals(m.types[typeIndex]) {
+			return fmt.Errorf("%w: import %s.%s has a wrong signature", errInvalidModule, moduleName, name)
+		}
+
+		m.imports = append(m.imports, fn)
+	}
+
+	return nil
+}
+
+func decodeIndexes(r *reader) ([]uint32, error) {
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]uint32, 0, n)
+
+	for i := uint32(0); i < n; i++ {
+		index, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+func (m *module) decodeMemory(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+
+	if n != 1 {
+		return fmt.Errorf("%w: %d memories", errUnsupportedFeature, n)
+	}
+
+	flags, err := r.byte()
+	if err != nil {
+		return err
+	}
+
+	if m.memoryPages, err = r.u32(); err != nil {
+		return err
+	}
+
+	// the memory is bounded by maxPages below its maximum
+	m.maxMemoryPages = maxPages
+
+	if flags == 1 {
+		max, err := r.u32()
+		if err != nil {
+			return err
+		}
+
+		if max > specMaxPages || max < m.memoryPages {
+			return fmt.Errorf("%w: memory maximum %d", errInvalidModule, max)
+		}
+
+		if max < m.maxMemoryPages {
+			m.maxMemoryPages = max
+		}
+	} else if flags != 0 {
+		return fmt.Errorf("%w: memory flags 0x%x", errUnsupportedFeature, flags)
+	}
+
+	if m.memoryPages > specMaxPages {
+		return fmt.Errorf("%w: memory minimum %d", errInvalidModule, m.memoryPages)
+	}
+
+	if m.memoryPages > maxPages {
+		return fmt.Errorf("%w: more than %d memory pages", errUnsupportedFeature, maxPages)
+	}
+
+	m.hasMemory = true
+
+	return nil
+}
+
+func (m *module) decodeGlobals(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < n; i++ {
+		g := &global{}
+
+		if g.typ, err = decodeValueType(r); err != nil {
+			return err
+		}
+
+		mutable, err := r.byte()
+		if err != nil {
+			return err
+		}
+
+		if mutable > 1 {
+			return fmt.Errorf("%w: global mutability 0x%x", errInvalidModule, mutable)
+		}
+
+		g.mutable = mutable == 1
+
+		if g.init, err = decodeConstExpr(r, g.typ); err != nil {
+			return err
+		}
+
+		m.globals = append(m.globals, g)
+	}
+
+	return nil
+}
+
+// decodeConstExpr decodes an initializer, only the constants are supported
+func decodeConstExpr(r *reader, typ byte) (uint64, error) {
+	op, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+
+	var value uint64
+
+	switch {
+	case op == opI32Const && typ == valueTypeI32:
+		v, err := r.s32()
+		if err != nil {
+			return 0, err
+		}
+
+		value = uint64(uint32(v))
+	case op == opI64Const && typ == valueTypeI64:
+		v, err := r.s64()
+		if err != nil {
+			return 0, err
+		}
+
+		value = uint64(v)
+	default:
+		return 0, fmt.Errorf("%w: initializer 0x%x", errUnsupportedFeature, op)
+	}
+
+	if end, err := r.byte(); err != nil {
+		return 0, err
+	} else if end != opEnd {
+		return 0, fmt.Errorf("%w: initializer not terminated", errInvalidModule)
+	}
+
+	return value, nil
+}
+
+func (m *module) decodeExports(r *reader, numFuncs int) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < n; i++ {
+		name, err := r.name()
+		if err != nil {
+			return err
+		}
+
+		e := &export{}
+
+		if e.kind, err = r.byte(); err != nil {
+			return err
+		}
+
+		if e.index, err = r.u32(); err != nil {
+			return err
+		}
+
+		var found bool
+
+		switch e.kind {
+		case externalFunc:
+			found = int(e.index) < numFuncs
+		case externalTable:
+		case externalMemory:
+			found = m.hasMemory && e.index == 0
+		case externalGlobal:
+			found = int(e.index) < len(m.globals)
+		default:
+			return fmt.Errorf("%w: export kind 0x%x", errInvalidModule, e.kind)
+		}
+
+		if !found {
+			return fmt.Errorf("%w: export %q of kind %d not found", errInvalidModule, name, e.kind)
+		}
+
+		if _, ok := m.exports[name]; ok {
+			return fmt.Errorf("%w: duplicate export %q", errInvalidModule, name)
+		}
+
+		m.exports[name] = e
+	}
+
+	return nil
+}
+
+func decodeBodies(r *reader) ([][]byte, error) {
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+
+	bodies := make([][]byte, 0, n)
+
+	for i := uint32(0); i < n; i++ {
+		body, err := r.vec()
+		if err != nil {
+			return nil, err
+		}
+
+		bodies = append(bodies, body)
+	}
+
+	return bodies, nil
+}
+
+func (m *module) decodeBody(fn *function, body []byte) error {
+	r := &reader{buf: body}
+
+	groups, err := r.u32()
+	if err != nil {
+		return err
+	}
+
+	fn.locals = append(fn.locals, fn.typ.params...)
+
+	for i := uint32(0); i < groups; i++ {
+		count, err := r.u32()
+		if err != nil {
+			return err
+		}
+
+		typ, err := decodeValueType(r)
+		if err != nil {
+			return err
+		}
+
+		if uint64(len(fn.locals))+uint64(count) > maxLocals {
+			return fmt.Errorf("%w: more than %d locals", errInvalidModule, maxLocals)
+		}
+
+		for j := uint32(0); j < count; j++ {
+			fn.locals = append(fn.locals, typ)
+		}
+	}
+
+	fn.code, err = m.compile(fn, r)
+
+	return err
+}
+
+func (m *module) decodeData(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < n; i++ {
+		if mode, err := r.u32(); err != nil {
+			return err
+		} else if mode != 0 {
+			return fmt.Errorf("%w: data segment mode %d", errUnsupportedFeature, mode)
+		}
+
+		offset, err := decodeConstExpr(r, valueTypeI32)
+		if err != nil {
+			return err
+		}
+
+		data, err := r.vec()
+		if err != nil {
+			return err
+		}
+
+		m.data = append(m.data, &dataSegment{offset: uint32(offset), data: data})
+	}
+
+	return nil
+}
+
+// reader decodes the primitives of the binary format
+type reader struct {
+	buf []byte
+	pos int
+}
+
+func (r *reader) eof() bool {
+	return r.pos >= len(r.buf)
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.eof() {
+		return 0, fmt.Errorf("%w: unexpected end", errInvalidModule)
+	}
+
+	b := r.buf[r.pos]
+	r.pos++
+
+	return b, nil
+}
+
+func (r *reader) bytes(n uint32) ([]byte, error) {
+	if uint64(len(r.buf)-r.pos) < uint64(n) {
+		return nil, fmt.Errorf("%w: unexpected end", errInvalidModule)
+	}
+
+	b := r.buf[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+
+	return b, nil
+}
+
+func (r *reader) vec() ([]byte, error) {
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.bytes(n)
+}
+
+func (r *reader) name() (string, error) {
+	b, err := r.vec()
+	if err != nil {
+		return "", err
+	}
+
+	if !utf8.Valid(b) {
+		return "", fmt.Errorf("%w: invalid UTF-8 name", errInvalidModule)
+	}
+
+	return string(b), nil
+}
+
+// uleb decodes an unsigned LEB128 of at most the given bits
+func (r *reader) uleb(bits uint) (uint64, error) {
+	var (
+		result uint64
+		shift  uint
+	)
+
+	for {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+
+		if shift >= bits || (bits-shift < 7 && b&0x7f>>(bits-shift) != 0) {
+			return 0, fmt.Errorf("%w: integer too large", errInvalidModule)
+		}
+
+		result |= uint64(b&0x7f) << shift
+		shift += 7
+
+		if b&0x80 == 0 {
+			return result, nil
+		}
+	}
+}
+
+// sleb decodes a signed LEB128 of at most the given bits
+func (r *reader) sleb(bits uint) (int64, error) {
+	var (
+		result int64
+		shift  uint
+		b      byte
+		err    error
+	)
+
+	for {
+		if b, err = r.byte(); err != nil {
+			return 0, err
+		}
+
+		if shift >= bits {
+			return 0, fmt.Errorf("%w: integer too large", errInvalidModule)
+		}
+
+		result |= int64(b&0x7f) << shift
+		shift += 7
+
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	if shift < 64 && b&0x40 != 0 {
+		result |= -1 << shift
+	}
+
+	if bits < 64 && (result < -(1<<(bits-1)) || result >= 1<<(bits-1)) {
+		return 0, fmt.Errorf("%w: integer too large", errInvalidModule)
+	}
+
+	return result, nil
+}
+
+func (r *reader) u32() (uint32, error) {
+	v, err := r.uleb(32)
+
+	return uint32(v), err
+}
+
+func (r *reader) s32() (int32, error) {
+	v, err := r.sleb(32)
+
+	return int32(v), err
+}
+
+func (r *reader) s64() (int64, error) {
+	return r.sleb(64)
+}
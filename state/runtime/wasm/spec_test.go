@@ -0,0 +1,339 @@
+package wasm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+)
+
+// specTestsDir holds the core tests of the WebAssembly specification converted by wast2json,
+// downloaded by make download-wasm-spec-tests
+const specTestsDir = "testdata/spec"
+
+// specGas is the gas of each invocation, the tests aren't bounded by the gas
+const specGas = 1 << 40
+
+var errSpecUnsupported = errors.New("unsupported by the runtime")
+
+// the commands of the wast2json output
+type specValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type specAction struct {
+	Type   string      `json:"type"`
+	Module string      `json:"module"`
+	Field  string      `json:"field"`
+	Args   []specValue `json:"args"`
+}
+
+type specCommand struct {
+	Type       string      `json:"type"`
+	Line       int         `json:"line"`
+	Name       string      `json:"name"`
+	Filename   string      `json:"filename"`
+	ModuleType string      `json:"module_type"`
+	Action     *specAction `json:"action"`
+	Expected   []specValue `json:"expected"`
+}
+
+type specScript struct {
+	Commands []*specCommand `json:"commands"`
+}
+
+func printNothing(*instance, []uint64) (uint64, error) {
+	return 0, nil
+}
+
+// spectestFuncs are the functions of the spectest module imported by the tests, they print nothing
+var spectestFuncs = map[string]*hostFunc{
+	"print":     {typ: signature(nil), call: printNothing},
+	"print_i32": {typ: signature([]byte{valueTypeI32}), call: printNothing},
+	"print_i64": {typ: signature([]byte{valueTypeI64}), call: printNothing},
+}
+
+// resolveSpectest resolves the imports of the tests, the modules registered by the tests
+// can't be linked and the imports of floats, tables and globals are unsupported
+func resolveSpectest(moduleName, name string) (*hostFunc, error) {
+	fn, ok := spectestFuncs[name]
+	if moduleName != "spectest" || !ok {
+		return nil, fmt.Errorf("%w: import %s.%s", errUnsupportedFeature, moduleName, name)
+	}
+
+	return fn, nil
+}
+
+// specRunner runs the commands of a script on the instances of its modules
+type specRunner struct {
+	dir string
+
+	// the last module and the named ones, nil if they are unsupported
+	current *instance
+	named   map[string]*instance
+
+	passed, skipped int
+}
+
+func (s *specRunner) read(filename string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.dir, filename))
+}
+
+// instantiate decodes and instantiates the module
+func instantiate(code []byte) (*instance, error) {
+	m, err := decodeBinary(code, resolveSpectest)
+	if err != nil {
+		return nil, err
+	}
+
+	in := newInstance(m, &runtime.Contract{Gas: specGas}, nil, nil)
+
+	return in, in.instantiate()
+}
+
+// instance returns the instance the action is run on, nil if its module is unsupported
+func (s *specRunner) instance(action *specAction) (*instance, error) {
+	if action.Module == "" {
+		return s.current, nil
+	}
+
+	in, ok := s.named[action.Module]
+	if !ok {
+		return nil, fmt.Errorf("module %s not found", action.Module)
+	}
+
+	return in, nil
+}
+
+// run runs the action, errSpecUnsupported if its module or its values are unsupported
+func (s *specRunner) run(action *specAction) ([]uint64, error) {
+	in, err := s.instance(action)
+	if err != nil {
+		return nil, err
+	}
+
+	if in == nil {
+		return nil, errSpecUnsupported
+	}
+
+	e, ok := in.module.exports[action.Field]
+	if !ok {
+		return nil, fmt.Errorf("export %q not found", action.Field)
+	}
+
+	switch {
+	case action.Type == "get" && e.kind == externalGlobal:
+		return []uint64{in.globals[e.index]}, nil
+	case action.Type == "invoke" && e.kind == externalFunc:
+	default:
+		return nil, fmt.Errorf("can't %s the export %q", action.Type, action.Field)
+	}
+
+	in.stack = in.stack[:0]
+	in.base = 0
+	in.depth = 0
+	in.gas = specGas
+
+	for _, arg := range action.Args {
+		v, err := parseSpecValue(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := in.push(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := in.call(e.index); err != nil {
+		// the validated code can't underflow the stack, it isn't a trap
+		if errors.Is(err, errStackUnderflow) {
+			return nil, err
+		}
+
+		return nil, &specTrap{err}
+	}
+
+	return in.popN(len(in.module.funcType(e.index).results))
+}
+
+// specTrap is a trap of an invocation
+type specTrap struct {
+	err error
+}
+
+func (t *specTrap) Error() string {
+	return fmt.Sprintf("trap: %v", t.err)
+}
+
+// parseSpecValue parses an integer value, errSpecUnsupported for the other types
+func parseSpecValue(value specValue) (uint64, error) {
+	bits := 32
+
+	switch value.Type {
+	case "i32":
+	case "i64":
+		bits = 64
+	default:
+		return 0, errSpecUnsupported
+	}
+
+	return strconv.ParseUint(value.Value, 10, bits)
+}
+
+// runCommand runs the command of the script, errSpecUnsupported if it is skipped
+func (s *specRunner) runCommand(cmd *specCommand) error {
+	switch cmd.Type {
+	case "module":
+		code, err := s.read(cmd.Filename)
+		if err != nil {
+			return err
+		}
+
+		in, err := instantiate(code)
+		if err != nil && !errors.Is(err, errUnsupportedFeature) {
+			return err
+		}
+
+		s.current = in
+
+		if cmd.Name != "" {
+			s.named[cmd.Name] = in
+		}
+
+		if in == nil {
+			return errSpecUnsupported
+		}
+	case "action", "assert_return":
+		for _, expected := range cmd.Expected {
+			if _, err := parseSpecValue(expected); err != nil {
+				return err
+			}
+		}
+
+		results, err := s.run(cmd.Action)
+		if err != nil || cmd.Type == "action" {
+			return err
+		}
+
+		if len(results) != len(cmd.Expected) {
+			return fmt.Errorf("expected %d results but got %d", len(cmd.Expected), len(results))
+		}
+
+		for i, expected := range cmd.Expected {
+			v, _ := parseSpecValue(expected)
+			if expected.Type == "i32" {
+				results[i] = uint64(uint32(results[i]))
+			}
+
+			if results[i] != v {
+				return fmt.Errorf("expected %d but got %d", v, results[i])
+			}
+		}
+	case "assert_trap", "assert_exhaustion":
+		if cmd.Action == nil {
+			// the instantiation of the module traps
+			return s.reject(cmd.Filename)
+		}
+
+		for _, arg := range cmd.Action.Args {
+			if _, err := parseSpecValue(arg); err != nil {
+				return err
+			}
+		}
+
+		_, err := s.run(cmd.Action)
+
+		var trap *specTrap
+		if !errors.As(err, &trap) {
+			if err == nil {
+				err = errors.New("expected a trap")
+			}
+
+			return err
+		}
+	case "assert_invalid", "assert_malformed", "assert_unlinkable", "assert_uninstantiable":
+		// the malformed modules of the text format aren't binaries
+		if cmd.ModuleType == "text" {
+			return errSpecUnsupported
+		}
+
+		return s.reject(cmd.Filename)
+	default:
+		// the registered modules can't be linked
+		return errSpecUnsupported
+	}
+
+	return nil
+}
+
+// reject checks that the module of the file fails to be decoded or instantiated
+func (s *specRunner) reject(filename string) error {
+	code, err := s.read(filename)
+	if err != nil {
+		return err
+	}
+
+	if _, err := instantiate(code); err == nil {
+		return errors.New("expected the module to be rejected")
+	}
+
+	return nil
+}
+
+// TestSpec runs the core tests of the specification, the commands of the modules using
+// the unsupported features are skipped
+func TestSpec(t *testing.T) {
+	t.Parallel()
+
+	scripts, err := filepath.Glob(filepath.Join(specTestsDir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(scripts) == 0 {
+		t.Skip("the spec tests are missing, run make download-wasm-spec-tests")
+	}
+
+	for _, script := range scripts {
+		script := script
+
+		t.Run(strings.TrimSuffix(filepath.Base(script), ".json"), func(t *testing.T) {
+			t.Parallel()
+
+			data, err := os.ReadFile(script)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var spec specScript
+			if err := json.Unmarshal(data, &spec); err != nil {
+				t.Fatal(err)
+			}
+
+			s := &specRunner{dir: filepath.Dir(script), named: map[string]*instance{}}
+
+			for _, cmd := range spec.Commands {
+				err := s.runCommand(cmd)
+
+				switch {
+				case errors.Is(err, errSpecUnsupported):
+					s.skipped++
+				case err != nil:
+					t.Errorf("line %d, %s: %v", cmd.Line, cmd.Type, err)
+				default:
+					s.passed++
+				}
+			}
+
+			t.Logf("%d commands passed, %d skipped", s.passed, s.skipped)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package wasm
+
+import (
+	"fmt"
+)
+
+// unknownType is the type of the operands popped from an unreachable code, it matches any type
+const unknownType = 0
+
+// ctrlFrame is a block being validated
+type ctrlFrame struct {
+	op      byte
+	results []byte
+
+	// the height of the operand stack when the block was entered
+	height int
+
+	// the rest of the block is unreachable, after a branch, a return or an unreachable
+	unreachable bool
+}
+
+// validator checks the types of the operands of the instructions of a function body,
+// following the validation algorithm of the specification
+type validator struct {
+	operands []byte
+	frames   []ctrlFrame
+}
+
+func errTypeMismatch(expected, actual byte) error {
+	return fmt.Errorf("%w: type mismatch, expected 0x%x but found 0x%x", errInvalidModule, expected, actual)
+}
+
+func (v *validator) push(types ...byte) {
+	v.operands = append(v.operands, types...)
+}
+
+// pop pops an operand of the expected type, any type if unknownType, and returns its type
+func (v *validator) pop(expected byte) (byte, error) {
+	frame := &v.frames[len(v.frames)-1]
+
+	if len(v.operands) == frame.height {
+		if frame.unreachable {
+			return unknownType, nil
+		}
+
+		return 0, fmt.Errorf("%w: type mismatch, missing operand", errInvalidModule)
+	}
+
+	actual := v.operands[len(v.operands)-1]
+	v.operands = v.operands[:len(v.operands)-1]
+
+	if actual != expected && actual != unknownType && expected != unknownType {
+		return 0, errTypeMismatch(expected, actual)
+	}
+
+	return actual, nil
+}
+
+// popN pops the operands of the expected types and returns their types, in order
+func (v *validator) popN(expected []byte) ([]byte, error) {
+	actual := make([]byte, len(expected))
+
+	for i := len(expected) - 1; i >= 0; i-- {
+		typ, err := v.pop(expected[i])
+		if err != nil {
+			return nil, err
+		}
+
+		actual[i] = typ
+	}
+
+	return actual, nil
+}
+
+func (v *validator) pushFrame(op byte, results []byte) {
+	v.frames = append(v.frames, ctrlFrame{op: op, results: results, height: len(v.operands)})
+}
+
+// popFrame ends the innermost block, its results must be the only operands left by the block
+func (v *validator) popFrame() (ctrlFrame, error) {
+	frame := v.frames[len(v.frames)-1]
+
+	if _, err := v.popN(frame.results); err != nil {
+		return frame, err
+	}
+
+	if len(v.operands) != frame.height {
+		return frame, fmt.Errorf("%w: type mismatch, operands left at the end of a block", errInvalidModule)
+	}
+
+	v.frames = v.frames[:len(v.frames)-1]
+
+	return frame, nil
+}
+
+// labelTypes returns the types of the operands of a branch to the block at the depth,
+// a loop is branched to its start, without operands
+func (v *validator) labelTypes(depth int) []byte {
+	frame := v.frames[len(v.frames)-1-depth]
+	if frame.op == opLoop {
+		return nil
+	}
+
+	return frame.results
+}
+
+// setUnreachable drops the operands of the innermost block, the rest of it isn't reached
+func (v *validator) setUnreachable() {
+	frame := &v.frames[len(v.frames)-1]
+
+	v.operands = v.operands[:frame.height]
+	frame.unreachable = true
+}
+
+// numericSignature returns the operand and the result types of the numeric instructions
+func numericSignature(op byte) ([]byte, byte) {
+	const (
+		i32 = valueTypeI32
+		i64 = valueTypeI64
+	)
+
+	var (
+		i32s = []byte{i32, i32}
+		i64s = []byte{i64, i64}
+	)
+
+	switch {
+	case op == opI32Eqz:
+		return []byte{i32}, i32
+	case op >= opI32Eq && op <= opI32GeU:
+		return i32s, i32
+	case op == opI64Eqz:
+		return []byte{i64}, i32
+	case op >= opI64Eq && op <= opI64GeU:
+		return i64s, i32
+	case op >= opI32Clz && op <= opI32Popcnt:
+		return []byte{i32}, i32
+	case op >= opI32Add && op <= opI32Rotr:
+		return i32s, i32
+	case op >= opI64Clz && op <= opI64Popcnt:
+		return []byte{i64}, i64
+	case op >= opI64Add && op <= opI64Rotr:
+		return i64s, i64
+	case op == opI32WrapI64:
+		return []byte{i64}, i32
+	case op == opI64ExtendI32S, op == opI64ExtendI32U:
+		return []byte{i32}, i64
+	case op == opI32Extend8S, op == opI32Extend16S:
+		return []byte{i32}, i32
+	}
+
+	return []byte{i64}, i64
+}
+
+// memoryType returns the type of the value loaded or stored by the memory instruction
+func memoryType(op byte) byte {
+	switch op {
+	case opI32Load, opI32Load8S, opI32Load8U, opI32Load16S, opI32Load16U, opI32Store, opI32Store8, opI32Store16:
+		return valueTypeI32
+	}
+
+	return valueTypeI64
+}
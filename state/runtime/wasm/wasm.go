@@ -0,0 +1,113 @@
+package wasm
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+var _ runtime.Runtime = &WASM{}
+
+// modulesCacheSize is the number of decoded modules kept in memory
+const modulesCacheSize = 32
+
+// WASM is an experimental runtime for the contracts compiled to WebAssembly.
+// Only the contracts at the addresses registered in the genesis run on it,
+// the modules must export a "main" function and interact with the state
+// through the functions of the "env" module. Floats, tables and calls
+// to other contracts are not supported
+type WASM struct {
+	contracts map[types.Address]struct{}
+
+	// the decoded modules by the hash of their code
+	modules *lru.Cache
+}
+
+// NewWASM creates a new runtime for the WASM contracts at the addresses
+func NewWASM(contracts []types.Address) *WASM {
+	modules, _ := lru.New(modulesCacheSize)
+
+	w := &WASM{
+		contracts: make(map[types.Address]struct{}, len(contracts)),
+		modules:   modules,
+	}
+
+	for _, addr := range contracts {
+		w.contracts[addr] = struct{}{}
+	}
+
+	return w
+}
+
+// CanRun implements the runtime interface
+func (w *WASM) CanRun(c *runtime.Contract, _ runtime.Host, _ *chain.ForksInTime) bool {
+	if _, ok := w.contracts[c.CodeAddress]; !ok {
+		return false
+	}
+
+	return IsModule(c.Code)
+}
+
+// Name implements the runtime interface
+func (w *WASM) Name() string {
+	return "wasm"
+}
+
+// Run implements the runtime interface
+func (w *WASM) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) *runtime.ExecutionResult {
+	m, err := w.module(c.Code)
+	if err != nil {
+		return &runtime.ExecutionResult{
+			GasUsed: c.Gas,
+			Err:     err,
+		}
+	}
+
+	in := newInstance(m, c, host, config)
+
+	err = in.run()
+	if errors.Is(err, errFinish) {
+		err = nil
+	}
+
+	gasLeft := in.gas
+	if err != nil && !errors.Is(err, runtime.ErrExecutionReverted) {
+		gasLeft = 0
+	}
+
+	return &runtime.ExecutionResult{
+		ReturnValue: in.output,
+		GasLeft:     gasLeft,
+		GasUsed:     c.Gas - gasLeft,
+		Err:         err,
+	}
+}
+
+// module returns the decoded module of the code
+func (w *WASM) module(code []byte) (*module, error) {
+	hash := types.BytesToHash(crypto.Keccak256(code))
+
+	if m, ok := w.modules.Get(hash); ok {
+		return m.(*module), nil //nolint:forcetypeassert
+	}
+
+	m, err := decodeModule(code)
+	if err != nil {
+		return nil, err
+	}
+
+	w.modules.Add(hash, m)
+
+	return m, nil
+}
+
+// Validate decodes the WASM module, returning the reason why it can't be run
+func Validate(code []byte) error {
+	_, err := decodeModule(code)
+
+	return err
+}
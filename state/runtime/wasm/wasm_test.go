@@ -0,0 +1,600 @@
+package wasm
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	contractAddr = types.StringToAddress("1000")
+	callerAddr   = types.StringToAddress("2000")
+)
+
+// mockHost is a runtime host with an in memory storage
+type mockHost struct {
+	storage map[types.Hash]types.Hash
+	logs    [][]types.Hash
+}
+
+func newMockHost() *mockHost {
+	return &mockHost{storage: make(map[types.Hash]types.Hash)}
+}
+
+func (m *mockHost) AccountExists(addr types.Address) bool {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockHost) SetStorage(
+	addr types.Address,
+	key types.Hash,
+	value types.Hash,
+	config *chain.ForksInTime,
+) runtime.StorageStatus {
+	prev, ok := m.storage[key]
+	m.storage[key] = value
+
+	if !ok {
+		return runtime.StorageAdded
+	}
+
+	if prev == value {
+		return runtime.StorageUnchanged
+	}
+
+	return runtime.StorageModified
+}
+
+func (m *mockHost) GetBalance(addr types.Address) *big.Int {
+	return big.NewInt(100)
+}
+
+func (m *mockHost) GetCodeSize(addr types.Address) int {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) GetCodeHash(addr types.Address) types.Hash {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) GetCode(addr types.Address) []byte {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) Selfdestruct(addr types.Address, beneficiary types.Address) {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Number: 10, Timestamp: 1000}
+}
+
+func (m *mockHost) GetBlockHash(number int64) types.Hash {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) EmitLog(addr types.Address, topics []types.Hash, data []byte) {
+	m.logs = append(m.logs, topics)
+}
+
+func (m *mockHost) Callx(*runtime.Contract, runtime.Host) *runtime.ExecutionResult {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) Empty(addr types.Address) bool {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) GetNonce(addr types.Address) uint64 {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) GetTracer() runtime.VMTracer {
+	return nil
+}
+
+func (m *mockHost) GetRefund() uint64 {
+	panic("Not implemented in tests")
+}
+
+// testFunc is a function of a test module, its locals are declared one by one
+type testFunc struct {
+	typ    uint32
+	locals []byte
+	body   []byte
+}
+
+// testModule encodes a module in the binary format
+type testModule struct {
+	types   []*funcType
+	imports map[uint32]string
+	funcs   []testFunc
+	pages   int
+	data    []byte
+
+	// maxPages is the maximum of the memory, none if 0
+	maxPages int
+}
+
+func uleb(v uint64) []byte {
+	var buf []byte
+
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+
+		if v == 0 {
+			return append(buf, b)
+		}
+
+		buf = append(buf, b|0x80)
+	}
+}
+
+func vec(items ...[]byte) []byte {
+	buf := uleb(uint64(len(items)))
+	for _, item := range items {
+		buf = append(buf, item...)
+	}
+
+	return buf
+}
+
+func name(s string) []byte {
+	return append(uleb(uint64(len(s))), s...)
+}
+
+func section(id byte, payload []byte) []byte {
+	return append(append([]byte{id}, uleb(uint64(len(payload)))...), payload...)
+}
+
+func (m *testModule) encode() []byte {
+	code := append(append([]byte{}, magic...), version...)
+
+	// the imports are the first functions
+	imports := [][]byte{}
+
+	for i := uint32(0); i < uint32(len(m.imports)); i++ {
+		entry := append(name(hostModule), name(m.imports[i])...)
+		entry = append(entry, externalFunc)
+		entry = append(entry, uleb(uint64(hostTypeIndex(m, m.imports[i])))...)
+		imports = append(imports, entry)
+	}
+
+	// the signatures of the imports are known once they are resolved
+	types := [][]byte{}
+	for _, typ := range m.types {
+		entry := []byte{typeFunc}
+		entry = append(entry, append(uleb(uint64(len(typ.params))), typ.params...)...)
+		entry = append(entry, append(uleb(uint64(len(typ.results))), typ.results...)...)
+		types = append(types, entry)
+	}
+
+	code = append(code, section(sectionType, vec(types...))...)
+
+	if len(imports) > 0 {
+		code = append(code, section(sectionImport, vec(imports...))...)
+	}
+
+	funcs, bodies := [][]byte{}, [][]byte{}
+
+	for _, fn := range m.funcs {
+		funcs = append(funcs, uleb(uint64(fn.typ)))
+
+		locals := [][]byte{}
+		for _, typ := range fn.locals {
+			locals = append(locals, []byte{1, typ})
+		}
+
+		body := append(vec(locals...), fn.body...)
+		bodies = append(bodies, append(uleb(uint64(len(body))), body...))
+	}
+
+	code = append(code, section(sectionFunction, vec(funcs...))...)
+
+	if m.maxPages > 0 {
+		code = append(code, section(sectionMemory, vec([]byte{1, byte(m.pages), byte(m.maxPages)}))...)
+	} else if m.pages > 0 {
+		code = append(code, section(sectionMemory, vec([]byte{0, byte(m.pages)}))...)
+	}
+
+	// the last function is the entrypoint
+	main := append(name(entrypoint), externalFunc)
+	main = append(main, uleb(uint64(len(m.imports)+len(m.funcs)-1))...)
+	code = append(code, section(sectionExport, vec(main))...)
+
+	code = append(code, section(sectionCode, vec(bodies...))...)
+
+	if len(m.data) > 0 {
+		segment := []byte{0, opI32Const, 0, opEnd}
+		segment = append(segment, append(uleb(uint64(len(m.data))), m.data...)...)
+		code = append(code, section(sectionData, vec(segment))...)
+	}
+
+	return code
+}
+
+// hostTypeIndex returns the index of the signature of the host function, adding it to the types,
+// an unknown function has the signature of main
+func hostTypeIndex(m *testModule, name string) int {
+	fn, ok := hostFuncs[name]
+	if !ok {
+		return 0
+	}
+
+	for i, typ := range m.types {
+		if typ.equals(fn.typ) {
+			return i
+		}
+	}
+
+	m.types = append(m.types, fn.typ)
+
+	return len(m.types) - 1
+}
+
+var typeMain = &funcType{}
+
+// newTestModule returns a module with the imported host functions, the
+// main function is the last one and its body ends the execution
+func newTestModule(imports []string, funcs ...testFunc) *testModule {
+	m := &testModule{
+		types:   []*funcType{typeMain},
+		imports: make(map[uint32]string),
+		funcs:   funcs,
+		pages:   1,
+	}
+
+	for i, name := range imports {
+		m.imports[uint32(i)] = name
+	}
+
+	return m
+}
+
+func runModule(t *testing.T, m *testModule, host runtime.Host, gas uint64, static bool) *runtime.ExecutionResult {
+	t.Helper()
+
+	code := m.encode()
+	require.NoError(t, Validate(code))
+
+	contract := runtime.NewContractCall(1, callerAddr, callerAddr, contractAddr, big.NewInt(5), gas, code, []byte{0x1, 0x2})
+	contract.Static = static
+
+	w := NewWASM([]types.Address{contractAddr})
+	require.True(t, w.CanRun(contract, host, &chain.ForksInTime{}))
+
+	forks := chain.AllForksEnabled.At(0)
+
+	return w.Run(contract, host, &forks)
+}
+
+func TestWASM_CanRun(t *testing.T) {
+	t.Parallel()
+
+	w := NewWASM([]types.Address{contractAddr})
+	code := newTestModule(nil, testFunc{body: []byte{opEnd}}).encode()
+
+	tests := []struct {
+		name     string
+		address  types.Address
+		code     []byte
+		expected bool
+	}{
+		{"registered module", contractAddr, code, true},
+		{"registered evm code", contractAddr, []byte{0x60, 0x00}, false},
+		{"unregistered module", callerAddr, code, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			contract := runtime.NewContractCall(1, callerAddr, callerAddr, tt.address, nil, 0, tt.code, nil)
+
+			assert.Equal(t, tt.expected, w.CanRun(contract, nil, &chain.ForksInTime{}))
+		})
+	}
+}
+
+func TestWASM_Run(t *testing.T) {
+	t.Parallel()
+
+	// finish(0, 8) returning the first 8 bytes of the memory
+	finish8 := []byte{opI32Const, 0, opI32Const, 8, opCall, 0}
+
+	t.Run("computes in a loop", func(t *testing.T) {
+		t.Parallel()
+
+		// n = 5, acc = 1, while n != 0 { acc *= n, n-- }, returns acc
+		body := []byte{
+			opI64Const, 5, opLocalSet, 0,
+			opI64Const, 1, opLocalSet, 1,
+			opBlock, blockTypeEmpty,
+			opLoop, blockTypeEmpty,
+			opLocalGet, 0, opI64Eqz, opBrIf, 1,
+			opLocalGet, 1, opLocalGet, 0, opI64Mul, opLocalSet, 1,
+			opLocalGet, 0, opI64Const, 1, opI64Sub, opLocalSet, 0,
+			opBr, 0,
+			opEnd,
+			opEnd,
+			opI32Const, 0, opLocalGet, 1, opI64Store, 3, 0,
+		}
+		body = append(append(body, finish8...), opEnd)
+
+		m := newTestModule([]string{"finish"}, testFunc{locals: []byte{valueTypeI64, valueTypeI64}, body: body})
+
+		res := runModule(t, m, newMockHost(), 10000, false)
+		require.NoError(t, res.Err)
+
+		assert.Equal(t, uint64(120), binary.LittleEndian.Uint64(res.ReturnValue))
+		assert.Equal(t, res.GasUsed+res.GasLeft, uint64(10000))
+	})
+
+	t.Run("calls functions with if else", func(t *testing.T) {
+		t.Parallel()
+
+		// max(a, b i32) i32
+		max := testFunc{
+			typ: 1,
+			body: []byte{
+				opLocalGet, 0, opLocalGet, 1, opI32GtS,
+				opIf, valueTypeI32,
+				opLocalGet, 0,
+				opElse,
+				opLocalGet, 1,
+				opEnd,
+				opEnd,
+			},
+		}
+
+		// stores max(-3, 7) and max(9, 2)
+		body := []byte{
+			opI32Const, 0, opI32Const, 0x7d, opI32Const, 7, opCall, 1, opI32Store, 2, 0,
+			opI32Const, 4, opI32Const, 9, opI32Const, 2, opCall, 1, opI32Store, 2, 0,
+		}
+		body = append(append(body, finish8...), opEnd)
+
+		m := newTestModule([]string{"finish"}, max, testFunc{body: body})
+		m.types = append(m.types, &funcType{
+			params:  []byte{valueTypeI32, valueTypeI32},
+			results: []byte{valueTypeI32},
+		})
+
+		res := runModule(t, m, newMockHost(), 10000, false)
+		require.NoError(t, res.Err)
+
+		assert.Equal(t, uint32(7), binary.LittleEndian.Uint32(res.ReturnValue[0:4]))
+		assert.Equal(t, uint32(9), binary.LittleEndian.Uint32(res.ReturnValue[4:8]))
+	})
+
+	t.Run("leaves the result of a loop", func(t *testing.T) {
+		t.Parallel()
+
+		body := []byte{
+			opI32Const, 0,
+			opLoop, valueTypeI32, opI32Const, 7, opEnd,
+			opI32Store, 2, 0,
+		}
+		body = append(append(body, finish8...), opEnd)
+
+		res := runModule(t, newTestModule([]string{"finish"}, testFunc{body: body}), newMockHost(), 10000, false)
+		require.NoError(t, res.Err)
+
+		assert.Equal(t, uint32(7), binary.LittleEndian.Uint32(res.ReturnValue[0:4]))
+	})
+
+	t.Run("grows the memory up to its maximum", func(t *testing.T) {
+		t.Parallel()
+
+		// stores the results of growing the memory by a page twice
+		body := []byte{
+			opI32Const, 0, opI32Const, 1, opMemoryGrow, 0, opI32Store, 2, 0,
+			opI32Const, 4, opI32Const, 1, opMemoryGrow, 0, opI32Store, 2, 0,
+		}
+		body = append(append(body, finish8...), opEnd)
+
+		m := newTestModule([]string{"finish"}, testFunc{body: body})
+		m.maxPages = 2
+
+		res := runModule(t, m, newMockHost(), 10000, false)
+		require.NoError(t, res.Err)
+
+		assert.Equal(t, uint32(1), binary.LittleEndian.Uint32(res.ReturnValue[0:4]))
+		assert.Equal(t, uint32(math.MaxUint32), binary.LittleEndian.Uint32(res.ReturnValue[4:8]))
+	})
+
+	t.Run("reads and writes the storage", func(t *testing.T) {
+		t.Parallel()
+
+		host := newMockHost()
+
+		// the key at 0 is 0x01.., the value at 32 is the input
+		body := []byte{
+			opI32Const, 32, opI32Const, 0, opI32Const, 2, opCall, 1,
+			opI32Const, 0, opI32Const, 32, opCall, 2,
+			opI32Const, 0, opI32Const, 0xc0, 0x00, opCall, 3,
+			opI32Const, 0xc0, 0x00, opI32Const, 32, opCall, 0,
+			opEnd,
+		}
+
+		m := newTestModule([]string{"finish", "input_copy", "storage_store", "storage_load"}, testFunc{body: body})
+		m.data = []byte{0x01}
+
+		res := runModule(t, m, host, 50000, false)
+		require.NoError(t, res.Err)
+
+		key := types.BytesToHash(append([]byte{0x01}, make([]byte, 31)...))
+		value := types.BytesToHash(append([]byte{0x01, 0x02}, make([]byte, 30)...))
+
+		assert.Equal(t, value, host.storage[key])
+		assert.Equal(t, value.Bytes(), res.ReturnValue)
+	})
+
+	t.Run("reverts", func(t *testing.T) {
+		t.Parallel()
+
+		body := []byte{opI32Const, 0, opI32Const, 1, opCall, 0, opEnd}
+
+		m := newTestModule([]string{"revert"}, testFunc{body: body})
+		m.data = []byte{0xff}
+
+		res := runModule(t, m, newMockHost(), 10000, false)
+
+		assert.ErrorIs(t, res.Err, runtime.ErrExecutionReverted)
+		assert.Equal(t, []byte{0xff}, res.ReturnValue)
+		assert.NotZero(t, res.GasLeft)
+	})
+
+	t.Run("runs out of gas", func(t *testing.T) {
+		t.Parallel()
+
+		body := []byte{opLoop, blockTypeEmpty, opBr, 0, opEnd, opEnd}
+
+		res := runModule(t, newTestModule(nil, testFunc{body: body}), newMockHost(), 10000, false)
+
+		assert.ErrorIs(t, res.Err, runtime.ErrOutOfGas)
+		assert.Zero(t, res.GasLeft)
+		assert.Equal(t, uint64(10000), res.GasUsed)
+	})
+
+	t.Run("traps on division by zero", func(t *testing.T) {
+		t.Parallel()
+
+		body := []byte{opI32Const, 1, opI32Const, 0, opI32DivU, opDrop, opEnd}
+
+		res := runModule(t, newTestModule(nil, testFunc{body: body}), newMockHost(), 10000, false)
+
+		assert.ErrorIs(t, res.Err, errDivisionByZero)
+		assert.Zero(t, res.GasLeft)
+	})
+
+	t.Run("traps on out of bounds memory", func(t *testing.T) {
+		t.Parallel()
+
+		// the address wraps to 0xffffffff
+		body := []byte{opI32Const, 0x7f, opI32Load, 2, 0, opDrop, opEnd}
+
+		res := runModule(t, newTestModule(nil, testFunc{body: body}), newMockHost(), 10000, false)
+
+		assert.ErrorIs(t, res.Err, errMemoryOutOfBounds)
+	})
+
+	t.Run("can't write in a static call", func(t *testing.T) {
+		t.Parallel()
+
+		host := newMockHost()
+		body := []byte{opI32Const, 0, opI32Const, 0, opCall, 0, opEnd}
+
+		res := runModule(t, newTestModule([]string{"storage_store"}, testFunc{body: body}), host, 50000, true)
+
+		assert.ErrorIs(t, res.Err, errWriteProtection)
+		assert.Empty(t, host.storage)
+	})
+}
+
+func TestDecodeModule(t *testing.T) {
+	t.Parallel()
+
+	valid := newTestModule(nil, testFunc{body: []byte{opEnd}})
+
+	noMain := valid.encode()
+	// rename the export
+	for i := range noMain {
+		if string(noMain[i:i+len(entrypoint)]) == entrypoint {
+			noMain[i] = 'x'
+
+			break
+		}
+	}
+
+	tests := []struct {
+		name string
+		code []byte
+		err  error
+	}{
+		{"valid", valid.encode(), nil},
+		{"bad preamble", []byte{0x00, 0x61, 0x73, 0x6d, 0x02, 0, 0, 0}, errInvalidModule},
+		{"no entrypoint", noMain, errNoEntrypoint},
+		{
+			"unknown import",
+			newTestModule([]string{"selfdestruct"}, testFunc{body: []byte{opEnd}}).encode(),
+			errInvalidModule,
+		},
+		{
+			"float instruction",
+			newTestModule(nil, testFunc{body: []byte{0x43, 0, 0, 0, 0, opDrop, opEnd}}).encode(),
+			errUnsupportedFeature,
+		},
+		{
+			"unterminated block",
+			newTestModule(nil, testFunc{body: []byte{opBlock, blockTypeEmpty, opEnd}}).encode(),
+			errInvalidModule,
+		},
+		{
+			"branch out of the function",
+			newTestModule(nil, testFunc{body: []byte{opBr, 1, opEnd}}).encode(),
+			errInvalidModule,
+		},
+		{
+			"operands of another type",
+			newTestModule(nil, testFunc{body: []byte{opI64Const, 1, opI32Const, 1, opI32Add, opDrop, opEnd}}).encode(),
+			errInvalidModule,
+		},
+		{
+			"missing operand",
+			newTestModule(nil, testFunc{body: []byte{opI32Const, 1, opI32Add, opDrop, opEnd}}).encode(),
+			errInvalidModule,
+		},
+		{
+			"operands left at the end",
+			newTestModule(nil, testFunc{body: []byte{opI32Const, 1, opEnd}}).encode(),
+			errInvalidModule,
+		},
+		{
+			"if with a result without else",
+			newTestModule(nil, testFunc{body: []byte{
+				opI32Const, 1, opIf, valueTypeI32, opI32Const, 1, opEnd, opDrop, opEnd,
+			}}).encode(),
+			errInvalidModule,
+		},
+		{
+			"alignment larger than natural",
+			newTestModule(nil, testFunc{body: []byte{opI32Const, 0, opI32Load, 3, 0, opDrop, opEnd}}).encode(),
+			errInvalidModule,
+		},
+		{
+			"operands of unreachable code",
+			newTestModule(nil, testFunc{body: []byte{opUnreachable, opI32Add, opDrop, opEnd}}).encode(),
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := decodeModule(tt.code)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
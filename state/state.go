@@ -2,6 +2,7 @@ package state
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -12,6 +13,9 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// ErrStateNotFound is returned when the state at a root isn't stored
+var ErrStateNotFound = errors.New("state not found")
+
 type State interface {
 	NewSnapshotAt(types.Hash) (Snapshot, error)
 	NewSnapshot() Snapshot
@@ -24,6 +28,13 @@ type Snapshot interface {
 	Commit(objs []*Object) (Snapshot, []byte)
 }
 
+// Prover is a State that can build merkle proofs of the trie entries
+type Prover interface {
+	// GetProof returns the RLP encoded trie nodes on the path
+	// from the root to the (already hashed) key
+	GetProof(root types.Hash, key []byte) ([][]byte, error)
+}
+
 // Account is the account reference in the ethereum state
 type Account struct {
 	Nonce    uint64
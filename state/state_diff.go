@@ -0,0 +1,78 @@
+package state
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// StateDiff returns the state changes of the committed transition,
+// the previous values are read from the snapshot it was applied on
+func (t *Transition) StateDiff() (*types.StateDiff, error) {
+	diff := &types.StateDiff{}
+
+	for _, obj := range t.committed {
+		prev, err := t.snap.GetAccount(obj.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		if prev == nil && obj.Deleted {
+			// touched and removed in the same block
+			continue
+		}
+
+		account := &types.AccountDiff{
+			Address:      obj.Address,
+			Created:      prev == nil,
+			Deleted:      obj.Deleted,
+			PrevBalance:  big.NewInt(0),
+			Balance:      big.NewInt(0),
+			PrevCodeHash: types.BytesToHash(emptyCodeHash),
+			CodeHash:     types.BytesToHash(emptyCodeHash),
+		}
+
+		if prev != nil {
+			account.PrevNonce = prev.Nonce
+			account.PrevBalance = new(big.Int).Set(prev.Balance)
+			account.PrevCodeHash = types.BytesToHash(prev.CodeHash)
+		}
+
+		if !obj.Deleted {
+			account.Nonce = obj.Nonce
+			account.Balance = new(big.Int).Set(obj.Balance)
+			account.CodeHash = obj.CodeHash
+		}
+
+		for _, entry := range obj.Storage {
+			slot := &types.StorageDiff{Key: types.BytesToHash(entry.Key)}
+
+			if prev != nil {
+				slot.PrevValue = t.snap.GetStorage(obj.Address, prev.Root, slot.Key)
+			}
+
+			if !entry.Deleted {
+				slot.Value = types.BytesToHash(entry.Val)
+			}
+
+			if slot.PrevValue != slot.Value {
+				account.Storage = append(account.Storage, slot)
+			}
+		}
+
+		if !account.Created && !account.Deleted && !isAccountChanged(account) {
+			continue
+		}
+
+		diff.Accounts = append(diff.Accounts, account)
+	}
+
+	return diff, nil
+}
+
+func isAccountChanged(account *types.AccountDiff) bool {
+	return account.PrevNonce != account.Nonce ||
+		account.PrevBalance.Cmp(account.Balance) != 0 ||
+		account.PrevCodeHash != account.CodeHash ||
+		len(account.Storage) > 0
+}
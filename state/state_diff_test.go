@@ -0,0 +1,116 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// diffSnapshot is a mock snapshot the state diff can be taken against
+type diffSnapshot struct {
+	mockSnapshot
+}
+
+func (d *diffSnapshot) GetAccount(addr types.Address) (*Account, error) {
+	if _, ok := d.state[addr]; !ok {
+		return nil, nil
+	}
+
+	account, err := d.mockSnapshot.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	account.CodeHash = emptyCodeHash
+
+	return account, nil
+}
+
+func (d *diffSnapshot) Commit(objs []*Object) (Snapshot, []byte) {
+	return d, nil
+}
+
+func TestTransition_StateDiff(t *testing.T) {
+	t.Parallel()
+
+	addr3 := types.StringToAddress("3")
+
+	snap := &diffSnapshot{mockSnapshot{state: map[types.Address]*PreState{
+		addr1: {
+			Nonce:   1,
+			Balance: 100,
+			State: map[types.Hash]types.Hash{
+				hash1: hash1,
+				hash2: hash2,
+			},
+		},
+		addr2: {
+			Balance: 10,
+		},
+	}}}
+
+	txn := newTxn(snap)
+
+	// changed account
+	txn.IncrNonce(addr1)
+	require.NoError(t, txn.SubBalance(addr1, big.NewInt(30)))
+	txn.SetState(addr1, hash1, hash2)
+	txn.SetState(addr1, hash2, hash2)                  // unchanged slot
+	txn.SetState(addr1, hash0, hash1)                  // new slot
+	txn.SetState(addr1, types.Hash{0x3}, types.Hash{}) // unchanged empty slot
+
+	// deleted account
+	txn.Suicide(addr2)
+
+	// created account
+	txn.AddBalance(addr3, big.NewInt(30))
+
+	// touched but empty account
+	txn.AddBalance(types.StringToAddress("4"), big.NewInt(0))
+
+	transition := NewTransition(chain.ForksInTime{EIP155: true}, snap, txn)
+	transition.Commit()
+
+	diff, err := transition.StateDiff()
+	require.NoError(t, err)
+
+	emptyHash := types.BytesToHash(emptyCodeHash)
+
+	assert.Equal(t, &types.StateDiff{
+		Accounts: []*types.AccountDiff{
+			{
+				Address:      addr1,
+				PrevNonce:    1,
+				Nonce:        2,
+				PrevBalance:  big.NewInt(100),
+				Balance:      big.NewInt(70),
+				PrevCodeHash: emptyHash,
+				CodeHash:     emptyHash,
+				Storage: []*types.StorageDiff{
+					{Key: hash0, PrevValue: types.Hash{}, Value: hash1},
+					{Key: hash1, PrevValue: hash1, Value: hash2},
+				},
+			},
+			{
+				Address:      addr2,
+				Deleted:      true,
+				PrevBalance:  big.NewInt(10),
+				Balance:      big.NewInt(0),
+				PrevCodeHash: emptyHash,
+				CodeHash:     emptyHash,
+			},
+			{
+				Address:      addr3,
+				Created:      true,
+				PrevBalance:  big.NewInt(0),
+				Balance:      big.NewInt(30),
+				PrevCodeHash: emptyHash,
+				CodeHash:     emptyHash,
+			},
+		},
+	}, diff)
+}
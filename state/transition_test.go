@@ -1,10 +1,19 @@
 package state
 
 import (
+	"math"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/freeze"
+	"github.com/0xPolygon/polygon-edge/contracts/gasquota"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -16,8 +25,9 @@ func newTestTransition(preState map[types.Address]*PreState) *Transition {
 	}
 
 	return &Transition{
-		logger: hclog.NewNullLogger(),
-		state:  newTestTxn(preState),
+		logger:          hclog.NewNullLogger(),
+		state:           newTestTxn(preState),
+		coinbaseRewards: new(big.Int),
 	}
 }
 
@@ -88,6 +98,30 @@ func TestSubGasLimitPrice(t *testing.T) {
 	}
 }
 
+func TestSubGasLimitPrice_Sponsored(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {Balance: 0},
+		addr2: {Balance: 1000},
+	})
+
+	msg := &types.Transaction{
+		From:     addr1,
+		Gas:      10,
+		GasPrice: big.NewInt(10),
+		SponsorV: big.NewInt(27),
+		SponsorR: big.NewInt(1),
+		SponsorS: big.NewInt(1),
+		Sponsor:  addr2,
+	}
+
+	// the sponsor pays the gas
+	assert.NoError(t, transition.subGasLimitPrice(msg))
+	assert.Zero(t, transition.GetBalance(addr1).Sign())
+	assert.Zero(t, transition.GetBalance(addr2).Cmp(big.NewInt(900)))
+}
+
 func TestTransfer(t *testing.T) {
 	t.Parallel()
 
@@ -159,3 +193,425 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyCreate_MaxInitCodeSize(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {Balance: 1000},
+	})
+	transition.maxInitCodeSize = 4
+
+	contract := runtime.NewContractCreation(1, addr1, addr1, addr2, big.NewInt(0), 1000, make([]byte, 5))
+	result := transition.applyCreate(contract, transition)
+
+	assert.ErrorIs(t, result.Err, runtime.ErrMaxInitCodeSizeExceeded)
+	assert.Zero(t, result.GasLeft)
+	// the nonce of the caller is left untouched
+	assert.Zero(t, transition.state.GetNonce(addr1))
+}
+
+func TestApply_DailyGasQuota(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {Balance: 0},
+	})
+	transition.evm = evm.NewEVM()
+	transition.precompiles = precompiled.NewPrecompiled()
+	transition.gasPool = 1000000
+	transition.dailyGasQuota = 50000
+
+	freeTx := func(nonce uint64) *types.Transaction {
+		return &types.Transaction{
+			From:     addr1,
+			To:       &addr2,
+			Nonce:    nonce,
+			Gas:      21000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		}
+	}
+
+	// the free transactions are accepted until the quota is used
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		_, err := transition.Apply(freeTx(nonce))
+		assert.NoError(t, err)
+	}
+
+	gasPool := transition.gasPool
+
+	_, err := transition.Apply(freeTx(2))
+
+	appErr, ok := err.(*TransitionApplicationError) //nolint:errorlint
+	assert.True(t, ok)
+	assert.ErrorIs(t, appErr.Err, ErrGasQuotaExceeded)
+	assert.Equal(t, uint64(42000), gasquota.Usage(transition.state, addr1, 0))
+
+	// the rejected transaction leaves the gas of the block to the next ones
+	assert.Equal(t, gasPool, transition.gasPool)
+}
+
+func TestApply_RestoreSlotRefund(t *testing.T) {
+	t.Parallel()
+
+	var (
+		contract = types.StringToAddress("1000")
+		slot     = types.BytesToHash([]byte{3})
+	)
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {Balance: 1000000},
+		contract: {
+			State: map[types.Hash]types.Hash{slot: types.BytesToHash([]byte{1})},
+		},
+	})
+	transition.evm = evm.NewEVM()
+	transition.precompiles = precompiled.NewPrecompiled()
+	transition.gasPool = 1000000
+	transition.config = chain.ForksInTime{
+		Homestead:      true,
+		Byzantium:      true,
+		Constantinople: true,
+		Petersburg:     true,
+		Istanbul:       true,
+		EIP3529:        true,
+	}
+
+	// PUSH1 2 PUSH1 3 SSTORE PUSH1 1 PUSH1 3 SSTORE STOP
+	transition.state.SetCode(contract, []byte{0x60, 0x02, 0x60, 0x03, 0x55, 0x60, 0x01, 0x60, 0x03, 0x55, 0x00})
+
+	result, err := transition.Apply(&types.Transaction{
+		From:     addr1,
+		To:       &contract,
+		Gas:      100000,
+		GasPrice: big.NewInt(1),
+		Value:    big.NewInt(0),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, result.Err)
+
+	// modifying the slot and restoring its original value costs 5000 + 800 - 4200
+	assert.Equal(t, uint64(21000+4*3+1600), result.GasUsed)
+	assert.Equal(t, types.BytesToHash([]byte{1}), transition.state.GetState(contract, slot))
+}
+
+func TestApply_CoinbaseRewards(t *testing.T) {
+	t.Parallel()
+
+	coinbase := types.StringToAddress("5")
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {Balance: 1000000},
+	})
+	transition.evm = evm.NewEVM()
+	transition.precompiles = precompiled.NewPrecompiled()
+	transition.gasPool = 1000000
+	transition.ctx.Coinbase = coinbase
+
+	transfer := func(nonce uint64) *types.Transaction {
+		return &types.Transaction{
+			From:     addr1,
+			To:       &addr2,
+			Nonce:    nonce,
+			Gas:      21000,
+			GasPrice: big.NewInt(2),
+			Value:    big.NewInt(0),
+		}
+	}
+
+	_, err := transition.Apply(transfer(0))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42000), transition.CoinbaseRewards())
+
+	// the fees of the isolated transactions are reverted with their state
+	_, err = transition.ApplyIsolated(transfer(1))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42000), transition.CoinbaseRewards())
+	assert.Equal(t, big.NewInt(42000), transition.state.GetBalance(coinbase))
+}
+
+func TestApply_Freeze(t *testing.T) {
+	t.Parallel()
+
+	var (
+		account     = types.StringToAddress("3")
+		nonGovernor = types.StringToAddress("4")
+	)
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {Balance: 0},
+		addr2: {Balance: 0},
+	})
+	transition.evm = evm.NewEVM()
+	transition.precompiles = precompiled.NewPrecompiled()
+	transition.gasPool = 1000000
+	transition.freeze = &chain.Freeze{
+		Governors: []types.Address{addr1, addr2},
+		Threshold: 2,
+	}
+
+	newTx := func(from, to types.Address, input []byte) *types.Transaction {
+		return &types.Transaction{
+			From:     from,
+			To:       &to,
+			Nonce:    transition.state.GetNonce(from),
+			Gas:      100000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+			Input:    input,
+		}
+	}
+
+	// the votes of the non governors fail
+	result, err := transition.Apply(newTx(nonGovernor, freeze.AddrFreezeContract, freeze.EncodeVote(account, true)))
+	assert.NoError(t, err)
+	assert.ErrorIs(t, result.Err, freeze.ErrNotGovernor)
+
+	// the account is frozen once the threshold of the governors vote
+	for _, governor := range []types.Address{addr1, addr2} {
+		assert.False(t, freeze.IsFrozen(transition.state, account))
+
+		result, err = transition.Apply(newTx(governor, freeze.AddrFreezeContract, freeze.EncodeVote(account, true)))
+		assert.NoError(t, err)
+		assert.NoError(t, result.Err)
+	}
+
+	assert.True(t, freeze.IsFrozen(transition.state, account))
+
+	// the transactions from and to the frozen account are rejected
+	for _, tx := range []*types.Transaction{
+		newTx(addr1, account, nil),
+		newTx(account, addr1, nil),
+	} {
+		_, err = transition.Apply(tx)
+
+		appErr, ok := err.(*TransitionApplicationError) //nolint:errorlint
+		assert.True(t, ok)
+		assert.ErrorIs(t, appErr.Err, ErrAccountFrozen)
+		assert.False(t, appErr.IsRecoverable)
+	}
+
+	_, err = transition.Apply(newTx(addr1, addr2, nil))
+	assert.NoError(t, err)
+
+	// the contract returns the frozen status as a bool and reverts on the other selectors
+	code, err := hex.DecodeHex(freeze.FreezeSCBytecode)
+	assert.NoError(t, err)
+	transition.state.SetCode(freeze.AddrFreezeContract, code)
+	transition.config.Byzantium = true
+
+	isFrozen := func(account types.Address) []byte {
+		input := append(
+			keccak.Keccak256(nil, []byte("isFrozen(address)"))[:4],
+			types.BytesToHash(account.Bytes()).Bytes()...,
+		)
+
+		result, err := transition.Apply(newTx(addr1, freeze.AddrFreezeContract, input))
+		assert.NoError(t, err)
+		assert.NoError(t, result.Err)
+
+		return result.ReturnValue
+	}
+
+	assert.Equal(t, types.BytesToHash([]byte{1}).Bytes(), isFrozen(account))
+	assert.Equal(t, types.ZeroHash.Bytes(), isFrozen(addr2))
+
+	for _, governor := range []types.Address{addr1, addr2} {
+		result, err = transition.Apply(newTx(governor, freeze.AddrFreezeContract, freeze.EncodeVote(account, false)))
+		assert.NoError(t, err)
+		assert.NoError(t, result.Err)
+	}
+
+	assert.Equal(t, types.ZeroHash.Bytes(), isFrozen(account))
+
+	result, err = transition.Apply(newTx(addr1, freeze.AddrFreezeContract, []byte{0x1, 0x2, 0x3, 0x4}))
+	assert.NoError(t, err)
+	assert.True(t, result.Reverted())
+}
+
+func TestApply_BlobTx(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {Balance: 10 * types.MaxBlobGasPerBlock},
+	})
+	transition.evm = evm.NewEVM()
+	transition.precompiles = precompiled.NewPrecompiled()
+	transition.gasPool = 1000000
+	transition.blobGasPrice = big.NewInt(1)
+
+	blobTx := func(nonce uint64, blobs int, blobFeeCap int64) *types.Transaction {
+		hashes := make([]types.Hash, blobs)
+		for i := range hashes {
+			hashes[i] = types.KZGToVersionedHash([]byte{byte(i)})
+		}
+
+		return &types.Transaction{
+			Type:       types.BlobTx,
+			From:       addr1,
+			To:         &addr2,
+			Nonce:      nonce,
+			Gas:        21000,
+			GasPrice:   big.NewInt(0),
+			Value:      big.NewInt(0),
+			BlobFeeCap: big.NewInt(blobFeeCap),
+			BlobHashes: hashes,
+		}
+	}
+
+	applicationErr := func(err error) error {
+		appErr, ok := err.(*TransitionApplicationError) //nolint:errorlint
+		assert.True(t, ok)
+
+		return appErr.Err
+	}
+
+	// the blob fee cap must cover the blob gas price
+	_, err := transition.Apply(blobTx(0, 1, 0))
+	assert.ErrorIs(t, applicationErr(err), ErrBlobFeeCapTooLow)
+
+	// the blob fee is burned from the caller
+	_, err = transition.Apply(blobTx(0, 4, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4*types.BlobGasPerBlob), transition.BlobGasUsed())
+	assert.Equal(t, big.NewInt(9*types.MaxBlobGasPerBlock+2*types.BlobGasPerBlob), transition.state.GetBalance(addr1))
+
+	// the blob gas of the block is limited
+	_, err = transition.Apply(blobTx(1, 3, 1))
+	assert.ErrorIs(t, applicationErr(err), ErrBlobGasLimitReached)
+
+	// a blob transaction carries blobs
+	_, err = transition.Apply(blobTx(1, 0, 1))
+	assert.ErrorIs(t, applicationErr(err), types.ErrMissingBlobHashes)
+}
+
+func TestWrite_ExecutionBudget(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {Balance: 0},
+	})
+	transition.evm = evm.NewEVM()
+	transition.precompiles = precompiled.NewPrecompiled()
+	transition.gasPool = math.MaxInt64
+	transition.SetExecutionBudget(50 * time.Millisecond)
+
+	// JUMPDEST PUSH1 0 JUMP, an endless loop until the gas runs out,
+	// at an address past the precompiles
+	loop := types.StringToAddress("1000")
+	transition.state.SetCode(loop, []byte{0x5b, 0x60, 0x00, 0x56})
+
+	err := transition.Write(&types.Transaction{
+		From:     addr1,
+		To:       &loop,
+		Gas:      math.MaxInt64,
+		GasPrice: big.NewInt(0),
+		Value:    big.NewInt(0),
+	})
+
+	appErr, ok := err.(*TransitionApplicationError) //nolint:errorlint
+	assert.True(t, ok)
+	assert.ErrorIs(t, appErr.Err, ErrExecutionTimeout)
+	assert.False(t, appErr.IsRecoverable)
+
+	// the interrupted transaction leaves no trace
+	assert.Zero(t, transition.state.GetNonce(addr1))
+	assert.Empty(t, transition.Receipts())
+	assert.Equal(t, uint64(math.MaxInt64), transition.gasPool)
+	assert.Nil(t, transition.ctx.Interrupt)
+
+	// the transactions within the budget go on
+	transition.state.SetCode(loop, nil)
+
+	assert.NoError(t, transition.Write(&types.Transaction{
+		From:     addr1,
+		To:       &loop,
+		Gas:      21000,
+		GasPrice: big.NewInt(0),
+		Value:    big.NewInt(0),
+	}))
+}
+
+func TestSelfdestruct_EIP6780(t *testing.T) {
+	t.Parallel()
+
+	addr3 := types.StringToAddress("3")
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {Balance: 100},
+		addr3: {Balance: 50},
+	})
+	transition.config.EIP3529 = true
+	transition.config.EIP6780 = true
+
+	// an existing account only sends its balance
+	transition.Selfdestruct(addr1, addr2)
+
+	assert.False(t, transition.state.HasSuicided(addr1))
+	assert.Zero(t, transition.state.GetBalance(addr1).Sign())
+	assert.Equal(t, big.NewInt(100), transition.state.GetBalance(addr2))
+
+	// an account created by the transaction is deleted, without refund
+	transition.state.MarkCreated(addr3)
+	transition.Selfdestruct(addr3, addr2)
+
+	assert.True(t, transition.state.HasSuicided(addr3))
+	assert.Equal(t, big.NewInt(150), transition.state.GetBalance(addr2))
+	assert.Zero(t, transition.state.GetRefund())
+
+	// the created accounts are reset with the transaction
+	transition.state.CleanDeleteObjects(true)
+	assert.False(t, transition.state.IsCreated(addr3))
+}
+
+func TestWrite_ExpiredTx(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		txExpiry    bool
+		validUntil  uint64
+		expectedErr error
+	}{
+		{
+			name:        "should reject the expiry before the fork",
+			txExpiry:    false,
+			validUntil:  10,
+			expectedErr: ErrTxExpiryDisabled,
+		},
+		{
+			name:        "should reject the expired transaction",
+			txExpiry:    true,
+			validUntil:  9,
+			expectedErr: ErrTxExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			transition := newTestTransition(nil)
+			transition.config.TxExpiry = tt.txExpiry
+			transition.ctx.Number = 10
+
+			err := transition.Write(&types.Transaction{
+				From:       addr1,
+				To:         &addr2,
+				Value:      big.NewInt(0),
+				GasPrice:   big.NewInt(0),
+				Gas:        21000,
+				ValidUntil: tt.validUntil,
+			})
+
+			var appErr *TransitionApplicationError
+
+			assert.ErrorAs(t, err, &appErr)
+			assert.ErrorIs(t, appErr.Err, tt.expectedErr)
+			assert.False(t, appErr.IsRecoverable)
+		})
+	}
+}
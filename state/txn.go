@@ -26,6 +26,9 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// createdIndex is the index of the accounts created by the transaction
+	createdIndex = types.BytesToHash([]byte{4}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -34,6 +37,12 @@ type Txn struct {
 	snapshots []*iradix.Tree
 	txn       *iradix.Txn
 	codeCache *lru.Cache
+
+	// the accesses of the transaction being applied, if recorded
+	accesses *AccessSet
+
+	// the locations touched by any transaction, including the reverted ones, if recorded
+	touched map[location]struct{}
 }
 
 func NewTxn(snapshot Snapshot) *Txn {
@@ -64,6 +73,10 @@ func (txn *Txn) Snapshot() int {
 	id := len(txn.snapshots)
 	txn.snapshots = append(txn.snapshots, t)
 
+	if txn.accesses != nil {
+		txn.accesses.snapshot(id)
+	}
+
 	return id
 }
 
@@ -75,10 +88,54 @@ func (txn *Txn) RevertToSnapshot(id int) {
 
 	tree := txn.snapshots[id]
 	txn.txn = tree.Txn()
+
+	if txn.accesses != nil {
+		txn.accesses.revertToSnapshot(id)
+	}
+}
+
+func (txn *Txn) recordRead(addr types.Address) {
+	if txn.accesses != nil {
+		txn.accesses.read(location{addr: addr})
+	}
+
+	txn.touch(location{addr: addr})
+}
+
+func (txn *Txn) recordWrite(addr types.Address) {
+	if txn.accesses != nil {
+		txn.accesses.write(location{addr: addr})
+	}
+
+	txn.touch(location{addr: addr})
+}
+
+func (txn *Txn) recordSlotRead(addr types.Address, key types.Hash) {
+	if txn.accesses != nil {
+		txn.accesses.read(location{addr: addr, slot: key, storage: true})
+	}
+
+	txn.touch(location{addr: addr, slot: key, storage: true})
+}
+
+func (txn *Txn) recordSlotWrite(addr types.Address, key types.Hash) {
+	if txn.accesses != nil {
+		txn.accesses.write(location{addr: addr, slot: key, storage: true})
+	}
+
+	txn.touch(location{addr: addr, slot: key, storage: true})
+}
+
+func (txn *Txn) touch(loc location) {
+	if txn.touched != nil {
+		txn.touched[loc] = struct{}{}
+	}
 }
 
 // GetAccount returns an account
 func (txn *Txn) GetAccount(addr types.Address) (*Account, bool) {
+	txn.recordRead(addr)
+
 	object, exists := txn.getStateObject(addr)
 	if !exists {
 		return nil, false
@@ -136,6 +193,9 @@ func (txn *Txn) upsertAccount(addr types.Address, create bool, f func(object *St
 }
 
 func (txn *Txn) AddSealingReward(addr types.Address, balance *big.Int) {
+	txn.recordRead(addr)
+	txn.recordWrite(addr)
+
 	txn.upsertAccount(addr, true, func(object *StateObject) {
 		if object.Suicide {
 			*object = *newStateObject(txn)
@@ -148,6 +208,9 @@ func (txn *Txn) AddSealingReward(addr types.Address, balance *big.Int) {
 
 // AddBalance adds balance
 func (txn *Txn) AddBalance(addr types.Address, balance *big.Int) {
+	txn.recordRead(addr)
+	txn.recordWrite(addr)
+
 	txn.upsertAccount(addr, true, func(object *StateObject) {
 		object.Account.Balance.Add(object.Account.Balance, balance)
 	})
@@ -165,6 +228,8 @@ func (txn *Txn) SubBalance(addr types.Address, amount *big.Int) error {
 		return runtime.ErrNotEnoughFunds
 	}
 
+	txn.recordWrite(addr)
+
 	txn.upsertAccount(addr, true, func(object *StateObject) {
 		object.Account.Balance.Sub(object.Account.Balance, amount)
 	})
@@ -174,6 +239,8 @@ func (txn *Txn) SubBalance(addr types.Address, amount *big.Int) error {
 
 // SetBalance sets the balance
 func (txn *Txn) SetBalance(addr types.Address, balance *big.Int) {
+	txn.recordWrite(addr)
+
 	txn.upsertAccount(addr, true, func(object *StateObject) {
 		object.Account.Balance.SetBytes(balance.Bytes())
 	})
@@ -181,6 +248,8 @@ func (txn *Txn) SetBalance(addr types.Address, balance *big.Int) {
 
 // GetBalance returns the balance of an address
 func (txn *Txn) GetBalance(addr types.Address) *big.Int {
+	txn.recordRead(addr)
+
 	object, exists := txn.getStateObject(addr)
 	if !exists {
 		return big.NewInt(0)
@@ -244,13 +313,19 @@ func (txn *Txn) SetStorage(
 
 	txn.SetState(addr, key, value)
 
+	// the refund of the cleared slots is reduced by EIP-3529
+	clearRefund := uint64(15000)
+	if config.EIP3529 {
+		clearRefund = 4800
+	}
+
 	legacyGasMetering := !config.Istanbul && (config.Petersburg || !config.Constantinople)
 
 	if legacyGasMetering {
 		if oldValue == zeroHash {
 			return runtime.StorageAdded
 		} else if value == zeroHash {
-			txn.AddRefund(15000)
+			txn.AddRefund(clearRefund)
 
 			return runtime.StorageDeleted
 		}
@@ -264,7 +339,7 @@ func (txn *Txn) SetStorage(
 		}
 
 		if value == zeroHash { // delete slot (2.1.2b)
-			txn.AddRefund(15000)
+			txn.AddRefund(clearRefund)
 
 			return runtime.StorageDeleted
 		}
@@ -274,22 +349,23 @@ func (txn *Txn) SetStorage(
 
 	if original != zeroHash { // Storage slot was populated before this transaction started
 		if current == zeroHash { // recreate slot (2.2.1.1)
-			txn.SubRefund(15000)
+			txn.SubRefund(clearRefund)
 		} else if value == zeroHash { // delete slot (2.2.1.2)
-			txn.AddRefund(15000)
+			txn.AddRefund(clearRefund)
 		}
 	}
 
 	if original == value {
 		if original == zeroHash { // reset to original nonexistent slot (2.2.2.1)
-			// Storage was used as memory (allocation and deallocation occurred within the same contract)
-			if config.Istanbul {
+			// Storage was used as memory (allocation and deallocation occurred within the same contract).
+			// The refunds follow the EIP-2200 gas costs, which EIP-3529 leaves unchanged without EIP-2929
+			if config.Istanbul || config.EIP3529 {
 				txn.AddRefund(19200)
 			} else {
 				txn.AddRefund(19800)
 			}
 		} else { // reset to original existing slot (2.2.2.2)
-			if config.Istanbul {
+			if config.Istanbul || config.EIP3529 {
 				txn.AddRefund(4200)
 			} else {
 				txn.AddRefund(4800)
@@ -306,6 +382,8 @@ func (txn *Txn) SetState(
 	key,
 	value types.Hash,
 ) {
+	txn.recordSlotWrite(addr, key)
+
 	txn.upsertAccount(addr, true, func(object *StateObject) {
 		if object.Txn == nil {
 			object.Txn = iradix.New().Txn()
@@ -321,6 +399,8 @@ func (txn *Txn) SetState(
 
 // GetState returns the state of the address at a given key
 func (txn *Txn) GetState(addr types.Address, key types.Hash) types.Hash {
+	txn.recordSlotRead(addr, key)
+
 	object, exists := txn.getStateObject(addr)
 	if !exists {
 		return types.Hash{}
@@ -346,6 +426,9 @@ func (txn *Txn) GetState(addr types.Address, key types.Hash) types.Hash {
 
 // IncrNonce increases the nonce of the address
 func (txn *Txn) IncrNonce(addr types.Address) {
+	txn.recordRead(addr)
+	txn.recordWrite(addr)
+
 	txn.upsertAccount(addr, true, func(object *StateObject) {
 		object.Account.Nonce++
 	})
@@ -353,6 +436,8 @@ func (txn *Txn) IncrNonce(addr types.Address) {
 
 // SetNonce reduces the balance
 func (txn *Txn) SetNonce(addr types.Address, nonce uint64) {
+	txn.recordWrite(addr)
+
 	txn.upsertAccount(addr, true, func(object *StateObject) {
 		object.Account.Nonce = nonce
 	})
@@ -360,6 +445,8 @@ func (txn *Txn) SetNonce(addr types.Address, nonce uint64) {
 
 // GetNonce returns the nonce of an addr
 func (txn *Txn) GetNonce(addr types.Address) uint64 {
+	txn.recordRead(addr)
+
 	object, exists := txn.getStateObject(addr)
 	if !exists {
 		return 0
@@ -372,6 +459,8 @@ func (txn *Txn) GetNonce(addr types.Address) uint64 {
 
 // SetCode sets the code for an address
 func (txn *Txn) SetCode(addr types.Address, code []byte) {
+	txn.recordWrite(addr)
+
 	txn.upsertAccount(addr, true, func(object *StateObject) {
 		object.Account.CodeHash = crypto.Keccak256(code)
 		object.DirtyCode = true
@@ -380,6 +469,8 @@ func (txn *Txn) SetCode(addr types.Address, code []byte) {
 }
 
 func (txn *Txn) GetCode(addr types.Address) []byte {
+	txn.recordRead(addr)
+
 	object, exists := txn.getStateObject(addr)
 	if !exists {
 		return nil
@@ -407,6 +498,8 @@ func (txn *Txn) GetCodeSize(addr types.Address) int {
 }
 
 func (txn *Txn) GetCodeHash(addr types.Address) types.Hash {
+	txn.recordRead(addr)
+
 	object, exists := txn.getStateObject(addr)
 	if !exists {
 		return types.Hash{}
@@ -417,6 +510,9 @@ func (txn *Txn) GetCodeHash(addr types.Address) types.Hash {
 
 // Suicide marks the given account as suicided
 func (txn *Txn) Suicide(addr types.Address) bool {
+	txn.recordRead(addr)
+	txn.recordWrite(addr)
+
 	var suicided bool
 
 	txn.upsertAccount(addr, false, func(object *StateObject) {
@@ -436,11 +532,41 @@ func (txn *Txn) Suicide(addr types.Address) bool {
 
 // HasSuicided returns true if the account suicided
 func (txn *Txn) HasSuicided(addr types.Address) bool {
+	txn.recordRead(addr)
+
 	object, exists := txn.getStateObject(addr)
 
 	return exists && object.Suicide
 }
 
+// MarkCreated records that the account is created by the current transaction
+func (txn *Txn) MarkCreated(addr types.Address) {
+	created := map[types.Address]struct{}{}
+
+	if data, exists := txn.txn.Get(createdIndex); exists {
+		//nolint:forcetypeassert
+		for a := range data.(map[types.Address]struct{}) {
+			created[a] = struct{}{}
+		}
+	}
+
+	created[addr] = struct{}{}
+	txn.txn.Insert(createdIndex, created)
+}
+
+// IsCreated returns true if the account is created by the current transaction
+func (txn *Txn) IsCreated(addr types.Address) bool {
+	data, exists := txn.txn.Get(createdIndex)
+	if !exists {
+		return false
+	}
+
+	//nolint:forcetypeassert
+	_, ok := data.(map[types.Address]struct{})[addr]
+
+	return ok
+}
+
 // Refund
 func (txn *Txn) AddRefund(gas uint64) {
 	refund := txn.GetRefund() + gas
@@ -475,6 +601,8 @@ func (txn *Txn) GetRefund() uint64 {
 
 // GetCommittedState returns the state of the address in the trie
 func (txn *Txn) GetCommittedState(addr types.Address, key types.Hash) types.Hash {
+	txn.recordSlotRead(addr, key)
+
 	obj, ok := txn.getStateObject(addr)
 	if !ok {
 		return types.Hash{}
@@ -484,6 +612,8 @@ func (txn *Txn) GetCommittedState(addr types.Address, key types.Hash) types.Hash
 }
 
 func (txn *Txn) TouchAccount(addr types.Address) {
+	txn.recordWrite(addr)
+
 	txn.upsertAccount(addr, true, func(obj *StateObject) {
 
 	})
@@ -492,12 +622,16 @@ func (txn *Txn) TouchAccount(addr types.Address) {
 // TODO, check panics with this ones
 
 func (txn *Txn) Exist(addr types.Address) bool {
+	txn.recordRead(addr)
+
 	_, exists := txn.getStateObject(addr)
 
 	return exists
 }
 
 func (txn *Txn) Empty(addr types.Address) bool {
+	txn.recordRead(addr)
+
 	obj, exists := txn.getStateObject(addr)
 	if !exists {
 		return true
@@ -517,6 +651,9 @@ func newStateObject(txn *Txn) *StateObject {
 }
 
 func (txn *Txn) CreateAccount(addr types.Address) {
+	txn.recordRead(addr)
+	txn.recordWrite(addr)
+
 	obj := &StateObject{
 		Account: &Account{
 			Balance:  big.NewInt(0),
@@ -565,8 +702,9 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) {
 		txn.txn.Insert(k, obj2)
 	}
 
-	// delete refunds
+	// delete refunds and the accounts created by the transaction
 	txn.txn.Delete(refundIndex)
+	txn.txn.Delete(createdIndex)
 }
 
 func (txn *Txn) Commit(deleteEmptyObjects bool) []*Object {
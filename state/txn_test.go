@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -66,3 +67,88 @@ func TestSnapshotUpdateData(t *testing.T) {
 	txn.RevertToSnapshot(ss)
 	assert.Equal(t, hash1, txn.GetState(addr1, hash1))
 }
+
+func TestSetStorageRefund(t *testing.T) {
+	t.Parallel()
+
+	var (
+		istanbul = &chain.ForksInTime{Constantinople: true, Petersburg: true, Istanbul: true}
+		eip3529  = &chain.ForksInTime{Constantinople: true, Petersburg: true, Istanbul: true, EIP3529: true}
+		slot     = types.StringToHash("3")
+	)
+
+	tests := []struct {
+		name   string
+		config *chain.ForksInTime
+		key    types.Hash
+		// values set in order in the same transaction
+		values []types.Hash
+		refund uint64
+	}{
+		{
+			name:   "clear existing slot",
+			config: istanbul,
+			key:    hash1,
+			values: []types.Hash{types.ZeroHash},
+			refund: 15000,
+		},
+		{
+			name:   "clear existing slot after EIP-3529",
+			config: eip3529,
+			key:    hash1,
+			values: []types.Hash{types.ZeroHash},
+			refund: 4800,
+		},
+		{
+			name:   "restore nonexistent slot",
+			config: istanbul,
+			key:    slot,
+			values: []types.Hash{hash2, types.ZeroHash},
+			refund: 19200,
+		},
+		{
+			name:   "restore nonexistent slot after EIP-3529",
+			config: eip3529,
+			key:    slot,
+			values: []types.Hash{hash2, types.ZeroHash},
+			refund: 19200,
+		},
+		{
+			name:   "restore existing slot",
+			config: istanbul,
+			key:    hash1,
+			values: []types.Hash{hash2, hash1},
+			refund: 4200,
+		},
+		{
+			name:   "restore existing slot after EIP-3529",
+			config: eip3529,
+			key:    hash1,
+			values: []types.Hash{hash2, hash1},
+			refund: 4200,
+		},
+		{
+			name:   "restore cleared slot after EIP-3529",
+			config: eip3529,
+			key:    hash1,
+			values: []types.Hash{types.ZeroHash, hash1},
+			refund: 4200,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			txn := newTestTxn(defaultPreState)
+
+			for _, value := range tt.values {
+				txn.SetStorage(addr1, tt.key, value, tt.config)
+			}
+
+			assert.Equal(t, tt.refund, txn.GetRefund())
+		})
+	}
+}
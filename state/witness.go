@@ -0,0 +1,122 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrWitnessNotSupported = errors.New("the state does not support merkle proofs")
+
+// Witness are the trie nodes and the contract codes of the parent state touched
+// by the execution of a block, enough to re-execute it without the state
+type Witness struct {
+	// the RLP encoded nodes on the paths to the accounts and the storage slots
+	Nodes [][]byte
+
+	Codes [][]byte
+}
+
+// RecordWitness records the state locations touched by the transactions applied from now on
+func (t *Transition) RecordWitness() {
+	t.state.touched = make(map[location]struct{})
+}
+
+// Witness returns the witness of the transactions applied since RecordWitness,
+// the root is the one of the parent state the transition was started at
+func (t *Transition) Witness(root types.Hash) (*Witness, error) {
+	prover, ok := t.auxState.(Prover)
+	if !ok {
+		return nil, ErrWitnessNotSupported
+	}
+
+	var (
+		nodes = map[types.Hash][]byte{}
+		codes = map[types.Hash][]byte{}
+
+		// the touched slots of each touched account
+		slots = map[types.Address][]types.Hash{}
+	)
+
+	addNodes := func(proofRoot types.Hash, key []byte) error {
+		proof, err := prover.GetProof(proofRoot, key)
+		if err != nil {
+			return err
+		}
+
+		for _, node := range proof {
+			nodes[types.BytesToHash(crypto.Keccak256(node))] = node
+		}
+
+		return nil
+	}
+
+	for loc := range t.state.touched {
+		if _, ok := slots[loc.addr]; !ok {
+			slots[loc.addr] = []types.Hash{}
+		}
+
+		if loc.storage {
+			slots[loc.addr] = append(slots[loc.addr], loc.slot)
+		}
+	}
+
+	for addr, keys := range slots {
+		if err := addNodes(root, crypto.Keccak256(addr.Bytes())); err != nil {
+			return nil, err
+		}
+
+		account, err := t.snap.GetAccount(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		if account == nil {
+			// the proof of the absence is enough
+			continue
+		}
+
+		if !bytes.Equal(account.CodeHash, emptyCodeHash) {
+			codeHash := types.BytesToHash(account.CodeHash)
+			if code, ok := t.auxState.GetCode(codeHash); ok {
+				codes[codeHash] = code
+			}
+		}
+
+		if account.Root == types.ZeroHash {
+			continue
+		}
+
+		for _, key := range keys {
+			if err := addNodes(account.Root, crypto.Keccak256(key.Bytes())); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &Witness{
+		Nodes: sortedByHash(nodes),
+		Codes: sortedByHash(codes),
+	}, nil
+}
+
+func sortedByHash(entries map[types.Hash][]byte) [][]byte {
+	hashes := make([]types.Hash, 0, len(entries))
+	for hash := range entries {
+		hashes = append(hashes, hash)
+	}
+
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i].Bytes(), hashes[j].Bytes()) < 0
+	})
+
+	res := make([][]byte, 0, len(hashes))
+	for _, hash := range hashes {
+		res = append(res, entries[hash])
+	}
+
+	return res
+}
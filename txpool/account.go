@@ -252,8 +252,9 @@ func (a *account) enqueue(tx *types.Transaction) error {
 //
 // Eligible transactions are all sequential in order of nonce
 // and the first one has to have nonce less (or equal) to the account's
-// nextNonce.
-func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Transaction) {
+// nextNonce. The free transactions of the account, promoted or not,
+// can't use more than freeGasLeft, the first one exceeding it stays enqueued.
+func (a *account) promote(freeGasLeft uint64) (promoted []*types.Transaction, pruned []*types.Transaction) {
 	a.promoted.lock(true)
 	a.enqueued.lock(true)
 
@@ -271,6 +272,19 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 
 	nextNonce := a.enqueued.peek().Nonce
 
+	// the free transactions already promoted use the quota first
+	for _, tx := range a.promoted.queue {
+		if !isFreeTx(tx) {
+			continue
+		}
+
+		if tx.Gas >= freeGasLeft {
+			freeGasLeft = 0
+		} else {
+			freeGasLeft -= tx.Gas
+		}
+	}
+
 	// move all promotable txs (enqueued txs that are sequential in nonce)
 	// to the account's promoted queue
 	for {
@@ -279,6 +293,14 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 			break
 		}
 
+		if isFreeTx(tx) {
+			if tx.Gas > freeGasLeft {
+				break
+			}
+
+			freeGasLeft -= tx.Gas
+		}
+
 		// pop from enqueued
 		tx = a.enqueued.pop()
 
@@ -0,0 +1,406 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto/threshold"
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// encryptedTopicNameV1 is the topic of the decryption shares of the encrypted transactions
+	encryptedTopicNameV1 = "txpool-encrypted/0.1"
+
+	// encryptedRetention is the number of blocks whose decrypted transactions are kept
+	encryptedRetention = 16
+)
+
+var (
+	// EncryptedTxAddress is the destination of the transactions carrying an encrypted transaction
+	EncryptedTxAddress = types.StringToAddress("1003")
+
+	ErrMalformedDecryptionShares = errors.New("malformed decryption shares")
+	ErrDecryptedTxOutOfOrder     = errors.New("decrypted transaction out of order")
+)
+
+// EncryptionConfig is the configuration of the encrypted mempool
+type EncryptionConfig struct {
+	// Threshold is the number of decryption shares decrypting a transaction
+	Threshold int
+
+	// PublicShares are the public keys of the key shares, by share index starting at 1
+	PublicShares [][]byte
+
+	// Share is the key share of this node, nil if it doesn't take part in the decryption
+	Share *threshold.Share
+}
+
+// EncryptTx returns the input of a transaction to EncryptedTxAddress carrying the given
+// signed transaction, encrypted to the aggregate key of the validators. The input starts
+// with the hash of the transaction, binding the proposers to its content
+func EncryptTx(publicKey []byte, tx *types.Transaction) ([]byte, error) {
+	raw := tx.MarshalRLP()
+
+	ciphertext, err := threshold.Encrypt(publicKey, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(keccak.Keccak256(nil, raw), ciphertext...), nil
+}
+
+// encryptedTx is a transaction carried encrypted by a transaction to EncryptedTxAddress
+type encryptedTx struct {
+	hash       types.Hash
+	ciphertext []byte
+}
+
+// encryptedTxs returns the encrypted transactions carried by the given transactions, in order
+func encryptedTxs(txs []*types.Transaction) []*encryptedTx {
+	res := make([]*encryptedTx, 0)
+
+	for _, tx := range txs {
+		if tx.To == nil || *tx.To != EncryptedTxAddress || len(tx.Input) <= types.HashLength {
+			continue
+		}
+
+		res = append(res, &encryptedTx{
+			hash:       types.BytesToHash(tx.Input[:types.HashLength]),
+			ciphertext: tx.Input[types.HashLength:],
+		})
+	}
+
+	return res
+}
+
+// VerifyDecryptedTxs checks that the transactions decrypted from the encrypted transactions
+// of the parent block lead the block, in the order they were encrypted in the parent block
+func VerifyDecryptedTxs(parent, block *types.Block) error {
+	encrypted := encryptedTxs(parent.Transactions)
+	if len(encrypted) == 0 {
+		return nil
+	}
+
+	positions := make(map[types.Hash]int, len(encrypted))
+	for i, tx := range encrypted {
+		positions[tx.hash] = i
+	}
+
+	next, leading := 0, true
+
+	for _, tx := range block.Transactions {
+		position, ok := positions[tx.Hash]
+		if !ok {
+			leading = false
+
+			continue
+		}
+
+		if !leading || position < next {
+			return ErrDecryptedTxOutOfOrder
+		}
+
+		next = position + 1
+	}
+
+	return nil
+}
+
+// encryptedBlock holds the encrypted transactions of a block being decrypted
+type encryptedBlock struct {
+	txs       []*encryptedTx
+	shares    [][]*threshold.DecryptionShare
+	decrypted []*types.Transaction
+
+	// settled marks the transactions either decrypted or failing to be
+	settled []bool
+	pending int
+	doneCh  chan struct{}
+}
+
+func newEncryptedBlock(txs []*encryptedTx) *encryptedBlock {
+	b := &encryptedBlock{
+		txs:       txs,
+		shares:    make([][]*threshold.DecryptionShare, len(txs)),
+		decrypted: make([]*types.Transaction, len(txs)),
+		settled:   make([]bool, len(txs)),
+		pending:   len(txs),
+		doneCh:    make(chan struct{}),
+	}
+
+	if b.pending == 0 {
+		close(b.doneCh)
+	}
+
+	for i, tx := range txs {
+		// nobody can decrypt a malformed ciphertext
+		if err := threshold.ValidateCiphertext(tx.ciphertext); err != nil {
+			b.settle(i, nil)
+		}
+	}
+
+	return b
+}
+
+// settle records the decrypted transaction, nil if the decryption failed
+func (b *encryptedBlock) settle(i int, tx *types.Transaction) {
+	b.decrypted[i] = tx
+	b.settled[i] = true
+
+	if b.pending--; b.pending == 0 {
+		close(b.doneCh)
+	}
+}
+
+// hasShare returns true if the transaction already has the decryption share of the given index
+func (b *encryptedBlock) hasShare(i int, index uint64) bool {
+	for _, share := range b.shares[i] {
+		if share.Index == index {
+			return true
+		}
+	}
+
+	return false
+}
+
+// encryptedPool decrypts the encrypted transactions of the new blocks with the decryption
+// shares gossiped by the validators, to execute them in the next block
+type encryptedPool struct {
+	sync.Mutex
+
+	pool   *TxPool
+	config *EncryptionConfig
+	topic  *network.Topic
+
+	blocks map[uint64]*encryptedBlock
+	latest uint64
+
+	// early holds the decryption shares received before their block
+	early map[uint64][]*proto.DecryptionShares
+}
+
+// startEncryptedPool enables the encrypted mempool, gossiping the decryption shares
+func (p *TxPool) startEncryptedPool(config *EncryptionConfig) error {
+	e := &encryptedPool{
+		pool:   p,
+		config: config,
+		blocks: make(map[uint64]*encryptedBlock),
+		early:  make(map[uint64][]*proto.DecryptionShares),
+	}
+
+	if p.network != nil {
+		topic, err := p.network.NewTopic(encryptedTopicNameV1, &proto.DecryptionShares{})
+		if err != nil {
+			return err
+		}
+
+		topic.SetValidator(e.validateGossipShares)
+
+		if err := topic.Subscribe(e.addGossipShares); err != nil {
+			return err
+		}
+
+		e.topic = topic
+	}
+
+	p.encrypted = e
+
+	return nil
+}
+
+// addBlock starts decrypting the encrypted transactions of the block,
+// publishing the decryption shares of this node
+func (e *encryptedPool) addBlock(block *types.Block) {
+	number := block.Number()
+	encrypted := newEncryptedBlock(encryptedTxs(block.Transactions))
+
+	e.Lock()
+
+	e.blocks[number] = encrypted
+	e.latest = number
+
+	for n := range e.blocks {
+		if n+encryptedRetention <= number {
+			delete(e.blocks, n)
+		}
+	}
+
+	early := e.early[number]
+
+	for n := range e.early {
+		if n <= number {
+			delete(e.early, n)
+		}
+	}
+
+	e.Unlock()
+
+	for _, msg := range early {
+		e.addShares(msg)
+	}
+
+	if len(encrypted.txs) == 0 || e.config.Share == nil || !e.pool.getSealing() {
+		return
+	}
+
+	msg := &proto.DecryptionShares{
+		Number: number,
+		Shares: make([][]byte, len(encrypted.txs)),
+	}
+
+	for i, tx := range encrypted.txs {
+		share, err := e.config.Share.Decrypt(tx.ciphertext)
+		if err != nil {
+			continue
+		}
+
+		msg.Shares[i] = share.Marshal()
+	}
+
+	e.addShares(msg)
+
+	if e.topic != nil {
+		if err := e.topic.Publish(msg); err != nil {
+			e.pool.logger.Error("failed to publish decryption shares", "number", number, "err", err)
+		}
+	}
+}
+
+// addShares adds the verified decryption shares of a block,
+// decrypting the transactions reaching the threshold
+func (e *encryptedPool) addShares(msg *proto.DecryptionShares) {
+	e.Lock()
+	defer e.Unlock()
+
+	encrypted, ok := e.blocks[msg.Number]
+	if !ok {
+		// the block may not be processed yet, its shares are held for a while
+		if msg.Number > e.latest && msg.Number <= e.latest+encryptedRetention &&
+			len(e.early[msg.Number]) < 2*len(e.config.PublicShares) {
+			e.early[msg.Number] = append(e.early[msg.Number], msg)
+		}
+
+		return
+	}
+
+	if len(msg.Shares) != len(encrypted.txs) {
+		return
+	}
+
+	for i, raw := range msg.Shares {
+		if len(raw) == 0 || encrypted.settled[i] {
+			continue
+		}
+
+		share, err := threshold.UnmarshalDecryptionShare(raw)
+		if err != nil || share.Index == 0 || share.Index > uint64(len(e.config.PublicShares)) ||
+			encrypted.hasShare(i, share.Index) {
+			continue
+		}
+
+		if err := share.Verify(e.config.PublicShares[share.Index-1], encrypted.txs[i].ciphertext); err != nil {
+			e.pool.logger.Debug("invalid decryption share", "number", msg.Number, "index", share.Index, "err", err)
+
+			continue
+		}
+
+		encrypted.shares[i] = append(encrypted.shares[i], share)
+
+		if len(encrypted.shares[i]) >= e.config.Threshold {
+			encrypted.settle(i, e.decrypt(encrypted.txs[i], encrypted.shares[i]))
+		}
+	}
+}
+
+// decrypt combines the decryption shares of the transaction,
+// returns nil if it doesn't match the hash it was included with
+func (e *encryptedPool) decrypt(encrypted *encryptedTx, shares []*threshold.DecryptionShare) *types.Transaction {
+	raw, err := threshold.Combine(encrypted.ciphertext, shares, e.config.Threshold)
+	if err != nil {
+		e.pool.logger.Debug("failed to decrypt transaction", "hash", encrypted.hash, "err", err)
+
+		return nil
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(raw); err != nil || tx.Hash != encrypted.hash {
+		e.pool.logger.Debug("invalid decrypted transaction", "hash", encrypted.hash)
+
+		return nil
+	}
+
+	return tx
+}
+
+// validateGossipShares checks the format of the gossiped decryption shares,
+// their proofs are verified once their block is known
+func (e *encryptedPool) validateGossipShares(obj interface{}, _ peer.ID) error {
+	msg, ok := obj.(*proto.DecryptionShares)
+	if !ok {
+		return ErrMalformedDecryptionShares
+	}
+
+	for _, raw := range msg.Shares {
+		if len(raw) != 0 && len(raw) != threshold.DecryptionShareSize {
+			return ErrMalformedDecryptionShares
+		}
+	}
+
+	return nil
+}
+
+func (e *encryptedPool) addGossipShares(obj interface{}, _ peer.ID) {
+	// only the validators decrypt the transactions
+	if !e.pool.getSealing() {
+		return
+	}
+
+	msg, ok := obj.(*proto.DecryptionShares)
+	if !ok {
+		e.pool.logger.Error("failed to cast gossiped message to decryption shares")
+
+		return
+	}
+
+	e.addShares(msg)
+}
+
+// Decrypted returns the transactions decrypted from the encrypted transactions of the given block,
+// in their order in the block. It waits until all of them are decrypted or the context is done
+func (p *TxPool) Decrypted(ctx context.Context, number uint64) []*types.Transaction {
+	if p.encrypted == nil {
+		return nil
+	}
+
+	p.encrypted.Lock()
+	encrypted, ok := p.encrypted.blocks[number]
+	p.encrypted.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	select {
+	case <-encrypted.doneCh:
+	case <-ctx.Done():
+	}
+
+	p.encrypted.Lock()
+	defer p.encrypted.Unlock()
+
+	txs := make([]*types.Transaction, 0, len(encrypted.decrypted))
+
+	for _, tx := range encrypted.decrypted {
+		if tx != nil {
+			txs = append(txs, tx)
+		}
+	}
+
+	return txs
+}
@@ -0,0 +1,170 @@
+package txpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/crypto/threshold"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEncryptedCarrier returns the transaction carrying the given transaction encrypted
+func newEncryptedCarrier(t *testing.T, publicKey []byte, tx *types.Transaction) *types.Transaction {
+	t.Helper()
+
+	input, err := EncryptTx(publicKey, tx)
+	require.NoError(t, err)
+
+	carrier := newTx(addr1, 0, 1)
+	carrier.To = &EncryptedTxAddress
+	carrier.Input = input
+
+	return carrier
+}
+
+func TestEncryptedPool_Decrypt(t *testing.T) {
+	t.Parallel()
+
+	publicKey, shares, err := threshold.GenerateShares(2, 3)
+	require.NoError(t, err)
+
+	key, sender := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(100)
+
+	hidden, err := signer.SignTx(newTx(sender, 0, 1), key)
+	require.NoError(t, err)
+	hidden.ComputeHash()
+
+	malformed := newTx(addr1, 1, 1)
+	malformed.To = &EncryptedTxAddress
+	malformed.Input = make([]byte, types.HashLength+1)
+
+	block := &types.Block{
+		Header: &types.Header{Number: 5},
+		Transactions: []*types.Transaction{
+			newEncryptedCarrier(t, publicKey, hidden),
+			malformed,
+		},
+	}
+
+	config := &EncryptionConfig{
+		Threshold:    2,
+		PublicShares: [][]byte{shares[0].Public(), shares[1].Public(), shares[2].Public()},
+		Share:        shares[0],
+	}
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSealing(true)
+	require.NoError(t, pool.startEncryptedPool(config))
+
+	// the share of another validator arrives before the block
+	share, err := shares[2].Decrypt(block.Transactions[0].Input[types.HashLength:])
+	require.NoError(t, err)
+
+	pool.encrypted.addShares(&proto.DecryptionShares{
+		Number: 5,
+		Shares: [][]byte{share.Marshal(), nil},
+	})
+
+	// the own share of the node reaches the threshold
+	pool.encrypted.addBlock(block)
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFn()
+
+	decrypted := pool.Decrypted(ctx, 5)
+	require.Len(t, decrypted, 1)
+	assert.Equal(t, hidden.Hash, decrypted[0].Hash)
+	assert.Zero(t, decrypted[0].Value.Cmp(big.NewInt(1)))
+
+	// unknown blocks have no decrypted transactions
+	assert.Empty(t, pool.Decrypted(ctx, 4))
+}
+
+func TestEncryptedPool_InvalidShare(t *testing.T) {
+	t.Parallel()
+
+	publicKey, shares, err := threshold.GenerateShares(2, 2)
+	require.NoError(t, err)
+
+	block := &types.Block{
+		Header:       &types.Header{Number: 1},
+		Transactions: []*types.Transaction{newEncryptedCarrier(t, publicKey, newTx(addr2, 0, 1))},
+	}
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	require.NoError(t, pool.startEncryptedPool(&EncryptionConfig{
+		Threshold:    2,
+		PublicShares: [][]byte{shares[0].Public(), shares[1].Public()},
+	}))
+
+	pool.encrypted.addBlock(block)
+
+	// the share is claimed by the index of another share
+	share, err := shares[0].Decrypt(block.Transactions[0].Input[types.HashLength:])
+	require.NoError(t, err)
+
+	share.Index = 2
+
+	pool.encrypted.addShares(&proto.DecryptionShares{Number: 1, Shares: [][]byte{share.Marshal()}})
+	assert.Empty(t, pool.encrypted.blocks[1].shares[0])
+
+	// the transaction isn't decrypted in time
+	ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelFn()
+
+	assert.Empty(t, pool.Decrypted(ctx, 1))
+}
+
+func TestVerifyDecryptedTxs(t *testing.T) {
+	t.Parallel()
+
+	first, second, other := newTx(addr1, 0, 1), newTx(addr1, 1, 1), newTx(addr2, 0, 1)
+	for _, tx := range []*types.Transaction{first, second, other} {
+		tx.ComputeHash()
+	}
+
+	carrier := func(tx *types.Transaction) *types.Transaction {
+		carrier := newTx(addr3, 0, 1)
+		carrier.To = &EncryptedTxAddress
+		carrier.Input = append(tx.Hash.Bytes(), 0x1)
+
+		return carrier
+	}
+
+	parent := &types.Block{
+		Header:       &types.Header{},
+		Transactions: []*types.Transaction{carrier(first), carrier(second)},
+	}
+
+	testCases := []struct {
+		name string
+		txs  []*types.Transaction
+		err  error
+	}{
+		{"decrypted transactions lead", []*types.Transaction{first, second, other}, nil},
+		{"decrypted transaction omitted", []*types.Transaction{second, other}, nil},
+		{"decrypted transactions out of order", []*types.Transaction{second, first}, ErrDecryptedTxOutOfOrder},
+		{"decrypted transaction after another", []*types.Transaction{first, other, second}, ErrDecryptedTxOutOfOrder},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			block := &types.Block{Header: &types.Header{}, Transactions: test.txs}
+
+			assert.ErrorIs(t, VerifyDecryptedTxs(parent, block), test.err)
+		})
+	}
+}
@@ -10,6 +10,11 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+
+	// arrivals holds the arrival sequence number of each transaction,
+	// used by the FIFO ordering of the executables
+	arrivals map[types.Hash]uint64
+	sequence uint64
 }
 
 // add inserts the given transaction into the map. Returns false
@@ -24,6 +29,13 @@ func (m *lookupMap) add(tx *types.Transaction) bool {
 
 	m.all[tx.Hash] = tx
 
+	if m.arrivals == nil {
+		m.arrivals = make(map[types.Hash]uint64)
+	}
+
+	m.sequence++
+	m.arrivals[tx.Hash] = m.sequence
+
 	return true
 }
 
@@ -34,6 +46,7 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.arrivals, tx.Hash)
 	}
 }
 
@@ -49,3 +62,12 @@ func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 
 	return tx, true
 }
+
+// arrival returns the arrival sequence number of the given transaction,
+// 0 if it isn't in the map. [thread-safe]
+func (m *lookupMap) arrival(hash types.Hash) uint64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.arrivals[hash]
+}
@@ -41,6 +41,31 @@ func (m defaultMockStore) GetBalance(types.Hash, types.Address) (*big.Int, error
 	return balance, nil
 }
 
+func (m defaultMockStore) GetStorage(types.Hash, types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+// freezeMockStore is the store of a chain whose freeze contract state is kept in memory
+type freezeMockStore struct {
+	defaultMockStore
+
+	storage map[types.Hash]types.Hash
+}
+
+func (m *freezeMockStore) GetStorage(_ types.Hash, _ types.Address, slot types.Hash) types.Hash {
+	return m.storage[slot]
+}
+
+func (m *freezeMockStore) GetState(_ types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *freezeMockStore) SetState(_ types.Address, key, value types.Hash) {
+	m.storage[key] = value
+}
+
+func (m *freezeMockStore) EmitLog(types.Address, []types.Hash, []byte) {}
+
 type faultyMockStore struct {
 }
 
@@ -60,9 +85,17 @@ func (fms faultyMockStore) GetBalance(root types.Hash, addr types.Address) (*big
 	return nil, fmt.Errorf("unable to fetch account state")
 }
 
+func (fms faultyMockStore) GetStorage(types.Hash, types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
 type mockSigner struct {
 }
 
 func (s *mockSigner) Sender(tx *types.Transaction) (types.Address, error) {
 	return tx.From, nil
 }
+
+func (s *mockSigner) Sponsor(tx *types.Transaction) (types.Address, error) {
+	return tx.Sponsor, nil
+}
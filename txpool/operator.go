@@ -2,6 +2,7 @@ package txpool
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
@@ -78,3 +79,36 @@ func (p *TxPool) Subscribe(
 		}
 	}
 }
+
+// SubscribePending implements the operator endpoint. It streams the transactions admitted to the pool
+// which match the request along with their drop and replace events. The stream is closed
+// if the client doesn't keep up with the pool
+func (p *TxPool) SubscribePending(
+	request *proto.SubscribePendingRequest,
+	stream proto.TxnPoolOperator_SubscribePendingServer,
+) error {
+	filter, err := newPendingFilter(request)
+	if err != nil {
+		return err
+	}
+
+	subscription := p.pendingStream.subscribe(filter, request.BufferSize)
+	defer p.pendingStream.unsubscribe(subscription)
+
+	for {
+		select {
+		case event := <-subscription.eventCh:
+			if sendErr := stream.Send(event); sendErr != nil {
+				return nil
+			}
+		case <-subscription.doneCh:
+			if errors.Is(subscription.err, ErrPendingStreamOverflow) {
+				return subscription.err
+			}
+
+			return nil
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package txpool
+
+import (
+	"container/heap"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Policies ordering the executable transactions of a block
+const (
+	// PriceOrdering includes the highest priced transactions first (default)
+	PriceOrdering = "price"
+
+	// FIFOOrdering includes the transactions in their arrival order
+	FIFOOrdering = "fifo"
+
+	// FairOrdering includes the transactions of the accounts in turns,
+	// the highest priced first within a turn
+	FairOrdering = "fair"
+)
+
+// executablesQueue holds the primaries of the accounts,
+// popped in the order of the block transactions
+type executablesQueue interface {
+	clear()
+	push(tx *types.Transaction)
+	pop() *types.Transaction
+	length() uint64
+}
+
+// newExecutablesQueue returns the executables queue of the given ordering policy
+func newExecutablesQueue(ordering string, index *lookupMap) (executablesQueue, error) {
+	switch ordering {
+	case "", PriceOrdering:
+		return newPricedQueue(), nil
+	case FIFOOrdering:
+		return newFIFOQueue(index), nil
+	case FairOrdering:
+		return newFairQueue(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOrdering, ordering)
+	}
+}
+
+// sortedQueue is a heap of transactions sorted by the given function
+type sortedQueue struct {
+	txs  []*types.Transaction
+	less func(a, b *types.Transaction) bool
+}
+
+func (q *sortedQueue) Len() int {
+	return len(q.txs)
+}
+
+func (q *sortedQueue) Swap(i, j int) {
+	q.txs[i], q.txs[j] = q.txs[j], q.txs[i]
+}
+
+func (q *sortedQueue) Less(i, j int) bool {
+	return q.less(q.txs[i], q.txs[j])
+}
+
+func (q *sortedQueue) Push(x interface{}) {
+	transaction, ok := x.(*types.Transaction)
+	if !ok {
+		return
+	}
+
+	q.txs = append(q.txs, transaction)
+}
+
+func (q *sortedQueue) Pop() interface{} {
+	n := len(q.txs)
+	x := q.txs[n-1]
+	q.txs = q.txs[0 : n-1]
+
+	return x
+}
+
+// fifoQueue pops the transactions in their arrival order in the pool
+type fifoQueue struct {
+	queue sortedQueue
+}
+
+func newFIFOQueue(index *lookupMap) *fifoQueue {
+	return &fifoQueue{
+		queue: sortedQueue{
+			less: func(a, b *types.Transaction) bool {
+				return index.arrival(a.Hash) < index.arrival(b.Hash)
+			},
+		},
+	}
+}
+
+// clear empties the underlying queue.
+func (q *fifoQueue) clear() {
+	q.queue.txs = q.queue.txs[:0]
+}
+
+// push pushes the given transaction onto the queue.
+func (q *fifoQueue) push(tx *types.Transaction) {
+	heap.Push(&q.queue, tx)
+}
+
+// pop removes the earliest arrived transaction from the queue
+// or nil if the queue is empty.
+func (q *fifoQueue) pop() *types.Transaction {
+	if q.length() == 0 {
+		return nil
+	}
+
+	transaction, ok := heap.Pop(&q.queue).(*types.Transaction)
+	if !ok {
+		return nil
+	}
+
+	return transaction
+}
+
+// length returns the number of transactions in the queue.
+func (q *fifoQueue) length() uint64 {
+	return uint64(q.queue.Len())
+}
+
+// fairQueue pops the transactions of the accounts in turns: an account
+// isn't served again until every other account got as many transactions
+type fairQueue struct {
+	queue sortedQueue
+
+	// turns is the number of transactions popped for each account
+	// since the queue was cleared
+	turns map[types.Address]uint64
+}
+
+func newFairQueue() *fairQueue {
+	q := &fairQueue{
+		turns: make(map[types.Address]uint64),
+	}
+
+	q.queue.less = func(a, b *types.Transaction) bool {
+		if turnsA, turnsB := q.turns[a.From], q.turns[b.From]; turnsA != turnsB {
+			return turnsA < turnsB
+		}
+
+		return a.GasPrice.Cmp(b.GasPrice) > 0
+	}
+
+	return q
+}
+
+// clear empties the underlying queue and starts the turns over.
+func (q *fairQueue) clear() {
+	q.queue.txs = q.queue.txs[:0]
+	q.turns = make(map[types.Address]uint64)
+}
+
+// push pushes the given transaction onto the queue.
+func (q *fairQueue) push(tx *types.Transaction) {
+	heap.Push(&q.queue, tx)
+}
+
+// pop removes the transaction of the account with the fewest turns
+// or nil if the queue is empty.
+func (q *fairQueue) pop() *types.Transaction {
+	if q.length() == 0 {
+		return nil
+	}
+
+	transaction, ok := heap.Pop(&q.queue).(*types.Transaction)
+	if !ok {
+		return nil
+	}
+
+	q.turns[transaction.From]++
+
+	return transaction
+}
+
+// length returns the number of transactions in the queue.
+func (q *fairQueue) length() uint64 {
+	return uint64(q.queue.Len())
+}
@@ -0,0 +1,220 @@
+package txpool
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+)
+
+const (
+	// defaultPendingBufferSize is the number of the events buffered for a subscriber by default
+	defaultPendingBufferSize = 1024
+
+	// maxPendingBufferSize is the maximum number of the events buffered for a subscriber
+	maxPendingBufferSize = 64 * 1024
+)
+
+var (
+	ErrPendingStreamOverflow = errors.New("subscriber is too slow, pending transactions stream closed")
+	ErrPendingStreamClosed   = errors.New("pending transactions stream closed")
+)
+
+// pendingFilter selects the transactions streamed to a subscriber
+type pendingFilter struct {
+	// senders are the streamed senders, all senders if empty
+	senders map[types.Address]struct{}
+
+	// minGasPrice is the minimum gas price of the streamed transactions
+	minGasPrice *big.Int
+}
+
+func newPendingFilter(req *proto.SubscribePendingRequest) (*pendingFilter, error) {
+	filter := &pendingFilter{
+		senders:     make(map[types.Address]struct{}, len(req.Senders)),
+		minGasPrice: new(big.Int),
+	}
+
+	for _, sender := range req.Senders {
+		addr := types.Address{}
+		if err := addr.UnmarshalText([]byte(sender)); err != nil {
+			return nil, err
+		}
+
+		filter.senders[addr] = struct{}{}
+	}
+
+	if req.MinGasPrice != "" {
+		if _, ok := filter.minGasPrice.SetString(req.MinGasPrice, 0); !ok {
+			return nil, errors.New("invalid min gas price")
+		}
+	}
+
+	return filter, nil
+}
+
+// match checks if the transaction is streamed to the subscriber
+func (f *pendingFilter) match(tx *types.Transaction) bool {
+	if len(f.senders) > 0 {
+		if _, ok := f.senders[tx.From]; !ok {
+			return false
+		}
+	}
+
+	return tx.GasPrice != nil && tx.GasPrice.Cmp(f.minGasPrice) >= 0
+}
+
+// pendingSubscription is a subscriber of the pending transactions stream
+type pendingSubscription struct {
+	filter *pendingFilter
+
+	// eventCh buffers the events of the subscriber
+	eventCh chan *proto.PendingTxEvent
+
+	// doneCh is closed when the subscription is stopped, err holds the reason
+	doneCh chan struct{}
+	err    error
+	once   sync.Once
+}
+
+// stop closes the subscription with the reason, only the first reason is kept
+func (s *pendingSubscription) stop(err error) {
+	s.once.Do(func() {
+		s.err = err
+		close(s.doneCh)
+	})
+}
+
+// pendingStream streams the pending transactions and their drop and replace events.
+// The events are never blocked by the subscribers, a subscriber which doesn't keep up
+// with its buffer is closed instead
+type pendingStream struct {
+	lock          sync.RWMutex
+	subscriptions map[*pendingSubscription]struct{}
+
+	numSubscriptions int64
+}
+
+func newPendingStream() *pendingStream {
+	return &pendingStream{
+		subscriptions: make(map[*pendingSubscription]struct{}),
+	}
+}
+
+// subscribe registers a new subscriber buffering up to bufferSize events
+func (s *pendingStream) subscribe(filter *pendingFilter, bufferSize uint64) *pendingSubscription {
+	if bufferSize == 0 {
+		bufferSize = defaultPendingBufferSize
+	} else if bufferSize > maxPendingBufferSize {
+		bufferSize = maxPendingBufferSize
+	}
+
+	sub := &pendingSubscription{
+		filter:  filter,
+		eventCh: make(chan *proto.PendingTxEvent, bufferSize),
+		doneCh:  make(chan struct{}),
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.subscriptions[sub] = struct{}{}
+	atomic.AddInt64(&s.numSubscriptions, 1)
+
+	return sub
+}
+
+// unsubscribe removes the subscriber
+func (s *pendingStream) unsubscribe(sub *pendingSubscription) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.subscriptions[sub]; ok {
+		delete(s.subscriptions, sub)
+		atomic.AddInt64(&s.numSubscriptions, -1)
+	}
+
+	sub.stop(ErrPendingStreamClosed)
+}
+
+// close stops all the subscribers
+func (s *pendingStream) close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for sub := range s.subscriptions {
+		sub.stop(ErrPendingStreamClosed)
+	}
+
+	s.subscriptions = make(map[*pendingSubscription]struct{})
+	atomic.StoreInt64(&s.numSubscriptions, 0)
+}
+
+// publish sends the event of the transactions to the subscribers, the ADDED events
+// carry the whole transactions
+func (s *pendingStream) publish(eventType proto.EventType, txs ...*types.Transaction) {
+	if atomic.LoadInt64(&s.numSubscriptions) < 1 || len(txs) == 0 {
+		return
+	}
+
+	for _, tx := range txs {
+		event := &proto.PendingTxEvent{
+			Type:   eventType,
+			TxHash: tx.Hash.String(),
+			From:   tx.From.String(),
+		}
+
+		if eventType == proto.EventType_ADDED {
+			event.Raw = tx.MarshalRLP()
+		}
+
+		s.send(tx, event)
+	}
+}
+
+// publishReplaced sends the REPLACED events of the transactions superseded
+// by the transactions of the same nonce
+func (s *pendingStream) publishReplaced(replaced []*types.Transaction, by map[uint64]*types.Transaction) {
+	if atomic.LoadInt64(&s.numSubscriptions) < 1 {
+		return
+	}
+
+	for _, tx := range replaced {
+		event := &proto.PendingTxEvent{
+			Type:   proto.EventType_REPLACED,
+			TxHash: tx.Hash.String(),
+			From:   tx.From.String(),
+		}
+
+		if replacement, ok := by[tx.Nonce]; ok {
+			event.ReplacedBy = replacement.Hash.String()
+		}
+
+		s.send(tx, event)
+	}
+}
+
+// send pushes the event to the subscribers interested in the transaction [NON-BLOCKING]
+func (s *pendingStream) send(tx *types.Transaction, event *proto.PendingTxEvent) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for sub := range s.subscriptions {
+		if !sub.filter.match(tx) {
+			continue
+		}
+
+		select {
+		case sub.eventCh <- event:
+		default:
+			// the subscriber doesn't keep up, close it rather than slowing down the pool
+			sub.stop(ErrPendingStreamOverflow)
+
+			metrics.IncrCounter([]string{"txpool", "pending_stream", "overflows"}, 1)
+		}
+	}
+}
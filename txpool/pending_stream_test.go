@@ -0,0 +1,105 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingStream_Filter(t *testing.T) {
+	t.Parallel()
+
+	filter, err := newPendingFilter(&proto.SubscribePendingRequest{
+		Senders:     []string{addr1.String()},
+		MinGasPrice: "0x10",
+	})
+	require.NoError(t, err)
+
+	stream := newPendingStream()
+	sub := stream.subscribe(filter, 0)
+
+	cheap := newTx(addr1, 0, 1)
+	cheap.GasPrice = big.NewInt(15)
+
+	expensive := newTx(addr1, 1, 1)
+	expensive.GasPrice = big.NewInt(16)
+
+	otherSender := newTx(addr2, 0, 1)
+	otherSender.GasPrice = big.NewInt(100)
+
+	stream.publish(proto.EventType_ADDED, cheap, expensive, otherSender)
+	stream.publish(proto.EventType_DROPPED, expensive)
+
+	require.Len(t, sub.eventCh, 2)
+
+	added := <-sub.eventCh
+	assert.Equal(t, proto.EventType_ADDED, added.Type)
+	assert.Equal(t, expensive.Hash.String(), added.TxHash)
+	assert.Equal(t, addr1.String(), added.From)
+	assert.Equal(t, expensive.MarshalRLP(), added.Raw)
+
+	dropped := <-sub.eventCh
+	assert.Equal(t, proto.EventType_DROPPED, dropped.Type)
+	assert.Empty(t, dropped.Raw)
+
+	_, err = newPendingFilter(&proto.SubscribePendingRequest{MinGasPrice: "cheap"})
+	assert.Error(t, err)
+}
+
+func TestPendingStream_Replaced(t *testing.T) {
+	t.Parallel()
+
+	stream := newPendingStream()
+	sub := stream.subscribe(&pendingFilter{minGasPrice: new(big.Int)}, 0)
+
+	promoted := newTx(addr1, 3, 1)
+	promoted.Hash = types.StringToHash("1")
+
+	replaced := newTx(addr1, 3, 1)
+	replaced.Hash = types.StringToHash("2")
+
+	stream.publishReplaced(
+		[]*types.Transaction{replaced},
+		map[uint64]*types.Transaction{promoted.Nonce: promoted},
+	)
+
+	event := <-sub.eventCh
+	assert.Equal(t, proto.EventType_REPLACED, event.Type)
+	assert.Equal(t, replaced.Hash.String(), event.TxHash)
+	assert.Equal(t, promoted.Hash.String(), event.ReplacedBy)
+}
+
+func TestPendingStream_Backpressure(t *testing.T) {
+	t.Parallel()
+
+	stream := newPendingStream()
+
+	slow := stream.subscribe(&pendingFilter{minGasPrice: new(big.Int)}, 2)
+	fast := stream.subscribe(&pendingFilter{minGasPrice: new(big.Int)}, 10)
+
+	// the slow subscriber is closed once its buffer is full,
+	// without blocking the other subscribers
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		stream.publish(proto.EventType_ADDED, newTx(addr1, nonce, 1))
+	}
+
+	<-slow.doneCh
+	assert.ErrorIs(t, slow.err, ErrPendingStreamOverflow)
+	assert.Len(t, fast.eventCh, 3)
+
+	select {
+	case <-fast.doneCh:
+		t.Fatal("fast subscriber closed")
+	default:
+	}
+
+	stream.unsubscribe(slow)
+	stream.close()
+
+	<-fast.doneCh
+	assert.ErrorIs(t, fast.err, ErrPendingStreamClosed)
+}
@@ -0,0 +1,116 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	networkGrpc "github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// privateTxProto is the protocol relaying the private transactions to the designated validators
+	privateTxProto = "/private-tx/0.1"
+
+	// privateRelayTimeout is the timeout of relaying a private transaction to a validator
+	privateRelayTimeout = 5 * time.Second
+)
+
+var (
+	ErrPrivateTxNoRoute     = errors.New("no validator to hold the private transaction")
+	ErrPrivateTxNotAccepted = errors.New("node doesn't accept private transactions")
+)
+
+// privateRelay receives the private transactions relayed by the other nodes
+type privateRelay struct {
+	proto.UnimplementedPrivateTxRelayServer
+
+	pool   *TxPool
+	stream *networkGrpc.GrpcStream
+}
+
+// Relay adds the private transaction to the pool, only the validators hold the private transactions
+func (r *privateRelay) Relay(ctx context.Context, req *proto.PrivateTxn) (*empty.Empty, error) {
+	if !r.pool.getSealing() {
+		return nil, ErrPrivateTxNotAccepted
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(req.Raw); err != nil {
+		return nil, err
+	}
+
+	if err := r.pool.addTx(private, tx); err != nil && !errors.Is(err, ErrAlreadyKnown) {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// startPrivateRelay registers the protocol receiving the private transactions
+func (p *TxPool) startPrivateRelay() {
+	relay := &privateRelay{
+		pool:   p,
+		stream: networkGrpc.NewGrpcStream(),
+	}
+
+	proto.RegisterPrivateTxRelayServer(relay.stream.GrpcServer(), relay)
+	relay.stream.Serve()
+	p.network.RegisterProtocol(privateTxProto, relay.stream)
+
+	p.privateRelay = relay
+}
+
+// AddPrivateTx adds a transaction to the pool without gossiping it. The transaction is relayed
+// to the designated validators if any, otherwise it's held locally and only included
+// in the blocks proposed by this node
+func (p *TxPool) AddPrivateTx(tx *types.Transaction) error {
+	if len(p.privatePeers) == 0 && !p.getSealing() {
+		return ErrPrivateTxNoRoute
+	}
+
+	if err := p.addTx(private, tx); err != nil {
+		p.logger.Error("failed to add private tx", "err", err)
+
+		return err
+	}
+
+	if len(p.privatePeers) == 0 || p.network == nil {
+		return nil
+	}
+
+	raw := tx.MarshalRLPWithSidecar()
+
+	for _, peerID := range p.privatePeers {
+		go p.relayPrivateTx(peerID, tx.Hash, raw)
+	}
+
+	return nil
+}
+
+// relayPrivateTx hands the private transaction to the designated validator
+func (p *TxPool) relayPrivateTx(peerID peer.ID, hash types.Hash, raw []byte) {
+	conn, err := p.network.NewProtoConnection(privateTxProto, peerID)
+	if err != nil {
+		p.logger.Error("failed to connect to private tx peer", "peer", peerID, "err", err)
+
+		return
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), privateRelayTimeout)
+	defer cancel()
+
+	if _, err := proto.NewPrivateTxRelayClient(conn).Relay(ctx, &proto.PrivateTxn{Raw: raw}); err != nil {
+		p.logger.Error("failed to relay private tx", "peer", peerID, "hash", hash, "err", err)
+
+		return
+	}
+
+	p.logger.Debug("relayed private tx", "peer", peerID, "hash", hash)
+}
@@ -0,0 +1,92 @@
+package txpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPrivateTx(t *testing.T) {
+	t.Parallel()
+
+	t.Run("node is a validator", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.SetSealing(true)
+
+		sub := pool.pendingStream.subscribe(&pendingFilter{minGasPrice: new(big.Int)}, 0)
+
+		go func() {
+			assert.NoError(t, pool.AddPrivateTx(newTx(addr1, 0, 1)))
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+
+		// the private transactions are not disclosed to the pending stream subscribers
+		assert.Len(t, sub.eventCh, 0)
+	})
+
+	t.Run("no validator to hold the transaction", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+		pool.SetSealing(false)
+
+		assert.ErrorIs(t, pool.AddPrivateTx(newTx(addr1, 0, 1)), ErrPrivateTxNoRoute)
+	})
+}
+
+func TestPrivateRelay(t *testing.T) {
+	t.Parallel()
+
+	key, sender := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(100)
+
+	signedTx, err := signer.SignTx(newTx(sender, 0, 1), key)
+	require.NoError(t, err)
+
+	t.Run("node is a validator", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(signer)
+		pool.SetSealing(true)
+
+		relay := &privateRelay{pool: pool}
+
+		go func() {
+			_, err := relay.Relay(context.Background(), &proto.PrivateTxn{Raw: signedTx.MarshalRLP()})
+			assert.NoError(t, err)
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+		assert.Equal(t, uint64(1), pool.accounts.get(sender).enqueued.length())
+	})
+
+	t.Run("node is a non validator", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(signer)
+		pool.SetSealing(false)
+
+		relay := &privateRelay{pool: pool}
+
+		_, err = relay.Relay(context.Background(), &proto.PrivateTxn{Raw: signedTx.MarshalRLP()})
+		assert.ErrorIs(t, err, ErrPrivateTxNotAccepted)
+	})
+}
@@ -0,0 +1,104 @@
+package proto_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// releasedTxnPoolOperatorAPI is the TxnPoolOperator API served under the unversioned v1 package,
+// which must stay wire compatible
+var releasedTxnPoolOperatorAPI = map[string]string{
+	"TxnPoolOperator.Status":    "(google.protobuf.Empty) returns (TxnPoolStatusResp)",
+	"TxnPoolOperator.AddTxn":    "(AddTxnReq) returns (AddTxnResp)",
+	"TxnPoolOperator.Subscribe": "(SubscribeRequest) returns (stream TxPoolEvent)",
+	"AddTxnReq.raw":             "1 message google.protobuf.Any",
+	"AddTxnReq.from":            "2 string",
+	"AddTxnResp.txHash":         "1 string",
+	"TxnPoolStatusResp.length":  "1 uint64",
+	"SubscribeRequest.types":    "1 repeated enum EventType",
+	"TxPoolEvent.type":          "1 enum EventType",
+	"TxPoolEvent.txHash":        "2 string",
+	"EventType.ADDED":           "0",
+	"EventType.ENQUEUED":        "1",
+	"EventType.PROMOTED":        "2",
+	"EventType.DROPPED":         "3",
+	"EventType.DEMOTED":         "4",
+	"EventType.PRUNED_PROMOTED": "5",
+	"EventType.PRUNED_ENQUEUED": "6",
+}
+
+func TestTxnPoolOperatorAPICompatibility(t *testing.T) {
+	t.Parallel()
+
+	tests.AssertProtoCompatible(t, proto.File_txpool_proto_operator_proto, releasedTxnPoolOperatorAPI)
+}
+
+type mockTxnPoolOperatorServer struct {
+	proto.UnimplementedTxnPoolOperatorServer
+}
+
+func (m *mockTxnPoolOperatorServer) Status(context.Context, *emptypb.Empty) (*proto.TxnPoolStatusResp, error) {
+	return &proto.TxnPoolStatusResp{Length: 10}, nil
+}
+
+func newTestTxnPoolOperatorConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	srv := &mockTxnPoolOperatorServer{}
+
+	proto.RegisterTxnPoolOperatorServer(s, srv)
+	proto.RegisterLegacyTxnPoolOperatorServer(s, srv)
+
+	go func() {
+		_ = s.Serve(lis)
+	}()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+
+	return conn
+}
+
+func TestLegacyTxnPoolOperatorService(t *testing.T) {
+	t.Parallel()
+
+	conn := newTestTxnPoolOperatorConn(t)
+
+	// versioned client
+	resp, err := proto.NewTxnPoolOperatorClient(conn).Status(context.Background(), &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), resp.Length)
+
+	// client generated from the unversioned package
+	legacyResp := &proto.TxnPoolStatusResp{}
+	assert.NoError(t, conn.Invoke(
+		context.Background(),
+		"/"+proto.LegacyTxnPoolOperatorServiceName+"/Status",
+		&emptypb.Empty{},
+		legacyResp,
+	))
+	assert.Equal(t, uint64(10), legacyResp.Length)
+}
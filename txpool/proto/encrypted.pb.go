@@ -0,0 +1,157 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.12.4
+// source: txpool/proto/encrypted.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DecryptionShares are the shares of a validator decrypting the encrypted
+// transactions of a block
+type DecryptionShares struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// number of the block including the encrypted transactions
+	Number uint64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// decryption shares, one per encrypted transaction of the block
+	Shares [][]byte `protobuf:"bytes,2,rep,name=shares,proto3" json:"shares,omitempty"`
+}
+
+func (x *DecryptionShares) Reset() {
+	*x = DecryptionShares{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_proto_encrypted_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecryptionShares) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecryptionShares) ProtoMessage() {}
+
+func (x *DecryptionShares) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_proto_encrypted_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecryptionShares.ProtoReflect.Descriptor instead.
+func (*DecryptionShares) Descriptor() ([]byte, []int) {
+	return file_txpool_proto_encrypted_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DecryptionShares) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *DecryptionShares) GetShares() [][]byte {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+var File_txpool_proto_encrypted_proto protoreflect.FileDescriptor
+
+var file_txpool_proto_encrypted_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65,
+	0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09,
+	0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x22, 0x42, 0x0a, 0x10, 0x44, 0x65, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x42, 0x0f, 0x5a,
+	0x0d, 0x2f, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_txpool_proto_encrypted_proto_rawDescOnce sync.Once
+	file_txpool_proto_encrypted_proto_rawDescData = file_txpool_proto_encrypted_proto_rawDesc
+)
+
+func file_txpool_proto_encrypted_proto_rawDescGZIP() []byte {
+	file_txpool_proto_encrypted_proto_rawDescOnce.Do(func() {
+		file_txpool_proto_encrypted_proto_rawDescData = protoimpl.X.CompressGZIP(file_txpool_proto_encrypted_proto_rawDescData)
+	})
+	return file_txpool_proto_encrypted_proto_rawDescData
+}
+
+var file_txpool_proto_encrypted_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_txpool_proto_encrypted_proto_goTypes = []interface{}{
+	(*DecryptionShares)(nil), // 0: txpool.v1.DecryptionShares
+}
+var file_txpool_proto_encrypted_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_txpool_proto_encrypted_proto_init() }
+func file_txpool_proto_encrypted_proto_init() {
+	if File_txpool_proto_encrypted_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_txpool_proto_encrypted_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecryptionShares); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_txpool_proto_encrypted_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_txpool_proto_encrypted_proto_goTypes,
+		DependencyIndexes: file_txpool_proto_encrypted_proto_depIdxs,
+		MessageInfos:      file_txpool_proto_encrypted_proto_msgTypes,
+	}.Build()
+	File_txpool_proto_encrypted_proto = out.File
+	file_txpool_proto_encrypted_proto_rawDesc = nil
+	file_txpool_proto_encrypted_proto_goTypes = nil
+	file_txpool_proto_encrypted_proto_depIdxs = nil
+}
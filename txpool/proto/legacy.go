@@ -0,0 +1,16 @@
+package proto
+
+import "google.golang.org/grpc"
+
+// LegacyTxnPoolOperatorServiceName is the name the TxnPoolOperator service was served under
+// before it was moved to the versioned txpool.v1 package
+const LegacyTxnPoolOperatorServiceName = "v1.TxnPoolOperator"
+
+// RegisterLegacyTxnPoolOperatorServer registers the TxnPoolOperator service under its legacy name,
+// so the clients generated from the unversioned package keep working
+func RegisterLegacyTxnPoolOperatorServer(s grpc.ServiceRegistrar, srv TxnPoolOperatorServer) {
+	desc := TxnPoolOperator_ServiceDesc
+	desc.ServiceName = LegacyTxnPoolOperatorServiceName
+
+	s.RegisterService(&desc, srv)
+}
@@ -1,8 +1,8 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.27.1
-// 	protoc        v3.19.3
-// source: operator.proto
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.12.4
+// source: txpool/proto/operator.proto
 
 package proto
 
@@ -39,6 +39,9 @@ const (
 	EventType_PRUNED_PROMOTED EventType = 5
 	// For pruned enqueued transactions
 	EventType_PRUNED_ENQUEUED EventType = 6
+	// For enqueued transactions superseded by a promoted transaction
+	// of the same sender and nonce
+	EventType_REPLACED EventType = 7
 )
 
 // Enum value maps for EventType.
@@ -51,6 +54,7 @@ var (
 		4: "DEMOTED",
 		5: "PRUNED_PROMOTED",
 		6: "PRUNED_ENQUEUED",
+		7: "REPLACED",
 	}
 	EventType_value = map[string]int32{
 		"ADDED":           0,
@@ -60,6 +64,7 @@ var (
 		"DEMOTED":         4,
 		"PRUNED_PROMOTED": 5,
 		"PRUNED_ENQUEUED": 6,
+		"REPLACED":        7,
 	}
 )
 
@@ -74,11 +79,11 @@ func (x EventType) String() string {
 }
 
 func (EventType) Descriptor() protoreflect.EnumDescriptor {
-	return file_operator_proto_enumTypes[0].Descriptor()
+	return file_txpool_proto_operator_proto_enumTypes[0].Descriptor()
 }
 
 func (EventType) Type() protoreflect.EnumType {
-	return &file_operator_proto_enumTypes[0]
+	return &file_txpool_proto_operator_proto_enumTypes[0]
 }
 
 func (x EventType) Number() protoreflect.EnumNumber {
@@ -87,7 +92,7 @@ func (x EventType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use EventType.Descriptor instead.
 func (EventType) EnumDescriptor() ([]byte, []int) {
-	return file_operator_proto_rawDescGZIP(), []int{0}
+	return file_txpool_proto_operator_proto_rawDescGZIP(), []int{0}
 }
 
 type AddTxnReq struct {
@@ -102,7 +107,7 @@ type AddTxnReq struct {
 func (x *AddTxnReq) Reset() {
 	*x = AddTxnReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_operator_proto_msgTypes[0]
+		mi := &file_txpool_proto_operator_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -115,7 +120,7 @@ func (x *AddTxnReq) String() string {
 func (*AddTxnReq) ProtoMessage() {}
 
 func (x *AddTxnReq) ProtoReflect() protoreflect.Message {
-	mi := &file_operator_proto_msgTypes[0]
+	mi := &file_txpool_proto_operator_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -128,7 +133,7 @@ func (x *AddTxnReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddTxnReq.ProtoReflect.Descriptor instead.
 func (*AddTxnReq) Descriptor() ([]byte, []int) {
-	return file_operator_proto_rawDescGZIP(), []int{0}
+	return file_txpool_proto_operator_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *AddTxnReq) GetRaw() *anypb.Any {
@@ -156,7 +161,7 @@ type AddTxnResp struct {
 func (x *AddTxnResp) Reset() {
 	*x = AddTxnResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_operator_proto_msgTypes[1]
+		mi := &file_txpool_proto_operator_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -169,7 +174,7 @@ func (x *AddTxnResp) String() string {
 func (*AddTxnResp) ProtoMessage() {}
 
 func (x *AddTxnResp) ProtoReflect() protoreflect.Message {
-	mi := &file_operator_proto_msgTypes[1]
+	mi := &file_txpool_proto_operator_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -182,7 +187,7 @@ func (x *AddTxnResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddTxnResp.ProtoReflect.Descriptor instead.
 func (*AddTxnResp) Descriptor() ([]byte, []int) {
-	return file_operator_proto_rawDescGZIP(), []int{1}
+	return file_txpool_proto_operator_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *AddTxnResp) GetTxHash() string {
@@ -203,7 +208,7 @@ type TxnPoolStatusResp struct {
 func (x *TxnPoolStatusResp) Reset() {
 	*x = TxnPoolStatusResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_operator_proto_msgTypes[2]
+		mi := &file_txpool_proto_operator_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -216,7 +221,7 @@ func (x *TxnPoolStatusResp) String() string {
 func (*TxnPoolStatusResp) ProtoMessage() {}
 
 func (x *TxnPoolStatusResp) ProtoReflect() protoreflect.Message {
-	mi := &file_operator_proto_msgTypes[2]
+	mi := &file_txpool_proto_operator_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,7 +234,7 @@ func (x *TxnPoolStatusResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TxnPoolStatusResp.ProtoReflect.Descriptor instead.
 func (*TxnPoolStatusResp) Descriptor() ([]byte, []int) {
-	return file_operator_proto_rawDescGZIP(), []int{2}
+	return file_txpool_proto_operator_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *TxnPoolStatusResp) GetLength() uint64 {
@@ -245,13 +250,13 @@ type SubscribeRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Requested event types
-	Types []EventType `protobuf:"varint,1,rep,packed,name=types,proto3,enum=v1.EventType" json:"types,omitempty"`
+	Types []EventType `protobuf:"varint,1,rep,packed,name=types,proto3,enum=txpool.v1.EventType" json:"types,omitempty"`
 }
 
 func (x *SubscribeRequest) Reset() {
 	*x = SubscribeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_operator_proto_msgTypes[3]
+		mi := &file_txpool_proto_operator_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -264,7 +269,7 @@ func (x *SubscribeRequest) String() string {
 func (*SubscribeRequest) ProtoMessage() {}
 
 func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_operator_proto_msgTypes[3]
+	mi := &file_txpool_proto_operator_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -277,7 +282,7 @@ func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return file_operator_proto_rawDescGZIP(), []int{3}
+	return file_txpool_proto_operator_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SubscribeRequest) GetTypes() []EventType {
@@ -292,14 +297,14 @@ type TxPoolEvent struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type   EventType `protobuf:"varint,1,opt,name=type,proto3,enum=v1.EventType" json:"type,omitempty"`
+	Type   EventType `protobuf:"varint,1,opt,name=type,proto3,enum=txpool.v1.EventType" json:"type,omitempty"`
 	TxHash string    `protobuf:"bytes,2,opt,name=txHash,proto3" json:"txHash,omitempty"`
 }
 
 func (x *TxPoolEvent) Reset() {
 	*x = TxPoolEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_operator_proto_msgTypes[4]
+		mi := &file_txpool_proto_operator_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -312,7 +317,7 @@ func (x *TxPoolEvent) String() string {
 func (*TxPoolEvent) ProtoMessage() {}
 
 func (x *TxPoolEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_operator_proto_msgTypes[4]
+	mi := &file_txpool_proto_operator_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -325,7 +330,7 @@ func (x *TxPoolEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TxPoolEvent.ProtoReflect.Descriptor instead.
 func (*TxPoolEvent) Descriptor() ([]byte, []int) {
-	return file_operator_proto_rawDescGZIP(), []int{4}
+	return file_txpool_proto_operator_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *TxPoolEvent) GetType() EventType {
@@ -342,102 +347,282 @@ func (x *TxPoolEvent) GetTxHash() string {
 	return ""
 }
 
-var File_operator_proto protoreflect.FileDescriptor
-
-var file_operator_proto_rawDesc = []byte{
-	0x0a, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x02, 0x76, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
-	0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x47, 0x0a, 0x09,
-	0x41, 0x64, 0x64, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x03, 0x72, 0x61, 0x77,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x03, 0x72, 0x61,
-	0x77, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x22, 0x24, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x54, 0x78, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x22, 0x2b, 0x0a, 0x11, 0x54,
-	0x78, 0x6e, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x37, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73,
-	0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x05,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x76, 0x31,
-	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x22, 0x48, 0x0a, 0x0b, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x12, 0x21, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d,
-	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x2a, 0x76, 0x0a, 0x09, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x44, 0x44, 0x45,
-	0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x4e, 0x51, 0x55, 0x45, 0x55, 0x45, 0x44, 0x10,
-	0x01, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x52, 0x4f, 0x4d, 0x4f, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12,
-	0x0b, 0x0a, 0x07, 0x44, 0x52, 0x4f, 0x50, 0x50, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07,
-	0x44, 0x45, 0x4d, 0x4f, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x52, 0x55,
-	0x4e, 0x45, 0x44, 0x5f, 0x50, 0x52, 0x4f, 0x4d, 0x4f, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x13,
-	0x0a, 0x0f, 0x50, 0x52, 0x55, 0x4e, 0x45, 0x44, 0x5f, 0x45, 0x4e, 0x51, 0x55, 0x45, 0x55, 0x45,
-	0x44, 0x10, 0x06, 0x32, 0xa9, 0x01, 0x0a, 0x0f, 0x54, 0x78, 0x6e, 0x50, 0x6f, 0x6f, 0x6c, 0x4f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x37, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x54,
-	0x78, 0x6e, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x12, 0x27, 0x0a, 0x06, 0x41, 0x64, 0x64, 0x54, 0x78, 0x6e, 0x12, 0x0d, 0x2e, 0x76, 0x31, 0x2e,
-	0x41, 0x64, 0x64, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x41,
-	0x64, 0x64, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x34, 0x0a, 0x09, 0x53, 0x75, 0x62,
-	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73,
-	0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x76,
-	0x31, 0x2e, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42,
-	0x0f, 0x5a, 0x0d, 0x2f, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+type SubscribePendingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Senders of the streamed transactions, all senders if empty
+	Senders []string `protobuf:"bytes,1,rep,name=senders,proto3" json:"senders,omitempty"`
+	// Minimum gas price of the streamed transactions in wei
+	MinGasPrice string `protobuf:"bytes,2,opt,name=minGasPrice,proto3" json:"minGasPrice,omitempty"`
+	// Number of the events buffered for a slow client before its stream is closed
+	BufferSize uint64 `protobuf:"varint,3,opt,name=bufferSize,proto3" json:"bufferSize,omitempty"`
+}
+
+func (x *SubscribePendingRequest) Reset() {
+	*x = SubscribePendingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_proto_operator_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribePendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribePendingRequest) ProtoMessage() {}
+
+func (x *SubscribePendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_proto_operator_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribePendingRequest.ProtoReflect.Descriptor instead.
+func (*SubscribePendingRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_proto_operator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubscribePendingRequest) GetSenders() []string {
+	if x != nil {
+		return x.Senders
+	}
+	return nil
+}
+
+func (x *SubscribePendingRequest) GetMinGasPrice() string {
+	if x != nil {
+		return x.MinGasPrice
+	}
+	return ""
+}
+
+func (x *SubscribePendingRequest) GetBufferSize() uint64 {
+	if x != nil {
+		return x.BufferSize
+	}
+	return 0
+}
+
+type PendingTxEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type   EventType `protobuf:"varint,1,opt,name=type,proto3,enum=txpool.v1.EventType" json:"type,omitempty"`
+	TxHash string    `protobuf:"bytes,2,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	From   string    `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// RLP encoded transaction, set for the ADDED events
+	Raw []byte `protobuf:"bytes,4,opt,name=raw,proto3" json:"raw,omitempty"`
+	// Hash of the superseding transaction, set for the REPLACED events
+	ReplacedBy string `protobuf:"bytes,5,opt,name=replacedBy,proto3" json:"replacedBy,omitempty"`
+}
+
+func (x *PendingTxEvent) Reset() {
+	*x = PendingTxEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_proto_operator_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PendingTxEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingTxEvent) ProtoMessage() {}
+
+func (x *PendingTxEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_proto_operator_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingTxEvent.ProtoReflect.Descriptor instead.
+func (*PendingTxEvent) Descriptor() ([]byte, []int) {
+	return file_txpool_proto_operator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PendingTxEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_ADDED
+}
+
+func (x *PendingTxEvent) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *PendingTxEvent) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *PendingTxEvent) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *PendingTxEvent) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+var File_txpool_proto_operator_proto protoreflect.FileDescriptor
+
+var file_txpool_proto_operator_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x74,
+	0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x47, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a,
+	0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79,
+	0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x22, 0x24, 0x0a, 0x0a, 0x41, 0x64, 0x64,
+	0x54, 0x78, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x22,
+	0x2b, 0x0a, 0x11, 0x54, 0x78, 0x6e, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x3e, 0x0a, 0x10,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2a, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x14, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x4f, 0x0a, 0x0b,
+	0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x78, 0x70, 0x6f,
+	0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x22, 0x75, 0x0a,
+	0x17, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x47, 0x61, 0x73, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72,
+	0x53, 0x69, 0x7a, 0x65, 0x22, 0x98, 0x01, 0x0a, 0x0e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x54, 0x78, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x10, 0x0a,
+	0x03, 0x72, 0x61, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12,
+	0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x42, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x42, 0x79, 0x2a,
+	0x84, 0x01, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a,
+	0x05, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x4e, 0x51, 0x55,
+	0x45, 0x55, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x52, 0x4f, 0x4d, 0x4f, 0x54,
+	0x45, 0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x52, 0x4f, 0x50, 0x50, 0x45, 0x44, 0x10,
+	0x03, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x4d, 0x4f, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x13,
+	0x0a, 0x0f, 0x50, 0x52, 0x55, 0x4e, 0x45, 0x44, 0x5f, 0x50, 0x52, 0x4f, 0x4d, 0x4f, 0x54, 0x45,
+	0x44, 0x10, 0x05, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x52, 0x55, 0x4e, 0x45, 0x44, 0x5f, 0x45, 0x4e,
+	0x51, 0x55, 0x45, 0x55, 0x45, 0x44, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x50, 0x4c,
+	0x41, 0x43, 0x45, 0x44, 0x10, 0x07, 0x32, 0xa1, 0x02, 0x0a, 0x0f, 0x54, 0x78, 0x6e, 0x50, 0x6f,
+	0x6f, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3e, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x74,
+	0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x78, 0x6e, 0x50, 0x6f, 0x6f, 0x6c,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x35, 0x0a, 0x06, 0x41, 0x64,
+	0x64, 0x54, 0x78, 0x6e, 0x12, 0x14, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x64, 0x64, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x74, 0x78, 0x70,
+	0x6f, 0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x78, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x42, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x1b,
+	0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x78,
+	0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x53, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x22, 0x2e, 0x74, 0x78, 0x70, 0x6f,
+	0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x54, 0x78, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x74,
+	0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
-	file_operator_proto_rawDescOnce sync.Once
-	file_operator_proto_rawDescData = file_operator_proto_rawDesc
+	file_txpool_proto_operator_proto_rawDescOnce sync.Once
+	file_txpool_proto_operator_proto_rawDescData = file_txpool_proto_operator_proto_rawDesc
 )
 
-func file_operator_proto_rawDescGZIP() []byte {
-	file_operator_proto_rawDescOnce.Do(func() {
-		file_operator_proto_rawDescData = protoimpl.X.CompressGZIP(file_operator_proto_rawDescData)
+func file_txpool_proto_operator_proto_rawDescGZIP() []byte {
+	file_txpool_proto_operator_proto_rawDescOnce.Do(func() {
+		file_txpool_proto_operator_proto_rawDescData = protoimpl.X.CompressGZIP(file_txpool_proto_operator_proto_rawDescData)
 	})
-	return file_operator_proto_rawDescData
-}
-
-var file_operator_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_operator_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_operator_proto_goTypes = []interface{}{
-	(EventType)(0),            // 0: v1.EventType
-	(*AddTxnReq)(nil),         // 1: v1.AddTxnReq
-	(*AddTxnResp)(nil),        // 2: v1.AddTxnResp
-	(*TxnPoolStatusResp)(nil), // 3: v1.TxnPoolStatusResp
-	(*SubscribeRequest)(nil),  // 4: v1.SubscribeRequest
-	(*TxPoolEvent)(nil),       // 5: v1.TxPoolEvent
-	(*anypb.Any)(nil),         // 6: google.protobuf.Any
-	(*emptypb.Empty)(nil),     // 7: google.protobuf.Empty
-}
-var file_operator_proto_depIdxs = []int32{
-	6, // 0: v1.AddTxnReq.raw:type_name -> google.protobuf.Any
-	0, // 1: v1.SubscribeRequest.types:type_name -> v1.EventType
-	0, // 2: v1.TxPoolEvent.type:type_name -> v1.EventType
-	7, // 3: v1.TxnPoolOperator.Status:input_type -> google.protobuf.Empty
-	1, // 4: v1.TxnPoolOperator.AddTxn:input_type -> v1.AddTxnReq
-	4, // 5: v1.TxnPoolOperator.Subscribe:input_type -> v1.SubscribeRequest
-	3, // 6: v1.TxnPoolOperator.Status:output_type -> v1.TxnPoolStatusResp
-	2, // 7: v1.TxnPoolOperator.AddTxn:output_type -> v1.AddTxnResp
-	5, // 8: v1.TxnPoolOperator.Subscribe:output_type -> v1.TxPoolEvent
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
-}
-
-func init() { file_operator_proto_init() }
-func file_operator_proto_init() {
-	if File_operator_proto != nil {
+	return file_txpool_proto_operator_proto_rawDescData
+}
+
+var file_txpool_proto_operator_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_txpool_proto_operator_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_txpool_proto_operator_proto_goTypes = []interface{}{
+	(EventType)(0),                  // 0: txpool.v1.EventType
+	(*AddTxnReq)(nil),               // 1: txpool.v1.AddTxnReq
+	(*AddTxnResp)(nil),              // 2: txpool.v1.AddTxnResp
+	(*TxnPoolStatusResp)(nil),       // 3: txpool.v1.TxnPoolStatusResp
+	(*SubscribeRequest)(nil),        // 4: txpool.v1.SubscribeRequest
+	(*TxPoolEvent)(nil),             // 5: txpool.v1.TxPoolEvent
+	(*SubscribePendingRequest)(nil), // 6: txpool.v1.SubscribePendingRequest
+	(*PendingTxEvent)(nil),          // 7: txpool.v1.PendingTxEvent
+	(*anypb.Any)(nil),               // 8: google.protobuf.Any
+	(*emptypb.Empty)(nil),           // 9: google.protobuf.Empty
+}
+var file_txpool_proto_operator_proto_depIdxs = []int32{
+	8, // 0: txpool.v1.AddTxnReq.raw:type_name -> google.protobuf.Any
+	0, // 1: txpool.v1.SubscribeRequest.types:type_name -> txpool.v1.EventType
+	0, // 2: txpool.v1.TxPoolEvent.type:type_name -> txpool.v1.EventType
+	0, // 3: txpool.v1.PendingTxEvent.type:type_name -> txpool.v1.EventType
+	9, // 4: txpool.v1.TxnPoolOperator.Status:input_type -> google.protobuf.Empty
+	1, // 5: txpool.v1.TxnPoolOperator.AddTxn:input_type -> txpool.v1.AddTxnReq
+	4, // 6: txpool.v1.TxnPoolOperator.Subscribe:input_type -> txpool.v1.SubscribeRequest
+	6, // 7: txpool.v1.TxnPoolOperator.SubscribePending:input_type -> txpool.v1.SubscribePendingRequest
+	3, // 8: txpool.v1.TxnPoolOperator.Status:output_type -> txpool.v1.TxnPoolStatusResp
+	2, // 9: txpool.v1.TxnPoolOperator.AddTxn:output_type -> txpool.v1.AddTxnResp
+	5, // 10: txpool.v1.TxnPoolOperator.Subscribe:output_type -> txpool.v1.TxPoolEvent
+	7, // 11: txpool.v1.TxnPoolOperator.SubscribePending:output_type -> txpool.v1.PendingTxEvent
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_txpool_proto_operator_proto_init() }
+func file_txpool_proto_operator_proto_init() {
+	if File_txpool_proto_operator_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_operator_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_txpool_proto_operator_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AddTxnReq); i {
 			case 0:
 				return &v.state
@@ -449,7 +634,7 @@ func file_operator_proto_init() {
 				return nil
 			}
 		}
-		file_operator_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_txpool_proto_operator_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AddTxnResp); i {
 			case 0:
 				return &v.state
@@ -461,7 +646,7 @@ func file_operator_proto_init() {
 				return nil
 			}
 		}
-		file_operator_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_txpool_proto_operator_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TxnPoolStatusResp); i {
 			case 0:
 				return &v.state
@@ -473,7 +658,7 @@ func file_operator_proto_init() {
 				return nil
 			}
 		}
-		file_operator_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_txpool_proto_operator_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SubscribeRequest); i {
 			case 0:
 				return &v.state
@@ -485,7 +670,7 @@ func file_operator_proto_init() {
 				return nil
 			}
 		}
-		file_operator_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_txpool_proto_operator_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TxPoolEvent); i {
 			case 0:
 				return &v.state
@@ -497,24 +682,48 @@ func file_operator_proto_init() {
 				return nil
 			}
 		}
+		file_txpool_proto_operator_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribePendingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_proto_operator_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PendingTxEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_operator_proto_rawDesc,
+			RawDescriptor: file_txpool_proto_operator_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_operator_proto_goTypes,
-		DependencyIndexes: file_operator_proto_depIdxs,
-		EnumInfos:         file_operator_proto_enumTypes,
-		MessageInfos:      file_operator_proto_msgTypes,
+		GoTypes:           file_txpool_proto_operator_proto_goTypes,
+		DependencyIndexes: file_txpool_proto_operator_proto_depIdxs,
+		EnumInfos:         file_txpool_proto_operator_proto_enumTypes,
+		MessageInfos:      file_txpool_proto_operator_proto_msgTypes,
 	}.Build()
-	File_operator_proto = out.File
-	file_operator_proto_rawDesc = nil
-	file_operator_proto_goTypes = nil
-	file_operator_proto_depIdxs = nil
+	File_txpool_proto_operator_proto = out.File
+	file_txpool_proto_operator_proto_rawDesc = nil
+	file_txpool_proto_operator_proto_goTypes = nil
+	file_txpool_proto_operator_proto_depIdxs = nil
 }
@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: txpool/proto/operator.proto
 
 package proto
 
@@ -25,6 +29,9 @@ type TxnPoolOperatorClient interface {
 	AddTxn(ctx context.Context, in *AddTxnReq, opts ...grpc.CallOption) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribeClient, error)
+	// SubscribePending streams the full transactions admitted to the pool
+	// along with their drop and replace events
+	SubscribePending(ctx context.Context, in *SubscribePendingRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribePendingClient, error)
 }
 
 type txnPoolOperatorClient struct {
@@ -37,7 +44,7 @@ func NewTxnPoolOperatorClient(cc grpc.ClientConnInterface) TxnPoolOperatorClient
 
 func (c *txnPoolOperatorClient) Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TxnPoolStatusResp, error) {
 	out := new(TxnPoolStatusResp)
-	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/Status", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/txpool.v1.TxnPoolOperator/Status", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +53,7 @@ func (c *txnPoolOperatorClient) Status(ctx context.Context, in *emptypb.Empty, o
 
 func (c *txnPoolOperatorClient) AddTxn(ctx context.Context, in *AddTxnReq, opts ...grpc.CallOption) (*AddTxnResp, error) {
 	out := new(AddTxnResp)
-	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/AddTxn", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/txpool.v1.TxnPoolOperator/AddTxn", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +61,7 @@ func (c *txnPoolOperatorClient) AddTxn(ctx context.Context, in *AddTxnReq, opts
 }
 
 func (c *txnPoolOperatorClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &TxnPoolOperator_ServiceDesc.Streams[0], "/v1.TxnPoolOperator/Subscribe", opts...)
+	stream, err := c.cc.NewStream(ctx, &TxnPoolOperator_ServiceDesc.Streams[0], "/txpool.v1.TxnPoolOperator/Subscribe", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +92,38 @@ func (x *txnPoolOperatorSubscribeClient) Recv() (*TxPoolEvent, error) {
 	return m, nil
 }
 
+func (c *txnPoolOperatorClient) SubscribePending(ctx context.Context, in *SubscribePendingRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribePendingClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TxnPoolOperator_ServiceDesc.Streams[1], "/txpool.v1.TxnPoolOperator/SubscribePending", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &txnPoolOperatorSubscribePendingClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TxnPoolOperator_SubscribePendingClient interface {
+	Recv() (*PendingTxEvent, error)
+	grpc.ClientStream
+}
+
+type txnPoolOperatorSubscribePendingClient struct {
+	grpc.ClientStream
+}
+
+func (x *txnPoolOperatorSubscribePendingClient) Recv() (*PendingTxEvent, error) {
+	m := new(PendingTxEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TxnPoolOperatorServer is the server API for TxnPoolOperator service.
 // All implementations must embed UnimplementedTxnPoolOperatorServer
 // for forward compatibility
@@ -95,6 +134,9 @@ type TxnPoolOperatorServer interface {
 	AddTxn(context.Context, *AddTxnReq) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error
+	// SubscribePending streams the full transactions admitted to the pool
+	// along with their drop and replace events
+	SubscribePending(*SubscribePendingRequest, TxnPoolOperator_SubscribePendingServer) error
 	mustEmbedUnimplementedTxnPoolOperatorServer()
 }
 
@@ -111,6 +153,9 @@ func (UnimplementedTxnPoolOperatorServer) AddTxn(context.Context, *AddTxnReq) (*
 func (UnimplementedTxnPoolOperatorServer) Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedTxnPoolOperatorServer) SubscribePending(*SubscribePendingRequest, TxnPoolOperator_SubscribePendingServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribePending not implemented")
+}
 func (UnimplementedTxnPoolOperatorServer) mustEmbedUnimplementedTxnPoolOperatorServer() {}
 
 // UnsafeTxnPoolOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -134,7 +179,7 @@ func _TxnPoolOperator_Status_Handler(srv interface{}, ctx context.Context, dec f
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.TxnPoolOperator/Status",
+		FullMethod: "/txpool.v1.TxnPoolOperator/Status",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(TxnPoolOperatorServer).Status(ctx, req.(*emptypb.Empty))
@@ -152,7 +197,7 @@ func _TxnPoolOperator_AddTxn_Handler(srv interface{}, ctx context.Context, dec f
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/v1.TxnPoolOperator/AddTxn",
+		FullMethod: "/txpool.v1.TxnPoolOperator/AddTxn",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(TxnPoolOperatorServer).AddTxn(ctx, req.(*AddTxnReq))
@@ -181,11 +226,32 @@ func (x *txnPoolOperatorSubscribeServer) Send(m *TxPoolEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TxnPoolOperator_SubscribePending_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribePendingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TxnPoolOperatorServer).SubscribePending(m, &txnPoolOperatorSubscribePendingServer{stream})
+}
+
+type TxnPoolOperator_SubscribePendingServer interface {
+	Send(*PendingTxEvent) error
+	grpc.ServerStream
+}
+
+type txnPoolOperatorSubscribePendingServer struct {
+	grpc.ServerStream
+}
+
+func (x *txnPoolOperatorSubscribePendingServer) Send(m *PendingTxEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // TxnPoolOperator_ServiceDesc is the grpc.ServiceDesc for TxnPoolOperator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var TxnPoolOperator_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "v1.TxnPoolOperator",
+	ServiceName: "txpool.v1.TxnPoolOperator",
 	HandlerType: (*TxnPoolOperatorServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -203,6 +269,11 @@ var TxnPoolOperator_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TxnPoolOperator_Subscribe_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribePending",
+			Handler:       _TxnPoolOperator_SubscribePending_Handler,
+			ServerStreams: true,
+		},
 	},
-	Metadata: "operator.proto",
+	Metadata: "txpool/proto/operator.proto",
 }
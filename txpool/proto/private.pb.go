@@ -0,0 +1,154 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.12.4
+// source: txpool/proto/private.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PrivateTxn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RLP encoded transaction
+	Raw []byte `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
+}
+
+func (x *PrivateTxn) Reset() {
+	*x = PrivateTxn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_proto_private_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrivateTxn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrivateTxn) ProtoMessage() {}
+
+func (x *PrivateTxn) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_proto_private_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrivateTxn.ProtoReflect.Descriptor instead.
+func (*PrivateTxn) Descriptor() ([]byte, []int) {
+	return file_txpool_proto_private_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PrivateTxn) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+var File_txpool_proto_private_proto protoreflect.FileDescriptor
+
+var file_txpool_proto_private_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x74, 0x78,
+	0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1e, 0x0a, 0x0a, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x54,
+	0x78, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x72, 0x61, 0x77, 0x32, 0x48, 0x0a, 0x0e, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x54,
+	0x78, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x36, 0x0a, 0x05, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x12,
+	0x15, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x76,
+	0x61, 0x74, 0x65, 0x54, 0x78, 0x6e, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x0f,
+	0x5a, 0x0d, 0x2f, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_txpool_proto_private_proto_rawDescOnce sync.Once
+	file_txpool_proto_private_proto_rawDescData = file_txpool_proto_private_proto_rawDesc
+)
+
+func file_txpool_proto_private_proto_rawDescGZIP() []byte {
+	file_txpool_proto_private_proto_rawDescOnce.Do(func() {
+		file_txpool_proto_private_proto_rawDescData = protoimpl.X.CompressGZIP(file_txpool_proto_private_proto_rawDescData)
+	})
+	return file_txpool_proto_private_proto_rawDescData
+}
+
+var file_txpool_proto_private_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_txpool_proto_private_proto_goTypes = []interface{}{
+	(*PrivateTxn)(nil),    // 0: txpool.v1.PrivateTxn
+	(*emptypb.Empty)(nil), // 1: google.protobuf.Empty
+}
+var file_txpool_proto_private_proto_depIdxs = []int32{
+	0, // 0: txpool.v1.PrivateTxRelay.Relay:input_type -> txpool.v1.PrivateTxn
+	1, // 1: txpool.v1.PrivateTxRelay.Relay:output_type -> google.protobuf.Empty
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_txpool_proto_private_proto_init() }
+func file_txpool_proto_private_proto_init() {
+	if File_txpool_proto_private_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_txpool_proto_private_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrivateTxn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_txpool_proto_private_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_txpool_proto_private_proto_goTypes,
+		DependencyIndexes: file_txpool_proto_private_proto_depIdxs,
+		MessageInfos:      file_txpool_proto_private_proto_msgTypes,
+	}.Build()
+	File_txpool_proto_private_proto = out.File
+	file_txpool_proto_private_proto_rawDesc = nil
+	file_txpool_proto_private_proto_goTypes = nil
+	file_txpool_proto_private_proto_depIdxs = nil
+}
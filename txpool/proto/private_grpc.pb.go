@@ -0,0 +1,108 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: txpool/proto/private.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PrivateTxRelayClient is the client API for PrivateTxRelay service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PrivateTxRelayClient interface {
+	// Relay adds the private transaction to the pool of the validator
+	Relay(ctx context.Context, in *PrivateTxn, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type privateTxRelayClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPrivateTxRelayClient(cc grpc.ClientConnInterface) PrivateTxRelayClient {
+	return &privateTxRelayClient{cc}
+}
+
+func (c *privateTxRelayClient) Relay(ctx context.Context, in *PrivateTxn, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/txpool.v1.PrivateTxRelay/Relay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrivateTxRelayServer is the server API for PrivateTxRelay service.
+// All implementations must embed UnimplementedPrivateTxRelayServer
+// for forward compatibility
+type PrivateTxRelayServer interface {
+	// Relay adds the private transaction to the pool of the validator
+	Relay(context.Context, *PrivateTxn) (*emptypb.Empty, error)
+	mustEmbedUnimplementedPrivateTxRelayServer()
+}
+
+// UnimplementedPrivateTxRelayServer must be embedded to have forward compatible implementations.
+type UnimplementedPrivateTxRelayServer struct {
+}
+
+func (UnimplementedPrivateTxRelayServer) Relay(context.Context, *PrivateTxn) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Relay not implemented")
+}
+func (UnimplementedPrivateTxRelayServer) mustEmbedUnimplementedPrivateTxRelayServer() {}
+
+// UnsafePrivateTxRelayServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PrivateTxRelayServer will
+// result in compilation errors.
+type UnsafePrivateTxRelayServer interface {
+	mustEmbedUnimplementedPrivateTxRelayServer()
+}
+
+func RegisterPrivateTxRelayServer(s grpc.ServiceRegistrar, srv PrivateTxRelayServer) {
+	s.RegisterService(&PrivateTxRelay_ServiceDesc, srv)
+}
+
+func _PrivateTxRelay_Relay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrivateTxn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivateTxRelayServer).Relay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/txpool.v1.PrivateTxRelay/Relay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivateTxRelayServer).Relay(ctx, req.(*PrivateTxn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PrivateTxRelay_ServiceDesc is the grpc.ServiceDesc for PrivateTxRelay service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PrivateTxRelay_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "txpool.v1.PrivateTxRelay",
+	HandlerType: (*PrivateTxRelayServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Relay",
+			Handler:    _PrivateTxRelay_Relay_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "txpool/proto/private.proto",
+}
@@ -0,0 +1,67 @@
+package txpool
+
+import (
+	"sort"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+)
+
+// quarantineSize is the number of the most recent quarantined transactions remembered by the pool
+const quarantineSize = 1024
+
+// QuarantinedTx is a transaction excluded from the block building, such as a transaction
+// whose execution exceeded the time budget. The pool rejects it until it's forgotten
+type QuarantinedTx struct {
+	Hash   types.Hash
+	From   types.Address
+	Nonce  uint64
+	Reason string
+	Time   time.Time
+}
+
+// Quarantine drops the account of the transaction, like Drop, and rejects the transaction
+// if it's submitted again. The reason is reported by GetQuarantined
+func (p *TxPool) Quarantine(tx *types.Transaction, reason string) {
+	p.quarantine.Add(tx.Hash, &QuarantinedTx{
+		Hash:   tx.Hash,
+		From:   tx.From,
+		Nonce:  tx.Nonce,
+		Reason: reason,
+		Time:   time.Now(),
+	})
+
+	p.Drop(tx)
+
+	metrics.IncrCounter([]string{"txpool", "quarantined"}, 1)
+
+	p.logger.Warn("quarantined tx",
+		"hash", tx.Hash.String(),
+		"address", tx.From.String(),
+		"reason", reason,
+	)
+}
+
+// GetQuarantined returns the quarantined transactions, the most recent first
+func (p *TxPool) GetQuarantined() []*QuarantinedTx {
+	keys := p.quarantine.Keys()
+	txs := make([]*QuarantinedTx, 0, len(keys))
+
+	for _, key := range keys {
+		if value, ok := p.quarantine.Peek(key); ok {
+			txs = append(txs, value.(*QuarantinedTx)) //nolint:forcetypeassert
+		}
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Time.After(txs[j].Time)
+	})
+
+	return txs
+}
+
+// isQuarantined returns true if the transaction is quarantined
+func (p *TxPool) isQuarantined(hash types.Hash) bool {
+	return p.quarantine.Contains(hash)
+}
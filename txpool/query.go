@@ -38,6 +38,18 @@ func (p *TxPool) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
 	return tx, true
 }
 
+// BlobSidecar returns the sidecar of a recent blob transaction, nil if unknown
+func (p *TxPool) BlobSidecar(hash types.Hash) *types.BlobSidecar {
+	sidecar, ok := p.sidecars.Get(hash)
+	if !ok {
+		return nil
+	}
+
+	blobSidecar, _ := sidecar.(*types.BlobSidecar)
+
+	return blobSidecar
+}
+
 // GetTxs gets pending and queued transactions
 func (p *TxPool) GetTxs(inclQueued bool) (
 	allPromoted, allEnqueued map[types.Address][]*types.Transaction,
@@ -72,6 +72,21 @@ func (q *accountQueue) clear() (removed []*types.Transaction) {
 	return
 }
 
+// replace swaps the transaction in the queue with the given one of the same nonce.
+// Returns false if the transaction is not in the queue.
+func (q *accountQueue) replace(old, tx *types.Transaction) bool {
+	for idx, queued := range q.queue {
+		if queued.Hash == old.Hash {
+			q.queue[idx] = tx
+			heap.Fix(&q.queue, idx)
+
+			return true
+		}
+	}
+
+	return false
+}
+
 // push pushes the given transactions onto the queue.
 func (q *accountQueue) push(tx *types.Transaction) {
 	heap.Push(&q.queue, tx)
@@ -37,11 +37,17 @@ func (g *slotGauge) highPressure() bool {
 	return g.read() > (highPressureMark*g.max)/100
 }
 
-// slotsRequired calculates the number of slots required for given transaction(s).
+// slotsRequired calculates the number of slots required for given transaction(s),
+// including the sidecars of the blob transactions
 func slotsRequired(txs ...*types.Transaction) uint64 {
 	slots := uint64(0)
 	for _, tx := range txs {
-		slots += (tx.Size() + txSlotSize - 1) / txSlotSize
+		size := tx.Size()
+		if tx.Sidecar != nil {
+			size += tx.Sidecar.Size()
+		}
+
+		slots += (size + txSlotSize - 1) / txSlotSize
 	}
 
 	return slots
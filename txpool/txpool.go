@@ -3,12 +3,16 @@ package txpool
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/freeze"
+	"github.com/0xPolygon/polygon-edge/contracts/gasquota"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
@@ -16,6 +20,7 @@ import (
 	"github.com/armon/go-metrics"
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"google.golang.org/grpc"
 )
@@ -33,6 +38,14 @@ const (
 	maxAccountSkips = uint64(10)
 
 	pruningCooldown = 5000 * time.Millisecond
+
+	// blobTxMaxSize is the maximum size of a blob transaction gossiped with its sidecar
+	blobTxMaxSize = txMaxSize +
+		types.MaxBlobGasPerBlock/types.BlobGasPerBlob*(types.BlobSize+types.KZGCommitmentSize+types.KZGProofSize)
+
+	// blobSidecarCacheSize is the number of sidecars kept for the blob transactions
+	// until their blocks are written
+	blobSidecarCacheSize = 128
 )
 
 // errors
@@ -52,15 +65,32 @@ var (
 	ErrMaxEnqueuedLimitReached = errors.New("maximum number of enqueued transactions reached")
 	ErrRejectFutureTx          = errors.New("rejected future tx due to low slots")
 	ErrSmartContractRestricted = errors.New("smart contract deployment restricted")
+	ErrTxNotFound              = errors.New("transaction not found in the pool")
+	ErrReplacementMismatch     = errors.New("replacement transaction has a different sender or nonce")
+	ErrReplacementUnderpriced  = errors.New("replacement transaction underpriced")
+	ErrMalformedGossipTx       = errors.New("malformed gossip transaction")
+	ErrSponsoredTxDisabled     = errors.New("sponsored transactions are not enabled")
+	ErrExtractSponsorSignature = errors.New("cannot extract sponsor signature")
+	ErrTxExpiryDisabled        = errors.New("transaction expiry is not enabled")
+	ErrTxExpired               = errors.New("transaction expired")
+	ErrAccountFrozen           = errors.New("account frozen")
+	ErrGasQuotaExceeded        = errors.New("daily gas quota exceeded")
+	ErrUnknownOrdering         = errors.New("unknown transaction ordering")
+	ErrMaxInitCodeSizeExceeded = errors.New("max initcode size exceeded")
+	ErrBlobTxDisabled          = errors.New("blob transactions are not enabled")
+	ErrMissingBlobSidecar      = errors.New("blob transaction without sidecar")
+	ErrTxQuarantined           = errors.New("transaction quarantined")
+	ErrTxVetoed                = errors.New("transaction vetoed")
 )
 
 // indicates origin of a transaction
 type txOrigin int
 
 const (
-	local  txOrigin = iota // json-RPC/gRPC endpoints
-	gossip                 // gossip protocol
-	reorg                  // legacy code
+	local   txOrigin = iota // json-RPC/gRPC endpoints
+	gossip                  // gossip protocol
+	reorg                   // legacy code
+	private                 // private submission, never gossiped
 )
 
 func (o txOrigin) String() (s string) {
@@ -71,6 +101,8 @@ func (o txOrigin) String() (s string) {
 		s = "gossip"
 	case reorg:
 		s = "reorg"
+	case private:
+		s = "private"
 	}
 
 	return
@@ -81,11 +113,19 @@ type store interface {
 	Header() *types.Header
 	GetNonce(root types.Hash, addr types.Address) uint64
 	GetBalance(root types.Hash, addr types.Address) (*big.Int, error)
+	GetStorage(root types.Hash, addr types.Address, slot types.Hash) types.Hash
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
 }
 
 type signer interface {
 	Sender(tx *types.Transaction) (types.Address, error)
+	Sponsor(tx *types.Transaction) (types.Address, error)
+}
+
+// AdmissionHook vets the transactions before they are admitted, such as the hooks of the plugins
+type AdmissionHook interface {
+	// AdmitTx returns an error if the transaction from the given origin is refused
+	AdmitTx(tx *types.Transaction, origin string) error
 }
 
 type Config struct {
@@ -93,6 +133,24 @@ type Config struct {
 	MaxSlots            uint64
 	MaxAccountEnqueued  uint64
 	DeploymentWhitelist []types.Address
+
+	// PrivateTxPeers are the validators holding the private transactions submitted to this node
+	PrivateTxPeers []peer.ID
+
+	// Ordering is the policy ordering the transactions of the proposed blocks
+	Ordering string
+
+	// Encryption enables the encrypted mempool if set
+	Encryption *EncryptionConfig
+
+	// MaxInitCodeSize is the maximum size of a contract creation code, unlimited if 0
+	MaxInitCodeSize uint64
+
+	// Freeze rejects the transactions from and to the accounts frozen in the freeze contract
+	Freeze bool
+
+	// DailyGasQuota rejects the free transactions exceeding the daily gas quota of their sender, unlimited if 0
+	DailyGasQuota uint64
 }
 
 /* All requests are passed to the main loop
@@ -141,15 +199,29 @@ type TxPool struct {
 	// map of all accounts registered by the pool
 	accounts accountsMap
 
-	// all the primaries sorted by max gas price
-	executables *pricedQueue
+	// all the primaries sorted by the ordering policy
+	executables executablesQueue
 
 	// lookup map keeping track of all
 	// transactions present in the pool
 	index lookupMap
 
 	// networking stack
-	topic *network.Topic
+	network *network.Server
+	topic   *network.Topic
+
+	// privatePeers are the validators the private transactions are relayed to
+	privatePeers []peer.ID
+	privateRelay *privateRelay
+
+	// encrypted decrypts the encrypted transactions of the new blocks
+	encrypted *encryptedPool
+
+	// sidecars holds the sidecars of the recent blob transactions, by transaction hash
+	sidecars *lru.Cache
+
+	// quarantine holds the recent quarantined transactions, by transaction hash
+	quarantine *lru.Cache
 
 	// gauge for measuring pool capacity
 	gauge slotGauge
@@ -157,6 +229,15 @@ type TxPool struct {
 	// priceLimit is a lower threshold for gas price
 	priceLimit uint64
 
+	// maxInitCodeSize is the maximum size of a contract creation code, unlimited if 0
+	maxInitCodeSize uint64
+
+	// freeze rejects the transactions from and to the frozen accounts
+	freeze bool
+
+	// dailyGasQuota is the gas the free transactions of an account can use per day, unlimited if 0
+	dailyGasQuota uint64
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	enqueueReqCh chan enqueueRequest
@@ -173,9 +254,19 @@ type TxPool struct {
 	// Event manager for txpool events
 	eventManager *eventManager
 
+	// pendingStream streams the admitted transactions to the subscribers
+	pendingStream *pendingStream
+
 	// deploymentWhitelist map
 	deploymentWhitelist deploymentWhitelist
 
+	// admissionHooks vet the transactions passing the validation, before they are admitted
+	admissionHooks []AdmissionHook
+
+	// impersonated are the accounts whose transactions are accepted
+	// without a valid signature, only in the dev consensus
+	impersonated sync.Map
+
 	// indicates which txpool operator commands should be implemented
 	proto.UnimplementedTxnPoolOperatorServer
 
@@ -229,14 +320,20 @@ func NewTxPool(
 	config *Config,
 ) (*TxPool, error) {
 	pool := &TxPool{
-		logger:      logger.Named("txpool"),
-		forks:       forks,
-		store:       store,
-		executables: newPricedQueue(),
-		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
+		logger:     logger.Named("txpool"),
+		forks:      forks,
+		store:      store,
+		accounts:   accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
+		index:      lookupMap{all: make(map[types.Hash]*types.Transaction)},
+		gauge:      slotGauge{height: 0, max: config.MaxSlots},
+		priceLimit: config.PriceLimit,
+
+		maxInitCodeSize: config.MaxInitCodeSize,
+		freeze:          config.Freeze,
+		dailyGasQuota:   config.DailyGasQuota,
+		network:         network,
+
+		privatePeers: config.PrivateTxPeers,
 
 		//	main loop channels
 		enqueueReqCh: make(chan enqueueRequest),
@@ -245,8 +342,24 @@ func NewTxPool(
 		shutdownCh:   make(chan struct{}),
 	}
 
+	executables, err := newExecutablesQueue(config.Ordering, &pool.index)
+	if err != nil {
+		return nil, err
+	}
+
+	pool.executables = executables
+
+	if pool.sidecars, err = lru.New(blobSidecarCacheSize); err != nil {
+		return nil, err
+	}
+
+	if pool.quarantine, err = lru.New(quarantineSize); err != nil {
+		return nil, err
+	}
+
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
+	pool.pendingStream = newPendingStream()
 
 	if network != nil {
 		// subscribe to the gossip protocol
@@ -255,11 +368,21 @@ func NewTxPool(
 			return nil, err
 		}
 
+		topic.SetValidator(pool.validateGossipTx)
+
 		if subscribeErr := topic.Subscribe(pool.addGossipTx); subscribeErr != nil {
 			return nil, fmt.Errorf("unable to subscribe to gossip topic, %w", subscribeErr)
 		}
 
 		pool.topic = topic
+
+		pool.startPrivateRelay()
+	}
+
+	if config.Encryption != nil {
+		if err := pool.startEncryptedPool(config.Encryption); err != nil {
+			return nil, err
+		}
 	}
 
 	// initialize deployment whitelist
@@ -267,6 +390,7 @@ func NewTxPool(
 
 	if grpcServer != nil {
 		proto.RegisterTxnPoolOperatorServer(grpcServer, pool)
+		proto.RegisterLegacyTxnPoolOperatorServer(grpcServer, pool)
 	}
 
 	return pool, nil
@@ -318,9 +442,23 @@ func (p *TxPool) Start() {
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
 	p.eventManager.Close()
+	p.pendingStream.close()
+
+	if p.privateRelay != nil {
+		if err := p.privateRelay.stream.Close(); err != nil {
+			p.logger.Error("failed to close private tx relay", "err", err)
+		}
+	}
+
 	p.shutdownCh <- struct{}{}
 }
 
+// AddAdmissionHook adds a hook vetting the transactions admitted to the pool,
+// the hooks are added before the pool is in use
+func (p *TxPool) AddAdmissionHook(hook AdmissionHook) {
+	p.admissionHooks = append(p.admissionHooks, hook)
+}
+
 // SetSigner sets the signer the pool will use
 // to validate a transaction's signature.
 func (p *TxPool) SetSigner(s signer) {
@@ -355,20 +493,108 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 		return err
 	}
 
-	// broadcast the transaction only if a topic
-	// subscription is present
-	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
-		}
+	p.gossipTx(tx)
 
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
-		}
+	return nil
+}
+
+// swapTx swaps the transaction with its replacement in the queues of the account
+func (p *TxPool) swapTx(old, tx *types.Transaction) error {
+	account := p.accounts.get(old.From)
+
+	account.promoted.lock(true)
+	account.enqueued.lock(true)
+
+	defer func() {
+		account.enqueued.unlock()
+		account.promoted.unlock()
+	}()
+
+	if ok := p.index.add(tx); !ok {
+		return ErrAlreadyKnown
 	}
 
+	if !account.promoted.replace(old, tx) && !account.enqueued.replace(old, tx) {
+		p.index.remove(tx)
+
+		return ErrTxNotFound
+	}
+
+	p.index.remove(old)
+	p.gauge.decrease(slotsRequired(old))
+	p.gauge.increase(slotsRequired(tx))
+
+	return nil
+}
+
+// gossipTx broadcasts the transaction only if a topic
+// subscription is present
+func (p *TxPool) gossipTx(tx *types.Transaction) {
+	if p.topic == nil {
+		return
+	}
+
+	// a blob transaction is gossiped with its sidecar, the other transactions are marshaled
+	// to a pooled buffer, released once the message is copied by the publishing
+	var raw []byte
+
+	if tx.Type == types.BlobTx {
+		raw = tx.MarshalRLPWithSidecar()
+	} else {
+		buf := types.GetBuffer()
+		defer types.PutBuffer(buf)
+
+		*buf = tx.MarshalRLPTo(*buf)
+		raw = *buf
+	}
+
+	msg := &proto.Txn{
+		Raw: &any.Any{
+			Value: raw,
+		},
+	}
+
+	if err := p.topic.Publish(msg); err != nil {
+		p.logger.Error("failed to topic tx", "err", err)
+	}
+}
+
+// ReplaceTx swaps the pending transaction with a higher priced transaction of the same sender
+// and nonce. The replaced transaction is dropped from the pool immediately and the replacement
+// is gossiped, so that it supersedes the original on the other nodes once promoted
+func (p *TxPool) ReplaceTx(hash types.Hash, tx *types.Transaction) error {
+	old, ok := p.index.get(hash)
+	if !ok {
+		return ErrTxNotFound
+	}
+
+	if err := p.validateTx(tx); err != nil {
+		return err
+	}
+
+	if tx.From != old.From || tx.Nonce != old.Nonce {
+		return ErrReplacementMismatch
+	}
+
+	if tx.GasPrice.Cmp(old.GasPrice) <= 0 {
+		return ErrReplacementUnderpriced
+	}
+
+	tx.ComputeHash()
+
+	if err := p.swapTx(old, tx); err != nil {
+		return err
+	}
+
+	p.eventManager.signalEvent(proto.EventType_DROPPED, old.Hash)
+	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
+	p.pendingStream.publish(proto.EventType_ADDED, tx)
+	p.pendingStream.publishReplaced([]*types.Transaction{old}, map[uint64]*types.Transaction{tx.Nonce: tx})
+
+	p.logger.Debug("replaced tx", "hash", old.Hash.String(), "replacement", tx.Hash.String())
+
+	p.gossipTx(tx)
+
 	return nil
 }
 
@@ -389,8 +615,8 @@ func (p *TxPool) Prepare() {
 	}
 }
 
-// Peek returns the best-price selected
-// transaction ready for execution.
+// Peek returns the next transaction ready for execution,
+// selected by the ordering policy of the pool.
 func (p *TxPool) Peek() *types.Transaction {
 	// Popping the executables queue
 	// does not remove the actual tx
@@ -467,10 +693,14 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 	// update metrics
 	p.updatePending(-1 * int64(len(dropped)))
 
+	p.pendingStream.publish(proto.EventType_DROPPED, dropped...)
+
 	// drop enqueued
 	dropped = account.enqueued.clear()
 	clearAccountQueue(dropped)
 
+	p.pendingStream.publish(proto.EventType_DROPPED, dropped...)
+
 	p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
 	p.logger.Debug("dropped account txs",
 		"num", droppedCount,
@@ -515,6 +745,61 @@ func (p *TxPool) ResetWithHeaders(headers ...*types.Header) {
 	p.processEvent(e)
 }
 
+// ImpersonateAccount accepts the transactions of the account without a valid signature.
+// It is only meant for the dev consensus, the other nodes of a network reject these transactions
+func (p *TxPool) ImpersonateAccount(addr types.Address) {
+	p.impersonated.Store(addr, struct{}{})
+}
+
+// StopImpersonatingAccount requires again the transactions of the account to be signed
+func (p *TxPool) StopImpersonatingAccount(addr types.Address) {
+	p.impersonated.Delete(addr)
+}
+
+// IsImpersonated returns true if the transactions of the account are accepted without a valid signature
+func (p *TxPool) IsImpersonated(addr types.Address) bool {
+	_, ok := p.impersonated.Load(addr)
+
+	return ok
+}
+
+// Clear drops the transactions of all the accounts, whose next nonces are reset
+// to the ones of the latest state. It syncs the pool with a chain rewound to a previous block
+func (p *TxPool) Clear() {
+	stateRoot := p.store.Header().StateRoot
+
+	p.accounts.Range(func(key, _ interface{}) bool {
+		addr, _ := key.(types.Address)
+		account := p.accounts.get(addr)
+
+		account.promoted.lock(true)
+		account.enqueued.lock(true)
+
+		promoted := account.promoted.clear()
+		enqueued := account.enqueued.clear()
+
+		account.setNonce(p.store.GetNonce(stateRoot, addr))
+		account.resetDemotions()
+		account.resetSkips()
+
+		account.enqueued.unlock()
+		account.promoted.unlock()
+
+		for _, dropped := range [][]*types.Transaction{promoted, enqueued} {
+			p.index.remove(dropped...)
+			p.gauge.decrease(slotsRequired(dropped...))
+			p.pendingStream.publish(proto.EventType_DROPPED, dropped...)
+			p.eventManager.signalEvent(proto.EventType_DROPPED, toHash(dropped...)...)
+		}
+
+		p.updatePending(-1 * int64(len(promoted)))
+
+		return true
+	})
+
+	p.logger.Debug("cleared the pool", "state_root", stateRoot)
+}
+
 // processEvent collects the latest nonces for each account containted
 // in the received event. Resets all known accounts with the new nonce.
 func (p *TxPool) processEvent(event *blockchain.Event) {
@@ -549,6 +834,10 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 		// remove mined txs from the lookup map
 		p.index.remove(block.Transactions...)
 
+		if p.encrypted != nil {
+			p.encrypted.addBlock(block)
+		}
+
 		// Extract latest nonces
 		for _, tx := range block.Transactions {
 			var err error
@@ -592,17 +881,87 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 	// reset accounts with the new state
 	p.resetAccounts(stateNonces)
 
+	p.dropExpiredTxs(p.store.Header().Number + 1)
+
+	if p.freeze {
+		p.dropFrozenTxs(p.store.Header().StateRoot)
+	}
+
 	if !p.getSealing() {
 		// only non-validator cleanup inactive accounts
 		p.updateAccountSkipsCounts(stateNonces)
 	}
 }
 
+// dropExpiredTxs drops the executable transactions which can't be included in the block of the given number,
+// with the following transactions of their accounts. The enqueued transactions are dropped once promoted
+func (p *TxPool) dropExpiredTxs(number uint64) {
+	for _, tx := range p.accounts.getPrimaries() {
+		if tx.IsExpired(number) {
+			p.Drop(tx)
+		}
+	}
+}
+
+// dropFrozenTxs drops the executable transactions from and to the accounts frozen at the given state root,
+// with the following transactions of their accounts
+func (p *TxPool) dropFrozenTxs(root types.Hash) {
+	for _, tx := range p.accounts.getPrimaries() {
+		if p.isFrozen(root, tx) {
+			p.Drop(tx)
+		}
+	}
+}
+
+// isFrozen returns true if the sender, the sponsor or the recipient of the transaction
+// is frozen at the given state root
+func (p *TxPool) isFrozen(root types.Hash, tx *types.Transaction) bool {
+	state := &storeState{p.store, root}
+
+	if freeze.IsFrozen(state, tx.From) || (tx.IsSponsored() && freeze.IsFrozen(state, tx.Sponsor)) {
+		return true
+	}
+
+	return tx.To != nil && freeze.IsFrozen(state, *tx.To)
+}
+
+// isFreeTx returns true if the transaction pays no gas, so it's charged on the daily gas quota of its sender
+func isFreeTx(tx *types.Transaction) bool {
+	return tx.EffectiveGasPrice().Sign() == 0
+}
+
+// freeGasLeft returns the gas the free transactions of the account can still use
+// on the day of the latest block, at the given state root
+func (p *TxPool) freeGasLeft(root types.Hash, addr types.Address) uint64 {
+	if p.dailyGasQuota == 0 {
+		return math.MaxUint64
+	}
+
+	day := gasquota.Day(p.store.Header().Timestamp)
+
+	used := gasquota.Usage(&storeState{p.store, root}, addr, day)
+	if used >= p.dailyGasQuota {
+		return 0
+	}
+
+	return p.dailyGasQuota - used
+}
+
+// storeState reads the contract storage of the store at a state root
+type storeState struct {
+	store store
+	root  types.Hash
+}
+
+func (s *storeState) GetState(addr types.Address, key types.Hash) types.Hash {
+	return s.store.GetStorage(s.root, addr, key)
+}
+
 // validateTx ensures the transaction conforms to specific
 // constraints before entering the pool.
 func (p *TxPool) validateTx(tx *types.Transaction) error {
 	// Check the transaction size to overcome DOS Attacks
-	if uint64(len(tx.MarshalRLP())) > txMaxSize {
+	if tx.Size() > txMaxSize {
 		return ErrOversizedData
 	}
 
@@ -611,24 +970,58 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrNegativeValue
 	}
 
-	// Check if the transaction is signed properly
+	// Check if the transaction is signed properly,
+	// unless its sender is impersonated
+	if tx.From == types.ZeroAddress || !p.IsImpersonated(tx.From) {
+		// Extract the sender
+		from, signerErr := p.signer.Sender(tx)
+		if signerErr != nil {
+			return ErrExtractSignature
+		}
 
-	// Extract the sender
-	from, signerErr := p.signer.Sender(tx)
-	if signerErr != nil {
-		return ErrExtractSignature
+		// If the from field is set, check that
+		// it matches the signer
+		if tx.From != types.ZeroAddress &&
+			tx.From != from {
+			return ErrInvalidSender
+		}
+
+		// If no address was set, update it
+		if tx.From == types.ZeroAddress {
+			tx.From = from
+		}
 	}
 
-	// If the from field is set, check that
-	// it matches the signer
-	if tx.From != types.ZeroAddress &&
-		tx.From != from {
-		return ErrInvalidSender
+	// Check if the transaction is sponsored properly
+	if tx.IsSponsored() {
+		if !p.forks.SponsoredTx {
+			return ErrSponsoredTxDisabled
+		}
+
+		sponsor, sponsorErr := p.signer.Sponsor(tx)
+		if sponsorErr != nil {
+			return ErrExtractSponsorSignature
+		}
+
+		tx.Sponsor = sponsor
+	}
+
+	// Check if the transaction can still be included in the next block
+	if tx.ValidUntil != 0 {
+		if !p.forks.TxExpiry {
+			return ErrTxExpiryDisabled
+		}
+
+		if tx.IsExpired(p.store.Header().Number + 1) {
+			return ErrTxExpired
+		}
 	}
 
-	// If no address was set, update it
-	if tx.From == types.ZeroAddress {
-		tx.From = from
+	// Check the blobs of a blob transaction, carried in its sidecar while in the pool
+	if tx.Type == types.BlobTx {
+		if err := p.validateBlobTx(tx); err != nil {
+			return err
+		}
 	}
 
 	// Check if transaction can deploy smart contract
@@ -636,6 +1029,11 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrSmartContractRestricted
 	}
 
+	// Check the creation code against the limit of the chain
+	if tx.IsContractCreation() && p.maxInitCodeSize != 0 && uint64(len(tx.Input)) > p.maxInitCodeSize {
+		return ErrMaxInitCodeSizeExceeded
+	}
+
 	// Reject underpriced transactions
 	if tx.IsUnderpriced(p.priceLimit) {
 		return ErrUnderpriced
@@ -644,6 +1042,16 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	// Grab the state root for the latest block
 	stateRoot := p.store.Header().StateRoot
 
+	// Check if the sender, the sponsor or the recipient is frozen
+	if p.freeze && p.isFrozen(stateRoot, tx) {
+		return ErrAccountFrozen
+	}
+
+	// Check the free transaction fits in the daily gas quota of the sender
+	if p.dailyGasQuota != 0 && isFreeTx(tx) && tx.Gas > p.freeGasLeft(stateRoot, tx.From) {
+		return ErrGasQuotaExceeded
+	}
+
 	// Check nonce ordering
 	if p.store.GetNonce(stateRoot, tx.From) > tx.Nonce {
 		return ErrNonceTooLow
@@ -654,8 +1062,18 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrInvalidAccountState
 	}
 
-	// Check if the sender has enough funds to execute the transaction
-	if accountBalance.Cmp(tx.Cost()) < 0 {
+	if tx.IsSponsored() {
+		// Check if the sender has enough funds for the value and the sponsor for the gas
+		sponsorBalance, balanceErr := p.store.GetBalance(stateRoot, tx.Sponsor)
+		if balanceErr != nil {
+			return ErrInvalidAccountState
+		}
+
+		if accountBalance.Cmp(tx.Value) < 0 || sponsorBalance.Cmp(tx.GasCost()) < 0 {
+			return ErrInsufficientFunds
+		}
+	} else if accountBalance.Cmp(tx.Cost()) < 0 {
+		// Check if the sender has enough funds to execute the transaction
 		return ErrInsufficientFunds
 	}
 
@@ -679,6 +1097,23 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	return nil
 }
 
+// validateBlobTx checks the blob hashes of a blob transaction against its sidecar
+func (p *TxPool) validateBlobTx(tx *types.Transaction) error {
+	if !p.forks.BlobTx {
+		return ErrBlobTxDisabled
+	}
+
+	if err := tx.ValidateBlobHashes(); err != nil {
+		return err
+	}
+
+	if tx.Sidecar == nil {
+		return ErrMissingBlobSidecar
+	}
+
+	return tx.Sidecar.Validate(tx.BlobHashes)
+}
+
 func (p *TxPool) signalPruning() {
 	select {
 	case p.pruneCh <- struct{}{}:
@@ -709,6 +1144,8 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 			p.index.remove(removed...)
 			p.gauge.decrease(slotsRequired(removed...))
 
+			p.pendingStream.publish(proto.EventType_PRUNED_ENQUEUED, removed...)
+
 			return true
 		},
 	)
@@ -746,14 +1183,35 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 
 	tx.ComputeHash()
 
+	if p.isQuarantined(tx.Hash) {
+		return ErrTxQuarantined
+	}
+
+	for _, hook := range p.admissionHooks {
+		if err := hook.AdmitTx(tx, origin.String()); err != nil {
+			return fmt.Errorf("%w: %v", ErrTxVetoed, err)
+		}
+	}
+
 	// add to index
 	if ok := p.index.add(tx); !ok {
 		return ErrAlreadyKnown
 	}
 
+	// keep the sidecar of a blob transaction until its block is written
+	if tx.Type == types.BlobTx {
+		p.sidecars.Add(tx.Hash, tx.Sidecar)
+	}
+
 	// initialize account for this address once
 	p.createAccountOnce(tx.From)
 
+	// stream the transaction before it's enqueued, so that it precedes its later events.
+	// The private transactions are not disclosed until their inclusion
+	if origin != private {
+		p.pendingStream.publish(proto.EventType_ADDED, tx)
+	}
+
 	// send request [BLOCKING]
 	p.enqueueReqCh <- enqueueRequest{tx: tx}
 	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
@@ -778,6 +1236,8 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 
 		p.index.remove(tx)
 
+		p.pendingStream.publish(proto.EventType_DROPPED, tx)
+
 		return
 	}
 
@@ -803,13 +1263,23 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 	addr := req.account
 	account := p.accounts.get(addr)
 
-	// promote enqueued txs
-	promoted, pruned := account.promote()
+	// promote enqueued txs, the free ones within the daily gas quota of the account
+	promoted, pruned := account.promote(p.freeGasLeft(p.store.Header().StateRoot, addr))
 	p.logger.Debug("promote request", "promoted", promoted, "addr", addr.String())
 
 	p.index.remove(pruned...)
 	p.gauge.decrease(slotsRequired(pruned...))
 
+	if len(pruned) > 0 {
+		// the pruned transactions have the nonces of the promoted ones
+		replacements := make(map[uint64]*types.Transaction, len(promoted))
+		for _, tx := range promoted {
+			replacements[tx.Nonce] = tx
+		}
+
+		p.pendingStream.publishReplaced(pruned, replacements)
+	}
+
 	// update metrics
 	p.updatePending(int64(len(promoted)))
 
@@ -837,6 +1307,13 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 		return
 	}
 
+	// the known transactions are dropped before being decoded, they are received from many peers
+	if hash, ok := types.TxHashFromRLP(raw.Raw.Value); ok {
+		if _, known := p.index.get(hash); known {
+			return
+		}
+	}
+
 	tx := new(types.Transaction)
 
 	// decode tx
@@ -858,6 +1335,49 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	}
 }
 
+// validateGossipTx runs the stateless checks of a gossiped transaction before it's relayed,
+// the checks depending on the state of the pool are left to addGossipTx
+func (p *TxPool) validateGossipTx(obj interface{}, _ peer.ID) error {
+	raw, ok := obj.(*proto.Txn)
+	if !ok || raw.Raw == nil {
+		return ErrMalformedGossipTx
+	}
+
+	if uint64(len(raw.Raw.Value)) > blobTxMaxSize {
+		return ErrOversizedData
+	}
+
+	// a known transaction was already checked and relayed, it isn't relayed again
+	if hash, ok := types.TxHashFromRLP(raw.Raw.Value); ok {
+		if _, known := p.index.get(hash); known {
+			return fmt.Errorf("%w: %v", network.ErrIgnoreMessage, ErrAlreadyKnown)
+		}
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedGossipTx, err)
+	}
+
+	// only a blob transaction carries more than the transaction size limit, in its sidecar
+	if tx.Sidecar == nil && uint64(len(raw.Raw.Value)) > txMaxSize {
+		return ErrOversizedData
+	}
+
+	if tx.Value.Sign() < 0 {
+		return ErrNegativeValue
+	}
+
+	// the signer is set once the pool is attached to the chain
+	if p.signer != nil {
+		if _, err := p.signer.Sender(tx); err != nil {
+			return ErrExtractSignature
+		}
+	}
+
+	return nil
+}
+
 // resetAccounts updates existing accounts with the new nonce and prunes stale transactions.
 func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 	if len(stateNonces) == 0 {
@@ -902,6 +1422,7 @@ func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 			proto.EventType_PRUNED_PROMOTED,
 			toHash(allPrunedPromoted...)...,
 		)
+		p.pendingStream.publish(proto.EventType_PRUNED_PROMOTED, allPrunedPromoted...)
 
 		p.updatePending(int64(-1 * len(allPrunedPromoted)))
 	}
@@ -913,6 +1434,7 @@ func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 			proto.EventType_PRUNED_ENQUEUED,
 			toHash(allPrunedEnqueued...)...,
 		)
+		p.pendingStream.publish(proto.EventType_PRUNED_ENQUEUED, allPrunedEnqueued...)
 	}
 }
 
@@ -10,8 +10,11 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/freeze"
+	"github.com/0xPolygon/polygon-edge/contracts/gasquota"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
+	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/golang/protobuf/ptypes/any"
@@ -323,6 +326,129 @@ func TestAddTxErrors(t *testing.T) {
 			ErrInsufficientFunds,
 		)
 	})
+
+	t.Run("blob transactions carry a matching sidecar", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+
+		// the commitment to the empty blob, the compressed G1 point at infinity
+		commitment := make([]byte, types.KZGCommitmentSize)
+		commitment[0] = 0xc0
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Type = types.BlobTx
+		tx.To = &addr1
+		tx.GasTipCap = big.NewInt(1)
+		tx.BlobFeeCap = big.NewInt(1)
+		tx.BlobHashes = []types.Hash{types.KZGToVersionedHash(commitment)}
+		tx = signTx(tx)
+
+		assert.ErrorIs(t, pool.validateTx(tx), ErrBlobTxDisabled)
+
+		pool.forks.BlobTx = true
+		assert.ErrorIs(t, pool.validateTx(tx), ErrMissingBlobSidecar)
+
+		tx.Sidecar = &types.BlobSidecar{
+			Blobs:       [][]byte{make([]byte, types.BlobSize)},
+			Commitments: [][]byte{append([]byte{}, commitment...)},
+			Proofs:      [][]byte{append([]byte{}, commitment...)},
+		}
+		assert.NoError(t, pool.validateTx(tx))
+
+		tx.Sidecar.Blobs[0][31] = 1
+		assert.ErrorIs(t, pool.validateTx(tx), types.ErrInvalidBlobProof)
+
+		tx.Sidecar.Commitments[0][0] = 1
+		assert.ErrorIs(t, pool.validateTx(tx), types.ErrBlobSidecarMismatch)
+	})
+
+	t.Run("expiring transactions are valid in the next block", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+		pool.store = defaultMockStore{
+			DefaultHeader: &types.Header{
+				Number:   10,
+				GasLimit: mockHeader.GasLimit,
+			},
+		}
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.ValidUntil = 11
+		tx = signTx(tx)
+
+		assert.ErrorIs(t, pool.validateTx(tx), ErrTxExpiryDisabled)
+
+		pool.forks.TxExpiry = true
+		assert.NoError(t, pool.validateTx(tx))
+
+		tx = newTx(defaultAddr, 0, 1)
+		tx.ValidUntil = 10
+		tx = signTx(tx)
+
+		assert.ErrorIs(t, pool.validateTx(tx), ErrTxExpired)
+	})
+
+	t.Run("transactions from and to frozen accounts are rejected", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+
+		store := &freezeMockStore{
+			defaultMockStore: NewDefaultMockStore(mockHeader),
+			storage:          make(map[types.Hash]types.Hash),
+		}
+		pool.store = store
+
+		freezeConfig := &chain.Freeze{
+			Governors: []types.Address{addr5},
+			Threshold: 1,
+		}
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.To = &addr1
+		tx = signTx(tx)
+
+		assert.NoError(t, freeze.Vote(store, freezeConfig, addr5, addr1, true))
+
+		// the frozen accounts are only enforced on the chains with a freeze contract
+		assert.NoError(t, pool.validateTx(tx))
+
+		pool.freeze = true
+		assert.ErrorIs(t, pool.validateTx(tx), ErrAccountFrozen)
+
+		assert.NoError(t, freeze.Vote(store, freezeConfig, addr5, addr1, false))
+		assert.NoError(t, pool.validateTx(tx))
+
+		assert.NoError(t, freeze.Vote(store, freezeConfig, addr5, defaultAddr, true))
+		assert.ErrorIs(t, pool.validateTx(tx), ErrAccountFrozen)
+	})
+
+	t.Run("free transactions exceeding the daily gas quota are rejected", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+		pool.priceLimit = 0
+
+		store := &freezeMockStore{
+			defaultMockStore: NewDefaultMockStore(mockHeader),
+			storage:          make(map[types.Hash]types.Hash),
+		}
+		pool.store = store
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.GasPrice = big.NewInt(0)
+		tx = signTx(tx)
+
+		// the quota is only enforced on the free-gas chains
+		gasquota.AddUsage(store, defaultAddr, gasquota.Day(mockHeader.Timestamp), 1)
+		assert.NoError(t, pool.validateTx(tx))
+
+		pool.dailyGasQuota = validGasLimit
+		assert.ErrorIs(t, pool.validateTx(tx), ErrGasQuotaExceeded)
+
+		// the paying transactions aren't charged on the quota
+		tx = newTx(defaultAddr, 0, 1)
+		tx = signTx(tx)
+		assert.NoError(t, pool.validateTx(tx))
+	})
 }
 
 func TestPruneAccountsWithNonceHoles(t *testing.T) {
@@ -567,6 +693,46 @@ func TestAddGossipTx(t *testing.T) {
 	})
 }
 
+func TestValidateGossipTx(t *testing.T) {
+	t.Parallel()
+
+	key, _ := tests.GenerateKeyAndAddr(t)
+	signer := crypto.NewEIP155Signer(uint64(100))
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(signer)
+
+	toProto := func(raw []byte) *proto.Txn {
+		return &proto.Txn{
+			Raw: &any.Any{
+				Value: raw,
+			},
+		}
+	}
+
+	signedTx, err := signer.SignTx(newTx(types.ZeroAddress, 1, 1), key)
+	assert.NoError(t, err)
+
+	assert.NoError(t, pool.validateGossipTx(toProto(signedTx.MarshalRLP()), ""))
+
+	// the gossiped transactions are relayed whatever the state of the pool
+	pool.SetSealing(false)
+	assert.NoError(t, pool.validateGossipTx(toProto(signedTx.MarshalRLP()), ""))
+
+	assert.ErrorIs(t, pool.validateGossipTx(&proto.Txn{}, ""), ErrMalformedGossipTx)
+	assert.ErrorIs(t, pool.validateGossipTx(toProto([]byte{0x1, 0x2}), ""), ErrMalformedGossipTx)
+	assert.ErrorIs(
+		t,
+		pool.validateGossipTx(toProto(newTx(types.ZeroAddress, 1, 1).MarshalRLP()), ""),
+		ErrExtractSignature,
+	)
+
+	// the known transactions are ignored without being decoded
+	pool.index.add(signedTx.ComputeHash())
+	assert.ErrorIs(t, pool.validateGossipTx(toProto(signedTx.MarshalRLP()), ""), network.ErrIgnoreMessage)
+}
+
 func TestDropKnownGossipTx(t *testing.T) {
 	t.Parallel()
 
@@ -834,6 +1000,34 @@ func TestPromoteHandler(t *testing.T) {
 		assert.Equal(t, uint64(10), pool.accounts.get(addr1).promoted.length())
 	})
 
+	t.Run("free txs promoted within the daily gas quota", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		pool.priceLimit = 0
+		pool.dailyGasQuota = 2 * validGasLimit
+
+		// the first two free txs fit in the quota, the third stays enqueued
+		for nonce := uint64(0); nonce < 3; nonce++ {
+			tx := newTx(addr1, nonce, 1)
+			tx.GasPrice = big.NewInt(0)
+
+			go func() {
+				err := pool.addTx(local, tx)
+				assert.NoError(t, err)
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+		}
+
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).getNonce())
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+	})
+
 	t.Run("one tx -> one promotion", func(t *testing.T) {
 		/* In this scenario, each received tx will be instantly promoted.
 		All txs are sent in the order of expected nonce. */
@@ -1434,6 +1628,101 @@ func TestDrop(t *testing.T) {
 	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
 }
 
+func TestQuarantine(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// send 1 tx and promote it
+	go func() {
+		err := pool.addTx(local, newTx(addr1, 0, 1))
+		assert.NoError(t, err)
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	// quarantine the tx
+	pool.Prepare()
+	tx := pool.Peek()
+	pool.Quarantine(tx, "execution timeout")
+
+	assert.Equal(t, uint64(0), pool.gauge.read())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+
+	quarantined := pool.GetQuarantined()
+	if assert.Len(t, quarantined, 1) {
+		assert.Equal(t, tx.Hash, quarantined[0].Hash)
+		assert.Equal(t, addr1, quarantined[0].From)
+		assert.Equal(t, "execution timeout", quarantined[0].Reason)
+	}
+
+	// the quarantined tx is rejected
+	assert.ErrorIs(t, pool.addTx(local, tx.Copy()), ErrTxQuarantined)
+}
+
+func TestClear(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// promote the first 2 txs of the account, the last one is enqueued
+	for _, nonce := range []uint64{0, 1, 5} {
+		go func(nonce uint64) {
+			err := pool.addTx(local, newTx(addr1, nonce, 1))
+			assert.NoError(t, err)
+		}(nonce)
+
+		if nonce == 5 {
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+			continue
+		}
+
+		go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	assert.Equal(t, uint64(3), pool.gauge.read())
+	assert.Equal(t, uint64(2), pool.accounts.get(addr1).getNonce())
+
+	// the chain is rewound to the state where the account has no transactions
+	pool.Clear()
+
+	assert.Equal(t, uint64(0), pool.gauge.read())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+	assert.Equal(t, uint64(0), pool.Length())
+	assert.Len(t, pool.index.all, 0)
+}
+
+func TestImpersonateAccount(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(crypto.NewEIP155Signer(100))
+
+	// the transaction isn't signed
+	assert.ErrorIs(t, pool.validateTx(newTx(addr1, 0, 1)), ErrExtractSignature)
+
+	pool.ImpersonateAccount(addr1)
+	assert.True(t, pool.IsImpersonated(addr1))
+	assert.NoError(t, pool.validateTx(newTx(addr1, 0, 1)))
+
+	// the sender must still be set
+	assert.ErrorIs(t, pool.validateTx(newTx(types.ZeroAddress, 0, 1)), ErrExtractSignature)
+
+	pool.StopImpersonatingAccount(addr1)
+	assert.False(t, pool.IsImpersonated(addr1))
+	assert.ErrorIs(t, pool.validateTx(newTx(addr1, 0, 1)), ErrExtractSignature)
+}
+
 func TestDemote(t *testing.T) {
 	t.Parallel()
 
@@ -1711,6 +2000,20 @@ func TestPermissionSmartContractDeployment(t *testing.T) {
 			ErrSmartContractRestricted,
 		)
 	})
+	t.Run("Creation code over the init code limit is rejected", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+		pool.maxInitCodeSize = 16
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.To = nil
+		tx.Input = make([]byte, 17)
+
+		assert.ErrorIs(t,
+			pool.validateTx(signTx(tx)),
+			ErrMaxInitCodeSizeExceeded,
+		)
+	})
 }
 
 /* "Integrated" tests */
@@ -2239,6 +2542,80 @@ func TestExecutablesOrder(t *testing.T) {
 	}
 }
 
+func TestExecutablesOrder_Policies(t *testing.T) {
+	t.Parallel()
+
+	newPricedTx := func(addr types.Address, nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr, nonce, 1)
+		tx.GasPrice.SetUint64(gasPrice)
+		tx.ComputeHash()
+
+		return tx
+	}
+
+	// the transactions in their arrival order
+	arrivals := []*types.Transaction{
+		newPricedTx(addr1, 0, 1),
+		newPricedTx(addr1, 1, 1),
+		newPricedTx(addr1, 2, 1),
+		newPricedTx(addr2, 0, 3),
+		newPricedTx(addr2, 1, 3),
+		newPricedTx(addr3, 0, 2),
+	}
+
+	testCases := []struct {
+		ordering      string
+		expectedOrder []int
+	}{
+		{PriceOrdering, []int{3, 4, 5, 0, 1, 2}},
+		{FIFOOrdering, []int{0, 1, 2, 3, 4, 5}},
+		{FairOrdering, []int{3, 5, 0, 4, 1, 2}},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.ordering, func(t *testing.T) {
+			t.Parallel()
+
+			index := &lookupMap{all: make(map[types.Hash]*types.Transaction)}
+			promoted := map[types.Address][]*types.Transaction{}
+
+			for _, tx := range arrivals {
+				index.add(tx)
+				promoted[tx.From] = append(promoted[tx.From], tx)
+			}
+
+			queue, err := newExecutablesQueue(test.ordering, index)
+			assert.NoError(t, err)
+
+			// push the primaries, then the next transaction of the popped accounts
+			for _, txs := range promoted {
+				queue.push(txs[0])
+			}
+
+			var order []int
+
+			for tx := queue.pop(); tx != nil; tx = queue.pop() {
+				for i, arrived := range arrivals {
+					if arrived == tx {
+						order = append(order, i)
+					}
+				}
+
+				promoted[tx.From] = promoted[tx.From][1:]
+				if len(promoted[tx.From]) != 0 {
+					queue.push(promoted[tx.From][0])
+				}
+			}
+
+			assert.Equal(t, test.expectedOrder, order)
+		})
+	}
+
+	_, err := newExecutablesQueue("random", &lookupMap{})
+	assert.ErrorIs(t, err, ErrUnknownOrdering)
+}
+
 type status int
 
 // Status of a transaction resulted
@@ -2729,3 +3106,64 @@ func TestSetSealing(t *testing.T) {
 		})
 	}
 }
+
+func TestReplaceTx(t *testing.T) {
+	t.Parallel()
+
+	setupPool := func(t *testing.T) (*TxPool, *types.Transaction) {
+		t.Helper()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		tx := newTx(addr1, 0, 1)
+
+		go func() {
+			assert.NoError(t, pool.addTx(local, tx))
+		}()
+		pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		return pool, tx
+	}
+
+	t.Run("replaces the promoted tx", func(t *testing.T) {
+		t.Parallel()
+
+		pool, tx := setupPool(t)
+
+		replacement := newTx(addr1, 0, 1)
+		replacement.GasPrice = new(big.Int).Add(tx.GasPrice, big.NewInt(1))
+
+		assert.NoError(t, pool.ReplaceTx(tx.Hash, replacement))
+
+		account := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(1), account.promoted.length())
+		assert.Equal(t, replacement.Hash, account.promoted.peek().Hash)
+		assert.Equal(t, slotsRequired(replacement), pool.gauge.read())
+
+		_, ok := pool.index.get(tx.Hash)
+		assert.False(t, ok)
+
+		_, ok = pool.index.get(replacement.Hash)
+		assert.True(t, ok)
+	})
+
+	t.Run("rejects invalid replacements", func(t *testing.T) {
+		t.Parallel()
+
+		pool, tx := setupPool(t)
+
+		underpriced := newTx(addr1, 0, 1)
+		assert.ErrorIs(t, pool.ReplaceTx(tx.Hash, underpriced), ErrReplacementUnderpriced)
+
+		otherNonce := newTx(addr1, 1, 1)
+		otherNonce.GasPrice = new(big.Int).Add(tx.GasPrice, big.NewInt(1))
+		assert.ErrorIs(t, pool.ReplaceTx(tx.Hash, otherNonce), ErrReplacementMismatch)
+
+		assert.ErrorIs(t, pool.ReplaceTx(types.StringToHash("1"), otherNonce), ErrTxNotFound)
+
+		assert.Equal(t, tx.Hash, pool.accounts.get(addr1).promoted.peek().Hash)
+	})
+}
@@ -0,0 +1,279 @@
+package types
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/crypto/kzg4844"
+	"github.com/umbracle/fastrlp"
+)
+
+// TxType is the EIP-2718 type of a transaction
+type TxType byte
+
+const (
+	// LegacyTx is the type of the untyped transactions
+	LegacyTx TxType = 0x00
+
+	// BlobTx is the type of the blob-carrying transactions (EIP-4844)
+	BlobTx TxType = 0x03
+)
+
+// Parameters of the blobs and of the blob gas market (EIP-4844)
+const (
+	// BlobSize is the size of a blob, 4096 field elements of 32 bytes
+	BlobSize = 131072
+
+	// KZGCommitmentSize is the size of the KZG commitment to a blob
+	KZGCommitmentSize = 48
+
+	// KZGProofSize is the size of the KZG proof of a blob
+	KZGProofSize = 48
+
+	// BlobCommitmentVersionKZG is the version byte of the hashes of the KZG commitments
+	BlobCommitmentVersionKZG = 0x01
+
+	// BlobGasPerBlob is the blob gas used by a blob
+	BlobGasPerBlob = 1 << 17
+
+	// TargetBlobGasPerBlock is the blob gas used by a block keeping the blob gas price constant
+	TargetBlobGasPerBlock = 3 * BlobGasPerBlob
+
+	// MaxBlobGasPerBlock is the maximum blob gas used by a block
+	MaxBlobGasPerBlock = 6 * BlobGasPerBlob
+
+	// MinBlobGasPrice is the minimum price of the blob gas
+	MinBlobGasPrice = 1
+
+	// BlobGasPriceUpdateFraction bounds the change of the blob gas price between two blocks
+	BlobGasPriceUpdateFraction = 3338477
+)
+
+var (
+	ErrMissingBlobHashes      = errors.New("blob transaction without blob hashes")
+	ErrInvalidBlobHashVersion = errors.New("invalid blob hash version")
+	ErrBlobSidecarMismatch    = errors.New("blob sidecar doesn't match the blob hashes")
+	ErrInvalidBlobSidecar     = errors.New("invalid blob sidecar")
+	ErrInvalidBlobProof       = errors.New("invalid blob KZG proof")
+	ErrUnknownTxType          = errors.New("unknown transaction type")
+)
+
+// AccessTuple is an entry of the access list of a typed transaction
+type AccessTuple struct {
+	Address     Address
+	StorageKeys []Hash
+}
+
+// BlobSidecar holds the blobs of a blob transaction with their KZG commitments and proofs.
+// It is gossiped with the transaction and stored apart from the blocks for a while
+type BlobSidecar struct {
+	Blobs       [][]byte
+	Commitments [][]byte
+	Proofs      [][]byte
+}
+
+// KZGToVersionedHash returns the versioned hash of the KZG commitment to a blob
+func KZGToVersionedHash(commitment []byte) Hash {
+	hash := Hash(sha256.Sum256(commitment))
+	hash[0] = BlobCommitmentVersionKZG
+
+	return hash
+}
+
+// BlobGas returns the blob gas used by the transaction
+func (t *Transaction) BlobGas() uint64 {
+	return uint64(len(t.BlobHashes)) * BlobGasPerBlob
+}
+
+// ValidateBlobHashes checks the versioned hashes of the blobs of a blob transaction
+func (t *Transaction) ValidateBlobHashes() error {
+	if len(t.BlobHashes) == 0 {
+		return ErrMissingBlobHashes
+	}
+
+	for _, hash := range t.BlobHashes {
+		if hash[0] != BlobCommitmentVersionKZG {
+			return fmt.Errorf("%w: %d", ErrInvalidBlobHashVersion, hash[0])
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the sidecar against the versioned hashes of the transaction,
+// and verifies that each blob matches its commitment with its KZG proof
+func (s *BlobSidecar) Validate(hashes []Hash) error {
+	if len(s.Blobs) != len(hashes) || len(s.Commitments) != len(hashes) || len(s.Proofs) != len(hashes) {
+		return ErrBlobSidecarMismatch
+	}
+
+	for i, hash := range hashes {
+		if len(s.Blobs[i]) != BlobSize || len(s.Commitments[i]) != KZGCommitmentSize ||
+			len(s.Proofs[i]) != KZGProofSize {
+			return ErrInvalidBlobSidecar
+		}
+
+		if KZGToVersionedHash(s.Commitments[i]) != hash {
+			return ErrBlobSidecarMismatch
+		}
+
+		if err := kzg4844.VerifyBlobProof(s.Blobs[i], s.Commitments[i], s.Proofs[i]); err != nil {
+			return fmt.Errorf("%w: blob %d, %v", ErrInvalidBlobProof, i, err)
+		}
+	}
+
+	return nil
+}
+
+// Size returns the size of the blobs, commitments and proofs
+func (s *BlobSidecar) Size() uint64 {
+	return uint64(len(s.Blobs)) * (BlobSize + KZGCommitmentSize + KZGProofSize)
+}
+
+func (s *BlobSidecar) MarshalRLPTo(dst []byte) []byte {
+	return MarshalRLPTo(s.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith marshals the sidecar to RLP with a specific fastrlp.Arena
+func (s *BlobSidecar) MarshalRLPWith(a *fastrlp.Arena) *fastrlp.Value {
+	vv := a.NewArray()
+
+	for _, list := range [][][]byte{s.Blobs, s.Commitments, s.Proofs} {
+		v := a.NewArray()
+		for _, item := range list {
+			v.Set(a.NewBytes(item))
+		}
+
+		vv.Set(v)
+	}
+
+	return vv
+}
+
+func (s *BlobSidecar) UnmarshalRLP(input []byte) error {
+	return UnmarshalRlp(s.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom unmarshals a BlobSidecar in RLP format
+func (s *BlobSidecar) UnmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 3 {
+		return fmt.Errorf("incorrect number of elements to decode blob sidecar, expected 3 but found %d", len(elems))
+	}
+
+	return s.unmarshalLists(elems)
+}
+
+// unmarshalLists unmarshals the blobs, commitments and proofs lists of a sidecar
+func (s *BlobSidecar) unmarshalLists(elems []*fastrlp.Value) error {
+	lists := make([][][]byte, 3)
+
+	for i, elem := range elems {
+		items, err := elem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		lists[i] = make([][]byte, len(items))
+
+		for j, item := range items {
+			if lists[i][j], err = item.GetBytes(nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.Blobs, s.Commitments, s.Proofs = lists[0], lists[1], lists[2]
+
+	return nil
+}
+
+// BlobSidecars are the sidecars of the blob transactions of a block, in their order in the block.
+// The sidecar of a transaction whose blobs weren't received is empty
+type BlobSidecars []*BlobSidecar
+
+func (s BlobSidecars) MarshalRLPTo(dst []byte) []byte {
+	return MarshalRLPTo(s.MarshalRLPWith, dst)
+}
+
+func (s *BlobSidecars) MarshalRLPWith(a *fastrlp.Arena) *fastrlp.Value {
+	vv := a.NewArray()
+	for _, sidecar := range *s {
+		vv.Set(sidecar.MarshalRLPWith(a))
+	}
+
+	return vv
+}
+
+func (s *BlobSidecars) UnmarshalRLP(input []byte) error {
+	return UnmarshalRlp(s.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom unmarshals the BlobSidecars in RLP format
+func (s *BlobSidecars) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range elems {
+		sidecar := &BlobSidecar{}
+		if err := sidecar.UnmarshalRLPFrom(p, elem); err != nil {
+			return err
+		}
+
+		*s = append(*s, sidecar)
+	}
+
+	return nil
+}
+
+// CalcExcessBlobGas returns the excess blob gas of a block from the blob gas of its parent
+func CalcExcessBlobGas(parent *Header) uint64 {
+	var excess, used uint64
+
+	if parent.ExcessBlobGas != nil {
+		excess = *parent.ExcessBlobGas
+	}
+
+	if parent.BlobGasUsed != nil {
+		used = *parent.BlobGasUsed
+	}
+
+	if excess+used < TargetBlobGasPerBlock {
+		return 0
+	}
+
+	return excess + used - TargetBlobGasPerBlock
+}
+
+// CalcBlobGasPrice returns the price of the blob gas of a block with the given excess blob gas
+func CalcBlobGasPrice(excessBlobGas uint64) *big.Int {
+	return fakeExponential(
+		big.NewInt(MinBlobGasPrice),
+		new(big.Int).SetUint64(excessBlobGas),
+		big.NewInt(BlobGasPriceUpdateFraction),
+	)
+}
+
+// fakeExponential approximates factor * e ** (numerator / denominator) using Taylor expansion
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	output := new(big.Int)
+	accum := new(big.Int).Mul(factor, denominator)
+
+	for i := int64(1); accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+
+		accum.Mul(accum, numerator)
+		accum.Div(accum, denominator)
+		accum.Div(accum, big.NewInt(i))
+	}
+
+	return output.Div(output, denominator)
+}
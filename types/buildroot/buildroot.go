@@ -24,19 +24,50 @@ func CalculateReceiptsRoot(receipts []*types.Receipt) types.Hash {
 	return res
 }
 
-// CalculateTransactionsRoot calculates the root of a list of transactions
-func CalculateTransactionsRoot(transactions []*types.Transaction) types.Hash {
+// CalculateReceiptProof returns the merkle proof of the receipt at the given index
+// in the trie of the receipts root
+func CalculateReceiptProof(receipts []*types.Receipt, index int) ([][]byte, error) {
 	ar := arenaPool.Get()
+	defer arenaPool.Put(ar)
+
+	keys := make([][]byte, len(receipts))
+	values := make([][]byte, len(receipts))
+
+	for i, receipt := range receipts {
+		keys[i] = ar.NewUint(uint64(i)).MarshalTo(nil)
+		values[i] = receipt.MarshalRLPWith(ar).MarshalTo(nil)
 
-	res := calculateRootWithRlp(len(transactions), func(i int) *fastrlp.Value {
 		ar.Reset()
+	}
 
-		return transactions[i].MarshalRLPWith(ar)
+	return itrie.GetProofFromList(keys, values, ar.NewUint(uint64(index)).MarshalTo(nil))
+}
+
+// CalculateTransactionsRoot calculates the root of a list of transactions,
+// the typed transactions being stored as their EIP-2718 envelope
+func CalculateTransactionsRoot(transactions []*types.Transaction) types.Hash {
+	return CalculateRoot(len(transactions), func(i int) []byte {
+		return transactions[i].MarshalRLPTo(nil)
 	})
+}
 
-	arenaPool.Put(ar)
+// CalculateTransactionProof returns the merkle proof of the transaction at the given index
+// in the trie of the transactions root
+func CalculateTransactionProof(transactions []*types.Transaction, index int) ([][]byte, error) {
+	ar := arenaPool.Get()
+	defer arenaPool.Put(ar)
 
-	return res
+	keys := make([][]byte, len(transactions))
+	values := make([][]byte, len(transactions))
+
+	for i, tx := range transactions {
+		keys[i] = ar.NewUint(uint64(i)).MarshalTo(nil)
+		values[i] = tx.MarshalRLPTo(nil)
+
+		ar.Reset()
+	}
+
+	return itrie.GetProofFromList(keys, values, ar.NewUint(uint64(index)).MarshalTo(nil))
 }
 
 // CalculateUncleRoot calculates the root of a list of uncles
@@ -0,0 +1,41 @@
+package buildroot
+
+import (
+	"testing"
+
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/fastrlp"
+)
+
+func TestCalculateReceiptProof(t *testing.T) {
+	t.Parallel()
+
+	// below and above the fast hasher limit
+	for _, num := range []int{1, 3, 130} {
+		receipts := make([]*types.Receipt, num)
+		for i := range receipts {
+			receipts[i] = &types.Receipt{
+				CumulativeGasUsed: uint64(21000 * (i + 1)),
+			}
+			receipts[i].SetStatus(types.ReceiptSuccess)
+		}
+
+		root := CalculateReceiptsRoot(receipts)
+
+		for _, index := range []int{0, num - 1} {
+			proof, err := CalculateReceiptProof(receipts, index)
+			assert.NoError(t, err)
+
+			key := (&fastrlp.Arena{}).NewUint(uint64(index)).MarshalTo(nil)
+
+			value, err := itrie.VerifyProof(root, key, proof)
+			assert.NoError(t, err)
+
+			receipt := &types.Receipt{}
+			assert.NoError(t, receipt.UnmarshalRLP(value))
+			assert.Equal(t, receipts[index].CumulativeGasUsed, receipt.CumulativeGasUsed)
+		}
+	}
+}
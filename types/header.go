@@ -26,6 +26,10 @@ type Header struct {
 	MixHash      Hash
 	Nonce        Nonce
 	Hash         Hash
+
+	// the blob gas of the block (EIP-4844), only set once the blob transactions are enabled
+	BlobGasUsed   *uint64
+	ExcessBlobGas *uint64
 }
 
 func (h *Header) Equal(hh *Header) bool {
@@ -83,6 +87,16 @@ func (h *Header) Copy() *Header {
 	newHeader.ExtraData = make([]byte, len(h.ExtraData))
 	copy(newHeader.ExtraData[:], h.ExtraData[:])
 
+	if h.BlobGasUsed != nil {
+		blobGasUsed := *h.BlobGasUsed
+		newHeader.BlobGasUsed = &blobGasUsed
+	}
+
+	if h.ExcessBlobGas != nil {
+		excessBlobGas := *h.ExcessBlobGas
+		newHeader.ExcessBlobGas = &excessBlobGas
+	}
+
 	return newHeader
 }
 
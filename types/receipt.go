@@ -28,6 +28,9 @@ type Receipt struct {
 	GasUsed         uint64
 	ContractAddress *Address
 	TxHash          Hash
+
+	// RevertReason is the output of a reverted transaction, empty otherwise
+	RevertReason []byte
 }
 
 func (r *Receipt) SetStatus(s ReceiptStatus) {
@@ -104,6 +107,7 @@ func (b *Bloom) setEncode(hasher *keccak.Keccak, h []byte) {
 // IsLogInBloom checks if the log has a possible presence in the bloom filter
 func (b *Bloom) IsLogInBloom(log *Log) bool {
 	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
 
 	// Check if the log address is present
 	addressPresent := b.isByteArrPresent(hasher, log.Address.Bytes())
@@ -120,11 +124,17 @@ func (b *Bloom) IsLogInBloom(log *Log) bool {
 		}
 	}
 
-	keccak.DefaultKeccakPool.Put(hasher)
-
 	return true
 }
 
+// IsPresent checks if the data, an address or a topic, has a possible presence in the bloom filter
+func (b *Bloom) IsPresent(data []byte) bool {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	return b.isByteArrPresent(hasher, data)
+}
+
 // isByteArrPresent checks if the byte array is possibly present in the Bloom filter
 func (b *Bloom) isByteArrPresent(hasher *keccak.Keccak, data []byte) bool {
 	hasher.Reset()
@@ -141,7 +151,7 @@ func (b *Bloom) isByteArrPresent(hasher *keccak.Keccak, data []byte) bool {
 
 		referenceByte := b[byteLocation]
 
-		isSet := int(referenceByte & (1 << (bitLocation - 1)))
+		isSet := int(referenceByte & (1 << bitLocation))
 
 		if isSet == 0 {
 			return false
@@ -3,6 +3,7 @@ package types
 import (
 	"math/big"
 	"reflect"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -65,6 +66,38 @@ func TestRLPMarshall_And_Unmarshall_Transaction(t *testing.T) {
 	}
 }
 
+func TestRLPMarshall_And_Unmarshall_SponsoredTransaction(t *testing.T) {
+	addrTo := StringToAddress("11")
+	txn := &Transaction{
+		Nonce:    0,
+		GasPrice: big.NewInt(11),
+		Gas:      11,
+		To:       &addrTo,
+		Value:    big.NewInt(1),
+		Input:    []byte{1, 2},
+		V:        big.NewInt(25),
+		S:        big.NewInt(26),
+		R:        big.NewInt(27),
+		SponsorV: big.NewInt(28),
+		SponsorR: big.NewInt(29),
+		SponsorS: big.NewInt(30),
+		From:     StringToAddress("12"),
+		Sponsor:  StringToAddress("13"),
+	}
+
+	unmarshalledTxn := new(Transaction)
+	if err := unmarshalledTxn.UnmarshalStoreRLP(txn.MarshalStoreRLPTo(nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	unmarshalledTxn.ComputeHash()
+
+	txn.Hash = unmarshalledTxn.Hash
+	if !reflect.DeepEqual(txn, unmarshalledTxn) {
+		t.Fatal("[ERROR] Unmarshalled transaction not equal to base transaction")
+	}
+}
+
 func TestRLPStorage_Marshall_And_Unmarshall_Receipt(t *testing.T) {
 	addr := StringToAddress("11")
 	hash := StringToHash("10")
@@ -95,6 +128,16 @@ func TestRLPStorage_Marshall_And_Unmarshall_Receipt(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"Marshal receipt with revert reason",
+			&Receipt{
+				CumulativeGasUsed: 10,
+				GasUsed:           100,
+				TxHash:            hash,
+				RevertReason:      []byte{0x4e, 0x48, 0x7b, 0x71},
+			},
+			true,
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -129,3 +172,243 @@ func TestRLPUnmarshal_Header_ComputeHash(t *testing.T) {
 	assert.NoError(t, h2.UnmarshalRLP(data))
 	assert.Equal(t, h.Hash, h2.Hash)
 }
+
+func testBlobTransaction() *Transaction {
+	addrTo := StringToAddress("11")
+	commitment := pointAtInfinity()
+
+	return &Transaction{
+		Type:       BlobTx,
+		ChainID:    big.NewInt(100),
+		Nonce:      1,
+		GasTipCap:  big.NewInt(2),
+		GasPrice:   big.NewInt(11),
+		Gas:        21000,
+		To:         &addrTo,
+		Value:      big.NewInt(1),
+		Input:      []byte{1, 2},
+		AccessList: []*AccessTuple{{Address: addrTo, StorageKeys: []Hash{StringToHash("1")}}},
+		BlobFeeCap: big.NewInt(3),
+		BlobHashes: []Hash{KZGToVersionedHash(commitment)},
+		V:          big.NewInt(1),
+		R:          big.NewInt(26),
+		S:          big.NewInt(27),
+		Sidecar: &BlobSidecar{
+			Blobs:       [][]byte{make([]byte, BlobSize)},
+			Commitments: [][]byte{commitment},
+			Proofs:      [][]byte{pointAtInfinity()},
+		},
+	}
+}
+
+func TestRLPMarshall_And_Unmarshall_BlobTransaction(t *testing.T) {
+	t.Parallel()
+
+	txn := testBlobTransaction()
+	txn.ComputeHash()
+
+	// the consensus form doesn't carry the sidecar
+	unmarshalledTxn := new(Transaction)
+	assert.NoError(t, unmarshalledTxn.UnmarshalRLP(txn.MarshalRLP()))
+	assert.Nil(t, unmarshalledTxn.Sidecar)
+	assert.Equal(t, txn.Hash, unmarshalledTxn.Hash)
+
+	unmarshalledTxn.Sidecar = txn.Sidecar
+	assert.Equal(t, txn, unmarshalledTxn)
+
+	// the network form carries the sidecar, with the same hash
+	unmarshalledTxn = new(Transaction)
+	assert.NoError(t, unmarshalledTxn.UnmarshalRLP(txn.MarshalRLPWithSidecar()))
+	assert.Equal(t, txn, unmarshalledTxn)
+	assert.NoError(t, unmarshalledTxn.Sidecar.Validate(unmarshalledTxn.BlobHashes))
+
+	// the stored form is the envelope of the transaction with its sender
+	txn.From = StringToAddress("12")
+	txn.Sidecar = nil
+
+	unmarshalledTxn = new(Transaction)
+	assert.NoError(t, unmarshalledTxn.UnmarshalStoreRLP(txn.MarshalStoreRLPTo(nil)))
+	assert.Equal(t, txn, unmarshalledTxn)
+}
+
+func TestRLPUnmarshal_UnknownTxType(t *testing.T) {
+	t.Parallel()
+
+	envelope := testBlobTransaction().MarshalRLP()
+	envelope[0] = 0x02
+
+	assert.ErrorIs(t, new(Transaction).UnmarshalRLP(envelope), ErrUnknownTxType)
+}
+
+func TestRLPMarshall_And_Unmarshall_BlobHeader(t *testing.T) {
+	t.Parallel()
+
+	blobGasUsed, excessBlobGas := uint64(BlobGasPerBlob), uint64(TargetBlobGasPerBlock)
+
+	h := &Header{BlobGasUsed: &blobGasUsed, ExcessBlobGas: &excessBlobGas}
+	h.ComputeHash()
+
+	h2 := new(Header)
+	assert.NoError(t, h2.UnmarshalRLP(h.MarshalRLP()))
+	assert.Equal(t, h, h2)
+}
+
+func testBenchBlock(txs int) *Block {
+	to := StringToAddress("11")
+	block := &Block{Header: &Header{Number: 1, ExtraData: make([]byte, 97)}}
+
+	for i := 0; i < txs; i++ {
+		block.Transactions = append(block.Transactions, (&Transaction{
+			Nonce:    uint64(i),
+			GasPrice: big.NewInt(11),
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(1),
+			Input:    make([]byte, 68),
+			V:        big.NewInt(27),
+			R:        new(big.Int).Lsh(big.NewInt(1), 255),
+			S:        new(big.Int).Lsh(big.NewInt(1), 254),
+		}).ComputeHash())
+	}
+
+	return block
+}
+
+func BenchmarkBlock_UnmarshalRLP(b *testing.B) {
+	data := testBenchBlock(500).MarshalRLP()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := new(Block).UnmarshalRLP(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTransaction_UnmarshalRLP(b *testing.B) {
+	data := testBenchBlock(1).Transactions[0].MarshalRLP()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := new(Transaction).UnmarshalRLP(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestRLPUnmarshal_Transaction_Values(t *testing.T) {
+	t.Parallel()
+
+	txn := testBenchBlock(1).Transactions[0]
+	txn.Value = new(big.Int).Lsh(big.NewInt(1), 300)
+
+	decoded := new(Transaction)
+	assert.NoError(t, decoded.UnmarshalRLP(txn.MarshalRLP()))
+
+	assert.Equal(t, txn.Value, decoded.Value)
+	assert.Equal(t, txn.R, decoded.R)
+
+	// the values backed by the same allocation don't overwrite each other when growing
+	decoded.GasPrice.Lsh(decoded.GasPrice, 300)
+
+	assert.Equal(t, txn.Value, decoded.Value)
+	assert.Equal(t, txn.V, decoded.V)
+	assert.Equal(t, txn.R, decoded.R)
+}
+
+func TestTxHashFromRLP(t *testing.T) {
+	t.Parallel()
+
+	txn := testBenchBlock(1).Transactions[0]
+	data := txn.MarshalRLP()
+
+	hash, ok := TxHashFromRLP(data)
+	assert.True(t, ok)
+	assert.Equal(t, txn.Hash, hash)
+
+	// the hash of a truncated or extended encoding isn't returned
+	_, ok = TxHashFromRLP(data[:len(data)-1])
+	assert.False(t, ok)
+
+	_, ok = TxHashFromRLP(append(data, 0x1))
+	assert.False(t, ok)
+
+	// the typed transactions are decoded
+	_, ok = TxHashFromRLP(testBlobTransaction().MarshalRLP())
+	assert.False(t, ok)
+}
+
+func BenchmarkTxHashFromRLP(b *testing.B) {
+	data := testBenchBlock(1).Transactions[0].MarshalRLP()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, ok := TxHashFromRLP(data); !ok {
+			b.Fatal("no hash")
+		}
+	}
+}
+
+func BenchmarkTransaction_Size(b *testing.B) {
+	txn := testBenchBlock(1).Transactions[0]
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		// the size is cached by the transaction
+		txn.size = atomic.Value{}
+
+		if txn.Size() == 0 {
+			b.Fatal("no size")
+		}
+	}
+}
+
+func TestRLPMarshall_And_Unmarshall_ExpiringTransaction(t *testing.T) {
+	addrTo := StringToAddress("11")
+
+	for _, sponsored := range []bool{false, true} {
+		txn := &Transaction{
+			Nonce:      0,
+			GasPrice:   big.NewInt(11),
+			Gas:        11,
+			To:         &addrTo,
+			Value:      big.NewInt(1),
+			Input:      []byte{1, 2},
+			V:          big.NewInt(25),
+			S:          big.NewInt(26),
+			R:          big.NewInt(27),
+			From:       StringToAddress("12"),
+			ValidUntil: 100,
+		}
+
+		if sponsored {
+			txn.SponsorV = big.NewInt(28)
+			txn.SponsorR = big.NewInt(29)
+			txn.SponsorS = big.NewInt(30)
+			txn.Sponsor = StringToAddress("13")
+		}
+
+		unmarshalledTxn := new(Transaction)
+		if err := unmarshalledTxn.UnmarshalStoreRLP(txn.MarshalStoreRLPTo(nil)); err != nil {
+			t.Fatal(err)
+		}
+
+		unmarshalledTxn.ComputeHash()
+
+		txn.Hash = unmarshalledTxn.Hash
+		if !reflect.DeepEqual(txn, unmarshalledTxn) {
+			t.Fatal("[ERROR] Unmarshalled transaction not equal to base transaction")
+		}
+
+		assert.False(t, unmarshalledTxn.IsExpired(100))
+		assert.True(t, unmarshalledTxn.IsExpired(101))
+	}
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"sync"
+
 	"github.com/umbracle/fastrlp"
 )
 
@@ -18,6 +20,36 @@ func MarshalRLPTo(obj marshalRLPFunc, dst []byte) []byte {
 	return dst
 }
 
+// maxPooledBufferSize is the size above which the buffers aren't pooled,
+// so that a few blob transactions don't pin their buffers in the pool
+const maxPooledBufferSize = 256 * 1024
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 1024)
+
+		return &buf
+	},
+}
+
+// GetBuffer returns an empty buffer to marshal to, for the encodings which don't outlive
+// the caller, such as the gossiped messages which are copied when sent
+func GetBuffer() *[]byte {
+	buf, _ := bufferPool.Get().(*[]byte)
+	*buf = (*buf)[:0]
+
+	return buf
+}
+
+// PutBuffer releases the buffer, it must not be used afterwards
+func PutBuffer(buf *[]byte) {
+	if cap(*buf) > maxPooledBufferSize {
+		return
+	}
+
+	bufferPool.Put(buf)
+}
+
 func (b *Block) MarshalRLP() []byte {
 	return b.MarshalRLPTo(nil)
 }
@@ -83,6 +115,12 @@ func (h *Header) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewBytes(h.MixHash.Bytes()))
 	vv.Set(arena.NewCopyBytes(h.Nonce[:]))
 
+	// the blob gas, only set once the blob transactions are enabled
+	if h.BlobGasUsed != nil && h.ExcessBlobGas != nil {
+		vv.Set(arena.NewUint(*h.BlobGasUsed))
+		vv.Set(arena.NewUint(*h.ExcessBlobGas))
+	}
+
 	return vv
 }
 
@@ -159,12 +197,101 @@ func (t *Transaction) MarshalRLP() []byte {
 	return t.MarshalRLPTo(nil)
 }
 
+// MarshalRLPTo marshals the transaction, the typed transactions to their EIP-2718 envelope
 func (t *Transaction) MarshalRLPTo(dst []byte) []byte {
+	if t.Type == BlobTx {
+		return MarshalRLPTo(t.marshalBlobTxWith, append(dst, byte(BlobTx)))
+	}
+
 	return MarshalRLPTo(t.MarshalRLPWith, dst)
 }
 
-// MarshalRLPWith marshals the transaction to RLP with a specific fastrlp.Arena
+// MarshalRLPWithSidecar marshals the transaction with the sidecar of a blob transaction,
+// in the form they are sent and gossiped with
+func (t *Transaction) MarshalRLPWithSidecar() []byte {
+	if t.Type != BlobTx || t.Sidecar == nil {
+		return t.MarshalRLP()
+	}
+
+	return MarshalRLPTo(func(arena *fastrlp.Arena) *fastrlp.Value {
+		vv := arena.NewArray()
+		vv.Set(t.marshalBlobTxWith(arena))
+
+		sidecar := t.Sidecar.MarshalRLPWith(arena)
+		for i := 0; i < sidecar.Elems(); i++ {
+			vv.Set(sidecar.Get(i))
+		}
+
+		return vv
+	}, []byte{byte(BlobTx)})
+}
+
+// marshalBlobTxWith marshals the payload of a blob transaction
+func (t *Transaction) marshalBlobTxWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBigInt(t.ChainID))
+	vv.Set(arena.NewUint(t.Nonce))
+	vv.Set(arena.NewBigInt(t.GasTipCap))
+	vv.Set(arena.NewBigInt(t.GasPrice))
+	vv.Set(arena.NewUint(t.Gas))
+
+	if t.To != nil {
+		vv.Set(arena.NewBytes((*t.To).Bytes()))
+	} else {
+		vv.Set(arena.NewNull())
+	}
+
+	vv.Set(arena.NewBigInt(t.Value))
+	vv.Set(arena.NewCopyBytes(t.Input))
+	vv.Set(marshalAccessListWith(arena, t.AccessList))
+	vv.Set(arena.NewBigInt(t.BlobFeeCap))
+
+	hashes := arena.NewArray()
+	for _, hash := range t.BlobHashes {
+		hashes.Set(arena.NewCopyBytes(hash.Bytes()))
+	}
+
+	vv.Set(hashes)
+
+	// signature values, V is the parity of the signature
+	vv.Set(arena.NewBigInt(t.V))
+	vv.Set(arena.NewBigInt(t.R))
+	vv.Set(arena.NewBigInt(t.S))
+
+	return vv
+}
+
+func marshalAccessListWith(arena *fastrlp.Arena, accessList []*AccessTuple) *fastrlp.Value {
+	if len(accessList) == 0 {
+		return arena.NewNullArray()
+	}
+
+	vv := arena.NewArray()
+
+	for _, tuple := range accessList {
+		v := arena.NewArray()
+		v.Set(arena.NewCopyBytes(tuple.Address.Bytes()))
+
+		keys := arena.NewArray()
+		for _, key := range tuple.StorageKeys {
+			keys.Set(arena.NewCopyBytes(key.Bytes()))
+		}
+
+		v.Set(keys)
+		vv.Set(v)
+	}
+
+	return vv
+}
+
+// MarshalRLPWith marshals the transaction to RLP with a specific fastrlp.Arena,
+// a typed transaction is marshaled as the bytes of its envelope
 func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	if t.Type != LegacyTx {
+		return arena.NewCopyBytes(t.MarshalRLPTo(nil))
+	}
+
 	vv := arena.NewArray()
 
 	vv.Set(arena.NewUint(t.Nonce))
@@ -186,5 +313,17 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewBigInt(t.R))
 	vv.Set(arena.NewBigInt(t.S))
 
+	// sponsor signature values
+	if t.IsSponsored() {
+		vv.Set(arena.NewBigInt(t.SponsorV))
+		vv.Set(arena.NewBigInt(t.SponsorR))
+		vv.Set(arena.NewBigInt(t.SponsorS))
+	}
+
+	// expiry, the last value if any
+	if t.ValidUntil != 0 {
+		vv.Set(arena.NewUint(t.ValidUntil))
+	}
+
 	return vv
 }
@@ -48,6 +48,10 @@ func (t *Transaction) MarshalStoreRLPWith(a *fastrlp.Arena) *fastrlp.Value {
 	// context part
 	vv.Set(a.NewBytes(t.From.Bytes()))
 
+	if t.IsSponsored() {
+		vv.Set(a.NewBytes(t.Sponsor.Bytes()))
+	}
+
 	return vv
 }
 
@@ -85,5 +89,10 @@ func (r *Receipt) MarshalStoreRLPWith(a *fastrlp.Arena) *fastrlp.Value {
 	// TxHash
 	vv.Set(a.NewBytes(r.TxHash.Bytes()))
 
+	// revert reason, only stored for the reverted transactions
+	if len(r.RevertReason) > 0 {
+		vv.Set(a.NewBytes(r.RevertReason))
+	}
+
 	return vv
 }
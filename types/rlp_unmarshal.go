@@ -3,7 +3,9 @@ package types
 import (
 	"fmt"
 	"math/big"
+	"math/bits"
 
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/umbracle/fastrlp"
 )
 
@@ -60,6 +62,10 @@ func (b *Block) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 		return err
 	}
 
+	if len(txns) > 0 {
+		b.Transactions = make([]*Transaction, 0, len(txns))
+	}
+
 	for _, txn := range txns {
 		bTxn := &Transaction{}
 		if err := bTxn.UnmarshalRLPFrom(p, txn); err != nil {
@@ -75,6 +81,10 @@ func (b *Block) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 		return err
 	}
 
+	if len(uncles) > 0 {
+		b.Uncles = make([]*Header, 0, len(uncles))
+	}
+
 	for _, uncle := range uncles {
 		bUncle := &Header{}
 		if err := bUncle.UnmarshalRLPFrom(p, uncle); err != nil {
@@ -165,6 +175,23 @@ func (h *Header) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	h.SetNonce(nonce)
 
+	// the blob gas, only set once the blob transactions are enabled
+	h.BlobGasUsed, h.ExcessBlobGas = nil, nil
+
+	if len(elems) >= 17 {
+		blobGasUsed, err := elems[15].GetUint64()
+		if err != nil {
+			return err
+		}
+
+		excessBlobGas, err := elems[16].GetUint64()
+		if err != nil {
+			return err
+		}
+
+		h.BlobGasUsed, h.ExcessBlobGas = &blobGasUsed, &excessBlobGas
+	}
+
 	// compute the hash after the decoding
 	h.ComputeHash()
 
@@ -181,6 +208,10 @@ func (r *Receipts) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 		return err
 	}
 
+	if len(elems) > 0 {
+		*r = make(Receipts, 0, len(elems))
+	}
+
 	for _, elem := range elems {
 		rr := &Receipt{}
 		if err := rr.UnmarshalRLPFrom(p, elem); err != nil {
@@ -288,29 +319,60 @@ func (l *Log) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 	return nil
 }
 
+// UnmarshalRLP unmarshals a Transaction in RLP format, or the EIP-2718 envelope
+// of a typed transaction, with its sidecar if any
 func (t *Transaction) UnmarshalRLP(input []byte) error {
+	if len(input) > 0 && input[0] <= 0x7f {
+		return t.unmarshalTyped(input)
+	}
+
 	return UnmarshalRlp(t.UnmarshalRLPFrom, input)
 }
 
 // UnmarshalRLPFrom unmarshals a Transaction in RLP format
 func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	// a typed transaction is the bytes of its envelope
+	if v.Type() == fastrlp.TypeBytes {
+		envelope, err := v.GetBytes(nil)
+		if err != nil {
+			return err
+		}
+
+		return t.unmarshalTyped(envelope)
+	}
+
 	elems, err := v.GetElems()
 	if err != nil {
 		return err
 	}
 
+	t.Type = LegacyTx
+
 	if len(elems) < 9 {
 		return fmt.Errorf("incorrect number of elements to decode transaction, expected 9 but found %d", len(elems))
 	}
 
 	p.Hash(t.Hash[:0], v)
 
+	// the expiry follows the signature values and the sponsor signature, if any
+	t.ValidUntil = 0
+
+	if len(elems) == 10 || len(elems) == 13 {
+		if t.ValidUntil, err = elems[len(elems)-1].GetUint64(); err != nil {
+			return err
+		}
+
+		elems = elems[:len(elems)-1]
+	}
+
+	vals := new(txValues)
+
 	// nonce
 	if t.Nonce, err = elems[0].GetUint64(); err != nil {
 		return err
 	}
 	// gasPrice
-	t.GasPrice = new(big.Int)
+	t.GasPrice = vals.bigInt(0)
 	if err := elems[1].GetBigInt(t.GasPrice); err != nil {
 		return err
 	}
@@ -321,14 +383,14 @@ func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 	// to
 	if vv, _ := v.Get(3).Bytes(); len(vv) == 20 {
 		// address
-		addr := BytesToAddress(vv)
-		t.To = &addr
+		copy(vals.to[:], vv)
+		t.To = &vals.to
 	} else {
 		// reset To
 		t.To = nil
 	}
 	// value
-	t.Value = new(big.Int)
+	t.Value = vals.bigInt(1)
 	if err := elems[4].GetBigInt(t.Value); err != nil {
 		return err
 	}
@@ -338,21 +400,250 @@ func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 	}
 
 	// V
-	t.V = new(big.Int)
+	t.V = vals.bigInt(2)
 	if err = elems[6].GetBigInt(t.V); err != nil {
 		return err
 	}
 
 	// R
-	t.R = new(big.Int)
+	t.R = vals.bigInt(3)
 	if err = elems[7].GetBigInt(t.R); err != nil {
 		return err
 	}
 	// S
-	t.S = new(big.Int)
+	t.S = vals.bigInt(4)
 	if err = elems[8].GetBigInt(t.S); err != nil {
 		return err
 	}
 
+	// the sponsor signature, only set in the sponsored transactions
+	if len(elems) < 12 {
+		t.SponsorV, t.SponsorR, t.SponsorS = nil, nil, nil
+
+		return nil
+	}
+
+	t.SponsorV = new(big.Int)
+	if err = elems[9].GetBigInt(t.SponsorV); err != nil {
+		return err
+	}
+
+	t.SponsorR = new(big.Int)
+	if err = elems[10].GetBigInt(t.SponsorR); err != nil {
+		return err
+	}
+
+	t.SponsorS = new(big.Int)
+	if err = elems[11].GetBigInt(t.SponsorS); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// txValues holds the values of a decoded legacy transaction in a single allocation,
+// the words of the integers up to 256 bits are backed by it too. The blocks are decoded
+// transaction by transaction, the values would otherwise be allocated one by one
+type txValues struct {
+	ints  [5]big.Int
+	words [5][256 / bits.UintSize]big.Word
+	to    Address
+}
+
+// bigInt returns the i-th integer, backed by the i-th words. An integer growing
+// past its words is moved out of them, it never overwrites the next integer
+func (vals *txValues) bigInt(i int) *big.Int {
+	return vals.ints[i].SetBits(vals.words[i][:0:len(vals.words[i])])
+}
+
+// TxHashFromRLP returns the hash of the RLP encoded legacy transaction without decoding it.
+// It's used to drop the known transactions before paying for their decoding, it returns
+// false for the typed transactions, whose hash doesn't cover their sidecar
+func TxHashFromRLP(input []byte) (Hash, bool) {
+	if size, ok := rlpListSize(input); !ok || size != uint64(len(input)) {
+		return ZeroHash, false
+	}
+
+	return keccak.Sum256(input), true
+}
+
+// rlpListSize returns the full size of the RLP list the input starts with
+func rlpListSize(input []byte) (uint64, bool) {
+	if len(input) == 0 || input[0] < 0xc0 {
+		return 0, false
+	}
+
+	if input[0] < 0xf8 {
+		return 1 + uint64(input[0]-0xc0), true
+	}
+
+	lenSize := int(input[0] - 0xf7)
+	if len(input) < 1+lenSize {
+		return 0, false
+	}
+
+	var size uint64
+	for _, b := range input[1 : 1+lenSize] {
+		size = size<<8 | uint64(b)
+	}
+
+	return 1 + uint64(lenSize) + size, true
+}
+
+// unmarshalTyped unmarshals the EIP-2718 envelope of a typed transaction
+func (t *Transaction) unmarshalTyped(envelope []byte) error {
+	if len(envelope) == 0 || TxType(envelope[0]) != BlobTx {
+		return ErrUnknownTxType
+	}
+
+	pr := fastrlp.DefaultParserPool.Get()
+	defer fastrlp.DefaultParserPool.Put(pr)
+
+	v, err := pr.Parse(envelope[1:])
+	if err != nil {
+		return err
+	}
+
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	// the network form wraps the transaction with its sidecar
+	t.Sidecar = nil
+
+	if len(elems) == 4 && elems[0].Type() == fastrlp.TypeArray {
+		t.Sidecar = &BlobSidecar{}
+		if err := t.Sidecar.unmarshalLists(elems[1:]); err != nil {
+			return err
+		}
+
+		v = elems[0]
+	}
+
+	if err := t.unmarshalBlobTxFrom(v); err != nil {
+		return err
+	}
+
+	t.ComputeHash()
+
+	return nil
+}
+
+// unmarshalBlobTxFrom unmarshals the payload of a blob transaction
+func (t *Transaction) unmarshalBlobTxFrom(v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 14 {
+		return fmt.Errorf("incorrect number of elements to decode blob transaction, expected 14 but found %d", len(elems))
+	}
+
+	t.Type = BlobTx
+	t.SponsorV, t.SponsorR, t.SponsorS = nil, nil, nil
+	t.ValidUntil = 0
+
+	bigInts := []struct {
+		dst  **big.Int
+		elem *fastrlp.Value
+	}{
+		{&t.ChainID, elems[0]},
+		{&t.GasTipCap, elems[2]},
+		{&t.GasPrice, elems[3]},
+		{&t.Value, elems[6]},
+		{&t.BlobFeeCap, elems[9]},
+		{&t.V, elems[11]},
+		{&t.R, elems[12]},
+		{&t.S, elems[13]},
+	}
+
+	for _, bigInt := range bigInts {
+		*bigInt.dst = new(big.Int)
+		if err := bigInt.elem.GetBigInt(*bigInt.dst); err != nil {
+			return err
+		}
+	}
+
+	if t.Nonce, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if t.Gas, err = elems[4].GetUint64(); err != nil {
+		return err
+	}
+
+	// a blob transaction can't create a contract
+	to := Address{}
+	if err := elems[5].GetAddr(to[:0]); err != nil {
+		return err
+	}
+
+	t.To = &to
+
+	if t.Input, err = elems[7].GetBytes(t.Input[:0]); err != nil {
+		return err
+	}
+
+	if t.AccessList, err = unmarshalAccessList(elems[8]); err != nil {
+		return err
+	}
+
+	hashes, err := elems[10].GetElems()
+	if err != nil {
+		return err
+	}
+
+	t.BlobHashes = make([]Hash, len(hashes))
+
+	for i, hash := range hashes {
+		if err := hash.GetHash(t.BlobHashes[i][:0]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func unmarshalAccessList(v *fastrlp.Value) ([]*AccessTuple, error) {
+	elems, err := v.GetElems()
+	if err != nil {
+		return nil, err
+	}
+
+	accessList := make([]*AccessTuple, len(elems))
+
+	for i, elem := range elems {
+		fields, err := elem.GetElems()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("incorrect number of elements to decode access tuple, expected 2 but found %d", len(fields))
+		}
+
+		tuple := &AccessTuple{}
+		if err := fields[0].GetAddr(tuple.Address[:0]); err != nil {
+			return nil, err
+		}
+
+		keys, err := fields[1].GetElems()
+		if err != nil {
+			return nil, err
+		}
+
+		tuple.StorageKeys = make([]Hash, len(keys))
+
+		for j, key := range keys {
+			if err := key.GetHash(tuple.StorageKeys[j][:0]); err != nil {
+				return nil, err
+			}
+		}
+
+		accessList[i] = tuple
+	}
+
+	return accessList, nil
+}
@@ -80,6 +80,12 @@ func (t *Transaction) UnmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value)
 		return err
 	}
 
+	if len(elems) > 2 {
+		if err = elems[2].GetAddr(t.Sponsor[:]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -141,7 +147,7 @@ func (r *Receipt) UnmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 
 	// tx hash
 	// backwards compatibility, old receipts did not marshal a TxHash
-	if len(elems) == 4 {
+	if len(elems) >= 4 {
 		vv, err := elems[3].Bytes()
 		if err != nil {
 			return err
@@ -150,5 +156,12 @@ func (r *Receipt) UnmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 		r.TxHash = BytesToHash(vv)
 	}
 
+	// revert reason
+	if len(elems) >= 5 {
+		if r.RevertReason, err = elems[4].GetBytes(nil); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,182 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/fastrlp"
+)
+
+// StateDiff is the set of the state changes of a block
+type StateDiff struct {
+	Accounts []*AccountDiff
+}
+
+// AccountDiff is the change of an account in a block,
+// a deleted account has all of its storage cleared
+type AccountDiff struct {
+	Address Address
+	Created bool
+	Deleted bool
+
+	PrevNonce    uint64
+	Nonce        uint64
+	PrevBalance  *big.Int
+	Balance      *big.Int
+	PrevCodeHash Hash
+	CodeHash     Hash
+
+	Storage []*StorageDiff
+}
+
+// StorageDiff is the change of a storage slot in a block
+type StorageDiff struct {
+	Key       Hash
+	PrevValue Hash
+	Value     Hash
+}
+
+func (s *StateDiff) MarshalRLPTo(dst []byte) []byte {
+	return MarshalRLPTo(s.MarshalRLPWith, dst)
+}
+
+func (s *StateDiff) MarshalRLPWith(a *fastrlp.Arena) *fastrlp.Value {
+	if len(s.Accounts) == 0 {
+		return a.NewNullArray()
+	}
+
+	vv := a.NewArray()
+	for _, account := range s.Accounts {
+		vv.Set(account.MarshalRLPWith(a))
+	}
+
+	return vv
+}
+
+func (d *AccountDiff) MarshalRLPWith(a *fastrlp.Arena) *fastrlp.Value {
+	vv := a.NewArray()
+	vv.Set(a.NewBytes(d.Address.Bytes()))
+	vv.Set(a.NewBool(d.Created))
+	vv.Set(a.NewBool(d.Deleted))
+	vv.Set(a.NewUint(d.PrevNonce))
+	vv.Set(a.NewUint(d.Nonce))
+	vv.Set(a.NewBigInt(d.PrevBalance))
+	vv.Set(a.NewBigInt(d.Balance))
+	vv.Set(a.NewBytes(d.PrevCodeHash.Bytes()))
+	vv.Set(a.NewBytes(d.CodeHash.Bytes()))
+
+	if len(d.Storage) == 0 {
+		vv.Set(a.NewNullArray())
+	} else {
+		storage := a.NewArray()
+		for _, slot := range d.Storage {
+			v := a.NewArray()
+			v.Set(a.NewBytes(slot.Key.Bytes()))
+			v.Set(a.NewBytes(slot.PrevValue.Bytes()))
+			v.Set(a.NewBytes(slot.Value.Bytes()))
+			storage.Set(v)
+		}
+		vv.Set(storage)
+	}
+
+	return vv
+}
+
+func (s *StateDiff) UnmarshalRLP(input []byte) error {
+	return UnmarshalRlp(s.UnmarshalRLPFrom, input)
+}
+
+func (s *StateDiff) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range elems {
+		account := &AccountDiff{}
+		if err := account.UnmarshalRLPFrom(p, elem); err != nil {
+			return err
+		}
+
+		s.Accounts = append(s.Accounts, account)
+	}
+
+	return nil
+}
+
+func (d *AccountDiff) UnmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 10 {
+		return fmt.Errorf("incorrect number of elements to decode account diff, expected 10 but found %d", len(elems))
+	}
+
+	if err = elems[0].GetAddr(d.Address[:]); err != nil {
+		return err
+	}
+
+	if d.Created, err = elems[1].GetBool(); err != nil {
+		return err
+	}
+
+	if d.Deleted, err = elems[2].GetBool(); err != nil {
+		return err
+	}
+
+	if d.PrevNonce, err = elems[3].GetUint64(); err != nil {
+		return err
+	}
+
+	if d.Nonce, err = elems[4].GetUint64(); err != nil {
+		return err
+	}
+
+	d.PrevBalance = new(big.Int)
+	if err = elems[5].GetBigInt(d.PrevBalance); err != nil {
+		return err
+	}
+
+	d.Balance = new(big.Int)
+	if err = elems[6].GetBigInt(d.Balance); err != nil {
+		return err
+	}
+
+	if err = elems[7].GetHash(d.PrevCodeHash[:]); err != nil {
+		return err
+	}
+
+	if err = elems[8].GetHash(d.CodeHash[:]); err != nil {
+		return err
+	}
+
+	storageElems, err := elems[9].GetElems()
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range storageElems {
+		slotElems, err := elem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(slotElems) < 3 {
+			return fmt.Errorf("incorrect number of elements to decode storage diff, expected 3 but found %d", len(slotElems))
+		}
+
+		slot := &StorageDiff{}
+
+		for i, hash := range []*Hash{&slot.Key, &slot.PrevValue, &slot.Value} {
+			if err := slotElems[i].GetHash(hash[:]); err != nil {
+				return err
+			}
+		}
+
+		d.Storage = append(d.Storage, slot)
+	}
+
+	return nil
+}
@@ -20,6 +20,31 @@ type Transaction struct {
 	Hash     Hash
 	From     Address
 
+	// sponsor signature of a sponsored transaction, whose gas is paid by the sponsor
+	SponsorV *big.Int
+	SponsorR *big.Int
+	SponsorS *big.Int
+
+	// Sponsor is the account paying the gas of a sponsored transaction
+	Sponsor Address
+
+	// ValidUntil is the last block the transaction can be included in, no expiry if 0
+	ValidUntil uint64
+
+	// Type is the EIP-2718 type of the transaction
+	Type TxType
+
+	// the fields of the blob transactions, whose GasPrice is the max fee per gas
+	ChainID    *big.Int
+	GasTipCap  *big.Int
+	AccessList []*AccessTuple
+	BlobFeeCap *big.Int
+	BlobHashes []Hash
+
+	// Sidecar holds the blobs of a blob transaction while it is gossiped,
+	// it isn't part of the transaction
+	Sidecar *BlobSidecar
+
 	// Cache
 	size atomic.Value
 }
@@ -29,8 +54,38 @@ func (t *Transaction) IsContractCreation() bool {
 	return t.To == nil
 }
 
+// IsSponsored checks if the gas of the transaction is paid by a sponsor
+func (t *Transaction) IsSponsored() bool {
+	return t.SponsorR != nil && t.SponsorS != nil
+}
+
+// IsExpired checks if the transaction can't be included in the block of the given number
+func (t *Transaction) IsExpired(number uint64) bool {
+	return t.ValidUntil != 0 && number > t.ValidUntil
+}
+
+// Payer returns the account paying the gas of the transaction
+func (t *Transaction) Payer() Address {
+	if t.IsSponsored() {
+		return t.Sponsor
+	}
+
+	return t.From
+}
+
 // ComputeHash computes the hash of the transaction
 func (t *Transaction) ComputeHash() *Transaction {
+	if t.Type != LegacyTx {
+		// the hash of a typed transaction is the hash of its envelope
+		buf := GetBuffer()
+		*buf = t.MarshalRLPTo(*buf)
+		t.Hash = keccak.Sum256(*buf)
+
+		PutBuffer(buf)
+
+		return t
+	}
+
 	ar := marshalArenaPool.Get()
 	hash := keccak.DefaultKeccakPool.Get()
 
@@ -67,17 +122,74 @@ func (t *Transaction) Copy() *Transaction {
 		tt.S = big.NewInt(0).SetBits(t.S.Bits())
 	}
 
+	if t.SponsorV != nil {
+		tt.SponsorV = new(big.Int).Set(t.SponsorV)
+	}
+
+	if t.SponsorR != nil {
+		tt.SponsorR = new(big.Int).Set(t.SponsorR)
+	}
+
+	if t.SponsorS != nil {
+		tt.SponsorS = new(big.Int).Set(t.SponsorS)
+	}
+
 	tt.Input = make([]byte, len(t.Input))
 	copy(tt.Input[:], t.Input[:])
 
+	if t.ChainID != nil {
+		tt.ChainID = new(big.Int).Set(t.ChainID)
+	}
+
+	if t.GasTipCap != nil {
+		tt.GasTipCap = new(big.Int).Set(t.GasTipCap)
+	}
+
+	if t.BlobFeeCap != nil {
+		tt.BlobFeeCap = new(big.Int).Set(t.BlobFeeCap)
+	}
+
+	if t.AccessList != nil {
+		tt.AccessList = make([]*AccessTuple, len(t.AccessList))
+		for i, tuple := range t.AccessList {
+			tt.AccessList[i] = &AccessTuple{
+				Address:     tuple.Address,
+				StorageKeys: append([]Hash{}, tuple.StorageKeys...),
+			}
+		}
+	}
+
+	if t.BlobHashes != nil {
+		tt.BlobHashes = append([]Hash{}, t.BlobHashes...)
+	}
+
 	return tt
 }
 
-// Cost returns gas * gasPrice + value
+// EffectiveGasPrice returns the price paid per gas by the transaction: the tip of a blob
+// transaction, capped by its max fee per gas, since the chain has no base fee
+func (t *Transaction) EffectiveGasPrice() *big.Int {
+	if t.Type == BlobTx && t.GasTipCap != nil && t.GasTipCap.Cmp(t.GasPrice) < 0 {
+		return new(big.Int).Set(t.GasTipCap)
+	}
+
+	return new(big.Int).Set(t.GasPrice)
+}
+
+// GasCost returns gas * gasPrice
+func (t *Transaction) GasCost() *big.Int {
+	return new(big.Int).Mul(t.GasPrice, new(big.Int).SetUint64(t.Gas))
+}
+
+// Cost returns gas * gasPrice + value, plus the max blob fee of a blob transaction
 func (t *Transaction) Cost() *big.Int {
 	total := new(big.Int).Mul(t.GasPrice, new(big.Int).SetUint64(t.Gas))
 	total.Add(total, t.Value)
 
+	if t.Type == BlobTx && t.BlobFeeCap != nil {
+		total.Add(total, new(big.Int).Mul(t.BlobFeeCap, new(big.Int).SetUint64(t.BlobGas())))
+	}
+
 	return total
 }
 
@@ -91,7 +203,12 @@ func (t *Transaction) Size() uint64 {
 		return sizeVal
 	}
 
-	size := uint64(len(t.MarshalRLP()))
+	// the encoding is only measured, it's marshaled to a pooled buffer
+	buf := GetBuffer()
+	*buf = t.MarshalRLPTo(*buf)
+	size := uint64(len(*buf))
+
+	PutBuffer(buf)
 	t.size.Store(size)
 
 	return size
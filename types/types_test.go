@@ -5,7 +5,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEIP55(t *testing.T) {
@@ -84,3 +86,88 @@ func TestTransactionCopy(t *testing.T) {
 		t.Fatal("[ERROR] Copied transaction not equal base transaction")
 	}
 }
+
+func TestBlobGasPrice(t *testing.T) {
+	t.Parallel()
+
+	used := uint64(MaxBlobGasPerBlock)
+	excess := uint64(0)
+	parent := &Header{BlobGasUsed: &used, ExcessBlobGas: &excess}
+
+	// full blocks raise the excess blob gas by the target
+	assert.Equal(t, uint64(TargetBlobGasPerBlock), CalcExcessBlobGas(parent))
+	assert.Equal(t, uint64(0), CalcExcessBlobGas(&Header{}))
+
+	cases := []struct {
+		excess   uint64
+		expected int64
+	}{
+		{0, 1},
+		{2314057, 1},
+		{2314058, 2},
+		{10 * 1024 * 1024, 23},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, CalcBlobGasPrice(c.excess).Int64())
+	}
+}
+
+// pointAtInfinity returns the compressed G1 point at infinity,
+// the KZG commitment to the empty blob and its proof
+func pointAtInfinity() []byte {
+	point := make([]byte, KZGCommitmentSize)
+	point[0] = 0xc0
+
+	return point
+}
+
+func TestBlobSidecar_Validate(t *testing.T) {
+	t.Parallel()
+
+	commitment := pointAtInfinity()
+	sidecar := &BlobSidecar{
+		Blobs:       [][]byte{make([]byte, BlobSize)},
+		Commitments: [][]byte{commitment},
+		Proofs:      [][]byte{pointAtInfinity()},
+	}
+
+	assert.NoError(t, sidecar.Validate([]Hash{KZGToVersionedHash(commitment)}))
+	assert.ErrorIs(t, sidecar.Validate([]Hash{StringToHash("1")}), ErrBlobSidecarMismatch)
+	assert.ErrorIs(t, sidecar.Validate(nil), ErrBlobSidecarMismatch)
+
+	// the commitment to another blob, the compressed generator of G1
+	generator, err := hex.DecodeHex(
+		"0x97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb",
+	)
+	require.NoError(t, err)
+
+	sidecar.Commitments[0] = generator
+	assert.ErrorIs(t, sidecar.Validate([]Hash{KZGToVersionedHash(generator)}), ErrInvalidBlobProof)
+
+	// the proof isn't a compressed point
+	sidecar.Commitments[0] = commitment
+	sidecar.Proofs[0] = make([]byte, KZGProofSize)
+	assert.ErrorIs(t, sidecar.Validate([]Hash{KZGToVersionedHash(commitment)}), ErrInvalidBlobProof)
+
+	sidecar.Blobs[0] = sidecar.Blobs[0][1:]
+	assert.ErrorIs(t, sidecar.Validate([]Hash{KZGToVersionedHash(commitment)}), ErrInvalidBlobSidecar)
+}
+
+func TestBloom_IsLogInBloom(t *testing.T) {
+	t.Parallel()
+
+	log := &Log{
+		Address: StringToAddress("1"),
+		Topics:  []Hash{StringToHash("2"), StringToHash("3")},
+	}
+
+	bloom := CreateBloom([]*Receipt{{Logs: []*Log{log}}})
+
+	assert.True(t, bloom.IsLogInBloom(log))
+	assert.True(t, bloom.IsPresent(log.Address.Bytes()))
+	assert.True(t, bloom.IsPresent(log.Topics[1].Bytes()))
+
+	assert.False(t, bloom.IsPresent(StringToAddress("4").Bytes()))
+	assert.False(t, bloom.IsLogInBloom(&Log{Address: log.Address, Topics: []Hash{StringToHash("5")}}))
+}
@@ -0,0 +1,196 @@
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/versioning"
+	"github.com/hashicorp/go-hclog"
+)
+
+// countdownBlocks are the numbers of the remaining blocks logged before an upgrade
+var countdownBlocks = []uint64{10000, 1000, 100, 10, 5, 4, 3, 2, 1}
+
+var ErrUpgradeRequired = errors.New("the binary doesn't support the scheduled upgrade")
+
+// Blockchain is the interface of the blockchain the upgrades are counted down on
+type Blockchain interface {
+	SubscribeEvents() blockchain.Subscription
+}
+
+// Status is the readiness of the node and its peers for an upgrade
+type Status struct {
+	*chain.Upgrade
+
+	// Supported is set if the binary of the node supports the upgrade
+	Supported bool
+
+	// ReadyPeers is the number of the peers whose binary supports the upgrade
+	ReadyPeers int
+}
+
+// Coordinator gates the blocks of the scheduled upgrades by the version of the binary:
+// the node stops producing and importing blocks at the height of an upgrade it doesn't support
+type Coordinator struct {
+	logger   hclog.Logger
+	upgrades []*chain.Upgrade
+
+	// version is the version of the binary, empty for the development builds
+	version string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewCoordinator creates a coordinator of the given upgrades for the binary of the given version.
+// The development builds, without version, are assumed to support every upgrade
+func NewCoordinator(logger hclog.Logger, upgrades []*chain.Upgrade, version string) *Coordinator {
+	c := &Coordinator{
+		logger:   logger.Named("upgrade"),
+		upgrades: upgrades,
+		version:  version,
+	}
+
+	if version == "" && len(upgrades) > 0 {
+		c.logger.Warn("the binary has no version, assuming it supports the scheduled upgrades")
+	}
+
+	return c
+}
+
+// Supports returns true if the binary supports the upgrade
+func (c *Coordinator) Supports(upgrade *chain.Upgrade) bool {
+	return c.version == "" || versioning.Supports(c.version, upgrade.Version)
+}
+
+// CheckBlock returns ErrUpgradeRequired if the block of the given number
+// is at or past the height of an upgrade the binary doesn't support
+func (c *Coordinator) CheckBlock(number uint64) error {
+	for _, upgrade := range c.upgrades {
+		if number >= upgrade.Block && !c.Supports(upgrade) {
+			return fmt.Errorf(
+				"%w: %s requires version %s from block %d, the binary is %s",
+				ErrUpgradeRequired,
+				upgrade.Name,
+				upgrade.Version,
+				upgrade.Block,
+				c.version,
+			)
+		}
+	}
+
+	return nil
+}
+
+// Status returns the readiness for the scheduled upgrades of the node
+// and of the peers of the given versions
+func (c *Coordinator) Status(peerVersions []string) []*Status {
+	res := make([]*Status, len(c.upgrades))
+
+	for i, upgrade := range c.upgrades {
+		ready := 0
+
+		for _, version := range peerVersions {
+			if versioning.Supports(version, upgrade.Version) {
+				ready++
+			}
+		}
+
+		res[i] = &Status{
+			Upgrade:    upgrade,
+			Supported:  c.Supports(upgrade),
+			ReadyPeers: ready,
+		}
+	}
+
+	return res
+}
+
+// Start starts counting down the upgrades as the blocks are written
+func (c *Coordinator) Start(b Blockchain) {
+	if len(c.upgrades) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	sub := b.SubscribeEvents()
+
+	c.wg.Add(1)
+
+	go func() {
+		defer c.wg.Done()
+		defer sub.Close()
+
+		eventCh := sub.GetEventCh()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-eventCh:
+				if ev == nil {
+					return
+				}
+
+				if ev.Type != blockchain.EventFork && len(ev.NewChain) > 0 {
+					c.countdown(ev.Header().Number)
+				}
+			}
+		}
+	}()
+}
+
+// Close stops the countdown
+func (c *Coordinator) Close() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+
+	c.wg.Wait()
+}
+
+// countdown logs the upgrades whose height is a countdown step after the given block,
+// as a warning if the binary doesn't support them
+func (c *Coordinator) countdown(number uint64) {
+	for _, upgrade := range c.upgrades {
+		if upgrade.Block <= number {
+			continue
+		}
+
+		remaining := upgrade.Block - number
+		if !isCountdownStep(remaining) {
+			continue
+		}
+
+		if c.Supports(upgrade) {
+			c.logger.Info("scheduled upgrade", "name", upgrade.Name, "block", upgrade.Block, "remaining", remaining)
+
+			continue
+		}
+
+		c.logger.Warn(
+			"the binary doesn't support the scheduled upgrade, the node will halt at its block",
+			"name", upgrade.Name,
+			"block", upgrade.Block,
+			"remaining", remaining,
+			"required", upgrade.Version,
+			"version", c.version,
+		)
+	}
+}
+
+func isCountdownStep(remaining uint64) bool {
+	for _, step := range countdownBlocks {
+		if remaining == step {
+			return true
+		}
+	}
+
+	return false
+}
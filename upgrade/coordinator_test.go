@@ -0,0 +1,65 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoordinator_CheckBlock(t *testing.T) {
+	t.Parallel()
+
+	upgrades := []*chain.Upgrade{
+		{Name: "v07", Block: 100, Version: "0.7.0"},
+		{Name: "v08", Block: 200, Version: "0.8.0"},
+	}
+
+	cases := []struct {
+		name    string
+		version string
+		number  uint64
+		err     bool
+	}{
+		{"before the upgrade", "0.6.3", 99, false},
+		{"at the upgrade", "0.6.3", 100, true},
+		{"supported upgrade", "0.7.1", 150, false},
+		{"past a supported upgrade", "0.7.1", 200, true},
+		{"development build", "", 1000, false},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			coordinator := NewCoordinator(hclog.NewNullLogger(), upgrades, c.version)
+
+			err := coordinator.CheckBlock(c.number)
+			if c.err {
+				assert.ErrorIs(t, err, ErrUpgradeRequired)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCoordinator_Status(t *testing.T) {
+	t.Parallel()
+
+	upgrades := []*chain.Upgrade{
+		{Name: "v07", Block: 100, Version: "0.7.0"},
+	}
+
+	coordinator := NewCoordinator(hclog.NewNullLogger(), upgrades, "0.6.3")
+
+	// the peers which didn't advertise their version aren't ready
+	status := coordinator.Status([]string{"0.7.0", "v0.7.2", "0.6.3", ""})
+
+	assert.Equal(t, []*Status{
+		{Upgrade: upgrades[0], Supported: false, ReadyPeers: 2},
+	}, status)
+}
@@ -0,0 +1,227 @@
+package bundle
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+var (
+	ErrAlreadySigned    = errors.New("the bundle is already signed by the key")
+	ErrInvalidSignature = errors.New("invalid bundle signature")
+	ErrBundleMismatch   = errors.New("the validator set differs from the bundle")
+	ErrNoValidators     = errors.New("the bundle has no validators")
+)
+
+// Bundle is the validator set of a chain at the last block of an epoch, signed by the validators
+// exporting it. A halted chain is restarted from the validator set of a bundle signed by a supermajority
+type Bundle struct {
+	ChainID   uint64     `json:"chainId"`
+	Epoch     uint64     `json:"epoch"`
+	Block     uint64     `json:"block"`
+	BlockHash types.Hash `json:"blockHash"`
+
+	Type       validators.ValidatorType `json:"type"`
+	Validators []*Validator             `json:"validators"`
+
+	Signatures []*Signature `json:"signatures"`
+}
+
+// Validator is a validator of the bundle with its stake, nil if the chain has no staking contract
+type Validator struct {
+	Address      types.Address                    `json:"address"`
+	BLSPublicKey validators.BLSValidatorPublicKey `json:"blsPublicKey,omitempty"`
+	Stake        *big.Int                         `json:"stake,omitempty"`
+}
+
+// Signature is the signature of the bundle by a validator key
+type Signature struct {
+	Signer    types.Address `json:"signer"`
+	Signature string        `json:"signature"`
+}
+
+// Hash returns the hash of the bundle signed by the validators, its signatures excluded
+func (b *Bundle) Hash() (types.Hash, error) {
+	unsigned := *b
+	unsigned.Signatures = nil
+
+	raw, err := json.Marshal(&unsigned)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(crypto.Keccak256(raw)), nil
+}
+
+// Sign adds the signature of the key to the bundle
+func (b *Bundle) Sign(key *ecdsa.PrivateKey) error {
+	signer := crypto.PubKeyToAddress(&key.PublicKey)
+
+	if b.signedBy(signer) {
+		return ErrAlreadySigned
+	}
+
+	hash, err := b.Hash()
+	if err != nil {
+		return err
+	}
+
+	sig, err := crypto.Sign(key, hash.Bytes())
+	if err != nil {
+		return err
+	}
+
+	b.Signatures = append(b.Signatures, &Signature{
+		Signer:    signer,
+		Signature: hex.EncodeToHex(sig),
+	})
+
+	return nil
+}
+
+// Verify checks the signatures of the bundle, returning the signers
+func (b *Bundle) Verify() ([]types.Address, error) {
+	hash, err := b.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	signers := make([]types.Address, 0, len(b.Signatures))
+	seen := make(map[types.Address]bool, len(b.Signatures))
+
+	for _, sig := range b.Signatures {
+		raw, err := hex.DecodeHex(sig.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("%w of %s: %v", ErrInvalidSignature, sig.Signer, err)
+		}
+
+		pub, err := crypto.RecoverPubkey(raw, hash.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%w of %s: %v", ErrInvalidSignature, sig.Signer, err)
+		}
+
+		if crypto.PubKeyToAddress(pub) != sig.Signer || seen[sig.Signer] {
+			return nil, fmt.Errorf("%w of %s", ErrInvalidSignature, sig.Signer)
+		}
+
+		seen[sig.Signer] = true
+		signers = append(signers, sig.Signer)
+	}
+
+	return signers, nil
+}
+
+// HasSupermajority returns true if more than 2/3 of the validators of the bundle are among the signers
+func (b *Bundle) HasSupermajority(signers []types.Address) bool {
+	members := make(map[types.Address]bool, len(b.Validators))
+	for _, v := range b.Validators {
+		members[v.Address] = true
+	}
+
+	count := 0
+
+	for _, signer := range signers {
+		if members[signer] {
+			count++
+		}
+	}
+
+	return 3*count > 2*len(b.Validators)
+}
+
+// ValidatorSet returns the validator set of the bundle
+func (b *Bundle) ValidatorSet() (validators.Validators, error) {
+	if len(b.Validators) == 0 {
+		return nil, ErrNoValidators
+	}
+
+	set := validators.NewValidatorSetFromType(b.Type)
+	if set == nil {
+		return nil, validators.ErrInvalidValidatorType
+	}
+
+	for _, v := range b.Validators {
+		var validator validators.Validator
+
+		switch b.Type {
+		case validators.BLSValidatorType:
+			validator = validators.NewBLSValidator(v.Address, v.BLSPublicKey)
+		default:
+			validator = validators.NewECDSAValidator(v.Address)
+		}
+
+		if err := set.Add(validator); err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+}
+
+// CoSign adds the signatures of the other bundle of the same validator set
+func (b *Bundle) CoSign(other *Bundle) error {
+	hash, err := b.Hash()
+	if err != nil {
+		return err
+	}
+
+	otherHash, err := other.Hash()
+	if err != nil {
+		return err
+	}
+
+	if hash != otherHash {
+		return ErrBundleMismatch
+	}
+
+	for _, sig := range other.Signatures {
+		if !b.signedBy(sig.Signer) {
+			b.Signatures = append(b.Signatures, sig)
+		}
+	}
+
+	return nil
+}
+
+func (b *Bundle) signedBy(signer types.Address) bool {
+	for _, sig := range b.Signatures {
+		if sig.Signer == signer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReadFile reads the bundle of the file
+func ReadFile(path string) (*Bundle, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bundle{}
+	if err := json.Unmarshal(raw, b); err != nil {
+		return nil, fmt.Errorf("invalid bundle %s: %w", path, err)
+	}
+
+	return b, nil
+}
+
+// WriteFile writes the bundle to the file
+func (b *Bundle) WriteFile(path string) error {
+	raw, err := json.MarshalIndent(b, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, raw, 0600)
+}
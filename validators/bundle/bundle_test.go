@@ -0,0 +1,104 @@
+package bundle
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBundle(t *testing.T, n int) (*Bundle, []*ecdsa.PrivateKey) {
+	t.Helper()
+
+	b := &Bundle{
+		ChainID:   100,
+		Epoch:     3,
+		Block:     30,
+		BlockHash: types.StringToHash("1"),
+		Type:      validators.ECDSAValidatorType,
+	}
+
+	keys := make([]*ecdsa.PrivateKey, n)
+
+	for i := range keys {
+		key, err := crypto.GenerateECDSAKey()
+		require.NoError(t, err)
+
+		keys[i] = key
+		b.Validators = append(b.Validators, &Validator{
+			Address: crypto.PubKeyToAddress(&key.PublicKey),
+			Stake:   big.NewInt(1000),
+		})
+	}
+
+	return b, keys
+}
+
+func TestBundle_SignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	b, keys := newTestBundle(t, 4)
+
+	for _, key := range keys[:2] {
+		require.NoError(t, b.Sign(key))
+	}
+
+	assert.ErrorIs(t, b.Sign(keys[0]), ErrAlreadySigned)
+
+	signers, err := b.Verify()
+	require.NoError(t, err)
+	assert.False(t, b.HasSupermajority(signers))
+
+	// the bundle exported by another validator is co-signed
+	raw, err := json.Marshal(b)
+	require.NoError(t, err)
+
+	other := &Bundle{}
+	require.NoError(t, json.Unmarshal(raw, other))
+
+	other.Signatures = nil
+
+	require.NoError(t, other.Sign(keys[2]))
+	require.NoError(t, b.CoSign(other))
+
+	signers, err = b.Verify()
+	require.NoError(t, err)
+	assert.Len(t, signers, 3)
+	assert.True(t, b.HasSupermajority(signers))
+
+	// the signatures don't hold for another validator set
+	b.Validators[0].Stake = big.NewInt(1)
+
+	_, err = b.Verify()
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+	assert.ErrorIs(t, b.CoSign(other), ErrBundleMismatch)
+}
+
+func TestBundle_File(t *testing.T) {
+	t.Parallel()
+
+	b, keys := newTestBundle(t, 2)
+	require.NoError(t, b.Sign(keys[0]))
+
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(t, b.WriteFile(path))
+
+	read, err := ReadFile(path)
+	require.NoError(t, err)
+
+	signers, err := read.Verify()
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{b.Validators[0].Address}, signers)
+
+	set, err := read.ValidatorSet()
+	require.NoError(t, err)
+	assert.Equal(t, 2, set.Len())
+	assert.True(t, set.Includes(b.Validators[1].Address))
+}
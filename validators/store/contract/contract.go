@@ -3,7 +3,12 @@ package contract
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"sync"
 
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
@@ -30,10 +35,18 @@ type ContractValidatorStore struct {
 
 	// LRU cache for the validators
 	validatorSetCache *lru.Cache
+
+	// deployments are the heights the staking contract is deployed or updated at, on top of the genesis.
+	// The BLS keys the contract holds at its last deployment are trusted without proof of possession
+	deployments []uint64
+
+	trustedKeysLock sync.Mutex
+	trustedKeys     map[uint64]map[types.Address][]byte
 }
 
 type Executor interface {
 	BeginTxn(types.Hash, *types.Header, types.Address) (*state.Transition, error)
+	GetForksInTime(uint64) chain.ForksInTime
 }
 
 func NewContractValidatorStore(
@@ -41,6 +54,7 @@ func NewContractValidatorStore(
 	blockchain store.HeaderGetter,
 	executor Executor,
 	validatorSetCacheSize int,
+	deployments []uint64,
 ) (*ContractValidatorStore, error) {
 	var (
 		validatorsCache *lru.Cache
@@ -58,6 +72,7 @@ func NewContractValidatorStore(
 		blockchain:        blockchain,
 		executor:          executor,
 		validatorSetCache: validatorsCache,
+		deployments:       deployments,
 	}, nil
 }
 
@@ -83,16 +98,83 @@ func (s *ContractValidatorStore) GetValidatorsByHeight(
 		return nil, err
 	}
 
-	fetchedValidators, err := FetchValidators(validatorType, transition, types.ZeroAddress)
+	// The BLS keys need a proof of possession once the fork is enabled,
+	// the keys registered before are accepted as they are
+	var trustedBLSKeys map[types.Address][]byte
+
+	if validatorType == validators.BLSValidatorType && s.executor.GetForksInTime(height).BLSProofOfPossession {
+		if trustedBLSKeys, err = s.getTrustedBLSKeys(height); err != nil {
+			return nil, err
+		}
+	}
+
+	fetchedValidators, err := FetchValidators(validatorType, transition, types.ZeroAddress, trustedBLSKeys)
 	if err != nil {
 		return nil, err
 	}
 
+	// The validators out of the stake limits of the contract don't join the validator set
+	limits, err := stakingHelper.ReadStakeLimits(storageReader(transition))
+	if err != nil {
+		return nil, err
+	}
+
+	if fetchedValidators, err = FilterValidatorsByStake(
+		transition,
+		types.ZeroAddress,
+		fetchedValidators,
+		limits,
+	); err != nil {
+		return nil, err
+	}
+
 	s.saveToValidatorSetCache(height, fetchedValidators)
 
 	return fetchedValidators, nil
 }
 
+// GetMaxValidatorExits returns the number of validators which can leave the validator set per epoch,
+// set in the staking contract at the height. It's read from the storage, the contracts deployed
+// without the limit have none
+func (s *ContractValidatorStore) GetMaxValidatorExits(height uint64) (uint64, error) {
+	transition, err := s.getTransitionForQuery(height)
+	if err != nil {
+		return 0, err
+	}
+
+	return stakingHelper.ReadMaxValidatorExits(storageReader(transition))
+}
+
+// GetValidatorStakes returns the stakes of the validators in the staking contract at the height
+func (s *ContractValidatorStore) GetValidatorStakes(
+	height uint64,
+	vals validators.Validators,
+) (map[types.Address]*big.Int, error) {
+	transition, err := s.getTransitionForQuery(height)
+	if err != nil {
+		return nil, err
+	}
+
+	stakes := make(map[types.Address]*big.Int, vals.Len())
+
+	for idx := 0; idx < vals.Len(); idx++ {
+		addr := vals.At(uint64(idx)).Addr()
+
+		if stakes[addr], err = staking.QueryAccountStake(transition, types.ZeroAddress, addr); err != nil {
+			return nil, err
+		}
+	}
+
+	return stakes, nil
+}
+
+// storageReader returns the reader of the storage of the staking contract in the transition
+func storageReader(transition *state.Transition) stakingHelper.StorageReader {
+	return func(slot types.Hash) (types.Hash, error) {
+		return transition.GetStorage(staking.AddrStakingContract, slot), nil
+	}
+}
+
 func (s *ContractValidatorStore) getTransitionForQuery(height uint64) (*state.Transition, error) {
 	header, ok := s.blockchain.GetHeaderByNumber(height)
 	if !ok {
@@ -102,6 +184,52 @@ func (s *ContractValidatorStore) getTransitionForQuery(height uint64) (*state.Tr
 	return s.executor.BeginTxn(header.StateRoot, header, types.ZeroAddress)
 }
 
+// getTrustedBLSKeys returns the BLS keys the staking contract held at its last deployment before the height.
+// The keys of the deployments are set by the chain configuration, not registered by the validators,
+// so they carry no proof of possession
+func (s *ContractValidatorStore) getTrustedBLSKeys(height uint64) (map[types.Address][]byte, error) {
+	deployment := uint64(0)
+
+	for _, d := range s.deployments {
+		if d <= height && d > deployment {
+			deployment = d
+		}
+	}
+
+	s.trustedKeysLock.Lock()
+	defer s.trustedKeysLock.Unlock()
+
+	if keys, ok := s.trustedKeys[deployment]; ok {
+		return keys, nil
+	}
+
+	transition, err := s.getTransitionForQuery(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[types.Address][]byte)
+
+	if transition.AccountExists(staking.AddrStakingContract) {
+		registrations, err := FetchBLSRegistrations(transition, types.ZeroAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, registration := range registrations {
+			keys[registration.Address] = registration.Registration
+		}
+	}
+
+	if s.trustedKeys == nil {
+		s.trustedKeys = make(map[uint64]map[types.Address][]byte)
+	}
+
+	s.trustedKeys[deployment] = keys
+
+	return keys, nil
+}
+
 // loadCachedValidatorSet loads validators from validatorSetCache
 func (s *ContractValidatorStore) loadCachedValidatorSet(height uint64) (validators.Validators, error) {
 	cachedRawValidators, ok := s.validatorSetCache.Get(height)
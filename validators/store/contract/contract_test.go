@@ -62,7 +62,8 @@ func newTestCache(t *testing.T, size int) *lru.Cache {
 }
 
 type mockExecutor struct {
-	BeginTxnFn func(types.Hash, *types.Header, types.Address) (*state.Transition, error)
+	BeginTxnFn       func(types.Hash, *types.Header, types.Address) (*state.Transition, error)
+	GetForksInTimeFn func(uint64) chain.ForksInTime
 }
 
 func (m *mockExecutor) BeginTxn(
@@ -73,6 +74,10 @@ func (m *mockExecutor) BeginTxn(
 	return m.BeginTxnFn(hash, header, address)
 }
 
+func (m *mockExecutor) GetForksInTime(height uint64) chain.ForksInTime {
+	return m.GetForksInTimeFn(height)
+}
+
 func newTestTransition(
 	t *testing.T,
 ) *state.Transition {
@@ -212,6 +217,7 @@ func TestNewContractValidatorStore(t *testing.T) {
 				blockchain,
 				executor,
 				test.cacheSize,
+				nil,
 			)
 
 			assert.Equal(t, test.expectedRes, res)
@@ -399,6 +405,41 @@ func TestContractValidatorStoreGetValidators(t *testing.T) {
 
 					return transitionForBLSValidators, nil
 				},
+				GetForksInTimeFn: func(height uint64) chain.ForksInTime {
+					return chain.ForksInTime{}
+				},
+			},
+			cacheSize:     1,
+			initialCaches: map[uint64]interface{}{},
+			validatorType: validators.BLSValidatorType,
+			height:        1,
+			expectedRes:   blsValidators,
+			expectedErr:   nil,
+			finalCaches: map[uint64]interface{}{
+				1: blsValidators,
+			},
+		},
+		{
+			// the keys the contract is predeployed with at the genesis are trusted
+			name: "should return fetched BLS validators with trusted keys after the proof of possession fork",
+			blockchain: &store.MockBlockchain{
+				GetHeaderByNumberFn: func(height uint64) (*types.Header, bool) {
+					assert.Contains(t, []uint64{0, 1}, height)
+
+					return header, true
+				},
+			},
+			executor: &mockExecutor{
+				BeginTxnFn: func(hash types.Hash, head *types.Header, addr types.Address) (*state.Transition, error) {
+					assert.Equal(t, stateRoot, hash)
+					assert.Equal(t, header, head)
+					assert.Equal(t, types.ZeroAddress, addr)
+
+					return transitionForBLSValidators, nil
+				},
+				GetForksInTimeFn: func(height uint64) chain.ForksInTime {
+					return chain.ForksInTime{BLSProofOfPossession: true}
+				},
 			},
 			cacheSize:     1,
 			initialCaches: map[uint64]interface{}{},
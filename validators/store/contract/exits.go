@@ -0,0 +1,42 @@
+package contract
+
+import (
+	"github.com/0xPolygon/polygon-edge/validators"
+)
+
+// LimitValidatorExits returns the validator set of an epoch fetched from the staking contract, with at most
+// maxExits validators of the set of the previous epoch leaving. The validators beyond the limit stay in the set
+// after the fetched validators, and leave in the order of the previous set in the following epochs.
+// The fetched set is returned as is without limit or previous set
+func LimitValidatorExits(
+	prev validators.Validators,
+	fetched validators.Validators,
+	maxExits uint64,
+) (validators.Validators, error) {
+	if maxExits == 0 || prev == nil {
+		return fetched, nil
+	}
+
+	var (
+		res   = fetched.Copy()
+		exits = uint64(0)
+	)
+
+	for idx := 0; idx < prev.Len(); idx++ {
+		validator := prev.At(uint64(idx))
+
+		if fetched.Includes(validator.Addr()) {
+			continue
+		}
+
+		if exits++; exits <= maxExits {
+			continue
+		}
+
+		if err := res.Add(validator.Copy()); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
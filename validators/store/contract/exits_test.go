@@ -0,0 +1,70 @@
+package contract
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitValidatorExits(t *testing.T) {
+	t.Parallel()
+
+	var (
+		val1 = validators.NewECDSAValidator(types.StringToAddress("1"))
+		val2 = validators.NewECDSAValidator(types.StringToAddress("2"))
+		val3 = validators.NewECDSAValidator(types.StringToAddress("3"))
+		val4 = validators.NewECDSAValidator(types.StringToAddress("4"))
+	)
+
+	tests := []struct {
+		name     string
+		prev     validators.Validators
+		fetched  validators.Validators
+		maxExits uint64
+		expected validators.Validators
+	}{
+		{
+			name:     "should return the fetched validators without limit",
+			prev:     validators.NewECDSAValidatorSet(val1, val2, val3),
+			fetched:  validators.NewECDSAValidatorSet(val1),
+			maxExits: 0,
+			expected: validators.NewECDSAValidatorSet(val1),
+		},
+		{
+			name:     "should return the fetched validators without previous set",
+			prev:     nil,
+			fetched:  validators.NewECDSAValidatorSet(val1),
+			maxExits: 1,
+			expected: validators.NewECDSAValidatorSet(val1),
+		},
+		{
+			name:     "should let the validators leave up to the limit",
+			prev:     validators.NewECDSAValidatorSet(val1, val2, val3),
+			fetched:  validators.NewECDSAValidatorSet(val1, val4),
+			maxExits: 2,
+			expected: validators.NewECDSAValidatorSet(val1, val4),
+		},
+		{
+			name:     "should keep the validators leaving beyond the limit in the order of the previous set",
+			prev:     validators.NewECDSAValidatorSet(val1, val2, val3, val4),
+			fetched:  validators.NewECDSAValidatorSet(val1),
+			maxExits: 1,
+			expected: validators.NewECDSAValidatorSet(val1, val3, val4),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := LimitValidatorExits(test.prev, test.fetched, test.maxExits)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
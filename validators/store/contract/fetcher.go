@@ -1,26 +1,31 @@
 package contract
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/0xPolygon/polygon-edge/contracts/staking"
 	"github.com/0xPolygon/polygon-edge/crypto"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 )
 
-// FetchValidators fetches validators from a contract switched by validator type
+// FetchValidators fetches validators from a contract switched by validator type.
+// The BLS keys need a proof of possession if the trusted BLS keys are given, apart from the trusted keys.
+// Without trusted BLS keys, the keys registered without proof are accepted too
 func FetchValidators(
 	validatorType validators.ValidatorType,
 	transition *state.Transition,
 	from types.Address,
+	trustedBLSKeys map[types.Address][]byte,
 ) (validators.Validators, error) {
 	switch validatorType {
 	case validators.ECDSAValidatorType:
 		return FetchECDSAValidators(transition, from)
 	case validators.BLSValidatorType:
-		return FetchBLSValidators(transition, from)
+		return FetchBLSValidators(transition, from, trustedBLSKeys)
 	}
 
 	return nil, fmt.Errorf("unsupported validator type: %s", validatorType)
@@ -46,34 +51,33 @@ func FetchECDSAValidators(
 	return ecdsaValidators, nil
 }
 
-// FetchBLSValidators queries a contract for validator addresses & BLS Public Keys and returns ECDSAValidators
+// FetchBLSValidators queries a contract for validator addresses & BLS Public Keys and returns BLSValidators.
+// If the trusted BLS keys are given, the BLS keys must be registered with a valid proof of possession
+// unless they are trusted
 func FetchBLSValidators(
 	transition *state.Transition,
 	from types.Address,
+	trustedBLSKeys map[types.Address][]byte,
 ) (validators.Validators, error) {
-	valAddrs, err := staking.QueryValidators(transition, from)
-	if err != nil {
-		return nil, err
-	}
-
-	blsPublicKeys, err := staking.QueryBLSPublicKeys(transition, from)
+	registrations, err := FetchBLSRegistrations(transition, from)
 	if err != nil {
 		return nil, err
 	}
 
 	blsValidators := validators.NewBLSValidatorSet()
 
-	for idx := range valAddrs {
-		// ignore the validator whose BLS Key is not set
+	for _, registration := range registrations {
+		// ignore the validator whose BLS Key is not set or not proven
 		// because BLS validator needs to have both Address and BLS Public Key set
 		// in the contract
-		if _, err := crypto.UnmarshalBLSPublicKey(blsPublicKeys[idx]); err != nil {
+		blsPublicKey, ok := verifyBLSRegistration(registration, trustedBLSKeys)
+		if !ok {
 			continue
 		}
 
 		if err := blsValidators.Add(validators.NewBLSValidator(
-			valAddrs[idx],
-			blsPublicKeys[idx],
+			registration.Address,
+			blsPublicKey,
 		)); err != nil {
 			return nil, err
 		}
@@ -81,3 +85,102 @@ func FetchBLSValidators(
 
 	return blsValidators, nil
 }
+
+// FilterValidatorsByStake returns the validators whose stake in the contract is within the limits,
+// in the order of the given validators. The validators are returned as is without limit
+func FilterValidatorsByStake(
+	transition *state.Transition,
+	from types.Address,
+	vals validators.Validators,
+	limits *stakingHelper.StakeLimits,
+) (validators.Validators, error) {
+	if limits == nil || limits.IsEmpty() {
+		return vals, nil
+	}
+
+	res := validators.NewValidatorSetFromType(vals.Type())
+
+	for idx := 0; idx < vals.Len(); idx++ {
+		validator := vals.At(uint64(idx))
+
+		stake, err := staking.QueryAccountStake(transition, from, validator.Addr())
+		if err != nil {
+			return nil, err
+		}
+
+		if !limits.Allows(stake) {
+			continue
+		}
+
+		if err := res.Add(validator.Copy()); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// BLSRegistration is a validator with the bytes it registered as its BLS key in the contract
+type BLSRegistration struct {
+	Address      types.Address
+	Registration []byte
+}
+
+// FetchBLSRegistrations queries a contract for validator addresses & the registered BLS keys, not verified
+func FetchBLSRegistrations(
+	transition *state.Transition,
+	from types.Address,
+) ([]*BLSRegistration, error) {
+	valAddrs, err := staking.QueryValidators(transition, from)
+	if err != nil {
+		return nil, err
+	}
+
+	blsPublicKeys, err := staking.QueryBLSPublicKeys(transition, from)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blsPublicKeys) != len(valAddrs) {
+		return nil, fmt.Errorf("%d BLS public keys for %d validators", len(blsPublicKeys), len(valAddrs))
+	}
+
+	registrations := make([]*BLSRegistration, len(valAddrs))
+
+	for idx := range valAddrs {
+		registrations[idx] = &BLSRegistration{
+			Address:      valAddrs[idx],
+			Registration: blsPublicKeys[idx],
+		}
+	}
+
+	return registrations, nil
+}
+
+// verifyBLSRegistration returns the BLS public key of the registration, proven by its proof of possession.
+// The trusted keys, such as the keys the contract is deployed with, are accepted as they are.
+// Without trusted keys, the proof isn't required and any valid key is accepted
+func verifyBLSRegistration(
+	registration *BLSRegistration,
+	trustedBLSKeys map[types.Address][]byte,
+) ([]byte, bool) {
+	if blsPublicKey, err := staking.VerifyBLSRegistration(
+		registration.Address,
+		registration.Registration,
+	); err == nil {
+		return blsPublicKey, true
+	}
+
+	if trustedBLSKeys != nil {
+		trusted, ok := trustedBLSKeys[registration.Address]
+		if !ok || !bytes.Equal(trusted, registration.Registration) {
+			return nil, false
+		}
+	}
+
+	if _, err := crypto.UnmarshalBLSPublicKey(registration.Registration); err != nil {
+		return nil, false
+	}
+
+	return registration.Registration, true
+}
@@ -3,13 +3,18 @@ package contract
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/contracts/staking"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
 	testHelper "github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFetchValidators(t *testing.T) {
@@ -21,6 +26,7 @@ func TestFetchValidators(t *testing.T) {
 		fakeValidatorType,
 		nil,
 		types.ZeroAddress,
+		nil,
 	)
 
 	assert.Nil(t, res)
@@ -75,6 +81,7 @@ func TestFetchECDSAValidators(t *testing.T) {
 				validators.ECDSAValidatorType,
 				test.transition,
 				test.from,
+				nil,
 			)
 
 			assert.Equal(t, test.expectedRes, res)
@@ -83,6 +90,22 @@ func TestFetchECDSAValidators(t *testing.T) {
 	}
 }
 
+// newTestBLSRegistration returns a BLS public key and its registration with the proof of possession by the address
+func newTestBLSRegistration(t *testing.T, addr types.Address) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := crypto.GenerateBLSKey()
+	require.NoError(t, err)
+
+	pubkey, err := crypto.BLSSecretKeyToPubkeyBytes(key)
+	require.NoError(t, err)
+
+	pop, err := crypto.SignBLSProofOfPossession(key, addr)
+	require.NoError(t, err)
+
+	return pubkey, staking.EncodeBLSRegistration(pubkey, pop)
+}
+
 func TestFetchBLSValidators(t *testing.T) {
 	t.Parallel()
 
@@ -91,14 +114,23 @@ func TestFetchBLSValidators(t *testing.T) {
 			validators.NewBLSValidator(addr1, testBLSPubKey1),
 			validators.NewBLSValidator(addr2, []byte{}), // validator 2 has not set BLS Public Key
 		)
+
+		provenPubKey, provenRegistration = newTestBLSRegistration(t, addr1)
+		_, otherRegistration             = newTestBLSRegistration(t, addr1)
+
+		registeredValidators = validators.NewBLSValidatorSet(
+			validators.NewBLSValidator(addr1, provenRegistration),
+			validators.NewBLSValidator(addr2, otherRegistration), // proof of possession by validator 1
+		)
 	)
 
 	tests := []struct {
-		name        string
-		transition  *state.Transition
-		from        types.Address
-		expectedRes validators.Validators
-		expectedErr error
+		name           string
+		transition     *state.Transition
+		from           types.Address
+		trustedBLSKeys map[types.Address][]byte
+		expectedRes    validators.Validators
+		expectedErr    error
 	}{
 		{
 			name: "should return error if QueryValidators failed",
@@ -110,7 +142,48 @@ func TestFetchBLSValidators(t *testing.T) {
 			expectedErr: errors.New("empty input"),
 		},
 		{
-			name: "should return ECDSA Validators",
+			name: "should return BLS Validators with trusted keys",
+			transition: newTestTransitionWithPredeployedStakingContract(
+				t,
+				blsValidators,
+			),
+			from: types.ZeroAddress,
+			trustedBLSKeys: map[types.Address][]byte{
+				addr1: testBLSPubKey1,
+			},
+			expectedRes: validators.NewBLSValidatorSet(
+				validators.NewBLSValidator(addr1, testBLSPubKey1),
+			),
+			expectedErr: nil,
+		},
+		{
+			name: "should ignore BLS keys without proof of possession",
+			transition: newTestTransitionWithPredeployedStakingContract(
+				t,
+				blsValidators,
+			),
+			from: types.ZeroAddress,
+			trustedBLSKeys: map[types.Address][]byte{
+				addr1: testBLSPubKey2,
+			},
+			expectedRes: validators.NewBLSValidatorSet(),
+			expectedErr: nil,
+		},
+		{
+			name: "should return BLS Validators with proven keys",
+			transition: newTestTransitionWithPredeployedStakingContract(
+				t,
+				registeredValidators,
+			),
+			from:           types.ZeroAddress,
+			trustedBLSKeys: map[types.Address][]byte{},
+			expectedRes: validators.NewBLSValidatorSet(
+				validators.NewBLSValidator(addr1, provenPubKey),
+			),
+			expectedErr: nil,
+		},
+		{
+			name: "should return BLS Validators without proof of possession if no key is trusted",
 			transition: newTestTransitionWithPredeployedStakingContract(
 				t,
 				blsValidators,
@@ -133,6 +206,7 @@ func TestFetchBLSValidators(t *testing.T) {
 				validators.BLSValidatorType,
 				test.transition,
 				test.from,
+				test.trustedBLSKeys,
 			)
 
 			assert.Equal(t, test.expectedRes, res)
@@ -140,3 +214,67 @@ func TestFetchBLSValidators(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterValidatorsByStake(t *testing.T) {
+	t.Parallel()
+
+	ecdsaValidators := validators.NewECDSAValidatorSet(
+		validators.NewECDSAValidator(addr1),
+		validators.NewECDSAValidator(addr2),
+	)
+
+	tests := []struct {
+		name        string
+		limits      *stakingHelper.StakeLimits
+		expectedRes validators.Validators
+	}{
+		{
+			name:        "should return validators as is without limits",
+			limits:      nil,
+			expectedRes: ecdsaValidators,
+		},
+		{
+			name: "should return validators as is with empty limits",
+			limits: &stakingHelper.StakeLimits{
+				MinSelfStake:      big.NewInt(0),
+				MaxValidatorStake: big.NewInt(0),
+			},
+			expectedRes: ecdsaValidators,
+		},
+		{
+			name: "should return validators within the maximum validator stake",
+			limits: &stakingHelper.StakeLimits{
+				MinSelfStake:      big.NewInt(0),
+				MaxValidatorStake: big.NewInt(10),
+			},
+			expectedRes: ecdsaValidators,
+		},
+		{
+			name: "should ignore validators below the minimum self-stake",
+			limits: &stakingHelper.StakeLimits{
+				MinSelfStake:      big.NewInt(1),
+				MaxValidatorStake: big.NewInt(0),
+			},
+			expectedRes: validators.NewECDSAValidatorSet(),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			// the validators are predeployed without stake
+			res, err := FilterValidatorsByStake(
+				newTestTransitionWithPredeployedStakingContract(t, ecdsaValidators),
+				types.ZeroAddress,
+				ecdsaValidators,
+				test.limits,
+			)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedRes, res)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package versioning
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidVersion = errors.New("invalid version")
+
+// semver is a parsed semantic version, the build metadata is ignored
+type semver struct {
+	numbers    [3]uint64
+	prerelease string
+}
+
+func parse(version string) (*semver, error) {
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+
+	if idx := strings.IndexByte(v, '+'); idx >= 0 {
+		v = v[:idx]
+	}
+
+	res := &semver{}
+
+	if idx := strings.IndexByte(v, '-'); idx >= 0 {
+		res.prerelease = v[idx+1:]
+		v = v[:idx]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+		}
+
+		res.numbers[i] = n
+	}
+
+	return res, nil
+}
+
+// Validate returns an error if the version isn't a semantic version
+func Validate(version string) error {
+	_, err := parse(version)
+
+	return err
+}
+
+// Compare compares two semantic versions such as v0.6.3 or 1.0.0-rc1, returning
+// -1, 0 or 1 if a is lower than, equal to or greater than b.
+// A prerelease is lower than its release
+func Compare(a, b string) (int, error) {
+	va, err := parse(a)
+	if err != nil {
+		return 0, err
+	}
+
+	vb, err := parse(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range va.numbers {
+		if va.numbers[i] < vb.numbers[i] {
+			return -1, nil
+		} else if va.numbers[i] > vb.numbers[i] {
+			return 1, nil
+		}
+	}
+
+	switch {
+	case va.prerelease == vb.prerelease:
+		return 0, nil
+	case va.prerelease == "":
+		return 1, nil
+	case vb.prerelease == "":
+		return -1, nil
+	case va.prerelease < vb.prerelease:
+		return -1, nil
+	default:
+		return 1, nil
+	}
+}
+
+// Supports returns true if the given version is at least the required version.
+// The versions which can't be parsed don't support any requirement
+func Supports(version, required string) bool {
+	cmp, err := Compare(version, required)
+
+	return err == nil && cmp >= 0
+}
@@ -0,0 +1,40 @@
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		a, b string
+		cmp  int
+	}{
+		{"v0.6.3", "0.6.3", 0},
+		{"0.6.3", "0.7.0", -1},
+		{"1.0.0", "0.99.99", 1},
+		{"1.0.0-rc1", "1.0.0", -1},
+		{"1.0.0-rc2", "1.0.0-rc1", 1},
+		{"1.0.0+build.5", "1.0.0", 0},
+	}
+
+	for _, c := range cases {
+		cmp, err := Compare(c.a, c.b)
+		assert.NoError(t, err)
+		assert.Equal(t, c.cmp, cmp, "%s %s", c.a, c.b)
+	}
+
+	_, err := Compare("1.0", "1.0.0")
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+}
+
+func TestSupports(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Supports("0.7.1", "0.7.0"))
+	assert.False(t, Supports("0.7.0-rc1", "0.7.0"))
+	assert.False(t, Supports("", "0.7.0"))
+}
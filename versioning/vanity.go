@@ -0,0 +1,61 @@
+package versioning
+
+import (
+	"bytes"
+	"strings"
+)
+
+const (
+	// VanityLength is the maximum length of the vanity, the prefix of the extra data of the blocks
+	VanityLength = 32
+
+	vanityPrefix    = "edge/"
+	vanitySeparator = "/"
+
+	// shortCommitLength is the length of the commit kept in the vanity
+	shortCommitLength = 8
+)
+
+// Vanity returns the version and the commit of the binary to embed in the extra data
+// of the sealed blocks, such as edge/v0.6.3/1a2b3c4d. It is nil for the builds without them.
+// It only depends on the build, so all the nodes running the same build seal the same vanity
+func Vanity() []byte {
+	return vanity(Version, Commit)
+}
+
+func vanity(version, commit string) []byte {
+	if version == "" && commit == "" {
+		return nil
+	}
+
+	if len(commit) > shortCommitLength {
+		commit = commit[:shortCommitLength]
+	}
+
+	v := vanityPrefix + version + vanitySeparator + commit
+	if len(v) > VanityLength {
+		// the commit is cut first, as the version matters more
+		v = v[:VanityLength]
+	}
+
+	return []byte(v)
+}
+
+// ParseVanity returns the version and the commit embedded in the vanity of a block,
+// ok is false if the vanity doesn't embed them
+func ParseVanity(vanity []byte) (version string, commit string, ok bool) {
+	v := string(bytes.TrimRight(vanity, "\x00"))
+	if !strings.HasPrefix(v, vanityPrefix) {
+		return "", "", false
+	}
+
+	v = strings.TrimPrefix(v, vanityPrefix)
+
+	idx := strings.LastIndex(v, vanitySeparator)
+	if idx < 0 {
+		// the separator was cut
+		return v, "", true
+	}
+
+	return v[:idx], v[idx+1:], true
+}
@@ -0,0 +1,51 @@
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVanity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version string
+		commit  string
+		vanity  string
+
+		// the version and the commit parsed back from the vanity
+		parsedVersion string
+		parsedCommit  string
+	}{
+		{"release", "v0.6.3", "1a2b3c4d5e6f", "edge/v0.6.3/1a2b3c4d", "v0.6.3", "1a2b3c4d"},
+		{"development", "", "1a2b3c4d5e6f", "edge//1a2b3c4d", "", "1a2b3c4d"},
+		{"commit cut", "v0.6.3-rc1.prerelease", "1a2b3c4d", "edge/v0.6.3-rc1.prerelease/1a2b3", "v0.6.3-rc1.prerelease", "1a2b3"},
+		{"separator cut", "v0.6.3-rc1.long.prerelease.name", "1a2b3c4d", "edge/v0.6.3-rc1.long.prerelease.", "v0.6.3-rc1.long.prerelease.", ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := vanity(tt.version, tt.commit)
+			assert.Equal(t, tt.vanity, string(v))
+
+			// the vanity is padded with zeros in the extra data
+			padded := append(v, make([]byte, VanityLength-len(v))...)
+
+			version, commit, ok := ParseVanity(padded)
+			assert.True(t, ok)
+			assert.Equal(t, tt.parsedVersion, version)
+			assert.Equal(t, tt.parsedCommit, commit)
+		})
+	}
+
+	assert.Nil(t, vanity("", ""))
+
+	_, _, ok := ParseVanity(make([]byte, VanityLength))
+	assert.False(t, ok)
+}